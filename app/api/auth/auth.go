@@ -63,6 +63,21 @@ func Check(ctx context.Context, authorizer authz.Authorizer, session *auth.Sessi
 	return nil
 }
 
+// CheckAdmin checks if the principal of the current auth session is a system administrator.
+// It's used for system wide operations that aren't bound to any resource.
+// Returns nil if the principal is an admin, otherwise returns NotAuthenticated or NotAuthorized.
+func CheckAdmin(session *auth.Session) error {
+	if session == nil {
+		return ErrNotAuthenticated
+	}
+
+	if !session.Principal.Admin {
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
+
 // CheckChild checks if a resource specific permission is granted for the current auth session
 // in the scope of a parent.
 // Returns nil if the permission is granted, otherwise returns an error.
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckRegistry checks if a registry specific permission is granted for the current auth session
+// in the scope of the space the registry belongs to.
+// Read access is granted to everyone if orPublic is set and the space is public.
+// Returns nil if the permission is granted, otherwise returns an error.
+// NotAuthenticated, NotAuthorized, or any underlying error.
+func CheckRegistry(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	session *auth.Session,
+	space *types.Space,
+	identifier string,
+	permission enum.Permission,
+	orPublic bool,
+) error {
+	if orPublic && space.IsPublic {
+		return nil
+	}
+
+	scope := &types.Scope{SpacePath: space.Path}
+	resource := &types.Resource{
+		Type:       enum.ResourceTypeRegistry,
+		Identifier: identifier,
+	}
+
+	return Check(ctx, authorizer, session, scope, resource, permission)
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announcement
+
+import (
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const maxMessageLength = 1024
+
+// checkAnnouncement verifies the message and the time range of the announcement.
+func checkAnnouncement(announcement *types.Announcement) error {
+	if announcement.Message == "" {
+		return check.NewValidationError("The message of an announcement can't be empty.")
+	}
+	if utf8.RuneCountInString(announcement.Message) > maxMessageLength {
+		return check.NewValidationErrorf("The message of an announcement can be at most %d characters long.",
+			maxMessageLength)
+	}
+
+	if announcement.Starts < 0 || announcement.Ends < 0 {
+		return check.NewValidationError("The start and end time of an announcement can't be negative.")
+	}
+	if announcement.Ends != 0 && announcement.Ends <= announcement.Starts {
+		return check.NewValidationError("The end time of an announcement has to be after its start time.")
+	}
+
+	return nil
+}
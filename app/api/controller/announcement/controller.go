@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+)
+
+// cacheDuration is the duration for which the active announcements are cached, both on the server and by clients.
+// Changes to announcements become visible to users once the cached announcements expired.
+const cacheDuration = 30 * time.Second
+
+type Controller struct {
+	announcementStore store.AnnouncementStore
+	activeCache       cache.Cache[struct{}, []*types.Announcement]
+}
+
+func NewController(announcementStore store.AnnouncementStore) *Controller {
+	return &Controller{
+		announcementStore: announcementStore,
+		activeCache: cache.New[struct{}, []*types.Announcement](activeGetter{
+			announcementStore: announcementStore,
+		}, cacheDuration),
+	}
+}
+
+// CacheDuration returns the duration for which clients are allowed to cache the active announcements.
+func (c *Controller) CacheDuration() time.Duration {
+	return cacheDuration
+}
+
+// activeGetter loads the currently active announcements for the cache.
+type activeGetter struct {
+	announcementStore store.AnnouncementStore
+}
+
+func (g activeGetter) Find(ctx context.Context, _ struct{}) ([]*types.Announcement, error) {
+	announcements, err := g.announcementStore.ListActive(ctx, time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active announcements: %w", err)
+	}
+
+	return announcements, nil
+}
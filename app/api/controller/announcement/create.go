@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Message     string                    `json:"message"`
+	Severity    enum.AnnouncementSeverity `json:"severity"`
+	Starts      int64                     `json:"starts"`
+	Ends        int64                     `json:"ends"`
+	Dismissible bool                      `json:"dismissible"`
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Message = strings.TrimSpace(in.Message)
+
+	severity, ok := in.Severity.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("The provided severity %q is invalid.", in.Severity)
+	}
+	in.Severity = severity
+
+	return nil
+}
+
+// Create creates a new announcement. Only admins are allowed to manage announcements.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
+) (*types.Announcement, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	announcement := &types.Announcement{
+		Message:     in.Message,
+		Severity:    in.Severity,
+		Starts:      in.Starts,
+		Ends:        in.Ends,
+		Dismissible: in.Dismissible,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := checkAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+
+	if err := c.announcementStore.Create(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("failed to store announcement: %w", err)
+	}
+
+	return announcement, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announcement
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+)
+
+// Delete deletes an announcement.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	id int64,
+) error {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return err
+	}
+
+	announcement, err := c.announcementStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find announcement: %w", err)
+	}
+
+	if err = c.announcementStore.Delete(ctx, announcement.ID); err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// List returns all announcements, including the scheduled and expired ones.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.Announcement, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	announcements, err := c.announcementStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// ListActive returns the announcements currently shown to users.
+// The result is cached briefly, as it's polled by all clients.
+func (c *Controller) ListActive(ctx context.Context) ([]*types.Announcement, error) {
+	cached, err := c.activeCache.Get(ctx, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	// announcements might have started or ended since they got cached.
+	now := time.Now().UnixMilli()
+	announcements := make([]*types.Announcement, 0, len(cached))
+	for _, announcement := range cached {
+		if announcement.IsActive(now) {
+			announcements = append(announcements, announcement)
+		}
+	}
+
+	return announcements, nil
+}
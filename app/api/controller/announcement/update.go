@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Message     *string                    `json:"message"`
+	Severity    *enum.AnnouncementSeverity `json:"severity"`
+	Starts      *int64                     `json:"starts"`
+	Ends        *int64                     `json:"ends"`
+	Dismissible *bool                      `json:"dismissible"`
+}
+
+// Update updates an existing announcement.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	id int64,
+	in *UpdateInput,
+) (*types.Announcement, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	announcement, err := c.announcementStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find announcement: %w", err)
+	}
+
+	if in.Message != nil {
+		announcement.Message = strings.TrimSpace(*in.Message)
+	}
+	if in.Severity != nil {
+		severity, ok := in.Severity.Sanitize()
+		if !ok {
+			return nil, check.NewValidationErrorf("The provided severity %q is invalid.", *in.Severity)
+		}
+		announcement.Severity = severity
+	}
+	if in.Starts != nil {
+		announcement.Starts = *in.Starts
+	}
+	if in.Ends != nil {
+		announcement.Ends = *in.Ends
+	}
+	if in.Dismissible != nil {
+		announcement.Dismissible = *in.Dismissible
+	}
+
+	if err = checkAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+
+	announcement.Updated = time.Now().UnixMilli()
+
+	if err = c.announcementStore.Update(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+
+	return announcement, nil
+}
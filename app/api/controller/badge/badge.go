@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// Badge colors, matching the colors commonly used for README badges.
+const (
+	colorSuccess = "#4c1"
+	colorFailure = "#e05d44"
+	colorPending = "#dfb317"
+	colorInfo    = "#007ec6"
+	colorUnknown = "#9f9f9f"
+)
+
+const (
+	// charWidth is the approximated average width of a character of the badge font (Verdana, 11px).
+	charWidth = 7
+	// padding is the horizontal padding on each side of the label and the message.
+	padding = 5
+)
+
+// Badge is a small image with a label and a message, e.g. "build | passing".
+type Badge struct {
+	Label   string
+	Message string
+	Color   string
+	// Public is true if the badge belongs to a public repository and can be cached by shared caches.
+	Public bool
+}
+
+// SVG renders the badge as a flat SVG image.
+func (b *Badge) SVG() []byte {
+	labelWidth := textWidth(b.Label)
+	messageWidth := textWidth(b.Message)
+	width := labelWidth + messageWidth
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`,
+		width, label, message)
+	fmt.Fprintf(buf, `<title>%s: %s</title>`, label, message)
+	buf.WriteString(`<linearGradient id="s" x2="0" y2="100%">` +
+		`<stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/>` +
+		`</linearGradient>`)
+	fmt.Fprintf(buf, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	fmt.Fprintf(buf, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/>`, labelWidth)
+	fmt.Fprintf(buf, `<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, b.Color)
+	fmt.Fprintf(buf, `<rect width="%d" height="20" fill="url(#s)"/></g>`, width)
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" ` +
+		`font-size="11">`)
+	fmt.Fprintf(buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`, labelWidth/2, label)
+	fmt.Fprintf(buf, `<text x="%d" y="14">%s</text>`, labelWidth/2, label)
+	fmt.Fprintf(buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`,
+		labelWidth+messageWidth/2, message)
+	fmt.Fprintf(buf, `<text x="%d" y="14">%s</text>`, labelWidth+messageWidth/2, message)
+	buf.WriteString(`</g></svg>`)
+
+	return buf.Bytes()
+}
+
+func textWidth(text string) int {
+	return len([]rune(text))*charWidth + 2*padding
+}
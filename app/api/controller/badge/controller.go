@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// cacheDuration is the duration for which badges are cached, both on the server and by clients.
+const cacheDuration = time.Minute
+
+type Controller struct {
+	authorizer authz.Authorizer
+	repoStore  store.RepoStore
+	cache      cache.Cache[badgeKey, *Badge]
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	checkStore store.CheckStore,
+	pullreqStore store.PullReqStore,
+	git git.Interface,
+) *Controller {
+	return &Controller{
+		authorizer: authorizer,
+		repoStore:  repoStore,
+		cache: cache.New[badgeKey, *Badge](badgeGetter{
+			repoStore:    repoStore,
+			checkStore:   checkStore,
+			pullreqStore: pullreqStore,
+			git:          git,
+		}, cacheDuration),
+	}
+}
+
+// CacheDuration returns the duration for which clients are allowed to cache badges.
+func (c *Controller) CacheDuration() time.Duration {
+	return cacheDuration
+}
+
+// Checks returns a badge with the combined status of all status checks of the latest commit of the branch.
+func (c *Controller) Checks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) (*Badge, error) {
+	return c.getBadge(ctx, session, repoRef, badgeKey{kind: badgeKindChecks, branch: branch})
+}
+
+// Build returns a badge with the status of the pipelines executed for the latest commit of the branch.
+// If a pipeline identifier is provided, only the status of that pipeline is shown.
+func (c *Controller) Build(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+	pipeline string,
+) (*Badge, error) {
+	return c.getBadge(ctx, session, repoRef, badgeKey{kind: badgeKindBuild, branch: branch, pipeline: pipeline})
+}
+
+// PullReqs returns a badge with the number of open pull requests targeting the repository.
+func (c *Controller) PullReqs(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*Badge, error) {
+	return c.getBadge(ctx, session, repoRef, badgeKey{kind: badgeKindPullReqs})
+}
+
+func (c *Controller) getBadge(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key badgeKey,
+) (*Badge, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	key.repoID = repo.ID
+	if key.kind != badgeKindPullReqs && key.branch == "" {
+		key.branch = repo.DefaultBranch
+	}
+
+	badge, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cached badge is shared, hence it's copied before the repo specific fields are set.
+	out := *badge
+	out.Public = repo.IsPublic
+
+	return &out, nil
+}
+
+// getRepoCheckAccess verifies the principal can view the repository.
+// Badges of public repositories are accessible anonymously, as they are meant to be embedded in READMEs.
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
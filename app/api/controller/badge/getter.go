@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type badgeKind string
+
+const (
+	badgeKindChecks   badgeKind = "checks"
+	badgeKindBuild    badgeKind = "build"
+	badgeKindPullReqs badgeKind = "pullreqs"
+)
+
+// maxChecks is the maximum number of status checks of a commit that are taken into account.
+const maxChecks = 100
+
+type badgeKey struct {
+	repoID   int64
+	kind     badgeKind
+	branch   string
+	pipeline string
+}
+
+// badgeGetter computes the badges that aren't cached yet.
+type badgeGetter struct {
+	repoStore    store.RepoStore
+	checkStore   store.CheckStore
+	pullreqStore store.PullReqStore
+	git          git.Interface
+}
+
+func (g badgeGetter) Find(ctx context.Context, key badgeKey) (*Badge, error) {
+	switch key.kind {
+	case badgeKindChecks:
+		checks, err := g.listChecks(ctx, key.repoID, key.branch)
+		if err != nil {
+			return nil, err
+		}
+
+		return statusBadge("checks", checks), nil
+
+	case badgeKindBuild:
+		checks, err := g.listChecks(ctx, key.repoID, key.branch)
+		if err != nil {
+			return nil, err
+		}
+
+		builds := make([]types.Check, 0, len(checks))
+		for _, check := range checks {
+			if check.Payload.Kind != enum.CheckPayloadKindPipeline {
+				continue
+			}
+			if key.pipeline != "" && check.Identifier != key.pipeline {
+				continue
+			}
+			builds = append(builds, check)
+		}
+
+		label := "build"
+		if key.pipeline != "" {
+			label = key.pipeline
+		}
+
+		return statusBadge(label, builds), nil
+
+	case badgeKindPullReqs:
+		count, err := g.pullreqStore.Count(ctx, &types.PullReqFilter{
+			TargetRepoID: key.repoID,
+			States:       []enum.PullReqState{enum.PullReqStateOpen},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count open pull requests: %w", err)
+		}
+
+		color := colorInfo
+		if count == 0 {
+			color = colorSuccess
+		}
+
+		return &Badge{
+			Label:   "pull requests",
+			Message: strconv.FormatInt(count, 10) + " open",
+			Color:   color,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown badge kind %q", key.kind)
+}
+
+// listChecks returns the status checks reported for the latest commit of the branch.
+func (g badgeGetter) listChecks(ctx context.Context, repoID int64, branch string) ([]types.Check, error) {
+	repo, err := g.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	out, err := g.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: branch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	checks, err := g.checkStore.List(ctx, repoID, out.Branch.SHA, types.CheckListOptions{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Page: 1, Size: maxChecks},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	return checks, nil
+}
+
+// statusBadge returns a badge with the combined status of the checks:
+// failing if any check failed, pending if any check isn't completed yet and passing if all checks succeeded.
+func statusBadge(label string, checks []types.Check) *Badge {
+	if len(checks) == 0 {
+		return &Badge{Label: label, Message: "unknown", Color: colorUnknown}
+	}
+
+	pending := false
+	for _, check := range checks {
+		switch check.Status {
+		case enum.CheckStatusFailure, enum.CheckStatusError:
+			return &Badge{Label: label, Message: "failing", Color: colorFailure}
+		case enum.CheckStatusPending, enum.CheckStatusRunning:
+			pending = true
+		case enum.CheckStatusSuccess:
+		}
+	}
+
+	if pending {
+		return &Badge{Label: label, Message: "pending", Color: colorPending}
+	}
+
+	return &Badge{Label: label, Message: "passing", Color: colorSuccess}
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/externalhook"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/malwarescan"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -64,6 +67,11 @@ type Controller struct {
 	urlProvider       url.Provider
 	protectionManager *protection.Manager
 	resourceLimiter   limiter.ResourceLimiter
+	externalHooks     *externalhook.Service
+	pushedBranchStore store.PushedBranchStore
+	pushStore         store.PushStore
+	maintenance       *maintenance.Service
+	malwareScan       *malwarescan.Service
 }
 
 func NewController(
@@ -76,6 +84,11 @@ func NewController(
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	limiter limiter.ResourceLimiter,
+	externalHooks *externalhook.Service,
+	pushedBranchStore store.PushedBranchStore,
+	pushStore store.PushStore,
+	maintenance *maintenance.Service,
+	malwareScan *malwarescan.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:        authorizer,
@@ -87,6 +100,11 @@ func NewController(
 		urlProvider:       urlProvider,
 		protectionManager: protectionManager,
 		resourceLimiter:   limiter,
+		externalHooks:     externalHooks,
+		pushedBranchStore: pushedBranchStore,
+		pushStore:         pushStore,
+		maintenance:       maintenance,
+		malwareScan:       malwareScan,
 	}
 }
 
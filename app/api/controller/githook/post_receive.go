@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/git"
@@ -40,6 +41,9 @@ const (
 	gitReferenceNamePullReq = "refs/pullreq/"
 )
 
+// maxPullReqSuggestions is the maximum number of branches pull requests are suggested for after a push.
+const maxPullReqSuggestions = 5
+
 // PostReceive executes the post-receive hook for a git repository.
 func (c *Controller) PostReceive(
 	ctx context.Context,
@@ -52,7 +56,7 @@ func (c *Controller) PostReceive(
 	}
 
 	// report ref events (best effort)
-	c.reportReferenceEvents(ctx, repo, in.PrincipalID, in.PostReceiveInput)
+	c.reportReferenceEvents(ctx, repo, in.PrincipalID, in.Origin(), in.PostReceiveInput)
 
 	// create output object and have following messages fill its messages
 	out := hook.Output{}
@@ -60,6 +64,12 @@ func (c *Controller) PostReceive(
 	// handle branch updates related to PRs - best effort
 	c.handlePRMessaging(ctx, repo, in.PostReceiveInput, &out)
 
+	// remember the latest branch created by the user and record the push for the activity feed - best effort
+	if in.Origin() == enum.GitPushOriginUser {
+		c.recordPushedBranch(ctx, repo, in.PrincipalID, in.PostReceiveInput)
+		c.recordPushes(ctx, repo, in.PrincipalID, in.PostReceiveInput)
+	}
+
 	return out, nil
 }
 
@@ -70,14 +80,15 @@ func (c *Controller) reportReferenceEvents(
 	ctx context.Context,
 	repo *types.Repository,
 	principalID int64,
+	origin enum.GitPushOrigin,
 	in hook.PostReceiveInput,
 ) {
 	for _, refUpdate := range in.RefUpdates {
 		switch {
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch):
-			c.reportBranchEvent(ctx, repo, principalID, refUpdate)
+			c.reportBranchEvent(ctx, repo, principalID, origin, in.PushOptions, refUpdate)
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag):
-			c.reportTagEvent(ctx, repo, principalID, refUpdate)
+			c.reportTagEvent(ctx, repo, principalID, origin, refUpdate)
 		default:
 			// Ignore any other references in post-receive
 		}
@@ -88,20 +99,27 @@ func (c *Controller) reportBranchEvent(
 	ctx context.Context,
 	repo *types.Repository,
 	principalID int64,
+	origin enum.GitPushOrigin,
+	pushOptions []string,
 	branchUpdate hook.ReferenceUpdate,
 ) {
+	branchName := branchUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
 	switch {
 	case branchUpdate.Old == types.NilSHA:
 		c.gitReporter.BranchCreated(ctx, &events.BranchCreatedPayload{
-			RepoID:      repo.ID,
-			PrincipalID: principalID,
-			Ref:         branchUpdate.Ref,
-			SHA:         branchUpdate.New,
+			RepoID:         repo.ID,
+			PrincipalID:    principalID,
+			Ref:            branchUpdate.Ref,
+			SHA:            branchUpdate.New,
+			PushOptions:    pushOptions,
+			SuggestPullReq: repo.PullReqSuggestions && branchName != repo.DefaultBranch,
 		})
 	case branchUpdate.New == types.NilSHA:
 		c.gitReporter.BranchDeleted(ctx, &events.BranchDeletedPayload{
 			RepoID:      repo.ID,
 			PrincipalID: principalID,
+			Origin:      origin,
 			Ref:         branchUpdate.Ref,
 			SHA:         branchUpdate.Old,
 		})
@@ -123,10 +141,12 @@ func (c *Controller) reportBranchEvent(
 		c.gitReporter.BranchUpdated(ctx, &events.BranchUpdatedPayload{
 			RepoID:      repo.ID,
 			PrincipalID: principalID,
+			Origin:      origin,
 			Ref:         branchUpdate.Ref,
 			OldSHA:      branchUpdate.Old,
 			NewSHA:      branchUpdate.New,
 			Forced:      forced,
+			PushOptions: pushOptions,
 		})
 	}
 }
@@ -135,6 +155,7 @@ func (c *Controller) reportTagEvent(
 	ctx context.Context,
 	repo *types.Repository,
 	principalID int64,
+	origin enum.GitPushOrigin,
 	tagUpdate hook.ReferenceUpdate,
 ) {
 	switch {
@@ -142,6 +163,7 @@ func (c *Controller) reportTagEvent(
 		c.gitReporter.TagCreated(ctx, &events.TagCreatedPayload{
 			RepoID:      repo.ID,
 			PrincipalID: principalID,
+			Origin:      origin,
 			Ref:         tagUpdate.Ref,
 			SHA:         tagUpdate.New,
 		})
@@ -149,6 +171,7 @@ func (c *Controller) reportTagEvent(
 		c.gitReporter.TagDeleted(ctx, &events.TagDeletedPayload{
 			RepoID:      repo.ID,
 			PrincipalID: principalID,
+			Origin:      origin,
 			Ref:         tagUpdate.Ref,
 			SHA:         tagUpdate.Old,
 		})
@@ -156,6 +179,7 @@ func (c *Controller) reportTagEvent(
 		c.gitReporter.TagUpdated(ctx, &events.TagUpdatedPayload{
 			RepoID:      repo.ID,
 			PrincipalID: principalID,
+			Origin:      origin,
 			Ref:         tagUpdate.Ref,
 			OldSHA:      tagUpdate.Old,
 			NewSHA:      tagUpdate.New,
@@ -166,37 +190,108 @@ func (c *Controller) reportTagEvent(
 }
 
 // handlePRMessaging checks any single branch push for pr information and returns an according response if needed.
-// TODO: If it is a new branch, or an update on a branch without any PR, it also sends out an SSE for pr creation.
 func (c *Controller) handlePRMessaging(
 	ctx context.Context,
 	repo *types.Repository,
 	in hook.PostReceiveInput,
 	out *hook.Output,
 ) {
-	// skip anything that was a batch push / isn't branch related / isn't updating/creating a branch.
-	if len(in.RefUpdates) != 1 ||
-		!strings.HasPrefix(in.RefUpdates[0].Ref, gitReferenceNamePrefixBranch) ||
-		in.RefUpdates[0].New == types.NilSHA {
+	suggestions := 0
+	for _, refUpdate := range in.RefUpdates {
+		// skip anything that isn't branch related / isn't updating/creating a branch.
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.New == types.NilSHA {
+			continue
+		}
+
+		// limit the output for batch pushes.
+		if suggestions == maxPullReqSuggestions {
+			out.Messages = append(out.Messages, "Pull request suggestions for further branches were omitted.")
+			break
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+		if c.suggestPullRequest(ctx, repo, branchName, out) {
+			suggestions++
+		}
+	}
+}
+
+// recordPushedBranch stores the last non-default branch created by the push as the latest pushed branch of the user.
+// The UI uses it to offer the creation of a pull request for the branch.
+func (c *Controller) recordPushedBranch(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in hook.PostReceiveInput,
+) {
+	var created *hook.ReferenceUpdate
+	for i := range in.RefUpdates {
+		refUpdate := &in.RefUpdates[i]
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.Old != types.NilSHA ||
+			refUpdate.Ref[len(gitReferenceNamePrefixBranch):] == repo.DefaultBranch {
+			continue
+		}
+		created = refUpdate
+	}
+
+	if created == nil {
 		return
 	}
 
-	// for now we only care about first branch that was pushed.
-	branchName := in.RefUpdates[0].Ref[len(gitReferenceNamePrefixBranch):]
+	err := c.pushedBranchStore.Upsert(ctx, &types.PushedBranch{
+		RepoID:      repo.ID,
+		PrincipalID: principalID,
+		Branch:      created.Ref[len(gitReferenceNamePrefixBranch):],
+		SHA:         created.New,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to record pushed branch %q", created.Ref)
+	}
+}
+
+// recordPushes stores all branch and tag updates of the push, which are listed in the activity feed of the user.
+// Deleted references aren't recorded.
+func (c *Controller) recordPushes(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in hook.PostReceiveInput,
+) {
+	now := time.Now().UnixMilli()
 
-	c.suggestPullRequest(ctx, repo, branchName, out)
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New == types.NilSHA ||
+			!strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) &&
+				!strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag) {
+			continue
+		}
 
-	// TODO: store latest pushed branch for user in cache and send out SSE
+		err := c.pushStore.Create(ctx, &types.Push{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			OldSHA:      refUpdate.Old,
+			NewSHA:      refUpdate.New,
+			Created:     now,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to record push of %q", refUpdate.Ref)
+		}
+	}
 }
 
+// suggestPullRequest adds messages about open pull requests of the branch, or suggests creating a new one.
+// It returns true if any message was added.
 func (c *Controller) suggestPullRequest(
 	ctx context.Context,
 	repo *types.Repository,
 	branchName string,
 	out *hook.Output,
-) {
+) bool {
 	if branchName == repo.DefaultBranch {
 		// Don't suggest a pull request if this is a push to the default branch.
-		return
+		return false
 	}
 
 	// do we have a PR related to it?
@@ -217,7 +312,7 @@ func (c *Controller) suggestPullRequest(
 			branchName,
 			repo.Path,
 		)
-		return
+		return false
 	}
 
 	// for already existing PRs, print them to users terminal for easier access.
@@ -229,7 +324,12 @@ func (c *Controller) suggestPullRequest(
 			msgs[2*i+2] = "    " + c.urlProvider.GenerateUIPRURL(repo.Path, pr.Number)
 		}
 		out.Messages = append(out.Messages, msgs...)
-		return
+		return true
+	}
+
+	if !repo.PullReqSuggestions {
+		// Suggesting new pull requests is disabled for the repository.
+		return false
 	}
 
 	// this is a new PR!
@@ -237,4 +337,6 @@ func (c *Controller) suggestPullRequest(
 		fmt.Sprintf("Create a new PR for branch %q", branchName),
 		"  "+c.urlProvider.GenerateUICompareURL(repo.Path, repo.DefaultBranch, branchName),
 	)
+
+	return true
 }
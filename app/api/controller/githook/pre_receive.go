@@ -24,14 +24,19 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 )
 
+// maxNewCommits is the maximum number of new commits of a branch that are verified during a push.
+const maxNewCommits = 1000
+
 // PreReceive executes the pre-receive hook for a git repository.
 //
 //nolint:revive // not yet fully implemented
@@ -55,22 +60,50 @@ func (c *Controller) PreReceive(
 
 	refUpdates := groupRefsByAction(in.RefUpdates)
 
+	// Git applies the reference updates of a (non-atomic) push one by one after pre-receive accepted the push,
+	// so all checks are evaluated for all references first and the push is rejected as a whole if any check fails.
+	var rejections []string
+
 	if slices.Contains(refUpdates.branches.deleted, repo.DefaultBranch) {
 		// Default branch mustn't be deleted.
-		output.Error = ptr.String(usererror.ErrDefaultBranchCantBeDeleted.Error())
-		return output, nil
+		rejections = append(rejections, usererror.ErrDefaultBranchCantBeDeleted.Error())
+	}
+
+	if in.System {
+		// System calls bypass all checks, so ensure they are executed with the system service identity.
+		if err := c.verifySystemPrincipal(ctx, in.PrincipalID); err != nil {
+			return hook.Output{}, err
+		}
 	}
 
 	if in.Internal {
 		// It's an internal call, so no need to verify protection rules.
+		rejectPush(&output, rejections)
 		return output, nil
 	}
 
-	if c.blockPullReqRefUpdate(refUpdates) {
-		output.Error = ptr.String(usererror.ErrPullReqRefsCantBeModified.Error())
+	if c.maintenance.IsReadOnly() {
+		// Pushes are the only writes that aren't blocked by the api, reject them with a friendly message.
+		rejectPush(&output, []string{c.maintenance.Message()})
 		return output, nil
 	}
 
+	if repo.Archived {
+		rejectPush(&output, []string{usererror.ErrRepoArchived.Error()})
+		return output, nil
+	}
+
+	if c.blockPullReqRefUpdate(refUpdates) {
+		rejections = append(rejections, usererror.ErrPullReqRefsCantBeModified.Error())
+	}
+
+	conflicts, err := c.findRefConflicts(ctx, repo, refUpdates)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to find reference conflicts: %w", err)
+	}
+
+	rejections = append(rejections, conflicts...)
+
 	// TODO: use store.PrincipalInfoCache once we abstracted principals.
 	principal, err := c.principalStore.Find(ctx, in.PrincipalID)
 	if err != nil {
@@ -82,14 +115,190 @@ func (c *Controller) PreReceive(
 		Metadata:  nil,
 	}
 
-	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+	newCommits := c.newCommitsFunc(repo, in)
+
+	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, newCommits, &output)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 	}
 
+	if output.Error != nil {
+		rejections = append(rejections, *output.Error)
+	}
+
+	// scanning is expensive, so it's only done if the push passed all other checks.
+	if len(rejections) == 0 {
+		rejections = c.scanForMalware(ctx, repo, in)
+	}
+
+	if len(rejections) > 0 {
+		rejectPush(&output, rejections)
+		return output, nil
+	}
+
+	// external hooks are only invoked after all built-in checks passed.
+	result, err := c.externalHooks.Run(ctx, repo, principal, in.PreReceiveInput)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to run external hooks: %w", err)
+	}
+
+	output.Messages = append(output.Messages, result.Messages...)
+	if result.Error != "" {
+		output.Error = ptr.String(result.Error)
+	}
+
 	return output, nil
 }
 
+// scanForMalware scans the files added by the push for malware and returns the reasons for rejecting the push.
+func (c *Controller) scanForMalware(
+	ctx context.Context,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) []string {
+	if !c.malwareScan.Enabled() {
+		return nil
+	}
+
+	shas := make([]string, 0, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New != types.NilSHA && !slices.Contains(shas, refUpdate.New) {
+			shas = append(shas, refUpdate.New)
+		}
+	}
+
+	findings, err := c.malwareScan.ScanPush(ctx, repo, in.PrincipalID, in.Environment.AlternateObjectDirs, shas)
+	if err != nil {
+		if c.malwareScan.FailOpen() {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to scan push for malware, accepting it")
+			return nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Msg("failed to scan push for malware, rejecting it")
+		return []string{"The pushed files couldn't be scanned for malware, please try again later."}
+	}
+
+	rejections := make([]string, len(findings))
+	for i, finding := range findings {
+		rejections[i] = fmt.Sprintf("Malware %q detected in file %q.", finding.Signature, finding.Path)
+	}
+
+	return rejections
+}
+
+// rejectPush rejects the push with all provided reasons (if any).
+func rejectPush(output *hook.Output, reasons []string) {
+	switch len(reasons) {
+	case 0:
+		return
+	case 1:
+		output.Error = ptr.String(reasons[0])
+	default:
+		output.Messages = append(output.Messages, "The push was rejected for the following reasons:")
+		for _, reason := range reasons {
+			output.Messages = append(output.Messages, "  - "+reason)
+		}
+		output.Error = ptr.String("Push rejected, no references were updated.")
+	}
+}
+
+// findRefConflicts returns the references created by the push that can't be created because of a conflicting
+// reference (e.g. "refs/heads/a" and "refs/heads/a/b"), either in the repository or in the same push.
+// Git would only reject the conflicting references, while other references of the push would get updated.
+// References deleted by the same push don't cause conflicts, so a push can replace "refs/heads/a" with
+// "refs/heads/a/b".
+func (c *Controller) findRefConflicts(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates changedRefs,
+) ([]string, error) {
+	created := refUpdates.fullNames(func(c *changes) []string { return c.created })
+	if len(created) == 0 {
+		return nil, nil
+	}
+
+	conflicts := findPushRefConflicts(created)
+
+	out, err := c.git.FindRefConflicts(ctx, &git.FindRefConflictsParams{
+		ReadParams:      git.ReadParams{RepoUID: repo.GitUID},
+		RefNames:        created,
+		DeletedRefNames: refUpdates.fullNames(func(c *changes) []string { return c.deleted }),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range created {
+		if existing, ok := out.Conflicts[ref]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("Reference %q conflicts with existing reference %q.",
+				ref, existing))
+		}
+	}
+
+	return conflicts, nil
+}
+
+// newCommitsFunc returns a function that lists the commits the push adds to a branch.
+// Pre-receive runs before the objects are moved out of quarantine,
+// so the alternate object directories provided by git are used to access the new commits.
+func (c *Controller) newCommitsFunc(
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) func(ctx context.Context, branchName string) ([]types.Commit, error) {
+	newSHAs := make(map[string]string)
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.New == types.NilSHA {
+			continue
+		}
+		newSHAs[refUpdate.Ref[len(gitReferenceNamePrefixBranch):]] = refUpdate.New
+	}
+
+	cache := make(map[string][]types.Commit)
+
+	return func(ctx context.Context, branchName string) ([]types.Commit, error) {
+		sha, ok := newSHAs[branchName]
+		if !ok {
+			return nil, nil
+		}
+
+		if commits, ok := cache[sha]; ok {
+			return commits, nil
+		}
+
+		out, err := c.git.ListNewCommits(ctx, &git.ListNewCommitsParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			SHA:                 sha,
+			Limit:               maxNewCommits,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list new commits: %w", err)
+		}
+
+		// controller.MapCommit can't be used here because of an import cycle.
+		commits := make([]types.Commit, len(out.Commits))
+		for i, commit := range out.Commits {
+			commits[i] = types.Commit{
+				SHA:     commit.SHA,
+				Title:   commit.Title,
+				Message: commit.Message,
+				Author: types.Signature{
+					Identity: types.Identity{Name: commit.Author.Identity.Name, Email: commit.Author.Identity.Email},
+					When:     commit.Author.When,
+				},
+				Committer: types.Signature{
+					Identity: types.Identity{Name: commit.Committer.Identity.Name, Email: commit.Committer.Identity.Email},
+					When:     commit.Committer.When,
+				},
+			}
+		}
+
+		cache[sha] = commits
+
+		return commits, nil
+	}
+}
+
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 	fn := func(ref string) bool {
 		return strings.HasPrefix(ref, gitReferenceNamePullReq)
@@ -105,6 +314,7 @@ func (c *Controller) checkProtectionRules(
 	session *auth.Session,
 	repo *types.Repository,
 	refUpdates changedRefs,
+	newCommits func(ctx context.Context, branchName string) ([]types.Commit, error),
 	output *hook.Output,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
@@ -133,6 +343,7 @@ func (c *Controller) checkProtectionRules(
 			RefAction:   refAction,
 			RefType:     refType,
 			RefNames:    names,
+			NewCommits:  newCommits,
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -195,6 +406,32 @@ type changedRefs struct {
 	other    changes
 }
 
+// fullNames returns the full reference names of the selected changes of branches, tags and other references.
+func (c *changedRefs) fullNames(selectChanges func(c *changes) []string) []string {
+	var names []string
+	for _, name := range selectChanges(&c.branches) {
+		names = append(names, gitReferenceNamePrefixBranch+name)
+	}
+	for _, name := range selectChanges(&c.tags) {
+		names = append(names, gitReferenceNamePrefixTag+name)
+	}
+	return append(names, selectChanges(&c.other)...)
+}
+
+// findPushRefConflicts returns the conflicts between references created by the same push.
+func findPushRefConflicts(created []string) []string {
+	var conflicts []string
+	for _, ref := range created {
+		for _, other := range created {
+			if strings.HasPrefix(other, ref+"/") {
+				conflicts = append(conflicts, fmt.Sprintf("Reference %q conflicts with reference %q of the push.",
+					ref, other))
+			}
+		}
+	}
+	return conflicts
+}
+
 func groupRefsByAction(refUpdates []hook.ReferenceUpdate) (c changedRefs) {
 	for _, refUpdate := range refUpdates {
 		switch {
@@ -210,3 +447,18 @@ func groupRefsByAction(refUpdates []hook.ReferenceUpdate) (c changedRefs) {
 	}
 	return
 }
+
+// verifySystemPrincipal verifies that the principal is allowed to execute system git operations.
+// Only admin service accounts (like the system service account) are allowed to do so.
+func (c *Controller) verifySystemPrincipal(ctx context.Context, principalID int64) error {
+	principal, err := c.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to find system principal with id %d: %w", principalID, err)
+	}
+
+	if principal.Type != enum.PrincipalTypeService || !principal.Admin {
+		return usererror.Forbidden("System git operations can only be executed by the system service account.")
+	}
+
+	return nil
+}
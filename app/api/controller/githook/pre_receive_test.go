@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/pubsub"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const testSHA = "1111111111111111111111111111111111111111"
+
+// refConflictsGit reports the configured existing references as conflicts
+// unless they are deleted by the push.
+type refConflictsGit struct {
+	git.Interface
+	existing []string
+	params   *git.FindRefConflictsParams
+}
+
+func (g *refConflictsGit) FindRefConflicts(
+	_ context.Context,
+	params *git.FindRefConflictsParams,
+) (*git.FindRefConflictsOutput, error) {
+	g.params = params
+
+	deleted := map[string]bool{}
+	for _, ref := range params.DeletedRefNames {
+		deleted[ref] = true
+	}
+
+	out := &git.FindRefConflictsOutput{Conflicts: map[string]string{}}
+	for _, ref := range params.RefNames {
+		for _, existing := range g.existing {
+			if !deleted[existing] && git.IsRefConflict(ref, existing) {
+				out.Conflicts[ref] = existing
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func TestFindRefConflicts(t *testing.T) {
+	created := func(ref string) hook.ReferenceUpdate {
+		return hook.ReferenceUpdate{Ref: ref, Old: types.NilSHA, New: testSHA}
+	}
+	deleted := func(ref string) hook.ReferenceUpdate {
+		return hook.ReferenceUpdate{Ref: ref, Old: testSHA, New: types.NilSHA}
+	}
+
+	tests := []struct {
+		name        string
+		existing    []string
+		updates     []hook.ReferenceUpdate
+		wantDeleted []string
+		want        []string
+	}{
+		{
+			name:     "created below existing branch",
+			existing: []string{"refs/heads/a"},
+			updates:  []hook.ReferenceUpdate{created("refs/heads/a/b")},
+			want:     []string{`Reference "refs/heads/a/b" conflicts with existing reference "refs/heads/a".`},
+		},
+		{
+			name:     "created above existing branch",
+			existing: []string{"refs/heads/a/b"},
+			updates:  []hook.ReferenceUpdate{created("refs/heads/a")},
+			want:     []string{`Reference "refs/heads/a" conflicts with existing reference "refs/heads/a/b".`},
+		},
+		{
+			name:    "conflict within the push",
+			updates: []hook.ReferenceUpdate{created("refs/tags/v1"), created("refs/tags/v1/rc")},
+			want:    []string{`Reference "refs/tags/v1" conflicts with reference "refs/tags/v1/rc" of the push.`},
+		},
+		{
+			name:        "existing branch deleted in the same push",
+			existing:    []string{"refs/heads/a"},
+			updates:     []hook.ReferenceUpdate{deleted("refs/heads/a"), created("refs/heads/a/b")},
+			wantDeleted: []string{"refs/heads/a"},
+			want:        nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &refConflictsGit{existing: test.existing}
+			c := &Controller{git: g}
+
+			got, err := c.findRefConflicts(context.Background(), &types.Repository{GitUID: "repo"},
+				groupRefsByAction(test.updates))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("conflicts = %q, want %q", got, test.want)
+			}
+
+			if !reflect.DeepEqual(g.params.DeletedRefNames, test.wantDeleted) {
+				t.Errorf("deleted refs = %q, want %q", g.params.DeletedRefNames, test.wantDeleted)
+			}
+		})
+	}
+}
+
+// readOnlyRepoStore returns a single repository.
+type readOnlyRepoStore struct {
+	store.RepoStore
+}
+
+func (readOnlyRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, Identifier: "repo", DefaultBranch: "main"}, nil
+}
+
+// emptySettingsStore doesn't contain any settings.
+type emptySettingsStore struct {
+	store.SettingsStore
+}
+
+func (emptySettingsStore) Find(context.Context, enum.SettingsScope, int64, string) (json.RawMessage, error) {
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestPreReceiveReadOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := &types.Config{}
+	config.Maintenance.ReadOnly = true
+	config.Maintenance.Message = "backup running"
+
+	maintenanceService, err := maintenance.NewService(ctx, config,
+		settings.NewService(emptySettingsStore{}, nil), pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create maintenance service: %v", err)
+	}
+
+	c := NewController(nil, nil, readOnlyRepoStore{}, nil, nil, nil, nil, nil, limiter.NewResourceLimiter(),
+		nil, nil, nil, maintenanceService, nil)
+
+	in := types.GithookPreReceiveInput{
+		GithookInputBase: types.GithookInputBase{RepoID: 1, PrincipalID: 2},
+		PreReceiveInput: hook.PreReceiveInput{
+			RefUpdates: []hook.ReferenceUpdate{{Ref: "refs/heads/feature", Old: types.NilSHA, New: testSHA}},
+		},
+	}
+
+	out, err := c.PreReceive(ctx, nil, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Error == nil || *out.Error != "backup running" {
+		t.Errorf("expected push to be rejected in read-only mode, got %+v", out)
+	}
+
+	// internal pushes (e.g. merges) are already blocked by the api.
+	in.Internal = true
+	out, err = c.PreReceive(ctx, nil, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Error != nil {
+		t.Errorf("expected internal push to be accepted, got %q", *out.Error)
+	}
+}
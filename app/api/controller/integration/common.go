@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxTokenLength   = 1024
+	maxChannelLength = 80
+)
+
+// checkIntegration verifies the integration can be used to post messages.
+// Slack integrations require either an incoming webhook URL or a bot token with a channel,
+// Microsoft Teams integrations always require an incoming webhook URL.
+func (c *Controller) checkIntegration(integration *types.Integration) error {
+	if len(integration.Token) > maxTokenLength {
+		return check.NewValidationErrorf("The token can be at most %d characters long.", maxTokenLength)
+	}
+	if len(integration.Channel) > maxChannelLength {
+		return check.NewValidationErrorf("The channel can be at most %d characters long.", maxChannelLength)
+	}
+
+	switch integration.Type {
+	case enum.IntegrationTypeSlack:
+		if integration.URL == "" && (integration.Token == "" || integration.Channel == "") {
+			return check.NewValidationError(
+				"A Slack integration requires either an incoming webhook URL or a bot token and a channel.")
+		}
+	case enum.IntegrationTypeTeams:
+		if integration.URL == "" {
+			return check.NewValidationError("A Microsoft Teams integration requires an incoming webhook URL.")
+		}
+		if integration.Token != "" || integration.Channel != "" {
+			return check.NewValidationError("A Microsoft Teams integration doesn't support a token or a channel.")
+		}
+	}
+
+	if integration.URL != "" {
+		if err := c.integrationService.CheckURL(integration.Type, integration.URL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sanitizeEvents validates the provided events and removes duplicates.
+func sanitizeEvents(in []enum.IntegrationEvent) ([]enum.IntegrationEvent, error) {
+	seen := make(map[enum.IntegrationEvent]struct{}, len(in))
+	events := make([]enum.IntegrationEvent, 0, len(in))
+	for _, e := range in {
+		event, ok := e.Sanitize()
+		if !ok || event == "" {
+			return nil, check.NewValidationErrorf("The provided event %q is invalid.", e)
+		}
+		if _, ok = seen[event]; ok {
+			continue
+		}
+		seen[event] = struct{}{}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
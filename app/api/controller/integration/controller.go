@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	integrationStore   store.IntegrationStore
+	repoStore          store.RepoStore
+	spaceStore         store.SpaceStore
+	integrationService *integration.Service
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	integrationStore store.IntegrationStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	integrationService *integration.Service,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		integrationStore:   integrationStore,
+		repoStore:          repoStore,
+		spaceStore:         spaceStore,
+		integrationService: integrationService,
+	}
+}
+
+// getParentCheckAccess returns the ID of the referenced space or repository
+// after verifying the principal has the edit or view permission on it.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.ParentResourceType,
+	parentRef string,
+	edit bool,
+) (int64, error) {
+	switch parentType {
+	case enum.ParentResourceTypeRepo:
+		if parentRef == "" {
+			return 0, usererror.BadRequest("A valid repository reference must be provided.")
+		}
+
+		repo, err := c.repoStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		permission := enum.PermissionRepoView
+		if edit {
+			permission = enum.PermissionRepoEdit
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission, false); err != nil {
+			return 0, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+
+		return repo.ID, nil
+
+	case enum.ParentResourceTypeSpace:
+		if parentRef == "" {
+			return 0, usererror.BadRequest("A valid space reference must be provided.")
+		}
+
+		space, err := c.spaceStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		permission := enum.PermissionSpaceView
+		if edit {
+			permission = enum.PermissionSpaceEdit
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+			return 0, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+
+		return space.ID, nil
+	}
+
+	return 0, fmt.Errorf("unsupported integration parent type %q", parentType)
+}
+
+func (c *Controller) getIntegrationCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.ParentResourceType,
+	parentRef string,
+	identifier string,
+	edit bool,
+) (*types.Integration, error) {
+	parentID, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, edit)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := c.integrationStore.FindByIdentifier(ctx, parentType, parentID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integration: %w", err)
+	}
+
+	return integration, nil
+}
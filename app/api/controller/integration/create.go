@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier  string                  `json:"identifier"`
+	Description string                  `json:"description"`
+	Type        enum.IntegrationType    `json:"type"`
+	URL         string                  `json:"url"`
+	Token       string                  `json:"token"`
+	Channel     string                  `json:"channel"`
+	Events      []enum.IntegrationEvent `json:"events"`
+	Enabled     bool                    `json:"enabled"`
+}
+
+func (in *CreateInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	integrationType, ok := in.Type.Sanitize()
+	if !ok || integrationType == "" {
+		return check.NewValidationErrorf("The provided integration type %q is invalid.", in.Type)
+	}
+	in.Type = integrationType
+
+	events, err := sanitizeEvents(in.Events)
+	if err != nil {
+		return err
+	}
+	in.Events = events
+
+	return nil
+}
+
+// Create creates a new integration for a space or a repository.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.ParentResourceType,
+	parentRef string,
+	in *CreateInput,
+) (*types.Integration, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	parentID, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	integration := &types.Integration{
+		ParentID:    parentID,
+		ParentType:  parentType,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		URL:         in.URL,
+		Token:       in.Token,
+		Channel:     in.Channel,
+		Events:      in.Events,
+		Enabled:     in.Enabled,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.checkIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	if err = c.integrationStore.Create(ctx, integration); err != nil {
+		return nil, fmt.Errorf("failed to store integration: %w", err)
+	}
+
+	return integration, nil
+}
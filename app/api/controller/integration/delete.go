@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes an integration of a space or a repository.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.ParentResourceType,
+	parentRef string,
+	identifier string,
+) error {
+	integration, err := c.getIntegrationCheckAccess(ctx, session, parentType, parentRef, identifier, true)
+	if err != nil {
+		return err
+	}
+
+	if err = c.integrationStore.Delete(ctx, integration.ID); err != nil {
+		return fmt.Errorf("failed to delete integration: %w", err)
+	}
+
+	return nil
+}
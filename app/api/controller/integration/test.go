@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Test posts a test message to the channel of an integration.
+func (c *Controller) Test(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.ParentResourceType,
+	parentRef string,
+	identifier string,
+) error {
+	in, err := c.getIntegrationCheckAccess(ctx, session, parentType, parentRef, identifier, true)
+	if err != nil {
+		return err
+	}
+
+	err = c.integrationService.Send(ctx, in, &integration.Message{
+		Title: "Test message",
+		Text:  "The integration \"" + in.Identifier + "\" is configured correctly.",
+		Color: integration.ColorGray,
+	})
+	if err != nil {
+		return usererror.BadRequestf("Failed to post the test message: %s", err)
+	}
+
+	return nil
+}
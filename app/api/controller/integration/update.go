@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Identifier  *string                 `json:"identifier"`
+	Description *string                 `json:"description"`
+	URL         *string                 `json:"url"`
+	Token       *string                 `json:"token"`
+	Channel     *string                 `json:"channel"`
+	Events      []enum.IntegrationEvent `json:"events"`
+	Enabled     *bool                   `json:"enabled"`
+}
+
+// Update updates an existing integration. The type of an integration can't be changed.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.ParentResourceType,
+	parentRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.Integration, error) {
+	integration, err := c.getIntegrationCheckAccess(ctx, session, parentType, parentRef, identifier, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Identifier != nil {
+		if err = check.Identifier(*in.Identifier); err != nil {
+			return nil, err
+		}
+		integration.Identifier = *in.Identifier
+	}
+	if in.Description != nil {
+		if err = check.Description(*in.Description); err != nil {
+			return nil, err
+		}
+		integration.Description = *in.Description
+	}
+	if in.URL != nil {
+		integration.URL = *in.URL
+	}
+	if in.Token != nil {
+		integration.Token = *in.Token
+	}
+	if in.Channel != nil {
+		integration.Channel = *in.Channel
+	}
+	if in.Events != nil {
+		if integration.Events, err = sanitizeEvents(in.Events); err != nil {
+			return nil, err
+		}
+	}
+	if in.Enabled != nil {
+		integration.Enabled = *in.Enabled
+	}
+
+	if err = c.checkIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	if err = c.integrationStore.Update(ctx, integration); err != nil {
+		return nil, fmt.Errorf("failed to update integration: %w", err)
+	}
+
+	return integration, nil
+}
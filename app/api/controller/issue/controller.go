@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxTitleLength = 256
+	maxLabelLength = 64
+	maxLabels      = 20
+	maxAssignees   = 10
+)
+
+type Controller struct {
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	issueStore         store.IssueStore
+	principalInfoCache store.PrincipalInfoCache
+	eventReporter      *issueevents.Reporter
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	issueStore store.IssueStore,
+	principalInfoCache store.PrincipalInfoCache,
+	eventReporter *issueevents.Reporter,
+) *Controller {
+	return &Controller{
+		tx:                 tx,
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		issueStore:         issueStore,
+		principalInfoCache: principalInfoCache,
+		eventReporter:      eventReporter,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission, orPublic bool,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.Importing {
+		return nil, usererror.BadRequest("Repository import is in progress.")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, orPublic); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+// checkModifyAccess verifies that the principal is either the author of the issue
+// or is allowed to push to the repository.
+func (c *Controller) checkModifyAccess(ctx context.Context,
+	session *auth.Session, repo *types.Repository, issue *types.Issue,
+) error {
+	if issue.CreatedBy == session.Principal.ID {
+		return nil
+	}
+
+	if err := apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush, false); err != nil {
+		return fmt.Errorf("access check failed: %w", err)
+	}
+
+	return nil
+}
+
+func checkTitle(title string) error {
+	if title == "" {
+		return usererror.BadRequest("Issue title can't be empty.")
+	}
+
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return usererror.BadRequestf("Issue title can be at most %d characters long.", maxTitleLength)
+	}
+
+	return nil
+}
+
+// sanitizeLabels trims the labels, removes duplicates and verifies the result.
+func sanitizeLabels(labels []string) ([]string, error) {
+	result := make([]string, 0, len(labels))
+	seen := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, usererror.BadRequest("Issue labels can't be empty.")
+		}
+
+		if utf8.RuneCountInString(label) > maxLabelLength {
+			return nil, usererror.BadRequestf("Issue labels can be at most %d characters long.", maxLabelLength)
+		}
+
+		if _, ok := seen[label]; ok {
+			continue
+		}
+
+		seen[label] = struct{}{}
+		result = append(result, label)
+	}
+
+	if len(result) > maxLabels {
+		return nil, usererror.BadRequestf("An issue can have at most %d labels.", maxLabels)
+	}
+
+	return result, nil
+}
+
+// sanitizeAssignees removes duplicates from the assignees and verifies that all of them are existing users.
+func (c *Controller) sanitizeAssignees(ctx context.Context, assigneeIDs []int64) ([]int64, error) {
+	result := make([]int64, 0, len(assigneeIDs))
+	seen := make(map[int64]struct{}, len(assigneeIDs))
+	for _, id := range assigneeIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+
+	if len(result) > maxAssignees {
+		return nil, usererror.BadRequestf("An issue can have at most %d assignees.", maxAssignees)
+	}
+
+	infos, err := c.principalInfoCache.Map(ctx, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assignee infos: %w", err)
+	}
+
+	for _, id := range result {
+		info, ok := infos[id]
+		if !ok || info.Type != enum.PrincipalTypeUser {
+			return nil, usererror.BadRequestf("Assignee with id %d doesn't exist.", id)
+		}
+	}
+
+	return result, nil
+}
+
+func eventBase(issue *types.Issue, principal *types.Principal) issueevents.Base {
+	return issueevents.Base{
+		IssueID:     issue.ID,
+		RepoID:      issue.RepoID,
+		PrincipalID: principal.ID,
+		Number:      issue.Number,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+	AssigneeIDs []int64  `json:"assignee_ids"`
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := checkTitle(in.Title); err != nil {
+		return err
+	}
+
+	labels, err := sanitizeLabels(in.Labels)
+	if err != nil {
+		return err
+	}
+
+	in.Labels = labels
+
+	return nil
+}
+
+// Create creates a new issue.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Issue, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeIDs, err := c.sanitizeAssignees(ctx, in.AssigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	issue := &types.Issue{
+		RepoID:      repo.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		Edited:      now,
+		State:       enum.IssueStateOpen,
+		Title:       in.Title,
+		Description: in.Description,
+		Labels:      in.Labels,
+		AssigneeIDs: assigneeIDs,
+	}
+
+	// the event is reported within the transaction to store it in the event outbox atomically with the issue.
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.IssueSeq++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to acquire IssueSeq number: %w", err)
+		}
+
+		issue.Number = repo.IssueSeq
+
+		if err = c.issueStore.Create(ctx, issue); err != nil {
+			return fmt.Errorf("issue creation failed: %w", err)
+		}
+
+		c.eventReporter.Created(ctx, &issueevents.CreatedPayload{
+			Base: eventBase(issue, &session.Principal),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.issueStore.Find(ctx, issue.ID)
+}
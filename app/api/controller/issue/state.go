@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StateInput struct {
+	State enum.IssueState `json:"state"`
+}
+
+// State opens or closes an issue.
+func (c *Controller) State(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	number int64,
+	in *StateInput,
+) (*types.Issue, error) {
+	state, ok := in.State.Sanitize()
+	if !ok {
+		return nil, usererror.BadRequest("Issue state must be either open or closed.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	if err = c.checkModifyAccess(ctx, session, repo, issue); err != nil {
+		return nil, err
+	}
+
+	if issue.State == state {
+		return issue, nil
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+			issue.State = state
+			if state == enum.IssueStateClosed {
+				now := time.Now().UnixMilli()
+				closedBy := session.Principal.ID
+				issue.ClosedBy = &closedBy
+				issue.Closed = &now
+			} else {
+				issue.ClosedBy = nil
+				issue.Closed = nil
+			}
+			issue.ClosedByPullReq = nil
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue state: %w", err)
+		}
+
+		base := eventBase(issue, &session.Principal)
+		if state == enum.IssueStateClosed {
+			c.eventReporter.Closed(ctx, &issueevents.ClosedPayload{Base: base})
+		} else {
+			c.eventReporter.Reopened(ctx, &issueevents.ReopenedPayload{Base: base})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
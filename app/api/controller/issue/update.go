@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInput is used to update an issue. Only the provided fields are changed.
+type UpdateInput struct {
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Labels      *[]string `json:"labels"`
+	AssigneeIDs *[]int64  `json:"assignee_ids"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := checkTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+	}
+
+	if in.Labels != nil {
+		labels, err := sanitizeLabels(*in.Labels)
+		if err != nil {
+			return err
+		}
+
+		in.Labels = &labels
+	}
+
+	return nil
+}
+
+// Update updates the title, description, labels or assignees of an issue.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	number int64,
+	in *UpdateInput,
+) (*types.Issue, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	if err = c.checkModifyAccess(ctx, session, repo, issue); err != nil {
+		return nil, err
+	}
+
+	var assigneeIDs []int64
+	if in.AssigneeIDs != nil {
+		assigneeIDs, err = c.sanitizeAssignees(ctx, *in.AssigneeIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+			if in.Title != nil {
+				issue.Title = *in.Title
+			}
+			if in.Description != nil {
+				issue.Description = *in.Description
+			}
+			if in.Labels != nil {
+				issue.Labels = *in.Labels
+			}
+			if in.AssigneeIDs != nil {
+				issue.AssigneeIDs = assigneeIDs
+			}
+			issue.Edited = time.Now().UnixMilli()
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+
+		c.eventReporter.Updated(ctx, &issueevents.UpdatedPayload{
+			Base: eventBase(issue, &session.Principal),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
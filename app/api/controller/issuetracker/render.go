@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/issuetracker"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxRenderTextLength is the maximum length of a text that can be rendered.
+const maxRenderTextLength = 256 << 10
+
+type RenderInput struct {
+	Text string `json:"text"`
+}
+
+type RenderOutput struct {
+	// Markdown is the text with all issue references replaced by markdown links.
+	Markdown   string                 `json:"markdown"`
+	References []types.IssueReference `json:"references"`
+}
+
+// Render finds the references to issues in a text (e.g. a commit message, pull request title or comment)
+// using the issue key patterns that apply to the repository and links them to the issue tracker.
+func (c *Controller) Render(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RenderInput,
+) (*RenderOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Text) > maxRenderTextLength {
+		return nil, check.NewValidationErrorf("The text can be at most %d bytes long.", maxRenderTextLength)
+	}
+
+	linker, err := c.issueTrackerService.Linker(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue linker: %w", err)
+	}
+
+	refs := linker.FindReferences(in.Text)
+	if refs == nil {
+		refs = []types.IssueReference{}
+	}
+
+	return &RenderOutput{
+		Markdown:   issuetracker.RenderMarkdown(in.Text, refs),
+		References: refs,
+	}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/issuetracker"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SettingsOutput contains the issue tracker settings of a space.
+type SettingsOutput struct {
+	types.IssueTrackerSettings
+	// Inherited is true in case the space doesn't configure any settings
+	// and the returned settings are inherited from one of its ancestors.
+	Inherited bool `json:"inherited"`
+}
+
+// FindSettings returns the issue tracker settings that apply to the space.
+func (c *Controller) FindSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*SettingsOutput, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findSettings(ctx, space.ID)
+}
+
+// UpdateSettings replaces the issue tracker settings of the space.
+// The settings apply to all repositories of the space and of subspaces without own settings.
+func (c *Controller) UpdateSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.IssueTrackerSettings,
+) (*SettingsOutput, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Patterns == nil {
+		in.Patterns = []types.IssueKeyPattern{}
+	}
+
+	if err = issuetracker.CheckSettings(in); err != nil {
+		return nil, err
+	}
+
+	if err = c.settings.SetIssueTrackerSettings(ctx, space.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return c.findSettings(ctx, space.ID)
+}
+
+func (c *Controller) findSettings(ctx context.Context, spaceID int64) (*SettingsOutput, error) {
+	own, err := c.settings.IssueTrackerSettings(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if own != nil {
+		return &SettingsOutput{IssueTrackerSettings: *own}, nil
+	}
+
+	effective, err := c.issueTrackerService.EffectiveSettings(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if effective == nil {
+		return &SettingsOutput{IssueTrackerSettings: types.IssueTrackerSettings{
+			Patterns: []types.IssueKeyPattern{},
+		}}, nil
+	}
+
+	return &SettingsOutput{IssueTrackerSettings: *effective, Inherited: true}, nil
+}
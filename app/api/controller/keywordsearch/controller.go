@@ -19,13 +19,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
 )
 
 type Controller struct {
-	authorizer authz.Authorizer
-	repoCtrl   *repo.Controller
-	searcher   keywordsearch.Searcher
-	spaceCtrl  *space.Controller
+	authorizer     authz.Authorizer
+	repoCtrl       *repo.Controller
+	searcher       keywordsearch.Searcher
+	spaceCtrl      *space.Controller
+	pullreqStore   store.PullReqStore
+	principalStore store.PrincipalStore
 }
 
 func NewController(
@@ -33,11 +36,15 @@ func NewController(
 	searcher keywordsearch.Searcher,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
+	pullreqStore store.PullReqStore,
+	principalStore store.PrincipalStore,
 ) *Controller {
 	return &Controller{
-		authorizer: authorizer,
-		searcher:   searcher,
-		repoCtrl:   repoCtrl,
-		spaceCtrl:  spaceCtrl,
+		authorizer:     authorizer,
+		searcher:       searcher,
+		repoCtrl:       repoCtrl,
+		spaceCtrl:      spaceCtrl,
+		pullreqStore:   pullreqStore,
+		principalStore: principalStore,
 	}
 }
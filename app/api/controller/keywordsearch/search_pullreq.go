@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	pullReqQueryAuthorPrefix = "author:"
+	pullReqQueryRepoPrefix   = "repo:"
+)
+
+// pullReqQuery is the parsed form of a pull request search query.
+type pullReqQuery struct {
+	terms     []string
+	phrases   []string
+	author    string
+	repoPaths []string
+}
+
+// SearchPullReqs performs a full-text search over the titles, descriptions and comments
+// of all pull requests the user has access to in the provided repositories or space.
+func (c *Controller) SearchPullReqs(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.PullReqSearchInput,
+) ([]*types.PullReq, int64, error) {
+	query := parsePullReqQuery(in.Query)
+	if len(query.terms) == 0 && len(query.phrases) == 0 {
+		return nil, 0, usererror.BadRequest("query has to contain at least one search term.")
+	}
+
+	if len(query.repoPaths) == 0 && in.SpacePath == "" {
+		return nil, 0, usererror.BadRequest(
+			"either a repo filter or a space path needs to be set.")
+	}
+
+	repoIDToPathMap, err := c.getReposByPath(ctx, session, query.repoPaths)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search repos by path: %w", err)
+	}
+
+	spaceRepoIDToPathMap, err := c.getReposBySpacePath(ctx, session, in.SpacePath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search repos by space path: %w", err)
+	}
+
+	for repoID, repoPath := range spaceRepoIDToPathMap {
+		repoIDToPathMap[repoID] = repoPath
+	}
+
+	if len(repoIDToPathMap) == 0 {
+		return []*types.PullReq{}, 0, nil
+	}
+
+	filter := &types.PullReqSearchFilter{
+		Page:    in.Page,
+		Size:    in.Size,
+		Terms:   query.terms,
+		Phrases: query.phrases,
+		RepoIDs: make([]int64, 0, len(repoIDToPathMap)),
+	}
+
+	for repoID := range repoIDToPathMap {
+		filter.RepoIDs = append(filter.RepoIDs, repoID)
+	}
+
+	if query.author != "" {
+		author, err := c.principalStore.FindByUID(ctx, query.author)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return []*types.PullReq{}, 0, nil
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to find author: %w", err)
+		}
+
+		filter.CreatedBy = author.ID
+	}
+
+	list, err := c.pullreqStore.Search(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	if filter.Page <= 1 && len(list) < filter.Size {
+		return list, int64(len(list)), nil
+	}
+
+	count, err := c.pullreqStore.SearchCount(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pull requests: %w", err)
+	}
+
+	return list, count, nil
+}
+
+// parsePullReqQuery splits the query into search terms, quoted phrases and filters.
+func parsePullReqQuery(s string) pullReqQuery {
+	var query pullReqQuery
+
+	for len(s) > 0 {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			break
+		}
+
+		if s[0] == '"' {
+			// an unterminated quote extends the phrase to the end of the query.
+			phrase, rest, _ := strings.Cut(s[1:], `"`)
+			s = rest
+
+			if phrase = strings.Join(strings.Fields(phrase), " "); phrase != "" {
+				query.phrases = append(query.phrases, phrase)
+			}
+
+			continue
+		}
+
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 {
+			end = len(s)
+		}
+
+		token := s[:end]
+		s = s[end:]
+
+		switch {
+		case strings.HasPrefix(token, pullReqQueryAuthorPrefix) && len(token) > len(pullReqQueryAuthorPrefix):
+			query.author = token[len(pullReqQueryAuthorPrefix):]
+		case strings.HasPrefix(token, pullReqQueryRepoPrefix) && len(token) > len(pullReqQueryRepoPrefix):
+			query.repoPaths = append(query.repoPaths, token[len(pullReqQueryRepoPrefix):])
+		default:
+			query.terms = append(query.terms, token)
+		}
+	}
+
+	return query
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePullReqQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  pullReqQuery
+	}{
+		{
+			name:  "empty",
+			input: "  ",
+			want:  pullReqQuery{},
+		},
+		{
+			name:  "terms",
+			input: "fix  login ",
+			want:  pullReqQuery{terms: []string{"fix", "login"}},
+		},
+		{
+			name:  "phrases",
+			input: `"login  flow" docs "session cookie"`,
+			want: pullReqQuery{
+				terms:   []string{"docs"},
+				phrases: []string{"login flow", "session cookie"},
+			},
+		},
+		{
+			name:  "unterminated-phrase",
+			input: `docs "login flow`,
+			want: pullReqQuery{
+				terms:   []string{"docs"},
+				phrases: []string{"login flow"},
+			},
+		},
+		{
+			name:  "filters",
+			input: "author:john repo:space/repo1 login repo:space/repo2 author:",
+			want: pullReqQuery{
+				terms:     []string{"login", "author:"},
+				author:    "john",
+				repoPaths: []string{"space/repo1", "space/repo2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parsePullReqQuery(test.input)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
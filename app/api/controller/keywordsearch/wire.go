@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
 )
@@ -33,6 +34,8 @@ func ProvideController(
 	searcher keywordsearch.Searcher,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
+	pullreqStore store.PullReqStore,
+	principalStore store.PrincipalStore,
 ) *Controller {
-	return NewController(authorizer, searcher, repoCtrl, spaceCtrl)
+	return NewController(authorizer, searcher, repoCtrl, spaceCtrl, pullreqStore, principalStore)
 }
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxTextLength is the maximum size of a markdown document that can be rendered.
+const maxTextLength = 1 << 20 // 1 MiB
+
+type RenderInput struct {
+	Text string `json:"text"`
+
+	// RepoRef is the repository the text belongs to. It's required to resolve relative links and issue references.
+	RepoRef string `json:"repo_ref"`
+	// GitRef is the git reference against which relative links are resolved. Defaults to the default branch.
+	GitRef string `json:"git_ref"`
+	// Path is the path of the markdown file in the repository. Relative links are resolved against its directory.
+	Path string `json:"path"`
+}
+
+type RenderOutput struct {
+	HTML string `json:"html"`
+}
+
+// Render converts markdown to sanitized HTML.
+func (c *Controller) Render(ctx context.Context,
+	session *auth.Session,
+	in *RenderInput,
+) (*RenderOutput, error) {
+	if len(in.Text) > maxTextLength {
+		return nil, usererror.BadRequestf("Text can't be longer than %d bytes.", maxTextLength)
+	}
+
+	opts := markdown.Options{}
+
+	if in.RepoRef != "" {
+		repo, err := c.repoStore.FindByRef(ctx, in.RepoRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true); err != nil {
+			return nil, err
+		}
+
+		opts.RepoPath = repo.Path
+		opts.GitRef = in.GitRef
+		opts.Path = in.Path
+
+		if opts.GitRef == "" {
+			opts.GitRef = repo.DefaultBranch
+		}
+	}
+
+	html, err := c.renderer.Render([]byte(in.Text), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenderOutput{HTML: html}, nil
+}
@@ -15,15 +15,18 @@
 package principal
 
 import (
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/app/store"
 )
 
 type controller struct {
 	principalStore store.PrincipalStore
+	avatar         *avatar.Service
 }
 
-func newController(principalStore store.PrincipalStore) *controller {
+func newController(principalStore store.PrincipalStore, avatar *avatar.Service) *controller {
 	return &controller{
 		principalStore: principalStore,
+		avatar:         avatar,
 	}
 }
@@ -16,6 +16,7 @@ package principal
 
 import (
 	"context"
+	"io"
 
 	"github.com/harness/gitness/types"
 )
@@ -25,4 +26,7 @@ import (
 type Controller interface {
 	// List lists the principals based on the provided filter.
 	List(ctx context.Context, opts *types.PrincipalFilter) ([]*types.PrincipalInfo, error)
+
+	// Avatar returns either a signed url or a reader for the avatar of the principal.
+	Avatar(ctx context.Context, principalID int64) (string, io.ReadCloser, error)
 }
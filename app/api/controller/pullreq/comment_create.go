@@ -108,6 +108,10 @@ func (c *Controller) CommentCreate(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	if err = c.checkCanComment(ctx, session, repo, pr); err != nil {
+		return nil, err
+	}
+
 	var cut git.DiffCutOutput
 	if in.IsCodeComment() {
 		// fetch code snippet from git for code comments
@@ -199,6 +203,8 @@ func (c *Controller) CommentCreate(
 		c.reportCommentCreated(ctx, pr, session.Principal.ID, act.ID, act.IsReply())
 	}
 
+	c.recordMentions(ctx, pr, &session.Principal, &act.ID, "", act.Text)
+
 	return act, nil
 }
 
@@ -62,6 +62,10 @@ func (c *Controller) CommentUpdate(
 		return nil, errValidate
 	}
 
+	if err = c.checkCanComment(ctx, session, repo, pr); err != nil {
+		return nil, err
+	}
+
 	act, err := c.getCommentCheckEditAccess(ctx, session, pr, commentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comment: %w", err)
@@ -71,19 +75,69 @@ func (c *Controller) CommentUpdate(
 		return act, nil
 	}
 
-	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
-		now := time.Now().UnixMilli()
-		act.Edited = now
-		act.Text = in.Text
-		return nil
+	var oldText string
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
+			oldText = act.Text
+			now := time.Now().UnixMilli()
+			act.Edited = now
+			act.Text = in.Text
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		return c.recordCommentEdit(ctx, act, oldText, session.Principal.ID)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update comment: %w", err)
+		return nil, err
 	}
 
 	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
+	c.recordMentions(ctx, pr, &session.Principal, &act.ID, oldText, act.Text)
+
 	return act, nil
 }
+
+// recordCommentEdit adds the new text of the comment to its edit history.
+// On the first edit the original text is recorded as well, so the history contains all versions of the comment.
+func (c *Controller) recordCommentEdit(
+	ctx context.Context,
+	act *types.PullReqActivity,
+	oldText string,
+	principalID int64,
+) error {
+	count, err := c.activityEditStore.Count(ctx, act.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count comment edits: %w", err)
+	}
+
+	if count == 0 {
+		err = c.activityEditStore.Create(ctx, &types.PullReqActivityEdit{
+			ActivityID: act.ID,
+			Text:       oldText,
+			EditedBy:   act.CreatedBy,
+			Edited:     act.Created,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record original comment text: %w", err)
+		}
+	}
+
+	err = c.activityEditStore.Create(ctx, &types.PullReqActivityEdit{
+		ActivityID: act.ID,
+		Text:       act.Text,
+		EditedBy:   principalID,
+		Edited:     act.Edited,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record comment edit: %w", err)
+	}
+
+	return nil
+}
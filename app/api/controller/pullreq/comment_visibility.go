@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CommentVisibilityInput struct {
+	Hidden bool                            `json:"hidden"`
+	Reason enum.PullReqCommentHiddenReason `json:"reason"`
+}
+
+func (in *CommentVisibilityInput) Validate() error {
+	if !in.Hidden {
+		return nil
+	}
+
+	if _, ok := in.Reason.Sanitize(); !ok {
+		return usererror.BadRequest("A valid reason must be provided for hiding a comment")
+	}
+
+	return nil
+}
+
+// CommentVisibility hides or unhides a pull request comment. Only repository admins can moderate comments.
+func (c *Controller) CommentVisibility(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID int64,
+	in *CommentVisibilityInput,
+) (*types.PullReqActivity, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	act, err := c.getCommentCheckModifyAccess(ctx, pr, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
+		act.Hidden = nil
+		act.HiddenBy = nil
+		act.HiddenReason = nil
+
+		if in.Hidden {
+			now := time.Now().UnixMilli()
+			act.Hidden = &now
+			act.HiddenBy = &session.Principal.ID
+			act.HiddenReason = &in.Reason
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update visibility of comment: %w", err)
+	}
+
+	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return act, nil
+}
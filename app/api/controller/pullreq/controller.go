@@ -25,8 +25,12 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/mention"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqlint"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -45,9 +49,12 @@ type Controller struct {
 	authorizer          authz.Authorizer
 	pullreqStore        store.PullReqStore
 	activityStore       store.PullReqActivityStore
+	activityEditStore   store.PullReqActivityEditStore
 	codeCommentView     store.CodeCommentView
 	reviewStore         store.PullReqReviewStore
 	reviewerStore       store.PullReqReviewerStore
+	reviewReminderStore store.ReviewReminderStore
+	mergeScheduleStore  store.MergeScheduleStore
 	repoStore           store.RepoStore
 	principalStore      store.PrincipalStore
 	fileViewStore       store.PullReqFileViewStore
@@ -61,6 +68,12 @@ type Controller struct {
 	protectionManager   *protection.Manager
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
+	realtime            *realtime.Service
+	mentions            *mention.Service
+	pullreqLint         *pullreqlint.Service
+	settings            *settings.Service
+	auditLogStore       store.AuditLogStore
+	auditEnabled        bool
 }
 
 func NewController(
@@ -69,9 +82,12 @@ func NewController(
 	authorizer authz.Authorizer,
 	pullreqStore store.PullReqStore,
 	pullreqActivityStore store.PullReqActivityStore,
+	activityEditStore store.PullReqActivityEditStore,
 	codeCommentView store.CodeCommentView,
 	pullreqReviewStore store.PullReqReviewStore,
 	pullreqReviewerStore store.PullReqReviewerStore,
+	reviewReminderStore store.ReviewReminderStore,
+	mergeScheduleStore store.MergeScheduleStore,
 	repoStore store.RepoStore,
 	principalStore store.PrincipalStore,
 	fileViewStore store.PullReqFileViewStore,
@@ -85,6 +101,12 @@ func NewController(
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
+	realtime *realtime.Service,
+	mentions *mention.Service,
+	pullreqLint *pullreqlint.Service,
+	settings *settings.Service,
+	auditLogStore store.AuditLogStore,
+	auditEnabled bool,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -92,9 +114,12 @@ func NewController(
 		authorizer:          authorizer,
 		pullreqStore:        pullreqStore,
 		activityStore:       pullreqActivityStore,
+		activityEditStore:   activityEditStore,
 		codeCommentView:     codeCommentView,
 		reviewStore:         pullreqReviewStore,
 		reviewerStore:       pullreqReviewerStore,
+		reviewReminderStore: reviewReminderStore,
+		mergeScheduleStore:  mergeScheduleStore,
 		repoStore:           repoStore,
 		principalStore:      principalStore,
 		fileViewStore:       fileViewStore,
@@ -108,6 +133,12 @@ func NewController(
 		protectionManager:   protectionManager,
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
+		realtime:            realtime,
+		mentions:            mentions,
+		pullreqLint:         pullreqLint,
+		settings:            settings,
+		auditLogStore:       auditLogStore,
+		auditEnabled:        auditEnabled,
 	}
 }
 
@@ -157,6 +188,11 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
 
+	// pull requests of archived repositories can't be created, updated or merged.
+	if repo.Archived && reqPermission == enum.PermissionRepoPush {
+		return nil, usererror.ErrRepoArchived
+	}
+
 	return repo, nil
 }
 
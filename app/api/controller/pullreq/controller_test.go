@@ -0,0 +1,340 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/mention"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreqlint"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/url"
+	gitness_cache "github.com/harness/gitness/cache"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// pullreqGit is a fake git implementation that resolves every commit, merges every pull request
+// and deletes every branch.
+type pullreqGit struct {
+	git.Interface
+	merges          []*git.MergeParams
+	deletedBranches []*git.DeleteBranchParams
+}
+
+func (g *pullreqGit) GetCommit(_ context.Context, params *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	return &git.GetCommitOutput{Commit: git.Commit{SHA: params.SHA}}, nil
+}
+
+func (g *pullreqGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.merges = append(g.merges, params)
+	return git.MergeOutput{
+		BaseSHA:      "base",
+		HeadSHA:      params.HeadExpectedSHA,
+		MergeBaseSHA: "base",
+		MergeSHA:     "merged",
+	}, nil
+}
+
+func (g *pullreqGit) DeleteBranch(_ context.Context, params *git.DeleteBranchParams) error {
+	g.deletedBranches = append(g.deletedBranches, params)
+	return nil
+}
+
+// allowAuthorizer grants every permission to every principal.
+type allowAuthorizer struct{}
+
+func (allowAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+func (allowAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return true, nil
+}
+
+// testEnv is a pull request controller backed by an in-memory database,
+// with a repository and the author and a reviewer of its pull requests.
+type testEnv struct {
+	ctrl               *Controller
+	git                *pullreqGit
+	repo               *types.Repository
+	space              *types.Space
+	author             *types.Principal
+	reviewer           *types.Principal
+	repoStore          store.RepoStore
+	ruleStore          store.RuleStore
+	pullreqStore       store.PullReqStore
+	reviewerStore      store.PullReqReviewerStore
+	activityStore      store.PullReqActivityStore
+	mergeScheduleStore store.MergeScheduleStore
+	settings           *settings.Service
+	auditLogStore      store.AuditLogStore
+	prCount            int64
+}
+
+func setupController(t *testing.T, name string, authorizer authz.Authorizer) *testEnv {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	db, err := sqlx.Connect("sqlite3", "file:"+name+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	// comments on failed scheduled merges are posted by the system service principal.
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation,
+		gitness_cache.NoRowCache{})
+	config := &types.Config{InstanceID: "test"}
+	config.Principal.System.UID = "gitness"
+	config.Principal.System.Email = "system@gitness.io"
+	config.Principal.System.DisplayName = "Gitness"
+	serviceCtrl := service.NewController(check.PrincipalUIDDefault, nil, principalStore)
+	if err = bootstrap.SystemService(ctx, config, serviceCtrl); err != nil {
+		t.Fatalf("failed to setup system service: %v", err)
+	}
+
+	principals := make([]*types.Principal, 2)
+	for i, uid := range []string{"author", "reviewer"} {
+		user := &types.User{UID: uid, Email: uid + "@example.com", DisplayName: uid}
+		if err = principalStore.CreateUser(ctx, user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		principals[i] = user.ToPrincipal()
+	}
+
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, gitness_cache.NoRowCache{})
+
+	space := &types.Space{Identifier: "acme", CreatedBy: principals[0].ID}
+	if err = spaceStore.Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %v", err)
+	}
+	err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: space.Identifier, SpaceID: space.ID, CreatedBy: principals[0].ID, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %v", err)
+	}
+
+	repo := &types.Repository{
+		Identifier:    "app",
+		ParentID:      space.ID,
+		GitUID:        "app",
+		DefaultBranch: "main",
+		CreatedBy:     principals[0].ID,
+	}
+	if err = repoStore.Create(ctx, repo); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+	if repo, err = repoStore.Find(ctx, repo.ID); err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://localhost:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:               events.ModeInMemory,
+		MaxStreamLength:    100,
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    1,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+	eventReaderFactory, err := pullreqevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reader factory: %v", err)
+	}
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	reviewerStore := database.NewPullReqReviewerStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+	mergeScheduleStore := database.NewMergeScheduleStore(db)
+	checkStore := database.NewCheckStore(db, pCache)
+	auditLogStore := database.NewAuditLogStore(db)
+	settingsService := settings.NewService(database.NewSettingsStore(db), spaceStore)
+
+	pullreqLint, err := pullreqlint.NewService(ctx, config, eventReaderFactory, settingsService,
+		pullreqStore, checkStore)
+	if err != nil {
+		t.Fatalf("failed to create pull request lint service: %v", err)
+	}
+
+	ruleStore := database.NewRuleStore(db, pCache)
+	protectionManager, err := protection.ProvideManager(ruleStore, repoStore, settingsService)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	gitFake := &pullreqGit{}
+
+	ctrl := NewController(
+		dbtx.New(db),
+		urlProvider,
+		authorizer,
+		pullreqStore,
+		activityStore,
+		nil,
+		nil,
+		database.NewPullReqReviewStore(db),
+		reviewerStore,
+		nil,
+		mergeScheduleStore,
+		repoStore,
+		principalStore,
+		nil,
+		nil,
+		checkStore,
+		gitFake,
+		eventReporter,
+		lock.NewInMemory(lock.Config{App: "test", Expiry: time.Minute, Tries: 1, RetryDelay: time.Millisecond}),
+		nil,
+		nil,
+		protectionManager,
+		sse.NewStreamer(pubsub.NewInMemory(), "test"),
+		codeowners.New(repoStore, gitFake, codeowners.Config{}, principalStore, nil),
+		nil,
+		mention.NewService(principalStore, database.NewPrincipalInfoView(db), nil),
+		pullreqLint,
+		settingsService,
+		auditLogStore,
+		true,
+	)
+
+	return &testEnv{
+		ctrl:               ctrl,
+		git:                gitFake,
+		repo:               repo,
+		space:              space,
+		author:             principals[0],
+		reviewer:           principals[1],
+		repoStore:          repoStore,
+		ruleStore:          ruleStore,
+		pullreqStore:       pullreqStore,
+		reviewerStore:      reviewerStore,
+		activityStore:      activityStore,
+		mergeScheduleStore: mergeScheduleStore,
+		settings:           settingsService,
+		auditLogStore:      auditLogStore,
+	}
+}
+
+func (e *testEnv) session(principal *types.Principal) *auth.Session {
+	return &auth.Session{Principal: *principal, Metadata: &auth.EmptyMetadata{}}
+}
+
+// createPullReq creates an open pull request of the author with the provided source branch commit.
+func (e *testEnv) createPullReq(t *testing.T, sourceSHA string) *types.PullReq {
+	t.Helper()
+	return e.createPullReqFrom(t, e.repo, sourceSHA)
+}
+
+// createPullReqFrom creates an open pull request of the author from a branch of the source repository.
+func (e *testEnv) createPullReqFrom(t *testing.T, sourceRepo *types.Repository, sourceSHA string) *types.PullReq {
+	t.Helper()
+
+	e.prCount++
+	now := time.Now().UnixMilli()
+	pr := &types.PullReq{
+		Number:           e.prCount,
+		CreatedBy:        e.author.ID,
+		Created:          now,
+		Updated:          now,
+		Edited:           now,
+		State:            enum.PullReqStateOpen,
+		Title:            "Add feature",
+		SourceRepoID:     sourceRepo.ID,
+		SourceBranch:     fmt.Sprintf("feature-%d", e.prCount),
+		SourceSHA:        sourceSHA,
+		TargetRepoID:     e.repo.ID,
+		TargetBranch:     "main",
+		MergeBaseSHA:     "base",
+		MergeCheckStatus: enum.MergeCheckStatusMergeable,
+		Conversation:     enum.PullReqConversationOpen,
+	}
+	if err := e.pullreqStore.Create(context.Background(), pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	return pr
+}
+
+// setReview records the review decision of the reviewer for the source branch commit.
+func (e *testEnv) setReview(t *testing.T, pr *types.PullReq, decision enum.PullReqReviewDecision, sha string) {
+	t.Helper()
+
+	now := time.Now().UnixMilli()
+	err := e.reviewerStore.Create(context.Background(), &types.PullReqReviewer{
+		PullReqID:      pr.ID,
+		PrincipalID:    e.reviewer.ID,
+		CreatedBy:      e.reviewer.ID,
+		Created:        now,
+		Updated:        now,
+		RepoID:         e.repo.ID,
+		Type:           enum.PullReqReviewerTypeSelfAssigned,
+		ReviewDecision: decision,
+		SHA:            sha,
+	})
+	if err != nil {
+		t.Fatalf("failed to create reviewer: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Events streams the real-time events of a pull request (comments, reviews, pushes, state changes).
+// If lastEventID is provided, the events following it are replayed first.
+func (c *Controller) Events(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	lastEventID int64,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	if pullreqNum <= 0 {
+		return nil, nil, nil, usererror.BadRequest("A valid pull request number must be provided.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	chEvents, chErr, sseCancel, err := c.realtime.StreamPullReq(ctx, repo.ID, pr.ID, lastEventID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to stream pull request events: %w", err)
+	}
+
+	return chEvents, chErr, sseCancel, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// recordMentions records the principals newly mentioned in a comment or the pull request description
+// as a pull request activity and reports them with the mentioned event.
+// The principal that wrote the text is never considered to be mentioned.
+// Errors are only logged, as mentions are not critical for the operation that changed the text.
+func (c *Controller) recordMentions(
+	ctx context.Context,
+	pr *types.PullReq,
+	principal *types.Principal,
+	commentID *int64,
+	oldText string,
+	newText string,
+) {
+	mentioned, err := c.mentions.ResolveNew(ctx, oldText, newText)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to resolve mentions")
+		return
+	}
+
+	principalIDs := make([]int64, 0, len(mentioned))
+	for _, p := range mentioned {
+		if p.ID != principal.ID {
+			principalIDs = append(principalIDs, p.ID)
+		}
+	}
+
+	if len(principalIDs) == 0 {
+		return
+	}
+
+	pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get pull request activity number for mention")
+		return
+	}
+
+	payload := &types.PullRequestActivityPayloadMention{
+		PrincipalIDs: principalIDs,
+		CommentID:    commentID,
+	}
+	if _, err = c.activityStore.CreateWithPayload(ctx, pr, principal.ID, payload); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to write pull request mention activity")
+	}
+
+	c.eventReporter.Mentioned(ctx, &pullreqevents.MentionedPayload{
+		Base:         eventBase(pr, principal),
+		PrincipalIDs: principalIDs,
+		CommentID:    commentID,
+	})
+}
@@ -155,6 +155,20 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
 	}
 
+	// change requests block the merge independent of the protection rules.
+	var changesRequestedBy []types.PrincipalInfo
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionChangeReq {
+			changesRequestedBy = append(changesRequestedBy, reviewer.Reviewer)
+		}
+	}
+
+	// so do violations of the pull request lint policy of the repository.
+	lintViolations, err := c.pullreqLint.Lint(ctx, pr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lint pull request: %w", err)
+	}
+
 	targetWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
@@ -163,14 +177,14 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
@@ -211,6 +225,14 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	deleteSourceBranch := ruleOut.DeleteSourceBranch
+	if !deleteSourceBranch {
+		deleteSourceBranch, err = c.deleteSourceBranchRequested(ctx, session, sourceRepo, pr, isRepoOwner)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// we want to complete the merge independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state.
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -273,7 +295,7 @@ func (c *Controller) Merge(
 
 		// With in.DryRun=true this function never returns types.MergeViolations
 		out := &types.MergeResponse{
-			BranchDeleted:  ruleOut.DeleteSourceBranch,
+			BranchDeleted:  deleteSourceBranch,
 			RuleViolations: violations,
 
 			// values only retured by dry run
@@ -284,13 +306,19 @@ func (c *Controller) Merge(
 			RequiresCommentResolution:     ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:      ruleOut.RequiresNoChangeRequests,
 			MinimumRequiredApprovalsCount: ruleOut.MinimumRequiredApprovalsCount,
+			ChangesRequestedBy:            changesRequestedBy,
+			LintViolations:                lintViolations,
 		}
 
 		return out, nil, nil
 	}
 
-	if protection.IsCritical(violations) {
-		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	if protection.IsCritical(violations) || len(changesRequestedBy) > 0 || len(lintViolations) > 0 {
+		return nil, &types.MergeViolations{
+			RuleViolations:     violations,
+			ChangesRequestedBy: changesRequestedBy,
+			LintViolations:     lintViolations,
+		}, nil
 	}
 
 	// commit details: author, committer and message
@@ -315,7 +343,29 @@ func (c *Controller) Merge(
 		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
 	}
 
-	// backfill commit title if none provided
+	// backfill commit title and message from the repository template if none provided
+	if in.Title == "" || in.Message == "" {
+		template, err := c.mergeMessageTemplate(ctx, targetRepo, in.Method)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get merge message template: %w", err)
+		}
+
+		if template != nil {
+			title, message, err := c.renderMergeMessage(ctx, template, sourceRepo, pr, reviewers)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to render merge message template: %w", err)
+			}
+
+			if in.Title == "" {
+				in.Title = title
+			}
+			if in.Message == "" {
+				in.Message = message
+			}
+		}
+	}
+
+	// fall back to the default commit title
 	if in.Title == "" {
 		switch in.Method {
 		case enum.MergeMethodMerge:
@@ -407,7 +457,7 @@ func (c *Controller) Merge(
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -439,7 +489,7 @@ func (c *Controller) Merge(
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if deleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
 			WriteParams: sourceWriteParams,
 			BranchName:  pr.SourceBranch,
@@ -472,3 +522,72 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// deleteSourceBranchRequested returns whether the source branch should be deleted after the merge
+// according to the pull request or, if not set there, the source repository setting.
+// The branch is kept if it's the default branch, if the user isn't allowed to push to the source repository,
+// if it's protected against deletion or if it's used as the source branch by other open pull requests.
+func (c *Controller) deleteSourceBranchRequested(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	isRepoOwner bool,
+) (bool, error) {
+	deleteSourceBranch := sourceRepo.PullReqDeleteSourceBranch
+	if pr.DeleteSourceBranch != nil {
+		deleteSourceBranch = *pr.DeleteSourceBranch
+	}
+	if !deleteSourceBranch || pr.SourceBranch == sourceRepo.DefaultBranch {
+		return false, nil
+	}
+
+	// merging requires push permission on the target repository, but not on the source repository of a fork.
+	if pr.SourceRepoID != pr.TargetRepoID {
+		err := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to check push permission on the source repo: %w", err)
+		}
+
+		isRepoOwner, err = apiauth.IsRepoOwner(ctx, c.authorizer, session, sourceRepo)
+		if err != nil {
+			return false, fmt.Errorf("failed to determine if user is source repo owner: %w", err)
+		}
+	}
+
+	sourceRules, err := c.protectionManager.ForRepository(ctx, sourceRepo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch protection rules for the source repository: %w", err)
+	}
+
+	violations, err := sourceRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: false,
+		IsRepoOwner: isRepoOwner,
+		Repo:        sourceRepo,
+		RefAction:   protection.RefActionDelete,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to verify protection rules for the source branch: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return false, nil
+	}
+
+	// the count includes the pull request being merged
+	openPullReqs, err := c.pullreqStore.Count(ctx, &types.PullReqFilter{
+		SourceRepoID: sourceRepo.ID,
+		SourceBranch: pr.SourceBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to count open pull requests of the source branch: %w", err)
+	}
+
+	return openPullReqs <= 1, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// viewOnlyAuthorizer grants every permission, except that the repository can only be viewed.
+type viewOnlyAuthorizer struct {
+	repo string
+}
+
+func (a viewOnlyAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return resource.Identifier != a.repo || permission == enum.PermissionRepoView, nil
+}
+
+func (a viewOnlyAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		ok, err := a.Check(ctx, session, &permissionChecks[i].Scope, &permissionChecks[i].Resource,
+			permissionChecks[i].Permission)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// createFork creates a repository next to the test repository that deletes merged source branches.
+func (e *testEnv) createFork(t *testing.T) *types.Repository {
+	t.Helper()
+
+	ctx := context.Background()
+	fork := &types.Repository{
+		Identifier:                "app-fork",
+		ParentID:                  e.space.ID,
+		GitUID:                    "app-fork",
+		DefaultBranch:             "main",
+		ForkID:                    e.repo.ID,
+		PullReqDeleteSourceBranch: true,
+		CreatedBy:                 e.author.ID,
+	}
+	if err := e.repoStore.Create(ctx, fork); err != nil {
+		t.Fatalf("failed to create fork: %v", err)
+	}
+
+	return fork
+}
+
+// requestDeleteSourceBranch marks the source branch of the pull request for deletion after the merge.
+func (e *testEnv) requestDeleteSourceBranch(t *testing.T, pr *types.PullReq) {
+	t.Helper()
+
+	deleteSourceBranch := true
+	_, err := e.pullreqStore.UpdateOptLock(context.Background(), pr, func(pr *types.PullReq) error {
+		pr.DeleteSourceBranch = &deleteSourceBranch
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update pull request: %v", err)
+	}
+}
+
+// mergeDeletesBranch merges the pull request and returns the repository in which the source branch got deleted.
+func (e *testEnv) mergeDeletesBranch(t *testing.T, pr *types.PullReq) (string, bool) {
+	t.Helper()
+
+	out, violations, err := e.ctrl.Merge(context.Background(), e.session(e.author), e.repo.Path, pr.Number,
+		&MergeInput{Method: enum.MergeMethodSquash, SourceSHA: pr.SourceSHA})
+	if err != nil {
+		t.Fatalf("failed to merge pull request: %v", err)
+	}
+	if violations != nil {
+		t.Fatalf("expected the pull request to be merged, got %+v", violations)
+	}
+
+	if len(e.git.deletedBranches) == 0 {
+		if out.BranchDeleted {
+			t.Fatalf("expected the source branch to be reported as kept")
+		}
+		return "", false
+	}
+
+	deleted := e.git.deletedBranches[0]
+	if len(e.git.deletedBranches) != 1 || deleted.BranchName != pr.SourceBranch || !out.BranchDeleted {
+		t.Fatalf("expected the source branch %s to be deleted, got %+v", pr.SourceBranch, e.git.deletedBranches)
+	}
+
+	return deleted.RepoUID, true
+}
+
+func TestMergeDeleteSourceBranch(t *testing.T) {
+	env := setupController(t, "merge_delete_source_branch", allowAuthorizer{})
+
+	pr := env.createPullReq(t, "sha1")
+	env.requestDeleteSourceBranch(t, pr)
+
+	repoUID, deleted := env.mergeDeletesBranch(t, pr)
+	if !deleted || repoUID != env.repo.GitUID {
+		t.Errorf("expected the source branch to be deleted in %s, got deleted=%t in %q",
+			env.repo.GitUID, deleted, repoUID)
+	}
+}
+
+func TestMergeDeleteSourceBranch_Protected(t *testing.T) {
+	env := setupController(t, "merge_delete_source_branch_protected", allowAuthorizer{})
+
+	now := time.Now().UnixMilli()
+	pattern := protection.Pattern{Include: []string{"feature-*"}}
+	err := env.ruleStore.Create(context.Background(), &types.Rule{
+		CreatedBy:  env.author.ID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     &env.repo.ID,
+		Identifier: "keep-features",
+		Type:       protection.TypeBranch,
+		State:      enum.RuleStateActive,
+		Pattern:    pattern.JSON(),
+		Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+	})
+	if err != nil {
+		t.Fatalf("failed to create rule: %v", err)
+	}
+
+	pr := env.createPullReq(t, "sha1")
+	env.requestDeleteSourceBranch(t, pr)
+
+	if repoUID, deleted := env.mergeDeletesBranch(t, pr); deleted {
+		t.Errorf("expected the protected source branch to be kept, got deleted in %s", repoUID)
+	}
+}
+
+func TestMergeDeleteSourceBranch_Fork(t *testing.T) {
+	env := setupController(t, "merge_delete_source_branch_fork", allowAuthorizer{})
+
+	fork := env.createFork(t)
+	pr := env.createPullReqFrom(t, fork, "sha1")
+
+	// the setting of the fork applies, as the pull request doesn't override it.
+	repoUID, deleted := env.mergeDeletesBranch(t, pr)
+	if !deleted || repoUID != fork.GitUID {
+		t.Errorf("expected the source branch to be deleted in %s, got deleted=%t in %q",
+			fork.GitUID, deleted, repoUID)
+	}
+}
+
+func TestMergeDeleteSourceBranch_NoPermission(t *testing.T) {
+	env := setupController(t, "merge_delete_source_branch_no_permission", viewOnlyAuthorizer{repo: "app-fork"})
+
+	fork := env.createFork(t)
+	pr := env.createPullReqFrom(t, fork, "sha1")
+
+	if repoUID, deleted := env.mergeDeletesBranch(t, pr); deleted {
+		t.Errorf("expected the source branch to be kept without push permission on the fork, got deleted in %s",
+			repoUID)
+	}
+}
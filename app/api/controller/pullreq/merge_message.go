@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/services/mergemessage"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// coAuthorCommitsPageSize is the number of pull request commits listed at once when looking for co-authors.
+const coAuthorCommitsPageSize = 1000
+
+// mergeMessageTemplate returns the commit message template the repository configures for the merge method.
+// Nil is returned if the repository doesn't define one.
+func (c *Controller) mergeMessageTemplate(
+	ctx context.Context,
+	repo *types.Repository,
+	method enum.MergeMethod,
+) (*types.CommitMessageTemplate, error) {
+	templates, err := c.settings.MergeMessageTemplates(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if templates == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	switch method {
+	case enum.MergeMethodMerge:
+		return templates.Merge, nil
+	case enum.MergeMethodSquash:
+		return templates.Squash, nil
+	case enum.MergeMethodRebase:
+		// Rebase doesn't create a commit of its own.
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// renderMergeMessage renders the commit title and message template for the pull request.
+func (c *Controller) renderMergeMessage(
+	ctx context.Context,
+	template *types.CommitMessageTemplate,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	reviewers []*types.PullReqReviewer,
+) (string, string, error) {
+	approvers := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionApproved {
+			approvers = append(approvers, reviewer.Reviewer.DisplayName)
+		}
+	}
+
+	values := map[string]string{
+		mergemessage.VarNumber:       strconv.FormatInt(pr.Number, 10),
+		mergemessage.VarTitle:        pr.Title,
+		mergemessage.VarDescription:  pr.Description,
+		mergemessage.VarSourceBranch: pr.SourceBranch,
+		mergemessage.VarTargetBranch: pr.TargetBranch,
+		mergemessage.VarSourceRepo:   sourceRepo.Path,
+		mergemessage.VarAuthor:       pr.Author.DisplayName,
+		mergemessage.VarAuthorEmail:  pr.Author.Email,
+		mergemessage.VarApprovers:    strings.Join(approvers, ", "),
+	}
+
+	// listing the commits is only worth it if the template needs the co-authors.
+	if mergemessage.Uses(template.Title, mergemessage.VarCoAuthoredBy) ||
+		mergemessage.Uses(template.Message, mergemessage.VarCoAuthoredBy) {
+		coAuthoredBy, err := c.coAuthoredBy(ctx, sourceRepo, pr)
+		if err != nil {
+			return "", "", err
+		}
+		values[mergemessage.VarCoAuthoredBy] = coAuthoredBy
+	}
+
+	return mergemessage.Render(template.Title, values), mergemessage.Render(template.Message, values), nil
+}
+
+// coAuthoredBy returns the "Co-authored-by" trailer lines of the authors of the pull request commits.
+// The author of the pull request isn't listed as a co-author. All commits of the pull request are inspected.
+func (c *Controller) coAuthoredBy(
+	ctx context.Context,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+) (string, error) {
+	var commits []git.Commit
+	for page := int32(1); ; page++ {
+		output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(sourceRepo),
+			GitREF:     pr.SourceSHA,
+			After:      pr.MergeBaseSHA,
+			Page:       page,
+			Limit:      coAuthorCommitsPageSize,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		commits = append(commits, output.Commits...)
+
+		if len(output.Commits) < coAuthorCommitsPageSize {
+			break
+		}
+	}
+
+	seen := map[string]struct{}{
+		strings.ToLower(pr.Author.Email): {},
+	}
+
+	lines := make([]string, 0)
+
+	// commits are listed newest first, the co-authors are listed in the order of their first commit.
+	for i := len(commits) - 1; i >= 0; i-- {
+		identity := commits[i].Author.Identity
+		email := strings.ToLower(identity.Email)
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+
+		lines = append(lines, fmt.Sprintf("Co-authored-by: %s <%s>", identity.Name, identity.Email))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
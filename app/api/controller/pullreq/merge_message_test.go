@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// commitsGit is a fake git implementation that lists pages of the commits, newest first.
+type commitsGit struct {
+	git.Interface
+	commits []git.Commit
+	pages   []int32
+}
+
+func (g *commitsGit) ListCommits(_ context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error) {
+	g.pages = append(g.pages, params.Page)
+
+	start := int(params.Page-1) * int(params.Limit)
+	if start > len(g.commits) {
+		start = len(g.commits)
+	}
+	end := start + int(params.Limit)
+	if end > len(g.commits) {
+		end = len(g.commits)
+	}
+
+	return &git.ListCommitsOutput{Commits: g.commits[start:end]}, nil
+}
+
+func commitBy(name, email string) git.Commit {
+	return git.Commit{Author: git.Signature{Identity: git.Identity{Name: name, Email: email}}}
+}
+
+func mergeMessagePullReq() *types.PullReq {
+	return &types.PullReq{
+		Number:       7,
+		Title:        "Add feature",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		SourceSHA:    "b",
+		MergeBaseSHA: "a",
+		Author:       types.PrincipalInfo{DisplayName: "Alice", Email: "alice@example.com"},
+	}
+}
+
+func TestCoAuthoredBy(t *testing.T) {
+	// more commits than fit on a page, the first co-author only shows up on the last page.
+	commits := make([]git.Commit, 2*coAuthorCommitsPageSize+500)
+	commits[0] = commitBy("Dave", "dave@example.com")
+	for i := 1; i < 2*coAuthorCommitsPageSize; i++ {
+		commits[i] = commitBy("Alice", "ALICE@example.com")
+	}
+	for i := 2 * coAuthorCommitsPageSize; i < len(commits)-1; i++ {
+		commits[i] = commitBy("Bob", "bob@example.com")
+	}
+	commits[len(commits)-1] = commitBy("Carol", "carol@example.com")
+
+	gitFake := &commitsGit{commits: commits}
+	c := &Controller{git: gitFake}
+
+	got, err := c.coAuthoredBy(context.Background(), &types.Repository{GitUID: "app"}, mergeMessagePullReq())
+	if err != nil {
+		t.Fatalf("failed to list co-authors: %v", err)
+	}
+
+	want := "Co-authored-by: Carol <carol@example.com>\n" +
+		"Co-authored-by: Bob <bob@example.com>\n" +
+		"Co-authored-by: Dave <dave@example.com>"
+	if got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	if len(gitFake.pages) != 3 {
+		t.Errorf("expected all 3 pages of commits to be listed, got pages %v", gitFake.pages)
+	}
+}
+
+func TestRenderMergeMessage(t *testing.T) {
+	reviewers := []*types.PullReqReviewer{
+		{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: types.PrincipalInfo{DisplayName: "Bob"}},
+		{ReviewDecision: enum.PullReqReviewDecisionChangeReq, Reviewer: types.PrincipalInfo{DisplayName: "Carol"}},
+		{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: types.PrincipalInfo{DisplayName: "Dave"}},
+	}
+	sourceRepo := &types.Repository{GitUID: "app", Path: "acme/app"}
+
+	tests := []struct {
+		name        string
+		template    types.CommitMessageTemplate
+		description string
+		wantTitle   string
+		wantMessage string
+		wantListed  bool
+	}{
+		{
+			name: "variables",
+			template: types.CommitMessageTemplate{
+				Title:   "{{ title }} (#{{ number }})",
+				Message: "{{ description }}\n\nMerge {{source_repo}}:{{source_branch}} into {{target_branch}}",
+			},
+			description: "Adds the feature.",
+			wantTitle:   "Add feature (#7)",
+			wantMessage: "Adds the feature.\n\nMerge acme/app:feature into main",
+		},
+		{
+			name: "author and approvers",
+			template: types.CommitMessageTemplate{
+				Title:   "{{ title }}",
+				Message: "Author: {{ author }} <{{ author_email }}>\nApproved-by: {{ approvers }}",
+			},
+			wantTitle:   "Add feature",
+			wantMessage: "Author: Alice <alice@example.com>\nApproved-by: Bob, Dave",
+		},
+		{
+			name: "co-authors",
+			template: types.CommitMessageTemplate{
+				Title:   "{{ title }}",
+				Message: "{{ description }}\n\n{{ co_authored_by }}",
+			},
+			wantTitle:   "Add feature",
+			wantMessage: "Co-authored-by: Bob <bob@example.com>",
+			wantListed:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitFake := &commitsGit{commits: []git.Commit{
+				commitBy("Alice", "alice@example.com"),
+				commitBy("Bob", "bob@example.com"),
+			}}
+			c := &Controller{git: gitFake}
+
+			pr := mergeMessagePullReq()
+			pr.Description = test.description
+
+			title, message, err := c.renderMergeMessage(context.Background(), &test.template, sourceRepo, pr,
+				reviewers)
+			if err != nil {
+				t.Fatalf("failed to render merge message: %v", err)
+			}
+
+			if title != test.wantTitle {
+				t.Errorf("title: want %q, got %q", test.wantTitle, title)
+			}
+			if message != test.wantMessage {
+				t.Errorf("message: want %q, got %q", test.wantMessage, message)
+			}
+
+			// the commits are only listed if the template needs the co-authors.
+			if listed := len(gitFake.pages) > 0; listed != test.wantListed {
+				t.Errorf("expected commits listed to be %t, got %t", test.wantListed, listed)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// mergeScheduleMaxDelay is the latest time in the future a pull request can be scheduled for merging.
+const mergeScheduleMaxDelay = 90 * 24 * time.Hour
+
+type MergeScheduleInput struct {
+	// MergeAt is the time (unix millis) at which the pull request is merged.
+	MergeAt int64            `json:"merge_at"`
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+}
+
+func (in *MergeScheduleInput) sanitize(now time.Time) error {
+	if in.MergeAt <= now.UnixMilli() {
+		return usererror.BadRequest("The merge time has to be in the future.")
+	}
+
+	if in.MergeAt > now.Add(mergeScheduleMaxDelay).UnixMilli() {
+		return usererror.BadRequestf("Pull requests can be scheduled for merging at most %d days in advance.",
+			int(mergeScheduleMaxDelay.Hours()/24))
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequest("Rebase doesn't support customizing commit title and message.")
+	}
+
+	return nil
+}
+
+// MergeScheduleFind returns the merge schedule of the pull request.
+func (c *Controller) MergeScheduleFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (*types.MergeSchedule, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	schedule, err := c.mergeScheduleStore.Find(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("The pull request isn't scheduled for merging.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// MergeScheduleSet schedules the approved pull request for merging at the provided time.
+// Only approvals of the current source branch commit count, and the scheduled merge fails
+// if the source branch gets updated before the merge. An existing schedule of the pull request is replaced.
+func (c *Controller) MergeScheduleSet(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *MergeScheduleInput,
+) (*types.MergeSchedule, error) {
+	now := time.Now()
+	if err := in.sanitize(now); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Only open pull requests can be scheduled for merging.")
+	}
+
+	if pr.IsDraft {
+		return nil, usererror.BadRequest("Draft pull requests can't be scheduled for merging.")
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	approved := false
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionApproved && reviewer.SHA == pr.SourceSHA {
+			approved = true
+			break
+		}
+	}
+
+	if !approved {
+		return nil, usererror.BadRequest(
+			"Only pull requests approved at the latest source branch commit can be scheduled for merging.")
+	}
+
+	schedule := &types.MergeSchedule{
+		PullReqID: pr.ID,
+		CreatedBy: session.Principal.ID,
+		Created:   now.UnixMilli(),
+		MergeAt:   in.MergeAt,
+		Method:    in.Method,
+		Title:     in.Title,
+		Message:   in.Message,
+		SourceSHA: pr.SourceSHA,
+	}
+
+	if err = c.mergeScheduleStore.Upsert(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to store merge schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// MergeScheduleCancel removes the merge schedule of the pull request.
+func (c *Controller) MergeScheduleCancel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	deleted, err := c.mergeScheduleStore.Delete(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete merge schedule: %w", err)
+	}
+
+	if !deleted {
+		return usererror.NotFound("The pull request isn't scheduled for merging.")
+	}
+
+	return nil
+}
+
+// MergeScheduled merges the pull request of the merge schedule on behalf of the principal that scheduled it.
+// Only the source branch commit that was approved when the merge was scheduled is merged,
+// and protection rules are verified as for any other merge. In case the pull request can't be merged,
+// the reason is posted as a comment on the pull request. Pull requests that aren't open anymore are ignored.
+func (c *Controller) MergeScheduled(ctx context.Context, schedule *types.MergeSchedule) error {
+	pr, err := c.pullreqStore.Find(ctx, schedule.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	repo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repo: %w", err)
+	}
+
+	principal, err := c.principalStore.Find(ctx, schedule.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal that scheduled the merge: %w", err)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	if pr.SourceSHA != schedule.SourceSHA {
+		return c.commentScheduledMergeFailure(ctx, repo, pr,
+			[]string{"The source branch was updated after the merge was scheduled."})
+	}
+
+	_, violations, err := c.Merge(ctx, session, repo.Path, pr.Number, &MergeInput{
+		Method:    schedule.Method,
+		SourceSHA: schedule.SourceSHA,
+		Title:     schedule.Title,
+		Message:   schedule.Message,
+	})
+
+	var reasons []string
+	switch {
+	case err != nil:
+		reasons = append(reasons, usererror.Translate(ctx, err).Message)
+	case violations != nil:
+		if len(violations.ConflictFiles) > 0 {
+			reasons = append(reasons, "The pull request has merge conflicts.")
+		}
+		for _, reviewer := range violations.ChangesRequestedBy {
+			reasons = append(reasons, fmt.Sprintf("Reviewer %s requested changes.", reviewer.DisplayName))
+		}
+		reasons = append(reasons, violations.LintViolations...)
+		for i := range violations.RuleViolations {
+			if !violations.RuleViolations[i].IsCritical() {
+				continue
+			}
+			for _, violation := range violations.RuleViolations[i].Violations {
+				reasons = append(reasons, violation.Message)
+			}
+		}
+	default:
+		return nil
+	}
+
+	return c.commentScheduledMergeFailure(ctx, repo, pr, reasons)
+}
+
+// commentScheduledMergeFailure posts the reasons of a failed scheduled merge as a comment on the pull request.
+func (c *Controller) commentScheduledMergeFailure(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	reasons []string,
+) error {
+	text := "The scheduled merge of this pull request failed:\n"
+	for _, reason := range reasons {
+		text += "\n- " + reason
+	}
+
+	_, err := c.CommentCreate(ctx, bootstrap.NewSystemServiceSession(), repo.Path, pr.Number,
+		&CommentCreateInput{Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to comment on failed scheduled merge: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMergeScheduleSet(t *testing.T) {
+	env := setupController(t, "merge_schedule_set", allowAuthorizer{})
+	ctx := context.Background()
+
+	in := func() *MergeScheduleInput {
+		return &MergeScheduleInput{
+			MergeAt: time.Now().Add(time.Hour).UnixMilli(),
+			Method:  enum.MergeMethodSquash,
+		}
+	}
+
+	// the approval was given before the source branch got updated.
+	stale := env.createPullReq(t, "b")
+	env.setReview(t, stale, enum.PullReqReviewDecisionApproved, "a")
+
+	_, err := env.ctrl.MergeScheduleSet(ctx, env.session(env.author), env.repo.Path, stale.Number, in())
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for a stale approval, got: %v", err)
+	}
+
+	approved := env.createPullReq(t, "b")
+	env.setReview(t, approved, enum.PullReqReviewDecisionApproved, "b")
+
+	schedule, err := env.ctrl.MergeScheduleSet(ctx, env.session(env.author), env.repo.Path, approved.Number, in())
+	if err != nil {
+		t.Fatalf("failed to schedule merge: %v", err)
+	}
+	if schedule.SourceSHA != "b" {
+		t.Errorf("expected the approved source sha to be stored, got %q", schedule.SourceSHA)
+	}
+
+	stored, err := env.mergeScheduleStore.Find(ctx, approved.ID)
+	if err != nil {
+		t.Fatalf("failed to find merge schedule: %v", err)
+	}
+	if stored.SourceSHA != "b" || stored.CreatedBy != env.author.ID {
+		t.Errorf("unexpected stored merge schedule: %+v", stored)
+	}
+}
+
+func TestMergeScheduled(t *testing.T) {
+	env := setupController(t, "merge_scheduled", allowAuthorizer{})
+	ctx := context.Background()
+
+	schedule := func(pr *types.PullReq, sourceSHA string) *types.MergeSchedule {
+		return &types.MergeSchedule{
+			PullReqID: pr.ID,
+			CreatedBy: env.author.ID,
+			MergeAt:   time.Now().UnixMilli(),
+			Method:    enum.MergeMethodSquash,
+			SourceSHA: sourceSHA,
+		}
+	}
+
+	// the source branch got updated after the merge was scheduled.
+	moved := env.createPullReq(t, "b")
+	env.setReview(t, moved, enum.PullReqReviewDecisionApproved, "a")
+
+	if err := env.ctrl.MergeScheduled(ctx, schedule(moved, "a")); err != nil {
+		t.Fatalf("failed scheduled merge: %v", err)
+	}
+	if len(env.git.merges) != 0 {
+		t.Fatalf("expected no merge of an updated source branch, got %d", len(env.git.merges))
+	}
+
+	activities, err := env.activityStore.List(ctx, moved.ID, &types.PullReqActivityFilter{})
+	if err != nil {
+		t.Fatalf("failed to list activities: %v", err)
+	}
+	if len(activities) != 1 || !strings.Contains(activities[0].Text, "source branch was updated") {
+		t.Fatalf("expected a comment about the updated source branch, got %+v", activities)
+	}
+
+	pr, err := env.pullreqStore.Find(ctx, moved.ID)
+	if err != nil {
+		t.Fatalf("failed to find pull request: %v", err)
+	}
+	if pr.State != enum.PullReqStateOpen {
+		t.Errorf("expected the pull request to stay open, got %s", pr.State)
+	}
+
+	// the approved source branch commit is merged.
+	unchanged := env.createPullReq(t, "b")
+	env.setReview(t, unchanged, enum.PullReqReviewDecisionApproved, "b")
+
+	if err = env.ctrl.MergeScheduled(ctx, schedule(unchanged, "b")); err != nil {
+		t.Fatalf("failed scheduled merge: %v", err)
+	}
+	if len(env.git.merges) != 1 || env.git.merges[0].HeadExpectedSHA != "b" {
+		t.Fatalf("expected a merge of the approved source sha, got %+v", env.git.merges)
+	}
+
+	pr, err = env.pullreqStore.Find(ctx, unchanged.ID)
+	if err != nil {
+		t.Fatalf("failed to find pull request: %v", err)
+	}
+	if pr.State != enum.PullReqStateMerged || pr.MergedBy == nil || *pr.MergedBy != env.author.ID {
+		t.Errorf("expected the pull request to be merged by the author, got state=%s merged_by=%v",
+			pr.State, pr.MergedBy)
+	}
+}
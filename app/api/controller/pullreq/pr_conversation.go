@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ConversationInput struct {
+	Conversation enum.PullReqConversation `json:"conversation"`
+}
+
+func (in *ConversationInput) Validate() error {
+	conversation, ok := in.Conversation.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid value provided for conversation")
+	}
+
+	in.Conversation = conversation
+
+	return nil
+}
+
+// Conversation restricts who can comment on a pull request. Only repository admins can change it.
+func (c *Controller) Conversation(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *ConversationInput,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.Conversation == in.Conversation {
+		return pr, nil
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.Conversation = in.Conversation
+		pr.Edited = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request conversation: %w", err)
+	}
+
+	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
+
+// checkCanComment verifies that the principal is allowed to comment on the pull request
+// given the conversation restrictions of the pull request.
+func (c *Controller) checkCanComment(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+) error {
+	var permission enum.Permission
+	var message string
+
+	switch pr.Conversation {
+	case enum.PullReqConversationCollaborators:
+		permission = enum.PermissionRepoPush
+		message = "Commenting on this pull request is restricted to collaborators."
+	case enum.PullReqConversationLocked:
+		permission = enum.PermissionRepoEdit
+		message = "The conversation on this pull request is locked."
+	case enum.PullReqConversationOpen:
+		return nil
+	default:
+		return nil
+	}
+
+	err := apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return usererror.Forbidden(message)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check comment access: %w", err)
+	}
+
+	return nil
+}
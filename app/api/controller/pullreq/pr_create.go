@@ -39,6 +39,9 @@ type CreateInput struct {
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
+
+	// DeleteSourceBranch overrides the repository setting for deleting the source branch after merge.
+	DeleteSourceBranch *bool `json:"delete_source_branch"`
 }
 
 // Create creates a new pull request.
@@ -99,30 +102,47 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("The source branch doesn't contain any new commits")
 	}
 
-	targetRepo, err = c.repoStore.UpdateOptLock(ctx, targetRepo, func(repo *types.Repository) error {
-		repo.PullReqSeq++
+	var pr *types.PullReq
+
+	// the event is reported within the transaction to store it in the event outbox atomically with the pull request.
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		targetRepo, err = c.repoStore.UpdateOptLock(ctx, targetRepo, func(repo *types.Repository) error {
+			repo.PullReqSeq++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to acquire PullReqSeq number: %w", err)
+		}
+
+		pr = newPullReq(session, targetRepo.PullReqSeq, sourceRepo, targetRepo, in, sourceSHA, mergeBaseSHA)
+
+		err = c.pullreqStore.Create(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("pullreq creation failed: %w", err)
+		}
+
+		c.eventReporter.Created(ctx, &pullreqevents.CreatedPayload{
+			Base:         eventBase(pr, &session.Principal),
+			SourceBranch: in.SourceBranch,
+			TargetBranch: in.TargetBranch,
+			SourceSHA:    sourceSHA,
+		})
+
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire PullReqSeq number: %w", err)
+		return nil, err
 	}
 
-	pr := newPullReq(session, targetRepo.PullReqSeq, sourceRepo, targetRepo, in, sourceSHA, mergeBaseSHA)
-
-	err = c.pullreqStore.Create(ctx, pr)
-	if err != nil {
-		return nil, fmt.Errorf("pullreq creation failed: %w", err)
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
-	c.eventReporter.Created(ctx, &pullreqevents.CreatedPayload{
-		Base:         eventBase(pr, &session.Principal),
-		SourceBranch: in.SourceBranch,
-		TargetBranch: in.TargetBranch,
-		SourceSHA:    sourceSHA,
-	})
+	c.recordMentions(ctx, pr, &session.Principal, nil, "", pr.Description)
 
-	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
-		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	if err = c.pullreqLint.Report(ctx, pr); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to report pull request lint check")
 	}
 
 	return pr, nil
@@ -139,29 +159,31 @@ func newPullReq(
 ) *types.PullReq {
 	now := time.Now().UnixMilli()
 	return &types.PullReq{
-		ID:               0, // the ID will be populated in the data layer
-		Version:          0,
-		Number:           number,
-		CreatedBy:        session.Principal.ID,
-		Created:          now,
-		Updated:          now,
-		Edited:           now,
-		State:            enum.PullReqStateOpen,
-		IsDraft:          in.IsDraft,
-		Title:            in.Title,
-		Description:      in.Description,
-		SourceRepoID:     sourceRepo.ID,
-		SourceBranch:     in.SourceBranch,
-		SourceSHA:        sourceSHA,
-		TargetRepoID:     targetRepo.ID,
-		TargetBranch:     in.TargetBranch,
-		ActivitySeq:      0,
-		MergedBy:         nil,
-		Merged:           nil,
-		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
-		MergeMethod:      nil,
-		MergeBaseSHA:     mergeBaseSHA,
-		Author:           *session.Principal.ToPrincipalInfo(),
-		Merger:           nil,
+		ID:                 0, // the ID will be populated in the data layer
+		Version:            0,
+		Number:             number,
+		CreatedBy:          session.Principal.ID,
+		Created:            now,
+		Updated:            now,
+		Edited:             now,
+		State:              enum.PullReqStateOpen,
+		IsDraft:            in.IsDraft,
+		Title:              in.Title,
+		Description:        in.Description,
+		SourceRepoID:       sourceRepo.ID,
+		SourceBranch:       in.SourceBranch,
+		SourceSHA:          sourceSHA,
+		TargetRepoID:       targetRepo.ID,
+		TargetBranch:       in.TargetBranch,
+		ActivitySeq:        0,
+		MergedBy:           nil,
+		Merged:             nil,
+		MergeCheckStatus:   enum.MergeCheckStatusUnchecked,
+		MergeMethod:        nil,
+		MergeBaseSHA:       mergeBaseSHA,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		Conversation:       enum.PullReqConversationOpen,
+		Author:             *session.Principal.ToPrincipalInfo(),
+		Merger:             nil,
 	}
 }
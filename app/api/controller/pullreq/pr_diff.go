@@ -27,6 +27,7 @@ import (
 )
 
 // RawDiff writes raw git diff to writer w.
+// The diff options only change the presentation of the diff, code comments stay anchored to the file lines.
 func (c *Controller) RawDiff(
 	ctx context.Context,
 	w io.Writer,
@@ -34,6 +35,7 @@ func (c *Controller) RawDiff(
 	repoRef string,
 	pullreqNum int64,
 	setSHAs func(sourceSHA, mergeBaseSHA string),
+	options gittypes.DiffOptions,
 	files ...gittypes.FileDiffRequest,
 ) error {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
@@ -51,10 +53,12 @@ func (c *Controller) RawDiff(
 	}
 
 	return c.git.RawDiff(ctx, w, &git.DiffParams{
-		ReadParams: git.CreateReadParams(repo),
-		BaseRef:    pr.MergeBaseSHA,
-		HeadRef:    pr.SourceSHA,
-		MergeBase:  true,
+		ReadParams:      git.CreateReadParams(repo),
+		BaseRef:         pr.MergeBaseSHA,
+		HeadRef:         pr.SourceSHA,
+		MergeBase:       true,
+		ApplyAttributes: true,
+		Options:         options,
 	}, files...)
 }
 
@@ -65,6 +69,7 @@ func (c *Controller) Diff(
 	pullreqNum int64,
 	setSHAs func(sourceSHA, mergeBaseSHA string),
 	includePatch bool,
+	options gittypes.DiffOptions,
 	files ...gittypes.FileDiffRequest,
 ) (types.Stream[*git.FileDiff], error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
@@ -82,11 +87,13 @@ func (c *Controller) Diff(
 	}
 
 	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
-		ReadParams:   git.CreateReadParams(repo),
-		BaseRef:      pr.MergeBaseSHA,
-		HeadRef:      pr.SourceSHA,
-		MergeBase:    true,
-		IncludePatch: includePatch,
+		ReadParams:      git.CreateReadParams(repo),
+		BaseRef:         pr.MergeBaseSHA,
+		HeadRef:         pr.SourceSHA,
+		MergeBase:       true,
+		IncludePatch:    includePatch,
+		ApplyAttributes: true,
+		Options:         options,
 	}, files...))
 
 	return reader, nil
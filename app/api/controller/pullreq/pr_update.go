@@ -32,6 +32,9 @@ import (
 type UpdateInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
+
+	// DeleteSourceBranch overrides the repository setting for deleting the source branch after merge.
+	DeleteSourceBranch *bool `json:"delete_source_branch"`
 }
 
 func (in *UpdateInput) Check() error {
@@ -79,16 +82,23 @@ func (c *Controller) Update(ctx context.Context,
 		}
 	}
 
-	if pr.Title == in.Title && pr.Description == in.Description {
+	deleteSourceBranchChanged := in.DeleteSourceBranch != nil &&
+		(pr.DeleteSourceBranch == nil || *pr.DeleteSourceBranch != *in.DeleteSourceBranch)
+
+	if pr.Title == in.Title && pr.Description == in.Description && !deleteSourceBranchChanged {
 		return pr, nil
 	}
 
 	needToWriteActivity := in.Title != pr.Title
 	oldTitle := pr.Title
+	oldDescription := pr.Description
 
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.Title = in.Title
 		pr.Description = in.Description
+		if in.DeleteSourceBranch != nil {
+			pr.DeleteSourceBranch = in.DeleteSourceBranch
+		}
 		pr.Edited = time.Now().UnixMilli()
 		if needToWriteActivity {
 			pr.ActivitySeq++
@@ -114,5 +124,16 @@ func (c *Controller) Update(ctx context.Context,
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
+	if pr.Description != oldDescription {
+		c.recordMentions(ctx, pr, &session.Principal, nil, oldDescription, pr.Description)
+	}
+
+	if pr.Title != oldTitle || pr.Description != oldDescription {
+		if err = c.pullreqLint.Report(ctx, pr); err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to report pull request lint check")
+		}
+	}
+
 	return pr, nil
 }
@@ -149,6 +149,12 @@ func (c *Controller) updateReviewer(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	// a change request stays in effect until the reviewer approves the pull request or the review gets dismissed.
+	if reviewer != nil && reviewer.ReviewDecision == enum.PullReqReviewDecisionChangeReq &&
+		review.Decision == enum.PullReqReviewDecisionReviewed {
+		return reviewer, nil
+	}
+
 	if reviewer != nil {
 		reviewer.LatestReviewID = &review.ID
 		reviewer.ReviewDecision = review.Decision
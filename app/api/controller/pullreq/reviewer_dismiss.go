@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// reviewerDismissMaxReasonLength is the maximum length of the reason of a review dismissal.
+	reviewerDismissMaxReasonLength = 1024
+
+	// MethodReviewDismiss is the audit log method recorded when a review gets dismissed.
+	MethodReviewDismiss = "REVIEW_DISMISS"
+)
+
+type ReviewerDismissInput struct {
+	Reason string `json:"reason"`
+}
+
+func (in *ReviewerDismissInput) sanitize() error {
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	if in.Reason == "" {
+		return usererror.BadRequest("A reason is required to dismiss a review.")
+	}
+
+	if len(in.Reason) > reviewerDismissMaxReasonLength {
+		return usererror.BadRequestf("The reason can be at most %d characters long.", reviewerDismissMaxReasonLength)
+	}
+
+	return nil
+}
+
+// ReviewerDismiss dismisses the change request of a reviewer,
+// so it doesn't block the merge of the pull request anymore. The dismissal, together with its reason,
+// is recorded in the pull request activity and in the audit log.
+func (c *Controller) ReviewerDismiss(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	reviewerID int64,
+	in *ReviewerDismissInput,
+	accessor types.AuditAccessor,
+) (*types.PullReqReviewer, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	// Dismissing reviews is reserved to repository owners. There's no dedicated repository admin permission,
+	// repo edit is only granted to space owners and it's what identifies repository owners (see apiauth.IsRepoOwner).
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Reviews can only be dismissed on open pull requests.")
+	}
+
+	reviewer, err := c.reviewerStore.Find(ctx, pr.ID, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviewer: %w", err)
+	}
+
+	if reviewer.ReviewDecision != enum.PullReqReviewDecisionChangeReq {
+		return nil, usererror.BadRequest("Only reviews requesting changes can be dismissed.")
+	}
+
+	reviewer.ReviewDecision = enum.PullReqReviewDecisionReviewed
+
+	if err = c.reviewerStore.Update(ctx, reviewer); err != nil {
+		return nil, fmt.Errorf("failed to update reviewer: %w", err)
+	}
+
+	err = func() error {
+		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		payload := &types.PullRequestActivityPayloadReviewDismiss{
+			ReviewerID: reviewer.PrincipalID,
+			CommitSHA:  reviewer.SHA,
+			Reason:     in.Reason,
+		}
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload)
+		return err
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after review dismissal")
+	}
+
+	c.recordReviewDismissal(ctx, session, pr, reviewer, in.Reason, accessor)
+
+	return reviewer, nil
+}
+
+// recordReviewDismissal records the review dismissal in the audit log, including the reason.
+// Failures are only logged because the review got dismissed already.
+func (c *Controller) recordReviewDismissal(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	reviewer *types.PullReqReviewer,
+	reason string,
+	accessor types.AuditAccessor,
+) {
+	if !c.auditEnabled {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("reason", reason)
+	query.Set("commit_sha", reviewer.SHA)
+
+	err := c.auditLogStore.Create(ctx,
+		types.NewAuditLog(accessor, session.Principal.ID, MethodReviewDismiss, query, time.Now().UnixMilli()))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq_id", pr.ID).
+			Int64("reviewer_id", reviewer.PrincipalID).
+			Msg("failed to record review dismissal in the audit log")
+	}
+}
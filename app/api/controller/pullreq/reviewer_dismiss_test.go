@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ownerAuthorizer grants every permission to the repository owner,
+// everyone else gets all permissions except repo edit.
+type ownerAuthorizer struct {
+	owner string
+}
+
+func (a ownerAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return session.Principal.UID == a.owner || permission != enum.PermissionRepoEdit, nil
+}
+
+func (a ownerAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		ok, err := a.Check(ctx, session, &permissionChecks[i].Scope, &permissionChecks[i].Resource,
+			permissionChecks[i].Permission)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// mergeBlocked attempts to merge the pull request and returns whether change requests blocked the merge.
+func (e *testEnv) mergeBlocked(t *testing.T, pr *types.PullReq) bool {
+	t.Helper()
+
+	_, violations, err := e.ctrl.Merge(context.Background(), e.session(e.author), e.repo.Path, pr.Number,
+		&MergeInput{Method: enum.MergeMethodSquash, SourceSHA: pr.SourceSHA})
+	if err != nil {
+		t.Fatalf("failed to merge pull request: %v", err)
+	}
+
+	if violations == nil {
+		return false
+	}
+
+	if len(violations.ChangesRequestedBy) != 1 || violations.ChangesRequestedBy[0].ID != e.reviewer.ID {
+		t.Fatalf("expected the merge to be blocked by the reviewer, got %+v", violations)
+	}
+
+	return true
+}
+
+// review submits the review decision of the reviewer for the source branch commit of the pull request.
+func (e *testEnv) review(t *testing.T, pr *types.PullReq, decision enum.PullReqReviewDecision) {
+	t.Helper()
+
+	_, err := e.ctrl.ReviewSubmit(context.Background(), e.session(e.reviewer), e.repo.Path, pr.Number,
+		&ReviewSubmitInput{CommitSHA: pr.SourceSHA, Decision: decision})
+	if err != nil {
+		t.Fatalf("failed to submit %s review: %v", decision, err)
+	}
+}
+
+func TestChangeRequestBlocksMerge(t *testing.T) {
+	env := setupController(t, "change_request_merge", ownerAuthorizer{owner: "author"})
+
+	pr := env.createPullReq(t, "a")
+	env.review(t, pr, enum.PullReqReviewDecisionChangeReq)
+
+	if !env.mergeBlocked(t, pr) {
+		t.Fatal("expected the change request to block the merge")
+	}
+
+	// a plain review doesn't lift the change request.
+	env.review(t, pr, enum.PullReqReviewDecisionReviewed)
+	if !env.mergeBlocked(t, pr) {
+		t.Fatal("expected the change request to block the merge after a review without approval")
+	}
+
+	// the approval of the reviewer does.
+	env.review(t, pr, enum.PullReqReviewDecisionApproved)
+	if env.mergeBlocked(t, pr) {
+		t.Fatal("expected the approval to unblock the merge")
+	}
+	if len(env.git.merges) != 1 {
+		t.Errorf("expected the pull request to be merged, got %d merges", len(env.git.merges))
+	}
+}
+
+func TestReviewerDismiss(t *testing.T) {
+	env := setupController(t, "reviewer_dismiss", ownerAuthorizer{owner: "author"})
+	ctx := context.Background()
+
+	pr := env.createPullReq(t, "a")
+	env.review(t, pr, enum.PullReqReviewDecisionChangeReq)
+
+	in := &ReviewerDismissInput{Reason: "addressed offline"}
+	accessor := types.AuditAccessor{
+		RequestID: "request", Path: "/api/v1/repos/space/repo/+/pullreq/1/reviewers/2/dismiss",
+		RemoteAddr: "127.0.0.1", UserAgent: "test"}
+
+	// only repository owners can dismiss reviews.
+	_, err := env.ctrl.ReviewerDismiss(ctx, env.session(env.reviewer), env.repo.Path, pr.Number,
+		env.reviewer.ID, in, accessor)
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Fatalf("expected the dismissal by a non-owner to be forbidden, got: %v", err)
+	}
+
+	_, err = env.ctrl.ReviewerDismiss(ctx, env.session(env.author), env.repo.Path, pr.Number,
+		env.reviewer.ID, &ReviewerDismissInput{Reason: " "}, accessor)
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for a dismissal without reason, got: %v", err)
+	}
+
+	reviewer, err := env.ctrl.ReviewerDismiss(ctx, env.session(env.author), env.repo.Path, pr.Number,
+		env.reviewer.ID, in, accessor)
+	if err != nil {
+		t.Fatalf("failed to dismiss review: %v", err)
+	}
+	if reviewer.ReviewDecision != enum.PullReqReviewDecisionReviewed {
+		t.Errorf("expected the dismissed review to be reviewed, got %s", reviewer.ReviewDecision)
+	}
+
+	// the dismissal is recorded in the audit log, including its reason.
+	auditLogs, err := env.auditLogStore.List(ctx, &types.AuditLogFilter{PrincipalID: env.author.ID})
+	if err != nil {
+		t.Fatalf("failed to list audit logs: %v", err)
+	}
+	if len(auditLogs) != 1 || auditLogs[0].Method != MethodReviewDismiss ||
+		auditLogs[0].RequestID != accessor.RequestID || auditLogs[0].Path != accessor.Path {
+		t.Fatalf("expected the dismissal in the audit log, got %+v", auditLogs)
+	}
+	query, err := url.ParseQuery(auditLogs[0].Query)
+	if err != nil || query.Get("reason") != in.Reason || query.Get("commit_sha") != "a" {
+		t.Errorf("unexpected audit log query %q: %v", auditLogs[0].Query, err)
+	}
+
+	// the dismissed review doesn't block the merge anymore.
+	if env.mergeBlocked(t, pr) {
+		t.Fatal("expected the dismissal to unblock the merge")
+	}
+
+	// reviews of merged pull requests can't be dismissed.
+	_, err = env.ctrl.ReviewerDismiss(ctx, env.session(env.author), env.repo.Path, pr.Number,
+		env.reviewer.ID, in, accessor)
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for dismissing a merged pull request, got: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// reviewerSnoozeMaxDuration is the longest period for which review reminders can be snoozed.
+const reviewerSnoozeMaxDuration = 90 * 24 * time.Hour
+
+type ReviewerSnoozeInput struct {
+	// Until is the time (unix millis) until which no review reminders are sent, zero resumes the reminders.
+	Until int64 `json:"until"`
+}
+
+// ReviewerSnooze suppresses the review reminders of the pull request for the reviewer until the provided time.
+// Reviewers can only snooze their own reminders.
+func (c *Controller) ReviewerSnooze(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	reviewerID int64,
+	in *ReviewerSnoozeInput,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if session.Principal.ID != reviewerID {
+		return usererror.Forbidden("Only the reviewer can snooze review reminders.")
+	}
+
+	now := time.Now()
+	if in.Until < 0 || in.Until > now.Add(reviewerSnoozeMaxDuration).UnixMilli() {
+		return usererror.BadRequestf("Review reminders can be snoozed for at most %d days.",
+			int(reviewerSnoozeMaxDuration.Hours()/24))
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if _, err = c.reviewerStore.Find(ctx, pr.ID, reviewerID); err != nil {
+		return fmt.Errorf("failed to find reviewer: %w", err)
+	}
+
+	if err = c.reviewReminderStore.Snooze(ctx, pr.ID, reviewerID, in.Until); err != nil {
+		return fmt.Errorf("failed to snooze review reminders: %w", err)
+	}
+
+	return nil
+}
@@ -19,14 +19,19 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/mention"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqlint"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -38,24 +43,30 @@ var WireSet = wire.NewSet(
 
 func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer authz.Authorizer,
 	pullReqStore store.PullReqStore, pullReqActivityStore store.PullReqActivityStore,
-	codeCommentsView store.CodeCommentView,
+	activityEditStore store.PullReqActivityEditStore, codeCommentsView store.CodeCommentView,
 	pullReqReviewStore store.PullReqReviewStore, pullReqReviewerStore store.PullReqReviewerStore,
+	reviewReminderStore store.ReviewReminderStore, mergeScheduleStore store.MergeScheduleStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore,
 	fileViewStore store.PullReqFileViewStore, membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service,
+	codeOwners *codeowners.Service, realtime *realtime.Service, mentions *mention.Service,
+	pullreqLint *pullreqlint.Service, settings *settings.Service,
+	auditLogStore store.AuditLogStore, config *types.Config,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
-		codeCommentsView,
+		activityEditStore, codeCommentsView,
 		pullReqReviewStore, pullReqReviewerStore,
+		reviewReminderStore, mergeScheduleStore,
 		repoStore, principalStore,
 		fileViewStore, membershipStore,
 		checkStore,
 		rpcClient, eventReporter,
 		mtxManager, codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners)
+		pullreqService, ruleManager, sseStreamer, codeOwners, realtime, mentions,
+		pullreqLint, settings,
+		auditLogStore, config.Audit.Enabled)
 }
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListArtifacts lists the artifacts (images or packages) of an artifact registry.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	filter types.ListQueryFilter,
+) ([]*types.RegistryArtifact, int64, error) {
+	registry, err := c.getRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.artifactStore.Count(ctx, registry.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count artifacts: %w", err)
+	}
+
+	artifacts, err := c.artifactStore.List(ctx, registry.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, count, nil
+}
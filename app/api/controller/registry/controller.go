@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/registries"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errDigestMismatch = errors.New("content doesn't match the digest")
+
+type Controller struct {
+	config        *types.Config
+	authorizer    authz.Authorizer
+	spaceStore    store.SpaceStore
+	registryStore store.RegistryStore
+	artifactStore store.RegistryArtifactStore
+	versionStore  store.RegistryVersionStore
+	blobMetaStore store.RegistryBlobStore
+	uploadStore   store.RegistryUploadStore
+	blobStore     blob.Store
+}
+
+func NewController(
+	config *types.Config,
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	registryStore store.RegistryStore,
+	artifactStore store.RegistryArtifactStore,
+	versionStore store.RegistryVersionStore,
+	blobMetaStore store.RegistryBlobStore,
+	uploadStore store.RegistryUploadStore,
+	blobStore blob.Store,
+) *Controller {
+	return &Controller{
+		config:        config,
+		authorizer:    authorizer,
+		spaceStore:    spaceStore,
+		registryStore: registryStore,
+		artifactStore: artifactStore,
+		versionStore:  versionStore,
+		blobMetaStore: blobMetaStore,
+		uploadStore:   uploadStore,
+		blobStore:     blobStore,
+	}
+}
+
+// getSpaceCheckAccess fetches the space and checks if the current user
+// has the permission for registries in the space.
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	err = apiauth.CheckSpaceScope(ctx, c.authorizer, session, space, enum.ResourceTypeRegistry, permission,
+		permission == enum.PermissionRegistryView)
+	if err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return space, nil
+}
+
+// getRegistryCheckAccess fetches the registry and checks if the current user has the permission for it.
+// Registries of public spaces can be read by anyone.
+func (c *Controller) getRegistryCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.Registry, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	registry, err := c.registryStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry: %w", err)
+	}
+
+	err = apiauth.CheckRegistry(ctx, c.authorizer, session, space, registry.Identifier, permission,
+		permission == enum.PermissionRegistryView)
+	if err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return registry, nil
+}
+
+// findOrCreateArtifact returns the artifact of the registry with the provided name, it's created if missing.
+func (c *Controller) findOrCreateArtifact(
+	ctx context.Context,
+	registryID int64,
+	name string,
+) (*types.RegistryArtifact, error) {
+	artifact, err := c.artifactStore.FindByName(ctx, registryID, name)
+	if err == nil {
+		return artifact, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	artifact = &types.RegistryArtifact{
+		RegistryID: registryID,
+		Name:       name,
+		Created:    now,
+		Updated:    now,
+	}
+
+	err = c.artifactStore.Create(ctx, artifact)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// created concurrently by another push
+		return c.artifactStore.FindByName(ctx, registryID, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// storeBlob stores the content as a blob of the registry and returns it.
+// The content is buffered in a temporary file to verify the digest before it's stored.
+// If the expected digest is empty the content isn't verified.
+func (c *Controller) storeBlob(
+	ctx context.Context,
+	registryID int64,
+	content io.Reader,
+	expectedDigest string,
+	mediaType string,
+) (*types.RegistryBlob, error) {
+	file, err := os.CreateTemp("", "gitness-registry-blob-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+		if rErr := os.Remove(file.Name()); rErr != nil {
+			log.Ctx(ctx).Warn().Err(rErr).Msgf("failed to remove temporary file %s", file.Name())
+		}
+	}()
+
+	digester := registries.NewDigester()
+	size, err := io.Copy(io.MultiWriter(file, digester), content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer blob content: %w", err)
+	}
+
+	digest := registries.Digest(digester)
+	if expectedDigest != "" && digest != expectedDigest {
+		return nil, errDigestMismatch
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind temporary file: %w", err)
+	}
+
+	return c.putBlob(ctx, registryID, digest, mediaType, size, file)
+}
+
+// putBlob stores verified content as a blob of the registry, unless the registry already has it.
+func (c *Controller) putBlob(
+	ctx context.Context,
+	registryID int64,
+	digest string,
+	mediaType string,
+	size int64,
+	content io.Reader,
+) (*types.RegistryBlob, error) {
+	existing, err := c.blobMetaStore.Find(ctx, registryID, digest)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find blob: %w", err)
+	}
+
+	if err = c.blobStore.Upload(ctx, content, registries.BlobPath(registryID, digest)); err != nil {
+		return nil, fmt.Errorf("failed to upload blob: %w", err)
+	}
+
+	b := &types.RegistryBlob{
+		RegistryID: registryID,
+		Digest:     digest,
+		MediaType:  mediaType,
+		Size:       size,
+		Created:    time.Now().UnixMilli(),
+	}
+
+	err = c.blobMetaStore.Create(ctx, b)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// stored concurrently by another push, the content is the same.
+		return c.blobMetaStore.Find(ctx, registryID, digest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob: %w", err)
+	}
+
+	return b, nil
+}
+
+// openBlob returns a signed URL for the blob if the blob store supports it, otherwise a reader of its content.
+func (c *Controller) openBlob(ctx context.Context, registryID int64, digest string) (string, io.ReadCloser, error) {
+	blobPath := registries.BlobPath(registryID, digest)
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, blobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, blobPath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return "", nil, usererror.NotFound("Blob content not found")
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download blob: %w", err)
+	}
+
+	return "", file, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Type        enum.RegistryType `json:"type"`
+	// MaxVersions is the number of most recent versions kept per artifact, 0 keeps all versions.
+	MaxVersions int64 `json:"max_versions"`
+	// MaxAge is the time (in milliseconds) after which versions are removed, 0 keeps versions forever.
+	MaxAge int64 `json:"max_age"`
+}
+
+func (in *CreateInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	registryType, ok := in.Type.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Registry type must be one of %v.", enum.RegistryType("").Enum())
+	}
+	in.Type = registryType
+
+	return checkRetention(in.MaxVersions, in.MaxAge)
+}
+
+func checkRetention(maxVersions, maxAge int64) error {
+	if maxVersions < 0 {
+		return usererror.BadRequest("The number of versions to keep can't be negative.")
+	}
+	if maxAge < 0 {
+		return usererror.BadRequest("The maximum age of versions can't be negative.")
+	}
+
+	return nil
+}
+
+// Create creates a new artifact registry in the space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.Registry, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionRegistryEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	registry := &types.Registry{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		MaxVersions: in.MaxVersions,
+		MaxAge:      in.MaxAge,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.registryStore.Create(ctx, registry); err != nil {
+		return nil, fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	return registry, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/registries"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delete deletes an artifact registry with all its artifacts.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	registry, err := c.getRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryDelete)
+	if err != nil {
+		return err
+	}
+
+	blobs, err := c.blobMetaStore.ListCreatedBefore(ctx, registry.ID, math.MaxInt64)
+	if err != nil {
+		return fmt.Errorf("failed to list blobs of registry: %w", err)
+	}
+
+	if err = c.registryStore.Delete(ctx, registry.ID); err != nil {
+		return fmt.Errorf("failed to delete registry: %w", err)
+	}
+
+	// the registry is gone, failing to remove its content only leaves unused files behind.
+	for _, b := range blobs {
+		if err = c.blobStore.Delete(ctx, registries.BlobPath(registry.ID, b.Digest)); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("registry.id", registry.ID).
+				Str("blob.digest", b.Digest).
+				Msg("failed to delete content of deleted registry")
+		}
+	}
+
+	return nil
+}
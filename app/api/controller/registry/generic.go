@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	genericNameMaxLength    = 255
+	genericDefaultMediaType = "application/octet-stream"
+)
+
+var genericNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._+-]*$`)
+
+func checkGenericName(kind, name string) error {
+	if len(name) > genericNameMaxLength || !genericNameRegex.MatchString(name) {
+		return usererror.BadRequestf("%s has to start with a letter or digit, can contain letters, digits, "+
+			"'.', '_', '+' and '-' and can't be longer than %d characters.", kind, genericNameMaxLength)
+	}
+
+	return nil
+}
+
+func checkGenericFile(packageName, version, fileName string) error {
+	if err := checkGenericName("Package name", packageName); err != nil {
+		return err
+	}
+	if err := checkGenericName("Version", version); err != nil {
+		return err
+	}
+	return checkGenericName("File name", fileName)
+}
+
+// getGenericRegistryCheckAccess fetches the registry, verifies it hosts generic packages and checks access.
+func (c *Controller) getGenericRegistryCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.Registry, error) {
+	registry, err := c.getRegistryCheckAccess(ctx, session, spaceRef, identifier, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	if registry.Type != enum.RegistryTypeGeneric {
+		return nil, usererror.BadRequest("The registry doesn't host generic packages.")
+	}
+
+	return registry, nil
+}
+
+// UploadGenericFile uploads a file of a generic package version.
+// Files are immutable, an existing file has to be deleted before it can be uploaded again.
+func (c *Controller) UploadGenericFile(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	packageName string,
+	version string,
+	fileName string,
+	mediaType string,
+	content io.Reader,
+) (*types.RegistryVersion, error) {
+	registry, err := c.getGenericRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryPush)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkGenericFile(packageName, version, fileName); err != nil {
+		return nil, err
+	}
+
+	if mediaType == "" {
+		mediaType = genericDefaultMediaType
+	}
+
+	artifact, err := c.findOrCreateArtifact(ctx, registry.ID, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.versionStore.Find(ctx, artifact.ID, version, fileName)
+	if err == nil {
+		return nil, usererror.Conflict("The file already exists in the package version.")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find package file: %w", err)
+	}
+
+	b, err := c.storeBlob(ctx, registry.ID, content, "", mediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	file := &types.RegistryVersion{
+		ArtifactID: artifact.ID,
+		Name:       version,
+		FileName:   fileName,
+		Digest:     b.Digest,
+		MediaType:  mediaType,
+		Size:       b.Size,
+		CreatedBy:  session.Principal.ID,
+		Created:    now,
+		Updated:    now,
+	}
+
+	err = c.versionStore.Create(ctx, file)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("The file already exists in the package version.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package file: %w", err)
+	}
+
+	if err = c.artifactStore.Touch(ctx, artifact.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to update package: %w", err)
+	}
+
+	return file, nil
+}
+
+// DownloadGenericFile returns a file of a generic package version.
+// A signed URL is returned instead of the content if the blob store supports it.
+func (c *Controller) DownloadGenericFile(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	packageName string,
+	version string,
+	fileName string,
+) (*types.RegistryVersion, string, io.ReadCloser, error) {
+	registry, err := c.getGenericRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	file, err := c.findGenericFile(ctx, registry, packageName, version, fileName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	signedURL, content, err := c.openBlob(ctx, registry.ID, file.Digest)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return file, signedURL, content, nil
+}
+
+// DeleteGenericFile deletes a file of a generic package version.
+func (c *Controller) DeleteGenericFile(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	packageName string,
+	version string,
+	fileName string,
+) error {
+	registry, err := c.getGenericRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryDelete)
+	if err != nil {
+		return err
+	}
+
+	file, err := c.findGenericFile(ctx, registry, packageName, version, fileName)
+	if err != nil {
+		return err
+	}
+
+	if err = c.versionStore.Delete(ctx, file.ID); err != nil {
+		return fmt.Errorf("failed to delete package file: %w", err)
+	}
+
+	if _, err = c.artifactStore.DeleteEmpty(ctx, registry.ID); err != nil {
+		return fmt.Errorf("failed to delete empty packages: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) findGenericFile(
+	ctx context.Context,
+	registry *types.Registry,
+	packageName string,
+	version string,
+	fileName string,
+) (*types.RegistryVersion, error) {
+	artifact, err := c.artifactStore.FindByName(ctx, registry.ID, packageName)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Package not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find package: %w", err)
+	}
+
+	file, err := c.versionStore.Find(ctx, artifact.ID, version, fileName)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Package file not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find package file: %w", err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the artifact registries of the space.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.RegistryFilter,
+) ([]*types.Registry, int64, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Type != "" {
+		if _, ok := filter.Type.Sanitize(); !ok {
+			return nil, 0, usererror.BadRequestf("Registry type must be one of %v.", enum.RegistryType("").Enum())
+		}
+	}
+
+	count, err := c.registryStore.Count(ctx, space.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count registries: %w", err)
+	}
+
+	registries, err := c.registryStore.List(ctx, space.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	return registries, count, nil
+}
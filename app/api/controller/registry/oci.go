@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// OCI distribution API error codes.
+const (
+	OCICodeBlobUnknown         = "BLOB_UNKNOWN"
+	OCICodeBlobUploadInvalid   = "BLOB_UPLOAD_INVALID"
+	OCICodeBlobUploadUnknown   = "BLOB_UPLOAD_UNKNOWN"
+	OCICodeDigestInvalid       = "DIGEST_INVALID"
+	OCICodeManifestBlobUnknown = "MANIFEST_BLOB_UNKNOWN"
+	OCICodeManifestInvalid     = "MANIFEST_INVALID"
+	OCICodeManifestUnknown     = "MANIFEST_UNKNOWN"
+	OCICodeNameInvalid         = "NAME_INVALID"
+	OCICodeNameUnknown         = "NAME_UNKNOWN"
+	OCICodeSizeInvalid         = "SIZE_INVALID"
+	OCICodeUnauthorized        = "UNAUTHORIZED"
+	OCICodeDenied              = "DENIED"
+	OCICodeUnsupported         = "UNSUPPORTED"
+)
+
+// OCIError is an error of the OCI distribution API.
+type OCIError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *OCIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func newOCIError(status int, code string, format string, args ...any) *OCIError {
+	return &OCIError{
+		Status:  status,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+var (
+	// imageNameRegex matches the path components of image names as defined by the distribution spec.
+	imageNameRegex = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*(/[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*)*$`)
+	tagRegex       = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+)
+
+// resolveImage resolves the name used in the OCI distribution API to the registry and the image name.
+// The name has the form "<space path>/<registry identifier>/<image name>" where the image name
+// can contain slashes itself, hence the deepest space with a matching docker registry is used.
+func (c *Controller) resolveImage(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	permission enum.Permission,
+) (*types.Registry, string, error) {
+	segments := strings.Split(name, "/")
+
+	for i := len(segments) - 2; i >= 1; i-- {
+		spacePath := strings.Join(segments[:i], "/")
+		identifier := segments[i]
+		image := strings.Join(segments[i+1:], "/")
+
+		space, err := c.spaceStore.FindByRef(ctx, spacePath)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find space: %w", err)
+		}
+
+		registry, err := c.registryStore.FindByIdentifier(ctx, space.ID, identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find registry: %w", err)
+		}
+
+		if registry.Type != enum.RegistryTypeDocker {
+			continue
+		}
+
+		err = apiauth.CheckRegistry(ctx, c.authorizer, session, space, registry.Identifier, permission,
+			permission == enum.PermissionRegistryView)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if !imageNameRegex.MatchString(image) {
+			return nil, "", newOCIError(http.StatusBadRequest, OCICodeNameInvalid, "invalid image name %q", image)
+		}
+
+		return registry, image, nil
+	}
+
+	return nil, "", newOCIError(http.StatusNotFound, OCICodeNameUnknown, "repository %q not found", name)
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/registries"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindBlob returns the blob of an image repository without its content.
+func (c *Controller) FindBlob(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	digest string,
+) (*types.RegistryBlob, error) {
+	registry, _, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findBlob(ctx, registry, digest)
+}
+
+// GetBlob returns the blob of an image repository with either a signed URL or a reader of its content.
+func (c *Controller) GetBlob(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	digest string,
+) (*types.RegistryBlob, string, io.ReadCloser, error) {
+	registry, _, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	b, err := c.findBlob(ctx, registry, digest)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	signedURL, content, err := c.openBlob(ctx, registry.ID, b.Digest)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return b, signedURL, content, nil
+}
+
+// DeleteBlob isn't supported, blobs are removed by the garbage collection once they are no longer referenced.
+func (c *Controller) DeleteBlob(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	_ string,
+) error {
+	if _, _, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryDelete); err != nil {
+		return err
+	}
+
+	return newOCIError(http.StatusMethodNotAllowed, OCICodeUnsupported,
+		"blobs are removed automatically once they are no longer referenced")
+}
+
+func (c *Controller) findBlob(
+	ctx context.Context,
+	registry *types.Registry,
+	digest string,
+) (*types.RegistryBlob, error) {
+	if !registries.ValidDigest(digest) {
+		return nil, newOCIError(http.StatusBadRequest, OCICodeDigestInvalid, "invalid digest %q", digest)
+	}
+
+	b, err := c.blobMetaStore.Find(ctx, registry.ID, digest)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, newOCIError(http.StatusNotFound, OCICodeBlobUnknown, "blob %s not found", digest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blob: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/registries"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindManifest returns the manifest blob of an image by tag or digest without its content.
+func (c *Controller) FindManifest(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	reference string,
+) (*types.RegistryBlob, error) {
+	registry, image, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findManifest(ctx, registry, image, reference)
+}
+
+// GetManifest returns the manifest of an image by tag or digest.
+func (c *Controller) GetManifest(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	reference string,
+) (*types.RegistryBlob, io.ReadCloser, error) {
+	registry, image, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	manifest, err := c.findManifest(ctx, registry, image, reference)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := c.blobStore.Download(ctx, registries.BlobPath(registry.ID, manifest.Digest))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download manifest: %w", err)
+	}
+
+	return manifest, content, nil
+}
+
+// PutManifest stores the manifest of an image and tags it unless the reference is a digest.
+// All blobs and manifests referenced by the manifest have to exist in the registry.
+func (c *Controller) PutManifest(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	reference string,
+	mediaType string,
+	content io.Reader,
+) (*types.RegistryBlob, error) {
+	registry, image, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryPush)
+	if err != nil {
+		return nil, err
+	}
+
+	isDigest := registries.ValidDigest(reference)
+	if !isDigest && !tagRegex.MatchString(reference) {
+		return nil, newOCIError(http.StatusBadRequest, OCICodeManifestInvalid, "invalid tag %q", reference)
+	}
+
+	maxSize := c.config.Registry.MaxManifestSize
+	raw, err := io.ReadAll(io.LimitReader(content, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if int64(len(raw)) > maxSize {
+		return nil, newOCIError(http.StatusRequestEntityTooLarge, OCICodeSizeInvalid,
+			"manifest exceeds the maximum size of %d bytes", maxSize)
+	}
+
+	manifest, err := registries.ParseManifest(mediaType, raw)
+	if err != nil {
+		return nil, newOCIError(http.StatusBadRequest, OCICodeManifestInvalid, "%s", err.Error())
+	}
+
+	digester := registries.NewDigester()
+	_, _ = digester.Write(raw)
+	digest := registries.Digest(digester)
+	if isDigest && digest != reference {
+		return nil, newOCIError(http.StatusBadRequest, OCICodeDigestInvalid,
+			"manifest doesn't match digest %s", reference)
+	}
+
+	for _, ref := range manifest.References() {
+		_, err = c.blobMetaStore.Find(ctx, registry.ID, ref)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, newOCIError(http.StatusBadRequest, OCICodeManifestBlobUnknown,
+				"referenced blob %s not found", ref)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find referenced blob: %w", err)
+		}
+	}
+
+	b, err := c.putBlob(ctx, registry.ID, digest, manifest.MediaType, int64(len(raw)), bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.blobMetaStore.ReplaceReferences(ctx, registry.ID, digest, manifest.References()); err != nil {
+		return nil, fmt.Errorf("failed to store manifest references: %w", err)
+	}
+
+	artifact, err := c.findOrCreateArtifact(ctx, registry.ID, image)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDigest {
+		if err = c.tagManifest(ctx, session, artifact, reference, b); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// DeleteManifest deletes a tag, or all tags of the image pointing at the manifest if the reference is a digest.
+// The content is removed by the garbage collection once it's no longer referenced.
+func (c *Controller) DeleteManifest(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	reference string,
+) error {
+	registry, image, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryDelete)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := c.findImage(ctx, registry, image)
+	if err != nil {
+		return err
+	}
+
+	var tags []*types.RegistryVersion
+	if registries.ValidDigest(reference) {
+		tags, err = c.versionStore.ListByDigest(ctx, artifact.ID, reference)
+		if err != nil {
+			return fmt.Errorf("failed to list tags of manifest: %w", err)
+		}
+	} else {
+		var tag *types.RegistryVersion
+		tag, err = c.versionStore.Find(ctx, artifact.ID, reference, "")
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find tag: %w", err)
+		}
+		if tag != nil {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == 0 {
+		return newOCIError(http.StatusNotFound, OCICodeManifestUnknown, "manifest %s not found", reference)
+	}
+
+	for _, tag := range tags {
+		if err = c.versionStore.Delete(ctx, tag.ID); err != nil {
+			return fmt.Errorf("failed to delete tag %q: %w", tag.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) tagManifest(
+	ctx context.Context,
+	session *auth.Session,
+	artifact *types.RegistryArtifact,
+	tag string,
+	manifest *types.RegistryBlob,
+) error {
+	now := time.Now().UnixMilli()
+
+	version, err := c.versionStore.Find(ctx, artifact.ID, tag, "")
+	switch {
+	case err == nil:
+		version.Digest = manifest.Digest
+		version.MediaType = manifest.MediaType
+		version.Size = manifest.Size
+		version.CreatedBy = session.Principal.ID
+		version.Updated = now
+		err = c.versionStore.Update(ctx, version)
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+		err = c.versionStore.Create(ctx, &types.RegistryVersion{
+			ArtifactID: artifact.ID,
+			Name:       tag,
+			Digest:     manifest.Digest,
+			MediaType:  manifest.MediaType,
+			Size:       manifest.Size,
+			CreatedBy:  session.Principal.ID,
+			Created:    now,
+			Updated:    now,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to tag manifest: %w", err)
+	}
+
+	if err = c.artifactStore.Touch(ctx, artifact.ID, now); err != nil {
+		return fmt.Errorf("failed to update image: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) findImage(
+	ctx context.Context,
+	registry *types.Registry,
+	image string,
+) (*types.RegistryArtifact, error) {
+	artifact, err := c.artifactStore.FindByName(ctx, registry.ID, image)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, newOCIError(http.StatusNotFound, OCICodeNameUnknown, "image %q not found", image)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find image: %w", err)
+	}
+
+	return artifact, nil
+}
+
+func (c *Controller) findManifest(
+	ctx context.Context,
+	registry *types.Registry,
+	image string,
+	reference string,
+) (*types.RegistryBlob, error) {
+	digest := reference
+	if !registries.ValidDigest(reference) {
+		artifact, err := c.findImage(ctx, registry, image)
+		if err != nil {
+			return nil, err
+		}
+
+		tag, err := c.versionStore.Find(ctx, artifact.ID, reference, "")
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, newOCIError(http.StatusNotFound, OCICodeManifestUnknown, "manifest %s not found", reference)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tag: %w", err)
+		}
+
+		digest = tag.Digest
+	}
+
+	manifest, err := c.blobMetaStore.Find(ctx, registry.ID, digest)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) || (err == nil && manifest.MediaType == "") {
+		// blobs uploaded via the blob API don't have a media type and can't be pulled as manifest.
+		return nil, newOCIError(http.StatusNotFound, OCICodeManifestUnknown, "manifest %s not found", reference)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find manifest: %w", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+type TagsListOutput struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ListTags lists the tags of an image in lexical order.
+// If n is positive at most n tags following the tag last are returned.
+func (c *Controller) ListTags(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	n int,
+	last string,
+) (*TagsListOutput, error) {
+	registry, image, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := c.findImage(ctx, registry, image)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := c.versionStore.ListNames(ctx, artifact.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	// the pagination relies on the lexical order, independent of the collation of the database.
+	sort.Strings(names)
+
+	tags := make([]string, 0, len(names))
+	for _, tag := range names {
+		if last != "" && tag <= last {
+			continue
+		}
+		if n > 0 && len(tags) >= n {
+			break
+		}
+		tags = append(tags, tag)
+	}
+
+	return &TagsListOutput{
+		Name: name,
+		Tags: tags,
+	}, nil
+}
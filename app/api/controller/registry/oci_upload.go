@@ -0,0 +1,326 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/registries"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// StartUpload starts a blob upload of an image repository.
+// If the digest is provided the body contains the whole blob (monolithic upload) and the stored blob is returned.
+// If a blob to mount is provided and the registry already has it, the existing blob is returned.
+// Otherwise, an upload session is created that accepts the content in chunks.
+func (c *Controller) StartUpload(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	digest string,
+	mount string,
+	content io.Reader,
+) (*types.RegistryUpload, *types.RegistryBlob, error) {
+	registry, _, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// blobs are shared by all images of a registry, mounting from an image of the same registry is a no-op.
+	if mount != "" && registries.ValidDigest(mount) {
+		b, err := c.blobMetaStore.Find(ctx, registry.ID, mount)
+		if err == nil {
+			return nil, b, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, nil, fmt.Errorf("failed to find blob to mount: %w", err)
+		}
+	}
+
+	if digest != "" {
+		b, err := c.storeUploadedBlob(ctx, registry.ID, content, digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, b, nil
+	}
+
+	now := time.Now().UnixMilli()
+	upload := &types.RegistryUpload{
+		RegistryID: registry.ID,
+		Identifier: uuid.NewString(),
+		CreatedBy:  session.Principal.ID,
+		Created:    now,
+		Updated:    now,
+	}
+
+	if err = c.uploadStore.Create(ctx, upload); err != nil {
+		return nil, nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+
+	return upload, nil, nil
+}
+
+// UploadStatus returns the progress of a blob upload.
+func (c *Controller) UploadStatus(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	uploadIdentifier string,
+) (*types.RegistryUpload, error) {
+	_, upload, err := c.findUpload(ctx, session, name, uploadIdentifier)
+	return upload, err
+}
+
+// UploadChunk appends a chunk to a blob upload. The content range is optional,
+// if provided the chunk has to start at the end of the content uploaded so far.
+func (c *Controller) UploadChunk(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	uploadIdentifier string,
+	contentRange string,
+	content io.Reader,
+) (*types.RegistryUpload, error) {
+	_, upload, err := c.findUpload(ctx, session, name, uploadIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentRange != "" {
+		start, ok := parseRangeStart(contentRange)
+		if !ok || start != upload.Size {
+			return nil, newOCIError(http.StatusRequestedRangeNotSatisfiable, OCICodeBlobUploadInvalid,
+				"chunk has to start at offset %d", upload.Size)
+		}
+	}
+
+	if err = c.appendChunk(ctx, upload, content); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// CompleteUpload completes a blob upload with an optional last chunk and verifies the digest of the blob.
+func (c *Controller) CompleteUpload(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	uploadIdentifier string,
+	digest string,
+	content io.Reader,
+) (*types.RegistryBlob, error) {
+	registry, upload, err := c.findUpload(ctx, session, name, uploadIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := &uploadReader{
+		ctx:       ctx,
+		blobStore: c.blobStore,
+		upload:    upload,
+	}
+	defer parts.Close()
+
+	b, err := c.storeUploadedBlob(ctx, registry.ID, io.MultiReader(parts, content), digest)
+	if err != nil {
+		return nil, err
+	}
+
+	c.deleteUpload(ctx, upload)
+
+	return b, nil
+}
+
+// CancelUpload cancels a blob upload and removes the content uploaded so far.
+func (c *Controller) CancelUpload(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	uploadIdentifier string,
+) error {
+	_, upload, err := c.findUpload(ctx, session, name, uploadIdentifier)
+	if err != nil {
+		return err
+	}
+
+	c.deleteUpload(ctx, upload)
+
+	return nil
+}
+
+func (c *Controller) findUpload(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	uploadIdentifier string,
+) (*types.Registry, *types.RegistryUpload, error) {
+	registry, _, err := c.resolveImage(ctx, session, name, enum.PermissionRegistryPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upload, err := c.uploadStore.FindByIdentifier(ctx, registry.ID, uploadIdentifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, newOCIError(http.StatusNotFound, OCICodeBlobUploadUnknown,
+			"upload %s not found", uploadIdentifier)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find upload: %w", err)
+	}
+
+	return registry, upload, nil
+}
+
+func (c *Controller) appendChunk(ctx context.Context, upload *types.RegistryUpload, content io.Reader) error {
+	counter := &countingReader{reader: content}
+	part := upload.Parts
+
+	err := c.blobStore.Upload(ctx, counter, registries.UploadPartPath(upload.RegistryID, upload.Identifier, part))
+	if err != nil {
+		return fmt.Errorf("failed to upload chunk: %w", err)
+	}
+
+	err = c.uploadStore.AddPart(ctx, upload, counter.count, time.Now().UnixMilli())
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return newOCIError(http.StatusRequestedRangeNotSatisfiable, OCICodeBlobUploadInvalid,
+			"chunks of an upload have to be uploaded sequentially")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update upload: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) storeUploadedBlob(
+	ctx context.Context,
+	registryID int64,
+	content io.Reader,
+	digest string,
+) (*types.RegistryBlob, error) {
+	if !registries.ValidDigest(digest) {
+		return nil, newOCIError(http.StatusBadRequest, OCICodeDigestInvalid, "invalid digest %q", digest)
+	}
+
+	b, err := c.storeBlob(ctx, registryID, content, digest, "")
+	if errors.Is(err, errDigestMismatch) {
+		return nil, newOCIError(http.StatusBadRequest, OCICodeDigestInvalid,
+			"uploaded content doesn't match digest %s", digest)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (c *Controller) deleteUpload(ctx context.Context, upload *types.RegistryUpload) {
+	registries.DeleteUploadParts(ctx, c.blobStore, upload)
+
+	if err := c.uploadStore.Delete(ctx, upload.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("upload.id", upload.Identifier).
+			Msg("failed to delete registry upload")
+	}
+}
+
+// parseRangeStart returns the start offset of a content range of the form "<start>-<end>".
+func parseRangeStart(contentRange string) (int64, bool) {
+	start, _, ok := strings.Cut(strings.TrimPrefix(contentRange, "bytes="), "-")
+	if !ok {
+		return 0, false
+	}
+
+	offset, err := strconv.ParseInt(start, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+
+	return offset, true
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// uploadReader reads the chunks of an upload in order, opening one chunk at a time.
+type uploadReader struct {
+	ctx       context.Context
+	blobStore blob.Store
+	upload    *types.RegistryUpload
+	part      int64
+	current   io.ReadCloser
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if r.part >= r.upload.Parts {
+				return 0, io.EOF
+			}
+
+			part, err := r.blobStore.Download(r.ctx,
+				registries.UploadPartPath(r.upload.RegistryID, r.upload.Identifier, r.part))
+			if err != nil {
+				return 0, fmt.Errorf("failed to open chunk %d of upload: %w", r.part, err)
+			}
+
+			r.current = part
+			r.part++
+		}
+
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			_ = r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+
+		return n, err
+	}
+}
+
+func (r *uploadReader) Close() {
+	if r.current != nil {
+		_ = r.current.Close()
+		r.current = nil
+	}
+}
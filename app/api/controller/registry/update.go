@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Description *string `json:"description"`
+	MaxVersions *int64  `json:"max_versions"`
+	MaxAge      *int64  `json:"max_age"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	var maxVersions, maxAge int64
+	if in.MaxVersions != nil {
+		maxVersions = *in.MaxVersions
+	}
+	if in.MaxAge != nil {
+		maxAge = *in.MaxAge
+	}
+
+	return checkRetention(maxVersions, maxAge)
+}
+
+// Update updates the description and the retention policy of an artifact registry.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.Registry, error) {
+	registry, err := c.getRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.Description != nil {
+		registry.Description = *in.Description
+	}
+	if in.MaxVersions != nil {
+		registry.MaxVersions = *in.MaxVersions
+	}
+	if in.MaxAge != nil {
+		registry.MaxAge = *in.MaxAge
+	}
+	registry.Updated = time.Now().UnixMilli()
+
+	if err = c.registryStore.Update(ctx, registry); err != nil {
+		return nil, fmt.Errorf("failed to update registry: %w", err)
+	}
+
+	return registry, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeleteVersion deletes a version of an artifact, i.e. an image tag or all files of a package version.
+// The content is removed by the garbage collection once it's no longer referenced.
+func (c *Controller) DeleteVersion(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	artifactName string,
+	versionName string,
+) error {
+	registry, err := c.getRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryDelete)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := c.artifactStore.FindByName(ctx, registry.ID, artifactName)
+	if err != nil {
+		return fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	deleted, err := c.versionStore.DeleteByName(ctx, artifact.ID, versionName)
+	if err != nil {
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+	if deleted == 0 {
+		return usererror.NotFound("Version not found")
+	}
+
+	if _, err = c.artifactStore.DeleteEmpty(ctx, registry.ID); err != nil {
+		return fmt.Errorf("failed to delete empty artifacts: %w", err)
+	}
+
+	return nil
+}
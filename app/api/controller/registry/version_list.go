@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListVersions lists the versions of an artifact, i.e. the tags of an image or the files of a package.
+func (c *Controller) ListVersions(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	artifactName string,
+	filter types.ListQueryFilter,
+) ([]*types.RegistryVersion, int64, error) {
+	registry, err := c.getRegistryCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionRegistryView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	artifact, err := c.artifactStore.FindByName(ctx, registry.ID, artifactName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	count, err := c.versionStore.Count(ctx, artifact.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count versions: %w", err)
+	}
+
+	versions, err := c.versionStore.List(ctx, artifact.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	return versions, count, nil
+}
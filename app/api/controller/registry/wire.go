@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	config *types.Config,
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	registryStore store.RegistryStore,
+	artifactStore store.RegistryArtifactStore,
+	versionStore store.RegistryVersionStore,
+	blobMetaStore store.RegistryBlobStore,
+	uploadStore store.RegistryUploadStore,
+	blobStore blob.Store,
+) *Controller {
+	return NewController(config, authorizer, spaceStore, registryStore, artifactStore, versionStore,
+		blobMetaStore, uploadStore, blobStore)
+}
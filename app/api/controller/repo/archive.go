@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ArchiveContent is an archive of the tree of a git ref that's created while it's read.
+type ArchiveContent struct {
+	Data        io.ReadCloser
+	FileName    string
+	ContentType string
+}
+
+// Archive returns an archive of the tree of the git ref, the archive name is the git ref
+// followed by the extension of the archive format (e.g. "main.zip" or "v1.0.0.tar.gz").
+// All files in the archive are placed in a directory named after the repository and the git ref.
+func (c *Controller) Archive(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	archiveName string,
+) (*ArchiveContent, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	gitRef, format, ok := gitenum.ParseArchiveName(archiveName)
+	if !ok {
+		return nil, usererror.BadRequestf("Archive name has to be a git ref followed by one of the extensions %v.",
+			gitenum.ArchiveFormats)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	// fail early if the git ref doesn't exist, the archive itself is created only once the content is read.
+	_, err = c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       "",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of git ref: %w", err)
+	}
+
+	prefix := repo.Identifier + "-" + strings.ReplaceAll(gitRef, "/", "-")
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := c.git.Archive(ctx, &git.ArchiveParams{
+			ReadParams: readParams,
+			GitRef:     gitRef,
+			Format:     format,
+			Prefix:     prefix,
+		}, pw)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to create repository archive")
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	return &ArchiveContent{
+		Data:        pr,
+		FileName:    prefix + "." + string(format),
+		ContentType: format.ContentType(),
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MarkArchived makes the repository read-only: pushes are rejected and pull requests can't be created or merged,
+// while the repository can still be read, cloned and forked.
+func (c *Controller) MarkArchived(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	return c.updateArchived(ctx, session, repoRef, true)
+}
+
+// Unarchive makes an archived repository writable again.
+// Unarchiving updates the repository, which restarts the inactivity period of the archival policy.
+func (c *Controller) Unarchive(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	return c.updateArchived(ctx, session, repoRef, false)
+}
+
+// updateArchived sets the archived flag of the repository, which is restricted to repository admins.
+func (c *Controller) updateArchived(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	archived bool,
+) (*types.Repository, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.Archived != archived {
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.Archived = archived
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// backfill repo url
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	return repo, nil
+}
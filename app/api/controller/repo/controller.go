@@ -19,19 +19,34 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/signedurl"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/bandwidth"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/dependencies"
+	"github.com/harness/gitness/app/services/highlight"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/services/instance"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/languages"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/app/services/pathindex"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -44,28 +59,53 @@ var (
 	errPublicRepoCreationDisabled = usererror.BadRequestf("Public repository creation is disabled.")
 )
 
+// spaceBandwidthLimitsCacheDuration is the duration for which the resolved git bandwidth limits of spaces are cached,
+// as they are needed for every git request (changes of the limits apply after at most that duration).
+const spaceBandwidthLimitsCacheDuration = 30 * time.Second
+
 type Controller struct {
 	defaultBranch                 string
 	publicResourceCreationEnabled bool
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	principalInfoCache store.PrincipalInfoCache
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	mtxManager         lock.MutexManager
-	identifierCheck    check.RepoIdentifier
+	tx                  dbtx.Transactor
+	urlProvider         url.Provider
+	authorizer          authz.Authorizer
+	repoStore           store.RepoStore
+	spaceStore          store.SpaceStore
+	pipelineStore       store.PipelineStore
+	principalStore      store.PrincipalStore
+	ruleStore           store.RuleStore
+	principalInfoCache  store.PrincipalInfoCache
+	protectionManager   *protection.Manager
+	git                 git.Interface
+	importer            *importer.Repository
+	codeOwners          *codeowners.Service
+	eventReporter       *repoevents.Reporter
+	systemReporter      *systemevents.Reporter
+	indexer             keywordsearch.Indexer
+	resourceLimiter     limiter.ResourceLimiter
+	mtxManager          lock.MutexManager
+	identifierCheck     check.RepoIdentifier
+	realtime            *realtime.Service
+	bandwidthLimiter    *bandwidth.Limiter
+	pullreqStore        store.PullReqStore
+	pushedBranchStore   store.PushedBranchStore
+	usage               *usage.Service
+	settings            *settings.Service
+	webhookStore        store.WebhookStore
+	insights            *insights.Service
+	languages           *languages.Service
+	markdownRenderer    *markdown.Renderer
+	highlighter         *highlight.Service
+	pathIndex           *pathindex.Service
+	urlSigner           *signedurl.Signer
+	instanceSettings    *instance.Service
+	malwareFindings     store.MalwareFindingStore
+	dependencies        *dependencies.Service
+	vulnerabilityAlerts store.VulnerabilityAlertStore
+
+	// spaceBandwidthLimits caches the effective git bandwidth limit per space.
+	spaceBandwidthLimits cache.Cache[int64, int64]
 }
 
 func NewController(
@@ -84,10 +124,28 @@ func NewController(
 	importer *importer.Repository,
 	codeOwners *codeowners.Service,
 	eventReporter *repoevents.Reporter,
+	systemReporter *systemevents.Reporter,
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
 	identifierCheck check.RepoIdentifier,
+	realtime *realtime.Service,
+	bandwidthLimiter *bandwidth.Limiter,
+	pullreqStore store.PullReqStore,
+	pushedBranchStore store.PushedBranchStore,
+	usage *usage.Service,
+	settings *settings.Service,
+	webhookStore store.WebhookStore,
+	insights *insights.Service,
+	languages *languages.Service,
+	markdownRenderer *markdown.Renderer,
+	highlighter *highlight.Service,
+	pathIndex *pathindex.Service,
+	urlSigner *signedurl.Signer,
+	instanceSettings *instance.Service,
+	malwareFindings store.MalwareFindingStore,
+	dependencies *dependencies.Service,
+	vulnerabilityAlerts store.VulnerabilityAlertStore,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
@@ -106,10 +164,31 @@ func NewController(
 		importer:                      importer,
 		codeOwners:                    codeOwners,
 		eventReporter:                 eventReporter,
+		systemReporter:                systemReporter,
 		indexer:                       indexer,
 		resourceLimiter:               limiter,
 		mtxManager:                    mtxManager,
 		identifierCheck:               identifierCheck,
+		realtime:                      realtime,
+		bandwidthLimiter:              bandwidthLimiter,
+		pullreqStore:                  pullreqStore,
+		pushedBranchStore:             pushedBranchStore,
+		usage:                         usage,
+		settings:                      settings,
+		webhookStore:                  webhookStore,
+		insights:                      insights,
+		languages:                     languages,
+		markdownRenderer:              markdownRenderer,
+		highlighter:                   highlighter,
+		pathIndex:                     pathIndex,
+		urlSigner:                     urlSigner,
+		instanceSettings:              instanceSettings,
+		malwareFindings:               malwareFindings,
+		dependencies:                  dependencies,
+		vulnerabilityAlerts:           vulnerabilityAlerts,
+
+		spaceBandwidthLimits: cache.New[int64, int64](spaceBandwidthLimitGetter{spaceStore: spaceStore},
+			spaceBandwidthLimitsCacheDuration),
 	}
 }
 
@@ -136,6 +215,7 @@ func (c *Controller) getRepo(
 
 // getRepoCheckAccess fetches an active repo (not one that is currently being imported)
 // and checks if the current user has permission to access it.
+// Archived repositories are read-only, so an error is returned in case push permission is requested for one.
 func (c *Controller) getRepoCheckAccess(
 	ctx context.Context,
 	session *auth.Session,
@@ -152,6 +232,10 @@ func (c *Controller) getRepoCheckAccess(
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
 
+	if repo.Archived && reqPermission == enum.PermissionRepoPush {
+		return nil, usererror.ErrRepoArchived
+	}
+
 	return repo, nil
 }
 
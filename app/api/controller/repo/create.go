@@ -18,7 +18,10 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,15 +31,21 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/resources"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/gotidy/ptr"
 	"github.com/rs/zerolog/log"
 )
 
+// templateRuleIdentifier is the identifier of the protection rule created for required checks of repo templates.
+const templateRuleIdentifier = "template-required-checks"
+
 var (
 	// errRepositoryRequiresParent if the user tries to create a repo without a parent space.
 	errRepositoryRequiresParent = usererror.BadRequest(
@@ -50,11 +59,12 @@ type CreateInput struct {
 	Identifier    string `json:"identifier"`
 	DefaultBranch string `json:"default_branch"`
 	Description   string `json:"description"`
-	IsPublic      bool   `json:"is_public"`
-	ForkID        int64  `json:"fork_id"`
-	Readme        bool   `json:"readme"`
-	License       string `json:"license"`
-	GitIgnore     string `json:"git_ignore"`
+	IsPublic      *bool  `json:"is_public"`
+	// ForkID is the id of the repository to fork (optional), the fork borrows the objects of the repository.
+	ForkID    int64  `json:"fork_id"`
+	Readme    bool   `json:"readme"`
+	License   string `json:"license"`
+	GitIgnore string `json:"git_ignore"`
 }
 
 // Create creates a new repository.
@@ -70,15 +80,46 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
+	template, _, err := c.settings.EffectiveRepoTemplate(ctx, parentSpace.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo template of the space: %w", err)
+	}
+
+	var forkSource *types.Repository
+	if in.ForkID != 0 {
+		forkSource, err = c.getForkSourceCheckAccess(ctx, session, in.ForkID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	applyRepoTemplate(in, template)
+	if in.DefaultBranch == "" {
+		in.DefaultBranch = c.defaultBranch
+	}
+
 	var repo *types.Repository
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
 			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
 		}
 
-		gitResp, err := c.createGitRepository(ctx, session, in)
-		if err != nil {
-			return fmt.Errorf("error creating repository on git: %w", err)
+		var gitUID string
+		if forkSource != nil {
+			gitResp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+				SourceRepoUID: forkSource.GitUID,
+			})
+			if err != nil {
+				return fmt.Errorf("error forking repository on git: %w", err)
+			}
+			gitUID = gitResp.UID
+			in.DefaultBranch = gitResp.DefaultBranch
+		} else {
+			gitResp, err := c.createGitRepository(ctx, session, in)
+			if err != nil {
+				return fmt.Errorf("error creating repository on git: %w", err)
+			}
+			gitUID = gitResp.UID
 		}
 
 		now := time.Now().UnixMilli()
@@ -86,14 +127,16 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			Version:       0,
 			ParentID:      parentSpace.ID,
 			Identifier:    in.Identifier,
-			GitUID:        gitResp.UID,
+			GitUID:        gitUID,
 			Description:   in.Description,
-			IsPublic:      in.IsPublic,
+			IsPublic:      *in.IsPublic,
 			CreatedBy:     session.Principal.ID,
 			Created:       now,
 			Updated:       now,
 			ForkID:        in.ForkID,
 			DefaultBranch: in.DefaultBranch,
+
+			PullReqSuggestions: true,
 		}
 		err = c.repoStore.Create(ctx, repo)
 		if err != nil {
@@ -103,6 +146,20 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			return fmt.Errorf("failed to create repository in storage: %w", err)
 		}
 
+		if err = c.createTemplateResources(ctx, session, repo, template); err != nil {
+			return fmt.Errorf("failed to apply repo template: %w", err)
+		}
+
+		if forkSource != nil {
+			_, err = c.repoStore.UpdateOptLock(ctx, forkSource, func(r *types.Repository) error {
+				r.NumForks++
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update number of forks of the source repository: %w", err)
+			}
+		}
+
 		return nil
 	}, sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
@@ -112,8 +169,10 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 	// backfil GitURL
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
 
+	c.systemReporter.RepoCreated(ctx, session.Principal.ID, repo)
+
 	// index repository if files are created
-	if in.Readme || in.GitIgnore != "" || (in.License != "" && in.License != "none") {
+	if forkSource != nil || in.Readme || in.GitIgnore != "" || (in.License != "" && in.License != "none") {
 		err = c.indexer.Index(ctx, repo)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
@@ -150,13 +209,41 @@ func (c *Controller) getSpaceCheckAuthRepoCreation(
 	return space, nil
 }
 
+// getForkSourceCheckAccess returns the repository that is forked,
+// the principal has to be able to read it (public repositories can be forked by anyone).
+func (c *Controller) getForkSourceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	forkID int64,
+) (*types.Repository, error) {
+	source, err := c.getRepoCheckAccess(ctx, session, strconv.FormatInt(forkID, 10),
+		enum.PermissionRepoView, true)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequest("The repository to fork doesn't exist.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Importing {
+		return nil, usererror.BadRequest("Repositories can't be forked while they are being imported.")
+	}
+
+	return source, nil
+}
+
 func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == "" {
 		in.Identifier = in.UID
 	}
 
-	if in.IsPublic && !c.publicResourceCreationEnabled {
+	if in.IsPublic == nil {
+		// resources can only be public by default if they can be public at all.
+		in.IsPublic = ptr.Bool(c.instanceSettings.DefaultPublic() && c.publicResourceCreationEnabled)
+	}
+
+	if *in.IsPublic && !c.publicResourceCreationEnabled {
 		return errPublicRepoCreationDisabled
 	}
 
@@ -173,8 +260,102 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	if in.ForkID < 0 {
+		return usererror.BadRequest("The id of the repository to fork must be positive.")
+	}
+
+	// forks get the content and the default branch of the forked repository.
+	if in.ForkID != 0 && (in.Readme || in.GitIgnore != "" || (in.License != "" && in.License != "none")) {
+		return usererror.BadRequest("Forks can't be created with a readme, license or gitignore file.")
+	}
+
+	return nil
+}
+
+// applyRepoTemplate fills the values of the input that weren't provided with the values of the template.
+func applyRepoTemplate(in *CreateInput, template *types.RepoTemplate) {
+	if template == nil {
+		return
+	}
+
 	if in.DefaultBranch == "" {
-		in.DefaultBranch = c.defaultBranch
+		in.DefaultBranch = template.DefaultBranch
+	}
+	if template.Readme {
+		in.Readme = true
+	}
+	if in.License == "" {
+		in.License = template.License
+	}
+	if in.GitIgnore == "" {
+		in.GitIgnore = template.GitIgnore
+	}
+}
+
+// createTemplateResources creates the protection rule and webhooks defined by the repo template.
+func (c *Controller) createTemplateResources(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	template *types.RepoTemplate,
+) error {
+	if template == nil {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	if len(template.RequiredChecks) > 0 {
+		definition, err := json.Marshal(&protection.Branch{
+			PullReq: protection.DefPullReq{
+				StatusChecks: protection.DefStatusChecks{RequireIdentifiers: template.RequiredChecks},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal rule definition: %w", err)
+		}
+
+		definition, err = c.protectionManager.SanitizeJSON(protection.TypeBranch, definition)
+		if err != nil {
+			return fmt.Errorf("invalid rule definition: %w", err)
+		}
+
+		pattern := protection.Pattern{Default: true}
+		err = c.ruleStore.Create(ctx, &types.Rule{
+			CreatedBy:   session.Principal.ID,
+			Created:     now,
+			Updated:     now,
+			RepoID:      &repo.ID,
+			Type:        protection.TypeBranch,
+			State:       enum.RuleStateActive,
+			Identifier:  templateRuleIdentifier,
+			Description: "Required status checks of the space repository template.",
+			Pattern:     pattern.JSON(),
+			Definition:  definition,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create protection rule: %w", err)
+		}
+	}
+
+	for _, templateHook := range template.Webhooks {
+		err := c.webhookStore.Create(ctx, &types.Webhook{
+			CreatedBy:   session.Principal.ID,
+			Created:     now,
+			Updated:     now,
+			ParentID:    repo.ID,
+			ParentType:  enum.WebhookParentRepo,
+			Identifier:  templateHook.Identifier,
+			DisplayName: templateHook.Identifier,
+			URL:         templateHook.URL,
+			Secret:      templateHook.Secret,
+			Enabled:     true,
+			Insecure:    templateHook.Insecure,
+			Triggers:    templateHook.Triggers,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook %q: %w", templateHook.Identifier, err)
+		}
 	}
 
 	return nil
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/git"
@@ -45,6 +46,10 @@ func (c *Controller) UpdateDefaultBranch(
 		return nil, err
 	}
 
+	if repo.Archived {
+		return nil, usererror.ErrRepoArchived
+	}
+
 	// the max time we give an update default branch to succeed
 	const timeout = 2 * time.Minute
 
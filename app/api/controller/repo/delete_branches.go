@@ -0,0 +1,244 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxBulkDeleteBranches is the maximum number of branches deleted by a single bulk deletion.
+const maxBulkDeleteBranches = request.PerPageMax
+
+// BranchDeleteStatus is the outcome of the deletion of a single branch of a bulk deletion.
+type BranchDeleteStatus string
+
+const (
+	BranchDeleteStatusDeleted  BranchDeleteStatus = "deleted"
+	BranchDeleteStatusSkipped  BranchDeleteStatus = "skipped"
+	BranchDeleteStatusNotFound BranchDeleteStatus = "not_found"
+)
+
+type DeleteBranchesInput struct {
+	// Branches are the names of the branches to delete.
+	Branches []string `json:"branches"`
+	// StaleDays deletes all branches without commits in the provided number of days.
+	// Can't be combined with Branches.
+	StaleDays int `json:"stale_days"`
+	// BypassRules allows the deletion of protected branches if the user is allowed to bypass the rules.
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *DeleteBranchesInput) sanitize() error {
+	if len(in.Branches) == 0 && in.StaleDays <= 0 {
+		return usererror.BadRequest("Either branches or stale days must be provided.")
+	}
+
+	if len(in.Branches) > 0 && in.StaleDays > 0 {
+		return usererror.BadRequest("Branches and stale days can't be provided at the same time.")
+	}
+
+	if in.StaleDays < 0 {
+		return usererror.BadRequest("Stale days can't be negative.")
+	}
+
+	if len(in.Branches) > maxBulkDeleteBranches {
+		return usererror.BadRequestf("At most %d branches can be deleted at once.", maxBulkDeleteBranches)
+	}
+
+	return nil
+}
+
+type BranchDeleteResult struct {
+	Name       string                 `json:"name"`
+	SHA        string                 `json:"sha,omitempty"`
+	Status     BranchDeleteStatus     `json:"status"`
+	Reason     string                 `json:"reason,omitempty"`
+	Violations []types.RuleViolations `json:"violations,omitempty"`
+}
+
+// DeleteBranches deletes multiple branches of a repo in a single git operation.
+// The default branch, branches protected by rules and branches with open pull requests are skipped.
+// In case stale days are provided, up to maxBulkDeleteBranches of the oldest stale branches are deleted.
+func (c *Controller) DeleteBranches(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *DeleteBranchesInput,
+) ([]BranchDeleteResult, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	branchNames := in.Branches
+	if in.StaleDays > 0 {
+		branchNames, err = c.listStaleBranches(ctx, repo, in.StaleDays)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BranchDeleteResult, 0, len(branchNames))
+	toDelete := make([]string, 0, len(branchNames))
+
+	for _, branchName := range branchNames {
+		result, err := c.checkBranchDeletable(ctx, session, repo, rules, isRepoOwner, in.BypassRules, branchName)
+		if err != nil {
+			return nil, err
+		}
+
+		if result != nil {
+			results = append(results, *result)
+			continue
+		}
+
+		toDelete = append(toDelete, branchName)
+	}
+
+	if len(toDelete) == 0 {
+		return results, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	out, err := c.git.DeleteBranches(ctx, &git.DeleteBranchesParams{
+		WriteParams: writeParams,
+		BranchNames: toDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, branch := range out.Deleted {
+		results = append(results, BranchDeleteResult{
+			Name:   branch.Name,
+			SHA:    branch.SHA,
+			Status: BranchDeleteStatusDeleted,
+		})
+	}
+
+	for _, branchName := range out.NotFound {
+		results = append(results, BranchDeleteResult{
+			Name:   branchName,
+			Status: BranchDeleteStatusNotFound,
+		})
+	}
+
+	return results, nil
+}
+
+// checkBranchDeletable returns the result with the reason why a branch is skipped, or nil if it can be deleted.
+func (c *Controller) checkBranchDeletable(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	rules protection.Protection,
+	isRepoOwner bool,
+	bypassRules bool,
+	branchName string,
+) (*BranchDeleteResult, error) {
+	if branchName == repo.DefaultBranch {
+		return &BranchDeleteResult{
+			Name:   branchName,
+			Status: BranchDeleteStatusSkipped,
+			Reason: "default branch",
+		}, nil
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: bypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   protection.RefActionDelete,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return &BranchDeleteResult{
+			Name:       branchName,
+			Status:     BranchDeleteStatusSkipped,
+			Reason:     "protected branch",
+			Violations: violations,
+		}, nil
+	}
+
+	openPullReqs, err := c.pullreqStore.Count(ctx, &types.PullReqFilter{
+		SourceRepoID: repo.ID,
+		SourceBranch: branchName,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open pull requests of branch %q: %w", branchName, err)
+	}
+	if openPullReqs > 0 {
+		return &BranchDeleteResult{
+			Name:   branchName,
+			Status: BranchDeleteStatusSkipped,
+			Reason: "branch has open pull requests",
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// listStaleBranches returns the names of the oldest branches without commits in the provided number of days.
+func (c *Controller) listStaleBranches(ctx context.Context, repo *types.Repository, staleDays int) ([]string, error) {
+	committedBefore := time.Now().Add(-time.Duration(staleDays) * 24 * time.Hour)
+
+	out, err := c.git.ListBranches(ctx, &git.ListBranchesParams{
+		ReadParams:      git.CreateReadParams(repo),
+		Sort:            git.BranchSortOptionDate,
+		Order:           git.SortOrderAsc,
+		Page:            1,
+		PageSize:        maxBulkDeleteBranches,
+		CommittedBefore: committedBefore.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale branches: %w", err)
+	}
+
+	branchNames := make([]string, len(out.Branches))
+	for i := range out.Branches {
+		branchNames[i] = out.Branches[i].Name
+	}
+
+	return branchNames, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Dependencies returns the license and the declared dependencies of the default branch of the repository.
+// The returned boolean value is true if they are (re)detected in the background and the result is stale.
+func (c *Controller) Dependencies(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepoDependencies, bool, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sha, err := c.getDefaultBranchSHA(ctx, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	if sha == "" {
+		// the repository is empty, there's nothing to detect.
+		return &types.RepoDependencies{Dependencies: []types.Dependency{}}, false, nil
+	}
+
+	return c.dependencies.Find(ctx, repo, sha)
+}
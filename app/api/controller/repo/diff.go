@@ -47,10 +47,11 @@ func (c *Controller) RawDiff(
 	}
 
 	return c.git.RawDiff(ctx, w, &git.DiffParams{
-		ReadParams: git.CreateReadParams(repo),
-		BaseRef:    info.BaseRef,
-		HeadRef:    info.HeadRef,
-		MergeBase:  info.MergeBase,
+		ReadParams:      git.CreateReadParams(repo),
+		BaseRef:         info.BaseRef,
+		HeadRef:         info.HeadRef,
+		MergeBase:       info.MergeBase,
+		ApplyAttributes: true,
 	}, files...)
 }
 
@@ -149,11 +150,12 @@ func (c *Controller) Diff(
 	}
 
 	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
-		ReadParams:   git.CreateReadParams(repo),
-		BaseRef:      info.BaseRef,
-		HeadRef:      info.HeadRef,
-		MergeBase:    info.MergeBase,
-		IncludePatch: includePatch,
+		ReadParams:      git.CreateReadParams(repo),
+		BaseRef:         info.BaseRef,
+		HeadRef:         info.HeadRef,
+		MergeBase:       info.MergeBase,
+		IncludePatch:    includePatch,
+		ApplyAttributes: true,
 	}, files...))
 
 	return reader, nil
@@ -33,13 +33,21 @@ func (c *Controller) GitInfoRefs(
 	gitProtocol string,
 	w io.Writer,
 ) error {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	repo, isWiki, err := c.getGitRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
 	if err != nil {
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	var gitRepo git.Repository = repo
+	if isWiki {
+		if err = c.ensureWiki(ctx, repo); err != nil {
+			return err
+		}
+		gitRepo = wikiRepository{repo}
+	}
+
 	if err = c.git.GetInfoRefs(ctx, w, &git.InfoRefsParams{
-		ReadParams: git.CreateReadParams(repo),
+		ReadParams: git.CreateReadParams(gitRepo),
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		Service:     string(service),
 		Options:     nil,
@@ -18,11 +18,15 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync/atomic"
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // GitServicePack executes the service pack part of git's smart http protocol (receive-/upload-pack).
@@ -43,11 +47,36 @@ func (c *Controller) GitServicePack(
 		permission = enum.PermissionRepoPush
 	}
 
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission, !isWriteOperation)
+	repo, isWiki, err := c.getGitRepoCheckAccess(ctx, session, repoRef, permission, !isWriteOperation)
 	if err != nil {
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	if isWiki {
+		if err = c.ensureWiki(ctx, repo); err != nil {
+			return err
+		}
+	}
+
+	spaceLimit, err := c.spaceBandwidthLimits.Get(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get bandwidth limit of space: %w", err)
+	}
+
+	var principalID int64
+	if session != nil {
+		principalID = session.Principal.ID
+	}
+
+	transfer := c.bandwidthLimiter.Acquire(principalID, spaceLimit)
+	defer transfer.Release()
+
+	// count the transferred bytes for usage reporting.
+	cr := &countingReader{Reader: transfer.Reader(ctx, r)}
+	cw := &countingWriter{Writer: transfer.Writer(ctx, w)}
+	r = cr
+	w = cw
+
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		Service:     string(service),
@@ -57,21 +86,88 @@ func (c *Controller) GitServicePack(
 	}
 
 	// setup read/writeparams depending on whether it's a write operation
-	if isWriteOperation {
+	switch {
+	case isWriteOperation && isWiki:
+		var writeParams git.WriteParams
+		writeParams, err = c.createWikiWriteParams(ctx, session, repo)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+		params.WriteParams = &writeParams
+	case isWriteOperation:
 		var writeParams git.WriteParams
 		writeParams, err = controller.CreateRPCExternalWriteParams(ctx, c.urlProvider, session, repo)
 		if err != nil {
 			return fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 		params.WriteParams = &writeParams
-	} else {
+	case isWiki:
+		readParams := git.CreateReadParams(wikiRepository{repo})
+		params.ReadParams = &readParams
+	default:
 		readParams := git.CreateReadParams(repo)
 		params.ReadParams = &readParams
 	}
 
-	if err = c.git.ServicePack(ctx, w, params); err != nil {
+	err = c.git.ServicePack(ctx, w, params)
+
+	// the traffic is recorded even for failed operations, as the bytes were transferred regardless.
+	if errUsage := c.usage.RecordGitTransfer(ctx, repo.ID, principalID,
+		cr.n.Load(), cw.n.Load()); errUsage != nil {
+		log.Ctx(ctx).Warn().Err(errUsage).Msg("failed to record usage of git transfer")
+	}
+
+	if err != nil {
 		return fmt.Errorf("failed service pack operation %q  on git: %w", service, err)
 	}
 
 	return nil
 }
+
+// spaceBandwidthLimitGetter resolves the effective git bandwidth limit of a space:
+// the limits of the space and all its ancestors apply, so the most restrictive one is returned (0 if none is set).
+type spaceBandwidthLimitGetter struct {
+	spaceStore store.SpaceStore
+}
+
+func (g spaceBandwidthLimitGetter) Find(ctx context.Context, spaceID int64) (int64, error) {
+	var limit int64
+	for spaceID > 0 {
+		space, err := g.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return 0, err
+		}
+
+		if space.GitBandwidthLimit > 0 && (limit == 0 || space.GitBandwidthLimit < limit) {
+			limit = space.GitBandwidthLimit
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return limit, nil
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	io.Reader
+	n atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	io.Writer
+	n atomic.Int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n.Add(int64(n))
+	return n, err
+}
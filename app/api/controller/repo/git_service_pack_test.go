@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type spaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+	finds  int
+}
+
+func (s *spaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	s.finds++
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+func TestSpaceBandwidthLimitGetter(t *testing.T) {
+	// root (10MB/s) > team (no limit) > project (50MB/s) > sub (5MB/s)
+	spaces := &spaceStore{spaces: map[int64]*types.Space{
+		1: {ID: 1, GitBandwidthLimit: 10 << 20},
+		2: {ID: 2, ParentID: 1},
+		3: {ID: 3, ParentID: 2, GitBandwidthLimit: 50 << 20},
+		4: {ID: 4, ParentID: 3, GitBandwidthLimit: 5 << 20},
+		5: {ID: 5},
+	}}
+
+	tests := []struct {
+		name    string
+		spaceID int64
+		want    int64
+	}{
+		{name: "own limit", spaceID: 1, want: 10 << 20},
+		{name: "inherited from ancestor", spaceID: 2, want: 10 << 20},
+		{name: "stricter ancestor limit wins", spaceID: 3, want: 10 << 20},
+		{name: "stricter own limit wins", spaceID: 4, want: 5 << 20},
+		{name: "no limit", spaceID: 5, want: 0},
+	}
+
+	getter := spaceBandwidthLimitGetter{spaceStore: spaces}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := getter.Find(context.Background(), test.spaceID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("limit = %d, want %d", got, test.want)
+			}
+		})
+	}
+
+	if _, err := getter.Find(context.Background(), 42); err == nil {
+		t.Error("expected error for unknown space")
+	}
+}
+
+func TestSpaceBandwidthLimitsCached(t *testing.T) {
+	spaces := &spaceStore{spaces: map[int64]*types.Space{
+		1: {ID: 1, GitBandwidthLimit: 10 << 20},
+		2: {ID: 2, ParentID: 1},
+	}}
+
+	limits := cache.New[int64, int64](spaceBandwidthLimitGetter{spaceStore: spaces}, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		got, err := limits.Get(context.Background(), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != 10<<20 {
+			t.Errorf("limit = %d, want %d", got, 10<<20)
+		}
+	}
+
+	// the space and its parent are only read once.
+	if spaces.finds != 2 {
+		t.Errorf("expected 2 space lookups, got %d", spaces.finds)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type HighlightInput struct {
+	// Path is the path of the file, it's used to select the language.
+	Path string
+	// GitRef is used to find the blob of the file in case no blob SHA is provided.
+	GitRef string
+	// SHA is the SHA of the blob (e.g. the old or new SHA of a file diff).
+	SHA string
+	// LineFrom and LineTo limit the returned lines (1-based, inclusive), e.g. to the lines of a diff hunk.
+	LineFrom int
+	LineTo   int
+}
+
+func (in *HighlightInput) sanitize() error {
+	if in.Path == "" {
+		return usererror.BadRequest("Path is required.")
+	}
+
+	if in.LineFrom < 0 || in.LineTo < 0 {
+		return usererror.BadRequest("Line numbers can't be negative.")
+	}
+
+	if in.LineTo > 0 && in.LineFrom > in.LineTo {
+		return usererror.BadRequest("Line from can't be greater than line to.")
+	}
+
+	return nil
+}
+
+// Highlight returns the syntax highlighting tokens of a blob, either of the file at the git ref or
+// of the blob with the provided SHA. The result can be limited to a range of lines.
+func (c *Controller) Highlight(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *HighlightInput,
+) (*types.HighlightedBlob, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	blobSHA := in.SHA
+	if blobSHA == "" {
+		gitRef := in.GitRef
+		if gitRef == "" {
+			gitRef = repo.DefaultBranch
+		}
+
+		treeNodeOutput, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: git.CreateReadParams(repo),
+			GitREF:     gitRef,
+			Path:       in.Path,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tree node: %w", err)
+		}
+
+		if treeNodeOutput.Node.Type != git.TreeNodeTypeBlob {
+			return nil, usererror.BadRequestf(
+				"Object in '%s' at '/%s' is of type '%s'. Only objects of type %s support highlighting.",
+				gitRef, in.Path, treeNodeOutput.Node.Type, git.TreeNodeTypeBlob)
+		}
+
+		blobSHA = treeNodeOutput.Node.SHA
+	}
+
+	blob, err := c.highlighter.Highlight(ctx, repo, blobSHA, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the highlighted blob is cached and shared, hence only a copy is modified.
+	out := *blob
+	out.Lines = selectLines(blob.Lines, in.LineFrom, in.LineTo)
+
+	return &out, nil
+}
+
+func selectLines(lines []types.HighlightLine, lineFrom, lineTo int) []types.HighlightLine {
+	if lineFrom == 0 {
+		lineFrom = 1
+	}
+	if lineTo == 0 || lineTo > len(lines) {
+		lineTo = len(lines)
+	}
+	if lineFrom > lineTo {
+		return []types.HighlightLine{}
+	}
+
+	return lines[lineFrom-1 : lineTo]
+}
@@ -54,7 +54,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
 		}
 
-		remoteRepository, provider, err := importer.LoadRepositoryFromProvider(ctx, in.Provider, in.ProviderRepo)
+		remoteRepository, provider, err := c.importer.LoadRepositoryFromProvider(ctx, in.Provider, in.ProviderRepo)
 		if err != nil {
 			return err
 		}
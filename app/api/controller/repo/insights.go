@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// insightsCommitActivityWeeks is the number of weeks for which the commit activity is returned.
+const insightsCommitActivityWeeks = 52
+
+// InsightsCommitActivity returns the number of commits per week of the last year on the default branch.
+// The returned boolean value is true if the insights are (re)computed in the background and the result is stale.
+func (c *Controller) InsightsCommitActivity(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.InsightsCommitActivity, bool, error) {
+	repoInsights, pending, err := c.findInsights(ctx, session, repoRef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return insights.CommitActivity(repoInsights, time.Now(), insightsCommitActivityWeeks), pending, nil
+}
+
+// InsightsCodeFrequency returns the number of added and deleted lines per week on the default branch.
+// The returned boolean value is true if the insights are (re)computed in the background and the result is stale.
+func (c *Controller) InsightsCodeFrequency(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.InsightsCodeFrequency, bool, error) {
+	repoInsights, pending, err := c.findInsights(ctx, session, repoRef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return insights.CodeFrequency(repoInsights), pending, nil
+}
+
+// InsightsContributors returns the commit authors of the default branch ordered by the number of commits.
+// The returned boolean value is true if the insights are (re)computed in the background and the result is stale.
+func (c *Controller) InsightsContributors(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	limit int,
+) ([]types.InsightsContributor, bool, error) {
+	repoInsights, pending, err := c.findInsights(ctx, session, repoRef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	contributors := repoInsights.Contributors
+	if contributors == nil {
+		contributors = []types.InsightsContributor{}
+	}
+	if limit > 0 && len(contributors) > limit {
+		contributors = contributors[:limit]
+	}
+
+	return contributors, pending, nil
+}
+
+// findInsights returns the cached insights of the repository and triggers their recomputation
+// in case the default branch has moved since they were computed.
+func (c *Controller) findInsights(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepoInsights, bool, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sha, err := c.getDefaultBranchSHA(ctx, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	if sha == "" {
+		// the repository is empty, there's nothing to compute.
+		return &types.RepoInsights{RepoID: repo.ID}, false, nil
+	}
+
+	repoInsights, pending, err := c.insights.Find(ctx, repo, sha)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repoInsights, pending, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Languages returns the language distribution of the default branch of the repository.
+// The returned boolean value is true if the distribution is (re)computed in the background and the result is stale.
+func (c *Controller) Languages(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.LanguageStat, bool, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return c.findLanguages(ctx, repo)
+}
+
+func (c *Controller) findLanguages(ctx context.Context, repo *types.Repository) ([]types.LanguageStat, bool, error) {
+	sha, err := c.getDefaultBranchSHA(ctx, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	if sha == "" {
+		// the repository is empty, there's nothing to compute.
+		return []types.LanguageStat{}, false, nil
+	}
+
+	repoLanguages, pending, err := c.languages.Find(ctx, repo, sha)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repoLanguages.Languages, pending, nil
+}
+
+// getDefaultBranchSHA returns the commit SHA of the default branch of the repository,
+// or an empty string if the default branch doesn't exist.
+func (c *Controller) getDefaultBranchSHA(ctx context.Context, repo *types.Repository) (string, error) {
+	branchOut, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	return branchOut.Branch.SHA, nil
+}
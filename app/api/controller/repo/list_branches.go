@@ -29,6 +29,8 @@ type Branch struct {
 	Name   string        `json:"name"`
 	SHA    string        `json:"sha"`
 	Commit *types.Commit `json:"commit,omitempty"`
+	// Divergence contains the ahead/behind counts of the branch relative to the default branch.
+	Divergence *CommitDivergence `json:"divergence,omitempty"`
 }
 
 // ListBranches lists the branches of a repo.
@@ -44,13 +46,14 @@ func (c *Controller) ListBranches(ctx context.Context,
 	}
 
 	rpcOut, err := c.git.ListBranches(ctx, &git.ListBranchesParams{
-		ReadParams:    git.CreateReadParams(repo),
-		IncludeCommit: includeCommit,
-		Query:         filter.Query,
-		Sort:          mapToRPCBranchSortOption(filter.Sort),
-		Order:         mapToRPCSortOrder(filter.Order),
-		Page:          int32(filter.Page),
-		PageSize:      int32(filter.Size),
+		ReadParams:      git.CreateReadParams(repo),
+		IncludeCommit:   includeCommit,
+		Query:           filter.Query,
+		Sort:            mapToRPCBranchSortOption(filter.Sort),
+		Order:           mapToRPCSortOrder(filter.Order),
+		Page:            int32(filter.Page),
+		PageSize:        int32(filter.Size),
+		CommittedBefore: filter.StaleOlderThan / 1000,
 	})
 	if err != nil {
 		return nil, err
@@ -64,9 +67,51 @@ func (c *Controller) ListBranches(ctx context.Context,
 		}
 	}
 
+	if filter.IncludeDivergence {
+		err = c.setBranchDivergences(ctx, repo, branches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return branches, nil
 }
 
+// setBranchDivergences calculates the divergences of all branches relative to the default branch in a single call.
+func (c *Controller) setBranchDivergences(ctx context.Context, repo *types.Repository, branches []Branch) error {
+	if len(branches) == 0 {
+		return nil
+	}
+
+	params := &git.GetCommitDivergencesParams{
+		ReadParams: git.CreateReadParams(repo),
+		Requests:   make([]git.CommitDivergenceRequest, len(branches)),
+	}
+	for i := range branches {
+		params.Requests[i] = git.CommitDivergenceRequest{
+			From: branches[i].SHA,
+			To:   repo.DefaultBranch,
+		}
+	}
+
+	rpcOut, err := c.git.GetCommitDivergences(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to get branch divergences: %w", err)
+	}
+
+	for i := range rpcOut.Divergences {
+		if i >= len(branches) {
+			break
+		}
+		branches[i].Divergence = &CommitDivergence{
+			Ahead:  rpcOut.Divergences[i].Ahead,
+			Behind: rpcOut.Divergences[i].Behind,
+		}
+	}
+
+	return nil
+}
+
 func mapToRPCBranchSortOption(o enum.BranchSortOption) git.BranchSortOption {
 	switch o {
 	case enum.BranchSortOptionDate:
@@ -52,6 +52,9 @@ func (c *Controller) ListCommits(ctx context.Context,
 		Since:        filter.Since,
 		Until:        filter.Until,
 		Committer:    filter.Committer,
+		Author:       filter.Author,
+		Follow:       filter.Follow,
+		Cursor:       filter.CursorSHA,
 		IncludeStats: filter.IncludeStats,
 	})
 	if err != nil {
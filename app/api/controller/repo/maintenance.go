@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+
+	"github.com/rs/zerolog/log"
+)
+
+type GarbageCollectInput struct {
+	Aggressive bool `json:"aggressive"`
+}
+
+type GarbageCollectOutput struct {
+	// SizeBefore and SizeAfter are the repository sizes in KiB before and after the garbage collection.
+	SizeBefore int64 `json:"size_before"`
+	SizeAfter  int64 `json:"size_after"`
+}
+
+// GarbageCollect runs the git garbage collection on a repository and updates the stored repository size.
+// Only admins are allowed to run repository maintenance operations.
+func (c *Controller) GarbageCollect(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *GarbageCollectInput,
+) (*GarbageCollectOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	sizeBefore, err := c.git.GetRepositorySize(ctx, &git.GetRepositorySizeParams{ReadParams: readParams})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository size: %w", err)
+	}
+
+	// objects that are no longer reachable in the repo might still be used by its forks.
+	forks, err := c.repoStore.ListForks(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Bool("aggressive", in.Aggressive).
+		Int("forks", len(forks)).
+		Msg("running garbage collection on repository")
+
+	err = c.git.GarbageCollect(ctx, &git.GarbageCollectParams{
+		ReadParams:      readParams,
+		Aggressive:      in.Aggressive,
+		KeepUnreachable: len(forks) > 0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run garbage collection: %w", err)
+	}
+
+	sizeAfter, err := c.git.GetRepositorySize(ctx, &git.GetRepositorySizeParams{ReadParams: readParams})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository size: %w", err)
+	}
+
+	if err = c.repoStore.UpdateSize(ctx, repo.ID, sizeAfter.Size); err != nil {
+		return nil, fmt.Errorf("failed to update repository size: %w", err)
+	}
+
+	return &GarbageCollectOutput{
+		SizeBefore: sizeBefore.Size,
+		SizeAfter:  sizeAfter.Size,
+	}, nil
+}
+
+// DetachObjects copies all objects a fork borrows from the forked repository into the fork,
+// afterwards the fork no longer depends on the git repository of the forked repository.
+// Only admins are allowed to run repository maintenance operations.
+func (c *Controller) DetachObjects(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*DiskUsageOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Msg("detaching objects of repository")
+
+	err = c.git.DetachRepositoryObjects(ctx, &git.DetachRepositoryObjectsParams{
+		ReadParams: git.CreateReadParams(repo),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detach objects: %w", err)
+	}
+
+	// the repository size grows as the objects are stored in the repository itself now.
+	size, err := c.git.GetRepositorySize(ctx, &git.GetRepositorySizeParams{ReadParams: git.CreateReadParams(repo)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository size: %w", err)
+	}
+
+	if err = c.repoStore.UpdateSize(ctx, repo.ID, size.Size); err != nil {
+		return nil, fmt.Errorf("failed to update repository size: %w", err)
+	}
+
+	return c.DiskUsage(ctx, session, repoRef)
+}
+
+type FsckOutput struct {
+	Healthy  bool     `json:"healthy"`
+	Messages []string `json:"messages"`
+}
+
+// Fsck verifies the integrity of all objects of a repository.
+// Only admins are allowed to run repository maintenance operations.
+func (c *Controller) Fsck(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*FsckOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.git.Fsck(ctx, &git.FsckParams{ReadParams: git.CreateReadParams(repo)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify repository: %w", err)
+	}
+
+	if !out.Healthy {
+		log.Ctx(ctx).Warn().
+			Int64("repo.id", repo.ID).
+			Str("repo.path", repo.Path).
+			Strs("messages", out.Messages).
+			Msg("repository integrity check found problems")
+	}
+
+	messages := out.Messages
+	if messages == nil {
+		messages = []string{}
+	}
+
+	return &FsckOutput{
+		Healthy:  out.Healthy,
+		Messages: messages,
+	}, nil
+}
+
+type DiskUsageOutput struct {
+	// DiskSize is the size of all files of the repository on disk in bytes.
+	DiskSize int64 `json:"disk_size"`
+	// LooseObjects and LooseSize (in KiB) describe the objects that aren't packed yet.
+	LooseObjects int   `json:"loose_objects"`
+	LooseSize    int64 `json:"loose_size"`
+	// PackedObjects and PackSize (in KiB) describe the objects stored in packs.
+	PackedObjects int   `json:"packed_objects"`
+	Packs         int   `json:"packs"`
+	PackSize      int64 `json:"pack_size"`
+	// PrunePackable is the number of loose objects that are also present in packs.
+	PrunePackable int `json:"prune_packable"`
+	// Garbage and GarbageSize (in KiB) describe files in the object database that are neither objects nor packs.
+	Garbage     int   `json:"garbage"`
+	GarbageSize int64 `json:"garbage_size"`
+}
+
+// DiskUsage returns the object statistics and the disk usage of a repository.
+// Only admins are allowed to run repository maintenance operations.
+func (c *Controller) DiskUsage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*DiskUsageOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.git.GetRepositoryDiskUsage(ctx, &git.GetRepositoryDiskUsageParams{
+		ReadParams: git.CreateReadParams(repo),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository disk usage: %w", err)
+	}
+
+	return &DiskUsageOutput{
+		DiskSize:      out.DiskSize,
+		LooseObjects:  out.Objects.Count,
+		LooseSize:     out.Objects.Size,
+		PackedObjects: out.Objects.InPack,
+		Packs:         out.Objects.Packs,
+		PackSize:      out.Objects.SizePack,
+		PrunePackable: out.Objects.PrunePackable,
+		Garbage:       out.Objects.Garbage,
+		GarbageSize:   out.Objects.SizeGarbage,
+	}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListMalwareFindings lists the malware that was detected in pushes to the repository.
+func (c *Controller) ListMalwareFindings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pagination types.Pagination,
+) ([]*types.MalwareFinding, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.malwareFindings.Count(ctx, repo.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count malware findings: %w", err)
+	}
+
+	findings, err := c.malwareFindings.List(ctx, repo.ID, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list malware findings: %w", err)
+	}
+
+	return findings, count, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mergemessage"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	mergeMessageMaxTitleLength   = 1024
+	mergeMessageMaxMessageLength = 10000
+)
+
+// FindMergeMessageTemplates returns the merge commit message templates of the repository.
+// Empty templates are returned in case the repository doesn't configure any.
+func (c *Controller) FindMergeMessageTemplates(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.MergeMessageTemplates, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := c.settings.MergeMessageTemplates(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if templates == nil {
+		return &types.MergeMessageTemplates{}, nil
+	}
+
+	return templates, nil
+}
+
+// UpdateMergeMessageTemplates replaces the merge commit message templates of the repository.
+func (c *Controller) UpdateMergeMessageTemplates(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.MergeMessageTemplates,
+) (*types.MergeMessageTemplates, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Merge == nil && in.Squash == nil {
+		return nil, usererror.BadRequest("At least one template has to be provided.")
+	}
+
+	if err = sanitizeCommitMessageTemplate(in.Merge); err != nil {
+		return nil, err
+	}
+
+	if err = sanitizeCommitMessageTemplate(in.Squash); err != nil {
+		return nil, err
+	}
+
+	if err = c.settings.SetMergeMessageTemplates(ctx, repo.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// DeleteMergeMessageTemplates removes the merge commit message templates of the repository.
+func (c *Controller) DeleteMergeMessageTemplates(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.settings.DeleteMergeMessageTemplates(ctx, repo.ID)
+}
+
+func sanitizeCommitMessageTemplate(in *types.CommitMessageTemplate) error {
+	if in == nil {
+		return nil
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Title == "" {
+		return usererror.BadRequest("The commit title template can't be empty.")
+	}
+
+	if len(in.Title) > mergeMessageMaxTitleLength {
+		return usererror.BadRequestf("The commit title template can be at most %d characters long.",
+			mergeMessageMaxTitleLength)
+	}
+
+	if len(in.Message) > mergeMessageMaxMessageLength {
+		return usererror.BadRequestf("The commit message template can be at most %d characters long.",
+			mergeMessageMaxMessageLength)
+	}
+
+	if err := mergemessage.Validate(in.Title); err != nil {
+		return usererror.BadRequestf("The commit title template is invalid: %s.", err)
+	}
+
+	if err := mergemessage.Validate(in.Message); err != nil {
+		return usererror.BadRequestf("The commit message template is invalid: %s.", err)
+	}
+
+	return nil
+}
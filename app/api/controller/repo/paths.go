@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SearchPaths returns the file paths of the tree at the git ref that fuzzy match the query, best matches first.
+func (c *Controller) SearchPaths(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	query string,
+	limit int,
+) ([]types.PathMatch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	matches, err := c.pathIndex.Search(ctx, repo, gitRef, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search paths: %w", err)
+	}
+
+	return matches, nil
+}
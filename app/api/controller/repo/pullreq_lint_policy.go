@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	pullReqLintMaxTitlePatternLength = 1024
+	pullReqLintMaxDescriptionLength  = 10000
+	pullReqLintMaxSections           = 20
+	pullReqLintMaxSectionLength      = 100
+)
+
+// FindPullReqLintPolicy returns the pull request lint policy of the repository.
+// A policy without requirements is returned in case the repository doesn't configure any.
+func (c *Controller) FindPullReqLintPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PullReqLintPolicy, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := c.settings.PullReqLintPolicy(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return &types.PullReqLintPolicy{RequiredSections: []string{}}, nil
+	}
+
+	return policy, nil
+}
+
+// UpdatePullReqLintPolicy replaces the pull request lint policy of the repository.
+// Open pull requests are linted against the new policy when they get merged or updated.
+func (c *Controller) UpdatePullReqLintPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.PullReqLintPolicy,
+) (*types.PullReqLintPolicy, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sanitizePullReqLintPolicy(in); err != nil {
+		return nil, err
+	}
+
+	if err = c.settings.SetPullReqLintPolicy(ctx, repo.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// DeletePullReqLintPolicy removes the pull request lint policy of the repository.
+func (c *Controller) DeletePullReqLintPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.settings.DeletePullReqLintPolicy(ctx, repo.ID)
+}
+
+func sanitizePullReqLintPolicy(in *types.PullReqLintPolicy) error {
+	in.TitlePattern = strings.TrimSpace(in.TitlePattern)
+
+	if len(in.TitlePattern) > pullReqLintMaxTitlePatternLength {
+		return usererror.BadRequestf("The title pattern can be at most %d characters long.",
+			pullReqLintMaxTitlePatternLength)
+	}
+
+	if _, err := regexp.Compile(in.TitlePattern); err != nil {
+		return usererror.BadRequestf("The title pattern is not a valid regular expression: %s", err)
+	}
+
+	if in.MinDescriptionLength < 0 || in.MinDescriptionLength > pullReqLintMaxDescriptionLength {
+		return usererror.BadRequestf("Minimum description length has to be between 0 and %d.",
+			pullReqLintMaxDescriptionLength)
+	}
+
+	if len(in.RequiredSections) > pullReqLintMaxSections {
+		return usererror.BadRequestf("At most %d sections can be required.", pullReqLintMaxSections)
+	}
+
+	sections := make([]string, 0, len(in.RequiredSections))
+	for _, section := range in.RequiredSections {
+		section = strings.TrimSpace(section)
+		if section == "" {
+			return usererror.BadRequest("Required sections can't be empty.")
+		}
+		if len(section) > pullReqLintMaxSectionLength {
+			return usererror.BadRequestf("Required sections can be at most %d characters long.",
+				pullReqLintMaxSectionLength)
+		}
+		sections = append(sections, section)
+	}
+
+	in.RequiredSections = sections
+
+	if in.TitlePattern == "" && in.MinDescriptionLength == 0 && len(in.RequiredSections) == 0 {
+		return usererror.BadRequest("The policy has to define at least one requirement.")
+	}
+
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -64,6 +65,11 @@ func (c *Controller) PurgeNoAuth(
 	session *auth.Session,
 	repo *types.Repository,
 ) error {
+	// forks borrow the objects of the repo, they would be corrupted once the git repository is deleted.
+	if err := c.detachForks(ctx, repo); err != nil {
+		return err
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
@@ -72,6 +78,10 @@ func (c *Controller) PurgeNoAuth(
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
 
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
@@ -108,5 +118,68 @@ func (c *Controller) DeleteGitRepository(
 	} else if err != nil {
 		return fmt.Errorf("failed to remove git repository %s: %w", repo.GitUID, err)
 	}
+
+	wikiWriteParams, err := c.createWikiWriteParams(ctx, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params for wiki: %w", err)
+	}
+
+	// the wiki repository is only created on first use of the wiki.
+	err = c.git.DeleteRepository(ctx, &git.DeleteRepositoryParams{
+		WriteParams: wikiWriteParams,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove wiki git repository of %s: %w", repo.GitUID, err)
+	}
+
 	return nil
 }
+
+// detachForks copies the objects the forks of the repo borrow from it into the forks.
+func (c *Controller) detachForks(ctx context.Context, repo *types.Repository) error {
+	forks, err := c.repoStore.ListForks(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		err = c.git.DetachRepositoryObjects(ctx, &git.DetachRepositoryObjectsParams{
+			ReadParams: git.CreateReadParams(fork),
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to detach objects of fork %d: %w", fork.ID, err)
+		}
+
+		log.Ctx(ctx).Info().
+			Int64("repo.id", repo.ID).
+			Int64("fork.id", fork.ID).
+			Msg("detached objects of fork")
+	}
+
+	return nil
+}
+
+// decrementNumForks decrements the number of forks of the forked repository (if it still exists).
+func (c *Controller) decrementNumForks(ctx context.Context, sourceID int64) {
+	source, err := c.repoStore.Find(ctx, sourceID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find forked repository")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, source, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of forked repository")
+	}
+}
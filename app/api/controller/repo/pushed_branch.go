@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PushedBranch returns the latest branch the user created by pushing to the repository.
+// Branches that were deleted in the meantime or already have an open pull request aren't returned.
+func (c *Controller) PushedBranch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PushedBranch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pushedBranch, err := c.pushedBranchStore.Find(ctx, repo.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pushed branch: %w", err)
+	}
+
+	outdated, err := c.isPushedBranchOutdated(ctx, repo, pushedBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	if outdated {
+		if err = c.pushedBranchStore.Delete(ctx, repo.ID, session.Principal.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete outdated pushed branch: %w", err)
+		}
+
+		return nil, usererror.ErrNotFound
+	}
+
+	pushedBranch.CompareURL = c.urlProvider.GenerateUICompareURL(repo.Path, repo.DefaultBranch, pushedBranch.Branch)
+
+	return pushedBranch, nil
+}
+
+// isPushedBranchOutdated returns true if the pushed branch doesn't exist anymore
+// or if there's already an open pull request for it.
+func (c *Controller) isPushedBranchOutdated(
+	ctx context.Context,
+	repo *types.Repository,
+	pushedBranch *types.PushedBranch,
+) (bool, error) {
+	_, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: pushedBranch.Branch,
+	})
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get pushed branch: %w", err)
+	}
+
+	count, err := c.pullreqStore.Count(ctx, &types.PullReqFilter{
+		SourceRepoID: repo.ID,
+		SourceBranch: pushedBranch.Branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to count pull requests of pushed branch: %w", err)
+	}
+
+	return count > 0, nil
+}
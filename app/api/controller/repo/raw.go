@@ -25,6 +25,14 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// RawContent is the raw content of a file.
+type RawContent struct {
+	Data io.ReadCloser
+	Size int64
+	// SHA is the sha of the git blob, it uniquely identifies the content.
+	SHA string
+}
+
 // Raw finds the file of the repo at the given path and returns its raw content.
 // If no gitRef is provided, the content is retrieved from the default branch.
 func (c *Controller) Raw(ctx context.Context,
@@ -32,10 +40,10 @@ func (c *Controller) Raw(ctx context.Context,
 	repoRef string,
 	gitRef string,
 	repoPath string,
-) (io.ReadCloser, int64, error) {
+) (*RawContent, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	// set gitRef to default branch in case an empty reference was provided
@@ -52,12 +60,12 @@ func (c *Controller) Raw(ctx context.Context,
 		IncludeLatestCommit: false,
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read tree node: %w", err)
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
 	}
 
 	// viewing Raw content is only supported for blob content
 	if treeNodeOutput.Node.Type != git.TreeNodeTypeBlob {
-		return nil, 0, usererror.BadRequestf(
+		return nil, usererror.BadRequestf(
 			"Object in '%s' at '/%s' is of type '%s'. Only objects of type %s support raw viewing.",
 			gitRef, repoPath, treeNodeOutput.Node.Type, git.TreeNodeTypeBlob)
 	}
@@ -68,8 +76,12 @@ func (c *Controller) Raw(ctx context.Context,
 		SizeLimit:  0, // no size limit, we stream whatever data there is
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read blob: %w", err)
+		return nil, fmt.Errorf("failed to read blob: %w", err)
 	}
 
-	return blobReader.Content, blobReader.ContentSize, nil
+	return &RawContent{
+		Data: blobReader.Content,
+		Size: blobReader.ContentSize,
+		SHA:  treeNodeOutput.Node.SHA,
+	}, nil
 }
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxReadmeSize is the maximum size of a README file that is returned and rendered.
+	maxReadmeSize = 1 << 20 // 1 MiB
+
+	attributeExportIgnore = "export-ignore"
+)
+
+var (
+	readmeNameRegex = regexp.MustCompile(`(?i)^readme(\.[\w\-]+)?$`)
+
+	// readmeExtensionPriority defines which README is picked if a directory contains several of them.
+	// Files with extensions that aren't listed come last.
+	readmeExtensionPriority = []string{".md", ".markdown", ".mdown", ".mkdn", "", ".txt", ".rst", ".adoc"}
+
+	readmeMarkdownExtensions = map[string]bool{".md": true, ".markdown": true, ".mdown": true, ".mkdn": true}
+)
+
+type ReadmeOutput struct {
+	Path string `json:"path"`
+	SHA  string `json:"sha"`
+	Size int64  `json:"size"`
+	// Content is the raw content of the README, it's truncated if the README is larger than 1 MiB.
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated"`
+	// HTML is the rendered, sanitized content. Files that aren't markdown are rendered as preformatted text.
+	HTML string `json:"html"`
+}
+
+// Readme finds the README file of a directory and returns its content and its rendered HTML.
+// README files that are excluded from archives with the export-ignore git attribute are ignored.
+func (c *Controller) Readme(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	dirPath string,
+) (*ReadmeOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	treeOut, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       dirPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+
+	var candidates []git.TreeNode
+	for _, node := range treeOut.Nodes {
+		if node.Type == git.TreeNodeTypeBlob && node.Mode != git.TreeNodeModeSymlink &&
+			readmeNameRegex.MatchString(node.Name) {
+			candidates = append(candidates, node)
+		}
+	}
+
+	candidates, err = c.filterExportIgnored(ctx, readParams, gitRef, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, usererror.NotFound("README not found")
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		pi, pj := readmePriority(candidates[i].Name), readmePriority(candidates[j].Name)
+		if pi != pj {
+			return pi < pj
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	readme := candidates[0]
+
+	blobOut, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        readme.SHA,
+		SizeLimit:  maxReadmeSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get README content: %w", err)
+	}
+
+	defer func() {
+		if err := blobOut.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	content, err := io.ReadAll(blobOut.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read README content: %w", err)
+	}
+
+	var rendered string
+	if readmeMarkdownExtensions[strings.ToLower(path.Ext(readme.Name))] {
+		rendered, err = c.markdownRenderer.Render(content, markdown.Options{
+			RepoPath: repo.Path,
+			GitRef:   gitRef,
+			Path:     readme.Path,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rendered = "<pre>" + html.EscapeString(string(content)) + "</pre>"
+	}
+
+	return &ReadmeOutput{
+		Path:      readme.Path,
+		SHA:       readme.SHA,
+		Size:      blobOut.Size,
+		Content:   string(content),
+		Truncated: blobOut.ContentSize < blobOut.Size,
+		HTML:      rendered,
+	}, nil
+}
+
+// filterExportIgnored removes the nodes which have the export-ignore git attribute set.
+func (c *Controller) filterExportIgnored(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+	nodes []git.TreeNode,
+) ([]git.TreeNode, error) {
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+
+	paths := make([]string, len(nodes))
+	for i, node := range nodes {
+		paths[i] = node.Path
+	}
+
+	attrOut, err := c.git.CheckAttributes(ctx, &git.CheckAttributesParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Attributes: []string{attributeExportIgnore},
+		Paths:      paths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check git attributes: %w", err)
+	}
+
+	filtered := make([]git.TreeNode, 0, len(nodes))
+	for _, node := range nodes {
+		if attrOut.Attributes[node.Path][attributeExportIgnore] == git.AttributeValueSet {
+			continue
+		}
+		filtered = append(filtered, node)
+	}
+
+	return filtered, nil
+}
+
+func readmePriority(name string) int {
+	ext := strings.ToLower(path.Ext(name))
+	for i, e := range readmeExtensionPriority {
+		if e == ext {
+			return i
+		}
+	}
+
+	return len(readmeExtensionPriority)
+}
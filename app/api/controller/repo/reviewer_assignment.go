@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	reviewerAssignmentMaxPoolSize = 100
+	reviewerAssignmentMaxCount    = 10
+)
+
+// FindReviewerAssignment returns the automatic reviewer assignment settings of the repository.
+// Settings without any assignment are returned in case the repository doesn't configure any.
+func (c *Controller) FindReviewerAssignment(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.ReviewerAssignmentSettings, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := c.settings.ReviewerAssignmentSettings(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil {
+		return &types.ReviewerAssignmentSettings{
+			Strategy: enum.ReviewerAssignmentStrategyRoundRobin,
+			Pool:     []int64{},
+		}, nil
+	}
+
+	return settings, nil
+}
+
+// UpdateReviewerAssignment replaces the automatic reviewer assignment settings of the repository.
+// The settings apply to pull requests opened afterwards.
+func (c *Controller) UpdateReviewerAssignment(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.ReviewerAssignmentSettings,
+) (*types.ReviewerAssignmentSettings, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeReviewerAssignment(ctx, in); err != nil {
+		return nil, err
+	}
+
+	if err = c.settings.SetReviewerAssignmentSettings(ctx, repo.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// DeleteReviewerAssignment removes the automatic reviewer assignment settings of the repository.
+func (c *Controller) DeleteReviewerAssignment(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.settings.DeleteReviewerAssignmentSettings(ctx, repo.ID)
+}
+
+func (c *Controller) sanitizeReviewerAssignment(ctx context.Context, in *types.ReviewerAssignmentSettings) error {
+	strategy, ok := in.Strategy.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported reviewer assignment strategy: %s", in.Strategy)
+	}
+
+	in.Strategy = strategy
+
+	if len(in.Pool) > reviewerAssignmentMaxPoolSize {
+		return usererror.BadRequestf("The reviewer pool can have at most %d members.", reviewerAssignmentMaxPoolSize)
+	}
+
+	pool := make([]int64, 0, len(in.Pool))
+	seen := make(map[int64]struct{}, len(in.Pool))
+	for _, principalID := range in.Pool {
+		if _, ok := seen[principalID]; ok {
+			continue
+		}
+		seen[principalID] = struct{}{}
+
+		principal, err := c.principalStore.Find(ctx, principalID)
+		if err != nil {
+			return fmt.Errorf("failed to find reviewer pool member %d: %w", principalID, err)
+		}
+
+		if principal.Type != enum.PrincipalTypeUser {
+			return usererror.BadRequestf("Only users can be members of the reviewer pool: %s", principal.UID)
+		}
+
+		pool = append(pool, principalID)
+	}
+
+	in.Pool = pool
+
+	if in.Count < 0 || in.Count > reviewerAssignmentMaxCount {
+		return usererror.BadRequestf("Count has to be between 0 and %d.", reviewerAssignmentMaxCount)
+	}
+
+	if in.Count > len(in.Pool) {
+		return usererror.BadRequest("Count can't exceed the size of the reviewer pool.")
+	}
+
+	if in.Count == 0 && !in.CodeOwners {
+		return usererror.BadRequest("Reviewers have to be assigned from the reviewer pool or from CODEOWNERS.")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SignedURLInput struct {
+	Resource enum.SignedURLResource `json:"resource"`
+	// GitRef is the git ref of the raw file or the archive (default branch if empty).
+	GitRef string `json:"git_ref"`
+	// Path is the path of the raw file.
+	Path string `json:"path"`
+	// Format is the format of the archive.
+	Format gitenum.ArchiveFormat `json:"format"`
+	// ExpiresIn is the number of seconds the URL is valid for (server default if zero).
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+func (in *SignedURLInput) sanitize() error {
+	resource, ok := in.Resource.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Resource has to be one of %v.", enum.SignedURLResource("").Enum())
+	}
+	in.Resource = resource
+
+	in.GitRef = strings.TrimSpace(in.GitRef)
+	in.Path = strings.Trim(in.Path, "/")
+
+	switch in.Resource {
+	case enum.SignedURLResourceRaw:
+		if in.Path == "" {
+			return usererror.BadRequest("Path is required for raw content.")
+		}
+	case enum.SignedURLResourceArchive:
+		format, ok := in.Format.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Format has to be one of %v.", gitenum.ArchiveFormats)
+		}
+		in.Format = format
+	}
+
+	if in.ExpiresIn < 0 {
+		return usererror.BadRequest("Expiry can't be negative.")
+	}
+
+	return nil
+}
+
+// CreateSignedURL creates a short-lived URL for downloading raw content or an archive of the repository,
+// which can be used without any other credentials. The URL grants the read access of the calling principal.
+func (c *Controller) CreateSignedURL(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SignedURLInput,
+) (*types.SignedURL, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	// the signed url is authenticated as the principal itself, so any access restriction of the session would be lost.
+	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+		return nil, usererror.Forbidden("Signed URLs can't be created with scoped credentials.")
+	}
+
+	gitRef := in.GitRef
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	repoPath := "/v1/repos/" + repo.Path
+	query := url.Values{}
+
+	var resourcePath string
+	switch in.Resource {
+	case enum.SignedURLResourceRaw:
+		resourcePath = repoPath + "/raw/" + in.Path
+		query.Set(request.QueryParamGitRef, gitRef)
+	case enum.SignedURLResourceArchive:
+		resourcePath = repoPath + "/archive/" + gitRef + "." + string(in.Format)
+	}
+
+	expires := time.Now().Add(c.urlSigner.Expiry(in.ExpiresIn))
+	query = c.urlSigner.Sign(resourcePath, query, session.Principal.ID, expires)
+
+	// the repo path is escaped as a single path segment, same as the repo reference of the api routes.
+	escapedPath := "v1/repos/" + url.PathEscape(repo.Path) +
+		(&url.URL{Path: strings.TrimPrefix(resourcePath, repoPath)}).EscapedPath()
+
+	return &types.SignedURL{
+		URL:     c.urlProvider.GenerateAPIURL(escapedPath) + "?" + query.Encode(),
+		Expires: expires.UnixMilli(),
+	}, nil
+}
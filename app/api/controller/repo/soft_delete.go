@@ -71,6 +71,8 @@ func (c *Controller) SoftDelete(
 		return nil, fmt.Errorf("failed to soft delete repo: %w", err)
 	}
 
+	c.systemReporter.RepoDeleted(ctx, session.Principal.ID, repo)
+
 	return &SoftDeleteResponse{DeletedAt: now}, nil
 }
 
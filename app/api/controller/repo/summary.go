@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Summary returns an overview of the repository: the number of branches, tags and pull requests
+// and the language distribution of the default branch.
+func (c *Controller) Summary(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositorySummary, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	branchesOut, err := c.git.ListBranches(ctx, &git.ListBranchesParams{
+		ReadParams: git.CreateReadParams(repo),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	tagsOut, err := c.git.ListCommitTags(ctx, &git.ListCommitTagsParams{
+		ReadParams: git.CreateReadParams(repo),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	pullReqSummary := types.RepositoryPullReqSummary{}
+	if pullReqSummary.OpenCount, err = c.countPullReqs(ctx, repo, enum.PullReqStateOpen); err != nil {
+		return nil, err
+	}
+	if pullReqSummary.ClosedCount, err = c.countPullReqs(ctx, repo, enum.PullReqStateClosed); err != nil {
+		return nil, err
+	}
+	if pullReqSummary.MergedCount, err = c.countPullReqs(ctx, repo, enum.PullReqStateMerged); err != nil {
+		return nil, err
+	}
+
+	languages, _, err := c.findLanguages(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RepositorySummary{
+		BranchCount:    int64(len(branchesOut.Branches)),
+		TagCount:       int64(len(tagsOut.Tags)),
+		PullReqSummary: pullReqSummary,
+		Languages:      languages,
+	}, nil
+}
+
+func (c *Controller) countPullReqs(
+	ctx context.Context,
+	repo *types.Repository,
+	state enum.PullReqState,
+) (int64, error) {
+	count, err := c.pullreqStore.Count(ctx, &types.PullReqFilter{
+		TargetRepoID: repo.ID,
+		States:       []enum.PullReqState{state},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s pull requests: %w", state, err)
+	}
+
+	return count, nil
+}
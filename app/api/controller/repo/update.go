@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -29,11 +30,27 @@ import (
 type UpdateInput struct {
 	Description *string `json:"description"`
 	IsPublic    *bool   `json:"is_public"`
+
+	PullReqSuggestions        *bool `json:"pullreq_suggestions"`
+	PullReqDeleteSourceBranch *bool `json:"pullreq_delete_source_branch"`
+
+	// Version is optional, if provided the update fails if the repository has been modified in the meantime.
+	Version *int64 `json:"version"`
 }
 
 func (in *UpdateInput) hasChanges(repo *types.Repository) bool {
 	return (in.Description != nil && *in.Description != repo.Description) ||
-		(in.IsPublic != nil && *in.IsPublic != repo.IsPublic)
+		(in.IsPublic != nil && *in.IsPublic != repo.IsPublic) ||
+		(in.PullReqSuggestions != nil && *in.PullReqSuggestions != repo.PullReqSuggestions) ||
+		(in.PullReqDeleteSourceBranch != nil && *in.PullReqDeleteSourceBranch != repo.PullReqDeleteSourceBranch)
+}
+
+func (in *UpdateInput) checkVersion(repo *types.Repository) error {
+	if in.Version != nil && *in.Version != repo.Version {
+		return usererror.ErrVersionConflict
+	}
+
+	return nil
 }
 
 // Update updates a repository.
@@ -47,6 +64,10 @@ func (c *Controller) Update(ctx context.Context,
 		return nil, err
 	}
 
+	if err = in.checkVersion(repo); err != nil {
+		return nil, err
+	}
+
 	if !in.hasChanges(repo) {
 		return repo, nil
 	}
@@ -56,6 +77,10 @@ func (c *Controller) Update(ctx context.Context,
 	}
 
 	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		if err := in.checkVersion(repo); err != nil {
+			return err
+		}
+
 		// update values only if provided
 		if in.Description != nil {
 			repo.Description = *in.Description
@@ -63,6 +88,12 @@ func (c *Controller) Update(ctx context.Context,
 		if in.IsPublic != nil {
 			repo.IsPublic = *in.IsPublic
 		}
+		if in.PullReqSuggestions != nil {
+			repo.PullReqSuggestions = *in.PullReqSuggestions
+		}
+		if in.PullReqDeleteSourceBranch != nil {
+			repo.PullReqDeleteSourceBranch = *in.PullReqDeleteSourceBranch
+		}
 
 		return nil
 	})
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListVulnerabilityAlerts lists the alerts about known vulnerabilities that affect dependencies of the repository.
+func (c *Controller) ListVulnerabilityAlerts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.VulnerabilityAlertFilter,
+) ([]*types.VulnerabilityAlert, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.vulnerabilityAlerts.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count vulnerability alerts: %w", err)
+	}
+
+	alerts, err := c.vulnerabilityAlerts.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list vulnerability alerts: %w", err)
+	}
+
+	return alerts, count, nil
+}
@@ -0,0 +1,293 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// wikiGitUIDSuffix is appended to the git uid of a repo to get the git uid of its wiki repository.
+	wikiGitUIDSuffix = "-wiki"
+
+	// wikiRepoRefSuffix is appended to the path of a repo in clone urls referencing its wiki.
+	wikiRepoRefSuffix = ".wiki"
+
+	// wikiGitRef is the git reference from which the wiki pages are read and to which they are written.
+	wikiGitRef = "HEAD"
+
+	wikiPageExtension     = ".md"
+	wikiPageSlugMaxLength = 200
+)
+
+var wikiPageSlugRegex = regexp.MustCompile(`^[\p{L}\p{N}_\-][\p{L}\p{N}_.\-]*$`)
+
+// wikiRepository is the companion git repository that holds the wiki pages of a repo.
+type wikiRepository struct {
+	*types.Repository
+}
+
+func (r wikiRepository) GetGitUID() string {
+	return r.GitUID + wikiGitUIDSuffix
+}
+
+// FindWiki returns the details of the wiki of a repo.
+func (c *Controller) FindWiki(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Wiki, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Wiki{
+		GitURL: c.urlProvider.GenerateGITCloneURL(repo.Path + wikiRepoRefSuffix),
+	}, nil
+}
+
+// getGitRepoCheckAccess fetches the repo referenced by a git clone url and checks if the user has the required access.
+// Clone urls of the form `<repo>.wiki.git` reference the wiki of the repo, which shares the permissions of the repo.
+func (c *Controller) getGitRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+	orPublic bool,
+) (*types.Repository, bool, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission, orPublic)
+	if err == nil || !errors.Is(err, store.ErrResourceNotFound) || !strings.HasSuffix(repoRef, wikiRepoRefSuffix) {
+		return repo, false, err
+	}
+
+	repo, err = c.getRepoCheckAccess(ctx, session,
+		strings.TrimSuffix(repoRef, wikiRepoRefSuffix), reqPermission, orPublic)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repo, true, nil
+}
+
+// ensureWiki creates the wiki repository of the repo in case it doesn't exist yet.
+func (c *Controller) ensureWiki(ctx context.Context, repo *types.Repository) error {
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		systemPrincipal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	_, err = c.git.CreateRepository(ctx, &git.CreateRepositoryParams{
+		RepoUID:       wikiRepository{repo}.GetGitUID(),
+		Actor:         *identityFromPrincipal(systemPrincipal),
+		EnvVars:       envVars,
+		DefaultBranch: repo.DefaultBranch,
+	})
+	if errors.IsConflict(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create wiki repository: %w", err)
+	}
+
+	return nil
+}
+
+// createWikiWriteParams creates the write parameters for git write operations on the wiki repository.
+// Githooks are disabled, as protection rules and events of the repo don't apply to its wiki.
+func (c *Controller) createWikiWriteParams(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) (git.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		session.Principal.ID,
+		true,
+		false,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  session.Principal.DisplayName,
+			Email: session.Principal.Email,
+		},
+		RepoUID: wikiRepository{repo}.GetGitUID(),
+		EnvVars: envVars,
+	}, nil
+}
+
+// commitWikiPage commits a single page change to the wiki repository of the repo.
+func (c *Controller) commitWikiPage(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	title string,
+	message string,
+	action git.CommitFileAction,
+) error {
+	if err := c.ensureWiki(ctx, repo); err != nil {
+		return err
+	}
+
+	writeParams, err := c.createWikiWriteParams(ctx, session, repo)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = c.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams:   writeParams,
+		Title:         title,
+		Message:       message,
+		Actions:       []git.CommitFileAction{action},
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit wiki page: %w", err)
+	}
+
+	return nil
+}
+
+// getWikiPage reads the page with the provided slug from the wiki repository of the repo.
+func (c *Controller) getWikiPage(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	slug string,
+) (*types.WikiPage, error) {
+	if err := checkWikiPageSlug(slug); err != nil {
+		return nil, err
+	}
+
+	readParams := git.CreateReadParams(wikiRepository{repo})
+
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams:          readParams,
+		GitREF:              gitRef,
+		Path:                slug + wikiPageExtension,
+		IncludeLatestCommit: true,
+	})
+	if errors.IsNotFound(err) {
+		return nil, usererror.NotFound(fmt.Sprintf("Wiki page '%s' not found.", slug))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiki page tree node: %w", err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return nil, usererror.NotFound(fmt.Sprintf("Wiki page '%s' not found.", slug))
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  0, // pages are always returned in full
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiki page blob: %w", err)
+	}
+
+	defer func() {
+		_ = blob.Content.Close()
+	}()
+
+	content := new(strings.Builder)
+	if _, err = io.Copy(content, blob.Content); err != nil {
+		return nil, fmt.Errorf("failed to read wiki page content: %w", err)
+	}
+
+	page := mapWikiPage(node.Node)
+	page.Content = content.String()
+
+	if node.Commit != nil {
+		page.LatestCommit, err = controller.MapCommit(node.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map latest commit of wiki page: %w", err)
+		}
+	}
+
+	return page, nil
+}
+
+// wikiPageSlug converts a page title to the slug of the page, which is used as name of the page file.
+func wikiPageSlug(title string) (string, error) {
+	slug := strings.Join(strings.Fields(title), "-")
+	if slug == "" {
+		return "", usererror.BadRequest("Wiki page title can't be empty.")
+	}
+
+	if err := checkWikiPageSlug(slug); err != nil {
+		return "", err
+	}
+
+	return slug, nil
+}
+
+func checkWikiPageSlug(slug string) error {
+	if len(slug) > wikiPageSlugMaxLength {
+		return usererror.BadRequestf("Wiki page title can be at most %d characters long.", wikiPageSlugMaxLength)
+	}
+
+	if !wikiPageSlugRegex.MatchString(slug) {
+		return usererror.BadRequest(
+			"Wiki page title can only contain letters, numbers, spaces, '-', '_' and '.', and can't start with '.'.")
+	}
+
+	return nil
+}
+
+func mapWikiPage(node git.TreeNode) *types.WikiPage {
+	slug := strings.TrimSuffix(node.Name, wikiPageExtension)
+	return &types.WikiPage{
+		Slug:  slug,
+		Title: strings.ReplaceAll(slug, "-", " "),
+		Path:  node.Path,
+		SHA:   node.SHA,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateWikiPageInput struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// Message is the commit message of the change (Optional).
+	Message string `json:"message"`
+}
+
+// CreateWikiPage creates a new page in the wiki of a repo.
+func (c *Controller) CreateWikiPage(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateWikiPageInput,
+) (*types.WikiPage, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := wikiPageSlug(in.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.commitWikiPage(ctx, session, repo,
+		fmt.Sprintf("Create wiki page %q", strings.ReplaceAll(slug, "-", " ")),
+		in.Message,
+		git.CommitFileAction{
+			Action:  git.CreateAction,
+			Path:    slug + wikiPageExtension,
+			Payload: []byte(in.Content),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getWikiPage(ctx, repo, wikiGitRef, slug)
+}
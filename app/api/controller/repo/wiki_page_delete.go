@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeleteWikiPage deletes a page of the wiki of a repo.
+func (c *Controller) DeleteWikiPage(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	slug string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return err
+	}
+
+	if err = c.ensureWiki(ctx, repo); err != nil {
+		return err
+	}
+
+	page, err := c.getWikiPage(ctx, repo, wikiGitRef, slug)
+	if err != nil {
+		return err
+	}
+
+	return c.commitWikiPage(ctx, session, repo,
+		fmt.Sprintf("Delete wiki page %q", page.Title),
+		"",
+		git.CommitFileAction{
+			Action: git.DeleteAction,
+			Path:   page.Path,
+		})
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindWikiPage finds a page of the wiki of a repo and returns it together with its content.
+// If no gitRef is provided, the page is read from the default branch of the wiki.
+func (c *Controller) FindWikiPage(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	slug string,
+) (*types.WikiPage, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.ensureWiki(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = wikiGitRef
+	}
+
+	return c.getWikiPage(ctx, repo, gitRef, slug)
+}
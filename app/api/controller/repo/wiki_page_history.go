@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListWikiPageHistory lists the revisions of a page of the wiki of a repo, starting with the latest revision.
+func (c *Controller) ListWikiPageHistory(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	slug string,
+	filter *types.PaginationFilter,
+) ([]types.Commit, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkWikiPageSlug(slug); err != nil {
+		return nil, err
+	}
+
+	if err = c.ensureWiki(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	out, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(wikiRepository{repo}),
+		GitREF:     wikiGitRef,
+		Page:       int32(filter.Page),
+		Limit:      int32(filter.Limit),
+		Path:       slug + wikiPageExtension,
+	})
+	if errors.IsNotFound(err) {
+		// the wiki doesn't have any commits yet
+		return []types.Commit{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wiki page commits: %w", err)
+	}
+
+	commits := make([]types.Commit, len(out.Commits))
+	for i := range out.Commits {
+		var commit *types.Commit
+		commit, err = controller.MapCommit(&out.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListWikiPages lists all pages of the wiki of a repo.
+// If no gitRef is provided, the pages are listed from the default branch of the wiki.
+func (c *Controller) ListWikiPages(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) ([]*types.WikiPage, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.ensureWiki(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = wikiGitRef
+	}
+
+	out, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: git.CreateReadParams(wikiRepository{repo}),
+		GitREF:     gitRef,
+		Path:       "",
+	})
+	if errors.IsNotFound(err) {
+		// the wiki doesn't have any commits yet
+		return []*types.WikiPage{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wiki tree nodes: %w", err)
+	}
+
+	pages := make([]*types.WikiPage, 0, len(out.Nodes))
+	for _, node := range out.Nodes {
+		if node.Type != git.TreeNodeTypeBlob || !strings.HasSuffix(node.Name, wikiPageExtension) {
+			continue
+		}
+
+		pages = append(pages, mapWikiPage(node))
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateWikiPageInput struct {
+	// Title renames the page if it's different from the current title (Optional).
+	Title   *string `json:"title"`
+	Content *string `json:"content"`
+	// Message is the commit message of the change (Optional).
+	Message string `json:"message"`
+
+	// SHA can be used for optimistic locking of the update (Optional).
+	// The provided value is compared against the latest sha of the page that's being updated.
+	// If the SHA doesn't match, the update fails.
+	SHA string `json:"sha"`
+}
+
+func (in *UpdateWikiPageInput) isEmpty() bool {
+	return in.Title == nil && in.Content == nil
+}
+
+// UpdateWikiPage updates the content of a page of the wiki of a repo and optionally renames it.
+func (c *Controller) UpdateWikiPage(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	slug string,
+	in *UpdateWikiPageInput,
+) (*types.WikiPage, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.isEmpty() {
+		return nil, usererror.BadRequest("Nothing to update.")
+	}
+
+	if err = c.ensureWiki(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	page, err := c.getWikiPage(ctx, repo, wikiGitRef, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	newSlug := page.Slug
+	if in.Title != nil {
+		newSlug, err = wikiPageSlug(*in.Title)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	content := page.Content
+	if in.Content != nil {
+		content = *in.Content
+	}
+
+	action := git.CommitFileAction{
+		Action:  git.UpdateAction,
+		Path:    page.Path,
+		Payload: []byte(content),
+		SHA:     in.SHA,
+	}
+	if newSlug != page.Slug {
+		// the payload of a move action is the new path followed by the new content, separated by a null byte.
+		action.Action = git.MoveAction
+		action.Payload = append([]byte(newSlug+wikiPageExtension+"\x00"), action.Payload...)
+	}
+
+	err = c.commitWikiPage(ctx, session, repo,
+		fmt.Sprintf("Update wiki page %q", page.Title),
+		in.Message,
+		action)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getWikiPage(ctx, repo, wikiGitRef, newSlug)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWikiPageSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+		valid bool
+	}{
+		{title: "Home", want: "Home", valid: true},
+		{title: "  Getting   started ", want: "Getting-started", valid: true},
+		{title: "Release 1.2_rc", want: "Release-1.2_rc", valid: true},
+		{title: "Über uns", want: "Über-uns", valid: true},
+		{title: "", valid: false},
+		{title: "   ", valid: false},
+		{title: ".hidden", valid: false},
+		{title: "../etc/passwd", valid: false},
+		{title: "a/b", valid: false},
+		{title: strings.Repeat("a", wikiPageSlugMaxLength+1), valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			got, err := wikiPageSlug(test.title)
+			if !test.valid {
+				if err == nil {
+					t.Errorf("expected title %q to be rejected, got slug %q", test.title, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("slug = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -17,11 +17,24 @@ package repo
 import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/signedurl"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/bandwidth"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/dependencies"
+	"github.com/harness/gitness/app/services/highlight"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/services/instance"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/languages"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/app/services/pathindex"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -54,14 +67,38 @@ func ProvideController(
 	importer *importer.Repository,
 	codeOwners *codeowners.Service,
 	reporeporter *repoevents.Reporter,
+	systemReporter *systemevents.Reporter,
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
 	identifierCheck check.RepoIdentifier,
+	realtime *realtime.Service,
+	bandwidthLimiter *bandwidth.Limiter,
+	pullreqStore store.PullReqStore,
+	pushedBranchStore store.PushedBranchStore,
+	usage *usage.Service,
+	settings *settings.Service,
+	webhookStore store.WebhookStore,
+	insights *insights.Service,
+	languages *languages.Service,
+	markdownRenderer *markdown.Renderer,
+	highlighter *highlight.Service,
+	pathIndex *pathindex.Service,
+	urlSigner *signedurl.Signer,
+	instanceSettings *instance.Service,
+	malwareFindingStore store.MalwareFindingStore,
+	dependencies *dependencies.Service,
+	vulnerabilityAlertStore store.VulnerabilityAlertStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer, repoStore,
 		spaceStore, pipelineStore,
 		principalStore, ruleStore, principalInfoCache, protectionManager,
-		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager, identifierCheck)
+		rpcClient, importer, codeOwners, reporeporter, systemReporter, indexer, limiter, mtxManager, identifierCheck,
+		realtime, bandwidthLimiter, pullreqStore, pushedBranchStore, usage, settings, webhookStore,
+		insights, languages, markdownRenderer, highlighter, pathIndex, urlSigner,
+		instanceSettings,
+		malwareFindingStore,
+		dependencies,
+		vulnerabilityAlertStore)
 }
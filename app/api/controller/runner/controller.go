@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/services/runners"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const tokenLength = 32
+
+type Controller struct {
+	config           *types.Config
+	authorizer       authz.Authorizer
+	spaceStore       store.SpaceStore
+	repoStore        store.RepoStore
+	runnerStore      store.RunnerStore
+	regTokenStore    store.RunnerRegistrationTokenStore
+	stageStore       store.StageStore
+	stepStore        store.StepStore
+	executionManager manager.ExecutionManager
+	urlProvider      url.Provider
+}
+
+func NewController(
+	config *types.Config,
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	runnerStore store.RunnerStore,
+	regTokenStore store.RunnerRegistrationTokenStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	executionManager manager.ExecutionManager,
+	urlProvider url.Provider,
+) *Controller {
+	return &Controller{
+		config:           config,
+		authorizer:       authorizer,
+		spaceStore:       spaceStore,
+		repoStore:        repoStore,
+		runnerStore:      runnerStore,
+		regTokenStore:    regTokenStore,
+		stageStore:       stageStore,
+		stepStore:        stepStore,
+		executionManager: executionManager,
+		urlProvider:      urlProvider,
+	}
+}
+
+// getSpaceCheckAccess fetches the space and checks if the current user
+// has the permission to manage its runners.
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return space, nil
+}
+
+// authenticate returns the runner the access token belongs to.
+func (c *Controller) authenticate(ctx context.Context, token string) (*types.Runner, error) {
+	runner, err := c.runnerStore.FindByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	return runner, nil
+}
+
+// findLeasedStage returns the stage if it's leased by the runner.
+func (c *Controller) findLeasedStage(ctx context.Context, runner *types.Runner, stageID int64) (*types.Stage, error) {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Machine != runners.MachineName(runner.ID) {
+		return nil, usererror.Forbidden("The stage isn't leased by the runner.")
+	}
+
+	return stage, nil
+}
+
+// findLeasedStep returns the step if its stage is leased by the runner.
+func (c *Controller) findLeasedStep(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+	stepNumber int64,
+) (*types.Step, error) {
+	if _, err := c.findLeasedStage(ctx, runner, stageID); err != nil {
+		return nil, err
+	}
+
+	step, err := c.stepStore.FindByNumber(ctx, stageID, int(stepNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find step: %w", err)
+	}
+
+	return step, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate runner token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hash of the token, only the hash is stored in the database.
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+type HeartbeatInput struct {
+	Capacity int `json:"capacity"`
+	Running  int `json:"running"`
+}
+
+// Heartbeat records that the runner is alive and extends the leases of its stages.
+func (c *Controller) Heartbeat(
+	ctx context.Context,
+	token string,
+	in *HeartbeatInput,
+) (*types.RunnerHeartbeatOutput, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Capacity == 0 {
+		in.Capacity = runner.Capacity
+	}
+
+	if err = checkCapacity(in.Capacity, in.Running); err != nil {
+		return nil, err
+	}
+
+	err = c.runnerStore.UpdateHeartbeat(ctx, runner.ID, in.Capacity, in.Running, time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update runner heartbeat: %w", err)
+	}
+
+	return &types.RunnerHeartbeatOutput{
+		LeaseDuration: int64(c.config.CI.RunnerLeaseDuration / time.Second),
+	}, nil
+}
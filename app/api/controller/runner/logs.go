@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/livelog"
+)
+
+// WriteLogs streams log lines of a running step of a stage leased by the runner.
+func (c *Controller) WriteLogs(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	stepNumber int64,
+	lines []*livelog.Line,
+) error {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	step, err := c.findLeasedStep(ctx, runner, stageID, stepNumber)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if err = c.executionManager.Write(ctx, step.ID, line); err != nil {
+			return fmt.Errorf("failed to write log line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UploadLogs stores the complete logs of a finished step of a stage leased by the runner.
+func (c *Controller) UploadLogs(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	stepNumber int64,
+	r io.Reader,
+) error {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	step, err := c.findLeasedStep(ctx, runner, stageID, stepNumber)
+	if err != nil {
+		return err
+	}
+
+	if err = c.executionManager.UploadLogs(ctx, step.ID, r); err != nil {
+		return fmt.Errorf("failed to upload logs: %w", err)
+	}
+
+	return nil
+}
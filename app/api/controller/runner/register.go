@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const maxRunnerCapacity = 1000
+
+type RegisterInput struct {
+	// Token is the registration token created in the space of the runner.
+	Token      string            `json:"token"`
+	Identifier string            `json:"identifier"`
+	OS         string            `json:"os"`
+	Arch       string            `json:"arch"`
+	Kernel     string            `json:"kernel"`
+	Variant    string            `json:"variant"`
+	Labels     map[string]string `json:"labels"`
+	Version    string            `json:"version"`
+	Capacity   int               `json:"capacity"`
+}
+
+func (in *RegisterInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.OS = strings.TrimSpace(in.OS)
+	in.Arch = strings.TrimSpace(in.Arch)
+	if in.OS == "" || in.Arch == "" {
+		return usererror.BadRequest("The os and arch of the runner are required.")
+	}
+
+	if in.Capacity == 0 {
+		in.Capacity = 1
+	}
+
+	return checkCapacity(in.Capacity, 0)
+}
+
+// Register registers an external runner in the space of the registration token.
+// The access token of the runner is only returned once, afterwards only its hash is known.
+func (c *Controller) Register(ctx context.Context, in *RegisterInput) (*types.RunnerRegisterOutput, error) {
+	regToken, err := c.regTokenStore.FindByHash(ctx, hashToken(in.Token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner registration token: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	if regToken.Expires > 0 && regToken.Expires < now {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &types.Runner{
+		SpaceID:       regToken.SpaceID,
+		Identifier:    in.Identifier,
+		TokenHash:     hashToken(token),
+		OS:            in.OS,
+		Arch:          in.Arch,
+		Kernel:        in.Kernel,
+		Variant:       in.Variant,
+		Labels:        in.Labels,
+		Version:       in.Version,
+		Capacity:      in.Capacity,
+		LastHeartbeat: now,
+		Created:       now,
+		Updated:       now,
+	}
+
+	err = c.runnerStore.Create(ctx, runner)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A runner with the provided identifier is already registered in the space.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	return &types.RunnerRegisterOutput{
+		Runner:      runner,
+		AccessToken: token,
+	}, nil
+}
+
+func checkCapacity(capacity, running int) error {
+	if capacity < 1 || capacity > maxRunnerCapacity {
+		return usererror.BadRequestf("The capacity of the runner must be between 1 and %d.", maxRunnerCapacity)
+	}
+
+	if running < 0 {
+		return usererror.BadRequest("The number of running stages can't be negative.")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/services/runners"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type StageRequestInput struct {
+	Kind string `json:"kind"`
+	Type string `json:"type"`
+}
+
+// RequestStage leases the next stage the runner can execute. The request is held open for up to
+// the provided wait time (capped by the configured maximum) until a stage becomes available.
+// It returns nil if no stage became available in time.
+func (c *Controller) RequestStage(
+	ctx context.Context,
+	token string,
+	wait time.Duration,
+	in *StageRequestInput,
+) (*types.RunnerStage, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.Find(ctx, runner.SpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of runner: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	if err = c.runnerStore.UpdateHeartbeat(ctx, runner.ID, runner.Capacity, runner.Running, now); err != nil {
+		return nil, fmt.Errorf("failed to update runner heartbeat: %w", err)
+	}
+
+	if wait <= 0 || wait > c.config.CI.RunnerMaxPollWait {
+		wait = c.config.CI.RunnerMaxPollWait
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	stage, err := c.executionManager.Request(pollCtx, &manager.Request{
+		Kind:    in.Kind,
+		Type:    in.Type,
+		OS:      runner.OS,
+		Arch:    runner.Arch,
+		Kernel:  runner.Kernel,
+		Variant: runner.Variant,
+		Labels:  runner.Labels,
+		Match:   runners.SpaceMatcher(ctx, c.repoStore, space),
+	})
+	if err != nil && pollCtx.Err() != nil && ctx.Err() == nil {
+		return nil, nil //nolint:nilnil // no stage became available in time.
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to request stage: %w", err)
+	}
+
+	// the stage could have been accepted by another runner in the meantime.
+	if _, err = c.executionManager.Accept(ctx, stage.ID, runners.MachineName(runner.ID)); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Int64("stage.id", stage.ID).Msg("runner failed to accept stage")
+		return nil, nil //nolint:nilnil // no stage is leased.
+	}
+
+	execCtx, err := c.executionManager.Details(ctx, stage.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details of stage: %w", err)
+	}
+
+	return c.toRunnerStage(execCtx)
+}
+
+func (c *Controller) toRunnerStage(execCtx *manager.ExecutionContext) (*types.RunnerStage, error) {
+	// external runners clone the repository via the public clone url.
+	repo := execCtx.Repo
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	cloneURL, err := url.Parse(repo.GitURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse clone url '%s': %w", repo.GitURL, err)
+	}
+
+	secrets := make([]*types.RunnerSecret, len(execCtx.Secrets))
+	for i, secret := range execCtx.Secrets {
+		secrets[i] = &types.RunnerSecret{
+			Name: secret.Identifier,
+			Data: secret.Data,
+		}
+	}
+
+	out := &types.RunnerStage{
+		StageID:      execCtx.Stage.ID,
+		Stage:        execCtx.Stage,
+		Execution:    execCtx.Execution,
+		Repo:         repo,
+		Secrets:      secrets,
+		LeaseExpires: time.Now().Add(c.config.CI.RunnerLeaseDuration).UnixMilli(),
+	}
+
+	if execCtx.Config != nil {
+		out.Config = string(execCtx.Config.Data)
+	}
+
+	if execCtx.Netrc != nil {
+		out.Netrc = &types.RunnerNetrc{
+			Machine:  cloneURL.Hostname(),
+			Login:    execCtx.Netrc.Login,
+			Password: execCtx.Netrc.Password,
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StageUpdateInput struct {
+	Status   enum.CIStatus `json:"status"`
+	Error    string        `json:"error"`
+	ExitCode int           `json:"exit_code"`
+	Started  int64         `json:"started"`
+	Stopped  int64         `json:"stopped"`
+	// Steps are the steps of the stage. They are created when the stage is started
+	// and their final state is stored when the stage is complete.
+	Steps []*StepInput `json:"steps"`
+}
+
+type StepInput struct {
+	Number    int64         `json:"number"`
+	Name      string        `json:"name"`
+	Status    enum.CIStatus `json:"status"`
+	Error     string        `json:"error"`
+	ErrIgnore bool          `json:"errignore"`
+	ExitCode  int           `json:"exit_code"`
+	Started   int64         `json:"started"`
+	Stopped   int64         `json:"stopped"`
+	DependsOn []string      `json:"depends_on"`
+	Image     string        `json:"image"`
+	Detached  bool          `json:"detached"`
+	Schema    string        `json:"schema"`
+}
+
+// UpdateStage reports the progress of a stage leased by the runner.
+func (c *Controller) UpdateStage(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	in *StageUpdateInput,
+) (*types.Stage, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.findLeasedStage(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stage.Status.IsDone() {
+		return nil, usererror.Conflict("The stage is already complete.")
+	}
+
+	if in.Status == "" {
+		return nil, usererror.BadRequest("The status of the stage is required.")
+	}
+
+	steps, err := c.listSteps(ctx, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	stage.Status = in.Status
+	stage.Error = in.Error
+	stage.ExitCode = in.ExitCode
+	stage.Started = in.Started
+	stage.Stopped = in.Stopped
+
+	if in.Status == enum.CIStatusPending || in.Status == enum.CIStatusRunning {
+		// the steps are only created once, when the stage is started.
+		if len(steps) == 0 {
+			stage.Steps = newSteps(stage.ID, in.Steps)
+		}
+
+		if err = c.executionManager.BeforeStage(ctx, stage); err != nil {
+			return nil, fmt.Errorf("failed to start stage: %w", err)
+		}
+
+		return stage, nil
+	}
+
+	stage.Steps = mergeSteps(steps, in.Steps)
+
+	if err = c.executionManager.AfterStage(ctx, stage); err != nil {
+		return nil, fmt.Errorf("failed to complete stage: %w", err)
+	}
+
+	return stage, nil
+}
+
+// listSteps returns the persisted steps of the stage.
+func (c *Controller) listSteps(ctx context.Context, stage *types.Stage) ([]*types.Step, error) {
+	stages, err := c.stageStore.ListWithSteps(ctx, stage.ExecutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages of execution: %w", err)
+	}
+
+	for _, st := range stages {
+		if st.ID == stage.ID {
+			return st.Steps, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func newSteps(stageID int64, in []*StepInput) []*types.Step {
+	steps := make([]*types.Step, len(in))
+	for i, s := range in {
+		steps[i] = &types.Step{
+			StageID:   stageID,
+			Number:    s.Number,
+			Name:      s.Name,
+			Status:    s.Status,
+			Error:     s.Error,
+			ErrIgnore: s.ErrIgnore,
+			ExitCode:  s.ExitCode,
+			Started:   s.Started,
+			Stopped:   s.Stopped,
+			DependsOn: s.DependsOn,
+			Image:     s.Image,
+			Detached:  s.Detached,
+			Schema:    s.Schema,
+		}
+	}
+
+	return steps
+}
+
+// mergeSteps applies the reported state to the persisted steps, matched by their number.
+func mergeSteps(steps []*types.Step, in []*StepInput) []*types.Step {
+	byNumber := make(map[int64]*StepInput, len(in))
+	for _, s := range in {
+		byNumber[s.Number] = s
+	}
+
+	for _, step := range steps {
+		s, ok := byNumber[step.Number]
+		if !ok {
+			continue
+		}
+
+		step.Status = s.Status
+		step.Error = s.Error
+		step.ExitCode = s.ExitCode
+		step.Started = s.Started
+		step.Stopped = s.Stopped
+	}
+
+	return steps
+}
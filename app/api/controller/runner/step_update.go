@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StepUpdateInput struct {
+	Status   enum.CIStatus `json:"status"`
+	Error    string        `json:"error"`
+	ExitCode int           `json:"exit_code"`
+	Started  int64         `json:"started"`
+	Stopped  int64         `json:"stopped"`
+}
+
+// UpdateStep reports the progress of a step of a stage leased by the runner.
+func (c *Controller) UpdateStep(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	stepNumber int64,
+	in *StepUpdateInput,
+) (*types.Step, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := c.findLeasedStep(ctx, runner, stageID, stepNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Status == "" {
+		return nil, usererror.BadRequest("The status of the step is required.")
+	}
+
+	step.Status = in.Status
+	step.Error = in.Error
+	step.ExitCode = in.ExitCode
+	step.Started = in.Started
+	step.Stopped = in.Stopped
+
+	if in.Status == enum.CIStatusPending || in.Status == enum.CIStatusRunning {
+		err = c.executionManager.BeforeStep(ctx, step)
+	} else {
+		err = c.executionManager.AfterStep(ctx, step)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update step: %w", err)
+	}
+
+	return step, nil
+}
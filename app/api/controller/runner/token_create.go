@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type TokenCreateInput struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	// Lifetime is the time the token is valid for, the token never expires if not provided.
+	Lifetime *time.Duration `json:"lifetime"`
+}
+
+func (in *TokenCreateInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return check.TokenLifetime(in.Lifetime, true)
+}
+
+// TokenCreate creates a token that allows external runners to register in the space.
+// The token is only returned once, afterwards only its hash is known.
+func (c *Controller) TokenCreate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *TokenCreateInput,
+) (*types.RunnerRegistrationTokenCreateOutput, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expires int64
+	if in.Lifetime != nil {
+		expires = now.Add(*in.Lifetime).UnixMilli()
+	}
+
+	regToken := types.RunnerRegistrationToken{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Hash:        hashToken(token),
+		CreatedBy:   session.Principal.ID,
+		Created:     now.UnixMilli(),
+		Expires:     expires,
+	}
+
+	if err = c.regTokenStore.Create(ctx, &regToken); err != nil {
+		return nil, fmt.Errorf("failed to create runner registration token: %w", err)
+	}
+
+	return &types.RunnerRegistrationTokenCreateOutput{
+		RunnerRegistrationToken: regToken,
+		Token:                   token,
+	}, nil
+}
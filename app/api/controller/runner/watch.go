@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// Watch waits for the cancellation of the execution of a stage leased by the runner.
+// The request is held open for up to the configured maximum poll wait time.
+func (c *Controller) Watch(
+	ctx context.Context,
+	token string,
+	stageID int64,
+) (*types.RunnerWatchOutput, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.findLeasedStage(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, c.config.CI.RunnerMaxPollWait)
+	defer cancel()
+
+	cancelled, err := c.executionManager.Watch(pollCtx, stage.ExecutionID)
+	if err != nil && pollCtx.Err() != nil && ctx.Err() == nil {
+		return &types.RunnerWatchOutput{Cancelled: false}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch execution: %w", err)
+	}
+
+	return &types.RunnerWatchOutput{Cancelled: cancelled}, nil
+}
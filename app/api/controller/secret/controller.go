@@ -15,9 +15,16 @@
 package secret
 
 import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
@@ -25,6 +32,7 @@ type Controller struct {
 	secretStore store.SecretStore
 	authorizer  authz.Authorizer
 	spaceStore  store.SpaceStore
+	repoStore   store.RepoStore
 }
 
 func NewController(
@@ -32,11 +40,33 @@ func NewController(
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 ) *Controller {
 	return &Controller{
 		encrypter:   encrypter,
 		secretStore: secretStore,
 		authorizer:  authorizer,
 		spaceStore:  spaceStore,
+		repoStore:   repoStore,
+	}
+}
+
+// getRepoCheckAccess fetches the repository and checks if the current user
+// has the permission to access its secrets.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	permission enum.Permission,
+) (*types.Repository, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
 	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission, false); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
 }
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RepoCreateInput struct {
+	Description string `json:"description"`
+	Identifier  string `json:"identifier"`
+	Data        string `json:"data"`
+}
+
+// CreateForRepo creates a new secret scoped to a repository.
+// The secret is stored in the parent space of the repository,
+// but it is only visible to the pipelines and webhooks of the repository.
+func (c *Controller) CreateForRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RepoCreateInput,
+) (*types.Secret, error) {
+	if err := c.sanitizeRepoCreateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	secret := &types.Secret{
+		CreatedBy:   session.Principal.ID,
+		Description: in.Description,
+		Data:        in.Data,
+		SpaceID:     repo.ParentID,
+		RepoID:      &repo.ID,
+		Identifier:  in.Identifier,
+		Created:     now,
+		Updated:     now,
+		Version:     0,
+	}
+	secret, err = enc(c.encrypter, secret)
+	if err != nil {
+		return nil, fmt.Errorf("could not encrypt secret: %w", err)
+	}
+	err = c.secretStore.Create(ctx, secret)
+	if err != nil {
+		return nil, fmt.Errorf("secret creation failed: %w", err)
+	}
+
+	return secret, nil
+}
+
+func (c *Controller) sanitizeRepoCreateInput(in *RepoCreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	return check.Description(in.Description)
+}
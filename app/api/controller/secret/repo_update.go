@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateForRepo updates a repository secret.
+func (c *Controller) UpdateForRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.Secret, error) {
+	if err := c.sanitizeUpdateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := c.secretStore.FindByRepoIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	return c.secretStore.UpdateOptLock(ctx, secret, func(original *types.Secret) error {
+		if in.Identifier != nil {
+			original.Identifier = *in.Identifier
+		}
+		if in.Description != nil {
+			original.Description = *in.Description
+		}
+		if in.Data != nil {
+			data, err := c.encrypter.Encrypt(*in.Data)
+			if err != nil {
+				return fmt.Errorf("could not encrypt secret: %w", err)
+			}
+			original.Data = string(data)
+		}
+
+		return nil
+	})
+}
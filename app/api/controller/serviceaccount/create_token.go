@@ -32,6 +32,8 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+	// Scope optionally restricts the access of the token to a role within a space.
+	Scope *types.TokenScope `json:"scope,omitempty"`
 }
 
 // CreateToken creates a new service account access token.
@@ -56,6 +58,11 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	scope, err := token.ResolveScope(ctx, c.spaceStore, in.Scope)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreateSAT(
 		ctx,
 		c.tokenStore,
@@ -63,6 +70,7 @@ func (c *Controller) CreateToken(
 		sa,
 		in.Identifier,
 		in.Lifetime,
+		scope,
 	)
 	if err != nil {
 		return nil, err
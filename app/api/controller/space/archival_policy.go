@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const archivalPolicyMaxInactiveMonths = 120
+
+// FindArchivalPolicy returns the automatic archival policy of the space.
+// A policy without inactivity period is returned in case the space doesn't configure any.
+func (c *Controller) FindArchivalPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.RepoArchivalPolicy, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	policy, err := c.settings.RepoArchivalPolicy(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return &types.RepoArchivalPolicy{}, nil
+	}
+
+	return policy, nil
+}
+
+// UpdateArchivalPolicy replaces the automatic archival policy of the space.
+// The policy applies to all repositories of the space and of subspaces without own policy.
+func (c *Controller) UpdateArchivalPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.RepoArchivalPolicy,
+) (*types.RepoArchivalPolicy, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if in.InactiveMonths < 1 || in.InactiveMonths > archivalPolicyMaxInactiveMonths {
+		return nil, usererror.BadRequestf("Inactive months has to be between 1 and %d.",
+			archivalPolicyMaxInactiveMonths)
+	}
+
+	if err = c.settings.SetRepoArchivalPolicy(ctx, space.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// DeleteArchivalPolicy removes the automatic archival policy of the space.
+// Afterwards, the policy of the closest ancestor applies to the repositories of the space.
+func (c *Controller) DeleteArchivalPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	return c.settings.DeleteRepoArchivalPolicy(ctx, space.ID)
+}
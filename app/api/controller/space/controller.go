@@ -15,12 +15,19 @@
 package space
 
 import (
+	"time"
+
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth/authz"
+	spaceevents "github.com/harness/gitness/app/events/space"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -38,6 +45,8 @@ var (
 type Controller struct {
 	nestedSpacesEnabled           bool
 	publicResourceCreationEnabled bool
+	externalHookScriptsDir        string
+	externalHookMaxTimeout        time.Duration
 
 	tx              dbtx.Transactor
 	urlProvider     url.Provider
@@ -57,6 +66,16 @@ type Controller struct {
 	importer        *importer.Repository
 	exporter        *exporter.Repository
 	resourceLimiter limiter.ResourceLimiter
+
+	externalHookStore store.ExternalHookStore
+	ruleStore         store.RuleStore
+	settings          *settings.Service
+	usage             *usage.Service
+	instanceSettings  *instance.Service
+	invitationStore   store.InvitationStore
+	invitation        *invitation.Service
+
+	spaceEventReporter *spaceevents.Reporter
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -65,11 +84,15 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
-	limiter limiter.ResourceLimiter,
+	limiter limiter.ResourceLimiter, externalHookStore store.ExternalHookStore, ruleStore store.RuleStore,
+	settings *settings.Service, usage *usage.Service, spaceEventReporter *spaceevents.Reporter,
+	instanceSettings *instance.Service, invitationStore store.InvitationStore, invitation *invitation.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled:           config.NestedSpacesEnabled,
 		publicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+		externalHookScriptsDir:        config.Git.ExternalHooks.ScriptsDir,
+		externalHookMaxTimeout:        config.Git.ExternalHooks.MaxTimeout,
 		tx:                            tx,
 		urlProvider:                   urlProvider,
 		sseStreamer:                   sseStreamer,
@@ -88,5 +111,13 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		importer:                      importer,
 		exporter:                      exporter,
 		resourceLimiter:               limiter,
+		externalHookStore:             externalHookStore,
+		ruleStore:                     ruleStore,
+		settings:                      settings,
+		usage:                         usage,
+		spaceEventReporter:            spaceEventReporter,
+		instanceSettings:              instanceSettings,
+		invitationStore:               invitationStore,
+		invitation:                    invitation,
 	}
 }
@@ -29,6 +29,8 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
 )
 
 var (
@@ -42,7 +44,7 @@ type CreateInput struct {
 	UID         string `json:"uid" deprecated:"true"`
 	Identifier  string `json:"identifier"`
 	Description string `json:"description"`
-	IsPublic    bool   `json:"is_public"`
+	IsPublic    *bool  `json:"is_public"`
 }
 
 // Create creates a new space.
@@ -102,7 +104,7 @@ func (c *Controller) createSpaceInnerInTX(
 		ParentID:    parentID,
 		Identifier:  in.Identifier,
 		Description: in.Description,
-		IsPublic:    in.IsPublic,
+		IsPublic:    *in.IsPublic,
 		Path:        spacePath,
 		CreatedBy:   session.Principal.ID,
 		Created:     now,
@@ -122,6 +124,12 @@ func (c *Controller) createSpaceInnerInTX(
 		Created:    now,
 		Updated:    now,
 	}
+	// the new space takes precedence over a previous path of a renamed or moved space.
+	err = c.spacePathStore.DeleteAliasSegment(ctx, parentID, space.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete alias path segment: %w", err)
+	}
+
 	err = c.spacePathStore.InsertSegment(ctx, pathSegment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert primary path segment: %w", err)
@@ -196,7 +204,12 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return errNestedSpacesNotSupported
 	}
 
-	if in.IsPublic && !c.publicResourceCreationEnabled {
+	if in.IsPublic == nil {
+		// resources can only be public by default if they can be public at all.
+		in.IsPublic = ptr.Bool(c.instanceSettings.DefaultPublic() && c.publicResourceCreationEnabled)
+	}
+
+	if *in.IsPublic && !c.publicResourceCreationEnabled {
 		return errPublicSpaceCreationDisabled
 	}
 
@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	effectiveSettingGitBandwidthLimit = "git_bandwidth_limit"
+	effectiveSettingIssueTracker      = "issue_tracker"
+	effectiveSettingRepoTemplate      = "repo_template"
+	effectiveSettingRules             = "rules"
+	effectiveSettingMemberships       = "memberships"
+)
+
+// EffectiveSettings returns the settings that apply to a space,
+// together with the space each of the values is inherited from.
+// Protection rules and memberships are additive, so an entry is returned for every space that contributes to them.
+func (c *Controller) EffectiveSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]types.EffectiveSpaceSetting, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	var result []types.EffectiveSpaceSetting
+
+	bandwidthLimit, err := c.effectiveBandwidthLimit(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+	if bandwidthLimit != nil {
+		result = append(result, *bandwidthLimit)
+	}
+
+	issueTracker, source, err := c.settings.EffectiveIssueTrackerSettings(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective issue tracker settings: %w", err)
+	}
+	if issueTracker != nil {
+		result = append(result, newEffectiveSpaceSetting(space, source, effectiveSettingIssueTracker, issueTracker))
+	}
+
+	repoTemplate, err := c.findRepoTemplate(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+	if repoTemplate.SourcePath != "" {
+		result = append(result, types.EffectiveSpaceSetting{
+			Key:        effectiveSettingRepoTemplate,
+			Value:      repoTemplate.RepoTemplate,
+			SourcePath: repoTemplate.SourcePath,
+			Inherited:  repoTemplate.Inherited,
+		})
+	}
+
+	rules, err := c.effectiveRules(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, rules...)
+
+	memberships, err := c.effectiveMemberships(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, memberships...)
+
+	return result, nil
+}
+
+// effectiveBandwidthLimit returns the strictest git bandwidth limit of the space and its ancestors.
+// Bandwidth limits are always inherited, because a space must not be able to lift the limit of its ancestors.
+func (c *Controller) effectiveBandwidthLimit(
+	ctx context.Context,
+	space *types.Space,
+) (*types.EffectiveSpaceSetting, error) {
+	var source *types.Space
+	for current := space; ; {
+		if current.GitBandwidthLimit > 0 &&
+			(source == nil || current.GitBandwidthLimit < source.GitBandwidthLimit) {
+			source = current
+		}
+
+		if current.ParentID <= 0 {
+			break
+		}
+
+		parent, err := c.spaceStore.Find(ctx, current.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space: %w", err)
+		}
+
+		current = parent
+	}
+
+	if source == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	setting := newEffectiveSpaceSetting(space, source, effectiveSettingGitBandwidthLimit, source.GitBandwidthLimit)
+
+	return &setting, nil
+}
+
+// effectiveRules returns the number of protection rules defined on every space whose rules apply to the space.
+func (c *Controller) effectiveRules(
+	ctx context.Context,
+	space *types.Space,
+) ([]types.EffectiveSpaceSetting, error) {
+	chain, err := c.settings.InheritanceChain(ctx, space.ID, enum.InheritedSettingRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inheritance chain of protection rules: %w", err)
+	}
+
+	var result []types.EffectiveSpaceSetting
+	for _, source := range chain {
+		count, err := c.ruleStore.Count(ctx, &source.ID, nil, &types.RuleFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count protection rules of space: %w", err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		result = append(result, newEffectiveSpaceSetting(space, source, effectiveSettingRules, count))
+	}
+
+	return result, nil
+}
+
+// effectiveMemberships returns the number of members of every space whose memberships grant access to the space.
+func (c *Controller) effectiveMemberships(
+	ctx context.Context,
+	space *types.Space,
+) ([]types.EffectiveSpaceSetting, error) {
+	chain, err := c.settings.InheritanceChain(ctx, space.ID, enum.InheritedSettingMemberships)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inheritance chain of memberships: %w", err)
+	}
+
+	var result []types.EffectiveSpaceSetting
+	for _, source := range chain {
+		count, err := c.membershipStore.CountUsers(ctx, source.ID, types.MembershipUserFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count members of space: %w", err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		result = append(result, newEffectiveSpaceSetting(space, source, effectiveSettingMemberships, count))
+	}
+
+	return result, nil
+}
+
+func newEffectiveSpaceSetting(
+	space *types.Space,
+	source *types.Space,
+	key string,
+	value any,
+) types.EffectiveSpaceSetting {
+	return types.EffectiveSpaceSetting{
+		Key:        key,
+		Value:      value,
+		SourcePath: source.Path,
+		Inherited:  source.ID != space.ID,
+	}
+}
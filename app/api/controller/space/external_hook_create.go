@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const externalHookMaxTargetLength = 2048
+
+type ExternalHookCreateInput struct {
+	Identifier  string                `json:"identifier"`
+	Description string                `json:"description"`
+	Type        enum.ExternalHookType `json:"type"`
+	Target      string                `json:"target"`
+	Secret      string                `json:"secret"`
+	Timeout     int64                 `json:"timeout"`
+	Enabled     bool                  `json:"enabled"`
+}
+
+// ExternalHookCreate registers a new external pre-receive hook for the space.
+// External hooks can only be managed by admins, as script hooks are executed on the server.
+func (c *Controller) ExternalHookCreate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *ExternalHookCreateInput,
+) (*types.ExternalHook, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.sanitizeExternalHookCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	hook := &types.ExternalHook{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		Target:      in.Target,
+		Secret:      in.Secret,
+		Timeout:     in.Timeout,
+		Enabled:     in.Enabled,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := c.externalHookStore.Create(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to create external hook: %w", err)
+	}
+
+	return hook, nil
+}
+
+func (c *Controller) sanitizeExternalHookCreateInput(in *ExternalHookCreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	hookType, ok := in.Type.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("Invalid external hook type %q.", in.Type)
+	}
+	in.Type = hookType
+
+	return c.checkExternalHook(in.Type, in.Target, in.Secret, in.Timeout)
+}
+
+func (c *Controller) checkExternalHook(hookType enum.ExternalHookType, target, secret string, timeout int64) error {
+	if target == "" {
+		return check.NewValidationError("The target of an external hook is required.")
+	}
+	if len(target) > externalHookMaxTargetLength {
+		return check.NewValidationErrorf("The target of an external hook can be at most %d characters long.",
+			externalHookMaxTargetLength)
+	}
+
+	if timeout < 0 || time.Duration(timeout)*time.Second > c.externalHookMaxTimeout {
+		return check.NewValidationErrorf("The timeout of an external hook has to be between 0 and %d seconds.",
+			int64(c.externalHookMaxTimeout/time.Second))
+	}
+
+	switch hookType {
+	case enum.ExternalHookTypeHTTP:
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return check.NewValidationError("The target of an http hook has to be a valid http(s) URL.")
+		}
+	case enum.ExternalHookTypeScript:
+		if c.externalHookScriptsDir == "" {
+			return check.NewValidationError("Script hooks are disabled.")
+		}
+		if secret != "" {
+			return check.NewValidationError("Script hooks don't support secrets.")
+		}
+		if filepath.Base(target) != target || target == "." || target == ".." {
+			return check.NewValidationError("The target of a script hook has to be the file name of a script " +
+				"in the scripts directory.")
+		}
+		info, err := os.Stat(filepath.Join(c.externalHookScriptsDir, target))
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			return check.NewValidationErrorf("Script %q doesn't exist or isn't executable.", target)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+type ExternalHookUpdateInput struct {
+	Identifier  *string `json:"identifier"`
+	Description *string `json:"description"`
+	Target      *string `json:"target"`
+	Secret      *string `json:"secret"`
+	Timeout     *int64  `json:"timeout"`
+	Enabled     *bool   `json:"enabled"`
+}
+
+// ExternalHookUpdate updates an existing external hook of the space.
+func (c *Controller) ExternalHookUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *ExternalHookUpdateInput,
+) (*types.ExternalHook, error) {
+	hook, err := c.getExternalHookCheckAccess(ctx, session, spaceRef, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return nil, err
+		}
+		hook.Identifier = *in.Identifier
+	}
+	if in.Description != nil {
+		if err := check.Description(*in.Description); err != nil {
+			return nil, err
+		}
+		hook.Description = *in.Description
+	}
+	if in.Target != nil {
+		hook.Target = *in.Target
+	}
+	if in.Secret != nil {
+		hook.Secret = *in.Secret
+	}
+	if in.Timeout != nil {
+		hook.Timeout = *in.Timeout
+	}
+	if in.Enabled != nil {
+		hook.Enabled = *in.Enabled
+	}
+
+	if err := c.checkExternalHook(hook.Type, hook.Target, hook.Secret, hook.Timeout); err != nil {
+		return nil, err
+	}
+
+	if err := c.externalHookStore.Update(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to update external hook: %w", err)
+	}
+
+	return hook, nil
+}
@@ -53,7 +53,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 	}
 
 	remoteRepositories, provider, err :=
-		importer.LoadRepositoriesFromProviderSpace(ctx, in.Provider, in.ProviderSpace)
+		c.importer.LoadRepositoriesFromProviderSpace(ctx, in.Provider, in.ProviderSpace)
 	if err != nil {
 		return nil, err
 	}
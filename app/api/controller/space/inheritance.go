@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInheritanceInput is used for updating the inheritance flags of a space.
+type UpdateInheritanceInput struct {
+	Memberships  *bool `json:"memberships"`
+	Rules        *bool `json:"rules"`
+	IssueTracker *bool `json:"issue_tracker"`
+	RepoTemplate *bool `json:"repo_template"`
+}
+
+// FindInheritance returns the inheritance flags of a space.
+func (c *Controller) FindInheritance(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceInheritance, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	return c.settings.SpaceInheritance(ctx, space.ID)
+}
+
+// UpdateInheritance updates the inheritance flags of a space.
+func (c *Controller) UpdateInheritance(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *UpdateInheritanceInput,
+) (*types.SpaceInheritance, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	inheritance, err := c.settings.SpaceInheritance(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Memberships != nil {
+		inheritance.Memberships = *in.Memberships
+	}
+	if in.Rules != nil {
+		inheritance.Rules = *in.Rules
+	}
+	if in.IssueTracker != nil {
+		inheritance.IssueTracker = *in.IssueTracker
+	}
+	if in.RepoTemplate != nil {
+		inheritance.RepoTemplate = *in.RepoTemplate
+	}
+
+	err = c.settings.SetSpaceInheritance(ctx, space.ID, inheritance, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update inheritance of space: %w", err)
+	}
+
+	return inheritance, nil
+}
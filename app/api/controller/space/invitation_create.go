@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type InvitationCreateInput struct {
+	Email string              `json:"email"`
+	Role  enum.MembershipRole `json:"role"`
+}
+
+// InvitationCreate invites a person without an account to the space by email.
+// The invited person becomes a member of the space with the provided role after signing up.
+func (c *Controller) InvitationCreate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *InvitationCreateInput,
+) (*types.InvitationCreateOutput, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if in.Role == "" {
+		return nil, usererror.BadRequest("Role must be provided")
+	}
+
+	return c.invitation.Create(ctx, invitation.CreateInput{
+		Email:  in.Email,
+		Space:  space,
+		Role:   &in.Role,
+		Expiry: invitation.DefaultExpiry,
+	}, session.Principal.ID)
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// InvitationDelete cancels an invitation to the space.
+func (c *Controller) InvitationDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	invitationID int64,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	if _, err = c.findSpaceInvitation(ctx, space, invitationID); err != nil {
+		return err
+	}
+
+	if err = c.invitationStore.Delete(ctx, invitationID); err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+
+	return nil
+}
+
+// findSpaceInvitation finds the invitation and verifies it belongs to the space.
+func (c *Controller) findSpaceInvitation(
+	ctx context.Context,
+	space *types.Space,
+	invitationID int64,
+) (*types.Invitation, error) {
+	inv, err := c.invitationStore.Find(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	if inv.SpaceID == nil || *inv.SpaceID != space.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return inv, nil
+}
@@ -95,6 +95,19 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		Role:      in.Role,
 	}
 
+	// adding the same membership again is a no-op, to allow retries and declarative tooling.
+	existing, err := c.membershipStore.FindUser(ctx, membership.MembershipKey)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find existing membership: %w", err)
+	}
+	if existing != nil {
+		if existing.Role != in.Role {
+			return nil, usererror.Conflict("The user is already a member of the space with a different role")
+		}
+
+		return existing, nil
+	}
+
 	err = c.membershipStore.Create(ctx, &membership)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new membership: %w", err)
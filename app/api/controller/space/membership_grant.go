@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	minMembershipGrantLifetime = time.Hour
+	maxMembershipGrantLifetime = 30 * 24 * time.Hour
+)
+
+type MembershipGrantInput struct {
+	Role     enum.MembershipRole `json:"role"`
+	Lifetime time.Duration       `json:"lifetime"`
+}
+
+func (in *MembershipGrantInput) Validate() error {
+	if in.Role == "" {
+		return usererror.BadRequest("Role must be provided")
+	}
+
+	role, ok := in.Role.Sanitize()
+	if !ok {
+		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
+			in.Role, enum.MembershipRoles)
+		return usererror.BadRequest(msg)
+	}
+
+	in.Role = role
+
+	if in.Lifetime < minMembershipGrantLifetime || in.Lifetime > maxMembershipGrantLifetime {
+		return usererror.BadRequestf("The life time of an access grant has to be between %s and %s.",
+			minMembershipGrantLifetime, maxMembershipGrantLifetime)
+	}
+
+	return nil
+}
+
+// MembershipGrant grants a user a role in the space for a limited time.
+// If the user is already a member of the space, the existing role is restored once the grant expires,
+// otherwise the membership is removed. Granting access again replaces the previous grant.
+func (c *Controller) MembershipGrant(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userUID string,
+	in *MembershipGrantInput,
+) (*types.MembershipUser, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", userUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	key := types.MembershipKey{
+		SpaceID:     space.ID,
+		PrincipalID: user.ID,
+	}
+
+	now := time.Now()
+	expires := now.Add(in.Lifetime).UnixMilli()
+
+	existing, err := c.membershipStore.FindUser(ctx, key)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find existing membership: %w", err)
+	}
+
+	if existing == nil {
+		membership := types.Membership{
+			MembershipKey: key,
+			CreatedBy:     session.Principal.ID,
+			Created:       now.UnixMilli(),
+			Updated:       now.UnixMilli(),
+			Role:          in.Role,
+			Expires:       &expires,
+		}
+
+		err = c.membershipStore.Create(ctx, &membership)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary membership: %w", err)
+		}
+
+		return &types.MembershipUser{
+			Membership: membership,
+			Principal:  *user.ToPrincipalInfo(),
+			AddedBy:    *session.Principal.ToPrincipalInfo(),
+		}, nil
+	}
+
+	// the permanent role of the member; for an existing grant it's the role the grant replaced.
+	permanentRole := existing.Role
+	if existing.Expires != nil {
+		permanentRole = existing.PreviousRole
+	}
+
+	if permanentRole == in.Role {
+		return nil, usererror.BadRequest("The user is already a member of the space with the provided role")
+	}
+
+	existing.Role = in.Role
+	existing.PreviousRole = permanentRole
+	existing.Expires = &expires
+
+	err = c.membershipStore.Update(ctx, &existing.Membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update membership with temporary grant: %w", err)
+	}
+
+	return existing, nil
+}
@@ -80,11 +80,14 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to find membership for update: %w", err)
 	}
 
-	if membership.Role == in.Role {
+	// updating the role makes it permanent, also for members with a temporary grant.
+	if membership.Role == in.Role && membership.Expires == nil {
 		return membership, nil
 	}
 
 	membership.Role = in.Role
+	membership.Expires = nil
+	membership.PreviousRole = ""
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
 	if err != nil {
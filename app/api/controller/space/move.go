@@ -17,10 +17,13 @@ package space
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	spaceevents "github.com/harness/gitness/app/events/space"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -30,18 +33,23 @@ type MoveInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
 	UID        *string `json:"uid" deprecated:"true"`
 	Identifier *string `json:"identifier"`
+	ParentRef  *string `json:"parent_ref"`
 }
 
-func (i *MoveInput) hasChanges(space *types.Space) bool {
+func (i *MoveInput) hasChanges(space *types.Space, newParent *types.Space) bool {
 	if i.Identifier != nil && *i.Identifier != space.Identifier {
 		return true
 	}
 
+	if newParent != nil && newParent.ID != space.ParentID {
+		return true
+	}
+
 	return false
 }
 
-// Move moves a space to a new identifier.
-// TODO: Add support for moving to other parents and alias.
+// Move moves a space to a new identifier and/or a new parent space.
+// The previous path of the space is kept as an alias, which allows redirecting requests using the old path.
 //
 //nolint:gocognit // refactor if needed
 func (c *Controller) Move(
@@ -63,20 +71,41 @@ func (c *Controller) Move(
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	newParent, err := c.getNewParentCheckAuth(ctx, session, space, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
 	// exit early if there are no changes
-	if !in.hasChanges(space) {
+	if !in.hasChanges(space, newParent) {
 		return space, nil
 	}
 
+	oldPath := space.Path
+
 	if err = c.moveInner(
 		ctx,
 		session,
 		space,
 		in.Identifier,
+		newParent,
 	); err != nil {
 		return nil, err
 	}
 
+	newPath, err := c.spacePathStore.FindPrimaryBySpaceID(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find new primary path of the space: %w", err)
+	}
+	space.Path = newPath.Value
+
+	c.spaceEventReporter.Moved(ctx, &spaceevents.MovedPayload{
+		SpaceID:     space.ID,
+		PrincipalID: session.Principal.ID,
+		OldPath:     oldPath,
+		NewPath:     space.Path,
+	})
+
 	return space, nil
 }
 
@@ -91,26 +120,81 @@ func (c *Controller) sanitizeMoveInput(in *MoveInput, isRoot bool) error {
 		}
 	}
 
+	if in.ParentRef != nil {
+		if isRoot {
+			return usererror.BadRequest("Top level spaces can't be moved to a different parent space.")
+		}
+
+		if strings.TrimSpace(*in.ParentRef) == "" {
+			return usererror.BadRequest("Spaces can't be moved to the top level.")
+		}
+
+		// TODO (Nested Spaces): Remove once full support is added
+		if !c.nestedSpacesEnabled {
+			return errNestedSpacesNotSupported
+		}
+	}
+
 	return nil
 }
 
+// getNewParentCheckAuth returns the new parent space (if provided) and ensures
+// the principal is allowed to move the space into it.
+func (c *Controller) getNewParentCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	parentRef *string,
+) (*types.Space, error) {
+	if parentRef == nil {
+		return nil, nil //nolint:nilnil // no new parent provided.
+	}
+
+	newParent, err := c.getSpaceCheckAuthSpaceCreation(ctx, session, *parentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if newParent.ID == space.ParentID {
+		return newParent, nil
+	}
+
+	// a space can't be moved into itself or any of its subspaces.
+	if newParent.ID == space.ID ||
+		strings.HasPrefix(strings.ToLower(newParent.Path)+"/", strings.ToLower(space.Path)+"/") {
+		return nil, usererror.BadRequest("A space can't be moved into itself or one of its subspaces.")
+	}
+
+	return newParent, nil
+}
+
 func (c *Controller) moveInner(
 	ctx context.Context,
 	session *auth.Session,
 	space *types.Space,
 	inIdentifier *string,
+	newParent *types.Space,
 ) error {
 	return c.tx.WithTx(ctx, func(ctx context.Context) error {
-		// delete old primary segment
-		err := c.spacePathStore.DeletePrimarySegment(ctx, space.ID)
+		// keep the old primary segment as alias to redirect requests using the previous path.
+		err := c.spacePathStore.DemotePrimarySegment(ctx, space.ID)
 		if err != nil {
-			return fmt.Errorf("failed to delete primary path segment: %w", err)
+			return fmt.Errorf("failed to demote primary path segment: %w", err)
 		}
 
 		// update space with move inputs
 		if inIdentifier != nil {
 			space.Identifier = *inIdentifier
 		}
+		if newParent != nil {
+			space.ParentID = newParent.ID
+		}
+
+		// the space takes precedence over a previous path of another renamed or moved space (or its own).
+		err = c.spacePathStore.DeleteAliasSegment(ctx, space.ParentID, space.Identifier)
+		if err != nil {
+			return fmt.Errorf("failed to delete alias path segment: %w", err)
+		}
 
 		// add new primary segment using updated space data
 		now := time.Now().UnixMilli()
@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitcheck "github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/resources"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	repoTemplateMaxRequiredChecks = 32
+	repoTemplateMaxWebhooks       = 16
+)
+
+// RepoTemplateOutput contains the template for new repositories of a space.
+type RepoTemplateOutput struct {
+	types.RepoTemplate
+	// Inherited is true in case the space doesn't configure a template
+	// and the returned template is inherited from one of its ancestors.
+	Inherited bool `json:"inherited"`
+	// SourcePath is the path of the space the template is defined on (empty if no template applies).
+	SourcePath string `json:"source_path"`
+}
+
+// FindRepoTemplate returns the template that applies to new repositories of the space.
+func (c *Controller) FindRepoTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*RepoTemplateOutput, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	return c.findRepoTemplate(ctx, space)
+}
+
+// UpdateRepoTemplate replaces the template for new repositories of the space.
+// The template applies to all repositories created in the space or in subspaces without own template.
+// Webhook secrets are write-only and have to be provided with every update.
+func (c *Controller) UpdateRepoTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.RepoTemplate,
+) (*RepoTemplateOutput, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = sanitizeRepoTemplate(in); err != nil {
+		return nil, err
+	}
+
+	if err = c.settings.SetRepoTemplate(ctx, space.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return c.findRepoTemplate(ctx, space)
+}
+
+// DeleteRepoTemplate removes the template for new repositories of the space.
+// Afterwards, the template of the closest ancestor applies (if inherited).
+func (c *Controller) DeleteRepoTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	return c.settings.DeleteRepoTemplate(ctx, space.ID)
+}
+
+func (c *Controller) findRepoTemplate(ctx context.Context, space *types.Space) (*RepoTemplateOutput, error) {
+	template, source, err := c.settings.EffectiveRepoTemplate(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective repo template: %w", err)
+	}
+
+	if template == nil {
+		return &RepoTemplateOutput{RepoTemplate: types.RepoTemplate{
+			RequiredChecks: []string{},
+			Webhooks:       []types.RepoTemplateWebhook{},
+		}}, nil
+	}
+
+	// never expose webhook secrets.
+	for i := range template.Webhooks {
+		template.Webhooks[i].Secret = ""
+	}
+
+	return &RepoTemplateOutput{
+		RepoTemplate: *template,
+		Inherited:    source.ID != space.ID,
+		SourcePath:   source.Path,
+	}, nil
+}
+
+//nolint:gocognit // it's a list of independent checks.
+func sanitizeRepoTemplate(in *types.RepoTemplate) error {
+	in.DefaultBranch = strings.TrimSpace(in.DefaultBranch)
+	if in.DefaultBranch != "" {
+		if err := gitcheck.BranchName(in.DefaultBranch); err != nil {
+			return usererror.BadRequestf("Invalid default branch: %s", err)
+		}
+	}
+
+	if in.License != "" && in.License != "none" {
+		if _, err := resources.ReadLicense(in.License); err != nil {
+			return usererror.BadRequestf("Unknown license %q.", in.License)
+		}
+	}
+
+	if in.GitIgnore != "" {
+		if _, err := resources.ReadGitIgnore(in.GitIgnore); err != nil {
+			return usererror.BadRequestf("Unknown gitignore template %q.", in.GitIgnore)
+		}
+	}
+
+	if len(in.RequiredChecks) > repoTemplateMaxRequiredChecks {
+		return usererror.BadRequestf("At most %d required checks are allowed.", repoTemplateMaxRequiredChecks)
+	}
+	if in.RequiredChecks == nil {
+		in.RequiredChecks = []string{}
+	}
+	for i := range in.RequiredChecks {
+		in.RequiredChecks[i] = strings.TrimSpace(in.RequiredChecks[i])
+		if in.RequiredChecks[i] == "" {
+			return usererror.BadRequest("Required check identifiers can't be empty.")
+		}
+	}
+
+	if len(in.Webhooks) > repoTemplateMaxWebhooks {
+		return usererror.BadRequestf("At most %d webhooks are allowed.", repoTemplateMaxWebhooks)
+	}
+	if in.Webhooks == nil {
+		in.Webhooks = []types.RepoTemplateWebhook{}
+	}
+	identifiers := make(map[string]struct{}, len(in.Webhooks))
+	for i := range in.Webhooks {
+		hook := &in.Webhooks[i]
+		if err := check.Identifier(hook.Identifier); err != nil {
+			return err
+		}
+		if _, ok := identifiers[hook.Identifier]; ok {
+			return usererror.BadRequestf("Duplicate webhook identifier %q.", hook.Identifier)
+		}
+		identifiers[hook.Identifier] = struct{}{}
+
+		u, err := url.Parse(hook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return usererror.BadRequestf("Invalid url of webhook %q.", hook.Identifier)
+		}
+
+		for j := range hook.Triggers {
+			if _, ok := hook.Triggers[j].Sanitize(); !ok {
+				return usererror.BadRequestf("Invalid trigger %q of webhook %q.", hook.Triggers[j], hook.Identifier)
+			}
+		}
+	}
+
+	return nil
+}
@@ -176,6 +176,12 @@ func (c *Controller) restoreNoAuth(
 		Created:    now,
 		Updated:    now,
 	}
+	// the new space takes precedence over a previous path of a renamed or moved space.
+	err = c.spacePathStore.DeleteAliasSegment(ctx, space.ParentID, space.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete alias path segment: %w", err)
+	}
+
 	err = c.spacePathStore.InsertSegment(ctx, pathSegment)
 	if errors.Is(err, store.ErrDuplicate) {
 		return nil, usererror.BadRequest(fmt.Sprintf("A primary path already exists for %s.",
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const reviewReminderPolicyMaxHours = 24 * 90
+
+// FindReviewReminderPolicy returns the review reminder policy of the space.
+// A policy without reminders is returned in case the space doesn't configure any.
+func (c *Controller) FindReviewReminderPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.ReviewReminderPolicy, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	policy, err := c.settings.ReviewReminderPolicy(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return &types.ReviewReminderPolicy{}, nil
+	}
+
+	return policy, nil
+}
+
+// UpdateReviewReminderPolicy replaces the review reminder policy of the space.
+// The policy applies to all pull requests of the space and of subspaces without own policy.
+func (c *Controller) UpdateReviewReminderPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.ReviewReminderPolicy,
+) (*types.ReviewReminderPolicy, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if in.AfterHours < 1 || in.AfterHours > reviewReminderPolicyMaxHours {
+		return nil, usererror.BadRequestf("After hours has to be between 1 and %d.",
+			reviewReminderPolicyMaxHours)
+	}
+
+	if in.RepeatHours < 0 || in.RepeatHours > reviewReminderPolicyMaxHours {
+		return nil, usererror.BadRequestf("Repeat hours has to be between 0 and %d.",
+			reviewReminderPolicyMaxHours)
+	}
+
+	if err = c.settings.SetReviewReminderPolicy(ctx, space.ID, in, session.Principal.ID); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// DeleteReviewReminderPolicy removes the review reminder policy of the space.
+// Afterwards, the policy of the closest ancestor applies to the pull requests of the space.
+func (c *Controller) DeleteReviewReminderPolicy(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	return c.settings.DeleteReviewReminderPolicy(ctx, space.ID)
+}
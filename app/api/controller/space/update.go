@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -30,11 +31,25 @@ import (
 type UpdateInput struct {
 	Description *string `json:"description"`
 	IsPublic    *bool   `json:"is_public"`
+
+	GitBandwidthLimit *int64 `json:"git_bandwidth_limit"`
+
+	// Version is optional, if provided the update fails if the space has been modified in the meantime.
+	Version *int64 `json:"version"`
 }
 
 func (in *UpdateInput) hasChanges(space *types.Space) bool {
 	return (in.Description != nil && *in.Description != space.Description) ||
-		(in.IsPublic != nil && *in.IsPublic != space.IsPublic)
+		(in.IsPublic != nil && *in.IsPublic != space.IsPublic) ||
+		(in.GitBandwidthLimit != nil && *in.GitBandwidthLimit != space.GitBandwidthLimit)
+}
+
+func (in *UpdateInput) checkVersion(space *types.Space) error {
+	if in.Version != nil && *in.Version != space.Version {
+		return usererror.ErrVersionConflict
+	}
+
+	return nil
 }
 
 // Update updates a space.
@@ -49,6 +64,10 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if err = in.checkVersion(space); err != nil {
+		return nil, err
+	}
+
 	if !in.hasChanges(space) {
 		return space, nil
 	}
@@ -58,6 +77,10 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	}
 
 	space, err = c.spaceStore.UpdateOptLock(ctx, space, func(space *types.Space) error {
+		if err := in.checkVersion(space); err != nil {
+			return err
+		}
+
 		// update values only if provided
 		if in.Description != nil {
 			space.Description = *in.Description
@@ -65,6 +88,9 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		if in.IsPublic != nil {
 			space.IsPublic = *in.IsPublic
 		}
+		if in.GitBandwidthLimit != nil {
+			space.GitBandwidthLimit = *in.GitBandwidthLimit
+		}
 
 		return nil
 	})
@@ -89,5 +115,9 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.GitBandwidthLimit != nil && *in.GitBandwidthLimit < 0 {
+		return usererror.BadRequest("Git bandwidth limit can't be negative")
+	}
+
 	return nil
 }
@@ -18,8 +18,13 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
+	spaceevents "github.com/harness/gitness/app/events/space"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -41,11 +46,16 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore,
 	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
 	repoCtrl *repo.Controller, membershipStore store.MembershipStore, importer *importer.Repository,
-	exporter *exporter.Repository, limiter limiter.ResourceLimiter,
+	exporter *exporter.Repository, limiter limiter.ResourceLimiter, externalHookStore store.ExternalHookStore,
+	ruleStore store.RuleStore, settings *settings.Service, usage *usage.Service,
+	spaceEventReporter *spaceevents.Reporter, instanceSettings *instance.Service,
+	invitationStore store.InvitationStore, invitation *invitation.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, identifierCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
 		spaceStore, repoStore, principalStore,
-		repoCtrl, membershipStore, importer, exporter, limiter)
+		repoCtrl, membershipStore, importer, exporter, limiter, externalHookStore,
+		ruleStore, settings, usage, spaceEventReporter, instanceSettings,
+		invitationStore, invitation)
 }
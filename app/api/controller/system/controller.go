@@ -16,20 +16,78 @@ package system
 
 import (
 	"context"
+	"time"
 
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/usage"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore   store.PrincipalStore
+	config           *types.Config
+	db               *sqlx.DB
+	jobStore         job.Store
+	jobScheduler     *job.Scheduler
+	systemEventStore store.SystemEventStore
+	sseStreamer      sse.Streamer
+	auditLogStore    store.AuditLogStore
+	configReloader   *configreload.Reloader
+	git              git.Interface
+	eventSystem      *events.System
+	blobStore        blob.Store
+	usage            *usage.Service
+	maintenance      *maintenance.Service
+	instanceSettings *instance.Service
+
+	// started is the time the instance started, used for the startup grace period of the health checks.
+	started time.Time
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	db *sqlx.DB,
+	jobStore job.Store,
+	jobScheduler *job.Scheduler,
+	systemEventStore store.SystemEventStore,
+	sseStreamer sse.Streamer,
+	auditLogStore store.AuditLogStore,
+	configReloader *configreload.Reloader,
+	git git.Interface,
+	eventSystem *events.System,
+	blobStore blob.Store,
+	usage *usage.Service,
+	maintenance *maintenance.Service,
+	instanceSettings *instance.Service,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:   principalStore,
+		config:           config,
+		db:               db,
+		jobStore:         jobStore,
+		jobScheduler:     jobScheduler,
+		systemEventStore: systemEventStore,
+		sseStreamer:      sseStreamer,
+		auditLogStore:    auditLogStore,
+		configReloader:   configReloader,
+		git:              git,
+		eventSystem:      eventSystem,
+		blobStore:        blobStore,
+		usage:            usage,
+		maintenance:      maintenance,
+		instanceSettings: instanceSettings,
+		started:          time.Now(),
 	}
 }
 
@@ -39,5 +97,5 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return usrCount == 0 || c.config.UserSignupEnabled, nil
+	return usrCount == 0 || c.instanceSettings.UserSignupEnabled(), nil
 }
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DumpKindGoroutine = "goroutine"
+	DumpKindHeap      = "heap"
+)
+
+type DumpInput struct {
+	// Kinds are the kinds of dumps to write, all kinds are written if none are provided.
+	Kinds []string `json:"kinds"`
+}
+
+type DumpFile struct {
+	Kind string `json:"kind"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type DumpOutput struct {
+	Files []DumpFile `json:"files"`
+}
+
+// Dump writes goroutine and/or heap dumps of the running instance to the configured dump directory.
+func (c *Controller) Dump(ctx context.Context, session *auth.Session, in *DumpInput) (*DumpOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	if !c.config.Diagnostics.Enabled {
+		return nil, usererror.ErrNotFound
+	}
+
+	kinds, err := sanitizeDumpKinds(in.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := c.config.Diagnostics.DumpDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create dump directory: %w", err)
+	}
+
+	timestamp := time.Now().UTC().Format("20060102T150405.000Z")
+	out := &DumpOutput{Files: make([]DumpFile, 0, len(kinds))}
+
+	for _, kind := range kinds {
+		file, err := writeDump(dir, kind, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s dump: %w", kind, err)
+		}
+
+		log.Ctx(ctx).Info().
+			Str("dump.kind", kind).
+			Str("dump.path", file.Path).
+			Int64("principal_id", session.Principal.ID).
+			Msg("runtime dump written")
+
+		out.Files = append(out.Files, file)
+	}
+
+	return out, nil
+}
+
+func sanitizeDumpKinds(kinds []string) ([]string, error) {
+	if len(kinds) == 0 {
+		return []string{DumpKindGoroutine, DumpKindHeap}, nil
+	}
+
+	seen := make(map[string]struct{}, len(kinds))
+	result := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if kind != DumpKindGoroutine && kind != DumpKindHeap {
+			return nil, usererror.BadRequestf("Unknown dump kind %q, supported kinds are %q and %q.",
+				kind, DumpKindGoroutine, DumpKindHeap)
+		}
+
+		if _, ok := seen[kind]; ok {
+			continue
+		}
+
+		seen[kind] = struct{}{}
+		result = append(result, kind)
+	}
+
+	return result, nil
+}
+
+func writeDump(dir, kind, timestamp string) (DumpFile, error) {
+	// goroutine dumps are written as human-readable stack traces, heap dumps in the pprof format.
+	debug := 0
+	ext := "pb.gz"
+	if kind == DumpKindGoroutine {
+		debug = 2
+		ext = "txt"
+	} else {
+		runtime.GC() // get up-to-date statistics
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("gitness-%s-%s.%s", kind, timestamp, ext))
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return DumpFile{}, fmt.Errorf("failed to create dump file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err = pprof.Lookup(kind).WriteTo(f, debug); err != nil {
+		return DumpFile{}, fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return DumpFile{}, fmt.Errorf("failed to stat dump file: %w", err)
+	}
+
+	return DumpFile{
+		Kind: kind,
+		Path: path,
+		Size: info.Size(),
+	}, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/types"
+)
+
+// ListEvents lists the recorded system events, latest first.
+func (c *Controller) ListEvents(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.SystemEventFilter,
+) ([]*types.SystemEvent, int64, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.systemEventStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count system events: %w", err)
+	}
+
+	events, err := c.systemEventStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list system events: %w", err)
+	}
+
+	return events, count, nil
+}
+
+// StreamEvents streams the system events as they are recorded.
+func (c *Controller) StreamEvents(
+	ctx context.Context,
+	session *auth.Session,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to authorize stream: %w", err)
+	}
+
+	chEvents, chErr, sseCancel := c.sseStreamer.StreamSystem(ctx)
+
+	return chEvents, chErr, sseCancel, nil
+}
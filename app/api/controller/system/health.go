@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const databaseHealthTimeout = 5 * time.Second
+
+const (
+	HealthDependencyDatabase = "database"
+	HealthDependencyGit      = "git"
+	HealthDependencyEvents   = "events"
+	HealthDependencyBlob     = "blob"
+)
+
+// Health checks all dependencies of the instance concurrently.
+// Unhealthy dependencies result in the starting status during the configured startup grace period.
+func (c *Controller) Health(ctx context.Context) *types.HealthReport {
+	checks := map[string]func(context.Context) error{
+		HealthDependencyDatabase: func(ctx context.Context) error {
+			return c.checkDatabase(ctx, &types.DatabaseHealth{})
+		},
+		HealthDependencyGit:    c.git.CheckHealth,
+		HealthDependencyEvents: c.eventSystem.CheckHealth,
+		HealthDependencyBlob:   c.blobStore.CheckHealth,
+	}
+
+	report := &types.HealthReport{
+		Status:       enum.HealthStatusOK,
+		Started:      c.started.UnixMilli(),
+		Dependencies: make(map[string]types.DependencyHealth, len(checks)),
+	}
+
+	mx := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) error) {
+			defer wg.Done()
+
+			health := c.checkDependency(ctx, check)
+
+			mx.Lock()
+			defer mx.Unlock()
+
+			report.Dependencies[name] = health
+			if !health.Healthy {
+				report.Status = enum.HealthStatusUnavailable
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if report.Status != enum.HealthStatusOK && time.Since(c.started) < c.config.Health.StartupGracePeriod {
+		report.Status = enum.HealthStatusStarting
+	}
+
+	return report
+}
+
+func (c *Controller) checkDependency(ctx context.Context, check func(context.Context) error) types.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Health.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+
+	health := types.DependencyHealth{
+		Healthy:    err == nil,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+
+	return health
+}
+
+// DatabaseHealth verifies the connectivity of the database and that all known migrations are applied.
+func (c *Controller) DatabaseHealth(ctx context.Context) *types.DatabaseHealth {
+	stats := c.db.Stats()
+
+	health := &types.DatabaseHealth{
+		Pool: types.DatabasePoolStats{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		},
+	}
+
+	if err := c.checkDatabase(ctx, health); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	health.Healthy = true
+
+	return health
+}
+
+func (c *Controller) checkDatabase(ctx context.Context, health *types.DatabaseHealth) error {
+	ctx, cancel := context.WithTimeout(ctx, databaseHealthTimeout)
+	defer cancel()
+
+	if err := c.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	versions, err := migrate.Versions(c.db)
+	if err != nil {
+		return fmt.Errorf("failed to get migration versions: %w", err)
+	}
+	if len(versions) > 0 {
+		health.LatestVersion = versions[len(versions)-1]
+	}
+
+	health.Version, err = migrate.Current(ctx, c.db)
+	if err != nil {
+		return fmt.Errorf("failed to get current migration version: %w", err)
+	}
+
+	if health.Version != health.LatestVersion {
+		return fmt.Errorf("database is at migration version '%s', expected '%s'",
+			health.Version, health.LatestVersion)
+	}
+
+	return nil
+}
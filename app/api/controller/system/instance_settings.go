@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	minSessionTTL = int64(time.Hour / time.Second)
+	maxSessionTTL = int64(365 * 24 * time.Hour / time.Second)
+)
+
+func sanitizeInstanceSettings(in *types.InstanceSettings) error {
+	if in.UserSignupAllowedDomains != nil {
+		domains, err := sanitizeDomains(*in.UserSignupAllowedDomains)
+		if err != nil {
+			return err
+		}
+		in.UserSignupAllowedDomains = &domains
+	}
+
+	if in.WebhookAllowedNetworks != nil {
+		if err := webhook.ValidateNetworks(*in.WebhookAllowedNetworks); err != nil {
+			return usererror.BadRequestf("Invalid webhook allowed networks: %s", err)
+		}
+	}
+
+	if in.SessionTTL != nil && (*in.SessionTTL < minSessionTTL || *in.SessionTTL > maxSessionTTL) {
+		return usererror.BadRequestf("Session TTL has to be between %d and %d seconds.", minSessionTTL, maxSessionTTL)
+	}
+
+	return nil
+}
+
+func sanitizeDomains(domains []string) ([]string, error) {
+	sanitized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" {
+			continue
+		}
+
+		if strings.ContainsAny(domain, "@ /") || !strings.Contains(domain, ".") {
+			return nil, usererror.BadRequestf("Invalid user sign-up domain %q", domain)
+		}
+
+		sanitized = append(sanitized, domain)
+	}
+
+	return sanitized, nil
+}
+
+// IsDefaultPublic returns whether repositories and spaces created without an explicit visibility are public.
+func (c *Controller) IsDefaultPublic() bool {
+	return c.instanceSettings.DefaultPublic() && c.config.PublicResourceCreationEnabled
+}
+
+// GetInstanceSettings returns the instance settings stored at runtime together with the effective values.
+func (c *Controller) GetInstanceSettings(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.InstanceSettingsOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	return c.instanceSettingsOutput(), nil
+}
+
+// UpdateInstanceSettings replaces the instance settings stored at runtime.
+// Settings that aren't provided fall back to the configuration.
+func (c *Controller) UpdateInstanceSettings(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.InstanceSettings,
+) (*types.InstanceSettingsOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeInstanceSettings(in); err != nil {
+		return nil, err
+	}
+
+	if err := c.instanceSettings.Update(ctx, *in, session.Principal.ID); err != nil {
+		return nil, fmt.Errorf("failed to update instance settings: %w", err)
+	}
+
+	return c.instanceSettingsOutput(), nil
+}
+
+func (c *Controller) instanceSettingsOutput() *types.InstanceSettingsOutput {
+	return &types.InstanceSettingsOutput{
+		Settings:  c.instanceSettings.Settings(),
+		Effective: c.instanceSettings.Effective(),
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/job"
+)
+
+// ListJobs lists the background jobs of the system.
+func (c *Controller) ListJobs(
+	ctx context.Context,
+	session *auth.Session,
+	filter *job.ListFilter,
+) ([]*job.Job, int64, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.jobStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	jobs, err := c.jobStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	return jobs, count, nil
+}
+
+// FindJob returns a background job by its unique identifier.
+func (c *Controller) FindJob(ctx context.Context, session *auth.Session, jobUID string) (*job.Job, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	j, err := c.jobStore.Find(ctx, jobUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+
+	return j, nil
+}
+
+// CancelJob cancels a scheduled or running background job.
+func (c *Controller) CancelJob(ctx context.Context, session *auth.Session, jobUID string) (*job.Job, error) {
+	j, err := c.FindJob(ctx, session, jobUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !job.CanCancel(j) {
+		return nil, usererror.BadRequestf("Job in state '%s' can't be canceled", j.State)
+	}
+
+	if err = c.jobScheduler.CancelJob(ctx, jobUID); err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	return c.FindJob(ctx, session, jobUID)
+}
+
+// RetryJob reschedules a failed or canceled background job for immediate execution.
+func (c *Controller) RetryJob(ctx context.Context, session *auth.Session, jobUID string) (*job.Job, error) {
+	j, err := c.FindJob(ctx, session, jobUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !job.CanRetry(j) {
+		return nil, usererror.BadRequestf("Job in state '%s' can't be retried", j.State)
+	}
+
+	if err = c.jobScheduler.RetryJob(ctx, jobUID); err != nil {
+		return nil, fmt.Errorf("failed to retry job: %w", err)
+	}
+
+	return c.FindJob(ctx, session, jobUID)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+const maxMaintenanceMessageLength = 1024
+
+type UpdateMaintenanceInput struct {
+	ReadOnly bool   `json:"read_only"`
+	Message  string `json:"message"`
+}
+
+func (in *UpdateMaintenanceInput) sanitize() error {
+	if len(in.Message) > maxMaintenanceMessageLength {
+		return usererror.BadRequestf("Message can't be longer than %d characters.", maxMaintenanceMessageLength)
+	}
+
+	return nil
+}
+
+// IsReadOnly returns whether the system is in read-only maintenance mode.
+func (c *Controller) IsReadOnly() bool {
+	return c.maintenance.IsReadOnly()
+}
+
+// GetMaintenance returns the current maintenance mode of the system.
+func (c *Controller) GetMaintenance(ctx context.Context, session *auth.Session) (types.MaintenanceState, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return types.MaintenanceState{}, err
+	}
+
+	return c.maintenance.State(), nil
+}
+
+// UpdateMaintenance enables or disables the read-only maintenance mode of the system.
+func (c *Controller) UpdateMaintenance(
+	ctx context.Context,
+	session *auth.Session,
+	in *UpdateMaintenanceInput,
+) (types.MaintenanceState, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return types.MaintenanceState{}, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return types.MaintenanceState{}, err
+	}
+
+	if c.maintenance.State().Forced {
+		return types.MaintenanceState{}, usererror.BadRequest(
+			"Maintenance mode is enabled in the configuration and can't be changed at runtime.")
+	}
+
+	err := c.maintenance.Set(ctx, types.MaintenanceSettings{
+		ReadOnly: in.ReadOnly,
+		Message:  in.Message,
+	}, session.Principal.ID)
+	if err != nil {
+		return types.MaintenanceState{}, fmt.Errorf("failed to update maintenance mode: %w", err)
+	}
+
+	return c.maintenance.State(), nil
+}
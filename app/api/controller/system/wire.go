@@ -15,10 +15,20 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/usage"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
+	"github.com/jmoiron/sqlx"
 )
 
 // WireSet provides a wire set for this package.
@@ -26,6 +36,24 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	db *sqlx.DB,
+	jobStore job.Store,
+	jobScheduler *job.Scheduler,
+	systemEventStore store.SystemEventStore,
+	sseStreamer sse.Streamer,
+	auditLogStore store.AuditLogStore,
+	configReloader *configreload.Reloader,
+	git git.Interface,
+	eventSystem *events.System,
+	blobStore blob.Store,
+	usage *usage.Service,
+	maintenance *maintenance.Service,
+	instanceSettings *instance.Service,
+) *Controller {
+	return NewController(principalStore, config, db, jobStore, jobScheduler, systemEventStore, sseStreamer,
+		auditLogStore, configReloader, git, eventSystem, blobStore, usage, maintenance,
+		instanceSettings)
 }
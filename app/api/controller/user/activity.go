@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// contributionDays is the number of days covered by the contribution summary.
+	contributionDays = 365
+
+	contributionDateFormat = "2006-01-02"
+)
+
+// Activity lists the recent activities of the user (pushes, pull requests opened, merged and reviewed, comments),
+// limited to the repositories the current principal has access to.
+func (c *Controller) Activity(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	filter *types.UserActivityFilter,
+) ([]*types.UserActivity, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPaths, err := c.listActivityRepos(ctx, session, user.ID, filter.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repoPaths) == 0 {
+		return []*types.UserActivity{}, nil
+	}
+
+	filter.RepoIDs = make([]int64, 0, len(repoPaths))
+	for repoID := range repoPaths {
+		filter.RepoIDs = append(filter.RepoIDs, repoID)
+	}
+
+	activities, err := c.userActivityStore.List(ctx, user.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user activities: %w", err)
+	}
+
+	for _, activity := range activities {
+		activity.RepoPath = repoPaths[activity.RepoID]
+	}
+
+	return activities, nil
+}
+
+// Contributions returns the number of contributions of the user per day during the last year,
+// limited to the repositories the current principal has access to.
+// The days are calculated in the provided time zone, falling back to the time zone of the user and UTC.
+func (c *Controller) Contributions(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	timeZone string,
+) (*types.UserContributions, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeZone == "" {
+		timeZone = user.TimeZone
+	}
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil || timeZone == "Local" {
+		return nil, usererror.BadRequestf("Unknown time zone %q.", timeZone)
+	}
+
+	now := time.Now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	from := to.AddDate(0, 0, -contributionDays)
+
+	result := &types.UserContributions{
+		TimeZone: timeZone,
+		From:     from.Format(contributionDateFormat),
+		To:       to.AddDate(0, 0, -1).Format(contributionDateFormat),
+		Days:     []types.UserContributionDay{},
+	}
+
+	repoPaths, err := c.listActivityRepos(ctx, session, user.ID, from.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repoPaths) == 0 {
+		return result, nil
+	}
+
+	filter := &types.UserActivityFilter{
+		Since:   from.UnixMilli(),
+		Until:   to.UnixMilli(),
+		RepoIDs: make([]int64, 0, len(repoPaths)),
+	}
+	for repoID := range repoPaths {
+		filter.RepoIDs = append(filter.RepoIDs, repoID)
+	}
+
+	created, err := c.userActivityStore.ListCreated(ctx, user.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user activity times: %w", err)
+	}
+
+	counts := make(map[string]int64)
+	for _, ts := range created {
+		counts[time.UnixMilli(ts).In(loc).Format(contributionDateFormat)]++
+	}
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(contributionDateFormat)
+		if count := counts[date]; count > 0 {
+			result.Days = append(result.Days, types.UserContributionDay{Date: date, Count: count})
+			result.Total += count
+		}
+	}
+
+	return result, nil
+}
+
+// listActivityRepos returns the paths of all repositories the user was active in since the provided time
+// and the current principal is allowed to view, mapped by repository id.
+func (c *Controller) listActivityRepos(
+	ctx context.Context,
+	session *auth.Session,
+	principalID int64,
+	since int64,
+) (map[int64]string, error) {
+	repoIDs, err := c.userActivityStore.ListRepoIDs(ctx, principalID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of user activities: %w", err)
+	}
+
+	repoPaths := make(map[int64]string, len(repoIDs))
+	for _, repoID := range repoIDs {
+		repo, err := c.repoStore.Find(ctx, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository %d: %w", repoID, err)
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true)
+		if errors.Is(err, apiauth.ErrNotAuthorized) || errors.Is(err, apiauth.ErrNotAuthenticated) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check access to repository %d: %w", repoID, err)
+		}
+
+		repoPaths[repo.ID] = repo.Path
+	}
+
+	return repoPaths, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateAvatar resizes the provided image and stores it as the avatar of the user.
+func (c *Controller) UpdateAvatar(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	file io.Reader,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	user.Avatar, err = c.avatar.Upload(ctx, user.ID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Updated = time.Now().UnixMilli()
+
+	if err = c.principalStore.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update avatar of user: %w", err)
+	}
+
+	return user, nil
+}
+
+// DeleteAvatar removes the avatar of the user.
+func (c *Controller) DeleteAvatar(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if user.Avatar == "" {
+		return user, nil
+	}
+
+	user.Avatar = ""
+	user.Updated = time.Now().UnixMilli()
+
+	if err = c.principalStore.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to delete avatar of user: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	minBreakGlassJustificationLength = 10
+	maxBreakGlassJustificationLength = 1024
+)
+
+var errBreakGlassDisabled = usererror.BadRequest("Break-glass mode is disabled.")
+
+// BreakGlassInput is the input for activating break-glass access.
+type BreakGlassInput struct {
+	Justification string `json:"justification"`
+}
+
+func (in *BreakGlassInput) sanitize() error {
+	in.Justification = strings.TrimSpace(in.Justification)
+
+	if len(in.Justification) < minBreakGlassJustificationLength {
+		return usererror.BadRequestf("Justification must be at least %d characters long.",
+			minBreakGlassJustificationLength)
+	}
+	if len(in.Justification) > maxBreakGlassJustificationLength {
+		return usererror.BadRequestf("Justification must be at most %d characters long.",
+			maxBreakGlassJustificationLength)
+	}
+
+	return nil
+}
+
+// FindBreakGlass returns the active break-glass access of the current admin.
+func (c *Controller) FindBreakGlass(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.BreakGlass, error) {
+	if err := c.checkBreakGlass(session); err != nil {
+		return nil, err
+	}
+
+	breakGlass, err := c.breakGlass.Active(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if breakGlass == nil {
+		return nil, usererror.NotFound("Break-glass access isn't active")
+	}
+
+	return breakGlass, nil
+}
+
+// ActivateBreakGlass activates break-glass access of the current admin, which grants access to all spaces
+// until it expires. The activation is reported as system event and recorded in the audit log.
+func (c *Controller) ActivateBreakGlass(
+	ctx context.Context,
+	session *auth.Session,
+	in *BreakGlassInput,
+	accessor types.AuditAccessor,
+) (*types.BreakGlass, error) {
+	if err := c.checkBreakGlass(session); err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.breakGlass.Activate(ctx, &session.Principal, in.Justification, accessor)
+}
+
+// DeactivateBreakGlass ends the break-glass access of the current admin before it expires.
+func (c *Controller) DeactivateBreakGlass(
+	ctx context.Context,
+	session *auth.Session,
+	accessor types.AuditAccessor,
+) error {
+	if err := c.checkBreakGlass(session); err != nil {
+		return err
+	}
+
+	return c.breakGlass.Deactivate(ctx, &session.Principal, accessor)
+}
+
+func (c *Controller) checkBreakGlass(session *auth.Session) error {
+	if !c.breakGlass.Enabled() {
+		return errBreakGlassDisabled
+	}
+
+	if !session.Principal.Admin {
+		return usererror.Forbidden("Break-glass access is reserved for admins.")
+	}
+
+	return nil
+}
@@ -18,6 +18,12 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/breakglass"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -34,6 +40,17 @@ type Controller struct {
 	principalStore    store.PrincipalStore
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
+	spaceStore        store.SpaceStore
+	repoStore         store.RepoStore
+	userActivityStore store.UserActivityStore
+	systemReporter    *systemevents.Reporter
+	settings          *settings.Service
+	avatar            *avatar.Service
+	instanceSettings  *instance.Service
+	invitationStore   store.InvitationStore
+	invitation        *invitation.Service
+	breakGlass        *breakglass.Service
+	savedReplyStore   store.SavedReplyStore
 }
 
 func NewController(
@@ -43,6 +60,17 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	userActivityStore store.UserActivityStore,
+	systemReporter *systemevents.Reporter,
+	settings *settings.Service,
+	avatar *avatar.Service,
+	instanceSettings *instance.Service,
+	invitationStore store.InvitationStore,
+	invitation *invitation.Service,
+	breakGlass *breakglass.Service,
+	savedReplyStore store.SavedReplyStore,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -51,6 +79,17 @@ func NewController(
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
+		spaceStore:        spaceStore,
+		repoStore:         repoStore,
+		userActivityStore: userActivityStore,
+		systemReporter:    systemReporter,
+		settings:          settings,
+		avatar:            avatar,
+		instanceSettings:  instanceSettings,
+		invitationStore:   invitationStore,
+		invitation:        invitation,
+		breakGlass:        breakGlass,
+		savedReplyStore:   savedReplyStore,
 	}
 }
 
@@ -60,6 +60,17 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
  * Note: take admin separately to avoid potential vulnerabilities for user calls.
  */
 func (c *Controller) CreateNoAuth(ctx context.Context, in *CreateInput, admin bool) (*types.User, error) {
+	return c.createNoAuth(ctx, in, admin, false)
+}
+
+// createNoAuth creates a new user without auth checks.
+// Users pending approval can't login before they are approved by an admin.
+func (c *Controller) createNoAuth(
+	ctx context.Context,
+	in *CreateInput,
+	admin bool,
+	approvalPending bool,
+) (*types.User, error) {
 	if err := c.sanitizeCreateInput(in); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
@@ -78,6 +89,8 @@ func (c *Controller) CreateNoAuth(ctx context.Context, in *CreateInput, admin bo
 		Created:     time.Now().UnixMilli(),
 		Updated:     time.Now().UnixMilli(),
 		Admin:       admin,
+
+		ApprovalPending: approvalPending,
 	}
 
 	err = c.principalStore.CreateUser(ctx, user)
@@ -90,15 +103,18 @@ func (c *Controller) CreateNoAuth(ctx context.Context, in *CreateInput, admin bo
 		return nil, err
 	}
 
-	// first 'user' principal will be admin by default.
+	// first 'user' principal will be admin by default (and there's no one that could approve it).
 	if uCount == 1 {
 		user.Admin = true
+		user.ApprovalPending = false
 		err = c.principalStore.UpdateUser(ctx, user)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	c.systemReporter.UserCreated(ctx, user)
+
 	return user, nil
 }
 
@@ -32,6 +32,8 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+	// Scope optionally restricts the access of the token to a role within a space.
+	Scope *types.TokenScope `json:"scope,omitempty"`
 }
 
 /*
@@ -57,6 +59,11 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	scope, err := token.ResolveScope(ctx, c.spaceStore, in.Scope)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,6 +71,7 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		scope,
 	)
 	if err != nil {
 		return nil, err
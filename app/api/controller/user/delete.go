@@ -17,6 +17,7 @@ package user
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
@@ -26,6 +27,8 @@ import (
 )
 
 // Delete deletes a user.
+// The user is only marked as deleted to preserve authorship references
+// and can be restored until it's purged after the retention time.
 func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 	userUID string) error {
 	user, err := findUserFromUID(ctx, c.principalStore, userUID)
@@ -50,5 +53,9 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 		return err
 	}
 
-	return c.principalStore.DeleteUser(ctx, user.ID)
+	if user.Deleted != nil {
+		return usererror.BadRequest("user is already deleted")
+	}
+
+	return c.principalStore.SoftDeleteUser(ctx, user.ID, time.Now().UnixMilli())
 }
@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/invitation"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInvitationInput struct {
+	Email string `json:"email"`
+
+	// SpaceRef is the optional space the user is added to after registration.
+	SpaceRef string               `json:"space_ref"`
+	Role     *enum.MembershipRole `json:"role"`
+
+	// ExpiresIn is the validity of the invitation in milliseconds.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// CreateInvitation creates a new invitation that allows registration of a user account
+// even if open user sign-up is disabled.
+func (c *Controller) CreateInvitation(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInvitationInput,
+) (*types.InvitationCreateOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	var space *types.Space
+	if spaceRef := strings.Trim(strings.TrimSpace(in.SpaceRef), "/"); spaceRef != "" {
+		var err error
+		space, err = c.spaceStore.FindByRef(ctx, spaceRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+	}
+
+	return c.invitation.Create(ctx, invitation.CreateInput{
+		Email:  in.Email,
+		Space:  space,
+		Role:   in.Role,
+		Expiry: time.Duration(in.ExpiresIn) * time.Millisecond,
+	}, session.Principal.ID)
+}
+
+// ListInvitations lists the user invitations.
+func (c *Controller) ListInvitations(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.InvitationFilter,
+) ([]*types.Invitation, int64, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, 0, err
+	}
+
+	invitations, err := c.invitationStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	count, err := c.invitationStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	return invitations, count, nil
+}
+
+// DeleteInvitation deletes (revokes) a user invitation.
+func (c *Controller) DeleteInvitation(
+	ctx context.Context,
+	session *auth.Session,
+	invitationID int64,
+) error {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return err
+	}
+
+	if _, err := c.invitationStore.Find(ctx, invitationID); err != nil {
+		return fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	if err := c.invitationStore.Delete(ctx, invitationID); err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+
+	return nil
+}
+
+// ResendInvitation sends a pending user invitation again.
+// A new invitation link is generated, the previous one becomes invalid.
+func (c *Controller) ResendInvitation(
+	ctx context.Context,
+	session *auth.Session,
+	invitationID int64,
+) (*types.InvitationCreateOutput, error) {
+	if err := apiauth.CheckAdmin(session); err != nil {
+		return nil, err
+	}
+
+	inv, err := c.invitationStore.Find(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	return c.invitation.Resend(ctx, inv)
+}
+
+// FindInvitation returns the information about a pending invitation to the invited person.
+// No auth is required, the invitation token is used for it.
+func (c *Controller) FindInvitation(ctx context.Context, token string) (*types.InvitationInfo, error) {
+	inv, err := c.invitation.FindPending(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.invitation.Info(ctx, inv, token)
+}
+
+// AcceptInvitation accepts the invitation for an existing user, adding it to the space of the invitation.
+// Invited persons without an account accept the invitation by registering with the invitation token.
+func (c *Controller) AcceptInvitation(
+	ctx context.Context,
+	session *auth.Session,
+	token string,
+) (*types.InvitationInfo, error) {
+	if session == nil || session.Principal.Type != enum.PrincipalTypeUser {
+		return nil, apiauth.ErrNotAuthenticated
+	}
+
+	inv, err := c.invitation.FindPending(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(session.Principal.Email, inv.Email) {
+		return nil, usererror.Forbidden("The invitation was sent to a different email address.")
+	}
+
+	user, err := c.principalStore.FindUser(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.acceptInvitation(ctx, inv, user, time.Now().UnixMilli())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.invitation.Info(ctx, inv, token)
+}
+
+// acceptInvitation marks the invitation as accepted by the user and adds the user to the space of the invitation.
+func (c *Controller) acceptInvitation(
+	ctx context.Context,
+	inv *types.Invitation,
+	user *types.User,
+	now int64,
+) error {
+	err := c.invitationStore.MarkAccepted(ctx, inv.ID, user.ID, now)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return invitation.ErrInvalidInvitation
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mark invitation as accepted: %w", err)
+	}
+
+	if inv.SpaceID == nil || inv.Role == nil {
+		return nil
+	}
+
+	key := types.MembershipKey{
+		SpaceID:     *inv.SpaceID,
+		PrincipalID: user.ID,
+	}
+
+	// the user could have been added to the space directly in the meantime.
+	_, err = c.membershipStore.Find(ctx, key)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	err = c.membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: key,
+		CreatedBy:     inv.CreatedBy,
+		Created:       now,
+		Updated:       now,
+		Role:          *inv.Role,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add user to the space of the invitation: %w", err)
+	}
+
+	return nil
+}
@@ -50,10 +50,16 @@ func (c *Controller) Login(
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
 	}
 
+	// deleted users can't login.
+	if err == nil && user.Deleted != nil {
+		err = fmt.Errorf("user %q is deleted", user.UID)
+	}
+
 	// always return not found for security reasons.
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).
 			Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
+		c.systemReporter.LoginFailed(ctx, in.LoginIdentifier, nil)
 		return nil, usererror.ErrNotFound
 	}
 
@@ -66,14 +72,20 @@ func (c *Controller) Login(
 			Str("user_uid", user.UID).
 			Msg("invalid password")
 
+		c.systemReporter.LoginFailed(ctx, in.LoginIdentifier, user)
 		return nil, usererror.ErrNotFound
 	}
 
+	if user.ApprovalPending {
+		return nil, usererror.Forbidden("Your account is waiting for the approval of an administrator.")
+	}
+
 	tokenIdentifier, err := generateSessionTokenIdentifier()
 	if err != nil {
 		return nil, err
 	}
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier,
+		c.instanceSettings.SessionTTL())
 	if err != nil {
 		return nil, err
 	}
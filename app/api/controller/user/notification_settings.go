@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationSettingsUpdateInput stores the notification settings to update for a user.
+type NotificationSettingsUpdateInput struct {
+	Level *enum.NotificationLevel `json:"level"`
+}
+
+// FindNotificationSettings returns the notification settings of the provided user.
+func (c *Controller) FindNotificationSettings(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.NotificationSettings, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.settings.NotificationSettings(ctx, user.ID)
+}
+
+// UpdateNotificationSettings updates the notification settings of the provided user.
+func (c *Controller) UpdateNotificationSettings(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *NotificationSettingsUpdateInput,
+) (*types.NotificationSettings, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	notificationSettings, err := c.settings.NotificationSettings(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Level != nil {
+		level, ok := in.Level.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Notification level %q is not supported.", *in.Level)
+		}
+		notificationSettings.Level = level
+	}
+
+	err = c.settings.SetNotificationSettings(ctx, user.ID, notificationSettings, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	return notificationSettings, nil
+}
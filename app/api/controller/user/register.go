@@ -17,6 +17,8 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/usererror"
@@ -29,12 +31,57 @@ type RegisterInput struct {
 	DisplayName string `json:"display_name"`
 	UID         string `json:"uid"`
 	Password    string `json:"password"`
+
+	// InvitationToken allows registration even if user sign-up is disabled.
+	InvitationToken string `json:"invitation_token"`
 }
 
 // Register creates a new user and returns a new session token on success.
 // This doesn't require auth, but has limited functionalities (unable to create admin user for example).
+// If the user has to be approved by an admin first, no session token is returned.
 func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 	in *RegisterInput) (*types.TokenResponse, error) {
+	createInput := &CreateInput{
+		UID:         in.UID,
+		Email:       in.Email,
+		DisplayName: in.DisplayName,
+		Password:    in.Password,
+	}
+
+	var user *types.User
+	var err error
+
+	if in.InvitationToken != "" {
+		user, err = c.registerWithInvitation(ctx, createInput, in.InvitationToken)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		user, err = c.registerOpen(ctx, sysCtrl, createInput)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if user.ApprovalPending {
+		return nil, nil //nolint:nilnil // no session is created until the user is approved.
+	}
+
+	// TODO: how should we name session tokens?
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, "register",
+		c.instanceSettings.SessionTTL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token after successful user creation: %w", err)
+	}
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+func (c *Controller) registerOpen(
+	ctx context.Context,
+	sysCtrl *system.Controller,
+	in *CreateInput,
+) (*types.User, error) {
 	signUpAllowed, err := sysCtrl.IsUserSignupAllowed(ctx)
 	if err != nil {
 		return nil, err
@@ -44,21 +91,68 @@ func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 		return nil, usererror.Forbidden("User sign-up is disabled")
 	}
 
-	user, err := c.CreateNoAuth(ctx, &CreateInput{
-		UID:         in.UID,
-		Email:       in.Email,
-		DisplayName: in.DisplayName,
-		Password:    in.Password,
-	}, false)
+	if !isEmailDomainAllowed(in.Email, c.instanceSettings.UserSignupAllowedDomains()) {
+		return nil, usererror.Forbidden("User sign-up is not allowed for the domain of the email address")
+	}
+
+	user, err := c.createNoAuth(ctx, in, false, c.instanceSettings.UserSignupApprovalRequired())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// TODO: how should we name session tokens?
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, "register")
+	return user, nil
+}
+
+func (c *Controller) registerWithInvitation(
+	ctx context.Context,
+	in *CreateInput,
+	invitationToken string,
+) (*types.User, error) {
+	inv, err := c.invitation.FindPending(ctx, invitationToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create token after successful user creation: %w", err)
+		return nil, err
 	}
 
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	if !strings.EqualFold(strings.TrimSpace(in.Email), inv.Email) {
+		return nil, usererror.Forbidden("The email address doesn't match the invitation.")
+	}
+
+	now := time.Now().UnixMilli()
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = c.createNoAuth(ctx, in, false, false)
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		return c.acceptInvitation(ctx, inv, user, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// isEmailDomainAllowed checks whether the domain of the email is in the list of allowed domains.
+// An empty list allows all domains.
+func isEmailDomainAllowed(email string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 {
+		return false
+	}
+
+	domain := strings.TrimSpace(email[idx+1:])
+	for _, allowed := range allowedDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(allowed, "@")) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import "testing"
+
+func TestIsEmailDomainAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		domains []string
+		want    bool
+	}{
+		{name: "no-restriction", email: "jane@example.com", domains: nil, want: true},
+		{name: "allowed", email: "jane@example.com", domains: []string{"harness.io", "example.com"}, want: true},
+		{name: "allowed-case-insensitive", email: "jane@Example.COM", domains: []string{"example.com"}, want: true},
+		{name: "allowed-with-at", email: "jane@example.com", domains: []string{"@example.com"}, want: true},
+		{name: "not-allowed", email: "jane@example.org", domains: []string{"example.com"}, want: false},
+		{name: "subdomain", email: "jane@mail.example.com", domains: []string{"example.com"}, want: false},
+		{name: "suffix", email: "jane@badexample.com", domains: []string{"example.com"}, want: false},
+		{name: "no-domain", email: "jane", domains: []string{"example.com"}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isEmailDomainAllowed(test.email, test.domains); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
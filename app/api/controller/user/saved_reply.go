@@ -0,0 +1,231 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxSavedReplies          = 100
+	maxSavedReplyTitleLength = 100
+	maxSavedReplyTextLength  = 65536
+)
+
+// SavedReplyCreateInput is the input for creating a saved reply.
+type SavedReplyCreateInput struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// SavedReplyUpdateInput is the input for updating a saved reply.
+type SavedReplyUpdateInput struct {
+	Title *string `json:"title"`
+	Text  *string `json:"text"`
+}
+
+// ListSavedReplies returns the saved replies of the provided user, ordered by title.
+func (c *Controller) ListSavedReplies(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.SavedReply, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserView)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := c.savedReplyStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved replies: %w", err)
+	}
+
+	return replies, nil
+}
+
+// CreateSavedReply creates a new saved reply for the provided user.
+func (c *Controller) CreateSavedReply(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *SavedReplyCreateInput,
+) (*types.SavedReply, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	reply := &types.SavedReply{
+		PrincipalID: user.ID,
+		Title:       strings.TrimSpace(in.Title),
+		Text:        in.Text,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = checkSavedReply(reply); err != nil {
+		return nil, err
+	}
+
+	count, err := c.savedReplyStore.Count(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count saved replies: %w", err)
+	}
+	if count >= maxSavedReplies {
+		return nil, usererror.BadRequestf("A user can have at most %d saved replies.", maxSavedReplies)
+	}
+
+	err = c.savedReplyStore.Create(ctx, reply)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A saved reply with this title already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved reply: %w", err)
+	}
+
+	return reply, nil
+}
+
+// UpdateSavedReply updates a saved reply of the provided user.
+func (c *Controller) UpdateSavedReply(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+	in *SavedReplyUpdateInput,
+) (*types.SavedReply, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.findSavedReply(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Title != nil {
+		reply.Title = strings.TrimSpace(*in.Title)
+	}
+	if in.Text != nil {
+		reply.Text = *in.Text
+	}
+
+	if err = checkSavedReply(reply); err != nil {
+		return nil, err
+	}
+
+	reply.Updated = time.Now().UnixMilli()
+
+	err = c.savedReplyStore.Update(ctx, reply)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A saved reply with this title already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update saved reply: %w", err)
+	}
+
+	return reply, nil
+}
+
+// DeleteSavedReply deletes a saved reply of the provided user.
+func (c *Controller) DeleteSavedReply(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+) error {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return err
+	}
+
+	reply, err := c.findSavedReply(ctx, user, id)
+	if err != nil {
+		return err
+	}
+
+	if err = c.savedReplyStore.Delete(ctx, reply.ID); err != nil {
+		return fmt.Errorf("failed to delete saved reply: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) findUserCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	permission enum.Permission,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, permission); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// findSavedReply returns the saved reply if it belongs to the user.
+func (c *Controller) findSavedReply(ctx context.Context, user *types.User, id int64) (*types.SavedReply, error) {
+	reply, err := c.savedReplyStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saved reply: %w", err)
+	}
+
+	if reply.PrincipalID != user.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return reply, nil
+}
+
+func checkSavedReply(reply *types.SavedReply) error {
+	if reply.Title == "" {
+		return check.NewValidationError("The title of a saved reply can't be empty.")
+	}
+	if utf8.RuneCountInString(reply.Title) > maxSavedReplyTitleLength {
+		return check.NewValidationErrorf("The title of a saved reply can be at most %d characters long.",
+			maxSavedReplyTitleLength)
+	}
+
+	if strings.TrimSpace(reply.Text) == "" {
+		return check.NewValidationError("The text of a saved reply can't be empty.")
+	}
+	if len(reply.Text) > maxSavedReplyTextLength {
+		return check.NewValidationErrorf("The text of a saved reply can be at most %d bytes long.",
+			maxSavedReplyTextLength)
+	}
+
+	return nil
+}
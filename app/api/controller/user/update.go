@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -34,8 +35,17 @@ type UpdateInput struct {
 	Email       *string `json:"email"`
 	Password    *string `json:"password"`
 	DisplayName *string `json:"display_name"`
+
+	TimeZone      *string `json:"time_zone"`
+	Pronouns      *string `json:"pronouns"`
+	StatusMessage *string `json:"status_message"`
 }
 
+const (
+	maxPronounsLength      = 32
+	maxStatusMessageLength = 140
+)
+
 // Update updates the provided user.
 func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	userUID string, in *UpdateInput) (*types.User, error) {
@@ -59,6 +69,15 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	if in.Email != nil {
 		user.Email = *in.Email
 	}
+	if in.TimeZone != nil {
+		user.TimeZone = *in.TimeZone
+	}
+	if in.Pronouns != nil {
+		user.Pronouns = *in.Pronouns
+	}
+	if in.StatusMessage != nil {
+		user.StatusMessage = *in.StatusMessage
+	}
 	if in.Password != nil {
 		var hash []byte
 		hash, err = hashPassword([]byte(*in.Password), bcrypt.DefaultCost)
@@ -98,5 +117,28 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.TimeZone != nil {
+		*in.TimeZone = strings.TrimSpace(*in.TimeZone)
+		// time.LoadLocation returns UTC for an empty name, which is used to unset the time zone.
+		if _, err := time.LoadLocation(*in.TimeZone); err != nil || *in.TimeZone == "Local" {
+			return check.NewValidationErrorf("Unknown time zone %q.", *in.TimeZone)
+		}
+	}
+
+	if in.Pronouns != nil {
+		*in.Pronouns = strings.TrimSpace(*in.Pronouns)
+		if utf8.RuneCountInString(*in.Pronouns) > maxPronounsLength {
+			return check.NewValidationErrorf("Pronouns can be at most %d characters long.", maxPronounsLength)
+		}
+	}
+
+	if in.StatusMessage != nil {
+		*in.StatusMessage = strings.TrimSpace(*in.StatusMessage)
+		if utf8.RuneCountInString(*in.StatusMessage) > maxStatusMessageLength {
+			return check.NewValidationErrorf("The status message can be at most %d characters long.",
+				maxStatusMessageLength)
+		}
+	}
+
 	return nil
 }
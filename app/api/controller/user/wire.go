@@ -16,6 +16,12 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/breakglass"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -35,6 +41,17 @@ func ProvideController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	userActivityStore store.UserActivityStore,
+	systemReporter *systemevents.Reporter,
+	settings *settings.Service,
+	avatar *avatar.Service,
+	instanceSettings *instance.Service,
+	invitationStore store.InvitationStore,
+	invitation *invitation.Service,
+	breakGlass *breakglass.Service,
+	savedReplyStore store.SavedReplyStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -42,5 +59,16 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		spaceStore,
+		repoStore,
+		userActivityStore,
+		systemReporter,
+		settings,
+		avatar,
+		instanceSettings,
+		invitationStore,
+		invitation,
+		breakGlass,
+		savedReplyStore)
 }
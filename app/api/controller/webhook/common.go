@@ -15,10 +15,15 @@
 package webhook
 
 import (
+	"errors"
 	"net"
+	"net/textproto"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
@@ -28,12 +33,31 @@ const (
 	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
+	// webhookMaxHeaders defines the max allowed number of custom headers of a webhook.
+	webhookMaxHeaders = 16
+	// webhookMaxHeaderNameLength defines the max allowed length of a custom header name.
+	webhookMaxHeaderNameLength = 128
+	// webhookMaxHeaderValueLength defines the max allowed length of a custom header value.
+	webhookMaxHeaderValueLength = 256
+)
+
+var (
+	headerNameRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+	// reservedHeaders are set by the webhook delivery itself and can't be overwritten.
+	reservedHeaders = map[string]struct{}{
+		"Host":              {},
+		"Content-Type":      {},
+		"Content-Length":    {},
+		"Transfer-Encoding": {},
+		"User-Agent":        {},
+	}
 )
 
 var ErrInternalWebhookOperationNotAllowed = usererror.Forbidden("changes to internal webhooks are not allowed")
 
 // checkURL validates the url of a webhook.
-func checkURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool) error {
+func checkURL(rawURL string, networkPolicy *webhook.NetworkPolicy, internal bool) error {
 	// check URL
 	if len(rawURL) > webhookMaxURLLength {
 		return check.NewValidationErrorf("The URL of a webhook can be at most %d characters long.",
@@ -58,11 +82,11 @@ func checkURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool) error
 	}
 
 	if ip := net.ParseIP(host); ip != nil {
-		if !allowLoopback && ip.IsLoopback() {
+		err = networkPolicy.Check(ip, internal)
+		if errors.Is(err, webhook.ErrLoopbackNotAllowed) {
 			return check.NewValidationError("Loopback IP addresses are not allowed.")
 		}
-
-		if !allowPrivateNetwork && ip.IsPrivate() {
+		if errors.Is(err, webhook.ErrPrivateNetworkNotAllowed) {
 			return check.NewValidationError("Private IP addresses are not allowed.")
 		}
 	}
@@ -84,6 +108,31 @@ func checkSecret(secret string) error {
 	return nil
 }
 
+// checkHeaders validates the custom headers of a webhook.
+func checkHeaders(headers map[string]string) error {
+	if len(headers) > webhookMaxHeaders {
+		return check.NewValidationErrorf("A webhook can have at most %d custom headers.", webhookMaxHeaders)
+	}
+
+	for name, value := range headers {
+		if len(name) > webhookMaxHeaderNameLength || !headerNameRegex.MatchString(name) {
+			return check.NewValidationErrorf("The header name %q is invalid.", name)
+		}
+		if _, ok := reservedHeaders[textproto.CanonicalMIMEHeaderKey(name)]; ok {
+			return check.NewValidationErrorf("The header %q can't be customized.", name)
+		}
+		if len(value) > webhookMaxHeaderValueLength {
+			return check.NewValidationErrorf("The value of a header can be at most %d characters long.",
+				webhookMaxHeaderValueLength)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return check.NewValidationErrorf("The value of header %q contains invalid characters.", name)
+		}
+	}
+
+	return nil
+}
+
 // checkTriggers validates the triggers of a webhook.
 func checkTriggers(triggers []enum.WebhookTrigger) error {
 	// ignore duplicates here, should be deduplicated later
@@ -24,42 +24,35 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
-	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
-	allowLoopback       bool
-	allowPrivateNetwork bool
+	networkPolicy *webhook.NetworkPolicy
 
 	authorizer            authz.Authorizer
 	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
 	repoStore             store.RepoStore
 	webhookService        *webhook.Service
-	encrypter             encrypt.Encrypter
 }
 
 func NewController(
-	allowLoopback bool,
-	allowPrivateNetwork bool,
+	networkPolicy *webhook.NetworkPolicy,
 	authorizer authz.Authorizer,
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	webhookService *webhook.Service,
-	encrypter encrypt.Encrypter,
 ) *Controller {
 	return &Controller{
-		allowLoopback:         allowLoopback,
-		allowPrivateNetwork:   allowPrivateNetwork,
+		networkPolicy:         networkPolicy,
 		authorizer:            authorizer,
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
 		webhookService:        webhookService,
-		encrypter:             encrypter,
 	}
 }
 
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store/database/migrate"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -43,6 +44,9 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// Headers are custom headers sent with every delivery, values can reference
+	// secrets of the repository or its parent space using ${{ secrets.IDENTIFIER }}.
+	Headers map[string]string `json:"headers"`
 }
 
 // Create creates a new webhook.
@@ -56,7 +60,7 @@ func (c *Controller) Create(
 	internal bool,
 ) (*types.Webhook, error) {
 	// validate input
-	err := sanitizeCreateInput(in, c.allowLoopback, c.allowPrivateNetwork || internal)
+	err := sanitizeCreateInput(in, c.networkPolicy, internal)
 	if err != nil {
 		return nil, err
 	}
@@ -68,11 +72,6 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	encryptedSecret, err := c.encrypter.Encrypt(in.Secret)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
-	}
-
 	// create new webhook object
 	hook := &types.Webhook{
 		ID:         0, // the ID will be populated in the data layer
@@ -89,10 +88,11 @@ func (c *Controller) Create(
 		DisplayName:           in.DisplayName,
 		Description:           in.Description,
 		URL:                   in.URL,
-		Secret:                string(encryptedSecret),
+		Secret:                in.Secret,
 		Enabled:               in.Enabled,
 		Insecure:              in.Insecure,
 		Triggers:              deduplicateTriggers(in.Triggers),
+		Headers:               in.Headers,
 		LatestExecutionResult: nil,
 	}
 
@@ -121,7 +121,7 @@ func (c *Controller) Create(
 	return hook, nil
 }
 
-func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwork bool) error {
+func sanitizeCreateInput(in *CreateInput, networkPolicy *webhook.NetworkPolicy, internal bool) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == "" {
 		in.Identifier = in.UID
@@ -149,13 +149,16 @@ func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwor
 	if err := check.Description(in.Description); err != nil {
 		return err
 	}
-	if err := checkURL(in.URL, allowLoopback, allowPrivateNetwork); err != nil {
+	if err := checkURL(in.URL, networkPolicy, internal); err != nil {
 		return err
 	}
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := checkHeaders(in.Headers); err != nil { //nolint:revive
 		return err
 	}
 
@@ -16,9 +16,10 @@ package webhook
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -36,6 +37,11 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// Headers replace the custom headers of the webhook if provided (an empty object removes all of them).
+	Headers map[string]string `json:"headers"`
+
+	// Version is optional, if provided the update fails if the webhook has been modified in the meantime.
+	Version *int64 `json:"version"`
 }
 
 // Update updates an existing webhook.
@@ -47,7 +53,7 @@ func (c *Controller) Update(
 	in *UpdateInput,
 	allowModifyingInternal bool,
 ) (*types.Webhook, error) {
-	if err := sanitizeUpdateInput(in, c.allowLoopback, c.allowPrivateNetwork); err != nil {
+	if err := sanitizeUpdateInput(in, c.networkPolicy); err != nil {
 		return nil, err
 	}
 
@@ -66,6 +72,10 @@ func (c *Controller) Update(
 		return nil, ErrInternalWebhookOperationNotAllowed
 	}
 
+	if in.Version != nil && *in.Version != hook.Version {
+		return nil, usererror.ErrVersionConflict
+	}
+
 	// update webhook struct (only for values that are provided)
 	if in.Identifier != nil {
 		hook.Identifier = *in.Identifier
@@ -80,11 +90,7 @@ func (c *Controller) Update(
 		hook.URL = *in.URL
 	}
 	if in.Secret != nil {
-		encryptedSecret, err := c.encrypter.Encrypt(*in.Secret)
-		if err != nil {
-			return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
-		}
-		hook.Secret = string(encryptedSecret)
+		hook.Secret = *in.Secret
 	}
 	if in.Enabled != nil {
 		hook.Enabled = *in.Enabled
@@ -95,6 +101,9 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
+	if in.Headers != nil {
+		hook.Headers = in.Headers
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
@@ -103,7 +112,7 @@ func (c *Controller) Update(
 	return hook, nil
 }
 
-func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwork bool) error {
+func sanitizeUpdateInput(in *UpdateInput, networkPolicy *webhook.NetworkPolicy) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == nil {
 		in.Identifier = in.UID
@@ -125,7 +134,7 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 		}
 	}
 	if in.URL != nil {
-		if err := checkURL(*in.URL, allowLoopback, allowPrivateNetwork); err != nil {
+		if err := checkURL(*in.URL, networkPolicy, false); err != nil {
 			return err
 		}
 	}
@@ -139,6 +148,11 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 			return err
 		}
 	}
+	if in.Headers != nil {
+		if err := checkHeaders(in.Headers); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
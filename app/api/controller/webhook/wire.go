@@ -18,7 +18,6 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
-	"github.com/harness/gitness/encrypt"
 
 	"github.com/google/wire"
 )
@@ -28,12 +27,12 @@ var WireSet = wire.NewSet(
 	ProvideController,
 )
 
-func ProvideController(config webhook.Config, authorizer authz.Authorizer,
+func ProvideController(networkPolicy *webhook.NetworkPolicy, authorizer authz.Authorizer,
 	webhookStore store.WebhookStore, webhookExecutionStore store.WebhookExecutionStore,
-	repoStore store.RepoStore, webhookService *webhook.Service, encrypter encrypt.Encrypter,
+	repoStore store.RepoStore, webhookService *webhook.Service,
 ) *Controller {
 	return NewController(
-		config.AllowLoopback, config.AllowPrivateNetwork, authorizer,
+		networkPolicy, authorizer,
 		webhookStore, webhookExecutionStore,
-		repoStore, webhookService, encrypter)
+		repoStore, webhookService)
 }
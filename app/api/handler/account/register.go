@@ -49,6 +49,14 @@ func HandleRegister(userCtrl *user.Controller, sysCtrl *system.Controller, cooki
 			return
 		}
 
+		// the user has to be approved by an admin before it can login.
+		if tokenResponse == nil {
+			render.JSON(w, http.StatusAccepted, struct {
+				ApprovalPending bool `json:"approval_pending"`
+			}{ApprovalPending: true})
+			return
+		}
+
 		if includeCookie {
 			includeTokenCookie(r, w, tokenResponse, cookieName)
 		}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badge
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/badge"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleChecks returns a http.HandlerFunc that renders the status checks badge of a branch.
+func HandleChecks(badgeCtrl *badge.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out, err := badgeCtrl.Checks(ctx, session, repoRef, request.GetBranchFromQuery(r))
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		renderBadge(w, badgeCtrl, out)
+	}
+}
+
+// HandleBuild returns a http.HandlerFunc that renders the build badge of a branch.
+func HandleBuild(badgeCtrl *badge.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out, err := badgeCtrl.Build(ctx, session, repoRef,
+			request.GetBranchFromQuery(r), request.GetPipelineFromQuery(r))
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		renderBadge(w, badgeCtrl, out)
+	}
+}
+
+// HandlePullReqs returns a http.HandlerFunc that renders the open pull requests badge of a repository.
+func HandlePullReqs(badgeCtrl *badge.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out, err := badgeCtrl.PullReqs(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		renderBadge(w, badgeCtrl, out)
+	}
+}
+
+// renderBadge writes the badge as SVG image. Badges of public repositories may be cached by shared caches
+// (e.g. image proxies of code hosting platforms), badges of private repositories only by the client.
+func renderBadge(w http.ResponseWriter, badgeCtrl *badge.Controller, b *badge.Badge) {
+	render.Cache(w, b.Public, badgeCtrl.CacheDuration())
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b.SVG())
+}
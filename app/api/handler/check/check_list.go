@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
 )
 
 // HandleCheckList is an HTTP handler for listing status check results for a repository.
@@ -40,7 +41,11 @@ func HandleCheckList(checkCtrl *check.Controller) http.HandlerFunc {
 			return
 		}
 
-		opts := request.ParseCheckListOptions(r)
+		opts, err := request.ParseCheckListOptions(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		checks, count, err := checkCtrl.ListChecks(ctx, session, repoRef, commitSHA, opts)
 		if err != nil {
@@ -49,6 +54,14 @@ func HandleCheckList(checkCtrl *check.Controller) http.HandlerFunc {
 		}
 
 		render.Pagination(r, w, opts.Page, opts.Size, count)
+		nextCursor := request.NextCursor(checks, opts.Size, func(c types.Check) int64 { return c.ID })
+		render.PaginationCursor(r, w, opts.Size, nextCursor)
+
+		if request.CursorRequested(r) {
+			render.JSONCursorPage(w, http.StatusOK, checks, nextCursor)
+			return
+		}
+
 		render.JSON(w, http.StatusOK, checks)
 	}
 }
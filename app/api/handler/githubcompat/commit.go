@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+)
+
+var matcherSHA = regexp.MustCompile("^[0-9a-fA-F]{40}([0-9a-fA-F]{24})?$")
+
+// HandleListCommits lists the commits of a branch (the default branch if none is provided).
+func HandleListCommits(repoCtrl *repo.Controller, urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		page, perPage, err := parsePagination(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		gitRef := request.QueryParamOrDefault(r, queryParamSHA, repository.DefaultBranch)
+
+		list, err := repoCtrl.ListCommits(ctx, session, repoRef, gitRef, &types.CommitFilter{
+			PaginationFilter: types.PaginationFilter{Page: page, Limit: perPage},
+			Path:             request.QueryParamOrDefault(r, queryParamPath, ""),
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]commit, len(list.Commits))
+		for i := range list.Commits {
+			out[i] = c.commit(repository, &list.Commits[i])
+		}
+
+		writeLinkHeader(w, r, urlProvider, page, perPage, len(out), nil)
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleGetCommit returns a single commit, the reference can be a commit sha, branch or tag name.
+func HandleGetCommit(repoCtrl *repo.Controller, urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		ref, err := getRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cmt, err := resolveCommit(ctx, repoCtrl, session, repoRef, ref)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, c.commit(repository, cmt))
+	}
+}
+
+// resolveCommit returns the commit of the reference, which can be a commit sha, branch or tag name.
+func resolveCommit(
+	ctx context.Context,
+	repoCtrl *repo.Controller,
+	session *auth.Session,
+	repoRef string,
+	ref string,
+) (*types.Commit, error) {
+	if matcherSHA.MatchString(ref) {
+		return repoCtrl.GetCommit(ctx, session, repoRef, ref)
+	}
+
+	list, err := repoCtrl.ListCommits(ctx, session, repoRef, ref, &types.CommitFilter{
+		PaginationFilter: types.PaginationFilter{Page: 1, Limit: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list.Commits) == 0 {
+		return nil, usererror.NotFound("No commit found for the ref " + ref)
+	}
+
+	return &list.Commits[0], nil
+}
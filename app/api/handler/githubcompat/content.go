@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleGetContent returns the content of a file or the entries of a directory.
+func HandleGetContent(repoCtrl *repo.Controller, urlProvider urlprovider.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		gitRef := request.QueryParamOrDefault(r, queryParamRef, "")
+		filePath := getWildcardFromPath(r)
+
+		out, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, filePath, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if dir, ok := out.Content.(*repo.DirContent); ok {
+			entries := make([]content, len(dir.Entries))
+			for i := range dir.Entries {
+				entries[i] = c.contentInfo(repoRef, gitRef, &dir.Entries[i])
+			}
+
+			render.JSON(w, http.StatusOK, entries)
+			return
+		}
+
+		result := c.contentInfo(repoRef, gitRef, &out.ContentInfo)
+
+		switch v := out.Content.(type) {
+		case *repo.FileContent:
+			if v.DataSize < v.Size {
+				render.TranslatedUserError(ctx, w,
+					usererror.BadRequest("The file is too large to be returned, use the download url instead."))
+				return
+			}
+
+			result.Encoding = string(enum.ContentEncodingTypeBase64)
+			result.Size = v.Size
+			result.Content = v.Data
+			if v.Encoding != enum.ContentEncodingTypeBase64 {
+				result.Content = base64.StdEncoding.EncodeToString([]byte(v.Data))
+			}
+		case *repo.SymlinkContent:
+			result.Target = v.Target
+			result.Size = v.Size
+		case *repo.SubmoduleContent:
+			result.SubmoduleGitURL = v.URL
+		}
+
+		render.JSON(w, http.StatusOK, result)
+	}
+}
+
+func (c converter) contentInfo(repoRef string, gitRef string, info *repo.ContentInfo) content {
+	out := content{
+		Type:    string(info.Type),
+		Name:    info.Name,
+		Path:    info.Path,
+		SHA:     info.SHA,
+		URL:     c.apiURL(repoRef, "contents", info.Path),
+		HTMLURL: c.urlProvider.GenerateUIFileURL(repoRef, gitRef, info.Path),
+	}
+
+	if info.Type == repo.ContentTypeFile {
+		rawURL := c.urlProvider.GenerateAPIURL(
+			path.Join("v1/repos", url.PathEscape(repoRef), "+/raw", info.Path))
+		if gitRef != "" {
+			rawURL += "?" + url.Values{request.QueryParamGitRef: []string{gitRef}}.Encode()
+		}
+		out.DownloadURL = rawURL
+	}
+
+	return out
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+// converter maps gitness types to their GitHub API representation.
+type converter struct {
+	urlProvider url.Provider
+}
+
+func newConverter(urlProvider url.Provider) converter {
+	return converter{urlProvider: urlProvider}
+}
+
+func (c converter) apiURL(repoPath string, elem ...string) string {
+	return c.urlProvider.GenerateAPIURL(path.Join(append([]string{"v3/repos", repoPath}, elem...)...))
+}
+
+func (c converter) user(info *types.PrincipalInfo) user {
+	userType := "User"
+	if info.Type != enum.PrincipalTypeUser {
+		userType = "Bot"
+	}
+
+	return user{
+		Login:   info.UID,
+		ID:      info.ID,
+		Name:    info.DisplayName,
+		Email:   info.Email,
+		Type:    userType,
+		HTMLURL: c.urlProvider.GenerateUIUserURL(info.UID),
+	}
+}
+
+func (c converter) repository(repo *types.Repository) repository {
+	owner, _, _ := strings.Cut(repo.Path, "/")
+
+	visibility := "private"
+	if repo.IsPublic {
+		visibility = "public"
+	}
+
+	return repository{
+		ID:       repo.ID,
+		Name:     repo.Identifier,
+		FullName: repo.Path,
+		Owner: user{
+			Login:   owner,
+			ID:      repo.ParentID,
+			Type:    "Organization",
+			HTMLURL: c.urlProvider.GenerateUIRepoURL(owner),
+		},
+		Private:       !repo.IsPublic,
+		Visibility:    visibility,
+		Description:   repo.Description,
+		Fork:          repo.ForkID != 0,
+		DefaultBranch: repo.DefaultBranch,
+		URL:           c.apiURL(repo.Path),
+		HTMLURL:       c.urlProvider.GenerateUIRepoURL(repo.Path),
+		CloneURL:      c.urlProvider.GenerateGITCloneURL(repo.Path),
+		Size:          repo.Size,
+		CreatedAt:     formatTime(repo.Created),
+		UpdatedAt:     formatTime(repo.Updated),
+	}
+}
+
+func (c converter) branch(repo *types.Repository, name string, sha string) branch {
+	return branch{
+		Name: name,
+		Commit: commitRef{
+			SHA: sha,
+			URL: c.apiURL(repo.Path, "commits", sha),
+		},
+	}
+}
+
+func (c converter) commit(repo *types.Repository, in *types.Commit) commit {
+	parents := make([]commitRef, len(in.ParentSHAs))
+	for i, sha := range in.ParentSHAs {
+		parents[i] = commitRef{SHA: sha, URL: c.apiURL(repo.Path, "commits", sha)}
+	}
+
+	out := commit{
+		SHA: in.SHA,
+		Commit: gitCommit{
+			Message:   in.Message,
+			Author:    gitActorFrom(in.Author),
+			Committer: gitActorFrom(in.Committer),
+			URL:       c.apiURL(repo.Path, "git/commits", in.SHA),
+		},
+		URL:     c.apiURL(repo.Path, "commits", in.SHA),
+		HTMLURL: c.urlProvider.GenerateUIRepoURL(repo.Path) + "/commit/" + in.SHA,
+		Parents: parents,
+	}
+
+	if total := in.Stats.Total; total.Changes > 0 || total.Insertions > 0 || total.Deletions > 0 {
+		out.Stats = &stats{
+			Additions: total.Insertions,
+			Deletions: total.Deletions,
+			Total:     total.Insertions + total.Deletions,
+		}
+	}
+
+	return out
+}
+
+func (c converter) status(check *types.Check) status {
+	out := status{
+		ID:          check.ID,
+		State:       statusState(check.Status),
+		Description: check.Summary,
+		TargetURL:   check.Link,
+		Context:     check.Identifier,
+		CreatedAt:   formatTime(check.Created),
+		UpdatedAt:   formatTime(check.Updated),
+	}
+
+	if check.ReportedBy != nil {
+		creator := c.user(check.ReportedBy)
+		out.Creator = &creator
+	}
+
+	return out
+}
+
+func (c converter) pullRequest(repo *types.Repository, pr *types.PullReq) pullRequest {
+	owner, _, _ := strings.Cut(repo.Path, "/")
+	ghRepo := c.repository(repo)
+
+	out := pullRequest{
+		ID:       pr.ID,
+		Number:   pr.Number,
+		State:    pullRequestState(pr.State),
+		Title:    pr.Title,
+		Body:     pr.Description,
+		User:     c.user(&pr.Author),
+		Draft:    pr.IsDraft,
+		Merged:   pr.Merged != nil,
+		MergedAt: formatTimePtr(pr.Merged),
+		Head: pullRequestBranch{
+			Label: owner + ":" + pr.SourceBranch,
+			Ref:   pr.SourceBranch,
+			SHA:   pr.SourceSHA,
+			Repo:  ghRepo,
+		},
+		Base: pullRequestBranch{
+			Label: owner + ":" + pr.TargetBranch,
+			Ref:   pr.TargetBranch,
+			SHA:   pr.MergeBaseSHA,
+			Repo:  ghRepo,
+		},
+		URL:          c.apiURL(repo.Path, "pulls", strconv.FormatInt(pr.Number, 10)),
+		HTMLURL:      c.urlProvider.GenerateUIPRURL(repo.Path, pr.Number),
+		Comments:     pr.CommentCount,
+		Commits:      pr.Stats.Commits,
+		ChangedFiles: pr.Stats.FilesChanged,
+		CreatedAt:    formatTime(pr.Created),
+		UpdatedAt:    formatTime(pr.Edited),
+	}
+
+	if pr.Merger != nil {
+		merger := c.user(pr.Merger)
+		out.MergedBy = &merger
+	}
+
+	if pr.Merged != nil {
+		out.MergeCommitSHA = pr.MergeSHA
+	}
+
+	switch pr.MergeCheckStatus {
+	case enum.MergeCheckStatusMergeable:
+		out.Mergeable = ptr.Bool(true)
+	case enum.MergeCheckStatusConflict:
+		out.Mergeable = ptr.Bool(false)
+	case enum.MergeCheckStatusUnchecked:
+	}
+
+	switch pr.State {
+	case enum.PullReqStateMerged:
+		out.ClosedAt = formatTimePtr(pr.Merged)
+	case enum.PullReqStateClosed:
+		out.ClosedAt = formatTimePtr(&pr.Edited)
+	case enum.PullReqStateOpen:
+	}
+
+	return out
+}
+
+func gitActorFrom(sig types.Signature) gitActor {
+	return gitActor{
+		Name:  sig.Identity.Name,
+		Email: sig.Identity.Email,
+		Date:  sig.When.UTC().Format(time.RFC3339),
+	}
+}
+
+// statusState maps the status of a check to the state of a GitHub commit status,
+// which doesn't distinguish between pending and running.
+func statusState(s enum.CheckStatus) string {
+	if s == enum.CheckStatusRunning {
+		return string(enum.CheckStatusPending)
+	}
+
+	return string(s)
+}
+
+// pullRequestState maps the state of a pull request to GitHub, where merged pull requests are closed.
+func pullRequestState(s enum.PullReqState) string {
+	if s == enum.PullReqStateOpen {
+		return pullRequestStateOpen
+	}
+
+	return pullRequestStateClosed
+}
+
+func formatTime(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(millis *int64) *string {
+	if millis == nil {
+		return nil
+	}
+
+	s := formatTime(*millis)
+	return &s
+}
@@ -0,0 +1,363 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	pullRequestStateOpen   = "open"
+	pullRequestStateClosed = "closed"
+	pullRequestStateAll    = "all"
+)
+
+// HandleListPullRequests lists the pull requests of the repository.
+func HandleListPullRequests(
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	urlProvider url.Provider,
+) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		page, perPage, err := parsePagination(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		states, err := parsePullRequestStates(request.QueryParamOrDefault(r, queryParamState, pullRequestStateOpen))
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// GitHub expects the head in the "owner:branch" format.
+		head := request.QueryParamOrDefault(r, queryParamHead, "")
+		if _, branch, ok := strings.Cut(head, ":"); ok {
+			head = branch
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		list, total, err := pullreqCtrl.List(ctx, session, repoRef, &types.PullReqFilter{
+			Page:         page,
+			Size:         perPage,
+			SourceBranch: head,
+			TargetBranch: request.QueryParamOrDefault(r, queryParamBase, ""),
+			States:       states,
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderDesc,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]pullRequest, len(list))
+		for i := range list {
+			out[i] = c.pullRequest(repository, list[i])
+		}
+
+		count := int(total)
+		writeLinkHeader(w, r, urlProvider, page, perPage, len(out), &count)
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleGetPullRequest returns a pull request.
+func HandleGetPullRequest(
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	urlProvider url.Provider,
+) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		number, err := getNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Find(ctx, session, repoRef, number)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, c.pullRequest(repository, pr))
+	}
+}
+
+// HandleCreatePullRequest creates a new pull request.
+// Only pull requests within the same repository are supported.
+func HandleCreatePullRequest(
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	urlProvider url.Provider,
+) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(pullRequestCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		head := in.Head
+		if _, branch, ok := strings.Cut(head, ":"); ok {
+			head = branch
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Create(ctx, session, repoRef, &pullreq.CreateInput{
+			IsDraft:      in.Draft,
+			Title:        in.Title,
+			Description:  in.Body,
+			SourceBranch: head,
+			TargetBranch: in.Base,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, c.pullRequest(repository, pr))
+	}
+}
+
+// HandleUpdatePullRequest updates the title, body or state of a pull request.
+func HandleUpdatePullRequest(
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	urlProvider url.Provider,
+) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		number, err := getNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(pullRequestUpdateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Find(ctx, session, repoRef, number)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// GitHub allows partial updates, gitness always requires the title and the description.
+		if in.Title != nil || in.Body != nil {
+			updateInput := &pullreq.UpdateInput{
+				Title:       pr.Title,
+				Description: pr.Description,
+			}
+			if in.Title != nil {
+				updateInput.Title = *in.Title
+			}
+			if in.Body != nil {
+				updateInput.Description = *in.Body
+			}
+
+			pr, err = pullreqCtrl.Update(ctx, session, repoRef, number, updateInput)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+		}
+
+		if in.State != nil && *in.State != pullRequestState(pr.State) {
+			var state enum.PullReqState
+			switch *in.State {
+			case pullRequestStateOpen:
+				state = enum.PullReqStateOpen
+			case pullRequestStateClosed:
+				state = enum.PullReqStateClosed
+			default:
+				render.BadRequestf(ctx, w, "State must be either %s or %s.",
+					pullRequestStateOpen, pullRequestStateClosed)
+				return
+			}
+
+			pr, err = pullreqCtrl.State(ctx, session, repoRef, number, &pullreq.StateInput{
+				State:   state,
+				IsDraft: pr.IsDraft,
+			})
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+		}
+
+		render.JSON(w, http.StatusOK, c.pullRequest(repository, pr))
+	}
+}
+
+// HandleMergePullRequest merges a pull request.
+// If no SHA is provided, the current head of the pull request is merged.
+func HandleMergePullRequest(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		number, err := getNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(mergeInput)
+		if r.ContentLength != 0 {
+			err = json.NewDecoder(r.Body).Decode(in)
+			if err != nil {
+				render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+				return
+			}
+		}
+
+		if in.MergeMethod == "" {
+			in.MergeMethod = string(enum.MergeMethodMerge)
+		}
+
+		if in.SHA == "" {
+			pr, err := pullreqCtrl.Find(ctx, session, repoRef, number)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			in.SHA = pr.SourceSHA
+		}
+
+		result, violations, err := pullreqCtrl.Merge(ctx, session, repoRef, number, &pullreq.MergeInput{
+			Method:    enum.MergeMethod(in.MergeMethod),
+			SourceSHA: in.SHA,
+			Title:     in.CommitTitle,
+			Message:   in.CommitMessage,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if violations != nil {
+			render.JSON(w, http.StatusMethodNotAllowed,
+				usererror.New(http.StatusMethodNotAllowed, "Pull Request is not mergeable"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, mergeResult{
+			SHA:     result.SHA,
+			Merged:  true,
+			Message: "Pull Request successfully merged",
+		})
+	}
+}
+
+func parsePullRequestStates(state string) ([]enum.PullReqState, error) {
+	switch state {
+	case pullRequestStateOpen:
+		return []enum.PullReqState{enum.PullReqStateOpen}, nil
+	case pullRequestStateClosed:
+		return []enum.PullReqState{enum.PullReqStateClosed, enum.PullReqStateMerged}, nil
+	case pullRequestStateAll:
+		return nil, nil
+	default:
+		return nil, usererror.BadRequestf("State must be one of %s, %s or %s",
+			pullRequestStateOpen, pullRequestStateClosed, pullRequestStateAll)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+)
+
+// HandleGetRepo returns the repository.
+func HandleGetRepo(repoCtrl *repo.Controller, urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, c.repository(repository))
+	}
+}
+
+// HandleListBranches lists the branches of the repository.
+func HandleListBranches(repoCtrl *repo.Controller, urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		page, perPage, err := parsePagination(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, false, &types.BranchFilter{
+			Page: page,
+			Size: perPage,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]branch, len(branches))
+		for i := range branches {
+			out[i] = c.branch(repository, branches[i].Name, branches[i].SHA)
+		}
+
+		writeLinkHeader(w, r, urlProvider, page, perPage, len(out), nil)
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleGetBranch returns a branch of the repository.
+func HandleGetBranch(repoCtrl *repo.Controller, urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		b, err := repoCtrl.GetBranch(ctx, session, repoRef, getWildcardFromPath(r))
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, c.branch(repository, b.Name, b.SHA))
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+
+	"github.com/go-chi/chi"
+)
+
+const (
+	PathParamOwner    = "owner"
+	PathParamRepo     = "repo"
+	PathParamRef      = "ref"
+	PathParamNumber   = "number"
+	PathParamWildcard = "*"
+
+	queryParamPage    = "page"
+	queryParamPerPage = "per_page"
+	queryParamRef     = "ref"
+	queryParamSHA     = "sha"
+	queryParamPath    = "path"
+	queryParamState   = "state"
+	queryParamHead    = "head"
+	queryParamBase    = "base"
+
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
+// getRepoRefFromPath returns the gitness repo reference of the GitHub owner and repository name.
+func getRepoRefFromPath(r *http.Request) (string, error) {
+	owner, err := request.PathParamOrError(r, PathParamOwner)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := request.PathParamOrError(r, PathParamRepo)
+	if err != nil {
+		return "", err
+	}
+
+	return owner + "/" + strings.TrimSuffix(repo, ".git"), nil
+}
+
+func getRefFromPath(r *http.Request) (string, error) {
+	return request.PathParamOrError(r, PathParamRef)
+}
+
+func getNumberFromPath(r *http.Request) (int64, error) {
+	return request.PathParamAsPositiveInt64(r, PathParamNumber)
+}
+
+// getWildcardFromPath returns the (possibly empty) remainder of the path, like a file path or branch name.
+func getWildcardFromPath(r *http.Request) string {
+	return strings.Trim(chi.URLParam(r, PathParamWildcard), "/")
+}
+
+// parsePagination returns the page and page size using the GitHub query parameters.
+func parsePagination(r *http.Request) (int, int, error) {
+	page, err := request.QueryParamAsPositiveInt64OrDefault(r, queryParamPage, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	perPage, err := request.QueryParamAsPositiveInt64OrDefault(r, queryParamPerPage, defaultPerPage)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if perPage > maxPerPage {
+		return 0, 0, usererror.BadRequestf("%s can be at most %d", queryParamPerPage, maxPerPage)
+	}
+
+	return int(page), int(perPage), nil
+}
+
+// writeLinkHeader writes the Link header the way GitHub does, clients use it to iterate over the pages.
+// The total is optional, without it, the next page is linked if the current page is full.
+func writeLinkHeader(
+	w http.ResponseWriter,
+	r *http.Request,
+	urlProvider url.Provider,
+	page int,
+	perPage int,
+	count int,
+	total *int,
+) {
+	pageURL := func(p int) string {
+		params := r.URL.Query()
+		params.Set(queryParamPage, strconv.Itoa(p))
+		params.Set(queryParamPerPage, strconv.Itoa(perPage))
+		return urlProvider.GenerateAPIURL(r.URL.Path) + "?" + params.Encode()
+	}
+
+	var links []string
+
+	last := 0
+	if total != nil {
+		last = (*total + perPage - 1) / perPage
+		if last < 1 {
+			last = 1
+		}
+	}
+
+	hasNext := count == perPage
+	if total != nil {
+		hasNext = page < last
+	}
+
+	if hasNext {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"next\"", pageURL(page+1)))
+	}
+
+	if last > 0 && page < last {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"last\"", pageURL(last)))
+	}
+
+	if page > 1 {
+		links = append(links,
+			fmt.Sprintf("<%s>; rel=\"first\"", pageURL(1)),
+			fmt.Sprintf("<%s>; rel=\"prev\"", pageURL(page-1)))
+	}
+
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	defaultStatusContext = "default"
+	maxStatusContextLen  = 127
+)
+
+// regexpInvalidIdentifierChars matches characters that GitHub allows in status contexts, but gitness doesn't.
+var regexpInvalidIdentifierChars = regexp.MustCompile(`[^0-9a-zA-Z\-_.$]`)
+
+// HandleCreateStatus reports a commit status as gitness status check.
+// The status context is used as check identifier, unsupported characters are replaced.
+func HandleCreateStatus(checkCtrl *check.Controller, urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		sha, err := getRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(statusInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		reportInput, err := mapStatusInput(in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		statusCheck, err := checkCtrl.Report(ctx, session, repoRef, sha, reportInput, map[string]string{})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, c.status(statusCheck))
+	}
+}
+
+// HandleListStatuses lists the commit statuses of a reference.
+func HandleListStatuses(
+	repoCtrl *repo.Controller,
+	checkCtrl *check.Controller,
+	urlProvider url.Provider,
+) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		ref, err := getRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		page, perPage, err := parsePagination(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cmt, err := resolveCommit(ctx, repoCtrl, session, repoRef, ref)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		checks, count, err := checkCtrl.ListChecks(ctx, session, repoRef, cmt.SHA, types.CheckListOptions{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: perPage},
+			},
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]status, len(checks))
+		for i := range checks {
+			out[i] = c.status(&checks[i])
+		}
+
+		writeLinkHeader(w, r, urlProvider, page, perPage, len(out), &count)
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleGetCombinedStatus returns the combined state of all commit statuses of a reference.
+func HandleGetCombinedStatus(
+	repoCtrl *repo.Controller,
+	checkCtrl *check.Controller,
+	urlProvider url.Provider,
+) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		ref, err := getRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cmt, err := resolveCommit(ctx, repoCtrl, session, repoRef, ref)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		checks, _, err := checkCtrl.ListChecks(ctx, session, repoRef, cmt.SHA, types.CheckListOptions{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: 1, Size: maxPerPage},
+			},
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		statuses := make([]status, len(checks))
+		for i := range checks {
+			statuses[i] = c.status(&checks[i])
+		}
+
+		render.JSON(w, http.StatusOK, combinedStatus{
+			State:      combinedState(checks),
+			SHA:        cmt.SHA,
+			TotalCount: len(statuses),
+			Statuses:   statuses,
+			Repository: c.repository(repository),
+		})
+	}
+}
+
+func mapStatusInput(in *statusInput) (*check.ReportInput, error) {
+	checkStatus, ok := enum.CheckStatus(in.State).Sanitize()
+	if !ok || checkStatus == enum.CheckStatusRunning {
+		return nil, usererror.BadRequest("State must be one of error, failure, pending or success")
+	}
+
+	identifier := regexpInvalidIdentifierChars.ReplaceAllString(in.Context, "-")
+	if identifier == "" {
+		identifier = defaultStatusContext
+	}
+	if len(identifier) > maxStatusContextLen {
+		identifier = identifier[:maxStatusContextLen]
+	}
+
+	out := &check.ReportInput{
+		Identifier: identifier,
+		Status:     checkStatus,
+		Summary:    in.Description,
+		Link:       in.TargetURL,
+	}
+
+	// status checks without a link require a payload.
+	if in.TargetURL == "" {
+		out.Payload = types.CheckPayload{Kind: enum.CheckPayloadKindRaw}
+	}
+
+	return out, nil
+}
+
+// combinedState returns the combined state of the statuses the way GitHub computes it:
+// failure if any status failed, pending if any status is pending (or if there are none) and success otherwise.
+func combinedState(checks []types.Check) string {
+	if len(checks) == 0 {
+		return string(enum.CheckStatusPending)
+	}
+
+	state := enum.CheckStatusSuccess
+	for i := range checks {
+		switch checks[i].Status {
+		case enum.CheckStatusFailure, enum.CheckStatusError:
+			return string(enum.CheckStatusFailure)
+		case enum.CheckStatusPending, enum.CheckStatusRunning:
+			state = enum.CheckStatusPending
+		case enum.CheckStatusSuccess:
+		}
+	}
+
+	return string(state)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMapStatusInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             statusInput
+		wantErr        bool
+		wantIdentifier string
+		wantKind       enum.CheckPayloadKind
+	}{
+		{
+			name:           "context with slash and link",
+			in:             statusInput{State: "success", Context: "ci/build", TargetURL: "https://ci.example.com/1"},
+			wantIdentifier: "ci-build",
+			wantKind:       enum.CheckPayloadKindEmpty,
+		},
+		{
+			name:           "no context and no link",
+			in:             statusInput{State: "pending"},
+			wantIdentifier: defaultStatusContext,
+			wantKind:       enum.CheckPayloadKindRaw,
+		},
+		{
+			name:           "long context",
+			in:             statusInput{State: "failure", Context: strings.Repeat("a", 200)},
+			wantIdentifier: strings.Repeat("a", maxStatusContextLen),
+			wantKind:       enum.CheckPayloadKindRaw,
+		},
+		{
+			name:    "running isn't a github state",
+			in:      statusInput{State: "running"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown state",
+			in:      statusInput{State: "done"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := mapStatusInput(&test.in)
+			if test.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.Identifier != test.wantIdentifier {
+				t.Errorf("identifier: want %q, got %q", test.wantIdentifier, out.Identifier)
+			}
+			if out.Payload.Kind != test.wantKind {
+				t.Errorf("payload kind: want %q, got %q", test.wantKind, out.Payload.Kind)
+			}
+		})
+	}
+}
+
+func TestCombinedState(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []enum.CheckStatus
+		want     string
+	}{
+		{name: "no statuses", statuses: nil, want: "pending"},
+		{name: "all success", statuses: []enum.CheckStatus{"success", "success"}, want: "success"},
+		{name: "one pending", statuses: []enum.CheckStatus{"success", "running"}, want: "pending"},
+		{name: "one error", statuses: []enum.CheckStatus{"pending", "error"}, want: "failure"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checks := make([]types.Check, len(test.statuses))
+			for i, s := range test.statuses {
+				checks[i].Status = s
+			}
+
+			if got := combinedState(checks); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+// The types below mirror the subset of the GitHub REST API (v3) resources that is supported.
+// Fields that have no equivalent in gitness are omitted.
+
+type user struct {
+	Login   string `json:"login"`
+	ID      int64  `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Email   string `json:"email,omitempty"`
+	Type    string `json:"type"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+type repository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Owner         user   `json:"owner"`
+	Private       bool   `json:"private"`
+	Visibility    string `json:"visibility"`
+	Description   string `json:"description"`
+	Fork          bool   `json:"fork"`
+	DefaultBranch string `json:"default_branch"`
+	URL           string `json:"url"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	Size          int64  `json:"size"`
+	OpenIssues    int    `json:"open_issues_count"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+type branch struct {
+	Name      string    `json:"name"`
+	Commit    commitRef `json:"commit"`
+	Protected bool      `json:"protected"`
+}
+
+type commitRef struct {
+	SHA string `json:"sha"`
+	URL string `json:"url"`
+}
+
+type gitActor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+}
+
+type gitCommit struct {
+	Message   string    `json:"message"`
+	Author    gitActor  `json:"author"`
+	Committer gitActor  `json:"committer"`
+	Tree      commitRef `json:"tree"`
+	URL       string    `json:"url"`
+}
+
+type commit struct {
+	SHA     string      `json:"sha"`
+	Commit  gitCommit   `json:"commit"`
+	URL     string      `json:"url"`
+	HTMLURL string      `json:"html_url"`
+	Parents []commitRef `json:"parents"`
+	Stats   *stats      `json:"stats,omitempty"`
+}
+
+type stats struct {
+	Additions int64 `json:"additions"`
+	Deletions int64 `json:"deletions"`
+	Total     int64 `json:"total"`
+}
+
+type content struct {
+	Type            string `json:"type"`
+	Encoding        string `json:"encoding,omitempty"`
+	Size            int64  `json:"size"`
+	Name            string `json:"name"`
+	Path            string `json:"path"`
+	Content         string `json:"content,omitempty"`
+	SHA             string `json:"sha"`
+	URL             string `json:"url"`
+	HTMLURL         string `json:"html_url"`
+	DownloadURL     string `json:"download_url,omitempty"`
+	Target          string `json:"target,omitempty"`
+	SubmoduleGitURL string `json:"submodule_git_url,omitempty"`
+}
+
+type statusInput struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+type status struct {
+	ID          int64  `json:"id"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
+	Context     string `json:"context"`
+	Creator     *user  `json:"creator,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type combinedStatus struct {
+	State      string     `json:"state"`
+	SHA        string     `json:"sha"`
+	TotalCount int        `json:"total_count"`
+	Statuses   []status   `json:"statuses"`
+	Repository repository `json:"repository"`
+}
+
+type pullRequestBranch struct {
+	Label string     `json:"label"`
+	Ref   string     `json:"ref"`
+	SHA   string     `json:"sha"`
+	Repo  repository `json:"repo"`
+}
+
+type pullRequest struct {
+	ID             int64             `json:"id"`
+	Number         int64             `json:"number"`
+	State          string            `json:"state"`
+	Title          string            `json:"title"`
+	Body           string            `json:"body"`
+	User           user              `json:"user"`
+	Draft          bool              `json:"draft"`
+	Merged         bool              `json:"merged"`
+	MergedAt       *string           `json:"merged_at"`
+	MergedBy       *user             `json:"merged_by"`
+	MergeCommitSHA *string           `json:"merge_commit_sha"`
+	Mergeable      *bool             `json:"mergeable"`
+	Head           pullRequestBranch `json:"head"`
+	Base           pullRequestBranch `json:"base"`
+	URL            string            `json:"url"`
+	HTMLURL        string            `json:"html_url"`
+	Comments       int               `json:"comments"`
+	Commits        *int64            `json:"commits,omitempty"`
+	ChangedFiles   *int64            `json:"changed_files,omitempty"`
+	CreatedAt      string            `json:"created_at"`
+	UpdatedAt      string            `json:"updated_at"`
+	ClosedAt       *string           `json:"closed_at"`
+}
+
+type pullRequestCreateInput struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+	Draft bool   `json:"draft"`
+}
+
+type pullRequestUpdateInput struct {
+	Title *string `json:"title"`
+	Body  *string `json:"body"`
+	State *string `json:"state"`
+}
+
+type mergeInput struct {
+	CommitTitle   string `json:"commit_title"`
+	CommitMessage string `json:"commit_message"`
+	SHA           string `json:"sha"`
+	MergeMethod   string `json:"merge_method"`
+}
+
+type mergeResult struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcompat
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+)
+
+// HandleGetAuthenticatedUser returns the authenticated principal, tools use it to verify their credentials.
+func HandleGetAuthenticatedUser(urlProvider url.Provider) http.HandlerFunc {
+	c := newConverter(urlProvider)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, ok := request.AuthSessionFrom(ctx)
+		if !ok || session.Principal.ID <= 0 {
+			render.TranslatedUserError(ctx, w, usererror.ErrUnauthorized)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, c.user(session.Principal.ToPrincipalInfo()))
+	}
+}
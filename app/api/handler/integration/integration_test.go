@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/integration"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	integrationsvc "github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// allowAuthorizer permits all actions.
+type allowAuthorizer struct{}
+
+func (allowAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+func (allowAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return true, nil
+}
+
+func TestIntegrationHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := sqlx.Connect("sqlite3", "file:integration_handlers?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, cache.New(spacePathStore, store.ToLowerSpacePathTransformation),
+		spacePathStore)
+
+	space := &types.Space{Identifier: "acme", CreatedBy: 1}
+	if err = spaceStore.Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %v", err)
+	}
+	err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: space.Identifier, SpaceID: space.ID, CreatedBy: 1, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %v", err)
+	}
+
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+	integrationStore := database.NewIntegrationStore(db, encrypter)
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:               events.ModeInMemory,
+		MaxStreamLength:    100,
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    1,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+	prReaderFactory, err := pullreqevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create reader factory: %v", err)
+	}
+
+	integrationService, err := integrationsvc.NewService(ctx, integrationsvc.Config{
+		EventReaderName: "test",
+		Concurrency:     1,
+		AllowedHosts:    []string{"chat.example.com"},
+	}, prReaderFactory, integrationStore, nil, spaceStore, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create integration service: %v", err)
+	}
+
+	ctrl := integration.NewController(allowAuthorizer{}, integrationStore, nil, spaceStore, integrationService)
+
+	router := chi.NewRouter()
+	router.Route(fmt.Sprintf("/spaces/{%s}/integrations", request.PathParamSpaceRef), func(r chi.Router) {
+		r.Post("/", HandleCreate(ctrl, enum.ParentResourceTypeSpace))
+		r.Get("/", HandleList(ctrl, enum.ParentResourceTypeSpace))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIntegrationIdentifier), func(r chi.Router) {
+			r.Get("/", HandleFind(ctrl, enum.ParentResourceTypeSpace))
+			r.Delete("/", HandleDelete(ctrl, enum.ParentResourceTypeSpace))
+		})
+	})
+
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "admin", Type: enum.PrincipalTypeUser}}
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(request.WithAuthSession(r.Context(), session))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/spaces/acme/integrations",
+		`{"identifier":"teams","type":"teams","url":"https://intranet.local/hook","events":["pullreq_merged"]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected url of a disallowed host to be rejected, got %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(http.MethodPost, "/spaces/acme/integrations",
+		`{"identifier":"teams","type":"teams","url":"https://chat.example.com/hook","events":["pullreq_merged"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("failed to create integration, got %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(http.MethodGet, "/spaces/acme/integrations/teams", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to find integration, got %d: %s", w.Code, w.Body)
+	}
+
+	var found map[string]any
+	if err = json.NewDecoder(w.Body).Decode(&found); err != nil {
+		t.Fatalf("failed to decode integration: %v", err)
+	}
+
+	if found["has_url"] != true || found["url"] != nil || found["type"] != string(enum.IntegrationTypeTeams) {
+		t.Errorf("unexpected integration: %v", found)
+	}
+
+	w = serve(http.MethodDelete, "/spaces/acme/integrations/teams", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("failed to delete integration, got %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(http.MethodGet, "/spaces/acme/integrations", "")
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("expected no integrations after delete, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+// HandleSearchPullReqs returns a http.HandlerFunc that performs a full-text search over pull requests.
+func HandleSearchPullReqs(ctrl *keywordsearch.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		in := &types.PullReqSearchInput{
+			Query:     request.ParseQuery(r),
+			SpacePath: r.URL.Query().Get(request.QueryParamSpacePath),
+			Page:      request.ParsePage(r),
+			Size:      request.ParseLimit(r),
+		}
+
+		list, total, err := ctrl.SearchPullReqs(ctx, session, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Pagination(r, w, in.Page, in.Size, int(total))
+		render.JSON(w, http.StatusOK, list)
+	}
+}
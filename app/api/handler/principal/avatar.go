@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/principal"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleAvatar returns an http.HandlerFunc that renders the avatar of a principal.
+func HandleAvatar(principalCtrl principal.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		principalID, err := request.GetPrincipalIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		signedURL, file, err := principalCtrl.Avatar(ctx, principalID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// avatar urls change with every new avatar, so clients can cache them.
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+
+		if file == nil {
+			http.Redirect(w, r, signedURL, http.StatusTemporaryRedirect)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		render.Reader(ctx, w, http.StatusOK, file)
+		if err = file.Close(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to close avatar after rendering")
+		}
+	}
+}
@@ -45,6 +45,12 @@ func HandleDiff(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 			return
 		}
 
+		options, err := request.GetDiffOptionsFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		setSHAs := func(sourceSHA, mergeBaseSHA string) {
 			w.Header().Set("X-Source-Sha", sourceSHA)
 			w.Header().Set("X-Merge-Base-Sha", mergeBaseSHA)
@@ -63,7 +69,7 @@ func HandleDiff(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 		}
 
 		if strings.HasPrefix(r.Header.Get("Accept"), "text/plain") {
-			err := pullreqCtrl.RawDiff(ctx, w, session, repoRef, pullreqNumber, setSHAs, files...)
+			err := pullreqCtrl.RawDiff(ctx, w, session, repoRef, pullreqNumber, setSHAs, options, files...)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusOK)
 			}
@@ -71,7 +77,8 @@ func HandleDiff(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 		}
 
 		_, includePatch := request.QueryParam(r, "include_patch")
-		stream, err := pullreqCtrl.Diff(ctx, session, repoRef, pullreqNumber, setSHAs, includePatch, files...)
+		stream, err := pullreqCtrl.Diff(ctx, session, repoRef, pullreqNumber, setSHAs, includePatch, options,
+			files...)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -52,6 +53,14 @@ func HandleList(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 		}
 
 		render.Pagination(r, w, filter.Page, filter.Size, int(total))
+		nextCursor := request.NextCursor(list, filter.Size, func(pr *types.PullReq) int64 { return pr.ID })
+		render.PaginationCursor(r, w, filter.Size, nextCursor)
+
+		if request.CursorRequested(r) {
+			render.JSONCursorPage(w, http.StatusOK, list, nextCursor)
+			return
+		}
+
 		render.JSON(w, http.StatusOK, list)
 	}
 }
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/registry"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+type genericFileRef struct {
+	spaceRef    string
+	identifier  string
+	packageName string
+	version     string
+	fileName    string
+}
+
+func parseGenericFileRef(r *http.Request) (genericFileRef, error) {
+	var ref genericFileRef
+	var err error
+
+	if ref.spaceRef, err = request.GetSpaceRefFromPath(r); err != nil {
+		return ref, err
+	}
+	if ref.identifier, err = request.GetRegistryIdentifierFromPath(r); err != nil {
+		return ref, err
+	}
+	if ref.packageName, err = request.GetPackageNameFromPath(r); err != nil {
+		return ref, err
+	}
+	if ref.version, err = request.GetVersionNameFromPath(r); err != nil {
+		return ref, err
+	}
+	if ref.fileName, err = request.GetFileNameFromPath(r); err != nil {
+		return ref, err
+	}
+
+	return ref, nil
+}
+
+// HandleUploadGenericFile handles API that uploads a file of a generic package version.
+func HandleUploadGenericFile(registryCtrl *registry.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		ref, err := parseGenericFileRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		file, err := registryCtrl.UploadGenericFile(ctx, session, ref.spaceRef, ref.identifier,
+			ref.packageName, ref.version, ref.fileName, r.Header.Get("Content-Type"), r.Body)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, file)
+	}
+}
+
+// HandleDownloadGenericFile handles API that downloads a file of a generic package version.
+func HandleDownloadGenericFile(registryCtrl *registry.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		ref, err := parseGenericFileRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		file, signedURL, content, err := registryCtrl.DownloadGenericFile(ctx, session, ref.spaceRef,
+			ref.identifier, ref.packageName, ref.version, ref.fileName)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if content == nil {
+			http.Redirect(w, r, signedURL, http.StatusTemporaryRedirect)
+			return
+		}
+		defer func() {
+			if cErr := content.Close(); cErr != nil {
+				log.Ctx(ctx).Error().Err(cErr).Msg("failed to close file after rendering")
+			}
+		}()
+
+		w.Header().Set("Content-Type", file.MediaType)
+		w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+		render.Reader(ctx, w, http.StatusOK, content)
+	}
+}
+
+// HandleDeleteGenericFile handles API that deletes a file of a generic package version.
+func HandleDeleteGenericFile(registryCtrl *registry.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		ref, err := parseGenericFileRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		err = registryCtrl.DeleteGenericFile(ctx, session, ref.spaceRef, ref.identifier,
+			ref.packageName, ref.version, ref.fileName)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
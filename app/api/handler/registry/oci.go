@@ -0,0 +1,431 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/registry"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// OCIMount is the path the OCI distribution API is served under.
+	OCIMount = "/v2"
+
+	ociRealm = "Gitness Registry"
+
+	headerAPIVersion     = "Docker-Distribution-API-Version"
+	headerContentDigest  = "Docker-Content-Digest"
+	headerUploadUUID     = "Docker-Upload-UUID"
+	headerAuthenticate   = "WWW-Authenticate"
+	headerLocation       = "Location"
+	headerRange          = "Range"
+	headerContentRange   = "Content-Range"
+	headerContentType    = "Content-Type"
+	headerContentLength  = "Content-Length"
+	defaultBlobMediaType = "application/octet-stream"
+)
+
+type ociRouteKind int
+
+const (
+	ociRouteBase ociRouteKind = iota
+	ociRouteTags
+	ociRouteManifest
+	ociRouteBlob
+	ociRouteUpload
+)
+
+type ociRoute struct {
+	kind      ociRouteKind
+	name      string
+	reference string
+}
+
+// parseOCIPath parses the path (without the mount prefix) of an OCI distribution API request.
+// Image names can contain slashes, hence the routes are identified by their suffix.
+func parseOCIPath(path string) (ociRoute, bool) {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return ociRoute{kind: ociRouteBase}, true
+	}
+
+	if name, ok := strings.CutSuffix(path, "/tags/list"); ok && name != "" {
+		return ociRoute{kind: ociRouteTags, name: name}, true
+	}
+
+	// the separator found last wins, an upload wins over a blob as both match at the same position.
+	padded := path + "/"
+	best := ociRoute{}
+	bestIndex := -1
+	for _, r := range []struct {
+		kind      ociRouteKind
+		separator string
+	}{
+		{kind: ociRouteUpload, separator: "/blobs/uploads/"},
+		{kind: ociRouteManifest, separator: "/manifests/"},
+		{kind: ociRouteBlob, separator: "/blobs/"},
+	} {
+		i := strings.LastIndex(padded, r.separator)
+		if i <= bestIndex {
+			continue
+		}
+
+		bestIndex = i
+		best = ociRoute{
+			kind:      r.kind,
+			name:      path[:i],
+			reference: strings.TrimSuffix(padded[i+len(r.separator):], "/"),
+		}
+	}
+
+	if bestIndex <= 0 || strings.Contains(best.reference, "/") ||
+		(best.reference == "" && best.kind != ociRouteUpload) {
+		return ociRoute{}, false
+	}
+
+	return best, true
+}
+
+// HandleOCI handles the OCI distribution API used by container clients to push and pull images.
+func HandleOCI(registryCtrl *registry.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerAPIVersion, "registry/2.0")
+
+		route, ok := parseOCIPath(strings.TrimPrefix(r.URL.Path, OCIMount))
+		if !ok {
+			renderOCIError(r.Context(), w, &registry.OCIError{
+				Status:  http.StatusNotFound,
+				Code:    registry.OCICodeNameUnknown,
+				Message: "unknown endpoint",
+			})
+			return
+		}
+
+		h := &ociHandler{ctrl: registryCtrl, route: route}
+
+		switch route.kind {
+		case ociRouteBase:
+			h.handleBase(w, r)
+		case ociRouteTags:
+			h.handleTags(w, r)
+		case ociRouteManifest:
+			h.handleManifest(w, r)
+		case ociRouteBlob:
+			h.handleBlob(w, r)
+		case ociRouteUpload:
+			h.handleUpload(w, r)
+		}
+	}
+}
+
+type ociHandler struct {
+	ctrl  *registry.Controller
+	route ociRoute
+}
+
+func (h *ociHandler) handleBase(w http.ResponseWriter, r *http.Request) {
+	// clients check the base endpoint to find out whether they have to authenticate.
+	if _, ok := request.AuthSessionFrom(r.Context()); !ok {
+		renderOCIError(r.Context(), w, apiauth.ErrNotAuthenticated)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, struct{}{})
+}
+
+func (h *ociHandler) handleTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session, _ := request.AuthSessionFrom(ctx)
+
+	if r.Method != http.MethodGet {
+		renderOCIMethodNotAllowed(ctx, w)
+		return
+	}
+
+	n, err := strconv.Atoi(r.URL.Query().Get("n"))
+	if err != nil {
+		n = 0
+	}
+
+	tags, err := h.ctrl.ListTags(ctx, session, h.route.name, n, r.URL.Query().Get("last"))
+	if err != nil {
+		renderOCIError(ctx, w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, tags)
+}
+
+func (h *ociHandler) handleManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session, _ := request.AuthSessionFrom(ctx)
+	name, reference := h.route.name, h.route.reference
+
+	switch r.Method {
+	case http.MethodHead:
+		manifest, err := h.ctrl.FindManifest(ctx, session, name, reference)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		setContentHeaders(w, manifest)
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodGet:
+		manifest, content, err := h.ctrl.GetManifest(ctx, session, name, reference)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+		defer closeContent(ctx, content)
+
+		setContentHeaders(w, manifest)
+		render.Reader(ctx, w, http.StatusOK, content)
+
+	case http.MethodPut:
+		manifest, err := h.ctrl.PutManifest(ctx, session, name, reference, r.Header.Get(headerContentType), r.Body)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		w.Header().Set(headerLocation, fmt.Sprintf("%s/%s/manifests/%s", OCIMount, name, manifest.Digest))
+		w.Header().Set(headerContentDigest, manifest.Digest)
+		w.WriteHeader(http.StatusCreated)
+
+	case http.MethodDelete:
+		if err := h.ctrl.DeleteManifest(ctx, session, name, reference); err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		renderOCIMethodNotAllowed(ctx, w)
+	}
+}
+
+func (h *ociHandler) handleBlob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session, _ := request.AuthSessionFrom(ctx)
+	name, digest := h.route.name, h.route.reference
+
+	switch r.Method {
+	case http.MethodHead:
+		b, err := h.ctrl.FindBlob(ctx, session, name, digest)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		setContentHeaders(w, b)
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodGet:
+		b, signedURL, content, err := h.ctrl.GetBlob(ctx, session, name, digest)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		if content == nil {
+			w.Header().Set(headerContentDigest, b.Digest)
+			http.Redirect(w, r, signedURL, http.StatusTemporaryRedirect)
+			return
+		}
+		defer closeContent(ctx, content)
+
+		setContentHeaders(w, b)
+		render.Reader(ctx, w, http.StatusOK, content)
+
+	case http.MethodDelete:
+		renderOCIError(ctx, w, h.ctrl.DeleteBlob(ctx, session, name, digest))
+
+	default:
+		renderOCIMethodNotAllowed(ctx, w)
+	}
+}
+
+func (h *ociHandler) handleUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session, _ := request.AuthSessionFrom(ctx)
+	name, uploadID := h.route.name, h.route.reference
+	query := r.URL.Query()
+
+	if uploadID == "" {
+		if r.Method != http.MethodPost {
+			renderOCIMethodNotAllowed(ctx, w)
+			return
+		}
+
+		upload, b, err := h.ctrl.StartUpload(ctx, session, name, query.Get("digest"), query.Get("mount"), r.Body)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		if b != nil {
+			renderBlobCreated(w, name, b)
+			return
+		}
+
+		renderUploadProgress(w, http.StatusAccepted, name, upload)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		upload, err := h.ctrl.UploadStatus(ctx, session, name, uploadID)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		renderUploadProgress(w, http.StatusNoContent, name, upload)
+
+	case http.MethodPatch:
+		upload, err := h.ctrl.UploadChunk(ctx, session, name, uploadID, r.Header.Get(headerContentRange), r.Body)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		renderUploadProgress(w, http.StatusAccepted, name, upload)
+
+	case http.MethodPut:
+		b, err := h.ctrl.CompleteUpload(ctx, session, name, uploadID, query.Get("digest"), r.Body)
+		if err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		renderBlobCreated(w, name, b)
+
+	case http.MethodDelete:
+		if err := h.ctrl.CancelUpload(ctx, session, name, uploadID); err != nil {
+			renderOCIError(ctx, w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		renderOCIMethodNotAllowed(ctx, w)
+	}
+}
+
+func setContentHeaders(w http.ResponseWriter, b *types.RegistryBlob) {
+	mediaType := b.MediaType
+	if mediaType == "" {
+		mediaType = defaultBlobMediaType
+	}
+
+	w.Header().Set(headerContentType, mediaType)
+	w.Header().Set(headerContentLength, strconv.FormatInt(b.Size, 10))
+	w.Header().Set(headerContentDigest, b.Digest)
+}
+
+func renderBlobCreated(w http.ResponseWriter, name string, b *types.RegistryBlob) {
+	w.Header().Set(headerLocation, fmt.Sprintf("%s/%s/blobs/%s", OCIMount, name, b.Digest))
+	w.Header().Set(headerContentDigest, b.Digest)
+	w.Header().Set(headerContentLength, "0")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func renderUploadProgress(w http.ResponseWriter, status int, name string, upload *types.RegistryUpload) {
+	end := upload.Size - 1
+	if end < 0 {
+		end = 0
+	}
+
+	w.Header().Set(headerLocation, fmt.Sprintf("%s/%s/blobs/uploads/%s", OCIMount, name, upload.Identifier))
+	w.Header().Set(headerRange, fmt.Sprintf("0-%d", end))
+	w.Header().Set(headerUploadUUID, upload.Identifier)
+	w.Header().Set(headerContentLength, "0")
+	w.WriteHeader(status)
+}
+
+func renderOCIMethodNotAllowed(ctx context.Context, w http.ResponseWriter) {
+	renderOCIError(ctx, w, &registry.OCIError{
+		Status:  http.StatusMethodNotAllowed,
+		Code:    registry.OCICodeUnsupported,
+		Message: "method not allowed",
+	})
+}
+
+// renderOCIError renders the error in the format of the OCI distribution API.
+// Unauthenticated requests are challenged for basic auth, which accepts a personal access token as password.
+func renderOCIError(ctx context.Context, w http.ResponseWriter, err error) {
+	var ociErr *registry.OCIError
+	switch {
+	case errors.As(err, &ociErr):
+	case errors.Is(err, apiauth.ErrNotAuthenticated):
+		w.Header().Set(headerAuthenticate, fmt.Sprintf("Basic realm=%q", ociRealm))
+		ociErr = &registry.OCIError{
+			Status:  http.StatusUnauthorized,
+			Code:    registry.OCICodeUnauthorized,
+			Message: "authentication required",
+		}
+	case errors.Is(err, apiauth.ErrNotAuthorized):
+		ociErr = &registry.OCIError{
+			Status:  http.StatusForbidden,
+			Code:    registry.OCICodeDenied,
+			Message: "requested access to the resource is denied",
+		}
+	default:
+		userErr := usererror.Translate(ctx, err)
+		ociErr = &registry.OCIError{
+			Status:  userErr.Status,
+			Code:    registry.OCICodeUnsupported,
+			Message: userErr.Message,
+		}
+		if userErr.Status >= http.StatusInternalServerError {
+			log.Ctx(ctx).Error().Err(err).Msg("registry request failed")
+		}
+	}
+
+	w.Header().Set(headerContentType, "application/json; charset=utf-8")
+	w.WriteHeader(ociErr.Status)
+	err = json.NewEncoder(w).Encode(struct {
+		Errors []*registry.OCIError `json:"errors"`
+	}{
+		Errors: []*registry.OCIError{ociErr},
+	})
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to render registry error")
+	}
+}
+
+func closeContent(ctx context.Context, content interface{ Close() error }) {
+	if err := content.Close(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to close registry content after rendering")
+	}
+}
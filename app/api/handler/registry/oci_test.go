@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import "testing"
+
+func TestParseOCIPath(t *testing.T) {
+	digest := "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	tests := []struct {
+		path string
+		want ociRoute
+		ok   bool
+	}{
+		{path: "/", want: ociRoute{kind: ociRouteBase}, ok: true},
+		{path: "", want: ociRoute{kind: ociRouteBase}, ok: true},
+		{
+			path: "/space/images/app/tags/list",
+			want: ociRoute{kind: ociRouteTags, name: "space/images/app"},
+			ok:   true,
+		},
+		{
+			path: "/space/sub/images/team/app/manifests/v1.0",
+			want: ociRoute{kind: ociRouteManifest, name: "space/sub/images/team/app", reference: "v1.0"},
+			ok:   true,
+		},
+		{
+			path: "/space/images/app/blobs/" + digest,
+			want: ociRoute{kind: ociRouteBlob, name: "space/images/app", reference: digest},
+			ok:   true,
+		},
+		{
+			path: "/space/images/manifests/blobs/" + digest,
+			want: ociRoute{kind: ociRouteBlob, name: "space/images/manifests", reference: digest},
+			ok:   true,
+		},
+		{
+			path: "/space/images/app/blobs/uploads/",
+			want: ociRoute{kind: ociRouteUpload, name: "space/images/app"},
+			ok:   true,
+		},
+		{
+			path: "/space/images/app/blobs/uploads",
+			want: ociRoute{kind: ociRouteUpload, name: "space/images/app"},
+			ok:   true,
+		},
+		{
+			path: "/space/images/app/blobs/uploads/8d2f",
+			want: ociRoute{kind: ociRouteUpload, name: "space/images/app", reference: "8d2f"},
+			ok:   true,
+		},
+		{path: "/space/images/app/manifests/", ok: false},
+		{path: "/space/images/app", ok: false},
+	}
+
+	for _, test := range tests {
+		got, ok := parseOCIPath(test.path)
+		if ok != test.ok {
+			t.Errorf("%q: expected ok=%t, got %t", test.path, test.ok, ok)
+			continue
+		}
+		if ok && got != test.want {
+			t.Errorf("%q: expected %+v, got %+v", test.path, test.want, got)
+		}
+	}
+}
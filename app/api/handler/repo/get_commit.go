@@ -46,6 +46,11 @@ func HandleGetCommit(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		// commits are immutable, the sha identifies the response.
+		if render.NotModified(w, r, `"`+commit.SHA+`"`, commit.Committer.When) {
+			return
+		}
+
 		render.JSON(w, http.StatusOK, commit)
 	}
 }
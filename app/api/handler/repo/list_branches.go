@@ -41,7 +41,11 @@ func HandleListBranches(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseBranchFilter(r)
+		filter, err := request.ParseBranchFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, includeCommit, filter)
 		if err != nil {
@@ -52,6 +52,14 @@ func HandleListCommits(repoCtrl *repo.Controller) http.HandlerFunc {
 		// TODO: get last page indicator explicitly - current check is wrong in case len % limit == 0
 		isLastPage := len(list.Commits) < filter.Limit
 		render.PaginationNoTotal(r, w, filter.Page, filter.Limit, isLastPage)
+
+		nextCursor := ""
+		if !isLastPage && len(list.Commits) > 0 {
+			nextCursor = request.EncodeCommitCursor(list.Commits[len(list.Commits)-1].SHA)
+		}
+		render.PaginationCursor(r, w, filter.Limit, nextCursor)
+		list.NextCursor = nextCursor
+
 		render.JSON(w, http.StatusOK, list)
 	}
 }
@@ -17,6 +17,7 @@ package repo
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/render"
@@ -41,20 +42,25 @@ func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
 		path := request.GetOptionalRemainderFromPath(r)
 
-		dataReader, dataLength, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
+		content, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
 		defer func() {
-			if err := dataReader.Close(); err != nil {
+			if err := content.Data.Close(); err != nil {
 				log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
 			}
 		}()
 
-		w.Header().Add("Content-Length", fmt.Sprint(dataLength))
+		// the blob sha identifies the content, no matter which git ref was used to get it.
+		if render.NotModified(w, r, `"`+content.SHA+`"`, time.Time{}) {
+			return
+		}
+
+		w.Header().Add("Content-Length", fmt.Sprint(content.Size))
 
-		render.Reader(ctx, w, http.StatusOK, dataReader)
+		render.Reader(ctx, w, http.StatusOK, content.Data)
 	}
 }
@@ -0,0 +1,390 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // only used to generate fake object ids
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/url"
+	gitness_cache "github.com/harness/gitness/cache"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// wikiGit is an in-memory git implementation of a single wiki repository.
+type wikiGit struct {
+	git.Interface
+	created bool
+	files   map[string][]byte
+	commits []git.Commit
+	paths   map[string][]string // paths changed by each commit, by commit sha
+}
+
+func newWikiGit() *wikiGit {
+	return &wikiGit{files: map[string][]byte{}, paths: map[string][]string{}}
+}
+
+func fakeSHA(data ...[]byte) string {
+	h := sha1.New() //nolint:gosec // only used to generate fake object ids
+	for _, d := range data {
+		h.Write(d)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (g *wikiGit) CreateRepository(
+	_ context.Context,
+	params *git.CreateRepositoryParams,
+) (*git.CreateRepositoryOutput, error) {
+	if g.created {
+		return nil, errors.Conflict("repository already exists")
+	}
+	g.created = true
+	return &git.CreateRepositoryOutput{UID: params.RepoUID}, nil
+}
+
+func (g *wikiGit) CommitFiles(_ context.Context, params *git.CommitFilesParams) (git.CommitFilesResponse, error) {
+	var changed []string
+	for _, action := range params.Actions {
+		content, exists := g.files[action.Path]
+		if action.Action != git.CreateAction && !exists {
+			return git.CommitFilesResponse{}, errors.NotFound("file %q not found", action.Path)
+		}
+		if action.SHA != "" && action.SHA != fakeSHA(content) {
+			return git.CommitFilesResponse{}, errors.PreconditionFailed("sha mismatch for %q", action.Path)
+		}
+
+		switch action.Action {
+		case git.CreateAction:
+			if exists {
+				return git.CommitFilesResponse{}, errors.Conflict("file %q already exists", action.Path)
+			}
+			g.files[action.Path] = action.Payload
+			changed = append(changed, action.Path)
+		case git.UpdateAction:
+			g.files[action.Path] = action.Payload
+			changed = append(changed, action.Path)
+		case git.MoveAction:
+			newPath, newContent, _ := bytes.Cut(action.Payload, []byte{0})
+			delete(g.files, action.Path)
+			g.files[string(newPath)] = newContent
+			changed = append(changed, action.Path, string(newPath))
+		case git.DeleteAction:
+			delete(g.files, action.Path)
+			changed = append(changed, action.Path)
+		}
+	}
+
+	sha := fakeSHA([]byte(params.Title), []byte(fmt.Sprint(len(g.commits))))
+	g.commits = append([]git.Commit{{
+		SHA:       sha,
+		Title:     params.Title,
+		Message:   params.Message,
+		Author:    git.Signature{Identity: *params.Author, When: *params.AuthorDate},
+		Committer: git.Signature{Identity: *params.Committer, When: *params.CommitterDate},
+	}}, g.commits...)
+	g.paths[sha] = changed
+
+	return git.CommitFilesResponse{CommitID: sha}, nil
+}
+
+func (g *wikiGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	content, ok := g.files[params.Path]
+	if !ok {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+
+	return &git.GetTreeNodeOutput{
+		Node:   git.TreeNode{Type: git.TreeNodeTypeBlob, SHA: fakeSHA(content), Name: params.Path, Path: params.Path},
+		Commit: &g.commits[0],
+	}, nil
+}
+
+func (g *wikiGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	for _, content := range g.files {
+		if fakeSHA(content) == params.SHA {
+			return &git.GetBlobOutput{
+				SHA:         params.SHA,
+				Size:        int64(len(content)),
+				ContentSize: int64(len(content)),
+				Content:     io.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}
+	}
+	return nil, errors.NotFound("blob %q not found", params.SHA)
+}
+
+func (g *wikiGit) ListTreeNodes(context.Context, *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	if len(g.commits) == 0 {
+		return nil, errors.NotFound("reference not found")
+	}
+
+	nodes := make([]git.TreeNode, 0, len(g.files))
+	for path, content := range g.files {
+		nodes = append(nodes, git.TreeNode{Type: git.TreeNodeTypeBlob, SHA: fakeSHA(content), Name: path, Path: path})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Path < nodes[j].Path })
+
+	return &git.ListTreeNodeOutput{Nodes: nodes}, nil
+}
+
+func (g *wikiGit) ListCommits(_ context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error) {
+	if len(g.commits) == 0 {
+		return nil, errors.NotFound("reference not found")
+	}
+
+	var commits []git.Commit
+	for _, commit := range g.commits {
+		for _, path := range g.paths[commit.SHA] {
+			if path == params.Path {
+				commits = append(commits, commit)
+				break
+			}
+		}
+	}
+
+	return &git.ListCommitsOutput{Commits: commits, TotalCommits: len(commits)}, nil
+}
+
+// wikiAuthorizer grants all permissions to the writer and only view permissions to everyone else.
+type wikiAuthorizer struct{}
+
+func (wikiAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return session.Principal.UID == "writer" || permission == enum.PermissionRepoView, nil
+}
+
+func (a wikiAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		ok, err := a.Check(ctx, session, &permissionChecks[i].Scope, &permissionChecks[i].Resource,
+			permissionChecks[i].Permission)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func setupWikiRouter(t *testing.T, gitFake git.Interface) http.Handler {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", "file:wiki_handlers?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	// wiki commits are made by the system service principal.
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation,
+		gitness_cache.NoRowCache{})
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+	config.Principal.System.Email = "system@gitness.io"
+	config.Principal.System.DisplayName = "Gitness"
+	serviceCtrl := service.NewController(check.PrincipalUIDDefault, nil, principalStore)
+	if err = bootstrap.SystemService(ctx, config, serviceCtrl); err != nil {
+		t.Fatalf("failed to setup system service: %v", err)
+	}
+
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, gitness_cache.NoRowCache{})
+
+	space := &types.Space{Identifier: "acme", CreatedBy: 1}
+	if err = spaceStore.Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %v", err)
+	}
+	err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: space.Identifier, SpaceID: space.ID, CreatedBy: 1, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %v", err)
+	}
+
+	if err = repoStore.Create(ctx, &types.Repository{
+		Identifier: "docs", ParentID: space.ID, GitUID: "docs", DefaultBranch: "main", CreatedBy: 1,
+	}); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://localhost:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+
+	ctrl := repo.NewController(config, nil, urlProvider, wikiAuthorizer{}, repoStore, spaceStore,
+		nil, nil, nil, nil, nil, gitFake, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	router := chi.NewRouter()
+	router.Route(fmt.Sprintf("/repos/{%s}/wiki", request.PathParamRepoRef), func(r chi.Router) {
+		r.Get("/", HandleFindWiki(ctrl))
+		r.Route("/pages", func(r chi.Router) {
+			r.Get("/", HandleListWikiPages(ctrl))
+			r.Post("/", HandleCreateWikiPage(ctrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamWikiPage), func(r chi.Router) {
+				r.Get("/", HandleFindWikiPage(ctrl))
+				r.Patch("/", HandleUpdateWikiPage(ctrl))
+				r.Delete("/", HandleDeleteWikiPage(ctrl))
+				r.Get("/history", HandleListWikiPageHistory(ctrl))
+			})
+		})
+	})
+
+	return router
+}
+
+func TestWikiHandlers(t *testing.T) {
+	router := setupWikiRouter(t, newWikiGit())
+
+	serve := func(uid, method, path, body string, out any) int {
+		session := &auth.Session{Principal: types.Principal{
+			ID: 1, UID: uid, Email: uid + "@example.com", DisplayName: uid, Type: enum.PrincipalTypeUser,
+		}}
+
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(request.WithAuthSession(r.Context(), session))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if out != nil && w.Code < http.StatusBadRequest {
+			if err := json.NewDecoder(w.Body).Decode(out); err != nil {
+				t.Fatalf("failed to decode response of %s %s: %v", method, path, err)
+			}
+		}
+
+		return w.Code
+	}
+
+	const pages = "/repos/acme%2Fdocs/wiki/pages"
+
+	var wiki types.Wiki
+	if code := serve("reader", http.MethodGet, "/repos/acme%2Fdocs/wiki", "", &wiki); code != http.StatusOK {
+		t.Fatalf("failed to find wiki: %d", code)
+	}
+	if wiki.GitURL != "http://localhost:3000/git/acme/docs.wiki.git" {
+		t.Errorf("unexpected wiki git url: %q", wiki.GitURL)
+	}
+
+	var list []*types.WikiPage
+	if code := serve("reader", http.MethodGet, pages, "", &list); code != http.StatusOK || len(list) != 0 {
+		t.Fatalf("expected empty wiki, got %d: %v", code, list)
+	}
+
+	code := serve("reader", http.MethodPost, pages, `{"title":"Getting Started","content":"hi"}`, nil)
+	if code != http.StatusForbidden {
+		t.Fatalf("expected reader to be forbidden to create pages, got %d", code)
+	}
+
+	code = serve("writer", http.MethodPost, pages, `{"title":"../etc","content":"x"}`, nil)
+	if code != http.StatusBadRequest {
+		t.Fatalf("expected invalid title to be rejected, got %d", code)
+	}
+
+	var page types.WikiPage
+	code = serve("writer", http.MethodPost, pages, `{"title":"Getting  Started","content":"hello"}`, &page)
+	if code != http.StatusCreated || page.Slug != "Getting-Started" || page.Content != "hello" {
+		t.Fatalf("unexpected created page, got %d: %+v", code, page)
+	}
+
+	if code = serve("writer", http.MethodPost, pages, `{"title":"Getting Started"}`, nil); code != http.StatusConflict {
+		t.Fatalf("expected duplicate page to conflict, got %d", code)
+	}
+
+	page = types.WikiPage{}
+	code = serve("reader", http.MethodGet, pages+"/Getting-Started", "", &page)
+	if code != http.StatusOK || page.Title != "Getting Started" || page.LatestCommit == nil {
+		t.Fatalf("unexpected page, got %d: %+v", code, page)
+	}
+
+	code = serve("writer", http.MethodPatch, pages+"/Getting-Started", `{"content":"bye","sha":"stale"}`, nil)
+	if code != http.StatusPreconditionFailed {
+		t.Fatalf("expected stale sha to be rejected, got %d", code)
+	}
+
+	body := fmt.Sprintf(`{"title":"Intro","content":"welcome","sha":%q}`, page.SHA)
+	page = types.WikiPage{}
+	code = serve("writer", http.MethodPatch, pages+"/Getting-Started", body, &page)
+	if code != http.StatusOK || page.Slug != "Intro" || page.Content != "welcome" {
+		t.Fatalf("unexpected renamed page, got %d: %+v", code, page)
+	}
+
+	if code = serve("reader", http.MethodGet, pages+"/Getting-Started", "", nil); code != http.StatusNotFound {
+		t.Fatalf("expected renamed page to be gone, got %d", code)
+	}
+
+	var history []types.Commit
+	code = serve("reader", http.MethodGet, pages+"/Intro/history", "", &history)
+	if code != http.StatusOK || len(history) != 1 || history[0].Author.Identity.Name != "writer" {
+		t.Fatalf("unexpected page history, got %d: %+v", code, history)
+	}
+
+	list = nil
+	if code = serve("reader", http.MethodGet, pages, "", &list); code != http.StatusOK || len(list) != 1 {
+		t.Fatalf("expected one page, got %d: %v", code, list)
+	}
+
+	if code = serve("writer", http.MethodDelete, pages+"/Intro", "", nil); code != http.StatusNoContent {
+		t.Fatalf("failed to delete page, got %d", code)
+	}
+
+	list = nil
+	if code = serve("reader", http.MethodGet, pages, "", &list); code != http.StatusOK || len(list) != 0 {
+		t.Fatalf("expected no pages after delete, got %d: %v", code, list)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/url"
+	gitness_cache "github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// invitationMailer records the invitation emails.
+type invitationMailer struct {
+	notification.Client
+	sent []string
+}
+
+func (m *invitationMailer) SendInvitation(
+	_ context.Context,
+	recipientEmail string,
+	payload *notification.InvitationPayload,
+) error {
+	m.sent = append(m.sent, recipientEmail+" "+payload.SpacePath)
+	return nil
+}
+
+// spaceEditAuthorizer grants all permissions to the space owner only.
+type spaceEditAuthorizer struct{}
+
+func (spaceEditAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return session.Principal.UID == "owner", nil
+}
+
+func (a spaceEditAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	_ ...types.PermissionCheck,
+) (bool, error) {
+	return a.Check(ctx, session, nil, nil, "")
+}
+
+func TestInvitationHandlers(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", "file:space_invitation_handlers?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation,
+		gitness_cache.NoRowCache{})
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, cache.New(spacePathStore, store.ToLowerSpacePathTransformation),
+		spacePathStore)
+	invitationStore := database.NewInvitationStore(db)
+
+	owner := &types.User{ID: 1, UID: "owner", Email: "owner@example.com", DisplayName: "Owner"}
+	if err = principalStore.CreateUser(ctx, owner); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	acme := &types.Space{Identifier: "acme", CreatedBy: owner.ID}
+	if err = spaceStore.Create(ctx, acme); err != nil {
+		t.Fatalf("failed to create space: %v", err)
+	}
+	err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: acme.Identifier, SpaceID: acme.ID, CreatedBy: owner.ID, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %v", err)
+	}
+
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://localhost:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+
+	config := &types.Config{}
+	config.SMTP.Host = "smtp.example.com"
+
+	mailer := &invitationMailer{}
+	invitationService := invitation.NewService(config, invitationStore, principalStore,
+		cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)), spaceStore, mailer, urlProvider)
+
+	ctrl := space.NewController(config, nil, urlProvider, nil, nil, spaceEditAuthorizer{}, spacePathStore,
+		nil, nil, nil, nil, spaceStore, nil, principalStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, invitationStore, invitationService)
+
+	router := chi.NewRouter()
+	router.Route(fmt.Sprintf("/spaces/{%s}/invitations", request.PathParamSpaceRef), func(r chi.Router) {
+		r.Get("/", HandleInvitationList(ctrl))
+		r.Post("/", HandleInvitationCreate(ctrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamInvitationID), func(r chi.Router) {
+			r.Delete("/", HandleInvitationDelete(ctrl))
+			r.Post("/resend", HandleInvitationResend(ctrl))
+		})
+	})
+
+	serve := func(uid, method, path, body string, out any) int {
+		session := &auth.Session{Principal: types.Principal{ID: 1, UID: uid, Type: enum.PrincipalTypeUser}}
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(request.WithAuthSession(r.Context(), session))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if out != nil && w.Code < http.StatusBadRequest {
+			if err := json.NewDecoder(w.Body).Decode(out); err != nil {
+				t.Fatalf("failed to decode response of %s %s: %v", method, path, err)
+			}
+		}
+
+		return w.Code
+	}
+
+	const invitations = "/spaces/acme/invitations"
+
+	code := serve("member", http.MethodPost, invitations, `{"email":"jane@example.com","role":"contributor"}`, nil)
+	if code != http.StatusForbidden {
+		t.Fatalf("expected invitation without space edit permission to be forbidden, got %d", code)
+	}
+
+	code = serve("owner", http.MethodPost, invitations, `{"email":"owner@example.com","role":"reader"}`, nil)
+	if code != http.StatusConflict {
+		t.Fatalf("expected invitation of an existing user to conflict, got %d", code)
+	}
+
+	var created types.InvitationCreateOutput
+	code = serve("owner", http.MethodPost, invitations, `{"email":"jane@example.com","role":"contributor"}`, &created)
+	if code != http.StatusCreated || !created.EmailSent || created.Token == "" || created.Sent == 0 {
+		t.Fatalf("unexpected created invitation, got %d: %+v", code, created)
+	}
+
+	if len(mailer.sent) != 1 || mailer.sent[0] != "jane@example.com acme" {
+		t.Errorf("unexpected invitation emails: %v", mailer.sent)
+	}
+
+	// the invitation was just sent, so it can't be sent again right away.
+	resend := fmt.Sprintf("%s/%d/resend", invitations, created.ID)
+	if code = serve("owner", http.MethodPost, resend, "", nil); code != http.StatusBadRequest {
+		t.Fatalf("expected immediate resend to be rejected, got %d", code)
+	}
+
+	var list []*types.Invitation
+	if code = serve("owner", http.MethodGet, invitations, "", &list); code != http.StatusOK || len(list) != 1 {
+		t.Fatalf("expected one invitation, got %d: %+v", code, list)
+	}
+
+	if list[0].Email != "jane@example.com" || list[0].Role == nil || *list[0].Role != enum.MembershipRoleContributor {
+		t.Errorf("unexpected listed invitation: %+v", list[0])
+	}
+
+	code = serve("owner", http.MethodDelete, fmt.Sprintf("%s/%d", invitations, created.ID), "", nil)
+	if code != http.StatusNoContent {
+		t.Fatalf("failed to cancel invitation, got %d", code)
+	}
+
+	if _, err = invitationService.FindPending(ctx, created.Token); err == nil {
+		t.Errorf("expected canceled invitation to be invalid")
+	}
+
+	list = nil
+	if code = serve("owner", http.MethodGet, invitations, "", &list); code != http.StatusOK || len(list) != 0 {
+		t.Fatalf("expected no invitations after cancel, got %d: %+v", code, list)
+	}
+}
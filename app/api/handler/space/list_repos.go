@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -52,6 +53,14 @@ func HandleListRepos(spaceCtrl *space.Controller) http.HandlerFunc {
 		}
 
 		render.Pagination(r, w, filter.Page, filter.Size, int(count))
+		nextCursor := request.NextCursor(repos, filter.Size, func(repo *types.Repository) int64 { return repo.ID })
+		render.PaginationCursor(r, w, filter.Size, nextCursor)
+
+		if request.CursorRequested(r) {
+			render.JSONCursorPage(w, http.StatusOK, repos, nextCursor)
+			return
+		}
+
 		render.JSON(w, http.StatusOK, repos)
 	}
 }
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleDump returns an http.HandlerFunc that writes runtime dumps of the instance
+// and writes the json-encoded list of written dump files to the response body.
+func HandleDump(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		in := new(system.DumpInput)
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+				render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+				return
+			}
+		}
+
+		out, err := sysCtrl.Dump(ctx, session, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandlePprofProfile returns an http.HandlerFunc that serves the runtime profile named in the path.
+// The pprof index can't be used for named profiles as it expects to be served under /debug/pprof/.
+func HandlePprofProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, err := request.GetPprofProfileFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(r.Context(), w, err)
+			return
+		}
+
+		pprof.Handler(name).ServeHTTP(w, r)
+	}
+}
@@ -14,10 +14,59 @@
 
 package system
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/types/enum"
+)
 
 // HandleHealth writes a 200 OK status to the http.Response
 // if the server is healthy.
 func HandleHealth(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
+
+// HandleDatabaseHealth writes the database health to the http.Response.
+// The status is 200 OK if the database is healthy, otherwise 503 Service Unavailable.
+func HandleDatabaseHealth(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		health := sysCtrl.DatabaseHealth(r.Context())
+		if !health.Healthy {
+			render.JSON(w, http.StatusServiceUnavailable, health)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, health)
+	}
+}
+
+// HandleLiveness writes the health of the instance and its dependencies to the http.Response.
+// The status is 200 OK unless dependencies are unhealthy after the startup grace period,
+// in which case it's 503 Service Unavailable.
+func HandleLiveness(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := sysCtrl.Health(r.Context())
+		if report.Status == enum.HealthStatusUnavailable {
+			render.JSON(w, http.StatusServiceUnavailable, report)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, report)
+	}
+}
+
+// HandleReadiness writes the health of the instance and its dependencies to the http.Response.
+// The status is 200 OK if all dependencies are healthy, otherwise 503 Service Unavailable.
+func HandleReadiness(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := sysCtrl.Health(r.Context())
+		if report.Status != enum.HealthStatusOK {
+			render.JSON(w, http.StatusServiceUnavailable, report)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, report)
+	}
+}
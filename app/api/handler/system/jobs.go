@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/job"
+)
+
+// HandleListJobs returns an http.HandlerFunc that writes a json-encoded
+// list of the background jobs of the system to the response body.
+func HandleListJobs(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		filter := request.ParseJobFilter(r)
+
+		list, totalCount, err := sysCtrl.ListJobs(ctx, session, filter)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
+		render.JSON(w, http.StatusOK, list)
+	}
+}
+
+// HandleFindJob returns an http.HandlerFunc that writes the json-encoded background job to the response body.
+func HandleFindJob(sysCtrl *system.Controller) http.HandlerFunc {
+	return handleJob(sysCtrl.FindJob)
+}
+
+// HandleCancelJob returns an http.HandlerFunc that cancels a scheduled or running background job.
+func HandleCancelJob(sysCtrl *system.Controller) http.HandlerFunc {
+	return handleJob(sysCtrl.CancelJob)
+}
+
+// HandleRetryJob returns an http.HandlerFunc that reschedules a failed or canceled background job.
+func HandleRetryJob(sysCtrl *system.Controller) http.HandlerFunc {
+	return handleJob(sysCtrl.RetryJob)
+}
+
+func handleJob(fn func(context.Context, *auth.Session, string) (*job.Job, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		jobUID, err := request.GetJobUIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		j, err := fn(ctx, session, jobUID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, j)
+	}
+}
@@ -25,6 +25,8 @@ import (
 type ConfigOutput struct {
 	UserSignupAllowed             bool `json:"user_signup_allowed"`
 	PublicResourceCreationEnabled bool `json:"public_resource_creation_enabled"`
+	ReadOnly                      bool `json:"read_only"`
+	DefaultPublic                 bool `json:"default_public"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -42,6 +44,8 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 		render.JSON(w, http.StatusOK, ConfigOutput{
 			UserSignupAllowed:             userSignupAllowed,
 			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+			ReadOnly:                      sysCtrl.IsReadOnly(),
+			DefaultPublic:                 sysCtrl.IsDefaultPublic(),
 		})
 	}
 }
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	middlewaremaintenance "github.com/harness/gitness/app/api/middleware/maintenance"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestMaintenanceReadOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := sqlx.Connect("sqlite3", "file:maintenance_handlers?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	config := &types.Config{}
+	maintenanceService, err := maintenance.NewService(ctx, config,
+		settings.NewService(database.NewSettingsStore(db), nil), pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create maintenance service: %v", err)
+	}
+
+	sysCtrl := system.NewController(nil, config, db, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		maintenanceService, nil)
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	router := chi.NewRouter()
+	router.Use(middlewaremaintenance.BlockWrites(maintenanceService, "/v1/admin/maintenance"))
+	router.Get("/v1/repos", ok)
+	router.Post("/v1/repos", ok)
+	router.Put("/v1/admin/maintenance", HandleUpdateMaintenance(sysCtrl))
+
+	serve := func(admin bool, method, path, body string) *httptest.ResponseRecorder {
+		session := &auth.Session{Principal: types.Principal{ID: 1, UID: "admin", Admin: admin}}
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(request.WithAuthSession(r.Context(), session))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := serve(false, http.MethodPut, "/v1/admin/maintenance", `{"read_only":true}`); w.Code != http.StatusForbidden {
+		t.Fatalf("expected non-admin to be forbidden, got %d", w.Code)
+	}
+
+	w := serve(true, http.MethodPut, "/v1/admin/maintenance", `{"read_only":true,"message":"backup running"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to enable maintenance mode, got %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(true, http.MethodPost, "/v1/repos", "{}")
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "backup running") {
+		t.Errorf("expected write to be rejected in read-only mode, got %d: %s", w.Code, w.Body)
+	}
+
+	if w = serve(false, http.MethodGet, "/v1/repos", ""); w.Code != http.StatusOK {
+		t.Errorf("expected read to succeed in read-only mode, got %d", w.Code)
+	}
+
+	if w = serve(true, http.MethodPut, "/v1/admin/maintenance", `{"read_only":false}`); w.Code != http.StatusOK {
+		t.Fatalf("failed to disable maintenance mode, got %d: %s", w.Code, w.Body)
+	}
+
+	if w = serve(true, http.MethodPost, "/v1/repos", "{}"); w.Code != http.StatusOK {
+		t.Errorf("expected write to succeed after leaving read-only mode, got %d", w.Code)
+	}
+}
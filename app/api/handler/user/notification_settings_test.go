@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	gitness_cache "github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// selfAuthorizer only permits a principal to access its own user resource.
+type selfAuthorizer struct{}
+
+func (selfAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	resource *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return resource.Type == enum.ResourceTypeUser && resource.Identifier == session.Principal.UID, nil
+}
+
+func (a selfAuthorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		ok, err := a.Check(ctx, session, &permissionChecks[i].Scope, &permissionChecks[i].Resource,
+			permissionChecks[i].Permission)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func TestNotificationSettings(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", "file:notification_settings?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation,
+		gitness_cache.NoRowCache{})
+	settingsService := settings.NewService(database.NewSettingsStore(db), nil)
+
+	u := &types.User{ID: 1, UID: "jane", Email: "jane@example.com"}
+	if err = principalStore.CreateUser(ctx, u); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	ctrl := user.NewController(nil, nil, selfAuthorizer{}, principalStore, nil, nil, nil, nil, nil, nil,
+		settingsService, nil, nil, nil, nil, nil, nil)
+
+	session := &auth.Session{Principal: *u.ToPrincipal()}
+
+	serve := func(handler http.HandlerFunc, method, body string) (int, *types.NotificationSettings) {
+		r := httptest.NewRequest(method, "/api/v1/user/notification-settings", strings.NewReader(body))
+		r = r.WithContext(request.WithAuthSession(r.Context(), session))
+		w := httptest.NewRecorder()
+
+		handler(w, r)
+
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+
+		out := new(types.NotificationSettings)
+		if err := json.NewDecoder(w.Body).Decode(out); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		return w.Code, out
+	}
+
+	code, out := serve(HandleFindNotificationSettings(ctrl), http.MethodGet, "")
+	if code != http.StatusOK || out.Level != enum.NotificationLevelAll {
+		t.Fatalf("expected default settings, got code=%d settings=%+v", code, out)
+	}
+
+	code, out = serve(HandleUpdateNotificationSettings(ctrl), http.MethodPatch, `{"level":"mentions"}`)
+	if code != http.StatusOK || out.Level != enum.NotificationLevelMentions {
+		t.Fatalf("unexpected update result: code=%d settings=%+v", code, out)
+	}
+
+	code, _ = serve(HandleUpdateNotificationSettings(ctrl), http.MethodPatch, `{"level":"sometimes"}`)
+	if code != http.StatusBadRequest {
+		t.Fatalf("expected bad request for unknown level, got %d", code)
+	}
+
+	code, out = serve(HandleFindNotificationSettings(ctrl), http.MethodGet, "")
+	if code != http.StatusOK || out.Level != enum.NotificationLevelMentions {
+		t.Fatalf("expected stored settings, got code=%d settings=%+v", code, out)
+	}
+}
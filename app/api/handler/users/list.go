@@ -30,7 +30,12 @@ func HandleList(userCtrl *user.Controller) http.HandlerFunc {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		filter := request.ParseUserFilter(r)
+		filter, err := request.ParseUserFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		if filter.Order == enum.OrderDefault {
 			filter.Order = enum.OrderAsc
 		}
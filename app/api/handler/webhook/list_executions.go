@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
 )
 
 // HandleListExecutions returns a http.HandlerFunc that lists webhook executions.
@@ -40,7 +41,11 @@ func HandleListExecutions(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseWebhookExecutionFilter(r)
+		filter, err := request.ParseWebhookExecutionFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		executions, err := webhookCtrl.ListExecutions(ctx, session, repoRef, webhookIdentifier, filter)
 		if err != nil {
@@ -51,6 +56,14 @@ func HandleListExecutions(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		// TODO: get last page indicator explicitly - current check is wrong in case len % pageSize == 0
 		isLastPage := len(executions) < filter.Size
 		render.PaginationNoTotal(r, w, filter.Page, filter.Size, isLastPage)
+		nextCursor := request.NextCursor(executions, filter.Size, func(e *types.WebhookExecution) int64 { return e.ID })
+		render.PaginationCursor(r, w, filter.Size, nextCursor)
+
+		if request.CursorRequested(r) {
+			render.JSONCursorPage(w, http.StatusOK, executions, nextCursor)
+			return
+		}
+
 		render.JSON(w, http.StatusOK, executions)
 	}
 }
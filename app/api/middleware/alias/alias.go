@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alias
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/go-chi/chi"
+)
+
+// Resolver resolves aliases of space paths, which are the paths spaces were reachable at
+// before they got renamed or moved to a different parent space.
+type Resolver struct {
+	spacePathCache store.SpacePathCache
+	spacePathStore store.SpacePathStore
+	urlProvider    urlprovider.Provider
+	expiry         time.Duration
+}
+
+func NewResolver(
+	spacePathCache store.SpacePathCache,
+	spacePathStore store.SpacePathStore,
+	urlProvider urlprovider.Provider,
+	expiry time.Duration,
+) *Resolver {
+	return &Resolver{
+		spacePathCache: spacePathCache,
+		spacePathStore: spacePathStore,
+		urlProvider:    urlProvider,
+		expiry:         expiry,
+	}
+}
+
+// RedirectSpace redirects api requests that reference a space via an alias to the primary path of the space.
+func (a *Resolver) RedirectSpace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.redirect(w, r, next, request.PathParamSpaceRef, false, func(primaryRef string) string {
+			return a.apiLocation(r, request.PathParamSpaceRef, primaryRef)
+		})
+	})
+}
+
+// RedirectRepo redirects api requests that reference a repository via a space alias
+// to the primary path of the repository.
+func (a *Resolver) RedirectRepo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.redirect(w, r, next, request.PathParamRepoRef, true, func(primaryRef string) string {
+			return a.apiLocation(r, request.PathParamRepoRef, primaryRef)
+		})
+	})
+}
+
+// RedirectGit redirects git requests that reference a repository via a space alias
+// to the clone url of the repository.
+func (a *Resolver) RedirectGit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.redirect(w, r, next, request.PathParamRepoRef, true, func(primaryRef string) string {
+			location := a.urlProvider.GenerateGITCloneURL(primaryRef)
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "/" {
+				location += rctx.RoutePath
+			}
+			if r.URL.RawQuery != "" {
+				location += "?" + r.URL.RawQuery
+			}
+
+			return location
+		})
+	})
+}
+
+func (a *Resolver) redirect(
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.Handler,
+	paramName string,
+	isRepo bool,
+	location func(primaryRef string) string,
+) {
+	ctx := r.Context()
+
+	ref, err := request.PathParamOrError(r, paramName)
+	if err != nil {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	ref, err = url.PathUnescape(ref)
+	if err != nil {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	primaryRef, err := a.primaryRef(ctx, ref, isRepo)
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return
+	}
+
+	if primaryRef == "" {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	status := http.StatusMovedPermanently
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		// keep the method and the body of the request.
+		status = http.StatusPermanentRedirect
+	}
+
+	http.Redirect(w, r, location(primaryRef), status)
+}
+
+// primaryRef returns the primary reference of the space (or repository) in case the provided reference
+// uses a space alias. An empty string is returned in case the reference isn't using an alias.
+func (a *Resolver) primaryRef(ctx context.Context, ref string, isRepo bool) (string, error) {
+	ref = strings.Trim(ref, "/")
+
+	// references by id are never redirected.
+	if _, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return "", nil
+	}
+
+	spacePath := ref
+	leaf := ""
+	if isRepo {
+		var err error
+		spacePath, leaf, err = paths.DisectLeaf(ref)
+		if err != nil || spacePath == "" {
+			return "", nil //nolint:nilerr // invalid references are handled by the handler itself.
+		}
+	}
+
+	path, err := a.spacePathCache.Get(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find space path: %w", err)
+	}
+
+	if path.IsPrimary {
+		return "", nil
+	}
+
+	if a.expiry > 0 && time.Since(time.UnixMilli(path.AliasSince)) > a.expiry {
+		return "", usererror.Newf(http.StatusGone,
+			"The space %q got renamed or moved and its previous path isn't available anymore.", spacePath)
+	}
+
+	primary, err := a.spacePathStore.FindPrimaryBySpaceID(ctx, path.SpaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find primary path of space: %w", err)
+	}
+
+	return paths.Concatenate(primary.Value, leaf), nil
+}
+
+// apiLocation returns the url of the api request with the reference replaced by the primary reference.
+func (a *Resolver) apiLocation(r *http.Request, paramName string, primaryRef string) string {
+	rawRef := chi.URLParam(r, paramName)
+	escapedPath := strings.Replace(r.URL.EscapedPath(), "/"+rawRef, "/"+url.PathEscape(primaryRef), 1)
+
+	location := a.urlProvider.GenerateAPIURL(escapedPath)
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+
+	return location
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// storeTimeout is the max time spent on storing an audit log.
+const storeTimeout = 10 * time.Second
+
+// Handler returns an http middleware that records all mutating requests in the audit log.
+// The request body is captured (size-capped and with secrets redacted) if configured.
+// Returns a no-op middleware if auditing is disabled.
+func Handler(config *types.Config, auditLogStore store.AuditLogStore) func(http.Handler) http.Handler {
+	cfg := config.Audit
+	if !cfg.Enabled {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			ctx := r.Context()
+
+			var body []byte
+			var truncated bool
+			if cfg.CaptureRequestBody && isCapturable(r.Header.Get("Content-Type")) {
+				body, truncated = captureBody(r, cfg.MaxRequestBodySize)
+			}
+
+			// routes can add sensitive keys of their own (see RedactKeys), so redaction happens after routing.
+			keys := &routeKeys{}
+			r = r.WithContext(context.WithValue(ctx, routeKeysKey{}, keys))
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			accessor := AccessorFrom(r)
+			auditLog := &types.AuditLog{
+				RequestID:            accessor.RequestID,
+				Method:               r.Method,
+				Path:                 accessor.Path,
+				Query:                r.URL.RawQuery,
+				RemoteAddr:           accessor.RemoteAddr,
+				UserAgent:            accessor.UserAgent,
+				Status:               sw.status,
+				RequestBody:          redact(body, truncated, keys.keys...),
+				RequestBodyTruncated: truncated,
+				Duration:             time.Since(start).Milliseconds(),
+				Created:              start.UnixMilli(),
+			}
+			if session, ok := request.AuthSessionFrom(ctx); ok {
+				auditLog.PrincipalID = &session.Principal.ID
+			}
+
+			// the request context might already be canceled (e.g. client disconnected), but the log has to be stored.
+			storeCtx, cancel := context.WithTimeout(log.Ctx(ctx).WithContext(context.Background()), storeTimeout)
+			defer cancel()
+
+			if err := auditLogStore.Create(storeCtx, auditLog); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("failed to store audit log")
+			}
+		})
+	}
+}
+
+// RedactKeys returns an http middleware that marks the given json keys of the request body as sensitive
+// for the audit log of the route (e.g. keys that are only secret for a specific resource).
+func RedactKeys(keys ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rk, ok := r.Context().Value(routeKeysKey{}).(*routeKeys); ok {
+				rk.keys = append(rk.keys, keys...)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type routeKeysKey struct{}
+
+// routeKeys holds the additional sensitive keys of the matched route.
+type routeKeys struct {
+	keys []string
+}
+
+// captureBody reads up to maxSize bytes of the request body without consuming it for the actual handler.
+func captureBody(r *http.Request, maxSize int) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody || maxSize <= 0 {
+		return nil, false
+	}
+
+	captured, err := io.ReadAll(io.LimitReader(r.Body, int64(maxSize)+1))
+	r.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(captured), r.Body),
+		Closer: r.Body,
+	}
+	if err != nil {
+		log.Ctx(r.Context()).Warn().Err(err).Msg("failed to capture request body for audit log")
+		return nil, false
+	}
+
+	if len(captured) > maxSize {
+		return captured[:maxSize], true
+	}
+
+	return captured, false
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCapturable returns true for the content types of request bodies that are recorded (binary content is skipped).
+func isCapturable(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == "" ||
+		strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "text/plain")
+}
+
+// AccessorFrom returns the details of the request that are recorded in the audit log,
+// for actions that record themselves in the audit log.
+func AccessorFrom(r *http.Request) types.AuditAccessor {
+	requestID, _ := request.RequestIDFrom(r.Context())
+	return types.AuditAccessor{
+		RequestID:  requestID,
+		Path:       r.URL.Path,
+		RemoteAddr: remoteHost(r.RemoteAddr),
+		UserAgent:  r.UserAgent(),
+	}
+}
+
+func remoteHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// statusWriter records the status code of the response.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(p)
+}
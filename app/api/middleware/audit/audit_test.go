@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+)
+
+type auditLogStore struct {
+	store.AuditLogStore
+	logs []*types.AuditLog
+}
+
+func (s *auditLogStore) Create(_ context.Context, auditLog *types.AuditLog) error {
+	s.logs = append(s.logs, auditLog)
+	return nil
+}
+
+func TestHandler_RedactKeys(t *testing.T) {
+	config := &types.Config{}
+	config.Audit.Enabled = true
+	config.Audit.CaptureRequestBody = true
+	config.Audit.MaxRequestBodySize = 1024
+
+	auditLogs := &auditLogStore{}
+
+	r := chi.NewRouter()
+	r.Use(Handler(config, auditLogs))
+	r.Route("/integrations", func(r chi.Router) {
+		r.Use(RedactKeys("url"))
+		r.Post("/", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+	})
+	r.Post("/webhooks", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	const webhookURL = "https://hooks.slack.com/services/T0/B0/x"
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want string
+	}{
+		{
+			name: "integration create",
+			path: "/integrations",
+			body: `{"identifier":"slack","type":"slack","url":"` + webhookURL + `","token":"t"}`,
+			want: `{"identifier":"slack","token":"[REDACTED]","type":"slack","url":"[REDACTED]"}`,
+		},
+		{
+			name: "other route keeps url",
+			path: "/webhooks",
+			body: `{"identifier":"hook","url":"http://x"}`,
+			want: `{"identifier":"hook","url":"http://x"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditLogs.logs = nil
+
+			req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+			}
+			if len(auditLogs.logs) != 1 {
+				t.Fatalf("got %d audit logs, want 1", len(auditLogs.logs))
+			}
+			got := auditLogs.logs[0].RequestBody
+			if got != test.want {
+				t.Errorf("request body = %s, want %s", got, test.want)
+			}
+			if test.path == "/integrations" && strings.Contains(got, webhookURL) {
+				t.Errorf("request body contains the webhook url: %s", got)
+			}
+		})
+	}
+}
+
+func TestAccessorFrom_MatchesHandler(t *testing.T) {
+	config := &types.Config{}
+	config.Audit.Enabled = true
+
+	auditLogs := &auditLogStore{}
+
+	var accessor types.AuditAccessor
+	handler := Handler(config, auditLogs)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessor = AccessorFrom(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/repos/space/repo/+/pullreq/1/reviewers/2/dismiss?x=1", nil)
+	req.RemoteAddr = "10.0.0.1:12345"
+	req.Header.Set("User-Agent", "test")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(auditLogs.logs) != 1 {
+		t.Fatalf("got %d audit logs, want 1", len(auditLogs.logs))
+	}
+
+	want := types.AuditAccessor{
+		Path:       "/repos/space/repo/+/pullreq/1/reviewers/2/dismiss",
+		RemoteAddr: "10.0.0.1",
+		UserAgent:  "test",
+	}
+	if accessor != want {
+		t.Errorf("accessor = %+v, want %+v", accessor, want)
+	}
+
+	auditLog := auditLogs.logs[0]
+	if got := (types.AuditAccessor{
+		RequestID:  auditLog.RequestID,
+		Path:       auditLog.Path,
+		RemoteAddr: auditLog.RemoteAddr,
+		UserAgent:  auditLog.UserAgent,
+	}); got != accessor {
+		t.Errorf("audit log of the middleware = %+v, accessor = %+v", got, accessor)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeyParts are the parts of json keys whose values are redacted.
+var sensitiveKeyParts = []string{
+	"password",
+	"secret",
+	"token",
+	"credential",
+	"private_key",
+	"authorization",
+	"api_key",
+}
+
+// sensitiveKeys are json keys whose values are redacted (e.g. the data of a secret).
+var sensitiveKeys = map[string]bool{
+	"data": true,
+}
+
+// sensitiveValueRegex matches string values of sensitive keys in (truncated) json that can't be parsed.
+var sensitiveValueRegex = regexp.MustCompile(
+	`(?i)("(?:[^"]*(?:` + strings.Join(sensitiveKeyParts, "|") + `)[^"]*|data)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// redact returns the request body with the values of all sensitive keys and of the extra keys replaced.
+func redact(body []byte, truncated bool, extraKeys ...string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if !truncated {
+		var data any
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&data); err == nil {
+			if out, err := json.Marshal(redactValue(data, extraKeys)); err == nil {
+				return string(out)
+			}
+		}
+	}
+
+	out := sensitiveValueRegex.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+	if len(extraKeys) > 0 {
+		out = extraKeysValueRegex(extraKeys).ReplaceAllString(out, `${1}"`+redacted+`"`)
+	}
+
+	return out
+}
+
+// extraKeysValueRegex returns a regex that matches the string values of the given keys in json that can't be parsed.
+func extraKeysValueRegex(keys []string) *regexp.Regexp {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	return regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+}
+
+func redactValue(data any, extraKeys []string) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitiveKey(key, extraKeys) && value != nil {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(value, extraKeys)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, extraKeys)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string, extraKeys []string) bool {
+	key = strings.ToLower(key)
+	if sensitiveKeys[key] {
+		return true
+	}
+	for _, extraKey := range extraKeys {
+		if key == strings.ToLower(extraKey) {
+			return true
+		}
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
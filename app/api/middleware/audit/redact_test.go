@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		truncated bool
+		extraKeys []string
+		want      string
+	}{
+		{
+			name: "empty",
+			body: "",
+			want: "",
+		},
+		{
+			name: "nested json",
+			body: `{"uid":"s1","data":"value","webhook":{"secret":"abc","url":"http://x"},"items":[{"api_key":"k"}]}`,
+			want: `{"data":"[REDACTED]","items":[{"api_key":"[REDACTED]"}],"uid":"s1",` +
+				`"webhook":{"secret":"[REDACTED]","url":"http://x"}}`,
+		},
+		{
+			name: "numbers are kept as is",
+			body: `{"size":12345678901234567890,"new_password":"p"}`,
+			want: `{"new_password":"[REDACTED]","size":12345678901234567890}`,
+		},
+		{
+			name:      "truncated json",
+			body:      `{"display_name":"x","password":"hunter`,
+			truncated: true,
+			want:      `{"display_name":"x","password":"[REDACTED]"`,
+		},
+		{
+			name:      "extra keys",
+			body:      `{"identifier":"slack","url":"https://hooks.slack.com/services/T0/B0/x","events":["pullreq_created"]}`,
+			extraKeys: []string{"url"},
+			want:      `{"events":["pullreq_created"],"identifier":"slack","url":"[REDACTED]"}`,
+		},
+		{
+			name:      "extra keys in truncated json",
+			body:      `{"identifier":"slack","URL":"https://hooks.slack.com/services/T0`,
+			truncated: true,
+			extraKeys: []string{"url"},
+			want:      `{"identifier":"slack","URL":"[REDACTED]"`,
+		},
+		{
+			name: "plain text",
+			body: `some text`,
+			want: `some text`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := redact([]byte(test.body), test.truncated, test.extraKeys...); got != test.want {
+				t.Errorf("redact() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conditional provides support for conditional requests (ETag / Last-Modified),
+// allowing clients to revalidate cached responses of expensive read operations.
+package conditional
+
+import (
+	"net/http"
+	"time"
+)
+
+var noCacheHeaders = map[string]string{
+	"Expires":         time.Unix(0, 0).UTC().Format(http.TimeFormat),
+	"Cache-Control":   "no-cache, no-store, no-transform, must-revalidate, private, max-age=0",
+	"Pragma":          "no-cache",
+	"X-Accel-Expires": "0",
+}
+
+// NoCache sets headers that prevent responses from being cached by clients and proxies.
+// Unlike the chi middleware, the conditional request headers are kept
+// so the routes supporting conditional requests can revalidate responses.
+func NoCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range noCacheHeaders {
+			w.Header().Set(k, v)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package freeze
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/writefreeze"
+
+	"github.com/rs/zerolog/log"
+)
+
+// BlockWrites blocks all requests that could modify data while the write freeze is active.
+// Requests with a path starting with any of the provided prefixes are always allowed.
+func BlockWrites(flag *writefreeze.Flag, allowedPrefixes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isReadOnly(r) || hasAnyPrefix(r.URL.Path, allowedPrefixes) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+
+			info, err := flag.Get()
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to check write freeze flag")
+			}
+			if info == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			msg := "Write operations are temporarily disabled"
+			if info.Reason != "" {
+				msg += ": " + info.Reason
+			}
+
+			render.UserError(ctx, w, usererror.New(http.StatusServiceUnavailable, msg))
+		})
+	}
+}
+
+func isReadOnly(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderReplayed is set on responses that are replays of the response of an earlier request.
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	// releaseTimeout is the max time spent on releasing the key of a failed request.
+	releaseTimeout = 10 * time.Second
+)
+
+// Handler returns an http middleware that makes requests with an Idempotency-Key header idempotent.
+// The response of the first request is stored and replayed for all retries with the same key,
+// as long as the retry has the same method, path and body and the key isn't older than the retention time.
+// Requests without the header (or without an authenticated principal) are executed as usual.
+// The body of requests with the header is read into memory and therefore limited to the configured size.
+func Handler(
+	config *types.Config,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+) func(http.Handler) http.Handler {
+	cfg := config.IdempotencyKeys
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := r.Header.Get(HeaderIdempotencyKey)
+			session, ok := request.AuthSessionFrom(ctx)
+			if key == "" || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				render.TranslatedUserError(ctx, w,
+					usererror.BadRequestf("%s header can't be longer than %d characters.", HeaderIdempotencyKey, maxKeyLength))
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cfg.MaxRequestBodySize))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				render.TranslatedUserError(ctx, w, usererror.New(http.StatusRequestEntityTooLarge,
+					fmt.Sprintf("Request body can't be larger than %d bytes.", maxBytesErr.Limit)))
+				return
+			}
+			if err != nil {
+				render.TranslatedUserError(ctx, w, usererror.BadRequest("Failed to read request body."))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			idempotencyKey := &types.IdempotencyKey{
+				PrincipalID: session.Principal.ID,
+				Key:         key,
+				Fingerprint: fingerprint(r, body),
+				Created:     time.Now().UnixMilli(),
+			}
+
+			existing, err := claim(r, idempotencyKeyStore, idempotencyKey, cfg.RetentionTime, cfg.PendingLease)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+			if existing != nil {
+				replay(w, r, existing, idempotencyKey.Fingerprint)
+				return
+			}
+
+			// the key is released unless the response was stored (panic, server error, failure to store),
+			// otherwise all retries would be rejected as in progress.
+			stored := false
+			defer func() {
+				if !stored {
+					release(ctx, idempotencyKeyStore, idempotencyKey)
+				}
+			}()
+
+			rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			// server errors are usually transient - allow the client to retry the request.
+			if rw.status >= http.StatusInternalServerError {
+				return
+			}
+
+			idempotencyKey.ResponseStatus = rw.status
+			idempotencyKey.ResponseContentType = w.Header().Get("Content-Type")
+			idempotencyKey.ResponseBody = rw.body.Bytes()
+
+			if err = idempotencyKeyStore.UpdateResponse(ctx, idempotencyKey); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to store response of idempotency key")
+				return
+			}
+
+			stored = true
+		})
+	}
+}
+
+// release deletes the idempotency key of a request whose response isn't stored, so the request can be retried.
+func release(ctx context.Context, idempotencyKeyStore store.IdempotencyKeyStore, idempotencyKey *types.IdempotencyKey) {
+	// the request context might already be canceled (e.g. client disconnected), but the key has to be released.
+	releaseCtx, cancel := context.WithTimeout(log.Ctx(ctx).WithContext(context.Background()), releaseTimeout)
+	defer cancel()
+
+	if err := idempotencyKeyStore.Delete(releaseCtx, idempotencyKey.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete idempotency key")
+	}
+}
+
+// claim creates the idempotency key - or returns the existing one in case the key was already used.
+// Keys older than the retention time, and keys of requests that didn't complete within the pending lease
+// (e.g. because the server crashed), are taken over.
+func claim(
+	r *http.Request,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	idempotencyKey *types.IdempotencyKey,
+	retentionTime time.Duration,
+	pendingLease time.Duration,
+) (*types.IdempotencyKey, error) {
+	ctx := r.Context()
+
+	existing, err := idempotencyKeyStore.Find(ctx, idempotencyKey.PrincipalID, idempotencyKey.Key)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	if existing != nil {
+		age := time.Since(time.UnixMilli(existing.Created))
+		pending := existing.ResponseStatus == 0
+		if age < retentionTime && (!pending || age < pendingLease) {
+			return existing, nil
+		}
+
+		// the key expired or its request was abandoned, it can be reused.
+		if err = idempotencyKeyStore.Delete(ctx, existing.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+		}
+	}
+
+	err = idempotencyKeyStore.Create(ctx, idempotencyKey)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A request with the same idempotency key is already in progress.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create idempotency key: %w", err)
+	}
+
+	return nil, nil
+}
+
+// replay writes the stored response of the original request.
+func replay(w http.ResponseWriter, r *http.Request, existing *types.IdempotencyKey, fingerprint string) {
+	ctx := r.Context()
+
+	if existing.Fingerprint != fingerprint {
+		render.TranslatedUserError(ctx, w, usererror.New(http.StatusUnprocessableEntity,
+			"The idempotency key was already used for a different request."))
+		return
+	}
+
+	if existing.ResponseStatus == 0 {
+		render.TranslatedUserError(ctx, w, usererror.Conflict(
+			"A request with the same idempotency key is already in progress."))
+		return
+	}
+
+	if existing.ResponseContentType != "" {
+		w.Header().Set("Content-Type", existing.ResponseContentType)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(existing.ResponseStatus)
+
+	if _, err := w.Write(existing.ResponseBody); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to write replayed response")
+	}
+}
+
+// fingerprint identifies the request the idempotency key is used for.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+	_, _ = h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter records the response while writing it to the client.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type memoryStore struct {
+	lastID    int64
+	keys      map[string]*types.IdempotencyKey
+	updateErr error
+}
+
+func (s *memoryStore) Find(_ context.Context, _ int64, key string) (*types.IdempotencyKey, error) {
+	k, ok := s.keys[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	c := *k
+	return &c, nil
+}
+
+func (s *memoryStore) Create(_ context.Context, k *types.IdempotencyKey) error {
+	if _, ok := s.keys[k.Key]; ok {
+		return gitness_store.ErrDuplicate
+	}
+	s.lastID++
+	k.ID = s.lastID
+	c := *k
+	s.keys[k.Key] = &c
+	return nil
+}
+
+func (s *memoryStore) UpdateResponse(_ context.Context, k *types.IdempotencyKey) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	c := *k
+	s.keys[k.Key] = &c
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id int64) error {
+	for key, k := range s.keys {
+		if k.ID == id {
+			delete(s.keys, key)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) DeleteOld(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newConfig() *types.Config {
+	config := &types.Config{}
+	config.IdempotencyKeys.RetentionTime = time.Hour
+	config.IdempotencyKeys.PendingLease = time.Minute
+	config.IdempotencyKeys.MaxRequestBodySize = 16
+	return config
+}
+
+func serve(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/pullreq", strings.NewReader(body))
+	r.Header.Set(HeaderIdempotencyKey, key)
+	r = r.WithContext(request.WithAuthSession(r.Context(),
+		&auth.Session{Principal: types.Principal{ID: 1}}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	calls := 0
+	status := http.StatusCreated
+	h := Handler(newConfig(), &memoryStore{keys: map[string]*types.IdempotencyKey{}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(status)
+			_, _ = w.Write(body)
+		}))
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		return serve(h, key, body)
+	}
+
+	if w := do("a", "x"); w.Code != http.StatusCreated || w.Body.String() != "x" {
+		t.Fatalf("unexpected first response: %d %q", w.Code, w.Body.String())
+	}
+
+	w := do("a", "x")
+	if w.Code != http.StatusCreated || w.Body.String() != "x" || w.Header().Get(HeaderReplayed) != "true" {
+		t.Fatalf("expected replayed response, got: %d %q", w.Code, w.Body.String())
+	}
+	if calls != 1 {
+		t.Fatalf("expected handler to be called once, got %d calls", calls)
+	}
+
+	if w = do("a", "y"); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected reuse of key with different body to fail, got %d", w.Code)
+	}
+
+	// server errors aren't stored, the request can be retried.
+	status = http.StatusInternalServerError
+	do("b", "x")
+	status = http.StatusCreated
+	if w = do("b", "x"); w.Code != http.StatusCreated || calls != 3 {
+		t.Errorf("expected retry after server error to be executed, got %d (%d calls)", w.Code, calls)
+	}
+}
+
+func TestHandler_BodyTooLarge(t *testing.T) {
+	h := Handler(newConfig(), &memoryStore{keys: map[string]*types.IdempotencyKey{}})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+	if w := serve(h, "a", strings.Repeat("x", 17)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected request with too large body to fail, got %d", w.Code)
+	}
+}
+
+func TestHandler_ReleaseKey(t *testing.T) {
+	keys := &memoryStore{keys: map[string]*types.IdempotencyKey{}}
+	fail := true
+	h := Handler(newConfig(), keys)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if fail {
+				panic("handler failed")
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected handler to panic")
+			}
+		}()
+		serve(h, "a", "x")
+	}()
+
+	if len(keys.keys) != 0 {
+		t.Fatalf("expected key to be released after panic, got %d keys", len(keys.keys))
+	}
+
+	fail = false
+	keys.updateErr = errors.New("db unavailable")
+	serve(h, "a", "x")
+	if len(keys.keys) != 0 {
+		t.Fatalf("expected key to be released after failing to store the response, got %d keys", len(keys.keys))
+	}
+
+	keys.updateErr = nil
+	if w := serve(h, "a", "x"); w.Code != http.StatusCreated || w.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("expected retry to be executed, got %d", w.Code)
+	}
+}
+
+func TestHandler_PendingLease(t *testing.T) {
+	keys := &memoryStore{keys: map[string]*types.IdempotencyKey{}}
+	h := Handler(newConfig(), keys)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+	// a request that never completed (e.g. the server crashed).
+	pending := func(created time.Time) {
+		keys.keys["a"] = &types.IdempotencyKey{
+			ID:          100,
+			PrincipalID: 1,
+			Key:         "a",
+			Fingerprint: fingerprint(httptest.NewRequest(http.MethodPost, "/pullreq", nil), []byte("x")),
+			Created:     created.UnixMilli(),
+		}
+	}
+
+	pending(time.Now())
+	if w := serve(h, "a", "x"); w.Code != http.StatusConflict {
+		t.Errorf("expected request with pending key to be rejected, got %d", w.Code)
+	}
+
+	pending(time.Now().Add(-2 * time.Minute))
+	if w := serve(h, "a", "x"); w.Code != http.StatusCreated || w.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("expected request to take over abandoned key, got %d", w.Code)
+	}
+	if w := serve(h, "a", "x"); w.Code != http.StatusCreated || w.Header().Get(HeaderReplayed) != "true" {
+		t.Errorf("expected replayed response, got %d", w.Code)
+	}
+}
@@ -15,14 +15,18 @@
 package logging
 
 import (
+	"math/rand"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/logging"
 
+	"github.com/go-chi/chi"
 	"github.com/rs/xid"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
 )
 
@@ -62,15 +66,78 @@ func HLogRequestIDHandler() func(http.Handler) http.Handler {
 	}
 }
 
+// AccessLogConfig configures the access logs of http requests.
+type AccessLogConfig struct {
+	// SampleRate is the fraction of requests that are logged (between 0 and 1).
+	// Failed (5xx) and slow requests are always logged.
+	SampleRate float64
+
+	// SlowThreshold is the duration after which requests are considered slow.
+	// Slow requests are always logged as warning and with additional details. Non-positive values disable it.
+	SlowThreshold time.Duration
+
+	// RouteLevels overrides the log level of the access logs per route pattern (e.g. "/{repo_ref}/info/refs").
+	// Patterns ending with "*" match all routes starting with the prefix.
+	RouteLevels map[string]zerolog.Level
+}
+
+// level returns the log level of the access logs of the route.
+// The longest matching pattern wins, in case multiple prefix patterns match.
+func (c AccessLogConfig) level(route string) zerolog.Level {
+	if level, ok := c.RouteLevels[route]; ok {
+		return level
+	}
+
+	level := zerolog.InfoLevel
+	longest := -1
+	for pattern, l := range c.RouteLevels {
+		prefix, ok := strings.CutSuffix(pattern, "*")
+		if ok && len(prefix) > longest && strings.HasPrefix(route, prefix) {
+			level = l
+			longest = len(prefix)
+		}
+	}
+
+	return level
+}
+
 // HLogAccessLogHandler provides an hlog based middleware that logs access logs.
-func HLogAccessLogHandler() func(http.Handler) http.Handler {
+func HLogAccessLogHandler(config AccessLogConfig) func(http.Handler) http.Handler {
 	return hlog.AccessHandler(
 		func(r *http.Request, status, size int, duration time.Duration) {
-			hlog.FromRequest(r).Info().
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+
+			slow := config.SlowThreshold > 0 && duration >= config.SlowThreshold
+			failed := status >= http.StatusInternalServerError
+
+			var event *zerolog.Event
+			switch {
+			case slow || failed:
+				event = hlog.FromRequest(r).Warn()
+			case config.SampleRate < 1 && rand.Float64() >= config.SampleRate: //nolint:gosec // no security impact
+				return
+			default:
+				event = hlog.FromRequest(r).WithLevel(config.level(route))
+			}
+
+			event.
+				Str("http.route", route).
 				Int("http.status_code", status).
 				Int("http.response_size_bytes", size).
-				Dur("http.elapsed_ms", duration).
-				Msg("http request completed.")
+				Dur("http.elapsed_ms", duration)
+
+			if slow {
+				event.
+					Bool("http.slow", true).
+					Str("http.user_agent", r.UserAgent()).
+					Str("http.remote_addr", r.RemoteAddr).
+					Int64("http.request_size_bytes", r.ContentLength)
+			}
+
+			event.Msg("http request completed.")
 		},
 	)
 }
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestAccessLogConfigLevel(t *testing.T) {
+	config := AccessLogConfig{
+		RouteLevels: map[string]zerolog.Level{
+			"/{repo_ref}/info/refs":   zerolog.DebugLevel,
+			"/v1/system/*":            zerolog.TraceLevel,
+			"/v1/system/config/*":     zerolog.WarnLevel,
+			"/{repo_ref}/git-receive": zerolog.ErrorLevel,
+		},
+	}
+
+	tests := []struct {
+		route string
+		level zerolog.Level
+	}{
+		{route: "/{repo_ref}/info/refs", level: zerolog.DebugLevel},
+		{route: "/{repo_ref}/git-upload-pack", level: zerolog.InfoLevel},
+		{route: "/v1/system/version", level: zerolog.TraceLevel},
+		{route: "/v1/system/config/", level: zerolog.WarnLevel},
+		{route: "/v1/repos/{repo_ref}/", level: zerolog.InfoLevel},
+		{route: "", level: zerolog.InfoLevel},
+	}
+
+	for _, test := range tests {
+		if level := config.level(test.route); level != test.level {
+			t.Errorf("route %q: expected level %s, got %s", test.route, test.level, level)
+		}
+	}
+}
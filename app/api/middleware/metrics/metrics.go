@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// unmatchedRoute is used as route label for requests that didn't match any route,
+// to avoid creating a new time series for every requested path.
+const unmatchedRoute = "unmatched"
+
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "gitness",
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "Duration of the http requests in seconds, partitioned by handler, route, method and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"handler", "route", "method", "code"})
+
+func init() {
+	var errRegistered prometheus.AlreadyRegisteredError
+	if err := prometheus.Register(requestDuration); err != nil && !errors.As(err, &errRegistered) {
+		log.Warn().Err(err).Msg("failed to register http metrics")
+	}
+}
+
+// Handler returns an http.HandlerFunc middleware that records the duration of the requests per route.
+// The middleware has to be used with a chi router, as the route pattern is used to identify the route.
+func Handler(handler string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			requestDuration.
+				WithLabelValues(handler, route, r.Method, strconv.Itoa(status)).
+				Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// Endpoint returns an http.Handler that exposes all registered metrics in the prometheus format.
+// If a token is provided, requests have to authenticate with it as bearer token.
+func Endpoint(token string) http.Handler {
+	h := promhttp.Handler()
+	if token == "" {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
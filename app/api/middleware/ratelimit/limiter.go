@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// idleCleanupInterval is the interval in which buckets that are full again are removed.
+const idleCleanupInterval = time.Minute
+
+// Budget defines the budget of a single caller.
+type Budget struct {
+	// Rate is the number of requests per second that are added back to the budget.
+	Rate float64
+	// Burst is the maximum size of the budget.
+	Burst int
+}
+
+// Result is the outcome of a single rate limit check.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the time until the next request is allowed (zero if allowed).
+	RetryAfter time.Duration
+	// Reset is the time at which the budget is full again.
+	Reset time.Time
+}
+
+type bucket struct {
+	budget Budget
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last update to the bucket.
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.budget.Burst), b.tokens+elapsed*b.budget.Rate)
+	}
+	b.last = now
+}
+
+// Limiter is an in-memory token bucket rate limiter with a separate bucket per key.
+type Limiter struct {
+	mx        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewLimiter returns a new in-memory rate limiter.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consumes one request from the budget of the provided key.
+func (l *Limiter) Allow(key string, budget Budget) Result {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok || b.budget != budget {
+		b = &bucket{budget: budget, tokens: float64(budget.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.refill(now)
+
+	res := Result{Limit: budget.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - b.tokens) / budget.Rate)
+	}
+
+	res.Remaining = int(b.tokens)
+	res.Reset = now.Add(secondsToDuration((float64(budget.Burst) - b.tokens) / budget.Rate))
+
+	return res
+}
+
+// sweep removes all buckets that are full again, they are equivalent to a new bucket.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleCleanupInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.budget.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewLimiter()
+	l.now = func() time.Time { return now }
+
+	budget := Budget{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		if res := l.Allow("a", budget); !res.Allowed || res.Remaining != 1-i {
+			t.Fatalf("request %d: expected allowed with %d remaining, got %+v", i, 1-i, res)
+		}
+	}
+
+	res := l.Allow("a", budget)
+	if res.Allowed {
+		t.Fatalf("expected request to be limited")
+	}
+	if res.RetryAfter != time.Second {
+		t.Errorf("expected retry after 1s, got %s", res.RetryAfter)
+	}
+	if want := now.Add(2 * time.Second); !res.Reset.Equal(want) {
+		t.Errorf("expected reset at %s, got %s", want, res.Reset)
+	}
+
+	// other keys have their own budget.
+	if res = l.Allow("b", budget); !res.Allowed {
+		t.Errorf("expected request with different key to be allowed")
+	}
+
+	now = now.Add(time.Second)
+	if res = l.Allow("a", budget); !res.Allowed {
+		t.Errorf("expected request to be allowed after refill")
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewLimiter()
+	l.now = func() time.Time { return now }
+
+	l.Allow("a", Budget{Rate: 1, Burst: 10})
+	l.Allow("b", Budget{Rate: 0.001, Burst: 10})
+
+	now = now.Add(idleCleanupInterval)
+	l.Allow("c", Budget{Rate: 1, Burst: 10})
+
+	if _, ok := l.buckets["a"]; ok {
+		t.Errorf("expected full bucket to be removed")
+	}
+	if _, ok := l.buckets["b"]; !ok {
+		t.Errorf("expected bucket that isn't full to be kept")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	HeaderLimit      = "X-RateLimit-Limit"
+	HeaderRemaining  = "X-RateLimit-Remaining"
+	HeaderReset      = "X-RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// settings are the rate limit settings that can be changed at runtime.
+type settings struct {
+	enabled               bool
+	authBudget            Budget
+	anonymousBudget       Budget
+	exemptServiceAccounts bool
+	trustProxyHeaders     bool
+	trustedProxyHops      int
+}
+
+func settingsFromConfig(config *types.Config) *settings {
+	cfg := config.RateLimit
+	return &settings{
+		enabled:               cfg.Enabled,
+		authBudget:            Budget{Rate: cfg.Rate, Burst: cfg.Burst},
+		anonymousBudget:       Budget{Rate: cfg.AnonymousRate, Burst: cfg.AnonymousBurst},
+		exemptServiceAccounts: cfg.ExemptServiceAccounts,
+		trustProxyHeaders:     cfg.TrustProxyHeaders,
+		trustedProxyHops:      cfg.TrustedProxyHops,
+	}
+}
+
+// validate returns an error if the budgets of enabled rate limiting would reject every request.
+func (s *settings) validate() error {
+	if !s.enabled {
+		return nil
+	}
+
+	if s.authBudget.Rate <= 0 || s.authBudget.Burst <= 0 {
+		return fmt.Errorf("rate limit rate (%v) and burst (%d) have to be positive",
+			s.authBudget.Rate, s.authBudget.Burst)
+	}
+
+	if s.anonymousBudget.Rate <= 0 || s.anonymousBudget.Burst <= 0 {
+		return fmt.Errorf("anonymous rate limit rate (%v) and burst (%d) have to be positive",
+			s.anonymousBudget.Rate, s.anonymousBudget.Burst)
+	}
+
+	if s.trustProxyHeaders && s.trustedProxyHops <= 0 {
+		return fmt.Errorf("rate limit trusted proxy hops (%d) have to be positive", s.trustedProxyHops)
+	}
+
+	return nil
+}
+
+// Limit returns an http middleware that limits the request rate of callers using a token bucket.
+// Authenticated requests are limited per token (or principal if no token was used),
+// anonymous requests are limited per client IP.
+// The settings are updated whenever the configuration is reloaded.
+// Has to be installed after the authentication middleware.
+// An error is returned if the configured budgets are invalid, the same validation applies to reloads.
+func Limit(config *types.Config, reloader *configreload.Reloader) (func(http.Handler) http.Handler, error) {
+	initial := settingsFromConfig(config)
+	if err := initial.validate(); err != nil {
+		return nil, err
+	}
+
+	current := atomic.Pointer[settings]{}
+	current.Store(initial)
+
+	reloader.Register("rate limit", func(_ context.Context, config *types.Config) error {
+		s := settingsFromConfig(config)
+		if err := s.validate(); err != nil {
+			return err
+		}
+
+		current.Store(s)
+		return nil
+	})
+
+	limiter := NewLimiter()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			cfg := current.Load()
+			if !cfg.enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var key string
+			var budget Budget
+			if session, ok := request.AuthSessionFrom(ctx); ok {
+				if isExempt(session, cfg.exemptServiceAccounts) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				key, budget = sessionKey(session), cfg.authBudget
+			} else {
+				key, budget = "ip:"+clientIP(r, cfg.trustProxyHeaders, cfg.trustedProxyHops), cfg.anonymousBudget
+			}
+
+			// the limiter replaces buckets with a different budget, reloaded budgets apply immediately.
+			res := limiter.Allow(key, budget)
+
+			h := w.Header()
+			h.Set(HeaderLimit, strconv.Itoa(res.Limit))
+			h.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+			h.Set(HeaderReset, strconv.FormatInt(res.Reset.Unix(), 10))
+
+			if !res.Allowed {
+				retryAfter := int64(math.Ceil(res.RetryAfter.Seconds()))
+				h.Set(HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+				render.UserError(ctx, w, usererror.New(http.StatusTooManyRequests,
+					fmt.Sprintf("Rate limit exceeded, retry in %d seconds", retryAfter)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// isExempt returns true if the requests of the session aren't rate limited.
+// Internal services (e.g. git hooks) are never limited.
+func isExempt(session *auth.Session, exemptServiceAccounts bool) bool {
+	switch session.Principal.Type {
+	case enum.PrincipalTypeService:
+		return true
+	case enum.PrincipalTypeServiceAccount:
+		return exemptServiceAccounts
+	default:
+		return false
+	}
+}
+
+// sessionKey returns the budget key of the session - every token gets its own budget.
+func sessionKey(session *auth.Session) string {
+	if metadata, ok := session.Metadata.(*auth.TokenMetadata); ok {
+		return "token:" + strconv.FormatInt(metadata.TokenID, 10)
+	}
+	return "principal:" + strconv.FormatInt(session.Principal.ID, 10)
+}
+
+// clientIP returns the IP of the client, optionally taking proxy headers into account.
+// Every proxy appends the address it received the request from to X-Forwarded-For,
+// so only the entries added by the trusted proxies (counted from the right) can't be spoofed by the client.
+func clientIP(r *http.Request, trustProxyHeaders bool, trustedProxyHops int) string {
+	if trustProxyHeaders {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			ips := strings.Split(strings.Join(forwarded, ","), ",")
+			idx := len(ips) - trustedProxyHops
+			if idx < 0 {
+				idx = 0
+			}
+			return strings.TrimSpace(ips[idx])
+		}
+		if ip := r.Header.Get("X-Real-IP"); ip != "" {
+			return strings.TrimSpace(ip)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
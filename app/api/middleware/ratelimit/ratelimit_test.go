@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/types"
+)
+
+func TestLimitValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(config *types.Config)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			modify: func(*types.Config) {},
+		},
+		{
+			name:    "zero rate",
+			modify:  func(config *types.Config) { config.RateLimit.Rate = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "negative anonymous burst",
+			modify:  func(config *types.Config) { config.RateLimit.AnonymousBurst = -1 },
+			wantErr: true,
+		},
+		{
+			name: "no trusted proxy hops",
+			modify: func(config *types.Config) {
+				config.RateLimit.TrustProxyHeaders = true
+				config.RateLimit.TrustedProxyHops = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "disabled",
+			modify: func(config *types.Config) {
+				config.RateLimit.Enabled = false
+				config.RateLimit.Rate = 0
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &types.Config{}
+			config.RateLimit.Enabled = true
+			config.RateLimit.Rate = 10
+			config.RateLimit.Burst = 20
+			config.RateLimit.AnonymousRate = 1
+			config.RateLimit.AnonymousBurst = 5
+			config.RateLimit.TrustedProxyHops = 1
+			test.modify(config)
+
+			_, err := Limit(config, configreload.NewReloader())
+			if (err != nil) != test.wantErr {
+				t.Errorf("Limit() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name              string
+		forwarded         []string
+		realIP            string
+		trustProxyHeaders bool
+		trustedProxyHops  int
+		want              string
+	}{
+		{
+			name:      "proxy headers not trusted",
+			forwarded: []string{"1.1.1.1"},
+			want:      "10.0.0.1",
+		},
+		{
+			name:              "single proxy",
+			forwarded:         []string{"1.1.1.1"},
+			trustProxyHeaders: true,
+			trustedProxyHops:  1,
+			want:              "1.1.1.1",
+		},
+		{
+			name:              "spoofed entries are ignored",
+			forwarded:         []string{"6.6.6.6, 7.7.7.7, 1.1.1.1"},
+			trustProxyHeaders: true,
+			trustedProxyHops:  1,
+			want:              "1.1.1.1",
+		},
+		{
+			name:              "spoofed entries in multiple headers are ignored",
+			forwarded:         []string{"6.6.6.6", "1.1.1.1"},
+			trustProxyHeaders: true,
+			trustedProxyHops:  1,
+			want:              "1.1.1.1",
+		},
+		{
+			name:              "two proxies",
+			forwarded:         []string{"6.6.6.6, 1.1.1.1, 192.168.0.1"},
+			trustProxyHeaders: true,
+			trustedProxyHops:  2,
+			want:              "1.1.1.1",
+		},
+		{
+			name:              "fewer entries than hops",
+			forwarded:         []string{"1.1.1.1"},
+			trustProxyHeaders: true,
+			trustedProxyHops:  2,
+			want:              "1.1.1.1",
+		},
+		{
+			name:              "real ip",
+			realIP:            "2.2.2.2",
+			trustProxyHeaders: true,
+			trustedProxyHops:  1,
+			want:              "2.2.2.2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			for _, forwarded := range test.forwarded {
+				r.Header.Add("X-Forwarded-For", forwarded)
+			}
+			if test.realIP != "" {
+				r.Header.Set("X-Real-IP", test.realIP)
+			}
+
+			if got := clientIP(r, test.trustProxyHeaders, test.trustedProxyHops); got != test.want {
+				t.Errorf("clientIP() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	config := &types.Config{}
+	config.RateLimit.Enabled = true
+	config.RateLimit.Rate = 10
+	config.RateLimit.Burst = 20
+	config.RateLimit.AnonymousRate = 1
+	config.RateLimit.AnonymousBurst = 1
+	config.RateLimit.TrustProxyHeaders = true
+	config.RateLimit.TrustedProxyHops = 1
+
+	limit, err := Limit(config, configreload.NewReloader())
+	if err != nil {
+		t.Fatalf("Limit() error = %v", err)
+	}
+	h := limit(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// the client rotates the leftmost entry, the proxy appends the real client address.
+	for i, spoofed := range []string{"6.6.6.1", "6.6.6.2"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Forwarded-For", spoofed+", 1.1.1.1")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		want := http.StatusOK
+		if i > 0 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Errorf("request %d: status = %d, want %d", i, w.Code, want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shape provides response shaping of json responses:
+// the fields query parameter selects the attributes that are returned and
+// the expand query parameter adds related objects (e.g. the principal that created a resource).
+package shape
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+// expandableRelations maps the relations that can be expanded to the attribute holding the principal ID.
+var expandableRelations = map[string]string{
+	"creator":   "created_by",
+	"principal": "principal_id",
+}
+
+// Handler returns an http middleware that shapes the json response of GET requests
+// based on the fields and expand query parameters. Responses of requests without them are untouched.
+func Handler(principalInfoCache store.PrincipalInfoCache) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			fields := request.ParseFields(r)
+			expand := request.ParseExpand(r)
+			if r.Method != http.MethodGet || (len(fields) == 0 && len(expand) == 0) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, relation := range expand {
+				if _, ok := expandableRelations[relation]; !ok {
+					render.TranslatedUserError(ctx, w, usererror.BadRequestf(
+						"Relation '%s' can't be expanded (supported: %s).", relation, supportedRelations()))
+					return
+				}
+			}
+
+			bw := &bufferedWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(bw, r)
+
+			body := bw.body.Bytes()
+			if bw.status == http.StatusOK && isJSON(w.Header().Get("Content-Type")) {
+				shaped, err := shape(ctx, principalInfoCache, body, newFieldTree(fields), expand)
+				if err != nil {
+					log.Ctx(ctx).Warn().Err(err).Msg("failed to shape response, returning full response")
+				} else {
+					body = shaped
+				}
+			}
+
+			w.WriteHeader(bw.status)
+			if _, err := w.Write(body); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to write shaped response")
+			}
+		})
+	}
+}
+
+// shape applies the field selection and expands the relations of a json object or a json array of objects.
+func shape(
+	ctx context.Context,
+	principalInfoCache store.PrincipalInfoCache,
+	body []byte,
+	fields fieldTree,
+	expand []string,
+) ([]byte, error) {
+	var data any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	var objects []map[string]any
+	switch v := data.(type) {
+	case map[string]any:
+		objects = []map[string]any{v}
+	case []any:
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				objects = append(objects, obj)
+			}
+		}
+	default:
+		return body, nil
+	}
+
+	if err := expandRelations(ctx, principalInfoCache, objects, expand); err != nil {
+		return nil, err
+	}
+
+	if len(fields) > 0 {
+		// expanded relations are always returned.
+		for _, relation := range expand {
+			fields[relation] = nil
+		}
+		data = fields.apply(data)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// expandRelations adds the principal info of the expanded relations to the objects.
+func expandRelations(
+	ctx context.Context,
+	principalInfoCache store.PrincipalInfoCache,
+	objects []map[string]any,
+	expand []string,
+) error {
+	if len(expand) == 0 {
+		return nil
+	}
+
+	idsMap := map[int64]struct{}{}
+	for _, obj := range objects {
+		for _, relation := range expand {
+			if id, ok := principalID(obj[expandableRelations[relation]]); ok {
+				idsMap[id] = struct{}{}
+			}
+		}
+	}
+
+	ids := make([]int64, 0, len(idsMap))
+	for id := range idsMap {
+		ids = append(ids, id)
+	}
+
+	infos, err := principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load principal infos: %w", err)
+	}
+
+	for _, obj := range objects {
+		for _, relation := range expand {
+			obj[relation] = nil
+			if id, ok := principalID(obj[expandableRelations[relation]]); ok {
+				if info, found := infos[id]; found {
+					obj[relation] = info
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func principalID(v any) (int64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(n.String(), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// fieldTree is the tree of the selected fields, nested fields are separated by a dot (e.g. author.display_name).
+// A nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+func newFieldTree(fields []string) fieldTree {
+	tree := fieldTree{}
+	for _, field := range fields {
+		tree.add(strings.Split(field, "."))
+	}
+	return tree
+}
+
+func (t fieldTree) add(path []string) {
+	sub, exists := t[path[0]]
+	if exists && sub == nil {
+		// the whole value is already selected.
+		return
+	}
+
+	if len(path) == 1 {
+		t[path[0]] = nil
+		return
+	}
+
+	if !exists {
+		sub = fieldTree{}
+		t[path[0]] = sub
+	}
+	sub.add(path[1:])
+}
+
+// apply removes all attributes that weren't selected, arrays are filtered element wise.
+func (t fieldTree) apply(data any) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for key, value := range v {
+			sub, ok := t[key]
+			switch {
+			case !ok:
+				delete(v, key)
+			case sub != nil:
+				v[key] = sub.apply(value)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = t.apply(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func supportedRelations() string {
+	relations := make([]string, 0, len(expandableRelations))
+	for relation := range expandableRelations {
+		relations = append(relations, relation)
+	}
+	sort.Strings(relations)
+	return strings.Join(relations, ", ")
+}
+
+func isJSON(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json")
+}
+
+// bufferedWriter buffers the response so it can be shaped before it's written to the client.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	return w.body.Write(p)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shape
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/api/render"
+)
+
+func TestHandlerFields(t *testing.T) {
+	h := Handler(nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		render.JSON(w, http.StatusOK, []map[string]any{
+			{"id": 1, "title": "a", "author": map[string]any{"id": 3, "uid": "u", "email": "e"}},
+			{"id": 2, "title": "b", "author": map[string]any{"id": 4, "uid": "v", "email": "f"}},
+		})
+	}))
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: `[{"author":{"email":"e","id":3,"uid":"u"},"id":1,"title":"a"},` +
+			`{"author":{"email":"f","id":4,"uid":"v"},"id":2,"title":"b"}]`},
+		{query: "?fields=id", want: `[{"id":1},{"id":2}]`},
+		{query: "?fields=id,author.uid", want: `[{"author":{"uid":"u"},"id":1},{"author":{"uid":"v"},"id":2}]`},
+		{query: "?fields=author.uid&fields=author", want: `[{"author":{"email":"e","id":3,"uid":"u"}},` +
+			`{"author":{"email":"f","id":4,"uid":"v"}}]`},
+		{query: "?fields=unknown", want: `[{},{}]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+test.query, nil))
+
+			if got := compact(t, w.Body.Bytes()); got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHandlerUnknownRelation(t *testing.T) {
+	h := Handler(nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		render.JSON(w, http.StatusOK, map[string]any{"id": 1})
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?expand=unknown", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("want status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func compact(t *testing.T, data []byte) string {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode response: %s", err)
+	}
+	return string(out)
+}
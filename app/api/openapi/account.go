@@ -51,6 +51,16 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// invitationTokenRequest is the request for operations on the invitation of the token.
+type invitationTokenRequest struct {
+	Token string `path:"invitation_token"`
+}
+
+// registerApprovalPendingResponse is returned if the registered user has to be approved by an admin.
+type registerApprovalPendingResponse struct {
+	ApprovalPending bool `json:"approval_pending"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -81,7 +91,28 @@ func buildAccount(reflector *openapi3.Reflector) {
 	onRegister.WithMapOfAnything(map[string]interface{}{"operationId": "onRegister"})
 	_ = reflector.SetRequest(&onRegister, new(registerRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&onRegister, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onRegister, new(registerApprovalPendingResponse), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	opFindInvitation := openapi3.Operation{}
+	opFindInvitation.WithTags("account")
+	opFindInvitation.WithMapOfAnything(map[string]interface{}{"operationId": "findInvitation"})
+	_ = reflector.SetRequest(&opFindInvitation, new(invitationTokenRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindInvitation, new(types.InvitationInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindInvitation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindInvitation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/invitations/{invitation_token}", opFindInvitation)
+
+	opAcceptInvitation := openapi3.Operation{}
+	opAcceptInvitation.WithTags("account")
+	opAcceptInvitation.WithMapOfAnything(map[string]interface{}{"operationId": "acceptInvitation"})
+	_ = reflector.SetRequest(&opAcceptInvitation, new(invitationTokenRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAcceptInvitation, new(types.InvitationInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAcceptInvitation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAcceptInvitation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAcceptInvitation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/invitations/{invitation_token}/accept", opAcceptInvitation)
 }
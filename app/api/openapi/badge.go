@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterBadgeBranch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBranch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The branch of the badge. Defaults to the default branch of the repository."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterBadgePipeline = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPipeline,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The identifier of the pipeline. Defaults to all pipelines of the repository."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+func badgeOperations(reflector *openapi3.Reflector) {
+	opChecks := openapi3.Operation{}
+	opChecks.WithTags("repository")
+	opChecks.WithMapOfAnything(map[string]interface{}{"operationId": "getChecksBadge"})
+	opChecks.WithParameters(queryParameterBadgeBranch)
+	_ = reflector.SetRequest(&opChecks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opChecks, http.StatusOK, "image/svg+xml")
+	_ = reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/badges/checks", opChecks)
+
+	opBuild := openapi3.Operation{}
+	opBuild.WithTags("repository")
+	opBuild.WithMapOfAnything(map[string]interface{}{"operationId": "getBuildBadge"})
+	opBuild.WithParameters(queryParameterBadgeBranch, queryParameterBadgePipeline)
+	_ = reflector.SetRequest(&opBuild, new(repoRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opBuild, http.StatusOK, "image/svg+xml")
+	_ = reflector.SetJSONResponse(&opBuild, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBuild, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBuild, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBuild, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/badges/build", opBuild)
+
+	opPullReqs := openapi3.Operation{}
+	opPullReqs.WithTags("repository")
+	opPullReqs.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqsBadge"})
+	_ = reflector.SetRequest(&opPullReqs, new(repoRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opPullReqs, http.StatusOK, "image/svg+xml")
+	_ = reflector.SetJSONResponse(&opPullReqs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqs, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqs, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/badges/pullreqs", opPullReqs)
+}
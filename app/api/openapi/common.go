@@ -82,6 +82,22 @@ var queryParameterLimit = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterCursor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:     request.QueryParamCursor,
+		In:       openapi3.ParameterInQuery,
+		Required: ptr.Bool(false),
+		Description: ptr.String("The opaque cursor returned as next_cursor of the previous page. " +
+			"If the parameter is provided, the page parameter is ignored and lists are returned as " +
+			"an object with the items and the next_cursor. An empty cursor requests the first page."),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterAfter = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamAfter,
@@ -96,3 +112,36 @@ var queryParameterAfter = openapi3.ParameterOrRef{
 		},
 	},
 }
+
+var queryParameterFields = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFields,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Comma separated list of the fields to return (nested fields are separated by a dot)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterExpand = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamExpand,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Comma separated list of the relations to expand (creator, principal)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+// idempotencyKeyRequest documents the optional idempotency key of mutating operations.
+type idempotencyKeyRequest struct {
+	IdempotencyKey string `header:"Idempotency-Key" description:"Retries with the same key replay the original response."`
+}
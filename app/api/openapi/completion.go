@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// errorSchemaName is the name of the schema of all error payloads (usererror.Error).
+const errorSchemaName = "UsererrorError"
+
+// errorResponse describes a common error response of the API.
+type errorResponse struct {
+	status  int
+	name    string
+	example string
+}
+
+var errorResponses = []errorResponse{
+	{status: http.StatusBadRequest, name: "BadRequest", example: "Invalid request body: unexpected EOF."},
+	{status: http.StatusUnauthorized, name: "Unauthorized", example: "Unauthorized"},
+	{status: http.StatusForbidden, name: "Forbidden", example: "Forbidden"},
+	{status: http.StatusNotFound, name: "NotFound", example: "Resource not found"},
+	{status: http.StatusInternalServerError, name: "InternalServerError", example: "Internal error occurred"},
+}
+
+// paginationHeaders are the headers returned by all operations that support page based pagination.
+var paginationHeaders = []struct {
+	name        string
+	description string
+	example     int
+}{
+	{name: "x-total", description: "Total number of items.", example: 42},
+	{name: "x-total-pages", description: "Total number of pages.", example: 2},
+	{name: "x-page", description: "The current page.", example: 1},
+	{name: "x-per-page", description: "The number of items per page.", example: 30},
+	{name: "x-next-page", description: "The next page, only set if there is a next page.", example: 2},
+	{name: "x-prev-page", description: "The previous page, only set if there is a previous page.", example: 1},
+}
+
+// publicTags are the tags of operations that don't require authentication.
+var publicTags = map[string]bool{
+	"account": true,
+	"system":  true,
+}
+
+// completeSpec adds the error responses, pagination headers and enum documentation
+// that are common to all operations, so that generated clients can handle them.
+func completeSpec(reflector *openapi3.Reflector) {
+	spec := reflector.Spec
+
+	addErrorResponseComponents(reflector)
+	addPaginationHeaderComponents(spec)
+
+	for path, item := range spec.Paths.MapOfPathItemValues {
+		for method, op := range item.MapOfOperationValues {
+			completeOperation(&op)
+			item.MapOfOperationValues[method] = op
+		}
+		spec.Paths.MapOfPathItemValues[path] = item
+	}
+
+	documentEnums(spec)
+}
+
+func addErrorResponseComponents(reflector *openapi3.Reflector) {
+	// ensure the error schema is registered, independent of whether any operation references it explicitly.
+	schemas := reflector.Spec.ComponentsEns().SchemasEns()
+	if _, ok := schemas.MapOfSchemaOrRefValues[errorSchemaName]; !ok {
+		errorSchema, err := reflector.Reflect(new(usererror.Error))
+		panicOnErr(err)
+
+		schemaOrRef := openapi3.SchemaOrRef{}
+		schemaOrRef.FromJSONSchema(errorSchema.ToSchemaOrBool())
+		schemas.WithMapOfSchemaOrRefValuesItem(errorSchemaName, schemaOrRef)
+	}
+	schemaOrRef := openapi3.SchemaOrRef{
+		SchemaReference: &openapi3.SchemaReference{Ref: "#/components/schemas/" + errorSchemaName},
+	}
+
+	responses := reflector.Spec.ComponentsEns().ResponsesEns()
+	for _, errResp := range errorResponses {
+		responses.WithMapOfResponseOrRefValuesItem(errResp.name, openapi3.ResponseOrRef{
+			Response: &openapi3.Response{
+				Description: http.StatusText(errResp.status),
+				Content: map[string]openapi3.MediaType{
+					"application/json": {
+						Schema:  &schemaOrRef,
+						Example: ptrptr(map[string]interface{}{"message": errResp.example}),
+					},
+				},
+			},
+		})
+	}
+}
+
+func addPaginationHeaderComponents(spec *openapi3.Spec) {
+	headers := spec.ComponentsEns().HeadersEns()
+	for _, header := range paginationHeaders {
+		headers.WithMapOfHeaderOrRefValuesItem(header.name, openapi3.HeaderOrRef{
+			Header: &openapi3.Header{
+				Description: ptr.String(header.description),
+				Schema: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+				Example: ptrptr(header.example),
+			},
+		})
+	}
+}
+
+// completeOperation adds the common error responses that are missing and
+// documents the pagination headers and response shaping parameters for operations that support pagination.
+func completeOperation(op *openapi3.Operation) {
+	hasParams := op.RequestBody != nil
+	hasPathParams := false
+	hasPagination := false
+	for _, param := range op.Parameters {
+		if param.Parameter == nil {
+			continue
+		}
+		hasParams = true
+		switch {
+		case param.Parameter.In == openapi3.ParameterInPath:
+			hasPathParams = true
+		case param.Parameter.In == openapi3.ParameterInQuery && param.Parameter.Name == request.QueryParamPage:
+			hasPagination = true
+		}
+	}
+
+	public := false
+	for _, tag := range op.Tags {
+		public = public || publicTags[tag]
+	}
+
+	if op.Responses.MapOfResponseOrRefValues == nil {
+		op.Responses.MapOfResponseOrRefValues = map[string]openapi3.ResponseOrRef{}
+	}
+
+	for _, errResp := range errorResponses {
+		switch {
+		case errResp.status == http.StatusBadRequest && !hasParams,
+			errResp.status == http.StatusNotFound && !hasPathParams,
+			(errResp.status == http.StatusUnauthorized || errResp.status == http.StatusForbidden) && public:
+			continue
+		}
+
+		code := fmt.Sprint(errResp.status)
+		if existing, ok := op.Responses.MapOfResponseOrRefValues[code]; ok {
+			addErrorExample(existing, errResp)
+			continue
+		}
+		op.Responses.MapOfResponseOrRefValues[code] = openapi3.ResponseOrRef{
+			ResponseReference: &openapi3.ResponseReference{Ref: "#/components/responses/" + errResp.name},
+		}
+	}
+
+	if !hasPagination {
+		return
+	}
+
+	op.Parameters = append(op.Parameters, queryParameterFields, queryParameterExpand)
+
+	okResp, ok := op.Responses.MapOfResponseOrRefValues[fmt.Sprint(http.StatusOK)]
+	if !ok || okResp.Response == nil {
+		return
+	}
+	if okResp.Response.Headers == nil {
+		okResp.Response.Headers = map[string]openapi3.HeaderOrRef{}
+	}
+	for _, header := range paginationHeaders {
+		okResp.Response.Headers[header.name] = openapi3.HeaderOrRef{
+			HeaderReference: &openapi3.HeaderReference{Ref: "#/components/headers/" + header.name},
+		}
+	}
+}
+
+// addErrorExample adds the example of the common error response to an explicitly defined error response.
+func addErrorExample(resp openapi3.ResponseOrRef, errResp errorResponse) {
+	if resp.Response == nil {
+		return
+	}
+
+	for contentType, mediaType := range resp.Response.Content {
+		if mediaType.Example != nil || mediaType.Examples != nil {
+			continue
+		}
+		mediaType.Example = ptrptr(map[string]interface{}{"message": errResp.example})
+		resp.Response.Content[contentType] = mediaType
+	}
+}
+
+// documentEnums adds a description listing the possible values to all enum schemas without description.
+func documentEnums(spec *openapi3.Spec) {
+	if spec.Components == nil || spec.Components.Schemas == nil {
+		return
+	}
+
+	for name, schemaOrRef := range spec.Components.Schemas.MapOfSchemaOrRefValues {
+		schema := schemaOrRef.Schema
+		if schema == nil || len(schema.Enum) == 0 || schema.Description != nil {
+			continue
+		}
+
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, fmt.Sprintf("`%v`", v))
+		}
+		sort.Strings(values)
+
+		schema.Description = ptr.String("Possible values: " + strings.Join(values, ", ") + ".")
+		spec.Components.Schemas.MapOfSchemaOrRefValues[name] = schemaOrRef
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/integration"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// integrationType is used to add the has_url and has_token fields.
+type integrationType struct {
+	types.Integration
+	HasURL   bool `json:"has_url"`
+	HasToken bool `json:"has_token"`
+}
+
+type integrationRequest struct {
+	Identifier string `path:"integration_identifier"`
+}
+
+type createRepoIntegrationRequest struct {
+	repoRequest
+	integration.CreateInput
+}
+
+type repoIntegrationRequest struct {
+	repoRequest
+	integrationRequest
+}
+
+type updateRepoIntegrationRequest struct {
+	repoIntegrationRequest
+	integration.UpdateInput
+}
+
+type createSpaceIntegrationRequest struct {
+	spaceRequest
+	integration.CreateInput
+}
+
+type spaceIntegrationRequest struct {
+	spaceRequest
+	integrationRequest
+}
+
+type updateSpaceIntegrationRequest struct {
+	spaceIntegrationRequest
+	integration.UpdateInput
+}
+
+func integrationOperations(reflector *openapi3.Reflector) {
+	integrationOperationsForParent(reflector, "Repo", "/repos/{repo_ref}/integrations",
+		new(repoRequest), new(createRepoIntegrationRequest),
+		new(repoIntegrationRequest), new(updateRepoIntegrationRequest))
+	integrationOperationsForParent(reflector, "Space", "/spaces/{space_ref}/integrations",
+		new(spaceRequest), new(createSpaceIntegrationRequest),
+		new(spaceIntegrationRequest), new(updateSpaceIntegrationRequest))
+}
+
+//nolint:funlen
+func integrationOperationsForParent(
+	reflector *openapi3.Reflector,
+	parent string,
+	path string,
+	parentReq any,
+	createReq any,
+	integrationReq any,
+	updateReq any,
+) {
+	createIntegration := openapi3.Operation{}
+	createIntegration.WithTags("integration")
+	createIntegration.WithMapOfAnything(map[string]interface{}{"operationId": "create" + parent + "Integration"})
+	_ = reflector.SetRequest(&createIntegration, createReq, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createIntegration, new(integrationType), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createIntegration, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, path, createIntegration)
+
+	listIntegrations := openapi3.Operation{}
+	listIntegrations.WithTags("integration")
+	listIntegrations.WithMapOfAnything(map[string]interface{}{"operationId": "list" + parent + "Integrations"})
+	_ = reflector.SetRequest(&listIntegrations, parentReq, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listIntegrations, new([]integrationType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listIntegrations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listIntegrations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listIntegrations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path, listIntegrations)
+
+	getIntegration := openapi3.Operation{}
+	getIntegration.WithTags("integration")
+	getIntegration.WithMapOfAnything(map[string]interface{}{"operationId": "get" + parent + "Integration"})
+	_ = reflector.SetRequest(&getIntegration, integrationReq, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getIntegration, new(integrationType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path+"/{integration_identifier}", getIntegration)
+
+	updateIntegration := openapi3.Operation{}
+	updateIntegration.WithTags("integration")
+	updateIntegration.WithMapOfAnything(map[string]interface{}{"operationId": "update" + parent + "Integration"})
+	_ = reflector.SetRequest(&updateIntegration, updateReq, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateIntegration, new(integrationType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateIntegration, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, path+"/{integration_identifier}", updateIntegration)
+
+	deleteIntegration := openapi3.Operation{}
+	deleteIntegration.WithTags("integration")
+	deleteIntegration.WithMapOfAnything(map[string]interface{}{"operationId": "delete" + parent + "Integration"})
+	_ = reflector.SetRequest(&deleteIntegration, integrationReq, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteIntegration, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, path+"/{integration_identifier}", deleteIntegration)
+
+	testIntegration := openapi3.Operation{}
+	testIntegration.WithTags("integration")
+	testIntegration.WithMapOfAnything(map[string]interface{}{"operationId": "test" + parent + "Integration"})
+	_ = reflector.SetRequest(&testIntegration, integrationReq, http.MethodPost)
+	_ = reflector.SetJSONResponse(&testIntegration, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&testIntegration, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, path+"/{integration_identifier}/test", testIntegration)
+}
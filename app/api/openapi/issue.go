@@ -0,0 +1,227 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/issue"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createIssueRequest struct {
+	repoRequest
+	idempotencyKeyRequest
+	issue.CreateInput
+}
+
+type listIssueRequest struct {
+	repoRequest
+}
+
+type issueRequest struct {
+	repoRequest
+	Number int64 `path:"issue_number"`
+}
+
+type updateIssueRequest struct {
+	issueRequest
+	issue.UpdateInput
+}
+
+type stateIssueRequest struct {
+	issueRequest
+	issue.StateInput
+}
+
+var queryParameterQueryIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the issue titles are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCreatedByIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCreatedBy,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The principal ID who created the issues."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAssigneeIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAssigneeID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The principal ID to whom the issues are assigned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterLabelIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLabel,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The labels the issues have to carry (all of them)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterStateIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the issues to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.IssueState("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterSortIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the issues are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.IssueSortNumber),
+				Enum:    enum.IssueSort("").Enum(),
+			},
+		},
+	},
+}
+
+func issueOperations(reflector *openapi3.Reflector) {
+	createIssue := openapi3.Operation{}
+	createIssue.WithTags("issue")
+	createIssue.WithMapOfAnything(map[string]interface{}{"operationId": "createIssue"})
+	_ = reflector.SetRequest(&createIssue, new(createIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createIssue, new(types.Issue), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issues", createIssue)
+
+	listIssue := openapi3.Operation{}
+	listIssue.WithTags("issue")
+	listIssue.WithMapOfAnything(map[string]interface{}{"operationId": "listIssue"})
+	listIssue.WithParameters(
+		queryParameterStateIssue, queryParameterQueryIssue,
+		queryParameterCreatedByIssue, queryParameterAssigneeIssue, queryParameterLabelIssue,
+		queryParameterOrder, queryParameterSortIssue,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listIssue, new(listIssueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listIssue, new([]types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues", listIssue)
+
+	getIssue := openapi3.Operation{}
+	getIssue.WithTags("issue")
+	getIssue.WithMapOfAnything(map[string]interface{}{"operationId": "getIssue"})
+	_ = reflector.SetRequest(&getIssue, new(issueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues/{issue_number}", getIssue)
+
+	updateIssue := openapi3.Operation{}
+	updateIssue.WithTags("issue")
+	updateIssue.WithMapOfAnything(map[string]interface{}{"operationId": "updateIssue"})
+	_ = reflector.SetRequest(&updateIssue, new(updateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/issues/{issue_number}", updateIssue)
+
+	deleteIssue := openapi3.Operation{}
+	deleteIssue.WithTags("issue")
+	deleteIssue.WithMapOfAnything(map[string]interface{}{"operationId": "deleteIssue"})
+	_ = reflector.SetRequest(&deleteIssue, new(issueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteIssue, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&deleteIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/issues/{issue_number}", deleteIssue)
+
+	stateIssue := openapi3.Operation{}
+	stateIssue.WithTags("issue")
+	stateIssue.WithMapOfAnything(map[string]interface{}{"operationId": "stateIssue"})
+	_ = reflector.SetRequest(&stateIssue, new(stateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stateIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issues/{issue_number}/state", stateIssue)
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/issuetracker"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type updateIssueTrackerSettingsRequest struct {
+	spaceRequest
+	types.IssueTrackerSettings
+}
+
+type renderIssueReferencesRequest struct {
+	repoRequest
+	issuetracker.RenderInput
+}
+
+func issueTrackerOperations(reflector *openapi3.Reflector) {
+	opFindSettings := openapi3.Operation{}
+	opFindSettings.WithTags("space")
+	opFindSettings.WithMapOfAnything(map[string]interface{}{"operationId": "findIssueTrackerSettings"})
+	_ = reflector.SetRequest(&opFindSettings, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(issuetracker.SettingsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/issue-tracker", opFindSettings)
+
+	opUpdateSettings := openapi3.Operation{}
+	opUpdateSettings.WithTags("space")
+	opUpdateSettings.WithMapOfAnything(map[string]interface{}{"operationId": "updateIssueTrackerSettings"})
+	_ = reflector.SetRequest(&opUpdateSettings, new(updateIssueTrackerSettingsRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(issuetracker.SettingsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/issue-tracker", opUpdateSettings)
+
+	opRender := openapi3.Operation{}
+	opRender.WithTags("repository")
+	opRender.WithMapOfAnything(map[string]interface{}{"operationId": "renderIssueReferences"})
+	_ = reflector.SetRequest(&opRender, new(renderIssueReferencesRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRender, new(issuetracker.RenderOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issue-tracker/render", opRender)
+}
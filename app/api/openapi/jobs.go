@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/job"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// adminJobRequest is the request for job specific admin operations.
+	adminJobRequest struct {
+		JobUID string `path:"job_uid"`
+	}
+
+	// adminJobListRequest is the request for listing background jobs.
+	adminJobListRequest struct {
+		Type  string      `query:"type"  description:"The type of the jobs."`
+		State []job.State `query:"state" description:"The state of the jobs."`
+
+		// include pagination request
+		paginationRequest
+	}
+)
+
+// helper function that constructs the openapi specification
+// for the admin background job resources.
+func buildAdminJobs(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListJobs"})
+	_ = reflector.SetRequest(&opList, new(adminJobListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*job.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/jobs", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetJob"})
+	_ = reflector.SetRequest(&opFind, new(adminJobRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(job.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/jobs/{job_uid}", opFind)
+
+	opCancel := openapi3.Operation{}
+	opCancel.WithTags("admin")
+	opCancel.WithMapOfAnything(map[string]interface{}{"operationId": "adminCancelJob"})
+	_ = reflector.SetRequest(&opCancel, new(adminJobRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCancel, new(job.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/jobs/{job_uid}/cancel", opCancel)
+
+	opRetry := openapi3.Operation{}
+	opRetry.WithTags("admin")
+	opRetry.WithMapOfAnything(map[string]interface{}{"operationId": "adminRetryJob"})
+	_ = reflector.SetRequest(&opRetry, new(adminJobRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRetry, new(job.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/jobs/{job_uid}/retry", opRetry)
+}
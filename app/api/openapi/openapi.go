@@ -57,6 +57,15 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	buildAccount(&reflector)
 	buildUser(&reflector)
 	buildAdmin(&reflector)
+	buildAdminJobs(&reflector)
+	buildAdminSystemEvents(&reflector)
+	buildAdminAuditLogs(&reflector)
+	buildAdminDiagnostics(&reflector)
+	buildAdminConfigReload(&reflector)
+	buildAdminMaintenance(&reflector)
+	buildAdminInstanceSettings(&reflector)
+	announcementOperations(&reflector)
+	usageOperations(&reflector)
 	buildPrincipals(&reflector)
 	spaceOperations(&reflector)
 	pluginOperations(&reflector)
@@ -67,9 +76,20 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	secretOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
+	issueOperations(&reflector)
 	webhookOperations(&reflector)
+	integrationOperations(&reflector)
+	issueTrackerOperations(&reflector)
+	spaceInheritanceOperations(&reflector)
+	spaceRepoTemplateOperations(&reflector)
+	spaceArchivalPolicyOperations(&reflector)
+	spaceReviewReminderPolicyOperations(&reflector)
+	badgeOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
+	markdownOperations(&reflector)
+	runnerOperations(&reflector)
+	registryOperations(&reflector)
 
 	//
 	// define security scheme
@@ -78,7 +98,7 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	scheme := openapi3.SecuritySchemeOrRef{
 		SecurityScheme: &openapi3.SecurityScheme{
 			HTTPSecurityScheme: &openapi3.HTTPSecurityScheme{
-				Scheme: "bearerAuth",
+				Scheme: "bearer",
 				Bearer: &openapi3.Bearer{},
 			},
 		},
@@ -95,6 +115,12 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 		"bearerAuth": {},
 	})
 
+	//
+	// add common error responses, pagination headers and enum documentation
+	//
+
+	completeSpec(&reflector)
+
 	return reflector.Spec
 }
 
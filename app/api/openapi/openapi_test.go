@@ -13,3 +13,52 @@
 // limitations under the License.
 
 package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestGenerateCompletesOperations(t *testing.T) {
+	spec := NewOpenAPIService().Generate()
+
+	internalError := strconv.Itoa(http.StatusInternalServerError)
+	for path, item := range spec.Paths.MapOfPathItemValues {
+		for method, op := range item.MapOfOperationValues {
+			if _, ok := op.Responses.MapOfResponseOrRefValues[internalError]; !ok {
+				t.Errorf("%s %s: missing %s response", method, path, internalError)
+			}
+
+			if !hasQueryParameter(op, request.QueryParamPage) {
+				continue
+			}
+			okResp, ok := op.Responses.MapOfResponseOrRefValues[strconv.Itoa(http.StatusOK)]
+			if !ok || okResp.Response == nil {
+				continue
+			}
+			if _, ok = okResp.Response.Headers["x-total"]; !ok {
+				t.Errorf("%s %s: missing pagination headers", method, path)
+			}
+		}
+	}
+
+	for name, schema := range spec.Components.Schemas.MapOfSchemaOrRefValues {
+		if schema.Schema != nil && len(schema.Schema.Enum) > 0 && schema.Schema.Description == nil {
+			t.Errorf("enum schema %s is missing a description", name)
+		}
+	}
+}
+
+func hasQueryParameter(op openapi3.Operation, name string) bool {
+	for _, param := range op.Parameters {
+		if param.Parameter != nil && param.Parameter.In == openapi3.ParameterInQuery && param.Parameter.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -29,6 +29,10 @@ import (
 type principalRequest struct {
 }
 
+type principalAvatarRequest struct {
+	ID int64 `path:"principal_id"`
+}
+
 var queryParameterQueryPrincipals = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -77,4 +81,14 @@ func buildPrincipals(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals", opList)
+
+	opAvatar := openapi3.Operation{}
+	opAvatar.WithTags("principals")
+	opAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "getPrincipalAvatar"})
+	_ = reflector.SetRequest(&opAvatar, new(principalAvatarRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAvatar, nil, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAvatar, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals/{principal_id}/avatar", opAvatar)
 }
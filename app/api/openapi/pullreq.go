@@ -31,6 +31,7 @@ import (
 
 type createPullReqRequest struct {
 	repoRequest
+	idempotencyKeyRequest
 	pullreq.CreateInput
 }
 
@@ -63,11 +64,18 @@ type listPullReqActivitiesRequest struct {
 
 type mergePullReq struct {
 	pullReqRequest
+	idempotencyKeyRequest
 	pullreq.MergeInput
 }
 
+type mergeScheduleSetPullReqRequest struct {
+	pullReqRequest
+	pullreq.MergeScheduleInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
+	idempotencyKeyRequest
 	pullreq.CommentCreateInput
 }
 
@@ -90,6 +98,20 @@ type commentStatusPullReqRequest struct {
 	pullreq.CommentStatusInput
 }
 
+type commentVisibilityPullReqRequest struct {
+	pullReqCommentRequest
+	pullreq.CommentVisibilityInput
+}
+
+type commentHistoryPullReqRequest struct {
+	pullReqCommentRequest
+}
+
+type conversationPullReqRequest struct {
+	pullReqRequest
+	pullreq.ConversationInput
+}
+
 type reviewerListPullReqRequest struct {
 	pullReqRequest
 }
@@ -99,6 +121,16 @@ type reviewerDeletePullReqRequest struct {
 	PullReqReviewerID int64 `path:"pullreq_reviewer_id"`
 }
 
+type reviewerSnoozePullReqRequest struct {
+	reviewerDeletePullReqRequest
+	pullreq.ReviewerSnoozeInput
+}
+
+type reviewerDismissPullReqRequest struct {
+	reviewerDeletePullReqRequest
+	pullreq.ReviewerDismissInput
+}
+
 type reviewerAddPullReqRequest struct {
 	pullReqRequest
 	pullreq.ReviewerAddInput
@@ -123,13 +155,22 @@ type fileViewDeletePullReqRequest struct {
 	Path string `path:"file_path"`
 }
 
+type diffOptionsRequest struct {
+	IgnoreWhitespace bool   `query:"ignore_whitespace" description:"ignore whitespace when comparing lines"`
+	IgnoreBlankLines bool   `query:"ignore_blank_lines" description:"ignore changes whose lines are all blank"`
+	DiffAlgorithm    string `query:"diff_algorithm" enum:"myers,minimal,patience,histogram"`
+	ContextLines     int    `query:"context_lines" minimum:"0" maximum:"1000" description:"number of context lines"`
+}
+
 type getRawPRDiffRequest struct {
 	pullReqRequest
+	diffOptionsRequest
 	Path []string `query:"path" description:"provide path for diff operation"`
 }
 
 type postRawPRDiffRequest struct {
 	pullReqRequest
+	diffOptionsRequest
 	gittypes.FileDiffRequests
 }
 
@@ -151,6 +192,35 @@ var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQuerySearchPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamQuery,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The full-text search query. Supports quoted phrases " +
+			"as well as author:<uid> and repo:<path> filters."),
+		Required: ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSpacePathSearchPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSpacePath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The path of the space in which the pull requests are searched."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterSourceRepoRefPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        "source_repo_ref",
@@ -320,7 +390,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
 		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
 		queryParameterOrder, queryParameterSortPullRequest,
-		queryParameterPage, queryParameterLimit)
+		queryParameterPage, queryParameterLimit, queryParameterCursor)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listPullReq, new([]types.PullReq), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusBadRequest)
@@ -329,6 +399,20 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq", listPullReq)
 
+	searchPullReq := openapi3.Operation{}
+	searchPullReq.WithTags("pullreq")
+	searchPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "searchPullReq"})
+	searchPullReq.WithParameters(
+		queryParameterQuerySearchPullRequest, queryParameterSpacePathSearchPullRequest,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&searchPullReq, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&searchPullReq, new([]types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&searchPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&searchPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&searchPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&searchPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/search/pullreq", searchPullReq)
+
 	getPullReq := openapi3.Operation{}
 	getPullReq.WithTags("pullreq")
 	getPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReq"})
@@ -425,6 +509,43 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/status", commentStatusPullReq)
 
+	commentVisibilityPullReq := openapi3.Operation{}
+	commentVisibilityPullReq.WithTags("pullreq")
+	commentVisibilityPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentVisibilityPullReq"})
+	_ = reflector.SetRequest(&commentVisibilityPullReq, new(commentVisibilityPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&commentVisibilityPullReq, new(types.PullReqActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentVisibilityPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentVisibilityPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentVisibilityPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentVisibilityPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/visibility",
+		commentVisibilityPullReq)
+
+	commentHistoryPullReq := openapi3.Operation{}
+	commentHistoryPullReq.WithTags("pullreq")
+	commentHistoryPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentHistoryPullReq"})
+	_ = reflector.SetRequest(&commentHistoryPullReq, new(commentHistoryPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&commentHistoryPullReq, new([]types.PullReqActivityEdit), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentHistoryPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentHistoryPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentHistoryPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentHistoryPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/history", commentHistoryPullReq)
+
+	conversationPullReq := openapi3.Operation{}
+	conversationPullReq.WithTags("pullreq")
+	conversationPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "conversationPullReq"})
+	_ = reflector.SetRequest(&conversationPullReq, new(conversationPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&conversationPullReq, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&conversationPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&conversationPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&conversationPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&conversationPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/conversation", conversationPullReq)
+
 	reviewerAdd := openapi3.Operation{}
 	reviewerAdd.WithTags("pullreq")
 	reviewerAdd.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerAddPullReq"})
@@ -461,6 +582,32 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodDelete,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/{pullreq_reviewer_id}", reviewerDelete)
 
+	reviewerSnooze := openapi3.Operation{}
+	reviewerSnooze.WithTags("pullreq")
+	reviewerSnooze.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerSnoozePullReq"})
+	_ = reflector.SetRequest(&reviewerSnooze, new(reviewerSnoozePullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&reviewerSnooze, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&reviewerSnooze, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewerSnooze, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewerSnooze, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewerSnooze, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reviewerSnooze, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/{pullreq_reviewer_id}/snooze", reviewerSnooze)
+
+	reviewerDismiss := openapi3.Operation{}
+	reviewerDismiss.WithTags("pullreq")
+	reviewerDismiss.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerDismissPullReq"})
+	_ = reflector.SetRequest(&reviewerDismiss, new(reviewerDismissPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&reviewerDismiss, new(types.PullReqReviewer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reviewerDismiss, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewerDismiss, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewerDismiss, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewerDismiss, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reviewerDismiss, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/{pullreq_reviewer_id}/dismiss", reviewerDismiss)
+
 	reviewSubmit := openapi3.Operation{}
 	reviewSubmit.WithTags("pullreq")
 	reviewSubmit.WithMapOfAnything(map[string]interface{}{"operationId": "reviewSubmitPullReq"})
@@ -488,6 +635,43 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	mergeScheduleFind := openapi3.Operation{}
+	mergeScheduleFind.WithTags("pullreq")
+	mergeScheduleFind.WithMapOfAnything(map[string]interface{}{"operationId": "findMergeSchedulePullReq"})
+	_ = reflector.SetRequest(&mergeScheduleFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeScheduleFind, new(types.MergeSchedule), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeScheduleFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeScheduleFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeScheduleFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeScheduleFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-schedule", mergeScheduleFind)
+
+	mergeScheduleSet := openapi3.Operation{}
+	mergeScheduleSet.WithTags("pullreq")
+	mergeScheduleSet.WithMapOfAnything(map[string]interface{}{"operationId": "setMergeSchedulePullReq"})
+	_ = reflector.SetRequest(&mergeScheduleSet, new(mergeScheduleSetPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&mergeScheduleSet, new(types.MergeSchedule), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeScheduleSet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeScheduleSet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeScheduleSet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeScheduleSet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeScheduleSet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-schedule", mergeScheduleSet)
+
+	mergeScheduleCancel := openapi3.Operation{}
+	mergeScheduleCancel.WithTags("pullreq")
+	mergeScheduleCancel.WithMapOfAnything(map[string]interface{}{"operationId": "cancelMergeSchedulePullReq"})
+	_ = reflector.SetRequest(&mergeScheduleCancel, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&mergeScheduleCancel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&mergeScheduleCancel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeScheduleCancel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeScheduleCancel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeScheduleCancel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-schedule", mergeScheduleCancel)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
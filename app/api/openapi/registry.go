@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/registry"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createRegistryRequest struct {
+	spaceRequest
+	registry.CreateInput
+}
+
+type registryRequest struct {
+	spaceRequest
+	Identifier string `path:"registry_identifier"`
+}
+
+type updateRegistryRequest struct {
+	registryRequest
+	registry.UpdateInput
+}
+
+type registryArtifactRequest struct {
+	registryRequest
+	Name string `path:"artifact_name"`
+}
+
+type registryVersionRequest struct {
+	registryArtifactRequest
+	Version string `path:"version_name"`
+}
+
+type registryGenericFileRequest struct {
+	registryRequest
+	Package  string `path:"package_name"`
+	Version  string `path:"version_name"`
+	FileName string `path:"file_name"`
+}
+
+type uploadRegistryGenericFileRequest struct {
+	registryGenericFileRequest
+	Content string `json:"-" format:"binary" description:"Binary file to upload"`
+}
+
+var queryParameterQueryRegistry = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the registries by their identifier."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterQueryRegistryArtifact = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the artifacts or versions by their name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterRegistryType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRegistryType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the registries to list."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.RegistryType("").Enum(),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func registryOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("registry")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRegistry"})
+	_ = reflector.SetRequest(&opCreate, new(createRegistryRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Registry), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/registries", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("registry")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listRegistries"})
+	opList.WithParameters(queryParameterQueryRegistry, queryParameterRegistryType,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.Registry{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/registries", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("registry")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRegistry"})
+	_ = reflector.SetRequest(&opFind, new(registryRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Registry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/registries/{registry_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("registry")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRegistry"})
+	_ = reflector.SetRequest(&opUpdate, new(updateRegistryRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Registry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/registries/{registry_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("registry")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRegistry"})
+	_ = reflector.SetRequest(&opDelete, new(registryRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/registries/{registry_identifier}", opDelete)
+
+	opArtifactList := openapi3.Operation{}
+	opArtifactList.WithTags("registry")
+	opArtifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listRegistryArtifacts"})
+	opArtifactList.WithParameters(queryParameterQueryRegistryArtifact, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opArtifactList, new(registryRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opArtifactList, []types.RegistryArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opArtifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArtifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArtifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArtifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/registries/{registry_identifier}/artifacts", opArtifactList)
+
+	opVersionList := openapi3.Operation{}
+	opVersionList.WithTags("registry")
+	opVersionList.WithMapOfAnything(map[string]interface{}{"operationId": "listRegistryArtifactVersions"})
+	opVersionList.WithParameters(queryParameterQueryRegistryArtifact, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opVersionList, new(registryArtifactRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opVersionList, []types.RegistryVersion{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opVersionList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opVersionList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opVersionList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opVersionList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/registries/{registry_identifier}/artifacts/{artifact_name}/versions", opVersionList)
+
+	opVersionDelete := openapi3.Operation{}
+	opVersionDelete.WithTags("registry")
+	opVersionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRegistryArtifactVersion"})
+	_ = reflector.SetRequest(&opVersionDelete, new(registryVersionRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opVersionDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opVersionDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opVersionDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opVersionDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opVersionDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/registries/{registry_identifier}/artifacts/{artifact_name}/versions/{version_name}",
+		opVersionDelete)
+
+	opGenericUpload := openapi3.Operation{}
+	opGenericUpload.WithTags("registry")
+	opGenericUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadRegistryGenericFile"})
+	_ = reflector.SetRequest(&opGenericUpload, new(uploadRegistryGenericFileRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(types.RegistryVersion), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opGenericUpload, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/spaces/{space_ref}/registries/{registry_identifier}/generic/{package_name}/{version_name}/{file_name}",
+		opGenericUpload)
+
+	opGenericDownload := openapi3.Operation{}
+	opGenericDownload.WithTags("registry")
+	opGenericDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadRegistryGenericFile"})
+	_ = reflector.SetRequest(&opGenericDownload, new(registryGenericFileRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opGenericDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opGenericDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opGenericDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGenericDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGenericDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGenericDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/registries/{registry_identifier}/generic/{package_name}/{version_name}/{file_name}",
+		opGenericDownload)
+
+	opGenericDelete := openapi3.Operation{}
+	opGenericDelete.WithTags("registry")
+	opGenericDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRegistryGenericFile"})
+	_ = reflector.SetRequest(&opGenericDelete, new(registryGenericFileRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opGenericDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opGenericDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGenericDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGenericDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGenericDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/registries/{registry_identifier}/generic/{package_name}/{version_name}/{file_name}",
+		opGenericDelete)
+}
@@ -44,6 +44,21 @@ type repoRequest struct {
 	Ref string `path:"repo_ref"`
 }
 
+type updateReviewerAssignmentRequest struct {
+	repoRequest
+	types.ReviewerAssignmentSettings
+}
+
+type updatePullReqLintPolicyRequest struct {
+	repoRequest
+	types.PullReqLintPolicy
+}
+
+type updateMergeMessageTemplatesRequest struct {
+	repoRequest
+	types.MergeMessageTemplates
+}
+
 type updateRepoRequest struct {
 	repoRequest
 	repo.UpdateInput
@@ -107,6 +122,11 @@ type getContentOutput struct {
 	Content content     `json:"content"`
 }
 
+type wikiPageRequest struct {
+	repoRequest
+	Slug string `path:"wiki_page"`
+}
+
 type listCommitsRequest struct {
 	repoRequest
 }
@@ -129,6 +149,21 @@ type createBranchRequest struct {
 	repo.CreateBranchInput
 }
 
+type deleteBranchesRequest struct {
+	repoRequest
+	repo.DeleteBranchesInput
+}
+
+type getArchiveRequest struct {
+	repoRequest
+	ArchiveName string `path:"archive_name"`
+}
+
+type createSignedURLRequest struct {
+	repoRequest
+	repo.SignedURLInput
+}
+
 type getBranchRequest struct {
 	repoRequest
 	BranchName string `path:"branch_name"`
@@ -199,6 +234,31 @@ type restoreRequest struct {
 	repo.RestoreInput
 }
 
+type garbageCollectRequest struct {
+	repoRequest
+	repo.GarbageCollectInput
+}
+
+var queryParameterStateVulnerabilityAlert = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the vulnerability alerts to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.VulnerabilityAlertState("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterGitRef = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamGitRef,
@@ -215,6 +275,35 @@ var queryParameterGitRef = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryPaths = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The query which is fuzzy matched against the file paths."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterReadmePath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Path of the directory in which the README is searched (repository root by default)"),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(""),
+			},
+		},
+	},
+}
+
 var queryParameterPath = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamPath,
@@ -288,6 +377,34 @@ var QueryParamIncludeStats = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterHighlightPath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Path of the file, it's used to detect the language of the file"),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterBlobSHA = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSHA,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("SHA of the blob. If not provided, the blob of the file at the git ref is used"),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterLineFrom = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamLineFrom,
@@ -428,6 +545,64 @@ var queryParameterCommitter = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterAuthor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuthor,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Author pattern for which commit information should be retrieved."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterFollow = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFollow,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Continue listing the history of the path beyond renames (requires a path)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterIncludeDivergence = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeDivergence,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the divergence to the default branch should be included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterStaleOlderThan = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamStaleOlderThan,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only list branches whose latest commit is older than the timestamp (in milliseconds)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
 var queryParameterQueryRuleList = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -534,6 +709,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusConflict)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}", opUpdate)
 
 	opDelete := openapi3.Operation{}
@@ -572,6 +748,74 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/restore", opRestore)
 
+	opMarkArchived := openapi3.Operation{}
+	opMarkArchived.WithTags("repository")
+	opMarkArchived.WithMapOfAnything(map[string]interface{}{"operationId": "archiveRepository"})
+	_ = reflector.SetRequest(&opMarkArchived, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkArchived, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkArchived, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMarkArchived, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMarkArchived, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMarkArchived, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/archive", opMarkArchived)
+
+	opUnarchive := openapi3.Operation{}
+	opUnarchive.WithTags("repository")
+	opUnarchive.WithMapOfAnything(map[string]interface{}{"operationId": "unarchiveRepository"})
+	_ = reflector.SetRequest(&opUnarchive, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/unarchive", opUnarchive)
+
+	opGarbageCollect := openapi3.Operation{}
+	opGarbageCollect.WithTags("repository")
+	opGarbageCollect.WithMapOfAnything(map[string]interface{}{"operationId": "garbageCollectRepository"})
+	_ = reflector.SetRequest(&opGarbageCollect, new(garbageCollectRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opGarbageCollect, new(repo.GarbageCollectOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGarbageCollect, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGarbageCollect, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGarbageCollect, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGarbageCollect, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGarbageCollect, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/maintenance/gc", opGarbageCollect)
+
+	opFsck := openapi3.Operation{}
+	opFsck.WithTags("repository")
+	opFsck.WithMapOfAnything(map[string]interface{}{"operationId": "fsckRepository"})
+	_ = reflector.SetRequest(&opFsck, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFsck, new(repo.FsckOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFsck, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFsck, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFsck, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFsck, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/maintenance/fsck", opFsck)
+
+	opDiskUsage := openapi3.Operation{}
+	opDiskUsage.WithTags("repository")
+	opDiskUsage.WithMapOfAnything(map[string]interface{}{"operationId": "getRepositoryDiskUsage"})
+	_ = reflector.SetRequest(&opDiskUsage, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDiskUsage, new(repo.DiskUsageOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDiskUsage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDiskUsage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDiskUsage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDiskUsage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/maintenance/disk-usage", opDiskUsage)
+
+	opDetachObjects := openapi3.Operation{}
+	opDetachObjects.WithTags("repository")
+	opDetachObjects.WithMapOfAnything(map[string]interface{}{"operationId": "detachRepositoryObjects"})
+	_ = reflector.SetRequest(&opDetachObjects, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDetachObjects, new(repo.DiskUsageOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDetachObjects, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDetachObjects, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDetachObjects, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDetachObjects, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/maintenance/detach-objects",
+		opDetachObjects)
+
 	opMove := openapi3.Operation{}
 	opMove.WithTags("repository")
 	opMove.WithMapOfAnything(map[string]interface{}{"operationId": "moveRepository"})
@@ -594,6 +838,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opServiceAccounts, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/service-accounts", opServiceAccounts)
 
+	opMalwareFindings := openapi3.Operation{}
+	opMalwareFindings.WithTags("repository")
+	opMalwareFindings.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryMalwareFindings"})
+	opMalwareFindings.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opMalwareFindings, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMalwareFindings, []types.MalwareFinding{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMalwareFindings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMalwareFindings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMalwareFindings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMalwareFindings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/malware-findings", opMalwareFindings)
+
 	opGetContent := openapi3.Operation{}
 	opGetContent.WithTags("repository")
 	opGetContent.WithMapOfAnything(map[string]interface{}{"operationId": "getContent"})
@@ -631,6 +887,30 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opGetArchive := openapi3.Operation{}
+	opGetArchive.WithTags("repository")
+	opGetArchive.WithMapOfAnything(map[string]interface{}{"operationId": "getArchive"})
+	_ = reflector.SetRequest(&opGetArchive, new(getArchiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opGetArchive, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opGetArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGetArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{archive_name}", opGetArchive)
+
+	opCreateSignedURL := openapi3.Operation{}
+	opCreateSignedURL.WithTags("repository")
+	opCreateSignedURL.WithMapOfAnything(map[string]interface{}{"operationId": "createSignedURL"})
+	_ = reflector.SetRequest(&opCreateSignedURL, new(createSignedURLRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateSignedURL, new(types.SignedURL), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCreateSignedURL, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateSignedURL, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateSignedURL, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateSignedURL, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateSignedURL, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/signed-urls", opCreateSignedURL)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -648,8 +928,8 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opListCommits.WithTags("repository")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listCommits"})
 	opListCommits.WithParameters(queryParameterGitRef, queryParameterAfterCommits, queryParameterPath,
-		queryParameterSince, queryParameterUntil, queryParameterCommitter,
-		queryParameterPage, queryParameterLimit, QueryParamIncludeStats)
+		queryParameterSince, queryParameterUntil, queryParameterCommitter, queryParameterAuthor, queryParameterFollow,
+		queryParameterPage, queryParameterLimit, queryParameterCursor, QueryParamIncludeStats)
 	_ = reflector.SetRequest(&opListCommits, new(listCommitsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListCommits, []types.ListCommitResponse{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusInternalServerError)
@@ -704,6 +984,250 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetBranch, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/branches/{branch_name}", opGetBranch)
 
+	opPushedBranch := openapi3.Operation{}
+	opPushedBranch.WithTags("repository")
+	opPushedBranch.WithMapOfAnything(map[string]interface{}{"operationId": "getPushedBranch"})
+	_ = reflector.SetRequest(&opPushedBranch, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushedBranch, new(types.PushedBranch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushedBranch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushedBranch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushedBranch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushedBranch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pushed-branch", opPushedBranch)
+
+	opSummary := openapi3.Operation{}
+	opSummary.WithTags("repository")
+	opSummary.WithMapOfAnything(map[string]interface{}{"operationId": "summary"})
+	_ = reflector.SetRequest(&opSummary, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSummary, new(types.RepositorySummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/summary", opSummary)
+
+	opLanguages := openapi3.Operation{}
+	opLanguages.WithTags("repository")
+	opLanguages.WithMapOfAnything(map[string]interface{}{"operationId": "listLanguages"})
+	_ = reflector.SetRequest(&opLanguages, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLanguages, []types.LanguageStat{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLanguages, []types.LanguageStat{}, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/languages", opLanguages)
+
+	opDependencies := openapi3.Operation{}
+	opDependencies.WithTags("repository")
+	opDependencies.WithMapOfAnything(map[string]interface{}{"operationId": "listDependencies"})
+	_ = reflector.SetRequest(&opDependencies, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDependencies, new(types.RepoDependencies), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDependencies, new(types.RepoDependencies), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opDependencies, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencies, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencies, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencies, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/dependencies", opDependencies)
+
+	opVulnerabilityAlerts := openapi3.Operation{}
+	opVulnerabilityAlerts.WithTags("repository")
+	opVulnerabilityAlerts.WithMapOfAnything(map[string]interface{}{"operationId": "listVulnerabilityAlerts"})
+	opVulnerabilityAlerts.WithParameters(queryParameterStateVulnerabilityAlert, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opVulnerabilityAlerts, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opVulnerabilityAlerts, []types.VulnerabilityAlert{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opVulnerabilityAlerts, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opVulnerabilityAlerts, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opVulnerabilityAlerts, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opVulnerabilityAlerts, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/vulnerability-alerts", opVulnerabilityAlerts)
+
+	opFindReviewerAssignment := openapi3.Operation{}
+	opFindReviewerAssignment.WithTags("repository")
+	opFindReviewerAssignment.WithMapOfAnything(map[string]interface{}{"operationId": "findReviewerAssignment"})
+	_ = reflector.SetRequest(&opFindReviewerAssignment, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindReviewerAssignment, new(types.ReviewerAssignmentSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindReviewerAssignment, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindReviewerAssignment, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindReviewerAssignment, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindReviewerAssignment, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/reviewer-assignment", opFindReviewerAssignment)
+
+	opUpdateReviewerAssignment := openapi3.Operation{}
+	opUpdateReviewerAssignment.WithTags("repository")
+	opUpdateReviewerAssignment.WithMapOfAnything(map[string]interface{}{"operationId": "updateReviewerAssignment"})
+	_ = reflector.SetRequest(&opUpdateReviewerAssignment, new(updateReviewerAssignmentRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateReviewerAssignment, new(types.ReviewerAssignmentSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateReviewerAssignment, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateReviewerAssignment, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateReviewerAssignment, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateReviewerAssignment, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateReviewerAssignment, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/reviewer-assignment", opUpdateReviewerAssignment)
+
+	opDeleteReviewerAssignment := openapi3.Operation{}
+	opDeleteReviewerAssignment.WithTags("repository")
+	opDeleteReviewerAssignment.WithMapOfAnything(map[string]interface{}{"operationId": "deleteReviewerAssignment"})
+	_ = reflector.SetRequest(&opDeleteReviewerAssignment, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteReviewerAssignment, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteReviewerAssignment, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteReviewerAssignment, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteReviewerAssignment, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteReviewerAssignment, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/reviewer-assignment", opDeleteReviewerAssignment)
+
+	opFindPullReqLintPolicy := openapi3.Operation{}
+	opFindPullReqLintPolicy.WithTags("repository")
+	opFindPullReqLintPolicy.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqLintPolicy"})
+	_ = reflector.SetRequest(&opFindPullReqLintPolicy, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPullReqLintPolicy, new(types.PullReqLintPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPullReqLintPolicy, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPullReqLintPolicy, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPullReqLintPolicy, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPullReqLintPolicy, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq-lint-policy", opFindPullReqLintPolicy)
+
+	opUpdatePullReqLintPolicy := openapi3.Operation{}
+	opUpdatePullReqLintPolicy.WithTags("repository")
+	opUpdatePullReqLintPolicy.WithMapOfAnything(map[string]interface{}{"operationId": "updatePullReqLintPolicy"})
+	_ = reflector.SetRequest(&opUpdatePullReqLintPolicy, new(updatePullReqLintPolicyRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqLintPolicy, new(types.PullReqLintPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqLintPolicy, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqLintPolicy, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqLintPolicy, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqLintPolicy, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqLintPolicy, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq-lint-policy", opUpdatePullReqLintPolicy)
+
+	opDeletePullReqLintPolicy := openapi3.Operation{}
+	opDeletePullReqLintPolicy.WithTags("repository")
+	opDeletePullReqLintPolicy.WithMapOfAnything(map[string]interface{}{"operationId": "deletePullReqLintPolicy"})
+	_ = reflector.SetRequest(&opDeletePullReqLintPolicy, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePullReqLintPolicy, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePullReqLintPolicy, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePullReqLintPolicy, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePullReqLintPolicy, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePullReqLintPolicy, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq-lint-policy", opDeletePullReqLintPolicy)
+
+	opFindMergeMessageTemplates := openapi3.Operation{}
+	opFindMergeMessageTemplates.WithTags("repository")
+	opFindMergeMessageTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "findMergeMessageTemplates"})
+	_ = reflector.SetRequest(&opFindMergeMessageTemplates, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindMergeMessageTemplates, new(types.MergeMessageTemplates), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindMergeMessageTemplates, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindMergeMessageTemplates, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindMergeMessageTemplates, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindMergeMessageTemplates, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/merge-message-templates", opFindMergeMessageTemplates)
+
+	opUpdateMergeMessageTemplates := openapi3.Operation{}
+	opUpdateMergeMessageTemplates.WithTags("repository")
+	opUpdateMergeMessageTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "updateMergeMessageTemplates"})
+	_ = reflector.SetRequest(&opUpdateMergeMessageTemplates, new(updateMergeMessageTemplatesRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateMergeMessageTemplates, new(types.MergeMessageTemplates), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateMergeMessageTemplates, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateMergeMessageTemplates, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateMergeMessageTemplates, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateMergeMessageTemplates, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateMergeMessageTemplates, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/merge-message-templates", opUpdateMergeMessageTemplates)
+
+	opDeleteMergeMessageTemplates := openapi3.Operation{}
+	opDeleteMergeMessageTemplates.WithTags("repository")
+	opDeleteMergeMessageTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "deleteMergeMessageTemplates"})
+	_ = reflector.SetRequest(&opDeleteMergeMessageTemplates, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteMergeMessageTemplates, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteMergeMessageTemplates, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteMergeMessageTemplates, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteMergeMessageTemplates, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteMergeMessageTemplates, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/merge-message-templates", opDeleteMergeMessageTemplates)
+
+	opReadme := openapi3.Operation{}
+	opReadme.WithTags("repository")
+	opReadme.WithMapOfAnything(map[string]interface{}{"operationId": "getReadme"})
+	opReadme.WithParameters(queryParameterGitRef, queryParameterReadmePath)
+	_ = reflector.SetRequest(&opReadme, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReadme, new(repo.ReadmeOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/readme", opReadme)
+
+	opHighlight := openapi3.Operation{}
+	opHighlight.WithTags("repository")
+	opHighlight.WithMapOfAnything(map[string]interface{}{"operationId": "highlight"})
+	opHighlight.WithParameters(queryParameterHighlightPath, queryParameterGitRef, queryParameterBlobSHA,
+		queryParameterLineFrom, queryParameterLineTo)
+	_ = reflector.SetRequest(&opHighlight, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opHighlight, new(types.HighlightedBlob), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opHighlight, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opHighlight, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opHighlight, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opHighlight, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opHighlight, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/highlight", opHighlight)
+
+	opSearchPaths := openapi3.Operation{}
+	opSearchPaths.WithTags("repository")
+	opSearchPaths.WithMapOfAnything(map[string]interface{}{"operationId": "searchPaths"})
+	opSearchPaths.WithParameters(queryParameterGitRef, queryParameterQueryPaths, queryParameterLimit)
+	_ = reflector.SetRequest(&opSearchPaths, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSearchPaths, []types.PathMatch{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSearchPaths, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSearchPaths, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSearchPaths, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSearchPaths, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/paths", opSearchPaths)
+
+	opInsightsCommitActivity := openapi3.Operation{}
+	opInsightsCommitActivity.WithTags("repository")
+	opInsightsCommitActivity.WithMapOfAnything(map[string]interface{}{"operationId": "getInsightsCommitActivity"})
+	_ = reflector.SetRequest(&opInsightsCommitActivity, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opInsightsCommitActivity, []types.InsightsCommitActivity{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInsightsCommitActivity, []types.InsightsCommitActivity{}, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opInsightsCommitActivity, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInsightsCommitActivity, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInsightsCommitActivity, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInsightsCommitActivity, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/insights/commit-activity", opInsightsCommitActivity)
+
+	opInsightsCodeFrequency := openapi3.Operation{}
+	opInsightsCodeFrequency.WithTags("repository")
+	opInsightsCodeFrequency.WithMapOfAnything(map[string]interface{}{"operationId": "getInsightsCodeFrequency"})
+	_ = reflector.SetRequest(&opInsightsCodeFrequency, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opInsightsCodeFrequency, []types.InsightsCodeFrequency{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInsightsCodeFrequency, []types.InsightsCodeFrequency{}, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opInsightsCodeFrequency, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInsightsCodeFrequency, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInsightsCodeFrequency, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInsightsCodeFrequency, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/insights/code-frequency", opInsightsCodeFrequency)
+
+	opInsightsContributors := openapi3.Operation{}
+	opInsightsContributors.WithTags("repository")
+	opInsightsContributors.WithMapOfAnything(map[string]interface{}{"operationId": "getInsightsContributors"})
+	opInsightsContributors.WithParameters(queryParameterLimit)
+	_ = reflector.SetRequest(&opInsightsContributors, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opInsightsContributors, []types.InsightsContributor{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInsightsContributors, []types.InsightsContributor{}, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opInsightsContributors, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInsightsContributors, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInsightsContributors, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInsightsContributors, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/insights/contributors", opInsightsContributors)
+
 	opDeleteBranch := openapi3.Operation{}
 	opDeleteBranch.WithTags("repository")
 	opDeleteBranch.WithMapOfAnything(map[string]interface{}{"operationId": "deleteBranch"})
@@ -717,12 +1241,24 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDeleteBranch, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/branches/{branch_name}", opDeleteBranch)
 
+	opDeleteBranches := openapi3.Operation{}
+	opDeleteBranches.WithTags("repository")
+	opDeleteBranches.WithMapOfAnything(map[string]interface{}{"operationId": "deleteBranches"})
+	_ = reflector.SetRequest(&opDeleteBranches, new(deleteBranchesRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDeleteBranches, []repo.BranchDeleteResult{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDeleteBranches, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteBranches, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteBranches, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteBranches, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteBranches, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/branches/bulk-delete", opDeleteBranches)
+
 	opListBranches := openapi3.Operation{}
 	opListBranches.WithTags("repository")
 	opListBranches.WithMapOfAnything(map[string]interface{}{"operationId": "listBranches"})
 	opListBranches.WithParameters(queryParameterIncludeCommit,
 		queryParameterQueryBranches, queryParameterOrder, queryParameterSortBranch,
-		queryParameterPage, queryParameterLimit)
+		queryParameterPage, queryParameterLimit, queryParameterIncludeDivergence, queryParameterStaleOlderThan)
 	_ = reflector.SetRequest(&opListBranches, new(listBranchesRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListBranches, []repo.Branch{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opListBranches, new(usererror.Error), http.StatusInternalServerError)
@@ -932,4 +1468,94 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
+
+	opFindWiki := openapi3.Operation{}
+	opFindWiki.WithTags("repository")
+	opFindWiki.WithMapOfAnything(map[string]interface{}{"operationId": "findWiki"})
+	_ = reflector.SetRequest(&opFindWiki, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindWiki, new(types.Wiki), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindWiki, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindWiki, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindWiki, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindWiki, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/wiki", opFindWiki)
+
+	opListWikiPages := openapi3.Operation{}
+	opListWikiPages.WithTags("repository")
+	opListWikiPages.WithMapOfAnything(map[string]interface{}{"operationId": "listWikiPages"})
+	opListWikiPages.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opListWikiPages, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListWikiPages, []types.WikiPage{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListWikiPages, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListWikiPages, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListWikiPages, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListWikiPages, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/wiki/pages", opListWikiPages)
+
+	opCreateWikiPage := openapi3.Operation{}
+	opCreateWikiPage.WithTags("repository")
+	opCreateWikiPage.WithMapOfAnything(map[string]interface{}{"operationId": "createWikiPage"})
+	_ = reflector.SetRequest(&opCreateWikiPage, &struct {
+		repoRequest
+		repo.CreateWikiPageInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateWikiPage, new(types.WikiPage), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateWikiPage, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateWikiPage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateWikiPage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateWikiPage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateWikiPage, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/wiki/pages", opCreateWikiPage)
+
+	opFindWikiPage := openapi3.Operation{}
+	opFindWikiPage.WithTags("repository")
+	opFindWikiPage.WithMapOfAnything(map[string]interface{}{"operationId": "findWikiPage"})
+	opFindWikiPage.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opFindWikiPage, new(wikiPageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindWikiPage, new(types.WikiPage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindWikiPage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindWikiPage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindWikiPage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindWikiPage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/wiki/pages/{wiki_page}", opFindWikiPage)
+
+	opUpdateWikiPage := openapi3.Operation{}
+	opUpdateWikiPage.WithTags("repository")
+	opUpdateWikiPage.WithMapOfAnything(map[string]interface{}{"operationId": "updateWikiPage"})
+	_ = reflector.SetRequest(&opUpdateWikiPage, &struct {
+		wikiPageRequest
+		repo.UpdateWikiPageInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(types.WikiPage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateWikiPage, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/wiki/pages/{wiki_page}", opUpdateWikiPage)
+
+	opDeleteWikiPage := openapi3.Operation{}
+	opDeleteWikiPage.WithTags("repository")
+	opDeleteWikiPage.WithMapOfAnything(map[string]interface{}{"operationId": "deleteWikiPage"})
+	_ = reflector.SetRequest(&opDeleteWikiPage, new(wikiPageRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteWikiPage, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteWikiPage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteWikiPage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteWikiPage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteWikiPage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/wiki/pages/{wiki_page}", opDeleteWikiPage)
+
+	opListWikiPageHistory := openapi3.Operation{}
+	opListWikiPageHistory.WithTags("repository")
+	opListWikiPageHistory.WithMapOfAnything(map[string]interface{}{"operationId": "listWikiPageHistory"})
+	opListWikiPageHistory.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListWikiPageHistory, new(wikiPageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListWikiPageHistory, []types.Commit{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListWikiPageHistory, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListWikiPageHistory, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListWikiPageHistory, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListWikiPageHistory, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/wiki/pages/{wiki_page}/history", opListWikiPageHistory)
 }
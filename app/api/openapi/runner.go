@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createRunnerTokenRequest struct {
+	spaceRequest
+	runner.TokenCreateInput
+}
+
+type runnerTokenRequest struct {
+	spaceRequest
+	Identifier string `path:"runner_token_identifier"`
+}
+
+type runnerRequest struct {
+	spaceRequest
+	Identifier string `path:"runner_identifier"`
+}
+
+// runnerAuthRequest documents the access token external runners authenticate with.
+type runnerAuthRequest struct {
+	Token string `header:"X-Runner-Token" description:"The access token of the runner."`
+}
+
+type runnerHeartbeatRequest struct {
+	runnerAuthRequest
+	runner.HeartbeatInput
+}
+
+type runnerStageLeaseRequest struct {
+	runnerAuthRequest
+	runner.StageRequestInput
+}
+
+type runnerStageRequest struct {
+	runnerAuthRequest
+	StageID int64 `path:"stage_id"`
+}
+
+type runnerStageUpdateRequest struct {
+	runnerStageRequest
+	runner.StageUpdateInput
+}
+
+type runnerStepRequest struct {
+	runnerStageRequest
+	StepNumber int64 `path:"step_number"`
+}
+
+type runnerStepUpdateRequest struct {
+	runnerStepRequest
+	runner.StepUpdateInput
+}
+
+var queryParameterQueryRunner = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the runners by their identifier."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterWait = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamWait,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The time (in seconds) to wait for a stage to become available. " +
+			"Defaults to (and is capped by) the configured maximum poll wait time."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func runnerOperations(reflector *openapi3.Reflector) {
+	opTokenCreate := openapi3.Operation{}
+	opTokenCreate.WithTags("runner")
+	opTokenCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRunnerToken"})
+	_ = reflector.SetRequest(&opTokenCreate, new(createRunnerTokenRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(types.RunnerRegistrationTokenCreateOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/runner-tokens", opTokenCreate)
+
+	opTokenList := openapi3.Operation{}
+	opTokenList.WithTags("runner")
+	opTokenList.WithMapOfAnything(map[string]interface{}{"operationId": "listRunnerTokens"})
+	_ = reflector.SetRequest(&opTokenList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTokenList, []types.RunnerRegistrationToken{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/runner-tokens", opTokenList)
+
+	opTokenDelete := openapi3.Operation{}
+	opTokenDelete.WithTags("runner")
+	opTokenDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRunnerToken"})
+	_ = reflector.SetRequest(&opTokenDelete, new(runnerTokenRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opTokenDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/runner-tokens/{runner_token_identifier}", opTokenDelete)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("runner")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listRunners"})
+	opList.WithParameters(queryParameterQueryRunner, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.Runner{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/runners", opList)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("runner")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRunner"})
+	_ = reflector.SetRequest(&opDelete, new(runnerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/runners/{runner_identifier}", opDelete)
+
+	opRegister := openapi3.Operation{}
+	opRegister.WithTags("runner")
+	opRegister.WithMapOfAnything(map[string]interface{}{"operationId": "registerRunner"})
+	_ = reflector.SetRequest(&opRegister, new(runner.RegisterInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRegister, new(types.RunnerRegisterOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runners/register", opRegister)
+
+	opHeartbeat := openapi3.Operation{}
+	opHeartbeat.WithTags("runner")
+	opHeartbeat.WithMapOfAnything(map[string]interface{}{"operationId": "runnerHeartbeat"})
+	_ = reflector.SetRequest(&opHeartbeat, new(runnerHeartbeatRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(types.RunnerHeartbeatOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runners/heartbeat", opHeartbeat)
+
+	opStageRequest := openapi3.Operation{}
+	opStageRequest.WithTags("runner")
+	opStageRequest.WithMapOfAnything(map[string]interface{}{"operationId": "runnerRequestStage"})
+	opStageRequest.WithParameters(queryParameterWait)
+	_ = reflector.SetRequest(&opStageRequest, new(runnerStageLeaseRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opStageRequest, new(types.RunnerStage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStageRequest, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opStageRequest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStageRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStageRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runners/stages/request", opStageRequest)
+
+	opStageUpdate := openapi3.Operation{}
+	opStageUpdate.WithTags("runner")
+	opStageUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "runnerUpdateStage"})
+	_ = reflector.SetRequest(&opStageUpdate, new(runnerStageUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(types.Stage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opStageUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/runners/stages/{stage_id}", opStageUpdate)
+
+	opWatch := openapi3.Operation{}
+	opWatch.WithTags("runner")
+	opWatch.WithMapOfAnything(map[string]interface{}{"operationId": "runnerWatchStage"})
+	_ = reflector.SetRequest(&opWatch, new(runnerStageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opWatch, new(types.RunnerWatchOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/runners/stages/{stage_id}/watch", opWatch)
+
+	opStepUpdate := openapi3.Operation{}
+	opStepUpdate.WithTags("runner")
+	opStepUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "runnerUpdateStep"})
+	_ = reflector.SetRequest(&opStepUpdate, new(runnerStepUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opStepUpdate, new(types.Step), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStepUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStepUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStepUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStepUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStepUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/runners/stages/{stage_id}/steps/{step_number}", opStepUpdate)
+
+	opLogsWrite := openapi3.Operation{}
+	opLogsWrite.WithTags("runner")
+	opLogsWrite.WithMapOfAnything(map[string]interface{}{"operationId": "runnerWriteLogs"})
+	_ = reflector.SetRequest(&opLogsWrite, new(runnerStepRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLogsWrite, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opLogsWrite, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLogsWrite, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLogsWrite, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLogsWrite, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLogsWrite, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/runners/stages/{stage_id}/steps/{step_number}/logs", opLogsWrite)
+
+	opLogsUpload := openapi3.Operation{}
+	opLogsUpload.WithTags("runner")
+	opLogsUpload.WithMapOfAnything(map[string]interface{}{"operationId": "runnerUploadLogs"})
+	_ = reflector.SetRequest(&opLogsUpload, new(runnerStepRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLogsUpload, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opLogsUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLogsUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLogsUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLogsUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/runners/stages/{stage_id}/steps/{step_number}/logs/upload", opLogsUpload)
+}
@@ -18,9 +18,11 @@ import (
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/secret"
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 
+	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
@@ -41,6 +43,36 @@ type updateSecretRequest struct {
 	secret.UpdateInput
 }
 
+type createRepoSecretRequest struct {
+	repoRequest
+	secret.RepoCreateInput
+}
+
+type repoSecretRequest struct {
+	repoRequest
+	Identifier string `path:"secret_identifier"`
+}
+
+type updateRepoSecretRequest struct {
+	repoSecretRequest
+	secret.UpdateInput
+}
+
+var queryParameterQuerySecret = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the secrets by their identifier."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+//nolint:funlen
 func secretOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("secret")
@@ -86,4 +118,66 @@ func secretOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/secrets/{secret_ref}", opUpdate)
+
+	repoSecretOperations(reflector)
+}
+
+func repoSecretOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("secret")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoSecret"})
+	_ = reflector.SetRequest(&opCreate, new(createRepoSecretRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Secret), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/secrets", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("secret")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoSecrets"})
+	opList.WithParameters(queryParameterQuerySecret, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.Secret{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secrets", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("secret")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoSecret"})
+	_ = reflector.SetRequest(&opFind, new(repoSecretRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Secret), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secrets/{secret_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("secret")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoSecret"})
+	_ = reflector.SetRequest(&opUpdate, new(updateRepoSecretRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Secret), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/secrets/{secret_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("secret")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoSecret"})
+	_ = reflector.SetRequest(&opDelete, new(repoSecretRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/secrets/{secret_identifier}", opDelete)
 }
@@ -103,6 +103,20 @@ var queryParameterRecursive = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterArchived = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamArchived,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only list archived (true) or unarchived (false) repositories."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeBoolean),
+			},
+		},
+	},
+}
+
 var queryParameterSortSpace = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -245,6 +259,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusConflict)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}", opUpdate)
 
 	opDelete := openapi3.Operation{}
@@ -312,7 +327,8 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opRepos.WithTags("space")
 	opRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listRepos"})
 	opRepos.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
-		queryParameterPage, queryParameterLimit, queryParameterRecursive)
+		queryParameterPage, queryParameterLimit, queryParameterCursor, queryParameterRecursive,
+		queryParameterArchived)
 	_ = reflector.SetRequest(&opRepos, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opRepos, []types.Repository{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusInternalServerError)
@@ -382,6 +398,20 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/members", opMembershipAdd)
 
+	opMembershipFind := openapi3.Operation{}
+	opMembershipFind.WithTags("space")
+	opMembershipFind.WithMapOfAnything(map[string]interface{}{"operationId": "membershipFind"})
+	_ = reflector.SetRequest(&opMembershipFind, struct {
+		spaceRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMembershipFind, &types.MembershipUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members/{user_uid}", opMembershipFind)
+
 	opMembershipDelete := openapi3.Operation{}
 	opMembershipDelete.WithTags("space")
 	opMembershipDelete.WithMapOfAnything(map[string]interface{}{"operationId": "membershipDelete"})
@@ -411,6 +441,22 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/members/{user_uid}", opMembershipUpdate)
 
+	opMembershipGrant := openapi3.Operation{}
+	opMembershipGrant.WithTags("space")
+	opMembershipGrant.WithMapOfAnything(map[string]interface{}{"operationId": "membershipGrant"})
+	_ = reflector.SetRequest(&opMembershipGrant, &struct {
+		spaceRequest
+		UserUID string `path:"user_uid"`
+		space.MembershipGrantInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipGrant, &types.MembershipUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipGrant, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipGrant, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipGrant, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipGrant, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipGrant, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/members/{user_uid}/grant", opMembershipGrant)
+
 	opMembershipList := openapi3.Operation{}
 	opMembershipList.WithTags("space")
 	opMembershipList.WithMapOfAnything(map[string]interface{}{"operationId": "membershipList"})
@@ -427,4 +473,138 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opInvitationCreate := openapi3.Operation{}
+	opInvitationCreate.WithTags("space")
+	opInvitationCreate.WithMapOfAnything(map[string]interface{}{"operationId": "invitationCreate"})
+	_ = reflector.SetRequest(&opInvitationCreate, struct {
+		spaceRequest
+		space.InvitationCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, &types.InvitationCreateOutput{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInvitationCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/invitations", opInvitationCreate)
+
+	opInvitationList := openapi3.Operation{}
+	opInvitationList.WithTags("space")
+	opInvitationList.WithMapOfAnything(map[string]interface{}{"operationId": "invitationList"})
+	opInvitationList.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opInvitationList, &struct {
+		spaceRequest
+		Query   string `query:"query" description:"The substring which is used to filter the invitations by email."`
+		Pending bool   `query:"pending" description:"List only invitations that weren't accepted yet."`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opInvitationList, []types.Invitation{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInvitationList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInvitationList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInvitationList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInvitationList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/invitations", opInvitationList)
+
+	opInvitationDelete := openapi3.Operation{}
+	opInvitationDelete.WithTags("space")
+	opInvitationDelete.WithMapOfAnything(map[string]interface{}{"operationId": "invitationDelete"})
+	_ = reflector.SetRequest(&opInvitationDelete, struct {
+		spaceRequest
+		InvitationID int64 `path:"invitation_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opInvitationDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opInvitationDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInvitationDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInvitationDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInvitationDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/invitations/{invitation_id}", opInvitationDelete)
+
+	opInvitationResend := openapi3.Operation{}
+	opInvitationResend.WithTags("space")
+	opInvitationResend.WithMapOfAnything(map[string]interface{}{"operationId": "invitationResend"})
+	_ = reflector.SetRequest(&opInvitationResend, struct {
+		spaceRequest
+		InvitationID int64 `path:"invitation_id"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opInvitationResend, &types.InvitationCreateOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInvitationResend, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opInvitationResend, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInvitationResend, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInvitationResend, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInvitationResend, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/invitations/{invitation_id}/resend", opInvitationResend)
+
+	opExternalHookCreate := openapi3.Operation{}
+	opExternalHookCreate.WithTags("space")
+	opExternalHookCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createExternalHook"})
+	_ = reflector.SetRequest(&opExternalHookCreate, &struct {
+		spaceRequest
+		space.ExternalHookCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opExternalHookCreate, &types.ExternalHook{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opExternalHookCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExternalHookCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExternalHookCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExternalHookCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/external-hooks", opExternalHookCreate)
+
+	opExternalHookList := openapi3.Operation{}
+	opExternalHookList.WithTags("space")
+	opExternalHookList.WithMapOfAnything(map[string]interface{}{"operationId": "listExternalHooks"})
+	_ = reflector.SetRequest(&opExternalHookList, &struct{ spaceRequest }{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opExternalHookList, []types.ExternalHook{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opExternalHookList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExternalHookList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExternalHookList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExternalHookList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/external-hooks", opExternalHookList)
+
+	opExternalHookFind := openapi3.Operation{}
+	opExternalHookFind.WithTags("space")
+	opExternalHookFind.WithMapOfAnything(map[string]interface{}{"operationId": "findExternalHook"})
+	_ = reflector.SetRequest(&opExternalHookFind, &struct {
+		spaceRequest
+		Identifier string `path:"external_hook_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opExternalHookFind, &types.ExternalHook{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opExternalHookFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExternalHookFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExternalHookFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExternalHookFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/external-hooks/{external_hook_identifier}", opExternalHookFind)
+
+	opExternalHookUpdate := openapi3.Operation{}
+	opExternalHookUpdate.WithTags("space")
+	opExternalHookUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateExternalHook"})
+	_ = reflector.SetRequest(&opExternalHookUpdate, &struct {
+		spaceRequest
+		Identifier string `path:"external_hook_identifier"`
+		space.ExternalHookUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opExternalHookUpdate, &types.ExternalHook{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opExternalHookUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExternalHookUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExternalHookUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExternalHookUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/external-hooks/{external_hook_identifier}", opExternalHookUpdate)
+
+	opExternalHookDelete := openapi3.Operation{}
+	opExternalHookDelete.WithTags("space")
+	opExternalHookDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExternalHook"})
+	_ = reflector.SetRequest(&opExternalHookDelete, &struct {
+		spaceRequest
+		Identifier string `path:"external_hook_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opExternalHookDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opExternalHookDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExternalHookDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExternalHookDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExternalHookDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/external-hooks/{external_hook_identifier}", opExternalHookDelete)
 }
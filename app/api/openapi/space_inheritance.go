@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type updateSpaceInheritanceRequest struct {
+	spaceRequest
+	space.UpdateInheritanceInput
+}
+
+func spaceInheritanceOperations(reflector *openapi3.Reflector) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("space")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceInheritance"})
+	_ = reflector.SetRequest(&opFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.SpaceInheritance), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/inheritance", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("space")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceInheritance"})
+	_ = reflector.SetRequest(&opUpdate, new(updateSpaceInheritanceRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.SpaceInheritance), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/inheritance", opUpdate)
+
+	opEffective := openapi3.Operation{}
+	opEffective.WithTags("space")
+	opEffective.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceEffectiveSettings"})
+	_ = reflector.SetRequest(&opEffective, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opEffective, new([]types.EffectiveSpaceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opEffective, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opEffective, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opEffective, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opEffective, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/settings/effective", opEffective)
+}
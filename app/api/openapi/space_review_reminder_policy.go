@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type updateReviewReminderPolicyRequest struct {
+	spaceRequest
+	types.ReviewReminderPolicy
+}
+
+func spaceReviewReminderPolicyOperations(reflector *openapi3.Reflector) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("space")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceReviewReminderPolicy"})
+	_ = reflector.SetRequest(&opFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.ReviewReminderPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/review-reminder-policy", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("space")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceReviewReminderPolicy"})
+	_ = reflector.SetRequest(&opUpdate, new(updateReviewReminderPolicyRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.ReviewReminderPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/review-reminder-policy", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("space")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceReviewReminderPolicy"})
+	_ = reflector.SetRequest(&opDelete, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/review-reminder-policy", opDelete)
+}
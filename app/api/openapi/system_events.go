@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// adminSystemEventListRequest is the request for listing system events.
+type adminSystemEventListRequest struct {
+	Type  []enum.SystemEventType `query:"type"  description:"The type of the system events."`
+	Since int64                  `query:"since" description:"Only return events created at or after this time."`
+
+	// include pagination request
+	paginationRequest
+}
+
+// helper function that constructs the openapi specification
+// for the admin system event resources.
+func buildAdminSystemEvents(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListSystemEvents"})
+	_ = reflector.SetRequest(&opList, new(adminSystemEventListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.SystemEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/events", opList)
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterUsageWindow = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamWindow,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The time window of the usage report."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(string(enum.UsageWindowMonth)),
+				Enum:    enum.UsageWindow("").Enum(),
+			},
+		},
+	},
+}
+
+// helper function that constructs the openapi specification
+// for the space and instance usage resources.
+func usageOperations(reflector *openapi3.Reflector) {
+	opSpace := openapi3.Operation{}
+	opSpace.WithTags("space")
+	opSpace.WithMapOfAnything(map[string]interface{}{"operationId": "spaceUsage"})
+	opSpace.WithParameters(queryParameterUsageWindow)
+	_ = reflector.SetRequest(&opSpace, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpace, new(types.UsageReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usage", opSpace)
+
+	opInstance := openapi3.Operation{}
+	opInstance.WithTags("admin")
+	opInstance.WithMapOfAnything(map[string]interface{}{"operationId": "adminUsage"})
+	opInstance.WithParameters(queryParameterUsageWindow)
+	_ = reflector.SetJSONResponse(&opInstance, new(types.UsageReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInstance, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opInstance, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInstance, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/usage", opInstance)
+}
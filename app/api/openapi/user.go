@@ -61,6 +61,38 @@ var queryParameterSortMembershipSpaces = openapi3.ParameterOrRef{
 	},
 }
 
+type userActivityRequest struct {
+	UserUID string   `path:"user_uid"`
+	Types   []string `query:"type" enum:"push,pullreq_opened,pullreq_merged,pullreq_reviewed,pullreq_commented"`
+	Since   int64    `query:"since" description:"Only activities at or after this time (unix milliseconds)."`
+	Until   int64    `query:"until" description:"Only activities before this time (unix milliseconds)."`
+
+	// include pagination request
+	paginationRequest
+}
+
+type userContributionsRequest struct {
+	UserUID  string `path:"user_uid"`
+	TimeZone string `query:"time_zone" description:"IANA time zone of the days (defaults to the user time zone)."`
+}
+
+type updateAvatarRequest struct {
+	Content string `json:"-" format:"binary" description:"Avatar image (PNG, JPEG or GIF)"`
+}
+
+type savedReplyRequest struct {
+	ID int64 `path:"saved_reply_id"`
+}
+
+type createSavedReplyRequest struct {
+	user.SavedReplyCreateInput
+}
+
+type updateSavedReplyRequest struct {
+	savedReplyRequest
+	user.SavedReplyUpdateInput
+}
+
 // helper function that constructs the openapi specification
 // for user account resources.
 func buildUser(reflector *openapi3.Reflector) {
@@ -99,4 +131,130 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opFindNotificationSettings := openapi3.Operation{}
+	opFindNotificationSettings.WithTags("user")
+	opFindNotificationSettings.WithMapOfAnything(map[string]interface{}{"operationId": "getNotificationSettings"})
+	_ = reflector.SetRequest(&opFindNotificationSettings, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindNotificationSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindNotificationSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-settings", opFindNotificationSettings)
+
+	opUpdateNotificationSettings := openapi3.Operation{}
+	opUpdateNotificationSettings.WithTags("user")
+	opUpdateNotificationSettings.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateNotificationSettings"})
+	_ = reflector.SetRequest(&opUpdateNotificationSettings, new(user.NotificationSettingsUpdateInput),
+		http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notification-settings", opUpdateNotificationSettings)
+
+	opFindBreakGlass := openapi3.Operation{}
+	opFindBreakGlass.WithTags("user")
+	opFindBreakGlass.WithMapOfAnything(map[string]interface{}{"operationId": "getBreakGlass"})
+	_ = reflector.SetRequest(&opFindBreakGlass, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindBreakGlass, new(types.BreakGlass), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindBreakGlass, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindBreakGlass, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindBreakGlass, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindBreakGlass, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/break-glass", opFindBreakGlass)
+
+	opActivateBreakGlass := openapi3.Operation{}
+	opActivateBreakGlass.WithTags("user")
+	opActivateBreakGlass.WithMapOfAnything(map[string]interface{}{"operationId": "activateBreakGlass"})
+	_ = reflector.SetRequest(&opActivateBreakGlass, new(user.BreakGlassInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opActivateBreakGlass, new(types.BreakGlass), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opActivateBreakGlass, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opActivateBreakGlass, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opActivateBreakGlass, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/break-glass", opActivateBreakGlass)
+
+	opDeactivateBreakGlass := openapi3.Operation{}
+	opDeactivateBreakGlass.WithTags("user")
+	opDeactivateBreakGlass.WithMapOfAnything(map[string]interface{}{"operationId": "deactivateBreakGlass"})
+	_ = reflector.SetRequest(&opDeactivateBreakGlass, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeactivateBreakGlass, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeactivateBreakGlass, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeactivateBreakGlass, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeactivateBreakGlass, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/break-glass", opDeactivateBreakGlass)
+
+	opUpdateAvatar := openapi3.Operation{}
+	opUpdateAvatar.WithTags("user")
+	opUpdateAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserAvatar"})
+	_ = reflector.SetRequest(&opUpdateAvatar, new(updateAvatarRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/avatar", opUpdateAvatar)
+
+	opDeleteAvatar := openapi3.Operation{}
+	opDeleteAvatar.WithTags("user")
+	opDeleteAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserAvatar"})
+	_ = reflector.SetRequest(&opDeleteAvatar, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteAvatar, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDeleteAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/avatar", opDeleteAvatar)
+
+	opActivity := openapi3.Operation{}
+	opActivity.WithTags("user")
+	opActivity.WithMapOfAnything(map[string]interface{}{"operationId": "listUserActivity"})
+	_ = reflector.SetRequest(&opActivity, new(userActivityRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opActivity, new([]types.UserActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opActivity, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opActivity, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opActivity, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/users/{user_uid}/activity", opActivity)
+
+	opContributions := openapi3.Operation{}
+	opContributions.WithTags("user")
+	opContributions.WithMapOfAnything(map[string]interface{}{"operationId": "getUserContributions"})
+	_ = reflector.SetRequest(&opContributions, new(userContributionsRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opContributions, new(types.UserContributions), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opContributions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opContributions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opContributions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/users/{user_uid}/contributions", opContributions)
+
+	opListSavedReplies := openapi3.Operation{}
+	opListSavedReplies.WithTags("user")
+	opListSavedReplies.WithMapOfAnything(map[string]interface{}{"operationId": "listSavedReplies"})
+	_ = reflector.SetRequest(&opListSavedReplies, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSavedReplies, new([]types.SavedReply), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSavedReplies, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/saved-replies", opListSavedReplies)
+
+	opCreateSavedReply := openapi3.Operation{}
+	opCreateSavedReply.WithTags("user")
+	opCreateSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "createSavedReply"})
+	_ = reflector.SetRequest(&opCreateSavedReply, new(createSavedReplyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(types.SavedReply), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/saved-replies", opCreateSavedReply)
+
+	opUpdateSavedReply := openapi3.Operation{}
+	opUpdateSavedReply.WithTags("user")
+	opUpdateSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "updateSavedReply"})
+	_ = reflector.SetRequest(&opUpdateSavedReply, new(updateSavedReplyRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(types.SavedReply), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/saved-replies/{saved_reply_id}", opUpdateSavedReply)
+
+	opDeleteSavedReply := openapi3.Operation{}
+	opDeleteSavedReply.WithTags("user")
+	opDeleteSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSavedReply"})
+	_ = reflector.SetRequest(&opDeleteSavedReply, new(savedReplyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSavedReply, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSavedReply, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/saved-replies/{saved_reply_id}", opDeleteSavedReply)
 }
@@ -46,6 +46,8 @@ type (
 		Sort  string `query:"sort"      enum:"id,email,created,updated"`
 		Order string `query:"order"     enum:"asc,desc"`
 
+		Deleted bool `query:"deleted" description:"List deleted users that can still be restored."`
+
 		// include pagination request
 		paginationRequest
 	}
@@ -55,6 +57,31 @@ type (
 		adminUsersRequest
 		user.UpdateAdminInput
 	}
+
+	// adminUsersCreateTokenRequest is the request for creating a PAT for a user.
+	adminUsersCreateTokenRequest struct {
+		adminUsersRequest
+		user.CreateTokenInput
+	}
+
+	// adminInvitationCreateRequest is the request for creating a user invitation.
+	adminInvitationCreateRequest struct {
+		user.CreateInvitationInput
+	}
+
+	// adminInvitationListRequest is the request for listing user invitations.
+	adminInvitationListRequest struct {
+		Query   string `query:"query" description:"The substring which is used to filter the invitations by email."`
+		Pending bool   `query:"pending" description:"List only invitations that weren't accepted yet."`
+
+		// include pagination request
+		paginationRequest
+	}
+
+	// adminInvitationRequest is the request for invitation specific operations.
+	adminInvitationRequest struct {
+		ID int64 `path:"invitation_id"`
+	}
 )
 
 // helper function that constructs the openapi specification
@@ -117,4 +144,71 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opRestore := openapi3.Operation{}
+	opRestore.WithTags("admin")
+	opRestore.WithMapOfAnything(map[string]interface{}{"operationId": "adminRestoreUser"})
+	_ = reflector.SetRequest(&opRestore, new(adminUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRestore, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/restore", opRestore)
+
+	opCreateToken := openapi3.Operation{}
+	opCreateToken.WithTags("admin")
+	opCreateToken.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateUserToken"})
+	_ = reflector.SetRequest(&opCreateToken, new(adminUsersCreateTokenRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateToken, new(types.TokenResponse), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateToken, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateToken, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/tokens", opCreateToken)
+
+	opApprove := openapi3.Operation{}
+	opApprove.WithTags("admin")
+	opApprove.WithMapOfAnything(map[string]interface{}{"operationId": "adminApproveUser"})
+	_ = reflector.SetRequest(&opApprove, new(adminUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opApprove, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/approve", opApprove)
+
+	opCreateInvitation := openapi3.Operation{}
+	opCreateInvitation.WithTags("admin")
+	opCreateInvitation.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateInvitation"})
+	_ = reflector.SetRequest(&opCreateInvitation, new(adminInvitationCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateInvitation, new(types.InvitationCreateOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateInvitation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateInvitation, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateInvitation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/invitations", opCreateInvitation)
+
+	opListInvitations := openapi3.Operation{}
+	opListInvitations.WithTags("admin")
+	opListInvitations.WithMapOfAnything(map[string]interface{}{"operationId": "adminListInvitations"})
+	_ = reflector.SetRequest(&opListInvitations, new(adminInvitationListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListInvitations, new([]types.Invitation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListInvitations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/invitations", opListInvitations)
+
+	opDeleteInvitation := openapi3.Operation{}
+	opDeleteInvitation.WithTags("admin")
+	opDeleteInvitation.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteInvitation"})
+	_ = reflector.SetRequest(&opDeleteInvitation, new(adminInvitationRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteInvitation, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteInvitation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteInvitation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/invitations/{invitation_id}", opDeleteInvitation)
+
+	opResendInvitation := openapi3.Operation{}
+	opResendInvitation.WithTags("admin")
+	opResendInvitation.WithMapOfAnything(map[string]interface{}{"operationId": "adminResendInvitation"})
+	_ = reflector.SetRequest(&opResendInvitation, new(adminInvitationRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opResendInvitation, new(types.InvitationCreateOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opResendInvitation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opResendInvitation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opResendInvitation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/invitations/{invitation_id}/resend", opResendInvitation)
 }
@@ -35,6 +35,7 @@ type webhookType struct {
 
 type createWebhookRequest struct {
 	repoRequest
+	idempotencyKeyRequest
 	webhook.CreateInput
 }
 
@@ -157,6 +158,7 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusConflict)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/webhooks/{webhook_identifier}", updateWebhook)
 
 	deleteWebhook := openapi3.Operation{}
@@ -173,7 +175,7 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	listWebhookExecutions := openapi3.Operation{}
 	listWebhookExecutions.WithTags("webhook")
 	listWebhookExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "listWebhookExecutions"})
-	listWebhookExecutions.WithParameters(queryParameterPage, queryParameterLimit)
+	listWebhookExecutions.WithParameters(queryParameterPage, queryParameterLimit, queryParameterCursor)
 	_ = reflector.SetRequest(&listWebhookExecutions, new(listWebhookExecutionsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listWebhookExecutions, new([]types.WebhookExecution), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
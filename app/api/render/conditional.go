@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cacheControlRevalidate allows clients to store the response, but they have to revalidate it on every use.
+// The responses depend on the permissions of the caller and must not be stored by shared caches.
+const cacheControlRevalidate = "private, no-cache"
+
+// NotModified sets the ETag (and Last-Modified if not zero) of the response and writes
+// a 304 Not Modified response in case the client already has the latest version.
+// Returns true if the response was written, in which case the caller must not write the response.
+func NotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	h := w.Header()
+	h.Set("Cache-Control", cacheControlRevalidate)
+	h.Del("Expires")
+	h.Del("Pragma")
+	h.Del("X-Accel-Expires")
+
+	if etag != "" {
+		h.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		h.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if !isNotModified(r, etag, lastModified) {
+		return false
+	}
+
+	// the content headers don't apply to a 304 response.
+	h.Del("Content-Length")
+	h.Del("Content-Type")
+	w.WriteHeader(http.StatusNotModified)
+
+	return true
+}
+
+// isNotModified returns true if the version of the client matches the provided ETag or modification time.
+// As per RFC 9110 If-Modified-Since is ignored in case If-None-Match is provided.
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && matchesETag(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+
+	// the header only has a precision of seconds.
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+// matchesETag returns true if the If-None-Match header matches the ETag using weak comparison.
+func matchesETag(ifNoneMatch string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2023, 10, 1, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "no conditional headers", method: http.MethodGet, want: false},
+		{name: "matching etag", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"abc"`}, want: true},
+		{name: "weak etag", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"x", W/"abc"`}, want: true},
+		{name: "wildcard", method: http.MethodGet, headers: map[string]string{"If-None-Match": "*"}, want: true},
+		{name: "other etag", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"def"`}, want: false},
+		{name: "post", method: http.MethodPost, headers: map[string]string{"If-None-Match": `"abc"`}, want: false},
+		{
+			name:    "not modified since",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			want:    true,
+		},
+		{
+			name:    "modified since",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)},
+			want:    false,
+		},
+		{
+			name:   "etag takes precedence",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"If-None-Match":     `"def"`,
+				"If-Modified-Since": modified.Format(http.TimeFormat),
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, "/", nil)
+			for k, v := range test.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			if got := NotModified(w, r, `"abc"`, modified); got != test.want {
+				t.Fatalf("want %t, got %t", test.want, got)
+			}
+			if got := w.Header().Get("ETag"); got != `"abc"` {
+				t.Errorf("want etag header to be set, got %q", got)
+			}
+			if test.want && w.Code != http.StatusNotModified {
+				t.Errorf("want status %d, got %d", http.StatusNotModified, w.Code)
+			}
+		})
+	}
+}
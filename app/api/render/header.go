@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // format string for the link header value.
@@ -83,6 +84,16 @@ func PaginationNoTotal(r *http.Request, w http.ResponseWriter, page int, size in
 	}
 }
 
+// PaginationCursor writes the keyset pagination headers to the http.Response.
+// An empty next cursor indicates that there are no more pages.
+// Requests paging with a cursor also get the next cursor in the response body, see JSONCursorPage.
+func PaginationCursor(_ *http.Request, w http.ResponseWriter, size int, next string) {
+	w.Header().Set("x-per-page", strconv.Itoa(size))
+	if next != "" {
+		w.Header().Set("x-next-cursor", next)
+	}
+}
+
 // PaginationLimit writes the x-total header.
 func PaginationLimit(_ *http.Request, w http.ResponseWriter, total int) {
 	w.Header().Set("x-total", strconv.Itoa(total))
@@ -108,3 +119,18 @@ func NoCache(w http.ResponseWriter) {
 	w.Header().Set("Pragma", "no-cache")
 	w.Header().Set("Cache-Control", "no-cache, max-age=0, must-revalidate")
 }
+
+// Cache writes the required headers to allow caching of the response for the provided duration.
+// Public responses may also be cached by shared caches like proxies, private ones only by the client.
+func Cache(w http.ResponseWriter, public bool, maxAge time.Duration) {
+	visibility := "private"
+	if public {
+		visibility = "public"
+	}
+
+	// remove no-cache headers set by middlewares.
+	w.Header().Del("Expires")
+	w.Header().Del("Pragma")
+	w.Header().Del("X-Accel-Expires")
+	w.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", visibility, int(maxAge.Seconds())))
+}
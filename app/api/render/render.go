@@ -50,6 +50,22 @@ func JSON(w http.ResponseWriter, code int, v interface{}) {
 	writeJSON(w, v)
 }
 
+// CursorPage is the response body of list endpoints requested with a keyset pagination cursor.
+type CursorPage[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor is the cursor of the next page, it is empty if there are no more pages.
+	NextCursor string `json:"next_cursor"`
+}
+
+// JSONCursorPage writes the list and the cursor of the next page as a CursorPage to the response.
+func JSONCursorPage[T any](w http.ResponseWriter, code int, items []T, next string) {
+	if items == nil {
+		items = []T{}
+	}
+
+	JSON(w, code, CursorPage[T]{Items: items, NextCursor: next})
+}
+
 // Reader reads the content from the provided reader and writes it as is to the response body.
 // NOTE: If no content-type header is added beforehand, the content-type will be deduced
 // automatically by `http.DetectContentType` (https://pkg.go.dev/net/http#DetectContentType).
@@ -280,3 +280,19 @@ func TestJSONArrayDynamic(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONCursorPage(t *testing.T) {
+	w := httptest.NewRecorder()
+	JSONCursorPage[int](w, http.StatusOK, nil, "")
+
+	if got, want := w.Body.String(), "{\"items\":[],\"next_cursor\":\"\"}\n"; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+
+	w = httptest.NewRecorder()
+	JSONCursorPage(w, http.StatusOK, []int{1, 2}, "abc")
+
+	if got, want := w.Body.String(), "{\"items\":[1,2],\"next_cursor\":\"abc\"}\n"; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+}
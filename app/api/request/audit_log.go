@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamRequestID = "request_id"
+)
+
+// ParseAuditLogFilter extracts the audit log query parameters from the url.
+func ParseAuditLogFilter(r *http.Request) (*types.AuditLogFilter, error) {
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	principalID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamPrincipalID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AuditLogFilter{
+		Page:        ParsePage(r),
+		Size:        ParseLimit(r),
+		RequestID:   r.URL.Query().Get(QueryParamRequestID),
+		PrincipalID: principalID,
+		Since:       since,
+	}, nil
+}
@@ -21,10 +21,16 @@ import (
 )
 
 // ParseCheckListOptions extracts the status check list API options from the url.
-func ParseCheckListOptions(r *http.Request) types.CheckListOptions {
+func ParseCheckListOptions(r *http.Request) (types.CheckListOptions, error) {
+	cursorID, err := ParseCursor(r)
+	if err != nil {
+		return types.CheckListOptions{}, err
+	}
+
 	return types.CheckListOptions{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
-	}
+		CursorID:        cursorID,
+	}, nil
 }
 
 // ParseCheckRecentOptions extracts the list recent status checks API options from the url.
@@ -17,6 +17,7 @@ package request
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -46,6 +47,9 @@ const (
 	PerPageDefault  = 30
 	PerPageMax      = 100
 
+	QueryParamFields = "fields"
+	QueryParamExpand = "expand"
+
 	// TODO: have shared constants across all services?
 	HeaderRequestID       = "X-Request-Id"
 	HeaderUserAgent       = "User-Agent"
@@ -118,6 +122,32 @@ func ParseListQueryFilterFromRequest(r *http.Request) types.ListQueryFilter {
 	}
 }
 
+// ParseFields extracts the fields that should be included in the response from the url.
+// Fields can be provided as comma separated list and / or by repeating the parameter.
+func ParseFields(r *http.Request) []string {
+	return parseCommaSeparatedList(r, QueryParamFields)
+}
+
+// ParseExpand extracts the relations that should be expanded in the response from the url.
+func ParseExpand(r *http.Request) []string {
+	return parseCommaSeparatedList(r, QueryParamExpand)
+}
+
+func parseCommaSeparatedList(r *http.Request, paramName string) []string {
+	values, _ := QueryParamList(r, paramName)
+
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result
+}
+
 // GetContentEncodingFromHeadersOrDefault returns the content encoding from the request headers.
 func GetContentEncodingFromHeadersOrDefault(r *http.Request, dflt string) string {
 	return GetHeaderOrDefault(r, HeaderContentEncoding, dflt)
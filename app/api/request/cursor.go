@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"encoding/base64"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	QueryParamCursor = "cursor"
+
+	cursorPrefix       = "id:"
+	commitCursorPrefix = "sha:"
+)
+
+var regexpCommitSHA = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// ParseCursor extracts the keyset pagination cursor from the url
+// and returns the ID of the last item of the previous page. Returns 0 if no cursor is provided.
+func ParseCursor(r *http.Request) (int64, error) {
+	s := r.URL.Query().Get(QueryParamCursor)
+	if s == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, usererror.BadRequest("Invalid pagination cursor.")
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(string(raw), cursorPrefix), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usererror.BadRequest("Invalid pagination cursor.")
+	}
+
+	return id, nil
+}
+
+// CursorRequested returns true if the request pages with keyset pagination.
+// An empty cursor requests the first page.
+func CursorRequested(r *http.Request) bool {
+	return r.URL.Query().Has(QueryParamCursor)
+}
+
+// EncodeCursor returns the opaque keyset pagination cursor pointing to the item with the provided ID.
+func EncodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatInt(id, 10)))
+}
+
+// NextCursor returns the keyset pagination cursor of the page following the provided one,
+// or an empty string if the provided page is the last one.
+func NextCursor[T any](list []T, size int, getID func(T) int64) string {
+	if len(list) == 0 || len(list) < size {
+		return ""
+	}
+
+	return EncodeCursor(getID(list[len(list)-1]))
+}
+
+// ParseCommitCursor extracts the keyset pagination cursor of commit lists from the url
+// and returns the SHA of the last commit of the previous page. Returns an empty string if no cursor is provided.
+func ParseCommitCursor(r *http.Request) (string, error) {
+	s := r.URL.Query().Get(QueryParamCursor)
+	if s == "" {
+		return "", nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || !strings.HasPrefix(string(raw), commitCursorPrefix) {
+		return "", usererror.BadRequest("Invalid pagination cursor.")
+	}
+
+	sha := strings.TrimPrefix(string(raw), commitCursorPrefix)
+	if !regexpCommitSHA.MatchString(sha) {
+		return "", usererror.BadRequest("Invalid pagination cursor.")
+	}
+
+	return sha, nil
+}
+
+// EncodeCommitCursor returns the opaque keyset pagination cursor pointing to the commit with the provided SHA.
+func EncodeCommitCursor(sha string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(commitCursorPrefix + sha))
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
+	gitenum "github.com/harness/gitness/git/enum"
 	gittypes "github.com/harness/gitness/git/types"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -33,13 +34,24 @@ const (
 	QueryParamLineFrom      = "line_from"
 	QueryParamLineTo        = "line_to"
 	QueryParamPath          = "path"
+	QueryParamSHA           = "sha"
 	QueryParamSince         = "since"
 	QueryParamUntil         = "until"
 	QueryParamCommitter     = "committer"
+	QueryParamAuthor        = "author"
+	QueryParamFollow        = "follow"
 	QueryParamIncludeStats  = "include_stats"
 	QueryParamInternal      = "internal"
 	QueryParamService       = "service"
 	HeaderParamGitProtocol  = "Git-Protocol"
+
+	QueryParamIncludeDivergence = "include_divergence"
+	QueryParamStaleOlderThan    = "stale_older_than"
+
+	QueryParamIgnoreWhitespace = "ignore_whitespace"
+	QueryParamIgnoreBlankLines = "ignore_blank_lines"
+	QueryParamDiffAlgorithm    = "diff_algorithm"
+	QueryParamContextLines     = "context_lines"
 )
 
 func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
@@ -62,14 +74,26 @@ func ParseSortBranch(r *http.Request) enum.BranchSortOption {
 }
 
 // ParseBranchFilter extracts the branch filter from the url.
-func ParseBranchFilter(r *http.Request) *types.BranchFilter {
-	return &types.BranchFilter{
-		Query: ParseQuery(r),
-		Sort:  ParseSortBranch(r),
-		Order: ParseOrder(r),
-		Page:  ParsePage(r),
-		Size:  ParseLimit(r),
+func ParseBranchFilter(r *http.Request) (*types.BranchFilter, error) {
+	includeDivergence, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeDivergence, false)
+	if err != nil {
+		return nil, err
 	}
+	// stale_older_than is optional, skipped if set to 0
+	staleOlderThan, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamStaleOlderThan, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BranchFilter{
+		Query:             ParseQuery(r),
+		Sort:              ParseSortBranch(r),
+		Order:             ParseOrder(r),
+		Page:              ParsePage(r),
+		Size:              ParseLimit(r),
+		IncludeDivergence: includeDivergence,
+		StaleOlderThan:    staleOlderThan,
+	}, nil
 }
 
 // ParseSortTag extracts the tag sort parameter from the url.
@@ -106,6 +130,14 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	follow, err := QueryParamAsBoolOrDefault(r, QueryParamFollow, false)
+	if err != nil {
+		return nil, err
+	}
+	cursorSHA, err := ParseCommitCursor(r)
+	if err != nil {
+		return nil, err
+	}
 
 	return &types.CommitFilter{
 		After: QueryParamOrDefault(r, QueryParamAfter, ""),
@@ -117,7 +149,10 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 		Since:        since,
 		Until:        until,
 		Committer:    QueryParamOrDefault(r, QueryParamCommitter, ""),
+		Author:       QueryParamOrDefault(r, QueryParamAuthor, ""),
+		Follow:       follow,
 		IncludeStats: includeStats,
+		CursorSHA:    cursorSHA,
 	}, nil
 }
 
@@ -167,3 +202,38 @@ func GetFileDiffFromQuery(r *http.Request) (files gittypes.FileDiffRequests) {
 	}
 	return
 }
+
+// GetDiffOptionsFromQuery returns the options of how to compute a diff from the request query.
+func GetDiffOptionsFromQuery(r *http.Request) (gittypes.DiffOptions, error) {
+	ignoreWhitespace, err := QueryParamAsBoolOrDefault(r, QueryParamIgnoreWhitespace, false)
+	if err != nil {
+		return gittypes.DiffOptions{}, err
+	}
+
+	ignoreBlankLines, err := QueryParamAsBoolOrDefault(r, QueryParamIgnoreBlankLines, false)
+	if err != nil {
+		return gittypes.DiffOptions{}, err
+	}
+
+	algorithm := gitenum.DiffAlgorithm(QueryParamOrDefault(r, QueryParamDiffAlgorithm, ""))
+	if !algorithm.IsValid() {
+		return gittypes.DiffOptions{}, usererror.BadRequestf("Unsupported diff algorithm '%s'.", algorithm)
+	}
+
+	var contextLines *int
+	if value, ok := QueryParam(r, QueryParamContextLines); ok && value != "" {
+		lines, err := strconv.Atoi(value)
+		if err != nil || lines < 0 || lines > gittypes.MaxDiffContextLines {
+			return gittypes.DiffOptions{}, usererror.BadRequestf("Parameter '%s' must be between 0 and %d.",
+				QueryParamContextLines, gittypes.MaxDiffContextLines)
+		}
+		contextLines = &lines
+	}
+
+	return gittypes.DiffOptions{
+		IgnoreWhitespace: ignoreWhitespace,
+		IgnoreBlankLines: ignoreBlankLines,
+		Algorithm:        algorithm,
+		ContextLines:     contextLines,
+	}, nil
+}
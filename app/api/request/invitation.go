@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamInvitationID    = "invitation_id"
+	PathParamInvitationToken = "invitation_token"
+
+	QueryParamPending = "pending"
+)
+
+// GetInvitationIDFromPath returns the invitation id from the request path.
+func GetInvitationIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamInvitationID)
+}
+
+// GetInvitationTokenFromPath returns the invitation token from the request path.
+func GetInvitationTokenFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamInvitationToken)
+}
+
+// ParseInvitationFilter extracts the invitation filter from the url.
+func ParseInvitationFilter(r *http.Request) (*types.InvitationFilter, error) {
+	pending, err := QueryParamAsBoolOrDefault(r, QueryParamPending, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.InvitationFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		Pending:         pending,
+	}, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/job"
+)
+
+const (
+	PathParamJobUID = "job_uid"
+)
+
+func GetJobUIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamJobUID)
+}
+
+// ParseJobFilter extracts the job query parameters from the url.
+func ParseJobFilter(r *http.Request) *job.ListFilter {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[job.State]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := job.State(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]job.State, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return &job.ListFilter{
+		Page:  ParsePage(r),
+		Size:  ParseLimit(r),
+		Type:  r.URL.Query().Get(QueryParamType),
+		State: states,
+	}
+}
@@ -23,11 +23,15 @@ import (
 
 const (
 	PathParamPrincipalUID      = "principal_uid"
+	PathParamPrincipalID       = "principal_id"
 	PathParamUserUID           = "user_uid"
 	PathParamUserID            = "user_id"
 	PathParamServiceAccountUID = "sa_uid"
 
 	QueryParamPrincipalID = "principal_id"
+	QueryParamDeleted     = "deleted"
+
+	QueryParamApprovalPending = "approval_pending"
 )
 
 // GetUserIDFromPath returns the user id from the request path.
@@ -35,6 +39,11 @@ func GetUserIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamUserID)
 }
 
+// GetPrincipalIDFromPath returns the principal id from the request path.
+func GetPrincipalIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPrincipalID)
+}
+
 func GetPrincipalUIDFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamPrincipalUID)
 }
@@ -55,13 +64,27 @@ func ParseSortUser(r *http.Request) enum.UserAttr {
 }
 
 // ParseUserFilter extracts the user filter from the url.
-func ParseUserFilter(r *http.Request) *types.UserFilter {
-	return &types.UserFilter{
-		Order: ParseOrder(r),
-		Page:  ParsePage(r),
-		Sort:  ParseSortUser(r),
-		Size:  ParseLimit(r),
+func ParseUserFilter(r *http.Request) (*types.UserFilter, error) {
+	// deleted is optional to list the deleted users instead of the active ones.
+	deleted, err := QueryParamAsBoolOrDefault(r, QueryParamDeleted, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// approval_pending is optional to list only the users waiting for the approval of an admin.
+	approvalPending, err := QueryParamAsBoolOrDefault(r, QueryParamApprovalPending, false)
+	if err != nil {
+		return nil, err
 	}
+
+	return &types.UserFilter{
+		Order:           ParseOrder(r),
+		Page:            ParsePage(r),
+		Sort:            ParseSortUser(r),
+		Size:            ParseLimit(r),
+		Deleted:         deleted,
+		ApprovalPending: approvalPending,
+	}, nil
 }
 
 // ParsePrincipalTypes extracts the principal types from the url.
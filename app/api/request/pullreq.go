@@ -70,6 +70,12 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	cursorID, err := ParseCursor(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.PullReqFilter{
 		Page:          ParsePage(r),
 		Size:          ParseLimit(r),
@@ -81,6 +87,7 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		States:        parsePullReqStates(r),
 		Sort:          ParseSortPullReq(r),
 		Order:         ParseOrder(r),
+		CursorID:      cursorID,
 	}, nil
 }
 
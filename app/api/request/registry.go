@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamRegistryIdentifier = "registry_identifier"
+	PathParamArtifactName       = "artifact_name"
+	PathParamVersionName        = "version_name"
+	PathParamPackageName        = "package_name"
+	PathParamFileName           = "file_name"
+
+	QueryParamRegistryType = "type"
+)
+
+// GetRegistryIdentifierFromPath returns the identifier of the registry from the request path.
+func GetRegistryIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamRegistryIdentifier)
+}
+
+// GetArtifactNameFromPath returns the name of the artifact from the request path.
+// Image names can contain slashes, hence the name is expected to be escaped.
+func GetArtifactNameFromPath(r *http.Request) (string, error) {
+	return unescapedPathParam(r, PathParamArtifactName)
+}
+
+// GetVersionNameFromPath returns the name of the artifact version from the request path.
+func GetVersionNameFromPath(r *http.Request) (string, error) {
+	return unescapedPathParam(r, PathParamVersionName)
+}
+
+// GetPackageNameFromPath returns the name of the generic package from the request path.
+func GetPackageNameFromPath(r *http.Request) (string, error) {
+	return unescapedPathParam(r, PathParamPackageName)
+}
+
+// GetFileNameFromPath returns the name of the generic package file from the request path.
+func GetFileNameFromPath(r *http.Request) (string, error) {
+	return unescapedPathParam(r, PathParamFileName)
+}
+
+// ParseRegistryFilter extracts the registry filter from the url.
+func ParseRegistryFilter(r *http.Request) *types.RegistryFilter {
+	return &types.RegistryFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		Type:            enum.RegistryType(r.URL.Query().Get(QueryParamRegistryType)),
+	}
+}
+
+func unescapedPathParam(r *http.Request, paramName string) (string, error) {
+	raw, err := PathParamOrError(r, paramName)
+	if err != nil {
+		return "", err
+	}
+
+	// paths are unescaped
+	return url.PathUnescape(raw)
+}
@@ -25,6 +25,8 @@ import (
 const (
 	PathParamRepoRef = "repo_ref"
 	QueryParamRepoID = "repo_id"
+
+	QueryParamArchived = "archived"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -62,6 +64,17 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		deletionTime = &value
 	}
 
+	cursorID, err := ParseCursor(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// archived is optional to only list archived (true) or unarchived (false) repos.
+	archived, err := QueryParamAsBoolOrNil(r, QueryParamArchived)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.RepoFilter{
 		Query:             ParseQuery(r),
 		Order:             ParseOrder(r),
@@ -70,5 +83,7 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		Size:              ParseLimit(r),
 		Recursive:         recursive,
 		DeletedBeforeOrAt: deletionTime,
+		CursorID:          cursorID,
+		Archived:          archived,
 	}, nil
 }
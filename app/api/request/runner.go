@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	// HeaderRunnerToken is the header external runners authenticate with.
+	HeaderRunnerToken = "X-Runner-Token"
+
+	PathParamRunnerIdentifier      = "runner_identifier"
+	PathParamRunnerTokenIdentifier = "runner_token_identifier"
+	PathParamRunnerStageID         = "stage_id"
+
+	QueryParamWait = "wait"
+)
+
+// GetRunnerTokenFromHeader returns the token of the external runner from the request header.
+func GetRunnerTokenFromHeader(r *http.Request) (string, error) {
+	token := r.Header.Get(HeaderRunnerToken)
+	if token == "" {
+		return "", usererror.ErrUnauthorized
+	}
+
+	return token, nil
+}
+
+// GetRunnerIdentifierFromPath returns the identifier of the runner from the request path.
+func GetRunnerIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamRunnerIdentifier)
+}
+
+// GetRunnerTokenIdentifierFromPath returns the identifier of the runner registration token from the request path.
+func GetRunnerTokenIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamRunnerTokenIdentifier)
+}
+
+// GetRunnerStageIDFromPath returns the id of the stage from the request path.
+func GetRunnerStageIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamRunnerStageID)
+}
+
+// GetWaitFromQuery returns the time (in seconds) a runner is willing to wait for a stage, 0 if not provided.
+func GetWaitFromQuery(r *http.Request) (time.Duration, error) {
+	wait, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamWait, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(wait) * time.Second, nil
+}
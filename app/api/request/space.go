@@ -24,6 +24,8 @@ import (
 
 const (
 	PathParamSpaceRef = "space_ref"
+
+	QueryParamSpacePath = "space_path"
 )
 
 func GetSpaceRefFromPath(r *http.Request) (string, error) {
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	HeaderParamLastEventID = "Last-Event-ID"
+	QueryParamLastEventID  = "last_event_id"
+)
+
+// GetLastEventID returns the ID of the last event received by a reconnecting event stream client.
+// Browsers send it as header, the query parameter allows resuming with a newly created event source.
+// If no ID is provided, 0 is returned.
+func GetLastEventID(r *http.Request) (int64, error) {
+	value, ok := GetHeader(r, HeaderParamLastEventID)
+	if !ok {
+		value, ok = QueryParam(r, QueryParamLastEventID)
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, usererror.BadRequest("Last event ID must be a non-negative integer.")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ParseSystemEventFilter extracts the system event query parameters from the url.
+func ParseSystemEventFilter(r *http.Request) (*types.SystemEventFilter, error) {
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	strTypes, _ := QueryParamList(r, QueryParamType)
+	m := make(map[enum.SystemEventType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.SystemEventType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	eventTypes := make([]enum.SystemEventType, 0, len(m))
+	for t := range m {
+		eventTypes = append(eventTypes, t)
+	}
+
+	return &types.SystemEventFilter{
+		Page:  ParsePage(r),
+		Size:  ParseLimit(r),
+		Types: eventTypes,
+		Since: since,
+	}, nil
+}
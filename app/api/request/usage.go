@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamWindow = "window"
+)
+
+// ParseUsageFilter extracts the usage report filter from the url.
+func ParseUsageFilter(r *http.Request) (*types.UsageFilter, error) {
+	window, ok := enum.UsageWindow(r.URL.Query().Get(QueryParamWindow)).Sanitize()
+	if !ok {
+		return nil, usererror.BadRequestf("Invalid usage window. Valid values are %v.", enum.UsageWindow("").Enum())
+	}
+
+	return &types.UsageFilter{
+		Window: window,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamTimeZone = "time_zone"
+)
+
+// ParseUserActivityFilter extracts the user activity query parameters from the url.
+func ParseUserActivityFilter(r *http.Request) (*types.UserActivityFilter, error) {
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	strTypes, _ := QueryParamList(r, QueryParamType)
+	m := make(map[enum.UserActivityType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.UserActivityType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	activityTypes := make([]enum.UserActivityType, 0, len(m))
+	for t := range m {
+		activityTypes = append(activityTypes, t)
+	}
+
+	return &types.UserActivityFilter{
+		Page:  ParsePage(r),
+		Size:  ParseLimit(r),
+		Types: activityTypes,
+		Since: since,
+		Until: until,
+	}, nil
+}
+
+// ParseTimeZone extracts the time zone from the url.
+func ParseTimeZone(r *http.Request) string {
+	return r.URL.Query().Get(QueryParamTimeZone)
+}
@@ -181,6 +181,22 @@ func PathParamAsPositiveInt64(r *http.Request, paramName string) (int64, error)
 	return valueInt, nil
 }
 
+// QueryParamAsBoolOrNil tries to retrieve the parameter from the query and parse it to bool.
+// Nil is returned in case the parameter isn't provided.
+func QueryParamAsBoolOrNil(r *http.Request, paramName string) (*bool, error) {
+	rawValue, ok := QueryParam(r, paramName)
+	if !ok || len(rawValue) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	boolValue, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		return nil, usererror.BadRequestf("Parameter '%s' must be a boolean.", paramName)
+	}
+
+	return &boolValue, nil
+}
+
 // QueryParamAsBoolOrDefault tries to retrieve the parameter from the query and parse it to bool.
 func QueryParamAsBoolOrDefault(r *http.Request, paramName string, deflt bool) (bool, error) {
 	rawValue, ok := QueryParam(r, paramName)
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ParseVulnerabilityAlertFilter extracts the vulnerability alert query parameters from the url.
+func ParseVulnerabilityAlertFilter(r *http.Request) *types.VulnerabilityAlertFilter {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.VulnerabilityAlertState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.VulnerabilityAlertState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.VulnerabilityAlertState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return &types.VulnerabilityAlertFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		States:     states,
+	}
+}
@@ -46,11 +46,17 @@ func ParseWebhookFilter(r *http.Request) *types.WebhookFilter {
 }
 
 // ParseWebhookExecutionFilter extracts the WebhookExecution query parameters for listing from the url.
-func ParseWebhookExecutionFilter(r *http.Request) *types.WebhookExecutionFilter {
-	return &types.WebhookExecutionFilter{
-		Page: ParsePage(r),
-		Size: ParseLimit(r),
+func ParseWebhookExecutionFilter(r *http.Request) (*types.WebhookExecutionFilter, error) {
+	cursorID, err := ParseCursor(r)
+	if err != nil {
+		return nil, err
 	}
+
+	return &types.WebhookExecutionFilter{
+		Page:     ParsePage(r),
+		Size:     ParseLimit(r),
+		CursorID: cursorID,
+	}, nil
 }
 
 // ParseSortWebhook extracts the webhook sort parameter from the url.
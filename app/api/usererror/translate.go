@@ -68,6 +68,8 @@ func Translate(ctx context.Context, err error) *Error {
 		return ErrNotFound
 	case errors.Is(err, store.ErrDuplicate):
 		return ErrDuplicate
+	case errors.Is(err, store.ErrVersionConflict):
+		return ErrVersionConflict
 	case errors.Is(err, store.ErrPrimaryPathCantBeDeleted):
 		return ErrPrimaryPathCantBeDeleted
 	case errors.Is(err, store.ErrPathTooLong):
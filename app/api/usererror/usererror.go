@@ -50,6 +50,10 @@ var (
 	// ErrDuplicate is returned when a resource already exits.
 	ErrDuplicate = New(http.StatusConflict, "Resource already exists")
 
+	// ErrVersionConflict is returned if the version provided for an update doesn't match the stored version.
+	ErrVersionConflict = New(http.StatusConflict,
+		"The resource has been modified in the meantime, please fetch the latest version and retry")
+
 	// ErrPrimaryPathCantBeDeleted is returned when trying to delete a primary path.
 	ErrPrimaryPathCantBeDeleted = New(http.StatusBadRequest, "The primary path of an object can't be deleted")
 
@@ -70,6 +74,9 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrRepoArchived is returned if the user tries to modify an archived repository.
+	ErrRepoArchived = New(http.StatusForbidden, "The repository is archived and can't be modified, unarchive it first")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
@@ -93,8 +100,8 @@ var (
 // Error represents a json-encoded API error.
 type Error struct {
 	Status  int            `json:"-"`
-	Message string         `json:"message"`
-	Values  map[string]any `json:"values,omitempty"`
+	Message string         `json:"message"          description:"Human readable description of the error."`
+	Values  map[string]any `json:"values,omitempty" description:"Optional details about the error."`
 }
 
 func (e *Error) Error() string {
@@ -67,6 +67,9 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get principal for token: %w", err)
 		}
+		if principal.Deleted != nil {
+			return nil, fmt.Errorf("principal %d of token is deleted", principal.ID)
+		}
 		return []byte(principal.Salt), nil
 	})
 	if err != nil {
@@ -84,7 +87,7 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 	var metadata auth.Metadata
 	switch {
 	case claims.Token != nil:
-		metadata, err = a.metadataFromTokenClaims(ctx, principal, claims.Token)
+		metadata, err = a.metadataFromTokenClaims(ctx, principal, claims.Token, claims.Membership)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get metadata from token claims: %w", err)
 		}
@@ -94,6 +97,11 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, fmt.Errorf("jwt is missing sub-claims")
 	}
 
+	// access of scoped tokens is restricted to their space, which excludes any system admin operations.
+	if tknMetadata, ok := metadata.(*auth.TokenMetadata); ok && tknMetadata.Scope != nil {
+		principal.Admin = false
+	}
+
 	return &auth.Session{
 		Principal: *principal,
 		Metadata:  metadata,
@@ -104,6 +112,7 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	ctx context.Context,
 	principal *types.Principal,
 	tknClaims *jwt.SubClaimsToken,
+	mbsClaims *jwt.SubClaimsMembership,
 ) (auth.Metadata, error) {
 	// ensure tkn exists
 	tkn, err := a.tokenStore.Find(ctx, tknClaims.ID)
@@ -117,10 +126,20 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 			principal.ID, tkn.PrincipalID)
 	}
 
-	return &auth.TokenMetadata{
+	metadata := &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
-	}, nil
+	}
+
+	// scoped tokens carry the ephemeral membership they are restricted to.
+	if mbsClaims != nil {
+		metadata.Scope = &auth.MembershipMetadata{
+			SpaceID: mbsClaims.SpaceID,
+			Role:    mbsClaims.Role,
+		}
+	}
+
+	return metadata, nil
 }
 
 func (a *JWTAuthenticator) metadataFromMembershipClaims(
@@ -150,6 +169,9 @@ func extractToken(r *http.Request, cookieName string) string {
 	// strip bearer prefix if present
 	case strings.HasPrefix(headerToken, "Bearer "):
 		return headerToken[7:]
+	// strip token prefix used by GitHub clients if present
+	case strings.HasPrefix(headerToken, "token "):
+		return headerToken[6:]
 	// otherwise use value as is
 	case headerToken != "":
 		return headerToken
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/signedurl"
+	"github.com/harness/gitness/app/store"
+)
+
+var _ Authenticator = (*SignedURLAuthenticator)(nil)
+
+// SignedURLAuthenticator authenticates read requests of signed URLs as the principal that created the URL.
+type SignedURLAuthenticator struct {
+	signer         *signedurl.Signer
+	principalStore store.PrincipalStore
+}
+
+func NewSignedURLAuthenticator(
+	signer *signedurl.Signer,
+	principalStore store.PrincipalStore,
+) *SignedURLAuthenticator {
+	return &SignedURLAuthenticator{
+		signer:         signer,
+		principalStore: principalStore,
+	}
+}
+
+func (a *SignedURLAuthenticator) Authenticate(r *http.Request) (*auth.Session, error) {
+	if !r.URL.Query().Has(signedurl.QueryParamSignature) {
+		return nil, ErrNoAuthData
+	}
+
+	// signed urls only grant read access.
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return nil, fmt.Errorf("signed urls can't be used for %s requests", r.Method)
+	}
+
+	principalID, err := a.signer.Verify(r.URL.Path, r.URL.Query(), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signed url: %w", err)
+	}
+
+	principal, err := a.principalStore.Find(r.Context(), principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal of signed url: %w", err)
+	}
+	if principal.Deleted != nil {
+		return nil, fmt.Errorf("principal %d of signed url is deleted", principal.ID)
+	}
+
+	return &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}, nil
+}
+
+var _ Authenticator = (*ChainAuthenticator)(nil)
+
+// ChainAuthenticator authenticates requests with the first authenticator that finds auth data in the request.
+type ChainAuthenticator struct {
+	authenticators []Authenticator
+}
+
+func NewChainAuthenticator(authenticators ...Authenticator) *ChainAuthenticator {
+	return &ChainAuthenticator{
+		authenticators: authenticators,
+	}
+}
+
+func (a *ChainAuthenticator) Authenticate(r *http.Request) (*auth.Session, error) {
+	for _, authenticator := range a.authenticators {
+		session, err := authenticator.Authenticate(r)
+		if errors.Is(err, ErrNoAuthData) {
+			continue
+		}
+
+		return session, err
+	}
+
+	return nil, ErrNoAuthData
+}
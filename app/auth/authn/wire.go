@@ -15,6 +15,7 @@
 package authn
 
 import (
+	"github.com/harness/gitness/app/auth/signedurl"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -30,6 +31,10 @@ func ProvideAuthenticator(
 	config *types.Config,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	signer *signedurl.Signer,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewChainAuthenticator(
+		NewSignedURLAuthenticator(signer, principalStore),
+		NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName),
+	)
 }
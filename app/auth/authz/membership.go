@@ -16,11 +16,14 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/breakglass"
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -32,15 +35,18 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
+	breakGlass      *breakglass.Service
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
+	breakGlass *breakglass.Service,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
+		breakGlass:      breakGlass,
 	}
 }
 
@@ -72,8 +78,19 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// scoped tokens are restricted to their space, on top of the permissions of the principal
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.Scope != nil {
+		return a.checkWithTokenScope(ctx, session, tokenMetadata, scope, resource, permission)
+	}
+
 	if session.Principal.Admin {
-		return true, nil // system admin can call any API
+		allowed, err := a.checkAdmin(ctx, session, resource)
+		if err != nil {
+			return false, err
+		}
+		if allowed {
+			return true, nil // system admin can call any API
+		}
 	}
 
 	var spacePath string
@@ -101,6 +118,9 @@ func (a *MembershipAuthorizer) Check(
 	case enum.ResourceTypeTemplate:
 		spacePath = scope.SpacePath
 
+	case enum.ResourceTypeRegistry:
+		spacePath = scope.SpacePath
+
 	case enum.ResourceTypeUser:
 		// a user is allowed to view / edit themselves
 		if resource.Identifier == session.Principal.UID &&
@@ -148,6 +168,33 @@ func (a *MembershipAuthorizer) CheckAll(ctx context.Context, session *auth.Sessi
 	return true, nil
 }
 
+// checkAdmin checks whether the system admin bypasses the membership checks for the resource.
+// In break-glass mode, admins can only access resources of spaces they aren't members of
+// while their break-glass access is active. Otherwise, they are treated like any other user.
+// Only human users require break-glass access, service and system principals keep the bypass.
+func (a *MembershipAuthorizer) checkAdmin(
+	ctx context.Context,
+	session *auth.Session,
+	resource *types.Resource,
+) (bool, error) {
+	if !a.breakGlass.Enabled() || session.Principal.Type != enum.PrincipalTypeUser {
+		return true, nil
+	}
+
+	//nolint:exhaustive // only resources that belong to spaces require break-glass access
+	switch resource.Type {
+	case enum.ResourceTypeUser, enum.ResourceTypeService:
+		return true, nil
+	}
+
+	breakGlass, err := a.breakGlass.Active(ctx, session.Principal.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find break-glass access: %w", err)
+	}
+
+	return breakGlass != nil, nil
+}
+
 // checkWithMembershipMetadata checks access using the ephemeral membership provided in the metadata.
 func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	ctx context.Context,
@@ -179,3 +226,56 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	// access is granted by ephemeral membership
 	return true, nil
 }
+
+// checkWithTokenScope checks access of a scoped token. The scope can only restrict the access,
+// the principal of the token still requires the permission itself.
+func (a *MembershipAuthorizer) checkWithTokenScope(
+	ctx context.Context,
+	session *auth.Session,
+	tokenMetadata *auth.TokenMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	var spacePath string
+
+	//nolint:exhaustive // scoped tokens don't have access to anything outside of a space
+	switch resource.Type {
+	case enum.ResourceTypeSpace:
+		spacePath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+	case enum.ResourceTypeUser:
+		// a scoped token is only allowed to view the principal it was created for
+		return resource.Identifier == session.Principal.UID && permission == enum.PermissionUserView, nil
+	case enum.ResourceTypeService:
+		return false, nil
+	default:
+		spacePath = scope.SpacePath
+	}
+
+	space, err := a.spaceStore.Find(ctx, tokenMetadata.Scope.SpaceID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find space of token scope: %w", err)
+	}
+
+	if !paths.IsAncesterOf(space.Path, spacePath) || !roleHasPermission(tokenMetadata.Scope.Role, permission) {
+		log.Ctx(ctx).Debug().Msgf(
+			"requested permission %s in '%s' is outside of token scope '%s' with role %s",
+			permission,
+			spacePath,
+			space.Path,
+			tokenMetadata.Scope.Role,
+		)
+		return false, nil
+	}
+
+	unscopedSession := *session
+	unscopedSession.Metadata = &auth.TokenMetadata{
+		TokenType: tokenMetadata.TokenType,
+		TokenID:   tokenMetadata.TokenID,
+	}
+
+	return a.Check(ctx, &unscopedSession, scope, resource, permission)
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/cache"
 	gitness_store "github.com/harness/gitness/store"
@@ -40,17 +41,20 @@ type PermissionCache cache.Cache[PermissionCacheKey, bool]
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	settings *settings.Service,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
 		spaceStore:      spaceStore,
 		membershipStore: membershipStore,
+		settings:        settings,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
 	spaceStore      store.SpaceStore
 	membershipStore store.MembershipStore
+	settings        *settings.Service
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
@@ -81,18 +85,29 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 		}
 
 		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
+		// Expired temporary grants don't count, even if the cleanup job hasn't reverted them yet.
+		if membership != nil {
+			role, ok := membership.EffectiveRole(time.Now().UnixMilli())
+			if ok && roleHasPermission(role, key.Permission) {
+				return true, nil
+			}
 		}
 
 		// If membership with the requested permission has not been found in the current space,
-		// move to the parent space, if any.
+		// move to the parent space, if any (and if the space inherits the memberships of its ancestors).
 
 		if space.ParentID == 0 {
 			return false, nil
 		}
 
+		inheritance, err := g.settings.SpaceInheritance(ctx, space.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find inheritance of space: %w", err)
+		}
+		if !inheritance.Memberships {
+			return false, nil
+		}
+
 		space, err = g.spaceStore.Find(ctx, space.ParentID)
 		if err != nil {
 			return false, fmt.Errorf("failed to find parent space with id %d: %w", space.ParentID, err)
@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/breakglass"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// breakGlassSettingsStore keeps the break-glass access of principals in memory.
+type breakGlassSettingsStore struct {
+	store.SettingsStore
+	values map[int64]json.RawMessage
+}
+
+func (s *breakGlassSettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	_ string,
+) (json.RawMessage, error) {
+	value, ok := s.values[scopeID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+// noMembershipCache denies every membership check, so only the admin bypass grants access.
+type noMembershipCache struct{}
+
+func (noMembershipCache) Stats() (int64, int64) { return 0, 0 }
+
+func (noMembershipCache) Get(context.Context, PermissionCacheKey) (bool, error) { return false, nil }
+
+func TestMembershipAuthorizer_BreakGlass(t *testing.T) {
+	const adminID = 1
+	now := time.Now()
+	active, _ := json.Marshal(&types.BreakGlass{
+		Justification: "incident",
+		Activated:     now.UnixMilli(),
+		Expires:       now.Add(time.Hour).UnixMilli(),
+	})
+	expired, _ := json.Marshal(&types.BreakGlass{
+		Justification: "incident",
+		Activated:     now.Add(-2 * time.Hour).UnixMilli(),
+		Expires:       now.Add(-time.Hour).UnixMilli(),
+	})
+
+	repo := &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"}
+	user := &types.Resource{Type: enum.ResourceTypeUser, Identifier: "other"}
+	service := &types.Resource{Type: enum.ResourceTypeService, Identifier: "service"}
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		breakGlass    json.RawMessage
+		principalType enum.PrincipalType
+		resource      *types.Resource
+		permission    enum.Permission
+		want          bool
+	}{
+		{
+			name:          "disabled",
+			principalType: enum.PrincipalTypeUser,
+			resource:      repo,
+			permission:    enum.PermissionRepoView,
+			want:          true,
+		},
+		{
+			name:          "enabled, not activated",
+			enabled:       true,
+			principalType: enum.PrincipalTypeUser,
+			resource:      repo,
+			permission:    enum.PermissionRepoView,
+			want:          false,
+		},
+		{
+			name:          "enabled, active",
+			enabled:       true,
+			breakGlass:    active,
+			principalType: enum.PrincipalTypeUser,
+			resource:      repo,
+			permission:    enum.PermissionRepoView,
+			want:          true,
+		},
+		{
+			name:          "enabled, expired",
+			enabled:       true,
+			breakGlass:    expired,
+			principalType: enum.PrincipalTypeUser,
+			resource:      repo,
+			permission:    enum.PermissionRepoView,
+			want:          false,
+		},
+		{
+			name:          "enabled, service principal",
+			enabled:       true,
+			principalType: enum.PrincipalTypeService,
+			resource:      repo,
+			permission:    enum.PermissionRepoView,
+			want:          true,
+		},
+		{
+			name:          "enabled, service account principal",
+			enabled:       true,
+			principalType: enum.PrincipalTypeServiceAccount,
+			resource:      repo,
+			permission:    enum.PermissionRepoView,
+			want:          true,
+		},
+		{
+			name:          "enabled, user resource",
+			enabled:       true,
+			principalType: enum.PrincipalTypeUser,
+			resource:      user,
+			permission:    enum.PermissionUserEdit,
+			want:          true,
+		},
+		{
+			name:          "enabled, service resource",
+			enabled:       true,
+			principalType: enum.PrincipalTypeUser,
+			resource:      service,
+			permission:    enum.PermissionServiceView,
+			want:          true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settingsStore := &breakGlassSettingsStore{values: map[int64]json.RawMessage{}}
+			if test.breakGlass != nil {
+				settingsStore.values[adminID] = test.breakGlass
+			}
+
+			config := &types.Config{}
+			config.BreakGlass.Enabled = test.enabled
+			config.BreakGlass.Window = time.Hour
+			breakGlass := breakglass.NewService(config, settings.NewService(settingsStore, nil), nil, nil)
+
+			authorizer := NewMembershipAuthorizer(noMembershipCache{}, nil, breakGlass)
+			session := &auth.Session{Principal: types.Principal{
+				ID:    adminID,
+				UID:   "admin",
+				Admin: true,
+				Type:  test.principalType,
+			}}
+
+			got, err := authorizer.Check(context.Background(), session,
+				&types.Scope{SpacePath: "space"}, test.resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
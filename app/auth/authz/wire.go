@@ -17,6 +17,8 @@ package authz
 import (
 	"time"
 
+	"github.com/harness/gitness/app/services/breakglass"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -28,14 +30,19 @@ var WireSet = wire.NewSet(
 	ProvidePermissionCache,
 )
 
-func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceStore)
+func ProvideAuthorizer(
+	pCache PermissionCache,
+	spaceStore store.SpaceStore,
+	breakGlass *breakglass.Service,
+) Authorizer {
+	return NewMembershipAuthorizer(pCache, spaceStore, breakGlass)
 }
 
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	settings *settings.Service,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(spaceStore, membershipStore, settings, permissionCacheTimeout)
 }
@@ -31,10 +31,12 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+	// Scope optionally restricts the access of the token to a role within a space.
+	Scope *MembershipMetadata
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
-	return false
+	return m.Scope != nil
 }
 
 // MembershipMetadata contains information about an ephemeral membership grant.
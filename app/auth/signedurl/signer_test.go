@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signedurl
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	signer := NewSigner([]byte("secret"), 5*time.Minute, time.Hour)
+	now := time.Now()
+	path := "/v1/repos/space/repo/raw/README.md"
+
+	query := signer.Sign(path, url.Values{"git_ref": {"main"}}, 42, now.Add(time.Minute))
+
+	principalID, err := signer.Verify(path, query, now)
+	if err != nil {
+		t.Fatalf("failed to verify signed url: %v", err)
+	}
+	if principalID != 42 {
+		t.Errorf("expected principal 42, got %d", principalID)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		modify func(url.Values)
+		now    time.Time
+		err    error
+	}{
+		{name: "other path", path: "/v1/repos/space/repo/raw/secret.txt", err: ErrInvalidSignature},
+		{name: "other ref", modify: func(q url.Values) { q.Set("git_ref", "dev") }, err: ErrInvalidSignature},
+		{name: "other principal", modify: func(q url.Values) { q.Set(QueryParamPrincipal, "1") }, err: ErrInvalidSignature},
+		{name: "extended expiry", modify: func(q url.Values) { q.Set(QueryParamExpires, "9999999999") },
+			err: ErrInvalidSignature},
+		{name: "no signature", modify: func(q url.Values) { q.Del(QueryParamSignature) }, err: ErrNoSignature},
+		{name: "expired", now: now.Add(2 * time.Minute), err: ErrExpired},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = append([]string(nil), v...)
+			}
+			if test.modify != nil {
+				test.modify(q)
+			}
+
+			p := path
+			if test.path != "" {
+				p = test.path
+			}
+
+			verifyAt := now
+			if !test.now.IsZero() {
+				verifyAt = test.now
+			}
+
+			if _, err := signer.Verify(p, q, verifyAt); !errors.Is(err, test.err) {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	signer := NewSigner([]byte("secret"), 5*time.Minute, time.Hour)
+
+	if expiry := signer.Expiry(0); expiry != 5*time.Minute {
+		t.Errorf("expected default expiry, got %s", expiry)
+	}
+	if expiry := signer.Expiry(60); expiry != time.Minute {
+		t.Errorf("expected requested expiry, got %s", expiry)
+	}
+	if expiry := signer.Expiry(24 * 60 * 60); expiry != time.Hour {
+		t.Errorf("expected max expiry, got %s", expiry)
+	}
+}
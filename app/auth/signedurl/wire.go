@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signedurl
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+	"github.com/rs/zerolog/log"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideSigner,
+)
+
+func ProvideSigner(config *types.Config) (*Signer, error) {
+	secret := []byte(config.SignedURL.Secret)
+	if len(secret) == 0 {
+		secret = []byte(config.Encrypter.Secret)
+	}
+
+	if len(secret) == 0 {
+		log.Warn().Msg("no secret configured for signed urls, " +
+			"using a random key (signed urls are only valid on this instance until it's restarted)")
+
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate random key for signed urls: %w", err)
+		}
+	}
+
+	return NewSigner(secret, config.SignedURL.DefaultExpiry, config.SignedURL.MaxExpiry), nil
+}
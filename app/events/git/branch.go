@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
@@ -25,10 +26,15 @@ import (
 const BranchCreatedEvent events.EventType = "branch-created"
 
 type BranchCreatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64              `json:"repo_id"`
+	PrincipalID int64              `json:"principal_id"`
+	Origin      enum.GitPushOrigin `json:"origin,omitempty"`
+	Ref         string             `json:"ref"`
+	SHA         string             `json:"sha"`
+	PushOptions []string           `json:"push_options,omitempty"`
+
+	// SuggestPullReq indicates that the creation of a pull request should be offered for the branch.
+	SuggestPullReq bool `json:"suggest_pullreq,omitempty"`
 }
 
 func (r *Reporter) BranchCreated(ctx context.Context, payload *BranchCreatedPayload) {
@@ -49,12 +55,14 @@ func (r *Reader) RegisterBranchCreated(fn events.HandlerFunc[*BranchCreatedPaylo
 const BranchUpdatedEvent events.EventType = "branch-updated"
 
 type BranchUpdatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	OldSHA      string `json:"old_sha"`
-	NewSHA      string `json:"new_sha"`
-	Forced      bool   `json:"forced"`
+	RepoID      int64              `json:"repo_id"`
+	PrincipalID int64              `json:"principal_id"`
+	Origin      enum.GitPushOrigin `json:"origin,omitempty"`
+	Ref         string             `json:"ref"`
+	OldSHA      string             `json:"old_sha"`
+	NewSHA      string             `json:"new_sha"`
+	Forced      bool               `json:"forced"`
+	PushOptions []string           `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) BranchUpdated(ctx context.Context, payload *BranchUpdatedPayload) {
@@ -75,10 +83,11 @@ func (r *Reader) RegisterBranchUpdated(fn events.HandlerFunc[*BranchUpdatedPaylo
 const BranchDeletedEvent events.EventType = "branch-deleted"
 
 type BranchDeletedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64              `json:"repo_id"`
+	PrincipalID int64              `json:"principal_id"`
+	Origin      enum.GitPushOrigin `json:"origin,omitempty"`
+	Ref         string             `json:"ref"`
+	SHA         string             `json:"sha"`
 }
 
 func (r *Reporter) BranchDeleted(ctx context.Context, payload *BranchDeletedPayload) {
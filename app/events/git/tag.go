@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
@@ -25,10 +26,11 @@ import (
 const TagCreatedEvent events.EventType = "tag-created"
 
 type TagCreatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64              `json:"repo_id"`
+	PrincipalID int64              `json:"principal_id"`
+	Origin      enum.GitPushOrigin `json:"origin,omitempty"`
+	Ref         string             `json:"ref"`
+	SHA         string             `json:"sha"`
 }
 
 func (r *Reporter) TagCreated(ctx context.Context, payload *TagCreatedPayload) {
@@ -49,12 +51,13 @@ func (r *Reader) RegisterTagCreated(fn events.HandlerFunc[*TagCreatedPayload],
 const TagUpdatedEvent events.EventType = "tag-updated"
 
 type TagUpdatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	OldSHA      string `json:"old_sha"`
-	NewSHA      string `json:"new_sha"`
-	Forced      bool   `json:"forced"`
+	RepoID      int64              `json:"repo_id"`
+	PrincipalID int64              `json:"principal_id"`
+	Origin      enum.GitPushOrigin `json:"origin,omitempty"`
+	Ref         string             `json:"ref"`
+	OldSHA      string             `json:"old_sha"`
+	NewSHA      string             `json:"new_sha"`
+	Forced      bool               `json:"forced"`
 }
 
 func (r *Reporter) TagUpdated(ctx context.Context, payload *TagUpdatedPayload) {
@@ -75,10 +78,11 @@ func (r *Reader) RegisterTagUpdated(fn events.HandlerFunc[*TagUpdatedPayload],
 const TagDeletedEvent events.EventType = "tag-deleted"
 
 type TagDeletedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64              `json:"repo_id"`
+	PrincipalID int64              `json:"principal_id"`
+	Origin      enum.GitPushOrigin `json:"origin,omitempty"`
+	Ref         string             `json:"ref"`
+	SHA         string             `json:"sha"`
 }
 
 func (r *Reporter) TagDeleted(ctx context.Context, payload *TagDeletedPayload) {
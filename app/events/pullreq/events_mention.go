@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MentionedEvent events.EventType = "mentioned"
+
+// MentionedPayload contains the principals that got mentioned in a pull request.
+// The PrincipalID of the Base is the principal that mentioned them.
+type MentionedPayload struct {
+	Base
+	PrincipalIDs []int64 `json:"principal_ids"`
+	// CommentID is the id of the comment containing the mentions, nil for mentions in the description.
+	CommentID *int64 `json:"comment_id,omitempty"`
+}
+
+func (r *Reporter) Mentioned(
+	ctx context.Context,
+	payload *MentionedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MentionedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request mentioned event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request mentioned event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMentioned(
+	fn events.HandlerFunc[*MentionedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MentionedEvent, fn, opts...)
+}
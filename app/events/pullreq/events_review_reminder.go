@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const ReviewReminderEvent events.EventType = "review-reminder"
+
+// ReviewReminderPayload is the payload of the event that reminds reviewers of reviews they didn't submit yet.
+type ReviewReminderPayload struct {
+	Base
+	ReviewerIDs []int64 `json:"reviewer_ids"`
+	// PendingHours is the number of hours the longest pending of the reviews was requested ago.
+	PendingHours int `json:"pending_hours"`
+}
+
+func (r *Reporter) ReviewReminder(
+	ctx context.Context,
+	payload *ReviewReminderPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewReminderEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request review reminder event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request review reminder event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewReminder(
+	fn events.HandlerFunc[*ReviewReminderPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewReminderEvent, fn, opts...)
+}
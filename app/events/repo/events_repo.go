@@ -45,3 +45,29 @@ func (r *Reader) RegisterRepoDeleted(fn events.HandlerFunc[*DeletedPayload],
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, DeletedEvent, fn, opts...)
 }
+
+const VulnerabilityAlertsCreatedEvent events.EventType = "vulnerability-alerts-created"
+
+type VulnerabilityAlertsCreatedPayload struct {
+	RepoID      int64   `json:"repo_id"`
+	PrincipalID int64   `json:"principal_id"`
+	AlertIDs    []int64 `json:"alert_ids"`
+}
+
+func (r *Reporter) VulnerabilityAlertsCreated(ctx context.Context, payload *VulnerabilityAlertsCreatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, VulnerabilityAlertsCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send vulnerability alerts created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported vulnerability alerts created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterVulnerabilityAlertsCreated(fn events.HandlerFunc[*VulnerabilityAlertsCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, VulnerabilityAlertsCreatedEvent, fn, opts...)
+}
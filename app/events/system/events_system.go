@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const ActivityEvent events.EventType = "activity"
+
+type ActivityPayload struct {
+	Type         enum.SystemEventType `json:"type"`
+	PrincipalID  *int64               `json:"principal_id,omitempty"`
+	ResourceID   *int64               `json:"resource_id,omitempty"`
+	ResourcePath string               `json:"resource_path"`
+	Message      string               `json:"message"`
+	Created      int64                `json:"created"`
+}
+
+func (r *Reporter) Activity(ctx context.Context, payload *ActivityPayload) {
+	if payload == nil {
+		return
+	}
+	if payload.Created == 0 {
+		payload.Created = time.Now().UnixMilli()
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ActivityEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send system activity event of type %s", payload.Type)
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported system activity event of type %s with id '%s'", payload.Type, eventID)
+}
+
+// RepoCreated reports that a repository was created by the principal.
+func (r *Reporter) RepoCreated(ctx context.Context, principalID int64, repo *types.Repository) {
+	r.Activity(ctx, &ActivityPayload{
+		Type:         enum.SystemEventTypeRepoCreated,
+		PrincipalID:  &principalID,
+		ResourceID:   &repo.ID,
+		ResourcePath: repo.Path,
+		Message:      fmt.Sprintf("repository %q created", repo.Path),
+	})
+}
+
+// RepoDeleted reports that a repository was deleted by the principal.
+func (r *Reporter) RepoDeleted(ctx context.Context, principalID int64, repo *types.Repository) {
+	r.Activity(ctx, &ActivityPayload{
+		Type:         enum.SystemEventTypeRepoDeleted,
+		PrincipalID:  &principalID,
+		ResourceID:   &repo.ID,
+		ResourcePath: repo.Path,
+		Message:      fmt.Sprintf("repository %q deleted", repo.Path),
+	})
+}
+
+// RepoArchived reports that a repository was archived automatically after the provided months of inactivity.
+func (r *Reporter) RepoArchived(ctx context.Context, repo *types.Repository, inactiveMonths int) {
+	r.Activity(ctx, &ActivityPayload{
+		Type:         enum.SystemEventTypeRepoArchived,
+		ResourceID:   &repo.ID,
+		ResourcePath: repo.Path,
+		Message:      fmt.Sprintf("repository %q archived after %d months of inactivity", repo.Path, inactiveMonths),
+	})
+}
+
+// BreakGlass reports that an instance admin activated break-glass access to all spaces.
+func (r *Reporter) BreakGlass(ctx context.Context, principal *types.Principal, breakGlass *types.BreakGlass) {
+	r.Activity(ctx, &ActivityPayload{
+		Type:         enum.SystemEventTypeBreakGlass,
+		PrincipalID:  &principal.ID,
+		ResourceID:   &principal.ID,
+		ResourcePath: principal.UID,
+		Message: fmt.Sprintf("admin %q activated break-glass access until %s: %s",
+			principal.UID, time.UnixMilli(breakGlass.Expires).UTC().Format(time.RFC3339), breakGlass.Justification),
+	})
+}
+
+// UserCreated reports that a new user was created.
+func (r *Reporter) UserCreated(ctx context.Context, user *types.User) {
+	r.Activity(ctx, &ActivityPayload{
+		Type:         enum.SystemEventTypeUserCreated,
+		PrincipalID:  &user.ID,
+		ResourceID:   &user.ID,
+		ResourcePath: user.UID,
+		Message:      fmt.Sprintf("user %q created", user.UID),
+	})
+}
+
+// LoginFailed reports a failed login attempt for the provided login identifier.
+// The user is only known in case the password didn't match.
+func (r *Reporter) LoginFailed(ctx context.Context, loginIdentifier string, user *types.User) {
+	payload := &ActivityPayload{
+		Type:         enum.SystemEventTypeLoginFailed,
+		ResourcePath: loginIdentifier,
+		Message:      fmt.Sprintf("failed login attempt for %q", loginIdentifier),
+	}
+	if user != nil {
+		payload.PrincipalID = &user.ID
+	}
+
+	r.Activity(ctx, payload)
+}
+
+// WebhookFailed reports a webhook execution that didn't succeed.
+func (r *Reporter) WebhookFailed(ctx context.Context, webhook *types.Webhook, execution *types.WebhookExecution) {
+	r.Activity(ctx, &ActivityPayload{
+		Type:         enum.SystemEventTypeWebhookFailed,
+		ResourceID:   &webhook.ID,
+		ResourcePath: webhook.Identifier,
+		Message: fmt.Sprintf("webhook %q execution for trigger %s ended with %s: %s",
+			webhook.Identifier, execution.TriggerType, execution.Result, execution.Error),
+	})
+}
+
+func (r *Reader) RegisterActivity(fn events.HandlerFunc[*ActivityPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ActivityEvent, fn, opts...)
+}
@@ -42,6 +42,36 @@ func GenerateEnvironmentVariables(
 	principalID int64,
 	disabled bool,
 	internal bool,
+) (map[string]string, error) {
+	return generateEnvironmentVariables(ctx, apiBaseURL, Payload{
+		RepoID:      repoID,
+		PrincipalID: principalID,
+		Disabled:    disabled,
+		Internal:    internal,
+	})
+}
+
+// GenerateSystemEnvironmentVariables generates the required environment variables for a payload
+// of git operations that are executed as the system service account (e.g. pull request head ref updates).
+// System calls are internal, so protection rules aren't verified, but they are reported separately from user pushes.
+func GenerateSystemEnvironmentVariables(
+	ctx context.Context,
+	apiBaseURL string,
+	repoID int64,
+	systemPrincipalID int64,
+) (map[string]string, error) {
+	return generateEnvironmentVariables(ctx, apiBaseURL, Payload{
+		RepoID:      repoID,
+		PrincipalID: systemPrincipalID,
+		Internal:    true,
+		System:      true,
+	})
+}
+
+func generateEnvironmentVariables(
+	ctx context.Context,
+	apiBaseURL string,
+	payload Payload,
 ) (map[string]string, error) {
 	// best effort retrieving of requestID - log in case we can't find it but don't fail operation.
 	requestID, ok := request.RequestIDFrom(ctx)
@@ -50,16 +80,8 @@ func GenerateEnvironmentVariables(
 	}
 
 	// generate githook base url
-	baseURL := strings.TrimLeft(apiBaseURL, "/") + "/v1/internal/git-hooks"
-
-	payload := Payload{
-		BaseURL:     baseURL,
-		RepoID:      repoID,
-		PrincipalID: principalID,
-		RequestID:   requestID,
-		Disabled:    disabled,
-		Internal:    internal,
-	}
+	payload.BaseURL = strings.TrimLeft(apiBaseURL, "/") + "/v1/internal/git-hooks"
+	payload.RequestID = requestID
 
 	if err := payload.Validate(); err != nil {
 		return nil, fmt.Errorf("generated payload is invalid: %w", err)
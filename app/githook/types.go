@@ -28,6 +28,7 @@ type Payload struct {
 	RequestID   string
 	Disabled    bool
 	Internal    bool // Internal calls originate from Gitness, and external calls are direct git pushes.
+	System      bool // System calls are internal calls executed as the system service account.
 }
 
 func (p Payload) Validate() error {
@@ -45,6 +46,9 @@ func (p Payload) Validate() error {
 	if p.RepoID <= 0 {
 		return errors.New("payload doesn't contain a repo id")
 	}
+	if p.System && !p.Internal {
+		return errors.New("payload of a system call has to be internal")
+	}
 
 	return nil
 }
@@ -54,5 +58,6 @@ func getInputBaseFromPayload(p Payload) types.GithookInputBase {
 		RepoID:      p.RepoID,
 		PrincipalID: p.PrincipalID,
 		Internal:    p.Internal,
+		System:      p.System,
 	}
 }
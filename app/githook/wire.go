@@ -19,6 +19,9 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/externalhook"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/malwarescan"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -52,6 +55,11 @@ func ProvideController(
 	protectionManager *protection.Manager,
 	githookFactory hook.ClientFactory,
 	limiter limiter.ResourceLimiter,
+	externalHookService *externalhook.Service,
+	pushedBranchStore store.PushedBranchStore,
+	pushStore store.PushStore,
+	maintenanceService *maintenance.Service,
+	malwareScan *malwarescan.Service,
 ) *githook.Controller {
 	ctrl := githook.NewController(
 		authorizer,
@@ -62,7 +70,12 @@ func ProvideController(
 		pullreqStore,
 		urlProvider,
 		protectionManager,
-		limiter)
+		limiter,
+		externalHookService,
+		pushedBranchStore,
+		pushStore,
+		maintenanceService,
+		malwareScan)
 
 	// TODO: improve wiring if possible
 	if fct, ok := githookFactory.(*ControllerClientFactory); ok {
@@ -51,7 +51,8 @@ type SubClaimsMembership struct {
 }
 
 // GenerateForToken generates a jwt for a given token.
-func GenerateForToken(token *types.Token, secret string) (string, error) {
+// If membership is provided, the access of the jwt is restricted to the ephemeral membership.
+func GenerateForToken(token *types.Token, membership *SubClaimsMembership, secret string) (string, error) {
 	var expiresAt int64
 	if token.ExpiresAt != nil {
 		expiresAt = *token.ExpiresAt
@@ -69,6 +70,7 @@ func GenerateForToken(token *types.Token, secret string) (string, error) {
 			Type: token.Type,
 			ID:   token.ID,
 		},
+		Membership: membership,
 	})
 
 	res, err := jwtToken.SignedString([]byte(secret))
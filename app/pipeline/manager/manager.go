@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/services/secrets"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
@@ -61,6 +62,9 @@ type (
 		Variant string            `json:"variant"`
 		Kernel  string            `json:"kernel"`
 		Labels  map[string]string `json:"labels,omitempty"`
+
+		// Match optionally restricts the stages that can be assigned to the agent.
+		Match func(stage *types.Stage) bool `json:"-"`
 	}
 
 	// Config represents a pipeline config file.
@@ -141,7 +145,7 @@ type Manager struct {
 	// Netrcs     store.NetrcService
 	Repos     store.RepoStore
 	Scheduler scheduler.Scheduler
-	Secrets   store.SecretStore
+	Secrets   *secrets.Resolver
 	// Status  store.StatusService
 	Stages store.StageStore
 	Steps  store.StepStore
@@ -163,7 +167,7 @@ func New(
 	checkStore store.CheckStore,
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
-	secretStore store.SecretStore,
+	secretResolver *secrets.Resolver,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
@@ -181,7 +185,7 @@ func New(
 		Checks:           checkStore,
 		Repos:            repoStore,
 		Scheduler:        scheduler,
-		Secrets:          secretStore,
+		Secrets:          secretResolver,
 		Stages:           stageStore,
 		Steps:            stepStore,
 		Users:            userStore,
@@ -208,6 +212,7 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 		Kernel:  args.Kernel,
 		Variant: args.Variant,
 		Labels:  args.Labels,
+		Match:   args.Match,
 	})
 	if err != nil && ctx.Err() != nil {
 		log.Debug().Err(err).Msg("manager: context canceled")
@@ -314,9 +319,13 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		Str("repo", repo.GetGitUID()).
 		Logger()
 
-	// TODO: Currently we fetch all the secrets from the same space.
+	// TODO: Currently we fetch all the secrets from the parent space and the repo.
 	// This logic can be updated when needed.
-	secrets, err := m.Secrets.ListAll(noContext, repo.ParentID)
+	pipelineSecrets, err := m.Secrets.ListForRepo(noContext, repo, secrets.Accessor{
+		RequestID:   fmt.Sprintf("execution-%d", execution.ID),
+		PrincipalID: &execution.CreatedBy,
+		Component:   "pipeline",
+	})
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: cannot list secrets")
 		return nil, err
@@ -352,7 +361,7 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		Repo:      repo,
 		Execution: execution,
 		Stage:     stage,
-		Secrets:   secrets,
+		Secrets:   pipelineSecrets,
 		Config:    file,
 		Netrc:     netrc,
 	}, nil
@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/services/secrets"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -48,12 +49,12 @@ func ProvideExecutionManager(
 	checkStore store.CheckStore,
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
-	secretStore store.SecretStore,
+	secretResolver *secrets.Resolver,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
-		logStore, logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore)
+		logStore, logStream, checkStore, repoStore, scheduler, secretResolver, stageStore, stepStore, userStore)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -87,6 +87,7 @@ func (q *queue) Request(ctx context.Context, params Filter) (*types.Stage, error
 		kernel:  params.Kernel,
 		variant: params.Variant,
 		labels:  params.Labels,
+		match:   params.Match,
 		channel: make(chan *types.Stage),
 		done:    ctx.Done(),
 	}
@@ -191,6 +192,10 @@ func (q *queue) signal(ctx context.Context) error {
 				}
 			}
 
+			if w.match != nil && !w.match(item) {
+				continue
+			}
+
 			select {
 			case w.channel <- item:
 			case <-w.done:
@@ -230,6 +235,7 @@ type worker struct {
 	kernel  string
 	variant string
 	labels  map[string]string
+	match   func(stage *types.Stage) bool
 	channel chan *types.Stage
 	done    <-chan struct{}
 }
@@ -32,6 +32,8 @@ type Filter struct {
 	Kernel  string
 	Variant string
 	Labels  map[string]string
+	// Match optionally restricts the stages to the ones it returns true for.
+	Match func(stage *types.Stage) bool
 }
 
 // Scheduler schedules Build stages for execution.
@@ -16,20 +16,30 @@ package router
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
 
+	"github.com/harness/gitness/app/api/controller/announcement"
+	"github.com/harness/gitness/app/api/controller/badge"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/integration"
+	"github.com/harness/gitness/app/api/controller/issue"
+	"github.com/harness/gitness/app/api/controller/issuetracker"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/markdown"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/registry"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -40,18 +50,27 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
+	handlerannouncement "github.com/harness/gitness/app/api/handler/announcement"
+	handlerbadge "github.com/harness/gitness/app/api/handler/badge"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
+	"github.com/harness/gitness/app/api/handler/githubcompat"
+	handlerintegration "github.com/harness/gitness/app/api/handler/integration"
+	handlerissue "github.com/harness/gitness/app/api/handler/issue"
+	handlerissuetracker "github.com/harness/gitness/app/api/handler/issuetracker"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
+	handlermarkdown "github.com/harness/gitness/app/api/handler/markdown"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
 	handlerpullreq "github.com/harness/gitness/app/api/handler/pullreq"
+	handlerregistry "github.com/harness/gitness/app/api/handler/registry"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -63,13 +82,27 @@ import (
 	"github.com/harness/gitness/app/api/handler/users"
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
+	"github.com/harness/gitness/app/api/middleware/alias"
+	"github.com/harness/gitness/app/api/middleware/audit"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	"github.com/harness/gitness/app/api/middleware/conditional"
 	"github.com/harness/gitness/app/api/middleware/encode"
+	middlewarefreeze "github.com/harness/gitness/app/api/middleware/freeze"
+	"github.com/harness/gitness/app/api/middleware/idempotency"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewaremaintenance "github.com/harness/gitness/app/api/middleware/maintenance"
+	"github.com/harness/gitness/app/api/middleware/metrics"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	"github.com/harness/gitness/app/api/middleware/ratelimit"
+	"github.com/harness/gitness/app/api/middleware/shape"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/app/writefreeze"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -106,7 +139,11 @@ func NewAPIHandler(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	integrationCtrl *integration.Controller,
+	issueTrackerCtrl *issuetracker.Controller,
+	badgeCtrl *badge.Controller,
 	githookCtrl *controllergithook.Controller,
 	saCtrl *serviceaccount.Controller,
 	userCtrl *user.Controller,
@@ -115,19 +152,32 @@ func NewAPIHandler(
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
-) APIHandler {
+	announcementCtrl *announcement.Controller,
+	markdownCtrl *markdown.Controller,
+	runnerCtrl *runner.Controller,
+	registryCtrl *registry.Controller,
+	freezeFlag *writefreeze.Flag,
+	maintenanceService *maintenance.Service,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	principalInfoCache store.PrincipalInfoCache,
+	auditLogStore store.AuditLogStore,
+	configReloader *configreload.Reloader,
+	aliasResolver *alias.Resolver,
+	urlProvider url.Provider,
+) (APIHandler, error) {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
 
 	// Apply common api middleware.
-	r.Use(middleware.NoCache)
+	r.Use(conditional.NoCache)
 	r.Use(middleware.Recoverer)
 
 	// configure logging middleware.
 	r.Use(hlog.URLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
-	r.Use(logging.HLogAccessLogHandler())
+	r.Use(logging.HLogAccessLogHandler(accessLogConfig(config)))
+	r.Use(metrics.Handler("api"))
 	r.Use(address.Handler("", ""))
 
 	// configure cors middleware
@@ -136,15 +186,48 @@ func NewAPIHandler(
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
 
+	// limit the request rate per token (or client ip for anonymous requests).
+	limit, err := ratelimit.Limit(config, configReloader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup rate limiting: %w", err)
+	}
+	r.Use(limit)
+
+	// record mutating requests in the audit log (if enabled).
+	r.Use(audit.Handler(config, auditLogStore))
+
+	// select the returned fields and expand relations of json responses (fields and expand query parameters).
+	r.Use(shape.Handler(principalInfoCache))
+
+	// block writes during a write freeze (git hooks are only called for already accepted pushes,
+	// external runners only report the progress of executions that are already running).
+	r.Use(middlewarefreeze.BlockWrites(freezeFlag,
+		"/v1/internal/", "/v1/login", "/v1/logout", "/v1/render", "/v1/runners/"))
+
+	// block writes while the system is in read-only maintenance mode (admins can still leave the mode).
+	r.Use(middlewaremaintenance.BlockWrites(maintenanceService,
+		"/v1/internal/", "/v1/login", "/v1/logout", "/v1/render", "/v1/admin/maintenance"))
+
+	// replay the original response for retries of mutating requests with an idempotency key.
+	idempotent := idempotency.Handler(config, idempotencyKeyStore)
+
 	r.Route("/v1", func(r chi.Router) {
 		setupRoutesV1(r, appCtx, config, repoCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
-			webhookCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl)
+			issueCtrl, webhookCtrl, integrationCtrl, issueTrackerCtrl, badgeCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl,
+			checkCtrl, sysCtrl, uploadCtrl, searchCtrl, announcementCtrl, markdownCtrl, runnerCtrl, registryCtrl,
+			idempotent,
+			aliasResolver)
 	})
 
+	if config.GitHubCompat.Enabled {
+		r.Route("/v3", func(r chi.Router) {
+			setupGitHubCompat(r, repoCtrl, pullreqCtrl, checkCtrl, urlProvider)
+		})
+	}
+
 	// wrap router in terminatedPath encoder.
-	return encode.TerminatedPathBefore(terminatedPathPrefixesAPI, r)
+	return encode.TerminatedPathBefore(terminatedPathPrefixesAPI, r), nil
 }
 
 func corsHandler(config *types.Config) func(http.Handler) http.Handler {
@@ -175,7 +258,11 @@ func setupRoutesV1(r chi.Router,
 	secretCtrl *secret.Controller,
 	spaceCtrl *space.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	integrationCtrl *integration.Controller,
+	issueTrackerCtrl *issuetracker.Controller,
+	badgeCtrl *badge.Controller,
 	githookCtrl *controllergithook.Controller,
 	saCtrl *serviceaccount.Controller,
 	userCtrl *user.Controller,
@@ -184,33 +271,56 @@ func setupRoutesV1(r chi.Router,
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	announcementCtrl *announcement.Controller,
+	markdownCtrl *markdown.Controller,
+	runnerCtrl *runner.Controller,
+	registryCtrl *registry.Controller,
+	idempotent func(http.Handler) http.Handler,
+	aliasResolver *alias.Resolver,
 ) {
-	setupSpaces(r, appCtx, spaceCtrl)
-	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, webhookCtrl, checkCtrl,
-		uploadCtrl)
+	setupSpaces(r, appCtx, config, spaceCtrl, integrationCtrl, issueTrackerCtrl, runnerCtrl, registryCtrl, aliasResolver)
+	setupRepos(r, appCtx, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, issueCtrl,
+		webhookCtrl, integrationCtrl, issueTrackerCtrl, badgeCtrl, checkCtrl, uploadCtrl, secretCtrl, idempotent,
+		aliasResolver)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
 	setupUser(r, userCtrl)
+	setupUsers(r, userCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, appCtx, config, userCtrl, sysCtrl, announcementCtrl)
+	setupAnnouncements(r, announcementCtrl)
 	setupAccount(r, userCtrl, sysCtrl, config)
 	setupSystem(r, config, sysCtrl)
 	setupResources(r)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
+	setupMarkdown(r, markdownCtrl)
+	setupRunners(r, runnerCtrl)
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
-func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller) {
+func setupSpaces(
+	r chi.Router,
+	appCtx context.Context,
+	config *types.Config,
+	spaceCtrl *space.Controller,
+	integrationCtrl *integration.Controller,
+	issueTrackerCtrl *issuetracker.Controller,
+	runnerCtrl *runner.Controller,
+	registryCtrl *registry.Controller,
+	aliasResolver *alias.Resolver,
+) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
 		r.Post("/import", handlerspace.HandleImport(spaceCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamSpaceRef), func(r chi.Router) {
+			r.Use(aliasResolver.RedirectSpace)
+
 			// space operations
 			r.Get("/", handlerspace.HandleFind(spaceCtrl))
 			r.Patch("/", handlerspace.HandleUpdate(spaceCtrl))
@@ -223,7 +333,7 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Post("/import", handlerspace.HandleImportRepositories(spaceCtrl))
 			r.Post("/move", handlerspace.HandleMove(spaceCtrl))
 			r.Get("/spaces", handlerspace.HandleListSpaces(spaceCtrl))
-			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
+			r.With(conditional.ETag).Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
@@ -231,65 +341,188 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 
+			r.Route("/external-hooks", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleExternalHookList(spaceCtrl))
+				r.Post("/", handlerspace.HandleExternalHookCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamExternalHookIdentifier), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleExternalHookFind(spaceCtrl))
+					r.Patch("/", handlerspace.HandleExternalHookUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleExternalHookDelete(spaceCtrl))
+				})
+			})
+
+			SetupIntegrations(r, integrationCtrl, enum.ParentResourceTypeSpace)
+
+			r.Route("/issue-tracker", func(r chi.Router) {
+				r.Get("/", handlerissuetracker.HandleFindSettings(issueTrackerCtrl))
+				r.Put("/", handlerissuetracker.HandleUpdateSettings(issueTrackerCtrl))
+			})
+
+			r.Get("/inheritance", handlerspace.HandleFindInheritance(spaceCtrl))
+			r.Patch("/inheritance", handlerspace.HandleUpdateInheritance(spaceCtrl))
+			r.Get("/settings/effective", handlerspace.HandleEffectiveSettings(spaceCtrl))
+
+			r.Route("/repo-template", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleFindRepoTemplate(spaceCtrl))
+				r.Put("/", handlerspace.HandleUpdateRepoTemplate(spaceCtrl))
+				r.Delete("/", handlerspace.HandleDeleteRepoTemplate(spaceCtrl))
+			})
+			r.Route("/archival-policy", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleFindArchivalPolicy(spaceCtrl))
+				r.Put("/", handlerspace.HandleUpdateArchivalPolicy(spaceCtrl))
+				r.Delete("/", handlerspace.HandleDeleteArchivalPolicy(spaceCtrl))
+			})
+			r.Route("/review-reminder-policy", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleFindReviewReminderPolicy(spaceCtrl))
+				r.Put("/", handlerspace.HandleUpdateReviewReminderPolicy(spaceCtrl))
+				r.Delete("/", handlerspace.HandleDeleteReviewReminderPolicy(spaceCtrl))
+			})
+			r.Get("/usage", handlerspace.HandleUsage(spaceCtrl))
+
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
 				r.Post("/", handlerspace.HandleMembershipAdd(spaceCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleMembershipFind(spaceCtrl))
 					r.Delete("/", handlerspace.HandleMembershipDelete(spaceCtrl))
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
+					r.Post("/grant", handlerspace.HandleMembershipGrant(spaceCtrl))
+				})
+			})
+
+			r.Route("/invitations", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleInvitationList(spaceCtrl))
+				r.Post("/", handlerspace.HandleInvitationCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamInvitationID), func(r chi.Router) {
+					r.Delete("/", handlerspace.HandleInvitationDelete(spaceCtrl))
+					r.Post("/resend", handlerspace.HandleInvitationResend(spaceCtrl))
+				})
+			})
+
+			r.Route("/runner-tokens", func(r chi.Router) {
+				r.Get("/", handlerrunner.HandleTokenList(runnerCtrl))
+				r.Post("/", handlerrunner.HandleTokenCreate(runnerCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamRunnerTokenIdentifier),
+					handlerrunner.HandleTokenDelete(runnerCtrl))
+			})
+
+			r.Route("/runners", func(r chi.Router) {
+				r.Get("/", handlerrunner.HandleList(runnerCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamRunnerIdentifier), handlerrunner.HandleDelete(runnerCtrl))
+			})
+
+			if config.Registry.Enabled {
+				setupRegistries(r, registryCtrl)
+			}
+		})
+	})
+}
+
+func setupRegistries(r chi.Router, registryCtrl *registry.Controller) {
+	r.Route("/registries", func(r chi.Router) {
+		r.Get("/", handlerregistry.HandleList(registryCtrl))
+		r.Post("/", handlerregistry.HandleCreate(registryCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamRegistryIdentifier), func(r chi.Router) {
+			r.Get("/", handlerregistry.HandleFind(registryCtrl))
+			r.Patch("/", handlerregistry.HandleUpdate(registryCtrl))
+			r.Delete("/", handlerregistry.HandleDelete(registryCtrl))
+
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerregistry.HandleListArtifacts(registryCtrl))
+				r.Route(fmt.Sprintf("/{%s}/versions", request.PathParamArtifactName), func(r chi.Router) {
+					r.Get("/", handlerregistry.HandleListVersions(registryCtrl))
+					r.Delete(fmt.Sprintf("/{%s}", request.PathParamVersionName),
+						handlerregistry.HandleDeleteVersion(registryCtrl))
 				})
 			})
+
+			r.Route(fmt.Sprintf("/generic/{%s}/{%s}/{%s}",
+				request.PathParamPackageName, request.PathParamVersionName, request.PathParamFileName),
+				func(r chi.Router) {
+					r.Put("/", handlerregistry.HandleUploadGenericFile(registryCtrl))
+					r.Get("/", handlerregistry.HandleDownloadGenericFile(registryCtrl))
+					r.Delete("/", handlerregistry.HandleDeleteGenericFile(registryCtrl))
+				})
 		})
 	})
 }
 
 func setupRepos(r chi.Router,
+	appCtx context.Context,
 	repoCtrl *repo.Controller,
 	pipelineCtrl *pipeline.Controller,
 	executionCtrl *execution.Controller,
 	triggerCtrl *trigger.Controller,
 	logCtrl *logs.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	integrationCtrl *integration.Controller,
+	issueTrackerCtrl *issuetracker.Controller,
+	badgeCtrl *badge.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	secretCtrl *secret.Controller,
+	idempotent func(http.Handler) http.Handler,
+	aliasResolver *alias.Resolver,
 ) {
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerrepo.HandleCreate(repoCtrl))
 		r.Post("/import", handlerrepo.HandleImport(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
+			r.Use(aliasResolver.RedirectRepo)
+
 			// repo level operations
 			r.Get("/", handlerrepo.HandleFind(repoCtrl))
 			r.Patch("/", handlerrepo.HandleUpdate(repoCtrl))
 			r.Delete("/", handlerrepo.HandleSoftDelete(repoCtrl))
 			r.Post("/purge", handlerrepo.HandlePurge(repoCtrl))
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
+			r.Post("/archive", handlerrepo.HandleMarkArchived(repoCtrl))
+			r.Post("/unarchive", handlerrepo.HandleUnarchive(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
+			r.Get("/malware-findings", handlerrepo.HandleListMalwareFindings(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
+			r.Get("/events", handlerrepo.HandleEvents(appCtx, repoCtrl))
+
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
+			r.Route("/maintenance", func(r chi.Router) {
+				r.Post("/gc", handlerrepo.HandleGarbageCollect(repoCtrl))
+				r.Post("/fsck", handlerrepo.HandleFsck(repoCtrl))
+				r.Get("/disk-usage", handlerrepo.HandleDiskUsage(repoCtrl))
+				r.Post("/detach-objects", handlerrepo.HandleDetachObjects(repoCtrl))
+			})
+
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
 			// We don't expect there to be any other operations in that route (as that could overlap with file names)
 			r.Route("/content", func(r chi.Router) {
-				r.Get("/*", handlerrepo.HandleGetContent(repoCtrl))
+				r.With(conditional.ETag).Get("/*", handlerrepo.HandleGetContent(repoCtrl))
 			})
 
 			r.Post("/path-details", handlerrepo.HandlePathsDetails(repoCtrl))
 
 			r.Route("/blame", func(r chi.Router) {
-				r.Get("/*", handlerrepo.HandleBlame(repoCtrl))
+				r.With(conditional.ETag).Get("/*", handlerrepo.HandleBlame(repoCtrl))
 			})
 
 			r.Route("/raw", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleArchive(repoCtrl))
+			})
+
+			r.Post("/signed-urls", handlerrepo.HandleCreateSignedURL(repoCtrl))
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
@@ -300,7 +533,7 @@ func setupRepos(r chi.Router,
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
-					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.With(conditional.ETag).Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
 				})
 			})
 
@@ -308,11 +541,42 @@ func setupRepos(r chi.Router,
 			r.Route("/branches", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListBranches(repoCtrl))
 				r.Post("/", handlerrepo.HandleCreateBranch(repoCtrl))
+				r.Post("/bulk-delete", handlerrepo.HandleDeleteBranches(repoCtrl))
 
 				// per branch operations (can't be grouped in single route)
 				r.Get("/*", handlerrepo.HandleGetBranch(repoCtrl))
 				r.Delete("/*", handlerrepo.HandleDeleteBranch(repoCtrl))
 			})
+			r.Get("/pushed-branch", handlerrepo.HandlePushedBranch(repoCtrl))
+
+			r.Get("/summary", handlerrepo.HandleSummary(repoCtrl))
+			r.Get("/languages", handlerrepo.HandleLanguages(repoCtrl))
+			r.Get("/dependencies", handlerrepo.HandleDependencies(repoCtrl))
+			r.Get("/vulnerability-alerts", handlerrepo.HandleListVulnerabilityAlerts(repoCtrl))
+			r.Route("/reviewer-assignment", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindReviewerAssignment(repoCtrl))
+				r.Put("/", handlerrepo.HandleUpdateReviewerAssignment(repoCtrl))
+				r.Delete("/", handlerrepo.HandleDeleteReviewerAssignment(repoCtrl))
+			})
+			r.Route("/pullreq-lint-policy", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindPullReqLintPolicy(repoCtrl))
+				r.Put("/", handlerrepo.HandleUpdatePullReqLintPolicy(repoCtrl))
+				r.Delete("/", handlerrepo.HandleDeletePullReqLintPolicy(repoCtrl))
+			})
+			r.Route("/merge-message-templates", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindMergeMessageTemplates(repoCtrl))
+				r.Put("/", handlerrepo.HandleUpdateMergeMessageTemplates(repoCtrl))
+				r.Delete("/", handlerrepo.HandleDeleteMergeMessageTemplates(repoCtrl))
+			})
+			r.Get("/readme", handlerrepo.HandleReadme(repoCtrl))
+			r.Get("/highlight", handlerrepo.HandleHighlight(repoCtrl))
+			r.Get("/paths", handlerrepo.HandleSearchPaths(repoCtrl))
+
+			r.Route("/insights", func(r chi.Router) {
+				r.Get("/commit-activity", handlerrepo.HandleInsightsCommitActivity(repoCtrl))
+				r.Get("/code-frequency", handlerrepo.HandleInsightsCodeFrequency(repoCtrl))
+				r.Get("/contributors", handlerrepo.HandleInsightsContributors(repoCtrl))
+			})
 
 			// tags operations
 			r.Route("/tags", func(r chi.Router) {
@@ -323,11 +587,11 @@ func setupRepos(r chi.Router,
 
 			// diffs
 			r.Route("/diff", func(r chi.Router) {
-				r.Get("/*", handlerrepo.HandleDiff(repoCtrl))
+				r.With(conditional.ETag).Get("/*", handlerrepo.HandleDiff(repoCtrl))
 				r.Post("/*", handlerrepo.HandleDiff(repoCtrl))
 			})
 			r.Route("/diff-stats", func(r chi.Router) {
-				r.Get("/*", handlerrepo.HandleDiffStats(repoCtrl))
+				r.With(conditional.ETag).Get("/*", handlerrepo.HandleDiffStats(repoCtrl))
 			})
 			r.Route("/merge-check", func(r chi.Router) {
 				r.Post("/*", handlerrepo.HandleMergeCheck(repoCtrl))
@@ -335,17 +599,33 @@ func setupRepos(r chi.Router,
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
-			SetupPullReq(r, pullreqCtrl)
+			SetupPullReq(r, appCtx, pullreqCtrl, idempotent)
+
+			SetupIssues(r, issueCtrl, idempotent)
+
+			SetupWebhook(r, webhookCtrl, idempotent)
+
+			SetupIntegrations(r, integrationCtrl, enum.ParentResourceTypeRepo)
+
+			r.Post("/issue-tracker/render", handlerissuetracker.HandleRender(issueTrackerCtrl))
 
-			SetupWebhook(r, webhookCtrl)
+			r.Route("/badges", func(r chi.Router) {
+				r.Get("/checks", handlerbadge.HandleChecks(badgeCtrl))
+				r.Get("/build", handlerbadge.HandleBuild(badgeCtrl))
+				r.Get("/pullreqs", handlerbadge.HandlePullReqs(badgeCtrl))
+			})
 
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
 			SetupChecks(r, checkCtrl)
 
+			SetupRepoSecrets(r, secretCtrl)
+
 			SetupUploads(r, uploadCtrl)
 
 			SetupRules(r, repoCtrl)
+
+			SetupWiki(r, repoCtrl)
 		})
 	})
 }
@@ -484,22 +764,31 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller) {
 	})
 }
 
-func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
+func SetupPullReq(
+	r chi.Router,
+	appCtx context.Context,
+	pullreqCtrl *pullreq.Controller,
+	idempotent func(http.Handler) http.Handler,
+) {
 	r.Route("/pullreq", func(r chi.Router) {
-		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
+		r.With(idempotent).Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
+			r.Put("/conversation", handlerpullreq.HandleConversation(pullreqCtrl))
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
+			r.Get("/events", handlerpullreq.HandleEvents(appCtx, pullreqCtrl))
 			r.Route("/comments", func(r chi.Router) {
-				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
+				r.With(idempotent).Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqCommentID), func(r chi.Router) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
 					r.Put("/status", handlerpullreq.HandleCommentStatus(pullreqCtrl))
+					r.Put("/visibility", handlerpullreq.HandleCommentVisibility(pullreqCtrl))
+					r.Get("/history", handlerpullreq.HandleCommentHistory(pullreqCtrl))
 				})
 			})
 			r.Route("/reviewers", func(r chi.Router) {
@@ -507,12 +796,19 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
+					r.Put("/snooze", handlerpullreq.HandleReviewerSnooze(pullreqCtrl))
+					r.Post("/dismiss", handlerpullreq.HandleReviewerDismiss(pullreqCtrl))
 				})
 			})
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
-			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.With(idempotent).Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Route("/merge-schedule", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleMergeScheduleFind(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleMergeScheduleSet(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeScheduleCancel(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -522,16 +818,34 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Delete("/*", handlerpullreq.HandleFileViewDelete(pullreqCtrl))
 			})
 			r.Get("/codeowners", handlerpullreq.HandleCodeOwner(pullreqCtrl))
-			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.With(conditional.ETag).Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
 		})
 	})
 }
 
-func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
+func SetupIssues(
+	r chi.Router,
+	issueCtrl *issue.Controller,
+	idempotent func(http.Handler) http.Handler,
+) {
+	r.Route("/issues", func(r chi.Router) {
+		r.With(idempotent).Post("/", handlerissue.HandleCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleList(issueCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueNumber), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleUpdate(issueCtrl))
+			r.Delete("/", handlerissue.HandleDelete(issueCtrl))
+			r.Post("/state", handlerissue.HandleState(issueCtrl))
+		})
+	})
+}
+
+func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller, idempotent func(http.Handler) http.Handler) {
 	r.Route("/webhooks", func(r chi.Router) {
-		r.Post("/", handlerwebhook.HandleCreate(webhookCtrl))
+		r.With(idempotent).Post("/", handlerwebhook.HandleCreate(webhookCtrl))
 		r.Get("/", handlerwebhook.HandleList(webhookCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookIdentifier), func(r chi.Router) {
@@ -551,6 +865,27 @@ func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	})
 }
 
+func SetupIntegrations(
+	r chi.Router,
+	integrationCtrl *integration.Controller,
+	parentType enum.ParentResourceType,
+) {
+	r.Route("/integrations", func(r chi.Router) {
+		// the url of an integration is a secret (e.g. a slack webhook url).
+		r.Use(audit.RedactKeys("url"))
+
+		r.Post("/", handlerintegration.HandleCreate(integrationCtrl, parentType))
+		r.Get("/", handlerintegration.HandleList(integrationCtrl, parentType))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIntegrationIdentifier), func(r chi.Router) {
+			r.Get("/", handlerintegration.HandleFind(integrationCtrl, parentType))
+			r.Patch("/", handlerintegration.HandleUpdate(integrationCtrl, parentType))
+			r.Delete("/", handlerintegration.HandleDelete(integrationCtrl, parentType))
+			r.Post("/test", handlerintegration.HandleTest(integrationCtrl, parentType))
+		})
+	})
+}
+
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Get("/recent", handlercheck.HandleCheckListRecent(checkCtrl))
@@ -561,6 +896,18 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	})
 }
 
+func SetupRepoSecrets(r chi.Router, secretCtrl *secret.Controller) {
+	r.Route("/secrets", func(r chi.Router) {
+		r.Post("/", handlersecret.HandleRepoCreate(secretCtrl))
+		r.Get("/", handlersecret.HandleRepoList(secretCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamSecretIdentifier), func(r chi.Router) {
+			r.Get("/", handlersecret.HandleRepoFind(secretCtrl))
+			r.Patch("/", handlersecret.HandleRepoUpdate(secretCtrl))
+			r.Delete("/", handlersecret.HandleRepoDelete(secretCtrl))
+		})
+	})
+}
+
 func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	r.Route("/rules", func(r chi.Router) {
 		r.Post("/", handlerrepo.HandleRuleCreate(repoCtrl))
@@ -573,13 +920,45 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
+func SetupWiki(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/wiki", func(r chi.Router) {
+		r.Get("/", handlerrepo.HandleFindWiki(repoCtrl))
+		r.Route("/pages", func(r chi.Router) {
+			r.Get("/", handlerrepo.HandleListWikiPages(repoCtrl))
+			r.Post("/", handlerrepo.HandleCreateWikiPage(repoCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamWikiPage), func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindWikiPage(repoCtrl))
+				r.Patch("/", handlerrepo.HandleUpdateWikiPage(repoCtrl))
+				r.Delete("/", handlerrepo.HandleDeleteWikiPage(repoCtrl))
+				r.Get("/history", handlerrepo.HandleListWikiPageHistory(repoCtrl))
+			})
+		})
+	})
+}
+
 func setupUser(r chi.Router, userCtrl *user.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
+		r.Put("/avatar", handleruser.HandleUpdateAvatar(userCtrl))
+		r.Delete("/avatar", handleruser.HandleDeleteAvatar(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Get("/notification-settings", handleruser.HandleFindNotificationSettings(userCtrl))
+		r.Patch("/notification-settings", handleruser.HandleUpdateNotificationSettings(userCtrl))
+		r.Get("/break-glass", handleruser.HandleFindBreakGlass(userCtrl))
+		r.Post("/break-glass", handleruser.HandleActivateBreakGlass(userCtrl))
+		r.Delete("/break-glass", handleruser.HandleDeactivateBreakGlass(userCtrl))
+
+		r.Route("/saved-replies", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListSavedReplies(userCtrl))
+			r.Post("/", handleruser.HandleCreateSavedReply(userCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSavedReplyID), func(r chi.Router) {
+				r.Patch("/", handleruser.HandleUpdateSavedReply(userCtrl))
+				r.Delete("/", handleruser.HandleDeleteSavedReply(userCtrl))
+			})
+		})
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -604,6 +983,13 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 	})
 }
 
+func setupUsers(r chi.Router, userCtrl *user.Controller) {
+	r.Route(fmt.Sprintf("/users/{%s}", request.PathParamUserUID), func(r chi.Router) {
+		r.Get("/activity", users.HandleActivity(userCtrl))
+		r.Get("/contributions", users.HandleContributions(userCtrl))
+	})
+}
+
 func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 	r.Route("/service-accounts", func(r chi.Router) {
 		// create takes parent information via body
@@ -645,14 +1031,49 @@ func setupResources(r chi.Router) {
 func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
 	r.Route("/principals", func(r chi.Router) {
 		r.Get("/", handlerprincipal.HandleList(principalCtrl))
+		r.Get(fmt.Sprintf("/{%s}/avatar", request.PathParamPrincipalID), handlerprincipal.HandleAvatar(principalCtrl))
+	})
+}
+
+func setupMarkdown(r chi.Router, markdownCtrl *markdown.Controller) {
+	r.Post("/render", handlermarkdown.HandleRender(markdownCtrl))
+}
+
+// setupRunners sets up the routes used by external runners, which authenticate with their access token.
+func setupRunners(r chi.Router, runnerCtrl *runner.Controller) {
+	r.Route("/runners", func(r chi.Router) {
+		r.Post("/register", handlerrunner.HandleRegister(runnerCtrl))
+		r.Post("/heartbeat", handlerrunner.HandleHeartbeat(runnerCtrl))
+		r.Post("/stages/request", handlerrunner.HandleStageRequest(runnerCtrl))
+		r.Route(fmt.Sprintf("/stages/{%s}", request.PathParamRunnerStageID), func(r chi.Router) {
+			r.Put("/", handlerrunner.HandleStageUpdate(runnerCtrl))
+			r.Get("/watch", handlerrunner.HandleWatch(runnerCtrl))
+			r.Route(fmt.Sprintf("/steps/{%s}", request.PathParamStepNumber), func(r chi.Router) {
+				r.Put("/", handlerrunner.HandleStepUpdate(runnerCtrl))
+				r.Post("/logs", handlerrunner.HandleLogsWrite(runnerCtrl))
+				r.Post("/logs/upload", handlerrunner.HandleLogsUpload(runnerCtrl))
+			})
+		})
 	})
 }
 
+func setupAnnouncements(r chi.Router, announcementCtrl *announcement.Controller) {
+	r.Get("/announcements", handlerannouncement.HandleListActive(announcementCtrl))
+}
+
 func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
 	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
+	r.Get("/search/pullreq", handlerkeywordsearch.HandleSearchPullReqs(searchCtrl))
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(
+	r chi.Router,
+	appCtx context.Context,
+	config *types.Config,
+	userCtrl *user.Controller,
+	sysCtrl *system.Controller,
+	announcementCtrl *announcement.Controller,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -663,9 +1084,68 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Get("/", users.HandleFind(userCtrl))
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
+				r.Post("/restore", users.HandleRestore(userCtrl))
+				r.Post("/tokens", users.HandleCreateToken(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+				r.Post("/approve", users.HandleApprove(userCtrl))
 			})
 		})
+		r.Route("/invitations", func(r chi.Router) {
+			r.Get("/", users.HandleListInvitations(userCtrl))
+			r.Post("/", users.HandleCreateInvitation(userCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamInvitationID), func(r chi.Router) {
+				r.Delete("/", users.HandleDeleteInvitation(userCtrl))
+				r.Post("/resend", users.HandleResendInvitation(userCtrl))
+			})
+		})
+		r.Route("/jobs", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListJobs(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamJobUID), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindJob(sysCtrl))
+				r.Post("/cancel", handlersystem.HandleCancelJob(sysCtrl))
+				r.Post("/retry", handlersystem.HandleRetryJob(sysCtrl))
+			})
+		})
+		r.Get("/audit-logs", handlersystem.HandleListAuditLogs(sysCtrl))
+		r.Route("/announcements", func(r chi.Router) {
+			r.Get("/", handlerannouncement.HandleList(announcementCtrl))
+			r.Post("/", handlerannouncement.HandleCreate(announcementCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamAnnouncementID), func(r chi.Router) {
+				r.Patch("/", handlerannouncement.HandleUpdate(announcementCtrl))
+				r.Delete("/", handlerannouncement.HandleDelete(announcementCtrl))
+			})
+		})
+		r.Post("/config/reload", handlersystem.HandleReloadConfig(sysCtrl))
+		r.Get("/maintenance", handlersystem.HandleGetMaintenance(sysCtrl))
+		r.Put("/maintenance", handlersystem.HandleUpdateMaintenance(sysCtrl))
+		r.Get("/settings", handlersystem.HandleGetInstanceSettings(sysCtrl))
+		r.Put("/settings", handlersystem.HandleUpdateInstanceSettings(sysCtrl))
+		r.Get("/usage", handlersystem.HandleUsage(sysCtrl))
+		r.Route("/events", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListEvents(sysCtrl))
+			r.Get("/stream", handlersystem.HandleStreamEvents(appCtx, sysCtrl))
+		})
+		if config.Diagnostics.Enabled {
+			setupDiagnostics(r, sysCtrl)
+		}
+	})
+}
+
+func setupDiagnostics(r chi.Router, sysCtrl *system.Controller) {
+	r.Route("/debug", func(r chi.Router) {
+		r.Route("/pprof", func(r chi.Router) {
+			r.Get("/", pprof.Index)
+			r.Get("/cmdline", pprof.Cmdline)
+			r.Get("/profile", pprof.Profile)
+			r.Get("/symbol", pprof.Symbol)
+			r.Post("/symbol", pprof.Symbol)
+			r.Get("/trace", pprof.Trace)
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamPprofProfile), handlersystem.HandlePprofProfile())
+		})
+		r.Get("/vars", expvar.Handler().ServeHTTP)
+		r.Post("/dump", handlersystem.HandleDump(sysCtrl))
 	})
 }
 
@@ -674,4 +1154,47 @@ func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Contr
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 	r.Post("/logout", account.HandleLogout(userCtrl, cookieName))
+	r.Route(fmt.Sprintf("/invitations/{%s}", request.PathParamInvitationToken), func(r chi.Router) {
+		r.Get("/", account.HandleFindInvitation(userCtrl))
+		r.Post("/accept", account.HandleAcceptInvitation(userCtrl))
+	})
+}
+
+func setupGitHubCompat(
+	r chi.Router,
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	checkCtrl *check.Controller,
+	urlProvider url.Provider,
+) {
+	r.Get("/user", githubcompat.HandleGetAuthenticatedUser(urlProvider))
+	r.Route(fmt.Sprintf("/repos/{%s}/{%s}", githubcompat.PathParamOwner, githubcompat.PathParamRepo),
+		func(r chi.Router) {
+			r.Get("/", githubcompat.HandleGetRepo(repoCtrl, urlProvider))
+
+			r.Get("/branches", githubcompat.HandleListBranches(repoCtrl, urlProvider))
+			r.Get("/branches/*", githubcompat.HandleGetBranch(repoCtrl, urlProvider))
+
+			r.Get("/contents", githubcompat.HandleGetContent(repoCtrl, urlProvider))
+			r.Get("/contents/*", githubcompat.HandleGetContent(repoCtrl, urlProvider))
+
+			r.Get("/commits", githubcompat.HandleListCommits(repoCtrl, urlProvider))
+			r.Route(fmt.Sprintf("/commits/{%s}", githubcompat.PathParamRef), func(r chi.Router) {
+				r.Get("/", githubcompat.HandleGetCommit(repoCtrl, urlProvider))
+				r.Get("/statuses", githubcompat.HandleListStatuses(repoCtrl, checkCtrl, urlProvider))
+				r.Get("/status", githubcompat.HandleGetCombinedStatus(repoCtrl, checkCtrl, urlProvider))
+			})
+			r.Post(fmt.Sprintf("/statuses/{%s}", githubcompat.PathParamRef),
+				githubcompat.HandleCreateStatus(checkCtrl, urlProvider))
+
+			r.Route("/pulls", func(r chi.Router) {
+				r.Get("/", githubcompat.HandleListPullRequests(repoCtrl, pullreqCtrl, urlProvider))
+				r.Post("/", githubcompat.HandleCreatePullRequest(repoCtrl, pullreqCtrl, urlProvider))
+				r.Route(fmt.Sprintf("/{%s}", githubcompat.PathParamNumber), func(r chi.Router) {
+					r.Get("/", githubcompat.HandleGetPullRequest(repoCtrl, pullreqCtrl, urlProvider))
+					r.Patch("/", githubcompat.HandleUpdatePullRequest(repoCtrl, pullreqCtrl, urlProvider))
+					r.Put("/merge", githubcompat.HandleMergePullRequest(pullreqCtrl))
+				})
+			})
+		})
 }
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// internalAPIPrefix is the prefix of the internal api that is used by the git hooks
+	// of in-flight git pushes, hence it has to stay available while draining.
+	internalAPIPrefix = APIMount + "/v1/internal/"
+
+	drainRetryAfter       = "10"
+	drainPollInterval     = 100 * time.Millisecond
+	drainProgressInterval = 10 * time.Second
+)
+
+var errDraining = usererror.New(http.StatusServiceUnavailable, "The server is shutting down, please retry.")
+
+// drainer keeps track of the in-flight git transfers and rejects new requests once the server is draining.
+type drainer struct {
+	mx           sync.Mutex
+	draining     bool
+	gitTransfers int
+}
+
+// beginGitTransfer registers a new git transfer - returns false if the server is draining.
+func (d *drainer) beginGitTransfer() bool {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.draining {
+		return false
+	}
+
+	d.gitTransfers++
+	return true
+}
+
+func (d *drainer) endGitTransfer() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.gitTransfers--
+}
+
+func (d *drainer) isDraining() bool {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.draining
+}
+
+func (d *drainer) startDraining() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.draining = true
+}
+
+func (d *drainer) inFlightGitTransfers() int {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.gitTransfers
+}
+
+// rejectDraining writes the response for requests that are rejected because the server is draining.
+func rejectDraining(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Connection", "close")
+	w.Header().Set("Retry-After", drainRetryAfter)
+	render.UserError(req.Context(), w, errDraining)
+}
+
+// isAllowedWhileDraining returns true iff the request has to be served while the server is draining.
+func isAllowedWhileDraining(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.Path, internalAPIPrefix)
+}
+
+// Drain stops the router from accepting new requests (except for the internal git hook calls)
+// and waits until all in-flight git transfers completed or the context is done.
+func (r *Router) Drain(ctx context.Context) error {
+	r.drainer.startDraining()
+
+	pollTicker := time.NewTicker(drainPollInterval)
+	defer pollTicker.Stop()
+
+	progressTicker := time.NewTicker(drainProgressInterval)
+	defer progressTicker.Stop()
+
+	for {
+		n := r.drainer.inFlightGitTransfers()
+		if n == 0 {
+			log.Ctx(ctx).Info().Msg("router: all git transfers completed")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Ctx(ctx).Warn().Int("git_transfers", n).Msg("router: draining interrupted")
+			return ctx.Err()
+		case <-progressTicker.C:
+			log.Ctx(ctx).Info().Int("git_transfers", n).Msg("router: waiting for in-flight git transfers")
+		case <-pollTicker.C:
+		}
+	}
+}
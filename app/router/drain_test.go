@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRouterDrain(t *testing.T) {
+	transferStarted := make(chan struct{})
+	releaseTransfer := make(chan struct{})
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	git := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/space/repo.git/git-receive-pack" {
+			close(transferStarted)
+			<-releaseTransfer
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := NewRouter(ok, git, ok, nil, "")
+
+	serve := func(path string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w.Code
+	}
+
+	transferDone := make(chan int)
+	go func() {
+		transferDone <- serve("/git/space/repo.git/git-receive-pack")
+	}()
+	<-transferStarted
+
+	drainDone := make(chan error)
+	go func() {
+		drainDone <- r.Drain(context.Background())
+	}()
+
+	// wait until the router started draining.
+	for !r.drainer.isDraining() {
+		time.Sleep(time.Millisecond)
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/git/space/repo.git/info/refs", want: http.StatusServiceUnavailable},
+		{path: "/api/v1/repos/space/repo", want: http.StatusServiceUnavailable},
+		{path: "/", want: http.StatusServiceUnavailable},
+		{path: "/api/v1/internal/git-hooks/pre-receive", want: http.StatusOK},
+	}
+	for _, test := range tests {
+		if got := serve(test.path); got != test.want {
+			t.Errorf("%s: expected status %d, got %d", test.path, test.want, got)
+		}
+	}
+
+	select {
+	case <-drainDone:
+		t.Fatal("drain completed before the in-flight git transfer")
+	case <-time.After(2 * drainPollInterval):
+	}
+
+	close(releaseTransfer)
+
+	if got := <-transferDone; got != http.StatusOK {
+		t.Errorf("in-flight git transfer: expected status %d, got %d", http.StatusOK, got)
+	}
+	if err := <-drainDone; err != nil {
+		t.Errorf("expected drain to complete, got error: %s", err)
+	}
+}
+
+func TestRouterDrainInterrupted(t *testing.T) {
+	r := NewRouter(http.NotFoundHandler(), http.NotFoundHandler(), http.NotFoundHandler(), nil, "")
+	if !r.drainer.beginGitTransfer() {
+		t.Fatal("expected git transfer to be accepted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*drainPollInterval)
+	defer cancel()
+
+	if err := r.Drain(ctx); err == nil {
+		t.Error("expected drain to be interrupted")
+	}
+}
@@ -20,13 +20,18 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
+	"github.com/harness/gitness/app/api/middleware/alias"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
+	middlewarefreeze "github.com/harness/gitness/app/api/middleware/freeze"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/api/middleware/metrics"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/app/writefreeze"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/go-chi/chi"
@@ -41,9 +46,12 @@ type GitHandler interface {
 
 // NewGitHandler returns a new GitHandler.
 func NewGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	freezeFlag *writefreeze.Flag,
+	aliasResolver *alias.Resolver,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -56,12 +64,16 @@ func NewGitHandler(
 	r.Use(hlog.URLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
-	r.Use(logging.HLogAccessLogHandler())
+	r.Use(logging.HLogAccessLogHandler(accessLogConfig(config)))
+	r.Use(metrics.Handler("git"))
 
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
 
 	r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
+		// redirect clone urls using the previous path of a renamed or moved space.
+		r.Use(aliasResolver.RedirectGit)
+
 		// routes that aren't coming from git
 		r.Group(func(r chi.Router) {
 			// redirect to repo (meant for UI, in case user navigates to clone url in browser)
@@ -75,7 +87,7 @@ func NewGitHandler(
 			// smart protocol
 			r.Post("/git-upload-pack", handlerrepo.HandleGitServicePack(
 				enum.GitServiceTypeUploadPack, repoCtrl, urlProvider))
-			r.Post("/git-receive-pack", handlerrepo.HandleGitServicePack(
+			r.With(middlewarefreeze.BlockWrites(freezeFlag)).Post("/git-receive-pack", handlerrepo.HandleGitServicePack(
 				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
 			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
 
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// accessLogConfig returns the access log config of the http handlers.
+// Invalid log levels of routes are ignored (with a warning), as they only affect the verbosity of the logs.
+func accessLogConfig(config *types.Config) logging.AccessLogConfig {
+	routeLevels := make(map[string]zerolog.Level, len(config.HTTPLogging.RouteLevels))
+	for route, rawLevel := range config.HTTPLogging.RouteLevels {
+		level, err := zerolog.ParseLevel(rawLevel)
+		if err != nil {
+			log.Warn().Err(err).Msgf("ignoring invalid log level %q of route %q", rawLevel, route)
+			continue
+		}
+
+		routeLevels[route] = level
+	}
+
+	return logging.AccessLogConfig{
+		SampleRate:    config.HTTPLogging.SampleRate,
+		SlowThreshold: config.HTTPLogging.SlowThreshold,
+		RouteLevels:   routeLevels,
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/registry"
+	handlerregistry "github.com/harness/gitness/app/api/handler/registry"
+	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewarefreeze "github.com/harness/gitness/app/api/middleware/freeze"
+	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/api/middleware/metrics"
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/writefreeze"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/rs/zerolog/hlog"
+)
+
+// RegistryHandler is an abstraction of an http handler that handles OCI distribution API calls.
+type RegistryHandler interface {
+	http.Handler
+}
+
+// NewRegistryHandler returns a new RegistryHandler.
+func NewRegistryHandler(
+	config *types.Config,
+	authenticator authn.Authenticator,
+	registryCtrl *registry.Controller,
+	freezeFlag *writefreeze.Flag,
+) RegistryHandler {
+	// Use go-chi router for inner routing.
+	r := chi.NewRouter()
+
+	// Apply common api middleware.
+	r.Use(middleware.NoCache)
+	r.Use(middleware.Recoverer)
+
+	// configure logging middleware.
+	r.Use(hlog.URLHandler("http.url"))
+	r.Use(hlog.MethodHandler("http.method"))
+	r.Use(logging.HLogRequestIDHandler())
+	r.Use(logging.HLogAccessLogHandler(accessLogConfig(config)))
+	r.Use(metrics.Handler("registry"))
+
+	// for now always attempt auth - enforced per operation.
+	r.Use(middlewareauthn.Attempt(authenticator))
+
+	// block pushes during a write freeze.
+	r.Use(middlewarefreeze.BlockWrites(freezeFlag))
+
+	// image names can contain slashes, so the OCI handler does the routing itself.
+	h := handlerregistry.HandleOCI(registryCtrl)
+	r.Handle(handlerregistry.OCIMount, h)
+	r.Handle(handlerregistry.OCIMount+"/*", h)
+
+	return r
+}
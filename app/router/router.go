@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"strings"
 
+	handlerregistry "github.com/harness/gitness/app/api/handler/registry"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/request"
 
@@ -37,9 +38,14 @@ type Router struct {
 	git GitHandler
 	web WebHandler
 
+	// registry is the optional handler of the OCI distribution API (nil if the registry is disabled).
+	registry RegistryHandler
+
 	// gitHost describes the optional host via which git traffic is identified.
 	// Note: always stored as lowercase.
 	gitHost string
+
+	drainer drainer
 }
 
 // NewRouter returns a new http.Handler that routes traffic
@@ -48,12 +54,14 @@ func NewRouter(
 	api APIHandler,
 	git GitHandler,
 	web WebHandler,
+	registry RegistryHandler,
 	gitHost string,
 ) *Router {
 	return &Router{
-		api: api,
-		git: git,
-		web: web,
+		api:      api,
+		git:      git,
+		web:      web,
+		registry: registry,
 
 		gitHost: strings.ToLower(gitHost),
 	}
@@ -89,10 +97,21 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			return
 		}
 
+		if !r.drainer.beginGitTransfer() {
+			rejectDraining(w, req)
+			return
+		}
+		defer r.drainer.endGitTransfer()
+
 		r.git.ServeHTTP(w, req)
 		return
 	}
 
+	if r.drainer.isDraining() && !isAllowedWhileDraining(req) {
+		rejectDraining(w, req)
+		return
+	}
+
 	/*
 	 * 2. REST API
 	 *
@@ -115,7 +134,21 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 
 	/*
-	 * 3. WEB
+	 * 3. REGISTRY
+	 *
+	 * All OCI distribution API calls start with "/v2" (mandated by the spec, clients don't support other paths).
+	 */
+	if r.isRegistryTraffic(req) {
+		log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Str("http.handler", "registry")
+		})
+
+		r.registry.ServeHTTP(w, req)
+		return
+	}
+
+	/*
+	 * 4. WEB
 	 *
 	 * Everything else will be routed to web (or return 404)
 	 */
@@ -163,3 +196,13 @@ func (r *Router) isAPITraffic(req *http.Request) bool {
 	p := req.URL.Path
 	return strings.HasPrefix(p, APIMount)
 }
+
+// isRegistryTraffic returns true iff the request is identified as part of the OCI distribution API.
+func (r *Router) isRegistryTraffic(req *http.Request) bool {
+	if r.registry == nil {
+		return false
+	}
+
+	p := req.URL.Path
+	return p == handlerregistry.OCIMount || strings.HasPrefix(p, handlerregistry.OCIMount+"/")
+}
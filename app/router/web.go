@@ -17,6 +17,9 @@ package router
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/system"
+	handlersystem "github.com/harness/gitness/app/api/handler/system"
+	"github.com/harness/gitness/app/api/middleware/metrics"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/types"
@@ -37,6 +40,7 @@ type WebHandler interface {
 // NewWebHandler returns a new WebHandler.
 func NewWebHandler(config *types.Config,
 	openapi openapi.Service,
+	sysCtrl *system.Controller,
 ) WebHandler {
 	// Use go-chi router for inner routing
 	r := chi.NewRouter()
@@ -63,6 +67,16 @@ func NewWebHandler(config *types.Config,
 		},
 	)
 
+	// health endpoints
+	r.Get("/healthz", handlersystem.HandleLiveness(sysCtrl))
+	r.Get("/readyz", handlersystem.HandleReadiness(sysCtrl))
+	r.Get("/healthz/db", handlersystem.HandleDatabaseHealth(sysCtrl))
+
+	// metrics endpoint
+	if config.Metrics.Enabled {
+		r.Handle("/metrics", metrics.Endpoint(config.Metrics.Token))
+	}
+
 	// openapi endpoints
 	// TODO: this should not be generated and marshaled on the fly every time?
 	r.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
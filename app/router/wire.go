@@ -18,17 +18,25 @@ import (
 	"context"
 	"strings"
 
+	"github.com/harness/gitness/app/api/controller/announcement"
+	"github.com/harness/gitness/app/api/controller/badge"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/integration"
+	"github.com/harness/gitness/app/api/controller/issue"
+	"github.com/harness/gitness/app/api/controller/issuetracker"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/markdown"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/registry"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -38,9 +46,14 @@ import (
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/middleware/alias"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/app/writefreeze"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -50,14 +63,17 @@ import (
 var WireSet = wire.NewSet(
 	ProvideRouter,
 	ProvideGitHandler,
+	ProvideRegistryHandler,
 	ProvideAPIHandler,
 	ProvideWebHandler,
+	ProvideAliasResolver,
 )
 
 func ProvideRouter(
 	api APIHandler,
 	git GitHandler,
 	web WebHandler,
+	registry RegistryHandler,
 	urlProvider url.Provider,
 ) *Router {
 	// use url provider as it has the latest data.
@@ -71,21 +87,41 @@ func ProvideRouter(
 		gitRoutingHost = gitHostname
 	}
 
-	return NewRouter(api, git, web, gitRoutingHost)
+	return NewRouter(api, git, web, registry, gitRoutingHost)
 }
 
 func ProvideGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	freezeFlag *writefreeze.Flag,
+	aliasResolver *alias.Resolver,
 ) GitHandler {
 	return NewGitHandler(
+		config,
 		urlProvider,
 		authenticator,
 		repoCtrl,
+		freezeFlag,
+		aliasResolver,
 	)
 }
 
+// ProvideRegistryHandler provides the handler of the OCI distribution API (nil if the registry is disabled).
+func ProvideRegistryHandler(
+	config *types.Config,
+	authenticator authn.Authenticator,
+	registryCtrl *registry.Controller,
+	freezeFlag *writefreeze.Flag,
+) RegistryHandler {
+	if !config.Registry.Enabled {
+		return nil
+	}
+
+	return NewRegistryHandler(config, authenticator, registryCtrl, freezeFlag)
+}
+
 func ProvideAPIHandler(
 	appCtx context.Context,
 	config *types.Config,
@@ -101,7 +137,11 @@ func ProvideAPIHandler(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	integrationCtrl *integration.Controller,
+	issueTrackerCtrl *issuetracker.Controller,
+	badgeCtrl *badge.Controller,
 	githookCtrl *githook.Controller,
 	saCtrl *serviceaccount.Controller,
 	userCtrl *user.Controller,
@@ -110,13 +150,38 @@ func ProvideAPIHandler(
 	sysCtrl *system.Controller,
 	blobCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
-) APIHandler {
+	announcementCtrl *announcement.Controller,
+	markdownCtrl *markdown.Controller,
+	runnerCtrl *runner.Controller,
+	registryCtrl *registry.Controller,
+	freezeFlag *writefreeze.Flag,
+	maintenanceService *maintenance.Service,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	principalInfoCache store.PrincipalInfoCache,
+	auditLogStore store.AuditLogStore,
+	configReloader *configreload.Reloader,
+	aliasResolver *alias.Resolver,
+	urlProvider url.Provider,
+) (APIHandler, error) {
 	return NewAPIHandler(appCtx, config,
 		authenticator, repoCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
-		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl)
+		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, issueCtrl, webhookCtrl,
+		integrationCtrl, issueTrackerCtrl, badgeCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl,
+		blobCtrl, searchCtrl, announcementCtrl, markdownCtrl, runnerCtrl, registryCtrl, freezeFlag, maintenanceService,
+		idempotencyKeyStore,
+		principalInfoCache, auditLogStore, configReloader, aliasResolver, urlProvider)
+}
+
+func ProvideWebHandler(config *types.Config, openapi openapi.Service, sysCtrl *system.Controller) WebHandler {
+	return NewWebHandler(config, openapi, sysCtrl)
 }
 
-func ProvideWebHandler(config *types.Config, openapi openapi.Service) WebHandler {
-	return NewWebHandler(config, openapi)
+// ProvideAliasResolver provides the resolver used to redirect requests using previous paths of spaces.
+func ProvideAliasResolver(
+	config *types.Config,
+	spacePathCache store.SpacePathCache,
+	spacePathStore store.SpacePathStore,
+	urlProvider url.Provider,
+) *alias.Resolver {
+	return alias.NewResolver(spacePathCache, spacePathStore, urlProvider, config.SpacePathAliasExpiry)
 }
@@ -16,10 +16,21 @@
 package server
 
 import (
+	"context"
+
+	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/http"
 )
 
 // Server is the http server for gitness.
 type Server struct {
 	*http.Server
+
+	router *router.Router
+}
+
+// Drain stops accepting new requests and waits for the in-flight git transfers to complete.
+// It is intended to be called during graceful shutdown, before the http server is shut down.
+func (s *Server) Drain(ctx context.Context) error {
+	return s.router.Drain(ctx)
 }
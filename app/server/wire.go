@@ -30,11 +30,13 @@ func ProvideServer(config *types.Config, router *router.Router) *Server {
 	return &Server{
 		http.NewServer(
 			http.Config{
-				Port:     config.Server.HTTP.Port,
-				Acme:     config.Server.Acme.Enabled,
-				AcmeHost: config.Server.Acme.Host,
+				Port:      config.Server.HTTP.Port,
+				Acme:      config.Server.Acme.Enabled,
+				AcmeHost:  config.Server.Acme.Host,
+				ReusePort: config.Server.HTTP.ReusePort,
 			},
 			router,
 		),
+		router,
 	}
 }
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archival
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeArchival        = "gitness:archival:inactive-repos"
+	jobCronArchival        = "41 3 * * *" // At 03:41 every day.
+	jobMaxDurationArchival = 30 * time.Minute
+)
+
+// Service archives repositories that are inactive for longer than allowed by the archival policy of their space.
+type Service struct {
+	scheduler      *job.Scheduler
+	executor       *job.Executor
+	settings       *settings.Service
+	repoStore      store.RepoStore
+	spaceStore     store.SpaceStore
+	systemReporter *systemevents.Reporter
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	settings *settings.Service,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	systemReporter *systemevents.Reporter,
+) *Service {
+	return &Service{
+		scheduler:      scheduler,
+		executor:       executor,
+		settings:       settings,
+		repoStore:      repoStore,
+		spaceStore:     spaceStore,
+		systemReporter: systemReporter,
+	}
+}
+
+// Register registers the archival job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeArchival, &archivalJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for repository archival: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeArchival,
+		jobTypeArchival,
+		jobCronArchival,
+		jobMaxDurationArchival,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule repository archival job: %w", err)
+	}
+
+	return nil
+}
+
+type archivalJob struct {
+	service *Service
+}
+
+// Handle archives the inactive repositories of all spaces with an archival policy.
+// The policy of the closest space applies to a repository, so subspaces can override the policy of their ancestors.
+func (j *archivalJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	policies, err := j.service.settings.RepoArchivalPolicies(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	resolver := &policyResolver{
+		spaceStore: j.service.spaceStore,
+		policies:   policies,
+		closest:    map[int64]int64{},
+	}
+
+	archived := 0
+	for spaceID, policy := range policies {
+		if policy.InactiveMonths <= 0 {
+			continue
+		}
+
+		since := now.AddDate(0, -policy.InactiveMonths, 0).UnixMilli()
+
+		repos, err := j.service.repoStore.ListInactive(ctx, spaceID, since)
+		if err != nil {
+			return "", fmt.Errorf("failed to list inactive repositories of space %d: %w", spaceID, err)
+		}
+
+		for _, repo := range repos {
+			closestSpaceID, err := resolver.closestPolicySpace(ctx, repo.ParentID)
+			if err != nil {
+				return "", err
+			}
+			if closestSpaceID != spaceID {
+				// the policy of a subspace applies to the repository.
+				continue
+			}
+
+			if err = j.service.archive(ctx, repo, policy.InactiveMonths); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to archive repository %q", repo.Path)
+				continue
+			}
+
+			archived++
+		}
+	}
+
+	result := fmt.Sprintf("archived %d inactive repositories", archived)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) archive(ctx context.Context, repo *types.Repository, inactiveMonths int) error {
+	repo, err := s.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.Archived = true
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark repository as archived: %w", err)
+	}
+
+	s.systemReporter.RepoArchived(ctx, repo, inactiveMonths)
+
+	return nil
+}
+
+// policyResolver finds the closest space with an archival policy, starting with the space of a repository.
+type policyResolver struct {
+	spaceStore store.SpaceStore
+	policies   map[int64]*types.RepoArchivalPolicy
+	// closest caches the id of the closest space with a policy, mapped by space id.
+	closest map[int64]int64
+}
+
+func (r *policyResolver) closestPolicySpace(ctx context.Context, spaceID int64) (int64, error) {
+	if closestID, ok := r.closest[spaceID]; ok {
+		return closestID, nil
+	}
+
+	closestID := int64(0)
+	if _, ok := r.policies[spaceID]; ok {
+		closestID = spaceID
+	} else {
+		space, err := r.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		if space.ParentID > 0 {
+			closestID, err = r.closestPolicySpace(ctx, space.ParentID)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	r.closest[spaceID] = closestID
+
+	return closestID, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"  // register gif decoder
+	_ "image/jpeg" // register jpeg decoder
+	"image/png"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	// Size is the width and height of stored avatars in pixels.
+	Size = 256
+
+	// maxSourceDimension limits the width and height of uploaded images to protect against decompression bombs.
+	maxSourceDimension = 4096
+)
+
+// Resize decodes the provided png, jpeg or gif image, crops it to a centered square and scales it down
+// to the avatar size (smaller images aren't scaled up). The result is encoded as png.
+func Resize(file io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, usererror.BadRequestf("The avatar image can be at most %d bytes large.", MaxFileSize)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, usererror.BadRequest("Only png, jpeg and gif images are supported as avatar.")
+	}
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid avatar image: %s", err)
+	}
+	if cfg.Width > maxSourceDimension || cfg.Height > maxSourceDimension {
+		return nil, usererror.BadRequestf("The avatar image can be at most %dx%d pixels large.",
+			maxSourceDimension, maxSourceDimension)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid avatar image: %s", err)
+	}
+
+	dst := scale(cropSquare(src), Size)
+
+	buf := &bytes.Buffer{}
+	if err = png.Encode(buf, dst); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// cropSquare returns the centered square of the image as NRGBA image.
+func cropSquare(src image.Image) *image.NRGBA {
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	dst := image.NewNRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), src, image.Pt(x0, y0), draw.Src)
+
+	return dst
+}
+
+// scale scales the square image down to the provided size by averaging the source pixels
+// covered by each target pixel. Images that are already small enough are returned unchanged.
+func scale(src *image.NRGBA, size int) *image.NRGBA {
+	side := src.Bounds().Dx()
+	if side <= size {
+		return src
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		sy0, sy1 := y*side/size, (y+1)*side/size
+		for x := 0; x < size; x++ {
+			sx0, sx1 := x*side/size, (x+1)*side/size
+
+			var r, g, b, a, n int
+			for sy := sy0; sy < sy1; sy++ {
+				off := src.PixOffset(sx0, sy)
+				for sx := sx0; sx < sx1; sx++ {
+					r += int(src.Pix[off])
+					g += int(src.Pix[off+1])
+					b += int(src.Pix[off+2])
+					a += int(src.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			off := dst.PixOffset(x, y)
+			dst.Pix[off] = uint8(r / n)
+			dst.Pix[off+1] = uint8(g / n)
+			dst.Pix[off+2] = uint8(b / n)
+			dst.Pix[off+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		expectedSize  int
+	}{
+		{name: "large landscape", width: 1024, height: 600, expectedSize: Size},
+		{name: "large portrait", width: 300, height: 900, expectedSize: Size},
+		{name: "small", width: 64, height: 100, expectedSize: 64},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := image.NewNRGBA(image.Rect(0, 0, test.width, test.height))
+			for y := 0; y < test.height; y++ {
+				for x := 0; x < test.width; x++ {
+					src.Set(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+				}
+			}
+
+			buf := &bytes.Buffer{}
+			if err := png.Encode(buf, src); err != nil {
+				t.Fatalf("failed to encode source image: %v", err)
+			}
+
+			data, err := Resize(buf)
+			if err != nil {
+				t.Fatalf("failed to resize: %v", err)
+			}
+
+			dst, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("failed to decode avatar: %v", err)
+			}
+
+			if b := dst.Bounds(); b.Dx() != test.expectedSize || b.Dy() != test.expectedSize {
+				t.Errorf("avatar size = %dx%d, want %dx%d", b.Dx(), b.Dy(), test.expectedSize, test.expectedSize)
+			}
+
+			r, g, b, _ := dst.At(test.expectedSize/2, test.expectedSize/2).RGBA()
+			if r>>8 != 200 || g>>8 != 100 || b>>8 != 50 {
+				t.Errorf("unexpected avatar color %d/%d/%d", r>>8, g>>8, b>>8)
+			}
+		})
+	}
+}
+
+func TestResize_InvalidImage(t *testing.T) {
+	if _, err := Resize(bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Errorf("expected error for invalid image")
+	}
+}
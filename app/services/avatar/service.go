@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/blob"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxFileSize is the maximum size of an uploaded avatar image.
+	MaxFileSize = 5 << 20 // 5 MB
+
+	// filePathFmt is the path of an avatar in the blob store, containing the principal id and the avatar.
+	filePathFmt = "avatars/%d/%s.png"
+)
+
+// Service stores avatars of principals in the blob store.
+type Service struct {
+	blobStore blob.Store
+}
+
+func NewService(blobStore blob.Store) *Service {
+	return &Service{
+		blobStore: blobStore,
+	}
+}
+
+// Upload resizes the provided image and stores it as new avatar of the principal.
+// It returns the identifier of the avatar, which has to be stored with the principal.
+func (s *Service) Upload(ctx context.Context, principalID int64, file io.Reader) (string, error) {
+	if file == nil {
+		return "", usererror.BadRequest("No avatar image provided.")
+	}
+
+	img, err := Resize(io.LimitReader(file, MaxFileSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	avatar := uuid.New().String()
+
+	err = s.blobStore.Upload(ctx, bytes.NewReader(img), filePath(principalID, avatar))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload avatar: %w", err)
+	}
+
+	return avatar, nil
+}
+
+// Download returns either a signed url or a reader for the avatar of the principal.
+func (s *Service) Download(ctx context.Context, principalID int64, avatar string) (string, io.ReadCloser, error) {
+	path := filePath(principalID, avatar)
+
+	signedURL, err := s.blobStore.GetSignedURL(ctx, path)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := s.blobStore.Download(ctx, path)
+	if errors.Is(err, blob.ErrNotFound) {
+		return "", nil, usererror.NotFound("Avatar not found.")
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download avatar from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
+
+func filePath(principalID int64, avatar string) string {
+	return fmt.Sprintf(filePathFmt, principalID, avatar)
+}
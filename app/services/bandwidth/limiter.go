@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bandwidth
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter throttles data transfers (e.g. git clones and pushes) per connection and per principal.
+// All limits are in bytes per second, a limit of 0 means no limit.
+type Limiter struct {
+	perConnection int64
+	perPrincipal  int64
+
+	mx         sync.Mutex
+	principals map[int64]*principalLimiter
+}
+
+// principalLimiter is the limiter shared by all concurrent transfers of a principal.
+type principalLimiter struct {
+	limiter *rate.Limiter
+	refs    int
+}
+
+func NewLimiter(perConnection int64, perPrincipal int64) *Limiter {
+	return &Limiter{
+		perConnection: perConnection,
+		perPrincipal:  perPrincipal,
+		principals:    map[int64]*principalLimiter{},
+	}
+}
+
+// Acquire starts a new throttled transfer for the principal (0 for anonymous transfers).
+// The provided limits (e.g. of the space) are applied in addition to the configured per connection limit.
+// The returned transfer has to be released once it's completed.
+func (l *Limiter) Acquire(principalID int64, limits ...int64) *Transfer {
+	t := &Transfer{
+		limiter:     l,
+		principalID: principalID,
+	}
+
+	connectionLimit := minLimit(append(limits, l.perConnection)...)
+	if connectionLimit > 0 {
+		t.limiters = append(t.limiters, newRateLimiter(connectionLimit))
+	}
+
+	if principalID > 0 && l.perPrincipal > 0 {
+		l.mx.Lock()
+		p, ok := l.principals[principalID]
+		if !ok {
+			p = &principalLimiter{limiter: newRateLimiter(l.perPrincipal)}
+			l.principals[principalID] = p
+		}
+		p.refs++
+		l.mx.Unlock()
+
+		t.limiters = append(t.limiters, p.limiter)
+		t.shared = true
+	}
+
+	return t
+}
+
+func (l *Limiter) release(principalID int64) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	p, ok := l.principals[principalID]
+	if !ok {
+		return
+	}
+
+	p.refs--
+	if p.refs <= 0 {
+		delete(l.principals, principalID)
+	}
+}
+
+// Transfer is a single throttled transfer.
+type Transfer struct {
+	limiter     *Limiter
+	principalID int64
+	limiters    []*rate.Limiter
+	shared      bool
+	releaseOnce sync.Once
+}
+
+// Limited returns true if any limit applies to the transfer.
+func (t *Transfer) Limited() bool {
+	return len(t.limiters) > 0
+}
+
+// Reader returns a reader that is throttled according to the limits of the transfer.
+func (t *Transfer) Reader(ctx context.Context, r io.Reader) io.Reader {
+	if !t.Limited() {
+		return r
+	}
+	return &reader{ctx: ctx, r: r, t: t}
+}
+
+// Writer returns a writer that is throttled according to the limits of the transfer.
+func (t *Transfer) Writer(ctx context.Context, w io.Writer) io.Writer {
+	if !t.Limited() {
+		return w
+	}
+	return &writer{ctx: ctx, w: w, t: t}
+}
+
+// Release releases the resources of the transfer.
+func (t *Transfer) Release() {
+	t.releaseOnce.Do(func() {
+		if t.shared {
+			t.limiter.release(t.principalID)
+		}
+	})
+}
+
+// chunkSize returns the max number of bytes that can be transferred at once.
+func (t *Transfer) chunkSize() int {
+	size := maxChunkSize
+	for _, l := range t.limiters {
+		if l.Burst() < size {
+			size = l.Burst()
+		}
+	}
+	return size
+}
+
+func (t *Transfer) wait(ctx context.Context, n int) error {
+	for _, l := range t.limiters {
+		if err := l.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxChunkSize is the max number of bytes that are transferred at once.
+const maxChunkSize = 32 * 1024
+
+type reader struct {
+	ctx context.Context
+	r   io.Reader
+	t   *Transfer
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if size := r.t.chunkSize(); len(p) > size {
+		p = p[:size]
+	}
+
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if wErr := r.t.wait(r.ctx, n); wErr != nil {
+			return n, wErr
+		}
+	}
+
+	return n, err
+}
+
+type writer struct {
+	ctx context.Context
+	w   io.Writer
+	t   *Transfer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	size := w.t.chunkSize()
+
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > size {
+			chunk = chunk[:size]
+		}
+
+		if err := w.t.wait(w.ctx, len(chunk)); err != nil {
+			return written, err
+		}
+
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+func newRateLimiter(limit int64) *rate.Limiter {
+	// bursts are capped to keep the transfer rate smooth.
+	burst := limit
+	if burst > maxChunkSize {
+		burst = maxChunkSize
+	}
+	return rate.NewLimiter(rate.Limit(limit), int(burst))
+}
+
+// minLimit returns the most restrictive of the limits, ignoring the ones that aren't set.
+func minLimit(limits ...int64) int64 {
+	var res int64
+	for _, limit := range limits {
+		if limit > 0 && (res == 0 || limit < res) {
+			res = limit
+		}
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Acquire(t *testing.T) {
+	l := NewLimiter(1000, 500)
+
+	if transfer := l.Acquire(0); len(transfer.limiters) != 1 || transfer.limiters[0].Limit() != 1000 {
+		t.Errorf("anonymous transfer should only be limited by the per connection limit")
+	}
+
+	if transfer := l.Acquire(0, 0, 200, 300); transfer.limiters[0].Limit() != 200 {
+		t.Errorf("expected the most restrictive limit to be used, got %v", transfer.limiters[0].Limit())
+	}
+
+	t1 := l.Acquire(1)
+	t2 := l.Acquire(1)
+	if len(t1.limiters) != 2 || t1.limiters[1] != t2.limiters[1] {
+		t.Fatalf("expected transfers of the same principal to share the principal limiter")
+	}
+
+	t1.Release()
+	t1.Release()
+	if _, ok := l.principals[1]; !ok {
+		t.Errorf("principal limiter removed while still in use")
+	}
+
+	t2.Release()
+	if _, ok := l.principals[1]; ok {
+		t.Errorf("principal limiter not removed after all transfers were released")
+	}
+
+	if transfer := NewLimiter(0, 0).Acquire(1); transfer.Limited() {
+		t.Errorf("expected transfer to be unlimited")
+	}
+}
+
+func TestTransfer_Writer(t *testing.T) {
+	const limit = 10 * 1024
+
+	transfer := NewLimiter(limit, 0).Acquire(1)
+	defer transfer.Release()
+
+	// the first burst is available immediately, the remaining data has to wait.
+	data := bytes.Repeat([]byte("x"), 2*limit)
+	buf := &bytes.Buffer{}
+
+	start := time.Now()
+	n, err := transfer.Writer(context.Background(), buf).Write(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != len(data) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("data wasn't written completely (%d of %d bytes)", n, len(data))
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("write wasn't throttled (took %s)", elapsed)
+	}
+}
+
+func TestTransfer_ReaderCanceled(t *testing.T) {
+	transfer := NewLimiter(1024, 0).Acquire(1)
+	defer transfer.Release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := transfer.Reader(ctx, bytes.NewReader(bytes.Repeat([]byte("x"), 4096)))
+	if _, err := io.ReadAll(r); err == nil {
+		t.Errorf("expected read to fail for canceled context")
+	}
+}
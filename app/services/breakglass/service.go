@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package breakglass
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// MethodBreakGlass is the audit log method recorded when an admin activates break-glass access.
+	MethodBreakGlass = "BREAK_GLASS"
+
+	// MethodBreakGlassEnd is the audit log method recorded when an admin deactivates break-glass access.
+	MethodBreakGlassEnd = "BREAK_GLASS_END"
+
+	// cacheDuration is how long the break-glass access of a principal is cached.
+	// Activation and deactivation evict the cache of this instance immediately,
+	// other instances pick up the change once their cached entry expires.
+	cacheDuration = 15 * time.Second
+)
+
+// Service manages the break-glass access of instance admins.
+// If break-glass mode is enabled, admins only have access to the spaces they are members of,
+// unless they activated break-glass access with a justification. The access expires automatically.
+type Service struct {
+	enabled       bool
+	window        time.Duration
+	auditEnabled  bool
+	settings      *settings.Service
+	auditLogStore store.AuditLogStore
+	reporter      *systemevents.Reporter
+	cache         *cache.TTLCache[int64, *types.BreakGlass]
+}
+
+func NewService(
+	config *types.Config,
+	settings *settings.Service,
+	auditLogStore store.AuditLogStore,
+	reporter *systemevents.Reporter,
+) *Service {
+	return &Service{
+		enabled:       config.BreakGlass.Enabled,
+		window:        config.BreakGlass.Window,
+		auditEnabled:  config.Audit.Enabled,
+		settings:      settings,
+		auditLogStore: auditLogStore,
+		reporter:      reporter,
+		cache:         cache.New[int64, *types.BreakGlass](breakGlassGetter{settings: settings}, cacheDuration),
+	}
+}
+
+// Enabled returns true if instance admins require break-glass access to access all spaces.
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// Active returns the break-glass access of the principal if it's currently active, nil otherwise.
+// It's called for every admin authorization check, so the stored access is read through a cache.
+// The expiry is evaluated on every call, so a cached access never outlives its window.
+func (s *Service) Active(ctx context.Context, principalID int64) (*types.BreakGlass, error) {
+	breakGlass, err := s.cache.Get(ctx, principalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if breakGlass == nil || !breakGlass.IsActive(time.Now().UnixMilli()) {
+		return nil, nil //nolint:nilnil
+	}
+
+	return breakGlass, nil
+}
+
+// Activate activates the break-glass access of the principal for the configured window.
+// Activating it again restarts the window with the new justification.
+func (s *Service) Activate(
+	ctx context.Context,
+	principal *types.Principal,
+	justification string,
+	accessor types.AuditAccessor,
+) (*types.BreakGlass, error) {
+	now := time.Now()
+	breakGlass := &types.BreakGlass{
+		Justification: justification,
+		Activated:     now.UnixMilli(),
+		Expires:       now.Add(s.window).UnixMilli(),
+	}
+
+	if err := s.settings.SetBreakGlass(ctx, principal.ID, breakGlass); err != nil {
+		return nil, err
+	}
+
+	s.cache.Evict(principal.ID)
+
+	log.Ctx(ctx).Warn().
+		Str("principal_uid", principal.UID).
+		Str("justification", justification).
+		Time("expires", time.UnixMilli(breakGlass.Expires)).
+		Msg("break-glass access activated")
+
+	s.reporter.BreakGlass(ctx, principal, breakGlass)
+	s.recordActivation(ctx, principal, breakGlass, accessor)
+
+	return breakGlass, nil
+}
+
+// Deactivate ends the break-glass access of the principal before it expires.
+func (s *Service) Deactivate(ctx context.Context, principal *types.Principal, accessor types.AuditAccessor) error {
+	if err := s.settings.DeleteBreakGlass(ctx, principal.ID); err != nil {
+		return err
+	}
+
+	s.cache.Evict(principal.ID)
+
+	log.Ctx(ctx).Info().
+		Str("principal_uid", principal.UID).
+		Msg("break-glass access deactivated")
+
+	s.recordDeactivation(ctx, principal, accessor)
+
+	return nil
+}
+
+// recordActivation records the activation in the audit log, including the justification.
+// Failures are only logged because the system event was reported already.
+func (s *Service) recordActivation(
+	ctx context.Context,
+	principal *types.Principal,
+	breakGlass *types.BreakGlass,
+	accessor types.AuditAccessor,
+) {
+	if !s.auditEnabled {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("justification", breakGlass.Justification)
+	query.Set("expires", fmt.Sprint(breakGlass.Expires))
+
+	err := s.auditLogStore.Create(ctx,
+		types.NewAuditLog(accessor, principal.ID, MethodBreakGlass, query, breakGlass.Activated))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("principal_uid", principal.UID).
+			Msg("failed to record break-glass activation in the audit log")
+	}
+}
+
+// recordDeactivation records the early deactivation of the break-glass access in the audit log.
+func (s *Service) recordDeactivation(
+	ctx context.Context,
+	principal *types.Principal,
+	accessor types.AuditAccessor,
+) {
+	if !s.auditEnabled {
+		return
+	}
+
+	err := s.auditLogStore.Create(ctx,
+		types.NewAuditLog(accessor, principal.ID, MethodBreakGlassEnd, nil, time.Now().UnixMilli()))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("principal_uid", principal.UID).
+			Msg("failed to record break-glass deactivation in the audit log")
+	}
+}
+
+// breakGlassGetter loads the break-glass access of a principal for the cache.
+type breakGlassGetter struct {
+	settings *settings.Service
+}
+
+func (g breakGlassGetter) Find(ctx context.Context, principalID int64) (*types.BreakGlass, error) {
+	return g.settings.BreakGlass(ctx, principalID)
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package breakglass
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// countingSettingsStore keeps the settings in memory and counts the reads.
+type countingSettingsStore struct {
+	store.SettingsStore
+	values map[int64]json.RawMessage
+	finds  int
+}
+
+func (s *countingSettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	_ string,
+) (json.RawMessage, error) {
+	s.finds++
+	value, ok := s.values[scopeID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+func (s *countingSettingsStore) Upsert(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	_ string,
+	value json.RawMessage,
+	_ int64,
+) error {
+	s.values[scopeID] = value
+	return nil
+}
+
+func (s *countingSettingsStore) Delete(_ context.Context, _ enum.SettingsScope, scopeID int64, _ string) error {
+	delete(s.values, scopeID)
+	return nil
+}
+
+// memAuditLogStore keeps the audit logs in memory.
+type memAuditLogStore struct {
+	store.AuditLogStore
+	logs []*types.AuditLog
+}
+
+func (s *memAuditLogStore) Create(_ context.Context, auditLog *types.AuditLog) error {
+	s.logs = append(s.logs, auditLog)
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:               events.ModeInMemory,
+		MaxStreamLength:    100,
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    1,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+	reporter, err := systemevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+
+	config := &types.Config{}
+	config.BreakGlass.Enabled = true
+	config.BreakGlass.Window = time.Hour
+	config.Audit.Enabled = true
+
+	settingsStore := &countingSettingsStore{values: map[int64]json.RawMessage{}}
+	auditLogStore := &memAuditLogStore{}
+	service := NewService(config, settings.NewService(settingsStore, nil), auditLogStore, reporter)
+	t.Cleanup(service.cache.Stop)
+
+	admin := &types.Principal{ID: 1, UID: "admin", Admin: true, Type: enum.PrincipalTypeUser}
+	accessor := types.AuditAccessor{
+		RequestID: "request", Path: "/api/v1/user/break-glass", RemoteAddr: "127.0.0.1", UserAgent: "test"}
+
+	// the access is read from the store once, the following checks are served by the cache
+	for i := 0; i < 3; i++ {
+		breakGlass, err := service.Active(ctx, admin.ID)
+		if err != nil {
+			t.Fatalf("failed to check break-glass access: %v", err)
+		}
+		if breakGlass != nil {
+			t.Fatalf("expected no active break-glass access, got %+v", breakGlass)
+		}
+	}
+	if settingsStore.finds != 1 {
+		t.Errorf("expected the store to be read once, got %d reads", settingsStore.finds)
+	}
+
+	// activation evicts the cached access, so it's active immediately
+	if _, err = service.Activate(ctx, admin, "incident", accessor); err != nil {
+		t.Fatalf("failed to activate break-glass access: %v", err)
+	}
+	breakGlass, err := service.Active(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("failed to check break-glass access: %v", err)
+	}
+	if breakGlass == nil || breakGlass.Justification != "incident" {
+		t.Fatalf("expected active break-glass access, got %+v", breakGlass)
+	}
+
+	// deactivation evicts the cached access and is recorded in the audit log
+	if err = service.Deactivate(ctx, admin, accessor); err != nil {
+		t.Fatalf("failed to deactivate break-glass access: %v", err)
+	}
+	breakGlass, err = service.Active(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("failed to check break-glass access: %v", err)
+	}
+	if breakGlass != nil {
+		t.Fatalf("expected no active break-glass access after deactivation, got %+v", breakGlass)
+	}
+
+	if len(auditLogStore.logs) != 2 {
+		t.Fatalf("expected 2 audit logs, got %d", len(auditLogStore.logs))
+	}
+	if got := auditLogStore.logs[0].Method; got != MethodBreakGlass {
+		t.Errorf("expected activation to be recorded as %s, got %s", MethodBreakGlass, got)
+	}
+	deactivation := auditLogStore.logs[1]
+	if deactivation.Method != MethodBreakGlassEnd {
+		t.Errorf("expected deactivation to be recorded as %s, got %s", MethodBreakGlassEnd, deactivation.Method)
+	}
+	if deactivation.PrincipalID == nil || *deactivation.PrincipalID != admin.ID ||
+		deactivation.RequestID != accessor.RequestID || deactivation.Path != accessor.Path ||
+		deactivation.RemoteAddr != accessor.RemoteAddr {
+		t.Errorf("unexpected deactivation audit log: %+v", deactivation)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDeletedUsers        = "gitness:cleanup:deleted-users"
+	jobCronDeletedUsers        = "40 1 * * *" // At minute 40 past 1 AM every day.
+	jobMaxDurationDeletedUsers = 5 * time.Minute
+)
+
+type deletedUsersCleanupJob struct {
+	retentionTime time.Duration
+
+	principalStore store.PrincipalStore
+	tokenStore     store.TokenStore
+}
+
+func newDeletedUsersCleanupJob(
+	retentionTime time.Duration,
+	principalStore store.PrincipalStore,
+	tokenStore store.TokenStore,
+) *deletedUsersCleanupJob {
+	return &deletedUsersCleanupJob{
+		retentionTime: retentionTime,
+
+		principalStore: principalStore,
+		tokenStore:     tokenStore,
+	}
+}
+
+// Handle purges deleted users that are past the retention time.
+// Purged users are anonymized and their tokens are removed.
+func (j *deletedUsersCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging deleted users older than %s (aka deleted before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	purgedIDs, err := j.principalStore.PurgeDeletedUsers(ctx, olderThan.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+
+	for _, id := range purgedIDs {
+		if err := j.tokenStore.DeleteForPrincipal(ctx, id); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete tokens of purged user %d", id)
+		}
+	}
+
+	result := "no old deleted users found"
+	if len(purgedIDs) > 0 {
+		result = fmt.Sprintf("purged %d deleted users", len(purgedIDs))
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeExpiredMemberships        = "gitness:cleanup:expired-memberships"
+	jobCronExpiredMemberships        = "*/5 * * * *" // At every 5th minute.
+	jobMaxDurationExpiredMemberships = 1 * time.Minute
+
+	expiredMembershipsBatchSize = 100
+
+	// MethodExpire is the audit log method recorded when a temporary access grant expires.
+	MethodExpire = "EXPIRE"
+)
+
+type expiredMembershipsCleanupJob struct {
+	membershipStore store.MembershipStore
+	auditLogStore   store.AuditLogStore
+	auditEnabled    bool
+}
+
+func newExpiredMembershipsCleanupJob(
+	membershipStore store.MembershipStore,
+	auditLogStore store.AuditLogStore,
+	auditEnabled bool,
+) *expiredMembershipsCleanupJob {
+	return &expiredMembershipsCleanupJob{
+		membershipStore: membershipStore,
+		auditLogStore:   auditLogStore,
+		auditEnabled:    auditEnabled,
+	}
+}
+
+// Handle reverts temporary access grants that expired.
+// Members get their previous role back, memberships that only existed because of the grant are removed.
+// NOTE: The authorizer ignores expired grants already, the job only brings the stored memberships in line.
+func (j *expiredMembershipsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now().UnixMilli()
+
+	log.Ctx(ctx).Info().Msg("start reverting expired temporary memberships")
+
+	var reverted, removed int
+	for {
+		memberships, err := j.membershipStore.ListExpired(ctx, now, expiredMembershipsBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list expired memberships: %w", err)
+		}
+
+		for i := range memberships {
+			m := &memberships[i]
+			grantedRole := m.Role
+
+			if m.PreviousRole == "" {
+				if err = j.membershipStore.Delete(ctx, m.MembershipKey); err != nil {
+					return "", fmt.Errorf("failed to delete expired membership: %w", err)
+				}
+				removed++
+			} else {
+				m.Role = m.PreviousRole
+				m.PreviousRole = ""
+				m.Expires = nil
+				if err = j.membershipStore.Update(ctx, m); err != nil {
+					return "", fmt.Errorf("failed to revert expired membership: %w", err)
+				}
+				reverted++
+			}
+
+			j.recordExpiry(ctx, m, grantedRole)
+		}
+
+		if len(memberships) < expiredMembershipsBatchSize {
+			break
+		}
+	}
+
+	result := "no expired memberships found"
+	if reverted+removed > 0 {
+		result = fmt.Sprintf("reverted %d and removed %d expired memberships", reverted, removed)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// recordExpiry records the expiry of a temporary access grant in the audit log.
+// Failures are only logged because the membership has been reverted already.
+func (j *expiredMembershipsCleanupJob) recordExpiry(
+	ctx context.Context,
+	m *types.Membership,
+	grantedRole enum.MembershipRole,
+) {
+	if !j.auditEnabled {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("space_id", fmt.Sprint(m.SpaceID))
+	query.Set("principal_id", fmt.Sprint(m.PrincipalID))
+	query.Set("role", string(grantedRole))
+
+	principalID := m.PrincipalID
+	err := j.auditLogStore.Create(ctx, &types.AuditLog{
+		RequestID:   jobTypeExpiredMemberships,
+		PrincipalID: &principalID,
+		Method:      MethodExpire,
+		Path:        "/memberships/grant",
+		Query:       query.Encode(),
+		UserAgent:   "cleanup",
+		Status:      http.StatusOK,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("space_id", m.SpaceID).
+			Int64("principal_id", m.PrincipalID).
+			Msg("failed to record membership grant expiry in the audit log")
+	}
+}
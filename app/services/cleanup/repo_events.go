@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeRepoEvents        = "gitness:cleanup:repo-events"
+	jobCronRepoEvents        = "7 * * * *" // At minute 7 past every hour.
+	jobMaxDurationRepoEvents = 1 * time.Minute
+)
+
+type repoEventsCleanupJob struct {
+	retentionTime time.Duration
+
+	repoEventStore store.RepoEventStore
+}
+
+func newRepoEventsCleanupJob(
+	retentionTime time.Duration,
+	repoEventStore store.RepoEventStore,
+) *repoEventsCleanupJob {
+	return &repoEventsCleanupJob{
+		retentionTime: retentionTime,
+
+		repoEventStore: repoEventStore,
+	}
+}
+
+// Handle purges old repo events that are past the retention time.
+func (j *repoEventsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging repo events older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.repoEventStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old repo events: %w", err)
+	}
+
+	result := "no old repo events found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d repo events", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -28,6 +28,14 @@ import (
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	DeletedUsersRetentionTime        time.Duration
+	SystemEventsRetentionTime        time.Duration
+	RepoEventsRetentionTime          time.Duration
+	IdempotencyKeysRetentionTime     time.Duration
+	AuditLogsRetentionTime           time.Duration
+
+	// AuditEnabled specifies whether cleanup jobs record their changes to access rights in the audit log.
+	AuditEnabled bool
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +49,26 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.DeletedUsersRetentionTime <= 0 {
+		return errors.New("config.DeletedUsersRetentionTime has to be provided")
+	}
+
+	if c.SystemEventsRetentionTime <= 0 {
+		return errors.New("config.SystemEventsRetentionTime has to be provided")
+	}
+
+	if c.RepoEventsRetentionTime <= 0 {
+		return errors.New("config.RepoEventsRetentionTime has to be provided")
+	}
+
+	if c.IdempotencyKeysRetentionTime <= 0 {
+		return errors.New("config.IdempotencyKeysRetentionTime has to be provided")
+	}
+
+	if c.AuditLogsRetentionTime <= 0 {
+		return errors.New("config.AuditLogsRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -52,6 +80,12 @@ type Service struct {
 	webhookExecutionStore store.WebhookExecutionStore
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
+	principalStore        store.PrincipalStore
+	systemEventStore      store.SystemEventStore
+	repoEventStore        store.RepoEventStore
+	idempotencyKeyStore   store.IdempotencyKeyStore
+	auditLogStore         store.AuditLogStore
+	membershipStore       store.MembershipStore
 	repoCtrl              *repo.Controller
 }
 
@@ -62,6 +96,12 @@ func NewService(
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	systemEventStore store.SystemEventStore,
+	repoEventStore store.RepoEventStore,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	auditLogStore store.AuditLogStore,
+	membershipStore store.MembershipStore,
 	repoCtrl *repo.Controller,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
@@ -76,6 +116,12 @@ func NewService(
 		webhookExecutionStore: webhookExecutionStore,
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
+		principalStore:        principalStore,
+		systemEventStore:      systemEventStore,
+		repoEventStore:        repoEventStore,
+		idempotencyKeyStore:   idempotencyKeyStore,
+		auditLogStore:         auditLogStore,
+		membershipStore:       membershipStore,
 		repoCtrl:              repoCtrl,
 	}, nil
 }
@@ -126,6 +172,72 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeDeletedUsers,
+		jobTypeDeletedUsers,
+		jobCronDeletedUsers,
+		jobMaxDurationDeletedUsers,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule deleted user cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeSystemEvents,
+		jobTypeSystemEvents,
+		jobCronSystemEvents,
+		jobMaxDurationSystemEvents,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule system events cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeRepoEvents,
+		jobTypeRepoEvents,
+		jobCronRepoEvents,
+		jobMaxDurationRepoEvents,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule repo events cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeIdempotencyKeys,
+		jobTypeIdempotencyKeys,
+		jobCronIdempotencyKeys,
+		jobMaxDurationIdempotencyKeys,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule idempotency keys cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeAuditLogs,
+		jobTypeAuditLogs,
+		jobCronAuditLogs,
+		jobMaxDurationAuditLogs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule audit logs cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeExpiredMemberships,
+		jobTypeExpiredMemberships,
+		jobCronExpiredMemberships,
+		jobMaxDurationExpiredMemberships,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule expired memberships cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +272,67 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeDeletedUsers,
+		newDeletedUsersCleanupJob(
+			s.config.DeletedUsersRetentionTime,
+			s.principalStore,
+			s.tokenStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for deleted users cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeSystemEvents,
+		newSystemEventsCleanupJob(
+			s.config.SystemEventsRetentionTime,
+			s.systemEventStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for system events cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeRepoEvents,
+		newRepoEventsCleanupJob(
+			s.config.RepoEventsRetentionTime,
+			s.repoEventStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for repo events cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeIdempotencyKeys,
+		newIdempotencyKeysCleanupJob(
+			s.config.IdempotencyKeysRetentionTime,
+			s.idempotencyKeyStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for idempotency keys cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeAuditLogs,
+		newAuditLogsCleanupJob(
+			s.config.AuditLogsRetentionTime,
+			s.auditLogStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for audit logs cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeExpiredMemberships,
+		newExpiredMembershipsCleanupJob(
+			s.membershipStore,
+			s.auditLogStore,
+			s.config.AuditEnabled,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for expired memberships cleanup: %w", err)
+	}
 	return nil
 }
@@ -33,6 +33,12 @@ func ProvideService(
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	systemEventStore store.SystemEventStore,
+	repoEventStore store.RepoEventStore,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	auditLogStore store.AuditLogStore,
+	membershipStore store.MembershipStore,
 	repoCtrl *repo.Controller,
 ) (*Service, error) {
 	return NewService(
@@ -42,6 +48,12 @@ func ProvideService(
 		webhookExecutionStore,
 		tokenStore,
 		repoStore,
+		principalStore,
+		systemEventStore,
+		repoEventStore,
+		idempotencyKeyStore,
+		auditLogStore,
+		membershipStore,
 		repoCtrl,
 	)
 }
@@ -369,6 +369,74 @@ func (s *Service) Evaluate(
 	}, nil
 }
 
+// Owners returns the owners of every CODEOWNERS entry that applies to the changes of the pull request.
+// User groups are expanded to their members and the author of the pull request is excluded.
+// Owners that can't be resolved are skipped, and so are entries left without any owner.
+func (s *Service) Owners(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) ([][]types.PrincipalInfo, error) {
+	owners, err := s.getApplicableCodeOwnersForPR(ctx, repo, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get codeOwners: %w", err)
+	}
+
+	if owners == nil {
+		return nil, nil
+	}
+
+	entryOwners := make([][]types.PrincipalInfo, 0, len(owners.Entries))
+	for _, entry := range owners.Entries {
+		var principals []*types.Principal
+		for _, owner := range entry.Owners {
+			if strings.HasPrefix(owner, userGroupPrefixMarker) {
+				usrgrp, err := s.userGroupResolver.Resolve(ctx, owner[1:])
+				if errors.Is(err, usergroup.ErrNotFound) {
+					log.Ctx(ctx).Debug().Msgf("usergroup %q not found hence skipping for code owner", owner)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("error resolving usergroup :%w", err)
+				}
+
+				members, err := s.principalStore.FindManyByUID(ctx, usrgrp.Users)
+				if err != nil {
+					return nil, fmt.Errorf("error finding usergroup members: %w", err)
+				}
+
+				principals = append(principals, members...)
+				continue
+			}
+
+			principal, err := s.principalStore.FindByEmail(ctx, owner)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				log.Ctx(ctx).Debug().Msgf("user %q not found in database hence skipping for code owner", owner)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error finding user by email : %w", err)
+			}
+
+			principals = append(principals, principal)
+		}
+
+		infos := make([]types.PrincipalInfo, 0, len(principals))
+		for _, principal := range principals {
+			if principal.ID == pr.CreatedBy {
+				continue
+			}
+			infos = append(infos, *principal.ToPrincipalInfo())
+		}
+
+		if len(infos) > 0 {
+			entryOwners = append(entryOwners, infos)
+		}
+	}
+
+	return entryOwners, nil
+}
+
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
 	owner string,
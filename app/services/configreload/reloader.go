@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configreload reloads a subset of the configuration at runtime.
+package configreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotAvailable is returned if a reload is requested before the reloader was started.
+var ErrNotAvailable = errors.New("config reload is not available")
+
+// LoadFunc loads the latest configuration.
+type LoadFunc func() (*types.Config, error)
+
+// ApplyFunc applies the reloadable settings of a reloaded configuration.
+type ApplyFunc func(ctx context.Context, config *types.Config) error
+
+type applier struct {
+	name  string
+	apply ApplyFunc
+}
+
+// Result is the outcome of a config reload.
+type Result struct {
+	// Applied are the names of the settings that were applied.
+	Applied []string `json:"applied"`
+	// Failed are the names of the settings that couldn't be applied with the corresponding error.
+	Failed   map[string]string `json:"failed,omitempty"`
+	Reloaded int64             `json:"reloaded"`
+}
+
+// Reloader reloads the configuration (on SIGHUP or on request) and applies the reloadable settings.
+// Only settings with a registered applier (e.g. log level, rate limits, webhook network allowlist) are
+// changed at runtime, all other changes only take effect after a restart.
+// NOTE: The reloader doesn't change the config that was used to wire the system.
+type Reloader struct {
+	mx       sync.Mutex
+	load     LoadFunc
+	appliers []applier
+}
+
+func NewReloader() *Reloader {
+	return &Reloader{}
+}
+
+// Register registers an applier for a reloadable setting.
+func (r *Reloader) Register(name string, apply ApplyFunc) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.appliers = append(r.appliers, applier{name: name, apply: apply})
+}
+
+// Reload loads the configuration and applies it to all registered appliers.
+// An applier failing doesn't prevent the others from being applied.
+func (r *Reloader) Reload(ctx context.Context) (*Result, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.load == nil {
+		return nil, ErrNotAvailable
+	}
+
+	config, err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	result := &Result{
+		Applied:  make([]string, 0, len(r.appliers)),
+		Reloaded: time.Now().UnixMilli(),
+	}
+
+	for _, a := range r.appliers {
+		if err := a.apply(ctx, config); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("config.setting", a.name).Msg("failed to apply reloaded setting")
+
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[a.name] = err.Error()
+
+			continue
+		}
+
+		result.Applied = append(result.Applied, a.name)
+	}
+
+	log.Ctx(ctx).Info().
+		Strs("config.applied", result.Applied).
+		Int("config.failed", len(result.Failed)).
+		Msg("config reloaded")
+
+	return result, nil
+}
+
+// Run enables reloads using the provided load function and reloads the configuration whenever
+// the process receives a SIGHUP. It blocks until the context is done.
+func (r *Reloader) Run(ctx context.Context, load LoadFunc) error {
+	r.mx.Lock()
+	r.load = load
+	r.mx.Unlock()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sigCh:
+			log.Ctx(ctx).Info().Msg("received SIGHUP, reloading config")
+
+			if _, err := r.Reload(ctx); err != nil {
+				// keep running with the current settings.
+				log.Ctx(ctx).Error().Err(err).Msg("failed to reload config")
+			}
+		}
+	}
+}
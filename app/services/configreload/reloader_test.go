@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configreload
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestReloader_Reload(t *testing.T) {
+	ctx := context.Background()
+	r := NewReloader()
+
+	var applied []string
+	r.Register("debug", func(_ context.Context, config *types.Config) error {
+		if !config.Debug {
+			return errors.New("debug not set")
+		}
+		applied = append(applied, "debug")
+		return nil
+	})
+	r.Register("trace", func(_ context.Context, config *types.Config) error {
+		if !config.Trace {
+			return errors.New("trace not set")
+		}
+		applied = append(applied, "trace")
+		return nil
+	})
+
+	if _, err := r.Reload(ctx); !errors.Is(err, ErrNotAvailable) {
+		t.Fatalf("expected ErrNotAvailable before the reloader runs, got: %v", err)
+	}
+
+	r.load = func() (*types.Config, error) {
+		return &types.Config{Debug: true}, nil
+	}
+
+	result, err := r.Reload(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Applied) != 1 || result.Applied[0] != "debug" {
+		t.Errorf("expected only debug to be applied, got: %v", result.Applied)
+	}
+	if _, ok := result.Failed["trace"]; !ok || len(result.Failed) != 1 {
+		t.Errorf("expected only trace to fail, got: %v", result.Failed)
+	}
+	if len(applied) != 1 {
+		t.Errorf("expected one applier to succeed, got: %v", applied)
+	}
+
+	r.load = func() (*types.Config, error) {
+		return nil, errors.New("invalid config")
+	}
+
+	if _, err = r.Reload(ctx); err == nil {
+		t.Errorf("expected an error for an invalid config")
+	}
+	if len(applied) != 1 {
+		t.Errorf("expected no applier to be called for an invalid config, got: %v", applied)
+	}
+}
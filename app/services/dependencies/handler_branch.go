@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+)
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.scheduleOnPush(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.SHA)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.scheduleOnPush(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.NewSHA)
+}
+
+// scheduleOnPush schedules the dependency detection if the pushed branch is the default branch of the repository.
+func (s *Service) scheduleOnPush(ctx context.Context, repoID int64, ref string, sha string) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository in db: %w", err)
+	}
+
+	branch, ok := strings.CutPrefix(ref, "refs/heads/")
+	if !ok || branch != repo.DefaultBranch {
+		return nil
+	}
+
+	return s.schedule(ctx, repo.ID, sha)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"regexp"
+	"strings"
+)
+
+// regexpLicenseFile matches the names of files in the repository root that typically contain the license.
+var regexpLicenseFile = regexp.MustCompile(`(?i)^(license|licence|copying|unlicense)([-.](md|txt|rst|mit|apache))?$`)
+
+// regexpSPDXIdentifier matches an SPDX license identifier tag, e.g. "SPDX-License-Identifier: MIT".
+var regexpSPDXIdentifier = regexp.MustCompile(`SPDX-License-Identifier:\s*([A-Za-z0-9.+-]+)`)
+
+// licenseHeuristic identifies a license by phrases that are all present in its (normalized) text.
+// The order matters: licenses that quote the title of another license have to come first.
+type licenseHeuristic struct {
+	spdxID  string
+	phrases []string
+}
+
+var licenseHeuristics = []licenseHeuristic{
+	{"AGPL-3.0", []string{"gnu affero general public license version 3"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license version 2.1"}},
+	{"GPL-3.0", []string{"gnu general public license version 3"}},
+	{"GPL-2.0", []string{"gnu general public license version 2"}},
+	{"Apache-2.0", []string{"apache license version 2.0"}},
+	{"MPL-2.0", []string{"mozilla public license version 2.0"}},
+	{"EPL-2.0", []string{"eclipse public license - v 2.0"}},
+	{"BSL-1.0", []string{"boost software license - version 1.0"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms",
+		"neither the name of"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"MIT", []string{"permission is hereby granted, free of charge", "the above copyright notice"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+}
+
+// isLicenseFile returns true if the file at the provided path is a license file in the repository root.
+func isLicenseFile(filePath string) bool {
+	return !strings.Contains(filePath, "/") && regexpLicenseFile.MatchString(filePath)
+}
+
+// detectLicense returns the SPDX identifier of the license contained in the provided text,
+// or an empty string if the license isn't recognized.
+func detectLicense(content []byte) string {
+	if match := regexpSPDXIdentifier.FindSubmatch(content); match != nil {
+		return string(match[1])
+	}
+
+	// normalize whitespace and case, license texts are often wrapped at arbitrary places.
+	text := strings.Join(strings.Fields(strings.ToLower(string(content))), " ")
+
+	for _, heuristic := range licenseHeuristics {
+		if containsAll(text, heuristic.phrases) {
+			return heuristic.spdxID
+		}
+	}
+
+	return ""
+}
+
+func containsAll(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if !strings.Contains(text, phrase) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"testing"
+)
+
+func TestDetectLicense(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "mit",
+			content: "MIT License\n\nCopyright (c) 2023 Example\n\nPermission is hereby granted, free of charge,\n" +
+				"to any person obtaining a copy of this software... The above copyright notice and this permission",
+			expected: "MIT",
+		},
+		{
+			name:     "apache",
+			content:  "                                 Apache License\n                           Version 2.0, January 2004",
+			expected: "Apache-2.0",
+		},
+		{
+			name: "gpl-3 mentioning lgpl",
+			content: "GNU GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007\n... use the GNU Lesser General Public" +
+				" License instead of this License.",
+			expected: "GPL-3.0",
+		},
+		{
+			name:     "lgpl-2.1",
+			content:  "GNU LESSER GENERAL PUBLIC LICENSE\n Version 2.1, February 1999",
+			expected: "LGPL-2.1",
+		},
+		{
+			name: "bsd-3",
+			content: "Redistribution and use in source and binary forms, with or without modification...\n" +
+				"3. Neither the name of the copyright holder nor the names of its contributors",
+			expected: "BSD-3-Clause",
+		},
+		{
+			name:     "spdx",
+			content:  "SPDX-License-Identifier: MPL-2.0",
+			expected: "MPL-2.0",
+		},
+		{
+			name:     "unknown",
+			content:  "All rights reserved.",
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := detectLicense([]byte(test.content)); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsLicenseFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "LICENSE", expected: true},
+		{path: "License.md", expected: true},
+		{path: "COPYING", expected: true},
+		{path: "LICENSE-APACHE", expected: true},
+		{path: "docs/LICENSE", expected: false},
+		{path: "license.go", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if got := isLicenseFile(test.path); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// manifestParser parses the content of a dependency manifest file into the list of declared dependencies.
+type manifestParser func(content []byte) ([]dependency, error)
+
+type dependency struct {
+	name    string
+	version string
+}
+
+// manifestParsers contains the supported dependency manifests, keyed by file name.
+var manifestParsers = map[string]struct {
+	ecosystem enum.DependencyEcosystem
+	parse     manifestParser
+}{
+	"go.mod":           {ecosystem: enum.DependencyEcosystemGo, parse: parseGoMod},
+	"package.json":     {ecosystem: enum.DependencyEcosystemNPM, parse: parsePackageJSON},
+	"requirements.txt": {ecosystem: enum.DependencyEcosystemPyPI, parse: parseRequirementsTxt},
+}
+
+// regexpExcludedPath matches paths of vendored and third party files which manifests are ignored.
+var regexpExcludedPath = regexp.MustCompile(
+	`(^|/)(vendor|node_modules|bower_components|third_party|testdata|\.git)/`)
+
+// isManifest returns true if the file at the provided path is a supported dependency manifest.
+func isManifest(filePath string) bool {
+	if regexpExcludedPath.MatchString(filePath) {
+		return false
+	}
+
+	_, ok := manifestParsers[path.Base(filePath)]
+	return ok
+}
+
+// parseManifest parses the dependency manifest at the provided path.
+func parseManifest(filePath string, content []byte) ([]types.Dependency, error) {
+	parser, ok := manifestParsers[path.Base(filePath)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dependency manifest %q", filePath)
+	}
+
+	deps, err := parser.parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dependency manifest %q: %w", filePath, err)
+	}
+
+	result := make([]types.Dependency, len(deps))
+	for i, dep := range deps {
+		result[i] = types.Dependency{
+			Manifest:  filePath,
+			Ecosystem: parser.ecosystem,
+			Name:      dep.name,
+			Version:   dep.version,
+		}
+	}
+
+	return result, nil
+}
+
+// parseGoMod returns the modules required by a go.mod file.
+// Replace directives aren't applied, the dependencies are reported as required.
+func parseGoMod(content []byte) ([]dependency, error) {
+	var deps []dependency
+
+	inRequireBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequireBlock && fields[0] == ")":
+			inRequireBlock = false
+			continue
+		case inRequireBlock:
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequireBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid require directive %q", strings.TrimSpace(line))
+		}
+
+		deps = append(deps, dependency{
+			name:    strings.Trim(fields[0], `"`),
+			version: fields[1],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	if inRequireBlock {
+		return nil, fmt.Errorf("unterminated require block")
+	}
+
+	return deps, nil
+}
+
+// parsePackageJSON returns the regular and the development dependencies of a package.json file.
+func parsePackageJSON(content []byte) ([]dependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal package.json: %w", err)
+	}
+
+	deps := make([]dependency, 0, len(pkg.Dependencies)+len(pkg.DevDependencies))
+	for _, m := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+		for name, version := range m {
+			deps = append(deps, dependency{name: name, version: version})
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool { return deps[i].name < deps[j].name })
+
+	return deps, nil
+}
+
+// regexpRequirement matches a requirement specifier of a pip requirements file,
+// for example "requests[security] >= 2.8.1, == 2.8.*".
+var regexpRequirement = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*([^;]*)`)
+
+// parseRequirementsTxt returns the packages listed in a pip requirements file.
+// Options, includes of other files and URL or path requirements are skipped.
+// Versions pinned with "==" are reported without the operator, other constraints are reported as written.
+func parseRequirementsTxt(content []byte) ([]dependency, error) {
+	var deps []dependency
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+
+		match := regexpRequirement.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		version := strings.ReplaceAll(strings.TrimSpace(match[3]), " ", "")
+		if pinned, ok := strings.CutPrefix(version, "=="); ok && !strings.ContainsAny(pinned, ",*") {
+			version = pinned
+		}
+
+		deps = append(deps, dependency{
+			name:    match[1],
+			version: version,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read requirements.txt: %w", err)
+	}
+
+	return deps, nil
+}
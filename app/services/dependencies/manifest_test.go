@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGoMod(t *testing.T) {
+	content := `module github.com/example/app
+
+go 1.20
+
+require github.com/single/dep v1.2.3
+
+require (
+	github.com/a/b v0.1.0 // indirect
+	// comment
+	"github.com/quoted/dep" v2.0.0+incompatible
+)
+
+replace github.com/a/b => ../b
+`
+
+	deps, err := parseGoMod([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dependency{
+		{name: "github.com/single/dep", version: "v1.2.3"},
+		{name: "github.com/a/b", version: "v0.1.0"},
+		{name: "github.com/quoted/dep", version: "v2.0.0+incompatible"},
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Errorf("expected %+v, got %+v", expected, deps)
+	}
+
+	if _, err := parseGoMod([]byte("require (\n\tgithub.com/a/b v0.1.0\n")); err == nil {
+		t.Error("expected error for unterminated require block")
+	}
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	content := `{
+  "name": "app",
+  "dependencies": {"react": "^18.2.0", "axios": "1.6.0"},
+  "devDependencies": {"typescript": "~5.3.0"}
+}`
+
+	deps, err := parsePackageJSON([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dependency{
+		{name: "axios", version: "1.6.0"},
+		{name: "react", version: "^18.2.0"},
+		{name: "typescript", version: "~5.3.0"},
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Errorf("expected %+v, got %+v", expected, deps)
+	}
+
+	if _, err := parsePackageJSON([]byte("{")); err == nil {
+		t.Error("expected error for invalid json")
+	}
+}
+
+func TestParseRequirementsTxt(t *testing.T) {
+	content := `# production dependencies
+-r base.txt
+--index-url https://pypi.example.com/simple
+requests[security]==2.31.0
+Django >= 4.2, < 5.0
+numpy
+flask==2.* ; python_version >= "3.8"
+git+https://github.com/example/lib.git#egg=lib
+./local/package
+`
+
+	deps, err := parseRequirementsTxt([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dependency{
+		{name: "requests", version: "2.31.0"},
+		{name: "Django", version: ">=4.2,<5.0"},
+		{name: "numpy", version: ""},
+		{name: "flask", version: "==2.*"},
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Errorf("expected %+v, got %+v", expected, deps)
+	}
+}
+
+func TestIsManifest(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "go.mod", expected: true},
+		{path: "web/package.json", expected: true},
+		{path: "tools/requirements.txt", expected: true},
+		{path: "vendor/github.com/lib/go.mod", expected: false},
+		{path: "web/node_modules/react/package.json", expected: false},
+		{path: "go.sum", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if got := isManifest(test.path); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
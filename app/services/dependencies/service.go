@@ -0,0 +1,329 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:dependencies"
+
+	jobType        = "repo-dependencies"
+	jobMaxRetries  = 2
+	jobMaxDuration = 5 * time.Minute
+
+	// maxFileSize is the maximum size of a manifest or license file that is analyzed.
+	maxFileSize = 1 << 20
+	// maxManifests is the maximum number of dependency manifests that are parsed per commit.
+	maxManifests = 100
+	// maxDependencies is the maximum number of dependencies that are stored per repository.
+	maxDependencies = 5000
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service detects the license and parses the dependency manifests of the default branch of repositories
+// in background jobs that are triggered by pushes to the default branch.
+type Service struct {
+	tx                dbtx.Transactor
+	git               git.Interface
+	repoStore         store.RepoStore
+	dependenciesStore store.RepoDependenciesStore
+	scheduler         *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	tx dbtx.Transactor,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	dependenciesStore store.RepoDependenciesStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided dependencies service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		tx:                tx,
+		git:               git,
+		repoStore:         repoStore,
+		dependenciesStore: dependenciesStore,
+		scheduler:         scheduler,
+	}
+
+	err := executor.Register(jobType, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register job handler for dependency detection: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for dependencies: %w", err)
+	}
+
+	return service, nil
+}
+
+type jobInput struct {
+	RepoID int64  `json:"repo_id"`
+	SHA    string `json:"sha"`
+}
+
+// Find returns the license and the dependencies of the repository. If they aren't detected for the provided
+// commit SHA a background job that detects them is scheduled. The returned boolean value
+// is true if the returned result is stale (or missing) and the detection is in progress.
+func (s *Service) Find(ctx context.Context, repo *types.Repository, sha string) (*types.RepoDependencies, bool, error) {
+	dependencies, err := s.dependenciesStore.Find(ctx, repo.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, false, fmt.Errorf("failed to find repo dependencies: %w", err)
+	}
+
+	if dependencies == nil {
+		dependencies = &types.RepoDependencies{RepoID: repo.ID}
+	}
+
+	if dependencies.Dependencies == nil {
+		dependencies.Dependencies = []types.Dependency{}
+	}
+
+	if dependencies.SHA == sha {
+		return dependencies, false, nil
+	}
+
+	if err := s.schedule(ctx, repo.ID, sha); err != nil {
+		return nil, false, err
+	}
+
+	return dependencies, true, nil
+}
+
+func (s *Service) schedule(ctx context.Context, repoID int64, sha string) error {
+	data, err := json.Marshal(jobInput{RepoID: repoID, SHA: sha})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobType + "-" + strconv.FormatInt(repoID, 10) + "-" + sha,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the dependencies of the commit are already being detected.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule dependency detection job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle detects the license and the dependencies of the commit provided in the job input and stores them.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input jobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "repository not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	out, err := s.git.ListTreeFiles(ctx, &git.ListTreeFilesParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		GitREF:     input.SHA,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list tree files: %w", err)
+	}
+
+	result := &types.RepoDependencies{
+		RepoID:       repo.ID,
+		SHA:          input.SHA,
+		Dependencies: []types.Dependency{},
+	}
+
+	licenseFiles, manifests := selectFiles(out.Files)
+
+	for _, file := range licenseFiles {
+		content, err := s.readFile(ctx, repo, file.SHA)
+		if err != nil {
+			return "", err
+		}
+
+		if result.License = detectLicense(content); result.License != "" {
+			break
+		}
+	}
+
+	for _, file := range manifests {
+		content, err := s.readFile(ctx, repo, file.SHA)
+		if err != nil {
+			return "", err
+		}
+
+		deps, err := parseManifest(file.Path, content)
+		if err != nil {
+			// a broken manifest shouldn't prevent detection of the dependencies declared in the other ones.
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Str("sha", input.SHA).
+				Msg("failed to parse dependency manifest")
+			continue
+		}
+
+		result.Dependencies = append(result.Dependencies, deps...)
+	}
+
+	if len(result.Dependencies) > maxDependencies {
+		result.Dependencies = result.Dependencies[:maxDependencies]
+	}
+
+	result.Computed = time.Now().UnixMilli()
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		return s.dependenciesStore.Upsert(ctx, result)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store repo dependencies: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("sha", input.SHA).
+		Str("license", result.License).
+		Msgf("detected %d dependencies in %d manifests", len(result.Dependencies), len(manifests))
+
+	return "", nil
+}
+
+// selectFiles returns the license files and the dependency manifests that should be analyzed.
+// Files that are too large are skipped and the manifests closest to the repository root are preferred.
+func selectFiles(files []gittypes.TreeFile) ([]gittypes.TreeFile, []gittypes.TreeFile) {
+	var licenseFiles, manifests []gittypes.TreeFile
+	for _, file := range files {
+		if file.Size > maxFileSize {
+			continue
+		}
+
+		switch {
+		case isLicenseFile(file.Path):
+			licenseFiles = append(licenseFiles, file)
+		case isManifest(file.Path):
+			manifests = append(manifests, file)
+		}
+	}
+
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return strings.Count(manifests[i].Path, "/") < strings.Count(manifests[j].Path, "/")
+	})
+
+	if len(manifests) > maxManifests {
+		manifests = manifests[:maxManifests]
+	}
+
+	return licenseFiles, manifests
+}
+
+func (s *Service) readFile(ctx context.Context, repo *types.Repository, sha string) ([]byte, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: git.CreateReadParams(repo),
+		SHA:        sha,
+		SizeLimit:  maxFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s: %w", sha, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", sha, err)
+	}
+
+	return content, nil
+}
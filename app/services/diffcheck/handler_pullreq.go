@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffcheck
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+)
+
+func (s *Service) handleEventCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.CheckPullReq(ctx, event.Payload.PullReqID, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleEventReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.CheckPullReq(ctx, event.Payload.PullReqID, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	return s.CheckPullReq(ctx, event.Payload.PullReqID, event.Payload.NewSHA)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffcheck
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/git/parser"
+)
+
+// secretRule detects a kind of secret in a line of text.
+type secretRule struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+var secretRules = []secretRule{
+	{name: "AWS access key", regex: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "Private key", regex: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+	{name: "GitHub token", regex: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{name: "GitLab token", regex: regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20,}`)},
+	{name: "Slack token", regex: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9\-]{10,}`)},
+	{name: "Stripe key", regex: regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{20,}\b`)},
+	{name: "Google API key", regex: regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}`)},
+	{
+		// quoted values assigned to secret-like names, e.g. `api_key = "..."` or `"password": "..."`.
+		name: "Hardcoded credential",
+		regex: regexp.MustCompile(
+			`(?i)(?:api[_\-]?key|secret|passwd|password|access[_\-]?token)["']?\s*[:=]\s*["'][A-Za-z0-9+/_\-]{16,}["']`),
+	},
+}
+
+// secretFinding is a secret found in a line added by a patch.
+type secretFinding struct {
+	Path string
+	Line int
+	Rule string
+}
+
+// findSecrets returns the secrets found in the lines added by the patch of the file.
+// Removed and unchanged lines are ignored, so only newly introduced secrets are reported.
+func findSecrets(path string, patch []byte) []secretFinding {
+	var findings []secretFinding
+
+	inHunk := false
+	newLine := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(patch))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(patch)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "@@") {
+			hunk, ok := parser.ParseDiffHunkHeader(line)
+			inHunk = ok
+			newLine = hunk.NewLine
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '+':
+			if rule, ok := matchSecret(line[1:]); ok {
+				findings = append(findings, secretFinding{Path: path, Line: newLine, Rule: rule})
+			}
+			newLine++
+		case ' ':
+			newLine++
+		}
+	}
+
+	return findings
+}
+
+// matchSecret returns the name of the first rule matching the text.
+func matchSecret(text string) (string, bool) {
+	for _, rule := range secretRules {
+		if rule.regex.MatchString(text) {
+			return rule.name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,332 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:diffcheck"
+
+	// CheckIdentifierSecrets is the identifier of the status check that fails
+	// if the changes of a pull request add secrets.
+	CheckIdentifierSecrets = "gitness-no-new-secrets"
+
+	// CheckIdentifierLargeFiles is the identifier of the status check that fails
+	// if the changes of a pull request add files exceeding the size limit.
+	CheckIdentifierLargeFiles = "gitness-no-large-files"
+
+	// maxFiles is the maximum number of changed files whose size is checked.
+	maxFiles = 1000
+
+	// maxReportedFindings is the maximum number of findings listed in the details of a check.
+	maxReportedFindings = 100
+)
+
+// Service checks the changes of pull requests for added secrets and large files,
+// the results are reported as status checks of the pull request source commit.
+type Service struct {
+	maxFileSize  int64
+	git          git.Interface
+	repoStore    store.RepoStore
+	pullReqStore store.PullReqStore
+	checkStore   store.CheckStore
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	checkStore store.CheckStore,
+) (*Service, error) {
+	service := &Service{
+		maxFileSize:  config.DiffChecks.MaxFileSize,
+		git:          git,
+		repoStore:    repoStore,
+		pullReqStore: pullReqStore,
+		checkStore:   checkStore,
+	}
+
+	if !config.DiffChecks.Enabled {
+		return service, nil
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.handleEventCreated)
+			_ = r.RegisterReopened(service.handleEventReopened)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for diff checks: %w", err)
+	}
+
+	return service, nil
+}
+
+// checkResult is the outcome of checking the changes of a pull request.
+type checkResult struct {
+	Secrets    []secretFinding
+	LargeFiles []largeFile
+	// Truncated is true if there were more changed files than could be checked for their size.
+	Truncated bool
+}
+
+type largeFile struct {
+	Path string
+	Size int64
+}
+
+type changedFile struct {
+	Path string
+	SHA  string
+}
+
+// CheckPullReq checks the changes of the pull request and reports the result as status checks.
+// Nothing is done if the pull request isn't open or its source branch moved past the provided commit.
+func (s *Service) CheckPullReq(ctx context.Context, pullReqID int64, sourceSHA string) error {
+	pr, err := s.pullReqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen || pr.SourceSHA != sourceSHA {
+		// a newer event takes care of the current source commit.
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	result, err := s.check(ctx, repo, pr.MergeBaseSHA, pr.SourceSHA)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("pullreq_id", pr.ID).
+		Str("sha", pr.SourceSHA).
+		Msgf("checked pull request changes, found %d secrets and %d large files",
+			len(result.Secrets), len(result.LargeFiles))
+
+	return s.report(ctx, repo, pr.SourceSHA, result)
+}
+
+// check scans the lines added between the merge base and the source commit for secrets,
+// and the files added or modified for their size.
+func (s *Service) check(
+	ctx context.Context,
+	repo *types.Repository,
+	mergeBaseSHA string,
+	sourceSHA string,
+) (checkResult, error) {
+	readParams := git.CreateReadParams(repo)
+
+	reader := git.NewStreamReader(s.git.Diff(ctx, &git.DiffParams{
+		ReadParams:   readParams,
+		BaseRef:      mergeBaseSHA,
+		HeadRef:      sourceSHA,
+		MergeBase:    true,
+		IncludePatch: true,
+	}))
+
+	var (
+		result  checkResult
+		changed []changedFile
+	)
+
+	for {
+		fileDiff, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return checkResult{}, fmt.Errorf("failed to read next file diff: %w", err)
+		}
+
+		if fileDiff.IsSubmodule || fileDiff.Status == gitenum.FileDiffStatusDeleted {
+			continue
+		}
+
+		if !fileDiff.IsBinary {
+			result.Secrets = append(result.Secrets, findSecrets(fileDiff.Path, fileDiff.Patch)...)
+		}
+
+		// renamed or copied files without changes don't add new content.
+		if fileDiff.SHA == fileDiff.OldSHA {
+			continue
+		}
+		if len(changed) >= maxFiles {
+			result.Truncated = true
+			continue
+		}
+
+		// sizes are resolved after the diff got read completely to not block the diff stream.
+		changed = append(changed, changedFile{Path: fileDiff.Path, SHA: fileDiff.SHA})
+	}
+
+	for _, file := range changed {
+		size, err := s.blobSize(ctx, readParams, file.SHA)
+		if err != nil {
+			return checkResult{}, fmt.Errorf("failed to get size of file %q: %w", file.Path, err)
+		}
+
+		if size > s.maxFileSize {
+			result.LargeFiles = append(result.LargeFiles, largeFile{Path: file.Path, Size: size})
+		}
+	}
+
+	return result, nil
+}
+
+// blobSize returns the size of the blob without reading its content.
+func (s *Service) blobSize(ctx context.Context, readParams git.ReadParams, sha string) (int64, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        sha,
+		SizeLimit:  1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := output.Content.Close(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+	}
+
+	return output.Size, nil
+}
+
+func (s *Service) report(ctx context.Context, repo *types.Repository, sha string, result checkResult) error {
+	secretsStatus := enum.CheckStatusSuccess
+	secretsSummary := "No secrets found in the changes"
+	secretsDetails := &strings.Builder{}
+	if len(result.Secrets) > 0 {
+		secretsStatus = enum.CheckStatusFailure
+		secretsSummary = fmt.Sprintf("Found %d potential secrets in the changes", len(result.Secrets))
+		for i, finding := range result.Secrets {
+			if i == maxReportedFindings {
+				fmt.Fprintf(secretsDetails, "- and %d more\n", len(result.Secrets)-i)
+				break
+			}
+			fmt.Fprintf(secretsDetails, "- `%s` line %d: %s\n", finding.Path, finding.Line, finding.Rule)
+		}
+	}
+
+	err := s.upsertCheck(ctx, repo, sha, CheckIdentifierSecrets, secretsStatus, secretsSummary,
+		secretsDetails.String())
+	if err != nil {
+		return err
+	}
+
+	sizesStatus := enum.CheckStatusSuccess
+	sizesSummary := fmt.Sprintf("No files exceed the size limit of %s", formatSize(s.maxFileSize))
+	sizesDetails := &strings.Builder{}
+	if len(result.LargeFiles) > 0 {
+		sizesStatus = enum.CheckStatusFailure
+		sizesSummary = fmt.Sprintf("Found %d files exceeding the size limit of %s",
+			len(result.LargeFiles), formatSize(s.maxFileSize))
+		for i, file := range result.LargeFiles {
+			if i == maxReportedFindings {
+				fmt.Fprintf(sizesDetails, "- and %d more\n", len(result.LargeFiles)-i)
+				break
+			}
+			fmt.Fprintf(sizesDetails, "- `%s`: %s\n", file.Path, formatSize(file.Size))
+		}
+	}
+	if result.Truncated {
+		fmt.Fprintf(sizesDetails, "\nOnly the first %d changed files were checked.\n", maxFiles)
+	}
+
+	return s.upsertCheck(ctx, repo, sha, CheckIdentifierLargeFiles, sizesStatus, sizesSummary,
+		sizesDetails.String())
+}
+
+func (s *Service) upsertCheck(
+	ctx context.Context,
+	repo *types.Repository,
+	sha string,
+	identifier string,
+	status enum.CheckStatus,
+	summary string,
+	details string,
+) error {
+	data, err := json.Marshal(types.CheckPayloadText{Details: details})
+	if err != nil {
+		return fmt.Errorf("failed to marshal check payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.checkStore.Upsert(ctx, &types.Check{
+		CreatedBy:  bootstrap.NewSystemServiceSession().Principal.ID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     repo.ID,
+		CommitSHA:  sha,
+		Identifier: identifier,
+		Status:     status,
+		Summary:    summary,
+		Metadata:   []byte("{}"),
+		Started:    now,
+		Ended:      now,
+		Payload: types.CheckPayload{
+			Kind: enum.CheckPayloadKindMarkdown,
+			Data: data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to report status check %s: %w", identifier, err)
+	}
+
+	return nil
+}
+
+func formatSize(size int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+}
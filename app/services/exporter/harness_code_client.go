@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/types"
 )
 
@@ -59,7 +60,14 @@ type client struct {
 }
 
 // newClient creates a new harness Client for interacting with the platforms APIs.
-func newClient(baseURL string, accountID string, orgID string, projectID string, token string) (*client, error) {
+func newClient(
+	baseURL string,
+	accountID string,
+	orgID string,
+	projectID string,
+	token string,
+	proxyResolver *proxy.Resolver,
+) (*client, error) {
 	if baseURL == "" {
 		return nil, fmt.Errorf("baseUrl required")
 	}
@@ -84,6 +92,7 @@ func newClient(baseURL string, accountID string, orgID string, projectID string,
 		token:     token,
 		httpClient: http.Client{
 			Transport: &http.Transport{
+				Proxy: proxyResolver.Proxy,
 				TLSClientConfig: &tls.Config{
 					InsecureSkipVerify: false,
 					MinVersion:         tls.VersionTLS12,
@@ -99,8 +108,9 @@ func newHarnessCodeClient(
 	orgID string,
 	projectID string,
 	token string,
+	proxyResolver *proxy.Resolver,
 ) (*harnessCodeClient, error) {
-	client, err := newClient(baseURL, accountID, orgID, projectID, token)
+	client, err := newClient(baseURL, accountID, orgID, projectID, token, proxyResolver)
 	if err != nil {
 		return nil, err
 	}
@@ -30,10 +30,12 @@ import (
 	gitnessurl "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/gotidy/ptr"
 	"github.com/rs/zerolog/log"
 )
 
@@ -54,6 +56,8 @@ type Repository struct {
 	scheduler   *job.Scheduler
 	encrypter   encrypt.Encrypter
 	sseStreamer sse.Streamer
+
+	proxyResolver *proxy.Resolver
 }
 
 type Input struct {
@@ -175,6 +179,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		harnessCodeInfo.OrgIdentifier,
 		harnessCodeInfo.ProjectIdentifier,
 		harnessCodeInfo.Token,
+		r.proxyResolver,
 	)
 	if err != nil {
 		return "", err
@@ -188,7 +193,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		Identifier:    repository.Identifier,
 		DefaultBranch: repository.DefaultBranch,
 		Description:   repository.Description,
-		IsPublic:      repository.IsPublic,
+		IsPublic:      ptr.Bool(repository.IsPublic),
 		Readme:        false,
 		License:       "",
 		GitIgnore:     "",
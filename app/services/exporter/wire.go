@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
@@ -37,6 +38,7 @@ func ProvideSpaceExporter(
 	executor *job.Executor,
 	encrypter encrypt.Encrypter,
 	sseStreamer sse.Streamer,
+	proxyResolver *proxy.Resolver,
 ) (*Repository, error) {
 	exporter := &Repository{
 		urlProvider: urlProvider,
@@ -45,6 +47,8 @@ func ProvideSpaceExporter(
 		scheduler:   scheduler,
 		encrypter:   encrypter,
 		sseStreamer: sseStreamer,
+
+		proxyResolver: proxyResolver,
 	}
 
 	err := executor.Register(jobType, exporter)
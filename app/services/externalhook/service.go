@@ -0,0 +1,365 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externalhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/http/proxy"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/version"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxOutputSize is the maximum size of the output of a hook that's processed.
+const maxOutputSize = 64 << 10
+
+type Config struct {
+	// ScriptsDir is the directory containing the scripts that can be used by script hooks.
+	// If empty, script hooks are disabled.
+	ScriptsDir     string
+	DefaultTimeout time.Duration
+	MaxTimeout     time.Duration
+	// UserAgentIdentity is the identity used for the user agent header of requests to HTTP hooks.
+	UserAgentIdentity string
+	// HeaderIdentity is the identity used for custom headers of requests to HTTP hooks (e.g. X-Gitness-Signature).
+	HeaderIdentity string
+}
+
+// Service invokes the external pre-receive hooks registered for the spaces of a repository.
+type Service struct {
+	config            Config
+	externalHookStore store.ExternalHookStore
+	spaceStore        store.SpaceStore
+	httpClient        *http.Client
+}
+
+func NewService(
+	config Config,
+	externalHookStore store.ExternalHookStore,
+	spaceStore store.SpaceStore,
+	proxyResolver *proxy.Resolver,
+) *Service {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyResolver != nil {
+		tr.Proxy = proxyResolver.Proxy
+	}
+
+	return &Service{
+		config:            config,
+		externalHookStore: externalHookStore,
+		spaceStore:        spaceStore,
+		httpClient:        &http.Client{Transport: tr},
+	}
+}
+
+// Payload is the JSON payload external hooks receive (request body of HTTP hooks, stdin of script hooks).
+type Payload struct {
+	Hook        string                 `json:"hook"`
+	Repo        RepoInfo               `json:"repo"`
+	Principal   PrincipalInfo          `json:"principal"`
+	RefUpdates  []hook.ReferenceUpdate `json:"ref_updates"`
+	PushOptions []string               `json:"push_options"`
+}
+
+// RepoInfo describes the repository a push is targeting.
+type RepoInfo struct {
+	ID            int64  `json:"id"`
+	Path          string `json:"path"`
+	Identifier    string `json:"identifier"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// PrincipalInfo describes the principal executing a push.
+type PrincipalInfo struct {
+	ID          int64  `json:"id"`
+	UID         string `json:"uid"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+}
+
+// Response is the JSON response HTTP hooks can return to reject a push and to print messages to the client.
+type Response struct {
+	Messages []string `json:"messages"`
+	Error    string   `json:"error"`
+}
+
+// Result is the combined result of all external hooks invoked for a push.
+type Result struct {
+	Messages []string
+	// Error is the user facing error if any hook rejected the push.
+	Error string
+}
+
+// Run invokes all enabled external hooks of the repository's space and its ancestor spaces, starting with the
+// hooks of the root space. Hooks are invoked one after the other, the first hook rejecting the push stops the chain.
+// Hooks that fail or time out reject the push.
+func (s *Service) Run(
+	ctx context.Context,
+	repo *types.Repository,
+	principal *types.Principal,
+	in hook.PreReceiveInput,
+) (Result, error) {
+	hooks, err := s.listHooks(ctx, repo.ParentID)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list external hooks: %w", err)
+	}
+
+	var result Result
+	for _, externalHook := range hooks {
+		payload := Payload{
+			Hook: externalHook.Identifier,
+			Repo: RepoInfo{
+				ID:            repo.ID,
+				Path:          repo.Path,
+				Identifier:    repo.Identifier,
+				DefaultBranch: repo.DefaultBranch,
+			},
+			Principal: PrincipalInfo{
+				ID:          principal.ID,
+				UID:         principal.UID,
+				DisplayName: principal.DisplayName,
+				Email:       principal.Email,
+			},
+			RefUpdates:  in.RefUpdates,
+			PushOptions: in.PushOptions,
+		}
+
+		resp, err := s.invoke(ctx, externalHook, &payload)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("external_hook_id", externalHook.ID).
+				Msg("external hook failed")
+
+			result.Error = fmt.Sprintf("External hook %q failed: %s", externalHook.Identifier, err)
+			return result, nil
+		}
+
+		result.Messages = append(result.Messages, resp.Messages...)
+
+		if resp.Error != "" {
+			result.Error = fmt.Sprintf("Rejected by external hook %q: %s", externalHook.Identifier, resp.Error)
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// listHooks returns the enabled hooks of the space and all its ancestors, hooks of the root space first.
+func (s *Service) listHooks(ctx context.Context, spaceID int64) ([]*types.ExternalHook, error) {
+	var spaceIDs []int64
+	for spaceID > 0 {
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceIDs = append(spaceIDs, space.ID)
+		spaceID = space.ParentID
+	}
+
+	hooks, err := s.externalHookStore.List(ctx, spaceIDs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// order hooks by the depth of their space (root space first), the store orders them by identifier.
+	depth := make(map[int64]int, len(spaceIDs))
+	for i, id := range spaceIDs {
+		depth[id] = len(spaceIDs) - i
+	}
+
+	ordered := make([]*types.ExternalHook, 0, len(hooks))
+	for d := 1; d <= len(spaceIDs); d++ {
+		for _, h := range hooks {
+			if depth[h.SpaceID] == d {
+				ordered = append(ordered, h)
+			}
+		}
+	}
+
+	return ordered, nil
+}
+
+func (s *Service) invoke(ctx context.Context, externalHook *types.ExternalHook, payload *Payload) (Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout(externalHook))
+	defer cancel()
+
+	switch externalHook.Type {
+	case enum.ExternalHookTypeHTTP:
+		return s.invokeHTTP(ctx, externalHook, body)
+	case enum.ExternalHookTypeScript:
+		return s.invokeScript(ctx, externalHook, body)
+	default:
+		return Response{}, fmt.Errorf("unsupported hook type %q", externalHook.Type)
+	}
+}
+
+func (s *Service) timeout(externalHook *types.ExternalHook) time.Duration {
+	if externalHook.Timeout <= 0 {
+		return s.config.DefaultTimeout
+	}
+
+	timeout := time.Duration(externalHook.Timeout) * time.Second
+	if timeout > s.config.MaxTimeout {
+		return s.config.MaxTimeout
+	}
+
+	return timeout
+}
+
+// invokeHTTP sends the payload to the HTTP hook.
+// Any non-2xx status code rejects the push, the error of the JSON response (if any) is shown to the user.
+func (s *Service) invokeHTTP(ctx context.Context, externalHook *types.ExternalHook, body []byte) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, externalHook.Target, bytes.NewReader(body))
+	if err != nil {
+		return Response{}, errors.New("invalid request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
+
+	if externalHook.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(externalHook.Secret))
+		_, _ = mac.Write(body)
+		req.Header.Set(fmt.Sprintf("X-%s-Signature", s.config.HeaderIdentity), hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return Response{}, errors.New("timed out")
+	}
+	if err != nil {
+		return Response{}, errors.New("request failed")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOutputSize))
+	if err != nil {
+		return Response{}, errors.New("failed to read response")
+	}
+
+	var out Response
+	if len(bytes.TrimSpace(data)) > 0 && strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(data, &out); err != nil {
+			return Response{}, errors.New("invalid response")
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if out.Error == "" {
+			out.Error = fmt.Sprintf("received status code %d", resp.StatusCode)
+		}
+	}
+
+	return out, nil
+}
+
+// invokeScript executes the script hook with the payload provided via stdin.
+// The lines written to stdout are shown to the user, a non-zero exit code rejects the push.
+func (s *Service) invokeScript(ctx context.Context, externalHook *types.ExternalHook, body []byte) (Response, error) {
+	if s.config.ScriptsDir == "" {
+		return Response{}, errors.New("script hooks are disabled")
+	}
+
+	dir, err := filepath.Abs(s.config.ScriptsDir)
+	if err != nil {
+		return Response{}, errors.New("invalid scripts directory")
+	}
+
+	// ASSUMPTION: the target is a plain file name (validated on creation).
+	script := filepath.Join(dir, filepath.Base(externalHook.Target))
+
+	stdout := &limitedBuffer{limit: maxOutputSize}
+	stderr := &limitedBuffer{limit: maxOutputSize}
+
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Dir = dir
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH")}
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// don't wait for child processes of the script that keep the output pipes open after the timeout.
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return Response{}, errors.New("timed out")
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		log.Ctx(ctx).Warn().Err(err).Str("script", script).Msg("failed to execute external hook script")
+		return Response{}, errors.New("failed to execute script")
+	}
+
+	out := Response{Messages: splitLines(stdout.String())}
+	if exitErr != nil {
+		out.Error = fmt.Sprintf("exit code %d", exitErr.ExitCode())
+		if len(out.Messages) == 0 {
+			out.Messages = splitLines(stderr.String())
+		}
+	}
+
+	return out, nil
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// limitedBuffer is a buffer that silently drops any data exceeding the limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if n := b.limit - b.Len(); n < len(p) {
+		if n > 0 {
+			b.Buffer.Write(p[:n])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externalhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestService_InvokeHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := Payload{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-Gitness-Signature") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(Response{Error: "missing signature"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(Response{Messages: []string{"checked " + payload.Repo.Path}})
+	}))
+	defer server.Close()
+
+	s := NewService(Config{DefaultTimeout: time.Second, MaxTimeout: time.Second, HeaderIdentity: "Gitness"},
+		nil, nil, nil)
+
+	tests := []struct {
+		name   string
+		secret string
+		exp    Response
+	}{
+		{
+			name:   "accepted",
+			secret: "secret",
+			exp:    Response{Messages: []string{"checked space/repo"}},
+		},
+		{
+			name: "rejected",
+			exp:  Response{Error: "missing signature"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hook := &types.ExternalHook{Type: enum.ExternalHookTypeHTTP, Target: server.URL, Secret: test.secret}
+
+			resp, err := s.invoke(context.Background(), hook, &Payload{Repo: RepoInfo{Path: "space/repo"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(test.exp, resp) {
+				t.Errorf("want=%+v got=%+v", test.exp, resp)
+			}
+		})
+	}
+}
+
+func TestService_InvokeScript(t *testing.T) {
+	dir := t.TempDir()
+
+	scripts := map[string]string{
+		"accept.sh": "#!/bin/sh\necho all good\n",
+		"reject.sh": "#!/bin/sh\necho no >&2\nexit 3\n",
+		"slow.sh":   "#!/bin/sh\nsleep 5\n",
+	}
+	for name, content := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o700); err != nil {
+			t.Fatalf("failed to write script: %s", err)
+		}
+	}
+
+	s := NewService(Config{ScriptsDir: dir, DefaultTimeout: 200 * time.Millisecond, MaxTimeout: time.Second},
+		nil, nil, nil)
+
+	tests := []struct {
+		script string
+		exp    Response
+		expErr bool
+	}{
+		{script: "accept.sh", exp: Response{Messages: []string{"all good"}}},
+		{script: "reject.sh", exp: Response{Messages: []string{"no"}, Error: "exit code 3"}},
+		{script: "slow.sh", expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.script, func(t *testing.T) {
+			hook := &types.ExternalHook{Type: enum.ExternalHookTypeScript, Target: test.script}
+
+			resp, err := s.invoke(context.Background(), hook, &Payload{})
+			if test.expErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(test.exp, resp) {
+				t.Errorf("want=%+v got=%+v", test.exp, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externalhook
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/http/proxy"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	externalHookStore store.ExternalHookStore,
+	spaceStore store.SpaceStore,
+	proxyResolver *proxy.Resolver,
+) *Service {
+	headerIdentity := config.Webhook.HeaderIdentity
+	if headerIdentity == "" {
+		headerIdentity = config.Webhook.UserAgentIdentity
+	}
+
+	return NewService(
+		Config{
+			ScriptsDir:        config.Git.ExternalHooks.ScriptsDir,
+			DefaultTimeout:    config.Git.ExternalHooks.DefaultTimeout,
+			MaxTimeout:        config.Git.ExternalHooks.MaxTimeout,
+			UserAgentIdentity: config.Webhook.UserAgentIdentity,
+			HeaderIdentity:    headerIdentity,
+		},
+		externalHookStore,
+		spaceStore,
+		proxyResolver,
+	)
+}
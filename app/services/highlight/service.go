@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package highlight
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// MaxBlobSize is the maximum size of a blob that gets highlighted.
+	MaxBlobSize = 1 << 20 // 1 MiB
+
+	// cacheDuration is the duration for which highlighted blobs are cached.
+	// Blobs are immutable, the duration only limits the memory usage.
+	cacheDuration = 10 * time.Minute
+
+	// binaryDetectionSize is the number of bytes inspected to detect binary content.
+	binaryDetectionSize = 8000
+)
+
+// Service is highlighting the source code of blobs.
+// The token streams are cached by blob SHA, so repeated requests for the same blob
+// (e.g. for different hunks of the same diff) are only tokenized once.
+type Service struct {
+	cache cache.Cache[blobKey, *types.HighlightedBlob]
+}
+
+func NewService(git git.Interface) *Service {
+	return &Service{
+		cache: cache.New[blobKey, *types.HighlightedBlob](blobGetter{git: git}, cacheDuration),
+	}
+}
+
+// Highlight returns the highlighted content of the blob.
+// The file path is used to select the language of the blob.
+// The returned object is shared and must not be modified.
+func (s *Service) Highlight(
+	ctx context.Context,
+	repo *types.Repository,
+	blobSHA string,
+	filePath string,
+) (*types.HighlightedBlob, error) {
+	return s.cache.Get(ctx, blobKey{
+		repoUID:  repo.GitUID,
+		sha:      blobSHA,
+		fileName: path.Base(filePath),
+	})
+}
+
+type blobKey struct {
+	repoUID  string
+	sha      string
+	fileName string
+}
+
+type blobGetter struct {
+	git git.Interface
+}
+
+func (g blobGetter) Find(ctx context.Context, key blobKey) (*types.HighlightedBlob, error) {
+	blobOut, err := g.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: git.ReadParams{RepoUID: key.repoUID},
+		SHA:        key.sha,
+		SizeLimit:  MaxBlobSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	defer func() {
+		if err := blobOut.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if blobOut.ContentSize < blobOut.Size {
+		return nil, errors.InvalidArgument("File is too large to be highlighted (max %d bytes).", MaxBlobSize)
+	}
+
+	content, err := io.ReadAll(blobOut.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	if bytes.IndexByte(content[:min(len(content), binaryDetectionSize)], 0) >= 0 {
+		return nil, errors.InvalidArgument("Binary files can't be highlighted.")
+	}
+
+	language, lines, err := Tokenize(key.fileName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.HighlightedBlob{
+		SHA:        key.sha,
+		Language:   language,
+		TotalLines: len(lines),
+		Lines:      lines,
+	}, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package highlight
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+)
+
+// Tokenize splits the content into lines of highlight tokens.
+// The lexer is selected based on the file name, or based on the content if the file name is unknown.
+// It returns the name of the detected language along with the lines.
+func Tokenize(filePath string, content []byte) (string, []types.HighlightLine, error) {
+	text := string(content)
+
+	lexer := lexers.Match(path.Base(filePath))
+	if lexer == nil {
+		lexer = lexers.Analyse(text)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, text)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to tokenize content: %w", err)
+	}
+
+	tokenLines := chroma.SplitTokensIntoLines(iterator.Tokens())
+
+	lines := make([]types.HighlightLine, len(tokenLines))
+	for i, tokenLine := range tokenLines {
+		tokens := make([]types.HighlightToken, 0, len(tokenLine))
+		for _, token := range tokenLine {
+			value := strings.TrimSuffix(token.Value, "\n")
+			if value == "" {
+				continue
+			}
+
+			tokens = append(tokens, types.HighlightToken{
+				Type:  chroma.StandardTypes[token.Type],
+				Value: value,
+			})
+		}
+
+		lines[i] = types.HighlightLine{
+			Number: i + 1,
+			Tokens: tokens,
+		}
+	}
+
+	return lexer.Config().Name, lines, nil
+}
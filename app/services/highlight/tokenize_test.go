@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package highlight
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestTokenize(t *testing.T) {
+	content := "package main\r\n\nfunc main() {}\n"
+
+	language, lines, err := Tokenize("dir/main.go", []byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if language != "Go" {
+		t.Errorf("expected language Go, got %q", language)
+	}
+
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+
+	for i, line := range lines {
+		if line.Number != i+1 {
+			t.Errorf("expected line number %d, got %d", i+1, line.Number)
+		}
+	}
+
+	if got := joinTokens(lines[0].Tokens); got != "package main" {
+		t.Errorf("unexpected first line %q", got)
+	}
+	if lines[0].Tokens[0].Type != "kn" {
+		t.Errorf("expected keyword namespace token, got %q", lines[0].Tokens[0].Type)
+	}
+	if len(lines[1].Tokens) != 0 {
+		t.Errorf("expected empty second line, got %v", lines[1].Tokens)
+	}
+	if got := joinTokens(lines[2].Tokens); got != "func main() {}" {
+		t.Errorf("unexpected third line %q", got)
+	}
+}
+
+func TestTokenizeUnknownFile(t *testing.T) {
+	language, lines, err := Tokenize("some-file", []byte("just text"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if language == "" {
+		t.Errorf("expected fallback language")
+	}
+	if len(lines) != 1 || joinTokens(lines[0].Tokens) != "just text" {
+		t.Errorf("unexpected lines %v", lines)
+	}
+}
+
+func joinTokens(tokens []types.HighlightToken) string {
+	sb := strings.Builder{}
+	for _, token := range tokens {
+		sb.WriteString(token.Value)
+	}
+	return sb.String()
+}
@@ -98,6 +98,8 @@ func (r *RepositoryInfo) ToRepo(
 		ForkID:        0,
 		DefaultBranch: r.DefaultBranch,
 		Importing:     true,
+
+		PullReqSuggestions: true,
 	}
 }
 
@@ -107,33 +109,36 @@ func hash(s string) string {
 	return base32.StdEncoding.EncodeToString(h.Sum(nil)[:10])
 }
 
-func oauthTransport(token string, scheme string) http.RoundTripper {
+func oauthTransport(token string, scheme string, base http.RoundTripper) http.RoundTripper {
 	if token == "" {
-		return nil
+		return base
 	}
 	return &oauth2.Transport{
 		Scheme: scheme,
 		Source: oauth2.StaticTokenSource(&scm.Token{Token: token}),
+		Base:   base,
 	}
 }
 
-func authHeaderTransport(token string) http.RoundTripper {
+func authHeaderTransport(token string, base http.RoundTripper) http.RoundTripper {
 	if token == "" {
-		return nil
+		return base
 	}
 	return &transport.Authorization{
 		Scheme:      "token",
 		Credentials: token,
+		Base:        base,
 	}
 }
 
-func basicAuthTransport(username, password string) http.RoundTripper {
+func basicAuthTransport(username, password string, base http.RoundTripper) http.RoundTripper {
 	if username == "" && password == "" {
-		return nil
+		return base
 	}
 	return &transport.BasicAuth{
 		Username: username,
 		Password: password,
+		Base:     base,
 	}
 }
 
@@ -141,7 +146,12 @@ func basicAuthTransport(username, password string) http.RoundTripper {
 // layer depending on the provider. For example, for bitbucket we support app passwords
 // so the auth transport is BasicAuth whereas it's Oauth for other providers.
 // It validates that auth credentials are provided if authReq is true.
-func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, error) { //nolint:gocognit
+// The base transport is used for sending the requests (e.g. via the configured proxy).
+func getScmClientWithTransport( //nolint:gocognit
+	provider Provider,
+	authReq bool,
+	base http.RoundTripper,
+) (*scm.Client, error) {
 	if authReq && (provider.Username == "" || provider.Password == "") {
 		return nil, usererror.BadRequest("scm provider authentication credentials missing")
 	}
@@ -161,7 +171,7 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 		} else {
 			c = github.NewDefault()
 		}
-		transport = oauthTransport(provider.Password, oauth2.SchemeBearer)
+		transport = oauthTransport(provider.Password, oauth2.SchemeBearer, base)
 
 	case ProviderTypeGitLab:
 		if provider.Host != "" {
@@ -172,7 +182,7 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 		} else {
 			c = gitlab.NewDefault()
 		}
-		transport = oauthTransport(provider.Password, oauth2.SchemeBearer)
+		transport = oauthTransport(provider.Password, oauth2.SchemeBearer, base)
 
 	case ProviderTypeBitbucket:
 		if provider.Host != "" {
@@ -183,7 +193,7 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 		} else {
 			c = bitbucket.NewDefault()
 		}
-		transport = basicAuthTransport(provider.Username, provider.Password)
+		transport = basicAuthTransport(provider.Username, provider.Password, base)
 
 	case ProviderTypeStash:
 		if provider.Host != "" {
@@ -194,7 +204,7 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 		} else {
 			c = stash.NewDefault()
 		}
-		transport = oauthTransport(provider.Password, oauth2.SchemeBearer)
+		transport = oauthTransport(provider.Password, oauth2.SchemeBearer, base)
 
 	case ProviderTypeGitea:
 		if provider.Host == "" {
@@ -204,7 +214,7 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 		if err != nil {
 			return nil, fmt.Errorf("scm provider Host invalid: %w", err)
 		}
-		transport = authHeaderTransport(provider.Password)
+		transport = authHeaderTransport(provider.Password, base)
 
 	case ProviderTypeGogs:
 		if provider.Host == "" {
@@ -214,7 +224,7 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 		if err != nil {
 			return nil, fmt.Errorf("scm provider Host invalid: %w", err)
 		}
-		transport = oauthTransport(provider.Password, oauth2.SchemeToken)
+		transport = oauthTransport(provider.Password, oauth2.SchemeToken, base)
 
 	default:
 		return nil, fmt.Errorf("unsupported scm provider: %s", provider)
@@ -228,12 +238,12 @@ func getScmClientWithTransport(provider Provider, authReq bool) (*scm.Client, er
 	return c, nil
 }
 
-func LoadRepositoryFromProvider(
+func (r *Repository) LoadRepositoryFromProvider(
 	ctx context.Context,
 	provider Provider,
 	repoSlug string,
 ) (RepositoryInfo, Provider, error) {
-	scmClient, err := getScmClientWithTransport(provider, false)
+	scmClient, err := getScmClientWithTransport(provider, false, r.httpTransport)
 	if err != nil {
 		return RepositoryInfo{}, provider, usererror.BadRequestf("could not create client: %s", err)
 	}
@@ -266,13 +276,13 @@ func LoadRepositoryFromProvider(
 }
 
 //nolint:gocognit
-func LoadRepositoriesFromProviderSpace(
+func (r *Repository) LoadRepositoriesFromProviderSpace(
 	ctx context.Context,
 	provider Provider,
 	spaceSlug string,
 ) ([]RepositoryInfo, Provider, error) {
 	var err error
-	scmClient, err := getScmClientWithTransport(provider, false)
+	scmClient, err := getScmClientWithTransport(provider, false, r.httpTransport)
 	if err != nil {
 		return nil, provider, usererror.BadRequestf("could not create client: %s", err)
 	}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -63,6 +64,7 @@ type Repository struct {
 	scheduler     *job.Scheduler
 	sseStreamer   sse.Streamer
 	indexer       keywordsearch.Indexer
+	httpTransport http.RoundTripper
 }
 
 var _ job.Handler = (*Repository)(nil)
@@ -492,13 +494,11 @@ func (r *Repository) createEnvVars(ctx context.Context,
 	principal *types.Principal,
 	repoID int64,
 ) (map[string]string, error) {
-	envVars, err := githook.GenerateEnvironmentVariables(
+	envVars, err := githook.GenerateSystemEnvironmentVariables(
 		ctx,
 		r.urlProvider.GetInternalAPIURL(),
 		repoID,
 		principal.ID,
-		false,
-		true,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
@@ -15,12 +15,15 @@
 package importer
 
 import (
+	"net/http"
+
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -45,7 +48,12 @@ func ProvideRepoImporter(
 	executor *job.Executor,
 	sseStreamer sse.Streamer,
 	indexer keywordsearch.Indexer,
+	proxyResolver *proxy.Resolver,
 ) (*Repository, error) {
+	// http.DefaultTransport, just with the configured proxy.
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.Proxy = proxyResolver.Proxy
+
 	importer := &Repository{
 		defaultBranch: config.Git.DefaultBranch,
 		urlProvider:   urlProvider,
@@ -58,6 +66,7 @@ func ProvideRepoImporter(
 		scheduler:     scheduler,
 		sseStreamer:   sseStreamer,
 		indexer:       indexer,
+		httpTransport: httpTransport,
 	}
 
 	err := executor.Register(jobType, importer)
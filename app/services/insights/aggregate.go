@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/git/types"
+	gitness_types "github.com/harness/gitness/types"
+)
+
+const week = 7 * 24 * time.Hour
+
+// weekStart returns the start of the week (Monday, 00:00 UTC) the provided time belongs to.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+// aggregate groups the commit activity by week and by author. The returned weeks are sorted
+// chronologically without gaps, the returned contributors are sorted by the number of commits.
+func aggregate(
+	activities []types.CommitActivity,
+) ([]gitness_types.InsightsWeek, []gitness_types.InsightsContributor) {
+	if len(activities) == 0 {
+		return []gitness_types.InsightsWeek{}, []gitness_types.InsightsContributor{}
+	}
+
+	weekMap := make(map[int64]*gitness_types.InsightsWeek)
+	contributorMap := make(map[string]*gitness_types.InsightsContributor)
+
+	var first, last time.Time
+
+	for _, activity := range activities {
+		when := activity.Author.When
+		start := weekStart(when)
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+
+		w, ok := weekMap[start.UnixMilli()]
+		if !ok {
+			w = &gitness_types.InsightsWeek{Week: start.UnixMilli()}
+			weekMap[start.UnixMilli()] = w
+		}
+
+		w.Commits++
+		w.Additions += activity.Additions
+		w.Deletions += activity.Deletions
+
+		key := strings.ToLower(activity.Author.Identity.Email)
+		c, ok := contributorMap[key]
+		if !ok {
+			c = &gitness_types.InsightsContributor{
+				Name:        activity.Author.Identity.Name,
+				Email:       activity.Author.Identity.Email,
+				FirstCommit: when.UnixMilli(),
+				LastCommit:  when.UnixMilli(),
+			}
+			contributorMap[key] = c
+		}
+
+		c.Commits++
+		c.Additions += activity.Additions
+		c.Deletions += activity.Deletions
+
+		if when.UnixMilli() < c.FirstCommit {
+			c.FirstCommit = when.UnixMilli()
+		}
+		if when.UnixMilli() > c.LastCommit {
+			// the most recently used name is the one shown.
+			c.LastCommit = when.UnixMilli()
+			c.Name = activity.Author.Identity.Name
+		}
+	}
+
+	weeks := make([]gitness_types.InsightsWeek, 0, int(last.Sub(first)/week)+1)
+	for t := first; !t.After(last); t = t.AddDate(0, 0, 7) {
+		if w, ok := weekMap[t.UnixMilli()]; ok {
+			weeks = append(weeks, *w)
+			continue
+		}
+		weeks = append(weeks, gitness_types.InsightsWeek{Week: t.UnixMilli()})
+	}
+
+	contributors := make([]gitness_types.InsightsContributor, 0, len(contributorMap))
+	for _, c := range contributorMap {
+		contributors = append(contributors, *c)
+	}
+
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return contributors[i].Email < contributors[j].Email
+	})
+
+	return weeks, contributors
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/types"
+	gitness_types "github.com/harness/gitness/types"
+)
+
+func TestAggregate(t *testing.T) {
+	activity := func(email string, when time.Time, additions, deletions int64) types.CommitActivity {
+		return types.CommitActivity{
+			Author: types.Signature{
+				Identity: types.Identity{Name: email, Email: email},
+				When:     when,
+			},
+			Additions: additions,
+			Deletions: deletions,
+		}
+	}
+
+	// 2024-01-01 is a Monday.
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	weeks, contributors := aggregate([]types.CommitActivity{
+		activity("b@example.com", monday.Add(22*24*time.Hour), 1, 1),
+		activity("A@example.com", monday.Add(6*24*time.Hour), 5, 0),
+		activity("a@example.com", monday.Add(time.Hour), 10, 2),
+	})
+
+	if len(weeks) != 4 {
+		t.Fatalf("expected 4 weeks, got %d", len(weeks))
+	}
+
+	if weeks[0].Week != monday.UnixMilli() || weeks[0].Commits != 2 ||
+		weeks[0].Additions != 15 || weeks[0].Deletions != 2 {
+		t.Errorf("unexpected first week: %+v", weeks[0])
+	}
+
+	if weeks[1].Commits != 0 || weeks[2].Commits != 0 || weeks[3].Commits != 1 {
+		t.Errorf("unexpected weeks: %+v", weeks)
+	}
+
+	if len(contributors) != 2 {
+		t.Fatalf("expected 2 contributors, got %d", len(contributors))
+	}
+
+	if contributors[0].Commits != 2 || contributors[0].Additions != 15 || contributors[0].Name != "A@example.com" {
+		t.Errorf("unexpected first contributor: %+v", contributors[0])
+	}
+
+	if contributors[1].Email != "b@example.com" || contributors[1].Commits != 1 {
+		t.Errorf("unexpected second contributor: %+v", contributors[1])
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 1, 7, 23, 59, 0, 0, time.UTC)
+	if got := weekStart(sunday); !got.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected week start: %s", got)
+	}
+}
+
+func TestCommitActivity(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	weeks, _ := aggregate([]types.CommitActivity{
+		{Author: types.Signature{When: monday.Add(time.Hour)}},
+		{Author: types.Signature{When: monday.AddDate(0, 0, 14)}},
+	})
+
+	activity := CommitActivity(&gitness_types.RepoInsights{Weeks: weeks}, monday.AddDate(0, 0, 23), 4)
+	if len(activity) != 4 {
+		t.Fatalf("expected 4 weeks, got %d", len(activity))
+	}
+
+	expected := []int64{1, 0, 1, 0}
+	for i, a := range activity {
+		if a.Week != monday.AddDate(0, 0, 7*i).UnixMilli() || a.Commits != expected[i] {
+			t.Errorf("unexpected week %d: %+v", i, a)
+		}
+	}
+}
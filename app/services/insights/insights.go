@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "repo-insights"
+	jobMaxRetries  = 2
+	jobMaxDuration = 15 * time.Minute
+)
+
+// Service computes the insights of repositories in background jobs and caches them per repository.
+type Service struct {
+	git           git.Interface
+	repoStore     store.RepoStore
+	insightsStore store.RepoInsightsStore
+	scheduler     *job.Scheduler
+}
+
+type jobInput struct {
+	RepoID int64  `json:"repo_id"`
+	SHA    string `json:"sha"`
+}
+
+// Find returns the cached insights of the repository, if the cached insights are not computed for the
+// provided commit SHA a background job that recomputes them is scheduled. The returned boolean value
+// is true if the returned insights are stale (or missing) and the recomputation is in progress.
+func (s *Service) Find(ctx context.Context, repo *types.Repository, sha string) (*types.RepoInsights, bool, error) {
+	insights, err := s.insightsStore.Find(ctx, repo.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, false, fmt.Errorf("failed to find repo insights: %w", err)
+	}
+
+	if insights == nil {
+		insights = &types.RepoInsights{RepoID: repo.ID}
+	}
+
+	if insights.SHA == sha {
+		return insights, false, nil
+	}
+
+	if err := s.schedule(ctx, repo.ID, sha); err != nil {
+		return nil, false, err
+	}
+
+	return insights, true, nil
+}
+
+func (s *Service) schedule(ctx context.Context, repoID int64, sha string) error {
+	data, err := json.Marshal(jobInput{RepoID: repoID, SHA: sha})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobType + "-" + strconv.FormatInt(repoID, 10) + "-" + sha,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the insights for the commit are already being computed.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule repo insights job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle walks the history of the commit provided in the job input and stores the computed insights.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input jobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "repository not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	out, err := s.git.ListCommitActivity(ctx, &git.ListCommitActivityParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		GitREF:     input.SHA,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list commit activity: %w", err)
+	}
+
+	weeks, contributors := aggregate(out.Activities)
+
+	err = s.insightsStore.Upsert(ctx, &types.RepoInsights{
+		RepoID:       repo.ID,
+		SHA:          input.SHA,
+		Computed:     time.Now().UnixMilli(),
+		Weeks:        weeks,
+		Contributors: contributors,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store repo insights: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("sha", input.SHA).
+		Msgf("computed repo insights from %d commits", len(out.Activities))
+
+	return "", nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// CommitActivity returns the number of commits of each of the last n weeks up to (and including)
+// the week of the provided time. Weeks without commits are included with zero commits.
+func CommitActivity(insights *types.RepoInsights, now time.Time, n int) []types.InsightsCommitActivity {
+	commits := make(map[int64]int64, len(insights.Weeks))
+	for _, w := range insights.Weeks {
+		commits[w.Week] = w.Commits
+	}
+
+	result := make([]types.InsightsCommitActivity, n)
+	start := weekStart(now).AddDate(0, 0, -7*(n-1))
+	for i := range result {
+		week := start.AddDate(0, 0, 7*i).UnixMilli()
+		result[i] = types.InsightsCommitActivity{
+			Week:    week,
+			Commits: commits[week],
+		}
+	}
+
+	return result
+}
+
+// CodeFrequency returns the number of added and deleted lines of every week of the history.
+func CodeFrequency(insights *types.RepoInsights) []types.InsightsCodeFrequency {
+	result := make([]types.InsightsCodeFrequency, len(insights.Weeks))
+	for i, w := range insights.Weeks {
+		result[i] = types.InsightsCodeFrequency{
+			Week:      w.Week,
+			Additions: w.Additions,
+			Deletions: w.Deletions,
+		}
+	}
+
+	return result
+}
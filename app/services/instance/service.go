@@ -0,0 +1,264 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// changedKey is the pubsub topic used to notify all instances about a change of the instance settings.
+	changedKey       = "instance_settings_changed"
+	changedNamespace = "instance"
+
+	KeyUserSignupEnabled          = "user_signup_enabled"
+	KeyUserSignupAllowedDomains   = "user_signup_allowed_domains"
+	KeyUserSignupApprovalRequired = "user_signup_approval_required"
+	KeyDefaultPublic              = "default_public"
+	KeyWebhookAllowedNetworks     = "webhook_allowed_networks"
+	KeySessionTTL                 = "session_ttl"
+)
+
+// ChangeFunc is called with the stored instance settings whenever they changed.
+type ChangeFunc func(ctx context.Context, settings types.InstanceSettings) error
+
+// Service provides the effective values of the instance settings.
+// Values stored at runtime take precedence over the configuration, which is used for all values that aren't stored.
+// The stored values are kept in memory of every instance, all instances are notified about changes via pubsub.
+type Service struct {
+	settings *settings.Service
+	pubsub   pubsub.PubSub
+
+	mx        sync.RWMutex
+	config    *types.Config
+	stored    types.InstanceSettings
+	listeners []ChangeFunc
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	settings *settings.Service,
+	bus pubsub.PubSub,
+	reloader *configreload.Reloader,
+) (*Service, error) {
+	service := &Service{
+		settings: settings,
+		pubsub:   bus,
+		config:   config,
+	}
+
+	if err := service.load(ctx); err != nil {
+		return nil, err
+	}
+
+	// the config is the fallback for all values that aren't stored, so keep it up to date.
+	reloader.Register("instance settings", func(_ context.Context, config *types.Config) error {
+		service.mx.Lock()
+		service.config = config
+		service.mx.Unlock()
+		return nil
+	})
+
+	_ = bus.Subscribe(ctx, changedKey, func([]byte) error {
+		return service.load(ctx)
+	}, pubsub.WithChannelNamespace(changedNamespace))
+
+	return service, nil
+}
+
+// OnChange registers a function that's called whenever the stored instance settings changed.
+func (s *Service) OnChange(fn ChangeFunc) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.listeners = append(s.listeners, fn)
+}
+
+// Settings returns the instance settings stored at runtime.
+func (s *Service) Settings() types.InstanceSettings {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return s.stored
+}
+
+// UserSignupEnabled returns whether users can sign up by themselves.
+func (s *Service) UserSignupEnabled() bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if s.stored.UserSignupEnabled != nil {
+		return *s.stored.UserSignupEnabled
+	}
+
+	return s.config.UserSignupEnabled
+}
+
+// UserSignupAllowedDomains returns the email domains users can sign up with (any domain if empty).
+func (s *Service) UserSignupAllowedDomains() []string {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if s.stored.UserSignupAllowedDomains != nil {
+		return *s.stored.UserSignupAllowedDomains
+	}
+
+	return s.config.UserSignupAllowedDomains
+}
+
+// UserSignupApprovalRequired returns whether users that signed up have to be approved by an admin.
+func (s *Service) UserSignupApprovalRequired() bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if s.stored.UserSignupApprovalRequired != nil {
+		return *s.stored.UserSignupApprovalRequired
+	}
+
+	return s.config.UserSignupApprovalRequired
+}
+
+// DefaultPublic returns whether repositories and spaces created without an explicit visibility are public.
+func (s *Service) DefaultPublic() bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if s.stored.DefaultPublic != nil {
+		return *s.stored.DefaultPublic
+	}
+
+	return s.config.DefaultPublic
+}
+
+// WebhookAllowedNetworks returns the networks webhooks can be delivered to regardless of the network policy.
+func (s *Service) WebhookAllowedNetworks() []string {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if s.stored.WebhookAllowedNetworks != nil {
+		return *s.stored.WebhookAllowedNetworks
+	}
+
+	return s.config.Webhook.AllowedNetworks
+}
+
+// SessionTTL returns the lifetime of the session tokens created on login and registration.
+func (s *Service) SessionTTL() time.Duration {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if s.stored.SessionTTL != nil {
+		return time.Duration(*s.stored.SessionTTL) * time.Second
+	}
+
+	return s.config.Token.Expire
+}
+
+// Effective returns the effective values of all instance settings together with their source.
+func (s *Service) Effective() []types.InstanceSetting {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	effective := func(key string, stored bool, value, configured any) types.InstanceSetting {
+		if stored {
+			return types.InstanceSetting{Key: key, Value: value, Source: enum.InstanceSettingSourceSettings}
+		}
+		return types.InstanceSetting{Key: key, Value: configured, Source: enum.InstanceSettingSourceConfig}
+	}
+
+	var sessionTTL int64
+	if s.stored.SessionTTL != nil {
+		sessionTTL = *s.stored.SessionTTL
+	}
+
+	var webhookAllowedNetworks []string
+	if s.stored.WebhookAllowedNetworks != nil {
+		webhookAllowedNetworks = *s.stored.WebhookAllowedNetworks
+	}
+
+	var userSignupAllowedDomains []string
+	if s.stored.UserSignupAllowedDomains != nil {
+		userSignupAllowedDomains = *s.stored.UserSignupAllowedDomains
+	}
+
+	return []types.InstanceSetting{
+		effective(KeyUserSignupEnabled, s.stored.UserSignupEnabled != nil,
+			s.stored.UserSignupEnabled, s.config.UserSignupEnabled),
+		effective(KeyUserSignupAllowedDomains, s.stored.UserSignupAllowedDomains != nil,
+			userSignupAllowedDomains, s.config.UserSignupAllowedDomains),
+		effective(KeyUserSignupApprovalRequired, s.stored.UserSignupApprovalRequired != nil,
+			s.stored.UserSignupApprovalRequired, s.config.UserSignupApprovalRequired),
+		effective(KeyDefaultPublic, s.stored.DefaultPublic != nil,
+			s.stored.DefaultPublic, s.config.DefaultPublic),
+		effective(KeyWebhookAllowedNetworks, s.stored.WebhookAllowedNetworks != nil,
+			webhookAllowedNetworks, s.config.Webhook.AllowedNetworks),
+		effective(KeySessionTTL, s.stored.SessionTTL != nil,
+			sessionTTL, int64(s.config.Token.Expire/time.Second)),
+	}
+}
+
+// Update stores the instance settings and notifies all instances about the change.
+// All values that are nil fall back to the configuration.
+func (s *Service) Update(ctx context.Context, in types.InstanceSettings, updatedBy int64) error {
+	if err := s.settings.SetInstanceSettings(ctx, &in, updatedBy); err != nil {
+		return err
+	}
+
+	s.apply(ctx, in)
+
+	err := s.pubsub.Publish(ctx, changedKey, nil, pubsub.WithPublishNamespace(changedNamespace))
+	if err != nil {
+		// the other instances keep the outdated settings until the next change.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish instance settings change")
+	}
+
+	return nil
+}
+
+func (s *Service) load(ctx context.Context) error {
+	stored, err := s.settings.InstanceSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load instance settings: %w", err)
+	}
+
+	s.apply(ctx, *stored)
+
+	return nil
+}
+
+func (s *Service) apply(ctx context.Context, stored types.InstanceSettings) {
+	s.mx.Lock()
+	s.stored = stored
+	listeners := s.listeners
+	s.mx.Unlock()
+
+	for _, fn := range listeners {
+		if err := fn(ctx, stored); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to apply instance settings")
+		}
+	}
+}
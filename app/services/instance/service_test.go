@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestService_Precedence(t *testing.T) {
+	config := &types.Config{UserSignupEnabled: true}
+	config.Token.Expire = 24 * time.Hour
+	config.Webhook.AllowedNetworks = []string{"10.0.0.0/8"}
+
+	s := &Service{config: config}
+
+	if !s.UserSignupEnabled() || s.DefaultPublic() || s.SessionTTL() != 24*time.Hour {
+		t.Fatalf("expected config values without stored settings")
+	}
+	if networks := s.WebhookAllowedNetworks(); len(networks) != 1 || networks[0] != "10.0.0.0/8" {
+		t.Fatalf("expected configured networks, got %v", networks)
+	}
+
+	s.stored = types.InstanceSettings{
+		UserSignupEnabled:      ptr.Bool(false),
+		WebhookAllowedNetworks: &[]string{},
+		SessionTTL:             ptr.Int64(3600),
+	}
+
+	if s.UserSignupEnabled() {
+		t.Errorf("expected stored signup setting to take precedence")
+	}
+	if s.DefaultPublic() {
+		t.Errorf("expected configured default visibility")
+	}
+	if networks := s.WebhookAllowedNetworks(); len(networks) != 0 {
+		t.Errorf("expected stored empty networks to take precedence, got %v", networks)
+	}
+	if ttl := s.SessionTTL(); ttl != time.Hour {
+		t.Errorf("expected stored session ttl, got %s", ttl)
+	}
+
+	sources := map[string]enum.InstanceSettingSource{}
+	for _, setting := range s.Effective() {
+		sources[setting.Key] = setting.Source
+	}
+
+	expected := map[string]enum.InstanceSettingSource{
+		KeyUserSignupEnabled:      enum.InstanceSettingSourceSettings,
+		KeyDefaultPublic:          enum.InstanceSettingSourceConfig,
+		KeyWebhookAllowedNetworks: enum.InstanceSettingSourceSettings,
+		KeySessionTTL:             enum.InstanceSettingSourceSettings,
+	}
+	for key, source := range expected {
+		if sources[key] != source {
+			t.Errorf("expected source %q for %s, got %q", source, key, sources[key])
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxCommentLength is the maximum number of characters of a comment that's included in a message.
+	maxCommentLength = 500
+
+	// shortSHALength is the length of commit SHAs shown in messages.
+	shortSHALength = 8
+)
+
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqCreated, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			return s.pullReqMessage(c, "opened", c.pr.Description, ColorBlue), nil
+		})
+}
+
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqReopened, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			return s.pullReqMessage(c, "reopened", "", ColorBlue), nil
+		})
+}
+
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqClosed, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			return s.pullReqMessage(c, "closed", "", ColorGray), nil
+		})
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqMerged, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			msg := s.pullReqMessage(c, "merged", "", ColorPurple)
+			msg.Facts = append(msg.Facts,
+				Fact{Name: "Merge method", Value: string(event.Payload.MergeMethod)},
+				Fact{Name: "Merge commit", Value: shortSHA(event.Payload.MergeSHA)},
+			)
+			return msg, nil
+		})
+}
+
+func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqBranchUpdated, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			action := "pushed new commits to"
+			if event.Payload.Forced {
+				action = "force pushed to"
+			}
+
+			msg := s.pullReqMessage(c, action, "", ColorBlue)
+			msg.Facts = append(msg.Facts, Fact{Name: "Commit", Value: shortSHA(event.Payload.NewSHA)})
+			return msg, nil
+		})
+}
+
+func (s *Service) handleEventPullReqCommentCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqCommentCreated, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			activity, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find comment: %w", err)
+			}
+
+			return s.pullReqMessage(c, "commented on", truncate(activity.Text, maxCommentLength), ColorGray), nil
+		})
+}
+
+func (s *Service) handleEventPullReqReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqReviewSubmitted, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			switch event.Payload.Decision {
+			case enum.PullReqReviewDecisionApproved:
+				return s.pullReqMessage(c, "approved", "", ColorGreen), nil
+			case enum.PullReqReviewDecisionChangeReq:
+				return s.pullReqMessage(c, "requested changes to", "", ColorRed), nil
+			case enum.PullReqReviewDecisionPending, enum.PullReqReviewDecisionReviewed:
+				return s.pullReqMessage(c, "reviewed", "", ColorGray), nil
+			}
+
+			return s.pullReqMessage(c, "reviewed", "", ColorGray), nil
+		})
+}
+
+func (s *Service) handleEventPullReqReviewReminder(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewReminderPayload]) error {
+	return s.notifyForPullReq(ctx, enum.IntegrationEventPullReqReviewReminder, event.Payload.Base,
+		func(c *pullReqContext) (*Message, error) {
+			reviewers, err := s.principalInfoCache.Map(ctx, event.Payload.ReviewerIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get reviewers: %w", err)
+			}
+
+			names := make([]string, 0, len(reviewers))
+			for _, reviewerID := range event.Payload.ReviewerIDs {
+				if reviewer, ok := reviewers[reviewerID]; ok {
+					names = append(names, reviewer.DisplayName)
+				}
+			}
+
+			msg := s.pullReqMessage(c, "", "", ColorBlue)
+			msg.Title = fmt.Sprintf("[%s] Pull request #%d: %s is waiting for review for %d hours",
+				c.repo.Path, c.pr.Number, c.pr.Title, event.Payload.PendingHours)
+			msg.Facts = append(msg.Facts, Fact{Name: "Pending reviewers", Value: strings.Join(names, ", ")})
+			return msg, nil
+		})
+}
+
+// pullReqMessage returns a message like "[space/repo] jane merged pull request #12: Fix login".
+func (s *Service) pullReqMessage(c *pullReqContext, action string, text string, color Color) *Message {
+	return &Message{
+		Title: fmt.Sprintf("[%s] %s %s pull request #%d: %s",
+			c.repo.Path, c.principal.DisplayName, action, c.pr.Number, c.pr.Title),
+		Text:      text,
+		URL:       s.urlProvider.GenerateUIPRURL(c.repo.Path, c.pr.Number),
+		LinkTitle: fmt.Sprintf("View pull request #%d", c.pr.Number),
+		Color:     color,
+		Facts: []Fact{
+			{Name: "Branches", Value: c.pr.SourceBranch + " → " + c.pr.TargetBranch},
+			{Name: "State", Value: pullReqState(c.pr.State, c.pr.IsDraft)},
+		},
+	}
+}
+
+func pullReqState(state enum.PullReqState, isDraft bool) string {
+	if state == enum.PullReqStateOpen && isDraft {
+		return "draft"
+	}
+	return string(state)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
+
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength]) + "…"
+}
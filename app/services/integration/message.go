@@ -0,0 +1,255 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// slackPostMessageURL is the Slack API endpoint used to post messages with a bot token.
+	slackPostMessageURL = "https://slack.com/api/chat.postMessage"
+
+	// maxResponseSize is the maximum size of a response body that's read.
+	maxResponseSize = 16 << 10
+)
+
+// Color defines the accent color of a message.
+type Color string
+
+const (
+	ColorBlue   Color = "#0278d5"
+	ColorGreen  Color = "#42ab45"
+	ColorRed    Color = "#cf2318"
+	ColorPurple Color = "#7d4dd3"
+	ColorGray   Color = "#9293ab"
+)
+
+// Message is a chat message independent of the integration type.
+type Message struct {
+	Title     string
+	Text      string
+	URL       string
+	LinkTitle string
+	Color     Color
+	Facts     []Fact
+}
+
+// Fact is a name value pair that's rendered as a table below the message text.
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// Send renders the message for the type of the integration and posts it to the linked channel.
+func (s *Service) Send(ctx context.Context, integration *types.Integration, msg *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	switch integration.Type {
+	case enum.IntegrationTypeSlack:
+		body := renderSlack(msg)
+		if integration.URL != "" {
+			return s.post(ctx, integration.URL, "", body, nil)
+		}
+
+		body.Channel = integration.Channel
+		var response struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		if err := s.post(ctx, slackPostMessageURL, integration.Token, body, &response); err != nil {
+			return err
+		}
+		if !response.OK {
+			return fmt.Errorf("slack rejected the message: %s", response.Error)
+		}
+		return nil
+
+	case enum.IntegrationTypeTeams:
+		return s.post(ctx, integration.URL, "", renderTeams(msg), nil)
+
+	default:
+		return fmt.Errorf("integration type %q is not supported", integration.Type)
+	}
+}
+
+func (s *Service) post(ctx context.Context, rawURL string, token string, body any, response any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("request failed with status %s: %s", resp.Status, string(respBody))
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(respBody, response); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color     string       `json:"color"`
+	Title     string       `json:"title"`
+	TitleLink string       `json:"title_link,omitempty"`
+	Text      string       `json:"text,omitempty"`
+	Fields    []slackField `json:"fields,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// slackEscaper escapes the characters that have a special meaning in Slack messages.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func renderSlack(msg *Message) *slackMessage {
+	fields := make([]slackField, len(msg.Facts))
+	for i, fact := range msg.Facts {
+		fields[i] = slackField{
+			Title: slackEscaper.Replace(fact.Name),
+			Value: slackEscaper.Replace(fact.Value),
+			Short: true,
+		}
+	}
+
+	return &slackMessage{
+		Text: slackEscaper.Replace(msg.Title),
+		Attachments: []slackAttachment{{
+			Color:     string(msg.Color),
+			Title:     slackEscaper.Replace(msg.Title),
+			TitleLink: msg.URL,
+			Text:      slackEscaper.Replace(msg.Text),
+			Fields:    fields,
+		}},
+	}
+}
+
+// teamsMessage is a message containing an adaptive card,
+// which is supported by Teams incoming webhooks and Teams workflows.
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []map[string]any `json:"body"`
+	Actions []map[string]any `json:"actions,omitempty"`
+}
+
+func renderTeams(msg *Message) *teamsMessage {
+	body := []map[string]any{{
+		"type":   "TextBlock",
+		"text":   msg.Title,
+		"weight": "Bolder",
+		"size":   "Medium",
+		"wrap":   true,
+	}}
+
+	if msg.Text != "" {
+		body = append(body, map[string]any{
+			"type": "TextBlock",
+			"text": msg.Text,
+			"wrap": true,
+		})
+	}
+
+	if len(msg.Facts) > 0 {
+		facts := make([]map[string]string, len(msg.Facts))
+		for i, fact := range msg.Facts {
+			facts[i] = map[string]string{"title": fact.Name, "value": fact.Value}
+		}
+		body = append(body, map[string]any{
+			"type":  "FactSet",
+			"facts": facts,
+		})
+	}
+
+	var actions []map[string]any
+	if msg.URL != "" {
+		actions = append(actions, map[string]any{
+			"type":  "Action.OpenUrl",
+			"title": msg.LinkTitle,
+			"url":   msg.URL,
+		})
+	}
+
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+			},
+		}},
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRenderSlack(t *testing.T) {
+	msg := &Message{
+		Title: "PR #1 <script> & co",
+		Text:  "a > b",
+		URL:   "https://gitness.example.com/pulls/1",
+		Color: ColorGreen,
+		Facts: []Fact{{Name: "Author", Value: "<jane>"}},
+	}
+
+	out := renderSlack(msg)
+
+	if out.Text != "PR #1 &lt;script&gt; &amp; co" {
+		t.Errorf("unexpected text: %q", out.Text)
+	}
+
+	if len(out.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(out.Attachments))
+	}
+
+	attachment := out.Attachments[0]
+	if attachment.Color != string(ColorGreen) || attachment.TitleLink != msg.URL || attachment.Text != "a &gt; b" {
+		t.Errorf("unexpected attachment: %+v", attachment)
+	}
+
+	if len(attachment.Fields) != 1 || attachment.Fields[0].Value != "&lt;jane&gt;" || !attachment.Fields[0].Short {
+		t.Errorf("unexpected fields: %+v", attachment.Fields)
+	}
+}
+
+func TestRenderTeams(t *testing.T) {
+	msg := &Message{
+		Title:     "PR #1 merged",
+		URL:       "https://gitness.example.com/pulls/1",
+		LinkTitle: "View pull request",
+		Facts:     []Fact{{Name: "Author", Value: "jane"}},
+	}
+
+	data, err := json.Marshal(renderTeams(msg))
+	if err != nil {
+		t.Fatalf("failed to marshal message: %v", err)
+	}
+
+	var out struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string `json:"type"`
+				Body []struct {
+					Type  string `json:"type"`
+					Text  string `json:"text"`
+					Facts []struct {
+						Title string `json:"title"`
+						Value string `json:"value"`
+					} `json:"facts"`
+				} `json:"body"`
+				Actions []struct {
+					Type  string `json:"type"`
+					Title string `json:"title"`
+					URL   string `json:"url"`
+				} `json:"actions"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to unmarshal message: %v", err)
+	}
+
+	if out.Type != "message" || len(out.Attachments) != 1 {
+		t.Fatalf("unexpected message: %s", data)
+	}
+
+	card := out.Attachments[0].Content
+	if out.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" || card.Type != "AdaptiveCard" {
+		t.Errorf("unexpected attachment: %s", data)
+	}
+
+	// the message has no text, hence only the title and the facts are rendered.
+	if len(card.Body) != 2 || card.Body[0].Text != msg.Title || card.Body[1].Type != "FactSet" {
+		t.Fatalf("unexpected card body: %s", data)
+	}
+
+	if len(card.Body[1].Facts) != 1 || card.Body[1].Facts[0].Title != "Author" {
+		t.Errorf("unexpected facts: %s", data)
+	}
+
+	if len(card.Actions) != 1 || card.Actions[0].URL != msg.URL || card.Actions[0].Title != msg.LinkTitle {
+		t.Errorf("unexpected actions: %s", data)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/http/proxy"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:integration"
+
+	// deliveryTimeout is the maximum time the delivery of a message to a single integration can take.
+	deliveryTimeout = 30 * time.Second
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	// AllowedHosts are hosts integrations can post messages to in addition to the official
+	// Slack and Microsoft Teams hosts (e.g. for proxies or self-hosted compatible services).
+	AllowedHosts []string
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service posts messages about pull request events to the Slack and Microsoft Teams channels
+// linked to a repository or any of its parent spaces.
+type Service struct {
+	config             Config
+	integrationStore   store.IntegrationStore
+	repoStore          store.RepoStore
+	spaceStore         store.SpaceStore
+	pullreqStore       store.PullReqStore
+	activityStore      store.PullReqActivityStore
+	principalInfoCache store.PrincipalInfoCache
+	urlProvider        url.Provider
+	httpClient         *http.Client
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	integrationStore store.IntegrationStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalInfoCache store.PrincipalInfoCache,
+	urlProvider url.Provider,
+	proxyResolver *proxy.Resolver,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided integration service config is invalid: %w", err)
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyResolver != nil {
+		tr.Proxy = proxyResolver.Proxy
+	}
+
+	service := &Service{
+		config:             config,
+		integrationStore:   integrationStore,
+		repoStore:          repoStore,
+		spaceStore:         spaceStore,
+		pullreqStore:       pullreqStore,
+		activityStore:      activityStore,
+		principalInfoCache: principalInfoCache,
+		urlProvider:        urlProvider,
+		httpClient:         &http.Client{Transport: tr, Timeout: deliveryTimeout},
+	}
+
+	_, err := prReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
+			_ = r.RegisterCommentCreated(service.handleEventPullReqCommentCreated)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterReviewReminder(service.handleEventPullReqReviewReminder)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for integrations: %w", err)
+	}
+
+	return service, nil
+}
+
+// pullReqContext contains the data of a pull request event that's used to render messages.
+type pullReqContext struct {
+	pr        *types.PullReq
+	repo      *types.Repository
+	principal *types.PrincipalInfo
+}
+
+// notifyForPullReq posts the message built for the pull request event to all integrations
+// of the target repository and its parent spaces that are subscribed to the event.
+// Failed deliveries are only logged, as retrying the event would send duplicate messages to other integrations.
+func (s *Service) notifyForPullReq(
+	ctx context.Context,
+	event enum.IntegrationEvent,
+	base pullreqevents.Base,
+	buildMessage func(*pullReqContext) (*Message, error),
+) error {
+	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repo: %w", err)
+	}
+
+	integrations, err := s.listSubscribed(ctx, repo, event)
+	if err != nil {
+		return err
+	}
+
+	if len(integrations) == 0 {
+		return nil
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	principal, err := s.principalInfoCache.Get(ctx, base.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	msg, err := buildMessage(&pullReqContext{
+		pr:        pr,
+		repo:      repo,
+		principal: principal,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build message for event %s: %w", event, err)
+	}
+
+	for _, integration := range integrations {
+		if err = s.Send(ctx, integration, msg); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to post %s message to integration %q of %s %d",
+				event, integration.Identifier, integration.ParentType, integration.ParentID)
+		}
+	}
+
+	return nil
+}
+
+// listSubscribed returns the integrations of the repository and all its parent spaces that are subscribed to the event.
+func (s *Service) listSubscribed(
+	ctx context.Context,
+	repo *types.Repository,
+	event enum.IntegrationEvent,
+) ([]*types.Integration, error) {
+	var spaceIDs []int64
+	for spaceID := repo.ParentID; spaceID > 0; {
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceIDs = append(spaceIDs, space.ID)
+		spaceID = space.ParentID
+	}
+
+	integrations, err := s.integrationStore.ListEnabled(ctx, repo.ID, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+
+	subscribed := make([]*types.Integration, 0, len(integrations))
+	for _, integration := range integrations {
+		if integration.IsSubscribed(event) {
+			subscribed = append(subscribed, integration)
+		}
+	}
+
+	return subscribed, nil
+}
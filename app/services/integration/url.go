@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxURLLength defines the max allowed length of an integration URL.
+const maxURLLength = 2048
+
+// officialHosts are the hosts of the incoming webhooks of each integration type.
+// Entries starting with a dot match all subdomains.
+var officialHosts = map[enum.IntegrationType][]string{
+	enum.IntegrationTypeSlack: {"hooks.slack.com"},
+	enum.IntegrationTypeTeams: {".webhook.office.com", ".logic.azure.com", ".powerplatform.com"},
+}
+
+// CheckURL validates the incoming webhook URL of an integration.
+// To prevent requests to internal services, only the official hosts of the integration type
+// and the hosts allowed in the config are accepted.
+func (s *Service) CheckURL(integrationType enum.IntegrationType, rawURL string) error {
+	if len(rawURL) > maxURLLength {
+		return check.NewValidationErrorf("The URL of an integration can be at most %d characters long.",
+			maxURLLength)
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return check.NewValidationErrorf("The provided integration URL is invalid: %s", err)
+	}
+
+	if parsedURL.Scheme != "https" {
+		return check.NewValidationError("The scheme of an integration URL must be https.")
+	}
+
+	host := strings.ToLower(parsedURL.Hostname())
+	for _, allowed := range append(officialHosts[integrationType], s.config.AllowedHosts...) {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return nil
+		}
+	}
+
+	return check.NewValidationErrorf("The host %q is not allowed for %s integrations.", host, integrationType)
+}
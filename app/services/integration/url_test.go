@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckURL(t *testing.T) {
+	s := &Service{config: Config{AllowedHosts: []string{"chat.example.com", ".proxy.example.com"}}}
+
+	tests := []struct {
+		name            string
+		integrationType enum.IntegrationType
+		url             string
+		allowed         bool
+	}{
+		{name: "slack", integrationType: enum.IntegrationTypeSlack,
+			url: "https://hooks.slack.com/services/T0/B0/X", allowed: true},
+		{name: "slack-case-insensitive", integrationType: enum.IntegrationTypeSlack,
+			url: "https://Hooks.Slack.com/services/T0/B0/X", allowed: true},
+		{name: "slack-subdomain", integrationType: enum.IntegrationTypeSlack,
+			url: "https://evil.hooks.slack.com/services", allowed: false},
+		{name: "slack-teams-host", integrationType: enum.IntegrationTypeSlack,
+			url: "https://example.webhook.office.com/hook", allowed: false},
+		{name: "teams-subdomain", integrationType: enum.IntegrationTypeTeams,
+			url: "https://example.webhook.office.com/hook", allowed: true},
+		{name: "teams-workflow", integrationType: enum.IntegrationTypeTeams,
+			url: "https://prod-1.westus.logic.azure.com/workflows/1", allowed: true},
+		{name: "teams-suffix", integrationType: enum.IntegrationTypeTeams,
+			url: "https://evilwebhook.office.com/hook", allowed: false},
+		{name: "http", integrationType: enum.IntegrationTypeSlack,
+			url: "http://hooks.slack.com/services", allowed: false},
+		{name: "internal", integrationType: enum.IntegrationTypeSlack,
+			url: "https://127.0.0.1/services", allowed: false},
+		{name: "config-host", integrationType: enum.IntegrationTypeTeams,
+			url: "https://chat.example.com/hook", allowed: true},
+		{name: "config-subdomain", integrationType: enum.IntegrationTypeSlack,
+			url: "https://eu.proxy.example.com/hook", allowed: true},
+		{name: "config-host-no-subdomain", integrationType: enum.IntegrationTypeSlack,
+			url: "https://eu.chat.example.com/hook", allowed: false},
+		{name: "too-long", integrationType: enum.IntegrationTypeSlack,
+			url: "https://hooks.slack.com/" + strings.Repeat("a", maxURLLength), allowed: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.CheckURL(test.integrationType, test.url)
+			if test.allowed && err != nil {
+				t.Errorf("expected url to be allowed, got: %v", err)
+			}
+			if !test.allowed && err == nil {
+				t.Errorf("expected url to be rejected")
+			}
+		})
+	}
+}
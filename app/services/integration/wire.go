@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/http/proxy"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	integrationStore store.IntegrationStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalInfoCache store.PrincipalInfoCache,
+	urlProvider url.Provider,
+	proxyResolver *proxy.Resolver,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		prReaderFactory,
+		integrationStore,
+		repoStore,
+		spaceStore,
+		pullreqStore,
+		activityStore,
+		principalInfoCache,
+		urlProvider,
+		proxyResolver,
+	)
+}
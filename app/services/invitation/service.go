@@ -0,0 +1,338 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	tokenLength = 32
+
+	// DefaultExpiry is used for invitations that are created without an explicit expiry.
+	DefaultExpiry = 7 * 24 * time.Hour
+	// MaxExpiry is the longest time an invitation can be valid.
+	MaxExpiry = 90 * 24 * time.Hour
+
+	// resendInterval is the minimum time between two invitation emails for the same invitation.
+	resendInterval = time.Minute
+)
+
+// ErrInvalidInvitation is returned for tokens of invitations that don't exist, expired or were already accepted.
+var ErrInvalidInvitation = usererror.Forbidden("The invitation is invalid or expired.")
+
+// CreateInput contains the information of a new invitation.
+type CreateInput struct {
+	Email string
+	// Space is the optional space the user is added to when accepting the invitation.
+	Space *types.Space
+	Role  *enum.MembershipRole
+	// Expiry is the validity of the invitation, DefaultExpiry is used if zero.
+	Expiry time.Duration
+}
+
+// Service creates invitations and sends them by email to the invited persons.
+// Only the hash of the invitation token is stored, so the token is only available when it's (re)generated.
+type Service struct {
+	smtpEnabled        bool
+	invitationStore    store.InvitationStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	spaceStore         store.SpaceStore
+	notificationClient notification.Client
+	urlProvider        url.Provider
+}
+
+func NewService(
+	config *types.Config,
+	invitationStore store.InvitationStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	spaceStore store.SpaceStore,
+	notificationClient notification.Client,
+	urlProvider url.Provider,
+) *Service {
+	return &Service{
+		smtpEnabled:        config.SMTP.Host != "",
+		invitationStore:    invitationStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		spaceStore:         spaceStore,
+		notificationClient: notificationClient,
+		urlProvider:        urlProvider,
+	}
+}
+
+// Create creates a new invitation and sends it to the invited email address.
+func (s *Service) Create(
+	ctx context.Context,
+	in CreateInput,
+	createdBy int64,
+) (*types.InvitationCreateOutput, error) {
+	if err := s.sanitizeCreateInput(ctx, &in); err != nil {
+		return nil, err
+	}
+
+	token, err := GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var spaceID *int64
+	if in.Space != nil {
+		spaceID = &in.Space.ID
+	}
+
+	now := time.Now()
+	invitation := &types.Invitation{
+		Email:     in.Email,
+		TokenHash: HashToken(token),
+		SpaceID:   spaceID,
+		Role:      in.Role,
+		CreatedBy: createdBy,
+		Created:   now.UnixMilli(),
+		Expires:   now.Add(in.Expiry).UnixMilli(),
+	}
+
+	err = s.invitationStore.Create(ctx, invitation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return s.send(ctx, invitation, token), nil
+}
+
+// Resend generates a new token for a pending invitation, extends its expiry and sends it again.
+// The previously sent invitation link becomes invalid.
+func (s *Service) Resend(
+	ctx context.Context,
+	invitation *types.Invitation,
+) (*types.InvitationCreateOutput, error) {
+	if invitation.Accepted != nil {
+		return nil, usererror.BadRequest("The invitation was already accepted.")
+	}
+
+	now := time.Now()
+	if invitation.Sent > 0 && now.Sub(time.UnixMilli(invitation.Sent)) < resendInterval {
+		return nil, usererror.BadRequest("The invitation was sent recently, please try again later.")
+	}
+
+	token, err := GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.TokenHash = HashToken(token)
+	invitation.Expires = now.Add(DefaultExpiry).UnixMilli()
+
+	err = s.invitationStore.UpdateToken(ctx, invitation)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequest("The invitation was already accepted.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invitation token: %w", err)
+	}
+
+	return s.send(ctx, invitation, token), nil
+}
+
+// FindPending returns the invitation of the token, if it wasn't accepted yet and isn't expired.
+func (s *Service) FindPending(ctx context.Context, token string) (*types.Invitation, error) {
+	invitation, err := s.invitationStore.FindByTokenHash(ctx, HashToken(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	if invitation.Accepted != nil || invitation.Expires < time.Now().UnixMilli() {
+		return nil, ErrInvalidInvitation
+	}
+
+	return invitation, nil
+}
+
+// Info returns the information about the invitation that is shown to the invited person.
+func (s *Service) Info(ctx context.Context, invitation *types.Invitation, token string) (*types.InvitationInfo, error) {
+	invitedBy, err := s.principalInfoCache.Get(ctx, invitation.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal info of the inviting user: %w", err)
+	}
+
+	spacePath, err := s.spacePath(ctx, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.InvitationInfo{
+		Email:       invitation.Email,
+		SpacePath:   spacePath,
+		Role:        invitation.Role,
+		InvitedBy:   *invitedBy,
+		Expires:     invitation.Expires,
+		RegisterURL: s.urlProvider.GenerateUIRegisterURL(token),
+	}, nil
+}
+
+func (s *Service) sanitizeCreateInput(ctx context.Context, in *CreateInput) error {
+	in.Email = strings.TrimSpace(in.Email)
+	if err := check.Email(in.Email); err != nil {
+		return err
+	}
+
+	if in.Space == nil && in.Role != nil {
+		return usererror.BadRequest("Role can only be provided together with a space")
+	}
+
+	if in.Space != nil && in.Role == nil {
+		role := enum.MembershipRoleReader
+		in.Role = &role
+	}
+
+	if in.Role != nil {
+		role, ok := in.Role.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid membership role %q", *in.Role)
+		}
+		in.Role = &role
+	}
+
+	if in.Expiry == 0 {
+		in.Expiry = DefaultExpiry
+	}
+
+	if in.Expiry < 0 || in.Expiry > MaxExpiry {
+		return usererror.BadRequestf("Invitation expiry must be positive and at most %s", MaxExpiry)
+	}
+
+	_, err := s.principalStore.FindUserByEmail(ctx, in.Email)
+	if err == nil {
+		return usererror.Conflict("A user with the provided email address already exists")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to check for existing user: %w", err)
+	}
+
+	return nil
+}
+
+// send sends the invitation email. Failures are only logged,
+// the invitation link can still be shared manually with the invited person.
+func (s *Service) send(
+	ctx context.Context,
+	invitation *types.Invitation,
+	token string,
+) *types.InvitationCreateOutput {
+	out := &types.InvitationCreateOutput{
+		Token: token,
+		URL:   s.urlProvider.GenerateUIRegisterURL(token),
+	}
+
+	if !s.smtpEnabled {
+		out.Invitation = *invitation
+		return out
+	}
+
+	err := s.sendEmail(ctx, invitation, out.URL)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("invitation_id", invitation.ID).
+			Msg("failed to send invitation email")
+		out.Invitation = *invitation
+		return out
+	}
+
+	invitation.Sent = time.Now().UnixMilli()
+	err = s.invitationStore.UpdateToken(ctx, invitation)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("invitation_id", invitation.ID).
+			Msg("failed to update sent time of invitation")
+	}
+
+	out.Invitation = *invitation
+	out.EmailSent = true
+
+	return out
+}
+
+func (s *Service) sendEmail(ctx context.Context, invitation *types.Invitation, invitationURL string) error {
+	invitedBy, err := s.principalInfoCache.Get(ctx, invitation.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info of the inviting user: %w", err)
+	}
+
+	spacePath, err := s.spacePath(ctx, invitation)
+	if err != nil {
+		return err
+	}
+
+	return s.notificationClient.SendInvitation(ctx, invitation.Email, &notification.InvitationPayload{
+		InvitedBy: invitedBy,
+		SpacePath: spacePath,
+		Role:      invitation.Role,
+		URL:       invitationURL,
+		Expires:   time.UnixMilli(invitation.Expires).UTC().Format(time.RFC1123),
+	})
+}
+
+func (s *Service) spacePath(ctx context.Context, invitation *types.Invitation) (string, error) {
+	if invitation.SpaceID == nil {
+		return "", nil
+	}
+
+	space, err := s.spaceStore.Find(ctx, *invitation.SpaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space of the invitation: %w", err)
+	}
+
+	return space.Path, nil
+}
+
+// GenerateToken generates a new random invitation token.
+func GenerateToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hash of the token, only the hash is stored in the database.
+func HashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
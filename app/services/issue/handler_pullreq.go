@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// handleEventPullReqMerged closes all open issues of the target repository
+// that are referenced with a closing keyword in the title or the description of the pull request.
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	numbers := FindClosingReferences(pr.Title + "\n" + pr.Description)
+
+	for _, number := range numbers {
+		err = s.closeIssue(ctx, pr, number, event.Payload.PrincipalID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			log.Ctx(ctx).Debug().Int64("issue_number", number).
+				Msg("issue referenced by merged pull request doesn't exist")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to close issue #%d: %w", number, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) closeIssue(ctx context.Context, pr *types.PullReq, number int64, principalID int64) error {
+	issue, err := s.issueStore.FindByNumber(ctx, pr.TargetRepoID, number)
+	if err != nil {
+		return err
+	}
+
+	if issue.State == enum.IssueStateClosed {
+		return nil
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		issue, err = s.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+			now := time.Now().UnixMilli()
+			issue.State = enum.IssueStateClosed
+			issue.ClosedBy = &principalID
+			issue.Closed = &now
+			issue.ClosedByPullReq = &pr.Number
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+
+		s.eventReporter.Closed(ctx, &issueevents.ClosedPayload{
+			Base: issueevents.Base{
+				IssueID:     issue.ID,
+				RepoID:      issue.RepoID,
+				PrincipalID: principalID,
+				Number:      issue.Number,
+			},
+			PullReqNumber: pr.Number,
+		})
+
+		return nil
+	})
+}
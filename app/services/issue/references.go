@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// closingReferenceRegex matches references to issues of the same repository
+// that are preceded by a closing keyword, e.g. "fixes #12" or "Closes: #7".
+var closingReferenceRegex = regexp.MustCompile(
+	`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// FindClosingReferences returns the numbers of all issues the text marks as closed, without duplicates.
+func FindClosingReferences(text string) []int64 {
+	var numbers []int64
+	seen := map[int64]struct{}{}
+	for _, match := range closingReferenceRegex.FindAllStringSubmatch(text, -1) {
+		number, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || number <= 0 {
+			continue
+		}
+
+		if _, ok := seen[number]; ok {
+			continue
+		}
+
+		seen[number] = struct{}{}
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
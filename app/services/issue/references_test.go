@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindClosingReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		numbers []int64
+	}{
+		{
+			name:    "none",
+			text:    "refactor the parser, see #4",
+			numbers: nil,
+		},
+		{
+			name:    "single",
+			text:    "fixes #12",
+			numbers: []int64{12},
+		},
+		{
+			name:    "keywords",
+			text:    "Closes #1, fix #2, Resolved: #3 and closed #1 again",
+			numbers: []int64{1, 2, 3},
+		},
+		{
+			name:    "whole words only",
+			text:    "prefixes #5, fixes #6x, unresolved #7",
+			numbers: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			numbers := FindClosingReferences(test.text)
+			if !reflect.DeepEqual(numbers, test.numbers) {
+				t.Errorf("expected %v, got %v", test.numbers, numbers)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+)
+
+const (
+	eventsReaderGroupName = "gitness:issue"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service closes the issues referenced by closing keywords ("fixes #12") of merged pull requests.
+type Service struct {
+	tx            dbtx.Transactor
+	pullreqStore  store.PullReqStore
+	issueStore    store.IssueStore
+	eventReporter *issueevents.Reporter
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	tx dbtx.Transactor,
+	pullreqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	eventReporter *issueevents.Reporter,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided issue service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		tx:            tx,
+		pullreqStore:  pullreqStore,
+		issueStore:    issueStore,
+		eventReporter: eventReporter,
+	}
+
+	_, err := prReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for issues: %w", err)
+	}
+
+	return service, nil
+}
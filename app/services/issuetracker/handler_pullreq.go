@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.notifyJira(ctx, event.Payload.Base, "opened")
+}
+
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.notifyJira(ctx, event.Payload.Base, "reopened")
+}
+
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.notifyJira(ctx, event.Payload.Base, "closed")
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.notifyJira(ctx, event.Payload.Base, "merged")
+}
+
+// notifyJira comments on all Jira issues referenced in the title or the source branch of the pull request.
+// Failed requests are only logged, as retrying the event would add duplicate comments to other issues.
+func (s *Service) notifyJira(ctx context.Context, base pullreqevents.Base, action string) error {
+	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	settings, err := s.EffectiveSettings(ctx, repo.ParentID)
+	if err != nil {
+		return err
+	}
+	if settings == nil || settings.Jira == nil || !settings.Jira.Enabled {
+		return nil
+	}
+
+	linker, err := NewLinker(settings)
+	if err != nil {
+		return fmt.Errorf("failed to create issue linker: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	var keys []string
+	seen := map[string]struct{}{}
+	for _, text := range []string{pr.Title, pr.SourceBranch} {
+		for _, ref := range linker.FindReferences(text) {
+			if _, ok := seen[ref.Key]; ok {
+				continue
+			}
+			seen[ref.Key] = struct{}{}
+			keys = append(keys, ref.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	principal, err := s.principalInfoCache.Get(ctx, base.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	token, err := s.jiraToken(ctx, repo.ParentID, settings.Jira)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get jira token, skipping jira notification")
+		return nil
+	}
+
+	text := fmt.Sprintf("%s %s pull request #%d %q in %s: %s",
+		principal.DisplayName, action, pr.Number, pr.Title, repo.Path,
+		s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number))
+
+	for _, key := range keys {
+		if err = s.postJiraComment(ctx, settings.Jira, token, key, text); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("issue_key", key).Msg("failed to comment on jira issue")
+		}
+	}
+
+	return nil
+}
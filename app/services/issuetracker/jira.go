@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// maxResponseSize is the maximum size of a response body that's read.
+const maxResponseSize = 16 << 10
+
+type jiraComment struct {
+	Body string `json:"body"`
+}
+
+// jiraToken returns the decrypted API token stored in the secret referenced by the Jira settings.
+// The secret is looked up in the space the settings apply to.
+func (s *Service) jiraToken(ctx context.Context, spaceID int64, jira *types.JiraSettings) (string, error) {
+	// the settings might be inherited, hence the secret is looked up in the space and its ancestors.
+	for spaceID > 0 {
+		secret, err := s.secretStore.FindByIdentifier(ctx, spaceID, jira.TokenSecret)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return "", fmt.Errorf("failed to find jira token secret: %w", err)
+		}
+		if err == nil {
+			token, err := s.encrypter.Decrypt([]byte(secret.Data))
+			if err != nil {
+				return "", fmt.Errorf("failed to decrypt jira token secret: %w", err)
+			}
+
+			return token, nil
+		}
+
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return "", fmt.Errorf("jira token secret %q not found", jira.TokenSecret)
+}
+
+// postJiraComment adds a comment to the Jira issue using the Jira REST API.
+func (s *Service) postJiraComment(
+	ctx context.Context,
+	jira *types.JiraSettings,
+	token string,
+	issueKey string,
+	text string,
+) error {
+	data, err := json.Marshal(jiraComment{Body: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	endpoint := strings.TrimSuffix(jira.URL, "/") + "/rest/api/2/issue/" + url.PathEscape(issueKey) + "/comment"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(jira.Username, token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return fmt.Errorf("request failed with status %s: %s", resp.Status, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const (
+	// keyPlaceholder is replaced with the issue key in the URL templates of issue key patterns.
+	keyPlaceholder = "{key}"
+
+	maxPatterns      = 20
+	maxPatternLength = 256
+	maxURLLength     = 2048
+)
+
+// ignoredRegex matches code, markdown links and bare URLs, in which issue keys aren't linked.
+var ignoredRegex = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|\\[[^\\]\n]*\\]\\([^)\n]*\\)|https?://\\S+")
+
+// Linker finds references to issues in texts using the issue key patterns of issue tracker settings.
+type Linker struct {
+	matchers []matcher
+}
+
+type matcher struct {
+	regex *regexp.Regexp
+	url   string
+}
+
+// NewLinker returns a linker for the issue key patterns of the settings.
+// The settings can be nil, in which case no references are found.
+func NewLinker(settings *types.IssueTrackerSettings) (*Linker, error) {
+	if settings == nil {
+		return &Linker{}, nil
+	}
+
+	matchers := make([]matcher, len(settings.Patterns))
+	for i, pattern := range settings.Patterns {
+		regex, err := regexp.Compile(pattern.Pattern)
+		if err != nil {
+			return nil, check.NewValidationErrorf("The issue key pattern %q is invalid: %s", pattern.Pattern, err)
+		}
+
+		matchers[i] = matcher{regex: regex, url: pattern.URL}
+	}
+
+	return &Linker{matchers: matchers}, nil
+}
+
+// FindReferences returns the references to issues in the text, ordered by their position.
+// Keys inside of code, markdown links and URLs are ignored. In case patterns match overlapping
+// parts of the text, the match that starts first (and for the same start, the pattern listed first) is used.
+func (l *Linker) FindReferences(text string) []types.IssueReference {
+	if len(l.matchers) == 0 {
+		return nil
+	}
+
+	ignored := ignoredRegex.FindAllStringIndex(text, -1)
+	isIgnored := func(start, end int) bool {
+		for _, r := range ignored {
+			if start < r[1] && end > r[0] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var refs []types.IssueReference
+	for _, m := range l.matchers {
+		for _, loc := range m.regex.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] || !isWholeWord(text, loc[0], loc[1]) || isIgnored(loc[0], loc[1]) {
+				continue
+			}
+
+			key := text[loc[0]:loc[1]]
+			refs = append(refs, types.IssueReference{
+				Key:   key,
+				URL:   strings.ReplaceAll(m.url, keyPlaceholder, url.PathEscape(key)),
+				Start: loc[0],
+				End:   loc[1],
+			})
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].Start < refs[j].Start })
+
+	result := make([]types.IssueReference, 0, len(refs))
+	for _, ref := range refs {
+		if len(result) > 0 && ref.Start < result[len(result)-1].End {
+			continue
+		}
+		result = append(result, ref)
+	}
+
+	return result
+}
+
+// isWholeWord returns true if the text isn't preceded or followed by a word character,
+// e.g. "PROJ-1" shouldn't match in "XPROJ-12".
+func isWholeWord(text string, start, end int) bool {
+	return (start == 0 || !isWordChar(text[start-1])) && (end == len(text) || !isWordChar(text[end]))
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// RenderMarkdown replaces the referenced issue keys in the text with markdown links to the issues.
+// The references have to be ordered and non-overlapping, as returned by FindReferences.
+func RenderMarkdown(text string, refs []types.IssueReference) string {
+	if len(refs) == 0 {
+		return text
+	}
+
+	sb := strings.Builder{}
+	pos := 0
+	for _, ref := range refs {
+		sb.WriteString(text[pos:ref.Start])
+		sb.WriteString("[")
+		sb.WriteString(ref.Key)
+		sb.WriteString("](")
+		sb.WriteString(ref.URL)
+		sb.WriteString(")")
+		pos = ref.End
+	}
+	sb.WriteString(text[pos:])
+
+	return sb.String()
+}
+
+// CheckSettings validates the issue tracker settings.
+func CheckSettings(settings *types.IssueTrackerSettings) error {
+	if len(settings.Patterns) > maxPatterns {
+		return check.NewValidationErrorf("At most %d issue key patterns can be configured.", maxPatterns)
+	}
+
+	for _, pattern := range settings.Patterns {
+		if pattern.Pattern == "" || len(pattern.Pattern) > maxPatternLength {
+			return check.NewValidationErrorf("An issue key pattern has to be between 1 and %d characters long.",
+				maxPatternLength)
+		}
+
+		if !strings.Contains(pattern.URL, keyPlaceholder) {
+			return check.NewValidationErrorf("The URL of the issue key pattern %q has to contain %q.",
+				pattern.Pattern, keyPlaceholder)
+		}
+
+		if err := checkURL(pattern.URL, "http", "https"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := NewLinker(settings); err != nil {
+		return err
+	}
+
+	jira := settings.Jira
+	if jira == nil || !jira.Enabled {
+		return nil
+	}
+
+	if err := checkURL(jira.URL, "https"); err != nil {
+		return err
+	}
+
+	if jira.Username == "" {
+		return check.NewValidationError("A Jira username is required.")
+	}
+
+	if err := check.Identifier(jira.TokenSecret); err != nil {
+		return check.NewValidationErrorf("The Jira token secret has to be the identifier of a secret: %s", err)
+	}
+
+	return nil
+}
+
+func checkURL(rawURL string, schemes ...string) error {
+	if len(rawURL) > maxURLLength {
+		return check.NewValidationErrorf("A URL can be at most %d characters long.", maxURLLength)
+	}
+
+	parsedURL, err := url.Parse(strings.ReplaceAll(rawURL, keyPlaceholder, "KEY"))
+	if err != nil || parsedURL.Host == "" {
+		return check.NewValidationErrorf("The URL %q is invalid.", rawURL)
+	}
+
+	for _, scheme := range schemes {
+		if parsedURL.Scheme == scheme {
+			return nil
+		}
+	}
+
+	return check.NewValidationErrorf("The scheme of the URL %q has to be one of %v.", rawURL, schemes)
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestLinker(t *testing.T) {
+	settings := &types.IssueTrackerSettings{
+		Patterns: []types.IssueKeyPattern{
+			{Pattern: `PROJ-\d+`, URL: "https://jira.example.com/browse/{key}"},
+			{Pattern: `#\d+`, URL: "https://tracker.example.com/issues/{key}"},
+		},
+	}
+
+	linker, err := NewLinker(settings)
+	if err != nil {
+		t.Fatalf("failed to create linker: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		keys     []string
+		markdown string
+	}{
+		{
+			name:     "none",
+			text:     "fix typo",
+			keys:     nil,
+			markdown: "fix typo",
+		},
+		{
+			name:     "single",
+			text:     "PROJ-12: fix typo",
+			keys:     []string{"PROJ-12"},
+			markdown: "[PROJ-12](https://jira.example.com/browse/PROJ-12): fix typo",
+		},
+		{
+			name: "multiple patterns",
+			text: "fix PROJ-1 and PROJ-2 (see #4)",
+			keys: []string{"PROJ-1", "PROJ-2", "#4"},
+			markdown: "fix [PROJ-1](https://jira.example.com/browse/PROJ-1) and " +
+				"[PROJ-2](https://jira.example.com/browse/PROJ-2) (see [#4](https://tracker.example.com/issues/%234))",
+		},
+		{
+			name:     "whole words only",
+			text:     "XPROJ-1 PROJ-1X",
+			keys:     nil,
+			markdown: "XPROJ-1 PROJ-1X",
+		},
+		{
+			name:     "branch name",
+			text:     "feature/PROJ-7-login",
+			keys:     []string{"PROJ-7"},
+			markdown: "feature/[PROJ-7](https://jira.example.com/browse/PROJ-7)-login",
+		},
+		{
+			name:     "ignored",
+			text:     "`PROJ-1` [PROJ-2](https://x.example.com) https://x.example.com/PROJ-3",
+			keys:     nil,
+			markdown: "`PROJ-1` [PROJ-2](https://x.example.com) https://x.example.com/PROJ-3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refs := linker.FindReferences(test.text)
+
+			var keys []string
+			for _, ref := range refs {
+				keys = append(keys, ref.Key)
+				if test.text[ref.Start:ref.End] != ref.Key {
+					t.Errorf("reference %q has wrong position %d-%d", ref.Key, ref.Start, ref.End)
+				}
+			}
+
+			if !reflect.DeepEqual(keys, test.keys) {
+				t.Errorf("expected keys %v, got %v", test.keys, keys)
+			}
+
+			if markdown := RenderMarkdown(test.text, refs); markdown != test.markdown {
+				t.Errorf("expected markdown %q, got %q", test.markdown, markdown)
+			}
+		})
+	}
+}
+
+func TestCheckSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *types.IssueTrackerSettings
+		valid    bool
+	}{
+		{
+			name: "valid",
+			settings: &types.IssueTrackerSettings{
+				Patterns: []types.IssueKeyPattern{{Pattern: `PROJ-\d+`, URL: "https://jira.example.com/browse/{key}"}},
+				Jira: &types.JiraSettings{
+					Enabled: true, URL: "https://jira.example.com", Username: "bot", TokenSecret: "jira_token",
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid pattern",
+			settings: &types.IssueTrackerSettings{
+				Patterns: []types.IssueKeyPattern{{Pattern: `PROJ-(`, URL: "https://jira.example.com/browse/{key}"}},
+			},
+		},
+		{
+			name: "missing key placeholder",
+			settings: &types.IssueTrackerSettings{
+				Patterns: []types.IssueKeyPattern{{Pattern: `PROJ-\d+`, URL: "https://jira.example.com/browse"}},
+			},
+		},
+		{
+			name: "jira without https",
+			settings: &types.IssueTrackerSettings{
+				Jira: &types.JiraSettings{
+					Enabled: true, URL: "http://jira.example.com", Username: "bot", TokenSecret: "jira_token",
+				},
+			},
+		},
+		{
+			name: "disabled jira isn't validated",
+			settings: &types.IssueTrackerSettings{
+				Jira: &types.JiraSettings{Enabled: false},
+			},
+			valid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckSettings(test.settings)
+			if test.valid && err != nil {
+				t.Errorf("expected settings to be valid, got: %s", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected settings to be invalid")
+			}
+		})
+	}
+}
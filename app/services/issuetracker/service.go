@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuetracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/http/proxy"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	eventsReaderGroupName = "gitness:issuetracker"
+
+	// requestTimeout is the maximum time a single request to the issue tracker can take.
+	requestTimeout = 30 * time.Second
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service links references to issues of external issue trackers and notifies
+// the issue trackers about state transitions of pull requests referencing their issues.
+type Service struct {
+	settings           *settings.Service
+	spaceStore         store.SpaceStore
+	repoStore          store.RepoStore
+	pullreqStore       store.PullReqStore
+	secretStore        store.SecretStore
+	principalInfoCache store.PrincipalInfoCache
+	encrypter          encrypt.Encrypter
+	urlProvider        url.Provider
+	httpClient         *http.Client
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	settingsService *settings.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	secretStore store.SecretStore,
+	principalInfoCache store.PrincipalInfoCache,
+	encrypter encrypt.Encrypter,
+	urlProvider url.Provider,
+	proxyResolver *proxy.Resolver,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided issue tracker service config is invalid: %w", err)
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyResolver != nil {
+		tr.Proxy = proxyResolver.Proxy
+	}
+
+	service := &Service{
+		settings:           settingsService,
+		spaceStore:         spaceStore,
+		repoStore:          repoStore,
+		pullreqStore:       pullreqStore,
+		secretStore:        secretStore,
+		principalInfoCache: principalInfoCache,
+		encrypter:          encrypter,
+		urlProvider:        urlProvider,
+		httpClient:         &http.Client{Transport: tr, Timeout: requestTimeout},
+	}
+
+	_, err := prReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for issue trackers: %w", err)
+	}
+
+	return service, nil
+}
+
+// EffectiveSettings returns the issue tracker settings that apply to the space.
+// These are the settings of the space itself or, if it doesn't configure any, of its closest ancestor
+// (unless the inheritance is interrupted by a space that doesn't inherit the issue tracker settings).
+// Nil is returned in case neither the space nor any of its ancestors configure issue tracker settings.
+func (s *Service) EffectiveSettings(ctx context.Context, spaceID int64) (*types.IssueTrackerSettings, error) {
+	settings, _, err := s.settings.EffectiveIssueTrackerSettings(ctx, spaceID)
+	return settings, err
+}
+
+// Linker returns the linker for the issue tracker settings that apply to the repository.
+func (s *Service) Linker(ctx context.Context, repo *types.Repository) (*Linker, error) {
+	settings, err := s.EffectiveSettings(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLinker(settings)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyrotation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReencrypt        = "gitness:keyrotation:reencrypt"
+	jobCronReencrypt        = "17 * * * *" // At minute 17 past every hour.
+	jobMaxDurationReencrypt = 10 * time.Minute
+)
+
+// Service re-encrypts content stored in the database that is encrypted with a previous encryption key.
+type Service struct {
+	scheduler         *job.Scheduler
+	executor          *job.Executor
+	encrypter         encrypt.Encrypter
+	webhookStore      store.WebhookStore
+	secretStore       store.SecretStore
+	externalHookStore store.ExternalHookStore
+	integrationStore  store.IntegrationStore
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	encrypter encrypt.Encrypter,
+	webhookStore store.WebhookStore,
+	secretStore store.SecretStore,
+	externalHookStore store.ExternalHookStore,
+	integrationStore store.IntegrationStore,
+) *Service {
+	return &Service{
+		scheduler:         scheduler,
+		executor:          executor,
+		encrypter:         encrypter,
+		webhookStore:      webhookStore,
+		secretStore:       secretStore,
+		externalHookStore: externalHookStore,
+		integrationStore:  integrationStore,
+	}
+}
+
+// Register registers the re-encryption job in case the encrypter supports key rotation.
+func (s *Service) Register(ctx context.Context) error {
+	reencrypter, ok := s.encrypter.(encrypt.Reencrypter)
+	if !ok {
+		return nil
+	}
+
+	err := s.executor.Register(jobTypeReencrypt, &reencryptJob{
+		reencrypter:       reencrypter,
+		webhookStore:      s.webhookStore,
+		secretStore:       s.secretStore,
+		externalHookStore: s.externalHookStore,
+		integrationStore:  s.integrationStore,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for re-encryption: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeReencrypt,
+		jobTypeReencrypt,
+		jobCronReencrypt,
+		jobMaxDurationReencrypt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule re-encryption job: %w", err)
+	}
+
+	return nil
+}
+
+type reencryptJob struct {
+	reencrypter       encrypt.Reencrypter
+	webhookStore      store.WebhookStore
+	secretStore       store.SecretStore
+	externalHookStore store.ExternalHookStore
+	integrationStore  store.IntegrationStore
+}
+
+// Handle re-encrypts all webhook secrets, pipeline secrets, external hook secrets and integration secrets
+// that aren't encrypted with the current key.
+func (j *reencryptJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	webhooks, err := j.webhookStore.ReencryptSecrets(ctx, j.reencrypter.Reencrypt)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encrypt webhook secrets: %w", err)
+	}
+
+	secrets, err := j.secretStore.ReencryptData(ctx, j.reencrypter.Reencrypt)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encrypt secrets: %w", err)
+	}
+
+	externalHooks, err := j.externalHookStore.ReencryptSecrets(ctx, j.reencrypter.Reencrypt)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encrypt external hook secrets: %w", err)
+	}
+
+	integrations, err := j.integrationStore.ReencryptSecrets(ctx, j.reencrypter.Reencrypt)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encrypt integration secrets: %w", err)
+	}
+
+	result := fmt.Sprintf(
+		"re-encrypted %d webhook secrets, %d secrets, %d external hook secrets and %d integration secrets",
+		webhooks, secrets, externalHooks, integrations)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
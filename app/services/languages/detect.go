@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package languages
+
+import (
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/git/types"
+	gitness_types "github.com/harness/gitness/types"
+)
+
+type language struct {
+	name  string
+	color string
+}
+
+var (
+	langAssembly   = language{"Assembly", "#6E4C13"}
+	langC          = language{"C", "#555555"}
+	langCPP        = language{"C++", "#f34b7d"}
+	langCSharp     = language{"C#", "#178600"}
+	langCSS        = language{"CSS", "#563d7c"}
+	langClojure    = language{"Clojure", "#db5855"}
+	langCoffee     = language{"CoffeeScript", "#244776"}
+	langDart       = language{"Dart", "#00B4AB"}
+	langDockerfile = language{"Dockerfile", "#384d54"}
+	langElixir     = language{"Elixir", "#6e4a7e"}
+	langErlang     = language{"Erlang", "#B83998"}
+	langFSharp     = language{"F#", "#b845fc"}
+	langGo         = language{"Go", "#00ADD8"}
+	langGroovy     = language{"Groovy", "#4298b8"}
+	langHCL        = language{"HCL", "#844FBA"}
+	langHTML       = language{"HTML", "#e34c26"}
+	langHaskell    = language{"Haskell", "#5e5086"}
+	langJava       = language{"Java", "#b07219"}
+	langJavaScript = language{"JavaScript", "#f1e05a"}
+	langJulia      = language{"Julia", "#a270ba"}
+	langKotlin     = language{"Kotlin", "#A97BFF"}
+	langLess       = language{"Less", "#1d365d"}
+	langLua        = language{"Lua", "#000080"}
+	langMakefile   = language{"Makefile", "#427819"}
+	langOCaml      = language{"OCaml", "#ef7a08"}
+	langObjC       = language{"Objective-C", "#438eff"}
+	langPHP        = language{"PHP", "#4F5D95"}
+	langPerl       = language{"Perl", "#0298c3"}
+	langPowerShell = language{"PowerShell", "#012456"}
+	langProtobuf   = language{"Protocol Buffer", ""}
+	langPython     = language{"Python", "#3572A5"}
+	langR          = language{"R", "#198CE7"}
+	langRuby       = language{"Ruby", "#701516"}
+	langRust       = language{"Rust", "#dea584"}
+	langSCSS       = language{"SCSS", "#c6538c"}
+	langSQL        = language{"SQL", "#e38c00"}
+	langScala      = language{"Scala", "#c22d40"}
+	langShell      = language{"Shell", "#89e051"}
+	langSvelte     = language{"Svelte", "#ff3e00"}
+	langSwift      = language{"Swift", "#F05138"}
+	langTypeScript = language{"TypeScript", "#3178c6"}
+	langVue        = language{"Vue", "#41b883"}
+	langZig        = language{"Zig", "#ec915c"}
+)
+
+// languagesByExtension maps lower case file extensions to languages.
+// Data and prose formats (JSON, YAML, Markdown, ...) are intentionally not included.
+var languagesByExtension = map[string]language{
+	".asm":    langAssembly,
+	".s":      langAssembly,
+	".c":      langC,
+	".h":      langC,
+	".cc":     langCPP,
+	".cpp":    langCPP,
+	".cxx":    langCPP,
+	".hh":     langCPP,
+	".hpp":    langCPP,
+	".cs":     langCSharp,
+	".css":    langCSS,
+	".clj":    langClojure,
+	".cljs":   langClojure,
+	".coffee": langCoffee,
+	".dart":   langDart,
+	".ex":     langElixir,
+	".exs":    langElixir,
+	".erl":    langErlang,
+	".fs":     langFSharp,
+	".go":     langGo,
+	".groovy": langGroovy,
+	".hcl":    langHCL,
+	".tf":     langHCL,
+	".htm":    langHTML,
+	".html":   langHTML,
+	".hs":     langHaskell,
+	".java":   langJava,
+	".cjs":    langJavaScript,
+	".js":     langJavaScript,
+	".jsx":    langJavaScript,
+	".mjs":    langJavaScript,
+	".jl":     langJulia,
+	".kt":     langKotlin,
+	".kts":    langKotlin,
+	".less":   langLess,
+	".lua":    langLua,
+	".mk":     langMakefile,
+	".ml":     langOCaml,
+	".m":      langObjC,
+	".mm":     langObjC,
+	".php":    langPHP,
+	".pl":     langPerl,
+	".pm":     langPerl,
+	".ps1":    langPowerShell,
+	".proto":  langProtobuf,
+	".py":     langPython,
+	".r":      langR,
+	".rb":     langRuby,
+	".rs":     langRust,
+	".scss":   langSCSS,
+	".sql":    langSQL,
+	".scala":  langScala,
+	".bash":   langShell,
+	".sh":     langShell,
+	".zsh":    langShell,
+	".svelte": langSvelte,
+	".swift":  langSwift,
+	".ts":     langTypeScript,
+	".tsx":    langTypeScript,
+	".vue":    langVue,
+	".zig":    langZig,
+}
+
+// languagesByFilename maps file names that don't have a meaningful extension to languages.
+var languagesByFilename = map[string]language{
+	"Dockerfile":     langDockerfile,
+	"Containerfile":  langDockerfile,
+	"Makefile":       langMakefile,
+	"GNUmakefile":    langMakefile,
+	"Gemfile":        langRuby,
+	"Rakefile":       langRuby,
+	"Jenkinsfile":    langGroovy,
+	"CMakeLists.txt": langCPP,
+}
+
+// regexpExcludedPath matches paths of vendored, generated and documentation files
+// that aren't taken into account when computing the language distribution.
+var regexpExcludedPath = regexp.MustCompile(
+	`(^|/)(vendor|node_modules|bower_components|third_party|Godeps|\.git|dist|docs?|Documentation)/` +
+		`|(\.min\.(js|css)|\.pb\.go|_generated\.go|\.generated\.\w+|-lock\.json|\.lock)$`)
+
+// detectLanguage returns the language of a file based on its path.
+func detectLanguage(filePath string) (language, bool) {
+	if regexpExcludedPath.MatchString(filePath) {
+		return language{}, false
+	}
+
+	name := path.Base(filePath)
+	if lang, ok := languagesByFilename[name]; ok {
+		return lang, true
+	}
+
+	if strings.HasPrefix(name, "Dockerfile.") {
+		return langDockerfile, true
+	}
+
+	lang, ok := languagesByExtension[strings.ToLower(path.Ext(name))]
+
+	return lang, ok
+}
+
+// Detect computes the language distribution of the provided files.
+// The returned languages are sorted by their size in descending order.
+func Detect(files []types.TreeFile) []gitness_types.LanguageStat {
+	statMap := make(map[string]*gitness_types.LanguageStat)
+	var total int64
+
+	for _, file := range files {
+		lang, ok := detectLanguage(file.Path)
+		if !ok {
+			continue
+		}
+
+		stat, ok := statMap[lang.name]
+		if !ok {
+			stat = &gitness_types.LanguageStat{
+				Name:  lang.name,
+				Color: lang.color,
+			}
+			statMap[lang.name] = stat
+		}
+
+		stat.Files++
+		stat.Bytes += file.Size
+		total += file.Size
+	}
+
+	stats := make([]gitness_types.LanguageStat, 0, len(statMap))
+	for _, stat := range statMap {
+		if total > 0 {
+			// round to one decimal place.
+			stat.Percentage = math.Round(float64(stat.Bytes)*1000/float64(total)) / 10
+		}
+		stats = append(stats, *stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Bytes != stats[j].Bytes {
+			return stats[i].Bytes > stats[j].Bytes
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
+	return stats
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package languages
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "main.go", expected: "Go"},
+		{path: "web/src/App.TSX", expected: "TypeScript"},
+		{path: "build/Dockerfile", expected: "Dockerfile"},
+		{path: "Dockerfile.dev", expected: "Dockerfile"},
+		{path: "Makefile", expected: "Makefile"},
+		{path: "README.md", expected: ""},
+		{path: "vendor/github.com/lib/lib.go", expected: ""},
+		{path: "web/node_modules/react/index.js", expected: ""},
+		{path: "rpc/service.pb.go", expected: ""},
+		{path: "static/app.min.js", expected: ""},
+		{path: "docs/example.py", expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			lang, ok := detectLanguage(test.path)
+			if ok != (test.expected != "") || lang.name != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, lang.name)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	stats := Detect([]types.TreeFile{
+		{Path: "main.go", Size: 600},
+		{Path: "app/app.go", Size: 150},
+		{Path: "web/index.ts", Size: 250},
+		{Path: "README.md", Size: 5000},
+	})
+
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 languages, got %d", len(stats))
+	}
+
+	if stats[0].Name != "Go" || stats[0].Files != 2 || stats[0].Bytes != 750 || stats[0].Percentage != 75 {
+		t.Errorf("unexpected first language: %+v", stats[0])
+	}
+
+	if stats[1].Name != "TypeScript" || stats[1].Percentage != 25 {
+		t.Errorf("unexpected second language: %+v", stats[1])
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package languages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:languages"
+
+	jobType        = "repo-languages"
+	jobMaxRetries  = 2
+	jobMaxDuration = 5 * time.Minute
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service computes the language distribution of the default branch of repositories
+// in background jobs that are triggered by pushes to the default branch.
+type Service struct {
+	git            git.Interface
+	repoStore      store.RepoStore
+	languagesStore store.RepoLanguagesStore
+	scheduler      *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	languagesStore store.RepoLanguagesStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided languages service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		git:            git,
+		repoStore:      repoStore,
+		languagesStore: languagesStore,
+		scheduler:      scheduler,
+	}
+
+	err := executor.Register(jobType, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register job handler for language detection: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for languages: %w", err)
+	}
+
+	return service, nil
+}
+
+type jobInput struct {
+	RepoID int64  `json:"repo_id"`
+	SHA    string `json:"sha"`
+}
+
+// Find returns the language distribution of the repository. If the stored distribution isn't computed
+// for the provided commit SHA a background job that recomputes it is scheduled. The returned boolean value
+// is true if the returned distribution is stale (or missing) and the recomputation is in progress.
+func (s *Service) Find(ctx context.Context, repo *types.Repository, sha string) (*types.RepoLanguages, bool, error) {
+	languages, err := s.languagesStore.Find(ctx, repo.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, false, fmt.Errorf("failed to find repo languages: %w", err)
+	}
+
+	if languages == nil {
+		languages = &types.RepoLanguages{RepoID: repo.ID}
+	}
+
+	if languages.Languages == nil {
+		languages.Languages = []types.LanguageStat{}
+	}
+
+	if languages.SHA == sha {
+		return languages, false, nil
+	}
+
+	if err := s.schedule(ctx, repo.ID, sha); err != nil {
+		return nil, false, err
+	}
+
+	return languages, true, nil
+}
+
+func (s *Service) schedule(ctx context.Context, repoID int64, sha string) error {
+	data, err := json.Marshal(jobInput{RepoID: repoID, SHA: sha})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobType + "-" + strconv.FormatInt(repoID, 10) + "-" + sha,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the language distribution of the commit is already being computed.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule language detection job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle computes the language distribution of the commit provided in the job input and stores it.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input jobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "repository not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	out, err := s.git.ListTreeFiles(ctx, &git.ListTreeFilesParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		GitREF:     input.SHA,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list tree files: %w", err)
+	}
+
+	stats := Detect(out.Files)
+
+	err = s.languagesStore.Upsert(ctx, &types.RepoLanguages{
+		RepoID:    repo.ID,
+		SHA:       input.SHA,
+		Computed:  time.Now().UnixMilli(),
+		Languages: stats,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store repo languages: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("sha", input.SHA).
+		Msgf("detected %d languages in %d files", len(stats), len(out.Files))
+
+	return "", nil
+}
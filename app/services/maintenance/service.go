@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// changedKey is the pubsub topic used to notify all instances about a change of the maintenance mode.
+	changedKey       = "maintenance_changed"
+	changedNamespace = "maintenance"
+
+	// DefaultMessage is the message shown to users if no message is configured.
+	DefaultMessage = "The system is in read-only mode for maintenance, please try again later."
+)
+
+// Service keeps track of the maintenance mode of the system. The mode is stored in the system settings
+// and kept in memory of every instance, so it can be checked for every request without any database access.
+type Service struct {
+	settings *settings.Service
+	pubsub   pubsub.PubSub
+
+	// forced is the maintenance mode enabled by the config.
+	forced *types.MaintenanceSettings
+
+	mx    sync.RWMutex
+	state types.MaintenanceSettings
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	settings *settings.Service,
+	bus pubsub.PubSub,
+) (*Service, error) {
+	service := &Service{
+		settings: settings,
+		pubsub:   bus,
+	}
+
+	if config.Maintenance.ReadOnly {
+		service.forced = &types.MaintenanceSettings{
+			ReadOnly: true,
+			Message:  config.Maintenance.Message,
+		}
+	}
+
+	if err := service.load(ctx); err != nil {
+		return nil, err
+	}
+
+	_ = bus.Subscribe(ctx, changedKey, func([]byte) error {
+		return service.load(ctx)
+	}, pubsub.WithChannelNamespace(changedNamespace))
+
+	return service, nil
+}
+
+// State returns the effective maintenance mode.
+func (s *Service) State() types.MaintenanceState {
+	if s.forced != nil {
+		return types.MaintenanceState{
+			MaintenanceSettings: *s.forced,
+			Forced:              true,
+		}
+	}
+
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return types.MaintenanceState{
+		MaintenanceSettings: s.state,
+	}
+}
+
+// IsReadOnly returns whether the system is in read-only mode.
+func (s *Service) IsReadOnly() bool {
+	return s.State().ReadOnly
+}
+
+// Message returns the message that explains why a request was rejected because of the read-only mode.
+func (s *Service) Message() string {
+	if message := s.State().Message; message != "" {
+		return message
+	}
+
+	return DefaultMessage
+}
+
+// Set stores the maintenance mode and notifies all instances about the change.
+func (s *Service) Set(ctx context.Context, in types.MaintenanceSettings, updatedBy int64) error {
+	if err := s.settings.SetMaintenanceSettings(ctx, &in, updatedBy); err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	s.state = in
+	s.mx.Unlock()
+
+	err := s.pubsub.Publish(ctx, changedKey, nil, pubsub.WithPublishNamespace(changedNamespace))
+	if err != nil {
+		// the other instances keep the outdated state until the next change.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish maintenance mode change")
+	}
+
+	return nil
+}
+
+func (s *Service) load(ctx context.Context) error {
+	state, err := s.settings.MaintenanceSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load maintenance mode: %w", err)
+	}
+
+	s.mx.Lock()
+	s.state = *state
+	s.mx.Unlock()
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func setupSettings(t *testing.T) *settings.Service {
+	t.Helper()
+
+	db, err := sqlx.Connect("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err = migrate.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	return settings.NewService(database.NewSettingsStore(db), nil)
+}
+
+func TestService_Set(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	settingsService := setupSettings(t)
+
+	service, err := NewService(ctx, &types.Config{}, settingsService, pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	if service.IsReadOnly() || service.Message() != DefaultMessage {
+		t.Fatalf("expected maintenance mode to be disabled by default, got %+v", service.State())
+	}
+
+	err = service.Set(ctx, types.MaintenanceSettings{ReadOnly: true, Message: "backup running"}, 1)
+	if err != nil {
+		t.Fatalf("failed to set maintenance mode: %v", err)
+	}
+
+	if !service.IsReadOnly() || service.Message() != "backup running" {
+		t.Errorf("unexpected maintenance mode after set: %+v", service.State())
+	}
+
+	// other instances load the stored maintenance mode on startup.
+	other, err := NewService(ctx, &types.Config{}, settingsService, pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	if state := other.State(); !state.ReadOnly || state.Message != "backup running" || state.Forced {
+		t.Errorf("unexpected loaded maintenance mode: %+v", state)
+	}
+
+	if err = service.Set(ctx, types.MaintenanceSettings{}, 1); err != nil {
+		t.Fatalf("failed to disable maintenance mode: %v", err)
+	}
+
+	if service.IsReadOnly() {
+		t.Errorf("expected maintenance mode to be disabled")
+	}
+}
+
+func TestService_Forced(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := &types.Config{}
+	config.Maintenance.ReadOnly = true
+
+	service, err := NewService(ctx, config, setupSettings(t), pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	if err = service.Set(ctx, types.MaintenanceSettings{}, 1); err != nil {
+		t.Fatalf("failed to set maintenance mode: %v", err)
+	}
+
+	// the config takes precedence over the stored maintenance mode.
+	if state := service.State(); !state.ReadOnly || !state.Forced || service.Message() != DefaultMessage {
+		t.Errorf("unexpected forced maintenance mode: %+v", state)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package malwarescan
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseClamdReply(t *testing.T) {
+	tests := []struct {
+		reply     string
+		signature string
+		wantErr   bool
+	}{
+		{reply: "stream: OK\x00", signature: ""},
+		{reply: "stream: Win.Test.EICAR_HDB-1 FOUND\x00", signature: "Win.Test.EICAR_HDB-1"},
+		{reply: "INSTREAM size limit exceeded. ERROR\x00", wantErr: true},
+	}
+	for _, test := range tests {
+		signature, err := parseClamdReply(test.reply)
+		if (err != nil) != test.wantErr {
+			t.Errorf("reply %q: unexpected error %v", test.reply, err)
+		}
+		if signature != test.signature {
+			t.Errorf("reply %q: expected signature %q, got %q", test.reply, test.signature, signature)
+		}
+	}
+}
+
+func TestClamdScanner(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		command := make([]byte, len("zINSTREAM\x00"))
+		if _, err = io.ReadFull(conn, command); err != nil {
+			return
+		}
+
+		content := &bytes.Buffer{}
+		for {
+			var size uint32
+			if err = binary.Read(conn, binary.BigEndian, &size); err != nil || size == 0 {
+				break
+			}
+			if _, err = io.CopyN(content, conn, int64(size)); err != nil {
+				return
+			}
+		}
+
+		received <- content.Bytes()
+		_, _ = conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+	}()
+
+	scanner := &clamdScanner{address: listener.Addr().String(), timeout: 5 * time.Second}
+
+	signature, err := scanner.Scan(context.Background(), strings.NewReader("malicious content"))
+	if err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if signature != "Eicar-Test-Signature" {
+		t.Errorf("expected signature %q, got %q", "Eicar-Test-Signature", signature)
+	}
+	if content := <-received; string(content) != "malicious content" {
+		t.Errorf("expected clamd to receive %q, got %q", "malicious content", content)
+	}
+}
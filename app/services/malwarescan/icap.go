@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package malwarescan
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// icapChunkSize is the size of the chunks of the encapsulated http response body.
+const icapChunkSize = 64 * 1024
+
+// icapScanner scans content by sending it as http response body in an ICAP RESPMOD request (RFC 3507).
+type icapScanner struct {
+	address string
+	service string
+	timeout time.Duration
+}
+
+func (s *icapScanner) Scan(ctx context.Context, r io.Reader) (string, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to icap server: %w", err)
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return "", fmt.Errorf("failed to set icap connection deadline: %w", err)
+	}
+
+	const resHeader = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+
+	w := bufio.NewWriter(conn)
+	_, _ = fmt.Fprintf(w, "RESPMOD icap://%s/%s ICAP/1.0\r\n", s.address, s.service)
+	_, _ = fmt.Fprintf(w, "Host: %s\r\n", s.address)
+	_, _ = fmt.Fprintf(w, "Allow: 204\r\n")
+	_, _ = fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(resHeader))
+	_, _ = w.WriteString(resHeader)
+
+	buf := make([]byte, icapChunkSize)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			_, _ = fmt.Fprintf(w, "%x\r\n", n)
+			_, _ = w.Write(buf[:n])
+			_, _ = w.WriteString("\r\n")
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read content: %w", readErr)
+		}
+	}
+	_, _ = w.WriteString("0\r\n\r\n")
+
+	if err = w.Flush(); err != nil {
+		return "", fmt.Errorf("failed to send icap request: %w", err)
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(conn))
+
+	statusLine, err := reader.ReadLine()
+	if err != nil {
+		return "", fmt.Errorf("failed to read icap status line: %w", err)
+	}
+
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read icap response header: %w", err)
+	}
+
+	return parseICAPResponse(statusLine, header)
+}
+
+// parseICAPResponse returns the signature of the detected malware, if any.
+// A "204 No Content" response means the content is unmodified (clean), infections are reported via headers.
+func parseICAPResponse(statusLine string, header textproto.MIMEHeader) (string, error) {
+	fields := strings.Fields(statusLine)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return "", fmt.Errorf("unexpected icap status line: %q", statusLine)
+	}
+
+	switch fields[1] {
+	case "204":
+		return "", nil
+	case "200":
+	default:
+		return "", fmt.Errorf("icap server responded with status: %q", statusLine)
+	}
+
+	// e.g. "X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;"
+	if infection := header.Get("X-Infection-Found"); infection != "" {
+		for _, part := range strings.Split(infection, ";") {
+			if threat, ok := strings.CutPrefix(strings.TrimSpace(part), "Threat="); ok {
+				return threat, nil
+			}
+		}
+		return infection, nil
+	}
+
+	for _, key := range []string{"X-Virus-Id", "X-Violations-Found"} {
+		if value := header.Get(key); value != "" {
+			return value, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package malwarescan
+
+import (
+	"net/textproto"
+	"testing"
+)
+
+func TestParseICAPResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusLine string
+		header     textproto.MIMEHeader
+		signature  string
+		wantErr    bool
+	}{
+		{
+			name:       "clean",
+			statusLine: "ICAP/1.0 204 No Content",
+		},
+		{
+			name:       "infection-found",
+			statusLine: "ICAP/1.0 200 OK",
+			header: textproto.MIMEHeader{
+				"X-Infection-Found": {"Type=0; Resolution=2; Threat=Eicar-Test-Signature;"},
+			},
+			signature: "Eicar-Test-Signature",
+		},
+		{
+			name:       "virus-id",
+			statusLine: "ICAP/1.0 200 OK",
+			header:     textproto.MIMEHeader{"X-Virus-Id": {"EICAR"}},
+			signature:  "EICAR",
+		},
+		{
+			name:       "modified-without-infection",
+			statusLine: "ICAP/1.0 200 OK",
+		},
+		{
+			name:       "server-error",
+			statusLine: "ICAP/1.0 500 Server Error",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid",
+			statusLine: "HTTP/1.1 200 OK",
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature, err := parseICAPResponse(test.statusLine, test.header)
+			if (err != nil) != test.wantErr {
+				t.Errorf("unexpected error %v", err)
+			}
+			if signature != test.signature {
+				t.Errorf("expected signature %q, got %q", test.signature, signature)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package malwarescan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ProtocolClamAV = "clamav"
+	ProtocolICAP   = "icap"
+)
+
+// Scanner scans content for malware.
+type Scanner interface {
+	// Scan returns the signature of the malware found in the content, or an empty string if it's clean.
+	Scan(ctx context.Context, r io.Reader) (string, error)
+}
+
+// Service scans the files that are added to repositories by pushes for malware.
+type Service struct {
+	enabled     bool
+	failOpen    bool
+	maxFileSize int64
+	maxFiles    int
+
+	scanner      Scanner
+	git          git.Interface
+	findingStore store.MalwareFindingStore
+}
+
+func NewService(
+	config *types.Config,
+	git git.Interface,
+	findingStore store.MalwareFindingStore,
+) (*Service, error) {
+	cfg := config.MalwareScan
+
+	var scanner Scanner
+	if cfg.Enabled {
+		switch cfg.Protocol {
+		case ProtocolClamAV:
+			scanner = &clamdScanner{address: cfg.Address, timeout: cfg.Timeout}
+		case ProtocolICAP:
+			scanner = &icapScanner{address: cfg.Address, service: cfg.ICAPService, timeout: cfg.Timeout}
+		default:
+			return nil, fmt.Errorf("unsupported malware scan protocol %q", cfg.Protocol)
+		}
+	}
+
+	return &Service{
+		enabled:      cfg.Enabled,
+		failOpen:     cfg.FailOpen,
+		maxFileSize:  cfg.MaxFileSize,
+		maxFiles:     cfg.MaxFiles,
+		scanner:      scanner,
+		git:          git,
+		findingStore: findingStore,
+	}, nil
+}
+
+// Enabled returns true if pushes are scanned for malware.
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// FailOpen returns true if pushes are accepted in case they couldn't be scanned.
+func (s *Service) FailOpen() bool {
+	return s.failOpen
+}
+
+// ScanPush scans the files that the push adds to the repository and returns the malware that was found.
+// Findings are recorded for the repository. Files larger than the configured maximum size are skipped.
+func (s *Service) ScanPush(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	alternateObjectDirs []string,
+	shas []string,
+) ([]*types.MalwareFinding, error) {
+	if !s.enabled || len(shas) == 0 {
+		return nil, nil
+	}
+
+	out, err := s.git.ListNewBlobs(ctx, &git.ListNewBlobsParams{
+		ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+		AlternateObjectDirs: alternateObjectDirs,
+		SHAs:                shas,
+		Limit:               s.maxFiles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new files: %w", err)
+	}
+
+	var findings []*types.MalwareFinding
+	for _, blob := range out.Blobs {
+		if blob.Size > s.maxFileSize {
+			log.Ctx(ctx).Debug().Msgf("skipping malware scan of file %q with size %d", blob.Path, blob.Size)
+			continue
+		}
+
+		signature, err := s.scanBlob(ctx, repo, alternateObjectDirs, blob.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan file %q: %w", blob.Path, err)
+		}
+		if signature == "" {
+			continue
+		}
+
+		finding := &types.MalwareFinding{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			BlobSHA:     blob.SHA,
+			Path:        blob.Path,
+			Signature:   signature,
+			Created:     time.Now().UnixMilli(),
+		}
+
+		if err = s.findingStore.Create(ctx, finding); err != nil {
+			return nil, fmt.Errorf("failed to record malware finding: %w", err)
+		}
+
+		log.Ctx(ctx).Warn().
+			Int64("repo_id", repo.ID).
+			Int64("principal_id", principalID).
+			Str("path", blob.Path).
+			Str("signature", signature).
+			Msg("malware detected in push")
+
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
+
+// scanBlob streams the content of the blob to the scanner.
+func (s *Service) scanBlob(
+	ctx context.Context,
+	repo *types.Repository,
+	alternateObjectDirs []string,
+	sha string,
+) (string, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		err := s.git.ReadBlob(ctx, &git.ReadBlobParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			AlternateObjectDirs: alternateObjectDirs,
+			SHA:                 sha,
+		}, pw)
+		pw.CloseWithError(err)
+	}()
+
+	return s.scanner.Scan(ctx, pr)
+}
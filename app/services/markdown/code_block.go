@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+const languageMermaid = "mermaid"
+
+// codeBlockRenderer renders fenced code blocks. Mermaid diagrams are rendered
+// as `<pre class="mermaid">` to be picked up by the mermaid library in the browser.
+type codeBlockRenderer struct{}
+
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *codeBlockRenderer) renderFencedCodeBlock(
+	w util.BufWriter,
+	source []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	n := node.(*ast.FencedCodeBlock)
+	language := n.Language(source)
+	mermaid := string(language) == languageMermaid
+
+	if !entering {
+		if mermaid {
+			_, _ = w.WriteString("</pre>\n")
+		} else {
+			_, _ = w.WriteString("</code></pre>\n")
+		}
+		return ast.WalkContinue, nil
+	}
+
+	switch {
+	case mermaid:
+		_, _ = w.WriteString(`<pre class="mermaid">`)
+	case language != nil:
+		_, _ = w.WriteString(`<pre><code class="language-`)
+		html.DefaultWriter.Write(w, language)
+		_, _ = w.WriteString(`">`)
+	default:
+		_, _ = w.WriteString("<pre><code>")
+	}
+
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		html.DefaultWriter.RawWrite(w, line.Value(source))
+	}
+
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	urlprovider "github.com/harness/gitness/app/url"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// mentionRegex matches mentions of principals, e.g. "@john".
+	// The mention has to be at the start of a word to avoid matching email addresses.
+	mentionRegex = regexp.MustCompile(`(?:^|[^\w@./\-])(@([\w.\-]*\w))`)
+
+	// issueRefRegex matches references to issues of the same repository, e.g. "#42".
+	issueRefRegex = regexp.MustCompile(`(?:^|[^\w&/#])(#(\d+))\b`)
+)
+
+// linkTransformer resolves repository relative links and images
+// and turns mentions and issue references into links.
+type linkTransformer struct {
+	urlProvider urlprovider.Provider
+}
+
+func (t *linkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	opts, _ := pc.Get(optionsKey).(Options)
+	source := reader.Source()
+
+	var texts []*ast.Text
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := n.(type) {
+		case *ast.Link:
+			n.Destination = []byte(t.resolveLink(opts, string(n.Destination), false))
+			return ast.WalkSkipChildren, nil
+		case *ast.Image:
+			n.Destination = []byte(t.resolveLink(opts, string(n.Destination), true))
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink, *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *extast.TaskCheckBox:
+			return ast.WalkContinue, nil
+		case *ast.Text:
+			texts = append(texts, n)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	// the texts are modified after the walk to not interfere with it.
+	for _, n := range texts {
+		t.autolink(opts, source, n)
+	}
+}
+
+// resolveLink returns the url of a link found in a markdown document.
+// Links relative to the document are resolved to the UI page of the referenced file,
+// images are resolved to the raw content of the referenced file.
+func (t *linkTransformer) resolveLink(opts Options, dest string, image bool) string {
+	if opts.RepoPath == "" || opts.GitRef == "" || dest == "" || strings.HasPrefix(dest, "#") {
+		return dest
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return dest
+	}
+
+	filePath := u.Path
+	if !strings.HasPrefix(filePath, "/") {
+		filePath = path.Join(path.Dir("/"+opts.Path), filePath)
+	}
+	filePath = strings.TrimLeft(path.Clean(filePath), "/")
+
+	if image {
+		escapedPath := "v1/repos/" + url.PathEscape(opts.RepoPath) + "/raw/" + (&url.URL{Path: filePath}).EscapedPath()
+		return t.urlProvider.GenerateAPIURL(escapedPath) + "?git_ref=" + url.QueryEscape(opts.GitRef)
+	}
+
+	link := t.urlProvider.GenerateUIFileURL(opts.RepoPath, opts.GitRef, filePath)
+	if u.Fragment != "" {
+		link += "#" + u.EscapedFragment()
+	}
+
+	return link
+}
+
+type autolinkMatch struct {
+	start, end int
+	url        string
+	class      string
+}
+
+// autolink replaces mentions and issue references in the text node with links.
+func (t *linkTransformer) autolink(opts Options, source []byte, n *ast.Text) {
+	segment := n.Segment
+	value := segment.Value(source)
+
+	var matches []autolinkMatch
+
+	for _, m := range mentionRegex.FindAllSubmatchIndex(value, -1) {
+		matches = append(matches, autolinkMatch{
+			start: m[2],
+			end:   m[3],
+			url:   t.urlProvider.GenerateUIUserURL(string(value[m[4]:m[5]])),
+			class: "mention",
+		})
+	}
+
+	if opts.RepoPath != "" {
+		for _, m := range issueRefRegex.FindAllSubmatchIndex(value, -1) {
+			number, err := strconv.ParseInt(string(value[m[4]:m[5]]), 10, 64)
+			if err != nil {
+				continue
+			}
+
+			matches = append(matches, autolinkMatch{
+				start: m[2],
+				end:   m[3],
+				url:   t.urlProvider.GenerateUIIssueURL(opts.RepoPath, number),
+				class: "issue-ref",
+			})
+		}
+	}
+
+	if len(matches) == 0 {
+		return
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+
+	parent := n.Parent()
+	pos := 0
+
+	for _, m := range matches {
+		if m.start < pos {
+			// overlapping match
+			continue
+		}
+
+		if m.start > pos {
+			parent.InsertBefore(parent, n,
+				ast.NewTextSegment(text.NewSegment(segment.Start+pos, segment.Start+m.start)))
+		}
+
+		link := ast.NewLink()
+		link.Destination = []byte(m.url)
+		link.SetAttributeString("class", []byte(m.class))
+		link.AppendChild(link, ast.NewTextSegment(text.NewSegment(segment.Start+m.start, segment.Start+m.end)))
+		parent.InsertBefore(parent, n, link)
+
+		pos = m.end
+	}
+
+	// the original node is kept for the rest of the text to preserve its line breaks.
+	n.Segment = segment.WithStart(segment.Start + pos)
+}
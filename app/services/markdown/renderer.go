@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/harness/gitness/app/url"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// Options defines how the references in a markdown document are resolved.
+type Options struct {
+	// RepoPath is the path of the repository the markdown document belongs to.
+	// Relative links and issue references are only resolved if it's provided.
+	RepoPath string
+
+	// GitRef is the git reference against which relative links are resolved.
+	GitRef string
+
+	// Path is the path of the markdown document in the repository.
+	// Relative links are resolved against the directory of the document.
+	Path string
+}
+
+var optionsKey = parser.NewContextKey()
+
+// Renderer converts markdown documents to sanitized HTML. It supports GitHub flavored markdown
+// (tables, strikethrough, autolinks and task lists), autolinking of mentions and issue references,
+// resolution of repository relative links and mermaid diagrams.
+// It's used for all markdown rendered by the server to keep the output consistent.
+type Renderer struct {
+	md        goldmark.Markdown
+	sanitizer *bluemonday.Policy
+}
+
+func NewRenderer(urlProvider url.Provider) *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+			parser.WithASTTransformers(
+				util.Prioritized(&linkTransformer{urlProvider: urlProvider}, 100),
+			),
+		),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(
+				util.Prioritized(&codeBlockRenderer{}, 100),
+			),
+		),
+	)
+
+	return &Renderer{
+		md:        md,
+		sanitizer: newSanitizer(),
+	}
+}
+
+// Render converts the markdown source to sanitized HTML.
+func (r *Renderer) Render(source []byte, opts Options) (string, error) {
+	pc := parser.NewContext()
+	pc.Set(optionsKey, opts)
+
+	buf := &bytes.Buffer{}
+	if err := r.md.Convert(source, buf, parser.WithContext(pc)); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return r.sanitizer.Sanitize(buf.String()), nil
+}
+
+func newSanitizer() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+
+	// autolinked mentions and issue references
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^(mention|issue-ref)$`)).OnElements("a")
+
+	// syntax highlighting hint for code blocks and mermaid diagrams
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.\-]+$`)).OnElements("code")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^mermaid$`)).OnElements("pre")
+
+	// task lists
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	// heading anchors
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\w\-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+
+	return policy
+}
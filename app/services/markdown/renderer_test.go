@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/url"
+)
+
+func TestRender(t *testing.T) {
+	urlProvider, err := url.NewProvider(
+		"http://localhost:3000",
+		"http://localhost:3000",
+		"http://localhost:3000/api",
+		"http://localhost:3000/git",
+		"http://localhost:3000",
+	)
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+
+	r := NewRenderer(urlProvider)
+	opts := Options{RepoPath: "space/repo", GitRef: "main", Path: "docs/README.md"}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "relative link",
+			input:    "[guide](guide.md#setup)",
+			opts:     opts,
+			contains: []string{`href="http://localhost:3000/space/repo/files/main/~/docs/guide.md#setup"`},
+		},
+		{
+			name:     "root relative image",
+			input:    "![logo](/assets/logo.png)",
+			opts:     opts,
+			contains: []string{`src="http://localhost:3000/api/v1/repos/space%2Frepo/raw/assets/logo.png?git_ref=main"`},
+		},
+		{
+			name:     "absolute link",
+			input:    "[site](https://example.com/a)",
+			opts:     opts,
+			contains: []string{`href="https://example.com/a"`},
+		},
+		{
+			name:     "relative link without repo",
+			input:    "[guide](guide.md)",
+			contains: []string{`href="guide.md"`},
+		},
+		{
+			name:  "mention and issue reference",
+			input: "thanks @john.doe for fixing #42, mail me at jane@example.com",
+			opts:  opts,
+			contains: []string{
+				`<a href="http://localhost:3000/users/john.doe" class="mention" rel="nofollow">@john.doe</a>`,
+				`<a href="http://localhost:3000/space/repo/issues/42" class="issue-ref" rel="nofollow">#42</a>`,
+			},
+			excludes: []string{`users/example.com`},
+		},
+		{
+			name:     "no autolinks in code",
+			input:    "`@john #42`",
+			opts:     opts,
+			contains: []string{`<code>@john #42</code>`},
+		},
+		{
+			name:     "task list",
+			input:    "- [x] done\n- [ ] todo",
+			contains: []string{`<input checked="" disabled="" type="checkbox"`, `<input disabled="" type="checkbox"`},
+		},
+		{
+			name:     "mermaid",
+			input:    "```mermaid\ngraph TD;\nA-->B;\n```",
+			contains: []string{"<pre class=\"mermaid\">graph TD;\nA--&gt;B;\n</pre>"},
+		},
+		{
+			name:     "code block",
+			input:    "```go\nfunc main() {}\n```",
+			contains: []string{`<pre><code class="language-go">func main() {}`},
+		},
+		{
+			name:     "sanitized",
+			input:    "[x](javascript:alert(1)) <script>alert(1)</script>",
+			excludes: []string{"javascript:", "<script>"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			html, err := r.Render([]byte(test.input), test.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, s := range test.contains {
+				if !strings.Contains(html, s) {
+					t.Errorf("expected output to contain %q, got %q", s, html)
+				}
+			}
+
+			for _, s := range test.excludes {
+				if strings.Contains(html, s) {
+					t.Errorf("expected output to not contain %q, got %q", s, html)
+				}
+			}
+		})
+	}
+}
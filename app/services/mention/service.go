@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+var (
+	// idRegex matches mentions using the principal id, e.g. "@[42]".
+	idRegex = regexp.MustCompile(`@\[(\d+)\]`)
+
+	// nameRegex matches mentions using the principal uid or user group identifier, e.g. "@john".
+	// The mention has to be at the start of a word to avoid matching email addresses.
+	nameRegex = regexp.MustCompile(`(?:^|[^\w@./\-])@([\w.\-]*\w)`)
+
+	// codeRegex matches code blocks and inline code, which are ignored when looking for mentions.
+	codeRegex = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// Service resolves the principals mentioned in texts, like comments and pull request descriptions.
+type Service struct {
+	principalStore    store.PrincipalStore
+	principalInfoView store.PrincipalInfoView
+	userGroupResolver usergroup.Resolver
+}
+
+func NewService(
+	principalStore store.PrincipalStore,
+	principalInfoView store.PrincipalInfoView,
+	userGroupResolver usergroup.Resolver,
+) *Service {
+	return &Service{
+		principalStore:    principalStore,
+		principalInfoView: principalInfoView,
+		userGroupResolver: userGroupResolver,
+	}
+}
+
+// Parse returns the principal ids and names mentioned in the text. Mentions in code are ignored.
+func Parse(text string) ([]int64, []string) {
+	text = codeRegex.ReplaceAllString(text, "")
+
+	var ids []int64
+	for _, match := range idRegex.FindAllStringSubmatch(text, -1) {
+		if id, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	var names []string
+	for _, match := range nameRegex.FindAllStringSubmatch(text, -1) {
+		names = append(names, match[1])
+	}
+
+	return ids, names
+}
+
+// Resolve returns the principals mentioned in the text.
+// Mentions of user groups are expanded to the users of the group.
+// Mentions that don't match any principal or user group are ignored.
+func (s *Service) Resolve(ctx context.Context, text string) ([]*types.PrincipalInfo, error) {
+	ids, names := Parse(text)
+	return s.resolve(ctx, ids, names)
+}
+
+// ResolveNew returns the principals mentioned in newText that weren't mentioned in oldText already.
+func (s *Service) ResolveNew(ctx context.Context, oldText, newText string) ([]*types.PrincipalInfo, error) {
+	mentioned, err := s.Resolve(ctx, newText)
+	if err != nil || len(mentioned) == 0 || oldText == "" {
+		return mentioned, err
+	}
+
+	previouslyMentioned, err := s.Resolve(ctx, oldText)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(previouslyMentioned))
+	for _, principal := range previouslyMentioned {
+		seen[principal.ID] = true
+	}
+
+	newlyMentioned := make([]*types.PrincipalInfo, 0, len(mentioned))
+	for _, principal := range mentioned {
+		if !seen[principal.ID] {
+			newlyMentioned = append(newlyMentioned, principal)
+		}
+	}
+
+	return newlyMentioned, nil
+}
+
+func (s *Service) resolve(ctx context.Context, ids []int64, names []string) ([]*types.PrincipalInfo, error) {
+	seen := make(map[int64]bool)
+	var mentioned []*types.PrincipalInfo
+
+	add := func(principal *types.PrincipalInfo) {
+		if !seen[principal.ID] {
+			seen[principal.ID] = true
+			mentioned = append(mentioned, principal)
+		}
+	}
+
+	if len(ids) > 0 {
+		principals, err := s.principalInfoView.FindMany(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find mentioned principals by id: %w", err)
+		}
+
+		for _, principal := range principals {
+			add(principal)
+		}
+	}
+
+	if len(names) == 0 {
+		return mentioned, nil
+	}
+
+	principals, err := s.principalStore.FindManyByUID(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mentioned principals by uid: %w", err)
+	}
+
+	found := make(map[string]bool, len(principals))
+	for _, principal := range principals {
+		found[principal.UID] = true
+		add(principal.ToPrincipalInfo())
+	}
+
+	// names that don't belong to a principal could be user groups.
+	for _, name := range names {
+		if found[name] {
+			continue
+		}
+		found[name] = true
+
+		members, err := s.resolveUserGroup(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			add(member)
+		}
+	}
+
+	return mentioned, nil
+}
+
+func (s *Service) resolveUserGroup(ctx context.Context, identifier string) ([]*types.PrincipalInfo, error) {
+	userGroup, err := s.userGroupResolver.Resolve(ctx, identifier)
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mentioned user group %q: %w", identifier, err)
+	}
+
+	if len(userGroup.Users) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.principalStore.FindManyByUID(ctx, userGroup.Users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users of mentioned user group %q: %w", identifier, err)
+	}
+
+	members := make([]*types.PrincipalInfo, len(users))
+	for i, user := range users {
+		members[i] = user.ToPrincipalInfo()
+	}
+
+	return members, nil
+}
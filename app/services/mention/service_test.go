@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mention
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantIDs   []int64
+		wantNames []string
+	}{
+		{
+			name: "no-mentions",
+			text: "looks good to me",
+		},
+		{
+			name:      "names",
+			text:      "@john and @jane.doe, please take a look @ops-team.",
+			wantNames: []string{"john", "jane.doe", "ops-team"},
+		},
+		{
+			name:    "ids",
+			text:    "@[1] and @[42]",
+			wantIDs: []int64{1, 42},
+		},
+		{
+			name:      "ignore-emails",
+			text:      "contact john@example.com or @admin",
+			wantNames: []string{"admin"},
+		},
+		{
+			name:      "ignore-code",
+			text:      "@john see `@Override` and\n```\n@Test\nvoid test() {}\n```\n",
+			wantNames: []string{"john"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ids, names := Parse(test.text)
+			if !slices.Equal(ids, test.wantIDs) {
+				t.Errorf("ids: want=%v got=%v", test.wantIDs, ids)
+			}
+			if !slices.Equal(names, test.wantNames) {
+				t.Errorf("names: want=%v got=%v", test.wantNames, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergemessage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Variables that can be used in commit message templates.
+const (
+	VarNumber       = "number"
+	VarTitle        = "title"
+	VarDescription  = "description"
+	VarSourceBranch = "source_branch"
+	VarTargetBranch = "target_branch"
+	VarSourceRepo   = "source_repo"
+	VarAuthor       = "author"
+	VarAuthorEmail  = "author_email"
+	// VarApprovers is the comma separated list of the names of the reviewers that approved the pull request.
+	VarApprovers = "approvers"
+	// VarCoAuthoredBy are the "Co-authored-by" trailer lines of the authors of the pull request commits,
+	// except for the author of the pull request.
+	VarCoAuthoredBy = "co_authored_by"
+)
+
+var variables = map[string]struct{}{
+	VarNumber:       {},
+	VarTitle:        {},
+	VarDescription:  {},
+	VarSourceBranch: {},
+	VarTargetBranch: {},
+	VarSourceRepo:   {},
+	VarAuthor:       {},
+	VarAuthorEmail:  {},
+	VarApprovers:    {},
+	VarCoAuthoredBy: {},
+}
+
+// variableRegex matches variable references, the variable name is the first submatch.
+var variableRegex = regexp.MustCompile(`{{\s*([a-z_]+)\s*}}`)
+
+// Validate returns an error in case the template references unknown variables.
+func Validate(template string) error {
+	for _, m := range variableRegex.FindAllStringSubmatch(template, -1) {
+		if _, ok := variables[m[1]]; !ok {
+			return fmt.Errorf("unknown variable %q", m[1])
+		}
+	}
+
+	return nil
+}
+
+// Uses returns true if the template references the variable.
+func Uses(template string, variable string) bool {
+	for _, m := range variableRegex.FindAllStringSubmatch(template, -1) {
+		if m[1] == variable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Render replaces the variable references of the template with their values.
+// Unknown variables and variables without value are replaced with an empty string.
+// Trailing whitespace of lines and repeated blank lines left behind by empty values are removed.
+func Render(template string, values map[string]string) string {
+	rendered := variableRegex.ReplaceAllStringFunc(template, func(ref string) string {
+		return values[variableRegex.FindStringSubmatch(ref)[1]]
+	})
+
+	lines := strings.Split(rendered, "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" && len(result) > 0 && result[len(result)-1] == "" {
+			continue
+		}
+		result = append(result, line)
+	}
+
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergemessage
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	if err := Validate("{{title}} (#{{ number }})\n\n{{co_authored_by}}"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := Validate("{{title}} by {{reviewer}}"); err == nil {
+		t.Error("expected an error for an unknown variable")
+	}
+}
+
+func TestUses(t *testing.T) {
+	if !Uses("{{title}}\n\n{{ co_authored_by }}", VarCoAuthoredBy) {
+		t.Error("expected the template to use co_authored_by")
+	}
+
+	if Uses("{{title}}", VarCoAuthoredBy) {
+		t.Error("expected the template not to use co_authored_by")
+	}
+}
+
+func TestRender(t *testing.T) {
+	values := map[string]string{
+		VarNumber:       "7",
+		VarTitle:        "Add endpoint",
+		VarApprovers:    "Jane, John",
+		VarCoAuthoredBy: "Co-authored-by: Jane <jane@example.com>",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "title",
+			template: "{{title}} (#{{number}})",
+			want:     "Add endpoint (#7)",
+		},
+		{
+			name:     "message",
+			template: "Approved by: {{approvers}}\n\n{{co_authored_by}}",
+			want:     "Approved by: Jane, John\n\nCo-authored-by: Jane <jane@example.com>",
+		},
+		{
+			name:     "empty values collapse blank lines",
+			template: "{{description}}\n\n{{source_branch}}\n\n{{co_authored_by}}\n",
+			want:     "Co-authored-by: Jane <jane@example.com>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Render(test.template, values); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergeschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeScheduledMerges        = "gitness:pullreq:scheduled-merges"
+	jobCronScheduledMerges        = "* * * * *" // Every minute.
+	jobMaxDurationScheduledMerges = 10 * time.Minute
+)
+
+// Service merges pull requests whose merge schedule is due.
+type Service struct {
+	scheduler          *job.Scheduler
+	executor           *job.Executor
+	mergeScheduleStore store.MergeScheduleStore
+	pullreqCtrl        *pullreq.Controller
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	mergeScheduleStore store.MergeScheduleStore,
+	pullreqCtrl *pullreq.Controller,
+) *Service {
+	return &Service{
+		scheduler:          scheduler,
+		executor:           executor,
+		mergeScheduleStore: mergeScheduleStore,
+		pullreqCtrl:        pullreqCtrl,
+	}
+}
+
+// Register registers the scheduled merge job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeScheduledMerges, &mergeJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for scheduled merges: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeScheduledMerges,
+		jobTypeScheduledMerges,
+		jobCronScheduledMerges,
+		jobMaxDurationScheduledMerges,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule scheduled merge job: %w", err)
+	}
+
+	return nil
+}
+
+type mergeJob struct {
+	service *Service
+}
+
+// Handle attempts to merge every pull request whose merge schedule is due.
+// A schedule is removed before the merge is attempted, so every schedule results in a single merge attempt.
+func (j *mergeJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	schedules, err := j.service.mergeScheduleStore.ListDue(ctx, time.Now().UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list due merge schedules: %w", err)
+	}
+
+	attempted := 0
+	for _, schedule := range schedules {
+		claimed, err := j.service.mergeScheduleStore.Delete(ctx, schedule.PullReqID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to claim merge schedule of pull request %d",
+				schedule.PullReqID)
+			continue
+		}
+
+		if !claimed {
+			// the schedule got cancelled in the meantime.
+			continue
+		}
+
+		attempted++
+
+		if err = j.service.pullreqCtrl.MergeScheduled(ctx, schedule); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed scheduled merge of pull request %d", schedule.PullReqID)
+		}
+	}
+
+	result := fmt.Sprintf("attempted %d scheduled merges", attempted)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
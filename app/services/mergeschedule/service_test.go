@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergeschedule
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// memMergeScheduleStore keeps the merge schedules in memory.
+// Schedules listed in cancelled are removed between listing and claiming them.
+type memMergeScheduleStore struct {
+	store.MergeScheduleStore
+	schedules map[int64]*types.MergeSchedule
+	cancelled map[int64]bool
+}
+
+func (s *memMergeScheduleStore) Delete(_ context.Context, prID int64) (bool, error) {
+	if s.cancelled[prID] {
+		delete(s.schedules, prID)
+		return false, nil
+	}
+	_, ok := s.schedules[prID]
+	delete(s.schedules, prID)
+	return ok, nil
+}
+
+func (s *memMergeScheduleStore) ListDue(_ context.Context, before int64) ([]*types.MergeSchedule, error) {
+	var due []*types.MergeSchedule
+	for _, schedule := range s.schedules {
+		if schedule.MergeAt < before {
+			due = append(due, schedule)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].MergeAt < due[j].MergeAt })
+	return due, nil
+}
+
+// closedPullReqStore returns every pull request as closed, so scheduled merges end without merging.
+type closedPullReqStore struct {
+	store.PullReqStore
+	found []int64
+}
+
+func (s *closedPullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	s.found = append(s.found, id)
+	return &types.PullReq{ID: id, State: enum.PullReqStateClosed}, nil
+}
+
+func TestMergeJob_Handle(t *testing.T) {
+	now := time.Now()
+	scheduleStore := &memMergeScheduleStore{
+		schedules: map[int64]*types.MergeSchedule{
+			1: {PullReqID: 1, MergeAt: now.Add(-time.Minute).UnixMilli()},
+			2: {PullReqID: 2, MergeAt: now.Add(-2 * time.Minute).UnixMilli()},
+			3: {PullReqID: 3, MergeAt: now.Add(time.Hour).UnixMilli()},
+		},
+		cancelled: map[int64]bool{2: true},
+	}
+	pullreqStore := &closedPullReqStore{}
+
+	pullreqCtrl := pullreq.NewController(nil, nil, nil, pullreqStore, nil, nil, nil, nil, nil, nil,
+		scheduleStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, false)
+	job := &mergeJob{service: NewService(nil, nil, scheduleStore, pullreqCtrl)}
+
+	result, err := job.Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("failed to handle scheduled merges: %v", err)
+	}
+	if result != "attempted 1 scheduled merges" {
+		t.Errorf("unexpected result: %s", result)
+	}
+
+	// only the due schedule that wasn't cancelled in the meantime is merged.
+	if len(pullreqStore.found) != 1 || pullreqStore.found[0] != 1 {
+		t.Errorf("expected a merge attempt of pull request 1 only, got %v", pullreqStore.found)
+	}
+
+	// due schedules are removed, schedules in the future are kept.
+	if _, ok := scheduleStore.schedules[3]; !ok || len(scheduleStore.schedules) != 1 {
+		t.Errorf("expected only the future merge schedule to remain, got %v", scheduleStore.schedules)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -88,6 +89,11 @@ func (s *Service) processPullReqBranchUpdatedEvent(
 		}
 	}
 
+	reviewerPrincipals, err = s.filterRecipients(ctx, enum.NotificationLevelAll, reviewerPrincipals)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return &PullReqBranchUpdatedPayload{
 		Base:      base,
 		NewSHA:    event.Payload.NewSHA,
@@ -33,6 +33,11 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *CommentPayload,
 	) error
+	SendDescriptionMentions(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *DescriptionMentionsPayload,
+	) error
 	SendCommentParticipants(
 		ctx context.Context,
 		recipients []*types.PrincipalInfo,
@@ -43,6 +48,11 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *ReviewerAddedPayload,
 	) error
+	SendReviewReminder(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *ReviewReminderPayload,
+	) error
 	SendPullReqBranchUpdated(
 		ctx context.Context,
 		recipients []*types.PrincipalInfo,
@@ -58,4 +68,25 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendWebhookFailed(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *WebhookFailedPayload,
+	) error
+	SendRepoArchived(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *RepoArchivedPayload,
+	) error
+	SendVulnerabilityAlerts(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *VulnerabilityAlertsPayload,
+	) error
+	// SendInvitation sends an invitation to an email address, as the invited person doesn't have an account yet.
+	SendInvitation(
+		ctx context.Context,
+		recipientEmail string,
+		payload *InvitationPayload,
+	) error
 }
@@ -17,14 +17,11 @@ package notification
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
-
-	"github.com/rs/zerolog/log"
+	"github.com/harness/gitness/types/enum"
 )
 
 type CommentPayload struct {
@@ -37,7 +34,7 @@ func (s *Service) notifyCommentCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CommentCreatedPayload],
 ) error {
-	payload, mentions, participants, author, err := s.processCommentCreatedEvent(ctx, event)
+	payload, participants, author, err := s.processCommentCreatedEvent(ctx, event)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -47,18 +44,6 @@ func (s *Service) notifyCommentCreated(
 		)
 	}
 
-	if len(mentions) > 0 {
-		err = s.notificationClient.SendCommentMentions(ctx, mentions, payload)
-		if err != nil {
-			return fmt.Errorf(
-				"failed to send notification to mentions for event %s for pullReqID %d: %w",
-				pullreqevents.CommentCreatedEvent,
-				event.Payload.PullReqID,
-				err,
-			)
-		}
-	}
-
 	if len(participants) > 0 {
 		err = s.notificationClient.SendCommentParticipants(ctx, participants, payload)
 		if err != nil {
@@ -95,24 +80,23 @@ func (s *Service) processCommentCreatedEvent(
 	event *events.Event[*pullreqevents.CommentCreatedPayload],
 ) (
 	payload *CommentPayload,
-	mentions []*types.PrincipalInfo,
 	participants []*types.PrincipalInfo,
 	author *types.PrincipalInfo,
 	err error,
 ) {
 	base, err := s.getBasePayload(ctx, event.Payload.Base)
 	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("failed to get base payload: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
 
 	activity, err := s.pullReqActivityStore.Find(ctx, event.Payload.ActivityID)
 	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("failed to fetch activity from pullReqActivityStore: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch activity from pullReqActivityStore: %w", err)
 	}
 
 	commenter, err := s.principalInfoView.Find(ctx, activity.CreatedBy)
 	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("failed to fetch commenter from principalInfoView: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch commenter from principalInfoView: %w", err)
 	}
 
 	payload = &CommentPayload{
@@ -124,17 +108,17 @@ func (s *Service) processCommentCreatedEvent(
 	seen := make(map[int64]bool)
 	seen[commenter.ID] = true
 
-	// process mentions
-	mentions, err = s.processMentions(ctx, activity.Text, seen)
+	// mentioned principals are notified with the mentioned event.
+	err = s.processMentions(ctx, activity.Text, seen)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// process participants
 	participants, err = s.processParticipants(
 		ctx, event.Payload.IsReply, seen, event.Payload.PullReqID, activity.Order)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// process author
@@ -142,37 +126,41 @@ func (s *Service) processCommentCreatedEvent(
 		author = base.Author
 	}
 
-	return payload, mentions, participants, author, nil
+	participants, err = s.filterRecipients(ctx, enum.NotificationLevelParticipating, participants)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if author != nil {
+		var authors []*types.PrincipalInfo
+		authors, err = s.filterRecipients(ctx, enum.NotificationLevelParticipating, []*types.PrincipalInfo{author})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(authors) == 0 {
+			author = nil
+		}
+	}
+
+	return payload, participants, author, nil
 }
 
+// processMentions marks the principals mentioned in the text as seen.
 func (s *Service) processMentions(
 	ctx context.Context,
 	text string,
 	seen map[int64]bool,
-) ([]*types.PrincipalInfo, error) {
-	var mentions []*types.PrincipalInfo
-
-	commentMentions := parseMentions(ctx, text)
-	if len(commentMentions) == 0 {
-		return []*types.PrincipalInfo{}, nil
+) error {
+	mentions, err := s.mentions.Resolve(ctx, text)
+	if err != nil {
+		return fmt.Errorf("failed to resolve comment mentions: %w", err)
 	}
 
-	var mentionIDs []int64
-	for _, mentionID := range commentMentions {
-		if !seen[mentionID] {
-			mentionIDs = append(mentionIDs, mentionID)
-			seen[mentionID] = true
-		}
-	}
-	if len(mentionIDs) > 0 {
-		var err error
-		mentions, err = s.principalInfoView.FindMany(ctx, mentionIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch thread mentions from principalInfoView: %w", err)
-		}
+	for _, mention := range mentions {
+		seen[mention.ID] = true
 	}
 
-	return mentions, nil
+	return nil
 }
 
 func (s *Service) processParticipants(
@@ -213,23 +201,3 @@ func (s *Service) processParticipants(
 
 	return participants, nil
 }
-
-var mentionRegex = regexp.MustCompile(`@\[(\d+)\]`)
-
-func parseMentions(ctx context.Context, text string) []int64 {
-	matches := mentionRegex.FindAllStringSubmatch(text, -1)
-
-	var mentions []int64
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		if mention, err := strconv.ParseInt(match[1], 10, 64); err == nil {
-			mentions = append(mentions, mention)
-		} else {
-			log.Ctx(ctx).Warn().Err(err).Msgf("failed to parse mention %q", match[1])
-		}
-	}
-
-	return mentions
-}
@@ -26,12 +26,18 @@ import (
 
 const (
 	TemplateReviewerAdded        = "reviewer_added.html"
+	TemplateReviewReminder       = "review_reminder.html"
 	TemplateCommentPRAuthor      = "comment_pr_author.html"
 	TemplateCommentMentions      = "comment_mentions.html"
 	TemplateCommentParticipants  = "comment_participants.html"
+	TemplateDescriptionMentions  = "description_mentions.html"
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateWebhookFailed        = "webhook_failed.html"
+	TemplateRepoArchived         = "repo_archived.html"
+	TemplateVulnerabilityAlerts  = "vulnerability_alerts.html"
+	TemplateInvitation           = "invitation.html"
 )
 
 type MailClient struct {
@@ -80,6 +86,24 @@ func (m MailClient) SendCommentMentions(
 
 	return m.Mailer.Send(ctx, *email)
 }
+func (m MailClient) SendDescriptionMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *DescriptionMentionsPayload,
+) error {
+	email, err := GenerateEmailFromPayload(
+		TemplateDescriptionMentions,
+		recipients,
+		payload.Base,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.MentionedEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
 func (m MailClient) SendCommentParticipants(
 	ctx context.Context,
 	recipients []*types.PrincipalInfo,
@@ -118,6 +142,25 @@ func (m MailClient) SendReviewerAdded(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendReviewReminder(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewReminderPayload,
+) error {
+	email, err := GenerateEmailFromPayload(
+		TemplateReviewReminder,
+		recipients,
+		payload.Base,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.ReviewReminderEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
 func (m MailClient) SendPullReqBranchUpdated(
 	ctx context.Context,
 	recipients []*types.PrincipalInfo,
@@ -169,6 +212,79 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendWebhookFailed(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookFailedPayload,
+) error {
+	body, err := GetHTMLBody(TemplateWebhookFailed, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for failed webhook: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectWebhookFailed, payload.Webhook.Identifier),
+		Body:         string(body),
+	})
+}
+
+func (m MailClient) SendRepoArchived(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *RepoArchivedPayload,
+) error {
+	body, err := GetHTMLBody(TemplateRepoArchived, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for archived repository: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectRepoArchived, payload.Repo.Path),
+		Body:         string(body),
+	})
+}
+
+func (m MailClient) SendVulnerabilityAlerts(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *VulnerabilityAlertsPayload,
+) error {
+	body, err := GetHTMLBody(TemplateVulnerabilityAlerts, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for vulnerability alerts: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectVulnerabilityAlerts, len(payload.Alerts), payload.Repo.Path),
+		Body:         string(body),
+	})
+}
+
+func (m MailClient) SendInvitation(
+	ctx context.Context,
+	recipientEmail string,
+	payload *InvitationPayload,
+) error {
+	body, err := GetHTMLBody(TemplateInvitation, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for invitation: %w", err)
+	}
+
+	subject := subjectInvitation
+	if payload.SpacePath != "" {
+		subject = fmt.Sprintf(subjectSpaceInvitation, payload.SpacePath)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{recipientEmail},
+		Subject:      subject,
+		Body:         string(body),
+	})
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type DescriptionMentionsPayload struct {
+	Base   *BasePullReqPayload
+	Author *types.PrincipalInfo
+	Text   string
+}
+
+func (s *Service) notifyMentioned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MentionedPayload],
+) error {
+	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	if err != nil {
+		return fmt.Errorf("failed to get base payload: %w", err)
+	}
+
+	mentioner, err := s.principalInfoCache.Get(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to get mentioner from principalInfoCache: %w", err)
+	}
+
+	mentioned, err := s.principalInfoView.FindMany(ctx, event.Payload.PrincipalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch mentioned principals from principalInfoView: %w", err)
+	}
+
+	recipients, err := s.filterRecipients(ctx, enum.NotificationLevelMentions, mentioned)
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	if event.Payload.CommentID == nil {
+		err = s.notificationClient.SendDescriptionMentions(ctx, recipients, &DescriptionMentionsPayload{
+			Base:   base,
+			Author: mentioner,
+			Text:   base.PullReq.Description,
+		})
+	} else {
+		var activity *types.PullReqActivity
+		activity, err = s.pullReqActivityStore.Find(ctx, *event.Payload.CommentID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch comment from pullReqActivityStore: %w", err)
+		}
+
+		err = s.notificationClient.SendCommentMentions(ctx, recipients, &CommentPayload{
+			Base:      base,
+			Commenter: mentioner,
+			Text:      activity.Text,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for pullReqID %d: %w",
+			pullreqevents.MentionedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	return nil
+}
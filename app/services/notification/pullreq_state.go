@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -51,6 +52,10 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -80,6 +85,10 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -109,6 +118,10 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -169,6 +182,11 @@ func (s *Service) processPullReqStateChangedEvent(
 
 	recipients[len(reviewers)] = author
 
+	recipients, err = s.filterRecipients(ctx, enum.NotificationLevelParticipating, recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return &PullReqStateChangedPayload{
 		Base:      basePayload,
 		ChangedBy: stateModifierPrincipal,
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// membershipPageSize is the number of memberships fetched at once when looking for repository admins.
+const membershipPageSize = 100
+
+type RepoArchivedPayload struct {
+	Repo    *types.Repository
+	RepoURL string
+}
+
+// notifyRepoArchived notifies the admins of a repository (space owners) that it was archived automatically.
+func (s *Service) notifyRepoArchived(ctx context.Context, repoID int64) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo %d: %w", repoID, err)
+	}
+
+	if !repo.Archived {
+		// the repository got unarchived in the meantime.
+		return nil
+	}
+
+	admins, err := s.repoAdmins(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := s.filterRecipients(ctx, enum.NotificationLevelParticipating, admins)
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendRepoArchived(ctx, recipients, &RepoArchivedPayload{
+		Repo:    repo,
+		RepoURL: s.urlProvider.GenerateUIRepoURL(repo.Path),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send notification for archived repo %d: %w", repoID, err)
+	}
+
+	return nil
+}
+
+// repoAdmins returns the space owners of all spaces whose memberships grant access to the repository.
+func (s *Service) repoAdmins(ctx context.Context, repo *types.Repository) ([]*types.PrincipalInfo, error) {
+	chain, err := s.settings.InheritanceChain(ctx, repo.ParentID, enum.InheritedSettingMemberships)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inheritance chain of memberships: %w", err)
+	}
+
+	var admins []*types.PrincipalInfo
+	for _, space := range chain {
+		for page := 1; ; page++ {
+			memberships, err := s.membershipStore.ListUsers(ctx, space.ID, types.MembershipUserFilter{
+				ListQueryFilter: types.ListQueryFilter{
+					Pagination: types.Pagination{Page: page, Size: membershipPageSize},
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list members of space %d: %w", space.ID, err)
+			}
+
+			for i := range memberships {
+				if memberships[i].Role == enum.MembershipRoleSpaceOwner {
+					admins = append(admins, &memberships[i].Principal)
+				}
+			}
+
+			if len(memberships) < membershipPageSize {
+				break
+			}
+		}
+	}
+
+	return admins, nil
+}
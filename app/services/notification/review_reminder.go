@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReviewReminderPayload struct {
+	Base         *BasePullReqPayload
+	PendingHours int
+}
+
+func (s *Service) notifyReviewReminder(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewReminderPayload],
+) error {
+	payload, recipients, err := s.processReviewReminderEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for pullReqID %d: %w",
+			pullreqevents.ReviewReminderEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendReviewReminder(ctx, recipients, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send email for event %s for pullReqID %d: %w",
+			pullreqevents.ReviewReminderEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processReviewReminderEvent(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewReminderPayload],
+) (*ReviewReminderPayload, []*types.PrincipalInfo, error) {
+	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
+	}
+
+	reviewers, err := s.principalInfoView.FindMany(ctx, event.Payload.ReviewerIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find reviewers: %w", err)
+	}
+
+	// the reminder is addressed to the reviewers directly.
+	recipients, err := s.filterRecipients(ctx, enum.NotificationLevelMentions, reviewers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ReviewReminderPayload{
+		Base:         base,
+		PendingHours: event.Payload.PendingHours,
+	}, recipients, nil
+}
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ReviewerAddedPayload struct {
@@ -42,6 +43,10 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
@@ -69,11 +74,21 @@ func (s *Service) processReviewerAddedEvent(
 		return nil, nil, fmt.Errorf("failed to get reviewer from principalInfoCache: %w", err)
 	}
 
-	recipients := []*types.PrincipalInfo{
-		base.Author,
-		reviewerPrincipal,
+	// the review request is addressed to the reviewer directly, the author only participates in the pull request.
+	reviewers, err := s.filterRecipients(ctx, enum.NotificationLevelMentions,
+		[]*types.PrincipalInfo{reviewerPrincipal})
+	if err != nil {
+		return nil, nil, err
 	}
 
+	authors, err := s.filterRecipients(ctx, enum.NotificationLevelParticipating,
+		[]*types.PrincipalInfo{base.Author})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recipients := append(reviewers, authors...)
+
 	return &ReviewerAddedPayload{
 		Base:     base,
 		Reviewer: reviewerPrincipal,
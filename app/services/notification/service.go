@@ -23,17 +23,29 @@ import (
 	"path"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/mention"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+	subjectWebhookFailed = "Webhook %q failed"
+	subjectRepoArchived  = "Repository %s was archived"
+
+	subjectVulnerabilityAlerts = "%d new vulnerabilities affect dependencies of %s"
+
+	subjectInvitation      = "You have been invited to Gitness"
+	subjectSpaceInvitation = "You have been invited to join %s"
 )
 
 var (
@@ -85,17 +97,23 @@ type Config struct {
 }
 
 type Service struct {
-	config                Config
-	notificationClient    Client
-	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
-	pullReqStore          store.PullReqStore
-	repoStore             store.RepoStore
-	principalInfoView     store.PrincipalInfoView
-	principalInfoCache    store.PrincipalInfoCache
-	pullReqReviewersStore store.PullReqReviewerStore
-	pullReqActivityStore  store.PullReqActivityStore
-	spacePathStore        store.SpacePathStore
-	urlProvider           url.Provider
+	config                  Config
+	notificationClient      Client
+	prReaderFactory         *events.ReaderFactory[*pullreqevents.Reader]
+	pullReqStore            store.PullReqStore
+	repoStore               store.RepoStore
+	principalInfoView       store.PrincipalInfoView
+	principalInfoCache      store.PrincipalInfoCache
+	pullReqReviewersStore   store.PullReqReviewerStore
+	pullReqActivityStore    store.PullReqActivityStore
+	spacePathStore          store.SpacePathStore
+	urlProvider             url.Provider
+	settings                *settings.Service
+	mentions                *mention.Service
+	webhookStore            store.WebhookStore
+	webhookExecutionStore   store.WebhookExecutionStore
+	membershipStore         store.MembershipStore
+	vulnerabilityAlertStore store.VulnerabilityAlertStore
 }
 
 func NewService(
@@ -111,19 +129,33 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	settings *settings.Service,
+	mentions *mention.Service,
+	systemReaderFactory *events.ReaderFactory[*systemevents.Reader],
+	webhookStore store.WebhookStore,
+	webhookExecutionStore store.WebhookExecutionStore,
+	membershipStore store.MembershipStore,
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	vulnerabilityAlertStore store.VulnerabilityAlertStore,
 ) (*Service, error) {
 	service := &Service{
-		config:                config,
-		notificationClient:    notificationClient,
-		prReaderFactory:       prReaderFactory,
-		pullReqStore:          pullReqStore,
-		repoStore:             repoStore,
-		principalInfoView:     principalInfoView,
-		principalInfoCache:    principalInfoCache,
-		pullReqReviewersStore: pullReqReviewersStore,
-		pullReqActivityStore:  pullReqActivityStore,
-		spacePathStore:        spacePathStore,
-		urlProvider:           urlProvider,
+		config:                  config,
+		notificationClient:      notificationClient,
+		prReaderFactory:         prReaderFactory,
+		pullReqStore:            pullReqStore,
+		repoStore:               repoStore,
+		principalInfoView:       principalInfoView,
+		principalInfoCache:      principalInfoCache,
+		pullReqReviewersStore:   pullReqReviewersStore,
+		pullReqActivityStore:    pullReqActivityStore,
+		spacePathStore:          spacePathStore,
+		urlProvider:             urlProvider,
+		settings:                settings,
+		mentions:                mentions,
+		webhookStore:            webhookStore,
+		webhookExecutionStore:   webhookExecutionStore,
+		membershipStore:         membershipStore,
+		vulnerabilityAlertStore: vulnerabilityAlertStore,
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -139,7 +171,9 @@ func NewService(
 				))
 
 			_ = r.RegisterReviewerAdded(service.notifyReviewerAdded)
+			_ = r.RegisterReviewReminder(service.notifyReviewReminder)
 			_ = r.RegisterCommentCreated(service.notifyCommentCreated)
+			_ = r.RegisterMentioned(service.notifyMentioned)
 			_ = r.RegisterBranchUpdated(service.notifyPullReqBranchUpdated)
 			_ = r.RegisterReviewSubmitted(service.notifyReviewSubmitted)
 
@@ -153,6 +187,42 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = systemReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *systemevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterActivity(service.notifySystemActivity)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch system event reader for %s: %w", eventReaderGroupName, err)
+	}
+
+	_, err = repoReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterVulnerabilityAlertsCreated(service.notifyVulnerabilityAlertsCreated)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
@@ -183,3 +253,38 @@ func (s *Service) getBasePayload(
 		PullReqURL: s.urlProvider.GenerateUIPRURL(repo.Path, pullReq.Number),
 	}, nil
 }
+
+// filterRecipients returns the recipients that want to receive notifications of the provided level.
+// Duplicate recipients are removed.
+func (s *Service) filterRecipients(
+	ctx context.Context,
+	level enum.NotificationLevel,
+	recipients []*types.PrincipalInfo,
+) ([]*types.PrincipalInfo, error) {
+	if len(recipients) == 0 {
+		return recipients, nil
+	}
+
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	levels, err := s.settings.NotificationLevels(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification levels of recipients: %w", err)
+	}
+
+	seen := make(map[int64]bool, len(recipients))
+	filtered := make([]*types.PrincipalInfo, 0, len(recipients))
+	for _, recipient := range recipients {
+		if seen[recipient.ID] || !levels[recipient.ID].Includes(level) {
+			continue
+		}
+
+		seen[recipient.ID] = true
+		filtered = append(filtered, recipient)
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type VulnerabilityAlertsPayload struct {
+	Repo    *types.Repository
+	RepoURL string
+	Alerts  []*types.VulnerabilityAlert
+}
+
+// notifyVulnerabilityAlertsCreated notifies the admins of a repository (space owners)
+// about new vulnerabilities that affect dependencies of the repository.
+func (s *Service) notifyVulnerabilityAlertsCreated(
+	ctx context.Context,
+	event *events.Event[*repoevents.VulnerabilityAlertsCreatedPayload],
+) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo %d: %w", event.Payload.RepoID, err)
+	}
+
+	alerts := make([]*types.VulnerabilityAlert, 0, len(event.Payload.AlertIDs))
+	for _, alertID := range event.Payload.AlertIDs {
+		alert, err := s.vulnerabilityAlertStore.Find(ctx, alertID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find vulnerability alert %d: %w", alertID, err)
+		}
+
+		if alert.State == enum.VulnerabilityAlertStateOpen {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	admins, err := s.repoAdmins(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := s.filterRecipients(ctx, enum.NotificationLevelParticipating, admins)
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendVulnerabilityAlerts(ctx, recipients, &VulnerabilityAlertsPayload{
+		Repo:    repo,
+		RepoURL: s.urlProvider.GenerateUIRepoURL(repo.Path),
+		Alerts:  alerts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send notification for vulnerability alerts of repo %d: %w", repo.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type WebhookFailedPayload struct {
+	Webhook   *types.Webhook
+	Execution *types.WebhookExecution
+}
+
+// notifySystemActivity sends notifications for system activities that are of interest to users.
+func (s *Service) notifySystemActivity(
+	ctx context.Context,
+	event *events.Event[*systemevents.ActivityPayload],
+) error {
+	if event.Payload.ResourceID == nil {
+		return nil
+	}
+
+	switch event.Payload.Type {
+	case enum.SystemEventTypeWebhookFailed:
+		return s.notifyWebhookFailed(ctx, *event.Payload.ResourceID)
+	case enum.SystemEventTypeRepoArchived:
+		return s.notifyRepoArchived(ctx, *event.Payload.ResourceID)
+	default:
+		return nil
+	}
+}
+
+// notifyWebhookFailed notifies the creator of the webhook about a failed execution.
+// To avoid flooding the creator with emails, only the first failure after a successful execution is reported.
+func (s *Service) notifyWebhookFailed(ctx context.Context, webhookID int64) error {
+	webhook, err := s.webhookStore.Find(ctx, webhookID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find webhook %d: %w", webhookID, err)
+	}
+
+	if webhook.Internal {
+		return nil
+	}
+
+	executions, err := s.webhookExecutionStore.ListForWebhook(ctx, webhookID,
+		&types.WebhookExecutionFilter{Page: 1, Size: 2})
+	if err != nil {
+		return fmt.Errorf("failed to list executions of webhook %d: %w", webhookID, err)
+	}
+
+	if len(executions) == 0 || executions[0].Result == enum.WebhookExecutionResultSuccess {
+		// the failed execution wasn't stored or the webhook recovered in the meantime.
+		return nil
+	}
+
+	if len(executions) > 1 && executions[1].Result != enum.WebhookExecutionResultSuccess {
+		log.Ctx(ctx).Debug().Msgf("webhook %d already failed before, skipping notification", webhookID)
+		return nil
+	}
+
+	creator, err := s.principalInfoCache.Get(ctx, webhook.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get creator of webhook %d from principalInfoCache: %w", webhookID, err)
+	}
+
+	recipients, err := s.filterRecipients(ctx, enum.NotificationLevelParticipating,
+		[]*types.PrincipalInfo{creator})
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendWebhookFailed(ctx, recipients, &WebhookFailedPayload{
+		Webhook:   webhook,
+		Execution: executions[0],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send notification for failed webhook %d: %w", webhookID, err)
+	}
+
+	return nil
+}
@@ -18,7 +18,11 @@ import (
 	"context"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/mention"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -44,6 +48,14 @@ func ProvideNotificationService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	settings *settings.Service,
+	mentions *mention.Service,
+	systemReaderFactory *events.ReaderFactory[*systemevents.Reader],
+	webhookStore store.WebhookStore,
+	webhookExecutionStore store.WebhookExecutionStore,
+	membershipStore store.MembershipStore,
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	vulnerabilityAlertStore store.VulnerabilityAlertStore,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -58,6 +70,14 @@ func ProvideNotificationService(
 		pullReqActivityStore,
 		spacePathStore,
 		urlProvider,
+		settings,
+		mentions,
+		systemReaderFactory,
+		webhookStore,
+		webhookExecutionStore,
+		membershipStore,
+		repoReaderFactory,
+		vulnerabilityAlertStore,
 	)
 }
 
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathindex
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	scoreMatch       = 16
+	scoreConsecutive = 8
+	scoreBoundary    = 12
+	scoreBaseName    = 4
+	penaltyGap       = 1
+)
+
+// match fuzzy matches the query against the path. All characters of the query have to be found in the path
+// in the same order (case-insensitive). Matches at the start of a path segment or word, consecutive matches
+// and matches in the file name score higher. It returns false if the path doesn't match the query.
+//
+// A forward scan finds the earliest position where the whole query is matched, a backward scan from there
+// then finds the shortest occurrence of the query that ends at that position.
+func match(query []rune, path []rune) (int, []int, bool) {
+	if len(query) == 0 {
+		return 0, nil, true
+	}
+
+	qi := 0
+	end := -1
+	for i := 0; i < len(path); i++ {
+		if unicode.ToLower(path[i]) != query[qi] {
+			continue
+		}
+		qi++
+		if qi == len(query) {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, len(query))
+	qi = len(query) - 1
+	for i := end; qi >= 0; i-- {
+		if unicode.ToLower(path[i]) == query[qi] {
+			positions[qi] = i
+			qi--
+		}
+	}
+
+	baseNameStart := 0
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' {
+			baseNameStart = i + 1
+			break
+		}
+	}
+
+	score := 0
+	for qi, idx := range positions {
+		score += scoreMatch
+		if isBoundary(path, idx) {
+			score += scoreBoundary
+		}
+		if idx >= baseNameStart {
+			score += scoreBaseName
+		}
+		if qi > 0 {
+			if gap := idx - positions[qi-1] - 1; gap == 0 {
+				score += scoreConsecutive
+			} else {
+				score -= gap * penaltyGap
+			}
+		}
+	}
+
+	// prefer shorter paths for equally good matches.
+	score -= len(path) - len(query)
+
+	return score, positions, true
+}
+
+// isBoundary returns whether the character at the provided index starts a path segment or a word.
+func isBoundary(path []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+
+	prev := path[i-1]
+	switch prev {
+	case '/', '_', '-', '.', ' ':
+		return true
+	}
+
+	return unicode.IsLower(prev) && unicode.IsUpper(path[i])
+}
+
+// search returns the best matches of the query from the list of paths, sorted by relevance.
+func search(paths []string, query string, limit int) []types.PathMatch {
+	queryRunes := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+
+	type scoredMatch struct {
+		types.PathMatch
+		score int
+	}
+
+	matches := make([]scoredMatch, 0, limit)
+	for _, p := range paths {
+		score, positions, ok := match(queryRunes, []rune(p))
+		if !ok {
+			continue
+		}
+
+		matches = append(matches, scoredMatch{
+			PathMatch: types.PathMatch{Path: p, Positions: positions},
+			score:     score,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].Path < matches[j].Path
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	result := make([]types.PathMatch, len(matches))
+	for i := range matches {
+		result[i] = matches[i].PathMatch
+	}
+
+	return result
+}
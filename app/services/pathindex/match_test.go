@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query     string
+		path      string
+		ok        bool
+		positions []int
+	}{
+		{query: "", path: "main.go", ok: true, positions: nil},
+		{query: "main", path: "main.go", ok: true, positions: []int{0, 1, 2, 3}},
+		{query: "mg", path: "main.go", ok: true, positions: []int{0, 5}},
+		{query: "rc", path: "app/repo/controller.go", ok: true, positions: []int{4, 9}},
+		{query: "ba", path: "xba/b", ok: true, positions: []int{1, 2}},
+		{query: "gm", path: "main.go", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query+"@"+test.path, func(t *testing.T) {
+			_, positions, ok := match([]rune(test.query), []rune(test.path))
+			if ok != test.ok {
+				t.Fatalf("expected match=%t, got %t", test.ok, ok)
+			}
+			if ok && !reflect.DeepEqual(positions, test.positions) {
+				t.Errorf("expected positions %v, got %v", test.positions, positions)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	paths := []string{
+		"README.md",
+		"app/api/controller/repo/controller.go",
+		"app/api/handler/repo/create.go",
+		"app/services/pathindex/service.go",
+		"cmd/gitness/main.go",
+	}
+
+	matches := search(paths, "repo ctrl", 2)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(matches))
+	}
+	if matches[0].Path != "app/api/controller/repo/controller.go" {
+		t.Errorf("unexpected best match %q", matches[0].Path)
+	}
+
+	matches = search(paths, "go", 2)
+	if len(matches) != 2 {
+		t.Fatalf("expected the number of matches to be limited to 2, got %d", len(matches))
+	}
+	if matches[0].Path != "cmd/gitness/main.go" {
+		t.Errorf("expected the shortest path with a boundary match first, got %q", matches[0].Path)
+	}
+}
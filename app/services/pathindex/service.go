@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathindex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:pathindex"
+
+	// invalidateKey is the pubsub topic used to notify all instances about changed repositories.
+	invalidateKey       = "invalidate_path_index"
+	invalidateNamespace = "pathindex"
+
+	// cacheDuration is the duration after which an index is rebuilt, even if no push was registered.
+	cacheDuration = 10 * time.Minute
+)
+
+// Service is keeping flat indexes of all file paths of trees of repositories in memory
+// and allows fuzzy searching for files in them.
+// The indexes are built on first use and are invalidated on every push to the repository.
+type Service struct {
+	git    git.Interface
+	pubsub pubsub.PubSub
+
+	mx      sync.Mutex
+	indexes map[int64]*repoIndexes
+}
+
+// repoIndexes holds the path indexes of a single repository, keyed by git ref.
+// The generation is incremented on every invalidation to discard indexes
+// that were being built while the repository changed.
+type repoIndexes struct {
+	generation int64
+	refs       map[string]*index
+}
+
+type index struct {
+	paths   []string
+	created time.Time
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	bus pubsub.PubSub,
+) (*Service, error) {
+	service := &Service{
+		git:     git,
+		pubsub:  bus,
+		indexes: make(map[int64]*repoIndexes),
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 10 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+			_ = r.RegisterTagDeleted(service.handleEventTagDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for path index: %w", err)
+	}
+
+	// the indexes are kept in memory of every instance, so every instance has to drop them.
+	_ = bus.Subscribe(ctx, invalidateKey, func(payload []byte) error {
+		repoID, err := strconv.ParseInt(string(payload), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid repository ID in path index invalidation: %w", err)
+		}
+
+		service.invalidate(repoID)
+
+		return nil
+	}, pubsub.WithChannelNamespace(invalidateNamespace))
+
+	go service.purger(ctx)
+
+	return service, nil
+}
+
+// Search returns the file paths of the tree at the git ref that best match the query.
+func (s *Service) Search(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	query string,
+	limit int,
+) ([]types.PathMatch, error) {
+	paths, err := s.paths(ctx, repo, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return search(paths, query, limit), nil
+}
+
+// paths returns the sorted list of file paths of the tree at the git ref, either from the cache or from git.
+func (s *Service) paths(ctx context.Context, repo *types.Repository, gitRef string) ([]string, error) {
+	now := time.Now()
+
+	s.mx.Lock()
+	indexes := s.indexes[repo.ID]
+	if indexes == nil {
+		indexes = &repoIndexes{refs: make(map[string]*index)}
+		s.indexes[repo.ID] = indexes
+	}
+	generation := indexes.generation
+	idx := indexes.refs[gitRef]
+	s.mx.Unlock()
+
+	if idx != nil && now.Sub(idx.created) < cacheDuration {
+		return idx.paths, nil
+	}
+
+	out, err := s.git.ListTreeFiles(ctx, &git.ListTreeFilesParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     gitRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree files: %w", err)
+	}
+
+	paths := make([]string, len(out.Files))
+	for i, f := range out.Files {
+		paths[i] = f.Path
+	}
+	sort.Strings(paths)
+
+	s.mx.Lock()
+	if current := s.indexes[repo.ID]; current != nil && current.generation == generation {
+		current.refs[gitRef] = &index{paths: paths, created: now}
+	}
+	s.mx.Unlock()
+
+	return paths, nil
+}
+
+// invalidate drops all path indexes of the repository.
+func (s *Service) invalidate(repoID int64) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	indexes := s.indexes[repoID]
+	if indexes == nil {
+		return
+	}
+
+	indexes.generation++
+	indexes.refs = make(map[string]*index)
+}
+
+// publishInvalidate notifies all instances that the path indexes of the repository are outdated.
+func (s *Service) publishInvalidate(ctx context.Context, repoID int64) error {
+	err := s.pubsub.Publish(ctx, invalidateKey, []byte(strconv.FormatInt(repoID, 10)),
+		pubsub.WithPublishNamespace(invalidateNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to publish path index invalidation: %w", err)
+	}
+
+	return nil
+}
+
+// purger periodically removes expired indexes to release the memory of repositories that aren't used anymore.
+func (s *Service) purger(ctx context.Context) {
+	ticker := time.NewTicker(cacheDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.purge(now)
+		}
+	}
+}
+
+func (s *Service) purge(now time.Time) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	var count int
+	for repoID, indexes := range s.indexes {
+		for gitRef, idx := range indexes.refs {
+			if now.Sub(idx.created) >= cacheDuration {
+				delete(indexes.refs, gitRef)
+				count++
+			}
+		}
+		if len(indexes.refs) == 0 {
+			delete(s.indexes, repoID)
+		}
+	}
+
+	if count > 0 {
+		log.Debug().Int("count", count).Msg("purged expired path indexes")
+	}
+}
@@ -25,9 +25,10 @@ const TypeBranch types.RuleType = "branch"
 
 // Branch implements protection rules for the rule type TypeBranch.
 type Branch struct {
-	Bypass    DefBypass    `json:"bypass"`
-	PullReq   DefPullReq   `json:"pullreq"`
-	Lifecycle DefLifecycle `json:"lifecycle"`
+	Bypass        DefBypass        `json:"bypass"`
+	PullReq       DefPullReq       `json:"pullreq"`
+	Lifecycle     DefLifecycle     `json:"lifecycle"`
+	CommitMessage DefCommitMessage `json:"commit_message"`
 }
 
 var (
@@ -94,6 +95,16 @@ func (v *Branch) RefChangeVerify(
 	}
 
 	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	commitMessageViolations, err := v.CommitMessage.RefChangeVerify(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	violations = append(violations, commitMessageViolations...)
 
 	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
 	bypassed := in.AllowBypass && bypassable
@@ -122,5 +133,9 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.CommitMessage.Sanitize(); err != nil {
+		return fmt.Errorf("commit message: %w", err)
+	}
+
 	return nil
 }
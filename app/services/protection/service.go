@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type (
@@ -50,6 +52,8 @@ type (
 	Manager struct {
 		defGenMap map[types.RuleType]DefinitionGenerator
 		ruleStore store.RuleStore
+		repoStore store.RepoStore
+		settings  *settings.Service
 	}
 )
 
@@ -70,10 +74,12 @@ func IsCritical(violations []types.RuleViolations) bool {
 }
 
 // NewManager creates new protection Manager.
-func NewManager(ruleStore store.RuleStore) *Manager {
+func NewManager(ruleStore store.RuleStore, repoStore store.RepoStore, settings *settings.Service) *Manager {
 	return &Manager{
 		defGenMap: make(map[types.RuleType]DefinitionGenerator),
 		ruleStore: ruleStore,
+		repoStore: repoStore,
+		settings:  settings,
 	}
 }
 
@@ -129,8 +135,52 @@ func (m *Manager) ForRepository(ctx context.Context, repoID int64) (Protection,
 		return nil, fmt.Errorf("failed to list rules for repository: %w", err)
 	}
 
+	ruleInfos, err = m.filterInherited(ctx, repoID, ruleInfos)
+	if err != nil {
+		return nil, err
+	}
+
 	return ruleSet{
 		rules:   ruleInfos,
 		manager: m,
 	}, nil
 }
+
+// filterInherited removes the rules of ancestor spaces that aren't inherited by the space of the repository.
+func (m *Manager) filterInherited(
+	ctx context.Context,
+	repoID int64,
+	ruleInfos []types.RuleInfoInternal,
+) ([]types.RuleInfoInternal, error) {
+	if len(ruleInfos) == 0 {
+		return ruleInfos, nil
+	}
+
+	repo, err := m.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	chain, err := m.settings.InheritanceChain(ctx, repo.ParentID, enum.InheritedSettingRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule inheritance of repository: %w", err)
+	}
+
+	spacePaths := make(map[string]struct{}, len(chain))
+	for _, space := range chain {
+		spacePaths[space.Path] = struct{}{}
+	}
+
+	filtered := ruleInfos[:0]
+	for _, ruleInfo := range ruleInfos {
+		if ruleInfo.SpacePath != "" {
+			if _, ok := spacePaths[ruleInfo.SpacePath]; !ok {
+				continue
+			}
+		}
+
+		filtered = append(filtered, ruleInfo)
+	}
+
+	return filtered, nil
+}
@@ -105,7 +105,7 @@ func TestManager_SanitizeJSON(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m := NewManager(nil)
+			m := NewManager(nil, nil, nil)
 
 			err := func() error {
 				for _, ruleType := range test.ruleTypes {
@@ -320,7 +320,7 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
@@ -433,7 +433,7 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+)
+
+// DefCommitMessage defines the rules the messages of all commits pushed to a branch have to comply with.
+type DefCommitMessage struct {
+	// Pattern is a regular expression all commit messages have to match.
+	Pattern string `json:"pattern,omitempty"`
+
+	// RequireIssueReference requires all commit messages to reference an issue.
+	RequireIssueReference bool `json:"require_issue_reference,omitempty"`
+
+	// IssueReferencePattern is the regular expression used to find issue references.
+	// If not provided, references like "#123" and "ABC-123" are accepted.
+	IssueReferencePattern string `json:"issue_reference_pattern,omitempty"`
+
+	// MaxSubjectLength is the maximum number of characters of the first line of the commit message.
+	MaxSubjectLength int `json:"max_subject_length,omitempty"`
+
+	// RequireSignOff requires a "Signed-off-by" trailer of the commit author (Developer Certificate of Origin).
+	RequireSignOff bool `json:"require_sign_off,omitempty"`
+}
+
+// ensures that the DefCommitMessage type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefCommitMessage)(nil)
+	_ RefChangeVerifier = (*DefCommitMessage)(nil)
+)
+
+const (
+	codeCommitMessagePattern        = "commit_message.pattern"
+	codeCommitMessageIssueReference = "commit_message.require_issue_reference"
+	codeCommitMessageSubjectLength  = "commit_message.max_subject_length"
+	codeCommitMessageSignOff        = "commit_message.require_sign_off"
+)
+
+// defaultIssueReferencePattern matches GitHub style (#123) and Jira style (ABC-123) issue references.
+const defaultIssueReferencePattern = `(^|[^\w])(#\d+|[A-Z][A-Z0-9_]+-\d+)\b`
+
+// maxReportedCommits is the maximum number of commits with violations that are reported per branch.
+const maxReportedCommits = 10
+
+var signOffRegexp = regexp.MustCompile(`(?m)^Signed-off-by:\s*(.*)<([^>]+)>\s*$`)
+
+func (v *DefCommitMessage) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) ([]types.RuleViolations, error) {
+	if !v.isEnabled() || in.NewCommits == nil || in.RefAction == RefActionDelete {
+		return nil, nil
+	}
+
+	patternRegexp, issueRegexp, err := v.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		commits, err := in.NewCommits(ctx, refName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get new commits of branch %q: %w", refName, err)
+		}
+
+		reported := 0
+		for i := range commits {
+			if reported == maxReportedCommits {
+				violations.Addf(codeCommitMessagePattern,
+					"Branch %q: more commits with invalid commit messages were omitted.", refName)
+				break
+			}
+
+			if v.verifyCommit(&violations, &commits[i], patternRegexp, issueRegexp) {
+				reported++
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// verifyCommit verifies the message of the commit and returns true if any violation was found.
+func (v *DefCommitMessage) verifyCommit(
+	violations *types.RuleViolations,
+	commit *types.Commit,
+	patternRegexp *regexp.Regexp,
+	issueRegexp *regexp.Regexp,
+) bool {
+	count := len(violations.Violations)
+	sha := commit.SHA
+	if len(sha) > 8 {
+		sha = sha[:8]
+	}
+
+	message := strings.TrimSpace(commit.Message)
+	subject, _, _ := strings.Cut(message, "\n")
+
+	if patternRegexp != nil && !patternRegexp.MatchString(message) {
+		violations.Addf(codeCommitMessagePattern,
+			"Commit %s: message doesn't match the required pattern %q.", sha, v.Pattern)
+	}
+
+	if issueRegexp != nil && !issueRegexp.MatchString(message) {
+		violations.Addf(codeCommitMessageIssueReference,
+			"Commit %s: message doesn't reference an issue.", sha)
+	}
+
+	if l := utf8.RuneCountInString(subject); v.MaxSubjectLength > 0 && l > v.MaxSubjectLength {
+		violations.Addf(codeCommitMessageSubjectLength,
+			"Commit %s: subject is %d characters long, the maximum allowed length is %d.",
+			sha, l, v.MaxSubjectLength)
+	}
+
+	if v.RequireSignOff && !hasSignOff(message, commit.Author.Identity.Email) {
+		violations.Addf(codeCommitMessageSignOff,
+			"Commit %s: message is missing a \"Signed-off-by: %s <%s>\" line.",
+			sha, commit.Author.Identity.Name, commit.Author.Identity.Email)
+	}
+
+	return len(violations.Violations) > count
+}
+
+func hasSignOff(message string, email string) bool {
+	for _, match := range signOffRegexp.FindAllStringSubmatch(message, -1) {
+		if strings.EqualFold(strings.TrimSpace(match[2]), email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *DefCommitMessage) isEnabled() bool {
+	return v.Pattern != "" || v.RequireIssueReference || v.MaxSubjectLength > 0 || v.RequireSignOff
+}
+
+func (v *DefCommitMessage) compile() (patternRegexp *regexp.Regexp, issueRegexp *regexp.Regexp, err error) {
+	if v.Pattern != "" {
+		patternRegexp, err = regexp.Compile(v.Pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	if v.RequireIssueReference {
+		pattern := v.IssueReferencePattern
+		if pattern == "" {
+			pattern = defaultIssueReferencePattern
+		}
+
+		issueRegexp, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid issue reference pattern: %w", err)
+		}
+	}
+
+	return patternRegexp, issueRegexp, nil
+}
+
+func (v *DefCommitMessage) Sanitize() error {
+	if v.MaxSubjectLength < 0 {
+		return errors.New("max subject length must be zero or a positive integer")
+	}
+
+	if v.IssueReferencePattern != "" && !v.RequireIssueReference {
+		return errors.New("issue reference pattern can only be used with require issue reference")
+	}
+
+	if _, _, err := v.compile(); err != nil {
+		return err
+	}
+
+	return nil
+}
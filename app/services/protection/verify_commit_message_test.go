@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDefCommitMessage_RefChangeVerify(t *testing.T) {
+	const refName = "a"
+	const sha = "0123456789abcdef"
+
+	author := types.Signature{Identity: types.Identity{Name: "John", Email: "john@example.com"}}
+
+	tests := []struct {
+		name      string
+		def       DefCommitMessage
+		message   string
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:    "empty",
+			message: "anything",
+		},
+		{
+			name:    "pattern-pass",
+			def:     DefCommitMessage{Pattern: `^(feat|fix): `},
+			message: "fix: something",
+		},
+		{
+			name:      "pattern-fail",
+			def:       DefCommitMessage{Pattern: `^(feat|fix): `},
+			message:   "something",
+			expCodes:  []string{"commit_message.pattern"},
+			expParams: [][]any{{"01234567", `^(feat|fix): `}},
+		},
+		{
+			name:    "issue-reference-pass",
+			def:     DefCommitMessage{RequireIssueReference: true},
+			message: "Fix something\n\nFixes ABC-123",
+		},
+		{
+			name:      "issue-reference-fail",
+			def:       DefCommitMessage{RequireIssueReference: true},
+			message:   "Fix something",
+			expCodes:  []string{"commit_message.require_issue_reference"},
+			expParams: [][]any{{"01234567"}},
+		},
+		{
+			name:      "subject-length-fail",
+			def:       DefCommitMessage{MaxSubjectLength: 5},
+			message:   "Fix something\n\nbody",
+			expCodes:  []string{"commit_message.max_subject_length"},
+			expParams: [][]any{{"01234567", 13, 5}},
+		},
+		{
+			name:    "sign-off-pass",
+			def:     DefCommitMessage{RequireSignOff: true},
+			message: "Fix something\n\nSigned-off-by: John <John@Example.com>",
+		},
+		{
+			name:      "sign-off-fail",
+			def:       DefCommitMessage{RequireSignOff: true},
+			message:   "Fix something\n\nSigned-off-by: Jane <jane@example.com>",
+			expCodes:  []string{"commit_message.require_sign_off"},
+			expParams: [][]any{{"01234567", "John", "john@example.com"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				RefNames:  []string{refName},
+				RefAction: RefActionUpdate,
+				RefType:   RefTypeBranch,
+				NewCommits: func(context.Context, string) ([]types.Commit, error) {
+					return []types.Commit{{SHA: sha, Message: test.message, Author: author}}, nil
+				},
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
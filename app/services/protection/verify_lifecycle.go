@@ -33,6 +33,9 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+
+		// NewCommits returns the commits a push adds to the branch (only set for git pushes).
+		NewCommits func(ctx context.Context, refName string) ([]types.Commit, error)
 	}
 
 	RefType int
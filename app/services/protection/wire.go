@@ -15,6 +15,7 @@
 package protection
 
 import (
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -24,8 +25,12 @@ var WireSet = wire.NewSet(
 	ProvideManager,
 )
 
-func ProvideManager(ruleStore store.RuleStore) (*Manager, error) {
-	m := NewManager(ruleStore)
+func ProvideManager(
+	ruleStore store.RuleStore,
+	repoStore store.RepoStore,
+	settings *settings.Service,
+) (*Manager, error) {
+	m := NewManager(ruleStore, repoStore, settings)
 
 	if err := m.Register(TypeBranch, func() Definition { return &Branch{} }); err != nil {
 		return nil, err
@@ -115,10 +115,17 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			return err
 		}
 
-		payload := &types.PullRequestActivityPayloadBranchUpdate{
+		// force pushes get their own activity, as the reviewed history of the branch got rewritten.
+		var payload types.PullReqActivityPayload = &types.PullRequestActivityPayloadBranchUpdate{
 			Old: event.Payload.OldSHA,
 			New: event.Payload.NewSHA,
 		}
+		if event.Payload.Forced {
+			payload = &types.PullRequestActivityPayloadBranchForcePush{
+				Old: event.Payload.OldSHA,
+				New: event.Payload.NewSHA,
+			}
+		}
 
 		_, err = s.activityStore.CreateWithPayload(ctx, pr, event.Payload.PrincipalID, payload)
 		if err != nil {
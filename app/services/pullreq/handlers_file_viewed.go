@@ -24,6 +24,8 @@ import (
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // handleFileViewedOnBranchUpdate handles pull request Branch Updated events.
@@ -38,12 +40,51 @@ func (s *Service) handleFileViewedOnBranchUpdate(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("failed to get repo git info: %w", err)
 	}
+
+	obsoletePaths, err := s.changedFilePaths(ctx, repoGit.GitUID, event.Payload.OldSHA, event.Payload.NewSHA)
+	if err != nil && event.Payload.Forced {
+		// after a force push the old commit might not be available anymore.
+		// As the history got rewritten, consider all files reviewed so far as obsolete.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get changed files of force push, marking all file views obsolete")
+
+		if err = s.fileViewStore.MarkAllObsolete(ctx, event.Payload.PullReqID); err != nil {
+			return fmt.Errorf("failed to mark all files obsolete for repo %d and pr %d: %w",
+				repoGit.ID, event.Payload.PullReqID, err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(obsoletePaths) == 0 {
+		return nil
+	}
+
+	err = s.fileViewStore.MarkObsolete(
+		ctx,
+		event.Payload.PullReqID,
+		obsoletePaths)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to mark files obsolete for repo %d and pr %d: %w",
+			repoGit.ID,
+			event.Payload.PullReqID,
+			err)
+	}
+
+	return nil
+}
+
+// changedFilePaths returns the paths of all files that were changed between the two commits.
+func (s *Service) changedFilePaths(ctx context.Context, gitUID string, oldSHA, newSHA string) ([]string, error) {
 	reader := git.NewStreamReader(s.git.Diff(ctx, &git.DiffParams{
 		ReadParams: git.ReadParams{
-			RepoUID: repoGit.GitUID,
+			RepoUID: gitUID,
 		},
-		BaseRef:      event.Payload.OldSHA,
-		HeadRef:      event.Payload.NewSHA,
+		BaseRef:      oldSHA,
+		HeadRef:      newSHA,
 		MergeBase:    false, // we want the direct changes
 		IncludePatch: false, // we don't care about the actual file changes
 	}))
@@ -55,7 +96,7 @@ func (s *Service) handleFileViewedOnBranchUpdate(ctx context.Context,
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("failed to read next file diff: %w", err)
+			return nil, fmt.Errorf("failed to read next file diff: %w", err)
 		}
 
 		// DELETED: mark as obsolete - handles open pr file deletions
@@ -78,21 +119,5 @@ func (s *Service) handleFileViewedOnBranchUpdate(ctx context.Context,
 		}
 	}
 
-	if len(obsoletePaths) == 0 {
-		return nil
-	}
-
-	err = s.fileViewStore.MarkObsolete(
-		ctx,
-		event.Payload.PullReqID,
-		obsoletePaths)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to mark files obsolete for repo %d and pr %d: %w",
-			repoGit.ID,
-			event.Payload.PullReqID,
-			err)
-	}
-
-	return nil
+	return obsoletePaths, nil
 }
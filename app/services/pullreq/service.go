@@ -257,13 +257,11 @@ func createSystemRPCWriteParams(
 	principal := bootstrap.NewSystemServiceSession().Principal
 
 	// generate envars (add everything githook CLI needs for execution)
-	envVars, err := githook.GenerateEnvironmentVariables(
+	envVars, err := githook.GenerateSystemEnvironmentVariables(
 		ctx,
 		urlProvider.GetInternalAPIURL(),
 		repoID,
 		principal.ID,
-		false,
-		true,
 	)
 	if err != nil {
 		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqlint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+)
+
+// headingRegex matches markdown ATX headings, the heading text is the first submatch.
+var headingRegex = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+
+// Lint returns the problems of the pull request title and description according to the policy.
+// The title pattern is expected to be valid, an invalid pattern is reported as a problem.
+func Lint(policy *types.PullReqLintPolicy, title, description string) []string {
+	var problems []string
+
+	if policy.TitlePattern != "" {
+		titleRegex, err := regexp.Compile(policy.TitlePattern)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("The title pattern %q is invalid.", policy.TitlePattern))
+		} else if !titleRegex.MatchString(title) {
+			problems = append(problems, fmt.Sprintf("The title doesn't match the pattern %q.", policy.TitlePattern))
+		}
+	}
+
+	if length := utf8.RuneCountInString(strings.TrimSpace(description)); length < policy.MinDescriptionLength {
+		problems = append(problems, fmt.Sprintf("The description has %d characters but needs at least %d.",
+			length, policy.MinDescriptionLength))
+	}
+
+	if len(policy.RequiredSections) > 0 {
+		sections := parseSections(description)
+		for _, required := range policy.RequiredSections {
+			content, ok := sections[strings.ToLower(strings.TrimSpace(required))]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("The description is missing the section %q.", required))
+			} else if content == "" {
+				problems = append(problems, fmt.Sprintf("The section %q of the description is empty.", required))
+			}
+		}
+	}
+
+	return problems
+}
+
+// parseSections returns the content of the markdown sections of the text, mapped by their lower case heading.
+// The content of a section ends with the next heading of any level.
+func parseSections(text string) map[string]string {
+	sections := map[string]string{}
+
+	var (
+		heading string
+		content strings.Builder
+		inFence bool
+	)
+
+	flush := func() {
+		if heading == "" {
+			return
+		}
+		if _, ok := sections[heading]; !ok || sections[heading] == "" {
+			sections[heading] = strings.TrimSpace(content.String())
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+
+		if m := headingRegex.FindStringSubmatch(line); m != nil && !inFence {
+			flush()
+			heading = strings.ToLower(m[1])
+			content.Reset()
+			continue
+		}
+
+		// html comments, as used by description templates for instructions, don't count as content.
+		if strings.HasPrefix(strings.TrimSpace(line), "<!--") && strings.HasSuffix(strings.TrimSpace(line), "-->") {
+			continue
+		}
+
+		content.WriteString(line)
+		content.WriteByte('\n')
+	}
+	flush()
+
+	return sections
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqlint
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestLint(t *testing.T) {
+	const conventional = `^(feat|fix|chore)(\([a-z-]+\))?: .+`
+
+	const description = "## Summary\n" +
+		"Adds the thing.\n" +
+		"\n" +
+		"## Testing\n" +
+		"<!-- describe how the change was tested -->\n" +
+		"\n" +
+		"```\n" +
+		"# not a heading\n" +
+		"```\n"
+
+	tests := []struct {
+		name        string
+		policy      types.PullReqLintPolicy
+		title       string
+		description string
+		want        int
+	}{
+		{
+			name:   "empty policy",
+			policy: types.PullReqLintPolicy{},
+			title:  "anything",
+			want:   0,
+		},
+		{
+			name:   "title matches",
+			policy: types.PullReqLintPolicy{TitlePattern: conventional},
+			title:  "feat(api): add endpoint",
+			want:   0,
+		},
+		{
+			name:   "title doesn't match",
+			policy: types.PullReqLintPolicy{TitlePattern: conventional},
+			title:  "Add endpoint",
+			want:   1,
+		},
+		{
+			name:        "description too short",
+			policy:      types.PullReqLintPolicy{MinDescriptionLength: 20},
+			description: "  too short  ",
+			want:        1,
+		},
+		{
+			name:        "required sections present",
+			policy:      types.PullReqLintPolicy{RequiredSections: []string{"summary", "Testing"}},
+			description: description,
+			want:        0,
+		},
+		{
+			name:        "required section missing",
+			policy:      types.PullReqLintPolicy{RequiredSections: []string{"Summary", "Risks"}},
+			description: description,
+			want:        1,
+		},
+		{
+			name:        "required section only has template comment",
+			policy:      types.PullReqLintPolicy{RequiredSections: []string{"Summary"}},
+			description: "## Summary\n<!-- what does the change do -->\n## Testing\nManually.\n",
+			want:        1,
+		},
+		{
+			name: "all problems",
+			policy: types.PullReqLintPolicy{
+				TitlePattern:         conventional,
+				MinDescriptionLength: 1000,
+				RequiredSections:     []string{"Risks"},
+			},
+			title:       "Add endpoint",
+			description: description,
+			want:        3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := Lint(&test.policy, test.title, test.description)
+			if len(problems) != test.want {
+				t.Errorf("expected %d problems, got %v", test.want, problems)
+			}
+		})
+	}
+}
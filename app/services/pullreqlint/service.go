@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqlint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	eventsReaderGroupName = "gitness:pullreqlint"
+
+	// CheckIdentifier is the identifier of the status check that fails
+	// if the title or description of a pull request violates the lint policy of the repository.
+	CheckIdentifier = "gitness-pullreq-lint"
+)
+
+// Service lints the title and description of pull requests according to the lint policy of the repository,
+// the result is reported as status check of the pull request source commit.
+type Service struct {
+	settings     *settings.Service
+	pullReqStore store.PullReqStore
+	checkStore   store.CheckStore
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	settings *settings.Service,
+	pullReqStore store.PullReqStore,
+	checkStore store.CheckStore,
+) (*Service, error) {
+	service := &Service{
+		settings:     settings,
+		pullReqStore: pullReqStore,
+		checkStore:   checkStore,
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReopened(service.handleEventReopened)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for pull request lint: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) handleEventReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.reportPullReq(ctx, event.Payload.PullReqID, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	return s.reportPullReq(ctx, event.Payload.PullReqID, event.Payload.NewSHA)
+}
+
+func (s *Service) reportPullReq(ctx context.Context, pullReqID int64, sourceSHA string) error {
+	pr, err := s.pullReqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen || pr.SourceSHA != sourceSHA {
+		// a newer event takes care of the current source commit.
+		return nil
+	}
+
+	return s.Report(ctx, pr)
+}
+
+// Lint returns the problems of the title and description of the pull request.
+// Nil is returned in case the target repository doesn't have a lint policy.
+func (s *Service) Lint(ctx context.Context, pr *types.PullReq) ([]string, error) {
+	policy, err := s.settings.PullReqLintPolicy(ctx, pr.TargetRepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return nil, nil
+	}
+
+	return Lint(policy, pr.Title, pr.Description), nil
+}
+
+// Report lints the pull request and reports the result as status check of the source commit.
+// Nothing is reported in case the target repository doesn't have a lint policy.
+func (s *Service) Report(ctx context.Context, pr *types.PullReq) error {
+	policy, err := s.settings.PullReqLintPolicy(ctx, pr.TargetRepoID)
+	if err != nil {
+		return err
+	}
+
+	if policy == nil {
+		return nil
+	}
+
+	problems := Lint(policy, pr.Title, pr.Description)
+
+	status := enum.CheckStatusSuccess
+	summary := "The title and description meet the pull request policy"
+	details := &strings.Builder{}
+	if len(problems) > 0 {
+		status = enum.CheckStatusFailure
+		summary = fmt.Sprintf("Found %d pull request policy violations", len(problems))
+		for _, problem := range problems {
+			fmt.Fprintf(details, "- %s\n", problem)
+		}
+	}
+
+	data, err := json.Marshal(types.CheckPayloadText{Details: details.String()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal check payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.checkStore.Upsert(ctx, &types.Check{
+		CreatedBy:  bootstrap.NewSystemServiceSession().Principal.ID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     pr.TargetRepoID,
+		CommitSHA:  pr.SourceSHA,
+		Identifier: CheckIdentifier,
+		Status:     status,
+		Summary:    summary,
+		Metadata:   []byte("{}"),
+		Started:    now,
+		Ended:      now,
+		Payload: types.CheckPayload{
+			Kind: enum.CheckPayloadKindMarkdown,
+			Data: data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to report status check %s: %w", CheckIdentifier, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:realtime"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service is responsible for forwarding the repository and pull request events
+// to the server sent event streams of the repositories and pull requests.
+// Every forwarded event is stored for a limited time, so that clients can resume the stream after reconnecting.
+type Service struct {
+	repoEventStore store.RepoEventStore
+	sseStreamer    sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoEventStore store.RepoEventStore,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided realtime service config is invalid: %w", err)
+	}
+	s := &Service{
+		repoEventStore: repoEventStore,
+		sseStreamer:    sseStreamer,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterBranchCreated(forwardGit(s, enum.SSETypeBranchCreated,
+				func(p *gitevents.BranchCreatedPayload) int64 { return p.RepoID }))
+			_ = r.RegisterBranchUpdated(forwardGit(s, enum.SSETypeBranchUpdated,
+				func(p *gitevents.BranchUpdatedPayload) int64 { return p.RepoID }))
+			_ = r.RegisterBranchDeleted(forwardGit(s, enum.SSETypeBranchDeleted,
+				func(p *gitevents.BranchDeletedPayload) int64 { return p.RepoID }))
+			_ = r.RegisterTagCreated(forwardGit(s, enum.SSETypeTagCreated,
+				func(p *gitevents.TagCreatedPayload) int64 { return p.RepoID }))
+			_ = r.RegisterTagUpdated(forwardGit(s, enum.SSETypeTagUpdated,
+				func(p *gitevents.TagUpdatedPayload) int64 { return p.RepoID }))
+			_ = r.RegisterTagDeleted(forwardGit(s, enum.SSETypeTagDeleted,
+				func(p *gitevents.TagDeletedPayload) int64 { return p.RepoID }))
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for realtime updates: %w", err)
+	}
+
+	_, err = pullreqReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(forwardPullReq(s, enum.SSETypePullRequestCreated,
+				func(p *pullreqevents.CreatedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterClosed(forwardPullReq(s, enum.SSETypePullRequestClosed,
+				func(p *pullreqevents.ClosedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterReopened(forwardPullReq(s, enum.SSETypePullRequestReopened,
+				func(p *pullreqevents.ReopenedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterMerged(forwardPullReq(s, enum.SSETypePullRequestMerged,
+				func(p *pullreqevents.MergedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterBranchUpdated(forwardPullReq(s, enum.SSETypePullRequestBranchUpdated,
+				func(p *pullreqevents.BranchUpdatedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterCommentCreated(forwardPullReq(s, enum.SSETypePullRequestCommentCreated,
+				func(p *pullreqevents.CommentCreatedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterReviewSubmitted(forwardPullReq(s, enum.SSETypePullRequestReviewSubmitted,
+				func(p *pullreqevents.ReviewSubmittedPayload) pullreqevents.Base { return p.Base }))
+			_ = r.RegisterReviewerAdded(forwardPullReq(s, enum.SSETypePullRequestReviewerAdded,
+				func(p *pullreqevents.ReviewerAddedPayload) pullreqevents.Base { return p.Base }))
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pullreq event reader for realtime updates: %w", err)
+	}
+
+	return s, nil
+}
+
+// forwardGit returns an event handler that forwards a git event to the event stream of the repository.
+func forwardGit[T any](
+	s *Service,
+	sseType enum.SSEType,
+	getRepoID func(T) int64,
+) events.HandlerFunc[T] {
+	return func(ctx context.Context, event *events.Event[T]) error {
+		return s.forward(ctx, sseType, getRepoID(event.Payload), nil, event.Payload)
+	}
+}
+
+// forwardPullReq returns an event handler that forwards a pull request event
+// to the event streams of the pull request and of its target repository.
+func forwardPullReq[T any](
+	s *Service,
+	sseType enum.SSEType,
+	getBase func(T) pullreqevents.Base,
+) events.HandlerFunc[T] {
+	return func(ctx context.Context, event *events.Event[T]) error {
+		base := getBase(event.Payload)
+		return s.forward(ctx, sseType, base.TargetRepoID, &base.PullReqID, event.Payload)
+	}
+}
+
+func (s *Service) forward(
+	ctx context.Context,
+	sseType enum.SSEType,
+	repoID int64,
+	pullreqID *int64,
+	payload any,
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event payload: %w", err)
+	}
+
+	repoEvent := &types.RepoEvent{
+		RepoID:    repoID,
+		PullReqID: pullreqID,
+		Type:      sseType,
+		Data:      data,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	if err = s.repoEventStore.Create(ctx, repoEvent); err != nil {
+		return fmt.Errorf("failed to store repo event: %w", err)
+	}
+
+	event := ToSSEEvent(repoEvent)
+
+	if err = s.sseStreamer.PublishRepo(ctx, repoID, event); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish %s event to repo %d", sseType, repoID)
+	}
+
+	if pullreqID != nil {
+		if err = s.sseStreamer.PublishPullReq(ctx, *pullreqID, event); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish %s event to pull request %d", sseType, *pullreqID)
+		}
+	}
+
+	return nil
+}
+
+// ToSSEEvent converts a stored repository event into a server sent event.
+func ToSSEEvent(e *types.RepoEvent) *sse.Event {
+	return &sse.Event{
+		ID:   strconv.FormatInt(e.ID, 10),
+		Type: e.Type,
+		Data: e.Data,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package realtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/sse"
+)
+
+// maxReplayEvents is the maximum number of missed events sent to a client resuming a stream.
+const maxReplayEvents = 1000
+
+// StreamRepo streams the events of a repository.
+// If lastEventID is provided, the stored events following it are sent first.
+func (s *Service) StreamRepo(
+	ctx context.Context,
+	repoID int64,
+	lastEventID int64,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	chEvents, chErr, sseCancel := s.sseStreamer.StreamRepo(ctx, repoID)
+	return s.resume(ctx, repoID, 0, lastEventID, chEvents, chErr, sseCancel)
+}
+
+// StreamPullReq streams the events of a pull request.
+// If lastEventID is provided, the stored events following it are sent first.
+func (s *Service) StreamPullReq(
+	ctx context.Context,
+	repoID int64,
+	pullreqID int64,
+	lastEventID int64,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	chEvents, chErr, sseCancel := s.sseStreamer.StreamPullReq(ctx, pullreqID)
+	return s.resume(ctx, repoID, pullreqID, lastEventID, chEvents, chErr, sseCancel)
+}
+
+// resume prepends the missed events to the live events.
+// The live stream is subscribed before the missed events are loaded, so no event can get lost in between.
+func (s *Service) resume(
+	ctx context.Context,
+	repoID int64,
+	pullreqID int64,
+	lastEventID int64,
+	chEvents <-chan *sse.Event,
+	chErr <-chan error,
+	sseCancel func(context.Context) error,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	if lastEventID <= 0 {
+		return chEvents, chErr, sseCancel, nil
+	}
+
+	missed, err := s.repoEventStore.ListAfter(ctx, repoID, pullreqID, lastEventID, maxReplayEvents)
+	if err != nil {
+		_ = sseCancel(ctx)
+		return nil, nil, nil, fmt.Errorf("failed to list missed events: %w", err)
+	}
+
+	events := make([]*sse.Event, len(missed))
+	for i, e := range missed {
+		events[i] = ToSSEEvent(e)
+	}
+
+	return sse.Replay(ctx, events, chEvents), chErr, sseCancel, nil
+}
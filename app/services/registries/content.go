@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registries
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+)
+
+const digestPrefix = "sha256:"
+
+var digestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ValidDigest returns true if the digest is a valid sha256 content digest.
+func ValidDigest(digest string) bool {
+	return digestRegex.MatchString(digest)
+}
+
+// NewDigester returns the hash used to compute content digests.
+func NewDigester() hash.Hash {
+	return sha256.New()
+}
+
+// Digest returns the content digest of the data written to the hash.
+func Digest(h hash.Hash) string {
+	return digestPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// BlobPath returns the path of a registry blob in the blob store.
+func BlobPath(registryID int64, digest string) string {
+	return fmt.Sprintf("registries/%d/blobs/%s", registryID, strings.Replace(digest, ":", "/", 1))
+}
+
+// UploadPartPath returns the path of a chunk of an in-progress upload in the blob store.
+func UploadPartPath(registryID int64, uploadIdentifier string, part int64) string {
+	return fmt.Sprintf("registries/%d/uploads/%s/%d", registryID, uploadIdentifier, part)
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registries
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Media types of the supported image manifests and indexes.
+const (
+	MediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+var ErrManifestInvalid = errors.New("manifest invalid")
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        *descriptor  `json:"config"`
+	Layers        []descriptor `json:"layers"`
+	Manifests     []descriptor `json:"manifests"`
+}
+
+// Manifest is a parsed image manifest or image index.
+type Manifest struct {
+	MediaType string
+	// Blobs are the digests of the config and the layers of an image manifest.
+	Blobs []string
+	// Manifests are the digests of the manifests referenced by an image index.
+	Manifests []string
+}
+
+// References returns all digests referenced by the manifest.
+func (m *Manifest) References() []string {
+	refs := make([]string, 0, len(m.Blobs)+len(m.Manifests))
+	refs = append(refs, m.Blobs...)
+	return append(refs, m.Manifests...)
+}
+
+// ParseManifest parses an image manifest or index.
+// The media type is taken from the manifest itself if the client didn't provide it.
+func ParseManifest(mediaType string, content []byte) (*Manifest, error) {
+	var raw manifest
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrManifestInvalid, err.Error())
+	}
+
+	if raw.SchemaVersion != 2 {
+		return nil, fmt.Errorf("%w: unsupported schema version %d", ErrManifestInvalid, raw.SchemaVersion)
+	}
+
+	if mediaType == "" {
+		mediaType = raw.MediaType
+	}
+	if mediaType == "" {
+		// OCI manifests don't require the media type field, tell them apart by their content.
+		mediaType = MediaTypeOCIManifest
+		if raw.Manifests != nil {
+			mediaType = MediaTypeOCIIndex
+		}
+	}
+
+	if raw.MediaType != "" && raw.MediaType != mediaType {
+		return nil, fmt.Errorf("%w: media type %q doesn't match content type %q",
+			ErrManifestInvalid, raw.MediaType, mediaType)
+	}
+
+	m := &Manifest{MediaType: mediaType}
+
+	switch mediaType {
+	case MediaTypeOCIManifest, MediaTypeDockerManifest:
+		if raw.Config == nil {
+			return nil, fmt.Errorf("%w: config is missing", ErrManifestInvalid)
+		}
+		m.Blobs = append(m.Blobs, raw.Config.Digest)
+		for _, layer := range raw.Layers {
+			m.Blobs = append(m.Blobs, layer.Digest)
+		}
+	case MediaTypeOCIIndex, MediaTypeDockerManifestList:
+		for _, child := range raw.Manifests {
+			m.Manifests = append(m.Manifests, child.Digest)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported media type %q", ErrManifestInvalid, mediaType)
+	}
+
+	for _, digest := range m.References() {
+		if !ValidDigest(digest) {
+			return nil, fmt.Errorf("%w: invalid digest %q", ErrManifestInvalid, digest)
+		}
+	}
+
+	return m, nil
+}
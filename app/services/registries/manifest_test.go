@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registries
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	config := "sha256:" + strings.Repeat("a", 64)
+	layer := "sha256:" + strings.Repeat("b", 64)
+
+	tests := []struct {
+		name      string
+		mediaType string
+		content   string
+		wantType  string
+		wantRefs  []string
+		wantErr   bool
+	}{
+		{
+			name:      "oci manifest",
+			mediaType: MediaTypeOCIManifest,
+			content: `{"schemaVersion":2,"config":{"digest":"` + config + `"},` +
+				`"layers":[{"digest":"` + layer + `"}]}`,
+			wantType: MediaTypeOCIManifest,
+			wantRefs: []string{config, layer},
+		},
+		{
+			name:     "docker manifest without content type",
+			content:  `{"schemaVersion":2,"mediaType":"` + MediaTypeDockerManifest + `","config":{"digest":"` + config + `"}}`,
+			wantType: MediaTypeDockerManifest,
+			wantRefs: []string{config},
+		},
+		{
+			name:     "oci index detected by content",
+			content:  `{"schemaVersion":2,"manifests":[{"digest":"` + layer + `"}]}`,
+			wantType: MediaTypeOCIIndex,
+			wantRefs: []string{layer},
+		},
+		{
+			name:      "mismatching media type",
+			mediaType: MediaTypeOCIManifest,
+			content:   `{"schemaVersion":2,"mediaType":"` + MediaTypeDockerManifest + `","config":{"digest":"` + config + `"}}`,
+			wantErr:   true,
+		},
+		{
+			name:    "schema version 1",
+			content: `{"schemaVersion":1}`,
+			wantErr: true,
+		},
+		{
+			name:      "invalid layer digest",
+			mediaType: MediaTypeOCIManifest,
+			content:   `{"schemaVersion":2,"config":{"digest":"` + config + `"},"layers":[{"digest":"md5:abc"}]}`,
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := ParseManifest(test.mediaType, []byte(test.content))
+			if test.wantErr {
+				if !errors.Is(err, ErrManifestInvalid) {
+					t.Fatalf("expected invalid manifest error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if m.MediaType != test.wantType {
+				t.Errorf("expected media type %q, got %q", test.wantType, m.MediaType)
+			}
+
+			refs := m.References()
+			if strings.Join(refs, ",") != strings.Join(test.wantRefs, ",") {
+				t.Errorf("expected references %v, got %v", test.wantRefs, refs)
+			}
+		})
+	}
+}
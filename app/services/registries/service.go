@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCleanup        = "gitness:registries:cleanup"
+	jobCronCleanup        = "17 * * * *" // Every hour.
+	jobMaxDurationCleanup = 30 * time.Minute
+)
+
+// Service applies the retention policies of the registries
+// and removes blobs and uploads that are no longer needed.
+type Service struct {
+	gcGracePeriod time.Duration
+	uploadExpiry  time.Duration
+	scheduler     *job.Scheduler
+	registryStore store.RegistryStore
+	artifactStore store.RegistryArtifactStore
+	versionStore  store.RegistryVersionStore
+	blobMetaStore store.RegistryBlobStore
+	uploadStore   store.RegistryUploadStore
+	blobStore     blob.Store
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeCleanup, jobTypeCleanup, jobCronCleanup, jobMaxDurationCleanup)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for registry cleanup: %w", err)
+	}
+
+	return nil
+}
+
+// Handle runs the cleanup of all registries.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	registries, err := s.registryStore.ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	now := time.Now()
+
+	var versions, blobs int
+	for _, registry := range registries {
+		removed, err := s.applyRetention(ctx, registry, now)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("registry.id", registry.ID).
+				Msg("failed to apply retention policy of registry")
+		}
+		versions += removed
+
+		removed, err = s.collectGarbage(ctx, registry, now)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("registry.id", registry.ID).
+				Msg("failed to collect garbage of registry")
+		}
+		blobs += removed
+	}
+
+	uploads, err := s.removeStaleUploads(ctx, now)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("removed %d versions, %d blobs and %d uploads", versions, blobs, uploads), nil
+}
+
+// applyRetention removes the versions of every artifact that exceed the number of versions to keep
+// or are older than the maximum age. Artifacts without any versions left are removed as well.
+func (s *Service) applyRetention(ctx context.Context, registry *types.Registry, now time.Time) (int, error) {
+	if registry.MaxVersions <= 0 && registry.MaxAge <= 0 {
+		return 0, nil
+	}
+
+	// versions are grouped by artifact, newest first.
+	versions, err := s.versionStore.ListByRegistry(ctx, registry.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	expiredBefore := now.UnixMilli() - registry.MaxAge
+
+	var removed int
+	var artifactID int64
+	var kept int64
+	seen := map[string]bool{}
+	for _, version := range versions {
+		if version.ArtifactID != artifactID {
+			artifactID = version.ArtifactID
+			kept = 0
+			seen = map[string]bool{}
+		}
+
+		// generic package versions consist of multiple files, the newest file counts.
+		if seen[version.Name] {
+			continue
+		}
+		seen[version.Name] = true
+
+		tooMany := registry.MaxVersions > 0 && kept >= registry.MaxVersions
+		tooOld := registry.MaxAge > 0 && version.Created < expiredBefore
+		if !tooMany && !tooOld {
+			kept++
+			continue
+		}
+
+		if _, err = s.versionStore.DeleteByName(ctx, version.ArtifactID, version.Name); err != nil {
+			return removed, fmt.Errorf("failed to delete version %q: %w", version.Name, err)
+		}
+		removed++
+	}
+
+	if _, err = s.artifactStore.DeleteEmpty(ctx, registry.ID); err != nil {
+		return removed, fmt.Errorf("failed to delete empty artifacts: %w", err)
+	}
+
+	return removed, nil
+}
+
+// collectGarbage removes the blobs that aren't reachable from any version of the registry.
+// Only blobs older than the grace period are considered, to not remove blobs of pushes in progress.
+func (s *Service) collectGarbage(ctx context.Context, registry *types.Registry, now time.Time) (int, error) {
+	blobs, err := s.blobMetaStore.ListCreatedBefore(ctx, registry.ID, now.Add(-s.gcGracePeriod).UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list blobs: %w", err)
+	}
+	if len(blobs) == 0 {
+		return 0, nil
+	}
+
+	versions, err := s.versionStore.ListByRegistry(ctx, registry.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	refs, err := s.blobMetaStore.ListReferences(ctx, registry.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list manifest references: %w", err)
+	}
+
+	children := map[string][]string{}
+	for _, ref := range refs {
+		children[ref.ManifestDigest] = append(children[ref.ManifestDigest], ref.Digest)
+	}
+
+	reachable := map[string]bool{}
+	queue := make([]string, 0, len(versions))
+	for _, version := range versions {
+		queue = append(queue, version.Digest)
+	}
+	for len(queue) > 0 {
+		digest := queue[0]
+		queue = queue[1:]
+		if reachable[digest] {
+			continue
+		}
+		reachable[digest] = true
+		queue = append(queue, children[digest]...)
+	}
+
+	var removed int
+	for _, b := range blobs {
+		if reachable[b.Digest] {
+			continue
+		}
+
+		if err = s.blobStore.Delete(ctx, BlobPath(registry.ID, b.Digest)); err != nil {
+			return removed, fmt.Errorf("failed to delete content of blob %s: %w", b.Digest, err)
+		}
+		if err = s.blobMetaStore.DeleteReferences(ctx, registry.ID, b.Digest); err != nil {
+			return removed, fmt.Errorf("failed to delete references of blob %s: %w", b.Digest, err)
+		}
+		if err = s.blobMetaStore.Delete(ctx, b.ID); err != nil {
+			return removed, fmt.Errorf("failed to delete blob %s: %w", b.Digest, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// removeStaleUploads removes the uploads that haven't been completed in time.
+func (s *Service) removeStaleUploads(ctx context.Context, now time.Time) (int, error) {
+	uploads, err := s.uploadStore.ListUpdatedBefore(ctx, now.Add(-s.uploadExpiry).UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stale uploads: %w", err)
+	}
+
+	for _, upload := range uploads {
+		DeleteUploadParts(ctx, s.blobStore, upload)
+
+		if err = s.uploadStore.Delete(ctx, upload.ID); err != nil {
+			return 0, fmt.Errorf("failed to delete upload: %w", err)
+		}
+	}
+
+	return len(uploads), nil
+}
+
+// DeleteUploadParts removes the uploaded chunks of an upload from the blob store.
+// Failures are only logged, leftover chunks don't affect the registry.
+func DeleteUploadParts(ctx context.Context, blobStore blob.Store, upload *types.RegistryUpload) {
+	for part := int64(0); part < upload.Parts; part++ {
+		err := blobStore.Delete(ctx, UploadPartPath(upload.RegistryID, upload.Identifier, part))
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("upload.id", upload.Identifier).
+				Int64("upload.part", part).
+				Msg("failed to delete part of registry upload")
+		}
+	}
+}
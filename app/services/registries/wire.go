@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registries
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	registryStore store.RegistryStore,
+	artifactStore store.RegistryArtifactStore,
+	versionStore store.RegistryVersionStore,
+	blobMetaStore store.RegistryBlobStore,
+	uploadStore store.RegistryUploadStore,
+	blobStore blob.Store,
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service := &Service{
+		gcGracePeriod: config.Registry.GCGracePeriod,
+		uploadExpiry:  config.Registry.UploadExpiry,
+		scheduler:     jobScheduler,
+		registryStore: registryStore,
+		artifactStore: artifactStore,
+		versionStore:  versionStore,
+		blobMetaStore: blobMetaStore,
+		uploadStore:   uploadStore,
+		blobStore:     blobStore,
+	}
+
+	err := executor.Register(jobTypeCleanup, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative repository configuration committed to the default branch.
+// The rules use the same pattern and definition format as the protection rules API.
+type Config struct {
+	PullReq *PullReqConfig `json:"pullreq"`
+	Rules   []RuleConfig   `json:"rules"`
+}
+
+// PullReqConfig holds the pull request settings of the repository.
+type PullReqConfig struct {
+	Suggestions        *bool `json:"suggestions"`
+	DeleteSourceBranch *bool `json:"delete_source_branch"`
+}
+
+// RuleConfig declares a branch protection rule of the repository.
+// RequiredChecks is a shortcut for the status checks required by the rule definition.
+type RuleConfig struct {
+	Identifier     string             `json:"identifier"`
+	Description    string             `json:"description"`
+	State          enum.RuleState     `json:"state"`
+	Pattern        protection.Pattern `json:"pattern"`
+	Definition     json.RawMessage    `json:"definition"`
+	RequiredChecks []string           `json:"required_checks"`
+}
+
+// Parse parses and validates the yaml repository configuration.
+// Unknown fields are rejected to surface typos instead of silently ignoring them.
+func Parse(data []byte) (*Config, error) {
+	var raw any
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+
+	if raw == nil {
+		return &Config{}, nil
+	}
+
+	// yaml is converted to json to reuse the json format (and decoders) of the rule definitions.
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported yaml content: %w", err)
+	}
+
+	config := &Config{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if err = config.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *Config) sanitize() error {
+	identifiers := make(map[string]struct{}, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+
+		if err := check.Identifier(rule.Identifier); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+
+		if _, ok := identifiers[rule.Identifier]; ok {
+			return fmt.Errorf("rule %q is declared more than once", rule.Identifier)
+		}
+		identifiers[rule.Identifier] = struct{}{}
+
+		if rule.State == "" {
+			rule.State = enum.RuleStateActive
+		}
+
+		var ok bool
+		if rule.State, ok = rule.State.Sanitize(); !ok {
+			return fmt.Errorf("rule %q: invalid state %q", rule.Identifier, rule.State)
+		}
+
+		if err := rule.Pattern.Validate(); err != nil {
+			return fmt.Errorf("rule %q: invalid pattern: %w", rule.Identifier, err)
+		}
+
+		if len(rule.Definition) == 0 {
+			rule.Definition = json.RawMessage("{}")
+		}
+	}
+
+	return nil
+}
+
+// definition returns the rule definition json with the required checks merged into it.
+func (r *RuleConfig) definition() (json.RawMessage, error) {
+	if len(r.RequiredChecks) == 0 {
+		return r.Definition, nil
+	}
+
+	var def protection.Branch
+	if err := json.Unmarshal(r.Definition, &def); err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+
+	required := def.PullReq.StatusChecks.RequireIdentifiers
+	for _, id := range r.RequiredChecks {
+		if !contains(required, id) {
+			required = append(required, id)
+		}
+	}
+	def.PullReq.StatusChecks.RequireIdentifiers = required
+
+	return json.Marshal(&def)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
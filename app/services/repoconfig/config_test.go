@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		check   func(t *testing.T, config *Config)
+	}{
+		{
+			name: "empty",
+			data: "",
+			check: func(t *testing.T, config *Config) {
+				if config.PullReq != nil || len(config.Rules) != 0 {
+					t.Errorf("expected empty config, got %+v", config)
+				}
+			},
+		},
+		{
+			name: "full",
+			data: `
+pullreq:
+  suggestions: false
+  delete_source_branch: true
+rules:
+  - identifier: protect-main
+    description: protects the default branch
+    pattern:
+      default: true
+    required_checks: [build]
+    definition:
+      pullreq:
+        approvals:
+          require_minimum_count: 1
+`,
+			check: func(t *testing.T, config *Config) {
+				if config.PullReq == nil || config.PullReq.Suggestions == nil || *config.PullReq.Suggestions {
+					t.Errorf("unexpected pull request config: %+v", config.PullReq)
+				}
+				if len(config.Rules) != 1 {
+					t.Fatalf("expected one rule, got %d", len(config.Rules))
+				}
+				rule := config.Rules[0]
+				if rule.State != enum.RuleStateActive {
+					t.Errorf("expected default state active, got %s", rule.State)
+				}
+				if !rule.Pattern.Default {
+					t.Error("expected default branch pattern")
+				}
+				if !reflect.DeepEqual(rule.RequiredChecks, []string{"build"}) {
+					t.Errorf("unexpected required checks: %v", rule.RequiredChecks)
+				}
+			},
+		},
+		{
+			name:    "unknown field",
+			data:    "labels: [bug]",
+			wantErr: true,
+		},
+		{
+			name:    "invalid identifier",
+			data:    "rules: [{identifier: 'not valid'}]",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate identifier",
+			data:    "rules: [{identifier: a}, {identifier: a}]",
+			wantErr: true,
+		},
+		{
+			name:    "invalid state",
+			data:    "rules: [{identifier: a, state: on}]",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := Parse([]byte(test.data))
+			if test.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			test.check(t, config)
+		})
+	}
+}
+
+func TestRuleConfigDefinition(t *testing.T) {
+	rule := RuleConfig{
+		Definition:     json.RawMessage(`{"pullreq":{"status_checks":{"require_identifiers":["lint"]}}}`),
+		RequiredChecks: []string{"build", "lint"},
+	}
+
+	data, err := rule.definition()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var def protection.Branch
+	if err = json.Unmarshal(data, &def); err != nil {
+		t.Fatalf("failed to unmarshal definition: %v", err)
+	}
+
+	want := []string{"lint", "build"}
+	if got := def.PullReq.StatusChecks.RequireIdentifiers; !reflect.DeepEqual(got, want) {
+		t.Errorf("want required checks %v, got %v", want, got)
+	}
+}
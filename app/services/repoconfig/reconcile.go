@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/protection"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// apply applies the configuration to the repository and returns the list of corrected differences.
+// Protection rules that aren't declared in the configuration are left untouched.
+func (s *Service) apply(
+	ctx context.Context,
+	repo *types.Repository,
+	config *Config,
+	principalID int64,
+) ([]string, error) {
+	var changes []string
+
+	if config.PullReq != nil {
+		pullReqChanges, err := s.applyPullReqConfig(ctx, repo, config.PullReq)
+		if err != nil {
+			return changes, err
+		}
+
+		changes = append(changes, pullReqChanges...)
+	}
+
+	for i := range config.Rules {
+		ruleChanges, err := s.applyRuleConfig(ctx, repo, &config.Rules[i], principalID)
+		if err != nil {
+			return changes, err
+		}
+
+		changes = append(changes, ruleChanges...)
+	}
+
+	return changes, nil
+}
+
+func (s *Service) applyPullReqConfig(
+	ctx context.Context,
+	repo *types.Repository,
+	config *PullReqConfig,
+) ([]string, error) {
+	var changes []string
+	if config.Suggestions != nil && *config.Suggestions != repo.PullReqSuggestions {
+		changes = append(changes, fmt.Sprintf("pull request suggestions set to %t", *config.Suggestions))
+	}
+	if config.DeleteSourceBranch != nil && *config.DeleteSourceBranch != repo.PullReqDeleteSourceBranch {
+		changes = append(changes,
+			fmt.Sprintf("pull request source branch deletion set to %t", *config.DeleteSourceBranch))
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	_, err := s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		if config.Suggestions != nil {
+			repo.PullReqSuggestions = *config.Suggestions
+		}
+		if config.DeleteSourceBranch != nil {
+			repo.PullReqDeleteSourceBranch = *config.DeleteSourceBranch
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request settings: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (s *Service) applyRuleConfig(
+	ctx context.Context,
+	repo *types.Repository,
+	config *RuleConfig,
+	principalID int64,
+) ([]string, error) {
+	definition, err := config.definition()
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", config.Identifier, err)
+	}
+
+	definition, err = s.protectionManager.SanitizeJSON(protection.TypeBranch, definition)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: invalid definition: %w", config.Identifier, err)
+	}
+
+	pattern := config.Pattern.JSON()
+
+	rule, err := s.ruleStore.FindByIdentifier(ctx, nil, &repo.ID, config.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		now := time.Now().UnixMilli()
+		err = s.ruleStore.Create(ctx, &types.Rule{
+			CreatedBy:   principalID,
+			Created:     now,
+			Updated:     now,
+			RepoID:      &repo.ID,
+			Type:        protection.TypeBranch,
+			State:       config.State,
+			Identifier:  config.Identifier,
+			Description: config.Description,
+			Pattern:     pattern,
+			Definition:  definition,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rule %q: %w", config.Identifier, err)
+		}
+
+		return []string{fmt.Sprintf("rule %q created", config.Identifier)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rule %q: %w", config.Identifier, err)
+	}
+
+	if rule.Type != protection.TypeBranch {
+		return nil, fmt.Errorf("rule %q isn't a branch protection rule", config.Identifier)
+	}
+
+	var changes []string
+	if rule.Description != config.Description {
+		changes = append(changes, fmt.Sprintf("rule %q: description updated", config.Identifier))
+	}
+	if rule.State != config.State {
+		changes = append(changes, fmt.Sprintf("rule %q: state changed from %s to %s",
+			config.Identifier, rule.State, config.State))
+	}
+	if !bytes.Equal(normalizePattern(rule.Pattern), pattern) {
+		changes = append(changes, fmt.Sprintf("rule %q: pattern updated", config.Identifier))
+	}
+	if !bytes.Equal(s.normalizeDefinition(rule), definition) {
+		changes = append(changes, fmt.Sprintf("rule %q: definition updated", config.Identifier))
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	rule.Description = config.Description
+	rule.State = config.State
+	rule.Pattern = pattern
+	rule.Definition = definition
+
+	err = s.ruleStore.Update(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rule %q: %w", config.Identifier, err)
+	}
+
+	return changes, nil
+}
+
+// normalizePattern returns the stored pattern in the format generated for the configured pattern.
+func normalizePattern(data json.RawMessage) json.RawMessage {
+	var pattern protection.Pattern
+	if err := json.Unmarshal(data, &pattern); err != nil {
+		return data
+	}
+
+	return pattern.JSON()
+}
+
+// normalizeDefinition returns the stored definition in the format generated for the configured definition.
+func (s *Service) normalizeDefinition(rule *types.Rule) json.RawMessage {
+	definition, err := s.protectionManager.SanitizeJSON(rule.Type, rule.Definition)
+	if err != nil {
+		return rule.Definition
+	}
+
+	return definition
+}
+
+// report stores the result of the reconciliation as a status check of the commit.
+func (s *Service) report(
+	ctx context.Context,
+	repo *types.Repository,
+	sha string,
+	principalID int64,
+	changes []string,
+	errReconcile error,
+) error {
+	status := enum.CheckStatusSuccess
+	var summary string
+	switch {
+	case errReconcile != nil:
+		status = enum.CheckStatusFailure
+		summary = "Repository configuration not applied: " + errReconcile.Error()
+	case len(changes) == 0:
+		summary = "Repository matches the configuration"
+	default:
+		summary = fmt.Sprintf("Repository configuration applied, corrected %d differences", len(changes))
+	}
+
+	details := &strings.Builder{}
+	for _, change := range changes {
+		details.WriteString("- ")
+		details.WriteString(change)
+		details.WriteString("\n")
+	}
+
+	data, err := json.Marshal(types.CheckPayloadText{Details: details.String()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal check payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.checkStore.Upsert(ctx, &types.Check{
+		CreatedBy:  principalID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     repo.ID,
+		CommitSHA:  sha,
+		Identifier: CheckIdentifier,
+		Status:     status,
+		Summary:    summary,
+		Metadata:   []byte("{}"),
+		Started:    now,
+		Ended:      now,
+		Payload: types.CheckPayload{
+			Kind: enum.CheckPayloadKindMarkdown,
+			Data: data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to report repo config status check: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:repoconfig"
+
+	// CheckIdentifier is the identifier of the status check reporting the reconciliation result.
+	CheckIdentifier = "gitness-config"
+
+	maxConfigFileSize = 1 << 20 // 1 MiB
+)
+
+// Service reconciles the settings and the protection rules of repositories
+// with the configuration file committed to their default branch.
+// The reconciliation runs on every push to the default branch and its result,
+// including the drift that got corrected, is reported as a status check of the pushed commit.
+type Service struct {
+	filePath          string
+	git               git.Interface
+	repoStore         store.RepoStore
+	ruleStore         store.RuleStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	ruleStore store.RuleStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+) (*Service, error) {
+	service := &Service{
+		filePath:          config.RepoConfig.FilePath,
+		git:               git,
+		repoStore:         repoStore,
+		ruleStore:         ruleStore,
+		checkStore:        checkStore,
+		protectionManager: protectionManager,
+	}
+
+	if !config.RepoConfig.Enabled {
+		return service, nil
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for repo config: %w", err)
+	}
+
+	return service, nil
+}
+
+// Reconcile applies the configuration file found at the commit to the repository
+// and reports the result as a status check of the commit.
+// Nothing is done if the commit doesn't contain the configuration file.
+func (s *Service) Reconcile(ctx context.Context, repo *types.Repository, sha string, principalID int64) error {
+	data, err := s.readFile(ctx, repo, sha)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+
+	config, err := Parse(data)
+	if err != nil {
+		return s.report(ctx, repo, sha, principalID, nil, err)
+	}
+
+	changes, err := s.apply(ctx, repo, config, principalID)
+	if err != nil {
+		return s.report(ctx, repo, sha, principalID, changes, err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("sha", sha).
+		Msgf("reconciled repository configuration with %d changes", len(changes))
+
+	return s.report(ctx, repo, sha, principalID, changes, nil)
+}
+
+// readFile returns the content of the configuration file, or nil if the commit doesn't contain it.
+//
+//nolint:nilnil // nil content means there's nothing to reconcile.
+func (s *Service) readFile(ctx context.Context, repo *types.Repository, sha string) ([]byte, error) {
+	params := git.CreateReadParams(repo)
+
+	node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: params,
+		GitREF:     sha,
+		Path:       s.filePath,
+	})
+	if gittypes.IsPathNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo config file node: %w", err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return nil, nil
+	}
+
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: params,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxConfigFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo config file content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close repo config file content reader")
+		}
+	}()
+
+	data, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repo config file content: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewerassignment
+
+import (
+	"sort"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// picker picks reviewers among candidates according to the assignment strategy.
+type picker struct {
+	strategy enum.ReviewerAssignmentStrategy
+	// pending is the number of pending review requests of the candidates.
+	pending map[int64]int
+	// lastAdded is the time the candidates were last added as reviewers to a pull request of the repository.
+	lastAdded map[int64]int64
+	// selected contains the principals that are already reviewers (or can't be ones).
+	selected map[int64]struct{}
+}
+
+// pick returns up to n of the candidates that aren't selected yet and marks them as selected.
+// Candidates that are equally suited are picked in the order they are provided.
+func (p *picker) pick(candidates []int64, n int) []int64 {
+	if n <= 0 {
+		return nil
+	}
+
+	available := make([]int64, 0, len(candidates))
+	for _, principalID := range candidates {
+		if _, ok := p.selected[principalID]; ok {
+			continue
+		}
+		available = append(available, principalID)
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		a, b := available[i], available[j]
+		if p.strategy == enum.ReviewerAssignmentStrategyLeastLoaded && p.pending[a] != p.pending[b] {
+			return p.pending[a] < p.pending[b]
+		}
+		return p.lastAdded[a] < p.lastAdded[b]
+	})
+
+	if len(available) > n {
+		available = available[:n]
+	}
+
+	for _, principalID := range available {
+		p.selected[principalID] = struct{}{}
+	}
+
+	return available
+}
+
+// anySelected returns true if any of the principals is selected.
+func (p *picker) anySelected(principalIDs []int64) bool {
+	for _, principalID := range principalIDs {
+		if _, ok := p.selected[principalID]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewerassignment
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPicker(t *testing.T) {
+	pending := map[int64]int{1: 3, 2: 0, 3: 1}
+	lastAdded := map[int64]int64{1: 100, 3: 300, 4: 200}
+
+	tests := []struct {
+		name       string
+		strategy   enum.ReviewerAssignmentStrategy
+		selected   []int64
+		candidates []int64
+		n          int
+		want       []int64
+	}{
+		{
+			name:       "round robin picks least recently added",
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			candidates: []int64{1, 2, 3, 4},
+			n:          2,
+			want:       []int64{2, 1},
+		},
+		{
+			name:       "least loaded picks fewest pending reviews",
+			strategy:   enum.ReviewerAssignmentStrategyLeastLoaded,
+			candidates: []int64{1, 2, 3, 4},
+			n:          3,
+			want:       []int64{2, 4, 3},
+		},
+		{
+			name:       "selected candidates are skipped",
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			selected:   []int64{2, 1},
+			candidates: []int64{1, 2, 3, 4},
+			n:          1,
+			want:       []int64{4},
+		},
+		{
+			name:       "fewer candidates than requested",
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			selected:   []int64{1},
+			candidates: []int64{1, 3},
+			n:          2,
+			want:       []int64{3},
+		},
+		{
+			name:       "nothing requested",
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			candidates: []int64{1, 3},
+			n:          0,
+			want:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &picker{
+				strategy:  test.strategy,
+				pending:   pending,
+				lastAdded: lastAdded,
+				selected:  map[int64]struct{}{},
+			}
+			for _, principalID := range test.selected {
+				p.selected[principalID] = struct{}{}
+			}
+
+			got := p.pick(test.candidates, test.n)
+			if len(got) == 0 && len(test.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+
+			for _, principalID := range got {
+				if !p.anySelected([]int64{principalID}) {
+					t.Errorf("picked principal %d isn't marked as selected", principalID)
+				}
+			}
+		})
+	}
+}
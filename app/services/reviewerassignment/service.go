@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewerassignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const eventsReaderGroupName = "gitness:reviewerassignment"
+
+// Service assigns reviewers to new pull requests according to the reviewer assignment settings of the repository.
+type Service struct {
+	settings      *settings.Service
+	codeOwners    *codeowners.Service
+	repoStore     store.RepoStore
+	pullreqStore  store.PullReqStore
+	reviewerStore store.PullReqReviewerStore
+	pullreqCtrl   *pullreq.Controller
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	settings *settings.Service,
+	codeOwners *codeowners.Service,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	service := &Service{
+		settings:      settings,
+		codeOwners:    codeOwners,
+		repoStore:     repoStore,
+		pullreqStore:  pullreqStore,
+		reviewerStore: reviewerStore,
+		pullreqCtrl:   pullreqCtrl,
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.handleEventCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for reviewer assignment: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) handleEventCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.AssignReviewers(ctx, event.Payload.PullReqID)
+}
+
+// AssignReviewers picks reviewers for the pull request from the reviewer pool and the CODEOWNERS of the repository
+// and adds them to the pull request. Reviewers that are already added count towards the assignment.
+func (s *Service) AssignReviewers(ctx context.Context, pullReqID int64) error {
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	assignment, err := s.settings.ReviewerAssignmentSettings(ctx, pr.TargetRepoID)
+	if err != nil {
+		return err
+	}
+
+	if assignment == nil {
+		return nil
+	}
+
+	targetRepo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repo: %w", err)
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	selected := map[int64]struct{}{pr.CreatedBy: {}}
+	for _, reviewer := range reviewers {
+		selected[reviewer.PrincipalID] = struct{}{}
+	}
+
+	var entryOwners [][]int64
+	if assignment.CodeOwners {
+		entryOwners, err = s.codeOwnerCandidates(ctx, targetRepo, pr)
+		if err != nil {
+			return err
+		}
+	}
+
+	candidateIDs := append([]int64{}, assignment.Pool...)
+	for _, owners := range entryOwners {
+		candidateIDs = append(candidateIDs, owners...)
+	}
+
+	pending, err := s.reviewerStore.CountPending(ctx, candidateIDs)
+	if err != nil {
+		return fmt.Errorf("failed to count pending reviews: %w", err)
+	}
+
+	lastAdded, err := s.reviewerStore.LastAdded(ctx, targetRepo.ID, candidateIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find last added reviewers: %w", err)
+	}
+
+	picker := &picker{
+		strategy:  assignment.Strategy,
+		pending:   pending,
+		lastAdded: lastAdded,
+		selected:  selected,
+	}
+
+	var assigned []int64
+
+	count := assignment.Count
+	for _, principalID := range assignment.Pool {
+		if _, ok := selected[principalID]; ok && principalID != pr.CreatedBy {
+			count--
+		}
+	}
+	assigned = append(assigned, picker.pick(assignment.Pool, count)...)
+
+	for _, owners := range entryOwners {
+		if picker.anySelected(owners) {
+			continue
+		}
+		assigned = append(assigned, picker.pick(owners, 1)...)
+	}
+
+	session := bootstrap.NewSystemServiceSession()
+	for _, principalID := range assigned {
+		_, err = s.pullreqCtrl.ReviewerAdd(ctx, session, targetRepo.Path, pr.Number,
+			&pullreq.ReviewerAddInput{ReviewerID: principalID})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to assign reviewer %d to pull request %d",
+				principalID, pr.ID)
+		}
+	}
+
+	return nil
+}
+
+// codeOwnerCandidates returns the ids of the owners of every CODEOWNERS entry that applies to the pull request.
+func (s *Service) codeOwnerCandidates(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) ([][]int64, error) {
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find source repo: %w", err)
+		}
+	}
+
+	entries, err := s.codeOwners.Owners(ctx, sourceRepo, pr)
+	if errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find code owners: %w", err)
+	}
+
+	entryOwners := make([][]int64, len(entries))
+	for i, owners := range entries {
+		entryOwners[i] = make([]int64, len(owners))
+		for j, owner := range owners {
+			entryOwners[i][j] = owner.ID
+		}
+	}
+
+	return entryOwners, nil
+}
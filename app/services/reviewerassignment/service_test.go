@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewerassignment
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/breakglass"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	gitness_cache "github.com/harness/gitness/cache"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestService_AssignReviewersBreakGlass verifies that the system service assigns reviewers
+// with the membership authorizer while break-glass mode restricts instance admins.
+// The system service isn't a member of the space, while the reviewers still need access to the repository.
+func TestService_AssignReviewersBreakGlass(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", "file:reviewer_assignment?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation,
+		gitness_cache.NoRowCache{})
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+	config.Principal.System.Email = "system@gitness.io"
+	config.Principal.System.DisplayName = "Gitness"
+	config.BreakGlass.Enabled = true
+	config.BreakGlass.Window = time.Hour
+	serviceCtrl := service.NewController(check.PrincipalUIDDefault, nil, principalStore)
+	if err = bootstrap.SystemService(ctx, config, serviceCtrl); err != nil {
+		t.Fatalf("failed to setup system service: %v", err)
+	}
+
+	users := map[string]*types.User{}
+	for _, uid := range []string{"author", "member", "outsider", "admin"} {
+		user := &types.User{UID: uid, Email: uid + "@example.com", DisplayName: uid, Admin: uid == "admin"}
+		if err = principalStore.CreateUser(ctx, user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		users[uid] = user
+	}
+
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, gitness_cache.NoRowCache{})
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	membershipStore := database.NewMembershipStore(db, pCache, spacePathStore, spaceStore)
+
+	space := &types.Space{Identifier: "acme", CreatedBy: users["author"].ID}
+	if err = spaceStore.Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %v", err)
+	}
+	err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: space.Identifier, SpaceID: space.ID, CreatedBy: users["author"].ID, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %v", err)
+	}
+
+	for _, uid := range []string{"author", "member"} {
+		err = membershipStore.Create(ctx, &types.Membership{
+			MembershipKey: types.MembershipKey{SpaceID: space.ID, PrincipalID: users[uid].ID},
+			CreatedBy:     users["author"].ID,
+			Role:          enum.MembershipRoleContributor,
+		})
+		if err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	repo := &types.Repository{
+		Identifier: "app", ParentID: space.ID, GitUID: "app", DefaultBranch: "main", CreatedBy: users["author"].ID,
+	}
+	if err = repoStore.Create(ctx, repo); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+
+	settingsService := settings.NewService(database.NewSettingsStore(db), spaceStore)
+	breakGlass := breakglass.NewService(config, settingsService, nil, nil)
+	authorizer := authz.NewMembershipAuthorizer(
+		authz.NewPermissionCache(spaceStore, membershipStore, settingsService, time.Minute),
+		spaceStore,
+		breakGlass,
+	)
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:               events.ModeInMemory,
+		MaxStreamLength:    100,
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    1,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	reviewerStore := database.NewPullReqReviewerStore(db, pCache)
+
+	pullreqCtrl := pullreq.NewController(dbtx.New(db), nil, authorizer, pullreqStore, nil, nil, nil, nil,
+		reviewerStore, nil, nil, repoStore, principalStore, nil, nil, nil, nil, eventReporter, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, settingsService, nil, false)
+
+	svc := &Service{
+		settings:      settingsService,
+		repoStore:     repoStore,
+		pullreqStore:  pullreqStore,
+		reviewerStore: reviewerStore,
+		pullreqCtrl:   pullreqCtrl,
+	}
+
+	err = settingsService.SetReviewerAssignmentSettings(ctx, repo.ID, &types.ReviewerAssignmentSettings{
+		Strategy: enum.ReviewerAssignmentStrategyRoundRobin,
+		Pool:     []int64{users["member"].ID, users["outsider"].ID, users["admin"].ID},
+		Count:    3,
+	}, users["author"].ID)
+	if err != nil {
+		t.Fatalf("failed to set reviewer assignment settings: %v", err)
+	}
+
+	now := time.Now().UnixMilli()
+	pr := &types.PullReq{
+		Number:           1,
+		CreatedBy:        users["author"].ID,
+		Created:          now,
+		Updated:          now,
+		Edited:           now,
+		State:            enum.PullReqStateOpen,
+		Title:            "Add feature",
+		SourceRepoID:     repo.ID,
+		SourceBranch:     "feature",
+		SourceSHA:        "a",
+		TargetRepoID:     repo.ID,
+		TargetBranch:     "main",
+		MergeCheckStatus: enum.MergeCheckStatusMergeable,
+		Conversation:     enum.PullReqConversationOpen,
+	}
+	if err = pullreqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	if err = svc.AssignReviewers(ctx, pr.ID); err != nil {
+		t.Fatalf("failed to assign reviewers: %v", err)
+	}
+
+	reviewers, err := reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		t.Fatalf("failed to list reviewers: %v", err)
+	}
+
+	// only the member has access to the repository, the outsider isn't a member and
+	// the admin without active break-glass access is treated like any other user.
+	uids := make([]string, len(reviewers))
+	for i, reviewer := range reviewers {
+		uids[i] = reviewer.Reviewer.UID
+		if reviewer.AddedBy.UID != config.Principal.System.UID {
+			t.Errorf("expected reviewer %s to be added by the system service, got %s",
+				reviewer.Reviewer.UID, reviewer.AddedBy.UID)
+		}
+	}
+	sort.Strings(uids)
+	if len(uids) != 1 || uids[0] != "member" {
+		t.Errorf("expected the member to be assigned as the only reviewer, got %v", uids)
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewreminder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReviewReminders        = "gitness:pullreq:review-reminders"
+	jobCronReviewReminders        = "7 * * * *" // At minute 7 of every hour.
+	jobMaxDurationReviewReminders = 15 * time.Minute
+)
+
+// Service reminds requested reviewers of pull requests whose review is pending
+// for longer than allowed by the review reminder policy of the space.
+type Service struct {
+	scheduler     *job.Scheduler
+	executor      *job.Executor
+	settings      *settings.Service
+	reminderStore store.ReviewReminderStore
+	pullreqStore  store.PullReqStore
+	repoStore     store.RepoStore
+	spaceStore    store.SpaceStore
+	eventReporter *pullreqevents.Reporter
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	settings *settings.Service,
+	reminderStore store.ReviewReminderStore,
+	pullreqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	eventReporter *pullreqevents.Reporter,
+) *Service {
+	return &Service{
+		scheduler:     scheduler,
+		executor:      executor,
+		settings:      settings,
+		reminderStore: reminderStore,
+		pullreqStore:  pullreqStore,
+		repoStore:     repoStore,
+		spaceStore:    spaceStore,
+		eventReporter: eventReporter,
+	}
+}
+
+// Register registers the review reminder job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeReviewReminders, &reminderJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for review reminders: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeReviewReminders,
+		jobTypeReviewReminders,
+		jobCronReviewReminders,
+		jobMaxDurationReviewReminders,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule review reminder job: %w", err)
+	}
+
+	return nil
+}
+
+type reminderJob struct {
+	service *Service
+}
+
+// pullReqReminder collects the reviewers of a pull request that are reminded together.
+type pullReqReminder struct {
+	pullReqID   int64
+	reviewerIDs []int64
+	// requested is the time the longest pending of the reviews was requested.
+	requested int64
+}
+
+// Handle reminds the reviewers of all pending reviews that are due according to the policy of their space.
+// The policy of the closest space applies to a pull request, so subspaces can override the policy of their ancestors.
+func (j *reminderJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	policies, err := j.service.settings.ReviewReminderPolicies(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	minAfterHours := 0
+	for _, policy := range policies {
+		if policy.AfterHours > 0 && (minAfterHours == 0 || policy.AfterHours < minAfterHours) {
+			minAfterHours = policy.AfterHours
+		}
+	}
+
+	if minAfterHours == 0 {
+		return "no review reminder policies", nil
+	}
+
+	now := time.Now()
+
+	reviews, err := j.service.reminderStore.ListPending(ctx, now.Add(-hours(minAfterHours)).UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list pending reviews: %w", err)
+	}
+
+	resolver := &policyResolver{
+		repoStore:  j.service.repoStore,
+		spaceStore: j.service.spaceStore,
+		policies:   policies,
+		repoSpaces: map[int64]int64{},
+		closest:    map[int64]int64{},
+	}
+
+	var reminders []*pullReqReminder
+	for _, review := range reviews {
+		policy, err := resolver.policyForRepo(ctx, review.RepoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to resolve review reminder policy of repo %d", review.RepoID)
+			continue
+		}
+
+		if policy == nil || !isDue(review, policy, now) {
+			continue
+		}
+
+		// pending reviews are ordered by pull request.
+		if len(reminders) == 0 || reminders[len(reminders)-1].pullReqID != review.PullReqID {
+			reminders = append(reminders, &pullReqReminder{
+				pullReqID: review.PullReqID,
+				requested: review.Requested,
+			})
+		}
+
+		reminder := reminders[len(reminders)-1]
+		reminder.reviewerIDs = append(reminder.reviewerIDs, review.PrincipalID)
+		if review.Requested < reminder.requested {
+			reminder.requested = review.Requested
+		}
+	}
+
+	reminded := 0
+	for _, reminder := range reminders {
+		if err = j.service.remind(ctx, reminder, now); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remind reviewers of pull request %d", reminder.pullReqID)
+			continue
+		}
+
+		reminded += len(reminder.reviewerIDs)
+	}
+
+	result := fmt.Sprintf("reminded %d reviewers of %d pull requests", reminded, len(reminders))
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) remind(ctx context.Context, reminder *pullReqReminder, now time.Time) error {
+	pr, err := s.pullreqStore.Find(ctx, reminder.pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	for _, reviewerID := range reminder.reviewerIDs {
+		if err = s.reminderStore.MarkReminded(ctx, pr.ID, reviewerID, now.UnixMilli()); err != nil {
+			return fmt.Errorf("failed to mark reviewer %d as reminded: %w", reviewerID, err)
+		}
+	}
+
+	s.eventReporter.ReviewReminder(ctx, &pullreqevents.ReviewReminderPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  bootstrap.NewSystemServiceSession().Principal.ID,
+			Number:       pr.Number,
+		},
+		ReviewerIDs:  reminder.reviewerIDs,
+		PendingHours: int(now.Sub(time.UnixMilli(reminder.requested)).Hours()),
+	})
+
+	return nil
+}
+
+// isDue returns true if the reviewer should be reminded of the pending review.
+// A review is due once it is pending for longer than allowed by the policy and the reviewer didn't snooze it.
+// Afterwards, the reminder is repeated in the interval of the policy, if any.
+func isDue(review *types.PendingReview, policy *types.ReviewReminderPolicy, now time.Time) bool {
+	if policy.AfterHours <= 0 || review.SnoozedUntil > now.UnixMilli() {
+		return false
+	}
+
+	if review.Requested > now.Add(-hours(policy.AfterHours)).UnixMilli() {
+		return false
+	}
+
+	// the review was requested again after the last reminder.
+	if review.Reminded < review.Requested {
+		return true
+	}
+
+	if policy.RepeatHours <= 0 {
+		return false
+	}
+
+	return review.Reminded <= now.Add(-hours(policy.RepeatHours)).UnixMilli()
+}
+
+func hours(n int) time.Duration {
+	return time.Duration(n) * time.Hour
+}
+
+// policyResolver finds the review reminder policy of the closest space of a repository.
+type policyResolver struct {
+	repoStore  store.RepoStore
+	spaceStore store.SpaceStore
+	policies   map[int64]*types.ReviewReminderPolicy
+	// repoSpaces caches the id of the parent space, mapped by repo id.
+	repoSpaces map[int64]int64
+	// closest caches the id of the closest space with a policy, mapped by space id.
+	closest map[int64]int64
+}
+
+func (r *policyResolver) policyForRepo(ctx context.Context, repoID int64) (*types.ReviewReminderPolicy, error) {
+	spaceID, ok := r.repoSpaces[repoID]
+	if !ok {
+		repo, err := r.repoStore.Find(ctx, repoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo %d: %w", repoID, err)
+		}
+
+		spaceID = repo.ParentID
+		r.repoSpaces[repoID] = spaceID
+	}
+
+	closestID, err := r.closestPolicySpace(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.policies[closestID], nil
+}
+
+func (r *policyResolver) closestPolicySpace(ctx context.Context, spaceID int64) (int64, error) {
+	if closestID, ok := r.closest[spaceID]; ok {
+		return closestID, nil
+	}
+
+	closestID := int64(0)
+	if _, ok := r.policies[spaceID]; ok {
+		closestID = spaceID
+	} else {
+		space, err := r.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		if space.ParentID > 0 {
+			closestID, err = r.closestPolicySpace(ctx, space.ParentID)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	r.closest[spaceID] = closestID
+
+	return closestID, nil
+}
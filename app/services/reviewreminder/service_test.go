@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewreminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestIsDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(h int) int64 { return now.Add(-time.Duration(h) * time.Hour).UnixMilli() }
+
+	policy := &types.ReviewReminderPolicy{AfterHours: 24, RepeatHours: 12}
+	once := &types.ReviewReminderPolicy{AfterHours: 24}
+
+	tests := []struct {
+		name   string
+		review types.PendingReview
+		policy *types.ReviewReminderPolicy
+		want   bool
+	}{
+		{name: "not pending long enough", review: types.PendingReview{Requested: ago(10)}, policy: policy},
+		{name: "first reminder", review: types.PendingReview{Requested: ago(30)}, policy: policy, want: true},
+		{
+			name:   "snoozed",
+			review: types.PendingReview{Requested: ago(30), SnoozedUntil: ago(-1)},
+			policy: policy,
+		},
+		{
+			name:   "snooze expired",
+			review: types.PendingReview{Requested: ago(30), SnoozedUntil: ago(1)},
+			policy: policy,
+			want:   true,
+		},
+		{
+			name:   "repeat interval not elapsed",
+			review: types.PendingReview{Requested: ago(30), Reminded: ago(6)},
+			policy: policy,
+		},
+		{
+			name:   "repeat interval elapsed",
+			review: types.PendingReview{Requested: ago(48), Reminded: ago(12)},
+			policy: policy,
+			want:   true,
+		},
+		{
+			name:   "no repeat",
+			review: types.PendingReview{Requested: ago(100), Reminded: ago(50)},
+			policy: once,
+		},
+		{
+			name:   "requested again after reminder",
+			review: types.PendingReview{Requested: ago(30), Reminded: ago(40)},
+			policy: once,
+			want:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isDue(&test.review, test.policy, now); got != test.want {
+				t.Errorf("isDue() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runners
+
+import (
+	"strconv"
+	"strings"
+)
+
+// machinePrefix is the prefix of the machine name of stages that are leased by external runners.
+const machinePrefix = "runner:"
+
+// MachineName returns the machine name that is stored on the stages leased by the runner.
+func MachineName(runnerID int64) string {
+	return machinePrefix + strconv.FormatInt(runnerID, 10)
+}
+
+// ParseMachineName returns the id of the runner the machine name belongs to.
+// It returns false if the machine isn't an external runner.
+func ParseMachineName(machine string) (int64, bool) {
+	idStr, ok := strings.CutPrefix(machine, machinePrefix)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
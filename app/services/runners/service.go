@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeLeases        = "gitness:runners:leases"
+	jobCronLeases        = "* * * * *" // Every minute.
+	jobMaxDurationLeases = 1 * time.Minute
+
+	// errRunnerLost is the error set on stages whose runner stopped sending heartbeats.
+	errRunnerLost = "runner lost: no heartbeat received within the lease duration"
+)
+
+// Service expires the leases of stages that are executed by external runners which stopped sending heartbeats.
+type Service struct {
+	leaseDuration     time.Duration
+	scheduler         *job.Scheduler
+	runnerStore       store.RunnerStore
+	stageStore        store.StageStore
+	pipelineScheduler scheduler.Scheduler
+	executionManager  manager.ExecutionManager
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeLeases, jobTypeLeases, jobCronLeases, jobMaxDurationLeases)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for runner leases: %w", err)
+	}
+
+	return nil
+}
+
+// Handle requeues the stages that haven't been started by a lost runner
+// and fails the stages that were running on a lost runner.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	stages, err := s.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	expiredBefore := time.Now().Add(-s.leaseDuration).UnixMilli()
+	lost := map[int64]bool{}
+
+	var requeued, failed int
+	for _, stage := range stages {
+		runnerID, ok := ParseMachineName(stage.Machine)
+		if !ok {
+			continue
+		}
+
+		isLost, ok := lost[runnerID]
+		if !ok {
+			runner, err := s.runnerStore.Find(ctx, runnerID)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return "", fmt.Errorf("failed to find runner %d: %w", runnerID, err)
+			}
+
+			isLost = runner == nil || runner.LastHeartbeat < expiredBefore
+			lost[runnerID] = isLost
+		}
+
+		if !isLost {
+			continue
+		}
+
+		if stage.Status == enum.CIStatusPending && stage.Started == 0 {
+			err = s.requeue(ctx, stage)
+			requeued++
+		} else {
+			err = s.fail(ctx, stage)
+			failed++
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("stage.id", stage.ID).
+				Int64("runner.id", runnerID).
+				Msg("failed to expire lease of stage")
+		}
+	}
+
+	return fmt.Sprintf("requeued %d stages, failed %d stages", requeued, failed), nil
+}
+
+func (s *Service) requeue(ctx context.Context, stage *types.Stage) error {
+	stage.Machine = ""
+	if err := s.stageStore.Update(ctx, stage); err != nil {
+		return fmt.Errorf("failed to release stage: %w", err)
+	}
+
+	return s.pipelineScheduler.Schedule(ctx, stage)
+}
+
+func (s *Service) fail(ctx context.Context, stage *types.Stage) error {
+	stages, err := s.stageStore.ListWithSteps(ctx, stage.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to list stages of execution: %w", err)
+	}
+
+	for _, st := range stages {
+		if st.ID == stage.ID {
+			stage = st
+			break
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	for _, step := range stage.Steps {
+		switch step.Status {
+		case enum.CIStatusRunning:
+			step.Status = enum.CIStatusError
+			step.Error = errRunnerLost
+			step.Stopped = now
+		case enum.CIStatusPending:
+			step.Status = enum.CIStatusSkipped
+			step.Stopped = now
+		default:
+		}
+	}
+
+	stage.Status = enum.CIStatusError
+	stage.Error = errRunnerLost
+	stage.Stopped = now
+
+	return s.executionManager.AfterStage(ctx, stage)
+}
+
+// SpaceMatcher returns a function that matches the stages of the repositories in the space or its subspaces.
+func SpaceMatcher(ctx context.Context, repoStore store.RepoStore, space *types.Space) func(*types.Stage) bool {
+	matches := map[int64]bool{}
+	prefix := strings.ToLower(space.Path) + types.PathSeparator
+
+	return func(stage *types.Stage) bool {
+		if match, ok := matches[stage.RepoID]; ok {
+			return match
+		}
+
+		repo, err := repoStore.Find(ctx, stage.RepoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo.id", stage.RepoID).Msg("failed to find repo of stage")
+			return false
+		}
+
+		match := strings.HasPrefix(strings.ToLower(repo.Path), prefix)
+		matches[stage.RepoID] = match
+
+		return match
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MethodAccess is the audit log method recorded when a secret value is accessed internally.
+const MethodAccess = "ACCESS"
+
+// Accessor describes who is accessing secret values and why.
+// It's recorded in the audit log for every secret that gets accessed.
+type Accessor struct {
+	// RequestID correlates the access, e.g. with the pipeline execution or the webhook trigger.
+	RequestID   string
+	PrincipalID *int64
+	// Component is the name of the system component that accesses the secrets (e.g. "pipeline").
+	Component string
+}
+
+// Resolver provides the decrypted values of space and repository secrets to other system components.
+// Secret values are never returned through the API, only internal consumers can read them.
+type Resolver struct {
+	secretStore   store.SecretStore
+	auditLogStore store.AuditLogStore
+	encrypter     encrypt.Encrypter
+	auditEnabled  bool
+}
+
+func NewResolver(
+	secretStore store.SecretStore,
+	auditLogStore store.AuditLogStore,
+	encrypter encrypt.Encrypter,
+	auditEnabled bool,
+) *Resolver {
+	return &Resolver{
+		secretStore:   secretStore,
+		auditLogStore: auditLogStore,
+		encrypter:     encrypter,
+		auditEnabled:  auditEnabled,
+	}
+}
+
+// ListForSpace returns all decrypted secrets of the space.
+func (r *Resolver) ListForSpace(
+	ctx context.Context,
+	spaceID int64,
+	accessor Accessor,
+) ([]*types.Secret, error) {
+	secrets, err := r.secretStore.ListAll(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space secrets: %w", err)
+	}
+
+	return r.decryptAll(ctx, secrets, accessor)
+}
+
+// ListForRepo returns all decrypted secrets available to the repository:
+// The secrets of the parent space and the secrets of the repository itself.
+// In case of identical identifiers the repository secret takes precedence.
+func (r *Resolver) ListForRepo(
+	ctx context.Context,
+	repo *types.Repository,
+	accessor Accessor,
+) ([]*types.Secret, error) {
+	spaceSecrets, err := r.secretStore.ListAll(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space secrets: %w", err)
+	}
+
+	repoSecrets, err := r.secretStore.ListAllForRepo(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository secrets: %w", err)
+	}
+
+	overridden := make(map[string]struct{}, len(repoSecrets))
+	for _, s := range repoSecrets {
+		overridden[s.Identifier] = struct{}{}
+	}
+
+	secrets := make([]*types.Secret, 0, len(spaceSecrets)+len(repoSecrets))
+	for _, s := range spaceSecrets {
+		if _, ok := overridden[s.Identifier]; !ok {
+			secrets = append(secrets, s)
+		}
+	}
+	secrets = append(secrets, repoSecrets...)
+
+	return r.decryptAll(ctx, secrets, accessor)
+}
+
+// Find returns the decrypted secret with the provided identifier.
+// If repoID is not zero, the repository secret is looked up first, before the space secret.
+// It returns store.ErrResourceNotFound if the secret doesn't exist.
+func (r *Resolver) Find(
+	ctx context.Context,
+	spaceID int64,
+	repoID int64,
+	identifier string,
+	accessor Accessor,
+) (*types.Secret, error) {
+	var secret *types.Secret
+	var err error
+
+	if repoID != 0 {
+		secret, err = r.secretStore.FindByRepoIdentifier(ctx, repoID, identifier)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find repository secret: %w", err)
+		}
+	}
+
+	if secret == nil {
+		secret, err = r.secretStore.FindByIdentifier(ctx, spaceID, identifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space secret: %w", err)
+		}
+	}
+
+	return r.decrypt(ctx, secret, accessor)
+}
+
+func (r *Resolver) decryptAll(
+	ctx context.Context,
+	secrets []*types.Secret,
+	accessor Accessor,
+) ([]*types.Secret, error) {
+	result := make([]*types.Secret, len(secrets))
+	for i, s := range secrets {
+		decrypted, err := r.decrypt(ctx, s, accessor)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = decrypted
+	}
+
+	return result, nil
+}
+
+func (r *Resolver) decrypt(
+	ctx context.Context,
+	secret *types.Secret,
+	accessor Accessor,
+) (*types.Secret, error) {
+	plaintext, err := r.encrypter.Decrypt([]byte(secret.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret %q: %w", secret.Identifier, err)
+	}
+
+	s := *secret
+	s.Data = plaintext
+
+	r.recordAccess(ctx, secret, accessor)
+
+	return &s, nil
+}
+
+// recordAccess records the access of a secret in the audit log.
+// Failures are only logged because they must not break the consumer of the secret.
+func (r *Resolver) recordAccess(ctx context.Context, secret *types.Secret, accessor Accessor) {
+	if !r.auditEnabled {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("space_id", fmt.Sprint(secret.SpaceID))
+	if secret.RepoID != nil {
+		query.Set("repo_id", fmt.Sprint(*secret.RepoID))
+	}
+
+	err := r.auditLogStore.Create(ctx, &types.AuditLog{
+		RequestID:   accessor.RequestID,
+		PrincipalID: accessor.PrincipalID,
+		Method:      MethodAccess,
+		Path:        "/secrets/" + url.PathEscape(secret.Identifier),
+		Query:       query.Encode(),
+		UserAgent:   accessor.Component,
+		Status:      http.StatusOK,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("secret", secret.Identifier).
+			Str("component", accessor.Component).
+			Msg("failed to record secret access in the audit log")
+	}
+}
@@ -0,0 +1,776 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Service provides typed access to the settings stored in the settings store.
+type Service struct {
+	settingsStore store.SettingsStore
+	spaceStore    store.SpaceStore
+}
+
+func NewService(settingsStore store.SettingsStore, spaceStore store.SpaceStore) *Service {
+	return &Service{
+		settingsStore: settingsStore,
+		spaceStore:    spaceStore,
+	}
+}
+
+// NotificationSettings returns the notification settings of the principal.
+// The default notification settings are returned in case the principal didn't configure any.
+func (s *Service) NotificationSettings(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationSettings, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopePrincipal, principalID, types.SettingsKeyNotifications)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return types.DefaultNotificationSettings(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	return decodeNotificationSettings(value)
+}
+
+// SetNotificationSettings stores the notification settings of the principal.
+func (s *Service) SetNotificationSettings(
+	ctx context.Context,
+	principalID int64,
+	settings *types.NotificationSettings,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification settings: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopePrincipal, principalID, types.SettingsKeyNotifications,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store notification settings: %w", err)
+	}
+
+	return nil
+}
+
+// NotificationLevels returns the notification levels of the provided principals, mapped by principal id.
+// The default notification level is used for principals that didn't configure any.
+func (s *Service) NotificationLevels(
+	ctx context.Context,
+	principalIDs []int64,
+) (map[int64]enum.NotificationLevel, error) {
+	values, err := s.settingsStore.FindMany(ctx, enum.SettingsScopePrincipal, principalIDs,
+		types.SettingsKeyNotifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	levels := make(map[int64]enum.NotificationLevel, len(principalIDs))
+	for _, principalID := range principalIDs {
+		settings := types.DefaultNotificationSettings()
+		if value, ok := values[principalID]; ok {
+			settings, err = decodeNotificationSettings(value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		levels[principalID] = settings.Level
+	}
+
+	return levels, nil
+}
+
+// IssueTrackerSettings returns the issue tracker settings configured for the space.
+// Nil is returned in case the space doesn't configure any.
+func (s *Service) IssueTrackerSettings(
+	ctx context.Context,
+	spaceID int64,
+) (*types.IssueTrackerSettings, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyIssueTracker)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue tracker settings: %w", err)
+	}
+
+	settings := &types.IssueTrackerSettings{}
+	if err = json.Unmarshal(value, settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal issue tracker settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetIssueTrackerSettings stores the issue tracker settings of the space.
+func (s *Service) SetIssueTrackerSettings(
+	ctx context.Context,
+	spaceID int64,
+	settings *types.IssueTrackerSettings,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal issue tracker settings: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyIssueTracker,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store issue tracker settings: %w", err)
+	}
+
+	return nil
+}
+
+// EffectiveIssueTrackerSettings returns the issue tracker settings that apply to the space
+// together with the space they are defined on. Nil is returned in case no settings apply.
+func (s *Service) EffectiveIssueTrackerSettings(
+	ctx context.Context,
+	spaceID int64,
+) (*types.IssueTrackerSettings, *types.Space, error) {
+	chain, err := s.InheritanceChain(ctx, spaceID, enum.InheritedSettingIssueTracker)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, space := range chain {
+		settings, err := s.IssueTrackerSettings(ctx, space.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if settings != nil {
+			return settings, space, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// RepoTemplate returns the template for new repositories configured for the space.
+// Nil is returned in case the space doesn't configure any.
+func (s *Service) RepoTemplate(
+	ctx context.Context,
+	spaceID int64,
+) (*types.RepoTemplate, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyRepoTemplate)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo template: %w", err)
+	}
+
+	template := &types.RepoTemplate{}
+	if err = json.Unmarshal(value, template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo template: %w", err)
+	}
+
+	return template, nil
+}
+
+// SetRepoTemplate stores the template for new repositories of the space.
+func (s *Service) SetRepoTemplate(
+	ctx context.Context,
+	spaceID int64,
+	template *types.RepoTemplate,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal repo template: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyRepoTemplate,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store repo template: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRepoTemplate removes the template for new repositories of the space.
+func (s *Service) DeleteRepoTemplate(ctx context.Context, spaceID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyRepoTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to delete repo template: %w", err)
+	}
+
+	return nil
+}
+
+// EffectiveRepoTemplate returns the template that applies to new repositories of the space
+// together with the space it is defined on. Nil is returned in case no template applies.
+func (s *Service) EffectiveRepoTemplate(
+	ctx context.Context,
+	spaceID int64,
+) (*types.RepoTemplate, *types.Space, error) {
+	chain, err := s.InheritanceChain(ctx, spaceID, enum.InheritedSettingRepoTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, space := range chain {
+		template, err := s.RepoTemplate(ctx, space.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if template != nil {
+			return template, space, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// RepoArchivalPolicy returns the automatic archival policy configured for the space.
+// Nil is returned in case the space doesn't configure any.
+func (s *Service) RepoArchivalPolicy(
+	ctx context.Context,
+	spaceID int64,
+) (*types.RepoArchivalPolicy, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyRepoArchival)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo archival policy: %w", err)
+	}
+
+	return decodeRepoArchivalPolicy(value)
+}
+
+// RepoArchivalPolicies returns the automatic archival policies of all spaces, mapped by space id.
+func (s *Service) RepoArchivalPolicies(ctx context.Context) (map[int64]*types.RepoArchivalPolicy, error) {
+	values, err := s.settingsStore.FindAll(ctx, enum.SettingsScopeSpace, types.SettingsKeyRepoArchival)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo archival policies: %w", err)
+	}
+
+	policies := make(map[int64]*types.RepoArchivalPolicy, len(values))
+	for spaceID, value := range values {
+		policy, err := decodeRepoArchivalPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+
+		policies[spaceID] = policy
+	}
+
+	return policies, nil
+}
+
+// SetRepoArchivalPolicy stores the automatic archival policy of the space.
+func (s *Service) SetRepoArchivalPolicy(
+	ctx context.Context,
+	spaceID int64,
+	policy *types.RepoArchivalPolicy,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal repo archival policy: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyRepoArchival,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store repo archival policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRepoArchivalPolicy removes the automatic archival policy of the space.
+func (s *Service) DeleteRepoArchivalPolicy(ctx context.Context, spaceID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyRepoArchival)
+	if err != nil {
+		return fmt.Errorf("failed to delete repo archival policy: %w", err)
+	}
+
+	return nil
+}
+
+// ReviewReminderPolicy returns the review reminder policy configured for the space.
+// Nil is returned in case the space doesn't configure any.
+func (s *Service) ReviewReminderPolicy(
+	ctx context.Context,
+	spaceID int64,
+) (*types.ReviewReminderPolicy, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyReviewReminders)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review reminder policy: %w", err)
+	}
+
+	return decodeReviewReminderPolicy(value)
+}
+
+// ReviewReminderPolicies returns the review reminder policies of all spaces, mapped by space id.
+func (s *Service) ReviewReminderPolicies(ctx context.Context) (map[int64]*types.ReviewReminderPolicy, error) {
+	values, err := s.settingsStore.FindAll(ctx, enum.SettingsScopeSpace, types.SettingsKeyReviewReminders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review reminder policies: %w", err)
+	}
+
+	policies := make(map[int64]*types.ReviewReminderPolicy, len(values))
+	for spaceID, value := range values {
+		policy, err := decodeReviewReminderPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+
+		policies[spaceID] = policy
+	}
+
+	return policies, nil
+}
+
+// SetReviewReminderPolicy stores the review reminder policy of the space.
+func (s *Service) SetReviewReminderPolicy(
+	ctx context.Context,
+	spaceID int64,
+	policy *types.ReviewReminderPolicy,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review reminder policy: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyReviewReminders,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store review reminder policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReviewReminderPolicy removes the review reminder policy of the space.
+func (s *Service) DeleteReviewReminderPolicy(ctx context.Context, spaceID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyReviewReminders)
+	if err != nil {
+		return fmt.Errorf("failed to delete review reminder policy: %w", err)
+	}
+
+	return nil
+}
+
+// ReviewerAssignmentSettings returns the automatic reviewer assignment settings configured for the repository.
+// Nil is returned in case the repository doesn't configure any.
+func (s *Service) ReviewerAssignmentSettings(
+	ctx context.Context,
+	repoID int64,
+) (*types.ReviewerAssignmentSettings, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyReviewerAssignment)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviewer assignment settings: %w", err)
+	}
+
+	settings := &types.ReviewerAssignmentSettings{}
+	if err = json.Unmarshal(value, settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reviewer assignment settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetReviewerAssignmentSettings stores the automatic reviewer assignment settings of the repository.
+func (s *Service) SetReviewerAssignmentSettings(
+	ctx context.Context,
+	repoID int64,
+	settings *types.ReviewerAssignmentSettings,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviewer assignment settings: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyReviewerAssignment,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store reviewer assignment settings: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReviewerAssignmentSettings removes the automatic reviewer assignment settings of the repository.
+func (s *Service) DeleteReviewerAssignmentSettings(ctx context.Context, repoID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyReviewerAssignment)
+	if err != nil {
+		return fmt.Errorf("failed to delete reviewer assignment settings: %w", err)
+	}
+
+	return nil
+}
+
+// PullReqLintPolicy returns the pull request lint policy configured for the repository.
+// Nil is returned in case the repository doesn't configure any.
+func (s *Service) PullReqLintPolicy(
+	ctx context.Context,
+	repoID int64,
+) (*types.PullReqLintPolicy, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyPullReqLint)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request lint policy: %w", err)
+	}
+
+	policy := &types.PullReqLintPolicy{}
+	if err = json.Unmarshal(value, policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pull request lint policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// SetPullReqLintPolicy stores the pull request lint policy of the repository.
+func (s *Service) SetPullReqLintPolicy(
+	ctx context.Context,
+	repoID int64,
+	policy *types.PullReqLintPolicy,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pull request lint policy: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyPullReqLint,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store pull request lint policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePullReqLintPolicy removes the pull request lint policy of the repository.
+func (s *Service) DeletePullReqLintPolicy(ctx context.Context, repoID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyPullReqLint)
+	if err != nil {
+		return fmt.Errorf("failed to delete pull request lint policy: %w", err)
+	}
+
+	return nil
+}
+
+// MergeMessageTemplates returns the merge commit message templates configured for the repository.
+// Nil is returned in case the repository doesn't configure any.
+func (s *Service) MergeMessageTemplates(
+	ctx context.Context,
+	repoID int64,
+) (*types.MergeMessageTemplates, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyMergeMessageTemplates)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge message templates: %w", err)
+	}
+
+	templates := &types.MergeMessageTemplates{}
+	if err = json.Unmarshal(value, templates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merge message templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// SetMergeMessageTemplates stores the merge commit message templates of the repository.
+func (s *Service) SetMergeMessageTemplates(
+	ctx context.Context,
+	repoID int64,
+	templates *types.MergeMessageTemplates,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(templates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal merge message templates: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyMergeMessageTemplates,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store merge message templates: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMergeMessageTemplates removes the merge commit message templates of the repository.
+func (s *Service) DeleteMergeMessageTemplates(ctx context.Context, repoID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopeRepo, repoID, types.SettingsKeyMergeMessageTemplates)
+	if err != nil {
+		return fmt.Errorf("failed to delete merge message templates: %w", err)
+	}
+
+	return nil
+}
+
+// BreakGlass returns the break-glass access of the principal.
+// Nil is returned in case the principal never activated it (it might be expired though).
+func (s *Service) BreakGlass(
+	ctx context.Context,
+	principalID int64,
+) (*types.BreakGlass, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopePrincipal, principalID, types.SettingsKeyBreakGlass)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find break-glass access: %w", err)
+	}
+
+	breakGlass := &types.BreakGlass{}
+	if err = json.Unmarshal(value, breakGlass); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal break-glass access: %w", err)
+	}
+
+	return breakGlass, nil
+}
+
+// SetBreakGlass stores the break-glass access of the principal.
+func (s *Service) SetBreakGlass(
+	ctx context.Context,
+	principalID int64,
+	breakGlass *types.BreakGlass,
+) error {
+	value, err := json.Marshal(breakGlass)
+	if err != nil {
+		return fmt.Errorf("failed to marshal break-glass access: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopePrincipal, principalID, types.SettingsKeyBreakGlass,
+		value, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to store break-glass access: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBreakGlass removes the break-glass access of the principal.
+func (s *Service) DeleteBreakGlass(ctx context.Context, principalID int64) error {
+	err := s.settingsStore.Delete(ctx, enum.SettingsScopePrincipal, principalID, types.SettingsKeyBreakGlass)
+	if err != nil {
+		return fmt.Errorf("failed to delete break-glass access: %w", err)
+	}
+
+	return nil
+}
+
+// SpaceInheritance returns the inheritance flags of the space.
+// The default flags (everything is inherited) are returned in case the space didn't configure any.
+func (s *Service) SpaceInheritance(
+	ctx context.Context,
+	spaceID int64,
+) (*types.SpaceInheritance, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyInheritance)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return types.DefaultSpaceInheritance(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find inheritance settings: %w", err)
+	}
+
+	inheritance := types.DefaultSpaceInheritance()
+	if err = json.Unmarshal(value, inheritance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal inheritance settings: %w", err)
+	}
+
+	return inheritance, nil
+}
+
+// SetSpaceInheritance stores the inheritance flags of the space.
+func (s *Service) SetSpaceInheritance(
+	ctx context.Context,
+	spaceID int64,
+	inheritance *types.SpaceInheritance,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(inheritance)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inheritance settings: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSpace, spaceID, types.SettingsKeyInheritance,
+		value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store inheritance settings: %w", err)
+	}
+
+	return nil
+}
+
+// InheritanceChain returns the spaces whose values of the setting apply to the space, starting with the space itself.
+// The chain ends with the root space or with the first space that doesn't inherit the setting from its parent.
+func (s *Service) InheritanceChain(
+	ctx context.Context,
+	spaceID int64,
+	setting enum.InheritedSetting,
+) ([]*types.Space, error) {
+	var chain []*types.Space
+	for spaceID > 0 {
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		chain = append(chain, space)
+
+		inheritance, err := s.SpaceInheritance(ctx, space.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !inheritance.Inherits(setting) {
+			break
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return chain, nil
+}
+
+// MaintenanceSettings returns the maintenance mode of the system.
+// The maintenance mode is disabled in case it was never configured.
+func (s *Service) MaintenanceSettings(ctx context.Context) (*types.MaintenanceSettings, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSystem, 0, types.SettingsKeyMaintenance)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.MaintenanceSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find maintenance settings: %w", err)
+	}
+
+	settings := &types.MaintenanceSettings{}
+	if err = json.Unmarshal(value, settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal maintenance settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetMaintenanceSettings stores the maintenance mode of the system.
+func (s *Service) SetMaintenanceSettings(
+	ctx context.Context,
+	settings *types.MaintenanceSettings,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance settings: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSystem, 0, types.SettingsKeyMaintenance, value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store maintenance settings: %w", err)
+	}
+
+	return nil
+}
+
+// InstanceSettings returns the instance settings stored at runtime.
+// Empty settings (everything falls back to the config) are returned in case none were stored.
+func (s *Service) InstanceSettings(ctx context.Context) (*types.InstanceSettings, error) {
+	value, err := s.settingsStore.Find(ctx, enum.SettingsScopeSystem, 0, types.SettingsKeyInstance)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.InstanceSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find instance settings: %w", err)
+	}
+
+	settings := &types.InstanceSettings{}
+	if err = json.Unmarshal(value, settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instance settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetInstanceSettings stores the instance settings.
+func (s *Service) SetInstanceSettings(
+	ctx context.Context,
+	settings *types.InstanceSettings,
+	updatedBy int64,
+) error {
+	value, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal instance settings: %w", err)
+	}
+
+	err = s.settingsStore.Upsert(ctx, enum.SettingsScopeSystem, 0, types.SettingsKeyInstance, value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to store instance settings: %w", err)
+	}
+
+	return nil
+}
+
+func decodeNotificationSettings(value json.RawMessage) (*types.NotificationSettings, error) {
+	settings := types.DefaultNotificationSettings()
+	if err := json.Unmarshal(value, settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification settings: %w", err)
+	}
+
+	// fall back to the default level in case the stored level isn't known (anymore).
+	settings.Level, _ = settings.Level.Sanitize()
+
+	return settings, nil
+}
+
+func decodeRepoArchivalPolicy(value json.RawMessage) (*types.RepoArchivalPolicy, error) {
+	policy := &types.RepoArchivalPolicy{}
+	if err := json.Unmarshal(value, policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo archival policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func decodeReviewReminderPolicy(value json.RawMessage) (*types.ReviewReminderPolicy, error) {
+	policy := &types.ReviewReminderPolicy{}
+	if err := json.Unmarshal(value, policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal review reminder policy: %w", err)
+	}
+
+	return policy, nil
+}
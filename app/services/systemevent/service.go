@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemevent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:systemevent"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service is responsible for recording system activity in the admin event feed
+// and for streaming it to connected admin dashboards.
+type Service struct {
+	systemEventStore store.SystemEventStore
+	sseStreamer      sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	systemReaderFactory *events.ReaderFactory[*systemevents.Reader],
+	systemEventStore store.SystemEventStore,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided system event service config is invalid: %w", err)
+	}
+	service := &Service{
+		systemEventStore: systemEventStore,
+		sseStreamer:      sseStreamer,
+	}
+
+	_, err := systemReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *systemevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterActivity(service.handleEventActivity)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch system event reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// handleEventActivity stores the reported system activity and publishes it to the admin event stream.
+func (s *Service) handleEventActivity(
+	ctx context.Context,
+	event *events.Event[*systemevents.ActivityPayload],
+) error {
+	payload := event.Payload
+
+	if _, ok := payload.Type.Sanitize(); !ok {
+		log.Ctx(ctx).Warn().Msgf("ignoring system activity event of unknown type %q", payload.Type)
+		return nil
+	}
+
+	systemEvent := &types.SystemEvent{
+		Type:         payload.Type,
+		PrincipalID:  payload.PrincipalID,
+		ResourceID:   payload.ResourceID,
+		ResourcePath: payload.ResourcePath,
+		Message:      payload.Message,
+		Created:      payload.Created,
+	}
+
+	if err := s.systemEventStore.Create(ctx, systemEvent); err != nil {
+		return fmt.Errorf("failed to store system event: %w", err)
+	}
+
+	if err := s.sseStreamer.PublishSystem(ctx, enum.SSETypeSystemEvent, systemEvent); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish system event")
+	}
+
+	return nil
+}
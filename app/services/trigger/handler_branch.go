@@ -23,6 +23,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/events"
+	githook "github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -33,6 +34,10 @@ func ExtractBranch(ref string) string {
 
 func (s *Service) handleEventBranchCreated(ctx context.Context,
 	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	if githook.HasPushOption(event.Payload.PushOptions, githook.PushOptionSkipCI) {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionBranchCreated,
@@ -51,6 +56,10 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 
 func (s *Service) handleEventBranchUpdated(ctx context.Context,
 	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	if githook.HasPushOption(event.Payload.PushOptions, githook.PushOptionSkipCI) {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionBranchUpdated,
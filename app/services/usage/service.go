@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "usage-aggregator"
+
+const day = 24 * time.Hour
+
+// Service records the usage of repositories and aggregates it periodically into daily usage data,
+// which is used for usage reporting (e.g. chargeback and capacity planning).
+type Service struct {
+	enabled    bool
+	cron       string
+	maxDur     time.Duration
+	retention  time.Duration
+	usageStore store.UsageMetricStore
+	repoStore  store.RepoStore
+	scheduler  *job.Scheduler
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for usage aggregator: %w", err)
+	}
+
+	return nil
+}
+
+// Handle aggregates the usage of the current and the previous day (to complete it)
+// and deletes the usage data that is older than the retention period.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	now := time.Now()
+	today := startOfDay(now)
+
+	for _, date := range []int64{today - day.Milliseconds(), today} {
+		if err := s.usageStore.Aggregate(ctx, date); err != nil {
+			return "", fmt.Errorf("failed to aggregate usage of %s: %w",
+				time.UnixMilli(date).UTC().Format(time.DateOnly), err)
+		}
+	}
+
+	n, err := s.usageStore.DeleteBefore(ctx, startOfDay(now.Add(-s.retention)))
+	if err != nil {
+		return "", fmt.Errorf("failed to delete expired usage data: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Int64("deleted", n).Msg("aggregated usage data")
+
+	return "", nil
+}
+
+// RecordGitTransfer adds a git transfer of a principal to the usage of the repository.
+func (s *Service) RecordGitTransfer(ctx context.Context, repoID, principalID, bytesIn, bytesOut int64) error {
+	if !s.enabled {
+		return nil
+	}
+
+	date := startOfDay(time.Now())
+
+	if err := s.usageStore.AddGitTraffic(ctx, repoID, date, bytesIn, bytesOut); err != nil {
+		return fmt.Errorf("failed to record git traffic: %w", err)
+	}
+
+	if principalID <= 0 {
+		return nil
+	}
+
+	if err := s.usageStore.AddActiveUser(ctx, repoID, date, principalID); err != nil {
+		return fmt.Errorf("failed to record active user: %w", err)
+	}
+
+	return nil
+}
+
+// Report returns the usage report of the space (and all its subspaces) during the time window.
+// A space id of zero returns the usage report of the whole instance.
+func (s *Service) Report(ctx context.Context, spaceID int64, window enum.UsageWindow) (*types.UsageReport, error) {
+	to := startOfDay(time.Now()) + day.Milliseconds()
+	from := to - window.Duration().Milliseconds()
+
+	repoCount, err := s.repoStore.Count(ctx, spaceID, &types.RepoFilter{Recursive: spaceID > 0})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count repositories: %w", err)
+	}
+
+	diskUsage, err := s.usageStore.SumDiskUsage(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+
+	activeUsers, err := s.usageStore.CountActiveUsers(ctx, spaceID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active users: %w", err)
+	}
+
+	daily, err := s.usageStore.ListDaily(ctx, spaceID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily usage: %w", err)
+	}
+
+	report := &types.UsageReport{
+		Window:      window,
+		From:        from,
+		To:          to,
+		RepoCount:   repoCount,
+		DiskUsage:   diskUsage,
+		ActiveUsers: activeUsers,
+		Daily:       daily,
+	}
+
+	for _, stats := range daily {
+		report.PullReqsCreated += stats.PullReqsCreated
+		report.PullReqsMerged += stats.PullReqsMerged
+		report.GitBytesIn += stats.GitBytesIn
+		report.GitBytesOut += stats.GitBytesOut
+	}
+
+	return report, nil
+}
+
+// startOfDay returns the start of the (UTC) day of the time in unix milliseconds.
+func startOfDay(t time.Time) int64 {
+	return t.UTC().Truncate(day).UnixMilli()
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// osvMaxBatchSize is the maximum number of queries the OSV API accepts in a single batch request.
+const osvMaxBatchSize = 1000
+
+// osvClient queries the OSV vulnerability database, see https://google.github.io/osv.dev/api/.
+type osvClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type osvPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type osvQuery struct {
+	Package osvPackage `json:"package"`
+	Version string     `json:"version"`
+}
+
+type osvSeverity struct {
+	Severity string `json:"severity"`
+}
+
+type osvVulnerability struct {
+	ID               string      `json:"id"`
+	Summary          string      `json:"summary"`
+	Details          string      `json:"details"`
+	DatabaseSpecific osvSeverity `json:"database_specific"`
+	Affected         []struct {
+		Package osvPackage `json:"package"`
+		Ranges  []struct {
+			Events []struct {
+				Introduced string `json:"introduced"`
+				Fixed      string `json:"fixed"`
+			} `json:"events"`
+		} `json:"ranges"`
+		DatabaseSpecific osvSeverity `json:"database_specific"`
+	} `json:"affected"`
+}
+
+// queryBatch returns the ids of the vulnerabilities affecting each of the queried package versions.
+func (c *osvClient) queryBatch(ctx context.Context, queries []osvQuery) ([][]string, error) {
+	result := make([][]string, 0, len(queries))
+
+	for start := 0; start < len(queries); start += osvMaxBatchSize {
+		end := start + osvMaxBatchSize
+		if end > len(queries) {
+			end = len(queries)
+		}
+
+		var out struct {
+			Results []struct {
+				Vulns []struct {
+					ID string `json:"id"`
+				} `json:"vulns"`
+			} `json:"results"`
+		}
+
+		err := c.do(ctx, http.MethodPost, "/v1/querybatch", map[string]any{"queries": queries[start:end]}, &out)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(out.Results) != end-start {
+			return nil, fmt.Errorf("osv returned %d results for %d queries", len(out.Results), end-start)
+		}
+
+		for _, r := range out.Results {
+			ids := make([]string, len(r.Vulns))
+			for i, vuln := range r.Vulns {
+				ids[i] = vuln.ID
+			}
+			result = append(result, ids)
+		}
+	}
+
+	return result, nil
+}
+
+// getVulnerability returns the details of the vulnerability with the provided id.
+func (c *osvClient) getVulnerability(ctx context.Context, id string) (*osvVulnerability, error) {
+	vuln := &osvVulnerability{}
+	if err := c.do(ctx, http.MethodGet, "/v1/vulns/"+url.PathEscape(id), nil, vuln); err != nil {
+		return nil, err
+	}
+
+	return vuln, nil
+}
+
+func (c *osvClient) do(ctx context.Context, method, path string, in any, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal osv request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.baseURL, "/")+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create osv request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send osv request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("osv request %s %s failed with status %d", method, path, resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode osv response: %w", err)
+	}
+
+	return nil
+}
+
+// severity returns the severity of the vulnerability as rated by the database that published it.
+// Only the qualitative ratings (e.g. of the GitHub advisory database) are used, CVSS vectors aren't evaluated.
+func (v *osvVulnerability) severity() enum.VulnerabilitySeverity {
+	ratings := []string{v.DatabaseSpecific.Severity}
+	for _, affected := range v.Affected {
+		ratings = append(ratings, affected.DatabaseSpecific.Severity)
+	}
+
+	for _, rating := range ratings {
+		switch strings.ToUpper(rating) {
+		case "CRITICAL":
+			return enum.VulnerabilitySeverityCritical
+		case "HIGH":
+			return enum.VulnerabilitySeverityHigh
+		case "MODERATE", "MEDIUM":
+			return enum.VulnerabilitySeverityMedium
+		case "LOW":
+			return enum.VulnerabilitySeverityLow
+		}
+	}
+
+	return enum.VulnerabilitySeverityUnknown
+}
+
+// fixedVersion returns the first version of the package that fixes the vulnerability, or empty if there's none.
+func (v *osvVulnerability) fixedVersion(pkg osvPackage) string {
+	for _, affected := range v.Affected {
+		if affected.Package.Ecosystem != pkg.Ecosystem || !strings.EqualFold(affected.Package.Name, pkg.Name) {
+			continue
+		}
+
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed != "" {
+					return event.Fixed
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// maxSummaryLength is the maximum length of the vulnerability summary stored with an alert.
+const maxSummaryLength = 1024
+
+// description returns a short description of the vulnerability.
+func (v *osvVulnerability) description() string {
+	summary := v.Summary
+	if summary == "" {
+		summary, _, _ = strings.Cut(strings.TrimSpace(v.Details), "\n")
+	}
+
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength]
+	}
+
+	return summary
+}
+
+var (
+	regexpGoVersion   = regexp.MustCompile(`^v(\d+\.\d+\.\d+[0-9A-Za-z.+-]*)$`)
+	regexpNPMVersion  = regexp.MustCompile(`^[=v]*(\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?)$`)
+	regexpPyPIVersion = regexp.MustCompile(`^\d[0-9A-Za-z.!+_-]*$`)
+)
+
+// osvQueryFor returns the OSV query for the dependency.
+// It returns false if the dependency doesn't declare an exact version, as OSV can only match exact versions.
+func osvQueryFor(dependency types.Dependency) (osvQuery, bool) {
+	var ecosystem, version string
+
+	switch dependency.Ecosystem {
+	case enum.DependencyEcosystemGo:
+		match := regexpGoVersion.FindStringSubmatch(dependency.Version)
+		if match == nil {
+			return osvQuery{}, false
+		}
+		ecosystem, version = "Go", strings.TrimSuffix(match[1], "+incompatible")
+	case enum.DependencyEcosystemNPM:
+		match := regexpNPMVersion.FindStringSubmatch(dependency.Version)
+		if match == nil {
+			return osvQuery{}, false
+		}
+		ecosystem, version = "npm", match[1]
+	case enum.DependencyEcosystemPyPI:
+		if !regexpPyPIVersion.MatchString(dependency.Version) {
+			return osvQuery{}, false
+		}
+		ecosystem, version = "PyPI", dependency.Version
+	default:
+		return osvQuery{}, false
+	}
+
+	return osvQuery{
+		Package: osvPackage{Name: dependency.Name, Ecosystem: ecosystem},
+		Version: version,
+	}, true
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestOSVQueryFor(t *testing.T) {
+	tests := []struct {
+		dependency types.Dependency
+		version    string
+		ok         bool
+	}{
+		{
+			dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemGo, Version: "v1.2.3"},
+			version:    "1.2.3",
+			ok:         true,
+		},
+		{
+			dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemGo, Version: "v2.0.0+incompatible"},
+			version:    "2.0.0",
+			ok:         true,
+		},
+		{
+			dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemNPM, Version: "4.17.21"},
+			version:    "4.17.21",
+			ok:         true,
+		},
+		{dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemNPM, Version: "^4.17.21"}, ok: false},
+		{
+			dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemPyPI, Version: "2.31.0"},
+			version:    "2.31.0",
+			ok:         true,
+		},
+		{dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemPyPI, Version: ">=2.0"}, ok: false},
+		{dependency: types.Dependency{Ecosystem: enum.DependencyEcosystemPyPI, Version: ""}, ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.dependency.Ecosystem)+" "+test.dependency.Version, func(t *testing.T) {
+			query, ok := osvQueryFor(test.dependency)
+			if ok != test.ok || query.Version != test.version {
+				t.Errorf("expected (%q, %t), got (%q, %t)", test.version, test.ok, query.Version, ok)
+			}
+		})
+	}
+}
+
+func TestOSVClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/querybatch", func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Queries []osvQuery `json:"queries"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		results := make([]map[string]any, len(in.Queries))
+		for i, q := range in.Queries {
+			results[i] = map[string]any{}
+			if q.Package.Name == "lodash" {
+				results[i]["vulns"] = []map[string]string{{"id": "GHSA-1"}}
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+	})
+	mux.HandleFunc("/v1/vulns/GHSA-1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"id": "GHSA-1",
+			"summary": "Prototype pollution",
+			"database_specific": {"severity": "MODERATE"},
+			"affected": [{
+				"package": {"name": "lodash", "ecosystem": "npm"},
+				"ranges": [{"events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+			}]
+		}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &osvClient{baseURL: server.URL, httpClient: server.Client()}
+	ctx := context.Background()
+
+	lodash := osvPackage{Name: "lodash", Ecosystem: "npm"}
+
+	ids, err := client.queryBatch(ctx, []osvQuery{
+		{Package: osvPackage{Name: "react", Ecosystem: "npm"}, Version: "18.2.0"},
+		{Package: lodash, Version: "4.17.20"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := [][]string{{}, {"GHSA-1"}}; !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+
+	vuln, err := client.getVulnerability(ctx, "GHSA-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if vuln.severity() != enum.VulnerabilitySeverityMedium {
+		t.Errorf("expected severity medium, got %s", vuln.severity())
+	}
+	if vuln.fixedVersion(lodash) != "4.17.21" {
+		t.Errorf("expected fixed version 4.17.21, got %q", vuln.fixedVersion(lodash))
+	}
+	if vuln.description() != "Prototype pollution" {
+		t.Errorf("unexpected description %q", vuln.description())
+	}
+
+	if _, err = client.getVulnerability(ctx, "GHSA-2"); err == nil {
+		t.Error("expected error for unknown vulnerability")
+	}
+}
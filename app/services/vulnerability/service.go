@@ -0,0 +1,306 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeAlerts        = "gitness:vulnerability:alerts"
+	jobCronAlerts        = "23 */6 * * *" // At minute 23 past every 6th hour.
+	jobMaxDurationAlerts = 2 * time.Hour
+)
+
+// Service matches the detected dependencies of repositories against the OSV vulnerability database
+// and maintains a vulnerability alert for every known vulnerability that affects a repository.
+type Service struct {
+	enabled           bool
+	osv               *osvClient
+	scheduler         *job.Scheduler
+	executor          *job.Executor
+	dependenciesStore store.RepoDependenciesStore
+	alertStore        store.VulnerabilityAlertStore
+	repoReporter      *repoevents.Reporter
+}
+
+func NewService(
+	config *types.Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	dependenciesStore store.RepoDependenciesStore,
+	alertStore store.VulnerabilityAlertStore,
+	repoReporter *repoevents.Reporter,
+) *Service {
+	return &Service{
+		enabled: config.VulnerabilityAlerts.Enabled,
+		osv: &osvClient{
+			baseURL:    config.VulnerabilityAlerts.OSVURL,
+			httpClient: &http.Client{Timeout: config.VulnerabilityAlerts.Timeout},
+		},
+		scheduler:         scheduler,
+		executor:          executor,
+		dependenciesStore: dependenciesStore,
+		alertStore:        alertStore,
+		repoReporter:      repoReporter,
+	}
+}
+
+// Register registers the vulnerability alerts job. The job is only scheduled if vulnerability alerts are enabled.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeAlerts, &alertsJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for vulnerability alerts: %w", err)
+	}
+
+	if !s.enabled {
+		return nil
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeAlerts,
+		jobTypeAlerts,
+		jobCronAlerts,
+		jobMaxDurationAlerts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule vulnerability alerts job: %w", err)
+	}
+
+	return nil
+}
+
+type alertsJob struct {
+	service *Service
+}
+
+// Handle matches the dependencies of all repositories against the vulnerability database.
+func (j *alertsJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !j.service.enabled {
+		return "vulnerability alerts are disabled", nil
+	}
+
+	repoIDs, err := j.service.dependenciesStore.ListRepoIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories with dependencies: %w", err)
+	}
+
+	// vulnerabilities caches the details of vulnerabilities, they are often shared by many repositories.
+	vulnerabilities := map[string]*osvVulnerability{}
+
+	created := 0
+	for _, repoID := range repoIDs {
+		n, err := j.service.checkRepo(ctx, repoID, vulnerabilities)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to check dependencies of repository %d", repoID)
+			continue
+		}
+
+		created += n
+	}
+
+	result := fmt.Sprintf("checked %d repositories, created %d vulnerability alerts", len(repoIDs), created)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// match is a known vulnerability that affects a dependency.
+type match struct {
+	dependency    types.Dependency
+	query         osvQuery
+	vulnerability *osvVulnerability
+}
+
+// checkRepo matches the dependencies of the repository against the vulnerability database,
+// synchronizes the vulnerability alerts of the repository and returns the number of new alerts.
+func (s *Service) checkRepo(
+	ctx context.Context,
+	repoID int64,
+	vulnerabilities map[string]*osvVulnerability,
+) (int, error) {
+	repoDependencies, err := s.dependenciesStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find dependencies: %w", err)
+	}
+
+	dependencies := make([]types.Dependency, 0, len(repoDependencies.Dependencies))
+	queries := make([]osvQuery, 0, len(repoDependencies.Dependencies))
+	for _, dependency := range repoDependencies.Dependencies {
+		query, ok := osvQueryFor(dependency)
+		if !ok {
+			continue
+		}
+
+		dependencies = append(dependencies, dependency)
+		queries = append(queries, query)
+	}
+
+	vulnerabilityIDs, err := s.osv.queryBatch(ctx, queries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query vulnerabilities: %w", err)
+	}
+
+	var matches []match
+	for i, ids := range vulnerabilityIDs {
+		for _, id := range ids {
+			vulnerability, ok := vulnerabilities[id]
+			if !ok {
+				vulnerability, err = s.osv.getVulnerability(ctx, id)
+				if err != nil {
+					return 0, fmt.Errorf("failed to get vulnerability %s: %w", id, err)
+				}
+
+				vulnerabilities[id] = vulnerability
+			}
+
+			matches = append(matches, match{
+				dependency:    dependencies[i],
+				query:         queries[i],
+				vulnerability: vulnerability,
+			})
+		}
+	}
+
+	return s.syncAlerts(ctx, repoID, matches)
+}
+
+type alertKey struct {
+	vulnerabilityID string
+	ecosystem       enum.DependencyEcosystem
+	pkg             string
+	version         string
+}
+
+// syncAlerts creates alerts for new matches, reopens resolved alerts that match again
+// and resolves the open alerts that don't match anymore. It returns the number of new (or reopened) alerts.
+func (s *Service) syncAlerts(ctx context.Context, repoID int64, matches []match) (int, error) {
+	alerts, err := s.alertStore.ListAll(ctx, repoID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list vulnerability alerts: %w", err)
+	}
+
+	existing := make(map[alertKey]*types.VulnerabilityAlert, len(alerts))
+	for _, alert := range alerts {
+		existing[alertKey{alert.VulnerabilityID, alert.Ecosystem, alert.Package, alert.Version}] = alert
+	}
+
+	now := time.Now().UnixMilli()
+	seen := make(map[alertKey]struct{}, len(matches))
+	var createdIDs []int64
+
+	for _, m := range matches {
+		key := alertKey{m.vulnerability.ID, m.dependency.Ecosystem, m.dependency.Name, m.dependency.Version}
+		if _, ok := seen[key]; ok {
+			// the same dependency is declared by multiple manifests.
+			continue
+		}
+		seen[key] = struct{}{}
+
+		severity := m.vulnerability.severity()
+		summary := m.vulnerability.description()
+		fixedVersion := m.vulnerability.fixedVersion(m.query.Package)
+
+		alert, ok := existing[key]
+		if !ok {
+			alert = &types.VulnerabilityAlert{
+				RepoID:          repoID,
+				VulnerabilityID: m.vulnerability.ID,
+				Ecosystem:       m.dependency.Ecosystem,
+				Package:         m.dependency.Name,
+				Version:         m.dependency.Version,
+				Manifest:        m.dependency.Manifest,
+				Severity:        severity,
+				Summary:         summary,
+				FixedVersion:    fixedVersion,
+				State:           enum.VulnerabilityAlertStateOpen,
+				Created:         now,
+				Updated:         now,
+			}
+
+			if err := s.alertStore.Create(ctx, alert); err != nil {
+				return 0, fmt.Errorf("failed to create vulnerability alert: %w", err)
+			}
+
+			createdIDs = append(createdIDs, alert.ID)
+			continue
+		}
+
+		reopened := alert.State == enum.VulnerabilityAlertStateResolved
+		if !reopened && alert.Severity == severity && alert.Summary == summary &&
+			alert.FixedVersion == fixedVersion && alert.Manifest == m.dependency.Manifest {
+			continue
+		}
+
+		alert.Manifest = m.dependency.Manifest
+		alert.Severity = severity
+		alert.Summary = summary
+		alert.FixedVersion = fixedVersion
+		alert.State = enum.VulnerabilityAlertStateOpen
+		alert.Resolved = nil
+		alert.Updated = now
+
+		if err := s.alertStore.Update(ctx, alert); err != nil {
+			return 0, fmt.Errorf("failed to update vulnerability alert: %w", err)
+		}
+
+		if reopened {
+			createdIDs = append(createdIDs, alert.ID)
+		}
+	}
+
+	for key, alert := range existing {
+		if _, ok := seen[key]; ok || alert.State != enum.VulnerabilityAlertStateOpen {
+			continue
+		}
+
+		alert.State = enum.VulnerabilityAlertStateResolved
+		alert.Resolved = &now
+		alert.Updated = now
+
+		if err := s.alertStore.Update(ctx, alert); err != nil {
+			return 0, fmt.Errorf("failed to resolve vulnerability alert: %w", err)
+		}
+	}
+
+	if len(createdIDs) > 0 {
+		s.repoReporter.VulnerabilityAlertsCreated(ctx, &repoevents.VulnerabilityAlertsCreatedPayload{
+			RepoID:      repoID,
+			PrincipalID: bootstrap.NewSystemServiceSession().Principal.ID,
+			AlertIDs:    createdIDs,
+		})
+	}
+
+	return len(createdIDs), nil
+}
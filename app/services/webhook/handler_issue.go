@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// IssuePayload describes the body of all issue triggers.
+type IssuePayload struct {
+	BaseSegment
+	IssueSegment
+}
+
+// handleEventIssueCreated handles created events for issues
+// and triggers issue created webhooks for the repo.
+func (s *Service) handleEventIssueCreated(ctx context.Context,
+	event *events.Event[*issueevents.CreatedPayload]) error {
+	return s.triggerForIssueEvent(ctx, enum.WebhookTriggerIssueCreated, event.ID, event.Payload.Base)
+}
+
+// handleEventIssueUpdated handles updated events for issues
+// and triggers issue updated webhooks for the repo.
+func (s *Service) handleEventIssueUpdated(ctx context.Context,
+	event *events.Event[*issueevents.UpdatedPayload]) error {
+	return s.triggerForIssueEvent(ctx, enum.WebhookTriggerIssueUpdated, event.ID, event.Payload.Base)
+}
+
+// handleEventIssueClosed handles closed events for issues
+// and triggers issue closed webhooks for the repo.
+func (s *Service) handleEventIssueClosed(ctx context.Context,
+	event *events.Event[*issueevents.ClosedPayload]) error {
+	return s.triggerForIssueEvent(ctx, enum.WebhookTriggerIssueClosed, event.ID, event.Payload.Base)
+}
+
+// handleEventIssueReopened handles reopened events for issues
+// and triggers issue reopened webhooks for the repo.
+func (s *Service) handleEventIssueReopened(ctx context.Context,
+	event *events.Event[*issueevents.ReopenedPayload]) error {
+	return s.triggerForIssueEvent(ctx, enum.WebhookTriggerIssueReopened, event.ID, event.Payload.Base)
+}
+
+func (s *Service) triggerForIssueEvent(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string, base issueevents.Base) error {
+	return s.triggerForEventWithIssue(ctx, triggerType, eventID, base.PrincipalID, base.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			return &IssuePayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(issue),
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// VulnerabilityAlertsPayload describes the body of the vulnerability alerts created trigger.
+type VulnerabilityAlertsPayload struct {
+	BaseSegment
+	VulnerabilityAlertsSegment
+}
+
+// handleEventVulnerabilityAlertsCreated handles vulnerability alerts created events for repos
+// and triggers vulnerability alerts created webhooks for the repo.
+func (s *Service) handleEventVulnerabilityAlertsCreated(ctx context.Context,
+	event *events.Event[*repoevents.VulnerabilityAlertsCreatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerVulnerabilityAlertsCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			alerts := make([]VulnerabilityAlertInfo, 0, len(event.Payload.AlertIDs))
+			for _, alertID := range event.Payload.AlertIDs {
+				alert, err := s.vulnerabilityAlertStore.Find(ctx, alertID)
+				if errors.Is(err, store.ErrResourceNotFound) {
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to get vulnerability alert %d: %w", alertID, err)
+				}
+
+				alerts = append(alerts, vulnerabilityAlertInfoFrom(alert))
+			}
+
+			return &VulnerabilityAlertsPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerVulnerabilityAlertsCreated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				VulnerabilityAlertsSegment: VulnerabilityAlertsSegment{
+					Alerts: alerts,
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/harness/gitness/app/services/secrets"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// secretReferenceRegex matches references to secrets in custom header values, e.g. "Bearer ${{ secrets.token }}".
+var secretReferenceRegex = regexp.MustCompile(`\$\{\{\s*secrets\.([a-zA-Z0-9_.\-]+)\s*\}\}`)
+
+// errSecretNotFound is returned if a custom header references a secret that doesn't exist.
+var errSecretNotFound = errors.New("secret not found")
+
+// resolveHeaders returns the custom headers of the webhook with all secret references replaced by the secret values.
+// Repository webhooks can reference secrets of the repository and its parent space,
+// space webhooks can reference secrets of the space.
+func (s *Service) resolveHeaders(
+	ctx context.Context,
+	webhook *types.Webhook,
+	triggerID string,
+) (map[string]string, error) {
+	if len(webhook.Headers) == 0 {
+		return nil, nil //nolint:nilnil // no headers is a valid result
+	}
+
+	var spaceID, repoID int64
+	switch webhook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository of webhook: %w", err)
+		}
+		spaceID, repoID = repo.ParentID, repo.ID
+	case enum.WebhookParentSpace:
+		spaceID = webhook.ParentID
+	default:
+		return nil, fmt.Errorf("webhook parent type %q is not supported", webhook.ParentType)
+	}
+
+	accessor := secrets.Accessor{
+		RequestID: triggerID,
+		Component: "webhook",
+	}
+
+	// cache resolved values as the same secret might be referenced in multiple headers.
+	values := map[string]string{}
+	resolved := make(map[string]string, len(webhook.Headers))
+	for name, value := range webhook.Headers {
+		var resolveErr error
+		resolved[name] = secretReferenceRegex.ReplaceAllStringFunc(value, func(ref string) string {
+			if resolveErr != nil {
+				return ""
+			}
+
+			identifier := secretReferenceRegex.FindStringSubmatch(ref)[1]
+			if v, ok := values[identifier]; ok {
+				return v
+			}
+
+			secret, err := s.secretResolver.Find(ctx, spaceID, repoID, identifier, accessor)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				resolveErr = fmt.Errorf("%w: %q", errSecretNotFound, identifier)
+				return ""
+			}
+			if err != nil {
+				resolveErr = fmt.Errorf("failed to resolve secret %q: %w", identifier, err)
+				return ""
+			}
+
+			values[identifier] = secret.Data
+			return secret.Data
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+	}
+
+	return resolved, nil
+}
@@ -16,31 +16,35 @@ package webhook
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"time"
 
-	"github.com/rs/zerolog/log"
-)
+	"github.com/harness/gitness/http/proxy"
 
-var (
-	errLoopbackNotAllowed       = errors.New("loopback not allowed")
-	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
+	"github.com/rs/zerolog/log"
 )
 
-func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
-	// no customizations? use default client
-	if allowLoopback && allowPrivateNetwork && !disableSSLVerification {
-		return http.DefaultClient
-	}
-
+// newHTTPClient creates a new http client for webhook deliveries.
+// The destination addresses are checked against the network policy on every connection.
+// If a proxy resolver is provided, requests are sent via the configured proxies and connections
+// to the proxies themselves are excluded from the network policy checks.
+func newHTTPClient(
+	networkPolicy *NetworkPolicy,
+	internal bool,
+	disableSSLVerification bool,
+	proxyResolver *proxy.Resolver,
+) *http.Client {
 	// Clone http.DefaultTransport (used by http.DefaultClient)
 	tr := http.DefaultTransport.(*http.Transport).Clone()
 
 	tr.TLSClientConfig.InsecureSkipVerify = disableSSLVerification
 
+	if proxyResolver != nil {
+		tr.Proxy = proxyResolver.Proxy
+	}
+
 	// create basic net.Dialer (Similar to what is used by http.DefaultTransport)
 	dialer := &net.Dialer{
 		Timeout:   30 * time.Second,
@@ -74,6 +78,12 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 			}
 		}()
 
+		// connections to the configured proxies are always allowed
+		if proxyResolver != nil && proxyResolver.IsProxyAddress(addr) {
+			keepConnection = true
+			return con, nil
+		}
+
 		// ensure a tcp address got established and close if it's localhost or private
 		tcpAddr, ok := con.RemoteAddr().(*net.TCPAddr)
 		if !ok {
@@ -82,12 +92,8 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 				addr, con.RemoteAddr())
 		}
 
-		if !allowLoopback && tcpAddr.IP.IsLoopback() {
-			return nil, errLoopbackNotAllowed
-		}
-
-		if !allowPrivateNetwork && tcpAddr.IP.IsPrivate() {
-			return nil, errPrivateNetworkNotAllowed
+		if err = networkPolicy.Check(tcpAddr.IP, internal); err != nil {
+			return nil, err
 		}
 
 		// otherwise keep connection
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	executionCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "webhook",
+		Name:      "executions_total",
+		Help:      "Number of webhook executions, partitioned by trigger and result.",
+	}, []string{"trigger", "result"})
+
+	executionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "webhook",
+		Name:      "execution_duration_seconds",
+		Help:      "Duration of the webhook executions in seconds, partitioned by result.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20},
+	}, []string{"result"})
+)
+
+func registerMetrics() {
+	for _, c := range []prometheus.Collector{executionCounter, executionDuration} {
+		// the metrics are shared by all webhook services (relevant for tests setting up multiple services).
+		var errRegistered prometheus.AlreadyRegisteredError
+		if err := prometheus.Register(c); err != nil && !errors.As(err, &errRegistered) {
+			log.Warn().Err(err).Msg("failed to register webhook metrics")
+		}
+	}
+}
+
+// observeExecution records the result and the duration of the webhook execution.
+func observeExecution(execution *types.WebhookExecution) {
+	executionCounter.WithLabelValues(string(execution.TriggerType), string(execution.Result)).Inc()
+	executionDuration.WithLabelValues(string(execution.Result)).
+		Observe(time.Duration(execution.Duration).Seconds())
+}
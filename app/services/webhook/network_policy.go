@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+)
+
+var (
+	ErrLoopbackNotAllowed       = errors.New("loopback not allowed")
+	ErrPrivateNetworkNotAllowed = errors.New("private network not allowed")
+)
+
+// NetworkPolicy defines the addresses webhooks can be delivered to.
+// It can be updated at runtime (e.g. when the configuration is reloaded).
+type NetworkPolicy struct {
+	allowLoopback       atomic.Bool
+	allowPrivateNetwork atomic.Bool
+	allowedNetworks     atomic.Pointer[[]*net.IPNet]
+	// overriddenNetworks replace the allowed networks of the config if set (e.g. by the instance settings).
+	overriddenNetworks atomic.Pointer[[]*net.IPNet]
+}
+
+func NewNetworkPolicy(allowLoopback bool, allowPrivateNetwork bool, allowedNetworks []string) (*NetworkPolicy, error) {
+	p := &NetworkPolicy{}
+	if err := p.Update(allowLoopback, allowPrivateNetwork, allowedNetworks); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Update replaces the policy. It fails without changing the policy if any of the networks is invalid.
+func (p *NetworkPolicy) Update(allowLoopback bool, allowPrivateNetwork bool, allowedNetworks []string) error {
+	networks, err := parseNetworks(allowedNetworks)
+	if err != nil {
+		return err
+	}
+
+	p.allowLoopback.Store(allowLoopback)
+	p.allowPrivateNetwork.Store(allowPrivateNetwork)
+	p.allowedNetworks.Store(&networks)
+
+	return nil
+}
+
+// OverrideAllowedNetworks replaces the allowed networks of the policy until it's called with nil.
+// It fails without changing the policy if any of the networks is invalid.
+func (p *NetworkPolicy) OverrideAllowedNetworks(allowedNetworks *[]string) error {
+	if allowedNetworks == nil {
+		p.overriddenNetworks.Store(nil)
+		return nil
+	}
+
+	networks, err := parseNetworks(*allowedNetworks)
+	if err != nil {
+		return err
+	}
+
+	p.overriddenNetworks.Store(&networks)
+
+	return nil
+}
+
+// ValidateNetworks returns an error if any of the networks isn't a valid CIDR.
+func ValidateNetworks(allowedNetworks []string) error {
+	_, err := parseNetworks(allowedNetworks)
+	return err
+}
+
+func parseNetworks(allowedNetworks []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(allowedNetworks))
+	for _, cidr := range allowedNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed network %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// Check returns an error if webhooks can't be delivered to the ip.
+// Addresses within the allowed networks are always allowed,
+// internal webhooks can always be delivered to private networks.
+func (p *NetworkPolicy) Check(ip net.IP, internal bool) error {
+	allowedNetworks := p.overriddenNetworks.Load()
+	if allowedNetworks == nil {
+		allowedNetworks = p.allowedNetworks.Load()
+	}
+
+	for _, network := range *allowedNetworks {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	if !p.allowLoopback.Load() && ip.IsLoopback() {
+		return ErrLoopbackNotAllowed
+	}
+
+	if !internal && !p.allowPrivateNetwork.Load() && ip.IsPrivate() {
+		return ErrPrivateNetworkNotAllowed
+	}
+
+	return nil
+}
@@ -22,12 +22,16 @@ import (
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/secrets"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/stream"
 )
 
@@ -47,6 +51,8 @@ type Config struct {
 	MaxRetries          int
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
+	// AllowedNetworks are networks (CIDRs) webhooks can be delivered to regardless of the other restrictions.
+	AllowedNetworks []string
 }
 
 func (c *Config) Prepare() error {
@@ -76,15 +82,18 @@ func (c *Config) Prepare() error {
 
 // Service is responsible for processing webhook events.
 type Service struct {
-	webhookStore          store.WebhookStore
-	webhookExecutionStore store.WebhookExecutionStore
-	urlProvider           url.Provider
-	repoStore             store.RepoStore
-	pullreqStore          store.PullReqStore
-	principalStore        store.PrincipalStore
-	git                   git.Interface
-	activityStore         store.PullReqActivityStore
-	encrypter             encrypt.Encrypter
+	webhookStore            store.WebhookStore
+	webhookExecutionStore   store.WebhookExecutionStore
+	urlProvider             url.Provider
+	repoStore               store.RepoStore
+	pullreqStore            store.PullReqStore
+	issueStore              store.IssueStore
+	vulnerabilityAlertStore store.VulnerabilityAlertStore
+	principalStore          store.PrincipalStore
+	git                     git.Interface
+	activityStore           store.PullReqActivityStore
+	systemReporter          *systemevents.Reporter
+	secretResolver          *secrets.Resolver
 
 	secureHTTPClient   *http.Client
 	insecureHTTPClient *http.Client
@@ -100,35 +109,49 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	issueReaderFactory *events.ReaderFactory[*issueevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	vulnerabilityAlertStore store.VulnerabilityAlertStore,
 	activityStore store.PullReqActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
-	encrypter encrypt.Encrypter,
+	systemReporter *systemevents.Reporter,
+	proxyResolver *proxy.Resolver,
+	networkPolicy *NetworkPolicy,
+	secretResolver *secrets.Resolver,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
 	}
+
+	registerMetrics()
+
 	service := &Service{
-		webhookStore:          webhookStore,
-		webhookExecutionStore: webhookExecutionStore,
-		repoStore:             repoStore,
-		pullreqStore:          pullreqStore,
-		activityStore:         activityStore,
-		urlProvider:           urlProvider,
-		principalStore:        principalStore,
-		git:                   git,
-		encrypter:             encrypter,
-
-		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
-
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		webhookStore:            webhookStore,
+		webhookExecutionStore:   webhookExecutionStore,
+		repoStore:               repoStore,
+		pullreqStore:            pullreqStore,
+		issueStore:              issueStore,
+		vulnerabilityAlertStore: vulnerabilityAlertStore,
+		activityStore:           activityStore,
+		urlProvider:             urlProvider,
+		principalStore:          principalStore,
+		git:                     git,
+		systemReporter:          systemReporter,
+		secretResolver:          secretResolver,
+
+		secureHTTPClient:   newHTTPClient(networkPolicy, false, false, proxyResolver),
+		insecureHTTPClient: newHTTPClient(networkPolicy, false, true, proxyResolver),
+
+		// internal webhooks are always delivered directly.
+		secureHTTPClientInternal:   newHTTPClient(networkPolicy, true, false, nil),
+		insecureHTTPClientInternal: newHTTPClient(networkPolicy, true, true, nil),
 
 		config: config,
 	}
@@ -182,5 +205,46 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = issueReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *issueevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventIssueCreated)
+			_ = r.RegisterUpdated(service.handleEventIssueUpdated)
+			_ = r.RegisterClosed(service.handleEventIssueClosed)
+			_ = r.RegisterReopened(service.handleEventIssueReopened)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch issue event reader for webhooks: %w", err)
+	}
+
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterVulnerabilityAlertsCreated(service.handleEventVulnerabilityAlertsCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
@@ -195,6 +195,8 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		execution.Duration = int64(time.Since(start))
 		execution.Created = time.Now().UnixMilli()
 
+		observeExecution(&execution)
+
 		// TODO: what if saving execution failed? For now we will rerun it in case of error or not show it in history
 		err := s.webhookExecutionStore.Create(oCtx, &execution)
 		if err != nil {
@@ -203,6 +205,10 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 				execution.Result, execution.Response.Status, execution.Error)
 		}
 
+		if execution.Result != enum.WebhookExecutionResultSuccess {
+			s.systemReporter.WebhookFailed(oCtx, webhook, &execution)
+		}
+
 		// update latest execution result of webhook IFF it's different from before (best effort)
 		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
 			_, err = s.webhookStore.UpdateOptLock(oCtx, webhook, func(hook *types.Webhook) error {
@@ -342,14 +348,20 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 	req.Header.Add(s.toXHeader("Webhook-Uid"), fmt.Sprint(webhook.Identifier))
 	req.Header.Add(s.toXHeader("Webhook-Identifier"), fmt.Sprint(webhook.Identifier))
 
+	// add custom headers - secret references are resolved only after the headers got recorded in the execution.
+	customHeaders := make([]string, 0, len(webhook.Headers))
+	for name, value := range webhook.Headers {
+		if req.Header.Get(name) != "" {
+			continue
+		}
+		req.Header.Set(name, value)
+		customHeaders = append(customHeaders, name)
+	}
+
 	// add HMAC only if a secret was provided
 	if webhook.Secret != "" {
-		decryptedSecret, err := s.encrypter.Decrypt([]byte(webhook.Secret))
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
-		}
 		var hmac string
-		hmac, err = generateHMACSHA256(bBuff.Bytes(), []byte(decryptedSecret))
+		hmac, err = generateHMACSHA256(bBuff.Bytes(), []byte(webhook.Secret))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate SHA256 based HMAC: %w", err)
 		}
@@ -366,6 +378,23 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 	}
 	execution.Request.Headers = hBuffer.String()
 
+	resolvedHeaders, err := s.resolveHeaders(ctx, webhook, execution.TriggerID)
+	if errors.Is(err, errSecretNotFound) {
+		tErr := fmt.Errorf("failed to resolve custom headers: %w", err)
+		execution.Error = tErr.Error()
+		execution.Result = enum.WebhookExecutionResultFatalError
+		return nil, tErr
+	}
+	if err != nil {
+		tErr := fmt.Errorf("failed to resolve custom headers: %w", err)
+		execution.Error = "an error occurred resolving the custom headers"
+		execution.Result = enum.WebhookExecutionResultRetriableError
+		return nil, tErr
+	}
+	for _, name := range customHeaders {
+		req.Header.Set(name, resolvedHeaders[name])
+	}
+
 	return req, nil
 }
 
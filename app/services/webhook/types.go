@@ -79,6 +79,16 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// IssueSegment contains details for all issue related payloads for webhooks.
+type IssueSegment struct {
+	Issue IssueInfo `json:"issue"`
+}
+
+// VulnerabilityAlertsSegment contains details for all vulnerability alert related payloads for webhooks.
+type VulnerabilityAlertsSegment struct {
+	Alerts []VulnerabilityAlertInfo `json:"alerts"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -146,6 +156,36 @@ func pullReqInfoFrom(pr *types.PullReq, repo *types.Repository, urlProvider url.
 	}
 }
 
+// IssueInfo describes the issue related info for a webhook payload.
+// NOTE: don't use types package as we want issue payload to be independent from API calls.
+type IssueInfo struct {
+	Number          int64           `json:"number"`
+	State           enum.IssueState `json:"state"`
+	Title           string          `json:"title"`
+	Labels          []string        `json:"labels"`
+	Author          PrincipalInfo   `json:"author"`
+	Assignees       []PrincipalInfo `json:"assignees"`
+	ClosedByPullReq *int64          `json:"closed_by_pullreq,omitempty"`
+}
+
+// issueInfoFrom gets the IssueInfo from a types.Issue.
+func issueInfoFrom(issue *types.Issue) IssueInfo {
+	assignees := make([]PrincipalInfo, len(issue.Assignees))
+	for i, assignee := range issue.Assignees {
+		assignees[i] = principalInfoFrom(assignee)
+	}
+
+	return IssueInfo{
+		Number:          issue.Number,
+		State:           issue.State,
+		Title:           issue.Title,
+		Labels:          issue.Labels,
+		Author:          principalInfoFrom(&issue.Author),
+		Assignees:       assignees,
+		ClosedByPullReq: issue.ClosedByPullReq,
+	}
+}
+
 // PrincipalInfo describes the principal related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type PrincipalInfo struct {
@@ -269,3 +309,33 @@ type CommentInfo struct {
 	ParentID *int64 `json:"parent_id,omitempty"`
 	Text     string `json:"text"`
 }
+
+// VulnerabilityAlertInfo describes the vulnerability alert related info for a webhook payload.
+type VulnerabilityAlertInfo struct {
+	ID              int64                        `json:"id"`
+	VulnerabilityID string                       `json:"vulnerability_id"`
+	Ecosystem       enum.DependencyEcosystem     `json:"ecosystem"`
+	Package         string                       `json:"package"`
+	Version         string                       `json:"version"`
+	Manifest        string                       `json:"manifest"`
+	Severity        enum.VulnerabilitySeverity   `json:"severity"`
+	Summary         string                       `json:"summary"`
+	FixedVersion    string                       `json:"fixed_version"`
+	State           enum.VulnerabilityAlertState `json:"state"`
+}
+
+// vulnerabilityAlertInfoFrom gets the VulnerabilityAlertInfo from a types.VulnerabilityAlert.
+func vulnerabilityAlertInfoFrom(alert *types.VulnerabilityAlert) VulnerabilityAlertInfo {
+	return VulnerabilityAlertInfo{
+		ID:              alert.ID,
+		VulnerabilityID: alert.VulnerabilityID,
+		Ecosystem:       alert.Ecosystem,
+		Package:         alert.Package,
+		Version:         alert.Version,
+		Manifest:        alert.Manifest,
+		Severity:        alert.Severity,
+		Summary:         alert.Summary,
+		FixedVersion:    alert.FixedVersion,
+		State:           alert.State,
+	}
+}
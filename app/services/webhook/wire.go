@@ -18,12 +18,19 @@ import (
 	"context"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	systemevents "github.com/harness/gitness/app/events/system"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/secrets"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/http/proxy"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -31,23 +38,61 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideService,
+	ProvideNetworkPolicy,
 )
 
 func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	issueReaderFactory *events.ReaderFactory[*issueevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	vulnerabilityAlertStore store.VulnerabilityAlertStore,
 	activityStore store.PullReqActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
-	encrypter encrypt.Encrypter,
+	systemReporter *systemevents.Reporter,
+	proxyResolver *proxy.Resolver,
+	networkPolicy *NetworkPolicy,
+	secretResolver *secrets.Resolver,
 ) (*Service, error) {
-	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
-		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
-		urlProvider, principalStore, git, encrypter)
+	return NewService(ctx, config, gitReaderFactory, prReaderFactory, issueReaderFactory,
+		repoReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullreqStore, issueStore,
+		vulnerabilityAlertStore, activityStore,
+		urlProvider, principalStore, git, systemReporter, proxyResolver, networkPolicy, secretResolver)
+}
+
+// ProvideNetworkPolicy provides the network policy of webhook deliveries,
+// which is updated whenever the configuration is reloaded.
+// The allowed networks of the config are overridden by the allowed networks of the instance settings (if set).
+func ProvideNetworkPolicy(
+	config Config,
+	reloader *configreload.Reloader,
+	instanceSettings *instance.Service,
+) (*NetworkPolicy, error) {
+	policy, err := NewNetworkPolicy(config.AllowLoopback, config.AllowPrivateNetwork, config.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = policy.OverrideAllowedNetworks(instanceSettings.Settings().WebhookAllowedNetworks); err != nil {
+		return nil, err
+	}
+
+	instanceSettings.OnChange(func(_ context.Context, settings types.InstanceSettings) error {
+		return policy.OverrideAllowedNetworks(settings.WebhookAllowedNetworks)
+	})
+
+	reloader.Register("webhook network policy", func(_ context.Context, config *types.Config) error {
+		return policy.Update(config.Webhook.AllowLoopback, config.Webhook.AllowPrivateNetwork,
+			config.Webhook.AllowedNetworks)
+	})
+
+	return policy, nil
 }
@@ -15,15 +15,33 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/archival"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/diffcheck"
+	"github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/keyrotation"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergeschedule"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/registries"
+	"github.com/harness/gitness/app/services/repoconfig"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/reviewerassignment"
+	"github.com/harness/gitness/app/services/reviewreminder"
+	"github.com/harness/gitness/app/services/runners"
+	"github.com/harness/gitness/app/services/systemevent"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usage"
+	"github.com/harness/gitness/app/services/vulnerability"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
 
 	"github.com/google/wire"
 )
@@ -42,6 +60,26 @@ type Services struct {
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	EventOutboxRelay   *events.OutboxRelay
+	KeyRotation        *keyrotation.Service
+	EventStreamTrimmer *events.StreamTrimmer
+	SystemEvent        *systemevent.Service
+	Realtime           *realtime.Service
+	Integration        *integration.Service
+	Issue              *issue.Service
+	EventSystem        *events.System
+	Elector            *lock.Elector
+	ConfigReloader     *configreload.Reloader
+	Usage              *usage.Service
+	RepoConfig         *repoconfig.Service
+	Runners            *runners.Service
+	Registries         *registries.Service
+	Archival           *archival.Service
+	Vulnerability      *vulnerability.Service
+	DiffCheck          *diffcheck.Service
+	ReviewReminder     *reviewreminder.Service
+	MergeSchedule      *mergeschedule.Service
+	ReviewerAssignment *reviewerassignment.Service
 }
 
 func ProvideServices(
@@ -54,6 +92,26 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	eventOutboxRelay *events.OutboxRelay,
+	keyRotationSvc *keyrotation.Service,
+	eventStreamTrimmer *events.StreamTrimmer,
+	systemEventSvc *systemevent.Service,
+	realtimeSvc *realtime.Service,
+	integrationSvc *integration.Service,
+	issueSvc *issue.Service,
+	eventSystem *events.System,
+	elector *lock.Elector,
+	configReloader *configreload.Reloader,
+	usageSvc *usage.Service,
+	repoConfigSvc *repoconfig.Service,
+	runnersSvc *runners.Service,
+	registriesSvc *registries.Service,
+	archivalSvc *archival.Service,
+	vulnerabilitySvc *vulnerability.Service,
+	diffCheckSvc *diffcheck.Service,
+	reviewReminderSvc *reviewreminder.Service,
+	mergeScheduleSvc *mergeschedule.Service,
+	reviewerAssignmentSvc *reviewerassignment.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -65,5 +123,25 @@ func ProvideServices(
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		EventOutboxRelay:   eventOutboxRelay,
+		KeyRotation:        keyRotationSvc,
+		EventStreamTrimmer: eventStreamTrimmer,
+		SystemEvent:        systemEventSvc,
+		Realtime:           realtimeSvc,
+		Integration:        integrationSvc,
+		Issue:              issueSvc,
+		EventSystem:        eventSystem,
+		Elector:            elector,
+		ConfigReloader:     configReloader,
+		Usage:              usageSvc,
+		RepoConfig:         repoConfigSvc,
+		Runners:            runnersSvc,
+		Registries:         registriesSvc,
+		Archival:           archivalSvc,
+		Vulnerability:      vulnerabilitySvc,
+		DiffCheck:          diffCheckSvc,
+		ReviewReminder:     reviewReminderSvc,
+		MergeSchedule:      mergeScheduleSvc,
+		ReviewerAssignment: reviewerAssignmentSvc,
 	}
 }
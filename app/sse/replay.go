@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"context"
+	"strconv"
+)
+
+// Replay returns a channel that first delivers the missed events and then forwards the live events.
+// Live events that have already been delivered as missed events are skipped, which requires numeric event IDs.
+// The forwarding stops once the context is done.
+func Replay(ctx context.Context, missed []*Event, live <-chan *Event) <-chan *Event {
+	if len(missed) == 0 {
+		return live
+	}
+
+	lastID, _ := strconv.ParseInt(missed[len(missed)-1].ID, 10, 64)
+
+	chEvents := make(chan *Event, cap(live))
+	go func() {
+		for _, event := range missed {
+			select {
+			case <-ctx.Done():
+				return
+			case chEvents <- event:
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-live:
+				if id, err := strconv.ParseInt(event.ID, 10, 64); err == nil && id <= lastID {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case chEvents <- event:
+				}
+			}
+		}
+	}()
+
+	return chEvents
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	live := make(chan *Event, 10)
+	live <- &Event{ID: "2"}
+	live <- &Event{ID: "3"}
+	live <- &Event{ID: "4"}
+
+	missed := []*Event{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	chEvents := Replay(ctx, missed, live)
+
+	want := []string{"1", "2", "3", "4"}
+	for _, id := range want {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %s", id)
+		case event := <-chEvents:
+			if event.ID != id {
+				t.Fatalf("got event %s, want %s", event.ID, id)
+			}
+		}
+	}
+
+	select {
+	case event := <-chEvents:
+		t.Fatalf("unexpected event %s", event.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -26,6 +26,8 @@ import (
 
 // Event is a server sent event.
 type Event struct {
+	// ID is the optional ID of the event, clients can use it to resume the stream via Last-Event-ID.
+	ID   string          `json:"id,omitempty"`
 	Type enum.SSEType    `json:"type"`
 	Data json.RawMessage `json:"data"`
 }
@@ -36,6 +38,24 @@ type Streamer interface {
 
 	// Stream streams the events on a space ID.
 	Stream(ctx context.Context, spaceID int64) (<-chan *Event, <-chan error, func(context.Context) error)
+
+	// PublishSystem publishes an instance level event (only streamed to admins).
+	PublishSystem(ctx context.Context, eventType enum.SSEType, data any) error
+
+	// StreamSystem streams the instance level events.
+	StreamSystem(ctx context.Context) (<-chan *Event, <-chan error, func(context.Context) error)
+
+	// PublishRepo publishes an event to a given repo ID.
+	PublishRepo(ctx context.Context, repoID int64, event *Event) error
+
+	// StreamRepo streams the events on a repo ID.
+	StreamRepo(ctx context.Context, repoID int64) (<-chan *Event, <-chan error, func(context.Context) error)
+
+	// PublishPullReq publishes an event to a given pull request ID.
+	PublishPullReq(ctx context.Context, pullreqID int64, event *Event) error
+
+	// StreamPullReq streams the events on a pull request ID.
+	StreamPullReq(ctx context.Context, pullreqID int64) (<-chan *Event, <-chan error, func(context.Context) error)
 }
 
 type pubsubStreamer struct {
@@ -51,20 +71,40 @@ func NewStreamer(pubsub pubsub.PubSub, namespace string) Streamer {
 }
 
 func (e *pubsubStreamer) Publish(ctx context.Context, spaceID int64, eventType enum.SSEType, data any) error {
+	return e.publish(ctx, getSpaceTopic(spaceID), eventType, data)
+}
+
+func (e *pubsubStreamer) PublishSystem(ctx context.Context, eventType enum.SSEType, data any) error {
+	return e.publish(ctx, systemTopic, eventType, data)
+}
+
+func (e *pubsubStreamer) PublishRepo(ctx context.Context, repoID int64, event *Event) error {
+	return e.publishEvent(ctx, getRepoTopic(repoID), event)
+}
+
+func (e *pubsubStreamer) PublishPullReq(ctx context.Context, pullreqID int64, event *Event) error {
+	return e.publishEvent(ctx, getPullReqTopic(pullreqID), event)
+}
+
+func (e *pubsubStreamer) publish(ctx context.Context, topic string, eventType enum.SSEType, data any) error {
 	dataSerialized, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to serialize data: %w", err)
 	}
-	event := Event{
+	event := &Event{
 		Type: eventType,
 		Data: dataSerialized,
 	}
+
+	return e.publishEvent(ctx, topic, event)
+}
+
+func (e *pubsubStreamer) publishEvent(ctx context.Context, topic string, event *Event) error {
 	serializedEvent, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to serialize event: %w", err)
 	}
 	namespaceOption := pubsub.WithPublishNamespace(e.namespace)
-	topic := getSpaceTopic(spaceID)
 	err = e.pubsub.Publish(ctx, topic, serializedEvent, namespaceOption)
 	if err != nil {
 		return fmt.Errorf("failed to publish event on pubsub: %w", err)
@@ -76,6 +116,31 @@ func (e *pubsubStreamer) Publish(ctx context.Context, spaceID int64, eventType e
 func (e *pubsubStreamer) Stream(
 	ctx context.Context,
 	spaceID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getSpaceTopic(spaceID))
+}
+
+func (e *pubsubStreamer) StreamSystem(ctx context.Context) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, systemTopic)
+}
+
+func (e *pubsubStreamer) StreamRepo(
+	ctx context.Context,
+	repoID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getRepoTopic(repoID))
+}
+
+func (e *pubsubStreamer) StreamPullReq(
+	ctx context.Context,
+	pullreqID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getPullReqTopic(pullreqID))
+}
+
+func (e *pubsubStreamer) stream(
+	ctx context.Context,
+	topic string,
 ) (<-chan *Event, <-chan error, func(context.Context) error) {
 	chEvent := make(chan *Event, 100) // TODO: check best size here
 	chErr := make(chan error)
@@ -94,7 +159,6 @@ func (e *pubsubStreamer) Stream(
 		return nil
 	}
 	namespaceOption := pubsub.WithChannelNamespace(e.namespace)
-	topic := getSpaceTopic(spaceID)
 	consumer := e.pubsub.Subscribe(ctx, topic, g, namespaceOption)
 	cleanupFN := func(_ context.Context) error {
 		return consumer.Close()
@@ -104,6 +168,17 @@ func (e *pubsubStreamer) Stream(
 }
 
 // getSpaceTopic creates the namespace name which will be `spaces:<id>`.
+// systemTopic is the topic used for instance level events.
+const systemTopic = "system"
+
 func getSpaceTopic(spaceID int64) string {
 	return "spaces:" + strconv.Itoa(int(spaceID))
 }
+
+func getRepoTopic(repoID int64) string {
+	return "repos:" + strconv.FormatInt(repoID, 10)
+}
+
+func getPullReqTopic(pullreqID int64) string {
+	return "pullreqs:" + strconv.FormatInt(pullreqID, 10)
+}
@@ -28,4 +28,7 @@ type (
 
 	// RepoGitInfoCache caches repository IDs to values GitUID.
 	RepoGitInfoCache cache.Cache[int64, *types.RepositoryGitInfo]
+
+	// RowCache caches database rows of hot lookups (principal by ID, repo by path, token by ID).
+	RowCache cache.RowCache
 )
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
 )
 
@@ -29,6 +30,7 @@ var WireSet = wire.NewSet(
 	ProvidePrincipalInfoCache,
 	ProvidePathCache,
 	ProvideRepoGitInfoCache,
+	ProvideRowCache,
 )
 
 // ProvidePrincipalInfoCache provides a cache for storing types.PrincipalInfo objects.
@@ -48,3 +50,13 @@ func ProvidePathCache(
 func ProvideRepoGitInfoCache(getter store.RepoGitInfoView) store.RepoGitInfoCache {
 	return cache.New[int64, *types.RepositoryGitInfo](getter, 15*time.Minute)
 }
+
+// ProvideRowCache provides a cache for database rows of hot lookups.
+// Unless enabled in the config, no rows are cached.
+func ProvideRowCache(config *types.Config, redisClient redis.UniversalClient) store.RowCache {
+	if !config.RowCache.Enabled {
+		return cache.NoRowCache{}
+	}
+
+	return cache.NewRedisRowCache(redisClient, config.RowCache.Namespace, config.RowCache.Duration)
+}
@@ -17,6 +17,7 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/harness/gitness/types"
@@ -67,6 +68,16 @@ type (
 		// DeleteUser deletes the user.
 		DeleteUser(ctx context.Context, id int64) error
 
+		// SoftDeleteUser marks the user as deleted, the user is kept to preserve authorship references.
+		SoftDeleteUser(ctx context.Context, id int64, deletedAt int64) error
+
+		// RestoreUser restores a deleted user that wasn't purged yet.
+		RestoreUser(ctx context.Context, id int64) error
+
+		// PurgeDeletedUsers anonymizes all users that were deleted before or at the provided time.
+		// Returns the IDs of the purged users.
+		PurgeDeletedUsers(ctx context.Context, deletedBeforeOrAt int64) ([]int64, error)
+
 		// ListUsers returns a list of users.
 		ListUsers(ctx context.Context, params *types.UserFilter) ([]*types.User, error)
 
@@ -147,6 +158,13 @@ type (
 		// DeletePrimarySegment deletes the primary segment of a space.
 		DeletePrimarySegment(ctx context.Context, spaceID int64) error
 
+		// DemotePrimarySegment turns the primary segment of a space into an alias,
+		// which keeps the previous path of the space resolvable after it got renamed or moved.
+		DemotePrimarySegment(ctx context.Context, spaceID int64) error
+
+		// DeleteAliasSegment deletes the alias segment with the provided identifier under the parent (if it exists).
+		DeleteAliasSegment(ctx context.Context, parentID int64, identifier string) error
+
 		// DeletePathsAndDescendandPaths deletes all space paths reachable from spaceID including itself.
 		DeletePathsAndDescendandPaths(ctx context.Context, spaceID int64) error
 	}
@@ -237,11 +255,21 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// ListForks returns all forks of a repo, including deleted ones.
+		ListForks(ctx context.Context, repoID int64) ([]*types.Repository, error)
+
+		// ListInactive returns the active, unarchived repos of the space and all its subspaces
+		// without any repo update, push or pull request update since the provided time.
+		ListInactive(ctx context.Context, spaceID int64, since int64) ([]*types.Repository, error)
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
 	RepoGitInfoView interface {
 		Find(ctx context.Context, id int64) (*types.RepositoryGitInfo, error)
+
+		// ListAll returns the git info of all repositories, including soft deleted ones.
+		ListAll(ctx context.Context) ([]*types.RepositoryGitInfo, error)
 	}
 
 	// MembershipStore defines the membership data storage.
@@ -251,6 +279,10 @@ type (
 		Create(ctx context.Context, membership *types.Membership) error
 		Update(ctx context.Context, membership *types.Membership) error
 		Delete(ctx context.Context, key types.MembershipKey) error
+
+		// ListExpired returns up to limit temporary memberships that expired before the provided time (unix millis).
+		ListExpired(ctx context.Context, before int64, limit int) ([]types.Membership, error)
+
 		CountUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) (int64, error)
 		ListUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) ([]types.MembershipUser, error)
 		CountSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) (int64, error)
@@ -275,6 +307,9 @@ type (
 		// If tokenTypes are provided, then only tokens of that type are deleted.
 		DeleteExpiredBefore(ctx context.Context, before time.Time, tknTypes []enum.TokenType) (int64, error)
 
+		// DeleteForPrincipal deletes all tokens of the principal.
+		DeleteForPrincipal(ctx context.Context, principalID int64) error
+
 		// List returns a list of tokens of a specific type for a specific principal.
 		List(ctx context.Context, principalID int64, tokenType enum.TokenType) ([]*types.Token, error)
 
@@ -319,6 +354,41 @@ type (
 
 		// List returns a list of pull requests in a space.
 		List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+
+		// Search returns a list of pull requests matching the full-text search filter, ordered by relevance.
+		Search(ctx context.Context, opts *types.PullReqSearchFilter) ([]*types.PullReq, error)
+
+		// SearchCount returns the number of pull requests matching the full-text search filter.
+		SearchCount(ctx context.Context, opts *types.PullReqSearchFilter) (int64, error)
+	}
+
+	// IssueStore defines the issue data storage.
+	IssueStore interface {
+		// Find the issue by id.
+		Find(ctx context.Context, id int64) (*types.Issue, error)
+
+		// FindByNumber finds the issue by repo ID and the issue number.
+		FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error)
+
+		// Create a new issue together with its labels and assignees.
+		Create(ctx context.Context, issue *types.Issue) error
+
+		// Update the issue together with its labels and assignees.
+		// It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, issue *types.Issue) error
+
+		// UpdateOptLock the issue details using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, issue *types.Issue,
+			mutateFn func(issue *types.Issue) error) (*types.Issue, error)
+
+		// Delete the issue.
+		Delete(ctx context.Context, id int64) error
+
+		// Count of issues in a repository.
+		Count(ctx context.Context, repoID int64, opts *types.IssueFilter) (int64, error)
+
+		// List returns a list of issues in a repository.
+		List(ctx context.Context, repoID int64, opts *types.IssueFilter) ([]*types.Issue, error)
 	}
 
 	PullReqActivityStore interface {
@@ -355,6 +425,67 @@ type (
 		ListAuthorIDs(ctx context.Context, prID int64, order int64) ([]int64, error)
 	}
 
+	// PullReqActivityEditStore defines the storage of the edit history of pull request comments.
+	PullReqActivityEditStore interface {
+		// Create records a version of the text of a pull request comment.
+		Create(ctx context.Context, edit *types.PullReqActivityEdit) error
+
+		// Count returns the number of recorded versions of the text of a pull request comment.
+		Count(ctx context.Context, activityID int64) (int64, error)
+
+		// List returns the recorded versions of the text of a pull request comment, oldest first.
+		List(ctx context.Context, activityID int64) ([]*types.PullReqActivityEdit, error)
+	}
+
+	// SavedReplyStore defines the storage of the saved replies of users.
+	SavedReplyStore interface {
+		// Find finds the saved reply by id.
+		Find(ctx context.Context, id int64) (*types.SavedReply, error)
+
+		// Create creates a new saved reply.
+		Create(ctx context.Context, reply *types.SavedReply) error
+
+		// Update updates an existing saved reply.
+		Update(ctx context.Context, reply *types.SavedReply) error
+
+		// Delete deletes the saved reply.
+		Delete(ctx context.Context, id int64) error
+
+		// Count returns the number of saved replies of the principal.
+		Count(ctx context.Context, principalID int64) (int64, error)
+
+		// List returns the saved replies of the principal, ordered by title.
+		List(ctx context.Context, principalID int64) ([]*types.SavedReply, error)
+	}
+
+	// ReviewReminderStore tracks the reminders sent to the requested reviewers of pull requests.
+	ReviewReminderStore interface {
+		// ListPending returns the pending reviews of open pull requests requested before the provided time.
+		ListPending(ctx context.Context, requestedBefore int64) ([]*types.PendingReview, error)
+
+		// MarkReminded records the time the reviewer was reminded of the review.
+		MarkReminded(ctx context.Context, prID, principalID, reminded int64) error
+
+		// Snooze suppresses the reminders of the review for the reviewer until the provided time.
+		Snooze(ctx context.Context, prID, principalID, until int64) error
+	}
+
+	// MergeScheduleStore stores the schedules of pull requests that are merged automatically.
+	MergeScheduleStore interface {
+		// Find returns the merge schedule of the pull request.
+		Find(ctx context.Context, prID int64) (*types.MergeSchedule, error)
+
+		// Upsert creates or replaces the merge schedule of the pull request.
+		Upsert(ctx context.Context, schedule *types.MergeSchedule) error
+
+		// Delete removes the merge schedule of the pull request.
+		// It returns false if the pull request wasn't scheduled for merging.
+		Delete(ctx context.Context, prID int64) (bool, error)
+
+		// ListDue returns the merge schedules with a merge time before the provided time.
+		ListDue(ctx context.Context, before int64) ([]*types.MergeSchedule, error)
+	}
+
 	// CodeCommentView is to manipulate only code-comment subset of PullReqActivity.
 	// It's used by internal service that migrates code comment line numbers after new commits.
 	CodeCommentView interface {
@@ -396,6 +527,14 @@ type (
 
 		// List returns all pull request reviewers for the pull request.
 		List(ctx context.Context, prID int64) ([]*types.PullReqReviewer, error)
+
+		// CountPending returns the number of open pull requests with a pending review for each of the principals.
+		// Principals without pending reviews are omitted from the result.
+		CountPending(ctx context.Context, principalIDs []int64) (map[int64]int, error)
+
+		// LastAdded returns the time each of the principals was last added as a reviewer to a pull request
+		// of the repository. Principals that were never added are omitted from the result.
+		LastAdded(ctx context.Context, repoID int64, principalIDs []int64) (map[int64]int64, error)
 	}
 
 	// PullReqFileViewStore stores information about what file a user viewed.
@@ -409,6 +548,9 @@ type (
 		// MarkObsolete updates all entries of the files as obsolete for the PR.
 		MarkObsolete(ctx context.Context, prID int64, filePaths []string) error
 
+		// MarkAllObsolete updates all entries of the PR as obsolete.
+		MarkAllObsolete(ctx context.Context, prID int64) error
+
 		// List lists all files marked as viewed by the user for the specified PR.
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
@@ -479,6 +621,10 @@ type (
 		// List lists the webhooks for a given parent type and id.
 		List(ctx context.Context, parentType enum.WebhookParent, parentID int64,
 			opts *types.WebhookFilter) ([]*types.Webhook, error)
+
+		// ReencryptSecrets re-encrypts the secrets of all webhooks using the provided function.
+		// Returns the number of updated webhooks.
+		ReencryptSecrets(ctx context.Context, reencrypt func(ciphertext []byte) ([]byte, bool, error)) (int64, error)
 	}
 
 	// WebhookExecutionStore defines the webhook execution data storage.
@@ -564,12 +710,18 @@ type (
 		// FindByIdentifier returns a secret given a space ID and a identifier
 		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Secret, error)
 
+		// FindByRepoIdentifier returns a secret given a repo ID and a identifier
+		FindByRepoIdentifier(ctx context.Context, repoID int64, identifier string) (*types.Secret, error)
+
 		// Create creates a new secret
 		Create(ctx context.Context, secret *types.Secret) error
 
 		// Count the number of secrets in a space matching the given filter.
 		Count(ctx context.Context, spaceID int64, pagination types.ListQueryFilter) (int64, error)
 
+		// CountForRepo counts the number of secrets of a repository matching the given filter.
+		CountForRepo(ctx context.Context, repoID int64, pagination types.ListQueryFilter) (int64, error)
+
 		// UpdateOptLock updates the secret using the optimistic locking mechanism.
 		UpdateOptLock(ctx context.Context, secret *types.Secret,
 			mutateFn func(secret *types.Secret) error) (*types.Secret, error)
@@ -583,11 +735,24 @@ type (
 		// DeleteByIdentifier deletes a secret given a space ID and a identifier.
 		DeleteByIdentifier(ctx context.Context, spaceID int64, identifier string) error
 
+		// DeleteByRepoIdentifier deletes a secret given a repo ID and a identifier.
+		DeleteByRepoIdentifier(ctx context.Context, repoID int64, identifier string) error
+
 		// List lists the secrets in a given space.
 		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.Secret, error)
 
 		// ListAll lists all the secrets in a given space.
 		ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error)
+
+		// ListForRepo lists the secrets of a given repository.
+		ListForRepo(ctx context.Context, repoID int64, filter types.ListQueryFilter) ([]*types.Secret, error)
+
+		// ListAllForRepo lists all the secrets of a given repository.
+		ListAllForRepo(ctx context.Context, repoID int64) ([]*types.Secret, error)
+
+		// ReencryptData re-encrypts the data of all secrets using the provided function.
+		// Returns the number of updated secrets.
+		ReencryptData(ctx context.Context, reencrypt func(ciphertext []byte) ([]byte, bool, error)) (int64, error)
 	}
 
 	ExecutionStore interface {
@@ -765,4 +930,484 @@ type (
 		// FindByIdentifier returns a types.UserGroup given a space ID and identifier.
 		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.UserGroup, error)
 	}
+
+	// IntegrationStore defines the chat integration data storage.
+	IntegrationStore interface {
+		// Find finds the integration by id.
+		Find(ctx context.Context, id int64) (*types.Integration, error)
+
+		// FindByIdentifier finds the integration of the parent by its identifier.
+		FindByIdentifier(ctx context.Context, parentType enum.ParentResourceType, parentID int64,
+			identifier string) (*types.Integration, error)
+
+		// Create creates a new integration.
+		Create(ctx context.Context, integration *types.Integration) error
+
+		// Update updates an existing integration.
+		Update(ctx context.Context, integration *types.Integration) error
+
+		// Delete deletes the integration with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the integrations of the parent, ordered by identifier.
+		List(ctx context.Context, parentType enum.ParentResourceType, parentID int64) ([]*types.Integration, error)
+
+		// ListEnabled lists the enabled integrations of the repository and of the provided spaces.
+		ListEnabled(ctx context.Context, repoID int64, spaceIDs []int64) ([]*types.Integration, error)
+
+		// ReencryptSecrets re-encrypts the URLs and tokens of all integrations using the provided function.
+		ReencryptSecrets(ctx context.Context, reencrypt func(ciphertext []byte) ([]byte, bool, error)) (int64, error)
+	}
+
+	// SystemEventStore defines the system event feed storage.
+	SystemEventStore interface {
+		// Create creates a new system event entry.
+		Create(ctx context.Context, event *types.SystemEvent) error
+
+		// List lists the system events matching the filter, latest first.
+		List(ctx context.Context, filter *types.SystemEventFilter) ([]*types.SystemEvent, error)
+
+		// Count counts the system events matching the filter.
+		Count(ctx context.Context, filter *types.SystemEventFilter) (int64, error)
+
+		// DeleteOld removes all system events that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// RepoEventStore defines the storage of the recent real-time repository events.
+	RepoEventStore interface {
+		// Create creates a new repository event entry.
+		Create(ctx context.Context, event *types.RepoEvent) error
+
+		// ListAfter lists the events of a repository (or only of one of its pull requests, if pullreqID is non-zero)
+		// that were created after the event with the provided ID, oldest first.
+		ListAfter(ctx context.Context, repoID, pullreqID, afterID int64, limit int) ([]*types.RepoEvent, error)
+
+		// DeleteOld removes all repository events that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// IdempotencyKeyStore defines the storage of the idempotency keys of mutating requests.
+	IdempotencyKeyStore interface {
+		// Find finds the idempotency key of a principal.
+		Find(ctx context.Context, principalID int64, key string) (*types.IdempotencyKey, error)
+
+		// Create creates a new idempotency key, returns store.ErrDuplicate if the principal already used the key.
+		Create(ctx context.Context, idempotencyKey *types.IdempotencyKey) error
+
+		// UpdateResponse stores the response of the request the idempotency key was used for.
+		UpdateResponse(ctx context.Context, idempotencyKey *types.IdempotencyKey) error
+
+		// Delete deletes an idempotency key.
+		Delete(ctx context.Context, id int64) error
+
+		// DeleteOld removes all idempotency keys that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// AuditLogStore defines the storage of the audit logs of mutating API requests.
+	AuditLogStore interface {
+		// Create creates a new audit log entry.
+		Create(ctx context.Context, auditLog *types.AuditLog) error
+
+		// List lists the audit logs matching the filter, latest first.
+		List(ctx context.Context, filter *types.AuditLogFilter) ([]*types.AuditLog, error)
+
+		// Count counts the audit logs matching the filter.
+		Count(ctx context.Context, filter *types.AuditLogFilter) (int64, error)
+
+		// DeleteOld removes all audit logs that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// ExternalHookStore defines the external pre-receive hook data storage.
+	ExternalHookStore interface {
+		// Find finds the external hook by id.
+		Find(ctx context.Context, id int64) (*types.ExternalHook, error)
+
+		// FindByIdentifier finds the external hook of a space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.ExternalHook, error)
+
+		// Create creates a new external hook.
+		Create(ctx context.Context, hook *types.ExternalHook) error
+
+		// Update updates an existing external hook.
+		Update(ctx context.Context, hook *types.ExternalHook) error
+
+		// Delete deletes the external hook with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the external hooks of the provided spaces, ordered by space and identifier.
+		List(ctx context.Context, spaceIDs []int64, onlyEnabled bool) ([]*types.ExternalHook, error)
+
+		// ReencryptSecrets re-encrypts the secrets of all external hooks using the provided function.
+		ReencryptSecrets(ctx context.Context, reencrypt func(ciphertext []byte) ([]byte, bool, error)) (int64, error)
+	}
+
+	// RepoInsightsStore stores the cached commit statistics of repositories.
+	RepoInsightsStore interface {
+		// Find returns the cached insights of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoInsights, error)
+
+		// Upsert creates or replaces the cached insights of the repository.
+		Upsert(ctx context.Context, insights *types.RepoInsights) error
+	}
+
+	// RepoLanguagesStore stores the language distribution of repositories.
+	RepoLanguagesStore interface {
+		// Find returns the language distribution of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoLanguages, error)
+
+		// Upsert creates or replaces the language distribution of the repository.
+		Upsert(ctx context.Context, languages *types.RepoLanguages) error
+	}
+
+	// RepoDependenciesStore stores the license and the declared dependencies of repositories.
+	RepoDependenciesStore interface {
+		// Find returns the license and the dependencies of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoDependencies, error)
+
+		// Upsert creates or replaces the license and the dependencies of the repository.
+		Upsert(ctx context.Context, dependencies *types.RepoDependencies) error
+
+		// ListRepoIDs returns the ids of all repositories with detected dependencies.
+		ListRepoIDs(ctx context.Context) ([]int64, error)
+	}
+
+	// VulnerabilityAlertStore stores alerts about known vulnerabilities that affect dependencies of repositories.
+	VulnerabilityAlertStore interface {
+		// Find finds the vulnerability alert by id.
+		Find(ctx context.Context, id int64) (*types.VulnerabilityAlert, error)
+
+		// Create creates a new vulnerability alert.
+		Create(ctx context.Context, alert *types.VulnerabilityAlert) error
+
+		// Update updates the severity, the details and the state of the vulnerability alert.
+		Update(ctx context.Context, alert *types.VulnerabilityAlert) error
+
+		// ListAll returns all vulnerability alerts of the repository, regardless of their state.
+		ListAll(ctx context.Context, repoID int64) ([]*types.VulnerabilityAlert, error)
+
+		// Count returns the number of vulnerability alerts of the repository that match the filter.
+		Count(ctx context.Context, repoID int64, filter *types.VulnerabilityAlertFilter) (int64, error)
+
+		// List returns the vulnerability alerts of the repository that match the filter, newest first.
+		List(ctx context.Context, repoID int64, filter *types.VulnerabilityAlertFilter) ([]*types.VulnerabilityAlert, error)
+	}
+
+	// PushedBranchStore stores the latest branch a user created by pushing to a repository.
+	PushedBranchStore interface {
+		// Upsert creates or replaces the latest pushed branch of the user in the repository.
+		Upsert(ctx context.Context, branch *types.PushedBranch) error
+
+		// Find returns the latest pushed branch of the user in the repository.
+		Find(ctx context.Context, repoID, principalID int64) (*types.PushedBranch, error)
+
+		// Delete removes the latest pushed branch of the user in the repository.
+		Delete(ctx context.Context, repoID, principalID int64) error
+	}
+
+	// PushStore records the reference updates pushed by users.
+	PushStore interface {
+		// Create records a new push.
+		Create(ctx context.Context, push *types.Push) error
+	}
+
+	// MalwareFindingStore records the malware detected in pushes of repositories.
+	MalwareFindingStore interface {
+		// Create records a new malware finding.
+		Create(ctx context.Context, finding *types.MalwareFinding) error
+
+		// Count returns the number of malware findings of the repository.
+		Count(ctx context.Context, repoID int64) (int64, error)
+
+		// List returns the malware findings of the repository, newest first.
+		List(ctx context.Context, repoID int64, pagination types.Pagination) ([]*types.MalwareFinding, error)
+	}
+
+	// UserActivityStore provides the activity of users across repositories,
+	// aggregated from pushes, pull requests, reviews and comments.
+	UserActivityStore interface {
+		// ListRepoIDs returns the ids of all repositories the principal was active in since the provided time.
+		ListRepoIDs(ctx context.Context, principalID int64, since int64) ([]int64, error)
+
+		// List returns the activities of the principal, newest first.
+		List(ctx context.Context, principalID int64, filter *types.UserActivityFilter) ([]*types.UserActivity, error)
+
+		// ListCreated returns the creation times of all activities of the principal matching the filter.
+		// Pagination of the filter is ignored.
+		ListCreated(ctx context.Context, principalID int64, filter *types.UserActivityFilter) ([]int64, error)
+	}
+
+	// AnnouncementStore defines the instance announcement data storage.
+	AnnouncementStore interface {
+		// Find finds the announcement by id.
+		Find(ctx context.Context, id int64) (*types.Announcement, error)
+
+		// Create creates a new announcement.
+		Create(ctx context.Context, announcement *types.Announcement) error
+
+		// Update updates an existing announcement.
+		Update(ctx context.Context, announcement *types.Announcement) error
+
+		// Delete deletes the announcement.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists all announcements, newest first.
+		List(ctx context.Context) ([]*types.Announcement, error)
+
+		// ListActive lists the announcements shown at the provided time (unix milliseconds), newest first.
+		ListActive(ctx context.Context, now int64) ([]*types.Announcement, error)
+	}
+
+	// InvitationStore defines the user invitation data storage.
+	InvitationStore interface {
+		// Find finds the invitation by id.
+		Find(ctx context.Context, id int64) (*types.Invitation, error)
+
+		// FindByTokenHash finds the invitation by the hash of its token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Invitation, error)
+
+		// Create creates a new invitation.
+		Create(ctx context.Context, invitation *types.Invitation) error
+
+		// UpdateToken replaces the token of the invitation and updates the expiry and sent time.
+		// It fails with store.ErrResourceNotFound if the invitation was already accepted.
+		UpdateToken(ctx context.Context, invitation *types.Invitation) error
+
+		// MarkAccepted marks the invitation as accepted by the user.
+		// It fails with store.ErrResourceNotFound if the invitation was already accepted.
+		MarkAccepted(ctx context.Context, id int64, acceptedBy int64, accepted int64) error
+
+		// Delete deletes the invitation.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the invitations, newest first.
+		List(ctx context.Context, filter *types.InvitationFilter) ([]*types.Invitation, error)
+
+		// Count returns the number of invitations matching the filter.
+		Count(ctx context.Context, filter *types.InvitationFilter) (int64, error)
+	}
+
+	// SettingsStore stores settings as json values, identified by scope, scope id and key.
+	SettingsStore interface {
+		// Find returns the value of the setting with the provided key in the scope.
+		Find(ctx context.Context, scope enum.SettingsScope, scopeID int64, key string) (json.RawMessage, error)
+
+		// FindMany returns the values of the setting with the provided key for multiple scope ids, mapped by scope id.
+		FindMany(
+			ctx context.Context,
+			scope enum.SettingsScope,
+			scopeIDs []int64,
+			key string,
+		) (map[int64]json.RawMessage, error)
+
+		// FindAll returns the values of the setting with the provided key for all ids of the scope, mapped by scope id.
+		FindAll(ctx context.Context, scope enum.SettingsScope, key string) (map[int64]json.RawMessage, error)
+
+		// Upsert creates or updates the value of the setting with the provided key in the scope.
+		Upsert(
+			ctx context.Context,
+			scope enum.SettingsScope,
+			scopeID int64,
+			key string,
+			value json.RawMessage,
+			updatedBy int64,
+		) error
+
+		// Delete removes the setting with the provided key from the scope (noop if it doesn't exist).
+		Delete(ctx context.Context, scope enum.SettingsScope, scopeID int64, key string) error
+	}
+
+	// UsageMetricStore stores the daily usage of repositories, used for usage reporting.
+	// A space id of zero refers to the whole instance, otherwise the space and all its subspaces are included.
+	UsageMetricStore interface {
+		// AddGitTraffic adds the number of bytes transferred by git to the usage of the repository for the day.
+		AddGitTraffic(ctx context.Context, repoID, date, bytesIn, bytesOut int64) error
+
+		// AddActiveUser marks the principal as active in the repository for the day.
+		AddActiveUser(ctx context.Context, repoID, date, principalID int64) error
+
+		// Aggregate stores the disk usage and the pull request activity of all repositories for the day.
+		Aggregate(ctx context.Context, date int64) error
+
+		// ListDaily returns the usage of the space aggregated by day, for the days in the [from, to) range.
+		ListDaily(ctx context.Context, spaceID, from, to int64) ([]types.UsageDailyStats, error)
+
+		// CountActiveUsers returns the number of distinct active users of the space in the [from, to) range.
+		CountActiveUsers(ctx context.Context, spaceID, from, to int64) (int64, error)
+
+		// SumDiskUsage returns the current disk usage of all repositories of the space.
+		SumDiskUsage(ctx context.Context, spaceID int64) (int64, error)
+
+		// DeleteBefore deletes all usage data of the days before the provided date.
+		DeleteBefore(ctx context.Context, date int64) (int64, error)
+	}
+
+	// RunnerRegistrationTokenStore stores the tokens used by external runners to register in a space.
+	RunnerRegistrationTokenStore interface {
+		// FindByHash finds the registration token by its hash.
+		FindByHash(ctx context.Context, hash string) (*types.RunnerRegistrationToken, error)
+
+		// Create creates a new registration token.
+		Create(ctx context.Context, token *types.RunnerRegistrationToken) error
+
+		// Delete deletes the registration token of the space with the provided identifier.
+		Delete(ctx context.Context, spaceID int64, identifier string) error
+
+		// List lists the registration tokens of the space.
+		List(ctx context.Context, spaceID int64) ([]*types.RunnerRegistrationToken, error)
+	}
+
+	// RunnerStore stores the external runners.
+	RunnerStore interface {
+		// Find finds the runner by id.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByTokenHash finds the runner by the hash of its token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// UpdateHeartbeat stores the heartbeat and the reported capacity of the runner.
+		UpdateHeartbeat(ctx context.Context, id int64, capacity, running int, heartbeat int64) error
+
+		// Delete deletes the runner of the space with the provided identifier.
+		Delete(ctx context.Context, spaceID int64, identifier string) error
+
+		// List lists the runners of the space.
+		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.Runner, error)
+
+		// Count returns the number of runners of the space.
+		Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error)
+	}
+
+	// RegistryStore stores the artifact registries.
+	RegistryStore interface {
+		// Find finds the registry by id.
+		Find(ctx context.Context, id int64) (*types.Registry, error)
+
+		// FindByIdentifier finds the registry of the space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Registry, error)
+
+		// Create creates a new registry.
+		Create(ctx context.Context, registry *types.Registry) error
+
+		// Update updates the description and the retention policy of the registry.
+		Update(ctx context.Context, registry *types.Registry) error
+
+		// Delete deletes the registry with all its artifacts.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the registries of the space.
+		List(ctx context.Context, spaceID int64, filter *types.RegistryFilter) ([]*types.Registry, error)
+
+		// Count returns the number of registries of the space.
+		Count(ctx context.Context, spaceID int64, filter *types.RegistryFilter) (int64, error)
+
+		// ListAll lists all registries of all spaces.
+		ListAll(ctx context.Context) ([]*types.Registry, error)
+	}
+
+	// RegistryArtifactStore stores the artifacts of the registries.
+	RegistryArtifactStore interface {
+		// FindByName finds the artifact of the registry by its name.
+		FindByName(ctx context.Context, registryID int64, name string) (*types.RegistryArtifact, error)
+
+		// Create creates a new artifact.
+		Create(ctx context.Context, artifact *types.RegistryArtifact) error
+
+		// Touch updates the last modification time of the artifact.
+		Touch(ctx context.Context, id int64, updated int64) error
+
+		// Delete deletes the artifact with all its versions.
+		Delete(ctx context.Context, id int64) error
+
+		// DeleteEmpty deletes all artifacts of the registry without any versions.
+		DeleteEmpty(ctx context.Context, registryID int64) (int64, error)
+
+		// List lists the artifacts of the registry.
+		List(ctx context.Context, registryID int64, filter types.ListQueryFilter) ([]*types.RegistryArtifact, error)
+
+		// Count returns the number of artifacts of the registry.
+		Count(ctx context.Context, registryID int64, filter types.ListQueryFilter) (int64, error)
+	}
+
+	// RegistryVersionStore stores the versions of the registry artifacts.
+	RegistryVersionStore interface {
+		// Find finds the file of an artifact version. The file name is empty for container images.
+		Find(ctx context.Context, artifactID int64, name, fileName string) (*types.RegistryVersion, error)
+
+		// Create creates a new artifact version.
+		Create(ctx context.Context, version *types.RegistryVersion) error
+
+		// Update points an existing artifact version at new content.
+		Update(ctx context.Context, version *types.RegistryVersion) error
+
+		// Delete deletes the artifact version by id.
+		Delete(ctx context.Context, id int64) error
+
+		// DeleteByName deletes all files of the artifact version with the provided name.
+		DeleteByName(ctx context.Context, artifactID int64, name string) (int64, error)
+
+		// List lists the versions of the artifact, most recently updated first.
+		List(ctx context.Context, artifactID int64, filter types.ListQueryFilter) ([]*types.RegistryVersion, error)
+
+		// Count returns the number of versions of the artifact.
+		Count(ctx context.Context, artifactID int64, filter types.ListQueryFilter) (int64, error)
+
+		// ListNames lists the distinct version names of the artifact in ascending order.
+		ListNames(ctx context.Context, artifactID int64) ([]string, error)
+
+		// ListByDigest lists the versions of the artifact pointing at the provided digest.
+		ListByDigest(ctx context.Context, artifactID int64, digest string) ([]*types.RegistryVersion, error)
+
+		// ListByRegistry lists all versions of all artifacts of the registry,
+		// grouped by artifact and ordered by creation time, newest first.
+		ListByRegistry(ctx context.Context, registryID int64) ([]*types.RegistryVersion, error)
+	}
+
+	// RegistryBlobStore stores the content addressable blobs of the registries.
+	RegistryBlobStore interface {
+		// Find finds the blob of the registry by its digest.
+		Find(ctx context.Context, registryID int64, digest string) (*types.RegistryBlob, error)
+
+		// Create creates a new blob. Returns store.ErrDuplicate if the registry already has a blob with the digest.
+		Create(ctx context.Context, blob *types.RegistryBlob) error
+
+		// Delete deletes the blob by id.
+		Delete(ctx context.Context, id int64) error
+
+		// ListCreatedBefore lists the blobs of the registry created before the provided time.
+		ListCreatedBefore(ctx context.Context, registryID int64, before int64) ([]*types.RegistryBlob, error)
+
+		// ReplaceReferences replaces the digests referenced by a manifest of the registry.
+		ReplaceReferences(ctx context.Context, registryID int64, manifestDigest string, digests []string) error
+
+		// ListReferences lists all manifest references of the registry.
+		ListReferences(ctx context.Context, registryID int64) ([]*types.RegistryManifestReference, error)
+
+		// DeleteReferences deletes the references of a manifest of the registry.
+		DeleteReferences(ctx context.Context, registryID int64, manifestDigest string) error
+	}
+
+	// RegistryUploadStore stores the in-progress chunked blob uploads.
+	RegistryUploadStore interface {
+		// FindByIdentifier finds the upload of the registry by its identifier.
+		FindByIdentifier(ctx context.Context, registryID int64, identifier string) (*types.RegistryUpload, error)
+
+		// Create creates a new upload.
+		Create(ctx context.Context, upload *types.RegistryUpload) error
+
+		// AddPart records an uploaded chunk. The update fails with store.ErrVersionConflict
+		// if another chunk has been added concurrently.
+		AddPart(ctx context.Context, upload *types.RegistryUpload, size, updated int64) error
+
+		// Delete deletes the upload by id.
+		Delete(ctx context.Context, id int64) error
+
+		// ListUpdatedBefore lists the uploads of all registries that haven't been updated since the provided time.
+		ListUpdatedBefore(ctx context.Context, before int64) ([]*types.RegistryUpload, error)
+	}
 )
@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AnnouncementStore = (*AnnouncementStore)(nil)
+
+// NewAnnouncementStore returns a new AnnouncementStore.
+func NewAnnouncementStore(db *sqlx.DB) *AnnouncementStore {
+	return &AnnouncementStore{
+		db: db,
+	}
+}
+
+// AnnouncementStore implements store.AnnouncementStore backed by a relational database.
+type AnnouncementStore struct {
+	db *sqlx.DB
+}
+
+type announcement struct {
+	ID          int64                     `db:"announcement_id"`
+	Message     string                    `db:"announcement_message"`
+	Severity    enum.AnnouncementSeverity `db:"announcement_severity"`
+	Starts      int64                     `db:"announcement_starts"`
+	Ends        int64                     `db:"announcement_ends"`
+	Dismissible bool                      `db:"announcement_dismissible"`
+	CreatedBy   int64                     `db:"announcement_created_by"`
+	Created     int64                     `db:"announcement_created"`
+	Updated     int64                     `db:"announcement_updated"`
+}
+
+const (
+	announcementColumns = `
+		 announcement_id
+		,announcement_message
+		,announcement_severity
+		,announcement_starts
+		,announcement_ends
+		,announcement_dismissible
+		,announcement_created_by
+		,announcement_created
+		,announcement_updated`
+)
+
+// Find finds the announcement by id.
+func (s *AnnouncementStore) Find(ctx context.Context, id int64) (*types.Announcement, error) {
+	const sqlQuery = `
+	SELECT` + announcementColumns + `
+	FROM announcements
+	WHERE announcement_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &announcement{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find announcement")
+	}
+
+	return mapToAnnouncement(dst), nil
+}
+
+// Create creates a new announcement.
+func (s *AnnouncementStore) Create(ctx context.Context, in *types.Announcement) error {
+	const sqlQuery = `
+	INSERT INTO announcements (
+		 announcement_message
+		,announcement_severity
+		,announcement_starts
+		,announcement_ends
+		,announcement_dismissible
+		,announcement_created_by
+		,announcement_created
+		,announcement_updated
+	) values (
+		 :announcement_message
+		,:announcement_severity
+		,:announcement_starts
+		,:announcement_ends
+		,:announcement_dismissible
+		,:announcement_created_by
+		,:announcement_created
+		,:announcement_updated
+	) RETURNING announcement_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAnnouncement(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind announcement object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing announcement.
+func (s *AnnouncementStore) Update(ctx context.Context, in *types.Announcement) error {
+	const sqlQuery = `
+	UPDATE announcements
+	SET
+		 announcement_message = :announcement_message
+		,announcement_severity = :announcement_severity
+		,announcement_starts = :announcement_starts
+		,announcement_ends = :announcement_ends
+		,announcement_dismissible = :announcement_dismissible
+		,announcement_updated = :announcement_updated
+	WHERE announcement_id = :announcement_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAnnouncement(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind announcement object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update announcement")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the announcement.
+func (s *AnnouncementStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM announcements
+	WHERE announcement_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists all announcements, newest first.
+func (s *AnnouncementStore) List(ctx context.Context) ([]*types.Announcement, error) {
+	stmt := database.Builder.
+		Select(announcementColumns).
+		From("announcements").
+		OrderBy("announcement_id DESC")
+
+	return s.list(ctx, stmt)
+}
+
+// ListActive lists the announcements shown at the provided time (unix milliseconds), newest first.
+func (s *AnnouncementStore) ListActive(ctx context.Context, now int64) ([]*types.Announcement, error) {
+	stmt := database.Builder.
+		Select(announcementColumns).
+		From("announcements").
+		Where("announcement_starts <= ?", now).
+		Where(squirrel.Or{
+			squirrel.Eq{"announcement_ends": 0},
+			squirrel.Gt{"announcement_ends": now},
+		}).
+		OrderBy("announcement_id DESC")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *AnnouncementStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.Announcement, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert announcement list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*announcement{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing announcement list query")
+	}
+
+	announcements := make([]*types.Announcement, len(dst))
+	for i := range dst {
+		announcements[i] = mapToAnnouncement(dst[i])
+	}
+
+	return announcements, nil
+}
+
+func mapToAnnouncement(in *announcement) *types.Announcement {
+	return &types.Announcement{
+		ID:          in.ID,
+		Message:     in.Message,
+		Severity:    in.Severity,
+		Starts:      in.Starts,
+		Ends:        in.Ends,
+		Dismissible: in.Dismissible,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapToInternalAnnouncement(in *types.Announcement) *announcement {
+	return &announcement{
+		ID:          in.ID,
+		Message:     in.Message,
+		Severity:    in.Severity,
+		Starts:      in.Starts,
+		Ends:        in.Ends,
+		Dismissible: in.Dismissible,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
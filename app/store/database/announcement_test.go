@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAnnouncementStore_ListActive(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	announcementStore := database.NewAnnouncementStore(db)
+
+	ctx := context.Background()
+
+	announcements := []*types.Announcement{
+		{Message: "always"},
+		{Message: "scheduled", Starts: 200},
+		{Message: "expired", Ends: 100},
+		{Message: "window", Starts: 50, Ends: 200},
+	}
+	for _, a := range announcements {
+		a.Severity = enum.AnnouncementSeverityInfo
+		if err := announcementStore.Create(ctx, a); err != nil {
+			t.Fatalf("failed to create announcement: %v", err)
+		}
+	}
+
+	active, err := announcementStore.ListActive(ctx, 150)
+	if err != nil {
+		t.Fatalf("failed to list active announcements: %v", err)
+	}
+
+	if len(active) != 2 || active[0].Message != "window" || active[1].Message != "always" {
+		t.Errorf("unexpected active announcements: %+v", active)
+	}
+
+	announcements[1].Starts = 100
+	if err = announcementStore.Update(ctx, announcements[1]); err != nil {
+		t.Fatalf("failed to update announcement: %v", err)
+	}
+	if err = announcementStore.Delete(ctx, announcements[0].ID); err != nil {
+		t.Fatalf("failed to delete announcement: %v", err)
+	}
+
+	active, err = announcementStore.ListActive(ctx, 150)
+	if err != nil {
+		t.Fatalf("failed to list active announcements: %v", err)
+	}
+
+	if len(active) != 2 || active[0].Message != "window" || active[1].Message != "scheduled" {
+		t.Errorf("unexpected active announcements after update: %+v", active)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AuditLogStore = (*AuditLogStore)(nil)
+
+// NewAuditLogStore returns a new AuditLogStore.
+func NewAuditLogStore(db *sqlx.DB) *AuditLogStore {
+	return &AuditLogStore{
+		db: db,
+	}
+}
+
+// AuditLogStore implements store.AuditLogStore backed by a relational database.
+type AuditLogStore struct {
+	db *sqlx.DB
+}
+
+const (
+	auditLogColumns = `
+		 audit_log_id
+		,audit_log_request_id
+		,audit_log_principal_id
+		,audit_log_method
+		,audit_log_path
+		,audit_log_query
+		,audit_log_remote_addr
+		,audit_log_user_agent
+		,audit_log_status
+		,audit_log_request_body
+		,audit_log_request_body_truncated
+		,audit_log_duration
+		,audit_log_created`
+)
+
+// Create creates a new audit log entry.
+func (s *AuditLogStore) Create(ctx context.Context, auditLog *types.AuditLog) error {
+	const sqlQuery = `
+	INSERT INTO audit_logs (
+		 audit_log_request_id
+		,audit_log_principal_id
+		,audit_log_method
+		,audit_log_path
+		,audit_log_query
+		,audit_log_remote_addr
+		,audit_log_user_agent
+		,audit_log_status
+		,audit_log_request_body
+		,audit_log_request_body_truncated
+		,audit_log_duration
+		,audit_log_created
+	) values (
+		 :audit_log_request_id
+		,:audit_log_principal_id
+		,:audit_log_method
+		,:audit_log_path
+		,:audit_log_query
+		,:audit_log_remote_addr
+		,:audit_log_user_agent
+		,:audit_log_status
+		,:audit_log_request_body
+		,:audit_log_request_body_truncated
+		,:audit_log_duration
+		,:audit_log_created
+	) RETURNING audit_log_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, auditLog)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit log object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&auditLog.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List lists the audit logs matching the filter, latest first.
+func (s *AuditLogStore) List(
+	ctx context.Context,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, error) {
+	stmt := database.Builder.
+		Select(auditLogColumns).
+		From("audit_logs")
+
+	stmt = applyAuditLogFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("audit_log_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert audit log list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.AuditLog{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing audit log list query")
+	}
+
+	return dst, nil
+}
+
+// Count counts the audit logs matching the filter.
+func (s *AuditLogStore) Count(ctx context.Context, filter *types.AuditLogFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_logs")
+
+	stmt = applyAuditLogFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert audit log count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing audit log count query")
+	}
+
+	return count, nil
+}
+
+// DeleteOld removes all audit logs that are older than the provided time.
+func (s *AuditLogStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_logs").
+		Where("audit_log_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete audit logs query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted audit logs")
+	}
+
+	return n, nil
+}
+
+func applyAuditLogFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditLogFilter,
+) squirrel.SelectBuilder {
+	if filter.RequestID != "" {
+		stmt = stmt.Where("audit_log_request_id = ?", filter.RequestID)
+	}
+
+	if filter.PrincipalID > 0 {
+		stmt = stmt.Where("audit_log_principal_id = ?", filter.PrincipalID)
+	}
+
+	if filter.Since > 0 {
+		stmt = stmt.Where("audit_log_created >= ?", filter.Since)
+	}
+
+	return stmt
+}
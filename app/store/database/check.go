@@ -166,6 +166,10 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
+	if database.IsMariaDB(s.db.DriverName()) {
+		return s.upsertMariaDB(ctx, db, check)
+	}
+
 	query, arg, err := db.BindNamed(sqlQuery, mapInternalCheck(check))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check object")
@@ -178,6 +182,78 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 	return nil
 }
 
+// upsertMariaDB is the MariaDB version of Upsert. It uses ON DUPLICATE KEY UPDATE instead of ON CONFLICT,
+// and the ID, author and creation time of the check are read back in a separate query.
+func (s *CheckStore) upsertMariaDB(ctx context.Context, db dbtx.Accessor, check *types.Check) error {
+	const sqlQuery = `
+	INSERT INTO checks (
+		 check_created_by
+		,check_created
+		,check_updated
+		,check_repo_id
+		,check_commit_sha
+		,check_uid
+		,check_status
+		,check_summary
+		,check_link
+		,check_payload
+		,check_metadata
+		,check_payload_kind
+		,check_payload_version
+		,check_started
+		,check_ended
+	) VALUES (
+		 :check_created_by
+		,:check_created
+		,:check_updated
+		,:check_repo_id
+		,:check_commit_sha
+		,:check_uid
+		,:check_status
+		,:check_summary
+		,:check_link
+		,:check_payload
+		,:check_metadata
+		,:check_payload_kind
+		,:check_payload_version
+		,:check_started
+		,:check_ended
+	)
+	ON DUPLICATE KEY UPDATE
+		 check_updated = :check_updated
+		,check_status = :check_status
+		,check_summary = :check_summary
+		,check_link = :check_link
+		,check_payload = :check_payload
+		,check_metadata = :check_metadata
+		,check_payload_kind = :check_payload_kind
+		,check_payload_version = :check_payload_version
+		,check_started = :check_started
+		,check_ended = :check_ended`
+
+	const sqlQueryReturning = `
+	SELECT check_id, check_created_by, check_created
+	FROM checks
+	WHERE check_repo_id = $1 AND check_commit_sha = $2 AND check_uid = $3`
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCheck(check))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	err = db.QueryRowContext(ctx, sqlQueryReturning, check.RepoID, check.CommitSHA, check.Identifier).
+		Scan(&check.ID, &check.CreatedBy, &check.Created)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to read upserted status check")
+	}
+
+	return nil
+}
+
 // Count counts status check results for a specific commit in a repo.
 func (s *CheckStore) Count(ctx context.Context,
 	repoID int64,
@@ -222,10 +298,27 @@ func (s *CheckStore) List(ctx context.Context,
 
 	stmt = s.applyOpts(stmt, opts.Query)
 
-	stmt = stmt.
-		Limit(database.Limit(opts.Size)).
-		Offset(database.Offset(opts.Page, opts.Size)).
-		OrderBy("check_updated desc")
+	stmt = stmt.Limit(database.Limit(opts.Size))
+
+	columns := []database.KeysetColumn{
+		{Name: "check_updated", Desc: true},
+		{Name: "check_id", Desc: true},
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if opts.CursorID > 0 {
+		var err error
+		stmt, err = database.ApplyKeyset(ctx, db, stmt, "checks", "check_id", opts.CursorID,
+			squirrel.Eq{"check_repo_id": repoID, "check_commit_sha": commitSHA}, columns)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		stmt = stmt.
+			Offset(database.Offset(opts.Page, opts.Size)).
+			OrderBy(database.KeysetOrderBy(columns)...)
+	}
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -234,8 +327,6 @@ func (s *CheckStore) List(ctx context.Context,
 
 	dst := make([]*check, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
-
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks query")
 	}
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	gitness_store "github.com/harness/gitness/store"
+	gitness_database "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+	"github.com/rs/xid"
+)
+
+// dollarSQLiteDriver is sqlite wrapped by the placeholder translation of the mariadb driver.
+// It allows running the stores through the translation without a mariadb server.
+const dollarSQLiteDriver = "sqlite3-dollar"
+
+func init() {
+	sql.Register(dollarSQLiteDriver, gitness_database.NewDollarDriver(&sqlite3.SQLiteDriver{}))
+}
+
+func setupDollarDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s.db?mode=memory&cache=shared&_foreign_keys=on", xid.New().String())
+	sqlDB, err := sql.Open(dollarSQLiteDriver, dsn)
+	if err != nil {
+		t.Fatalf("Error opening db, err: %v", err)
+	}
+
+	db := sqlx.NewDb(sqlDB, "sqlite3")
+	t.Cleanup(func() { db.Close() })
+
+	if err = migrate.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("Error migrating db, err: %v", err)
+	}
+
+	return db
+}
+
+func TestDatabase_DollarDriverRepoList(t *testing.T) {
+	db := setupDollarDB(t)
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepos(ctx, t, repoStore, 0, numTestRepos, 1)
+
+	count, err := repoStore.Count(ctx, 1, &types.RepoFilter{})
+	if err != nil {
+		t.Fatalf("failed to count repos %v", err)
+	}
+	if count != numTestRepos {
+		t.Errorf("count = %v, want %v", count, numTestRepos)
+	}
+
+	// the keyset condition repeats the cursor values, which requires the arguments to be rebound.
+	filter := &types.RepoFilter{Size: 3, Sort: enum.RepoAttrIdentifier, Order: enum.OrderAsc}
+	total := 0
+	for {
+		repos, err := repoStore.List(ctx, 1, filter)
+		if err != nil {
+			t.Fatalf("failed to list repos %v", err)
+		}
+		if len(repos) == 0 {
+			break
+		}
+		total += len(repos)
+		filter.CursorID = repos[len(repos)-1].ID
+	}
+	if total != numTestRepos {
+		t.Errorf("paged count = %v, want %v", total, numTestRepos)
+	}
+}
+
+func TestDatabase_DollarDriverSettings(t *testing.T) {
+	db := setupDollarDB(t)
+
+	principalStore, _, _, _ := setupStores(t, db)
+	settingsStore := database.NewSettingsStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	for _, value := range []string{`{"a":1}`, `{"a":2}`} {
+		err := settingsStore.Upsert(ctx, enum.SettingsScopeRepo, 1, "key", json.RawMessage(value), userID)
+		if err != nil {
+			t.Fatalf("failed to upsert setting %v", err)
+		}
+
+		got, err := settingsStore.Find(ctx, enum.SettingsScopeRepo, 1, "key")
+		if err != nil {
+			t.Fatalf("failed to find setting %v", err)
+		}
+		if string(got) != value {
+			t.Errorf("value = %s, want %s", got, value)
+		}
+	}
+
+	if err := settingsStore.Delete(ctx, enum.SettingsScopeRepo, 1, "key"); err != nil {
+		t.Fatalf("failed to delete setting %v", err)
+	}
+
+	_, err := settingsStore.Find(ctx, enum.SettingsScopeRepo, 1, "key")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ events.OutboxStore = (*EventOutboxStore)(nil)
+
+// NewEventOutboxStore returns a new EventOutboxStore.
+func NewEventOutboxStore(db *sqlx.DB) *EventOutboxStore {
+	return &EventOutboxStore{
+		db: db,
+	}
+}
+
+// EventOutboxStore implements events.OutboxStore backed by a relational database.
+type EventOutboxStore struct {
+	db *sqlx.DB
+}
+
+type eventOutboxMessage struct {
+	ID       int64  `db:"event_outbox_id"`
+	StreamID string `db:"event_outbox_stream_id"`
+	Payload  []byte `db:"event_outbox_payload"`
+	Created  int64  `db:"event_outbox_created"`
+}
+
+const (
+	eventOutboxColumns = `
+		 event_outbox_id
+		,event_outbox_stream_id
+		,event_outbox_payload
+		,event_outbox_created`
+)
+
+// Create stores the outbox message as part of the transaction stored in the context (if any).
+func (s *EventOutboxStore) Create(ctx context.Context, msg *events.OutboxMessage) error {
+	const sqlQuery = `
+	INSERT INTO event_outbox (
+		 event_outbox_stream_id
+		,event_outbox_payload
+		,event_outbox_created
+	) values (
+		 :event_outbox_stream_id
+		,:event_outbox_payload
+		,:event_outbox_created
+	) RETURNING event_outbox_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &eventOutboxMessage{
+		StreamID: msg.StreamID,
+		Payload:  msg.Payload,
+		Created:  msg.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind outbox message object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&msg.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Publish calls the publish function for up to limit of the oldest outbox messages
+// and deletes the messages that were published successfully.
+// On postgres the messages are locked for the duration of the transaction,
+// which allows multiple instances to publish outbox messages concurrently.
+func (s *EventOutboxStore) Publish(
+	ctx context.Context,
+	limit int,
+	publish func(msg *events.OutboxMessage) error,
+) (int, error) {
+	stmt := database.Builder.
+		Select(eventOutboxColumns).
+		From("event_outbox").
+		OrderBy("event_outbox_id ASC").
+		Limit(uint64(limit))
+
+	if !strings.HasPrefix(s.db.DriverName(), "sqlite") {
+		stmt = stmt.Suffix("FOR UPDATE SKIP LOCKED")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	var published []int64
+	var publishErr error
+
+	err = dbtx.New(s.db).WithTx(ctx, func(ctx context.Context) error {
+		db := dbtx.GetAccessor(ctx, s.db)
+
+		dst := make([]*eventOutboxMessage, 0, limit)
+		if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to list outbox messages")
+		}
+
+		for _, msg := range dst {
+			// stop on first error to preserve the order of the events.
+			publishErr = publish(&events.OutboxMessage{
+				ID:       msg.ID,
+				StreamID: msg.StreamID,
+				Payload:  msg.Payload,
+				Created:  msg.Created,
+			})
+			if publishErr != nil {
+				break
+			}
+
+			published = append(published, msg.ID)
+		}
+
+		if len(published) == 0 {
+			return nil
+		}
+
+		deleteSQL, deleteArgs, err := database.Builder.
+			Delete("event_outbox").
+			Where(squirrel.Eq{"event_outbox_id": published}).
+			ToSql()
+		if err != nil {
+			return errors.Wrap(err, "Failed to convert delete query to sql")
+		}
+
+		if _, err := db.ExecContext(ctx, deleteSQL, deleteArgs...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to delete published outbox messages")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if publishErr != nil {
+		return len(published), fmt.Errorf("failed to publish outbox message: %w", publishErr)
+	}
+
+	return len(published), nil
+}
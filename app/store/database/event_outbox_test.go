@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+func TestEventOutboxStore_Publish(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	outboxStore := database.NewEventOutboxStore(db)
+
+	for _, streamID := range []string{"s1", "s2", "s3"} {
+		if err := outboxStore.Create(ctx, &events.OutboxMessage{StreamID: streamID, Payload: []byte("x")}); err != nil {
+			t.Fatalf("failed to create outbox message: %v", err)
+		}
+	}
+
+	// messages of a rolled back transaction must never be published.
+	errRollback := errors.New("rollback")
+	err := dbtx.New(db).WithTx(ctx, func(ctx context.Context) error {
+		if err := outboxStore.Create(ctx, &events.OutboxMessage{StreamID: "rolled-back", Payload: []byte("x")}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("expected rollback error, got: %v", err)
+	}
+
+	var published []string
+	errPublish := errors.New("publish failed")
+
+	n, err := outboxStore.Publish(ctx, 10, func(msg *events.OutboxMessage) error {
+		if msg.StreamID == "s2" {
+			return errPublish
+		}
+		published = append(published, msg.StreamID)
+		return nil
+	})
+	if !errors.Is(err, errPublish) {
+		t.Fatalf("expected publish error, got: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("published = %d, want 1", n)
+	}
+
+	n, err = outboxStore.Publish(ctx, 10, func(msg *events.OutboxMessage) error {
+		published = append(published, msg.StreamID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to publish outbox messages: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("published = %d, want 2", n)
+	}
+
+	want := []string{"s1", "s2", "s3"}
+	if len(published) != len(want) {
+		t.Fatalf("published = %v, want %v", published, want)
+	}
+	for i := range want {
+		if published[i] != want[i] {
+			t.Errorf("published = %v, want %v", published, want)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ExternalHookStore = (*ExternalHookStore)(nil)
+
+// NewExternalHookStore returns a new ExternalHookStore.
+func NewExternalHookStore(db *sqlx.DB, encrypter encrypt.Encrypter) *ExternalHookStore {
+	return &ExternalHookStore{
+		db:        db,
+		encrypter: encrypter,
+	}
+}
+
+// ExternalHookStore implements store.ExternalHookStore backed by a relational database.
+// External hook secrets are encrypted before they are stored in the database.
+type ExternalHookStore struct {
+	db        *sqlx.DB
+	encrypter encrypt.Encrypter
+}
+
+// externalHook is an internal representation used to store external hook data in the database.
+type externalHook struct {
+	ID          int64                 `db:"external_hook_id"`
+	Version     int64                 `db:"external_hook_version"`
+	SpaceID     int64                 `db:"external_hook_space_id"`
+	Identifier  string                `db:"external_hook_uid"`
+	Description string                `db:"external_hook_description"`
+	Type        enum.ExternalHookType `db:"external_hook_type"`
+	Target      string                `db:"external_hook_target"`
+	Secret      []byte                `db:"external_hook_secret"`
+	Timeout     int64                 `db:"external_hook_timeout"`
+	Enabled     bool                  `db:"external_hook_enabled"`
+	CreatedBy   int64                 `db:"external_hook_created_by"`
+	Created     int64                 `db:"external_hook_created"`
+	Updated     int64                 `db:"external_hook_updated"`
+}
+
+const (
+	externalHookColumns = `
+		 external_hook_id
+		,external_hook_version
+		,external_hook_space_id
+		,external_hook_uid
+		,external_hook_description
+		,external_hook_type
+		,external_hook_target
+		,external_hook_secret
+		,external_hook_timeout
+		,external_hook_enabled
+		,external_hook_created_by
+		,external_hook_created
+		,external_hook_updated`
+
+	externalHookSelectBase = `
+	SELECT` + externalHookColumns + `
+	FROM external_hooks`
+)
+
+// Find finds the external hook by id.
+func (s *ExternalHookStore) Find(ctx context.Context, id int64) (*types.ExternalHook, error) {
+	const sqlQuery = externalHookSelectBase + `
+		WHERE external_hook_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &externalHook{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return s.mapToExternalHook(dst)
+}
+
+// FindByIdentifier finds the external hook of a space by its identifier.
+func (s *ExternalHookStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.ExternalHook, error) {
+	const sqlQuery = externalHookSelectBase + `
+		WHERE external_hook_space_id = $1 AND LOWER(external_hook_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &externalHook{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return s.mapToExternalHook(dst)
+}
+
+// Create creates a new external hook.
+func (s *ExternalHookStore) Create(ctx context.Context, hook *types.ExternalHook) error {
+	const sqlQuery = `
+		INSERT INTO external_hooks (
+			 external_hook_version
+			,external_hook_space_id
+			,external_hook_uid
+			,external_hook_description
+			,external_hook_type
+			,external_hook_target
+			,external_hook_secret
+			,external_hook_timeout
+			,external_hook_enabled
+			,external_hook_created_by
+			,external_hook_created
+			,external_hook_updated
+		) values (
+			 :external_hook_version
+			,:external_hook_space_id
+			,:external_hook_uid
+			,:external_hook_description
+			,:external_hook_type
+			,:external_hook_target
+			,:external_hook_secret
+			,:external_hook_timeout
+			,:external_hook_enabled
+			,:external_hook_created_by
+			,:external_hook_created
+			,:external_hook_updated
+		) RETURNING external_hook_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbHook, err := s.mapToInternalExternalHook(hook)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbHook)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind external hook object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&hook.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing external hook.
+func (s *ExternalHookStore) Update(ctx context.Context, hook *types.ExternalHook) error {
+	const sqlQuery = `
+		UPDATE external_hooks
+		SET
+			 external_hook_version = :external_hook_version
+			,external_hook_updated = :external_hook_updated
+			,external_hook_uid = :external_hook_uid
+			,external_hook_description = :external_hook_description
+			,external_hook_type = :external_hook_type
+			,external_hook_target = :external_hook_target
+			,external_hook_secret = :external_hook_secret
+			,external_hook_timeout = :external_hook_timeout
+			,external_hook_enabled = :external_hook_enabled
+		WHERE external_hook_id = :external_hook_id AND external_hook_version = :external_hook_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbHook, err := s.mapToInternalExternalHook(hook)
+	if err != nil {
+		return err
+	}
+
+	// update Version (used for optimistic locking) and Updated time
+	dbHook.Version++
+	dbHook.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbHook)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind external hook object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update external hook")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	hook.Version = dbHook.Version
+	hook.Updated = dbHook.Updated
+
+	return nil
+}
+
+// Delete deletes the external hook with the given id.
+func (s *ExternalHookStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM external_hooks
+		WHERE external_hook_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the external hooks of the provided spaces, ordered by space and identifier.
+func (s *ExternalHookStore) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	onlyEnabled bool,
+) ([]*types.ExternalHook, error) {
+	if len(spaceIDs) == 0 {
+		return []*types.ExternalHook{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(externalHookColumns).
+		From("external_hooks").
+		Where(squirrel.Eq{"external_hook_space_id": spaceIDs}).
+		OrderBy("external_hook_space_id", "external_hook_uid")
+
+	if onlyEnabled {
+		stmt = stmt.Where("external_hook_enabled = ?", true)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert external hook list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*externalHook{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing external hook list query")
+	}
+
+	hooks := make([]*types.ExternalHook, len(dst))
+	for i := range dst {
+		if hooks[i], err = s.mapToExternalHook(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return hooks, nil
+}
+
+// ReencryptSecrets re-encrypts the secrets of all external hooks using the provided function.
+func (s *ExternalHookStore) ReencryptSecrets(
+	ctx context.Context,
+	reencrypt func(ciphertext []byte) ([]byte, bool, error),
+) (int64, error) {
+	return database.Reencrypt(ctx, s.db, "external_hooks", "external_hook_id", "external_hook_secret", reencrypt)
+}
+
+func (s *ExternalHookStore) mapToExternalHook(hook *externalHook) (*types.ExternalHook, error) {
+	var secret string
+	if len(hook.Secret) > 0 {
+		var err error
+		secret, err = s.encrypter.Decrypt(hook.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret of external hook %d: %w", hook.ID, err)
+		}
+	}
+
+	return &types.ExternalHook{
+		ID:          hook.ID,
+		Version:     hook.Version,
+		SpaceID:     hook.SpaceID,
+		Identifier:  hook.Identifier,
+		Description: hook.Description,
+		Type:        hook.Type,
+		Target:      hook.Target,
+		Secret:      secret,
+		Timeout:     hook.Timeout,
+		Enabled:     hook.Enabled,
+		CreatedBy:   hook.CreatedBy,
+		Created:     hook.Created,
+		Updated:     hook.Updated,
+	}, nil
+}
+
+func (s *ExternalHookStore) mapToInternalExternalHook(hook *types.ExternalHook) (*externalHook, error) {
+	secret := []byte{}
+	if hook.Secret != "" {
+		var err error
+		secret, err = s.encrypter.Encrypt(hook.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt secret of external hook %d: %w", hook.ID, err)
+		}
+	}
+
+	return &externalHook{
+		ID:          hook.ID,
+		Version:     hook.Version,
+		SpaceID:     hook.SpaceID,
+		Identifier:  hook.Identifier,
+		Description: hook.Description,
+		Type:        hook.Type,
+		Target:      hook.Target,
+		Secret:      secret,
+		Timeout:     hook.Timeout,
+		Enabled:     hook.Enabled,
+		CreatedBy:   hook.CreatedBy,
+		Created:     hook.Created,
+		Updated:     hook.Updated,
+	}, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IdempotencyKeyStore = (*IdempotencyKeyStore)(nil)
+
+// NewIdempotencyKeyStore returns a new IdempotencyKeyStore.
+func NewIdempotencyKeyStore(db *sqlx.DB) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{
+		db: db,
+	}
+}
+
+// IdempotencyKeyStore implements store.IdempotencyKeyStore backed by a relational database.
+type IdempotencyKeyStore struct {
+	db *sqlx.DB
+}
+
+type idempotencyKey struct {
+	ID                  int64  `db:"idempotency_key_id"`
+	PrincipalID         int64  `db:"idempotency_key_principal_id"`
+	Key                 string `db:"idempotency_key_key"`
+	Fingerprint         string `db:"idempotency_key_fingerprint"`
+	ResponseStatus      int    `db:"idempotency_key_response_status"`
+	ResponseContentType string `db:"idempotency_key_response_content_type"`
+	ResponseBody        string `db:"idempotency_key_response_body"`
+	Created             int64  `db:"idempotency_key_created"`
+}
+
+const (
+	idempotencyKeyColumns = `
+		 idempotency_key_id
+		,idempotency_key_principal_id
+		,idempotency_key_key
+		,idempotency_key_fingerprint
+		,idempotency_key_response_status
+		,idempotency_key_response_content_type
+		,idempotency_key_response_body
+		,idempotency_key_created`
+)
+
+// Find finds the idempotency key of a principal.
+func (s *IdempotencyKeyStore) Find(
+	ctx context.Context,
+	principalID int64,
+	key string,
+) (*types.IdempotencyKey, error) {
+	const sqlQuery = `
+	SELECT` + idempotencyKeyColumns + `
+	FROM idempotency_keys
+	WHERE idempotency_key_principal_id = $1 AND idempotency_key_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &idempotencyKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find idempotency key")
+	}
+
+	return mapToIdempotencyKey(dst), nil
+}
+
+// Create creates a new idempotency key, returns store.ErrDuplicate if the principal already used the key.
+func (s *IdempotencyKeyStore) Create(ctx context.Context, idempotencyKey *types.IdempotencyKey) error {
+	const sqlQuery = `
+	INSERT INTO idempotency_keys (
+		 idempotency_key_principal_id
+		,idempotency_key_key
+		,idempotency_key_fingerprint
+		,idempotency_key_response_status
+		,idempotency_key_response_content_type
+		,idempotency_key_response_body
+		,idempotency_key_created
+	) values (
+		 :idempotency_key_principal_id
+		,:idempotency_key_key
+		,:idempotency_key_fingerprint
+		,:idempotency_key_response_status
+		,:idempotency_key_response_content_type
+		,:idempotency_key_response_body
+		,:idempotency_key_created
+	) RETURNING idempotency_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalIdempotencyKey(idempotencyKey))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind idempotency key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&idempotencyKey.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateResponse stores the response of the request the idempotency key was used for.
+func (s *IdempotencyKeyStore) UpdateResponse(ctx context.Context, idempotencyKey *types.IdempotencyKey) error {
+	const sqlQuery = `
+	UPDATE idempotency_keys
+	SET
+		 idempotency_key_response_status = :idempotency_key_response_status
+		,idempotency_key_response_content_type = :idempotency_key_response_content_type
+		,idempotency_key_response_body = :idempotency_key_response_body
+	WHERE idempotency_key_id = :idempotency_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalIdempotencyKey(idempotencyKey))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind idempotency key object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update idempotency key")
+	}
+
+	return nil
+}
+
+// Delete deletes an idempotency key.
+func (s *IdempotencyKeyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM idempotency_keys
+	WHERE idempotency_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete idempotency key")
+	}
+
+	return nil
+}
+
+// DeleteOld removes all idempotency keys that are older than the provided time.
+func (s *IdempotencyKeyStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("idempotency_keys").
+		Where("idempotency_key_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete idempotency keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete idempotency keys query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted idempotency keys")
+	}
+
+	return n, nil
+}
+
+func mapToIdempotencyKey(k *idempotencyKey) *types.IdempotencyKey {
+	return &types.IdempotencyKey{
+		ID:                  k.ID,
+		PrincipalID:         k.PrincipalID,
+		Key:                 k.Key,
+		Fingerprint:         k.Fingerprint,
+		ResponseStatus:      k.ResponseStatus,
+		ResponseContentType: k.ResponseContentType,
+		ResponseBody:        []byte(k.ResponseBody),
+		Created:             k.Created,
+	}
+}
+
+func mapToInternalIdempotencyKey(k *types.IdempotencyKey) *idempotencyKey {
+	return &idempotencyKey{
+		ID:                  k.ID,
+		PrincipalID:         k.PrincipalID,
+		Key:                 k.Key,
+		Fingerprint:         k.Fingerprint,
+		ResponseStatus:      k.ResponseStatus,
+		ResponseContentType: k.ResponseContentType,
+		ResponseBody:        string(k.ResponseBody),
+		Created:             k.Created,
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IntegrationStore = (*IntegrationStore)(nil)
+
+// NewIntegrationStore returns a new IntegrationStore.
+func NewIntegrationStore(db *sqlx.DB, encrypter encrypt.Encrypter) *IntegrationStore {
+	return &IntegrationStore{
+		db:        db,
+		encrypter: encrypter,
+	}
+}
+
+// IntegrationStore implements store.IntegrationStore backed by a relational database.
+// Integration URLs and tokens are encrypted before they are stored in the database.
+type IntegrationStore struct {
+	db        *sqlx.DB
+	encrypter encrypt.Encrypter
+}
+
+// integration is an internal representation used to store integration data in the database.
+type integration struct {
+	ID          int64                `db:"integration_id"`
+	Version     int64                `db:"integration_version"`
+	SpaceID     null.Int             `db:"integration_space_id"`
+	RepoID      null.Int             `db:"integration_repo_id"`
+	Identifier  string               `db:"integration_uid"`
+	Description string               `db:"integration_description"`
+	Type        enum.IntegrationType `db:"integration_type"`
+	URL         []byte               `db:"integration_url"`
+	Token       []byte               `db:"integration_token"`
+	Channel     string               `db:"integration_channel"`
+	Events      string               `db:"integration_events"`
+	Enabled     bool                 `db:"integration_enabled"`
+	CreatedBy   int64                `db:"integration_created_by"`
+	Created     int64                `db:"integration_created"`
+	Updated     int64                `db:"integration_updated"`
+}
+
+const (
+	integrationColumns = `
+		 integration_id
+		,integration_version
+		,integration_space_id
+		,integration_repo_id
+		,integration_uid
+		,integration_description
+		,integration_type
+		,integration_url
+		,integration_token
+		,integration_channel
+		,integration_events
+		,integration_enabled
+		,integration_created_by
+		,integration_created
+		,integration_updated`
+
+	integrationSelectBase = `
+	SELECT` + integrationColumns + `
+	FROM integrations`
+)
+
+// Find finds the integration by id.
+func (s *IntegrationStore) Find(ctx context.Context, id int64) (*types.Integration, error) {
+	const sqlQuery = integrationSelectBase + `
+		WHERE integration_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &integration{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return s.mapToIntegration(dst)
+}
+
+// FindByIdentifier finds the integration of the parent by its identifier.
+func (s *IntegrationStore) FindByIdentifier(
+	ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	identifier string,
+) (*types.Integration, error) {
+	stmt := database.Builder.
+		Select(integrationColumns).
+		From("integrations").
+		Where("LOWER(integration_uid) = ?", strings.ToLower(identifier))
+
+	stmt, err := integrationWhereParent(stmt, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &integration{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return s.mapToIntegration(dst)
+}
+
+// Create creates a new integration.
+func (s *IntegrationStore) Create(ctx context.Context, in *types.Integration) error {
+	const sqlQuery = `
+		INSERT INTO integrations (
+			 integration_version
+			,integration_space_id
+			,integration_repo_id
+			,integration_uid
+			,integration_description
+			,integration_type
+			,integration_url
+			,integration_token
+			,integration_channel
+			,integration_events
+			,integration_enabled
+			,integration_created_by
+			,integration_created
+			,integration_updated
+		) values (
+			 :integration_version
+			,:integration_space_id
+			,:integration_repo_id
+			,:integration_uid
+			,:integration_description
+			,:integration_type
+			,:integration_url
+			,:integration_token
+			,:integration_channel
+			,:integration_events
+			,:integration_enabled
+			,:integration_created_by
+			,:integration_created
+			,:integration_updated
+		) RETURNING integration_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIntegration, err := s.mapToInternalIntegration(in)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbIntegration)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind integration object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing integration.
+func (s *IntegrationStore) Update(ctx context.Context, in *types.Integration) error {
+	const sqlQuery = `
+		UPDATE integrations
+		SET
+			 integration_version = :integration_version
+			,integration_updated = :integration_updated
+			,integration_uid = :integration_uid
+			,integration_description = :integration_description
+			,integration_url = :integration_url
+			,integration_token = :integration_token
+			,integration_channel = :integration_channel
+			,integration_events = :integration_events
+			,integration_enabled = :integration_enabled
+		WHERE integration_id = :integration_id AND integration_version = :integration_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIntegration, err := s.mapToInternalIntegration(in)
+	if err != nil {
+		return err
+	}
+
+	// update Version (used for optimistic locking) and Updated time
+	dbIntegration.Version++
+	dbIntegration.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbIntegration)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind integration object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update integration")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	in.Version = dbIntegration.Version
+	in.Updated = dbIntegration.Updated
+
+	return nil
+}
+
+// Delete deletes the integration with the given id.
+func (s *IntegrationStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM integrations
+		WHERE integration_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the integrations of the parent, ordered by identifier.
+func (s *IntegrationStore) List(
+	ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+) ([]*types.Integration, error) {
+	stmt := database.Builder.
+		Select(integrationColumns).
+		From("integrations").
+		OrderBy("LOWER(integration_uid)")
+
+	stmt, err := integrationWhereParent(stmt, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, stmt)
+}
+
+// ListEnabled lists the enabled integrations of the repository and of the provided spaces.
+func (s *IntegrationStore) ListEnabled(
+	ctx context.Context,
+	repoID int64,
+	spaceIDs []int64,
+) ([]*types.Integration, error) {
+	stmt := database.Builder.
+		Select(integrationColumns).
+		From("integrations").
+		Where("integration_enabled = ?", true).
+		Where(squirrel.Or{
+			squirrel.Eq{"integration_repo_id": repoID},
+			squirrel.Eq{"integration_space_id": spaceIDs},
+		}).
+		OrderBy("integration_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ReencryptSecrets re-encrypts the URLs and tokens of all integrations using the provided function.
+func (s *IntegrationStore) ReencryptSecrets(
+	ctx context.Context,
+	reencrypt func(ciphertext []byte) ([]byte, bool, error),
+) (int64, error) {
+	urls, err := database.Reencrypt(ctx, s.db, "integrations", "integration_id", "integration_url", reencrypt)
+	if err != nil {
+		return 0, err
+	}
+
+	tokens, err := database.Reencrypt(ctx, s.db, "integrations", "integration_id", "integration_token", reencrypt)
+	if err != nil {
+		return 0, err
+	}
+
+	return urls + tokens, nil
+}
+
+func (s *IntegrationStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.Integration, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert integration list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*integration{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing integration list query")
+	}
+
+	integrations := make([]*types.Integration, len(dst))
+	for i := range dst {
+		if integrations[i], err = s.mapToIntegration(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return integrations, nil
+}
+
+func integrationWhereParent(
+	stmt squirrel.SelectBuilder,
+	parentType enum.ParentResourceType,
+	parentID int64,
+) (squirrel.SelectBuilder, error) {
+	switch parentType {
+	case enum.ParentResourceTypeRepo:
+		return stmt.Where("integration_repo_id = ?", parentID), nil
+	case enum.ParentResourceTypeSpace:
+		return stmt.Where("integration_space_id = ?", parentID), nil
+	default:
+		return stmt, fmt.Errorf("integration parent type '%s' is not supported", parentType)
+	}
+}
+
+func (s *IntegrationStore) mapToIntegration(in *integration) (*types.Integration, error) {
+	url, err := s.decrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt url of integration %d: %w", in.ID, err)
+	}
+
+	token, err := s.decrypt(in.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token of integration %d: %w", in.ID, err)
+	}
+
+	res := &types.Integration{
+		ID:          in.ID,
+		Version:     in.Version,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		URL:         url,
+		Token:       token,
+		Channel:     in.Channel,
+		Events:      integrationEventsFromString(in.Events),
+		Enabled:     in.Enabled,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	switch {
+	case in.RepoID.Valid && in.SpaceID.Valid:
+		return nil, fmt.Errorf("both repoID and spaceID are set for integration %d", in.ID)
+	case in.RepoID.Valid:
+		res.ParentType = enum.ParentResourceTypeRepo
+		res.ParentID = in.RepoID.Int64
+	case in.SpaceID.Valid:
+		res.ParentType = enum.ParentResourceTypeSpace
+		res.ParentID = in.SpaceID.Int64
+	default:
+		return nil, fmt.Errorf("neither repoID nor spaceID are set for integration %d", in.ID)
+	}
+
+	return res, nil
+}
+
+func (s *IntegrationStore) mapToInternalIntegration(in *types.Integration) (*integration, error) {
+	url, err := s.encrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt url of integration %d: %w", in.ID, err)
+	}
+
+	token, err := s.encrypt(in.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt token of integration %d: %w", in.ID, err)
+	}
+
+	res := &integration{
+		ID:          in.ID,
+		Version:     in.Version,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		URL:         url,
+		Token:       token,
+		Channel:     in.Channel,
+		Events:      integrationEventsToString(in.Events),
+		Enabled:     in.Enabled,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	switch in.ParentType {
+	case enum.ParentResourceTypeRepo:
+		res.RepoID = null.IntFrom(in.ParentID)
+	case enum.ParentResourceTypeSpace:
+		res.SpaceID = null.IntFrom(in.ParentID)
+	default:
+		return nil, fmt.Errorf("integration parent type '%s' is not supported", in.ParentType)
+	}
+
+	return res, nil
+}
+
+// encrypt encrypts a secret of the integration. An empty secret is stored as is.
+func (s *IntegrationStore) encrypt(secret string) ([]byte, error) {
+	if secret == "" {
+		return []byte{}, nil
+	}
+	return s.encrypter.Encrypt(secret)
+}
+
+// decrypt decrypts a secret of the integration. An empty secret is returned as is.
+func (s *IntegrationStore) decrypt(secret []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", nil
+	}
+	return s.encrypter.Decrypt(secret)
+}
+
+func integrationEventsFromString(eventsString string) []enum.IntegrationEvent {
+	if eventsString == "" {
+		return []enum.IntegrationEvent{}
+	}
+
+	rawEvents := strings.Split(eventsString, triggersSeparator)
+
+	events := make([]enum.IntegrationEvent, len(rawEvents))
+	for i, rawEvent := range rawEvents {
+		events[i] = enum.IntegrationEvent(rawEvent)
+	}
+
+	return events
+}
+
+func integrationEventsToString(events []enum.IntegrationEvent) string {
+	rawEvents := make([]string, len(events))
+	for i := range events {
+		rawEvents[i] = string(events[i])
+	}
+
+	return strings.Join(rawEvents, triggersSeparator)
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestIntegrationStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	integrationStore := database.NewIntegrationStore(db, encrypter)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	spaceIntegration := &types.Integration{
+		ParentID:   1,
+		ParentType: enum.ParentResourceTypeSpace,
+		Identifier: "Team",
+		Type:       enum.IntegrationTypeTeams,
+		URL:        "https://example.webhook.office.com/hook",
+		Events:     []enum.IntegrationEvent{enum.IntegrationEventPullReqMerged},
+		Enabled:    true,
+		CreatedBy:  userID,
+	}
+	repoIntegration := &types.Integration{
+		ParentID:   1,
+		ParentType: enum.ParentResourceTypeRepo,
+		Identifier: "slack",
+		Type:       enum.IntegrationTypeSlack,
+		Token:      "xoxb-token",
+		Channel:    "#reviews",
+		Events: []enum.IntegrationEvent{
+			enum.IntegrationEventPullReqCreated,
+			enum.IntegrationEventPullReqReviewSubmitted,
+		},
+		CreatedBy: userID,
+	}
+
+	for _, integration := range []*types.Integration{spaceIntegration, repoIntegration} {
+		if err = integrationStore.Create(ctx, integration); err != nil {
+			t.Fatalf("failed to create integration: %v", err)
+		}
+	}
+
+	err = integrationStore.Create(ctx, &types.Integration{
+		ParentID:   1,
+		ParentType: enum.ParentResourceTypeSpace,
+		Identifier: "team",
+		Type:       enum.IntegrationTypeTeams,
+		CreatedBy:  userID,
+	})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got: %v", err)
+	}
+
+	found, err := integrationStore.FindByIdentifier(ctx, enum.ParentResourceTypeSpace, 1, "TEAM")
+	if err != nil {
+		t.Fatalf("failed to find integration: %v", err)
+	}
+
+	if found.ID != spaceIntegration.ID || found.URL != spaceIntegration.URL || len(found.Events) != 1 {
+		t.Errorf("unexpected integration: %+v", found)
+	}
+
+	var rawURL []byte
+	err = db.Get(&rawURL, "SELECT integration_url FROM integrations WHERE integration_id = $1", found.ID)
+	if err != nil {
+		t.Fatalf("failed to read stored url: %v", err)
+	}
+
+	if string(rawURL) == spaceIntegration.URL {
+		t.Errorf("integration url is stored unencrypted")
+	}
+
+	enabled, err := integrationStore.ListEnabled(ctx, 1, []int64{1})
+	if err != nil {
+		t.Fatalf("failed to list enabled integrations: %v", err)
+	}
+
+	if len(enabled) != 1 || enabled[0].ID != spaceIntegration.ID {
+		t.Errorf("unexpected enabled integrations: %+v", enabled)
+	}
+
+	repoIntegration.Enabled = true
+	repoIntegration.Channel = "#merges"
+	if err = integrationStore.Update(ctx, repoIntegration); err != nil {
+		t.Fatalf("failed to update integration: %v", err)
+	}
+
+	stale := *repoIntegration
+	stale.Version = 0
+	if err = integrationStore.Update(ctx, &stale); !errors.Is(err, gitness_store.ErrVersionConflict) {
+		t.Fatalf("expected version conflict, got: %v", err)
+	}
+
+	found, err = integrationStore.Find(ctx, repoIntegration.ID)
+	if err != nil {
+		t.Fatalf("failed to find integration: %v", err)
+	}
+
+	if !found.Enabled || found.Channel != "#merges" || found.Token != "xoxb-token" || len(found.Events) != 2 {
+		t.Errorf("unexpected updated integration: %+v", found)
+	}
+
+	enabled, err = integrationStore.ListEnabled(ctx, 1, []int64{1})
+	if err != nil {
+		t.Fatalf("failed to list enabled integrations: %v", err)
+	}
+
+	if len(enabled) != 2 {
+		t.Errorf("expected 2 enabled integrations, got %d", len(enabled))
+	}
+
+	if err = integrationStore.Delete(ctx, spaceIntegration.ID); err != nil {
+		t.Fatalf("failed to delete integration: %v", err)
+	}
+
+	list, err := integrationStore.List(ctx, enum.ParentResourceTypeSpace, 1)
+	if err != nil {
+		t.Fatalf("failed to list integrations: %v", err)
+	}
+
+	if len(list) != 0 {
+		t.Errorf("expected no space integrations after delete, got %d", len(list))
+	}
+
+	_, err = integrationStore.Find(ctx, spaceIntegration.ID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
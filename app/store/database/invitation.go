@@ -0,0 +1,341 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.InvitationStore = (*InvitationStore)(nil)
+
+// NewInvitationStore returns a new InvitationStore.
+func NewInvitationStore(db *sqlx.DB) *InvitationStore {
+	return &InvitationStore{
+		db: db,
+	}
+}
+
+// InvitationStore implements store.InvitationStore backed by a relational database.
+type InvitationStore struct {
+	db *sqlx.DB
+}
+
+type invitation struct {
+	ID         int64       `db:"invitation_id"`
+	Email      string      `db:"invitation_email"`
+	TokenHash  string      `db:"invitation_token_hash"`
+	SpaceID    null.Int    `db:"invitation_space_id"`
+	Role       null.String `db:"invitation_role"`
+	CreatedBy  int64       `db:"invitation_created_by"`
+	Created    int64       `db:"invitation_created"`
+	Expires    int64       `db:"invitation_expires"`
+	AcceptedBy null.Int    `db:"invitation_accepted_by"`
+	Accepted   null.Int    `db:"invitation_accepted"`
+	Sent       int64       `db:"invitation_sent"`
+}
+
+const (
+	invitationColumns = `
+		 invitation_id
+		,invitation_email
+		,invitation_token_hash
+		,invitation_space_id
+		,invitation_role
+		,invitation_created_by
+		,invitation_created
+		,invitation_expires
+		,invitation_accepted_by
+		,invitation_accepted
+		,invitation_sent`
+
+	invitationSelectBase = `
+	SELECT` + invitationColumns + `
+	FROM user_invitations`
+)
+
+// Find finds the invitation by id.
+func (s *InvitationStore) Find(ctx context.Context, id int64) (*types.Invitation, error) {
+	const sqlQuery = invitationSelectBase + `
+	WHERE invitation_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &invitation{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find invitation")
+	}
+
+	return mapToInvitation(dst), nil
+}
+
+// FindByTokenHash finds the invitation by the hash of its token.
+func (s *InvitationStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Invitation, error) {
+	const sqlQuery = invitationSelectBase + `
+	WHERE invitation_token_hash = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &invitation{}
+	if err := db.GetContext(ctx, dst, sqlQuery, tokenHash); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find invitation by token")
+	}
+
+	return mapToInvitation(dst), nil
+}
+
+// Create creates a new invitation.
+func (s *InvitationStore) Create(ctx context.Context, in *types.Invitation) error {
+	const sqlQuery = `
+	INSERT INTO user_invitations (
+		 invitation_email
+		,invitation_token_hash
+		,invitation_space_id
+		,invitation_role
+		,invitation_created_by
+		,invitation_created
+		,invitation_expires
+		,invitation_accepted_by
+		,invitation_accepted
+		,invitation_sent
+	) values (
+		 :invitation_email
+		,:invitation_token_hash
+		,:invitation_space_id
+		,:invitation_role
+		,:invitation_created_by
+		,:invitation_created
+		,:invitation_expires
+		,:invitation_accepted_by
+		,:invitation_accepted
+		,:invitation_sent
+	) RETURNING invitation_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalInvitation(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind invitation object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateToken replaces the token of the invitation and updates the expiry and sent time.
+func (s *InvitationStore) UpdateToken(ctx context.Context, in *types.Invitation) error {
+	const sqlQuery = `
+	UPDATE user_invitations
+	SET
+		 invitation_token_hash = :invitation_token_hash
+		,invitation_expires = :invitation_expires
+		,invitation_sent = :invitation_sent
+	WHERE invitation_id = :invitation_id AND invitation_accepted IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalInvitation(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind invitation object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update invitation token")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// MarkAccepted marks the invitation as accepted by the user.
+// It fails with gitness_store.ErrResourceNotFound if the invitation was already accepted.
+func (s *InvitationStore) MarkAccepted(ctx context.Context, id int64, acceptedBy int64, accepted int64) error {
+	const sqlQuery = `
+	UPDATE user_invitations
+	SET
+		 invitation_accepted_by = $1
+		,invitation_accepted = $2
+	WHERE invitation_id = $3 AND invitation_accepted IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, acceptedBy, accepted, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark invitation as accepted")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the invitation.
+func (s *InvitationStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM user_invitations
+	WHERE invitation_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the invitations, newest first.
+func (s *InvitationStore) List(ctx context.Context, filter *types.InvitationFilter) ([]*types.Invitation, error) {
+	stmt := database.Builder.
+		Select(invitationColumns).
+		From("user_invitations").
+		OrderBy("invitation_id DESC")
+
+	stmt = applyInvitationFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert invitation list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*invitation{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing invitation list query")
+	}
+
+	invitations := make([]*types.Invitation, len(dst))
+	for i := range dst {
+		invitations[i] = mapToInvitation(dst[i])
+	}
+
+	return invitations, nil
+}
+
+// Count returns the number of invitations matching the filter.
+func (s *InvitationStore) Count(ctx context.Context, filter *types.InvitationFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("user_invitations")
+
+	stmt = applyInvitationFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert invitation count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing invitation count query")
+	}
+
+	return count, nil
+}
+
+func applyInvitationFilter(stmt squirrel.SelectBuilder, filter *types.InvitationFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(invitation_email) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	if filter.Pending {
+		stmt = stmt.Where("invitation_accepted IS NULL")
+	}
+
+	if filter.SpaceID != nil {
+		stmt = stmt.Where("invitation_space_id = ?", *filter.SpaceID)
+	}
+
+	return stmt
+}
+
+func mapToInvitation(in *invitation) *types.Invitation {
+	var role *enum.MembershipRole
+	if in.Role.Valid {
+		r := enum.MembershipRole(in.Role.String)
+		role = &r
+	}
+
+	return &types.Invitation{
+		ID:         in.ID,
+		Email:      in.Email,
+		TokenHash:  in.TokenHash,
+		SpaceID:    in.SpaceID.Ptr(),
+		Role:       role,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Expires:    in.Expires,
+		AcceptedBy: in.AcceptedBy.Ptr(),
+		Accepted:   in.Accepted.Ptr(),
+		Sent:       in.Sent,
+	}
+}
+
+func mapToInternalInvitation(in *types.Invitation) *invitation {
+	var role null.String
+	if in.Role != nil {
+		role = null.StringFrom(string(*in.Role))
+	}
+
+	return &invitation{
+		ID:         in.ID,
+		Email:      in.Email,
+		TokenHash:  in.TokenHash,
+		SpaceID:    null.IntFromPtr(in.SpaceID),
+		Role:       role,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Expires:    in.Expires,
+		AcceptedBy: null.IntFromPtr(in.AcceptedBy),
+		Accepted:   null.IntFromPtr(in.Accepted),
+		Sent:       in.Sent,
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestInvitationStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	invitationStore := database.NewInvitationStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	spaceID := int64(1)
+	role := enum.MembershipRoleContributor
+	now := time.Now().UnixMilli()
+
+	spaceInvitation := &types.Invitation{
+		Email:     "jane@example.com",
+		TokenHash: "hash-1",
+		SpaceID:   &spaceID,
+		Role:      &role,
+		CreatedBy: userID,
+		Created:   now,
+		Expires:   now + time.Hour.Milliseconds(),
+	}
+	userInvitation := &types.Invitation{
+		Email:     "john@example.com",
+		TokenHash: "hash-2",
+		CreatedBy: userID,
+		Created:   now + 1,
+		Expires:   now + time.Hour.Milliseconds(),
+	}
+
+	for _, invitation := range []*types.Invitation{spaceInvitation, userInvitation} {
+		if err := invitationStore.Create(ctx, invitation); err != nil {
+			t.Fatalf("failed to create invitation: %v", err)
+		}
+	}
+
+	found, err := invitationStore.FindByTokenHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("failed to find invitation by token hash: %v", err)
+	}
+
+	if found.ID != spaceInvitation.ID || found.SpaceID == nil || *found.SpaceID != spaceID ||
+		found.Role == nil || *found.Role != role || found.Sent != 0 {
+		t.Errorf("unexpected invitation: %+v", found)
+	}
+
+	spaceInvitation.TokenHash = "hash-3"
+	spaceInvitation.Sent = now
+	if err = invitationStore.UpdateToken(ctx, spaceInvitation); err != nil {
+		t.Fatalf("failed to update invitation token: %v", err)
+	}
+
+	if _, err = invitationStore.FindByTokenHash(ctx, "hash-1"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected previous token to be invalid, got: %v", err)
+	}
+
+	found, err = invitationStore.Find(ctx, spaceInvitation.ID)
+	if err != nil {
+		t.Fatalf("failed to find invitation: %v", err)
+	}
+
+	if found.TokenHash != "hash-3" || found.Sent != now {
+		t.Errorf("unexpected updated invitation: %+v", found)
+	}
+
+	list, err := invitationStore.List(ctx, &types.InvitationFilter{SpaceID: &spaceID})
+	if err != nil {
+		t.Fatalf("failed to list invitations: %v", err)
+	}
+
+	if len(list) != 1 || list[0].ID != spaceInvitation.ID {
+		t.Errorf("unexpected space invitations: %+v", list)
+	}
+
+	if err = invitationStore.MarkAccepted(ctx, userInvitation.ID, userID, now); err != nil {
+		t.Fatalf("failed to mark invitation accepted: %v", err)
+	}
+
+	err = invitationStore.MarkAccepted(ctx, userInvitation.ID, userID, now)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected accepting twice to fail, got: %v", err)
+	}
+
+	if err = invitationStore.UpdateToken(ctx, userInvitation); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected token update of accepted invitation to fail, got: %v", err)
+	}
+
+	count, err := invitationStore.Count(ctx, &types.InvitationFilter{Pending: true})
+	if err != nil {
+		t.Fatalf("failed to count invitations: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 pending invitation, got %d", count)
+	}
+
+	if err = invitationStore.Delete(ctx, spaceInvitation.ID); err != nil {
+		t.Fatalf("failed to delete invitation: %v", err)
+	}
+
+	if _, err = invitationStore.Find(ctx, spaceInvitation.ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected deleted invitation to be gone, got: %v", err)
+	}
+}
@@ -0,0 +1,614 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.IssueStore = (*IssueStore)(nil)
+
+// NewIssueStore returns a new IssueStore.
+func NewIssueStore(db *sqlx.DB,
+	pCache store.PrincipalInfoCache) *IssueStore {
+	return &IssueStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueStore implements store.IssueStore backed by a relational database.
+type IssueStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// issue is used to fetch issue data from the database.
+type issue struct {
+	ID      int64 `db:"issue_id"`
+	Version int64 `db:"issue_version"`
+	RepoID  int64 `db:"issue_repo_id"`
+	Number  int64 `db:"issue_number"`
+
+	CreatedBy int64 `db:"issue_created_by"`
+	Created   int64 `db:"issue_created"`
+	Updated   int64 `db:"issue_updated"`
+	Edited    int64 `db:"issue_edited"`
+
+	State       enum.IssueState `db:"issue_state"`
+	Title       string          `db:"issue_title"`
+	Description string          `db:"issue_description"`
+
+	ClosedBy        null.Int `db:"issue_closed_by"`
+	Closed          null.Int `db:"issue_closed"`
+	ClosedByPullReq null.Int `db:"issue_closed_by_pullreq"`
+}
+
+const (
+	issueColumns = `
+		 issue_id
+		,issue_version
+		,issue_repo_id
+		,issue_number
+		,issue_created_by
+		,issue_created
+		,issue_updated
+		,issue_edited
+		,issue_state
+		,issue_title
+		,issue_description
+		,issue_closed_by
+		,issue_closed
+		,issue_closed_by_pullreq`
+
+	issueSelectBase = `
+	SELECT` + issueColumns + `
+	FROM issues`
+)
+
+// Find finds the issue by id.
+func (s *IssueStore) Find(ctx context.Context, id int64) (*types.Issue, error) {
+	const sqlQuery = issueSelectBase + `
+	WHERE issue_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issue{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue")
+	}
+
+	return s.mapIssue(ctx, dst)
+}
+
+// FindByNumber finds the issue by repo ID and issue number.
+func (s *IssueStore) FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error) {
+	const sqlQuery = issueSelectBase + `
+	WHERE issue_repo_id = $1 AND issue_number = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issue{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, number); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue by number")
+	}
+
+	return s.mapIssue(ctx, dst)
+}
+
+// Create creates a new issue together with its labels and assignees.
+func (s *IssueStore) Create(ctx context.Context, in *types.Issue) error {
+	const sqlQuery = `
+	INSERT INTO issues (
+		 issue_version
+		,issue_repo_id
+		,issue_number
+		,issue_created_by
+		,issue_created
+		,issue_updated
+		,issue_edited
+		,issue_state
+		,issue_title
+		,issue_description
+		,issue_closed_by
+		,issue_closed
+		,issue_closed_by_pullreq
+	) values (
+		 :issue_version
+		,:issue_repo_id
+		,:issue_number
+		,:issue_created_by
+		,:issue_created
+		,:issue_updated
+		,:issue_edited
+		,:issue_state
+		,:issue_title
+		,:issue_description
+		,:issue_closed_by
+		,:issue_closed
+		,:issue_closed_by_pullreq
+	) RETURNING issue_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalIssue(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	if err = s.replaceLabels(ctx, in.ID, in.Labels); err != nil {
+		return err
+	}
+
+	return s.replaceAssignees(ctx, in.ID, in.AssigneeIDs)
+}
+
+// Update updates the issue together with its labels and assignees.
+func (s *IssueStore) Update(ctx context.Context, in *types.Issue) error {
+	const sqlQuery = `
+	UPDATE issues
+	SET
+	     issue_version = :issue_version
+		,issue_updated = :issue_updated
+		,issue_edited = :issue_edited
+		,issue_state = :issue_state
+		,issue_title = :issue_title
+		,issue_description = :issue_description
+		,issue_closed_by = :issue_closed_by
+		,issue_closed = :issue_closed
+		,issue_closed_by_pullreq = :issue_closed_by_pullreq
+	WHERE issue_id = :issue_id AND issue_version = :issue_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIssue := mapInternalIssue(in)
+	dbIssue.Version++
+	dbIssue.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbIssue)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update issue")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	if err = s.replaceLabels(ctx, in.ID, in.Labels); err != nil {
+		return err
+	}
+
+	if err = s.replaceAssignees(ctx, in.ID, in.AssigneeIDs); err != nil {
+		return err
+	}
+
+	updated, err := s.mapIssue(ctx, dbIssue)
+	if err != nil {
+		return err
+	}
+
+	*in = *updated
+
+	return nil
+}
+
+// UpdateOptLock updates the issue using the optimistic locking mechanism.
+func (s *IssueStore) UpdateOptLock(ctx context.Context, in *types.Issue,
+	mutateFn func(issue *types.Issue) error,
+) (*types.Issue, error) {
+	for {
+		dup := *in
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		in, err = s.Find(ctx, in.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the issue.
+func (s *IssueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `DELETE FROM issues WHERE issue_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	return nil
+}
+
+// Count of issues in a repository.
+func (s *IssueStore) Count(ctx context.Context, repoID int64, opts *types.IssueFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("issues").
+		Where("issue_repo_id = ?", repoID)
+
+	stmt = applyIssueFilter(stmt, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of issues in a repository.
+func (s *IssueStore) List(ctx context.Context, repoID int64, opts *types.IssueFilter) ([]*types.Issue, error) {
+	stmt := database.Builder.
+		Select(issueColumns).
+		From("issues").
+		Where("issue_repo_id = ?", repoID)
+
+	stmt = applyIssueFilter(stmt, opts)
+
+	// NOTE: string concatenation is safe because the
+	// order attribute is an enum and is not user-defined,
+	// and is therefore not subject to injection attacks.
+	opts.Sort, _ = opts.Sort.Sanitize()
+	desc := opts.Order == enum.OrderDesc
+	columns := []database.KeysetColumn{
+		{Name: "issue_" + string(opts.Sort), Desc: desc},
+		{Name: "issue_id", Desc: desc},
+	}
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy(database.KeysetOrderBy(columns)...)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*issue, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return s.mapSliceIssue(ctx, dst)
+}
+
+func applyIssueFilter(stmt squirrel.SelectBuilder, opts *types.IssueFilter) squirrel.SelectBuilder {
+	if len(opts.States) == 1 {
+		stmt = stmt.Where("issue_state = ?", opts.States[0])
+	} else if len(opts.States) > 1 {
+		stmt = stmt.Where(squirrel.Eq{"issue_state": opts.States})
+	}
+
+	if opts.Query != "" {
+		stmt = stmt.Where("LOWER(issue_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+	}
+
+	if opts.CreatedBy != 0 {
+		stmt = stmt.Where("issue_created_by = ?", opts.CreatedBy)
+	}
+
+	if opts.AssigneeID != 0 {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM issue_assignees
+			WHERE issue_assignee_issue_id = issue_id AND issue_assignee_principal_id = ?)`, opts.AssigneeID)
+	}
+
+	// issues have to carry all requested labels.
+	for _, label := range opts.Labels {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM issue_labels
+			WHERE issue_label_issue_id = issue_id AND issue_label_name = ?)`, label)
+	}
+
+	return stmt
+}
+
+// replaceLabels replaces all labels of the issue with the provided ones.
+func (s *IssueStore) replaceLabels(ctx context.Context, issueID int64, labels []string) error {
+	const sqlDelete = `DELETE FROM issue_labels WHERE issue_label_issue_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlDelete, issueID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete issue labels")
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("issue_labels").
+		Columns("issue_label_issue_id", "issue_label_name")
+	for _, label := range labels {
+		stmt = stmt.Values(issueID, label)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert issue labels")
+	}
+
+	return nil
+}
+
+// replaceAssignees replaces all assignees of the issue with the provided ones.
+func (s *IssueStore) replaceAssignees(ctx context.Context, issueID int64, assigneeIDs []int64) error {
+	const sqlDelete = `DELETE FROM issue_assignees WHERE issue_assignee_issue_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlDelete, issueID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete issue assignees")
+	}
+
+	if len(assigneeIDs) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("issue_assignees").
+		Columns("issue_assignee_issue_id", "issue_assignee_principal_id")
+	for _, assigneeID := range assigneeIDs {
+		stmt = stmt.Values(issueID, assigneeID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert issue assignees")
+	}
+
+	return nil
+}
+
+// listLabels returns the labels of the provided issues, ordered by name.
+func (s *IssueStore) listLabels(ctx context.Context, issueIDs []int64) (map[int64][]string, error) {
+	stmt := database.Builder.
+		Select("issue_label_issue_id", "issue_label_name").
+		From("issue_labels").
+		Where(squirrel.Eq{"issue_label_issue_id": issueIDs}).
+		OrderBy("issue_label_name")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]struct {
+		IssueID int64  `db:"issue_label_issue_id"`
+		Name    string `db:"issue_label_name"`
+	}, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list issue labels")
+	}
+
+	labels := make(map[int64][]string, len(issueIDs))
+	for _, label := range dst {
+		labels[label.IssueID] = append(labels[label.IssueID], label.Name)
+	}
+
+	return labels, nil
+}
+
+// listAssigneeIDs returns the principal IDs of the assignees of the provided issues.
+func (s *IssueStore) listAssigneeIDs(ctx context.Context, issueIDs []int64) (map[int64][]int64, error) {
+	stmt := database.Builder.
+		Select("issue_assignee_issue_id", "issue_assignee_principal_id").
+		From("issue_assignees").
+		Where(squirrel.Eq{"issue_assignee_issue_id": issueIDs}).
+		OrderBy("issue_assignee_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]struct {
+		IssueID     int64 `db:"issue_assignee_issue_id"`
+		PrincipalID int64 `db:"issue_assignee_principal_id"`
+	}, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list issue assignees")
+	}
+
+	assignees := make(map[int64][]int64, len(issueIDs))
+	for _, assignee := range dst {
+		assignees[assignee.IssueID] = append(assignees[assignee.IssueID], assignee.PrincipalID)
+	}
+
+	return assignees, nil
+}
+
+func mapIssue(in *issue) *types.Issue {
+	return &types.Issue{
+		ID:              in.ID,
+		Version:         in.Version,
+		Number:          in.Number,
+		RepoID:          in.RepoID,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Updated:         in.Updated,
+		Edited:          in.Edited,
+		State:           in.State,
+		Title:           in.Title,
+		Description:     in.Description,
+		ClosedBy:        in.ClosedBy.Ptr(),
+		Closed:          in.Closed.Ptr(),
+		ClosedByPullReq: in.ClosedByPullReq.Ptr(),
+		Labels:          []string{},
+		AssigneeIDs:     []int64{},
+		Author:          types.PrincipalInfo{},
+		Closer:          nil,
+		Assignees:       []*types.PrincipalInfo{},
+	}
+}
+
+func mapInternalIssue(in *types.Issue) *issue {
+	return &issue{
+		ID:              in.ID,
+		Version:         in.Version,
+		RepoID:          in.RepoID,
+		Number:          in.Number,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Updated:         in.Updated,
+		Edited:          in.Edited,
+		State:           in.State,
+		Title:           in.Title,
+		Description:     in.Description,
+		ClosedBy:        null.IntFromPtr(in.ClosedBy),
+		Closed:          null.IntFromPtr(in.Closed),
+		ClosedByPullReq: null.IntFromPtr(in.ClosedByPullReq),
+	}
+}
+
+func (s *IssueStore) mapIssue(ctx context.Context, in *issue) (*types.Issue, error) {
+	issues, err := s.mapSliceIssue(ctx, []*issue{in})
+	if err != nil {
+		return nil, err
+	}
+
+	return issues[0], nil
+}
+
+func (s *IssueStore) mapSliceIssue(ctx context.Context, issues []*issue) ([]*types.Issue, error) {
+	if len(issues) == 0 {
+		return []*types.Issue{}, nil
+	}
+
+	issueIDs := make([]int64, len(issues))
+	for i, in := range issues {
+		issueIDs[i] = in.ID
+	}
+
+	labels, err := s.listLabels(ctx, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeIDs, err := s.listAssigneeIDs(ctx, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect all principal IDs
+	ids := make([]int64, 0, 2*len(issues))
+	for _, in := range issues {
+		ids = append(ids, in.CreatedBy)
+		if in.ClosedBy.Valid {
+			ids = append(ids, in.ClosedBy.Int64)
+		}
+		ids = append(ids, assigneeIDs[in.ID]...)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue principal infos: %w", err)
+	}
+
+	// attach the labels, assignees and principal infos back to the slice items
+	m := make([]*types.Issue, len(issues))
+	for i, in := range issues {
+		m[i] = mapIssue(in)
+
+		if l, ok := labels[in.ID]; ok {
+			m[i].Labels = l
+		}
+
+		for _, assigneeID := range assigneeIDs[in.ID] {
+			m[i].AssigneeIDs = append(m[i].AssigneeIDs, assigneeID)
+			if assignee, ok := infoMap[assigneeID]; ok {
+				m[i].Assignees = append(m[i].Assignees, assignee)
+			} else {
+				log.Ctx(ctx).Warn().Int64("principal_id", assigneeID).Msg("failed to load issue assignee")
+			}
+		}
+
+		if author, ok := infoMap[in.CreatedBy]; ok {
+			m[i].Author = *author
+		}
+		if in.ClosedBy.Valid {
+			if closer, ok := infoMap[in.ClosedBy.Int64]; ok {
+				m[i].Closer = closer
+			}
+		}
+	}
+
+	return m, nil
+}
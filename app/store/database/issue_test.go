@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestIssueStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	issueStore := database.NewIssueStore(db, pCache)
+
+	issues := []*types.Issue{
+		{Number: 1, Title: "Login fails", Labels: []string{"bug", "auth"}, AssigneeIDs: []int64{userID}},
+		{Number: 2, Title: "Add dark mode", Labels: []string{"feature"}},
+		{Number: 3, Title: "Crash on logout", Labels: []string{"bug"}},
+	}
+	for _, issue := range issues {
+		issue.RepoID = 1
+		issue.CreatedBy = userID
+		issue.State = enum.IssueStateOpen
+		if err := issueStore.Create(ctx, issue); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+
+	issue, err := issueStore.FindByNumber(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("failed to find issue: %v", err)
+	}
+
+	if len(issue.Labels) != 2 || issue.Labels[0] != "auth" || issue.Labels[1] != "bug" {
+		t.Errorf("unexpected labels: %v", issue.Labels)
+	}
+	if len(issue.Assignees) != 1 || issue.Assignees[0].ID != userID {
+		t.Errorf("unexpected assignees: %+v", issue.Assignees)
+	}
+
+	issue, err = issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		issue.State = enum.IssueStateClosed
+		issue.Labels = []string{"bug"}
+		issue.AssigneeIDs = nil
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update issue: %v", err)
+	}
+
+	if issue.Version != 1 || len(issue.Labels) != 1 || len(issue.Assignees) != 0 {
+		t.Errorf("unexpected issue after update: %+v", issue)
+	}
+
+	tests := []struct {
+		name   string
+		filter types.IssueFilter
+		want   []int64
+	}{
+		{
+			name:   "all",
+			filter: types.IssueFilter{},
+			want:   []int64{3, 2, 1},
+		},
+		{
+			name:   "open",
+			filter: types.IssueFilter{States: []enum.IssueState{enum.IssueStateOpen}},
+			want:   []int64{3, 2},
+		},
+		{
+			name:   "label",
+			filter: types.IssueFilter{Labels: []string{"bug"}},
+			want:   []int64{3, 1},
+		},
+		{
+			name:   "query",
+			filter: types.IssueFilter{Query: "LOG"},
+			want:   []int64{3, 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			filter.Size = 10
+			filter.Order = enum.OrderDesc
+
+			list, err := issueStore.List(ctx, 1, &filter)
+			if err != nil {
+				t.Fatalf("failed to list issues: %v", err)
+			}
+
+			numbers := make([]int64, len(list))
+			for i, issue := range list {
+				numbers[i] = issue.Number
+			}
+
+			if len(numbers) != len(test.want) {
+				t.Fatalf("want issues %v, got %v", test.want, numbers)
+			}
+			for i := range numbers {
+				if numbers[i] != test.want[i] {
+					t.Fatalf("want issues %v, got %v", test.want, numbers)
+				}
+			}
+
+			count, err := issueStore.Count(ctx, 1, &filter)
+			if err != nil {
+				t.Fatalf("failed to count issues: %v", err)
+			}
+			if count != int64(len(test.want)) {
+				t.Errorf("want count %d, got %d", len(test.want), count)
+			}
+		})
+	}
+}
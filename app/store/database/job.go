@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -127,6 +128,67 @@ func (s *JobStore) ListByGroupID(ctx context.Context, groupID string) ([]*job.Jo
 	return dst, nil
 }
 
+// List returns a list of jobs matching the filter, most recently scheduled first.
+func (s *JobStore) List(ctx context.Context, filter *job.ListFilter) ([]*job.Job, error) {
+	stmt := database.Builder.
+		Select(jobColumns).
+		From("jobs")
+
+	stmt = applyJobFilter(stmt, filter)
+	stmt = stmt.OrderBy("job_scheduled DESC", "job_uid")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*job.Job, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list jobs")
+	}
+
+	return dst, nil
+}
+
+// Count returns the number of jobs matching the filter.
+func (s *JobStore) Count(ctx context.Context, filter *job.ListFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("jobs")
+
+	stmt = applyJobFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count jobs")
+	}
+
+	return count, nil
+}
+
+func applyJobFilter(stmt squirrel.SelectBuilder, filter *job.ListFilter) squirrel.SelectBuilder {
+	if filter.Type != "" {
+		stmt = stmt.Where("job_type = ?", filter.Type)
+	}
+
+	if len(filter.State) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"job_state": filter.State})
+	}
+
+	return stmt
+}
+
 // Create creates a new job.
 func (s *JobStore) Create(ctx context.Context, job *job.Job) error {
 	const sqlQuery = `
@@ -221,7 +283,12 @@ func (s *JobStore) Upsert(ctx context.Context, job *job.Job) error {
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(sqlQuery, job)
+	q := sqlQuery
+	if database.IsMariaDB(s.db.DriverName()) {
+		q = jobUpsertMariaDB
+	}
+
+	query, arg, err := db.BindNamed(q, job)
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind job object")
 	}
@@ -233,6 +300,56 @@ func (s *JobStore) Upsert(ctx context.Context, job *job.Job) error {
 	return nil
 }
 
+// jobUpsertMariaDB is the MariaDB version of the job upsert query. MariaDB doesn't support
+// a conditional update of a conflicting row, so each column gets updated only if the job definition has changed.
+// Columns that are part of the condition are updated last: The assignments are evaluated left to right.
+const jobUpsertMariaDB = `
+	INSERT INTO jobs (` + jobColumns + `
+	) VALUES (
+		 :job_uid
+		,:job_created
+		,:job_updated
+		,:job_type
+		,:job_priority
+		,:job_data
+		,:job_result
+		,:job_max_duration_seconds
+		,:job_max_retries
+		,:job_state
+		,:job_scheduled
+		,:job_total_executions
+		,:job_run_by
+		,:job_run_deadline
+		,:job_run_progress
+		,:job_last_executed
+		,:job_is_recurring
+		,:job_recurring_cron
+		,:job_consecutive_failures
+		,:job_last_failure_error
+		,:job_group_id
+	)
+	ON DUPLICATE KEY UPDATE
+		 job_updated = IF(` + jobChangedMariaDB + `, :job_updated, job_updated)
+		,job_result = IF(` + jobChangedMariaDB + `, :job_result, job_result)
+		,job_state = IF(` + jobChangedMariaDB + `, :job_state, job_state)
+		,job_scheduled = IF(` + jobChangedMariaDB + `, :job_scheduled, job_scheduled)
+		,job_type = IF(` + jobChangedMariaDB + `, :job_type, job_type)
+		,job_priority = IF(` + jobChangedMariaDB + `, :job_priority, job_priority)
+		,job_data = IF(` + jobChangedMariaDB + `, :job_data, job_data)
+		,job_max_duration_seconds = IF(` + jobChangedMariaDB + `, :job_max_duration_seconds, job_max_duration_seconds)
+		,job_max_retries = IF(` + jobChangedMariaDB + `, :job_max_retries, job_max_retries)
+		,job_is_recurring = IF(` + jobChangedMariaDB + `, :job_is_recurring, job_is_recurring)
+		,job_recurring_cron = IF(` + jobChangedMariaDB + `, :job_recurring_cron, job_recurring_cron)`
+
+const jobChangedMariaDB = `(
+		job_type <> :job_type OR
+		job_priority <> :job_priority OR
+		job_data <> :job_data OR
+		job_max_duration_seconds <> :job_max_duration_seconds OR
+		job_max_retries <> :job_max_retries OR
+		job_is_recurring <> :job_is_recurring OR
+		job_recurring_cron <> :job_recurring_cron)`
+
 // UpdateDefinition is used to update a job definition.
 func (s *JobStore) UpdateDefinition(ctx context.Context, job *job.Job) error {
 	const sqlQuery = `
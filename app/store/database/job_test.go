@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/job"
+)
+
+func TestDatabase_ListJobs(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	jobStore := database.NewJobStore(db)
+
+	ctx := context.Background()
+
+	for i, j := range []job.Job{
+		{UID: "job-1", Type: "import", State: job.JobStateFailed, Scheduled: 1},
+		{UID: "job-2", Type: "import", State: job.JobStateFinished, Scheduled: 2},
+		{UID: "job-3", Type: "cleanup", State: job.JobStateScheduled, Scheduled: 3, IsRecurring: true},
+	} {
+		j := j
+		if err := jobStore.Create(ctx, &j); err != nil {
+			t.Fatalf("failed to create job %d: %v", i, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter job.ListFilter
+		exp    []string
+	}{
+		{
+			name:   "all",
+			filter: job.ListFilter{Page: 1, Size: 10},
+			exp:    []string{"job-3", "job-2", "job-1"},
+		},
+		{
+			name:   "type",
+			filter: job.ListFilter{Page: 1, Size: 10, Type: "import"},
+			exp:    []string{"job-2", "job-1"},
+		},
+		{
+			name:   "state",
+			filter: job.ListFilter{Page: 1, Size: 10, State: []job.State{job.JobStateFailed, job.JobStateScheduled}},
+			exp:    []string{"job-3", "job-1"},
+		},
+		{
+			name:   "page",
+			filter: job.ListFilter{Page: 2, Size: 2},
+			exp:    []string{"job-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobs, err := jobStore.List(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list jobs: %v", err)
+			}
+
+			uids := make([]string, len(jobs))
+			for i, j := range jobs {
+				uids[i] = j.UID
+			}
+
+			if len(uids) != len(test.exp) {
+				t.Fatalf("got jobs %v, want %v", uids, test.exp)
+			}
+			for i := range uids {
+				if uids[i] != test.exp[i] {
+					t.Fatalf("got jobs %v, want %v", uids, test.exp)
+				}
+			}
+		})
+	}
+
+	count, err := jobStore.Count(ctx, &job.ListFilter{Type: "import"})
+	if err != nil {
+		t.Fatalf("failed to count jobs: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MalwareFindingStore = (*MalwareFindingStore)(nil)
+
+// NewMalwareFindingStore returns a new MalwareFindingStore.
+func NewMalwareFindingStore(db *sqlx.DB) *MalwareFindingStore {
+	return &MalwareFindingStore{
+		db: db,
+	}
+}
+
+// MalwareFindingStore implements store.MalwareFindingStore backed by a relational database.
+type MalwareFindingStore struct {
+	db *sqlx.DB
+}
+
+type malwareFinding struct {
+	ID          int64  `db:"malware_finding_id"`
+	RepoID      int64  `db:"malware_finding_repo_id"`
+	PrincipalID int64  `db:"malware_finding_principal_id"`
+	BlobSHA     string `db:"malware_finding_blob_sha"`
+	Path        string `db:"malware_finding_path"`
+	Signature   string `db:"malware_finding_signature"`
+	Created     int64  `db:"malware_finding_created"`
+}
+
+const (
+	malwareFindingColumns = `
+		 malware_finding_id
+		,malware_finding_repo_id
+		,malware_finding_principal_id
+		,malware_finding_blob_sha
+		,malware_finding_path
+		,malware_finding_signature
+		,malware_finding_created`
+)
+
+// Create records a new malware finding.
+func (s *MalwareFindingStore) Create(ctx context.Context, finding *types.MalwareFinding) error {
+	const sqlQuery = `
+	INSERT INTO malware_findings (
+		 malware_finding_repo_id
+		,malware_finding_principal_id
+		,malware_finding_blob_sha
+		,malware_finding_path
+		,malware_finding_signature
+		,malware_finding_created
+	) values (
+		 :malware_finding_repo_id
+		,:malware_finding_principal_id
+		,:malware_finding_blob_sha
+		,:malware_finding_path
+		,:malware_finding_signature
+		,:malware_finding_created
+	) RETURNING malware_finding_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMalwareFinding(finding))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind malware finding object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&finding.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of malware findings of the repository.
+func (s *MalwareFindingStore) Count(ctx context.Context, repoID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("malware_findings").
+		Where("malware_finding_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns the malware findings of the repository, newest first.
+func (s *MalwareFindingStore) List(
+	ctx context.Context,
+	repoID int64,
+	pagination types.Pagination,
+) ([]*types.MalwareFinding, error) {
+	stmt := database.Builder.
+		Select(malwareFindingColumns).
+		From("malware_findings").
+		Where("malware_finding_repo_id = ?", repoID).
+		OrderBy("malware_finding_created DESC", "malware_finding_id DESC").
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*malwareFinding, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list query")
+	}
+
+	result := make([]*types.MalwareFinding, len(dst))
+	for i, f := range dst {
+		result[i] = mapToMalwareFinding(f)
+	}
+
+	return result, nil
+}
+
+func mapToMalwareFinding(f *malwareFinding) *types.MalwareFinding {
+	return &types.MalwareFinding{
+		ID:          f.ID,
+		RepoID:      f.RepoID,
+		PrincipalID: f.PrincipalID,
+		BlobSHA:     f.BlobSHA,
+		Path:        f.Path,
+		Signature:   f.Signature,
+		Created:     f.Created,
+	}
+}
+
+func mapToInternalMalwareFinding(f *types.MalwareFinding) *malwareFinding {
+	return &malwareFinding{
+		ID:          f.ID,
+		RepoID:      f.RepoID,
+		PrincipalID: f.PrincipalID,
+		BlobSHA:     f.BlobSHA,
+		Path:        f.Path,
+		Signature:   f.Signature,
+		Created:     f.Created,
+	}
+}
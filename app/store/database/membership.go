@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -64,6 +65,9 @@ type membership struct {
 	Updated   int64 `db:"membership_updated"`
 
 	Role enum.MembershipRole `db:"membership_role"`
+
+	Expires      null.Int    `db:"membership_expires"`
+	PreviousRole null.String `db:"membership_previous_role"`
 }
 
 type membershipPrincipal struct {
@@ -83,7 +87,9 @@ const (
 		,membership_created_by
 		,membership_created
 		,membership_updated
-		,membership_role`
+		,membership_role
+		,membership_expires
+		,membership_previous_role`
 
 	membershipSelectBase = `
 	SELECT` + membershipColumns + `
@@ -131,6 +137,8 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,membership_created
 		,membership_updated
 		,membership_role
+		,membership_expires
+		,membership_previous_role
 	) values (
 		 :membership_space_id
 		,:membership_principal_id
@@ -138,6 +146,8 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,:membership_created
 		,:membership_updated
 		,:membership_role
+		,:membership_expires
+		,:membership_previous_role
 	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -154,13 +164,15 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 	return nil
 }
 
-// Update updates the role of a member of a space.
+// Update updates the role and the expiration of a member of a space.
 func (s *MembershipStore) Update(ctx context.Context, membership *types.Membership) error {
 	const sqlQuery = `
 	UPDATE memberships
 	SET
 		 membership_updated = :membership_updated
 		,membership_role = :membership_role
+		,membership_expires = :membership_expires
+		,membership_previous_role = :membership_previous_role
 	WHERE membership_space_id = :membership_space_id AND
 	      membership_principal_id = :membership_principal_id`
 
@@ -199,6 +211,28 @@ func (s *MembershipStore) Delete(ctx context.Context, key types.MembershipKey) e
 	return nil
 }
 
+// ListExpired returns all temporary memberships that expired before the provided time.
+func (s *MembershipStore) ListExpired(ctx context.Context, before int64, limit int) ([]types.Membership, error) {
+	const sqlQuery = membershipSelectBase + `
+	WHERE membership_expires IS NOT NULL AND membership_expires <= $1
+	ORDER BY membership_expires
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*membership, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired memberships")
+	}
+
+	result := make([]types.Membership, len(dst))
+	for i, m := range dst {
+		result[i] = mapToMembership(m)
+	}
+
+	return result, nil
+}
+
 // CountUsers returns a number of users memberships that matches the provided filter.
 func (s *MembershipStore) CountUsers(ctx context.Context,
 	spaceID int64,
@@ -388,6 +422,9 @@ func mapToMembership(m *membership) types.Membership {
 		Created:   m.Created,
 		Updated:   m.Updated,
 		Role:      m.Role,
+		Expires:   m.Expires.Ptr(),
+
+		PreviousRole: enum.MembershipRole(m.PreviousRole.String),
 	}
 }
 
@@ -399,6 +436,9 @@ func mapToInternalMembership(m *types.Membership) membership {
 		Created:     m.Created,
 		Updated:     m.Updated,
 		Role:        m.Role,
+
+		Expires:      null.IntFromPtr(m.Expires),
+		PreviousRole: null.NewString(string(m.PreviousRole), m.PreviousRole != ""),
 	}
 }
 
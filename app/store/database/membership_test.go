@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_MembershipListExpired(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	membershipStore := database.NewMembershipStore(db, pCache, spacePathStore, spaceStore)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	for id := int64(1); id <= 3; id++ {
+		createSpace(ctx, t, spaceStore, spacePathStore, userID, id, 0)
+	}
+
+	const now = int64(1000)
+	expired := now - 1
+	active := now + 1
+
+	// space 1: permanent membership, space 2: expired grant, space 3: active grant.
+	memberships := []types.Membership{
+		{Role: enum.MembershipRoleReader},
+		{Role: enum.MembershipRoleSpaceOwner, Expires: &expired, PreviousRole: enum.MembershipRoleReader},
+		{Role: enum.MembershipRoleContributor, Expires: &active},
+	}
+	for i := range memberships {
+		m := &memberships[i]
+		m.MembershipKey = types.MembershipKey{SpaceID: int64(i + 1), PrincipalID: userID}
+		m.CreatedBy = userID
+		if err := membershipStore.Create(ctx, m); err != nil {
+			t.Fatalf("failed to create membership %v", err)
+		}
+	}
+
+	list, err := membershipStore.ListExpired(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("failed to list expired memberships %v", err)
+	}
+	if len(list) != 1 || list[0].SpaceID != 2 {
+		t.Fatalf("expired memberships = %v, want membership of space 2", list)
+	}
+	if list[0].PreviousRole != enum.MembershipRoleReader || list[0].Expires == nil || *list[0].Expires != expired {
+		t.Errorf("expired membership = %+v, want previous role reader and expiry %d", list[0], expired)
+	}
+
+	if role, ok := list[0].EffectiveRole(now); !ok || role != enum.MembershipRoleReader {
+		t.Errorf("effective role = %q, want %q", role, enum.MembershipRoleReader)
+	}
+
+	// reverting the grant clears the expiry.
+	m := list[0]
+	m.Role, m.PreviousRole, m.Expires = m.PreviousRole, "", nil
+	if err = membershipStore.Update(ctx, &m); err != nil {
+		t.Fatalf("failed to update membership %v", err)
+	}
+
+	list, err = membershipStore.ListExpired(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("failed to list expired memberships %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("expired memberships after revert = %v, want none", list)
+	}
+}
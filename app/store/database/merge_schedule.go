@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeScheduleStore = (*MergeScheduleStore)(nil)
+
+// NewMergeScheduleStore returns a new MergeScheduleStore.
+func NewMergeScheduleStore(db *sqlx.DB) *MergeScheduleStore {
+	return &MergeScheduleStore{
+		db: db,
+	}
+}
+
+// MergeScheduleStore implements store.MergeScheduleStore backed by a relational database.
+type MergeScheduleStore struct {
+	db *sqlx.DB
+}
+
+type mergeSchedule struct {
+	PullReqID int64            `db:"merge_schedule_pullreq_id"`
+	CreatedBy int64            `db:"merge_schedule_created_by"`
+	Created   int64            `db:"merge_schedule_created"`
+	MergeAt   int64            `db:"merge_schedule_merge_at"`
+	Method    enum.MergeMethod `db:"merge_schedule_method"`
+	SourceSHA string           `db:"merge_schedule_source_sha"`
+	Title     string           `db:"merge_schedule_title"`
+	Message   string           `db:"merge_schedule_message"`
+}
+
+const (
+	mergeScheduleColumns = `
+		 merge_schedule_pullreq_id
+		,merge_schedule_created_by
+		,merge_schedule_created
+		,merge_schedule_merge_at
+		,merge_schedule_method
+		,merge_schedule_source_sha
+		,merge_schedule_title
+		,merge_schedule_message`
+)
+
+// Find returns the merge schedule of the pull request.
+func (s *MergeScheduleStore) Find(ctx context.Context, prID int64) (*types.MergeSchedule, error) {
+	const sqlQuery = `
+	SELECT` + mergeScheduleColumns + `
+	FROM pullreq_merge_schedules
+	WHERE merge_schedule_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeSchedule{}
+	if err := db.GetContext(ctx, dst, sqlQuery, prID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge schedule")
+	}
+
+	return mapToMergeSchedule(dst), nil
+}
+
+// Upsert creates or replaces the merge schedule of the pull request.
+func (s *MergeScheduleStore) Upsert(ctx context.Context, schedule *types.MergeSchedule) error {
+	const sqlQueryInsert = `
+	INSERT INTO pullreq_merge_schedules (
+		 merge_schedule_pullreq_id
+		,merge_schedule_created_by
+		,merge_schedule_created
+		,merge_schedule_merge_at
+		,merge_schedule_method
+		,merge_schedule_source_sha
+		,merge_schedule_title
+		,merge_schedule_message
+	) VALUES (
+		 :merge_schedule_pullreq_id
+		,:merge_schedule_created_by
+		,:merge_schedule_created
+		,:merge_schedule_merge_at
+		,:merge_schedule_method
+		,:merge_schedule_source_sha
+		,:merge_schedule_title
+		,:merge_schedule_message
+	)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (merge_schedule_pullreq_id) DO
+	UPDATE SET
+		 merge_schedule_created_by = :merge_schedule_created_by
+		,merge_schedule_created = :merge_schedule_created
+		,merge_schedule_merge_at = :merge_schedule_merge_at
+		,merge_schedule_method = :merge_schedule_method
+		,merge_schedule_source_sha = :merge_schedule_source_sha
+		,merge_schedule_title = :merge_schedule_title
+		,merge_schedule_message = :merge_schedule_message`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 merge_schedule_created_by = :merge_schedule_created_by
+		,merge_schedule_created = :merge_schedule_created
+		,merge_schedule_merge_at = :merge_schedule_merge_at
+		,merge_schedule_method = :merge_schedule_method
+		,merge_schedule_source_sha = :merge_schedule_source_sha
+		,merge_schedule_title = :merge_schedule_title
+		,merge_schedule_message = :merge_schedule_message`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeSchedule(schedule))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge schedule object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the merge schedule of the pull request.
+// It returns false if the pull request wasn't scheduled for merging.
+func (s *MergeScheduleStore) Delete(ctx context.Context, prID int64) (bool, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_merge_schedules
+	WHERE merge_schedule_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
+
+// ListDue returns the merge schedules with a merge time before the provided time, the earliest first.
+func (s *MergeScheduleStore) ListDue(ctx context.Context, before int64) ([]*types.MergeSchedule, error) {
+	const sqlQuery = `
+	SELECT` + mergeScheduleColumns + `
+	FROM pullreq_merge_schedules
+	WHERE merge_schedule_merge_at < $1
+	ORDER BY merge_schedule_merge_at ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*mergeSchedule{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing merge schedule list query")
+	}
+
+	schedules := make([]*types.MergeSchedule, len(dst))
+	for i := range dst {
+		schedules[i] = mapToMergeSchedule(dst[i])
+	}
+
+	return schedules, nil
+}
+
+func mapToMergeSchedule(in *mergeSchedule) *types.MergeSchedule {
+	return &types.MergeSchedule{
+		PullReqID: in.PullReqID,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		MergeAt:   in.MergeAt,
+		Method:    in.Method,
+		SourceSHA: in.SourceSHA,
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+}
+
+func mapToInternalMergeSchedule(in *types.MergeSchedule) *mergeSchedule {
+	return &mergeSchedule{
+		PullReqID: in.PullReqID,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		MergeAt:   in.MergeAt,
+		Method:    in.Method,
+		SourceSHA: in.SourceSHA,
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+}
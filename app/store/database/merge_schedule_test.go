@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_MergeSchedule(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	scheduleStore := database.NewMergeScheduleStore(db)
+
+	pr := &types.PullReq{
+		Number:           1,
+		CreatedBy:        userID,
+		State:            enum.PullReqStateOpen,
+		SourceRepoID:     1,
+		SourceBranch:     "feature",
+		TargetRepoID:     1,
+		TargetBranch:     "main",
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+	}
+	if err := pullreqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	schedule := &types.MergeSchedule{
+		PullReqID: pr.ID,
+		CreatedBy: userID,
+		Created:   100,
+		MergeAt:   500,
+		Method:    enum.MergeMethodSquash,
+		SourceSHA: "a1",
+	}
+	if err := scheduleStore.Upsert(ctx, schedule); err != nil {
+		t.Fatalf("failed to create merge schedule: %v", err)
+	}
+
+	schedule.MergeAt = 300
+	schedule.Title = "release"
+	schedule.SourceSHA = "b2"
+	if err := scheduleStore.Upsert(ctx, schedule); err != nil {
+		t.Fatalf("failed to replace merge schedule: %v", err)
+	}
+
+	found, err := scheduleStore.Find(ctx, pr.ID)
+	if err != nil {
+		t.Fatalf("failed to find merge schedule: %v", err)
+	}
+	if *found != *schedule {
+		t.Errorf("found merge schedule %+v, want %+v", found, schedule)
+	}
+
+	due, err := scheduleStore.ListDue(ctx, 300)
+	if err != nil {
+		t.Fatalf("failed to list due merge schedules: %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("expected no due merge schedules, got %d", len(due))
+	}
+
+	due, err = scheduleStore.ListDue(ctx, 301)
+	if err != nil {
+		t.Fatalf("failed to list due merge schedules: %v", err)
+	}
+	if len(due) != 1 || due[0].PullReqID != pr.ID {
+		t.Errorf("unexpected due merge schedules: %+v", due)
+	}
+
+	deleted, err := scheduleStore.Delete(ctx, pr.ID)
+	if err != nil || !deleted {
+		t.Fatalf("failed to delete merge schedule: deleted=%t err=%v", deleted, err)
+	}
+
+	deleted, err = scheduleStore.Delete(ctx, pr.ID)
+	if err != nil || deleted {
+		t.Errorf("expected nothing to delete: deleted=%t err=%v", deleted, err)
+	}
+
+	if _, err = scheduleStore.Find(ctx, pr.ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
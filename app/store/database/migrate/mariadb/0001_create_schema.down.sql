@@ -0,0 +1,28 @@
+DROP TABLE event_outbox;
+DROP TABLE plugins;
+DROP TABLE triggers;
+DROP TABLE templates;
+DROP TABLE connectors;
+DROP TABLE logs;
+DROP TABLE steps;
+DROP TABLE stages;
+DROP TABLE secrets;
+DROP TABLE executions;
+DROP TABLE pipelines;
+DROP TABLE jobs;
+DROP TABLE rules;
+DROP TABLE checks;
+DROP TABLE webhook_executions;
+DROP TABLE webhooks;
+DROP TABLE pullreq_search;
+DROP TABLE pullreq_file_views;
+DROP TABLE pullreq_reviewers;
+DROP TABLE pullreq_reviews;
+DROP TABLE pullreq_activities;
+DROP TABLE pullreqs;
+DROP TABLE memberships;
+DROP TABLE tokens;
+DROP TABLE space_paths;
+DROP TABLE repositories;
+DROP TABLE spaces;
+DROP TABLE principals;
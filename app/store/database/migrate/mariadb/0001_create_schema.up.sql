@@ -0,0 +1,707 @@
+-- The MySQL schema starts from the schema of the other dialects at the time MySQL support was added.
+-- Partial and expression indexes aren't supported, hence they are emulated using stored generated columns.
+
+CREATE TABLE principals (
+ principal_id             BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,principal_uid            VARCHAR(255)
+,principal_uid_unique     VARCHAR(255)
+,principal_email          VARCHAR(255)
+,principal_type           VARCHAR(50)
+,principal_display_name   VARCHAR(255)
+,principal_admin          BOOLEAN
+,principal_blocked        BOOLEAN
+,principal_salt           VARCHAR(255)
+,principal_created        BIGINT
+,principal_updated        BIGINT
+,principal_user_password  TEXT
+,principal_sa_parent_type VARCHAR(50)
+,principal_sa_parent_id   BIGINT
+,principal_deleted        BIGINT DEFAULT NULL
+,principal_purged         BOOLEAN NOT NULL DEFAULT FALSE
+,principal_email_lower    VARCHAR(255) AS (LOWER(principal_email)) STORED
+,UNIQUE KEY principals_uid_unique (principal_uid_unique)
+,UNIQUE KEY principals_lower_email (principal_email_lower)
+,KEY principals_sa_parent_id_sa_parent_type (principal_sa_parent_id, principal_sa_parent_type)
+,KEY principals_deleted (principal_deleted)
+);
+
+CREATE TABLE spaces (
+ space_id           BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,space_version      BIGINT NOT NULL DEFAULT 0
+,space_parent_id    BIGINT DEFAULT NULL
+,space_uid          VARCHAR(255) NOT NULL
+,space_description  TEXT
+,space_is_public    BOOLEAN NOT NULL
+,space_created_by   BIGINT NOT NULL
+,space_created      BIGINT NOT NULL
+,space_updated      BIGINT NOT NULL
+,space_deleted      BIGINT DEFAULT NULL
+,KEY spaces_parent_id (space_parent_id)
+,KEY spaces_deleted_parent_id (space_deleted, space_parent_id)
+,CONSTRAINT fk_space_parent_id FOREIGN KEY (space_parent_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE repositories (
+ repo_id                BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,repo_version           BIGINT NOT NULL DEFAULT 0
+,repo_parent_id         BIGINT NOT NULL
+,repo_uid               VARCHAR(255) NOT NULL
+,repo_description       TEXT
+,repo_is_public         BOOLEAN NOT NULL
+,repo_created_by        BIGINT NOT NULL
+,repo_created           BIGINT NOT NULL
+,repo_updated           BIGINT NOT NULL
+,repo_deleted           BIGINT DEFAULT NULL
+,repo_git_uid           VARCHAR(255) NOT NULL
+,repo_default_branch    VARCHAR(255) NOT NULL
+,repo_fork_id           BIGINT
+,repo_pullreq_seq       BIGINT NOT NULL
+,repo_num_forks         BIGINT NOT NULL
+,repo_num_pulls         BIGINT NOT NULL
+,repo_num_closed_pulls  BIGINT NOT NULL
+,repo_num_open_pulls    BIGINT NOT NULL
+,repo_num_merged_pulls  BIGINT NOT NULL
+,repo_importing         BOOLEAN NOT NULL DEFAULT FALSE
+,repo_size              BIGINT NOT NULL DEFAULT 0
+,repo_size_updated      BIGINT NOT NULL DEFAULT 0
+,repo_uid_active_lower  VARCHAR(255) AS (IF(repo_deleted IS NULL, LOWER(repo_uid), NULL)) STORED
+,UNIQUE KEY repositories_git_uid (repo_git_uid)
+,UNIQUE KEY repositories_parent_id_uid (repo_parent_id, repo_uid_active_lower)
+,KEY repositories_deleted (repo_deleted)
+,CONSTRAINT fk_repo_parent_id FOREIGN KEY (repo_parent_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE space_paths (
+ space_path_id            BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,space_path_uid           VARCHAR(255) NOT NULL
+,space_path_uid_unique    VARCHAR(255) NOT NULL
+,space_path_is_primary    BOOLEAN DEFAULT NULL
+,space_path_space_id      BIGINT NOT NULL
+,space_path_parent_id     BIGINT
+,space_path_created_by    BIGINT NOT NULL
+,space_path_created       BIGINT NOT NULL
+,space_path_updated       BIGINT NOT NULL
+,space_path_parent_key    BIGINT AS (COALESCE(space_path_parent_id, 0)) STORED
+,UNIQUE KEY space_paths_space_id_is_primary (space_path_space_id, space_path_is_primary)
+,UNIQUE KEY space_paths_uid_unique (space_path_parent_key, space_path_uid_unique)
+,CONSTRAINT fk_space_path_created_by FOREIGN KEY (space_path_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_space_path_space_id FOREIGN KEY (space_path_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_space_path_parent_id FOREIGN KEY (space_path_parent_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE tokens (
+ token_id             BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,token_type           VARCHAR(50)
+,token_uid            VARCHAR(255)
+,token_principal_id   BIGINT
+,token_expires_at     BIGINT
+,token_issued_at      BIGINT
+,token_created_by     BIGINT
+,token_uid_lower      VARCHAR(255) AS (LOWER(token_uid)) STORED
+,UNIQUE KEY tokens_principal_id_uid (token_principal_id, token_uid_lower)
+,KEY tokens_type_expires_at (token_type, token_expires_at)
+,CONSTRAINT fk_token_principal_id FOREIGN KEY (token_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE memberships (
+ membership_space_id     BIGINT NOT NULL
+,membership_principal_id BIGINT NOT NULL
+,membership_created_by   BIGINT NOT NULL
+,membership_created      BIGINT NOT NULL
+,membership_updated      BIGINT NOT NULL
+,membership_role         VARCHAR(50) NOT NULL
+,CONSTRAINT pk_memberships PRIMARY KEY (membership_space_id, membership_principal_id)
+,CONSTRAINT fk_membership_space_id FOREIGN KEY (membership_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_membership_principal_id FOREIGN KEY (membership_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_membership_created_by FOREIGN KEY (membership_created_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE pullreqs (
+ pullreq_id                 BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,pullreq_version            BIGINT NOT NULL DEFAULT 0
+,pullreq_created_by         BIGINT NOT NULL
+,pullreq_created            BIGINT NOT NULL
+,pullreq_updated            BIGINT NOT NULL
+,pullreq_edited             BIGINT NOT NULL
+,pullreq_number             BIGINT NOT NULL
+,pullreq_state              VARCHAR(50) NOT NULL
+,pullreq_is_draft           BOOLEAN NOT NULL DEFAULT FALSE
+,pullreq_comment_count      BIGINT NOT NULL DEFAULT 0
+,pullreq_title              TEXT NOT NULL
+,pullreq_description        MEDIUMTEXT NOT NULL
+,pullreq_source_repo_id     BIGINT NOT NULL
+,pullreq_source_branch      VARCHAR(255) NOT NULL
+,pullreq_source_sha         VARCHAR(64) NOT NULL
+,pullreq_target_repo_id     BIGINT NOT NULL
+,pullreq_target_branch      VARCHAR(255) NOT NULL
+,pullreq_activity_seq       BIGINT DEFAULT 0
+,pullreq_merged_by          BIGINT
+,pullreq_merged             BIGINT
+,pullreq_merge_method       VARCHAR(50)
+,pullreq_merge_check_status VARCHAR(50) NOT NULL
+,pullreq_merge_target_sha   VARCHAR(64)
+,pullreq_merge_sha          VARCHAR(64)
+,pullreq_merge_conflicts    TEXT
+,pullreq_merge_base_sha     VARCHAR(64) NOT NULL DEFAULT ''
+,pullreq_unresolved_count   BIGINT NOT NULL DEFAULT 0
+,pullreq_commit_count       BIGINT
+,pullreq_file_count         BIGINT
+,pullreq_source_branch_open VARCHAR(255) AS (IF(pullreq_state = 'open', pullreq_source_branch, NULL)) STORED
+,UNIQUE KEY pullreqs_source_repo_branch_target_repo_branch
+    (pullreq_source_repo_id, pullreq_source_branch_open, pullreq_target_repo_id, pullreq_target_branch)
+,UNIQUE KEY pullreqs_target_repo_id_number (pullreq_target_repo_id, pullreq_number)
+,CONSTRAINT fk_pullreq_created_by FOREIGN KEY (pullreq_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_pullreq_source_repo_id FOREIGN KEY (pullreq_source_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_target_repo_id FOREIGN KEY (pullreq_target_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_merged_by FOREIGN KEY (pullreq_merged_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE pullreq_activities (
+ pullreq_activity_id                           BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,pullreq_activity_version                      BIGINT NOT NULL
+,pullreq_activity_created_by                   BIGINT
+,pullreq_activity_created                      BIGINT NOT NULL
+,pullreq_activity_updated                      BIGINT NOT NULL
+,pullreq_activity_edited                       BIGINT NOT NULL
+,pullreq_activity_deleted                      BIGINT
+,pullreq_activity_parent_id                    BIGINT
+,pullreq_activity_repo_id                      BIGINT NOT NULL
+,pullreq_activity_pullreq_id                   BIGINT NOT NULL
+,pullreq_activity_order                        BIGINT NOT NULL
+,pullreq_activity_sub_order                    BIGINT NOT NULL
+,pullreq_activity_reply_seq                    BIGINT NOT NULL
+,pullreq_activity_type                         VARCHAR(50) NOT NULL
+,pullreq_activity_kind                         VARCHAR(50) NOT NULL
+,pullreq_activity_text                         MEDIUMTEXT NOT NULL
+,pullreq_activity_payload                      MEDIUMTEXT NOT NULL
+,pullreq_activity_metadata                     MEDIUMTEXT NOT NULL
+,pullreq_activity_resolved_by                  BIGINT DEFAULT NULL
+,pullreq_activity_resolved                     BIGINT NULL
+,pullreq_activity_outdated                     BOOLEAN
+,pullreq_activity_code_comment_merge_base_sha  VARCHAR(64)
+,pullreq_activity_code_comment_source_sha      VARCHAR(64)
+,pullreq_activity_code_comment_path            TEXT
+,pullreq_activity_code_comment_line_new        BIGINT
+,pullreq_activity_code_comment_span_new        BIGINT
+,pullreq_activity_code_comment_line_old        BIGINT
+,pullreq_activity_code_comment_span_old        BIGINT
+,UNIQUE KEY pullreq_activities_pullreq_id_order_sub_order
+    (pullreq_activity_pullreq_id, pullreq_activity_order, pullreq_activity_sub_order)
+,CONSTRAINT fk_pullreq_activities_created_by FOREIGN KEY (pullreq_activity_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_pullreq_activities_parent_id FOREIGN KEY (pullreq_activity_parent_id)
+    REFERENCES pullreq_activities (pullreq_activity_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_activities_repo_id FOREIGN KEY (pullreq_activity_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_activities_pullreq_id FOREIGN KEY (pullreq_activity_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_activities_resolved_by FOREIGN KEY (pullreq_activity_resolved_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE pullreq_reviews (
+ pullreq_review_id         BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,pullreq_review_created_by BIGINT NOT NULL
+,pullreq_review_created    BIGINT NOT NULL
+,pullreq_review_updated    BIGINT NOT NULL
+,pullreq_review_pullreq_id BIGINT NOT NULL
+,pullreq_review_decision   VARCHAR(50) NOT NULL
+,pullreq_review_sha        VARCHAR(64) NOT NULL
+,KEY index_pullreq_review_pullreq_id (pullreq_review_pullreq_id)
+,CONSTRAINT fk_pullreq_review_created_by FOREIGN KEY (pullreq_review_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_pullreq_review_pullreq_id FOREIGN KEY (pullreq_review_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE pullreq_reviewers (
+ pullreq_reviewer_pullreq_id        BIGINT NOT NULL
+,pullreq_reviewer_principal_id      BIGINT NOT NULL
+,pullreq_reviewer_created_by        BIGINT NOT NULL
+,pullreq_reviewer_created           BIGINT NOT NULL
+,pullreq_reviewer_updated           BIGINT NOT NULL
+,pullreq_reviewer_repo_id           BIGINT NOT NULL
+,pullreq_reviewer_type              VARCHAR(50) NOT NULL
+,pullreq_reviewer_latest_review_id  BIGINT
+,pullreq_reviewer_review_decision   VARCHAR(50) NOT NULL
+,pullreq_reviewer_sha               VARCHAR(64) NOT NULL
+,CONSTRAINT pk_pullreq_reviewers PRIMARY KEY (pullreq_reviewer_pullreq_id, pullreq_reviewer_principal_id)
+,CONSTRAINT fk_pullreq_reviewer_pullreq_id FOREIGN KEY (pullreq_reviewer_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reviewer_user_id FOREIGN KEY (pullreq_reviewer_principal_id)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_pullreq_reviewer_created_by FOREIGN KEY (pullreq_reviewer_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_pullreq_reviewer_repo_id FOREIGN KEY (pullreq_reviewer_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reviewer_latest_review_id FOREIGN KEY (pullreq_reviewer_latest_review_id)
+    REFERENCES pullreq_reviews (pullreq_review_id)
+    ON DELETE SET NULL
+);
+
+CREATE TABLE pullreq_file_views (
+ pullreq_file_view_pullreq_id   BIGINT NOT NULL
+,pullreq_file_view_principal_id BIGINT NOT NULL
+,pullreq_file_view_path         VARCHAR(768) NOT NULL
+,pullreq_file_view_sha          VARCHAR(64) NOT NULL
+,pullreq_file_view_obsolete     BOOLEAN NOT NULL
+,pullreq_file_view_created      BIGINT NOT NULL
+,pullreq_file_view_updated      BIGINT NOT NULL
+,CONSTRAINT pk_pullreq_file_views
+    PRIMARY KEY (pullreq_file_view_pullreq_id, pullreq_file_view_principal_id, pullreq_file_view_path)
+,KEY pullreq_file_views_pullreq_id_file_path (pullreq_file_view_pullreq_id, pullreq_file_view_path)
+,CONSTRAINT fk_pullreq_file_view_pullreq_id FOREIGN KEY (pullreq_file_view_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_file_view_principal_id FOREIGN KEY (pullreq_file_view_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE pullreq_search (
+ pullreq_search_pullreq_id   BIGINT NOT NULL PRIMARY KEY
+,pullreq_search_title        TEXT NOT NULL
+,pullreq_search_description  MEDIUMTEXT NOT NULL
+,pullreq_search_comments     MEDIUMTEXT NOT NULL
+,FULLTEXT KEY pullreq_search_fulltext
+    (pullreq_search_title, pullreq_search_description, pullreq_search_comments)
+,CONSTRAINT fk_pullreq_search_pullreq_id FOREIGN KEY (pullreq_search_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON DELETE CASCADE
+);
+
+CREATE TRIGGER pullreq_search_pullreq_insert AFTER INSERT ON pullreqs
+FOR EACH ROW
+    INSERT INTO pullreq_search (
+         pullreq_search_pullreq_id
+        ,pullreq_search_title
+        ,pullreq_search_description
+        ,pullreq_search_comments
+    ) VALUES (NEW.pullreq_id, NEW.pullreq_title, NEW.pullreq_description, '');
+
+CREATE TRIGGER pullreq_search_pullreq_update AFTER UPDATE ON pullreqs
+FOR EACH ROW
+    UPDATE pullreq_search
+    SET
+         pullreq_search_title = NEW.pullreq_title
+        ,pullreq_search_description = NEW.pullreq_description
+    WHERE pullreq_search_pullreq_id = NEW.pullreq_id
+        AND (NEW.pullreq_title <> OLD.pullreq_title OR NEW.pullreq_description <> OLD.pullreq_description);
+
+CREATE TRIGGER pullreq_search_activity_insert AFTER INSERT ON pullreq_activities
+FOR EACH ROW
+    UPDATE pullreq_search
+    SET pullreq_search_comments = COALESCE((
+        SELECT GROUP_CONCAT(pullreq_activity_text SEPARATOR ' ')
+        FROM pullreq_activities
+        WHERE
+            pullreq_activity_pullreq_id = NEW.pullreq_activity_pullreq_id AND
+            pullreq_activity_deleted IS NULL AND
+            pullreq_activity_kind <> 'system'
+    ), '')
+    WHERE pullreq_search_pullreq_id = NEW.pullreq_activity_pullreq_id
+        AND NEW.pullreq_activity_kind <> 'system';
+
+CREATE TRIGGER pullreq_search_activity_update AFTER UPDATE ON pullreq_activities
+FOR EACH ROW
+    UPDATE pullreq_search
+    SET pullreq_search_comments = COALESCE((
+        SELECT GROUP_CONCAT(pullreq_activity_text SEPARATOR ' ')
+        FROM pullreq_activities
+        WHERE
+            pullreq_activity_pullreq_id = NEW.pullreq_activity_pullreq_id AND
+            pullreq_activity_deleted IS NULL AND
+            pullreq_activity_kind <> 'system'
+    ), '')
+    WHERE pullreq_search_pullreq_id = NEW.pullreq_activity_pullreq_id
+        AND NEW.pullreq_activity_kind <> 'system';
+
+CREATE TABLE webhooks (
+ webhook_id                      BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,webhook_version                 BIGINT NOT NULL DEFAULT 0
+,webhook_created_by              BIGINT NOT NULL
+,webhook_created                 BIGINT NOT NULL
+,webhook_updated                 BIGINT NOT NULL
+,webhook_space_id                BIGINT
+,webhook_repo_id                 BIGINT
+,webhook_uid                     VARCHAR(255)
+,webhook_display_name            VARCHAR(255) NOT NULL
+,webhook_description             TEXT NOT NULL
+,webhook_url                     TEXT NOT NULL
+,webhook_secret                  BLOB NOT NULL
+,webhook_enabled                 BOOLEAN NOT NULL
+,webhook_insecure                BOOLEAN NOT NULL
+,webhook_internal                BOOLEAN NOT NULL DEFAULT FALSE
+,webhook_triggers                TEXT NOT NULL
+,webhook_latest_execution_result VARCHAR(50)
+,webhook_uid_lower               VARCHAR(255) AS (LOWER(webhook_uid)) STORED
+,UNIQUE KEY webhooks_repo_id_uid (webhook_repo_id, webhook_uid_lower)
+,UNIQUE KEY webhooks_space_id_uid (webhook_space_id, webhook_uid_lower)
+,CONSTRAINT fk_webhook_created_by FOREIGN KEY (webhook_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_webhook_space_id FOREIGN KEY (webhook_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_webhook_repo_id FOREIGN KEY (webhook_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE webhook_executions (
+ webhook_execution_id                   BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,webhook_execution_retrigger_of         BIGINT
+,webhook_execution_retriggerable        BOOLEAN NOT NULL
+,webhook_execution_webhook_id           BIGINT NOT NULL
+,webhook_execution_trigger_type         VARCHAR(50) NOT NULL
+,webhook_execution_trigger_id           VARCHAR(255) NOT NULL
+,webhook_execution_result               VARCHAR(50) NOT NULL
+,webhook_execution_created              BIGINT NOT NULL
+,webhook_execution_duration             BIGINT NOT NULL
+,webhook_execution_error                TEXT NOT NULL
+,webhook_execution_request_url          TEXT NOT NULL
+,webhook_execution_request_headers      TEXT NOT NULL
+,webhook_execution_request_body         MEDIUMTEXT NOT NULL
+,webhook_execution_response_status_code INTEGER NOT NULL
+,webhook_execution_response_status      VARCHAR(255) NOT NULL
+,webhook_execution_response_headers     TEXT NOT NULL
+,webhook_execution_response_body        MEDIUMTEXT NOT NULL
+,KEY webhook_executions_webhook_id (webhook_execution_webhook_id)
+,KEY webhook_executions_created (webhook_execution_created)
+);
+
+CREATE TABLE checks (
+ check_id              BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,check_created_by      BIGINT NOT NULL
+,check_created         BIGINT NOT NULL
+,check_updated         BIGINT NOT NULL
+,check_repo_id         BIGINT NOT NULL
+,check_commit_sha      VARCHAR(64) NOT NULL
+,check_uid             VARCHAR(255) NOT NULL
+,check_status          VARCHAR(50) NOT NULL
+,check_summary         TEXT NOT NULL
+,check_link            TEXT NOT NULL
+,check_payload         MEDIUMTEXT NOT NULL
+,check_metadata        TEXT NOT NULL
+,check_payload_version VARCHAR(50) NOT NULL DEFAULT ''
+,check_payload_kind    VARCHAR(50) NOT NULL DEFAULT ''
+,check_started         BIGINT NOT NULL DEFAULT 0
+,check_ended           BIGINT NOT NULL DEFAULT 0
+,UNIQUE KEY checks_repo_id_commit_sha_uid (check_repo_id, check_commit_sha, check_uid)
+,KEY checks_repo_id_created (check_repo_id, check_created)
+,CONSTRAINT fk_check_created_by FOREIGN KEY (check_created_by)
+    REFERENCES principals (principal_id)
+,CONSTRAINT fk_check_repo_id FOREIGN KEY (check_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE rules (
+ rule_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,rule_version     BIGINT NOT NULL
+,rule_created_by  BIGINT
+,rule_created     BIGINT NOT NULL
+,rule_updated     BIGINT NOT NULL
+,rule_space_id    BIGINT
+,rule_repo_id     BIGINT
+,rule_uid         VARCHAR(255) NOT NULL
+,rule_description TEXT NOT NULL
+,rule_type        VARCHAR(50) NOT NULL
+,rule_state       VARCHAR(50) NOT NULL
+,rule_pattern     TEXT NOT NULL
+,rule_definition  MEDIUMTEXT NOT NULL
+,rule_uid_lower   VARCHAR(255) AS (LOWER(rule_uid)) STORED
+,UNIQUE KEY rules_space_id_uid (rule_space_id, rule_uid_lower)
+,UNIQUE KEY rules_repo_id_uid (rule_repo_id, rule_uid_lower)
+,CONSTRAINT fk_rule_created_by FOREIGN KEY (rule_created_by)
+    REFERENCES principals (principal_id)
+    ON DELETE SET NULL
+,CONSTRAINT fk_rule_space_id FOREIGN KEY (rule_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_rule_repo_id FOREIGN KEY (rule_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE jobs (
+ job_uid                  VARCHAR(255) NOT NULL
+,job_created              BIGINT NOT NULL
+,job_updated              BIGINT NOT NULL
+,job_type                 VARCHAR(255) NOT NULL
+,job_priority             INTEGER NOT NULL
+,job_data                 MEDIUMTEXT NOT NULL
+,job_result               MEDIUMTEXT NOT NULL
+,job_max_duration_seconds INTEGER NOT NULL
+,job_max_retries          INTEGER NOT NULL
+,job_state                VARCHAR(50) NOT NULL
+,job_scheduled            BIGINT NOT NULL
+,job_total_executions     INTEGER
+,job_run_by               VARCHAR(255) NOT NULL
+,job_run_deadline         BIGINT
+,job_run_progress         INTEGER NOT NULL
+,job_last_executed        BIGINT
+,job_is_recurring         BOOLEAN NOT NULL
+,job_recurring_cron       VARCHAR(255) NOT NULL
+,job_consecutive_failures INTEGER NOT NULL
+,job_last_failure_error   TEXT NOT NULL
+,job_group_id             VARCHAR(255) NOT NULL DEFAULT ''
+,CONSTRAINT pk_jobs_uid PRIMARY KEY (job_uid)
+,KEY jobs_scheduled (job_state, job_scheduled)
+,KEY jobs_run_deadline (job_state, job_run_deadline)
+,KEY jobs_last_executed (job_is_recurring, job_state, job_last_executed)
+,KEY job_group_id (job_group_id)
+);
+
+CREATE TABLE pipelines (
+ pipeline_id             BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,pipeline_description    TEXT NOT NULL
+,pipeline_uid            VARCHAR(255) NOT NULL
+,pipeline_seq            BIGINT NOT NULL DEFAULT 0
+,pipeline_disabled       BOOLEAN NOT NULL
+,pipeline_repo_id        BIGINT NOT NULL
+,pipeline_default_branch VARCHAR(255) NOT NULL
+,pipeline_created_by     BIGINT NOT NULL
+,pipeline_config_path    TEXT NOT NULL
+,pipeline_created        BIGINT NOT NULL
+,pipeline_updated        BIGINT NOT NULL
+,pipeline_version        BIGINT NOT NULL
+,UNIQUE KEY pipelines_repo_id_uid (pipeline_repo_id, pipeline_uid)
+,CONSTRAINT fk_pipelines_repo_id FOREIGN KEY (pipeline_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pipelines_created_by FOREIGN KEY (pipeline_created_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE executions (
+ execution_id            BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,execution_pipeline_id   BIGINT NOT NULL
+,execution_repo_id       BIGINT NOT NULL
+,execution_created_by    BIGINT NOT NULL
+,execution_trigger       VARCHAR(255) NOT NULL
+,execution_number        BIGINT NOT NULL
+,execution_parent        BIGINT NOT NULL
+,execution_status        VARCHAR(50) NOT NULL
+,execution_error         TEXT NOT NULL
+,execution_event         VARCHAR(255) NOT NULL
+,execution_action        VARCHAR(255) NOT NULL
+,execution_link          TEXT NOT NULL
+,execution_timestamp     BIGINT NOT NULL
+,execution_title         TEXT NOT NULL
+,execution_message       TEXT NOT NULL
+,execution_before        VARCHAR(255) NOT NULL
+,execution_after         VARCHAR(255) NOT NULL
+,execution_ref           VARCHAR(255) NOT NULL
+,execution_source_repo   VARCHAR(255) NOT NULL
+,execution_source        VARCHAR(255) NOT NULL
+,execution_target        VARCHAR(255) NOT NULL
+,execution_author        VARCHAR(255) NOT NULL
+,execution_author_name   VARCHAR(255) NOT NULL
+,execution_author_email  VARCHAR(255) NOT NULL
+,execution_author_avatar TEXT NOT NULL
+,execution_sender        VARCHAR(255) NOT NULL
+,execution_params        TEXT NOT NULL
+,execution_cron          VARCHAR(255) NOT NULL
+,execution_deploy        VARCHAR(255) NOT NULL
+,execution_deploy_id     BIGINT NOT NULL
+,execution_debug         BOOLEAN NOT NULL DEFAULT FALSE
+,execution_started       BIGINT NOT NULL
+,execution_finished      BIGINT NOT NULL
+,execution_created       BIGINT NOT NULL
+,execution_updated       BIGINT NOT NULL
+,execution_version       BIGINT NOT NULL
+,UNIQUE KEY executions_pipeline_id_number (execution_pipeline_id, execution_number)
+,CONSTRAINT fk_executions_pipeline_id FOREIGN KEY (execution_pipeline_id)
+    REFERENCES pipelines (pipeline_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_executions_repo_id FOREIGN KEY (execution_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_executions_created_by FOREIGN KEY (execution_created_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE secrets (
+ secret_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,secret_uid         VARCHAR(255) NOT NULL
+,secret_space_id    BIGINT NOT NULL
+,secret_description TEXT NOT NULL
+,secret_data        BLOB NOT NULL
+,secret_created     BIGINT NOT NULL
+,secret_updated     BIGINT NOT NULL
+,secret_version     BIGINT NOT NULL
+,secret_created_by  BIGINT NOT NULL
+,UNIQUE KEY secrets_space_id_uid (secret_space_id, secret_uid)
+,CONSTRAINT fk_secrets_space_id FOREIGN KEY (secret_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_secrets_created_by FOREIGN KEY (secret_created_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE stages (
+ stage_id              BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,stage_execution_id    BIGINT NOT NULL
+,stage_repo_id         BIGINT NOT NULL
+,stage_number          BIGINT NOT NULL
+,stage_kind            VARCHAR(50) NOT NULL
+,stage_type            VARCHAR(50) NOT NULL
+,stage_name            VARCHAR(255) NOT NULL
+,stage_status          VARCHAR(50) NOT NULL
+,stage_error           TEXT NOT NULL
+,stage_parent_group_id BIGINT NOT NULL
+,stage_errignore       BOOLEAN NOT NULL
+,stage_exit_code       INTEGER NOT NULL
+,stage_limit           INTEGER NOT NULL
+,stage_os              VARCHAR(50) NOT NULL
+,stage_arch            VARCHAR(50) NOT NULL
+,stage_variant         VARCHAR(50) NOT NULL
+,stage_kernel          VARCHAR(50) NOT NULL
+,stage_machine         VARCHAR(255) NOT NULL
+,stage_started         BIGINT NOT NULL
+,stage_stopped         BIGINT NOT NULL
+,stage_created         BIGINT NOT NULL
+,stage_updated         BIGINT NOT NULL
+,stage_version         BIGINT NOT NULL
+,stage_on_success      BOOLEAN NOT NULL
+,stage_on_failure      BOOLEAN NOT NULL
+,stage_depends_on      TEXT NOT NULL
+,stage_labels          TEXT NOT NULL
+,stage_limit_repo      INTEGER NOT NULL DEFAULT 0
+,UNIQUE KEY stages_execution_id_number (stage_execution_id, stage_number)
+,KEY ix_stage_in_progress (stage_status)
+,CONSTRAINT fk_stages_execution_id FOREIGN KEY (stage_execution_id)
+    REFERENCES executions (execution_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE steps (
+ step_id              BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,step_stage_id        BIGINT NOT NULL
+,step_number          BIGINT NOT NULL
+,step_name            VARCHAR(100) NOT NULL
+,step_status          VARCHAR(50) NOT NULL
+,step_error           VARCHAR(500) NOT NULL
+,step_parent_group_id BIGINT NOT NULL
+,step_errignore       BOOLEAN NOT NULL
+,step_exit_code       INTEGER NOT NULL
+,step_started         BIGINT NOT NULL
+,step_stopped         BIGINT NOT NULL
+,step_version         BIGINT NOT NULL
+,step_depends_on      TEXT NOT NULL
+,step_image           TEXT NOT NULL
+,step_detached        BOOLEAN NOT NULL
+,step_schema          TEXT NOT NULL
+,UNIQUE KEY steps_stage_id_number (step_stage_id, step_number)
+,CONSTRAINT fk_steps_stage_id FOREIGN KEY (step_stage_id)
+    REFERENCES stages (stage_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE logs (
+ log_id   BIGINT NOT NULL PRIMARY KEY
+,log_data LONGBLOB NOT NULL
+,CONSTRAINT fk_logs_id FOREIGN KEY (log_id)
+    REFERENCES steps (step_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE connectors (
+ connector_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,connector_uid         VARCHAR(255) NOT NULL
+,connector_description TEXT NOT NULL
+,connector_type        VARCHAR(50) NOT NULL
+,connector_space_id    BIGINT NOT NULL
+,connector_data        TEXT NOT NULL
+,connector_created     BIGINT NOT NULL
+,connector_updated     BIGINT NOT NULL
+,connector_version     BIGINT NOT NULL
+,UNIQUE KEY connectors_space_id_uid (connector_space_id, connector_uid)
+,CONSTRAINT fk_connectors_space_id FOREIGN KEY (connector_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE templates (
+ template_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,template_uid         VARCHAR(255) NOT NULL
+,template_type        VARCHAR(50) NOT NULL
+,template_description TEXT NOT NULL
+,template_space_id    BIGINT NOT NULL
+,template_data        MEDIUMBLOB NOT NULL
+,template_created     BIGINT NOT NULL
+,template_updated     BIGINT NOT NULL
+,template_version     BIGINT NOT NULL
+,UNIQUE KEY templates_space_id_uid_type (template_space_id, template_uid, template_type)
+,CONSTRAINT fk_templates_space_id FOREIGN KEY (template_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE triggers (
+ trigger_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,trigger_uid         VARCHAR(255) NOT NULL
+,trigger_pipeline_id BIGINT NOT NULL
+,trigger_type        VARCHAR(50) NOT NULL
+,trigger_repo_id     BIGINT NOT NULL
+,trigger_secret      TEXT NOT NULL
+,trigger_description TEXT NOT NULL
+,trigger_disabled    BOOLEAN NOT NULL
+,trigger_created_by  BIGINT NOT NULL
+,trigger_actions     TEXT NOT NULL
+,trigger_created     BIGINT NOT NULL
+,trigger_updated     BIGINT NOT NULL
+,trigger_version     BIGINT NOT NULL
+,UNIQUE KEY triggers_pipeline_id_uid (trigger_pipeline_id, trigger_uid)
+,CONSTRAINT fk_triggers_pipeline_id FOREIGN KEY (trigger_pipeline_id)
+    REFERENCES pipelines (pipeline_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_triggers_repo_id FOREIGN KEY (trigger_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE plugins (
+ plugin_uid         VARCHAR(255) NOT NULL
+,plugin_description TEXT NOT NULL
+,plugin_logo        TEXT NOT NULL
+,plugin_spec        MEDIUMBLOB NOT NULL
+,plugin_type        VARCHAR(50) NOT NULL
+,plugin_version     VARCHAR(50) NOT NULL
+,UNIQUE KEY plugins_uid (plugin_uid)
+);
+
+CREATE TABLE event_outbox (
+ event_outbox_id        BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,event_outbox_stream_id VARCHAR(255) NOT NULL
+,event_outbox_payload   MEDIUMBLOB NOT NULL
+,event_outbox_created   BIGINT NOT NULL
+);
@@ -0,0 +1,12 @@
+CREATE TABLE system_events (
+ system_event_id            BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,system_event_type          VARCHAR(255) NOT NULL
+,system_event_principal_id  BIGINT
+,system_event_resource_id   BIGINT
+,system_event_resource_path VARCHAR(1024) NOT NULL
+,system_event_message       TEXT NOT NULL
+,system_event_created       BIGINT NOT NULL
+);
+
+CREATE INDEX system_events_created
+    ON system_events(system_event_created);
@@ -0,0 +1,19 @@
+CREATE TABLE idempotency_keys (
+ idempotency_key_id                    BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,idempotency_key_principal_id          BIGINT NOT NULL
+,idempotency_key_key                   VARCHAR(255) NOT NULL
+,idempotency_key_fingerprint           VARCHAR(255) NOT NULL
+,idempotency_key_response_status       INT NOT NULL
+,idempotency_key_response_content_type VARCHAR(255) NOT NULL
+,idempotency_key_response_body         MEDIUMTEXT NOT NULL
+,idempotency_key_created               BIGINT NOT NULL
+,CONSTRAINT fk_idempotency_key_principal_id FOREIGN KEY (idempotency_key_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idempotency_keys_principal_id_key
+    ON idempotency_keys(idempotency_key_principal_id, idempotency_key_key);
+
+CREATE INDEX idempotency_keys_created
+    ON idempotency_keys(idempotency_key_created);
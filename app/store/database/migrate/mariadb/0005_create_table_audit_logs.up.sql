@@ -0,0 +1,24 @@
+CREATE TABLE audit_logs (
+ audit_log_id                     BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,audit_log_request_id             VARCHAR(255) NOT NULL
+,audit_log_principal_id           BIGINT
+,audit_log_method                 VARCHAR(16) NOT NULL
+,audit_log_path                   VARCHAR(2048) NOT NULL
+,audit_log_query                  TEXT NOT NULL
+,audit_log_remote_addr            VARCHAR(255) NOT NULL
+,audit_log_user_agent             VARCHAR(1024) NOT NULL
+,audit_log_status                 INT NOT NULL
+,audit_log_request_body           MEDIUMTEXT NOT NULL
+,audit_log_request_body_truncated BOOLEAN NOT NULL
+,audit_log_duration               BIGINT NOT NULL
+,audit_log_created                BIGINT NOT NULL
+);
+
+CREATE INDEX audit_logs_request_id
+    ON audit_logs(audit_log_request_id);
+
+CREATE INDEX audit_logs_principal_id
+    ON audit_logs(audit_log_principal_id, audit_log_id);
+
+CREATE INDEX audit_logs_created
+    ON audit_logs(audit_log_created);
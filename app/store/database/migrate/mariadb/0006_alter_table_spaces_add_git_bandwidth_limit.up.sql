@@ -0,0 +1 @@
+ALTER TABLE spaces ADD COLUMN space_git_bandwidth_limit BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,21 @@
+CREATE TABLE external_hooks (
+ external_hook_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,external_hook_version     BIGINT NOT NULL
+,external_hook_space_id    BIGINT NOT NULL
+,external_hook_uid         VARCHAR(100) NOT NULL
+,external_hook_description TEXT NOT NULL
+,external_hook_type        VARCHAR(16) NOT NULL
+,external_hook_target      VARCHAR(2048) NOT NULL
+,external_hook_secret      BLOB NOT NULL
+,external_hook_timeout     INT NOT NULL
+,external_hook_enabled     BOOLEAN NOT NULL
+,external_hook_created_by  BIGINT NOT NULL
+,external_hook_created     BIGINT NOT NULL
+,external_hook_updated     BIGINT NOT NULL
+,CONSTRAINT fk_external_hook_space_id FOREIGN KEY (external_hook_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX external_hooks_space_id_uid
+    ON external_hooks(external_hook_space_id, external_hook_uid);
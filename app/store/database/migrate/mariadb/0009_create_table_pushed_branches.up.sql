@@ -0,0 +1,14 @@
+CREATE TABLE pushed_branches (
+ pushed_branch_repo_id      BIGINT NOT NULL
+,pushed_branch_principal_id BIGINT NOT NULL
+,pushed_branch_name         VARCHAR(255) NOT NULL
+,pushed_branch_sha          VARCHAR(64) NOT NULL
+,pushed_branch_created      BIGINT NOT NULL
+,CONSTRAINT pk_pushed_branches PRIMARY KEY (pushed_branch_repo_id, pushed_branch_principal_id)
+,CONSTRAINT fk_pushed_branch_repo_id FOREIGN KEY (pushed_branch_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_pushed_branch_principal_id FOREIGN KEY (pushed_branch_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
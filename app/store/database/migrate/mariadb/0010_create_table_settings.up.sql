@@ -0,0 +1,12 @@
+CREATE TABLE settings (
+ setting_id         BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,setting_scope      VARCHAR(32) NOT NULL
+,setting_scope_id   BIGINT NOT NULL
+,setting_key        VARCHAR(255) NOT NULL
+,setting_value      TEXT NOT NULL
+,setting_updated    BIGINT NOT NULL
+,setting_updated_by BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX settings_scope_scope_id_key
+    ON settings(setting_scope, setting_scope_id, setting_key);
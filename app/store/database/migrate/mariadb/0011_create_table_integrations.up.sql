@@ -0,0 +1,25 @@
+CREATE TABLE integrations (
+ integration_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,integration_version     BIGINT NOT NULL
+,integration_space_id    BIGINT
+,integration_repo_id     BIGINT
+,integration_uid         VARCHAR(100) NOT NULL
+,integration_description TEXT NOT NULL
+,integration_type        VARCHAR(16) NOT NULL
+,integration_url         BLOB NOT NULL
+,integration_token       BLOB NOT NULL
+,integration_channel     VARCHAR(255) NOT NULL
+,integration_events      TEXT NOT NULL
+,integration_enabled     BOOLEAN NOT NULL
+,integration_created_by  BIGINT NOT NULL
+,integration_created     BIGINT NOT NULL
+,integration_updated     BIGINT NOT NULL
+,UNIQUE KEY integrations_space_id_uid (integration_space_id, integration_uid)
+,UNIQUE KEY integrations_repo_id_uid (integration_repo_id, integration_uid)
+,CONSTRAINT fk_integration_space_id FOREIGN KEY (integration_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_integration_repo_id FOREIGN KEY (integration_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
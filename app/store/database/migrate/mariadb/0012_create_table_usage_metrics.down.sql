@@ -0,0 +1,2 @@
+DROP TABLE usage_active_users;
+DROP TABLE usage_metrics;
@@ -0,0 +1,26 @@
+CREATE TABLE usage_metrics (
+ usage_metric_repo_id          BIGINT NOT NULL
+,usage_metric_date             BIGINT NOT NULL
+,usage_metric_disk_usage       BIGINT NOT NULL DEFAULT 0
+,usage_metric_pullreqs_created BIGINT NOT NULL DEFAULT 0
+,usage_metric_pullreqs_merged  BIGINT NOT NULL DEFAULT 0
+,usage_metric_git_bytes_in     BIGINT NOT NULL DEFAULT 0
+,usage_metric_git_bytes_out    BIGINT NOT NULL DEFAULT 0
+,usage_metric_updated          BIGINT NOT NULL
+,PRIMARY KEY (usage_metric_repo_id, usage_metric_date)
+,KEY usage_metrics_date (usage_metric_date)
+,CONSTRAINT fk_usage_metric_repo_id FOREIGN KEY (usage_metric_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE usage_active_users (
+ usage_active_user_repo_id      BIGINT NOT NULL
+,usage_active_user_date         BIGINT NOT NULL
+,usage_active_user_principal_id BIGINT NOT NULL
+,PRIMARY KEY (usage_active_user_repo_id, usage_active_user_date, usage_active_user_principal_id)
+,KEY usage_active_users_date (usage_active_user_date)
+,CONSTRAINT fk_usage_active_user_repo_id FOREIGN KEY (usage_active_user_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
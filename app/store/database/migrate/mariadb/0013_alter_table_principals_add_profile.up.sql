@@ -0,0 +1,4 @@
+ALTER TABLE principals ADD COLUMN principal_avatar VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_time_zone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_pronouns VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_status_message VARCHAR(255) NOT NULL DEFAULT '';
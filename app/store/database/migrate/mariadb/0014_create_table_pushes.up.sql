@@ -0,0 +1,16 @@
+CREATE TABLE pushes (
+ push_id           BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,push_repo_id      BIGINT NOT NULL
+,push_principal_id BIGINT NOT NULL
+,push_ref          VARCHAR(255) NOT NULL
+,push_old_sha      VARCHAR(64) NOT NULL
+,push_new_sha      VARCHAR(64) NOT NULL
+,push_created      BIGINT NOT NULL
+,KEY pushes_principal_id_created (push_principal_id, push_created)
+,CONSTRAINT fk_push_repo_id FOREIGN KEY (push_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_push_principal_id FOREIGN KEY (push_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+CREATE TABLE announcements (
+ announcement_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,announcement_message     TEXT NOT NULL
+,announcement_severity    VARCHAR(32) NOT NULL
+,announcement_starts      BIGINT NOT NULL
+,announcement_ends        BIGINT NOT NULL
+,announcement_dismissible BOOLEAN NOT NULL
+,announcement_created_by  BIGINT NOT NULL
+,announcement_created     BIGINT NOT NULL
+,announcement_updated     BIGINT NOT NULL
+);
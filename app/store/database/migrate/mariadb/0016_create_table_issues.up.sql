@@ -0,0 +1,46 @@
+ALTER TABLE repositories ADD COLUMN repo_issue_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE issues (
+ issue_id                   BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,issue_version              BIGINT NOT NULL
+,issue_repo_id              BIGINT NOT NULL
+,issue_number               BIGINT NOT NULL
+,issue_created_by           BIGINT NOT NULL
+,issue_created              BIGINT NOT NULL
+,issue_updated              BIGINT NOT NULL
+,issue_edited               BIGINT NOT NULL
+,issue_state                VARCHAR(32) NOT NULL
+,issue_title                VARCHAR(1024) NOT NULL
+,issue_description          TEXT NOT NULL
+,issue_closed_by            BIGINT
+,issue_closed               BIGINT
+,issue_closed_by_pullreq    BIGINT
+,UNIQUE KEY issues_repo_id_number (issue_repo_id, issue_number)
+,CONSTRAINT fk_issue_repo_id FOREIGN KEY (issue_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_issue_created_by FOREIGN KEY (issue_created_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE issue_labels (
+ issue_label_issue_id BIGINT NOT NULL
+,issue_label_name     VARCHAR(255) NOT NULL
+,PRIMARY KEY (issue_label_issue_id, issue_label_name)
+,CONSTRAINT fk_issue_label_issue_id FOREIGN KEY (issue_label_issue_id)
+    REFERENCES issues (issue_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE issue_assignees (
+ issue_assignee_issue_id     BIGINT NOT NULL
+,issue_assignee_principal_id BIGINT NOT NULL
+,PRIMARY KEY (issue_assignee_issue_id, issue_assignee_principal_id)
+,KEY issue_assignees_principal_id (issue_assignee_principal_id)
+,CONSTRAINT fk_issue_assignee_issue_id FOREIGN KEY (issue_assignee_issue_id)
+    REFERENCES issues (issue_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_issue_assignee_principal_id FOREIGN KEY (issue_assignee_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
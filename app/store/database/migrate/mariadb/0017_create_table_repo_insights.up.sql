@@ -0,0 +1,9 @@
+CREATE TABLE repo_insights (
+ repo_insights_repo_id  BIGINT NOT NULL PRIMARY KEY
+,repo_insights_sha      VARCHAR(64) NOT NULL
+,repo_insights_computed BIGINT NOT NULL
+,repo_insights_data     MEDIUMTEXT NOT NULL
+,CONSTRAINT fk_repo_insights_repo_id FOREIGN KEY (repo_insights_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+CREATE TABLE repo_languages (
+ repo_languages_repo_id  BIGINT NOT NULL PRIMARY KEY
+,repo_languages_sha      VARCHAR(64) NOT NULL
+,repo_languages_computed BIGINT NOT NULL
+,repo_languages_data     MEDIUMTEXT NOT NULL
+,CONSTRAINT fk_repo_languages_repo_id FOREIGN KEY (repo_languages_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
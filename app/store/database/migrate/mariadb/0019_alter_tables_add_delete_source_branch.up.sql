@@ -0,0 +1,2 @@
+ALTER TABLE repositories ADD COLUMN repo_pullreq_delete_source_branch BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE pullreqs ADD COLUMN pullreq_delete_source_branch BOOLEAN;
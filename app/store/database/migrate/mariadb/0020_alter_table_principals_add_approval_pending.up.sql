@@ -0,0 +1 @@
+ALTER TABLE principals ADD COLUMN principal_user_approval_pending BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,22 @@
+CREATE TABLE user_invitations (
+ invitation_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,invitation_email       VARCHAR(255) NOT NULL
+,invitation_token_hash  VARCHAR(64) NOT NULL
+,invitation_space_id    BIGINT
+,invitation_role        VARCHAR(32)
+,invitation_created_by  BIGINT NOT NULL
+,invitation_created     BIGINT NOT NULL
+,invitation_expires     BIGINT NOT NULL
+,invitation_accepted_by BIGINT
+,invitation_accepted    BIGINT
+,UNIQUE KEY user_invitations_token_hash (invitation_token_hash)
+,CONSTRAINT fk_invitation_space_id FOREIGN KEY (invitation_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_invitation_created_by FOREIGN KEY (invitation_created_by)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_invitation_accepted_by FOREIGN KEY (invitation_accepted_by)
+    REFERENCES principals (principal_id)
+    ON DELETE SET NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE secrets DROP FOREIGN KEY fk_secrets_repo_id;
+ALTER TABLE secrets DROP COLUMN secret_repo_id;
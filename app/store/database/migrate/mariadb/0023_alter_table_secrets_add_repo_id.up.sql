@@ -0,0 +1,4 @@
+ALTER TABLE secrets ADD COLUMN secret_repo_id BIGINT;
+
+ALTER TABLE secrets ADD CONSTRAINT fk_secrets_repo_id FOREIGN KEY (secret_repo_id)
+    REFERENCES repositories (repo_id) ON DELETE CASCADE;
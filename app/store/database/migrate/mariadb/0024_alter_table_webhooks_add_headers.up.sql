@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_headers VARCHAR(8192) NOT NULL DEFAULT '';
@@ -0,0 +1,41 @@
+CREATE TABLE runner_registration_tokens (
+ registration_token_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,registration_token_space_id    BIGINT NOT NULL
+,registration_token_uid         VARCHAR(100) NOT NULL
+,registration_token_description VARCHAR(1024) NOT NULL
+,registration_token_hash        VARCHAR(64) NOT NULL
+,registration_token_created_by  BIGINT NOT NULL
+,registration_token_created     BIGINT NOT NULL
+,registration_token_expires     BIGINT NOT NULL
+,UNIQUE KEY runner_registration_tokens_space_id_uid (registration_token_space_id, registration_token_uid)
+,UNIQUE KEY runner_registration_tokens_hash (registration_token_hash)
+,CONSTRAINT fk_registration_token_space_id FOREIGN KEY (registration_token_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_registration_token_created_by FOREIGN KEY (registration_token_created_by)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE runners (
+ runner_id             BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,runner_space_id       BIGINT NOT NULL
+,runner_uid            VARCHAR(100) NOT NULL
+,runner_token_hash     VARCHAR(64) NOT NULL
+,runner_os             VARCHAR(50) NOT NULL
+,runner_arch           VARCHAR(50) NOT NULL
+,runner_kernel         VARCHAR(50) NOT NULL
+,runner_variant        VARCHAR(50) NOT NULL
+,runner_labels         TEXT NOT NULL
+,runner_version        VARCHAR(100) NOT NULL
+,runner_capacity       INT NOT NULL
+,runner_running        INT NOT NULL
+,runner_last_heartbeat BIGINT NOT NULL
+,runner_created        BIGINT NOT NULL
+,runner_updated        BIGINT NOT NULL
+,UNIQUE KEY runners_space_id_uid (runner_space_id, runner_uid)
+,UNIQUE KEY runners_token_hash (runner_token_hash)
+,CONSTRAINT fk_runner_space_id FOREIGN KEY (runner_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+);
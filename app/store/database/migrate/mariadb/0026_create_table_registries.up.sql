@@ -0,0 +1,86 @@
+CREATE TABLE registries (
+ registry_id           BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,registry_space_id     BIGINT NOT NULL
+,registry_uid          VARCHAR(100) NOT NULL
+,registry_description  VARCHAR(1024) NOT NULL
+,registry_type         VARCHAR(50) NOT NULL
+,registry_max_versions BIGINT NOT NULL
+,registry_max_age      BIGINT NOT NULL
+,registry_created_by   BIGINT NOT NULL
+,registry_created      BIGINT NOT NULL
+,registry_updated      BIGINT NOT NULL
+,UNIQUE KEY registries_space_id_uid (registry_space_id, registry_uid)
+,CONSTRAINT fk_registry_space_id FOREIGN KEY (registry_space_id)
+    REFERENCES spaces (space_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_registry_created_by FOREIGN KEY (registry_created_by)
+    REFERENCES principals (principal_id)
+);
+
+CREATE TABLE registry_artifacts (
+ artifact_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,artifact_registry_id BIGINT NOT NULL
+,artifact_name        VARCHAR(255) NOT NULL
+,artifact_created     BIGINT NOT NULL
+,artifact_updated     BIGINT NOT NULL
+,UNIQUE KEY registry_artifacts_registry_id_name (artifact_registry_id, artifact_name)
+,CONSTRAINT fk_artifact_registry_id FOREIGN KEY (artifact_registry_id)
+    REFERENCES registries (registry_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE registry_versions (
+ version_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,version_artifact_id BIGINT NOT NULL
+,version_name        VARCHAR(255) NOT NULL
+,version_file_name   VARCHAR(255) NOT NULL
+,version_digest      VARCHAR(100) NOT NULL
+,version_media_type  VARCHAR(255) NOT NULL
+,version_size        BIGINT NOT NULL
+,version_created_by  BIGINT NOT NULL
+,version_created     BIGINT NOT NULL
+,version_updated     BIGINT NOT NULL
+,UNIQUE KEY registry_versions_artifact_id_name_file_name (version_artifact_id, version_name, version_file_name)
+,CONSTRAINT fk_version_artifact_id FOREIGN KEY (version_artifact_id)
+    REFERENCES registry_artifacts (artifact_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE registry_blobs (
+ blob_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,blob_registry_id BIGINT NOT NULL
+,blob_digest      VARCHAR(100) NOT NULL
+,blob_media_type  VARCHAR(255) NOT NULL
+,blob_size        BIGINT NOT NULL
+,blob_created     BIGINT NOT NULL
+,UNIQUE KEY registry_blobs_registry_id_digest (blob_registry_id, blob_digest)
+,CONSTRAINT fk_blob_registry_id FOREIGN KEY (blob_registry_id)
+    REFERENCES registries (registry_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE registry_manifest_references (
+ reference_registry_id     BIGINT NOT NULL
+,reference_manifest_digest VARCHAR(100) NOT NULL
+,reference_digest          VARCHAR(100) NOT NULL
+,UNIQUE KEY registry_manifest_references_registry_id_manifest_digest_digest
+    (reference_registry_id, reference_manifest_digest, reference_digest)
+,CONSTRAINT fk_reference_registry_id FOREIGN KEY (reference_registry_id)
+    REFERENCES registries (registry_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE registry_uploads (
+ upload_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,upload_registry_id BIGINT NOT NULL
+,upload_uid         VARCHAR(100) NOT NULL
+,upload_size        BIGINT NOT NULL
+,upload_parts       INT NOT NULL
+,upload_created_by  BIGINT NOT NULL
+,upload_created     BIGINT NOT NULL
+,upload_updated     BIGINT NOT NULL
+,UNIQUE KEY registry_uploads_uid (upload_uid)
+,CONSTRAINT fk_upload_registry_id FOREIGN KEY (upload_registry_id)
+    REFERENCES registries (registry_id)
+    ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE memberships ADD COLUMN membership_expires BIGINT;
+ALTER TABLE memberships ADD COLUMN membership_previous_role VARCHAR(50);
+
+CREATE INDEX memberships_expires
+    ON memberships(membership_expires);
@@ -0,0 +1 @@
+DROP TABLE malware_findings;
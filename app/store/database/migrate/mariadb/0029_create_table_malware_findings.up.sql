@@ -0,0 +1,16 @@
+CREATE TABLE malware_findings (
+ malware_finding_id           BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,malware_finding_repo_id      BIGINT NOT NULL
+,malware_finding_principal_id BIGINT NOT NULL
+,malware_finding_blob_sha     VARCHAR(64) NOT NULL
+,malware_finding_path         TEXT NOT NULL
+,malware_finding_signature    VARCHAR(255) NOT NULL
+,malware_finding_created      BIGINT NOT NULL
+,KEY malware_findings_repo_id_created (malware_finding_repo_id, malware_finding_created)
+,CONSTRAINT fk_malware_finding_repo_id FOREIGN KEY (malware_finding_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_malware_finding_principal_id FOREIGN KEY (malware_finding_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE repo_dependencies;
+DROP TABLE repo_dependency_scans;
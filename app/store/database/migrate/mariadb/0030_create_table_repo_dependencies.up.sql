@@ -0,0 +1,22 @@
+CREATE TABLE repo_dependency_scans (
+ repo_dependency_scan_repo_id  BIGINT NOT NULL PRIMARY KEY
+,repo_dependency_scan_sha      VARCHAR(64) NOT NULL
+,repo_dependency_scan_computed BIGINT NOT NULL
+,repo_dependency_scan_license  VARCHAR(100) NOT NULL
+,CONSTRAINT fk_repo_dependency_scan_repo_id FOREIGN KEY (repo_dependency_scan_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE repo_dependencies (
+ repo_dependency_id        BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,repo_dependency_repo_id   BIGINT NOT NULL
+,repo_dependency_manifest  VARCHAR(1024) NOT NULL
+,repo_dependency_ecosystem VARCHAR(50) NOT NULL
+,repo_dependency_name      VARCHAR(255) NOT NULL
+,repo_dependency_version   VARCHAR(255) NOT NULL
+,KEY repo_dependencies_ecosystem_name (repo_dependency_ecosystem, repo_dependency_name)
+,CONSTRAINT fk_repo_dependency_repo_id FOREIGN KEY (repo_dependency_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
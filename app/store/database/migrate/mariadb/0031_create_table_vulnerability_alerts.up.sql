@@ -0,0 +1,22 @@
+CREATE TABLE vulnerability_alerts (
+ vulnerability_alert_id               BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,vulnerability_alert_repo_id          BIGINT NOT NULL
+,vulnerability_alert_vulnerability_id VARCHAR(100) NOT NULL
+,vulnerability_alert_ecosystem        VARCHAR(50) NOT NULL
+,vulnerability_alert_package          VARCHAR(255) NOT NULL
+,vulnerability_alert_version          VARCHAR(100) NOT NULL
+,vulnerability_alert_manifest         VARCHAR(1024) NOT NULL
+,vulnerability_alert_severity         VARCHAR(50) NOT NULL
+,vulnerability_alert_summary          TEXT NOT NULL
+,vulnerability_alert_fixed_version    VARCHAR(100) NOT NULL
+,vulnerability_alert_state            VARCHAR(50) NOT NULL
+,vulnerability_alert_created          BIGINT NOT NULL
+,vulnerability_alert_updated          BIGINT NOT NULL
+,vulnerability_alert_resolved         BIGINT
+,UNIQUE KEY vulnerability_alerts_repo_id_vulnerability_package (vulnerability_alert_repo_id,
+    vulnerability_alert_vulnerability_id, vulnerability_alert_ecosystem, vulnerability_alert_package,
+    vulnerability_alert_version)
+,CONSTRAINT fk_vulnerability_alert_repo_id FOREIGN KEY (vulnerability_alert_repo_id)
+    REFERENCES repositories (repo_id)
+    ON DELETE CASCADE
+);
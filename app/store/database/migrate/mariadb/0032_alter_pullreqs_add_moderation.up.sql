@@ -0,0 +1,17 @@
+CREATE TABLE pullreq_activity_edits (
+ pullreq_activity_edit_id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,pullreq_activity_edit_activity_id BIGINT NOT NULL
+,pullreq_activity_edit_text        TEXT NOT NULL
+,pullreq_activity_edit_edited_by   BIGINT NOT NULL
+,pullreq_activity_edit_edited      BIGINT NOT NULL
+,KEY pullreq_activity_edits_activity_id (pullreq_activity_edit_activity_id)
+,CONSTRAINT fk_pullreq_activity_edit_activity_id FOREIGN KEY (pullreq_activity_edit_activity_id)
+    REFERENCES pullreq_activities (pullreq_activity_id)
+    ON DELETE CASCADE
+);
+
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_hidden BIGINT;
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_hidden_by BIGINT;
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_hidden_reason VARCHAR(50);
+
+ALTER TABLE pullreqs ADD COLUMN pullreq_conversation VARCHAR(50) NOT NULL DEFAULT 'open';
@@ -0,0 +1,12 @@
+CREATE TABLE saved_replies (
+ saved_reply_id           BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY
+,saved_reply_principal_id BIGINT NOT NULL
+,saved_reply_title        VARCHAR(255) NOT NULL
+,saved_reply_text         TEXT NOT NULL
+,saved_reply_created      BIGINT NOT NULL
+,saved_reply_updated      BIGINT NOT NULL
+,UNIQUE KEY saved_replies_principal_id_title (saved_reply_principal_id, saved_reply_title)
+,CONSTRAINT fk_saved_reply_principal_id FOREIGN KEY (saved_reply_principal_id)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+CREATE TABLE pullreq_merge_schedules (
+ merge_schedule_pullreq_id BIGINT NOT NULL PRIMARY KEY
+,merge_schedule_created_by BIGINT NOT NULL
+,merge_schedule_created    BIGINT NOT NULL
+,merge_schedule_merge_at   BIGINT NOT NULL
+,merge_schedule_method     VARCHAR(50) NOT NULL
+,merge_schedule_source_sha VARCHAR(64) NOT NULL
+,merge_schedule_title      TEXT NOT NULL
+,merge_schedule_message    TEXT NOT NULL
+,KEY pullreq_merge_schedules_merge_at (merge_schedule_merge_at)
+,CONSTRAINT fk_merge_schedule_pullreq_id FOREIGN KEY (merge_schedule_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON DELETE CASCADE
+,CONSTRAINT fk_merge_schedule_created_by FOREIGN KEY (merge_schedule_created_by)
+    REFERENCES principals (principal_id)
+    ON DELETE CASCADE
+);
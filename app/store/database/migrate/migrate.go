@@ -32,6 +32,9 @@ var postgres embed.FS
 //go:embed sqlite/*.sql
 var sqlite embed.FS
 
+//go:embed mariadb/*.sql
+var mariadb embed.FS
+
 const (
 	tableName = "migrations"
 
@@ -40,6 +43,9 @@ const (
 
 	sqliteDriverName = "sqlite3"
 	sqliteSourceDir  = "sqlite"
+
+	mariadbDriverName = "mariadb"
+	mariadbSourceDir  = "mariadb"
 )
 
 // Migrate performs the database migration.
@@ -78,6 +84,11 @@ func Current(ctx context.Context, db *sqlx.DB) (string, error) {
 			SELECT count(*)
 			FROM information_schema.tables
 			WHERE table_name = ? and table_schema = 'public'`
+	case mariadbDriverName:
+		query = `
+			SELECT count(*)
+			FROM information_schema.tables
+			WHERE table_name = ? and table_schema = DATABASE()`
 	default:
 		return "", fmt.Errorf("unsupported driver '%s'", db.DriverName())
 	}
@@ -134,25 +145,34 @@ func getMigrator(db *sqlx.DB) (migrate.Options, error) {
 		}
 	}
 
+	folder, err := getSourceFS(db.DriverName())
+	if err != nil {
+		return migrate.Options{}, err
+	}
+
 	opts := migrate.Options{
 		After:  after,
 		Before: before,
 		DB:     db.DB,
-		FS:     sqlite,
+		FS:     folder,
 		Table:  tableName,
 	}
 
-	switch db.DriverName() {
+	return opts, nil
+}
+
+func getSourceFS(driverName string) (fs.FS, error) {
+	switch driverName {
 	case sqliteDriverName:
 		folder, _ := fs.Sub(sqlite, sqliteSourceDir)
-		opts.FS = folder
+		return folder, nil
 	case postgresDriverName:
 		folder, _ := fs.Sub(postgres, postgresSourceDir)
-		opts.FS = folder
-
+		return folder, nil
+	case mariadbDriverName:
+		folder, _ := fs.Sub(mariadb, mariadbSourceDir)
+		return folder, nil
 	default:
-		return migrate.Options{}, fmt.Errorf("unsupported driver '%s'", db.DriverName())
+		return nil, fmt.Errorf("unsupported driver '%s'", driverName)
 	}
-
-	return opts, nil
 }
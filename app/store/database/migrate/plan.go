@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Direction defines the direction in which a migration step is applied.
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+var (
+	upFileMatcher   = regexp.MustCompile(`^([\w-]+).up.sql$`)
+	downFileMatcher = regexp.MustCompile(`^([\w-]+).down.sql$`)
+)
+
+// Step describes a single migration file that is applied to move the database between versions.
+type Step struct {
+	// Version is the version of the migration file.
+	Version string
+	// Direction is the direction of the migration.
+	Direction Direction
+	// File is the name of the migration file.
+	File string
+	// Result is the version the database reports after the step was applied.
+	Result string
+	// SQL is the content of the migration file.
+	SQL string
+}
+
+// Status describes whether a migration version is applied to the database.
+type Status struct {
+	Version string
+	Applied bool
+	// Reversible indicates whether the version has a down migration.
+	Reversible bool
+}
+
+// Versions returns all migration versions known for the driver of the database, in ascending order.
+func Versions(db *sqlx.DB) ([]string, error) {
+	fsys, err := getSourceFS(db.DriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	return getVersions(fsys, upFileMatcher)
+}
+
+// ListStatus returns the status of all migration versions known for the driver of the database.
+func ListStatus(ctx context.Context, db *sqlx.DB) ([]Status, error) {
+	fsys, err := getSourceFS(db.DriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	upVersions, err := getVersions(fsys, upFileMatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	downVersions, err := getVersions(fsys, downFileMatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	reversible := make(map[string]bool, len(downVersions))
+	for _, version := range downVersions {
+		reversible[version] = true
+	}
+
+	statuses := make([]Status, len(upVersions))
+	for i, version := range upVersions {
+		statuses[i] = Status{
+			Version:    version,
+			Applied:    version <= current,
+			Reversible: reversible[version],
+		}
+	}
+
+	return statuses, nil
+}
+
+// Previous returns the version the database is at after reverting the provided number of down migrations.
+// An empty string is returned if all down migrations would be reverted.
+func Previous(ctx context.Context, db *sqlx.DB, steps int) (string, error) {
+	if steps < 1 {
+		return "", fmt.Errorf("number of steps has to be at least 1, got %d", steps)
+	}
+
+	fsys, err := getSourceFS(db.DriverName())
+	if err != nil {
+		return "", err
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := getVersions(fsys, downFileMatcher)
+	if err != nil {
+		return "", err
+	}
+
+	// find the index of the latest down migration that's applied.
+	idx := sort.SearchStrings(versions, current)
+	if idx == len(versions) || versions[idx] != current {
+		idx--
+	}
+
+	if idx-steps < 0 {
+		return "", nil
+	}
+
+	return versions[idx-steps], nil
+}
+
+// Plan returns the migration steps that would be applied to move the database to the provided version,
+// without executing any of them. An empty version plans the migration to the latest version.
+// Note that migration steps implemented in code are executed as part of the listed steps but not part of the SQL.
+func Plan(ctx context.Context, db *sqlx.DB, version string) ([]Step, error) {
+	fsys, err := getSourceFS(db.DriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		upVersions, err := getVersions(fsys, upFileMatcher)
+		if err != nil {
+			return nil, err
+		}
+		if len(upVersions) > 0 {
+			version = upVersions[len(upVersions)-1]
+		}
+	}
+
+	switch {
+	case version > current:
+		return planUp(fsys, current, version)
+	case version < current:
+		return planDown(fsys, current, version)
+	default:
+		return nil, nil
+	}
+}
+
+// PlanDown returns the migration steps that would be applied to revert all migrations.
+func PlanDown(ctx context.Context, db *sqlx.DB) ([]Step, error) {
+	fsys, err := getSourceFS(db.DriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return planDown(fsys, current, "")
+}
+
+func planUp(fsys fs.FS, current string, target string) ([]Step, error) {
+	files, err := getFiles(fsys, upFileMatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsVersion(files, target) {
+		return nil, fmt.Errorf("unknown migration version '%s'", target)
+	}
+
+	var steps []Step
+	for _, file := range files {
+		if file.version <= current {
+			continue
+		}
+		if file.version > target {
+			break
+		}
+
+		step, err := newStep(fsys, file, DirectionUp, file.version)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+func planDown(fsys fs.FS, current string, target string) ([]Step, error) {
+	files, err := getFiles(fsys, downFileMatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	if target != "" && !containsVersion(files, target) {
+		return nil, fmt.Errorf("unknown or irreversible migration version '%s'", target)
+	}
+
+	var steps []Step
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		if file.version > current {
+			continue
+		}
+		if file.version <= target {
+			break
+		}
+
+		result := ""
+		if i > 0 {
+			result = files[i-1].version
+		}
+
+		step, err := newStep(fsys, file, DirectionDown, result)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+func newStep(fsys fs.FS, file migrationFile, direction Direction, result string) (Step, error) {
+	content, err := fs.ReadFile(fsys, file.name)
+	if err != nil {
+		return Step{}, fmt.Errorf("failed to read migration file '%s': %w", file.name, err)
+	}
+
+	return Step{
+		Version:   file.version,
+		Direction: direction,
+		File:      file.name,
+		Result:    result,
+		SQL:       string(content),
+	}, nil
+}
+
+// migrationFile describes a migration file and the version it belongs to.
+type migrationFile struct {
+	version string
+	name    string
+}
+
+func getFiles(fsys fs.FS, matcher *regexp.Regexp) ([]migrationFile, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration files: %w", err)
+	}
+
+	var files []migrationFile
+	for _, entry := range entries {
+		if !matcher.MatchString(entry.Name()) {
+			continue
+		}
+		files = append(files, migrationFile{
+			version: matcher.ReplaceAllString(entry.Name(), "$1"),
+			name:    entry.Name(),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	return files, nil
+}
+
+func getVersions(fsys fs.FS, matcher *regexp.Regexp) ([]string, error) {
+	files, err := getFiles(fsys, matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, len(files))
+	for i, file := range files {
+		versions[i] = file.version
+	}
+
+	return versions, nil
+}
+
+func containsVersion(files []migrationFile, version string) bool {
+	for _, file := range files {
+		if file.version == version {
+			return true
+		}
+	}
+	return false
+}
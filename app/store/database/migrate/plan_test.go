@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", fmt.Sprintf("file:%s.db?mode=memory&cache=shared", xid.New().String()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	versions, err := Versions(db)
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+	latest := versions[len(versions)-1]
+
+	// all migrations are pending on a new database.
+	steps, err := Plan(ctx, db, "")
+	require.NoError(t, err)
+	require.Len(t, steps, len(versions))
+	assert.Equal(t, DirectionUp, steps[0].Direction)
+	assert.Equal(t, latest, steps[len(steps)-1].Result)
+
+	// planning must not touch the database.
+	current, err := Current(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, "", current)
+
+	require.NoError(t, Migrate(ctx, db))
+
+	steps, err = Plan(ctx, db, "")
+	require.NoError(t, err)
+	assert.Empty(t, steps)
+
+	statuses, err := ListStatus(ctx, db)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, "version %s", status.Version)
+	}
+
+	// revert the latest migration.
+	previous, err := Previous(ctx, db, 1)
+	require.NoError(t, err)
+
+	steps, err = Plan(ctx, db, previous)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, DirectionDown, steps[0].Direction)
+	assert.Equal(t, latest, steps[0].Version)
+	assert.Equal(t, previous, steps[0].Result)
+
+	_, err = Plan(ctx, db, "9999_unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,6 @@
+DROP TRIGGER pullreq_search_activity ON pullreq_activities;
+DROP TRIGGER pullreq_search_pullreq ON pullreqs;
+DROP FUNCTION pullreq_search_activity_trigger();
+DROP FUNCTION pullreq_search_pullreq_trigger();
+DROP FUNCTION pullreq_search_refresh(INTEGER);
+DROP TABLE pullreq_search;
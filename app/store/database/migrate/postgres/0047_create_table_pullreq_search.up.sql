@@ -0,0 +1,59 @@
+CREATE TABLE pullreq_search (
+ pullreq_search_pullreq_id INTEGER PRIMARY KEY
+,pullreq_search_vector TSVECTOR NOT NULL
+,CONSTRAINT fk_pullreq_search_pullreq_id FOREIGN KEY (pullreq_search_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX pullreq_search_vector
+    ON pullreq_search USING GIN (pullreq_search_vector);
+
+CREATE FUNCTION pullreq_search_refresh(pr_id INTEGER) RETURNS void AS $$
+BEGIN
+    INSERT INTO pullreq_search (pullreq_search_pullreq_id, pullreq_search_vector)
+    SELECT
+         pullreq_id
+        ,setweight(to_tsvector('english', pullreq_title), 'A') ||
+         setweight(to_tsvector('english', pullreq_description), 'B') ||
+         setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(pullreq_activity_text, ' ')
+            FROM pullreq_activities
+            WHERE
+                pullreq_activity_pullreq_id = pullreq_id AND
+                pullreq_activity_deleted IS NULL AND
+                pullreq_activity_kind <> 'system'
+         ), '')), 'C')
+    FROM pullreqs
+    WHERE pullreq_id = pr_id
+    ON CONFLICT (pullreq_search_pullreq_id)
+    DO UPDATE SET pullreq_search_vector = EXCLUDED.pullreq_search_vector;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION pullreq_search_pullreq_trigger() RETURNS trigger AS $$
+BEGIN
+    PERFORM pullreq_search_refresh(NEW.pullreq_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION pullreq_search_activity_trigger() RETURNS trigger AS $$
+BEGIN
+    IF NEW.pullreq_activity_kind <> 'system' THEN
+        PERFORM pullreq_search_refresh(NEW.pullreq_activity_pullreq_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pullreq_search_pullreq
+    AFTER INSERT OR UPDATE OF pullreq_title, pullreq_description ON pullreqs
+    FOR EACH ROW EXECUTE FUNCTION pullreq_search_pullreq_trigger();
+
+CREATE TRIGGER pullreq_search_activity
+    AFTER INSERT OR UPDATE OF pullreq_activity_text, pullreq_activity_deleted ON pullreq_activities
+    FOR EACH ROW EXECUTE FUNCTION pullreq_search_activity_trigger();
+
+SELECT pullreq_search_refresh(pullreq_id) FROM pullreqs;
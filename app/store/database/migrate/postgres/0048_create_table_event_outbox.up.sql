@@ -0,0 +1,6 @@
+CREATE TABLE event_outbox (
+ event_outbox_id SERIAL PRIMARY KEY
+,event_outbox_stream_id TEXT NOT NULL
+,event_outbox_payload BYTEA NOT NULL
+,event_outbox_created BIGINT NOT NULL
+);
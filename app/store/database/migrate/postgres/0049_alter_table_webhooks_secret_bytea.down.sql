@@ -0,0 +1,2 @@
+ALTER TABLE webhooks
+    ALTER COLUMN webhook_secret TYPE TEXT USING encode(webhook_secret, 'escape');
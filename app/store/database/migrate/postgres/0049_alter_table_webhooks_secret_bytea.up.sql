@@ -0,0 +1,2 @@
+ALTER TABLE webhooks
+    ALTER COLUMN webhook_secret TYPE BYTEA USING convert_to(webhook_secret, 'UTF8');
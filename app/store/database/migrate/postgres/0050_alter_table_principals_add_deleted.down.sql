@@ -0,0 +1,4 @@
+DROP INDEX principals_deleted;
+
+ALTER TABLE principals DROP COLUMN principal_purged;
+ALTER TABLE principals DROP COLUMN principal_deleted;
@@ -0,0 +1,12 @@
+CREATE TABLE system_events (
+ system_event_id SERIAL PRIMARY KEY
+,system_event_type TEXT NOT NULL
+,system_event_principal_id INTEGER
+,system_event_resource_id INTEGER
+,system_event_resource_path TEXT NOT NULL
+,system_event_message TEXT NOT NULL
+,system_event_created BIGINT NOT NULL
+);
+
+CREATE INDEX system_events_created
+    ON system_events(system_event_created);
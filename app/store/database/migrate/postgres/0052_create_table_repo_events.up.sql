@@ -0,0 +1,14 @@
+CREATE TABLE repo_events (
+ repo_event_id SERIAL PRIMARY KEY
+,repo_event_repo_id INTEGER NOT NULL
+,repo_event_pullreq_id INTEGER
+,repo_event_type TEXT NOT NULL
+,repo_event_data TEXT NOT NULL
+,repo_event_created BIGINT NOT NULL
+);
+
+CREATE INDEX repo_events_repo_id
+    ON repo_events(repo_event_repo_id, repo_event_id);
+
+CREATE INDEX repo_events_created
+    ON repo_events(repo_event_created);
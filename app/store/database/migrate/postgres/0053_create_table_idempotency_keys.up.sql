@@ -0,0 +1,20 @@
+CREATE TABLE idempotency_keys (
+ idempotency_key_id SERIAL PRIMARY KEY
+,idempotency_key_principal_id INTEGER NOT NULL
+,idempotency_key_key TEXT NOT NULL
+,idempotency_key_fingerprint TEXT NOT NULL
+,idempotency_key_response_status INTEGER NOT NULL
+,idempotency_key_response_content_type TEXT NOT NULL
+,idempotency_key_response_body TEXT NOT NULL
+,idempotency_key_created BIGINT NOT NULL
+,CONSTRAINT fk_idempotency_key_principal_id FOREIGN KEY (idempotency_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idempotency_keys_principal_id_key
+    ON idempotency_keys(idempotency_key_principal_id, idempotency_key_key);
+
+CREATE INDEX idempotency_keys_created
+    ON idempotency_keys(idempotency_key_created);
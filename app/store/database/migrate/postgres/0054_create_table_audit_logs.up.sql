@@ -0,0 +1,24 @@
+CREATE TABLE audit_logs (
+ audit_log_id SERIAL PRIMARY KEY
+,audit_log_request_id TEXT NOT NULL
+,audit_log_principal_id INTEGER
+,audit_log_method TEXT NOT NULL
+,audit_log_path TEXT NOT NULL
+,audit_log_query TEXT NOT NULL
+,audit_log_remote_addr TEXT NOT NULL
+,audit_log_user_agent TEXT NOT NULL
+,audit_log_status INTEGER NOT NULL
+,audit_log_request_body TEXT NOT NULL
+,audit_log_request_body_truncated BOOLEAN NOT NULL
+,audit_log_duration BIGINT NOT NULL
+,audit_log_created BIGINT NOT NULL
+);
+
+CREATE INDEX audit_logs_request_id
+    ON audit_logs(audit_log_request_id);
+
+CREATE INDEX audit_logs_principal_id
+    ON audit_logs(audit_log_principal_id, audit_log_id);
+
+CREATE INDEX audit_logs_created
+    ON audit_logs(audit_log_created);
@@ -0,0 +1 @@
+ALTER TABLE spaces DROP COLUMN space_git_bandwidth_limit;
@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_pullreq_suggestions BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,12 @@
+CREATE TABLE settings (
+ setting_id SERIAL PRIMARY KEY
+,setting_scope TEXT NOT NULL
+,setting_scope_id INTEGER NOT NULL
+,setting_key TEXT NOT NULL
+,setting_value TEXT NOT NULL
+,setting_updated BIGINT NOT NULL
+,setting_updated_by INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX settings_scope_scope_id_key
+    ON settings(setting_scope, setting_scope_id, setting_key);
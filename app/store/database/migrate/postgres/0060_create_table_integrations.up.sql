@@ -0,0 +1,33 @@
+CREATE TABLE integrations (
+ integration_id SERIAL PRIMARY KEY
+,integration_version INTEGER NOT NULL
+,integration_space_id INTEGER
+,integration_repo_id INTEGER
+,integration_uid TEXT NOT NULL
+,integration_description TEXT NOT NULL
+,integration_type TEXT NOT NULL
+,integration_url BYTEA NOT NULL
+,integration_token BYTEA NOT NULL
+,integration_channel TEXT NOT NULL
+,integration_events TEXT NOT NULL
+,integration_enabled BOOLEAN NOT NULL
+,integration_created_by INTEGER NOT NULL
+,integration_created BIGINT NOT NULL
+,integration_updated BIGINT NOT NULL
+,CONSTRAINT fk_integration_space_id FOREIGN KEY (integration_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_integration_repo_id FOREIGN KEY (integration_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX integrations_space_id_uid
+    ON integrations(integration_space_id, LOWER(integration_uid))
+    WHERE integration_repo_id IS NULL;
+
+CREATE UNIQUE INDEX integrations_repo_id_uid
+    ON integrations(integration_repo_id, LOWER(integration_uid))
+    WHERE integration_space_id IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE issue_assignees;
+DROP TABLE issue_labels;
+DROP TABLE issues;
+
+ALTER TABLE repositories DROP COLUMN repo_issue_seq;
@@ -0,0 +1,10 @@
+CREATE TABLE repo_insights (
+ repo_insights_repo_id INTEGER PRIMARY KEY
+,repo_insights_sha TEXT NOT NULL
+,repo_insights_computed BIGINT NOT NULL
+,repo_insights_data TEXT NOT NULL
+,CONSTRAINT fk_repo_insights_repo_id FOREIGN KEY (repo_insights_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP INDEX secrets_repo_id;
+ALTER TABLE secrets DROP CONSTRAINT fk_secrets_repo_id;
+ALTER TABLE secrets DROP COLUMN secret_repo_id;
@@ -0,0 +1,6 @@
+ALTER TABLE secrets ADD COLUMN secret_repo_id INTEGER;
+
+ALTER TABLE secrets ADD CONSTRAINT fk_secrets_repo_id FOREIGN KEY (secret_repo_id)
+    REFERENCES repositories (repo_id) ON DELETE CASCADE;
+
+CREATE INDEX secrets_repo_id ON secrets(secret_repo_id);
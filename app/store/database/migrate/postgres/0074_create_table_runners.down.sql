@@ -0,0 +1,2 @@
+DROP TABLE runners;
+DROP TABLE runner_registration_tokens;
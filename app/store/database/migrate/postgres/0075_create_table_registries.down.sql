@@ -0,0 +1,6 @@
+DROP TABLE registry_uploads;
+DROP TABLE registry_manifest_references;
+DROP TABLE registry_blobs;
+DROP TABLE registry_versions;
+DROP TABLE registry_artifacts;
+DROP TABLE registries;
@@ -0,0 +1,4 @@
+DROP INDEX memberships_expires;
+
+ALTER TABLE memberships DROP COLUMN membership_previous_role;
+ALTER TABLE memberships DROP COLUMN membership_expires;
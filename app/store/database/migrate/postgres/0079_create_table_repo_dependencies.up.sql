@@ -0,0 +1,29 @@
+CREATE TABLE repo_dependency_scans (
+ repo_dependency_scan_repo_id INTEGER PRIMARY KEY
+,repo_dependency_scan_sha TEXT NOT NULL
+,repo_dependency_scan_computed BIGINT NOT NULL
+,repo_dependency_scan_license TEXT NOT NULL
+,CONSTRAINT fk_repo_dependency_scan_repo_id FOREIGN KEY (repo_dependency_scan_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE repo_dependencies (
+ repo_dependency_id SERIAL PRIMARY KEY
+,repo_dependency_repo_id INTEGER NOT NULL
+,repo_dependency_manifest TEXT NOT NULL
+,repo_dependency_ecosystem TEXT NOT NULL
+,repo_dependency_name TEXT NOT NULL
+,repo_dependency_version TEXT NOT NULL
+,CONSTRAINT fk_repo_dependency_repo_id FOREIGN KEY (repo_dependency_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_dependencies_repo_id
+    ON repo_dependencies(repo_dependency_repo_id);
+
+CREATE INDEX repo_dependencies_ecosystem_name
+    ON repo_dependencies(repo_dependency_ecosystem, repo_dependency_name);
@@ -0,0 +1 @@
+DROP TABLE vulnerability_alerts;
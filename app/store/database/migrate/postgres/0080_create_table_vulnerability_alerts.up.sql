@@ -0,0 +1,24 @@
+CREATE TABLE vulnerability_alerts (
+ vulnerability_alert_id SERIAL PRIMARY KEY
+,vulnerability_alert_repo_id INTEGER NOT NULL
+,vulnerability_alert_vulnerability_id TEXT NOT NULL
+,vulnerability_alert_ecosystem TEXT NOT NULL
+,vulnerability_alert_package TEXT NOT NULL
+,vulnerability_alert_version TEXT NOT NULL
+,vulnerability_alert_manifest TEXT NOT NULL
+,vulnerability_alert_severity TEXT NOT NULL
+,vulnerability_alert_summary TEXT NOT NULL
+,vulnerability_alert_fixed_version TEXT NOT NULL
+,vulnerability_alert_state TEXT NOT NULL
+,vulnerability_alert_created BIGINT NOT NULL
+,vulnerability_alert_updated BIGINT NOT NULL
+,vulnerability_alert_resolved BIGINT
+,CONSTRAINT fk_vulnerability_alert_repo_id FOREIGN KEY (vulnerability_alert_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX vulnerability_alerts_repo_id_vulnerability_package
+    ON vulnerability_alerts(vulnerability_alert_repo_id, vulnerability_alert_vulnerability_id,
+        vulnerability_alert_ecosystem, vulnerability_alert_package, vulnerability_alert_version);
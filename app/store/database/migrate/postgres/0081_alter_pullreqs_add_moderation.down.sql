@@ -0,0 +1,7 @@
+ALTER TABLE pullreqs DROP COLUMN pullreq_conversation;
+
+ALTER TABLE pullreq_activities DROP COLUMN pullreq_activity_hidden_reason;
+ALTER TABLE pullreq_activities DROP COLUMN pullreq_activity_hidden_by;
+ALTER TABLE pullreq_activities DROP COLUMN pullreq_activity_hidden;
+
+DROP TABLE pullreq_activity_edits;
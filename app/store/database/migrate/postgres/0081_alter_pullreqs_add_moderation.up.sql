@@ -0,0 +1,20 @@
+CREATE TABLE pullreq_activity_edits (
+ pullreq_activity_edit_id SERIAL PRIMARY KEY
+,pullreq_activity_edit_activity_id INTEGER NOT NULL
+,pullreq_activity_edit_text TEXT NOT NULL
+,pullreq_activity_edit_edited_by INTEGER NOT NULL
+,pullreq_activity_edit_edited BIGINT NOT NULL
+,CONSTRAINT fk_pullreq_activity_edit_activity_id FOREIGN KEY (pullreq_activity_edit_activity_id)
+    REFERENCES pullreq_activities (pullreq_activity_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX pullreq_activity_edits_activity_id
+    ON pullreq_activity_edits(pullreq_activity_edit_activity_id);
+
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_hidden BIGINT;
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_hidden_by INTEGER;
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_hidden_reason TEXT;
+
+ALTER TABLE pullreqs ADD COLUMN pullreq_conversation TEXT NOT NULL DEFAULT 'open';
@@ -0,0 +1 @@
+DROP TABLE pullreq_merge_schedules;
@@ -0,0 +1,21 @@
+CREATE TABLE pullreq_merge_schedules (
+ merge_schedule_pullreq_id INTEGER PRIMARY KEY
+,merge_schedule_created_by INTEGER NOT NULL
+,merge_schedule_created BIGINT NOT NULL
+,merge_schedule_merge_at BIGINT NOT NULL
+,merge_schedule_method TEXT NOT NULL
+,merge_schedule_source_sha TEXT NOT NULL
+,merge_schedule_title TEXT NOT NULL
+,merge_schedule_message TEXT NOT NULL
+,CONSTRAINT fk_merge_schedule_pullreq_id FOREIGN KEY (merge_schedule_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_merge_schedule_created_by FOREIGN KEY (merge_schedule_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX pullreq_merge_schedules_merge_at
+    ON pullreq_merge_schedules(merge_schedule_merge_at);
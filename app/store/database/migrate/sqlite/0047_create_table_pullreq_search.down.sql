@@ -0,0 +1,6 @@
+DROP TRIGGER pullreq_search_activity_update;
+DROP TRIGGER pullreq_search_activity_insert;
+DROP TRIGGER pullreq_search_pullreq_delete;
+DROP TRIGGER pullreq_search_pullreq_update;
+DROP TRIGGER pullreq_search_pullreq_insert;
+DROP TABLE pullreq_search;
@@ -0,0 +1,71 @@
+CREATE VIRTUAL TABLE pullreq_search USING fts4 (
+ pullreq_search_title
+,pullreq_search_description
+,pullreq_search_comments
+);
+
+INSERT INTO pullreq_search (docid, pullreq_search_title, pullreq_search_description, pullreq_search_comments)
+SELECT
+     pullreq_id
+    ,pullreq_title
+    ,pullreq_description
+    ,COALESCE((
+        SELECT group_concat(pullreq_activity_text, ' ')
+        FROM pullreq_activities
+        WHERE
+            pullreq_activity_pullreq_id = pullreq_id AND
+            pullreq_activity_deleted IS NULL AND
+            pullreq_activity_kind <> 'system'
+    ), '')
+FROM pullreqs;
+
+CREATE TRIGGER pullreq_search_pullreq_insert AFTER INSERT ON pullreqs
+BEGIN
+    INSERT INTO pullreq_search (docid, pullreq_search_title, pullreq_search_description, pullreq_search_comments)
+    VALUES (new.pullreq_id, new.pullreq_title, new.pullreq_description, '');
+END;
+
+CREATE TRIGGER pullreq_search_pullreq_update AFTER UPDATE OF pullreq_title, pullreq_description ON pullreqs
+BEGIN
+    UPDATE pullreq_search
+    SET
+         pullreq_search_title = new.pullreq_title
+        ,pullreq_search_description = new.pullreq_description
+    WHERE docid = new.pullreq_id;
+END;
+
+CREATE TRIGGER pullreq_search_pullreq_delete AFTER DELETE ON pullreqs
+BEGIN
+    DELETE FROM pullreq_search WHERE docid = old.pullreq_id;
+END;
+
+CREATE TRIGGER pullreq_search_activity_insert AFTER INSERT ON pullreq_activities
+WHEN new.pullreq_activity_kind <> 'system'
+BEGIN
+    UPDATE pullreq_search
+    SET pullreq_search_comments = COALESCE((
+        SELECT group_concat(pullreq_activity_text, ' ')
+        FROM pullreq_activities
+        WHERE
+            pullreq_activity_pullreq_id = new.pullreq_activity_pullreq_id AND
+            pullreq_activity_deleted IS NULL AND
+            pullreq_activity_kind <> 'system'
+    ), '')
+    WHERE docid = new.pullreq_activity_pullreq_id;
+END;
+
+CREATE TRIGGER pullreq_search_activity_update
+AFTER UPDATE OF pullreq_activity_text, pullreq_activity_deleted ON pullreq_activities
+WHEN new.pullreq_activity_kind <> 'system'
+BEGIN
+    UPDATE pullreq_search
+    SET pullreq_search_comments = COALESCE((
+        SELECT group_concat(pullreq_activity_text, ' ')
+        FROM pullreq_activities
+        WHERE
+            pullreq_activity_pullreq_id = new.pullreq_activity_pullreq_id AND
+            pullreq_activity_deleted IS NULL AND
+            pullreq_activity_kind <> 'system'
+    ), '')
+    WHERE docid = new.pullreq_activity_pullreq_id;
+END;
@@ -0,0 +1,6 @@
+CREATE TABLE event_outbox (
+ event_outbox_id INTEGER PRIMARY KEY AUTOINCREMENT
+,event_outbox_stream_id TEXT NOT NULL
+,event_outbox_payload BLOB NOT NULL
+,event_outbox_created BIGINT NOT NULL
+);
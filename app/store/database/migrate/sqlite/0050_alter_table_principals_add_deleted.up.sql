@@ -0,0 +1,6 @@
+ALTER TABLE principals ADD COLUMN principal_deleted BIGINT DEFAULT NULL;
+ALTER TABLE principals ADD COLUMN principal_purged BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX principals_deleted
+    ON principals(principal_deleted)
+    WHERE principal_deleted IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE system_events;
@@ -0,0 +1,22 @@
+CREATE TABLE external_hooks (
+ external_hook_id INTEGER PRIMARY KEY AUTOINCREMENT
+,external_hook_version INTEGER NOT NULL
+,external_hook_space_id INTEGER NOT NULL
+,external_hook_uid TEXT NOT NULL
+,external_hook_description TEXT NOT NULL
+,external_hook_type TEXT NOT NULL
+,external_hook_target TEXT NOT NULL
+,external_hook_secret BLOB NOT NULL
+,external_hook_timeout INTEGER NOT NULL
+,external_hook_enabled BOOLEAN NOT NULL
+,external_hook_created_by INTEGER NOT NULL
+,external_hook_created BIGINT NOT NULL
+,external_hook_updated BIGINT NOT NULL
+,CONSTRAINT fk_external_hook_space_id FOREIGN KEY (external_hook_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX external_hooks_space_id_uid
+    ON external_hooks(external_hook_space_id, LOWER(external_hook_uid));
@@ -0,0 +1 @@
+DROP TABLE pushed_branches;
@@ -0,0 +1,16 @@
+CREATE TABLE pushed_branches (
+ pushed_branch_repo_id INTEGER NOT NULL
+,pushed_branch_principal_id INTEGER NOT NULL
+,pushed_branch_name TEXT NOT NULL
+,pushed_branch_sha TEXT NOT NULL
+,pushed_branch_created BIGINT NOT NULL
+,CONSTRAINT pk_pushed_branches PRIMARY KEY (pushed_branch_repo_id, pushed_branch_principal_id)
+,CONSTRAINT fk_pushed_branch_repo_id FOREIGN KEY (pushed_branch_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pushed_branch_principal_id FOREIGN KEY (pushed_branch_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
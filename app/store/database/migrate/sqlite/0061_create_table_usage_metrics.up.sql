@@ -0,0 +1,32 @@
+CREATE TABLE usage_metrics (
+ usage_metric_repo_id INTEGER NOT NULL
+,usage_metric_date BIGINT NOT NULL
+,usage_metric_disk_usage BIGINT NOT NULL DEFAULT 0
+,usage_metric_pullreqs_created INTEGER NOT NULL DEFAULT 0
+,usage_metric_pullreqs_merged INTEGER NOT NULL DEFAULT 0
+,usage_metric_git_bytes_in BIGINT NOT NULL DEFAULT 0
+,usage_metric_git_bytes_out BIGINT NOT NULL DEFAULT 0
+,usage_metric_updated BIGINT NOT NULL
+,CONSTRAINT pk_usage_metrics PRIMARY KEY (usage_metric_repo_id, usage_metric_date)
+,CONSTRAINT fk_usage_metric_repo_id FOREIGN KEY (usage_metric_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX usage_metrics_date
+    ON usage_metrics(usage_metric_date);
+
+CREATE TABLE usage_active_users (
+ usage_active_user_repo_id INTEGER NOT NULL
+,usage_active_user_date BIGINT NOT NULL
+,usage_active_user_principal_id INTEGER NOT NULL
+,CONSTRAINT pk_usage_active_users PRIMARY KEY (usage_active_user_repo_id, usage_active_user_date, usage_active_user_principal_id)
+,CONSTRAINT fk_usage_active_user_repo_id FOREIGN KEY (usage_active_user_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX usage_active_users_date
+    ON usage_active_users(usage_active_user_date);
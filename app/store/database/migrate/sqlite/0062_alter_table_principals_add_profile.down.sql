@@ -0,0 +1,4 @@
+ALTER TABLE principals DROP COLUMN principal_status_message;
+ALTER TABLE principals DROP COLUMN principal_pronouns;
+ALTER TABLE principals DROP COLUMN principal_time_zone;
+ALTER TABLE principals DROP COLUMN principal_avatar;
@@ -0,0 +1,4 @@
+ALTER TABLE principals ADD COLUMN principal_avatar TEXT NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_time_zone TEXT NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_pronouns TEXT NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_status_message TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,20 @@
+CREATE TABLE pushes (
+ push_id INTEGER PRIMARY KEY AUTOINCREMENT
+,push_repo_id INTEGER NOT NULL
+,push_principal_id INTEGER NOT NULL
+,push_ref TEXT NOT NULL
+,push_old_sha TEXT NOT NULL
+,push_new_sha TEXT NOT NULL
+,push_created BIGINT NOT NULL
+,CONSTRAINT fk_push_repo_id FOREIGN KEY (push_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_push_principal_id FOREIGN KEY (push_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX pushes_principal_id_created
+    ON pushes(push_principal_id, push_created);
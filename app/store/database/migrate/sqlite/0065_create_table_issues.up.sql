@@ -0,0 +1,56 @@
+ALTER TABLE repositories ADD COLUMN repo_issue_seq INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE issues (
+ issue_id INTEGER PRIMARY KEY AUTOINCREMENT
+,issue_version INTEGER NOT NULL
+,issue_repo_id INTEGER NOT NULL
+,issue_number INTEGER NOT NULL
+,issue_created_by INTEGER NOT NULL
+,issue_created BIGINT NOT NULL
+,issue_updated BIGINT NOT NULL
+,issue_edited BIGINT NOT NULL
+,issue_state TEXT NOT NULL
+,issue_title TEXT NOT NULL
+,issue_description TEXT NOT NULL
+,issue_closed_by INTEGER
+,issue_closed BIGINT
+,issue_closed_by_pullreq INTEGER
+,CONSTRAINT fk_issue_repo_id FOREIGN KEY (issue_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_issue_created_by FOREIGN KEY (issue_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX issues_repo_id_number
+    ON issues(issue_repo_id, issue_number);
+
+CREATE TABLE issue_labels (
+ issue_label_issue_id INTEGER NOT NULL
+,issue_label_name TEXT NOT NULL
+,CONSTRAINT pk_issue_labels PRIMARY KEY (issue_label_issue_id, issue_label_name)
+,CONSTRAINT fk_issue_label_issue_id FOREIGN KEY (issue_label_issue_id)
+    REFERENCES issues (issue_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE issue_assignees (
+ issue_assignee_issue_id INTEGER NOT NULL
+,issue_assignee_principal_id INTEGER NOT NULL
+,CONSTRAINT pk_issue_assignees PRIMARY KEY (issue_assignee_issue_id, issue_assignee_principal_id)
+,CONSTRAINT fk_issue_assignee_issue_id FOREIGN KEY (issue_assignee_issue_id)
+    REFERENCES issues (issue_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_issue_assignee_principal_id FOREIGN KEY (issue_assignee_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX issue_assignees_principal_id
+    ON issue_assignees(issue_assignee_principal_id);
@@ -0,0 +1,10 @@
+CREATE TABLE repo_languages (
+ repo_languages_repo_id INTEGER PRIMARY KEY
+,repo_languages_sha TEXT NOT NULL
+,repo_languages_computed BIGINT NOT NULL
+,repo_languages_data TEXT NOT NULL
+,CONSTRAINT fk_repo_languages_repo_id FOREIGN KEY (repo_languages_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
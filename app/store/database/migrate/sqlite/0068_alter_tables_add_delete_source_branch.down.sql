@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs DROP COLUMN pullreq_delete_source_branch;
+ALTER TABLE repositories DROP COLUMN repo_pullreq_delete_source_branch;
@@ -0,0 +1,27 @@
+CREATE TABLE user_invitations (
+ invitation_id INTEGER PRIMARY KEY AUTOINCREMENT
+,invitation_email TEXT NOT NULL
+,invitation_token_hash TEXT NOT NULL
+,invitation_space_id INTEGER
+,invitation_role TEXT
+,invitation_created_by INTEGER NOT NULL
+,invitation_created BIGINT NOT NULL
+,invitation_expires BIGINT NOT NULL
+,invitation_accepted_by INTEGER
+,invitation_accepted BIGINT
+,CONSTRAINT fk_invitation_space_id FOREIGN KEY (invitation_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_invitation_created_by FOREIGN KEY (invitation_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_invitation_accepted_by FOREIGN KEY (invitation_accepted_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX user_invitations_token_hash
+    ON user_invitations(invitation_token_hash);
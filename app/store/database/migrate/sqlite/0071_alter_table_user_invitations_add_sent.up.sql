@@ -0,0 +1 @@
+ALTER TABLE user_invitations ADD COLUMN invitation_sent BIGINT NOT NULL DEFAULT 0;
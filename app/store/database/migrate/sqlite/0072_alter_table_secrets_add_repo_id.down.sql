@@ -0,0 +1,53 @@
+-- columns with a foreign key can't be dropped, recreate the table without it.
+CREATE TABLE secrets_new (
+    secret_id INTEGER PRIMARY KEY AUTOINCREMENT
+    ,secret_uid TEXT NOT NULL
+    ,secret_space_id INTEGER NOT NULL
+    ,secret_description TEXT NOT NULL
+    ,secret_data BLOB NOT NULL
+    ,secret_created INTEGER NOT NULL
+    ,secret_updated INTEGER NOT NULL
+    ,secret_version INTEGER NOT NULL
+    ,secret_created_by INTEGER NOT NULL
+
+    ,UNIQUE (secret_space_id, secret_uid)
+
+    ,CONSTRAINT fk_secrets_space_id FOREIGN KEY (secret_space_id)
+        REFERENCES spaces (space_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+
+    ,CONSTRAINT fk_secrets_created_by FOREIGN KEY (secret_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+-- repository secrets are dropped as they would become space secrets otherwise.
+INSERT INTO secrets_new(
+     secret_id
+    ,secret_uid
+    ,secret_space_id
+    ,secret_description
+    ,secret_data
+    ,secret_created
+    ,secret_updated
+    ,secret_version
+    ,secret_created_by
+)
+SELECT
+     secret_id
+    ,secret_uid
+    ,secret_space_id
+    ,secret_description
+    ,secret_data
+    ,secret_created
+    ,secret_updated
+    ,secret_version
+    ,secret_created_by
+FROM secrets
+WHERE secret_repo_id IS NULL;
+
+DROP TABLE secrets;
+
+ALTER TABLE secrets_new RENAME TO secrets;
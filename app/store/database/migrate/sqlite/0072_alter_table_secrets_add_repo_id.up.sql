@@ -0,0 +1,4 @@
+ALTER TABLE secrets ADD COLUMN secret_repo_id INTEGER
+    REFERENCES repositories (repo_id) ON UPDATE NO ACTION ON DELETE CASCADE;
+
+CREATE INDEX secrets_repo_id ON secrets(secret_repo_id);
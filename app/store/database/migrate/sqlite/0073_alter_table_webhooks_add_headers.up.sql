@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_headers TEXT NOT NULL DEFAULT '';
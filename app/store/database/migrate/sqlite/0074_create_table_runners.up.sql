@@ -0,0 +1,52 @@
+CREATE TABLE runner_registration_tokens (
+ registration_token_id INTEGER PRIMARY KEY AUTOINCREMENT
+,registration_token_space_id INTEGER NOT NULL
+,registration_token_uid TEXT NOT NULL
+,registration_token_description TEXT NOT NULL
+,registration_token_hash TEXT NOT NULL
+,registration_token_created_by INTEGER NOT NULL
+,registration_token_created INTEGER NOT NULL
+,registration_token_expires INTEGER NOT NULL
+,CONSTRAINT fk_registration_token_space_id FOREIGN KEY (registration_token_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_registration_token_created_by FOREIGN KEY (registration_token_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX runner_registration_tokens_space_id_uid
+    ON runner_registration_tokens(registration_token_space_id, LOWER(registration_token_uid));
+
+CREATE UNIQUE INDEX runner_registration_tokens_hash
+    ON runner_registration_tokens(registration_token_hash);
+
+CREATE TABLE runners (
+ runner_id INTEGER PRIMARY KEY AUTOINCREMENT
+,runner_space_id INTEGER NOT NULL
+,runner_uid TEXT NOT NULL
+,runner_token_hash TEXT NOT NULL
+,runner_os TEXT NOT NULL
+,runner_arch TEXT NOT NULL
+,runner_kernel TEXT NOT NULL
+,runner_variant TEXT NOT NULL
+,runner_labels TEXT NOT NULL
+,runner_version TEXT NOT NULL
+,runner_capacity INTEGER NOT NULL
+,runner_running INTEGER NOT NULL
+,runner_last_heartbeat INTEGER NOT NULL
+,runner_created INTEGER NOT NULL
+,runner_updated INTEGER NOT NULL
+,CONSTRAINT fk_runner_space_id FOREIGN KEY (runner_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX runners_space_id_uid
+    ON runners(runner_space_id, LOWER(runner_uid));
+
+CREATE UNIQUE INDEX runners_token_hash
+    ON runners(runner_token_hash);
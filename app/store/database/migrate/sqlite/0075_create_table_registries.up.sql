@@ -0,0 +1,105 @@
+CREATE TABLE registries (
+ registry_id INTEGER PRIMARY KEY AUTOINCREMENT
+,registry_space_id INTEGER NOT NULL
+,registry_uid TEXT NOT NULL
+,registry_description TEXT NOT NULL
+,registry_type TEXT NOT NULL
+,registry_max_versions INTEGER NOT NULL
+,registry_max_age INTEGER NOT NULL
+,registry_created_by INTEGER NOT NULL
+,registry_created INTEGER NOT NULL
+,registry_updated INTEGER NOT NULL
+,CONSTRAINT fk_registry_space_id FOREIGN KEY (registry_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_registry_created_by FOREIGN KEY (registry_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX registries_space_id_uid
+    ON registries(registry_space_id, LOWER(registry_uid));
+
+CREATE TABLE registry_artifacts (
+ artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_registry_id INTEGER NOT NULL
+,artifact_name TEXT NOT NULL
+,artifact_created INTEGER NOT NULL
+,artifact_updated INTEGER NOT NULL
+,CONSTRAINT fk_artifact_registry_id FOREIGN KEY (artifact_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX registry_artifacts_registry_id_name
+    ON registry_artifacts(artifact_registry_id, artifact_name);
+
+CREATE TABLE registry_versions (
+ version_id INTEGER PRIMARY KEY AUTOINCREMENT
+,version_artifact_id INTEGER NOT NULL
+,version_name TEXT NOT NULL
+,version_file_name TEXT NOT NULL
+,version_digest TEXT NOT NULL
+,version_media_type TEXT NOT NULL
+,version_size INTEGER NOT NULL
+,version_created_by INTEGER NOT NULL
+,version_created INTEGER NOT NULL
+,version_updated INTEGER NOT NULL
+,CONSTRAINT fk_version_artifact_id FOREIGN KEY (version_artifact_id)
+    REFERENCES registry_artifacts (artifact_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX registry_versions_artifact_id_name_file_name
+    ON registry_versions(version_artifact_id, version_name, version_file_name);
+
+CREATE TABLE registry_blobs (
+ blob_id INTEGER PRIMARY KEY AUTOINCREMENT
+,blob_registry_id INTEGER NOT NULL
+,blob_digest TEXT NOT NULL
+,blob_media_type TEXT NOT NULL
+,blob_size INTEGER NOT NULL
+,blob_created INTEGER NOT NULL
+,CONSTRAINT fk_blob_registry_id FOREIGN KEY (blob_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX registry_blobs_registry_id_digest
+    ON registry_blobs(blob_registry_id, blob_digest);
+
+CREATE TABLE registry_manifest_references (
+ reference_registry_id INTEGER NOT NULL
+,reference_manifest_digest TEXT NOT NULL
+,reference_digest TEXT NOT NULL
+,CONSTRAINT fk_reference_registry_id FOREIGN KEY (reference_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX registry_manifest_references_registry_id_manifest_digest_digest
+    ON registry_manifest_references(reference_registry_id, reference_manifest_digest, reference_digest);
+
+CREATE TABLE registry_uploads (
+ upload_id INTEGER PRIMARY KEY AUTOINCREMENT
+,upload_registry_id INTEGER NOT NULL
+,upload_uid TEXT NOT NULL
+,upload_size INTEGER NOT NULL
+,upload_parts INTEGER NOT NULL
+,upload_created_by INTEGER NOT NULL
+,upload_created INTEGER NOT NULL
+,upload_updated INTEGER NOT NULL
+,CONSTRAINT fk_upload_registry_id FOREIGN KEY (upload_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX registry_uploads_uid
+    ON registry_uploads(upload_uid);
@@ -0,0 +1,3 @@
+DROP INDEX pushes_repo_id_created;
+
+ALTER TABLE repositories DROP COLUMN repo_archived;
@@ -0,0 +1,4 @@
+ALTER TABLE repositories ADD COLUMN repo_archived BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX pushes_repo_id_created
+    ON pushes(push_repo_id, push_created);
@@ -0,0 +1,20 @@
+CREATE TABLE malware_findings (
+ malware_finding_id INTEGER PRIMARY KEY AUTOINCREMENT
+,malware_finding_repo_id INTEGER NOT NULL
+,malware_finding_principal_id INTEGER NOT NULL
+,malware_finding_blob_sha TEXT NOT NULL
+,malware_finding_path TEXT NOT NULL
+,malware_finding_signature TEXT NOT NULL
+,malware_finding_created BIGINT NOT NULL
+,CONSTRAINT fk_malware_finding_repo_id FOREIGN KEY (malware_finding_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_malware_finding_principal_id FOREIGN KEY (malware_finding_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX malware_findings_repo_id_created
+    ON malware_findings(malware_finding_repo_id, malware_finding_created);
@@ -0,0 +1,15 @@
+CREATE TABLE pullreq_review_reminders (
+ review_reminder_pullreq_id INTEGER NOT NULL
+,review_reminder_principal_id INTEGER NOT NULL
+,review_reminder_sent BIGINT NOT NULL DEFAULT 0
+,review_reminder_snoozed_until BIGINT NOT NULL DEFAULT 0
+,CONSTRAINT pk_pullreq_review_reminders PRIMARY KEY (review_reminder_pullreq_id, review_reminder_principal_id)
+,CONSTRAINT fk_review_reminder_pullreq_id FOREIGN KEY (review_reminder_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_review_reminder_principal_id FOREIGN KEY (review_reminder_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -34,10 +34,15 @@ import (
 var _ store.PrincipalStore = (*PrincipalStore)(nil)
 
 // NewPrincipalStore returns a new PrincipalStore.
-func NewPrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransformation) *PrincipalStore {
+func NewPrincipalStore(
+	db *sqlx.DB,
+	uidTransformation store.PrincipalUIDTransformation,
+	rowCache store.RowCache,
+) *PrincipalStore {
 	return &PrincipalStore{
 		db:                db,
 		uidTransformation: uidTransformation,
+		rowCache:          rowCache,
 	}
 }
 
@@ -45,6 +50,7 @@ func NewPrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransfor
 type PrincipalStore struct {
 	db                *sqlx.DB
 	uidTransformation store.PrincipalUIDTransformation
+	rowCache          store.RowCache
 }
 
 // principal is a DB representation of a principal.
@@ -69,8 +75,16 @@ const principalCommonColumns = `
 
 // principalColumns defines the column that are used only in a principal itself
 // (for explicit principals the type is implicit, only the generic principal struct stores it explicitly).
-const principalColumns = principalCommonColumns + `
-	,principal_type`
+const principalColumns = principalCommonColumns + principalProfileColumns + `
+	,principal_type
+	,principal_deleted`
+
+// principalProfileColumns defines the columns of the profile of a principal.
+const principalProfileColumns = `
+	,principal_avatar
+	,principal_time_zone
+	,principal_pronouns
+	,principal_status_message`
 
 //nolint:goconst
 const principalSelectBase = `
@@ -82,6 +96,11 @@ func (s *PrincipalStore) Find(ctx context.Context, id int64) (*types.Principal,
 	const sqlQuery = principalSelectBase + `
 		WHERE principal_id = $1`
 
+	cached := new(types.Principal)
+	if rowCacheGet(ctx, s.rowCache, rowCacheKeyPrincipal(id), cached) {
+		return cached, nil
+	}
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	dst := new(principal)
@@ -89,7 +108,21 @@ func (s *PrincipalStore) Find(ctx context.Context, id int64) (*types.Principal,
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select by id query failed")
 	}
 
-	return s.mapDBPrincipal(dst), nil
+	res := s.mapDBPrincipal(dst)
+
+	rowCacheSet(ctx, s.rowCache, rowCacheKeyPrincipal(id), res)
+
+	return res, nil
+}
+
+// evict removes the principals from the row cache.
+func (s *PrincipalStore) evict(ctx context.Context, ids ...int64) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = rowCacheKeyPrincipal(id)
+	}
+
+	s.rowCache.Evict(ctx, keys...)
 }
 
 // FindByUID finds the principal by uid.
@@ -168,7 +201,8 @@ func (s *PrincipalStore) List(ctx context.Context,
 	opts *types.PrincipalFilter) ([]*types.Principal, error) {
 	stmt := database.Builder.
 		Select(principalColumns).
-		From("principals")
+		From("principals").
+		Where("principal_deleted IS NULL")
 
 	if len(opts.Types) == 1 {
 		stmt = stmt.Where("principal_type = ?", opts.Types[0])
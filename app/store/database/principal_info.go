@@ -49,7 +49,8 @@ const (
 		,principal_display_name
 		,principal_type
 		,principal_created
-		,principal_updated`
+		,principal_updated
+		,principal_avatar`
 )
 
 type principalInfo struct {
@@ -60,6 +61,7 @@ type principalInfo struct {
 	Type        enum.PrincipalType `db:"principal_type"`
 	Created     int64              `db:"principal_created"`
 	Updated     int64              `db:"principal_updated"`
+	Avatar      string             `db:"principal_avatar"`
 }
 
 // Find returns a single principal info object by id from the `principals` database table.
@@ -78,11 +80,14 @@ func (s *PrincipalInfoView) Find(ctx context.Context, id int64) (*types.Principa
 
 	info := &types.PrincipalInfo{}
 
+	var avatar string
 	if err := v.Scan(&info.ID, &info.UID, &info.Email, &info.DisplayName,
-		&info.Type, &info.Created, &info.Updated); err != nil {
+		&info.Type, &info.Created, &info.Updated, &avatar); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "failed to scan principal info")
 	}
 
+	info.AvatarURL = types.PrincipalAvatarURL(info.ID, avatar)
+
 	return info, nil
 }
 
@@ -112,12 +117,15 @@ func (s *PrincipalInfoView) FindMany(ctx context.Context, ids []int64) ([]*types
 
 	for rows.Next() {
 		info := &types.PrincipalInfo{}
+		var avatar string
 		err = rows.Scan(&info.ID, &info.UID, &info.Email, &info.DisplayName,
-			&info.Type, &info.Created, &info.Updated)
+			&info.Type, &info.Created, &info.Updated, &avatar)
 		if err != nil {
 			return nil, database.ProcessSQLErrorf(ctx, err, "failed to scan principal info")
 		}
 
+		info.AvatarURL = types.PrincipalAvatarURL(info.ID, avatar)
+
 		result = append(result, info)
 	}
 
@@ -138,5 +146,6 @@ func mapToPrincipalInfo(p *principalInfo) types.PrincipalInfo {
 		Type:        p.Type,
 		Created:     p.Created,
 		Updated:     p.Updated,
+		AvatarURL:   types.PrincipalAvatarURL(p.ID, p.Avatar),
 	}
 }
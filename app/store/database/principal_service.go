@@ -152,7 +152,9 @@ func (s *PrincipalStore) UpdateService(ctx context.Context, svc *types.Service)
 		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
 	}
 
-	return err
+	s.evict(ctx, svc.ID)
+
+	return nil
 }
 
 // DeleteService deletes the service.
@@ -168,6 +170,8 @@ func (s *PrincipalStore) DeleteService(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
 	}
 
+	s.evict(ctx, id)
+
 	return nil
 }
 
@@ -157,7 +157,9 @@ func (s *PrincipalStore) UpdateServiceAccount(ctx context.Context, sa *types.Ser
 		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
 	}
 
-	return err
+	s.evict(ctx, sa.ID)
+
+	return nil
 }
 
 // DeleteServiceAccount deletes the service account.
@@ -172,6 +174,8 @@ func (s *PrincipalStore) DeleteServiceAccount(ctx context.Context, id int64) err
 		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
 	}
 
+	s.evict(ctx, id)
+
 	return nil
 }
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -36,8 +38,10 @@ type user struct {
 	UIDUnique string `db:"principal_uid_unique"`
 }
 
-const userColumns = principalCommonColumns + `
-	,principal_user_password`
+const userColumns = principalCommonColumns + principalProfileColumns + `
+	,principal_user_password
+	,principal_user_approval_pending
+	,principal_deleted`
 
 const userSelectBase = `
 	SELECT` + userColumns + `
@@ -111,6 +115,7 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,principal_created
 			,principal_updated
 			,principal_user_password
+			,principal_user_approval_pending
 		) values (
 			'user'
 			,:principal_uid
@@ -123,6 +128,7 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,:principal_created
 			,:principal_updated
 			,:principal_user_password
+			,:principal_user_approval_pending
 		) RETURNING principal_id`
 
 	dbUser, err := s.mapToDBUser(user)
@@ -156,6 +162,11 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 			,principal_salt           = :principal_salt
 			,principal_updated        = :principal_updated
 			,principal_user_password  = :principal_user_password
+			,principal_user_approval_pending = :principal_user_approval_pending
+			,principal_avatar         = :principal_avatar
+			,principal_time_zone      = :principal_time_zone
+			,principal_pronouns       = :principal_pronouns
+			,principal_status_message = :principal_status_message
 		WHERE principal_type = 'user' AND principal_id = :principal_id`
 
 	dbUser, err := s.mapToDBUser(user)
@@ -174,7 +185,9 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
 	}
 
-	return err
+	s.evict(ctx, user.ID)
+
+	return nil
 }
 
 // DeleteUser deletes the user.
@@ -189,9 +202,161 @@ func (s *PrincipalStore) DeleteUser(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
 	}
 
+	s.evict(ctx, id)
+
+	return nil
+}
+
+// SoftDeleteUser marks the user as deleted.
+func (s *PrincipalStore) SoftDeleteUser(ctx context.Context, id int64, deletedAt int64) error {
+	const sqlQuery = `
+		UPDATE principals
+		SET
+			principal_deleted = $1
+			,principal_updated = $1
+		WHERE principal_type = 'user' AND principal_id = $2 AND principal_deleted IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, deletedAt, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Soft delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	s.evict(ctx, id)
+
 	return nil
 }
 
+// RestoreUser restores a deleted user that wasn't purged yet.
+func (s *PrincipalStore) RestoreUser(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		UPDATE principals
+		SET
+			principal_deleted = NULL
+			,principal_updated = $1
+		WHERE principal_type = 'user' AND principal_id = $2
+			AND principal_deleted IS NOT NULL AND principal_purged = FALSE`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, time.Now().UnixMilli(), id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Restore query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	s.evict(ctx, id)
+
+	return nil
+}
+
+// PurgeDeletedUsers anonymizes all users that were deleted before or at the provided time.
+// The principal itself is kept as a tombstone to preserve authorship references.
+func (s *PrincipalStore) PurgeDeletedUsers(ctx context.Context, deletedBeforeOrAt int64) ([]int64, error) {
+	const sqlQuery = `
+		UPDATE principals
+		SET
+			principal_uid = 'deleted-' || principal_id
+			,principal_uid_unique = 'deleted-' || principal_id
+			,principal_email = 'deleted-' || principal_id || '@deleted.invalid'
+			,principal_display_name = 'Deleted User'
+			,principal_admin = FALSE
+			,principal_blocked = TRUE
+			,principal_user_password = ''
+			,principal_avatar = ''
+			,principal_time_zone = ''
+			,principal_pronouns = ''
+			,principal_status_message = ''
+			,principal_purged = TRUE
+		WHERE principal_type = 'user' AND principal_deleted <= $1 AND principal_purged = FALSE
+		RETURNING principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if database.IsMariaDB(s.db.DriverName()) {
+		return s.purgeDeletedUsersMariaDB(ctx, db, deletedBeforeOrAt)
+	}
+
+	ids := []int64{}
+	if err := db.SelectContext(ctx, &ids, sqlQuery, deletedBeforeOrAt); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Purge query failed")
+	}
+
+	s.evict(ctx, ids...)
+
+	return ids, nil
+}
+
+// purgeDeletedUsersMariaDB is the MariaDB version of PurgeDeletedUsers.
+// MariaDB doesn't support UPDATE ... RETURNING, so the users are selected first.
+func (s *PrincipalStore) purgeDeletedUsersMariaDB(
+	ctx context.Context,
+	db dbtx.Accessor,
+	deletedBeforeOrAt int64,
+) ([]int64, error) {
+	const sqlQuery = `
+		SELECT principal_id
+		FROM principals
+		WHERE principal_type = 'user' AND principal_deleted <= $1 AND principal_purged = FALSE
+		FOR UPDATE`
+
+	ids := []int64{}
+	if err := db.SelectContext(ctx, &ids, sqlQuery, deletedBeforeOrAt); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to select users to purge")
+	}
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	stmt := database.Builder.
+		Update("principals").
+		Set("principal_uid", squirrel.Expr("'deleted-' || principal_id")).
+		Set("principal_uid_unique", squirrel.Expr("'deleted-' || principal_id")).
+		Set("principal_email", squirrel.Expr("'deleted-' || principal_id || '@deleted.invalid'")).
+		Set("principal_display_name", "Deleted User").
+		Set("principal_admin", false).
+		Set("principal_blocked", true).
+		Set("principal_user_password", "").
+		Set("principal_avatar", "").
+		Set("principal_time_zone", "").
+		Set("principal_pronouns", "").
+		Set("principal_status_message", "").
+		Set("principal_purged", true).
+		Where(squirrel.Eq{"principal_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Purge query failed")
+	}
+
+	s.evict(ctx, ids...)
+
+	return ids, nil
+}
+
 // ListUsers returns a list of users.
 func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter) ([]*types.User, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -201,6 +366,7 @@ func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter)
 		Select(userColumns).
 		From("principals").
 		Where("principal_type = 'user'")
+	stmt = applyUserDeletedFilter(stmt, opts)
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -250,6 +416,8 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 		stmt = stmt.Where("principal_admin = ?", opts.Admin)
 	}
 
+	stmt = applyUserDeletedFilter(stmt, opts)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -295,3 +463,14 @@ func (s *PrincipalStore) mapToDBUser(usr *types.User) (*user, error) {
 
 	return dbUser, nil
 }
+
+func applyUserDeletedFilter(stmt squirrel.SelectBuilder, opts *types.UserFilter) squirrel.SelectBuilder {
+	if opts.ApprovalPending {
+		stmt = stmt.Where("principal_user_approval_pending = ?", true)
+	}
+
+	if opts.Deleted {
+		return stmt.Where("principal_deleted IS NOT NULL AND principal_purged = FALSE")
+	}
+	return stmt.Where("principal_deleted IS NULL")
+}
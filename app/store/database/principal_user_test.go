@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_SoftDeleteUser(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	if err := principalStore.SoftDeleteUser(ctx, userID, 1000); err != nil {
+		t.Fatalf("failed to soft delete user: %v", err)
+	}
+
+	users, err := principalStore.ListUsers(ctx, &types.UserFilter{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("failed to list users: %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("len(users) = %d, want 0", len(users))
+	}
+
+	users, err = principalStore.ListUsers(ctx, &types.UserFilter{Page: 1, Size: 10, Deleted: true})
+	if err != nil {
+		t.Fatalf("failed to list deleted users: %v", err)
+	}
+	if len(users) != 1 || users[0].Deleted == nil || *users[0].Deleted != 1000 {
+		t.Fatalf("expected one deleted user, got: %v", users)
+	}
+
+	if err = principalStore.RestoreUser(ctx, userID); err != nil {
+		t.Fatalf("failed to restore user: %v", err)
+	}
+
+	user, err := principalStore.FindUser(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	if user.Deleted != nil {
+		t.Errorf("user.Deleted = %d, want nil", *user.Deleted)
+	}
+
+	if err = principalStore.SoftDeleteUser(ctx, userID, 2000); err != nil {
+		t.Fatalf("failed to soft delete user: %v", err)
+	}
+
+	// users deleted after the provided time must not be purged.
+	purged, err := principalStore.PurgeDeletedUsers(ctx, 1999)
+	if err != nil {
+		t.Fatalf("failed to purge users: %v", err)
+	}
+	if len(purged) != 0 {
+		t.Errorf("purged = %v, want none", purged)
+	}
+
+	purged, err = principalStore.PurgeDeletedUsers(ctx, 2000)
+	if err != nil {
+		t.Fatalf("failed to purge users: %v", err)
+	}
+	if len(purged) != 1 || purged[0] != userID {
+		t.Fatalf("purged = %v, want [%d]", purged, userID)
+	}
+
+	user, err = principalStore.FindUser(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to find purged user: %v", err)
+	}
+	if user.UID != "deleted-1" || !user.Blocked || user.Admin {
+		t.Errorf("user wasn't anonymized: %+v", user)
+	}
+
+	err = principalStore.RestoreUser(ctx, userID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error restoring purged user, got: %v", err)
+	}
+}
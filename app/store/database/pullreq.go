@@ -92,6 +92,10 @@ type pullReq struct {
 
 	CommitCount null.Int `db:"pullreq_commit_count"`
 	FileCount   null.Int `db:"pullreq_file_count"`
+
+	DeleteSourceBranch null.Bool `db:"pullreq_delete_source_branch"`
+
+	Conversation enum.PullReqConversation `db:"pullreq_conversation"`
 }
 
 const (
@@ -124,7 +128,9 @@ const (
 		,pullreq_merge_sha
 		,pullreq_merge_conflicts
 		,pullreq_commit_count
-		,pullreq_file_count`
+		,pullreq_file_count
+		,pullreq_delete_source_branch
+		,pullreq_conversation`
 
 	pullReqSelectBase = `
 	SELECT` + pullReqColumns + `
@@ -216,6 +222,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_conflicts
 		,pullreq_commit_count
 		,pullreq_file_count
+		,pullreq_delete_source_branch
+		,pullreq_conversation
 	) values (
 		 :pullreq_version
 		,:pullreq_number
@@ -245,6 +253,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_merge_conflicts
 		,:pullreq_commit_count
 		,:pullreq_file_count
+		,:pullreq_delete_source_branch
+		,:pullreq_conversation
 	) RETURNING pullreq_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -287,6 +297,8 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_conflicts = :pullreq_merge_conflicts
 		,pullreq_commit_count = :pullreq_commit_count 
 		,pullreq_file_count = :pullreq_file_count
+		,pullreq_delete_source_branch = :pullreq_delete_source_branch
+		,pullreq_conversation = :pullreq_conversation
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -491,13 +503,37 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 	}
 
 	stmt = stmt.Limit(database.Limit(opts.Size))
-	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
 	// NOTE: string concatenation is safe because the
 	// order attribute is an enum and is not user-defined,
 	// and is therefore not subject to injection attacks.
 	opts.Sort, _ = opts.Sort.Sanitize()
-	stmt = stmt.OrderBy("pullreq_" + string(opts.Sort) + " " + opts.Order.String())
+	desc := opts.Order == enum.OrderDesc
+	columns := []database.KeysetColumn{
+		{Name: "pullreq_" + string(opts.Sort), Desc: desc},
+		{Name: "pullreq_id", Desc: desc},
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if opts.CursorID > 0 {
+		scope := squirrel.Eq{}
+		if opts.SourceRepoID != 0 {
+			scope["pullreq_source_repo_id"] = opts.SourceRepoID
+		}
+		if opts.TargetRepoID != 0 {
+			scope["pullreq_target_repo_id"] = opts.TargetRepoID
+		}
+
+		var err error
+		stmt, err = database.ApplyKeyset(ctx, db, stmt, "pullreqs", "pullreq_id", opts.CursorID, scope, columns)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+		stmt = stmt.OrderBy(database.KeysetOrderBy(columns)...)
+	}
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -506,8 +542,6 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 
 	dst := make([]*pullReq, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
-
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
@@ -527,35 +561,37 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 	}
 
 	return &types.PullReq{
-		ID:               pr.ID,
-		Version:          pr.Version,
-		Number:           pr.Number,
-		CreatedBy:        pr.CreatedBy,
-		Created:          pr.Created,
-		Updated:          pr.Updated,
-		Edited:           pr.Edited,
-		State:            pr.State,
-		IsDraft:          pr.IsDraft,
-		CommentCount:     pr.CommentCount,
-		UnresolvedCount:  pr.UnresolvedCount,
-		Title:            pr.Title,
-		Description:      pr.Description,
-		SourceRepoID:     pr.SourceRepoID,
-		SourceBranch:     pr.SourceBranch,
-		SourceSHA:        pr.SourceSHA,
-		TargetRepoID:     pr.TargetRepoID,
-		TargetBranch:     pr.TargetBranch,
-		ActivitySeq:      pr.ActivitySeq,
-		MergedBy:         pr.MergedBy.Ptr(),
-		Merged:           pr.Merged.Ptr(),
-		MergeMethod:      (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
-		MergeCheckStatus: pr.MergeCheckStatus,
-		MergeTargetSHA:   pr.MergeTargetSHA.Ptr(),
-		MergeBaseSHA:     pr.MergeBaseSHA,
-		MergeSHA:         pr.MergeSHA.Ptr(),
-		MergeConflicts:   mergeConflicts,
-		Author:           types.PrincipalInfo{},
-		Merger:           nil,
+		ID:                 pr.ID,
+		Version:            pr.Version,
+		Number:             pr.Number,
+		CreatedBy:          pr.CreatedBy,
+		Created:            pr.Created,
+		Updated:            pr.Updated,
+		Edited:             pr.Edited,
+		State:              pr.State,
+		IsDraft:            pr.IsDraft,
+		CommentCount:       pr.CommentCount,
+		UnresolvedCount:    pr.UnresolvedCount,
+		Title:              pr.Title,
+		Description:        pr.Description,
+		SourceRepoID:       pr.SourceRepoID,
+		SourceBranch:       pr.SourceBranch,
+		SourceSHA:          pr.SourceSHA,
+		TargetRepoID:       pr.TargetRepoID,
+		TargetBranch:       pr.TargetBranch,
+		ActivitySeq:        pr.ActivitySeq,
+		MergedBy:           pr.MergedBy.Ptr(),
+		Merged:             pr.Merged.Ptr(),
+		MergeMethod:        (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
+		MergeCheckStatus:   pr.MergeCheckStatus,
+		MergeTargetSHA:     pr.MergeTargetSHA.Ptr(),
+		MergeBaseSHA:       pr.MergeBaseSHA,
+		MergeSHA:           pr.MergeSHA.Ptr(),
+		MergeConflicts:     mergeConflicts,
+		DeleteSourceBranch: pr.DeleteSourceBranch.Ptr(),
+		Conversation:       pr.Conversation,
+		Author:             types.PrincipalInfo{},
+		Merger:             nil,
 		Stats: types.PullReqStats{
 			Conversations:   pr.CommentCount,
 			UnresolvedCount: pr.UnresolvedCount,
@@ -570,35 +606,37 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 func mapInternalPullReq(pr *types.PullReq) *pullReq {
 	mergeConflicts := strings.Join(pr.MergeConflicts, "\n")
 	m := &pullReq{
-		ID:               pr.ID,
-		Version:          pr.Version,
-		Number:           pr.Number,
-		CreatedBy:        pr.CreatedBy,
-		Created:          pr.Created,
-		Updated:          pr.Updated,
-		Edited:           pr.Edited,
-		State:            pr.State,
-		IsDraft:          pr.IsDraft,
-		CommentCount:     pr.CommentCount,
-		UnresolvedCount:  pr.UnresolvedCount,
-		Title:            pr.Title,
-		Description:      pr.Description,
-		SourceRepoID:     pr.SourceRepoID,
-		SourceBranch:     pr.SourceBranch,
-		SourceSHA:        pr.SourceSHA,
-		TargetRepoID:     pr.TargetRepoID,
-		TargetBranch:     pr.TargetBranch,
-		ActivitySeq:      pr.ActivitySeq,
-		MergedBy:         null.IntFromPtr(pr.MergedBy),
-		Merged:           null.IntFromPtr(pr.Merged),
-		MergeMethod:      null.StringFromPtr((*string)(pr.MergeMethod)),
-		MergeCheckStatus: pr.MergeCheckStatus,
-		MergeTargetSHA:   null.StringFromPtr(pr.MergeTargetSHA),
-		MergeBaseSHA:     pr.MergeBaseSHA,
-		MergeSHA:         null.StringFromPtr(pr.MergeSHA),
-		MergeConflicts:   null.NewString(mergeConflicts, mergeConflicts != ""),
-		CommitCount:      null.IntFromPtr(pr.Stats.Commits),
-		FileCount:        null.IntFromPtr(pr.Stats.FilesChanged),
+		ID:                 pr.ID,
+		Version:            pr.Version,
+		Number:             pr.Number,
+		CreatedBy:          pr.CreatedBy,
+		Created:            pr.Created,
+		Updated:            pr.Updated,
+		Edited:             pr.Edited,
+		State:              pr.State,
+		IsDraft:            pr.IsDraft,
+		CommentCount:       pr.CommentCount,
+		UnresolvedCount:    pr.UnresolvedCount,
+		Title:              pr.Title,
+		Description:        pr.Description,
+		SourceRepoID:       pr.SourceRepoID,
+		SourceBranch:       pr.SourceBranch,
+		SourceSHA:          pr.SourceSHA,
+		TargetRepoID:       pr.TargetRepoID,
+		TargetBranch:       pr.TargetBranch,
+		ActivitySeq:        pr.ActivitySeq,
+		MergedBy:           null.IntFromPtr(pr.MergedBy),
+		Merged:             null.IntFromPtr(pr.Merged),
+		MergeMethod:        null.StringFromPtr((*string)(pr.MergeMethod)),
+		MergeCheckStatus:   pr.MergeCheckStatus,
+		MergeTargetSHA:     null.StringFromPtr(pr.MergeTargetSHA),
+		MergeBaseSHA:       pr.MergeBaseSHA,
+		MergeSHA:           null.StringFromPtr(pr.MergeSHA),
+		MergeConflicts:     null.NewString(mergeConflicts, mergeConflicts != ""),
+		CommitCount:        null.IntFromPtr(pr.Stats.Commits),
+		FileCount:          null.IntFromPtr(pr.Stats.FilesChanged),
+		DeleteSourceBranch: null.BoolFromPtr(pr.DeleteSourceBranch),
+		Conversation:       pr.Conversation,
 	}
 
 	return m
@@ -83,6 +83,10 @@ type pullReqActivity struct {
 	ResolvedBy null.Int `db:"pullreq_activity_resolved_by"`
 	Resolved   null.Int `db:"pullreq_activity_resolved"`
 
+	HiddenBy     null.Int    `db:"pullreq_activity_hidden_by"`
+	Hidden       null.Int    `db:"pullreq_activity_hidden"`
+	HiddenReason null.String `db:"pullreq_activity_hidden_reason"`
+
 	Outdated                null.Bool   `db:"pullreq_activity_outdated"`
 	CodeCommentMergeBaseSHA null.String `db:"pullreq_activity_code_comment_merge_base_sha"`
 	CodeCommentSourceSHA    null.String `db:"pullreq_activity_code_comment_source_sha"`
@@ -115,6 +119,9 @@ const (
 		,pullreq_activity_metadata
 		,pullreq_activity_resolved_by
 		,pullreq_activity_resolved
+		,pullreq_activity_hidden_by
+		,pullreq_activity_hidden
+		,pullreq_activity_hidden_reason
 		,pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha
@@ -167,6 +174,9 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_metadata
 		,pullreq_activity_resolved_by
 		,pullreq_activity_resolved
+		,pullreq_activity_hidden_by
+		,pullreq_activity_hidden
+		,pullreq_activity_hidden_reason
 		,pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha
@@ -195,6 +205,9 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_metadata
 		,:pullreq_activity_resolved_by
 		,:pullreq_activity_resolved
+		,:pullreq_activity_hidden_by
+		,:pullreq_activity_hidden
+		,:pullreq_activity_hidden_reason
 		,:pullreq_activity_outdated
 		,:pullreq_activity_code_comment_merge_base_sha
 		,:pullreq_activity_code_comment_source_sha
@@ -264,6 +277,9 @@ func (s *PullReqActivityStore) Update(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_metadata = :pullreq_activity_metadata
 		,pullreq_activity_resolved_by = :pullreq_activity_resolved_by
 		,pullreq_activity_resolved = :pullreq_activity_resolved
+		,pullreq_activity_hidden_by = :pullreq_activity_hidden_by
+		,pullreq_activity_hidden = :pullreq_activity_hidden
+		,pullreq_activity_hidden_reason = :pullreq_activity_hidden_reason
 		,pullreq_activity_outdated = :pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha = :pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha = :pullreq_activity_code_comment_source_sha
@@ -487,9 +503,15 @@ func mapPullReqActivity(act *pullReqActivity) *types.PullReqActivity {
 		Metadata:   make(map[string]interface{}),
 		ResolvedBy: act.ResolvedBy.Ptr(),
 		Resolved:   act.Resolved.Ptr(),
+		HiddenBy:   act.HiddenBy.Ptr(),
+		Hidden:     act.Hidden.Ptr(),
 		Author:     types.PrincipalInfo{},
 		Resolver:   nil,
 	}
+	if act.HiddenReason.Valid {
+		reason := enum.PullReqCommentHiddenReason(act.HiddenReason.String)
+		m.HiddenReason = &reason
+	}
 	if m.Type == enum.PullReqActivityTypeCodeComment && m.Kind == enum.PullReqActivityKindChangeComment {
 		m.CodeComment = &types.CodeCommentFields{
 			Outdated:     act.Outdated.Bool,
@@ -510,26 +532,29 @@ func mapPullReqActivity(act *pullReqActivity) *types.PullReqActivity {
 
 func mapInternalPullReqActivity(act *types.PullReqActivity) *pullReqActivity {
 	m := &pullReqActivity{
-		ID:         act.ID,
-		Version:    act.Version,
-		CreatedBy:  act.CreatedBy,
-		Created:    act.Created,
-		Updated:    act.Updated,
-		Edited:     act.Edited,
-		Deleted:    null.IntFromPtr(act.Deleted),
-		ParentID:   null.IntFromPtr(act.ParentID),
-		RepoID:     act.RepoID,
-		PullReqID:  act.PullReqID,
-		Order:      act.Order,
-		SubOrder:   act.SubOrder,
-		ReplySeq:   act.ReplySeq,
-		Type:       act.Type,
-		Kind:       act.Kind,
-		Text:       act.Text,
-		Payload:    act.PayloadRaw,
-		Metadata:   nil,
-		ResolvedBy: null.IntFromPtr(act.ResolvedBy),
-		Resolved:   null.IntFromPtr(act.Resolved),
+		ID:           act.ID,
+		Version:      act.Version,
+		CreatedBy:    act.CreatedBy,
+		Created:      act.Created,
+		Updated:      act.Updated,
+		Edited:       act.Edited,
+		Deleted:      null.IntFromPtr(act.Deleted),
+		ParentID:     null.IntFromPtr(act.ParentID),
+		RepoID:       act.RepoID,
+		PullReqID:    act.PullReqID,
+		Order:        act.Order,
+		SubOrder:     act.SubOrder,
+		ReplySeq:     act.ReplySeq,
+		Type:         act.Type,
+		Kind:         act.Kind,
+		Text:         act.Text,
+		Payload:      act.PayloadRaw,
+		Metadata:     nil,
+		ResolvedBy:   null.IntFromPtr(act.ResolvedBy),
+		Resolved:     null.IntFromPtr(act.Resolved),
+		HiddenBy:     null.IntFromPtr(act.HiddenBy),
+		Hidden:       null.IntFromPtr(act.Hidden),
+		HiddenReason: null.StringFromPtr((*string)(act.HiddenReason)),
 	}
 	if act.IsValidCodeComment() {
 		m.Outdated = null.BoolFrom(act.CodeComment.Outdated)
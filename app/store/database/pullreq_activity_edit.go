@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqActivityEditStore = (*PullReqActivityEditStore)(nil)
+
+// NewPullReqActivityEditStore returns a new PullReqActivityEditStore.
+func NewPullReqActivityEditStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *PullReqActivityEditStore {
+	return &PullReqActivityEditStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// PullReqActivityEditStore implements store.PullReqActivityEditStore backed by a relational database.
+type PullReqActivityEditStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type pullReqActivityEdit struct {
+	ID         int64  `db:"pullreq_activity_edit_id"`
+	ActivityID int64  `db:"pullreq_activity_edit_activity_id"`
+	Text       string `db:"pullreq_activity_edit_text"`
+	EditedBy   int64  `db:"pullreq_activity_edit_edited_by"`
+	Edited     int64  `db:"pullreq_activity_edit_edited"`
+}
+
+const (
+	pullReqActivityEditColumns = `
+		 pullreq_activity_edit_id
+		,pullreq_activity_edit_activity_id
+		,pullreq_activity_edit_text
+		,pullreq_activity_edit_edited_by
+		,pullreq_activity_edit_edited`
+)
+
+// Create records a version of the text of a pull request comment.
+func (s *PullReqActivityEditStore) Create(ctx context.Context, edit *types.PullReqActivityEdit) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_activity_edits (
+		 pullreq_activity_edit_activity_id
+		,pullreq_activity_edit_text
+		,pullreq_activity_edit_edited_by
+		,pullreq_activity_edit_edited
+	) values (
+		 :pullreq_activity_edit_activity_id
+		,:pullreq_activity_edit_text
+		,:pullreq_activity_edit_edited_by
+		,:pullreq_activity_edit_edited
+	) RETURNING pullreq_activity_edit_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqActivityEdit{
+		ActivityID: edit.ActivityID,
+		Text:       edit.Text,
+		EditedBy:   edit.EditedBy,
+		Edited:     edit.Edited,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request activity edit object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&edit.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pull request activity edit")
+	}
+
+	return nil
+}
+
+// Count returns the number of recorded versions of the text of a pull request comment.
+func (s *PullReqActivityEditStore) Count(ctx context.Context, activityID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("pullreq_activity_edits").
+		Where("pullreq_activity_edit_activity_id = ?", activityID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count pull request activity edits")
+	}
+
+	return count, nil
+}
+
+// List returns the recorded versions of the text of a pull request comment, oldest first.
+func (s *PullReqActivityEditStore) List(
+	ctx context.Context,
+	activityID int64,
+) ([]*types.PullReqActivityEdit, error) {
+	stmt := database.Builder.
+		Select(pullReqActivityEditColumns).
+		From("pullreq_activity_edits").
+		Where("pullreq_activity_edit_activity_id = ?", activityID).
+		OrderBy("pullreq_activity_edit_edited ASC", "pullreq_activity_edit_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqActivityEdit
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request activity edits")
+	}
+
+	ids := make([]int64, len(dst))
+	for i, edit := range dst {
+		ids[i] = edit.EditedBy
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load editor infos: %w", err)
+	}
+
+	edits := make([]*types.PullReqActivityEdit, len(dst))
+	for i, edit := range dst {
+		edits[i] = &types.PullReqActivityEdit{
+			ID:         edit.ID,
+			ActivityID: edit.ActivityID,
+			Text:       edit.Text,
+			EditedBy:   edit.EditedBy,
+			Edited:     edit.Edited,
+		}
+		if editor, ok := infoMap[edit.EditedBy]; ok {
+			edits[i].Editor = *editor
+		}
+	}
+
+	return edits, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_PullReqActivityEdit(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+	editStore := database.NewPullReqActivityEditStore(db, pCache)
+
+	pr := &types.PullReq{
+		Number:           1,
+		CreatedBy:        userID,
+		State:            enum.PullReqStateOpen,
+		SourceRepoID:     1,
+		SourceBranch:     "feature",
+		TargetRepoID:     1,
+		TargetBranch:     "main",
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		Conversation:     enum.PullReqConversationLocked,
+	}
+	if err := pullreqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	pr, err := pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		t.Fatalf("failed to find pull request: %v", err)
+	}
+	if pr.Conversation != enum.PullReqConversationLocked {
+		t.Errorf("conversation = %q, want %q", pr.Conversation, enum.PullReqConversationLocked)
+	}
+
+	now := time.Now().UnixMilli()
+	reason := enum.PullReqCommentHiddenReasonSpam
+	act := &types.PullReqActivity{
+		CreatedBy:    userID,
+		Created:      now,
+		Updated:      now,
+		Edited:       now,
+		RepoID:       1,
+		PullReqID:    pr.ID,
+		Order:        1,
+		Type:         enum.PullReqActivityTypeComment,
+		Kind:         enum.PullReqActivityKindComment,
+		Text:         "first",
+		PayloadRaw:   json.RawMessage("{}"),
+		HiddenBy:     &userID,
+		Hidden:       &now,
+		HiddenReason: &reason,
+	}
+	if err = activityStore.Create(ctx, act); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	act, err = activityStore.Find(ctx, act.ID)
+	if err != nil {
+		t.Fatalf("failed to find comment: %v", err)
+	}
+	if act.Hidden == nil || act.HiddenBy == nil || act.HiddenReason == nil || *act.HiddenReason != reason {
+		t.Errorf("hidden fields weren't stored: %+v", act)
+	}
+
+	count, err := editStore.Count(ctx, act.ID)
+	if err != nil {
+		t.Fatalf("failed to count edits: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count = %d, want 0", count)
+	}
+
+	for i, text := range []string{"first", "second", "third"} {
+		err = editStore.Create(ctx, &types.PullReqActivityEdit{
+			ActivityID: act.ID,
+			Text:       text,
+			EditedBy:   userID,
+			Edited:     now + int64(i),
+		})
+		if err != nil {
+			t.Fatalf("failed to create edit: %v", err)
+		}
+	}
+
+	edits, err := editStore.List(ctx, act.ID)
+	if err != nil {
+		t.Fatalf("failed to list edits: %v", err)
+	}
+	if len(edits) != 3 {
+		t.Fatalf("len(edits) = %d, want 3", len(edits))
+	}
+	for i, text := range []string{"first", "second", "third"} {
+		if edits[i].Text != text {
+			t.Errorf("edits[%d].Text = %q, want %q", i, edits[i].Text, text)
+		}
+		if edits[i].Editor.ID != userID {
+			t.Errorf("edits[%d].Editor.ID = %d, want %d", i, edits[i].Editor.ID, userID)
+		}
+	}
+
+	count, err = editStore.Count(ctx, act.ID)
+	if err != nil {
+		t.Fatalf("failed to count edits: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+}
@@ -96,6 +96,10 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
+	if database.IsMariaDB(s.db.DriverName()) {
+		return s.upsertMariaDB(ctx, db, view)
+	}
+
 	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullreqFileView(view))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pullreq file view object")
@@ -108,6 +112,56 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 	return nil
 }
 
+// upsertMariaDB is the MariaDB version of Upsert. It uses ON DUPLICATE KEY UPDATE instead of ON CONFLICT,
+// and the creation time is read back in a separate query.
+func (s *PullReqFileViewStore) upsertMariaDB(ctx context.Context, db dbtx.Accessor, view *types.PullReqFileView) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_file_views (
+		 pullreq_file_view_pullreq_id
+		,pullreq_file_view_principal_id
+		,pullreq_file_view_path
+		,pullreq_file_view_sha
+		,pullreq_file_view_obsolete
+		,pullreq_file_view_created
+		,pullreq_file_view_updated
+	) VALUES (
+		 :pullreq_file_view_pullreq_id
+		,:pullreq_file_view_principal_id
+		,:pullreq_file_view_path
+		,:pullreq_file_view_sha
+		,:pullreq_file_view_obsolete
+		,:pullreq_file_view_created
+		,:pullreq_file_view_updated
+	)
+	ON DUPLICATE KEY UPDATE
+		 pullreq_file_view_updated = :pullreq_file_view_updated
+		,pullreq_file_view_sha = :pullreq_file_view_sha
+		,pullreq_file_view_obsolete = :pullreq_file_view_obsolete`
+
+	const sqlQueryCreated = `
+	SELECT pullreq_file_view_created
+	FROM pullreq_file_views
+	WHERE pullreq_file_view_pullreq_id = $1 AND
+		pullreq_file_view_principal_id = $2 AND
+		pullreq_file_view_path = $3`
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullreqFileView(view))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pullreq file view object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	err = db.QueryRowContext(ctx, sqlQueryCreated, view.PullReqID, view.PrincipalID, view.Path).Scan(&view.Created)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to read pullreq file view creation time")
+	}
+
+	return nil
+}
+
 // DeleteByFileForPrincipal deletes the entry for the specified PR, principal, and file.
 func (s *PullReqFileViewStore) DeleteByFileForPrincipal(
 	ctx context.Context,
@@ -154,6 +208,29 @@ func (s *PullReqFileViewStore) MarkObsolete(ctx context.Context, prID int64, fil
 	return nil
 }
 
+// MarkAllObsolete updates all entries of the PR as obsolete.
+func (s *PullReqFileViewStore) MarkAllObsolete(ctx context.Context, prID int64) error {
+	stmt := database.Builder.
+		Update("pullreq_file_views").
+		Set("pullreq_file_view_obsolete", true).
+		Set("pullreq_file_view_updated", time.Now().UnixMilli()).
+		Where("pullreq_file_view_pullreq_id = ?", prID).
+		Where("pullreq_file_view_obsolete = ?", false)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create sql query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "failed to execute update query")
+	}
+
+	return nil
+}
+
 // List lists all files marked as viewed by the user for the specified PR.
 func (s *PullReqFileViewStore) List(
 	ctx context.Context,
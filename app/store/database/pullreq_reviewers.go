@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -219,6 +220,97 @@ func (s *PullReqReviewerStore) List(ctx context.Context, prID int64) ([]*types.P
 	return result, nil
 }
 
+// CountPending returns the number of open pull requests with a pending review for each of the principals.
+func (s *PullReqReviewerStore) CountPending(ctx context.Context, principalIDs []int64) (map[int64]int, error) {
+	if len(principalIDs) == 0 {
+		return map[int64]int{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("pullreq_reviewer_principal_id", "COUNT(*)").
+		From("pullreq_reviewers").
+		InnerJoin("pullreqs ON pullreq_id = pullreq_reviewer_pullreq_id").
+		Where(squirrel.Eq{"pullreq_reviewer_principal_id": principalIDs}).
+		Where("pullreq_reviewer_review_decision = ?", enum.PullReqReviewDecisionPending).
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		GroupBy("pullreq_reviewer_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pending review count query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pending review count query")
+	}
+	defer rows.Close()
+
+	result := make(map[int64]int, len(principalIDs))
+	for rows.Next() {
+		var principalID int64
+		var count int
+		if err = rows.Scan(&principalID, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan pending review count")
+		}
+		result[principalID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read pending review counts")
+	}
+
+	return result, nil
+}
+
+// LastAdded returns the time each of the principals was last added as a reviewer to a pull request of the repository.
+func (s *PullReqReviewerStore) LastAdded(
+	ctx context.Context,
+	repoID int64,
+	principalIDs []int64,
+) (map[int64]int64, error) {
+	if len(principalIDs) == 0 {
+		return map[int64]int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("pullreq_reviewer_principal_id", "MAX(pullreq_reviewer_created)").
+		From("pullreq_reviewers").
+		Where("pullreq_reviewer_repo_id = ?", repoID).
+		Where(squirrel.Eq{"pullreq_reviewer_principal_id": principalIDs}).
+		GroupBy("pullreq_reviewer_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert last added reviewer query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing last added reviewer query")
+	}
+	defer rows.Close()
+
+	result := make(map[int64]int64, len(principalIDs))
+	for rows.Next() {
+		var principalID, added int64
+		if err = rows.Scan(&principalID, &added); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan last added reviewer")
+		}
+		result[principalID] = added
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read last added reviewers")
+	}
+
+	return result, nil
+}
+
 func mapPullReqReviewer(v *pullReqReviewer) *types.PullReqReviewer {
 	m := &types.PullReqReviewer{
 		PullReqID:      v.PullReqID,
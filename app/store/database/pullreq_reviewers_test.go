@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_PullReqReviewerLoad(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	reviewerStore := database.NewPullReqReviewerStore(db, pCache)
+
+	states := []enum.PullReqState{enum.PullReqStateOpen, enum.PullReqStateOpen, enum.PullReqStateClosed}
+	decisions := []enum.PullReqReviewDecision{
+		enum.PullReqReviewDecisionPending,
+		enum.PullReqReviewDecisionApproved,
+		enum.PullReqReviewDecisionPending,
+	}
+
+	for i := range states {
+		pr := &types.PullReq{
+			Number:           int64(i + 1),
+			CreatedBy:        userID,
+			State:            states[i],
+			SourceRepoID:     1,
+			SourceBranch:     fmt.Sprintf("feature-%d", i),
+			TargetRepoID:     1,
+			TargetBranch:     "main",
+			MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		}
+		if err := pullreqStore.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+
+		err := reviewerStore.Create(ctx, &types.PullReqReviewer{
+			PullReqID:      pr.ID,
+			PrincipalID:    userID,
+			CreatedBy:      userID,
+			Created:        int64(100 * (i + 1)),
+			Updated:        int64(100 * (i + 1)),
+			RepoID:         1,
+			Type:           enum.PullReqReviewerTypeAssigned,
+			ReviewDecision: decisions[i],
+		})
+		if err != nil {
+			t.Fatalf("failed to create reviewer: %v", err)
+		}
+	}
+
+	const unknownID = 1000
+
+	pending, err := reviewerStore.CountPending(ctx, []int64{userID, unknownID})
+	if err != nil {
+		t.Fatalf("failed to count pending reviews: %v", err)
+	}
+	if len(pending) != 1 || pending[userID] != 1 {
+		t.Errorf("unexpected pending review counts: %v", pending)
+	}
+
+	lastAdded, err := reviewerStore.LastAdded(ctx, 1, []int64{userID, unknownID})
+	if err != nil {
+		t.Fatalf("failed to find last added reviewers: %v", err)
+	}
+	if len(lastAdded) != 1 || lastAdded[userID] != 300 {
+		t.Errorf("unexpected last added reviewers: %v", lastAdded)
+	}
+}
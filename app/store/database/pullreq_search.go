@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// Search returns a list of pull requests matching the full-text search filter, ordered by relevance.
+// The search index is maintained by database triggers on the pull request and activity tables.
+func (s *PullReqStore) Search(ctx context.Context, opts *types.PullReqSearchFilter) ([]*types.PullReq, error) {
+	stmt := database.Builder.
+		Select(pullReqColumns).
+		From("pullreqs")
+
+	stmt = s.applySearchFilter(stmt, opts)
+
+	switch {
+	case s.isSQLite():
+		// FTS4 doesn't provide a ranking function, the number of matched phrases
+		// (reflected by the length of the offsets) is used as an approximation.
+		stmt = stmt.OrderBy("length(offsets(pullreq_search)) DESC")
+	case database.IsMariaDB(s.db.DriverName()):
+		stmt = stmt.OrderByClause(mariaDBSearchMatch+" DESC", mariaDBSearchQuery(opts))
+	default:
+		query, args := postgresSearchQuery(opts)
+		stmt = stmt.OrderByClause("ts_rank(pullreq_search_vector, "+query+") DESC", args...)
+	}
+
+	stmt = stmt.OrderBy("pullreq_updated DESC")
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := make([]*pullReq, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pull request search query")
+	}
+
+	return s.mapSlicePullReq(ctx, dst)
+}
+
+// SearchCount returns the number of pull requests matching the full-text search filter.
+func (s *PullReqStore) SearchCount(ctx context.Context, opts *types.PullReqSearchFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("pullreqs")
+
+	stmt = s.applySearchFilter(stmt, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing pull request search count query")
+	}
+
+	return count, nil
+}
+
+func (s *PullReqStore) applySearchFilter(
+	stmt squirrel.SelectBuilder,
+	opts *types.PullReqSearchFilter,
+) squirrel.SelectBuilder {
+	switch {
+	case s.isSQLite():
+		stmt = stmt.
+			Join("pullreq_search ON pullreq_search.docid = pullreq_id").
+			Where("pullreq_search MATCH ?", sqliteSearchQuery(opts))
+	case database.IsMariaDB(s.db.DriverName()):
+		stmt = stmt.
+			Join("pullreq_search ON pullreq_search_pullreq_id = pullreq_id").
+			Where(mariaDBSearchMatch, mariaDBSearchQuery(opts))
+	default:
+		query, args := postgresSearchQuery(opts)
+		stmt = stmt.
+			Join("pullreq_search ON pullreq_search_pullreq_id = pullreq_id").
+			Where("pullreq_search_vector @@ "+query, args...)
+	}
+
+	if len(opts.RepoIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"pullreq_target_repo_id": opts.RepoIDs})
+	}
+
+	if opts.CreatedBy != 0 {
+		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
+	}
+
+	return stmt
+}
+
+func (s *PullReqStore) isSQLite() bool {
+	return strings.HasPrefix(s.db.DriverName(), "sqlite")
+}
+
+// sqliteSearchQuery returns the FTS4 match expression for the search filter.
+// Every term and phrase is quoted, which makes FTS treat special characters as token separators.
+func sqliteSearchQuery(opts *types.PullReqSearchFilter) string {
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, " ") + `"`
+	}
+
+	parts := make([]string, 0, len(opts.Terms)+len(opts.Phrases))
+	for _, term := range opts.Terms {
+		parts = append(parts, quote(term))
+	}
+	for _, phrase := range opts.Phrases {
+		parts = append(parts, quote(phrase))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// mariaDBSearchMatch is the full-text match expression of the MariaDB search index.
+// In boolean mode the expression evaluates to the relevance of the match.
+const mariaDBSearchMatch = "MATCH(pullreq_search_title, pullreq_search_description, pullreq_search_comments)" +
+	" AGAINST(? IN BOOLEAN MODE)"
+
+// mariaDBSearchQuery returns the boolean mode search expression for the search filter.
+// All terms and phrases are required, boolean operators in them are treated as token separators.
+func mariaDBSearchQuery(opts *types.PullReqSearchFilter) string {
+	clean := func(s string) string {
+		return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+			return strings.ContainsRune(`+-<>()~*"@`, r) || r == ' '
+		}), " ")
+	}
+
+	parts := make([]string, 0, len(opts.Terms)+len(opts.Phrases))
+	for _, term := range opts.Terms {
+		if term = clean(term); term != "" {
+			parts = append(parts, `+"`+term+`"`)
+		}
+	}
+	for _, phrase := range opts.Phrases {
+		if phrase = clean(phrase); phrase != "" {
+			parts = append(parts, `+"`+phrase+`"`)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// postgresSearchQuery returns the tsquery expression (and its arguments) for the search filter.
+func postgresSearchQuery(opts *types.PullReqSearchFilter) (string, []any) {
+	parts := make([]string, 0, len(opts.Phrases)+1)
+	args := make([]any, 0, len(opts.Phrases)+1)
+
+	if len(opts.Terms) > 0 {
+		parts = append(parts, "plainto_tsquery('english', ?)")
+		args = append(args, strings.Join(opts.Terms, " "))
+	}
+
+	for _, phrase := range opts.Phrases {
+		parts = append(parts, "phraseto_tsquery('english', ?)")
+		args = append(args, phrase)
+	}
+
+	return "(" + strings.Join(parts, " && ") + ")", args
+}
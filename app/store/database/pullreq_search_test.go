@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_PullReqSearch(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+
+	prs := []*types.PullReq{
+		{Number: 1, Title: "Fix login redirect", Description: "The session cookie was dropped."},
+		{Number: 2, Title: "Add webhook retries", Description: "Retries failed deliveries."},
+		{Number: 3, Title: "Update docs", Description: "Describe the login flow."},
+	}
+	for _, pr := range prs {
+		pr.CreatedBy = userID
+		pr.State = enum.PullReqStateOpen
+		pr.SourceRepoID = 1
+		pr.SourceBranch = "feature-" + strconv.FormatInt(pr.Number, 10)
+		pr.TargetRepoID = 1
+		pr.TargetBranch = "main"
+		pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
+		if err := pullreqStore.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	if err := activityStore.Create(ctx, &types.PullReqActivity{
+		CreatedBy:  userID,
+		Created:    now,
+		Updated:    now,
+		Edited:     now,
+		RepoID:     1,
+		PullReqID:  prs[1].ID,
+		Order:      1,
+		Type:       enum.PullReqActivityTypeComment,
+		Kind:       enum.PullReqActivityKindComment,
+		Text:       "this also needs exponential backoff",
+		PayloadRaw: json.RawMessage("{}"),
+	}); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  types.PullReqSearchFilter
+		numbers []int64
+	}{
+		{
+			name:    "term in title and description",
+			filter:  types.PullReqSearchFilter{Terms: []string{"login"}},
+			numbers: []int64{1, 3},
+		},
+		{
+			name:    "term in comment",
+			filter:  types.PullReqSearchFilter{Terms: []string{"backoff"}},
+			numbers: []int64{2},
+		},
+		{
+			name:    "phrase",
+			filter:  types.PullReqSearchFilter{Phrases: []string{"login flow"}},
+			numbers: []int64{3},
+		},
+		{
+			name:    "phrase not matching word order",
+			filter:  types.PullReqSearchFilter{Phrases: []string{"flow login"}},
+			numbers: []int64{},
+		},
+		{
+			name:    "other repo",
+			filter:  types.PullReqSearchFilter{Terms: []string{"login"}, RepoIDs: []int64{2}},
+			numbers: []int64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := pullreqStore.Search(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to search pull requests: %v", err)
+			}
+
+			count, err := pullreqStore.SearchCount(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to count pull requests: %v", err)
+			}
+
+			if int(count) != len(test.numbers) {
+				t.Errorf("count = %d, want %d", count, len(test.numbers))
+			}
+
+			found := make(map[int64]bool, len(list))
+			for _, pr := range list {
+				found[pr.Number] = true
+			}
+			for _, number := range test.numbers {
+				if !found[number] {
+					t.Errorf("pull request #%d not found in %v", number, found)
+				}
+			}
+			if len(list) != len(test.numbers) {
+				t.Errorf("len(list) = %d, want %d", len(list), len(test.numbers))
+			}
+		})
+	}
+
+	// the index follows title updates.
+	prs[1].Title = "Add webhook login"
+	if err := pullreqStore.Update(ctx, prs[1]); err != nil {
+		t.Fatalf("failed to update pull request: %v", err)
+	}
+
+	count, err := pullreqStore.SearchCount(ctx, &types.PullReqSearchFilter{Terms: []string{"login"}})
+	if err != nil {
+		t.Fatalf("failed to count pull requests: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count after update = %d, want 3", count)
+	}
+}
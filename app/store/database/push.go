@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PushStore = (*PushStore)(nil)
+
+// NewPushStore returns a new PushStore.
+func NewPushStore(db *sqlx.DB) *PushStore {
+	return &PushStore{
+		db: db,
+	}
+}
+
+// PushStore implements store.PushStore backed by a relational database.
+type PushStore struct {
+	db *sqlx.DB
+}
+
+type push struct {
+	ID          int64  `db:"push_id"`
+	RepoID      int64  `db:"push_repo_id"`
+	PrincipalID int64  `db:"push_principal_id"`
+	Ref         string `db:"push_ref"`
+	OldSHA      string `db:"push_old_sha"`
+	NewSHA      string `db:"push_new_sha"`
+	Created     int64  `db:"push_created"`
+}
+
+// Create records a new push.
+func (s *PushStore) Create(ctx context.Context, p *types.Push) error {
+	const sqlQuery = `
+	INSERT INTO pushes (
+		 push_repo_id
+		,push_principal_id
+		,push_ref
+		,push_old_sha
+		,push_new_sha
+		,push_created
+	) values (
+		 :push_repo_id
+		,:push_principal_id
+		,:push_ref
+		,:push_old_sha
+		,:push_new_sha
+		,:push_created
+	) RETURNING push_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPush(p))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&p.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalPush(p *types.Push) *push {
+	return &push{
+		ID:          p.ID,
+		RepoID:      p.RepoID,
+		PrincipalID: p.PrincipalID,
+		Ref:         p.Ref,
+		OldSHA:      p.OldSHA,
+		NewSHA:      p.NewSHA,
+		Created:     p.Created,
+	}
+}
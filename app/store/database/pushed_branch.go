@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PushedBranchStore = (*PushedBranchStore)(nil)
+
+// NewPushedBranchStore returns a new PushedBranchStore.
+func NewPushedBranchStore(db *sqlx.DB) *PushedBranchStore {
+	return &PushedBranchStore{
+		db: db,
+	}
+}
+
+// PushedBranchStore implements store.PushedBranchStore backed by a relational database.
+type PushedBranchStore struct {
+	db *sqlx.DB
+}
+
+type pushedBranch struct {
+	RepoID      int64  `db:"pushed_branch_repo_id"`
+	PrincipalID int64  `db:"pushed_branch_principal_id"`
+	Branch      string `db:"pushed_branch_name"`
+	SHA         string `db:"pushed_branch_sha"`
+	Created     int64  `db:"pushed_branch_created"`
+}
+
+const (
+	pushedBranchColumns = `
+		 pushed_branch_repo_id
+		,pushed_branch_principal_id
+		,pushed_branch_name
+		,pushed_branch_sha
+		,pushed_branch_created`
+)
+
+// Upsert creates or replaces the latest pushed branch of the user in the repository.
+func (s *PushedBranchStore) Upsert(ctx context.Context, branch *types.PushedBranch) error {
+	const sqlQueryInsert = `
+	INSERT INTO pushed_branches (
+		 pushed_branch_repo_id
+		,pushed_branch_principal_id
+		,pushed_branch_name
+		,pushed_branch_sha
+		,pushed_branch_created
+	) VALUES (
+		 :pushed_branch_repo_id
+		,:pushed_branch_principal_id
+		,:pushed_branch_name
+		,:pushed_branch_sha
+		,:pushed_branch_created
+	)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (pushed_branch_repo_id, pushed_branch_principal_id) DO
+	UPDATE SET
+		 pushed_branch_name = :pushed_branch_name
+		,pushed_branch_sha = :pushed_branch_sha
+		,pushed_branch_created = :pushed_branch_created`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 pushed_branch_name = :pushed_branch_name
+		,pushed_branch_sha = :pushed_branch_sha
+		,pushed_branch_created = :pushed_branch_created`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPushedBranch(branch))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pushed branch object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Find returns the latest pushed branch of the user in the repository.
+func (s *PushedBranchStore) Find(ctx context.Context, repoID, principalID int64) (*types.PushedBranch, error) {
+	stmt := database.Builder.
+		Select(pushedBranchColumns).
+		From("pushed_branches").
+		Where("pushed_branch_repo_id = ?", repoID).
+		Where("pushed_branch_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushedBranch{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pushed branch")
+	}
+
+	return mapToPushedBranch(dst), nil
+}
+
+// Delete removes the latest pushed branch of the user in the repository.
+func (s *PushedBranchStore) Delete(ctx context.Context, repoID, principalID int64) error {
+	stmt := database.Builder.
+		Delete("pushed_branches").
+		Where("pushed_branch_repo_id = ?", repoID).
+		Where("pushed_branch_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pushed branch")
+	}
+
+	return nil
+}
+
+func mapToInternalPushedBranch(branch *types.PushedBranch) *pushedBranch {
+	return &pushedBranch{
+		RepoID:      branch.RepoID,
+		PrincipalID: branch.PrincipalID,
+		Branch:      branch.Branch,
+		SHA:         branch.SHA,
+		Created:     branch.Created,
+	}
+}
+
+func mapToPushedBranch(branch *pushedBranch) *types.PushedBranch {
+	return &types.PushedBranch{
+		RepoID:      branch.RepoID,
+		PrincipalID: branch.PrincipalID,
+		Branch:      branch.Branch,
+		SHA:         branch.SHA,
+		Created:     branch.Created,
+	}
+}
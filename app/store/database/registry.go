@@ -0,0 +1,329 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RegistryStore = (*RegistryStore)(nil)
+
+// NewRegistryStore returns a new RegistryStore.
+func NewRegistryStore(db *sqlx.DB) *RegistryStore {
+	return &RegistryStore{
+		db: db,
+	}
+}
+
+// RegistryStore implements store.RegistryStore backed by a relational database.
+type RegistryStore struct {
+	db *sqlx.DB
+}
+
+type registry struct {
+	ID          int64             `db:"registry_id"`
+	SpaceID     int64             `db:"registry_space_id"`
+	Identifier  string            `db:"registry_uid"`
+	Description string            `db:"registry_description"`
+	Type        enum.RegistryType `db:"registry_type"`
+	MaxVersions int64             `db:"registry_max_versions"`
+	MaxAge      int64             `db:"registry_max_age"`
+	CreatedBy   int64             `db:"registry_created_by"`
+	Created     int64             `db:"registry_created"`
+	Updated     int64             `db:"registry_updated"`
+}
+
+const (
+	registryColumns = `
+		 registry_id
+		,registry_space_id
+		,registry_uid
+		,registry_description
+		,registry_type
+		,registry_max_versions
+		,registry_max_age
+		,registry_created_by
+		,registry_created
+		,registry_updated`
+
+	registrySelectBase = `
+	SELECT` + registryColumns + `
+	FROM registries`
+)
+
+// Find finds the registry by id.
+func (s *RegistryStore) Find(ctx context.Context, id int64) (*types.Registry, error) {
+	const sqlQuery = registrySelectBase + `
+	WHERE registry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &registry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find registry")
+	}
+
+	return mapToRegistry(dst), nil
+}
+
+// FindByIdentifier finds the registry of the space by its identifier.
+func (s *RegistryStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.Registry, error) {
+	const sqlQuery = registrySelectBase + `
+	WHERE registry_space_id = $1 AND LOWER(registry_uid) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &registry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find registry by identifier")
+	}
+
+	return mapToRegistry(dst), nil
+}
+
+// Create creates a new registry.
+func (s *RegistryStore) Create(ctx context.Context, r *types.Registry) error {
+	const sqlQuery = `
+	INSERT INTO registries (
+		 registry_space_id
+		,registry_uid
+		,registry_description
+		,registry_type
+		,registry_max_versions
+		,registry_max_age
+		,registry_created_by
+		,registry_created
+		,registry_updated
+	) values (
+		 :registry_space_id
+		,:registry_uid
+		,:registry_description
+		,:registry_type
+		,:registry_max_versions
+		,:registry_max_age
+		,:registry_created_by
+		,:registry_created
+		,:registry_updated
+	) RETURNING registry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistry(r))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the description and the retention policy of the registry.
+func (s *RegistryStore) Update(ctx context.Context, r *types.Registry) error {
+	const sqlQuery = `
+	UPDATE registries
+	SET
+		 registry_description = :registry_description
+		,registry_max_versions = :registry_max_versions
+		,registry_max_age = :registry_max_age
+		,registry_updated = :registry_updated
+	WHERE registry_id = :registry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistry(r))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update registry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the registry with all its artifacts.
+func (s *RegistryStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM registries
+	WHERE registry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List lists the registries of the space.
+func (s *RegistryStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.RegistryFilter,
+) ([]*types.Registry, error) {
+	stmt := database.Builder.
+		Select(registryColumns).
+		From("registries").
+		Where("registry_space_id = ?", spaceID).
+		OrderBy("registry_uid ASC")
+
+	stmt = applyRegistryFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert registry list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registry{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing registry list query")
+	}
+
+	return mapToRegistries(dst), nil
+}
+
+// Count returns the number of registries of the space.
+func (s *RegistryStore) Count(ctx context.Context, spaceID int64, filter *types.RegistryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("registries").
+		Where("registry_space_id = ?", spaceID)
+
+	stmt = applyRegistryFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert registry count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing registry count query")
+	}
+
+	return count, nil
+}
+
+// ListAll lists all registries of all spaces.
+func (s *RegistryStore) ListAll(ctx context.Context) ([]*types.Registry, error) {
+	const sqlQuery = registrySelectBase + `
+	ORDER BY registry_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registry{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list all registries")
+	}
+
+	return mapToRegistries(dst), nil
+}
+
+func applyRegistryFilter(stmt squirrel.SelectBuilder, filter *types.RegistryFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(registry_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	if filter.Type != "" {
+		stmt = stmt.Where("registry_type = ?", filter.Type)
+	}
+
+	return stmt
+}
+
+func mapToRegistry(in *registry) *types.Registry {
+	return &types.Registry{
+		ID:          in.ID,
+		SpaceID:     in.SpaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		MaxVersions: in.MaxVersions,
+		MaxAge:      in.MaxAge,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapToRegistries(in []*registry) []*types.Registry {
+	registries := make([]*types.Registry, len(in))
+	for i := range in {
+		registries[i] = mapToRegistry(in[i])
+	}
+	return registries
+}
+
+func mapToInternalRegistry(in *types.Registry) *registry {
+	return &registry{
+		ID:          in.ID,
+		SpaceID:     in.SpaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		MaxVersions: in.MaxVersions,
+		MaxAge:      in.MaxAge,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RegistryArtifactStore = (*RegistryArtifactStore)(nil)
+
+// NewRegistryArtifactStore returns a new RegistryArtifactStore.
+func NewRegistryArtifactStore(db *sqlx.DB) *RegistryArtifactStore {
+	return &RegistryArtifactStore{
+		db: db,
+	}
+}
+
+// RegistryArtifactStore implements store.RegistryArtifactStore backed by a relational database.
+type RegistryArtifactStore struct {
+	db *sqlx.DB
+}
+
+type registryArtifact struct {
+	ID         int64  `db:"artifact_id"`
+	RegistryID int64  `db:"artifact_registry_id"`
+	Name       string `db:"artifact_name"`
+	Created    int64  `db:"artifact_created"`
+	Updated    int64  `db:"artifact_updated"`
+}
+
+const (
+	registryArtifactColumns = `
+		 artifact_id
+		,artifact_registry_id
+		,artifact_name
+		,artifact_created
+		,artifact_updated`
+
+	registryArtifactSelectBase = `
+	SELECT` + registryArtifactColumns + `
+	FROM registry_artifacts`
+)
+
+// FindByName finds the artifact of the registry by its name.
+func (s *RegistryArtifactStore) FindByName(
+	ctx context.Context,
+	registryID int64,
+	name string,
+) (*types.RegistryArtifact, error) {
+	const sqlQuery = registryArtifactSelectBase + `
+	WHERE artifact_registry_id = $1 AND artifact_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &registryArtifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, registryID, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find registry artifact")
+	}
+
+	return mapToRegistryArtifact(dst), nil
+}
+
+// Create creates a new artifact.
+func (s *RegistryArtifactStore) Create(ctx context.Context, artifact *types.RegistryArtifact) error {
+	const sqlQuery = `
+	INSERT INTO registry_artifacts (
+		 artifact_registry_id
+		,artifact_name
+		,artifact_created
+		,artifact_updated
+	) values (
+		 :artifact_registry_id
+		,:artifact_name
+		,:artifact_created
+		,:artifact_updated
+	) RETURNING artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistryArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Touch updates the last modification time of the artifact.
+func (s *RegistryArtifactStore) Touch(ctx context.Context, id int64, updated int64) error {
+	const sqlQuery = `
+	UPDATE registry_artifacts
+	SET artifact_updated = $1
+	WHERE artifact_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, updated, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update registry artifact")
+	}
+
+	return nil
+}
+
+// Delete deletes the artifact with all its versions.
+func (s *RegistryArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM registry_artifacts
+	WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// DeleteEmpty deletes all artifacts of the registry without any versions.
+func (s *RegistryArtifactStore) DeleteEmpty(ctx context.Context, registryID int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM registry_artifacts
+	WHERE artifact_registry_id = $1 AND NOT EXISTS (
+		SELECT 1 FROM registry_versions WHERE version_artifact_id = artifact_id
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, registryID)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete empty registry artifacts")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count, nil
+}
+
+// List lists the artifacts of the registry.
+func (s *RegistryArtifactStore) List(
+	ctx context.Context,
+	registryID int64,
+	filter types.ListQueryFilter,
+) ([]*types.RegistryArtifact, error) {
+	stmt := database.Builder.
+		Select(registryArtifactColumns).
+		From("registry_artifacts").
+		Where("artifact_registry_id = ?", registryID).
+		OrderBy("artifact_name ASC")
+
+	stmt = applyRegistryArtifactFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert registry artifact list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registryArtifact{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing registry artifact list query")
+	}
+
+	artifacts := make([]*types.RegistryArtifact, len(dst))
+	for i := range dst {
+		artifacts[i] = mapToRegistryArtifact(dst[i])
+	}
+
+	return artifacts, nil
+}
+
+// Count returns the number of artifacts of the registry.
+func (s *RegistryArtifactStore) Count(
+	ctx context.Context,
+	registryID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("registry_artifacts").
+		Where("artifact_registry_id = ?", registryID)
+
+	stmt = applyRegistryArtifactFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert registry artifact count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing registry artifact count query")
+	}
+
+	return count, nil
+}
+
+func applyRegistryArtifactFilter(stmt squirrel.SelectBuilder, filter types.ListQueryFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(artifact_name) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func mapToRegistryArtifact(in *registryArtifact) *types.RegistryArtifact {
+	return &types.RegistryArtifact{
+		ID:         in.ID,
+		RegistryID: in.RegistryID,
+		Name:       in.Name,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
+
+func mapToInternalRegistryArtifact(in *types.RegistryArtifact) *registryArtifact {
+	return &registryArtifact{
+		ID:         in.ID,
+		RegistryID: in.RegistryID,
+		Name:       in.Name,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RegistryBlobStore = (*RegistryBlobStore)(nil)
+
+// NewRegistryBlobStore returns a new RegistryBlobStore.
+func NewRegistryBlobStore(db *sqlx.DB) *RegistryBlobStore {
+	return &RegistryBlobStore{
+		db: db,
+	}
+}
+
+// RegistryBlobStore implements store.RegistryBlobStore backed by a relational database.
+type RegistryBlobStore struct {
+	db *sqlx.DB
+}
+
+type registryBlob struct {
+	ID         int64  `db:"blob_id"`
+	RegistryID int64  `db:"blob_registry_id"`
+	Digest     string `db:"blob_digest"`
+	MediaType  string `db:"blob_media_type"`
+	Size       int64  `db:"blob_size"`
+	Created    int64  `db:"blob_created"`
+}
+
+type registryManifestReference struct {
+	ManifestDigest string `db:"reference_manifest_digest"`
+	Digest         string `db:"reference_digest"`
+}
+
+const (
+	registryBlobColumns = `
+		 blob_id
+		,blob_registry_id
+		,blob_digest
+		,blob_media_type
+		,blob_size
+		,blob_created`
+
+	registryBlobSelectBase = `
+	SELECT` + registryBlobColumns + `
+	FROM registry_blobs`
+)
+
+// Find finds the blob of the registry by its digest.
+func (s *RegistryBlobStore) Find(ctx context.Context, registryID int64, digest string) (*types.RegistryBlob, error) {
+	const sqlQuery = registryBlobSelectBase + `
+	WHERE blob_registry_id = $1 AND blob_digest = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &registryBlob{}
+	if err := db.GetContext(ctx, dst, sqlQuery, registryID, digest); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find registry blob")
+	}
+
+	return mapToRegistryBlob(dst), nil
+}
+
+// Create creates a new blob. Returns store.ErrDuplicate if the registry already has a blob with the digest.
+func (s *RegistryBlobStore) Create(ctx context.Context, blob *types.RegistryBlob) error {
+	const sqlQuery = `
+	INSERT INTO registry_blobs (
+		 blob_registry_id
+		,blob_digest
+		,blob_media_type
+		,blob_size
+		,blob_created
+	) values (
+		 :blob_registry_id
+		,:blob_digest
+		,:blob_media_type
+		,:blob_size
+		,:blob_created
+	) RETURNING blob_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistryBlob(blob))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry blob object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&blob.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the blob by id.
+func (s *RegistryBlobStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM registry_blobs
+	WHERE blob_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListCreatedBefore lists the blobs of the registry created before the provided time.
+func (s *RegistryBlobStore) ListCreatedBefore(
+	ctx context.Context,
+	registryID int64,
+	before int64,
+) ([]*types.RegistryBlob, error) {
+	const sqlQuery = registryBlobSelectBase + `
+	WHERE blob_registry_id = $1 AND blob_created < $2
+	ORDER BY blob_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registryBlob{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, registryID, before); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list registry blobs")
+	}
+
+	blobs := make([]*types.RegistryBlob, len(dst))
+	for i := range dst {
+		blobs[i] = mapToRegistryBlob(dst[i])
+	}
+
+	return blobs, nil
+}
+
+// ReplaceReferences replaces the digests referenced by a manifest of the registry.
+func (s *RegistryBlobStore) ReplaceReferences(
+	ctx context.Context,
+	registryID int64,
+	manifestDigest string,
+	digests []string,
+) error {
+	if err := s.DeleteReferences(ctx, registryID, manifestDigest); err != nil {
+		return err
+	}
+
+	if len(digests) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("registry_manifest_references").
+		Columns("reference_registry_id", "reference_manifest_digest", "reference_digest")
+
+	for _, digest := range digests {
+		stmt = stmt.Values(registryID, manifestDigest, digest)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert manifest reference insert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert manifest references")
+	}
+
+	return nil
+}
+
+// ListReferences lists all manifest references of the registry.
+func (s *RegistryBlobStore) ListReferences(
+	ctx context.Context,
+	registryID int64,
+) ([]*types.RegistryManifestReference, error) {
+	const sqlQuery = `
+	SELECT
+		 reference_manifest_digest
+		,reference_digest
+	FROM registry_manifest_references
+	WHERE reference_registry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registryManifestReference{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, registryID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list manifest references")
+	}
+
+	refs := make([]*types.RegistryManifestReference, len(dst))
+	for i := range dst {
+		refs[i] = &types.RegistryManifestReference{
+			ManifestDigest: dst[i].ManifestDigest,
+			Digest:         dst[i].Digest,
+		}
+	}
+
+	return refs, nil
+}
+
+// DeleteReferences deletes the references of a manifest of the registry.
+func (s *RegistryBlobStore) DeleteReferences(ctx context.Context, registryID int64, manifestDigest string) error {
+	const sqlQuery = `
+	DELETE FROM registry_manifest_references
+	WHERE reference_registry_id = $1 AND reference_manifest_digest = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, registryID, manifestDigest); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete manifest references")
+	}
+
+	return nil
+}
+
+func mapToRegistryBlob(in *registryBlob) *types.RegistryBlob {
+	return &types.RegistryBlob{
+		ID:         in.ID,
+		RegistryID: in.RegistryID,
+		Digest:     in.Digest,
+		MediaType:  in.MediaType,
+		Size:       in.Size,
+		Created:    in.Created,
+	}
+}
+
+func mapToInternalRegistryBlob(in *types.RegistryBlob) *registryBlob {
+	return &registryBlob{
+		ID:         in.ID,
+		RegistryID: in.RegistryID,
+		Digest:     in.Digest,
+		MediaType:  in.MediaType,
+		Size:       in.Size,
+		Created:    in.Created,
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_Registries(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	registryStore := database.NewRegistryStore(db)
+	artifactStore := database.NewRegistryArtifactStore(db)
+	versionStore := database.NewRegistryVersionStore(db)
+	blobStore := database.NewRegistryBlobStore(db)
+	uploadStore := database.NewRegistryUploadStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	registry := &types.Registry{
+		SpaceID:    1,
+		Identifier: "images",
+		Type:       enum.RegistryTypeDocker,
+		CreatedBy:  userID,
+	}
+	if err := registryStore.Create(ctx, registry); err != nil {
+		t.Fatalf("failed to create registry: %v", err)
+	}
+
+	duplicate := *registry
+	duplicate.Identifier = "IMAGES"
+	if err := registryStore.Create(ctx, &duplicate); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("expected duplicate error for registry identifier, got %v", err)
+	}
+
+	found, err := registryStore.FindByIdentifier(ctx, 1, "Images")
+	if err != nil {
+		t.Fatalf("failed to find registry: %v", err)
+	}
+	if found.ID != registry.ID || found.Type != enum.RegistryTypeDocker {
+		t.Errorf("unexpected registry %+v", found)
+	}
+
+	artifact := &types.RegistryArtifact{RegistryID: registry.ID, Name: "app/web"}
+	if err = artifactStore.Create(ctx, artifact); err != nil {
+		t.Fatalf("failed to create artifact: %v", err)
+	}
+
+	for i, tag := range []string{"v1", "v2", "latest"} {
+		version := &types.RegistryVersion{
+			ArtifactID: artifact.ID,
+			Name:       tag,
+			Digest:     "sha256:manifest",
+			CreatedBy:  userID,
+			Created:    int64(i),
+		}
+		if err = versionStore.Create(ctx, version); err != nil {
+			t.Fatalf("failed to create version %s: %v", tag, err)
+		}
+	}
+
+	names, err := versionStore.ListNames(ctx, artifact.ID)
+	if err != nil {
+		t.Fatalf("failed to list version names: %v", err)
+	}
+	if len(names) != 3 || names[0] != "latest" || names[2] != "v2" {
+		t.Errorf("unexpected version names %v", names)
+	}
+
+	versions, err := versionStore.ListByRegistry(ctx, registry.ID)
+	if err != nil {
+		t.Fatalf("failed to list registry versions: %v", err)
+	}
+	if len(versions) != 3 || versions[0].Name != "latest" {
+		t.Errorf("expected newest version first, got %+v", versions)
+	}
+
+	if err = blobStore.Create(ctx, &types.RegistryBlob{RegistryID: registry.ID, Digest: "sha256:layer"}); err != nil {
+		t.Fatalf("failed to create blob: %v", err)
+	}
+	err = blobStore.Create(ctx, &types.RegistryBlob{RegistryID: registry.ID, Digest: "sha256:layer"})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("expected duplicate error for blob digest, got %v", err)
+	}
+
+	digests := []string{"sha256:config", "sha256:layer"}
+	if err = blobStore.ReplaceReferences(ctx, registry.ID, "sha256:manifest", digests); err != nil {
+		t.Fatalf("failed to create references: %v", err)
+	}
+	if err = blobStore.ReplaceReferences(ctx, registry.ID, "sha256:manifest", digests); err != nil {
+		t.Fatalf("failed to replace references: %v", err)
+	}
+	refs, err := blobStore.ListReferences(ctx, registry.ID)
+	if err != nil {
+		t.Fatalf("failed to list references: %v", err)
+	}
+	if len(refs) != 2 {
+		t.Errorf("expected 2 references, got %d", len(refs))
+	}
+
+	if _, err = versionStore.DeleteByName(ctx, artifact.ID, "v1"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+	if _, err = versionStore.DeleteByName(ctx, artifact.ID, "v2"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+	if deleted, _ := artifactStore.DeleteEmpty(ctx, registry.ID); deleted != 0 {
+		t.Errorf("expected artifact with versions to be kept")
+	}
+	if _, err = versionStore.DeleteByName(ctx, artifact.ID, "latest"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+	if deleted, _ := artifactStore.DeleteEmpty(ctx, registry.ID); deleted != 1 {
+		t.Errorf("expected empty artifact to be deleted")
+	}
+
+	upload := &types.RegistryUpload{RegistryID: registry.ID, Identifier: "upload-1", CreatedBy: userID}
+	if err = uploadStore.Create(ctx, upload); err != nil {
+		t.Fatalf("failed to create upload: %v", err)
+	}
+	stale := *upload
+	if err = uploadStore.AddPart(ctx, upload, 10, 5); err != nil {
+		t.Fatalf("failed to add upload part: %v", err)
+	}
+	if err = uploadStore.AddPart(ctx, &stale, 10, 5); !errors.Is(err, gitness_store.ErrVersionConflict) {
+		t.Errorf("expected version conflict for concurrent upload part, got %v", err)
+	}
+	if upload.Size != 10 || upload.Parts != 1 {
+		t.Errorf("unexpected upload progress %+v", upload)
+	}
+
+	if err = registryStore.Delete(ctx, registry.ID); err != nil {
+		t.Fatalf("failed to delete registry: %v", err)
+	}
+	if _, err = blobStore.Find(ctx, registry.ID, "sha256:layer"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected blobs to be deleted with the registry, got %v", err)
+	}
+}
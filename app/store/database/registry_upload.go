@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RegistryUploadStore = (*RegistryUploadStore)(nil)
+
+// NewRegistryUploadStore returns a new RegistryUploadStore.
+func NewRegistryUploadStore(db *sqlx.DB) *RegistryUploadStore {
+	return &RegistryUploadStore{
+		db: db,
+	}
+}
+
+// RegistryUploadStore implements store.RegistryUploadStore backed by a relational database.
+type RegistryUploadStore struct {
+	db *sqlx.DB
+}
+
+type registryUpload struct {
+	ID         int64  `db:"upload_id"`
+	RegistryID int64  `db:"upload_registry_id"`
+	Identifier string `db:"upload_uid"`
+	Size       int64  `db:"upload_size"`
+	Parts      int64  `db:"upload_parts"`
+	CreatedBy  int64  `db:"upload_created_by"`
+	Created    int64  `db:"upload_created"`
+	Updated    int64  `db:"upload_updated"`
+}
+
+const (
+	registryUploadColumns = `
+		 upload_id
+		,upload_registry_id
+		,upload_uid
+		,upload_size
+		,upload_parts
+		,upload_created_by
+		,upload_created
+		,upload_updated`
+
+	registryUploadSelectBase = `
+	SELECT` + registryUploadColumns + `
+	FROM registry_uploads`
+)
+
+// FindByIdentifier finds the upload of the registry by its identifier.
+func (s *RegistryUploadStore) FindByIdentifier(
+	ctx context.Context,
+	registryID int64,
+	identifier string,
+) (*types.RegistryUpload, error) {
+	const sqlQuery = registryUploadSelectBase + `
+	WHERE upload_registry_id = $1 AND upload_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &registryUpload{}
+	if err := db.GetContext(ctx, dst, sqlQuery, registryID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find registry upload")
+	}
+
+	return mapToRegistryUpload(dst), nil
+}
+
+// Create creates a new upload.
+func (s *RegistryUploadStore) Create(ctx context.Context, upload *types.RegistryUpload) error {
+	const sqlQuery = `
+	INSERT INTO registry_uploads (
+		 upload_registry_id
+		,upload_uid
+		,upload_size
+		,upload_parts
+		,upload_created_by
+		,upload_created
+		,upload_updated
+	) values (
+		 :upload_registry_id
+		,:upload_uid
+		,:upload_size
+		,:upload_parts
+		,:upload_created_by
+		,:upload_created
+		,:upload_updated
+	) RETURNING upload_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistryUpload(upload))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry upload object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&upload.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// AddPart records an uploaded chunk. The update fails with store.ErrVersionConflict
+// if another chunk has been added concurrently.
+func (s *RegistryUploadStore) AddPart(ctx context.Context, upload *types.RegistryUpload, size, updated int64) error {
+	const sqlQuery = `
+	UPDATE registry_uploads
+	SET
+		 upload_size = upload_size + $1
+		,upload_parts = upload_parts + 1
+		,upload_updated = $2
+	WHERE upload_id = $3 AND upload_parts = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, size, updated, upload.ID, upload.Parts)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update registry upload")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	upload.Size += size
+	upload.Parts++
+	upload.Updated = updated
+
+	return nil
+}
+
+// Delete deletes the upload by id.
+func (s *RegistryUploadStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM registry_uploads
+	WHERE upload_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// ListUpdatedBefore lists the uploads of all registries that haven't been updated since the provided time.
+func (s *RegistryUploadStore) ListUpdatedBefore(ctx context.Context, before int64) ([]*types.RegistryUpload, error) {
+	const sqlQuery = registryUploadSelectBase + `
+	WHERE upload_updated < $1
+	ORDER BY upload_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registryUpload{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list registry uploads")
+	}
+
+	uploads := make([]*types.RegistryUpload, len(dst))
+	for i := range dst {
+		uploads[i] = mapToRegistryUpload(dst[i])
+	}
+
+	return uploads, nil
+}
+
+func mapToRegistryUpload(in *registryUpload) *types.RegistryUpload {
+	return &types.RegistryUpload{
+		ID:         in.ID,
+		RegistryID: in.RegistryID,
+		Identifier: in.Identifier,
+		Size:       in.Size,
+		Parts:      in.Parts,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
+
+func mapToInternalRegistryUpload(in *types.RegistryUpload) *registryUpload {
+	return &registryUpload{
+		ID:         in.ID,
+		RegistryID: in.RegistryID,
+		Identifier: in.Identifier,
+		Size:       in.Size,
+		Parts:      in.Parts,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
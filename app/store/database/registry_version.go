@@ -0,0 +1,364 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RegistryVersionStore = (*RegistryVersionStore)(nil)
+
+// NewRegistryVersionStore returns a new RegistryVersionStore.
+func NewRegistryVersionStore(db *sqlx.DB) *RegistryVersionStore {
+	return &RegistryVersionStore{
+		db: db,
+	}
+}
+
+// RegistryVersionStore implements store.RegistryVersionStore backed by a relational database.
+type RegistryVersionStore struct {
+	db *sqlx.DB
+}
+
+type registryVersion struct {
+	ID         int64  `db:"version_id"`
+	ArtifactID int64  `db:"version_artifact_id"`
+	Name       string `db:"version_name"`
+	FileName   string `db:"version_file_name"`
+	Digest     string `db:"version_digest"`
+	MediaType  string `db:"version_media_type"`
+	Size       int64  `db:"version_size"`
+	CreatedBy  int64  `db:"version_created_by"`
+	Created    int64  `db:"version_created"`
+	Updated    int64  `db:"version_updated"`
+}
+
+const (
+	registryVersionColumns = `
+		 version_id
+		,version_artifact_id
+		,version_name
+		,version_file_name
+		,version_digest
+		,version_media_type
+		,version_size
+		,version_created_by
+		,version_created
+		,version_updated`
+
+	registryVersionSelectBase = `
+	SELECT` + registryVersionColumns + `
+	FROM registry_versions`
+)
+
+// Find finds the file of an artifact version. The file name is empty for container images.
+func (s *RegistryVersionStore) Find(
+	ctx context.Context,
+	artifactID int64,
+	name string,
+	fileName string,
+) (*types.RegistryVersion, error) {
+	const sqlQuery = registryVersionSelectBase + `
+	WHERE version_artifact_id = $1 AND version_name = $2 AND version_file_name = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &registryVersion{}
+	if err := db.GetContext(ctx, dst, sqlQuery, artifactID, name, fileName); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find registry version")
+	}
+
+	return mapToRegistryVersion(dst), nil
+}
+
+// Create creates a new artifact version.
+func (s *RegistryVersionStore) Create(ctx context.Context, version *types.RegistryVersion) error {
+	const sqlQuery = `
+	INSERT INTO registry_versions (
+		 version_artifact_id
+		,version_name
+		,version_file_name
+		,version_digest
+		,version_media_type
+		,version_size
+		,version_created_by
+		,version_created
+		,version_updated
+	) values (
+		 :version_artifact_id
+		,:version_name
+		,:version_file_name
+		,:version_digest
+		,:version_media_type
+		,:version_size
+		,:version_created_by
+		,:version_created
+		,:version_updated
+	) RETURNING version_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistryVersion(version))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry version object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&version.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update points an existing artifact version at new content.
+func (s *RegistryVersionStore) Update(ctx context.Context, version *types.RegistryVersion) error {
+	const sqlQuery = `
+	UPDATE registry_versions
+	SET
+		 version_digest = :version_digest
+		,version_media_type = :version_media_type
+		,version_size = :version_size
+		,version_created_by = :version_created_by
+		,version_updated = :version_updated
+	WHERE version_id = :version_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRegistryVersion(version))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind registry version object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update registry version")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the artifact version by id.
+func (s *RegistryVersionStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM registry_versions
+	WHERE version_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// DeleteByName deletes all files of the artifact version with the provided name.
+func (s *RegistryVersionStore) DeleteByName(ctx context.Context, artifactID int64, name string) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM registry_versions
+	WHERE version_artifact_id = $1 AND version_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, artifactID, name)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count, nil
+}
+
+// List lists the versions of the artifact, most recently updated first.
+func (s *RegistryVersionStore) List(
+	ctx context.Context,
+	artifactID int64,
+	filter types.ListQueryFilter,
+) ([]*types.RegistryVersion, error) {
+	stmt := database.Builder.
+		Select(registryVersionColumns).
+		From("registry_versions").
+		Where("version_artifact_id = ?", artifactID).
+		OrderBy("version_updated DESC", "version_name ASC", "version_file_name ASC")
+
+	stmt = applyRegistryVersionFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert registry version list query to sql: %w", err)
+	}
+
+	return s.list(ctx, sql, args...)
+}
+
+// Count returns the number of versions of the artifact.
+func (s *RegistryVersionStore) Count(
+	ctx context.Context,
+	artifactID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("registry_versions").
+		Where("version_artifact_id = ?", artifactID)
+
+	stmt = applyRegistryVersionFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert registry version count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing registry version count query")
+	}
+
+	return count, nil
+}
+
+// ListNames lists the distinct version names of the artifact in ascending order.
+func (s *RegistryVersionStore) ListNames(ctx context.Context, artifactID int64) ([]string, error) {
+	const sqlQuery = `
+	SELECT DISTINCT version_name
+	FROM registry_versions
+	WHERE version_artifact_id = $1
+	ORDER BY version_name ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	names := []string{}
+	if err := db.SelectContext(ctx, &names, sqlQuery, artifactID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list registry version names")
+	}
+
+	return names, nil
+}
+
+// ListByDigest lists the versions of the artifact pointing at the provided digest.
+func (s *RegistryVersionStore) ListByDigest(
+	ctx context.Context,
+	artifactID int64,
+	digest string,
+) ([]*types.RegistryVersion, error) {
+	const sqlQuery = registryVersionSelectBase + `
+	WHERE version_artifact_id = $1 AND version_digest = $2
+	ORDER BY version_id ASC`
+
+	return s.list(ctx, sqlQuery, artifactID, digest)
+}
+
+// ListByRegistry lists all versions of all artifacts of the registry,
+// grouped by artifact and ordered by creation time, newest first.
+func (s *RegistryVersionStore) ListByRegistry(ctx context.Context, registryID int64) ([]*types.RegistryVersion, error) {
+	const sqlQuery = registryVersionSelectBase + `
+	INNER JOIN registry_artifacts ON artifact_id = version_artifact_id
+	WHERE artifact_registry_id = $1
+	ORDER BY version_artifact_id ASC, version_created DESC, version_id DESC`
+
+	return s.list(ctx, sqlQuery, registryID)
+}
+
+func (s *RegistryVersionStore) list(ctx context.Context, sql string, args ...any) ([]*types.RegistryVersion, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*registryVersion{}
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing registry version list query")
+	}
+
+	versions := make([]*types.RegistryVersion, len(dst))
+	for i := range dst {
+		versions[i] = mapToRegistryVersion(dst[i])
+	}
+
+	return versions, nil
+}
+
+func applyRegistryVersionFilter(stmt squirrel.SelectBuilder, filter types.ListQueryFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(version_name) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func mapToRegistryVersion(in *registryVersion) *types.RegistryVersion {
+	return &types.RegistryVersion{
+		ID:         in.ID,
+		ArtifactID: in.ArtifactID,
+		Name:       in.Name,
+		FileName:   in.FileName,
+		Digest:     in.Digest,
+		MediaType:  in.MediaType,
+		Size:       in.Size,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
+
+func mapToInternalRegistryVersion(in *types.RegistryVersion) *registryVersion {
+	return &registryVersion{
+		ID:         in.ID,
+		ArtifactID: in.ArtifactID,
+		Name:       in.Name,
+		FileName:   in.FileName,
+		Digest:     in.Digest,
+		MediaType:  in.MediaType,
+		Size:       in.Size,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+		Updated:    in.Updated,
+	}
+}
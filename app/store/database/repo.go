@@ -43,12 +43,14 @@ func NewRepoStore(
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
 	spaceStore store.SpaceStore,
+	rowCache store.RowCache,
 ) *RepoStore {
 	return &RepoStore{
 		db:             db,
 		spacePathCache: spacePathCache,
 		spacePathStore: spacePathStore,
 		spaceStore:     spaceStore,
+		rowCache:       rowCache,
 	}
 }
 
@@ -58,6 +60,7 @@ type RepoStore struct {
 	spacePathCache store.SpacePathCache
 	spacePathStore store.SpacePathStore
 	spaceStore     store.SpaceStore
+	rowCache       store.RowCache
 }
 
 type repository struct {
@@ -80,6 +83,7 @@ type repository struct {
 	DefaultBranch string `db:"repo_default_branch"`
 	ForkID        int64  `db:"repo_fork_id"`
 	PullReqSeq    int64  `db:"repo_pullreq_seq"`
+	IssueSeq      int64  `db:"repo_issue_seq"`
 
 	NumForks       int `db:"repo_num_forks"`
 	NumPulls       int `db:"repo_num_pulls"`
@@ -88,6 +92,10 @@ type repository struct {
 	NumMergedPulls int `db:"repo_num_merged_pulls"`
 
 	Importing bool `db:"repo_importing"`
+	Archived  bool `db:"repo_archived"`
+
+	PullReqSuggestions        bool `db:"repo_pullreq_suggestions"`
+	PullReqDeleteSourceBranch bool `db:"repo_pullreq_delete_source_branch"`
 }
 
 const (
@@ -107,18 +115,34 @@ const (
 		,repo_git_uid
 		,repo_default_branch
 		,repo_pullreq_seq
+		,repo_issue_seq
 		,repo_fork_id
 		,repo_num_forks
 		,repo_num_pulls
 		,repo_num_closed_pulls
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
-		,repo_importing`
+		,repo_importing
+		,repo_archived
+		,repo_pullreq_suggestions
+		,repo_pullreq_delete_source_branch`
 )
 
 // Find finds the repo by id.
 func (s *RepoStore) Find(ctx context.Context, id int64) (*types.Repository, error) {
-	return s.find(ctx, id, nil)
+	cached := new(types.Repository)
+	if rowCacheGet(ctx, s.rowCache, rowCacheKeyRepo(id), cached) {
+		return cached, nil
+	}
+
+	repo, err := s.find(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rowCacheSet(ctx, s.rowCache, rowCacheKeyRepo(id), repo)
+
+	return repo, nil
 }
 
 // find is a wrapper to find a repo by id w/o deleted timestamp.
@@ -201,7 +225,29 @@ func (s *RepoStore) findByRef(ctx context.Context, repoRef string, deletedAt *in
 
 // FindByRef finds the repo using the repoRef as either the id or the repo path.
 func (s *RepoStore) FindByRef(ctx context.Context, repoRef string) (*types.Repository, error) {
-	return s.findByRef(ctx, repoRef, nil)
+	// ASSUMPTION: digits only is not a valid repo path
+	if id, err := strconv.ParseInt(repoRef, 10, 64); err == nil {
+		return s.Find(ctx, id)
+	}
+
+	var id int64
+	if rowCacheGet(ctx, s.rowCache, rowCacheKeyRepoPath(repoRef), &id) {
+		repo, err := s.Find(ctx, id)
+		// the cached path is outdated in case the repo got moved or deleted in the meantime.
+		if err == nil && strings.EqualFold(repo.Path, repoRef) {
+			return repo, nil
+		}
+	}
+
+	repo, err := s.findByRef(ctx, repoRef, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rowCacheSet(ctx, s.rowCache, rowCacheKeyRepoPath(repoRef), repo.ID)
+	rowCacheSet(ctx, s.rowCache, rowCacheKeyRepo(repo.ID), repo)
+
+	return repo, nil
 }
 
 // FindByRefAndDeletedAt finds the repo using the repoRef and deleted timestamp.
@@ -232,12 +278,16 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_default_branch
 			,repo_fork_id
 			,repo_pullreq_seq
+			,repo_issue_seq
 			,repo_num_forks
 			,repo_num_pulls
 			,repo_num_closed_pulls
 			,repo_num_open_pulls
 			,repo_num_merged_pulls
 			,repo_importing
+			,repo_archived
+			,repo_pullreq_suggestions
+			,repo_pullreq_delete_source_branch
 		) values (
 			:repo_version
 			,:repo_parent_id
@@ -254,12 +304,16 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_default_branch
 			,:repo_fork_id
 			,:repo_pullreq_seq
+			,:repo_issue_seq
 			,:repo_num_forks
 			,:repo_num_pulls
 			,:repo_num_closed_pulls
 			,:repo_num_open_pulls
 			,:repo_num_merged_pulls
 			,:repo_importing
+			,:repo_archived
+			,:repo_pullreq_suggestions
+			,:repo_pullreq_delete_source_branch
 		) RETURNING repo_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -297,12 +351,16 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_is_public = :repo_is_public
 			,repo_default_branch = :repo_default_branch
 			,repo_pullreq_seq = :repo_pullreq_seq
+			,repo_issue_seq = :repo_issue_seq
 			,repo_num_forks = :repo_num_forks
 			,repo_num_pulls = :repo_num_pulls
 			,repo_num_closed_pulls = :repo_num_closed_pulls
 			,repo_num_open_pulls = :repo_num_open_pulls
 			,repo_num_merged_pulls = :repo_num_merged_pulls
 			,repo_importing = :repo_importing
+			,repo_archived = :repo_archived
+			,repo_pullreq_suggestions = :repo_pullreq_suggestions
+			,repo_pullreq_delete_source_branch = :repo_pullreq_delete_source_branch
 		WHERE repo_id = :repo_id AND repo_version = :repo_version - 1`
 
 	dbRepo := mapToInternalRepo(repo)
@@ -332,6 +390,8 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 		return gitness_store.ErrVersionConflict
 	}
 
+	s.rowCache.Evict(ctx, rowCacheKeyRepo(repo.ID))
+
 	repo.Version = dbRepo.Version
 	repo.Updated = dbRepo.Updated
 
@@ -373,6 +433,8 @@ func (s *RepoStore) UpdateSize(ctx context.Context, id int64, size int64) error
 		return fmt.Errorf("repo %d size not updated: %w", id, gitness_store.ErrResourceNotFound)
 	}
 
+	s.rowCache.Evict(ctx, rowCacheKeyRepo(id))
+
 	return nil
 }
 
@@ -488,6 +550,8 @@ func (s *RepoStore) Purge(ctx context.Context, id int64, deletedAt *int64) error
 		return database.ProcessSQLErrorf(ctx, err, "the delete query failed")
 	}
 
+	s.rowCache.Evict(ctx, rowCacheKeyRepo(id))
+
 	return nil
 }
 
@@ -626,16 +690,19 @@ func (s *RepoStore) list(
 		From("repositories").
 		Where("repo_parent_id = ?", fmt.Sprint(parentID))
 
+	db := dbtx.GetAccessor(ctx, s.db)
+
 	stmt = applyQueryFilter(stmt, filter)
-	stmt = applySortFilter(stmt, filter)
+	stmt, err := applySortFilter(ctx, db, stmt, squirrel.Eq{"repo_parent_id": parentID}, filter)
+	if err != nil {
+		return nil, err
+	}
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
-
 	dst := []*repository{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
@@ -676,7 +743,10 @@ FROM SpaceHierarchy h1;`
 		Where(squirrel.Eq{"repo_parent_id": spaceIDs})
 
 	stmt = applyQueryFilter(stmt, filter)
-	stmt = applySortFilter(stmt, filter)
+	stmt, err := applySortFilter(ctx, db, stmt, squirrel.Eq{"repo_parent_id": spaceIDs}, filter)
+	if err != nil {
+		return nil, err
+	}
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -718,34 +788,117 @@ func (s *RepoStore) ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeI
 	return s.mapToRepoSizes(dst), nil
 }
 
+// ListForks returns all forks of a repo, including deleted ones (their git repositories still exist).
+func (s *RepoStore) ListForks(ctx context.Context, repoID int64) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID).
+		OrderBy("repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListInactive returns the active repos of the space and all its subspaces that weren't updated
+// and didn't receive any push or pull request update since the provided time.
+// Archived repositories and repositories that are being imported are excluded.
+func (s *RepoStore) ListInactive(
+	ctx context.Context,
+	spaceID int64,
+	since int64,
+) ([]*types.Repository, error) {
+	const spacesQuery = `WITH RECURSIVE SpaceHierarchy AS (
+    SELECT space_id, space_parent_id
+    FROM spaces
+    WHERE space_id = $1
+
+    UNION
+
+    SELECT s.space_id, s.space_parent_id
+    FROM spaces s
+    JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
+)
+SELECT space_id
+FROM SpaceHierarchy h1;`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var spaceIDs []int64
+	if err := db.SelectContext(ctx, &spaceIDs, spacesQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to retrieve spaces")
+	}
+
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs}).
+		Where("repo_deleted IS NULL").
+		Where("repo_archived = ?", false).
+		Where("repo_importing = ?", false).
+		Where("repo_updated < ?", since).
+		Where("NOT EXISTS (SELECT 1 FROM pushes WHERE push_repo_id = repo_id AND push_created >= ?)", since).
+		Where(`NOT EXISTS (SELECT 1 FROM pullreqs
+			WHERE (pullreq_target_repo_id = repo_id OR pullreq_source_repo_id = repo_id)
+			AND pullreq_updated >= ?)`, since).
+		OrderBy("repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list inactive query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 func (s *RepoStore) mapToRepo(
 	ctx context.Context,
 	in *repository,
 ) (*types.Repository, error) {
 	var err error
 	res := &types.Repository{
-		ID:             in.ID,
-		Version:        in.Version,
-		ParentID:       in.ParentID,
-		Identifier:     in.Identifier,
-		Description:    in.Description,
-		IsPublic:       in.IsPublic,
-		Created:        in.Created,
-		CreatedBy:      in.CreatedBy,
-		Updated:        in.Updated,
-		Deleted:        in.Deleted.Ptr(),
-		Size:           in.Size,
-		SizeUpdated:    in.SizeUpdated,
-		GitUID:         in.GitUID,
-		DefaultBranch:  in.DefaultBranch,
-		ForkID:         in.ForkID,
-		PullReqSeq:     in.PullReqSeq,
-		NumForks:       in.NumForks,
-		NumPulls:       in.NumPulls,
-		NumClosedPulls: in.NumClosedPulls,
-		NumOpenPulls:   in.NumOpenPulls,
-		NumMergedPulls: in.NumMergedPulls,
-		Importing:      in.Importing,
+		ID:                        in.ID,
+		Version:                   in.Version,
+		ParentID:                  in.ParentID,
+		Identifier:                in.Identifier,
+		Description:               in.Description,
+		IsPublic:                  in.IsPublic,
+		Created:                   in.Created,
+		CreatedBy:                 in.CreatedBy,
+		Updated:                   in.Updated,
+		Deleted:                   in.Deleted.Ptr(),
+		Size:                      in.Size,
+		SizeUpdated:               in.SizeUpdated,
+		GitUID:                    in.GitUID,
+		DefaultBranch:             in.DefaultBranch,
+		ForkID:                    in.ForkID,
+		PullReqSeq:                in.PullReqSeq,
+		IssueSeq:                  in.IssueSeq,
+		NumForks:                  in.NumForks,
+		NumPulls:                  in.NumPulls,
+		NumClosedPulls:            in.NumClosedPulls,
+		NumOpenPulls:              in.NumOpenPulls,
+		NumMergedPulls:            in.NumMergedPulls,
+		Importing:                 in.Importing,
+		Archived:                  in.Archived,
+		PullReqSuggestions:        in.PullReqSuggestions,
+		PullReqDeleteSourceBranch: in.PullReqDeleteSourceBranch,
 		// Path: is set below
 	}
 
@@ -807,28 +960,32 @@ func (s *RepoStore) mapToRepoSizes(
 
 func mapToInternalRepo(in *types.Repository) *repository {
 	return &repository{
-		ID:             in.ID,
-		Version:        in.Version,
-		ParentID:       in.ParentID,
-		Identifier:     in.Identifier,
-		Description:    in.Description,
-		IsPublic:       in.IsPublic,
-		Created:        in.Created,
-		CreatedBy:      in.CreatedBy,
-		Updated:        in.Updated,
-		Deleted:        null.IntFromPtr(in.Deleted),
-		Size:           in.Size,
-		SizeUpdated:    in.SizeUpdated,
-		GitUID:         in.GitUID,
-		DefaultBranch:  in.DefaultBranch,
-		ForkID:         in.ForkID,
-		PullReqSeq:     in.PullReqSeq,
-		NumForks:       in.NumForks,
-		NumPulls:       in.NumPulls,
-		NumClosedPulls: in.NumClosedPulls,
-		NumOpenPulls:   in.NumOpenPulls,
-		NumMergedPulls: in.NumMergedPulls,
-		Importing:      in.Importing,
+		ID:                        in.ID,
+		Version:                   in.Version,
+		ParentID:                  in.ParentID,
+		Identifier:                in.Identifier,
+		Description:               in.Description,
+		IsPublic:                  in.IsPublic,
+		Created:                   in.Created,
+		CreatedBy:                 in.CreatedBy,
+		Updated:                   in.Updated,
+		Deleted:                   null.IntFromPtr(in.Deleted),
+		Size:                      in.Size,
+		SizeUpdated:               in.SizeUpdated,
+		GitUID:                    in.GitUID,
+		DefaultBranch:             in.DefaultBranch,
+		ForkID:                    in.ForkID,
+		PullReqSeq:                in.PullReqSeq,
+		IssueSeq:                  in.IssueSeq,
+		NumForks:                  in.NumForks,
+		NumPulls:                  in.NumPulls,
+		NumClosedPulls:            in.NumClosedPulls,
+		NumOpenPulls:              in.NumOpenPulls,
+		NumMergedPulls:            in.NumMergedPulls,
+		Importing:                 in.Importing,
+		Archived:                  in.Archived,
+		PullReqSuggestions:        in.PullReqSuggestions,
+		PullReqDeleteSourceBranch: in.PullReqDeleteSourceBranch,
 	}
 }
 
@@ -841,27 +998,53 @@ func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squ
 	} else {
 		stmt = stmt.Where("repo_deleted IS NULL")
 	}
+	if filter.Archived != nil {
+		stmt = stmt.Where("repo_archived = ?", *filter.Archived)
+	}
 	return stmt
 }
 
-func applySortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
+func applySortFilter(
+	ctx context.Context,
+	db dbtx.Accessor,
+	stmt squirrel.SelectBuilder,
+	scope squirrel.Sqlizer,
+	filter *types.RepoFilter,
+) (squirrel.SelectBuilder, error) {
 	stmt = stmt.Limit(database.Limit(filter.Size))
+
+	columns := repoKeysetColumns(filter)
+
+	if filter.CursorID > 0 {
+		return database.ApplyKeyset(ctx, db, stmt, "repositories", "repo_id", filter.CursorID, scope, columns)
+	}
+
 	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy(database.KeysetOrderBy(columns)...)
+
+	return stmt, nil
+}
 
+// repoKeysetColumns returns the columns defining the order of the repositories.
+// The repo ID is used as the final tie-breaker to guarantee a stable order for keyset pagination.
+func repoKeysetColumns(filter *types.RepoFilter) []database.KeysetColumn {
+	desc := filter.Order == enum.OrderDesc
+
+	var columns []database.KeysetColumn
 	switch filter.Sort {
 	// TODO [CODE-1363]: remove after identifier migration.
 	case enum.RepoAttrUID, enum.RepoAttrIdentifier, enum.RepoAttrNone:
-		// NOTE: string concatenation is safe because the
-		// order attribute is an enum and is not user-defined,
-		// and is therefore not subject to injection attacks.
-		stmt = stmt.OrderBy("repo_importing desc, repo_uid " + filter.Order.String())
+		columns = []database.KeysetColumn{
+			{Name: "repo_importing", Desc: true},
+			{Name: "repo_uid", Desc: desc},
+		}
 	case enum.RepoAttrCreated:
-		stmt = stmt.OrderBy("repo_created " + filter.Order.String())
+		columns = []database.KeysetColumn{{Name: "repo_created", Desc: desc}}
 	case enum.RepoAttrUpdated:
-		stmt = stmt.OrderBy("repo_updated " + filter.Order.String())
+		columns = []database.KeysetColumn{{Name: "repo_updated", Desc: desc}}
 	case enum.RepoAttrDeleted:
-		stmt = stmt.OrderBy("repo_deleted " + filter.Order.String())
+		columns = []database.KeysetColumn{{Name: "repo_deleted", Desc: desc}}
 	}
 
-	return stmt
+	return append(columns, database.KeysetColumn{Name: "repo_id", Desc: desc})
 }
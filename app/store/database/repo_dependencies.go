@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoDependenciesStore = (*RepoDependenciesStore)(nil)
+
+// NewRepoDependenciesStore returns a new RepoDependenciesStore.
+func NewRepoDependenciesStore(db *sqlx.DB) *RepoDependenciesStore {
+	return &RepoDependenciesStore{
+		db: db,
+	}
+}
+
+// RepoDependenciesStore implements store.RepoDependenciesStore backed by a relational database.
+type RepoDependenciesStore struct {
+	db *sqlx.DB
+}
+
+type repoDependencyScan struct {
+	RepoID   int64  `db:"repo_dependency_scan_repo_id"`
+	SHA      string `db:"repo_dependency_scan_sha"`
+	Computed int64  `db:"repo_dependency_scan_computed"`
+	License  string `db:"repo_dependency_scan_license"`
+}
+
+type repoDependency struct {
+	Manifest  string                   `db:"repo_dependency_manifest"`
+	Ecosystem enum.DependencyEcosystem `db:"repo_dependency_ecosystem"`
+	Name      string                   `db:"repo_dependency_name"`
+	Version   string                   `db:"repo_dependency_version"`
+}
+
+const (
+	repoDependencyScanColumns = `
+		 repo_dependency_scan_repo_id
+		,repo_dependency_scan_sha
+		,repo_dependency_scan_computed
+		,repo_dependency_scan_license`
+
+	repoDependencyColumns = `
+		 repo_dependency_manifest
+		,repo_dependency_ecosystem
+		,repo_dependency_name
+		,repo_dependency_version`
+)
+
+// Find returns the license and the dependencies of the repository.
+func (s *RepoDependenciesStore) Find(ctx context.Context, repoID int64) (*types.RepoDependencies, error) {
+	stmt := database.Builder.
+		Select(repoDependencyScanColumns).
+		From("repo_dependency_scans").
+		Where("repo_dependency_scan_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoDependencyScan{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo dependency scan")
+	}
+
+	stmt = database.Builder.
+		Select(repoDependencyColumns).
+		From("repo_dependencies").
+		Where("repo_dependency_repo_id = ?", repoID).
+		OrderBy("repo_dependency_manifest", "repo_dependency_name")
+
+	sql, args, err = stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dstDependencies := make([]*repoDependency, 0)
+	if err = db.SelectContext(ctx, &dstDependencies, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repo dependencies")
+	}
+
+	return mapToRepoDependencies(dst, dstDependencies), nil
+}
+
+// Upsert creates or replaces the license and the dependencies of the repository.
+// It should be called inside a transaction because the previous dependencies are deleted first.
+func (s *RepoDependenciesStore) Upsert(ctx context.Context, dependencies *types.RepoDependencies) error {
+	const sqlQueryInsert = `
+	INSERT INTO repo_dependency_scans (
+		 repo_dependency_scan_repo_id
+		,repo_dependency_scan_sha
+		,repo_dependency_scan_computed
+		,repo_dependency_scan_license
+	) VALUES (
+		 :repo_dependency_scan_repo_id
+		,:repo_dependency_scan_sha
+		,:repo_dependency_scan_computed
+		,:repo_dependency_scan_license
+	)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (repo_dependency_scan_repo_id) DO
+	UPDATE SET
+		 repo_dependency_scan_sha = :repo_dependency_scan_sha
+		,repo_dependency_scan_computed = :repo_dependency_scan_computed
+		,repo_dependency_scan_license = :repo_dependency_scan_license`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 repo_dependency_scan_sha = :repo_dependency_scan_sha
+		,repo_dependency_scan_computed = :repo_dependency_scan_computed
+		,repo_dependency_scan_license = :repo_dependency_scan_license`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &repoDependencyScan{
+		RepoID:   dependencies.RepoID,
+		SHA:      dependencies.SHA,
+		Computed: dependencies.Computed,
+		License:  dependencies.License,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo dependency scan object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	sql, args, err := database.Builder.
+		Delete("repo_dependencies").
+		Where("repo_dependency_repo_id = ?", dependencies.RepoID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repo dependencies")
+	}
+
+	if len(dependencies.Dependencies) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("repo_dependencies").
+		Columns(
+			"repo_dependency_repo_id",
+			"repo_dependency_manifest",
+			"repo_dependency_ecosystem",
+			"repo_dependency_name",
+			"repo_dependency_version",
+		)
+
+	for _, dependency := range dependencies.Dependencies {
+		stmt = stmt.Values(
+			dependencies.RepoID,
+			dependency.Manifest,
+			dependency.Ecosystem,
+			dependency.Name,
+			dependency.Version,
+		)
+	}
+
+	sql, args, err = stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert insert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repo dependencies")
+	}
+
+	return nil
+}
+
+// ListRepoIDs returns the ids of all repositories with detected dependencies.
+func (s *RepoDependenciesStore) ListRepoIDs(ctx context.Context) ([]int64, error) {
+	stmt := database.Builder.
+		Select("repo_dependency_scan_repo_id").
+		From("repo_dependency_scans").
+		OrderBy("repo_dependency_scan_repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	repoIDs := make([]int64, 0)
+	if err = db.SelectContext(ctx, &repoIDs, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repo ids with dependencies")
+	}
+
+	return repoIDs, nil
+}
+
+func mapToRepoDependencies(scan *repoDependencyScan, dependencies []*repoDependency) *types.RepoDependencies {
+	result := &types.RepoDependencies{
+		RepoID:       scan.RepoID,
+		SHA:          scan.SHA,
+		Computed:     scan.Computed,
+		License:      scan.License,
+		Dependencies: make([]types.Dependency, len(dependencies)),
+	}
+
+	for i, dependency := range dependencies {
+		result.Dependencies[i] = types.Dependency{
+			Manifest:  dependency.Manifest,
+			Ecosystem: dependency.Ecosystem,
+			Name:      dependency.Name,
+			Version:   dependency.Version,
+		}
+	}
+
+	return result
+}
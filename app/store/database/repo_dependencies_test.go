@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRepoDependenciesStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	dependenciesStore := database.NewRepoDependenciesStore(db)
+
+	err := dependenciesStore.Upsert(ctx, &types.RepoDependencies{
+		RepoID:   1,
+		SHA:      "aaa",
+		Computed: 1,
+		License:  "MIT",
+		Dependencies: []types.Dependency{
+			{Manifest: "go.mod", Ecosystem: enum.DependencyEcosystemGo, Name: "a", Version: "v1.0.0"},
+			{Manifest: "go.mod", Ecosystem: enum.DependencyEcosystemGo, Name: "b", Version: "v2.0.0"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to upsert dependencies: %v", err)
+	}
+
+	// the dependencies of the previous commit have to be replaced.
+	err = dependenciesStore.Upsert(ctx, &types.RepoDependencies{
+		RepoID:   1,
+		SHA:      "bbb",
+		Computed: 2,
+		License:  "Apache-2.0",
+		Dependencies: []types.Dependency{
+			{Manifest: "web/package.json", Ecosystem: enum.DependencyEcosystemNPM, Name: "react", Version: "^18.2.0"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to upsert dependencies: %v", err)
+	}
+
+	dependencies, err := dependenciesStore.Find(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find dependencies: %v", err)
+	}
+
+	if dependencies.SHA != "bbb" || dependencies.License != "Apache-2.0" || len(dependencies.Dependencies) != 1 ||
+		dependencies.Dependencies[0].Name != "react" || dependencies.Dependencies[0].Version != "^18.2.0" {
+		t.Errorf("unexpected dependencies: %+v", dependencies)
+	}
+}
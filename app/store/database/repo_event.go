@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoEventStore = (*RepoEventStore)(nil)
+
+// NewRepoEventStore returns a new RepoEventStore.
+func NewRepoEventStore(db *sqlx.DB) *RepoEventStore {
+	return &RepoEventStore{
+		db: db,
+	}
+}
+
+// RepoEventStore implements store.RepoEventStore backed by a relational database.
+type RepoEventStore struct {
+	db *sqlx.DB
+}
+
+type repoEvent struct {
+	ID        int64        `db:"repo_event_id"`
+	RepoID    int64        `db:"repo_event_repo_id"`
+	PullReqID null.Int     `db:"repo_event_pullreq_id"`
+	Type      enum.SSEType `db:"repo_event_type"`
+	Data      string       `db:"repo_event_data"`
+	Created   int64        `db:"repo_event_created"`
+}
+
+const (
+	repoEventColumns = `
+		 repo_event_id
+		,repo_event_repo_id
+		,repo_event_pullreq_id
+		,repo_event_type
+		,repo_event_data
+		,repo_event_created`
+)
+
+// Create creates a new repository event entry.
+func (s *RepoEventStore) Create(ctx context.Context, event *types.RepoEvent) error {
+	const sqlQuery = `
+	INSERT INTO repo_events (
+		 repo_event_repo_id
+		,repo_event_pullreq_id
+		,repo_event_type
+		,repo_event_data
+		,repo_event_created
+	) values (
+		 :repo_event_repo_id
+		,:repo_event_pullreq_id
+		,:repo_event_type
+		,:repo_event_data
+		,:repo_event_created
+	) RETURNING repo_event_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoEvent(event))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// ListAfter lists the events of a repository (or only of one of its pull requests, if pullreqID is non-zero)
+// that were created after the event with the provided ID, oldest first.
+func (s *RepoEventStore) ListAfter(
+	ctx context.Context,
+	repoID, pullreqID, afterID int64,
+	limit int,
+) ([]*types.RepoEvent, error) {
+	stmt := database.Builder.
+		Select(repoEventColumns).
+		From("repo_events").
+		Where("repo_event_repo_id = ?", repoID).
+		Where("repo_event_id > ?", afterID)
+
+	if pullreqID != 0 {
+		stmt = stmt.Where("repo_event_pullreq_id = ?", pullreqID)
+	}
+
+	stmt = stmt.OrderBy("repo_event_id ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert repo event list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoEvent{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing repo event list query")
+	}
+
+	result := make([]*types.RepoEvent, len(dst))
+	for i, e := range dst {
+		result[i] = mapToRepoEvent(e)
+	}
+
+	return result, nil
+}
+
+// DeleteOld removes all repository events that are older than the provided time.
+func (s *RepoEventStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("repo_events").
+		Where("repo_event_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete repo events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete repo events query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted repo events")
+	}
+
+	return n, nil
+}
+
+func mapToRepoEvent(e *repoEvent) *types.RepoEvent {
+	return &types.RepoEvent{
+		ID:        e.ID,
+		RepoID:    e.RepoID,
+		PullReqID: e.PullReqID.Ptr(),
+		Type:      e.Type,
+		Data:      []byte(e.Data),
+		Created:   e.Created,
+	}
+}
+
+func mapToInternalRepoEvent(e *types.RepoEvent) *repoEvent {
+	return &repoEvent{
+		ID:        e.ID,
+		RepoID:    e.RepoID,
+		PullReqID: null.IntFromPtr(e.PullReqID),
+		Type:      e.Type,
+		Data:      string(e.Data),
+		Created:   e.Created,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_RepoEventsListAfter(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	repoEventStore := database.NewRepoEventStore(db)
+
+	ctx := context.Background()
+	now := time.Now().UnixMilli()
+	pullreqID := int64(7)
+
+	ids := make([]int64, 0, 4)
+	for i, e := range []types.RepoEvent{
+		{RepoID: 1, Type: enum.SSETypeBranchUpdated},
+		{RepoID: 1, PullReqID: &pullreqID, Type: enum.SSETypePullRequestCommentCreated},
+		{RepoID: 2, Type: enum.SSETypeBranchUpdated},
+		{RepoID: 1, PullReqID: &pullreqID, Type: enum.SSETypePullRequestMerged},
+	} {
+		e := e
+		e.Data = []byte(`{"repo_id":1}`)
+		e.Created = now
+		if err := repoEventStore.Create(ctx, &e); err != nil {
+			t.Fatalf("failed to create repo event %d: %v", i, err)
+		}
+		ids = append(ids, e.ID)
+	}
+
+	tests := []struct {
+		name      string
+		repoID    int64
+		pullreqID int64
+		afterID   int64
+		exp       []int64
+	}{
+		{name: "repo", repoID: 1, exp: []int64{ids[0], ids[1], ids[3]}},
+		{name: "repo-after", repoID: 1, afterID: ids[1], exp: []int64{ids[3]}},
+		{name: "pullreq", repoID: 1, pullreqID: pullreqID, exp: []int64{ids[1], ids[3]}},
+		{name: "other-repo", repoID: 2, exp: []int64{ids[2]}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events, err := repoEventStore.ListAfter(ctx, test.repoID, test.pullreqID, test.afterID, 10)
+			if err != nil {
+				t.Fatalf("failed to list repo events: %v", err)
+			}
+
+			got := make([]int64, len(events))
+			for i, e := range events {
+				got[i] = e.ID
+			}
+
+			if len(got) != len(test.exp) {
+				t.Fatalf("got repo events %v, want %v", got, test.exp)
+			}
+			for i := range got {
+				if got[i] != test.exp[i] {
+					t.Fatalf("got repo events %v, want %v", got, test.exp)
+				}
+			}
+		})
+	}
+
+	events, err := repoEventStore.ListAfter(ctx, 1, 0, 0, 1)
+	if err != nil {
+		t.Fatalf("failed to list repo events: %v", err)
+	}
+	if len(events) != 1 || string(events[0].Data) != `{"repo_id":1}` {
+		t.Errorf("unexpected repo events: %+v", events)
+	}
+}
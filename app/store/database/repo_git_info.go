@@ -41,7 +41,7 @@ type RepoGitInfoView struct {
 
 func (s *RepoGitInfoView) Find(ctx context.Context, id int64) (*types.RepositoryGitInfo, error) {
 	const sqlQuery = `
-		SELECT repo_git_uid, repo_parent_id
+		SELECT repo_git_uid, repo_parent_id, repo_default_branch
 		FROM repositories
 		WHERE repo_id = $1`
 
@@ -54,9 +54,42 @@ func (s *RepoGitInfoView) Find(ctx context.Context, id int64) (*types.Repository
 
 	var result = types.RepositoryGitInfo{ID: id}
 
-	if err := v.Scan(&result.GitUID, &result.ParentID); err != nil {
+	if err := v.Scan(&result.GitUID, &result.ParentID, &result.DefaultBranch); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "failed to scan git uid")
 	}
 
 	return &result, nil
 }
+
+// ListAll returns the git info of all repositories, including soft deleted ones.
+func (s *RepoGitInfoView) ListAll(ctx context.Context) ([]*types.RepositoryGitInfo, error) {
+	const sqlQuery = `
+		SELECT repo_id, repo_parent_id, repo_git_uid, repo_default_branch
+		FROM repositories
+		ORDER BY repo_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sqlQuery)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to list repository git infos")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []*types.RepositoryGitInfo
+	for rows.Next() {
+		info := &types.RepositoryGitInfo{}
+		if err = rows.Scan(&info.ID, &info.ParentID, &info.GitUID, &info.DefaultBranch); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "failed to scan repository git info")
+		}
+		result = append(result, info)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to iterate repository git infos")
+	}
+
+	return result, nil
+}
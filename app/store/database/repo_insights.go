@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoInsightsStore = (*RepoInsightsStore)(nil)
+
+// NewRepoInsightsStore returns a new RepoInsightsStore.
+func NewRepoInsightsStore(db *sqlx.DB) *RepoInsightsStore {
+	return &RepoInsightsStore{
+		db: db,
+	}
+}
+
+// RepoInsightsStore implements store.RepoInsightsStore backed by a relational database.
+type RepoInsightsStore struct {
+	db *sqlx.DB
+}
+
+type repoInsights struct {
+	RepoID   int64  `db:"repo_insights_repo_id"`
+	SHA      string `db:"repo_insights_sha"`
+	Computed int64  `db:"repo_insights_computed"`
+	Data     string `db:"repo_insights_data"`
+}
+
+// repoInsightsData is the part of the insights that is stored as JSON.
+type repoInsightsData struct {
+	Weeks        []types.InsightsWeek        `json:"weeks"`
+	Contributors []types.InsightsContributor `json:"contributors"`
+}
+
+const (
+	repoInsightsColumns = `
+		 repo_insights_repo_id
+		,repo_insights_sha
+		,repo_insights_computed
+		,repo_insights_data`
+)
+
+// Find returns the cached insights of the repository.
+func (s *RepoInsightsStore) Find(ctx context.Context, repoID int64) (*types.RepoInsights, error) {
+	stmt := database.Builder.
+		Select(repoInsightsColumns).
+		From("repo_insights").
+		Where("repo_insights_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoInsights{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo insights")
+	}
+
+	return mapToRepoInsights(dst)
+}
+
+// Upsert creates or replaces the cached insights of the repository.
+func (s *RepoInsightsStore) Upsert(ctx context.Context, insights *types.RepoInsights) error {
+	const sqlQueryInsert = `
+	INSERT INTO repo_insights (
+		 repo_insights_repo_id
+		,repo_insights_sha
+		,repo_insights_computed
+		,repo_insights_data
+	) VALUES (
+		 :repo_insights_repo_id
+		,:repo_insights_sha
+		,:repo_insights_computed
+		,:repo_insights_data
+	)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (repo_insights_repo_id) DO
+	UPDATE SET
+		 repo_insights_sha = :repo_insights_sha
+		,repo_insights_computed = :repo_insights_computed
+		,repo_insights_data = :repo_insights_data`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 repo_insights_sha = :repo_insights_sha
+		,repo_insights_computed = :repo_insights_computed
+		,repo_insights_data = :repo_insights_data`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	dbInsights, err := mapToInternalRepoInsights(insights)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbInsights)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo insights object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalRepoInsights(insights *types.RepoInsights) (*repoInsights, error) {
+	data, err := json.Marshal(repoInsightsData{
+		Weeks:        insights.Weeks,
+		Contributors: insights.Contributors,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal repo insights: %w", err)
+	}
+
+	return &repoInsights{
+		RepoID:   insights.RepoID,
+		SHA:      insights.SHA,
+		Computed: insights.Computed,
+		Data:     string(data),
+	}, nil
+}
+
+func mapToRepoInsights(insights *repoInsights) (*types.RepoInsights, error) {
+	var data repoInsightsData
+	if err := json.Unmarshal([]byte(insights.Data), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo insights: %w", err)
+	}
+
+	return &types.RepoInsights{
+		RepoID:       insights.RepoID,
+		SHA:          insights.SHA,
+		Computed:     insights.Computed,
+		Weeks:        data.Weeks,
+		Contributors: data.Contributors,
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestRepoInsightsStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	insightsStore := database.NewRepoInsightsStore(db)
+
+	for _, sha := range []string{"aaa", "bbb"} {
+		err := insightsStore.Upsert(ctx, &types.RepoInsights{
+			RepoID:   1,
+			SHA:      sha,
+			Computed: 1,
+			Weeks:    []types.InsightsWeek{{Week: 1, Commits: 2, Additions: 3, Deletions: 4}},
+			Contributors: []types.InsightsContributor{
+				{Name: "dev", Email: "dev@example.com", Commits: 2},
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to upsert insights: %v", err)
+		}
+	}
+
+	insights, err := insightsStore.Find(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find insights: %v", err)
+	}
+
+	if insights.SHA != "bbb" || len(insights.Weeks) != 1 || insights.Weeks[0].Additions != 3 ||
+		len(insights.Contributors) != 1 || insights.Contributors[0].Email != "dev@example.com" {
+		t.Errorf("unexpected insights: %+v", insights)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoLanguagesStore = (*RepoLanguagesStore)(nil)
+
+// NewRepoLanguagesStore returns a new RepoLanguagesStore.
+func NewRepoLanguagesStore(db *sqlx.DB) *RepoLanguagesStore {
+	return &RepoLanguagesStore{
+		db: db,
+	}
+}
+
+// RepoLanguagesStore implements store.RepoLanguagesStore backed by a relational database.
+type RepoLanguagesStore struct {
+	db *sqlx.DB
+}
+
+type repoLanguages struct {
+	RepoID   int64  `db:"repo_languages_repo_id"`
+	SHA      string `db:"repo_languages_sha"`
+	Computed int64  `db:"repo_languages_computed"`
+	Data     string `db:"repo_languages_data"`
+}
+
+const (
+	repoLanguagesColumns = `
+		 repo_languages_repo_id
+		,repo_languages_sha
+		,repo_languages_computed
+		,repo_languages_data`
+)
+
+// Find returns the language distribution of the repository.
+func (s *RepoLanguagesStore) Find(ctx context.Context, repoID int64) (*types.RepoLanguages, error) {
+	stmt := database.Builder.
+		Select(repoLanguagesColumns).
+		From("repo_languages").
+		Where("repo_languages_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoLanguages{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo languages")
+	}
+
+	return mapToRepoLanguages(dst)
+}
+
+// Upsert creates or replaces the language distribution of the repository.
+func (s *RepoLanguagesStore) Upsert(ctx context.Context, languages *types.RepoLanguages) error {
+	const sqlQueryInsert = `
+	INSERT INTO repo_languages (
+		 repo_languages_repo_id
+		,repo_languages_sha
+		,repo_languages_computed
+		,repo_languages_data
+	) VALUES (
+		 :repo_languages_repo_id
+		,:repo_languages_sha
+		,:repo_languages_computed
+		,:repo_languages_data
+	)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (repo_languages_repo_id) DO
+	UPDATE SET
+		 repo_languages_sha = :repo_languages_sha
+		,repo_languages_computed = :repo_languages_computed
+		,repo_languages_data = :repo_languages_data`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 repo_languages_sha = :repo_languages_sha
+		,repo_languages_computed = :repo_languages_computed
+		,repo_languages_data = :repo_languages_data`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	dbLanguages, err := mapToInternalRepoLanguages(languages)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbLanguages)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo languages object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalRepoLanguages(languages *types.RepoLanguages) (*repoLanguages, error) {
+	data, err := json.Marshal(languages.Languages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal repo languages: %w", err)
+	}
+
+	return &repoLanguages{
+		RepoID:   languages.RepoID,
+		SHA:      languages.SHA,
+		Computed: languages.Computed,
+		Data:     string(data),
+	}, nil
+}
+
+func mapToRepoLanguages(languages *repoLanguages) (*types.RepoLanguages, error) {
+	var data []types.LanguageStat
+	if err := json.Unmarshal([]byte(languages.Data), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo languages: %w", err)
+	}
+
+	return &types.RepoLanguages{
+		RepoID:    languages.RepoID,
+		SHA:       languages.SHA,
+		Computed:  languages.Computed,
+		Languages: data,
+	}, nil
+}
@@ -16,12 +16,15 @@ package database_test
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
@@ -177,6 +180,235 @@ func TestDatabase_ListAll(t *testing.T) {
 	}
 }
 
+func TestDatabase_ListCursor(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepos(ctx, t, repoStore, 1, numTestRepos, 1)
+
+	for _, order := range []enum.Order{enum.OrderAsc, enum.OrderDesc} {
+		all, err := repoStore.List(ctx, 1,
+			&types.RepoFilter{Size: numTestRepos, Sort: enum.RepoAttrIdentifier, Order: order})
+		if err != nil {
+			t.Fatalf("failed to list repos %v", err)
+		}
+
+		var paged []*types.Repository
+		filter := &types.RepoFilter{Size: 3, Sort: enum.RepoAttrIdentifier, Order: order}
+		for {
+			repos, err := repoStore.List(ctx, 1, filter)
+			if err != nil {
+				t.Fatalf("failed to list repos %v", err)
+			}
+			if len(repos) == 0 {
+				break
+			}
+			paged = append(paged, repos...)
+			filter.CursorID = repos[len(repos)-1].ID
+		}
+
+		if len(paged) != len(all) {
+			t.Fatalf("%s: count = %v, want %v", order, len(paged), len(all))
+		}
+		for i := range all {
+			if paged[i].ID != all[i].ID {
+				t.Errorf("%s: repo[%d] = %v, want %v", order, i, paged[i].ID, all[i].ID)
+			}
+		}
+	}
+}
+
+func TestDatabase_ListCursorOtherSpace(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 2, 0)
+
+	// the cursor points to a repo of space 2, it can't be used to list the repos of space 1.
+	_, err := repoStore.List(ctx, 1, &types.RepoFilter{Size: 10, CursorID: 2})
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+
+	repos, err := repoStore.List(ctx, 2, &types.RepoFilter{Size: 10, CursorID: 2})
+	if err != nil {
+		t.Fatalf("failed to list repos %v", err)
+	}
+	if len(repos) != 0 {
+		t.Errorf("count = %v, want 0", len(repos))
+	}
+}
+
+func TestDatabase_ListForks(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	for id := int64(2); id <= 4; id++ {
+		fork := types.Repository{ID: id, Identifier: "fork_" + strconv.FormatInt(id, 10), ParentID: 1,
+			GitUID: "fork_" + strconv.FormatInt(id, 10), ForkID: 1}
+		if err := repoStore.Create(ctx, &fork); err != nil {
+			t.Fatalf("failed to create fork %v", err)
+		}
+	}
+
+	// deleted forks still borrow the objects of the forked repo.
+	deleted, err := repoStore.Find(ctx, 4)
+	if err != nil {
+		t.Fatalf("failed to find fork %v", err)
+	}
+	if err = repoStore.SoftDelete(ctx, deleted, 1); err != nil {
+		t.Fatalf("failed to delete fork %v", err)
+	}
+
+	forks, err := repoStore.ListForks(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list forks %v", err)
+	}
+	if len(forks) != 3 {
+		t.Fatalf("count = %v, want %v", len(forks), 3)
+	}
+	for i, fork := range forks {
+		if fork.ID != int64(i+2) {
+			t.Errorf("fork[%d] = %v, want %v", i, fork.ID, i+2)
+		}
+	}
+
+	forks, err = repoStore.ListForks(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to list forks %v", err)
+	}
+	if len(forks) != 0 {
+		t.Errorf("count = %v, want %v", len(forks), 0)
+	}
+}
+
+func TestDatabase_ListInactive(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pushStore := database.NewPushStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	// repos 1 and 2 are inactive, repo 3 was pushed to, repo 4 is archived and repo 5 is deleted.
+	for id := int64(1); id <= 5; id++ {
+		spaceID := int64(1)
+		if id == 2 {
+			spaceID = 2
+		}
+		createRepo(ctx, t, repoStore, id, spaceID, 0)
+	}
+
+	const since = int64(1000)
+
+	if err := pushStore.Create(ctx, &types.Push{RepoID: 3, PrincipalID: userID, Ref: "refs/heads/main",
+		OldSHA: types.NilSHA, NewSHA: types.NilSHA, Created: since}); err != nil {
+		t.Fatalf("failed to create push %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "UPDATE repositories SET repo_archived = TRUE WHERE repo_id = 4"); err != nil {
+		t.Fatalf("failed to archive repo %v", err)
+	}
+
+	deleted, err := repoStore.Find(ctx, 5)
+	if err != nil {
+		t.Fatalf("failed to find repo %v", err)
+	}
+	if err = repoStore.SoftDelete(ctx, deleted, 1); err != nil {
+		t.Fatalf("failed to delete repo %v", err)
+	}
+
+	repos, err := repoStore.ListInactive(ctx, 1, since)
+	if err != nil {
+		t.Fatalf("failed to list inactive repos %v", err)
+	}
+	if len(repos) != 2 || repos[0].ID != 1 || repos[1].ID != 2 {
+		t.Fatalf("inactive repos = %v, want [1 2]", repos)
+	}
+
+	repos, err = repoStore.ListInactive(ctx, 2, since)
+	if err != nil {
+		t.Fatalf("failed to list inactive repos %v", err)
+	}
+	if len(repos) != 1 || repos[0].ID != 2 {
+		t.Errorf("inactive repos of subspace = %v, want [2]", repos)
+	}
+}
+
+func TestDatabase_ListArchived(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepos(ctx, t, repoStore, 1, 3, 1)
+
+	repo, err := repoStore.Find(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to find repo %v", err)
+	}
+	if _, err = repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.Archived = true
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to archive repo %v", err)
+	}
+
+	for _, archived := range []bool{true, false} {
+		repos, err := repoStore.List(ctx, 1, &types.RepoFilter{Archived: &archived})
+		if err != nil {
+			t.Fatalf("failed to list repos %v", err)
+		}
+
+		for _, repo := range repos {
+			if repo.Archived != archived {
+				t.Errorf("repo %d archived = %v, want %v", repo.ID, repo.Archived, archived)
+			}
+		}
+
+		want := 2
+		if archived {
+			want = 1
+		}
+		if len(repos) != want {
+			t.Errorf("archived=%v: count = %v, want %v", archived, len(repos), want)
+		}
+	}
+}
+
 func createRepo(
 	ctx context.Context,
 	t *testing.T,
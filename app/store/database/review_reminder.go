@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ReviewReminderStore = (*ReviewReminderStore)(nil)
+
+// NewReviewReminderStore returns a new ReviewReminderStore.
+func NewReviewReminderStore(db *sqlx.DB) *ReviewReminderStore {
+	return &ReviewReminderStore{
+		db: db,
+	}
+}
+
+// ReviewReminderStore implements store.ReviewReminderStore backed by a relational database.
+type ReviewReminderStore struct {
+	db *sqlx.DB
+}
+
+type pendingReview struct {
+	PullReqID    int64 `db:"pullreq_id"`
+	RepoID       int64 `db:"pullreq_target_repo_id"`
+	PrincipalID  int64 `db:"pullreq_reviewer_principal_id"`
+	Requested    int64 `db:"pullreq_reviewer_updated"`
+	Reminded     int64 `db:"review_reminder_sent"`
+	SnoozedUntil int64 `db:"review_reminder_snoozed_until"`
+}
+
+// ListPending returns the pending reviews of open pull requests requested before the provided time.
+// Draft pull requests are excluded, as they aren't ready for review yet.
+func (s *ReviewReminderStore) ListPending(
+	ctx context.Context,
+	requestedBefore int64,
+) ([]*types.PendingReview, error) {
+	const sqlQuery = `
+	SELECT
+		 pullreq_id
+		,pullreq_target_repo_id
+		,pullreq_reviewer_principal_id
+		,pullreq_reviewer_updated
+		,COALESCE(review_reminder_sent, 0) AS review_reminder_sent
+		,COALESCE(review_reminder_snoozed_until, 0) AS review_reminder_snoozed_until
+	FROM pullreq_reviewers
+	INNER JOIN pullreqs ON pullreq_id = pullreq_reviewer_pullreq_id
+	LEFT JOIN pullreq_review_reminders ON
+		review_reminder_pullreq_id = pullreq_reviewer_pullreq_id AND
+		review_reminder_principal_id = pullreq_reviewer_principal_id
+	WHERE pullreq_state = $1 AND
+		pullreq_is_draft = FALSE AND
+		pullreq_reviewer_review_decision = $2 AND
+		pullreq_reviewer_updated < $3
+	ORDER BY pullreq_id, pullreq_reviewer_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pendingReview{}
+	err := db.SelectContext(ctx, &dst, sqlQuery,
+		enum.PullReqStateOpen, enum.PullReqReviewDecisionPending, requestedBefore)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pending review list query")
+	}
+
+	reviews := make([]*types.PendingReview, len(dst))
+	for i, r := range dst {
+		reviews[i] = &types.PendingReview{
+			PullReqID:    r.PullReqID,
+			RepoID:       r.RepoID,
+			PrincipalID:  r.PrincipalID,
+			Requested:    r.Requested,
+			Reminded:     r.Reminded,
+			SnoozedUntil: r.SnoozedUntil,
+		}
+	}
+
+	return reviews, nil
+}
+
+// MarkReminded records the time the reviewer was reminded of the review.
+func (s *ReviewReminderStore) MarkReminded(ctx context.Context, prID, principalID, reminded int64) error {
+	return s.upsert(ctx, "review_reminder_sent", prID, principalID, reminded)
+}
+
+// Snooze suppresses the reminders of the review for the reviewer until the provided time.
+func (s *ReviewReminderStore) Snooze(ctx context.Context, prID, principalID, until int64) error {
+	return s.upsert(ctx, "review_reminder_snoozed_until", prID, principalID, until)
+}
+
+// upsert sets the value of the column of the reminder, the reminder is created in case it doesn't exist.
+func (s *ReviewReminderStore) upsert(ctx context.Context, column string, prID, principalID, value int64) error {
+	sqlQuery := `
+	INSERT INTO pullreq_review_reminders (
+		 review_reminder_pullreq_id
+		,review_reminder_principal_id
+		,` + column + `
+	) VALUES ($1, $2, $3)`
+
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery += `
+	ON DUPLICATE KEY UPDATE ` + column + ` = VALUES(` + column + `)`
+	} else {
+		sqlQuery += `
+	ON CONFLICT (review_reminder_pullreq_id, review_reminder_principal_id) DO
+	UPDATE SET ` + column + ` = EXCLUDED.` + column
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, prID, principalID, value); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_ReviewReminder(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	reviewerStore := database.NewPullReqReviewerStore(db, pCache)
+	reminderStore := database.NewReviewReminderStore(db)
+
+	prs := make([]*types.PullReq, 3)
+	for i := range prs {
+		prs[i] = &types.PullReq{
+			Number:           int64(i + 1),
+			CreatedBy:        userID,
+			State:            enum.PullReqStateOpen,
+			IsDraft:          i == 2,
+			SourceRepoID:     1,
+			SourceBranch:     fmt.Sprintf("feature-%d", i),
+			TargetRepoID:     1,
+			TargetBranch:     "main",
+			MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		}
+		if err := pullreqStore.Create(ctx, prs[i]); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+
+		err := reviewerStore.Create(ctx, &types.PullReqReviewer{
+			PullReqID:      prs[i].ID,
+			PrincipalID:    userID,
+			CreatedBy:      userID,
+			Created:        int64(100 * (i + 1)),
+			Updated:        int64(100 * (i + 1)),
+			RepoID:         1,
+			Type:           enum.PullReqReviewerTypeRequested,
+			ReviewDecision: enum.PullReqReviewDecisionPending,
+		})
+		if err != nil {
+			t.Fatalf("failed to create reviewer: %v", err)
+		}
+	}
+
+	pending, err := reminderStore.ListPending(ctx, 250)
+	if err != nil {
+		t.Fatalf("failed to list pending reviews: %v", err)
+	}
+	if len(pending) != 2 || pending[0].PullReqID != prs[0].ID || pending[0].Reminded != 0 {
+		t.Fatalf("unexpected pending reviews: %+v", pending)
+	}
+
+	// draft pull requests aren't ready for review.
+	pending, err = reminderStore.ListPending(ctx, 1000)
+	if err != nil {
+		t.Fatalf("failed to list pending reviews: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending reviews, got %d", len(pending))
+	}
+
+	if err = reminderStore.MarkReminded(ctx, prs[0].ID, userID, 500); err != nil {
+		t.Fatalf("failed to mark review as reminded: %v", err)
+	}
+	if err = reminderStore.Snooze(ctx, prs[0].ID, userID, 900); err != nil {
+		t.Fatalf("failed to snooze review: %v", err)
+	}
+	if err = reminderStore.MarkReminded(ctx, prs[0].ID, userID, 600); err != nil {
+		t.Fatalf("failed to mark review as reminded: %v", err)
+	}
+
+	pending, err = reminderStore.ListPending(ctx, 150)
+	if err != nil {
+		t.Fatalf("failed to list pending reviews: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Reminded != 600 || pending[0].SnoozedUntil != 900 {
+		t.Errorf("unexpected pending review: %+v", pending)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+func rowCacheKeyPrincipal(id int64) string {
+	return "principal:" + strconv.FormatInt(id, 10)
+}
+
+func rowCacheKeyRepo(id int64) string {
+	return "repo:" + strconv.FormatInt(id, 10)
+}
+
+func rowCacheKeyRepoPath(path string) string {
+	return "repo-path:" + strings.ToLower(path)
+}
+
+func rowCacheKeyToken(id int64) string {
+	return "token:" + strconv.FormatInt(id, 10)
+}
+
+// rowCacheGet reads the value from the row cache.
+// The cache is bypassed inside of transactions to never serve rows that differ from the transaction's view.
+func rowCacheGet(ctx context.Context, rowCache store.RowCache, key string, dst any) bool {
+	if dbtx.GetTransaction(ctx) != nil {
+		return false
+	}
+
+	return rowCache.Get(ctx, key, dst)
+}
+
+// rowCacheSet stores the value in the row cache.
+// Rows read inside of transactions aren't cached as the transaction might be rolled back.
+func rowCacheSet(ctx context.Context, rowCache store.RowCache, key string, value any) {
+	if dbtx.GetTransaction(ctx) != nil {
+		return
+	}
+
+	rowCache.Set(ctx, key, value)
+}
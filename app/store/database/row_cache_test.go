@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"sync"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+)
+
+// memoryRowCache is an in-memory store.RowCache used to verify the cache usage of the stores.
+type memoryRowCache struct {
+	mx      sync.Mutex
+	entries map[string][]byte
+}
+
+func (c *memoryRowCache) Get(_ context.Context, key string, dst any) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	raw, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+
+	return gob.NewDecoder(bytes.NewReader(raw)).Decode(dst) == nil
+}
+
+func (c *memoryRowCache) Set(_ context.Context, key string, value any) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(value); err != nil {
+		return
+	}
+
+	c.entries[key] = buff.Bytes()
+}
+
+func (c *memoryRowCache) Evict(_ context.Context, keys ...string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+func TestDatabase_RepoRowCache(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	rowCache := &memoryRowCache{entries: map[string][]byte{}}
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, rowCache)
+
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	// lookups inside of a transaction must not populate the cache.
+	err := dbtx.New(db).WithTx(ctx, func(ctx context.Context) error {
+		_, err := repoStore.FindByRef(ctx, "space_1/repo_1")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to find repo in transaction: %v", err)
+	}
+	if len(rowCache.entries) != 0 {
+		t.Fatalf("expected empty cache after transaction, got %d entries", len(rowCache.entries))
+	}
+
+	repo, err := repoStore.FindByRef(ctx, "SPACE_1/repo_1")
+	if err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+
+	// change the row behind the back of the store - the cached repo is expected to be returned.
+	if _, err = db.ExecContext(ctx, "UPDATE repositories SET repo_description = 'changed'"); err != nil {
+		t.Fatalf("failed to update repo: %v", err)
+	}
+
+	cached, err := repoStore.FindByRef(ctx, "space_1/repo_1")
+	if err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+	if cached.Description != "" {
+		t.Errorf("expected cached repo, got description %q", cached.Description)
+	}
+
+	// updates through the store evict the cached repo.
+	_, err = repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.Description = "updated"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update repo: %v", err)
+	}
+
+	repo, err = repoStore.FindByRef(ctx, "space_1/repo_1")
+	if err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+	if repo.Description != "updated" {
+		t.Errorf("repo.Description = %q, want %q", repo.Description, "updated")
+	}
+}
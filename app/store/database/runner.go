@@ -0,0 +1,356 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+type runner struct {
+	ID            int64  `db:"runner_id"`
+	SpaceID       int64  `db:"runner_space_id"`
+	Identifier    string `db:"runner_uid"`
+	TokenHash     string `db:"runner_token_hash"`
+	OS            string `db:"runner_os"`
+	Arch          string `db:"runner_arch"`
+	Kernel        string `db:"runner_kernel"`
+	Variant       string `db:"runner_variant"`
+	Labels        string `db:"runner_labels"`
+	Version       string `db:"runner_version"`
+	Capacity      int    `db:"runner_capacity"`
+	Running       int    `db:"runner_running"`
+	LastHeartbeat int64  `db:"runner_last_heartbeat"`
+	Created       int64  `db:"runner_created"`
+	Updated       int64  `db:"runner_updated"`
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_space_id
+		,runner_uid
+		,runner_token_hash
+		,runner_os
+		,runner_arch
+		,runner_kernel
+		,runner_variant
+		,runner_labels
+		,runner_version
+		,runner_capacity
+		,runner_running
+		,runner_last_heartbeat
+		,runner_created
+		,runner_updated`
+
+	runnerSelectBase = `
+	SELECT` + runnerColumns + `
+	FROM runners`
+)
+
+// Find finds the runner by id.
+func (s *RunnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner")
+	}
+
+	return mapToRunner(dst)
+}
+
+// FindByTokenHash finds the runner by the hash of its token.
+func (s *RunnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE runner_token_hash = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, tokenHash); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner by token")
+	}
+
+	return mapToRunner(dst)
+}
+
+// Create creates a new runner.
+func (s *RunnerStore) Create(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+	INSERT INTO runners (
+		 runner_space_id
+		,runner_uid
+		,runner_token_hash
+		,runner_os
+		,runner_arch
+		,runner_kernel
+		,runner_variant
+		,runner_labels
+		,runner_version
+		,runner_capacity
+		,runner_running
+		,runner_last_heartbeat
+		,runner_created
+		,runner_updated
+	) values (
+		 :runner_space_id
+		,:runner_uid
+		,:runner_token_hash
+		,:runner_os
+		,:runner_arch
+		,:runner_kernel
+		,:runner_variant
+		,:runner_labels
+		,:runner_version
+		,:runner_capacity
+		,:runner_running
+		,:runner_last_heartbeat
+		,:runner_created
+		,:runner_updated
+	) RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRunner, err := mapToInternalRunner(r)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRunner)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateHeartbeat stores the heartbeat and the reported capacity of the runner.
+func (s *RunnerStore) UpdateHeartbeat(
+	ctx context.Context,
+	id int64,
+	capacity, running int,
+	heartbeat int64,
+) error {
+	const sqlQuery = `
+	UPDATE runners
+	SET
+		 runner_capacity = $1
+		,runner_running = $2
+		,runner_last_heartbeat = $3
+		,runner_updated = $3
+	WHERE runner_id = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, capacity, running, heartbeat, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner heartbeat")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the runner of the space with the provided identifier.
+func (s *RunnerStore) Delete(ctx context.Context, spaceID int64, identifier string) error {
+	const sqlQuery = `
+	DELETE FROM runners
+	WHERE runner_space_id = $1 AND LOWER(runner_uid) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, spaceID, identifier)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List lists the runners of the space.
+func (s *RunnerStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Runner, error) {
+	stmt := database.Builder.
+		Select(runnerColumns).
+		From("runners").
+		Where("runner_space_id = ?", spaceID).
+		OrderBy("runner_uid ASC")
+
+	stmt = applyRunnerFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert runner list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*runner{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing runner list query")
+	}
+
+	runners := make([]*types.Runner, len(dst))
+	for i := range dst {
+		if runners[i], err = mapToRunner(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return runners, nil
+}
+
+// Count returns the number of runners of the space.
+func (s *RunnerStore) Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("runners").
+		Where("runner_space_id = ?", spaceID)
+
+	stmt = applyRunnerFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert runner count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing runner count query")
+	}
+
+	return count, nil
+}
+
+func applyRunnerFilter(stmt squirrel.SelectBuilder, filter types.ListQueryFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(runner_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func mapToRunner(in *runner) (*types.Runner, error) {
+	var labels map[string]string
+	if in.Labels != "" {
+		if err := json.Unmarshal([]byte(in.Labels), &labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal labels of runner %d: %w", in.ID, err)
+		}
+	}
+
+	return &types.Runner{
+		ID:            in.ID,
+		SpaceID:       in.SpaceID,
+		Identifier:    in.Identifier,
+		TokenHash:     in.TokenHash,
+		OS:            in.OS,
+		Arch:          in.Arch,
+		Kernel:        in.Kernel,
+		Variant:       in.Variant,
+		Labels:        labels,
+		Version:       in.Version,
+		Capacity:      in.Capacity,
+		Running:       in.Running,
+		LastHeartbeat: in.LastHeartbeat,
+		Created:       in.Created,
+		Updated:       in.Updated,
+	}, nil
+}
+
+func mapToInternalRunner(in *types.Runner) (*runner, error) {
+	labels := ""
+	if len(in.Labels) > 0 {
+		raw, err := json.Marshal(in.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal runner labels: %w", err)
+		}
+		labels = string(raw)
+	}
+
+	return &runner{
+		ID:            in.ID,
+		SpaceID:       in.SpaceID,
+		Identifier:    in.Identifier,
+		TokenHash:     in.TokenHash,
+		OS:            in.OS,
+		Arch:          in.Arch,
+		Kernel:        in.Kernel,
+		Variant:       in.Variant,
+		Labels:        labels,
+		Version:       in.Version,
+		Capacity:      in.Capacity,
+		Running:       in.Running,
+		LastHeartbeat: in.LastHeartbeat,
+		Created:       in.Created,
+		Updated:       in.Updated,
+	}, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RunnerRegistrationTokenStore = (*RunnerRegistrationTokenStore)(nil)
+
+// NewRunnerRegistrationTokenStore returns a new RunnerRegistrationTokenStore.
+func NewRunnerRegistrationTokenStore(db *sqlx.DB) *RunnerRegistrationTokenStore {
+	return &RunnerRegistrationTokenStore{
+		db: db,
+	}
+}
+
+// RunnerRegistrationTokenStore implements store.RunnerRegistrationTokenStore backed by a relational database.
+type RunnerRegistrationTokenStore struct {
+	db *sqlx.DB
+}
+
+type runnerRegistrationToken struct {
+	ID          int64  `db:"registration_token_id"`
+	SpaceID     int64  `db:"registration_token_space_id"`
+	Identifier  string `db:"registration_token_uid"`
+	Description string `db:"registration_token_description"`
+	Hash        string `db:"registration_token_hash"`
+	CreatedBy   int64  `db:"registration_token_created_by"`
+	Created     int64  `db:"registration_token_created"`
+	Expires     int64  `db:"registration_token_expires"`
+}
+
+const (
+	runnerRegistrationTokenColumns = `
+		 registration_token_id
+		,registration_token_space_id
+		,registration_token_uid
+		,registration_token_description
+		,registration_token_hash
+		,registration_token_created_by
+		,registration_token_created
+		,registration_token_expires`
+
+	runnerRegistrationTokenSelectBase = `
+	SELECT` + runnerRegistrationTokenColumns + `
+	FROM runner_registration_tokens`
+)
+
+// FindByHash finds the registration token by its hash.
+func (s *RunnerRegistrationTokenStore) FindByHash(
+	ctx context.Context,
+	hash string,
+) (*types.RunnerRegistrationToken, error) {
+	const sqlQuery = runnerRegistrationTokenSelectBase + `
+	WHERE registration_token_hash = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runnerRegistrationToken{}
+	if err := db.GetContext(ctx, dst, sqlQuery, hash); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner registration token")
+	}
+
+	return mapToRunnerRegistrationToken(dst), nil
+}
+
+// Create creates a new registration token.
+func (s *RunnerRegistrationTokenStore) Create(ctx context.Context, token *types.RunnerRegistrationToken) error {
+	const sqlQuery = `
+	INSERT INTO runner_registration_tokens (
+		 registration_token_space_id
+		,registration_token_uid
+		,registration_token_description
+		,registration_token_hash
+		,registration_token_created_by
+		,registration_token_created
+		,registration_token_expires
+	) values (
+		 :registration_token_space_id
+		,:registration_token_uid
+		,:registration_token_description
+		,:registration_token_hash
+		,:registration_token_created_by
+		,:registration_token_created
+		,:registration_token_expires
+	) RETURNING registration_token_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRunnerRegistrationToken(token))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner registration token object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&token.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the registration token of the space with the provided identifier.
+func (s *RunnerRegistrationTokenStore) Delete(ctx context.Context, spaceID int64, identifier string) error {
+	const sqlQuery = `
+	DELETE FROM runner_registration_tokens
+	WHERE registration_token_space_id = $1 AND LOWER(registration_token_uid) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID, identifier); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the registration tokens of the space.
+func (s *RunnerRegistrationTokenStore) List(
+	ctx context.Context,
+	spaceID int64,
+) ([]*types.RunnerRegistrationToken, error) {
+	const sqlQuery = runnerRegistrationTokenSelectBase + `
+	WHERE registration_token_space_id = $1
+	ORDER BY registration_token_uid ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*runnerRegistrationToken{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing runner registration token list query")
+	}
+
+	tokens := make([]*types.RunnerRegistrationToken, len(dst))
+	for i := range dst {
+		tokens[i] = mapToRunnerRegistrationToken(dst[i])
+	}
+
+	return tokens, nil
+}
+
+func mapToRunnerRegistrationToken(in *runnerRegistrationToken) *types.RunnerRegistrationToken {
+	return &types.RunnerRegistrationToken{
+		ID:          in.ID,
+		SpaceID:     in.SpaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Hash:        in.Hash,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Expires:     in.Expires,
+	}
+}
+
+func mapToInternalRunnerRegistrationToken(in *types.RunnerRegistrationToken) *runnerRegistrationToken {
+	return &runnerRegistrationToken{
+		ID:          in.ID,
+		SpaceID:     in.SpaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Hash:        in.Hash,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Expires:     in.Expires,
+	}
+}
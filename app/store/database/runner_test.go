@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_Runners(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	runnerStore := database.NewRunnerStore(db)
+	regTokenStore := database.NewRunnerRegistrationTokenStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	regToken := &types.RunnerRegistrationToken{
+		SpaceID:    1,
+		Identifier: "ci",
+		Hash:       "registration-hash",
+		CreatedBy:  userID,
+	}
+	if err := regTokenStore.Create(ctx, regToken); err != nil {
+		t.Fatalf("failed to create registration token: %v", err)
+	}
+
+	found, err := regTokenStore.FindByHash(ctx, "registration-hash")
+	if err != nil {
+		t.Fatalf("failed to find registration token: %v", err)
+	}
+	if found.ID != regToken.ID || found.SpaceID != 1 {
+		t.Errorf("unexpected registration token %+v", found)
+	}
+
+	runner := &types.Runner{
+		SpaceID:    1,
+		Identifier: "linux-1",
+		TokenHash:  "access-hash",
+		OS:         "linux",
+		Arch:       "amd64",
+		Labels:     map[string]string{"gpu": "true"},
+		Capacity:   2,
+	}
+	if err = runnerStore.Create(ctx, runner); err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	duplicate := *runner
+	duplicate.Identifier = "LINUX-1"
+	duplicate.TokenHash = "other-hash"
+	if err = runnerStore.Create(ctx, &duplicate); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("expected duplicate error for runner identifier, got %v", err)
+	}
+
+	if err = runnerStore.UpdateHeartbeat(ctx, runner.ID, 4, 1, 1000); err != nil {
+		t.Fatalf("failed to update heartbeat: %v", err)
+	}
+
+	found2, err := runnerStore.FindByTokenHash(ctx, "access-hash")
+	if err != nil {
+		t.Fatalf("failed to find runner by token: %v", err)
+	}
+	if found2.Capacity != 4 || found2.Running != 1 || found2.LastHeartbeat != 1000 {
+		t.Errorf("heartbeat not stored: %+v", found2)
+	}
+	if found2.Labels["gpu"] != "true" {
+		t.Errorf("labels not stored: %v", found2.Labels)
+	}
+
+	count, err := runnerStore.Count(ctx, 1, types.ListQueryFilter{Query: "linux"})
+	if err != nil {
+		t.Fatalf("failed to count runners: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 runner, got %d", count)
+	}
+
+	if err = runnerStore.Delete(ctx, 1, "linux-1"); err != nil {
+		t.Fatalf("failed to delete runner: %v", err)
+	}
+	if _, err = runnerStore.Find(ctx, runner.ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected runner to be deleted, got err %v", err)
+	}
+	if err = runnerStore.Delete(ctx, 1, "linux-1"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error on second delete, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SavedReplyStore = (*SavedReplyStore)(nil)
+
+// NewSavedReplyStore returns a new SavedReplyStore.
+func NewSavedReplyStore(db *sqlx.DB) *SavedReplyStore {
+	return &SavedReplyStore{
+		db: db,
+	}
+}
+
+// SavedReplyStore implements store.SavedReplyStore backed by a relational database.
+type SavedReplyStore struct {
+	db *sqlx.DB
+}
+
+type savedReply struct {
+	ID          int64  `db:"saved_reply_id"`
+	PrincipalID int64  `db:"saved_reply_principal_id"`
+	Title       string `db:"saved_reply_title"`
+	Text        string `db:"saved_reply_text"`
+	Created     int64  `db:"saved_reply_created"`
+	Updated     int64  `db:"saved_reply_updated"`
+}
+
+const (
+	savedReplyColumns = `
+		 saved_reply_id
+		,saved_reply_principal_id
+		,saved_reply_title
+		,saved_reply_text
+		,saved_reply_created
+		,saved_reply_updated`
+)
+
+// Find finds the saved reply by id.
+func (s *SavedReplyStore) Find(ctx context.Context, id int64) (*types.SavedReply, error) {
+	const sqlQuery = `
+	SELECT` + savedReplyColumns + `
+	FROM saved_replies
+	WHERE saved_reply_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &savedReply{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find saved reply")
+	}
+
+	return mapToSavedReply(dst), nil
+}
+
+// Create creates a new saved reply.
+func (s *SavedReplyStore) Create(ctx context.Context, reply *types.SavedReply) error {
+	const sqlQuery = `
+	INSERT INTO saved_replies (
+		 saved_reply_principal_id
+		,saved_reply_title
+		,saved_reply_text
+		,saved_reply_created
+		,saved_reply_updated
+	) values (
+		 :saved_reply_principal_id
+		,:saved_reply_title
+		,:saved_reply_text
+		,:saved_reply_created
+		,:saved_reply_updated
+	) RETURNING saved_reply_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSavedReply(reply))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind saved reply object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&reply.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing saved reply.
+func (s *SavedReplyStore) Update(ctx context.Context, reply *types.SavedReply) error {
+	const sqlQuery = `
+	UPDATE saved_replies
+	SET
+		 saved_reply_title = :saved_reply_title
+		,saved_reply_text = :saved_reply_text
+		,saved_reply_updated = :saved_reply_updated
+	WHERE saved_reply_id = :saved_reply_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSavedReply(reply))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind saved reply object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update saved reply")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the saved reply.
+func (s *SavedReplyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM saved_replies
+	WHERE saved_reply_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of saved replies of the principal.
+func (s *SavedReplyStore) Count(ctx context.Context, principalID int64) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM saved_replies
+	WHERE saved_reply_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, principalID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing saved reply count query")
+	}
+
+	return count, nil
+}
+
+// List returns the saved replies of the principal, ordered by title.
+func (s *SavedReplyStore) List(ctx context.Context, principalID int64) ([]*types.SavedReply, error) {
+	stmt := database.Builder.
+		Select(savedReplyColumns).
+		From("saved_replies").
+		Where("saved_reply_principal_id = ?", principalID).
+		OrderBy("saved_reply_title ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert saved reply list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*savedReply{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing saved reply list query")
+	}
+
+	replies := make([]*types.SavedReply, len(dst))
+	for i := range dst {
+		replies[i] = mapToSavedReply(dst[i])
+	}
+
+	return replies, nil
+}
+
+func mapToSavedReply(in *savedReply) *types.SavedReply {
+	return &types.SavedReply{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Title:       in.Title,
+		Text:        in.Text,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapToInternalSavedReply(in *types.SavedReply) *savedReply {
+	return &savedReply{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Title:       in.Title,
+		Text:        in.Text,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestSavedReplyStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	savedReplyStore := database.NewSavedReplyStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	for _, title := range []string{"nit", "lgtm"} {
+		reply := &types.SavedReply{PrincipalID: userID, Title: title, Text: title + " text"}
+		if err := savedReplyStore.Create(ctx, reply); err != nil {
+			t.Fatalf("failed to create saved reply: %v", err)
+		}
+	}
+
+	err := savedReplyStore.Create(ctx, &types.SavedReply{PrincipalID: userID, Title: "nit", Text: "again"})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got: %v", err)
+	}
+
+	replies, err := savedReplyStore.List(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to list saved replies: %v", err)
+	}
+
+	if len(replies) != 2 || replies[0].Title != "lgtm" || replies[1].Title != "nit" {
+		t.Fatalf("unexpected saved replies: %+v", replies)
+	}
+
+	replies[0].Text = "looks good"
+	if err = savedReplyStore.Update(ctx, replies[0]); err != nil {
+		t.Fatalf("failed to update saved reply: %v", err)
+	}
+
+	reply, err := savedReplyStore.Find(ctx, replies[0].ID)
+	if err != nil {
+		t.Fatalf("failed to find saved reply: %v", err)
+	}
+
+	if reply.Text != "looks good" {
+		t.Errorf("unexpected saved reply text: %q", reply.Text)
+	}
+
+	if err = savedReplyStore.Delete(ctx, replies[1].ID); err != nil {
+		t.Fatalf("failed to delete saved reply: %v", err)
+	}
+
+	count, err := savedReplyStore.Count(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to count saved replies: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 saved reply, got %d", count)
+	}
+}
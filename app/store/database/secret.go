@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 )
@@ -42,6 +43,7 @@ const (
 	secret_id,
 	secret_description,
 	secret_space_id,
+	secret_repo_id,
 	secret_created_by,
 	secret_uid,
 	secret_data,
@@ -78,7 +80,7 @@ func (s *secretStore) Find(ctx context.Context, id int64) (*types.Secret, error)
 // FindByIdentifier returns a secret in a given space with a given identifier.
 func (s *secretStore) FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Secret, error) {
 	const findQueryStmt = secretQueryBase + `
-		WHERE secret_space_id = $1 AND secret_uid = $2`
+		WHERE secret_space_id = $1 AND secret_uid = $2 AND secret_repo_id IS NULL`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	dst := new(types.Secret)
@@ -88,6 +90,23 @@ func (s *secretStore) FindByIdentifier(ctx context.Context, spaceID int64, ident
 	return dst, nil
 }
 
+// FindByRepoIdentifier returns a secret of a given repository with a given identifier.
+func (s *secretStore) FindByRepoIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.Secret, error) {
+	const findQueryStmt = secretQueryBase + `
+		WHERE secret_repo_id = $1 AND secret_uid = $2`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(types.Secret)
+	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find secret")
+	}
+	return dst, nil
+}
+
 // Create creates a secret.
 func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 	//nolint:gosec // wrong flagging
@@ -95,6 +114,7 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 	INSERT INTO secrets (
 		secret_description,
 		secret_space_id,
+		secret_repo_id,
 		secret_created_by,
 		secret_uid,
 		secret_data,
@@ -104,6 +124,7 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 	) VALUES (
 		:secret_description,
 		:secret_space_id,
+		:secret_repo_id,
 		:secret_created_by,
 		:secret_uid,
 		:secret_data,
@@ -197,39 +218,46 @@ func (s *secretStore) UpdateOptLock(ctx context.Context,
 
 // List lists all the secrets present in a space.
 func (s *secretStore) List(ctx context.Context, parentID int64, filter types.ListQueryFilter) ([]*types.Secret, error) {
-	stmt := database.Builder.
-		Select(secretColumns).
-		From("secrets").
-		Where("secret_space_id = ?", fmt.Sprint(parentID))
-
-	if filter.Query != "" {
-		stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
-	}
-
-	stmt = stmt.Limit(database.Limit(filter.Size))
-	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
-
-	sql, args, err := stmt.ToSql()
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to convert query to sql")
-	}
+	return s.list(ctx, squirrel.Eq{"secret_space_id": parentID, "secret_repo_id": nil}, &filter)
+}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+// ListAll lists all the secrets present in a space.
+func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error) {
+	return s.list(ctx, squirrel.Eq{"secret_space_id": parentID, "secret_repo_id": nil}, nil)
+}
 
-	dst := []*types.Secret{}
-	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
-		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
-	}
+// ListForRepo lists all the secrets of a repository.
+func (s *secretStore) ListForRepo(
+	ctx context.Context,
+	repoID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Secret, error) {
+	return s.list(ctx, squirrel.Eq{"secret_repo_id": repoID}, &filter)
+}
 
-	return dst, nil
+// ListAllForRepo lists all the secrets of a repository.
+func (s *secretStore) ListAllForRepo(ctx context.Context, repoID int64) ([]*types.Secret, error) {
+	return s.list(ctx, squirrel.Eq{"secret_repo_id": repoID}, nil)
 }
 
-// ListAll lists all the secrets present in a space.
-func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error) {
+func (s *secretStore) list(
+	ctx context.Context,
+	owner squirrel.Eq,
+	filter *types.ListQueryFilter,
+) ([]*types.Secret, error) {
 	stmt := database.Builder.
 		Select(secretColumns).
 		From("secrets").
-		Where("secret_space_id = ?", fmt.Sprint(parentID))
+		Where(owner)
+
+	if filter != nil {
+		if filter.Query != "" {
+			stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+		}
+
+		stmt = stmt.Limit(database.Limit(filter.Size))
+		stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	}
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -267,7 +295,7 @@ func (s *secretStore) DeleteByIdentifier(ctx context.Context, spaceID int64, ide
 	//nolint:gosec // wrong flagging
 	const secretDeleteStmt = `
 	DELETE FROM secrets
-	WHERE secret_space_id = $1 AND secret_uid = $2`
+	WHERE secret_space_id = $1 AND secret_uid = $2 AND secret_repo_id IS NULL`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -278,12 +306,37 @@ func (s *secretStore) DeleteByIdentifier(ctx context.Context, spaceID int64, ide
 	return nil
 }
 
+// DeleteByRepoIdentifier deletes a secret with a given identifier of a repository.
+func (s *secretStore) DeleteByRepoIdentifier(ctx context.Context, repoID int64, identifier string) error {
+	//nolint:gosec // wrong flagging
+	const secretDeleteStmt = `
+	DELETE FROM secrets
+	WHERE secret_repo_id = $1 AND secret_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, secretDeleteStmt, repoID, identifier); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Could not delete secret")
+	}
+
+	return nil
+}
+
 // Count of secrets in a space.
 func (s *secretStore) Count(ctx context.Context, parentID int64, filter types.ListQueryFilter) (int64, error) {
+	return s.count(ctx, squirrel.Eq{"secret_space_id": parentID, "secret_repo_id": nil}, filter)
+}
+
+// CountForRepo counts the secrets of a repository.
+func (s *secretStore) CountForRepo(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error) {
+	return s.count(ctx, squirrel.Eq{"secret_repo_id": repoID}, filter)
+}
+
+func (s *secretStore) count(ctx context.Context, owner squirrel.Eq, filter types.ListQueryFilter) (int64, error) {
 	stmt := database.Builder.
 		Select("count(*)").
 		From("secrets").
-		Where("secret_space_id = ?", parentID)
+		Where(owner)
 
 	if filter.Query != "" {
 		stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
@@ -303,3 +356,11 @@ func (s *secretStore) Count(ctx context.Context, parentID int64, filter types.Li
 	}
 	return count, nil
 }
+
+// ReencryptData re-encrypts the data of all secrets using the provided function.
+func (s *secretStore) ReencryptData(
+	ctx context.Context,
+	reencrypt func(ciphertext []byte) ([]byte, bool, error),
+) (int64, error) {
+	return database.Reencrypt(ctx, s.db, "secrets", "secret_id", "secret_data", reencrypt)
+}
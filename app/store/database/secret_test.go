@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_SecretsOfSpaceAndRepo(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	secretStore := database.NewSecretStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	repoID := int64(1)
+	for _, s := range []*types.Secret{
+		{Identifier: "space_token", SpaceID: 1},
+		{Identifier: "repo_token", SpaceID: 1, RepoID: &repoID},
+	} {
+		s.CreatedBy = userID
+		s.Data = "value"
+		if err := secretStore.Create(ctx, s); err != nil {
+			t.Fatalf("failed to create secret %q: %v", s.Identifier, err)
+		}
+	}
+
+	spaceSecrets, err := secretStore.ListAll(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list space secrets: %v", err)
+	}
+	if len(spaceSecrets) != 1 || spaceSecrets[0].Identifier != "space_token" {
+		t.Errorf("expected only the space secret, got %v", spaceSecrets)
+	}
+
+	count, err := secretStore.CountForRepo(ctx, repoID, types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("failed to count repo secrets: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 repo secret, got %d", count)
+	}
+
+	if _, err = secretStore.FindByIdentifier(ctx, 1, "repo_token"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected repo secret to be hidden from the space, got err %v", err)
+	}
+
+	secret, err := secretStore.FindByRepoIdentifier(ctx, repoID, "repo_token")
+	if err != nil {
+		t.Fatalf("failed to find repo secret: %v", err)
+	}
+	if secret.RepoID == nil || *secret.RepoID != repoID {
+		t.Errorf("expected repo secret to belong to repo %d", repoID)
+	}
+
+	if err = secretStore.DeleteByIdentifier(ctx, 1, "repo_token"); err != nil {
+		t.Fatalf("failed to delete space secret: %v", err)
+	}
+	if _, err = secretStore.FindByRepoIdentifier(ctx, repoID, "repo_token"); err != nil {
+		t.Errorf("repo secret must not be deleted as space secret: %v", err)
+	}
+}
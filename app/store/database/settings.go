@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SettingsStore = (*SettingsStore)(nil)
+
+// NewSettingsStore returns a new SettingsStore.
+func NewSettingsStore(db *sqlx.DB) *SettingsStore {
+	return &SettingsStore{
+		db: db,
+	}
+}
+
+// SettingsStore implements store.SettingsStore backed by a relational database.
+type SettingsStore struct {
+	db *sqlx.DB
+}
+
+type setting struct {
+	ID        int64              `db:"setting_id"`
+	Scope     enum.SettingsScope `db:"setting_scope"`
+	ScopeID   int64              `db:"setting_scope_id"`
+	Key       string             `db:"setting_key"`
+	Value     string             `db:"setting_value"`
+	Updated   int64              `db:"setting_updated"`
+	UpdatedBy int64              `db:"setting_updated_by"`
+}
+
+// Find returns the value of the setting with the provided key in the scope.
+func (s *SettingsStore) Find(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key string,
+) (json.RawMessage, error) {
+	stmt := database.Builder.
+		Select("setting_value").
+		From("settings").
+		Where("setting_scope = ?", scope).
+		Where("setting_scope_id = ?", scopeID).
+		Where("setting_key = ?", key)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var value string
+	if err = db.GetContext(ctx, &value, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find setting")
+	}
+
+	return json.RawMessage(value), nil
+}
+
+// FindMany returns the values of the setting with the provided key for multiple scope ids, mapped by scope id.
+// Scope ids without a stored value are omitted from the result.
+func (s *SettingsStore) FindMany(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeIDs []int64,
+	key string,
+) (map[int64]json.RawMessage, error) {
+	if len(scopeIDs) == 0 {
+		return map[int64]json.RawMessage{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("setting_scope_id", "setting_value").
+		From("settings").
+		Where("setting_scope = ?", scope).
+		Where(squirrel.Eq{"setting_scope_id": scopeIDs}).
+		Where("setting_key = ?", key)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*setting
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find settings")
+	}
+
+	values := make(map[int64]json.RawMessage, len(dst))
+	for _, setting := range dst {
+		values[setting.ScopeID] = json.RawMessage(setting.Value)
+	}
+
+	return values, nil
+}
+
+// FindAll returns the values of the setting with the provided key for all ids of the scope, mapped by scope id.
+func (s *SettingsStore) FindAll(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	key string,
+) (map[int64]json.RawMessage, error) {
+	stmt := database.Builder.
+		Select("setting_scope_id", "setting_value").
+		From("settings").
+		Where("setting_scope = ?", scope).
+		Where("setting_key = ?", key)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*setting
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find settings")
+	}
+
+	values := make(map[int64]json.RawMessage, len(dst))
+	for _, setting := range dst {
+		values[setting.ScopeID] = json.RawMessage(setting.Value)
+	}
+
+	return values, nil
+}
+
+// Upsert creates or updates the value of the setting with the provided key in the scope.
+func (s *SettingsStore) Upsert(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key string,
+	value json.RawMessage,
+	updatedBy int64,
+) error {
+	const sqlQueryInsert = `
+	INSERT INTO settings (
+		 setting_scope
+		,setting_scope_id
+		,setting_key
+		,setting_value
+		,setting_updated
+		,setting_updated_by
+	) VALUES (
+		 :setting_scope
+		,:setting_scope_id
+		,:setting_key
+		,:setting_value
+		,:setting_updated
+		,:setting_updated_by
+	)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (setting_scope, setting_scope_id, setting_key) DO
+	UPDATE SET
+		 setting_value = :setting_value
+		,setting_updated = :setting_updated
+		,setting_updated_by = :setting_updated_by`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 setting_value = :setting_value
+		,setting_updated = :setting_updated
+		,setting_updated_by = :setting_updated_by`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &setting{
+		Scope:     scope,
+		ScopeID:   scopeID,
+		Key:       key,
+		Value:     string(value),
+		Updated:   time.Now().UnixMilli(),
+		UpdatedBy: updatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind setting object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the setting with the provided key from the scope (noop if it doesn't exist).
+func (s *SettingsStore) Delete(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key string,
+) error {
+	stmt := database.Builder.
+		Delete("settings").
+		Where("setting_scope = ?", scope).
+		Where("setting_scope_id = ?", scopeID).
+		Where("setting_key = ?", key)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete query failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestSettingsStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	settingsStore := database.NewSettingsStore(db)
+
+	ctx := context.Background()
+	scope := enum.SettingsScopePrincipal
+	key := types.SettingsKeyNotifications
+
+	_, err := settingsStore.Find(ctx, scope, 1, key)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	for id, value := range map[int64]string{1: `{"level":"all"}`, 2: `{"level":"none"}`} {
+		if err = settingsStore.Upsert(ctx, scope, id, key, json.RawMessage(value), userID); err != nil {
+			t.Fatalf("failed to create setting: %v", err)
+		}
+	}
+
+	if err = settingsStore.Upsert(ctx, scope, 1, key, json.RawMessage(`{"level":"mentions"}`), userID); err != nil {
+		t.Fatalf("failed to update setting: %v", err)
+	}
+
+	value, err := settingsStore.Find(ctx, scope, 1, key)
+	if err != nil {
+		t.Fatalf("failed to find setting: %v", err)
+	}
+
+	if string(value) != `{"level":"mentions"}` {
+		t.Errorf("unexpected setting value: %s", value)
+	}
+
+	values, err := settingsStore.FindMany(ctx, scope, []int64{1, 3}, key)
+	if err != nil {
+		t.Fatalf("failed to find settings: %v", err)
+	}
+
+	if len(values) != 1 || string(values[1]) != `{"level":"mentions"}` {
+		t.Errorf("unexpected settings: %v", values)
+	}
+
+	values, err = settingsStore.FindAll(ctx, scope, key)
+	if err != nil {
+		t.Fatalf("failed to find all settings: %v", err)
+	}
+
+	if len(values) != 2 || string(values[2]) != `{"level":"none"}` {
+		t.Errorf("unexpected settings: %v", values)
+	}
+
+	if err = settingsStore.Delete(ctx, scope, 1, key); err != nil {
+		t.Fatalf("failed to delete setting: %v", err)
+	}
+
+	_, err = settingsStore.Find(ctx, scope, 1, key)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("expected not found error after delete, got: %v", err)
+	}
+
+	values, err = settingsStore.FindAll(ctx, scope, key)
+	if err != nil {
+		t.Fatalf("failed to find all settings: %v", err)
+	}
+
+	if len(values) != 1 {
+		t.Errorf("expected 1 setting after delete, got %d", len(values))
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/database/migrate"
+	gitness_cache "github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
 
 	"github.com/jmoiron/sqlx"
@@ -71,14 +72,14 @@ func setupStores(t *testing.T, db *sqlx.DB) (
 ) {
 	t.Helper()
 
-	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation, gitness_cache.NoRowCache{})
 
 	spacePathTransformation := store.ToLowerSpacePathTransformation
 	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
 	spacePathCache := cache.New(spacePathStore, spacePathTransformation)
 
 	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
-	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, gitness_cache.NoRowCache{})
 
 	return principalStore, spaceStore, spacePathStore, repoStore
 }
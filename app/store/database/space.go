@@ -70,6 +70,8 @@ type space struct {
 	Created     int64    `db:"space_created"`
 	Updated     int64    `db:"space_updated"`
 	Deleted     null.Int `db:"space_deleted"`
+
+	GitBandwidthLimit int64 `db:"space_git_bandwidth_limit"`
 }
 
 const (
@@ -83,7 +85,8 @@ const (
 		,space_created_by
 		,space_created
 		,space_updated
-		,space_deleted`
+		,space_deleted
+		,space_git_bandwidth_limit`
 
 	spaceSelectBase = `
 	SELECT` + spaceColumns + `
@@ -238,6 +241,7 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			,space_created
 			,space_updated
 			,space_deleted
+			,space_git_bandwidth_limit
 		) values (
 			:space_version
 			,:space_parent_id
@@ -248,6 +252,7 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			,:space_created
 			,:space_updated
 			,:space_deleted
+			,:space_git_bandwidth_limit
 		) RETURNING space_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -280,6 +285,7 @@ func (s *SpaceStore) Update(ctx context.Context, space *types.Space) error {
 			,space_description	= :space_description
 			,space_is_public	= :space_is_public
 			,space_deleted 		= :space_deleted
+			,space_git_bandwidth_limit = :space_git_bandwidth_limit
 		WHERE space_id = :space_id AND space_version = :space_version - 1`
 
 	dbSpace := mapToInternalSpace(space)
@@ -699,6 +705,8 @@ func mapToSpace(
 		CreatedBy:   in.CreatedBy,
 		Updated:     in.Updated,
 		Deleted:     in.Deleted.Ptr(),
+
+		GitBandwidthLimit: in.GitBandwidthLimit,
 	}
 
 	// Only overwrite ParentID if it's not a root space
@@ -760,6 +768,8 @@ func mapToInternalSpace(s *types.Space) *space {
 		CreatedBy:   s.CreatedBy,
 		Updated:     s.Updated,
 		Deleted:     null.IntFromPtr(s.Deleted),
+
+		GitBandwidthLimit: s.GitBandwidthLimit,
 	}
 
 	// Only overwrite ParentID if it's not a root space
@@ -17,6 +17,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
@@ -161,6 +162,7 @@ func (s *SpacePathStore) FindByPath(ctx context.Context, path string) (*types.Sp
 	var parentID int64
 	originalPath := ""
 	isPrimary := true
+	var aliasSince int64
 	for i, segmentIdentifier := range segmentIdentifiers {
 		uniqueSegmentIdentifier := s.spacePathTransformation(segmentIdentifier, i == 0)
 
@@ -182,12 +184,18 @@ func (s *SpacePathStore) FindByPath(ctx context.Context, path string) (*types.Sp
 		originalPath = paths.Concatenate(originalPath, segment.Identifier)
 		parentID = segment.SpaceID
 		isPrimary = isPrimary && segment.IsPrimary.ValueOrZero()
+
+		// alias segments are updated when they become an alias.
+		if !segment.IsPrimary.ValueOrZero() && (aliasSince == 0 || segment.Updated < aliasSince) {
+			aliasSince = segment.Updated
+		}
 	}
 
 	return &types.SpacePath{
-		Value:     originalPath,
-		IsPrimary: isPrimary,
-		SpaceID:   segment.SpaceID,
+		Value:      originalPath,
+		IsPrimary:  isPrimary,
+		SpaceID:    segment.SpaceID,
+		AliasSince: aliasSince,
 	}, nil
 }
 
@@ -206,6 +214,50 @@ func (s *SpacePathStore) DeletePrimarySegment(ctx context.Context, spaceID int64
 	return nil
 }
 
+// DemotePrimarySegment turns the primary segment of the space into an alias.
+func (s *SpacePathStore) DemotePrimarySegment(ctx context.Context, spaceID int64) error {
+	const sqlQuery = `
+		UPDATE space_paths
+		SET
+			 space_path_is_primary = NULL
+			,space_path_updated = $1
+		WHERE space_path_space_id = $2 AND space_path_is_primary = TRUE`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, time.Now().UnixMilli(), spaceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "the update query failed")
+	}
+
+	return nil
+}
+
+// DeleteAliasSegment deletes the alias segment with the provided identifier under the parent (if it exists).
+func (s *SpacePathStore) DeleteAliasSegment(ctx context.Context, parentID int64, identifier string) error {
+	const sqlQueryNoParent = `
+		DELETE FROM space_paths
+		WHERE space_path_uid_unique = $1 AND space_path_parent_id IS NULL AND space_path_is_primary IS NULL`
+	const sqlQueryParent = `
+		DELETE FROM space_paths
+		WHERE space_path_uid_unique = $1 AND space_path_parent_id = $2 AND space_path_is_primary IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	uniqueIdentifier := s.spacePathTransformation(identifier, parentID == 0)
+
+	var err error
+	if parentID == 0 {
+		_, err = db.ExecContext(ctx, sqlQueryNoParent, uniqueIdentifier)
+	} else {
+		_, err = db.ExecContext(ctx, sqlQueryParent, uniqueIdentifier, parentID)
+	}
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	return nil
+}
+
 // DeletePathsAndDescendandPaths deletes all space paths reachable from spaceID including itself.
 func (s *SpacePathStore) DeletePathsAndDescendandPaths(ctx context.Context, spaceID int64) error {
 	const sqlQuery = `WITH RECURSIVE DescendantPaths AS (
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestSpacePathStore_Alias(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	if err := spacePathStore.DemotePrimarySegment(ctx, 1); err != nil {
+		t.Fatalf("failed to demote primary segment: %v", err)
+	}
+
+	if err := spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: "renamed", CreatedBy: userID, SpaceID: 1, IsPrimary: true,
+	}); err != nil {
+		t.Fatalf("failed to insert segment: %v", err)
+	}
+
+	alias, err := spacePathStore.FindByPath(ctx, "SPACE_1")
+	if err != nil {
+		t.Fatalf("failed to find alias path: %v", err)
+	}
+	if alias.IsPrimary || alias.SpaceID != 1 || alias.AliasSince == 0 {
+		t.Errorf("alias = %+v, want non-primary path of space 1 with alias time", alias)
+	}
+
+	primary, err := spacePathStore.FindPrimaryBySpaceID(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find primary path: %v", err)
+	}
+	if primary.Value != "renamed" {
+		t.Errorf("primary.Value = %q, want %q", primary.Value, "renamed")
+	}
+
+	if err = spacePathStore.DeleteAliasSegment(ctx, 0, "Space_1"); err != nil {
+		t.Fatalf("failed to delete alias segment: %v", err)
+	}
+
+	_, err = spacePathStore.FindByPath(ctx, "space_1")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	if _, err = spacePathStore.FindByPath(ctx, "renamed"); err != nil {
+		t.Errorf("failed to find primary path after deleting alias: %v", err)
+	}
+}
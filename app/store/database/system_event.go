@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SystemEventStore = (*SystemEventStore)(nil)
+
+// NewSystemEventStore returns a new SystemEventStore.
+func NewSystemEventStore(db *sqlx.DB) *SystemEventStore {
+	return &SystemEventStore{
+		db: db,
+	}
+}
+
+// SystemEventStore implements store.SystemEventStore backed by a relational database.
+type SystemEventStore struct {
+	db *sqlx.DB
+}
+
+const (
+	systemEventColumns = `
+		 system_event_id
+		,system_event_type
+		,system_event_principal_id
+		,system_event_resource_id
+		,system_event_resource_path
+		,system_event_message
+		,system_event_created`
+)
+
+// Create creates a new system event entry.
+func (s *SystemEventStore) Create(ctx context.Context, event *types.SystemEvent) error {
+	const sqlQuery = `
+	INSERT INTO system_events (
+		 system_event_type
+		,system_event_principal_id
+		,system_event_resource_id
+		,system_event_resource_path
+		,system_event_message
+		,system_event_created
+	) values (
+		 :system_event_type
+		,:system_event_principal_id
+		,:system_event_resource_id
+		,:system_event_resource_path
+		,:system_event_message
+		,:system_event_created
+	) RETURNING system_event_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, event)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind system event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List lists the system events matching the filter, latest first.
+func (s *SystemEventStore) List(
+	ctx context.Context,
+	filter *types.SystemEventFilter,
+) ([]*types.SystemEvent, error) {
+	stmt := database.Builder.
+		Select(systemEventColumns).
+		From("system_events")
+
+	stmt = applySystemEventFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("system_event_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert system event list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.SystemEvent{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing system event list query")
+	}
+
+	return dst, nil
+}
+
+// Count counts the system events matching the filter.
+func (s *SystemEventStore) Count(ctx context.Context, filter *types.SystemEventFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("system_events")
+
+	stmt = applySystemEventFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert system event count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing system event count query")
+	}
+
+	return count, nil
+}
+
+// DeleteOld removes all system events that are older than the provided time.
+func (s *SystemEventStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("system_events").
+		Where("system_event_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete system events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete system events query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted system events")
+	}
+
+	return n, nil
+}
+
+func applySystemEventFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.SystemEventFilter,
+) squirrel.SelectBuilder {
+	if len(filter.Types) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"system_event_type": filter.Types})
+	}
+
+	if filter.Since > 0 {
+		stmt = stmt.Where("system_event_created >= ?", filter.Since)
+	}
+
+	return stmt
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_SystemEvents(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	systemEventStore := database.NewSystemEventStore(db)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, e := range []types.SystemEvent{
+		{Type: enum.SystemEventTypeUserCreated, ResourcePath: "admin", Created: now.Add(-48 * time.Hour).UnixMilli()},
+		{Type: enum.SystemEventTypeRepoCreated, ResourcePath: "space/repo", Created: now.Add(-time.Hour).UnixMilli()},
+		{Type: enum.SystemEventTypeLoginFailed, ResourcePath: "admin", Created: now.UnixMilli()},
+	} {
+		e := e
+		if err := systemEventStore.Create(ctx, &e); err != nil {
+			t.Fatalf("failed to create system event %d: %v", i, err)
+		}
+		if e.ID == 0 {
+			t.Fatalf("system event %d didn't get an ID", i)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter types.SystemEventFilter
+		exp    []enum.SystemEventType
+	}{
+		{
+			name:   "all",
+			filter: types.SystemEventFilter{Page: 1, Size: 10},
+			exp: []enum.SystemEventType{
+				enum.SystemEventTypeLoginFailed,
+				enum.SystemEventTypeRepoCreated,
+				enum.SystemEventTypeUserCreated,
+			},
+		},
+		{
+			name: "types",
+			filter: types.SystemEventFilter{Page: 1, Size: 10, Types: []enum.SystemEventType{
+				enum.SystemEventTypeUserCreated,
+				enum.SystemEventTypeLoginFailed,
+			}},
+			exp: []enum.SystemEventType{enum.SystemEventTypeLoginFailed, enum.SystemEventTypeUserCreated},
+		},
+		{
+			name:   "since",
+			filter: types.SystemEventFilter{Page: 1, Size: 10, Since: now.Add(-2 * time.Hour).UnixMilli()},
+			exp:    []enum.SystemEventType{enum.SystemEventTypeLoginFailed, enum.SystemEventTypeRepoCreated},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events, err := systemEventStore.List(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list system events: %v", err)
+			}
+
+			got := make([]enum.SystemEventType, len(events))
+			for i, e := range events {
+				got[i] = e.Type
+			}
+
+			if len(got) != len(test.exp) {
+				t.Fatalf("got system events %v, want %v", got, test.exp)
+			}
+			for i := range got {
+				if got[i] != test.exp[i] {
+					t.Fatalf("got system events %v, want %v", got, test.exp)
+				}
+			}
+		})
+	}
+
+	n, err := systemEventStore.DeleteOld(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to delete old system events: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("deleted = %d, want 1", n)
+	}
+
+	count, err := systemEventStore.Count(ctx, &types.SystemEventFilter{})
+	if err != nil {
+		t.Fatalf("failed to count system events: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+}
@@ -33,24 +33,34 @@ import (
 var _ store.TokenStore = (*TokenStore)(nil)
 
 // NewTokenStore returns a new TokenStore.
-func NewTokenStore(db *sqlx.DB) *TokenStore {
-	return &TokenStore{db}
+func NewTokenStore(db *sqlx.DB, rowCache store.RowCache) *TokenStore {
+	return &TokenStore{
+		db:       db,
+		rowCache: rowCache,
+	}
 }
 
 // TokenStore implements a TokenStore backed by a relational database.
 type TokenStore struct {
-	db *sqlx.DB
+	db       *sqlx.DB
+	rowCache store.RowCache
 }
 
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
+	dst := new(types.Token)
+	if rowCacheGet(ctx, s.rowCache, rowCacheKeyToken(id), dst) {
+		return dst, nil
+	}
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
+	rowCacheSet(ctx, s.rowCache, rowCacheKeyToken(id), dst)
+
 	return dst, nil
 }
 
@@ -96,11 +106,38 @@ func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
 	}
 
+	s.rowCache.Evict(ctx, rowCacheKeyToken(id))
+
+	return nil
+}
+
+// DeleteForPrincipal deletes all tokens of the principal.
+func (s *TokenStore) DeleteForPrincipal(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM tokens
+		WHERE token_principal_id = $1
+		RETURNING token_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	ids := []int64{}
+	if err := db.SelectContext(ctx, &ids, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = rowCacheKeyToken(id)
+	}
+
+	s.rowCache.Evict(ctx, keys...)
+
 	return nil
 }
 
 // DeleteExpiredBefore deletes all tokens that expired before the provided time.
 // If tokenTypes are provided, then only tokens of that type are deleted.
+// NOTE: Deleted tokens aren't evicted from the row cache, as expired tokens are rejected during authentication anyway.
 func (s *TokenStore) DeleteExpiredBefore(
 	ctx context.Context,
 	before time.Time,
@@ -0,0 +1,426 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UsageMetricStore = (*UsageMetricStore)(nil)
+
+const usageDay = int64(24 * time.Hour / time.Millisecond)
+
+// NewUsageMetricStore returns a new UsageMetricStore.
+func NewUsageMetricStore(db *sqlx.DB) *UsageMetricStore {
+	return &UsageMetricStore{
+		db: db,
+	}
+}
+
+// UsageMetricStore implements store.UsageMetricStore backed by a relational database.
+type UsageMetricStore struct {
+	db *sqlx.DB
+}
+
+// AddGitTraffic adds the number of bytes transferred by git to the usage of the repository for the day.
+func (s *UsageMetricStore) AddGitTraffic(ctx context.Context, repoID, date, bytesIn, bytesOut int64) error {
+	const sqlQueryInsert = `
+	INSERT INTO usage_metrics (
+		 usage_metric_repo_id
+		,usage_metric_date
+		,usage_metric_git_bytes_in
+		,usage_metric_git_bytes_out
+		,usage_metric_updated
+	) VALUES ($1, $2, $3, $4, $5)`
+
+	const sqlQueryConflict = `
+	ON CONFLICT (usage_metric_repo_id, usage_metric_date) DO
+	UPDATE SET
+		 usage_metric_git_bytes_in = usage_metrics.usage_metric_git_bytes_in + EXCLUDED.usage_metric_git_bytes_in
+		,usage_metric_git_bytes_out = usage_metrics.usage_metric_git_bytes_out + EXCLUDED.usage_metric_git_bytes_out
+		,usage_metric_updated = EXCLUDED.usage_metric_updated`
+
+	const sqlQueryConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 usage_metric_git_bytes_in = usage_metric_git_bytes_in + VALUES(usage_metric_git_bytes_in)
+		,usage_metric_git_bytes_out = usage_metric_git_bytes_out + VALUES(usage_metric_git_bytes_out)
+		,usage_metric_updated = VALUES(usage_metric_updated)`
+
+	sqlQuery := sqlQueryInsert + sqlQueryConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = sqlQueryInsert + sqlQueryConflictMariaDB
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	_, err := db.ExecContext(ctx, sqlQuery, repoID, date, bytesIn, bytesOut, time.Now().UnixMilli())
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to add git traffic")
+	}
+
+	return nil
+}
+
+// AddActiveUser marks the principal as active in the repository for the day.
+func (s *UsageMetricStore) AddActiveUser(ctx context.Context, repoID, date, principalID int64) error {
+	const sqlQuery = `
+	INSERT INTO usage_active_users (
+		 usage_active_user_repo_id
+		,usage_active_user_date
+		,usage_active_user_principal_id
+	) VALUES ($1, $2, $3)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	_, err := db.ExecContext(ctx, s.insertIgnore(sqlQuery), repoID, date, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to add active user")
+	}
+
+	return nil
+}
+
+// Aggregate stores the disk usage and the pull request activity of all repositories for the day.
+// The authors and the mergers of the pull requests are marked as active users.
+func (s *UsageMetricStore) Aggregate(ctx context.Context, date int64) error {
+	const sqlQueryMetrics = `
+	INSERT INTO usage_metrics (
+		 usage_metric_repo_id
+		,usage_metric_date
+		,usage_metric_disk_usage
+		,usage_metric_pullreqs_created
+		,usage_metric_pullreqs_merged
+		,usage_metric_updated
+	)
+	SELECT
+		 repo_id
+		,$1
+		,repo_size
+		,(SELECT COUNT(*) FROM pullreqs
+			WHERE pullreq_target_repo_id = repo_id AND pullreq_created >= $1 AND pullreq_created < $2)
+		,(SELECT COUNT(*) FROM pullreqs
+			WHERE pullreq_target_repo_id = repo_id AND pullreq_merged >= $1 AND pullreq_merged < $2)
+		,%d
+	FROM repositories
+	WHERE repo_deleted IS NULL`
+
+	const sqlQueryMetricsConflict = `
+	ON CONFLICT (usage_metric_repo_id, usage_metric_date) DO
+	UPDATE SET
+		 usage_metric_disk_usage = EXCLUDED.usage_metric_disk_usage
+		,usage_metric_pullreqs_created = EXCLUDED.usage_metric_pullreqs_created
+		,usage_metric_pullreqs_merged = EXCLUDED.usage_metric_pullreqs_merged
+		,usage_metric_updated = EXCLUDED.usage_metric_updated`
+
+	const sqlQueryMetricsConflictMariaDB = `
+	ON DUPLICATE KEY UPDATE
+		 usage_metric_disk_usage = VALUES(usage_metric_disk_usage)
+		,usage_metric_pullreqs_created = VALUES(usage_metric_pullreqs_created)
+		,usage_metric_pullreqs_merged = VALUES(usage_metric_pullreqs_merged)
+		,usage_metric_updated = VALUES(usage_metric_updated)`
+
+	const sqlQueryUsers = `
+	INSERT INTO usage_active_users (
+		 usage_active_user_repo_id
+		,usage_active_user_date
+		,usage_active_user_principal_id
+	)
+	SELECT DISTINCT pullreq_target_repo_id, $1, pullreq_created_by
+	FROM pullreqs
+	WHERE pullreq_created >= $1 AND pullreq_created < $2
+	UNION
+	SELECT DISTINCT pullreq_target_repo_id, $1, pullreq_merged_by
+	FROM pullreqs
+	WHERE pullreq_merged >= $1 AND pullreq_merged < $2 AND pullreq_merged_by IS NOT NULL`
+
+	// the update time is inlined, as the type of a parameter in the select list can't be inferred by all drivers.
+	sqlQuery := fmt.Sprintf(sqlQueryMetrics, time.Now().UnixMilli()) + sqlQueryMetricsConflict
+	if database.IsMariaDB(s.db.DriverName()) {
+		sqlQuery = fmt.Sprintf(sqlQueryMetrics, time.Now().UnixMilli()) + sqlQueryMetricsConflictMariaDB
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	_, err := db.ExecContext(ctx, sqlQuery, date, date+usageDay)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to aggregate usage metrics")
+	}
+
+	_, err = db.ExecContext(ctx, s.insertIgnore(sqlQueryUsers), date, date+usageDay)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to aggregate active users")
+	}
+
+	return nil
+}
+
+// ListDaily returns the usage of the space aggregated by day, for the days in the [from, to) range.
+func (s *UsageMetricStore) ListDaily(
+	ctx context.Context,
+	spaceID, from, to int64,
+) ([]types.UsageDailyStats, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	spaceIDs, err := s.descendantSpaceIDs(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := database.Builder.
+		Select(
+			"usage_metric_date",
+			"SUM(usage_metric_disk_usage)",
+			"SUM(usage_metric_pullreqs_created)",
+			"SUM(usage_metric_pullreqs_merged)",
+			"SUM(usage_metric_git_bytes_in)",
+			"SUM(usage_metric_git_bytes_out)").
+		From("usage_metrics").
+		Where("usage_metric_date >= ?", from).
+		Where("usage_metric_date < ?", to).
+		GroupBy("usage_metric_date").
+		OrderBy("usage_metric_date")
+
+	if spaceIDs != nil {
+		stmt = stmt.
+			InnerJoin("repositories ON repo_id = usage_metric_repo_id").
+			Where(squirrel.Eq{"repo_parent_id": spaceIDs})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list daily usage")
+	}
+	defer rows.Close()
+
+	var result []types.UsageDailyStats
+	for rows.Next() {
+		var stats types.UsageDailyStats
+		err = rows.Scan(&stats.Date, &stats.DiskUsage, &stats.PullReqsCreated, &stats.PullReqsMerged,
+			&stats.GitBytesIn, &stats.GitBytesOut)
+		if err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan daily usage")
+		}
+
+		result = append(result, stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list daily usage")
+	}
+
+	activeUsers, err := s.countActiveUsersDaily(ctx, spaceIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].ActiveUsers = activeUsers[result[i].Date]
+	}
+
+	return result, nil
+}
+
+// countActiveUsersDaily returns the number of distinct active users per day, mapped by date.
+func (s *UsageMetricStore) countActiveUsersDaily(
+	ctx context.Context,
+	spaceIDs []int64,
+	from, to int64,
+) (map[int64]int64, error) {
+	stmt := database.Builder.
+		Select("usage_active_user_date", "COUNT(DISTINCT usage_active_user_principal_id)").
+		From("usage_active_users").
+		Where("usage_active_user_date >= ?", from).
+		Where("usage_active_user_date < ?", to).
+		GroupBy("usage_active_user_date")
+
+	stmt = s.applySpaceFilter(stmt, spaceIDs)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count daily active users")
+	}
+	defer rows.Close()
+
+	result := map[int64]int64{}
+	for rows.Next() {
+		var date, count int64
+		if err = rows.Scan(&date, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan daily active users")
+		}
+
+		result[date] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count daily active users")
+	}
+
+	return result, nil
+}
+
+// CountActiveUsers returns the number of distinct active users of the space in the [from, to) range.
+func (s *UsageMetricStore) CountActiveUsers(ctx context.Context, spaceID, from, to int64) (int64, error) {
+	spaceIDs, err := s.descendantSpaceIDs(ctx, spaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := database.Builder.
+		Select("COUNT(DISTINCT usage_active_user_principal_id)").
+		From("usage_active_users").
+		Where("usage_active_user_date >= ?", from).
+		Where("usage_active_user_date < ?", to)
+
+	stmt = s.applySpaceFilter(stmt, spaceIDs)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count active users")
+	}
+
+	return count, nil
+}
+
+// SumDiskUsage returns the current disk usage of all repositories of the space.
+func (s *UsageMetricStore) SumDiskUsage(ctx context.Context, spaceID int64) (int64, error) {
+	spaceIDs, err := s.descendantSpaceIDs(ctx, spaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := database.Builder.
+		Select("COALESCE(SUM(repo_size), 0)").
+		From("repositories").
+		Where("repo_deleted IS NULL")
+
+	if spaceIDs != nil {
+		stmt = stmt.Where(squirrel.Eq{"repo_parent_id": spaceIDs})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var size int64
+	if err = db.GetContext(ctx, &size, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to sum disk usage")
+	}
+
+	return size, nil
+}
+
+// DeleteBefore deletes all usage data of the days before the provided date.
+func (s *UsageMetricStore) DeleteBefore(ctx context.Context, date int64) (int64, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, `DELETE FROM usage_metrics WHERE usage_metric_date < $1`, date)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete usage metrics")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted usage metrics")
+	}
+
+	_, err = db.ExecContext(ctx, `DELETE FROM usage_active_users WHERE usage_active_user_date < $1`, date)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete active users")
+	}
+
+	return n, nil
+}
+
+// descendantSpaceIDs returns the ids of the space and all its subspaces.
+// Nil is returned for the space id zero, which refers to the whole instance.
+func (s *UsageMetricStore) descendantSpaceIDs(ctx context.Context, spaceID int64) ([]int64, error) {
+	if spaceID == 0 {
+		return nil, nil
+	}
+
+	const query = `WITH RECURSIVE SpaceHierarchy AS (
+    SELECT space_id, space_parent_id
+    FROM spaces
+    WHERE space_id = $1
+
+    UNION
+
+    SELECT s.space_id, s.space_parent_id
+    FROM spaces s
+    JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
+)
+SELECT space_id
+FROM SpaceHierarchy h1;`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	spaceIDs := []int64{}
+	if err := db.SelectContext(ctx, &spaceIDs, query, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to retrieve spaces")
+	}
+
+	return spaceIDs, nil
+}
+
+func (s *UsageMetricStore) applySpaceFilter(stmt squirrel.SelectBuilder, spaceIDs []int64) squirrel.SelectBuilder {
+	if spaceIDs == nil {
+		return stmt
+	}
+
+	return stmt.
+		InnerJoin("repositories ON repo_id = usage_active_user_repo_id").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs})
+}
+
+// insertIgnore turns the insert query into one that skips rows which already exist.
+func (s *UsageMetricStore) insertIgnore(sqlQuery string) string {
+	if database.IsMariaDB(s.db.DriverName()) {
+		return strings.Replace(sqlQuery, "INSERT INTO", "INSERT IGNORE INTO", 1)
+	}
+
+	return sqlQuery + `
+	ON CONFLICT DO NOTHING`
+}
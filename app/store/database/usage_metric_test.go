@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+)
+
+func TestUsageMetricStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	usageStore := database.NewUsageMetricStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 3, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 100)
+	createRepo(ctx, t, repoStore, 2, 2, 200)
+	createRepo(ctx, t, repoStore, 3, 3, 400)
+
+	day := int64(24 * time.Hour / time.Millisecond)
+	date := 10 * day
+
+	if err := usageStore.Aggregate(ctx, date); err != nil {
+		t.Fatalf("failed to aggregate: %s", err)
+	}
+	for _, repoID := range []int64{1, 2, 3} {
+		if err := usageStore.AddGitTraffic(ctx, repoID, date, 10, 20); err != nil {
+			t.Fatalf("failed to add git traffic: %s", err)
+		}
+		if err := usageStore.AddActiveUser(ctx, repoID, date, userID); err != nil {
+			t.Fatalf("failed to add active user: %s", err)
+		}
+	}
+	if err := usageStore.AddGitTraffic(ctx, 2, date+day, 5, 5); err != nil {
+		t.Fatalf("failed to add git traffic: %s", err)
+	}
+	// active users are counted once
+	if err := usageStore.AddActiveUser(ctx, 2, date, userID); err != nil {
+		t.Fatalf("failed to add active user: %s", err)
+	}
+
+	daily, err := usageStore.ListDaily(ctx, 1, date, date+2*day)
+	if err != nil {
+		t.Fatalf("failed to list daily usage: %s", err)
+	}
+	if len(daily) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(daily))
+	}
+	if daily[0].DiskUsage != 300 || daily[0].GitBytesIn != 20 || daily[0].GitBytesOut != 40 ||
+		daily[0].ActiveUsers != 1 {
+		t.Errorf("unexpected usage of the first day: %+v", daily[0])
+	}
+	if daily[1].DiskUsage != 0 || daily[1].GitBytesIn != 5 || daily[1].ActiveUsers != 0 {
+		t.Errorf("unexpected usage of the second day: %+v", daily[1])
+	}
+
+	daily, err = usageStore.ListDaily(ctx, 0, date, date+day)
+	if err != nil {
+		t.Fatalf("failed to list daily usage: %s", err)
+	}
+	if len(daily) != 1 || daily[0].DiskUsage != 700 || daily[0].GitBytesOut != 60 {
+		t.Errorf("unexpected usage of the instance: %+v", daily)
+	}
+
+	activeUsers, err := usageStore.CountActiveUsers(ctx, 3, date, date+2*day)
+	if err != nil {
+		t.Fatalf("failed to count active users: %s", err)
+	}
+	if activeUsers != 1 {
+		t.Errorf("expected 1 active user, got %d", activeUsers)
+	}
+
+	diskUsage, err := usageStore.SumDiskUsage(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to sum disk usage: %s", err)
+	}
+	if diskUsage != 300 {
+		t.Errorf("expected disk usage 300, got %d", diskUsage)
+	}
+
+	n, err := usageStore.DeleteBefore(ctx, date+day)
+	if err != nil {
+		t.Fatalf("failed to delete usage: %s", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 deleted usage metrics, got %d", n)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserActivityStore = (*UserActivityStore)(nil)
+
+// NewUserActivityStore returns a new UserActivityStore.
+func NewUserActivityStore(db *sqlx.DB) *UserActivityStore {
+	return &UserActivityStore{
+		db: db,
+	}
+}
+
+// UserActivityStore implements store.UserActivityStore backed by a relational database.
+// The activities aren't stored separately, they are aggregated from the tables of the underlying resources.
+type UserActivityStore struct {
+	db *sqlx.DB
+}
+
+type userActivity struct {
+	Type           enum.UserActivityType      `db:"activity_type"`
+	RepoID         int64                      `db:"activity_repo_id"`
+	Created        int64                      `db:"activity_created"`
+	Ref            string                     `db:"activity_ref"`
+	SHA            string                     `db:"activity_sha"`
+	PullReqNumber  int64                      `db:"activity_pullreq_number"`
+	PullReqTitle   string                     `db:"activity_pullreq_title"`
+	ReviewDecision enum.PullReqReviewDecision `db:"activity_review_decision"`
+}
+
+// userActivitySource describes a table the activities of a single type are read from.
+type userActivitySource struct {
+	typ             enum.UserActivityType
+	columns         []string
+	from            string
+	principalColumn string
+	repoColumn      string
+	createdColumn   string
+	where           string
+}
+
+// userActivitySources lists the sources of all activity types.
+// The columns of all sources have to match the columns of the userActivity struct.
+var userActivitySources = []userActivitySource{
+	{
+		typ: enum.UserActivityTypePush,
+		columns: []string{
+			"push_repo_id AS activity_repo_id",
+			"push_created AS activity_created",
+			"push_ref AS activity_ref",
+			"push_new_sha AS activity_sha",
+			"0 AS activity_pullreq_number",
+			"'' AS activity_pullreq_title",
+			"'' AS activity_review_decision",
+		},
+		from:            "pushes",
+		principalColumn: "push_principal_id",
+		repoColumn:      "push_repo_id",
+		createdColumn:   "push_created",
+	},
+	{
+		typ: enum.UserActivityTypePullReqOpened,
+		columns: []string{
+			"pullreq_target_repo_id AS activity_repo_id",
+			"pullreq_created AS activity_created",
+			"'' AS activity_ref",
+			"'' AS activity_sha",
+			"pullreq_number AS activity_pullreq_number",
+			"pullreq_title AS activity_pullreq_title",
+			"'' AS activity_review_decision",
+		},
+		from:            "pullreqs",
+		principalColumn: "pullreq_created_by",
+		repoColumn:      "pullreq_target_repo_id",
+		createdColumn:   "pullreq_created",
+	},
+	{
+		typ: enum.UserActivityTypePullReqMerged,
+		columns: []string{
+			"pullreq_target_repo_id AS activity_repo_id",
+			"pullreq_merged AS activity_created",
+			"'' AS activity_ref",
+			"COALESCE(pullreq_merge_sha, '') AS activity_sha",
+			"pullreq_number AS activity_pullreq_number",
+			"pullreq_title AS activity_pullreq_title",
+			"'' AS activity_review_decision",
+		},
+		from:            "pullreqs",
+		principalColumn: "pullreq_merged_by",
+		repoColumn:      "pullreq_target_repo_id",
+		createdColumn:   "pullreq_merged",
+		where:           "pullreq_merged IS NOT NULL",
+	},
+	{
+		typ: enum.UserActivityTypePullReqReviewed,
+		columns: []string{
+			"pullreq_target_repo_id AS activity_repo_id",
+			"pullreq_review_created AS activity_created",
+			"'' AS activity_ref",
+			"pullreq_review_sha AS activity_sha",
+			"pullreq_number AS activity_pullreq_number",
+			"pullreq_title AS activity_pullreq_title",
+			"pullreq_review_decision AS activity_review_decision",
+		},
+		from:            "pullreq_reviews JOIN pullreqs ON pullreq_id = pullreq_review_pullreq_id",
+		principalColumn: "pullreq_review_created_by",
+		repoColumn:      "pullreq_target_repo_id",
+		createdColumn:   "pullreq_review_created",
+	},
+	{
+		typ: enum.UserActivityTypePullReqCommented,
+		columns: []string{
+			"pullreq_activity_repo_id AS activity_repo_id",
+			"pullreq_activity_created AS activity_created",
+			"'' AS activity_ref",
+			"'' AS activity_sha",
+			"pullreq_number AS activity_pullreq_number",
+			"pullreq_title AS activity_pullreq_title",
+			"'' AS activity_review_decision",
+		},
+		from:            "pullreq_activities JOIN pullreqs ON pullreq_id = pullreq_activity_pullreq_id",
+		principalColumn: "pullreq_activity_created_by",
+		repoColumn:      "pullreq_activity_repo_id",
+		createdColumn:   "pullreq_activity_created",
+		where: fmt.Sprintf("pullreq_activity_kind IN ('%s', '%s') AND pullreq_activity_deleted IS NULL",
+			enum.PullReqActivityKindComment, enum.PullReqActivityKindChangeComment),
+	},
+}
+
+// ListRepoIDs returns the ids of all repositories the principal was active in since the provided time.
+func (s *UserActivityStore) ListRepoIDs(ctx context.Context, principalID int64, since int64) ([]int64, error) {
+	sql, args, err := s.union(principalID, &types.UserActivityFilter{Since: since}, "UNION",
+		func(src userActivitySource) []string {
+			return []string{src.repoColumn + " AS activity_repo_id"}
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repositories of user activities")
+	}
+
+	return dst, nil
+}
+
+// List returns the activities of the principal, newest first.
+func (s *UserActivityStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.UserActivityFilter,
+) ([]*types.UserActivity, error) {
+	sql, args, err := s.union(principalID, filter, "UNION ALL",
+		func(src userActivitySource) []string {
+			return append([]string{fmt.Sprintf("'%s' AS activity_type", src.typ)}, src.columns...)
+		})
+	if err != nil {
+		return nil, err
+	}
+	if sql == "" {
+		return []*types.UserActivity{}, nil
+	}
+
+	sql = "SELECT * FROM (" + sql + ") AS activities ORDER BY activity_created DESC" +
+		fmt.Sprintf(" LIMIT %d OFFSET %d", database.Limit(filter.Size), database.Offset(filter.Page, filter.Size))
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userActivity, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list user activities")
+	}
+
+	activities := make([]*types.UserActivity, len(dst))
+	for i, a := range dst {
+		activities[i] = &types.UserActivity{
+			Type:           a.Type,
+			RepoID:         a.RepoID,
+			Created:        a.Created,
+			Ref:            a.Ref,
+			SHA:            a.SHA,
+			PullReqNumber:  a.PullReqNumber,
+			PullReqTitle:   a.PullReqTitle,
+			ReviewDecision: a.ReviewDecision,
+		}
+	}
+
+	return activities, nil
+}
+
+// ListCreated returns the creation times of all activities of the principal matching the filter.
+// Pagination of the filter is ignored.
+func (s *UserActivityStore) ListCreated(
+	ctx context.Context,
+	principalID int64,
+	filter *types.UserActivityFilter,
+) ([]int64, error) {
+	sql, args, err := s.union(principalID, filter, "UNION ALL",
+		func(src userActivitySource) []string {
+			return []string{src.createdColumn + " AS activity_created"}
+		})
+	if err != nil {
+		return nil, err
+	}
+	if sql == "" {
+		return []int64{}, nil
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list user activity times")
+	}
+
+	return dst, nil
+}
+
+// union combines the queries of all activity sources matching the filter using the provided set operator.
+// It returns an empty query if no source matches the filter.
+func (s *UserActivityStore) union(
+	principalID int64,
+	filter *types.UserActivityFilter,
+	operator string,
+	columns func(src userActivitySource) []string,
+) (string, []any, error) {
+	queries := make([]string, 0, len(userActivitySources))
+	args := make([]any, 0)
+
+	for _, src := range userActivitySources {
+		if len(filter.Types) > 0 && !containsUserActivityType(filter.Types, src.typ) {
+			continue
+		}
+
+		// the placeholders of all parts are numbered once the whole query is assembled.
+		stmt := squirrel.StatementBuilder.
+			Select(columns(src)...).
+			From(src.from).
+			Where(src.principalColumn+" = ?", principalID)
+
+		if src.where != "" {
+			stmt = stmt.Where(src.where)
+		}
+
+		if filter.Since > 0 {
+			stmt = stmt.Where(src.createdColumn+" >= ?", filter.Since)
+		}
+
+		if filter.Until > 0 {
+			stmt = stmt.Where(src.createdColumn+" < ?", filter.Until)
+		}
+
+		if filter.RepoIDs != nil {
+			if len(filter.RepoIDs) == 0 {
+				return "", nil, nil
+			}
+			stmt = stmt.Where(squirrel.Eq{src.repoColumn: filter.RepoIDs})
+		}
+
+		sql, partArgs, err := stmt.ToSql()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to convert query to sql: %w", err)
+		}
+
+		queries = append(queries, sql)
+		args = append(args, partArgs...)
+	}
+
+	if len(queries) == 0 {
+		return "", nil, nil
+	}
+
+	sql, err := squirrel.Dollar.ReplacePlaceholders(strings.Join(queries, " "+operator+" "))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to number query placeholders: %w", err)
+	}
+
+	return sql, args, nil
+}
+
+func containsUserActivityType(list []enum.UserActivityType, t enum.UserActivityType) bool {
+	for _, typ := range list {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestUserActivityStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+	pushStore := database.NewPushStore(db)
+	userActivityStore := database.NewUserActivityStore(db)
+
+	for i, repoID := range []int64{1, 2} {
+		if err := pushStore.Create(ctx, &types.Push{
+			RepoID:      repoID,
+			PrincipalID: userID,
+			Ref:         "refs/heads/main",
+			OldSHA:      types.NilSHA,
+			NewSHA:      "abc",
+			Created:     int64(100 + i),
+		}); err != nil {
+			t.Fatalf("failed to create push: %v", err)
+		}
+	}
+
+	pr := &types.PullReq{
+		Number:           1,
+		Title:            "Fix login redirect",
+		CreatedBy:        userID,
+		Created:          200,
+		State:            enum.PullReqStateOpen,
+		SourceRepoID:     1,
+		SourceBranch:     "feature",
+		TargetRepoID:     1,
+		TargetBranch:     "main",
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+	}
+	if err := pullreqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	for i, kind := range []enum.PullReqActivityKind{enum.PullReqActivityKindComment, enum.PullReqActivityKindSystem} {
+		if err := activityStore.Create(ctx, &types.PullReqActivity{
+			CreatedBy:  userID,
+			Created:    300,
+			Updated:    300,
+			Edited:     300,
+			RepoID:     1,
+			PullReqID:  pr.ID,
+			Order:      int64(i + 1),
+			Type:       enum.PullReqActivityTypeComment,
+			Kind:       kind,
+			Text:       "looks good",
+			PayloadRaw: json.RawMessage("{}"),
+		}); err != nil {
+			t.Fatalf("failed to create activity: %v", err)
+		}
+	}
+
+	repoIDs, err := userActivityStore.ListRepoIDs(ctx, userID, 0)
+	if err != nil {
+		t.Fatalf("failed to list repo ids: %v", err)
+	}
+	if len(repoIDs) != 2 {
+		t.Errorf("expected 2 repositories, got %v", repoIDs)
+	}
+
+	list, err := userActivityStore.List(ctx, userID, &types.UserActivityFilter{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("failed to list activities: %v", err)
+	}
+
+	want := []enum.UserActivityType{
+		enum.UserActivityTypePullReqCommented,
+		enum.UserActivityTypePullReqOpened,
+		enum.UserActivityTypePush,
+		enum.UserActivityTypePush,
+	}
+	if len(list) != len(want) {
+		t.Fatalf("expected %d activities, got %d", len(want), len(list))
+	}
+	for i, a := range list {
+		if a.Type != want[i] {
+			t.Errorf("activity %d: expected type %s, got %s", i, want[i], a.Type)
+		}
+	}
+	if list[0].PullReqNumber != 1 || list[0].PullReqTitle != pr.Title {
+		t.Errorf("unexpected pull request of comment: %+v", list[0])
+	}
+	if list[2].RepoID != 2 || list[2].Ref != "refs/heads/main" || list[2].SHA != "abc" {
+		t.Errorf("unexpected push: %+v", list[2])
+	}
+
+	list, err = userActivityStore.List(ctx, userID, &types.UserActivityFilter{
+		Page:    1,
+		Size:    10,
+		Types:   []enum.UserActivityType{enum.UserActivityTypePush},
+		RepoIDs: []int64{1},
+	})
+	if err != nil {
+		t.Fatalf("failed to list filtered activities: %v", err)
+	}
+	if len(list) != 1 || list[0].RepoID != 1 {
+		t.Errorf("expected a single push in repo 1, got %+v", list)
+	}
+
+	created, err := userActivityStore.ListCreated(ctx, userID, &types.UserActivityFilter{Since: 101, Until: 300})
+	if err != nil {
+		t.Fatalf("failed to list activity times: %v", err)
+	}
+	if len(created) != 2 {
+		t.Errorf("expected 2 activities in time range, got %v", created)
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.VulnerabilityAlertStore = (*VulnerabilityAlertStore)(nil)
+
+// NewVulnerabilityAlertStore returns a new VulnerabilityAlertStore.
+func NewVulnerabilityAlertStore(db *sqlx.DB) *VulnerabilityAlertStore {
+	return &VulnerabilityAlertStore{
+		db: db,
+	}
+}
+
+// VulnerabilityAlertStore implements store.VulnerabilityAlertStore backed by a relational database.
+type VulnerabilityAlertStore struct {
+	db *sqlx.DB
+}
+
+type vulnerabilityAlert struct {
+	ID              int64                        `db:"vulnerability_alert_id"`
+	RepoID          int64                        `db:"vulnerability_alert_repo_id"`
+	VulnerabilityID string                       `db:"vulnerability_alert_vulnerability_id"`
+	Ecosystem       enum.DependencyEcosystem     `db:"vulnerability_alert_ecosystem"`
+	Package         string                       `db:"vulnerability_alert_package"`
+	Version         string                       `db:"vulnerability_alert_version"`
+	Manifest        string                       `db:"vulnerability_alert_manifest"`
+	Severity        enum.VulnerabilitySeverity   `db:"vulnerability_alert_severity"`
+	Summary         string                       `db:"vulnerability_alert_summary"`
+	FixedVersion    string                       `db:"vulnerability_alert_fixed_version"`
+	State           enum.VulnerabilityAlertState `db:"vulnerability_alert_state"`
+	Created         int64                        `db:"vulnerability_alert_created"`
+	Updated         int64                        `db:"vulnerability_alert_updated"`
+	Resolved        null.Int                     `db:"vulnerability_alert_resolved"`
+}
+
+const (
+	vulnerabilityAlertColumns = `
+		 vulnerability_alert_id
+		,vulnerability_alert_repo_id
+		,vulnerability_alert_vulnerability_id
+		,vulnerability_alert_ecosystem
+		,vulnerability_alert_package
+		,vulnerability_alert_version
+		,vulnerability_alert_manifest
+		,vulnerability_alert_severity
+		,vulnerability_alert_summary
+		,vulnerability_alert_fixed_version
+		,vulnerability_alert_state
+		,vulnerability_alert_created
+		,vulnerability_alert_updated
+		,vulnerability_alert_resolved`
+)
+
+// Find finds the vulnerability alert by id.
+func (s *VulnerabilityAlertStore) Find(ctx context.Context, id int64) (*types.VulnerabilityAlert, error) {
+	stmt := database.Builder.
+		Select(vulnerabilityAlertColumns).
+		From("vulnerability_alerts").
+		Where("vulnerability_alert_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &vulnerabilityAlert{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find vulnerability alert")
+	}
+
+	return mapToVulnerabilityAlert(dst), nil
+}
+
+// Create creates a new vulnerability alert.
+func (s *VulnerabilityAlertStore) Create(ctx context.Context, alert *types.VulnerabilityAlert) error {
+	const sqlQuery = `
+	INSERT INTO vulnerability_alerts (
+		 vulnerability_alert_repo_id
+		,vulnerability_alert_vulnerability_id
+		,vulnerability_alert_ecosystem
+		,vulnerability_alert_package
+		,vulnerability_alert_version
+		,vulnerability_alert_manifest
+		,vulnerability_alert_severity
+		,vulnerability_alert_summary
+		,vulnerability_alert_fixed_version
+		,vulnerability_alert_state
+		,vulnerability_alert_created
+		,vulnerability_alert_updated
+		,vulnerability_alert_resolved
+	) values (
+		 :vulnerability_alert_repo_id
+		,:vulnerability_alert_vulnerability_id
+		,:vulnerability_alert_ecosystem
+		,:vulnerability_alert_package
+		,:vulnerability_alert_version
+		,:vulnerability_alert_manifest
+		,:vulnerability_alert_severity
+		,:vulnerability_alert_summary
+		,:vulnerability_alert_fixed_version
+		,:vulnerability_alert_state
+		,:vulnerability_alert_created
+		,:vulnerability_alert_updated
+		,:vulnerability_alert_resolved
+	) RETURNING vulnerability_alert_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalVulnerabilityAlert(alert))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind vulnerability alert object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&alert.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the severity, the details and the state of the vulnerability alert.
+func (s *VulnerabilityAlertStore) Update(ctx context.Context, alert *types.VulnerabilityAlert) error {
+	const sqlQuery = `
+	UPDATE vulnerability_alerts
+	SET
+		 vulnerability_alert_manifest = :vulnerability_alert_manifest
+		,vulnerability_alert_severity = :vulnerability_alert_severity
+		,vulnerability_alert_summary = :vulnerability_alert_summary
+		,vulnerability_alert_fixed_version = :vulnerability_alert_fixed_version
+		,vulnerability_alert_state = :vulnerability_alert_state
+		,vulnerability_alert_updated = :vulnerability_alert_updated
+		,vulnerability_alert_resolved = :vulnerability_alert_resolved
+	WHERE vulnerability_alert_id = :vulnerability_alert_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalVulnerabilityAlert(alert))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind vulnerability alert object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update vulnerability alert")
+	}
+
+	return nil
+}
+
+// ListAll returns all vulnerability alerts of the repository, regardless of their state.
+func (s *VulnerabilityAlertStore) ListAll(ctx context.Context, repoID int64) ([]*types.VulnerabilityAlert, error) {
+	stmt := database.Builder.
+		Select(vulnerabilityAlertColumns).
+		From("vulnerability_alerts").
+		Where("vulnerability_alert_repo_id = ?", repoID).
+		OrderBy("vulnerability_alert_id")
+
+	return s.list(ctx, stmt)
+}
+
+// Count returns the number of vulnerability alerts of the repository that match the filter.
+func (s *VulnerabilityAlertStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.VulnerabilityAlertFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("vulnerability_alerts").
+		Where("vulnerability_alert_repo_id = ?", repoID)
+
+	stmt = applyVulnerabilityAlertFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns the vulnerability alerts of the repository that match the filter, newest first.
+func (s *VulnerabilityAlertStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.VulnerabilityAlertFilter,
+) ([]*types.VulnerabilityAlert, error) {
+	stmt := database.Builder.
+		Select(vulnerabilityAlertColumns).
+		From("vulnerability_alerts").
+		Where("vulnerability_alert_repo_id = ?", repoID)
+
+	stmt = applyVulnerabilityAlertFilter(stmt, filter)
+
+	stmt = stmt.
+		OrderBy("vulnerability_alert_created DESC", "vulnerability_alert_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *VulnerabilityAlertStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.VulnerabilityAlert, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*vulnerabilityAlert, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list query")
+	}
+
+	result := make([]*types.VulnerabilityAlert, len(dst))
+	for i, alert := range dst {
+		result[i] = mapToVulnerabilityAlert(alert)
+	}
+
+	return result, nil
+}
+
+func applyVulnerabilityAlertFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.VulnerabilityAlertFilter,
+) squirrel.SelectBuilder {
+	if len(filter.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"vulnerability_alert_state": filter.States})
+	}
+
+	return stmt
+}
+
+func mapToVulnerabilityAlert(alert *vulnerabilityAlert) *types.VulnerabilityAlert {
+	return &types.VulnerabilityAlert{
+		ID:              alert.ID,
+		RepoID:          alert.RepoID,
+		VulnerabilityID: alert.VulnerabilityID,
+		Ecosystem:       alert.Ecosystem,
+		Package:         alert.Package,
+		Version:         alert.Version,
+		Manifest:        alert.Manifest,
+		Severity:        alert.Severity,
+		Summary:         alert.Summary,
+		FixedVersion:    alert.FixedVersion,
+		State:           alert.State,
+		Created:         alert.Created,
+		Updated:         alert.Updated,
+		Resolved:        alert.Resolved.Ptr(),
+	}
+}
+
+func mapToInternalVulnerabilityAlert(alert *types.VulnerabilityAlert) *vulnerabilityAlert {
+	return &vulnerabilityAlert{
+		ID:              alert.ID,
+		RepoID:          alert.RepoID,
+		VulnerabilityID: alert.VulnerabilityID,
+		Ecosystem:       alert.Ecosystem,
+		Package:         alert.Package,
+		Version:         alert.Version,
+		Manifest:        alert.Manifest,
+		Severity:        alert.Severity,
+		Summary:         alert.Summary,
+		FixedVersion:    alert.FixedVersion,
+		State:           alert.State,
+		Created:         alert.Created,
+		Updated:         alert.Updated,
+		Resolved:        null.IntFromPtr(alert.Resolved),
+	}
+}
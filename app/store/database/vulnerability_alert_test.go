@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestVulnerabilityAlertStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	alertStore := database.NewVulnerabilityAlertStore(db)
+
+	for i, id := range []string{"GHSA-1", "GHSA-2"} {
+		alert := &types.VulnerabilityAlert{
+			RepoID:          1,
+			VulnerabilityID: id,
+			Ecosystem:       enum.DependencyEcosystemNPM,
+			Package:         "lodash",
+			Version:         "4.17.20",
+			Manifest:        "package.json",
+			Severity:        enum.VulnerabilitySeverityHigh,
+			State:           enum.VulnerabilityAlertStateOpen,
+			Created:         int64(i + 1),
+			Updated:         int64(i + 1),
+		}
+		if err := alertStore.Create(ctx, alert); err != nil {
+			t.Fatalf("failed to create alert: %v", err)
+		}
+	}
+
+	alerts, err := alertStore.ListAll(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list alerts: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+
+	resolved := int64(10)
+	alerts[0].State = enum.VulnerabilityAlertStateResolved
+	alerts[0].Resolved = &resolved
+	if err = alertStore.Update(ctx, alerts[0]); err != nil {
+		t.Fatalf("failed to update alert: %v", err)
+	}
+
+	filter := &types.VulnerabilityAlertFilter{States: []enum.VulnerabilityAlertState{enum.VulnerabilityAlertStateOpen}}
+
+	count, err := alertStore.Count(ctx, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to count alerts: %v", err)
+	}
+
+	open, err := alertStore.List(ctx, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to list alerts: %v", err)
+	}
+
+	if count != 1 || len(open) != 1 || open[0].VulnerabilityID != "GHSA-2" {
+		t.Errorf("expected only GHSA-2 to be open, got %d alerts", count)
+	}
+
+	alert, err := alertStore.Find(ctx, alerts[0].ID)
+	if err != nil {
+		t.Fatalf("failed to find alert: %v", err)
+	}
+
+	if alert.State != enum.VulnerabilityAlertStateResolved || alert.Resolved == nil || *alert.Resolved != resolved {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+}
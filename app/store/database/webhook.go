@@ -16,11 +16,13 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -35,15 +37,18 @@ import (
 var _ store.WebhookStore = (*WebhookStore)(nil)
 
 // NewWebhookStore returns a new WebhookStore.
-func NewWebhookStore(db *sqlx.DB) *WebhookStore {
+func NewWebhookStore(db *sqlx.DB, encrypter encrypt.Encrypter) *WebhookStore {
 	return &WebhookStore{
-		db: db,
+		db:        db,
+		encrypter: encrypter,
 	}
 }
 
 // WebhookStore implements store.Webhook backed by a relational database.
+// Webhook secrets are encrypted before they are stored in the database.
 type WebhookStore struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	encrypter encrypt.Encrypter
 }
 
 // webhook is an internal representation used to store webhook data in the database.
@@ -62,10 +67,11 @@ type webhook struct {
 	DisplayName           string      `db:"webhook_display_name"`
 	Description           string      `db:"webhook_description"`
 	URL                   string      `db:"webhook_url"`
-	Secret                string      `db:"webhook_secret"`
+	Secret                []byte      `db:"webhook_secret"`
 	Enabled               bool        `db:"webhook_enabled"`
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
+	Headers               string      `db:"webhook_headers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
 }
 
@@ -86,6 +92,7 @@ const (
 		,webhook_enabled
 		,webhook_insecure
 		,webhook_triggers
+		,webhook_headers
 		,webhook_latest_execution_result
 		,webhook_internal`
 
@@ -106,7 +113,7 @@ func (s *WebhookStore) Find(ctx context.Context, id int64) (*types.Webhook, erro
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
 	}
 
-	res, err := mapToWebhook(dst)
+	res, err := s.mapToWebhook(dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map webhook to external type: %w", err)
 	}
@@ -147,7 +154,7 @@ func (s *WebhookStore) FindByIdentifier(
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
 	}
 
-	res, err := mapToWebhook(dst)
+	res, err := s.mapToWebhook(dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map webhook to external type: %w", err)
 	}
@@ -172,6 +179,7 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled
 			,webhook_insecure
 			,webhook_triggers
+			,webhook_headers
 			,webhook_latest_execution_result
 			,webhook_internal
 		) values (
@@ -188,13 +196,14 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_enabled
 			,:webhook_insecure
 			,:webhook_triggers
+			,:webhook_headers
 			,:webhook_latest_execution_result
 			,:webhook_internal
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dbHook, err := mapToInternalWebhook(hook)
+	dbHook, err := s.mapToInternalWebhook(hook)
 	if err != nil {
 		return fmt.Errorf("failed to map webhook to internal db type: %w", err)
 	}
@@ -226,13 +235,14 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
+			,webhook_headers = :webhook_headers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dbHook, err := mapToInternalWebhook(hook)
+	dbHook, err := s.mapToInternalWebhook(hook)
 	if err != nil {
 		return fmt.Errorf("failed to map webhook to internal db type: %w", err)
 	}
@@ -437,7 +447,7 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
 	}
 
-	res, err := mapToWebhooks(dst)
+	res, err := s.mapToWebhooks(dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map webhooks to external type: %w", err)
 	}
@@ -445,7 +455,17 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 	return res, nil
 }
 
-func mapToWebhook(hook *webhook) (*types.Webhook, error) {
+func (s *WebhookStore) mapToWebhook(hook *webhook) (*types.Webhook, error) {
+	secret, err := s.decryptSecret(hook.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret of hook %d: %w", hook.ID, err)
+	}
+
+	headers, err := headersFromString(hook.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse headers of hook %d: %w", hook.ID, err)
+	}
+
 	res := &types.Webhook{
 		ID:         hook.ID,
 		Version:    hook.Version,
@@ -457,10 +477,11 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		DisplayName:           hook.DisplayName,
 		Description:           hook.Description,
 		URL:                   hook.URL,
-		Secret:                hook.Secret,
+		Secret:                secret,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersFromString(hook.Triggers),
+		Headers:               headers,
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
 	}
@@ -481,7 +502,17 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 	return res, nil
 }
 
-func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
+func (s *WebhookStore) mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
+	secret, err := s.encryptSecret(hook.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret of hook %d: %w", hook.ID, err)
+	}
+
+	headers, err := headersToString(hook.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize headers of hook %d: %w", hook.ID, err)
+	}
+
 	res := &webhook{
 		ID:         hook.ID,
 		Version:    hook.Version,
@@ -493,10 +524,11 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		DisplayName:           hook.DisplayName,
 		Description:           hook.Description,
 		URL:                   hook.URL,
-		Secret:                hook.Secret,
+		Secret:                secret,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersToString(hook.Triggers),
+		Headers:               headers,
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
 	}
@@ -513,11 +545,11 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 	return res, nil
 }
 
-func mapToWebhooks(hooks []*webhook) ([]*types.Webhook, error) {
+func (s *WebhookStore) mapToWebhooks(hooks []*webhook) ([]*types.Webhook, error) {
 	var err error
 	m := make([]*types.Webhook, len(hooks))
 	for i, hook := range hooks {
-		m[i], err = mapToWebhook(hook)
+		m[i], err = s.mapToWebhook(hook)
 		if err != nil {
 			return nil, err
 		}
@@ -525,6 +557,30 @@ func mapToWebhooks(hooks []*webhook) ([]*types.Webhook, error) {
 	return m, nil
 }
 
+// ReencryptSecrets re-encrypts the secrets of all webhooks using the provided function.
+func (s *WebhookStore) ReencryptSecrets(
+	ctx context.Context,
+	reencrypt func(ciphertext []byte) ([]byte, bool, error),
+) (int64, error) {
+	return database.Reencrypt(ctx, s.db, "webhooks", "webhook_id", "webhook_secret", reencrypt)
+}
+
+// encryptSecret encrypts the webhook secret. An empty secret is stored as is.
+func (s *WebhookStore) encryptSecret(secret string) ([]byte, error) {
+	if secret == "" {
+		return []byte{}, nil
+	}
+	return s.encrypter.Encrypt(secret)
+}
+
+// decryptSecret decrypts the webhook secret. An empty secret is returned as is.
+func (s *WebhookStore) decryptSecret(secret []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", nil
+	}
+	return s.encrypter.Decrypt(secret)
+}
+
 // triggersSeparator defines the character that's used to join triggers for storing them in the DB
 // ASSUMPTION: triggers are defined in an enum and don't contain ",".
 const triggersSeparator = ","
@@ -553,3 +609,30 @@ func triggersToString(triggers []enum.WebhookTrigger) string {
 
 	return strings.Join(rawTriggers, triggersSeparator)
 }
+
+// headersFromString parses the custom headers of a webhook as stored in the DB (json, or empty if none).
+func headersFromString(headersString string) (map[string]string, error) {
+	var headers map[string]string
+	if headersString == "" {
+		return headers, nil
+	}
+
+	if err := json.Unmarshal([]byte(headersString), &headers); err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+func headersToString(headers map[string]string) (string, error) {
+	if len(headers) == 0 {
+		return "", nil
+	}
+
+	raw, err := json.Marshal(headers)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
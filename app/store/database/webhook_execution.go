@@ -193,7 +193,13 @@ func (s *WebhookExecutionStore) ListForWebhook(ctx context.Context, webhookID in
 		Where("webhook_execution_webhook_id = ?", webhookID)
 
 	stmt = stmt.Limit(database.Limit(opts.Size))
-	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+
+	// the ordering is by ID only, hence the cursor can be applied without looking up the anchor row.
+	if opts.CursorID > 0 {
+		stmt = stmt.Where("webhook_execution_id < ?", opts.CursorID)
+	} else {
+		stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	}
 
 	// fixed ordering by desc id (new ones first) - add customized ordering if deemed necessary.
 	stmt = stmt.OrderBy("webhook_execution_id DESC")
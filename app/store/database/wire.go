@@ -16,14 +16,19 @@ package database
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
 
 	"github.com/google/wire"
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 // WireSet provides a wire set for this package.
@@ -45,18 +50,49 @@ var WireSet = wire.NewSet(
 	ProvideMembershipStore,
 	ProvideTokenStore,
 	ProvidePullReqStore,
+	ProvideIssueStore,
 	ProvidePullReqActivityStore,
+	ProvidePullReqActivityEditStore,
+	ProvideSavedReplyStore,
+	ProvideReviewReminderStore,
+	ProvideMergeScheduleStore,
 	ProvideCodeCommentView,
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
+	ProvideSystemEventStore,
+	ProvideRepoEventStore,
+	ProvideIdempotencyKeyStore,
+	ProvideAuditLogStore,
+	ProvideExternalHookStore,
+	ProvideIntegrationStore,
+	ProvidePushedBranchStore,
+	ProvideRepoInsightsStore,
+	ProvideRepoLanguagesStore,
+	ProvideRepoDependenciesStore,
+	ProvideVulnerabilityAlertStore,
+	ProvidePushStore,
+	ProvideMalwareFindingStore,
+	ProvideUserActivityStore,
+	ProvideAnnouncementStore,
+	ProvideInvitationStore,
+	ProvideRunnerRegistrationTokenStore,
+	ProvideRunnerStore,
+	ProvideRegistryStore,
+	ProvideRegistryArtifactStore,
+	ProvideRegistryVersionStore,
+	ProvideRegistryBlobStore,
+	ProvideRegistryUploadStore,
+	ProvideSettingsStore,
+	ProvideUsageMetricStore,
 	ProvideCheckStore,
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
 	ProvidePluginStore,
+	ProvideEventOutboxStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -66,18 +102,34 @@ func migrator(ctx context.Context, db *sqlx.DB) error {
 }
 
 // ProvideDatabase provides a database connection.
+// The connection pool statistics are exposed as prometheus metrics.
 func ProvideDatabase(ctx context.Context, config database.Config) (*sqlx.DB, error) {
-	return database.ConnectAndMigrate(
+	db, err := database.ConnectAndMigrate(
 		ctx,
 		config.Driver,
 		config.Datasource,
 		migrator,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	database.ConfigurePool(db, config)
+
+	if err = prometheus.Register(collectors.NewDBStatsCollector(db.DB, "gitness")); err != nil {
+		return nil, fmt.Errorf("failed to register database stats collector: %w", err)
+	}
+
+	return db, nil
 }
 
 // ProvidePrincipalStore provides a principal store.
-func ProvidePrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransformation) store.PrincipalStore {
-	return NewPrincipalStore(db, uidTransformation)
+func ProvidePrincipalStore(
+	db *sqlx.DB,
+	uidTransformation store.PrincipalUIDTransformation,
+	rowCache store.RowCache,
+) store.PrincipalStore {
+	return NewPrincipalStore(db, uidTransformation, rowCache)
 }
 
 // ProvidePrincipalInfoView provides a principal info store.
@@ -108,8 +160,9 @@ func ProvideRepoStore(
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
 	spaceStore store.SpaceStore,
+	rowCache store.RowCache,
 ) store.RepoStore {
-	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore, rowCache)
 }
 
 // ProvideRuleStore provides a rule store.
@@ -185,8 +238,8 @@ func ProvideMembershipStore(
 }
 
 // ProvideTokenStore provides a token store.
-func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
-	return NewTokenStore(db)
+func ProvideTokenStore(db *sqlx.DB, rowCache store.RowCache) store.TokenStore {
+	return NewTokenStore(db, rowCache)
 }
 
 // ProvidePullReqStore provides a pull request store.
@@ -196,6 +249,13 @@ func ProvidePullReqStore(db *sqlx.DB,
 	return NewPullReqStore(db, principalInfoCache)
 }
 
+// ProvideIssueStore provides an issue store.
+func ProvideIssueStore(db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueStore {
+	return NewIssueStore(db, principalInfoCache)
+}
+
 // ProvidePullReqActivityStore provides a pull request activity store.
 func ProvidePullReqActivityStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
@@ -203,6 +263,28 @@ func ProvidePullReqActivityStore(db *sqlx.DB,
 	return NewPullReqActivityStore(db, principalInfoCache)
 }
 
+// ProvidePullReqActivityEditStore provides a pull request comment edit history store.
+func ProvidePullReqActivityEditStore(db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.PullReqActivityEditStore {
+	return NewPullReqActivityEditStore(db, principalInfoCache)
+}
+
+// ProvideSavedReplyStore provides a saved reply store.
+func ProvideSavedReplyStore(db *sqlx.DB) store.SavedReplyStore {
+	return NewSavedReplyStore(db)
+}
+
+// ProvideReviewReminderStore provides a review reminder store.
+func ProvideReviewReminderStore(db *sqlx.DB) store.ReviewReminderStore {
+	return NewReviewReminderStore(db)
+}
+
+// ProvideMergeScheduleStore provides a merge schedule store.
+func ProvideMergeScheduleStore(db *sqlx.DB) store.MergeScheduleStore {
+	return NewMergeScheduleStore(db)
+}
+
 // ProvideCodeCommentView provides a code comment view.
 func ProvideCodeCommentView(db *sqlx.DB) store.CodeCommentView {
 	return NewCodeCommentView(db)
@@ -226,8 +308,8 @@ func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 }
 
 // ProvideWebhookStore provides a webhook store.
-func ProvideWebhookStore(db *sqlx.DB) store.WebhookStore {
-	return NewWebhookStore(db)
+func ProvideWebhookStore(db *sqlx.DB, encrypter encrypt.Encrypter) store.WebhookStore {
+	return NewWebhookStore(db, encrypter)
 }
 
 // ProvideWebhookExecutionStore provides a webhook execution store.
@@ -235,9 +317,139 @@ func ProvideWebhookExecutionStore(db *sqlx.DB) store.WebhookExecutionStore {
 	return NewWebhookExecutionStore(db)
 }
 
+// ProvideSystemEventStore provides a system event store.
+func ProvideSystemEventStore(db *sqlx.DB) store.SystemEventStore {
+	return NewSystemEventStore(db)
+}
+
+// ProvideRepoEventStore provides a repository event store.
+func ProvideRepoEventStore(db *sqlx.DB) store.RepoEventStore {
+	return NewRepoEventStore(db)
+}
+
+// ProvideIdempotencyKeyStore provides an idempotency key store.
+func ProvideIdempotencyKeyStore(db *sqlx.DB) store.IdempotencyKeyStore {
+	return NewIdempotencyKeyStore(db)
+}
+
+// ProvideExternalHookStore provides an external hook store.
+func ProvideExternalHookStore(db *sqlx.DB, encrypter encrypt.Encrypter) store.ExternalHookStore {
+	return NewExternalHookStore(db, encrypter)
+}
+
+// ProvideAuditLogStore provides an audit log store.
+func ProvideAuditLogStore(db *sqlx.DB) store.AuditLogStore {
+	return NewAuditLogStore(db)
+}
+
 // ProvideCheckStore provides a status check result store.
 func ProvideCheckStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
 ) store.CheckStore {
 	return NewCheckStore(db, principalInfoCache)
 }
+
+// ProvideEventOutboxStore provides an event outbox store.
+func ProvideEventOutboxStore(db *sqlx.DB) events.OutboxStore {
+	return NewEventOutboxStore(db)
+}
+
+// ProvidePushedBranchStore provides a pushed branch store.
+func ProvidePushedBranchStore(db *sqlx.DB) store.PushedBranchStore {
+	return NewPushedBranchStore(db)
+}
+
+// ProvideRepoInsightsStore provides a repo insights store.
+func ProvideRepoInsightsStore(db *sqlx.DB) store.RepoInsightsStore {
+	return NewRepoInsightsStore(db)
+}
+
+// ProvideRepoLanguagesStore provides a repo languages store.
+func ProvideRepoLanguagesStore(db *sqlx.DB) store.RepoLanguagesStore {
+	return NewRepoLanguagesStore(db)
+}
+
+// ProvideRepoDependenciesStore provides a repo dependencies store.
+func ProvideRepoDependenciesStore(db *sqlx.DB) store.RepoDependenciesStore {
+	return NewRepoDependenciesStore(db)
+}
+
+// ProvideVulnerabilityAlertStore provides a vulnerability alert store.
+func ProvideVulnerabilityAlertStore(db *sqlx.DB) store.VulnerabilityAlertStore {
+	return NewVulnerabilityAlertStore(db)
+}
+
+// ProvidePushStore provides a push store.
+func ProvidePushStore(db *sqlx.DB) store.PushStore {
+	return NewPushStore(db)
+}
+
+// ProvideMalwareFindingStore provides a malware finding store.
+func ProvideMalwareFindingStore(db *sqlx.DB) store.MalwareFindingStore {
+	return NewMalwareFindingStore(db)
+}
+
+// ProvideUserActivityStore provides a user activity store.
+func ProvideUserActivityStore(db *sqlx.DB) store.UserActivityStore {
+	return NewUserActivityStore(db)
+}
+
+// ProvideAnnouncementStore provides an announcement store.
+func ProvideAnnouncementStore(db *sqlx.DB) store.AnnouncementStore {
+	return NewAnnouncementStore(db)
+}
+
+// ProvideInvitationStore provides a user invitation store.
+func ProvideInvitationStore(db *sqlx.DB) store.InvitationStore {
+	return NewInvitationStore(db)
+}
+
+// ProvideRunnerRegistrationTokenStore provides a runner registration token store.
+func ProvideRunnerRegistrationTokenStore(db *sqlx.DB) store.RunnerRegistrationTokenStore {
+	return NewRunnerRegistrationTokenStore(db)
+}
+
+// ProvideRunnerStore provides a runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvideSettingsStore provides a settings store.
+func ProvideSettingsStore(db *sqlx.DB) store.SettingsStore {
+	return NewSettingsStore(db)
+}
+
+// ProvideIntegrationStore provides an integration store.
+func ProvideIntegrationStore(db *sqlx.DB, encrypter encrypt.Encrypter) store.IntegrationStore {
+	return NewIntegrationStore(db, encrypter)
+}
+
+// ProvideUsageMetricStore provides a usage metric store.
+func ProvideUsageMetricStore(db *sqlx.DB) store.UsageMetricStore {
+	return NewUsageMetricStore(db)
+}
+
+// ProvideRegistryStore provides a registry store.
+func ProvideRegistryStore(db *sqlx.DB) store.RegistryStore {
+	return NewRegistryStore(db)
+}
+
+// ProvideRegistryArtifactStore provides a registry artifact store.
+func ProvideRegistryArtifactStore(db *sqlx.DB) store.RegistryArtifactStore {
+	return NewRegistryArtifactStore(db)
+}
+
+// ProvideRegistryVersionStore provides a registry version store.
+func ProvideRegistryVersionStore(db *sqlx.DB) store.RegistryVersionStore {
+	return NewRegistryVersionStore(db)
+}
+
+// ProvideRegistryBlobStore provides a registry blob store.
+func ProvideRegistryBlobStore(db *sqlx.DB) store.RegistryBlobStore {
+	return NewRegistryBlobStore(db)
+}
+
+// ProvideRegistryUploadStore provides a registry upload store.
+func ProvideRegistryUploadStore(db *sqlx.DB) store.RegistryUploadStore {
+	return NewRegistryUploadStore(db)
+}
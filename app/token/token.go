@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
@@ -27,17 +28,14 @@ import (
 	"github.com/gotidy/ptr"
 )
 
-const (
-	// userSessionTokenLifeTime is the duration a login / register token is valid.
-	// NOTE: Users can list / delete session tokens via rest API if they want to cleanup earlier.
-	userSessionTokenLifeTime time.Duration = 30 * 24 * time.Hour // 30 days.
-)
-
+// CreateUserSession creates a login / register token that's valid for the provided lifetime.
+// NOTE: Users can list / delete session tokens via rest API if they want to cleanup earlier.
 func CreateUserSession(
 	ctx context.Context,
 	tokenStore store.TokenStore,
 	user *types.User,
 	identifier string,
+	lifetime time.Duration,
 ) (*types.Token, string, error) {
 	principal := user.ToPrincipal()
 	return create(
@@ -47,7 +45,8 @@ func CreateUserSession(
 		principal,
 		principal,
 		identifier,
-		ptr.Duration(userSessionTokenLifeTime),
+		ptr.Duration(lifetime),
+		nil,
 	)
 }
 
@@ -58,6 +57,7 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	scope *jwt.SubClaimsMembership,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -67,6 +67,7 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scope,
 	)
 }
 
@@ -77,6 +78,7 @@ func CreateSAT(
 	createdFor *types.ServiceAccount,
 	identifier string,
 	lifetime *time.Duration,
+	scope *jwt.SubClaimsMembership,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -86,9 +88,37 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scope,
 	)
 }
 
+// ResolveScope returns the jwt claims for the provided token scope.
+// Returns nil if no scope is provided.
+func ResolveScope(
+	ctx context.Context,
+	spaceStore store.SpaceStore,
+	scope *types.TokenScope,
+) (*jwt.SubClaimsMembership, error) {
+	if scope == nil {
+		return nil, nil
+	}
+
+	role, ok := scope.Role.Sanitize()
+	if !ok || role == "" {
+		return nil, usererror.BadRequestf("Token scope role '%s' is invalid.", scope.Role)
+	}
+
+	space, err := spaceStore.FindByRef(ctx, scope.SpaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of token scope: %w", err)
+	}
+
+	return &jwt.SubClaimsMembership{
+		SpaceID: space.ID,
+		Role:    role,
+	}, nil
+}
+
 func create(
 	ctx context.Context,
 	tokenStore store.TokenStore,
@@ -97,6 +127,7 @@ func create(
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	scope *jwt.SubClaimsMembership,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -120,8 +151,8 @@ func create(
 		return nil, "", fmt.Errorf("failed to store token in db: %w", err)
 	}
 
-	// create jwt token.
-	jwtToken, err := jwt.GenerateForToken(&token, createdFor.Salt)
+	// create jwt token, the optional scope is part of the signed claims and can't be altered.
+	jwtToken, err := jwt.GenerateForToken(&token, scope, createdFor.Salt)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create jwt token: %w", err)
 	}
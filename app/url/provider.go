@@ -40,6 +40,9 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GetInternalAPIURL() string
 
+	// GenerateAPIURL returns the public url of the api endpoint with the provided (escaped) path.
+	GenerateAPIURL(escapedPath string) string
+
 	// GenerateContainerGITCloneURL generates a URL that can be used by CI container builds to
 	// interact with gitness and clone a repo.
 	GenerateContainerGITCloneURL(repoPath string) string
@@ -57,6 +60,18 @@ type Provider interface {
 	// GenerateUICompareURL returns the url for the UI screen comparing two references.
 	GenerateUICompareURL(repoPath string, ref1 string, ref2 string) string
 
+	// GenerateUIFileURL returns the url for the UI screen of a file (or directory) of a repository.
+	GenerateUIFileURL(repoPath string, gitRef string, filePath string) string
+
+	// GenerateUIIssueURL returns the url for the UI screen of an existing issue.
+	GenerateUIIssueURL(repoPath string, issueNumber int64) string
+
+	// GenerateUIUserURL returns the url for the UI screen of a user.
+	GenerateUIUserURL(userUID string) string
+
+	// GenerateUIRegisterURL returns the url for the UI screen to register using the invitation token.
+	GenerateUIRegisterURL(invitationToken string) string
+
 	// GetAPIHostname returns the host for the api endpoint.
 	GetAPIHostname() string
 
@@ -143,6 +158,10 @@ func (p *provider) GetInternalAPIURL() string {
 	return p.internalURL.JoinPath(APIMount).String()
 }
 
+func (p *provider) GenerateAPIURL(escapedPath string) string {
+	return p.apiURL.String() + "/" + strings.TrimLeft(escapedPath, "/")
+}
+
 func (p *provider) GenerateContainerGITCloneURL(repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
@@ -178,6 +197,24 @@ func (p *provider) GenerateUICompareURL(repoPath string, ref1 string, ref2 strin
 	return p.uiURL.JoinPath(repoPath, "pulls/compare", ref1+"..."+ref2).String()
 }
 
+func (p *provider) GenerateUIFileURL(repoPath string, gitRef string, filePath string) string {
+	return p.uiURL.JoinPath(repoPath, "files", gitRef, "~", filePath).String()
+}
+
+func (p *provider) GenerateUIIssueURL(repoPath string, issueNumber int64) string {
+	return p.uiURL.JoinPath(repoPath, "issues", fmt.Sprint(issueNumber)).String()
+}
+
+func (p *provider) GenerateUIUserURL(userUID string) string {
+	return p.uiURL.JoinPath("users", userUID).String()
+}
+
+func (p *provider) GenerateUIRegisterURL(invitationToken string) string {
+	u := p.uiURL.JoinPath("register")
+	u.RawQuery = url.Values{"invitation": []string{invitationToken}}.Encode()
+	return u.String()
+}
+
 func (p *provider) GetAPIHostname() string {
 	return p.apiURL.Hostname()
 }
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package writefreeze provides a flag that temporarily blocks all write operations,
+// e.g. while a backup is taken.
+package writefreeze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const fileName = "write-freeze"
+
+// Info describes an active write freeze.
+type Info struct {
+	Reason  string `json:"reason"`
+	Created int64  `json:"created"`
+}
+
+// Flag is a file based write freeze flag.
+// The file is stored in the git root, that way it's shared by everything operating on the same data.
+type Flag struct {
+	path string
+}
+
+// New returns a new write freeze flag stored in the provided directory.
+func New(root string) *Flag {
+	return &Flag{
+		path: filepath.Join(root, fileName),
+	}
+}
+
+// Set activates the write freeze. It fails if the write freeze is already active.
+func (f *Flag) Set(reason string) error {
+	data, err := json.Marshal(Info{
+		Reason:  reason,
+		Created: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal write freeze info: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("write freeze is already active (%s)", f.path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create write freeze file: %w", err)
+	}
+
+	_, err = file.Write(data)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(f.path)
+		return fmt.Errorf("failed to write write freeze file: %w", err)
+	}
+
+	return nil
+}
+
+// Clear deactivates the write freeze. It's a noop if the write freeze isn't active.
+func (f *Flag) Clear() error {
+	err := os.Remove(f.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove write freeze file: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the info of the active write freeze, or nil if writes aren't frozen.
+func (f *Flag) Get() (*Info, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil //nolint:nilnil // writes aren't frozen
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read write freeze file: %w", err)
+	}
+
+	info := &Info{}
+	if err = json.Unmarshal(data, info); err != nil {
+		// the file existing is what counts, its content is informational only.
+		return &Info{}, nil //nolint:nilerr // freeze is active regardless of the content
+	}
+
+	return info, nil
+}
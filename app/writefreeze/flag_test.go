@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writefreeze
+
+import (
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	flag := New(t.TempDir())
+
+	info, err := flag.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info != nil {
+		t.Fatalf("expected no active freeze, got %+v", info)
+	}
+
+	if err = flag.Set("backup"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = flag.Set("another backup"); err == nil {
+		t.Fatal("expected error when setting an active freeze")
+	}
+
+	info, err = flag.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info == nil || info.Reason != "backup" || info.Created == 0 {
+		t.Fatalf("unexpected freeze info: %+v", info)
+	}
+
+	if err = flag.Clear(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = flag.Clear(); err != nil {
+		t.Fatalf("clear of an inactive freeze should be a noop: %v", err)
+	}
+
+	info, err = flag.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info != nil {
+		t.Fatalf("expected no active freeze after clear, got %+v", info)
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// azureAPIVersion is the version of the blob service REST API used for requests and signed URLs.
+	azureAPIVersion = "2020-12-06"
+
+	azureDefaultEndpointFmt = "https://%s.blob.core.windows.net"
+
+	// azureBlockSize is the size of the blocks larger files are uploaded in.
+	azureBlockSize = 8 << 20
+
+	azureTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+// AzureStore stores files as block blobs in an Azure storage container (the configured bucket).
+// The blob service REST API is used directly, requests are authorized with the shared key of the account.
+type AzureStore struct {
+	config   Config
+	endpoint *url.URL
+	key      []byte
+	client   *http.Client
+}
+
+func NewAzureStore(cfg Config) (Store, error) {
+	if cfg.Azure.AccountName == "" || cfg.Azure.AccountKey == "" {
+		return nil, errors.New("azure blob store requires an account name and account key")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cfg.Azure.AccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode azure account key: %w", err)
+	}
+
+	endpoint := cfg.Azure.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf(azureDefaultEndpointFmt, cfg.Azure.AccountName)
+	}
+
+	endpointURL, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse azure blob endpoint: %w", err)
+	}
+
+	// path-style endpoints (e.g. the azurite emulator) address the account in the first path segment.
+	if isAzurePathStyleHost(endpointURL.Hostname()) &&
+		!strings.HasPrefix(endpointURL.Path+"/", "/"+cfg.Azure.AccountName+"/") {
+		endpointURL.Path = path.Join("/", cfg.Azure.AccountName, endpointURL.Path)
+	}
+
+	return &AzureStore{
+		config:   cfg,
+		endpoint: endpointURL,
+		key:      key,
+		client:   &http.Client{},
+	}, nil
+}
+
+func (c *AzureStore) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	buf := make([]byte, azureBlockSize)
+
+	var blockIDs []string
+	for {
+		n, err := io.ReadFull(file, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		last := err != nil
+
+		// small files are uploaded with a single request.
+		if last && len(blockIDs) == 0 {
+			return c.putBlob(ctx, filePath, buf[:n])
+		}
+
+		if n > 0 {
+			blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", len(blockIDs))))
+			if err := c.putBlock(ctx, filePath, blockID, buf[:n]); err != nil {
+				return err
+			}
+			blockIDs = append(blockIDs, blockID)
+		}
+
+		if last {
+			break
+		}
+	}
+
+	// uncommitted blocks of failed uploads are removed by the service automatically.
+	return c.putBlockList(ctx, filePath, blockIDs)
+}
+
+func (c *AzureStore) putBlob(ctx context.Context, filePath string, data []byte) error {
+	header := c.encryptionHeader()
+	header.Set("x-ms-blob-type", "BlockBlob")
+
+	resp, err := c.do(ctx, http.MethodPut, c.blobURL(filePath, nil), header, data)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %s to container: %s %w", filePath, c.config.Bucket, err)
+	}
+	defer c.closeBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to write file: %s to container: %s %w", filePath, c.config.Bucket,
+			azureResponseError(resp))
+	}
+
+	return nil
+}
+
+func (c *AzureStore) putBlock(ctx context.Context, filePath string, blockID string, data []byte) error {
+	query := url.Values{}
+	query.Set("comp", "block")
+	query.Set("blockid", blockID)
+
+	resp, err := c.do(ctx, http.MethodPut, c.blobURL(filePath, query), c.encryptionHeader(), data)
+	if err != nil {
+		return fmt.Errorf("failed to write block of file: %s to container: %s %w", filePath, c.config.Bucket, err)
+	}
+	defer c.closeBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to write block of file: %s to container: %s %w", filePath, c.config.Bucket,
+			azureResponseError(resp))
+	}
+
+	return nil
+}
+
+func (c *AzureStore) putBlockList(ctx context.Context, filePath string, blockIDs []string) error {
+	blockList := struct {
+		XMLName xml.Name `xml:"BlockList"`
+		Latest  []string `xml:"Latest"`
+	}{Latest: blockIDs}
+
+	data, err := xml.Marshal(blockList)
+	if err != nil {
+		return fmt.Errorf("failed to marshal block list: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("comp", "blocklist")
+
+	header := c.encryptionHeader()
+	header.Set("Content-Type", "application/xml")
+
+	resp, err := c.do(ctx, http.MethodPut, c.blobURL(filePath, query), header, append([]byte(xml.Header), data...))
+	if err != nil {
+		return fmt.Errorf("failed to commit file: %s to container: %s %w", filePath, c.config.Bucket, err)
+	}
+	defer c.closeBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to commit file: %s to container: %s %w", filePath, c.config.Bucket,
+			azureResponseError(resp))
+	}
+
+	return nil
+}
+
+// GetSignedURL returns a URL with a service shared access signature that allows reading the blob.
+func (c *AzureStore) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	return c.signedURL(filePath, time.Now().Add(c.config.signedURLExpiry())), nil
+}
+
+// signedURL returns the URL of the blob with a service shared access signature that expires at the provided time.
+// See https://learn.microsoft.com/en-us/rest/api/storageservices/create-service-sas.
+func (c *AzureStore) signedURL(filePath string, expiresAt time.Time) string {
+	expiry := expiresAt.UTC().Format(azureTimeFormat)
+
+	protocol := "https,http"
+	if c.endpoint.Scheme == "https" {
+		protocol = "https"
+	}
+
+	canonicalizedResource := "/blob/" + c.config.Azure.AccountName + "/" + c.config.Bucket + "/" +
+		strings.TrimPrefix(filePath, "/")
+
+	stringToSign := strings.Join([]string{
+		"r",    // signed permissions
+		"",     // signed start
+		expiry, // signed expiry
+		canonicalizedResource,
+		"", // signed identifier
+		"", // signed ip
+		protocol,
+		azureAPIVersion,
+		"b", // signed resource
+		"",  // signed snapshot time
+		"",  // signed encryption scope
+		"",  // cache control
+		"",  // content disposition
+		"",  // content encoding
+		"",  // content language
+		"",  // content type
+	}, "\n")
+
+	query := url.Values{}
+	query.Set("sv", azureAPIVersion)
+	query.Set("sr", "b")
+	query.Set("sp", "r")
+	query.Set("se", expiry)
+	query.Set("spr", protocol)
+	query.Set("sig", c.sign(stringToSign))
+
+	return c.blobURL(filePath, query).String()
+}
+
+func (c *AzureStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.blobURL(filePath, nil), http.Header{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %s from container: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		c.closeBody(ctx, resp)
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer c.closeBody(ctx, resp)
+		return nil, fmt.Errorf("failed to read file: %s from container: %s %w", filePath, c.config.Bucket,
+			azureResponseError(resp))
+	}
+
+	return resp.Body, nil
+}
+
+func (c *AzureStore) Delete(ctx context.Context, filePath string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.blobURL(filePath, nil), http.Header{}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %s from container: %s %w", filePath, c.config.Bucket, err)
+	}
+	defer c.closeBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete file: %s from container: %s %w", filePath, c.config.Bucket,
+			azureResponseError(resp))
+	}
+
+	return nil
+}
+
+// CheckHealth verifies that the container is accessible.
+func (c *AzureStore) CheckHealth(ctx context.Context) error {
+	u := *c.endpoint
+	u.Path = path.Join("/", c.endpoint.Path, c.config.Bucket)
+	u.RawQuery = "restype=container"
+
+	resp, err := c.do(ctx, http.MethodGet, &u, http.Header{}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get properties of container %s: %w", c.config.Bucket, err)
+	}
+	defer c.closeBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get properties of container %s: %w", c.config.Bucket, azureResponseError(resp))
+	}
+
+	return nil
+}
+
+func (c *AzureStore) blobURL(filePath string, query url.Values) *url.URL {
+	u := *c.endpoint
+	u.Path = path.Join("/", c.endpoint.Path, c.config.Bucket, filePath)
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (c *AzureStore) encryptionHeader() http.Header {
+	header := http.Header{}
+	if c.config.Azure.EncryptionScope != "" {
+		header.Set("x-ms-encryption-scope", c.config.Azure.EncryptionScope)
+	}
+	return header
+}
+
+func (c *AzureStore) do(
+	ctx context.Context,
+	method string,
+	u *url.URL,
+	header http.Header,
+	data []byte,
+) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header = header
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("Authorization", "SharedKey "+c.config.Azure.AccountName+":"+c.sign(c.stringToSign(req)))
+
+	return c.client.Do(req)
+}
+
+// stringToSign returns the string to sign of a request authorized with shared key.
+// See https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func (c *AzureStore) stringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var b strings.Builder
+	b.WriteString(req.Method + "\n")
+	b.WriteString(req.Header.Get("Content-Encoding") + "\n")
+	b.WriteString(req.Header.Get("Content-Language") + "\n")
+	b.WriteString(contentLength + "\n")
+	b.WriteString(req.Header.Get("Content-MD5") + "\n")
+	b.WriteString(req.Header.Get("Content-Type") + "\n")
+	b.WriteString(req.Header.Get("Date") + "\n")
+	b.WriteString(req.Header.Get("If-Modified-Since") + "\n")
+	b.WriteString(req.Header.Get("If-Match") + "\n")
+	b.WriteString(req.Header.Get("If-None-Match") + "\n")
+	b.WriteString(req.Header.Get("If-Unmodified-Since") + "\n")
+	b.WriteString(req.Header.Get("Range") + "\n")
+
+	// canonicalized headers
+	var msHeaders []string
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower)
+		}
+	}
+	sort.Strings(msHeaders)
+	for _, name := range msHeaders {
+		b.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+
+	// canonicalized resource - for path-style endpoints the account name is part of the path as well,
+	// so it's included twice (e.g. /devstoreaccount1/devstoreaccount1/container/blob).
+	b.WriteString("/" + c.config.Azure.AccountName)
+	if escapedPath := req.URL.EscapedPath(); escapedPath != "" {
+		b.WriteString(escapedPath)
+	} else {
+		b.WriteString("/")
+	}
+
+	query := map[string][]string{}
+	for name, values := range req.URL.Query() {
+		name = strings.ToLower(name)
+		query[name] = append(query[name], values...)
+	}
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		b.WriteString("\n" + name + ":" + strings.Join(values, ","))
+	}
+
+	return b.String()
+}
+
+// isAzurePathStyleHost returns true if the host of a blob endpoint doesn't contain the account name.
+// This is the case for IP addresses and localhost, as used by the azurite emulator.
+func isAzurePathStyleHost(host string) bool {
+	return host == "localhost" || net.ParseIP(host) != nil
+}
+
+func (c *AzureStore) sign(stringToSign string) string {
+	h := hmac.New(sha256.New, c.key)
+	_, _ = h.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func (c *AzureStore) closeBody(ctx context.Context, resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to close azure response body")
+	}
+}
+
+func azureResponseError(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+}
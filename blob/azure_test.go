@@ -0,0 +1,241 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// azuriteAccountKey is the well-known account key of the azurite emulator.
+const azuriteAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+func newTestAzureStore(t *testing.T, accountName, endpoint string) *AzureStore {
+	t.Helper()
+
+	store, err := NewAzureStore(Config{
+		Provider: ProviderAzure,
+		Bucket:   "artifacts",
+		Azure: AzureConfig{
+			AccountName: accountName,
+			AccountKey:  azuriteAccountKey,
+			Endpoint:    endpoint,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create azure store: %v", err)
+	}
+
+	c, ok := store.(*AzureStore)
+	if !ok {
+		t.Fatalf("unexpected store type %T", store)
+	}
+
+	return c
+}
+
+func TestNewAzureStore_Endpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountName string
+		endpoint    string
+		want        string
+	}{
+		{
+			name:        "default",
+			accountName: "myaccount",
+			want:        "https://myaccount.blob.core.windows.net/artifacts/dir/file",
+		},
+		{
+			name:        "custom host-style",
+			accountName: "myaccount",
+			endpoint:    "https://myaccount.blob.core.chinacloudapi.cn/",
+			want:        "https://myaccount.blob.core.chinacloudapi.cn/artifacts/dir/file",
+		},
+		{
+			name:        "path-style without account",
+			accountName: "devstoreaccount1",
+			endpoint:    "http://127.0.0.1:10000",
+			want:        "http://127.0.0.1:10000/devstoreaccount1/artifacts/dir/file",
+		},
+		{
+			name:        "path-style with account",
+			accountName: "devstoreaccount1",
+			endpoint:    "http://localhost:10000/devstoreaccount1/",
+			want:        "http://localhost:10000/devstoreaccount1/artifacts/dir/file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestAzureStore(t, test.accountName, test.endpoint)
+			if got := c.blobURL("dir/file", nil).String(); got != test.want {
+				t.Errorf("blobURL() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// The expected signatures in the tests below were computed with the official azure sdk (azblob)
+// for the same requests, with the account key of the azurite emulator.
+
+func TestAzureStore_SharedKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountName string
+		endpoint    string
+		method      string
+		url         string
+		header      map[string]string
+		body        string
+		want        string
+	}{
+		{
+			name:        "put blob",
+			accountName: "myaccount",
+			method:      http.MethodPut,
+			url:         "https://myaccount.blob.core.windows.net/artifacts/dir/file%20name.txt",
+			header: map[string]string{
+				"Content-Type":   "application/octet-stream",
+				"x-ms-blob-type": "BlockBlob",
+			},
+			body: "hello",
+			want: "QR0XLgOw4tdYJAIc3o/xdXax6AkQrerPT0M8Dgsdg0k=",
+		},
+		{
+			name:        "put block",
+			accountName: "myaccount",
+			method:      http.MethodPut,
+			url:         "https://myaccount.blob.core.windows.net/artifacts/dir/file%20name.txt?blockid=MDAwMDAwMDA%3D&comp=block",
+			header: map[string]string{
+				"Content-Type": "application/octet-stream",
+			},
+			body: "hello",
+			want: "9399YzB8WmPwvRTjPYJsEbremFPxl1lzJq4WU5qiCSU=",
+		},
+		{
+			name:        "get container properties",
+			accountName: "myaccount",
+			method:      http.MethodGet,
+			url:         "https://myaccount.blob.core.windows.net/artifacts?restype=container",
+			want:        "lze32vqhyqzNSBM3I1KesX1rApOqq7/1RunAy+DHziU=",
+		},
+		{
+			name:        "path-style put blob",
+			accountName: "devstoreaccount1",
+			endpoint:    "http://127.0.0.1:10000/devstoreaccount1",
+			method:      http.MethodPut,
+			url:         "http://127.0.0.1:10000/devstoreaccount1/artifacts/dir/file%20name.txt",
+			header: map[string]string{
+				"Content-Type":   "application/octet-stream",
+				"x-ms-blob-type": "BlockBlob",
+			},
+			body: "hello",
+			want: "W8cOFJx6ebyAHsuWFj96VPogPsexLV73neOE9/5HIr0=",
+		},
+		{
+			name:        "path-style put block",
+			accountName: "devstoreaccount1",
+			endpoint:    "http://127.0.0.1:10000/devstoreaccount1",
+			method:      http.MethodPut,
+			url:         "http://127.0.0.1:10000/devstoreaccount1/artifacts/dir/file%20name.txt?blockid=MDAwMDAwMDA%3D&comp=block",
+			header: map[string]string{
+				"Content-Type": "application/octet-stream",
+			},
+			body: "hello",
+			want: "k5qe2JsUwMjlXhDxQ4Cc9KlnIV9WykTvzeMM4HeeuF8=",
+		},
+		{
+			name:        "path-style get container properties",
+			accountName: "devstoreaccount1",
+			endpoint:    "http://127.0.0.1:10000/devstoreaccount1",
+			method:      http.MethodGet,
+			url:         "http://127.0.0.1:10000/devstoreaccount1/artifacts?restype=container",
+			want:        "Kq9OibxvAAfRYeFogqfvZSpYPx1k75de/PmCL9LpMos=",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestAzureStore(t, test.accountName, test.endpoint)
+
+			req, err := http.NewRequest(test.method, test.url, bytes.NewReader([]byte(test.body)))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("x-ms-date", "Sun, 18 Oct 2026 03:54:05 GMT")
+			req.Header.Set("x-ms-version", "2023-11-03")
+			for name, value := range test.header {
+				req.Header.Set(name, value)
+			}
+
+			if got := c.sign(c.stringToSign(req)); got != test.want {
+				t.Errorf("signature = %s, want %s\nstring to sign:\n%s", got, test.want, c.stringToSign(req))
+			}
+		})
+	}
+}
+
+func TestAzureStore_SignedURL(t *testing.T) {
+	expiry := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		accountName string
+		endpoint    string
+		wantURL     string
+		wantSig     string
+	}{
+		{
+			name:        "https",
+			accountName: "myaccount",
+			wantURL:     "https://myaccount.blob.core.windows.net/artifacts/dir/file%20name.txt",
+			wantSig:     "UzGvZYi0cxrBD4lbDeBUYEYOToYtYUcRj9nDbeJXdEI=",
+		},
+		{
+			name:        "path-style http",
+			accountName: "devstoreaccount1",
+			endpoint:    "http://127.0.0.1:10000",
+			wantURL:     "http://127.0.0.1:10000/devstoreaccount1/artifacts/dir/file%20name.txt",
+			wantSig:     "4CeQw5jYMJNqlvAZDYWLSeBIdlM1m/6rr9Gz/yl3mpc=",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestAzureStore(t, test.accountName, test.endpoint)
+
+			signedURL, err := url.Parse(c.signedURL("dir/file name.txt", expiry))
+			if err != nil {
+				t.Fatalf("failed to parse signed url: %v", err)
+			}
+
+			query := signedURL.Query()
+			signedURL.RawQuery = ""
+			if got := signedURL.String(); got != test.wantURL {
+				t.Errorf("url = %s, want %s", got, test.wantURL)
+			}
+			if got := query.Get("se"); got != "2024-01-02T03:04:05Z" {
+				t.Errorf("expiry = %s, want 2024-01-02T03:04:05Z", got)
+			}
+			if got := query.Get("sig"); got != test.wantSig {
+				t.Errorf("signature = %s, want %s", got, test.wantSig)
+			}
+		})
+	}
+}
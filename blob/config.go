@@ -20,6 +20,8 @@ type Provider string
 
 const (
 	ProviderGCS        Provider = "gcs"
+	ProviderS3         Provider = "s3"
+	ProviderAzure      Provider = "azure"
 	ProviderFileSystem Provider = "filesystem"
 )
 
@@ -29,4 +31,59 @@ type Config struct {
 	KeyPath               string
 	TargetPrincipal       string
 	ImpersonationLifetime time.Duration
+
+	// SignedURLExpiry is the time for which signed URLs returned by the store are valid.
+	SignedURLExpiry time.Duration
+
+	GCS   GCSConfig
+	S3    S3Config
+	Azure AzureConfig
+}
+
+type GCSConfig struct {
+	// KMSKeyName is the optional name of the Cloud KMS key used to encrypt uploaded objects.
+	KMSKeyName string
+}
+
+// S3SSE is the server-side encryption algorithm used for objects stored in S3.
+type S3SSE string
+
+const (
+	S3SSENone   S3SSE = ""
+	S3SSEAES256 S3SSE = "AES256"
+	S3SSEKMS    S3SSE = "aws:kms"
+)
+
+type S3Config struct {
+	Region string
+	// Endpoint is the optional endpoint of an S3 compatible service.
+	Endpoint  string
+	PathStyle bool
+
+	// AccessKeyID and SecretAccessKey are optional, the default credential chain is used if not set.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	SSE S3SSE
+	// SSEKMSKeyID is the optional KMS key used with aws:kms encryption (the AWS managed key is used if not set).
+	SSEKMSKeyID string
+}
+
+type AzureConfig struct {
+	AccountName string
+	AccountKey  string
+	// Endpoint is the optional blob service endpoint, defaults to https://<account>.blob.core.windows.net.
+	// The account name is added to the path of endpoints on localhost or an IP address (e.g. azurite).
+	Endpoint string
+	// EncryptionScope is the optional encryption scope used to encrypt uploaded blobs.
+	EncryptionScope string
+}
+
+const defaultSignedURLExpiry = time.Hour
+
+func (c Config) signedURLExpiry() time.Duration {
+	if c.SignedURLExpiry <= 0 {
+		return defaultSignedURLExpiry
+	}
+	return c.SignedURLExpiry
 }
@@ -92,3 +92,29 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+
+	err := os.Remove(fileDiskPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+	return nil
+}
+
+// CheckHealth verifies that the base path is a directory (it's created with the first upload).
+func (c *FileSystemStore) CheckHealth(_ context.Context) error {
+	info, err := os.Stat(c.basePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat base path: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("base path '%s' is not a directory", c.basePath)
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -72,6 +73,7 @@ func (c *GCSStore) Upload(ctx context.Context, file io.Reader, filePath string)
 
 	bkt := gcsClient.Bucket(c.config.Bucket)
 	wc := bkt.Object(filePath).NewWriter(ctx)
+	wc.KMSKeyName = c.config.GCS.KMSKeyName
 	defer func() {
 		cErr := wc.Close()
 		if cErr != nil {
@@ -100,7 +102,7 @@ func (c *GCSStore) GetSignedURL(ctx context.Context, filePath string) (string, e
 	bkt := gcsClient.Bucket(c.config.Bucket)
 	signedURL, err := bkt.SignedURL(filePath, &storage.SignedURLOptions{
 		Method:  http.MethodGet,
-		Expires: time.Now().Add(1 * time.Hour),
+		Expires: time.Now().Add(c.config.signedURLExpiry()),
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
@@ -108,8 +110,48 @@ func (c *GCSStore) GetSignedURL(ctx context.Context, filePath string) (string, e
 	return signedURL, nil
 }
 
-func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error) {
-	return nil, fmt.Errorf("not implemented")
+func (c *GCSStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	reader, err := gcsClient.Bucket(c.config.Bucket).Object(filePath).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return reader, nil
+}
+
+func (c *GCSStore) Delete(ctx context.Context, filePath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+	return nil
+}
+
+// CheckHealth verifies that the bucket is accessible.
+func (c *GCSStore) CheckHealth(ctx context.Context) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	if _, err = gcsClient.Bucket(c.config.Bucket).Attrs(ctx); err != nil {
+		return fmt.Errorf("failed to get attributes of bucket %s: %w", c.config.Bucket, err)
+	}
+
+	return nil
 }
 
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
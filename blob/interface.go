@@ -34,4 +34,10 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete removes a file from the blob store. Deleting a missing file is not an error.
+	Delete(ctx context.Context, filePath string) error
+
+	// CheckHealth verifies that the blob store is accessible.
+	CheckHealth(ctx context.Context) error
 }
@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+type S3Store struct {
+	config   Config
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	switch cfg.S3.SSE {
+	case S3SSENone, S3SSEAES256, S3SSEKMS:
+	default:
+		return nil, fmt.Errorf("invalid S3 server-side encryption: %s", cfg.S3.SSE)
+	}
+	if cfg.S3.SSEKMSKeyID != "" && cfg.S3.SSE != S3SSEKMS {
+		return nil, fmt.Errorf("S3 KMS key requires server-side encryption %s", S3SSEKMS)
+	}
+
+	awsConfig := &aws.Config{
+		S3ForcePathStyle: aws.Bool(cfg.S3.PathStyle),
+	}
+	if cfg.S3.Region != "" {
+		awsConfig.Region = aws.String(cfg.S3.Region)
+	}
+	if cfg.S3.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.S3.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(!strings.HasPrefix(cfg.S3.Endpoint, "https://"))
+	}
+	if cfg.S3.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.S3.AccessKeyID, cfg.S3.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	return &S3Store{
+		config:   cfg,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	input := &s3manager.UploadInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+		Body:   file,
+	}
+	if c.config.S3.SSE != S3SSENone {
+		input.ServerSideEncryption = aws.String(string(c.config.S3.SSE))
+	}
+	if c.config.S3.SSEKMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(c.config.S3.SSEKMSKeyID)
+	}
+
+	// the uploader aborts incomplete multipart uploads on failure, no cleanup required.
+	if _, err := c.uploader.UploadWithContext(ctx, input); err != nil {
+		return fmt.Errorf("failed to write file: %s to bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+
+	signedURL, err := req.Presign(c.config.signedURLExpiry())
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
+	}
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return out.Body, nil
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	// S3 doesn't fail when deleting a missing object.
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+	return nil
+}
+
+// CheckHealth verifies that the bucket is accessible.
+func (c *S3Store) CheckHealth(ctx context.Context) error {
+	_, err := c.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(c.config.Bucket),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to access bucket %s: %w", c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+// RowCache is a key-value cache for database rows.
+// Cache failures are never returned to the caller, the caller falls back to the database instead.
+type RowCache interface {
+	// Get decodes the cached value of the key into dst and returns true on a cache hit.
+	Get(ctx context.Context, key string, dst any) bool
+
+	// Set stores the value under the provided key.
+	Set(ctx context.Context, key string, value any)
+
+	// Evict removes the provided keys from the cache.
+	Evict(ctx context.Context, keys ...string)
+}
+
+// NoRowCache is a RowCache that doesn't cache anything.
+type NoRowCache struct{}
+
+func (NoRowCache) Get(context.Context, string, any) bool { return false }
+func (NoRowCache) Set(context.Context, string, any)      {}
+func (NoRowCache) Evict(context.Context, ...string)      {}
+
+// RedisRowCache is a RowCache that stores gob encoded values in redis.
+type RedisRowCache struct {
+	client    redis.UniversalClient
+	prefix    string
+	duration  time.Duration
+	countHit  int64
+	countMiss int64
+}
+
+func NewRedisRowCache(client redis.UniversalClient, prefix string, duration time.Duration) *RedisRowCache {
+	return &RedisRowCache{
+		client:   client,
+		prefix:   prefix,
+		duration: duration,
+	}
+}
+
+// Stats returns number of cache hits and misses and can be used to monitor the cache efficiency.
+func (c *RedisRowCache) Stats() (int64, int64) {
+	return atomic.LoadInt64(&c.countHit), atomic.LoadInt64(&c.countMiss)
+}
+
+// Get implements the RowCache interface.
+func (c *RedisRowCache) Get(ctx context.Context, key string, dst any) bool {
+	raw, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		atomic.AddInt64(&c.countMiss, 1)
+		return false
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("row cache: failed to get value")
+		return false
+	}
+
+	if err = gob.NewDecoder(bytes.NewReader(raw)).Decode(dst); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("row cache: failed to decode value")
+		return false
+	}
+
+	atomic.AddInt64(&c.countHit, 1)
+
+	return true
+}
+
+// Set implements the RowCache interface.
+func (c *RedisRowCache) Set(ctx context.Context, key string, value any) {
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(value); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("row cache: failed to encode value")
+		return
+	}
+
+	if err := c.client.Set(ctx, c.prefix+key, buff.Bytes(), c.duration).Err(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("row cache: failed to set value")
+	}
+}
+
+// Evict implements the RowCache interface.
+func (c *RedisRowCache) Evict(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+
+	if err := c.client.Del(ctx, prefixed...).Err(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Strs("keys", keys).Msg("row cache: failed to evict keys")
+	}
+}
@@ -111,6 +111,13 @@ func (c *TTLCache[K, V]) Stop() {
 	close(c.purgeStop)
 }
 
+// Evict removes the object from the cache, so the next Get fetches it from the getter.
+func (c *TTLCache[K, V]) Evict(key K) {
+	c.mx.Lock()
+	delete(c.cache, key)
+	c.mx.Unlock()
+}
+
 // Stats returns number of cache hits and misses and can be used to monitor the cache efficiency.
 func (c *TTLCache[K, V]) Stats() (int64, int64) {
 	return c.countHit, c.countMiss
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	archiveVersion = 1
+
+	manifestFile = "manifest.json"
+	databaseDir  = "database"
+	reposDir     = "repos"
+
+	bundleExtension = ".bundle"
+)
+
+// manifest describes the content of a backup archive.
+type manifest struct {
+	Version      int            `json:"version"`
+	Created      int64          `json:"created"`
+	Driver       string         `json:"driver"`
+	DatabaseFile string         `json:"database_file"`
+	Repos        []manifestRepo `json:"repos"`
+}
+
+type manifestRepo struct {
+	GitUID        string `json:"git_uid"`
+	DefaultBranch string `json:"default_branch"`
+	Empty         bool   `json:"empty"`
+}
+
+// archiveWriter writes files into a gzip compressed tar archive.
+type archiveWriter struct {
+	gz  *gzip.Writer
+	tar *tar.Writer
+}
+
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	gz := gzip.NewWriter(w)
+	return &archiveWriter{
+		gz:  gz,
+		tar: tar.NewWriter(gz),
+	}
+}
+
+// addFile copies the file from the local file system into the archive.
+func (a *archiveWriter) addFile(name string, localPath string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	err = a.tar.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    stat.Size(),
+		ModTime: stat.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write header of %q: %w", name, err)
+	}
+
+	if _, err = io.Copy(a.tar, file); err != nil {
+		return fmt.Errorf("failed to write %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// addJSON writes the json encoded value into the archive.
+func (a *archiveWriter) addJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %q: %w", name, err)
+	}
+
+	err = a.tar.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write header of %q: %w", name, err)
+	}
+
+	if _, err = a.tar.Write(data); err != nil {
+		return fmt.Errorf("failed to write %q: %w", name, err)
+	}
+
+	return nil
+}
+
+func (a *archiveWriter) Close() error {
+	if err := a.tar.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+// extractArchive extracts all files of the gzip compressed tar archive into the directory.
+func extractArchive(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// never write outside of the target directory.
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || strings.HasPrefix(name, "..") {
+			return fmt.Errorf("invalid file name %q in archive", header.Name)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+
+		if err = extractFile(tr, target); err != nil {
+			return fmt.Errorf("failed to extract %q: %w", header.Name, err)
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string) error {
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // the archive is provided by the operator.
+	_, err = io.Copy(file, r)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+
+	return err
+}
+
+// readManifest reads the manifest of an extracted archive.
+func readManifest(dir string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m := &manifest{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if m.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", m.Version)
+	}
+
+	return m, nil
+}
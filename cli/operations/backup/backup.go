@@ -0,0 +1,241 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup provides the backup and restore commands.
+// A backup archive is a gzip compressed tarball with a database dump,
+// a git bundle for every repository and a manifest describing the content.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	storedatabase "github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/writefreeze"
+	"github.com/harness/gitness/cli/operations/server"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type backupCommand struct {
+	output      string
+	envfile     string
+	gracePeriod time.Duration
+}
+
+func (c *backupCommand) run(*kingpin.ParseContext) error {
+	ctx := context.Background()
+
+	config, err := loadConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.Connect(ctx, config.Database.Driver, config.Database.Datasource)
+	if err != nil {
+		return fmt.Errorf("failed to create database handle: %w", err)
+	}
+	defer db.Close()
+
+	gitService, err := newGitService(config)
+	if err != nil {
+		return err
+	}
+
+	// block all writes while the backup is taken to keep database and repositories consistent.
+	freezeFlag := writefreeze.New(config.Git.Root)
+	if err = freezeFlag.Set("backup in progress"); err != nil {
+		return err
+	}
+	defer func() {
+		if errClear := freezeFlag.Clear(); errClear != nil {
+			log.Err(errClear).Msg("failed to clear write freeze")
+		}
+	}()
+
+	// give write operations that were accepted before the freeze the chance to complete.
+	time.Sleep(c.gracePeriod)
+
+	tmpDir, err := os.MkdirTemp("", "gitness-backup-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	file, err := os.OpenFile(c.output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	m := &manifest{
+		Version: archiveVersion,
+		Created: time.Now().UnixMilli(),
+		Driver:  config.Database.Driver,
+	}
+
+	archive := newArchiveWriter(file)
+	err = writeBackup(ctx, archive, m, db, config.Database.Datasource, gitService, tmpDir)
+	if errClose := archive.Close(); err == nil {
+		err = errClose
+	}
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(c.output)
+		return err
+	}
+
+	fmt.Printf("backup of the database and %d repositories written to %s\n", len(m.Repos), c.output)
+	return nil
+}
+
+// writeBackup writes the database dump, the repository bundles and the manifest into the archive.
+func writeBackup(
+	ctx context.Context,
+	archive *archiveWriter,
+	m *manifest,
+	db *sqlx.DB,
+	datasource string,
+	gitService *git.Service,
+	tmpDir string,
+) error {
+	// the write freeze guarantees that the repositories match the database dump.
+	repos, err := storedatabase.NewRepoGitInfoView(db).ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	m.DatabaseFile, err = dumpDatabase(ctx, db, m.Driver, datasource, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	dumpPath := filepath.Join(tmpDir, m.DatabaseFile)
+	if err = archive.addFile(path.Join(databaseDir, m.DatabaseFile), dumpPath); err != nil {
+		return err
+	}
+	_ = os.Remove(dumpPath)
+
+	m.Repos = make([]manifestRepo, 0, len(repos))
+	for _, repo := range repos {
+		entry, err := backupRepo(ctx, archive, gitService, repo, tmpDir)
+		if err != nil {
+			return fmt.Errorf("failed to backup repository %d: %w", repo.ID, err)
+		}
+		m.Repos = append(m.Repos, entry)
+	}
+
+	return archive.addJSON(manifestFile, m)
+}
+
+// backupRepo writes the git bundle of the repository into the archive.
+func backupRepo(
+	ctx context.Context,
+	archive *archiveWriter,
+	gitService *git.Service,
+	repo *types.RepositoryGitInfo,
+	tmpDir string,
+) (manifestRepo, error) {
+	entry := manifestRepo{
+		GitUID:        repo.GitUID,
+		DefaultBranch: repo.DefaultBranch,
+	}
+
+	bundlePath := filepath.Join(tmpDir, repo.GitUID+bundleExtension)
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		return entry, fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	defer os.Remove(bundlePath)
+
+	out, err := gitService.CreateBundle(ctx, &git.CreateBundleParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Writer:     file,
+	})
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return entry, err
+	}
+
+	if out.Empty {
+		entry.Empty = true
+		return entry, nil
+	}
+
+	return entry, archive.addFile(path.Join(reposDir, repo.GitUID+bundleExtension), bundlePath)
+}
+
+// RegisterBackup helper function to register the backup command.
+func RegisterBackup(app *kingpin.Application) {
+	c := &backupCommand{}
+
+	cmd := app.Command("backup", "backup the database and all git repositories into a single archive").
+		Action(c.run)
+
+	cmd.Arg("output", "path of the backup archive to create").
+		Required().
+		StringVar(&c.output)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("grace-period", "time to wait for in-flight writes after the write freeze is set").
+		Default("5s").
+		DurationVar(&c.gracePeriod)
+}
+
+// loadConfig loads the server configuration, including the provided environment variable file.
+func loadConfig(envfile string) (*types.Config, error) {
+	_ = godotenv.Load(envfile)
+
+	config, err := server.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// newGitService creates a git service operating on the configured git root.
+func newGitService(config *types.Config) (*git.Service, error) {
+	gitConfig := server.ProvideGitConfig(config)
+
+	gitAdapter, err := adapter.New(gitConfig, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git adapter: %w", err)
+	}
+
+	gitService, err := git.New(gitConfig, gitAdapter, storage.NewLocalStore())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git service: %w", err)
+	}
+
+	return gitService, nil
+}
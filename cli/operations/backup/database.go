@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/store/database"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	driverSQLite   = "sqlite3"
+	driverPostgres = "postgres"
+)
+
+// dumpDatabase writes a consistent dump of the database into the directory and returns the dump file name.
+// SQLite is copied via VACUUM INTO, postgres and mariadb are dumped using their native tools.
+func dumpDatabase(ctx context.Context, db *sqlx.DB, driver, datasource, dir string) (string, error) {
+	switch driver {
+	case driverSQLite:
+		const name = "database.sqlite3"
+		if _, err := db.ExecContext(ctx, "VACUUM INTO ?", filepath.Join(dir, name)); err != nil {
+			return "", fmt.Errorf("failed to copy sqlite database: %w", err)
+		}
+		return name, nil
+
+	case driverPostgres:
+		const name = "database.pgdump"
+		err := runTool(ctx, nil, nil, "pg_dump",
+			"--format=custom",
+			"--no-owner",
+			"--file="+filepath.Join(dir, name),
+			"--dbname="+datasource,
+		)
+		return name, err
+
+	case database.DriverMariaDB:
+		const name = "database.sql"
+		env, args, dbName, err := mysqlToolArgs(datasource)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, "--single-transaction", "--result-file="+filepath.Join(dir, name), "--databases", dbName)
+		err = runTool(ctx, env, nil, "mysqldump", args...)
+		return name, err
+
+	default:
+		return "", fmt.Errorf("database driver %q doesn't support backups", driver)
+	}
+}
+
+// restoreDatabase loads the database dump created by dumpDatabase into the configured database.
+func restoreDatabase(ctx context.Context, driver, datasource, dumpPath string, force bool) error {
+	switch driver {
+	case driverSQLite:
+		return restoreSQLite(datasource, dumpPath, force)
+
+	case driverPostgres:
+		args := []string{"--no-owner", "--single-transaction", "--dbname=" + datasource}
+		if force {
+			args = append(args, "--clean", "--if-exists")
+		}
+		return runTool(ctx, nil, nil, "pg_restore", append(args, dumpPath)...)
+
+	case database.DriverMariaDB:
+		// the dump contains the statements to create and select the database.
+		env, args, _, err := mysqlToolArgs(datasource)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(dumpPath)
+		if err != nil {
+			return fmt.Errorf("failed to open database dump: %w", err)
+		}
+		defer file.Close()
+
+		return runTool(ctx, env, file, "mysql", args...)
+
+	default:
+		return fmt.Errorf("database driver %q doesn't support restores", driver)
+	}
+}
+
+// restoreSQLite copies the database file to the path of the configured sqlite datasource.
+func restoreSQLite(datasource, dumpPath string, force bool) error {
+	target := strings.TrimPrefix(datasource, "file:")
+	if i := strings.IndexByte(target, '?'); i >= 0 {
+		target = target[:i]
+	}
+
+	if _, err := os.Stat(target); err == nil && !force {
+		return fmt.Errorf("database file %q already exists (use --force to overwrite)", target)
+	}
+
+	src, err := os.Open(dumpPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database dump: %w", err)
+	}
+	defer src.Close()
+
+	// copy next to the target first to avoid leaving a partially written database behind.
+	tmp := target + ".restore"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create database file: %w", err)
+	}
+
+	_, err = io.Copy(dst, src)
+	if errClose := dst.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write database file: %w", err)
+	}
+
+	// remove stale journal files of the replaced database.
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		_ = os.Remove(target + suffix)
+	}
+
+	if err = os.Rename(tmp, target); err != nil {
+		return fmt.Errorf("failed to replace database file: %w", err)
+	}
+
+	return nil
+}
+
+// mysqlToolArgs returns the environment, connection arguments and database name for the mysql command line tools.
+// The password is passed via the environment to avoid exposing it in the process list.
+func mysqlToolArgs(datasource string) ([]string, []string, string, error) {
+	cfg, err := mysql.ParseDSN(datasource)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("datasource is of invalid format for driver mariadb: %w", err)
+	}
+
+	args := []string{"--user=" + cfg.User}
+	switch cfg.Net {
+	case "unix":
+		args = append(args, "--socket="+cfg.Addr)
+	default:
+		host, port, errSplit := net.SplitHostPort(cfg.Addr)
+		if errSplit != nil {
+			host, port = cfg.Addr, ""
+		}
+		args = append(args, "--host="+host)
+		if port != "" {
+			args = append(args, "--port="+port)
+		}
+	}
+
+	return []string{"MYSQL_PWD=" + cfg.Passwd}, args, cfg.DBName, nil
+}
+
+// runTool runs an external database tool and includes its error output in the returned error.
+func runTool(ctx context.Context, env []string, stdin io.Reader, name string, args ...string) error {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("%s is required for this database driver: %w", name, err)
+	}
+
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = stdin
+	cmd.Stderr = stderr
+
+	if err = cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/writefreeze"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store/database"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type restoreCommand struct {
+	input   string
+	envfile string
+	force   bool
+}
+
+func (c *restoreCommand) run(*kingpin.ParseContext) error {
+	ctx := context.Background()
+
+	config, err := loadConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gitness-restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	file, err := os.Open(c.input)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	err = extractArchive(file, tmpDir)
+	_ = file.Close()
+	if err != nil {
+		return err
+	}
+
+	m, err := readManifest(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if m.Driver != config.Database.Driver {
+		return fmt.Errorf("backup was created with database driver %q, but %q is configured",
+			m.Driver, config.Database.Driver)
+	}
+
+	gitService, err := newGitService(config)
+	if err != nil {
+		return err
+	}
+
+	// block all writes in case the server is running, it should be stopped for the restore.
+	freezeFlag := writefreeze.New(config.Git.Root)
+	if err = freezeFlag.Set("restore in progress"); err != nil {
+		return err
+	}
+	defer func() {
+		if errClear := freezeFlag.Clear(); errClear != nil {
+			log.Err(errClear).Msg("failed to clear write freeze")
+		}
+	}()
+
+	dumpPath := filepath.Join(tmpDir, databaseDir, m.DatabaseFile)
+	if err = restoreDatabase(ctx, m.Driver, config.Database.Datasource, dumpPath, c.force); err != nil {
+		return err
+	}
+
+	// the backup could be from an older version, ensure the schema is up to date.
+	db, err := database.ConnectAndMigrate(ctx, config.Database.Driver, config.Database.Datasource, migrate.Migrate)
+	if err != nil {
+		return fmt.Errorf("failed to migrate the restored database: %w", err)
+	}
+	_ = db.Close()
+
+	actor := git.Identity{
+		Name:  config.Principal.System.DisplayName,
+		Email: config.Principal.System.Email,
+	}
+
+	for _, repo := range m.Repos {
+		if err = c.restoreRepo(ctx, gitService, actor, tmpDir, repo); err != nil {
+			return fmt.Errorf("failed to restore repository %s: %w", repo.GitUID, err)
+		}
+	}
+
+	fmt.Printf("restored the database and %d repositories from %s\n", len(m.Repos), c.input)
+	return nil
+}
+
+// restoreRepo creates the repository from its git bundle.
+func (c *restoreCommand) restoreRepo(
+	ctx context.Context,
+	gitService *git.Service,
+	actor git.Identity,
+	tmpDir string,
+	repo manifestRepo,
+) error {
+	if c.force {
+		err := gitService.DeleteRepository(ctx, &git.DeleteRepositoryParams{
+			WriteParams: git.WriteParams{RepoUID: repo.GitUID, Actor: actor},
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete existing repository: %w", err)
+		}
+	}
+
+	params := &git.CreateRepositoryFromBundleParams{
+		RepoUID:       repo.GitUID,
+		DefaultBranch: repo.DefaultBranch,
+	}
+
+	if !repo.Empty {
+		bundle, err := os.Open(filepath.Join(tmpDir, reposDir, repo.GitUID+bundleExtension))
+		if err != nil {
+			return fmt.Errorf("failed to open bundle: %w", err)
+		}
+		defer bundle.Close()
+
+		params.Bundle = bundle
+	}
+
+	return gitService.CreateRepositoryFromBundle(ctx, params)
+}
+
+// RegisterRestore helper function to register the restore command.
+func RegisterRestore(app *kingpin.Application) {
+	c := &restoreCommand{}
+
+	cmd := app.Command("restore", "restore the database and all git repositories from a backup archive").
+		Action(c.run)
+
+	cmd.Arg("input", "path of the backup archive").
+		Required().
+		StringVar(&c.input)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("force", "overwrite the existing database and repositories").
+		BoolVar(&c.force)
+}
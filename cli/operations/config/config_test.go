@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		exp   string
+	}{
+		{key: "GITNESS_ENCRYPTER_SECRET", value: "abc", exp: redacted},
+		{key: "GITNESS_REDIS_PASSWORD", value: "abc", exp: redacted},
+		{key: "GITNESS_METRIC_TOKEN", value: "abc", exp: redacted},
+		{key: "GITNESS_SMTP_PASSWORD", value: "", exp: ""},
+		{key: "GITNESS_TOKEN_COOKIE_NAME", value: "token", exp: "token"},
+		{key: "GITNESS_DATABASE_DATASOURCE", value: "database.sqlite3", exp: "database.sqlite3"},
+		{
+			key:   "GITNESS_DATABASE_DATASOURCE",
+			value: "postgres://user:pw@localhost:5432/gitness?sslmode=disable",
+			exp:   "postgres://user:" + redacted + "@localhost:5432/gitness?sslmode=disable",
+		},
+		{
+			key:   "GITNESS_DATABASE_DATASOURCE",
+			value: "host=localhost user=gitness password=pw dbname=gitness",
+			exp:   "host=localhost user=gitness password=" + redacted + " dbname=gitness",
+		},
+		{
+			key:   "GITNESS_DATABASE_DATASOURCE",
+			value: "gitness:pw@tcp(localhost:3306)/gitness",
+			exp:   "gitness:" + redacted + "@tcp(localhost:3306)/gitness",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key+"="+test.value, func(t *testing.T) {
+			got := redact(setting{Key: test.key, Value: test.value})
+			if got.Value != test.exp {
+				t.Errorf("want: %q, got: %q", test.exp, got.Value)
+			}
+		})
+	}
+}
+
+func TestUnknownVariables(t *testing.T) {
+	known := settings(&types.Config{})
+
+	warnings := unknownVariables([]string{
+		"HOME=/root",
+		"GITNESS_HTTP_PORT=3000",
+		"GITNESS_HTTP_PROT=http",
+		"GITNESS_SOMETHING_COMPLETELY_DIFFERENT=1",
+	}, known)
+
+	exp := []string{
+		"unknown environment variable GITNESS_HTTP_PROT (did you mean GITNESS_HTTP_PROTO?)",
+		"unknown environment variable GITNESS_SOMETHING_COMPLETELY_DIFFERENT",
+	}
+
+	if len(warnings) != len(exp) {
+		t.Fatalf("want: %v, got: %v", exp, warnings)
+	}
+	for i := range exp {
+		if warnings[i] != exp[i] {
+			t.Errorf("want: %q, got: %q", exp[i], warnings[i])
+		}
+	}
+}
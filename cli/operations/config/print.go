@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/harness/gitness/cli/operations/server"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type printCommand struct {
+	envfile    string
+	showSecret bool
+	json       bool
+}
+
+func (c *printCommand) run(*kingpin.ParseContext) error {
+	_ = godotenv.Load(c.envfile)
+
+	cfg, err := server.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	list := settings(cfg)
+	if !c.showSecret {
+		for i := range list {
+			list[i] = redact(list[i])
+		}
+	}
+
+	if c.json {
+		out := make(map[string]string, len(list))
+		for _, s := range list {
+			out[s.Key] = s.Value
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	for _, s := range list {
+		fmt.Printf("%s=%s\n", s.Key, s.Value)
+	}
+	return nil
+}
+
+// helper function registers the config print command.
+func registerPrint(app *kingpin.CmdClause) {
+	c := &printCommand{}
+
+	cmd := app.Command("print", "print the effective configuration (including defaults) with secrets redacted").
+		Action(c.run)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("show-secrets", "print secrets instead of redacting them").
+		BoolVar(&c.showSecret)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,295 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/cli/operations/server"
+	"github.com/harness/gitness/events"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/http/proxy"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// encrypterKeyLength is the required length of the encryption keys (AES-256).
+const encrypterKeyLength = 32
+
+type validateCommand struct {
+	envfile string
+	strict  bool
+}
+
+func (c *validateCommand) run(*kingpin.ParseContext) error {
+	_ = godotenv.Load(c.envfile)
+
+	cfg, err := server.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	warnings := unknownVariables(os.Environ(), settings(cfg))
+	errs := validateValues(cfg)
+
+	for _, msg := range errs {
+		fmt.Printf("ERROR: %s\n", msg)
+	}
+	for _, msg := range warnings {
+		fmt.Printf("WARN:  %s\n", msg)
+	}
+
+	if len(errs) > 0 || (c.strict && len(warnings) > 0) {
+		return fmt.Errorf("configuration is invalid (%d error(s), %d warning(s))", len(errs), len(warnings))
+	}
+
+	fmt.Println("configuration is valid")
+	return nil
+}
+
+// unknownVariables returns a warning for every gitness environment variable that isn't used by the configuration.
+// Unknown variables are typically typos which lead to the default value being used silently.
+func unknownVariables(environ []string, known []setting) []string {
+	keys := make(map[string]struct{}, len(known))
+	for _, s := range known {
+		keys[s.Key] = struct{}{}
+	}
+
+	var warnings []string
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, envPrefix) {
+			continue
+		}
+		if _, ok := keys[key]; ok {
+			continue
+		}
+
+		msg := fmt.Sprintf("unknown environment variable %s", key)
+		if suggestion := closestKey(key, known); suggestion != "" {
+			msg += fmt.Sprintf(" (did you mean %s?)", suggestion)
+		}
+		warnings = append(warnings, msg)
+	}
+
+	return warnings
+}
+
+// closestKey returns the known key with the smallest edit distance, if it's close enough to be a likely typo.
+func closestKey(key string, known []setting) string {
+	const maxDistance = 3
+
+	best, bestDistance := "", maxDistance+1
+	for _, s := range known {
+		if d := levenshtein(key, s.Key); d < bestDistance {
+			best, bestDistance = s.Key, d
+		}
+	}
+
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// validateValues validates values which are accepted by envconfig but would fail (or misbehave) at runtime.
+func validateValues(cfg *types.Config) []string {
+	var errs []string
+	invalid := func(key string, value any, valid ...any) {
+		errs = append(errs, fmt.Sprintf("invalid value %q for %s (valid values: %v)", fmt.Sprint(value), key, valid))
+	}
+
+	switch cfg.Database.Driver {
+	case "sqlite3", "postgres", "mariadb":
+	default:
+		invalid("GITNESS_DATABASE_DRIVER", cfg.Database.Driver, "sqlite3", "postgres", "mariadb")
+	}
+
+	switch cfg.BlobStore.Provider {
+	case blob.ProviderFileSystem, blob.ProviderGCS:
+	default:
+		invalid("GITNESS_BLOBSTORE_PROVIDER", cfg.BlobStore.Provider, blob.ProviderFileSystem, blob.ProviderGCS)
+	}
+
+	switch cfg.Events.Mode {
+	case events.ModeInMemory, events.ModeRedis:
+	default:
+		invalid("GITNESS_EVENTS_MODE", cfg.Events.Mode, events.ModeInMemory, events.ModeRedis)
+	}
+
+	switch cfg.Lock.Provider {
+	case lock.MemoryProvider, lock.RedisProvider:
+	default:
+		invalid("GITNESS_LOCK_PROVIDER", cfg.Lock.Provider, lock.MemoryProvider, lock.RedisProvider)
+	}
+
+	switch cfg.PubSub.Provider {
+	case pubsub.ProviderMemory, pubsub.ProviderRedis:
+	default:
+		invalid("GITNESS_PUBSUB_PROVIDER", cfg.PubSub.Provider, pubsub.ProviderMemory, pubsub.ProviderRedis)
+	}
+
+	switch cfg.Git.LastCommitCache.Mode {
+	case gitenum.LastCommitCacheModeInMemory, gitenum.LastCommitCacheModeRedis, gitenum.LastCommitCacheModeNone:
+	default:
+		invalid("GITNESS_GIT_LAST_COMMIT_CACHE_MODE", cfg.Git.LastCommitCache.Mode,
+			gitenum.LastCommitCacheModeInMemory, gitenum.LastCommitCacheModeRedis, gitenum.LastCommitCacheModeNone)
+	}
+
+	if port := cfg.Server.HTTP.Port; port <= 0 || port > 65535 {
+		errs = append(errs, fmt.Sprintf("invalid port %d for GITNESS_HTTP_PORT", port))
+	}
+
+	if cfg.Encrypter.Secret != "" && len(cfg.Encrypter.Secret) != encrypterKeyLength {
+		errs = append(errs, fmt.Sprintf("GITNESS_ENCRYPTER_SECRET has to be %d characters long", encrypterKeyLength))
+	}
+	if cfg.Encrypter.Secret == "" && len(cfg.Encrypter.PreviousSecrets) > 0 {
+		errs = append(errs, "GITNESS_ENCRYPTER_PREVIOUS_SECRETS can't be used without GITNESS_ENCRYPTER_SECRET")
+	}
+	for i, secret := range cfg.Encrypter.PreviousSecrets {
+		if len(secret) != encrypterKeyLength {
+			errs = append(errs, fmt.Sprintf("GITNESS_ENCRYPTER_PREVIOUS_SECRETS entry %d has to be %d characters long",
+				i, encrypterKeyLength))
+		}
+	}
+
+	if cfg.Principal.Admin.Password != "" && cfg.Principal.Admin.Email == "" {
+		errs = append(errs, "GITNESS_PRINCIPAL_ADMIN_EMAIL is required if GITNESS_PRINCIPAL_ADMIN_PASSWORD is set")
+	}
+
+	if cfg.RateLimit.Enabled {
+		if cfg.RateLimit.Rate <= 0 || cfg.RateLimit.Burst <= 0 {
+			errs = append(errs, "GITNESS_RATE_LIMIT_RATE and GITNESS_RATE_LIMIT_BURST have to be positive")
+		}
+		if cfg.RateLimit.AnonymousRate <= 0 || cfg.RateLimit.AnonymousBurst <= 0 {
+			errs = append(errs,
+				"GITNESS_RATE_LIMIT_ANONYMOUS_RATE and GITNESS_RATE_LIMIT_ANONYMOUS_BURST have to be positive")
+		}
+	}
+
+	if _, err := proxy.NewResolver(server.ProvideProxyConfig(cfg)); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid egress proxy configuration: %s", err))
+	}
+
+	for _, cidr := range cfg.Webhook.AllowedNetworks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, fmt.Sprintf("GITNESS_WEBHOOK_ALLOWED_NETWORKS contains an invalid network: %s", err))
+		}
+	}
+
+	if hooks := cfg.Git.ExternalHooks; hooks.DefaultTimeout <= 0 || hooks.MaxTimeout < hooks.DefaultTimeout {
+		errs = append(errs, "GITNESS_GIT_EXTERNAL_HOOKS_DEFAULT_TIMEOUT has to be positive "+
+			"and not greater than GITNESS_GIT_EXTERNAL_HOOKS_MAX_TIMEOUT")
+	}
+
+	if signedURL := cfg.SignedURL; signedURL.DefaultExpiry <= 0 || signedURL.MaxExpiry < signedURL.DefaultExpiry {
+		errs = append(errs, "GITNESS_SIGNED_URL_DEFAULT_EXPIRY has to be positive "+
+			"and not greater than GITNESS_SIGNED_URL_MAX_EXPIRY")
+	}
+
+	if cfg.Audit.Enabled && cfg.Audit.CaptureRequestBody && cfg.Audit.MaxRequestBodySize <= 0 {
+		errs = append(errs, "GITNESS_AUDIT_MAX_REQUEST_BODY_SIZE has to be positive if request bodies are captured")
+	}
+
+	if cfg.BreakGlass.Enabled && cfg.BreakGlass.Window <= 0 {
+		errs = append(errs, "GITNESS_BREAK_GLASS_WINDOW has to be positive if break-glass mode is enabled")
+	}
+
+	if scan := cfg.MalwareScan; scan.Enabled {
+		if scan.Protocol != "clamav" && scan.Protocol != "icap" {
+			errs = append(errs, "GITNESS_MALWARE_SCAN_PROTOCOL has to be either clamav or icap")
+		}
+		if scan.Address == "" {
+			errs = append(errs, "GITNESS_MALWARE_SCAN_ADDRESS is required if malware scanning is enabled")
+		}
+		if scan.Timeout <= 0 || scan.MaxFileSize <= 0 || scan.MaxFiles <= 0 {
+			errs = append(errs, "GITNESS_MALWARE_SCAN_TIMEOUT, GITNESS_MALWARE_SCAN_MAX_FILE_SIZE "+
+				"and GITNESS_MALWARE_SCAN_MAX_FILES have to be positive")
+		}
+	}
+
+	if alerts := cfg.VulnerabilityAlerts; alerts.Enabled {
+		if _, err := url.ParseRequestURI(alerts.OSVURL); err != nil {
+			errs = append(errs, "GITNESS_VULNERABILITY_ALERTS_OSV_URL has to be a valid URL")
+		}
+		if alerts.Timeout <= 0 {
+			errs = append(errs, "GITNESS_VULNERABILITY_ALERTS_TIMEOUT has to be positive")
+		}
+	}
+
+	if cfg.DiffChecks.Enabled && cfg.DiffChecks.MaxFileSize <= 0 {
+		errs = append(errs, "GITNESS_DIFF_CHECKS_MAX_FILE_SIZE has to be positive if diff checks are enabled")
+	}
+
+	if cfg.Usage.Enabled && cfg.Usage.Retention < 24*time.Hour {
+		errs = append(errs, "GITNESS_USAGE_RETENTION has to be at least 24h")
+	}
+
+	return errs
+}
+
+// helper function registers the config validate command.
+func registerValidate(app *kingpin.CmdClause) {
+	c := &validateCommand{}
+
+	cmd := app.Command("validate", "validate the configuration and report unknown or invalid values").
+		Action(c.run)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("strict", "treat warnings (e.g. unknown variables) as errors").
+		BoolVar(&c.strict)
+}
@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/writefreeze"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+)
+
+// minGitVersion is the minimum supported git version (all git calls rely on --end-of-options).
+var minGitVersion = [3]int{2, 24, 0}
+
+func checkConfig(config *types.Config) []finding {
+	const check = "config"
+
+	findings := []finding{ok(check, "configuration loaded")}
+
+	switch {
+	case config.Encrypter.Secret == "" && len(config.Encrypter.PreviousSecrets) > 0:
+		findings = append(findings, failure(check,
+			"set GITNESS_ENCRYPTER_SECRET or remove GITNESS_ENCRYPTER_PREVIOUS_SECRETS",
+			"previous encryption keys are configured without a current encryption key"))
+	case config.Encrypter.Secret == "":
+		findings = append(findings, warning(check,
+			"set GITNESS_ENCRYPTER_SECRET to a random 32 character string",
+			"no encryption key configured, secrets are stored unencrypted"))
+	default:
+		_, err := encrypt.NewKeyring(config.Encrypter.Secret, config.Encrypter.PreviousSecrets,
+			config.Encrypter.MixedContent)
+		if err != nil {
+			findings = append(findings, failure(check,
+				"GITNESS_ENCRYPTER_SECRET has to be exactly 32 characters long",
+				"invalid encryption key: %s", err))
+		}
+	}
+
+	if config.Principal.Admin.Password != "" && config.Principal.Admin.Email == "" {
+		findings = append(findings, failure(check,
+			"set GITNESS_PRINCIPAL_ADMIN_EMAIL, it's required to create the admin user",
+			"admin password is configured without an admin email"))
+	}
+
+	if strings.Contains(config.URL.Git, "localhost") {
+		findings = append(findings, warning(check,
+			"set GITNESS_URL_BASE to the externally reachable address of the server",
+			"clone urls point to localhost (%s)", config.URL.Git))
+	}
+
+	return findings
+}
+
+func checkDatabase(ctx context.Context, config *types.Config) []finding {
+	const check = "database"
+
+	if config.Database.Driver == "sqlite3" {
+		path := strings.TrimPrefix(config.Database.Datasource, "file:")
+		if i := strings.IndexByte(path, '?'); i >= 0 {
+			path = path[:i]
+		}
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return []finding{warning(check,
+				"the database is created on the first server start, ensure the directory is writable",
+				"sqlite database %q doesn't exist yet", path)}
+		}
+	}
+
+	db, err := database.Open(config.Database.Driver, config.Database.Datasource)
+	if err != nil {
+		return []finding{failure(check,
+			"check GITNESS_DATABASE_DRIVER and GITNESS_DATABASE_DATASOURCE",
+			"invalid database configuration: %s", err)}
+	}
+	defer db.Close()
+
+	if err = db.PingContext(ctx); err != nil {
+		return []finding{failure(check,
+			"ensure the database is running and reachable with the configured GITNESS_DATABASE_DATASOURCE",
+			"failed to connect to %s database: %s", config.Database.Driver, err)}
+	}
+
+	findings := []finding{ok(check, "connected to %s database", config.Database.Driver)}
+
+	statuses, err := migrate.ListStatus(ctx, db)
+	if err != nil {
+		return append(findings, warning(check,
+			"run 'gitness migrate status' for details",
+			"failed to determine migration status: %s", err))
+	}
+
+	pending := 0
+	for _, s := range statuses {
+		if !s.Applied {
+			pending++
+		}
+	}
+
+	if pending > 0 {
+		return append(findings, warning(check,
+			"migrations are applied on server start, or run 'gitness migrate up'",
+			"%d database migration(s) pending", pending))
+	}
+
+	return append(findings, ok(check, "all %d database migrations applied", len(statuses)))
+}
+
+func checkGit(ctx context.Context) []finding {
+	const check = "git"
+
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return []finding{failure(check,
+			"install git and make sure it's available in the PATH",
+			"git binary not found: %s", err)}
+	}
+
+	out, err := exec.CommandContext(ctx, path, "version").Output()
+	if err != nil {
+		return []finding{failure(check,
+			"ensure the git installation at "+path+" is working",
+			"failed to get git version: %s", err)}
+	}
+
+	version, err := parseGitVersion(string(out))
+	if err != nil {
+		return []finding{warning(check, "", "unable to parse git version %q", strings.TrimSpace(string(out)))}
+	}
+
+	if compareVersions(version, minGitVersion) < 0 {
+		return []finding{failure(check,
+			fmt.Sprintf("upgrade git to version %s or newer", formatVersion(minGitVersion)),
+			"git version %s is not supported", formatVersion(version))}
+	}
+
+	return []finding{ok(check, "git version %s (%s)", formatVersion(version), path)}
+}
+
+var gitVersionRegex = regexp.MustCompile(`git version (\d+)\.(\d+)(?:\.(\d+))?`)
+
+// parseGitVersion parses the output of `git version`, e.g. "git version 2.39.2 (Apple Git-143)".
+func parseGitVersion(out string) ([3]int, error) {
+	var version [3]int
+
+	match := gitVersionRegex.FindStringSubmatch(out)
+	if match == nil {
+		return version, fmt.Errorf("unexpected output %q", out)
+	}
+
+	for i, part := range match[1:] {
+		if part == "" {
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return version, err
+		}
+		version[i] = v
+	}
+
+	return version, nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func formatVersion(v [3]int) string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+func checkHookPath(config *types.Config) []finding {
+	const check = "hooks"
+
+	hint := "set GITNESS_GIT_HOOK_PATH to the path of an executable gitness binary"
+
+	stat, err := os.Stat(config.Git.HookPath)
+	if err != nil {
+		return []finding{failure(check, hint, "hook binary %q isn't accessible: %s", config.Git.HookPath, err)}
+	}
+
+	if !stat.Mode().IsRegular() || stat.Mode().Perm()&0o111 == 0 {
+		return []finding{failure(check, hint, "hook binary %q isn't executable", config.Git.HookPath)}
+	}
+
+	return []finding{ok(check, "hook binary %q is executable", config.Git.HookPath)}
+}
+
+func checkDirectories(config *types.Config) []finding {
+	const check = "storage"
+
+	findings := []finding{checkWritable(check, "git root", config.Git.Root, "GITNESS_GIT_ROOT")}
+	if config.Git.TmpDir != "" {
+		findings = append(findings, checkWritable(check, "git tmp dir", config.Git.TmpDir, "GITNESS_GIT_TMP_DIR"))
+	}
+
+	info, err := writefreeze.New(config.Git.Root).Get()
+	switch {
+	case err != nil:
+		findings = append(findings, warning(check, "", "failed to check write freeze: %s", err))
+	case info != nil:
+		findings = append(findings, warning(check,
+			"writes are blocked, if no backup or restore is running remove the write-freeze file in the git root",
+			"write freeze is active since %s (%s)",
+			time.UnixMilli(info.Created).Format(time.RFC3339), info.Reason))
+	}
+
+	return findings
+}
+
+// checkWritable verifies that files can be created in the directory (or its closest existing parent).
+func checkWritable(check, name, dir, envVar string) finding {
+	hint := fmt.Sprintf("ensure the user running gitness can write to %s or change %s", dir, envVar)
+
+	target := dir
+	for {
+		_, err := os.Stat(target)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return failure(check, hint, "%s %q isn't accessible: %s", name, dir, err)
+		}
+		parent := filepath.Dir(target)
+		if parent == target {
+			break
+		}
+		target = parent
+	}
+
+	file, err := os.CreateTemp(target, ".gitness-doctor-*")
+	if err != nil {
+		return failure(check, hint, "%s %q isn't writable: %s", name, dir, err)
+	}
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+
+	if target != dir {
+		return ok(check, "%s %q doesn't exist yet but can be created", name, dir)
+	}
+
+	return ok(check, "%s %q is writable", name, dir)
+}
+
+// checkServer verifies that the server is reachable via the internal url, which is used by the git hooks.
+func checkServer(ctx context.Context, config *types.Config) []finding {
+	const check = "server"
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	url := strings.TrimSuffix(config.URL.Internal, "/") + "/api/v1/system/health"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return []finding{failure(check, "check GITNESS_URL_INTERNAL", "invalid internal url: %s", err)}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return []finding{warning(check,
+			"start the server, git hooks need to reach it via GITNESS_URL_INTERNAL",
+			"server isn't reachable at %s: %s", config.URL.Internal, err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return []finding{failure(check,
+			"ensure GITNESS_URL_INTERNAL points to this gitness instance",
+			"unexpected status %d from %s", resp.StatusCode, url)}
+	}
+
+	return []finding{ok(check, "server is reachable at %s", config.URL.Internal)}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"testing"
+)
+
+func TestParseGitVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		out       string
+		exp       [3]int
+		expErr    bool
+		supported bool
+	}{
+		{
+			name:      "linux",
+			out:       "git version 2.39.5\n",
+			exp:       [3]int{2, 39, 5},
+			supported: true,
+		},
+		{
+			name:      "apple",
+			out:       "git version 2.39.2 (Apple Git-143)",
+			exp:       [3]int{2, 39, 2},
+			supported: true,
+		},
+		{
+			name:      "windows",
+			out:       "git version 2.41.0.windows.1",
+			exp:       [3]int{2, 41, 0},
+			supported: true,
+		},
+		{
+			name:      "no-patch",
+			out:       "git version 2.24",
+			exp:       [3]int{2, 24, 0},
+			supported: true,
+		},
+		{
+			name: "too-old",
+			out:  "git version 2.17.1",
+			exp:  [3]int{2, 17, 1},
+		},
+		{
+			name:   "invalid",
+			out:    "command not found",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := parseGitVersion(test.out)
+			if test.expErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != test.exp {
+				t.Errorf("want: %v, got: %v", test.exp, version)
+			}
+			if supported := compareVersions(version, minGitVersion) >= 0; supported != test.supported {
+				t.Errorf("want supported: %t, got: %t", test.supported, supported)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package doctor provides the doctor command which diagnoses common installation problems.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/harness/gitness/cli/operations/server"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type status string
+
+const (
+	statusOK      status = "OK"
+	statusWarning status = "WARN"
+	statusFailure status = "FAIL"
+)
+
+// finding is the result of a single diagnostic check.
+type finding struct {
+	check   string
+	status  status
+	message string
+	// hint describes how to resolve the problem (empty for successful checks).
+	hint string
+}
+
+func ok(check, format string, args ...any) finding {
+	return finding{check: check, status: statusOK, message: fmt.Sprintf(format, args...)}
+}
+
+func warning(check, hint, format string, args ...any) finding {
+	return finding{check: check, status: statusWarning, message: fmt.Sprintf(format, args...), hint: hint}
+}
+
+func failure(check, hint, format string, args ...any) finding {
+	return finding{check: check, status: statusFailure, message: fmt.Sprintf(format, args...), hint: hint}
+}
+
+type command struct {
+	envfile string
+	timeout time.Duration
+}
+
+func (c *command) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_ = godotenv.Load(c.envfile)
+
+	var findings []finding
+
+	config, err := server.LoadConfig()
+	if err != nil {
+		findings = append(findings, failure("config",
+			"fix the GITNESS_* environment variables (see the envfile) and run the doctor again",
+			"failed to load configuration: %s", err))
+	} else {
+		findings = append(findings, checkConfig(config)...)
+		findings = append(findings, checkDatabase(ctx, config)...)
+		findings = append(findings, checkGit(ctx)...)
+		findings = append(findings, checkHookPath(config)...)
+		findings = append(findings, checkDirectories(config)...)
+		findings = append(findings, checkServer(ctx, config)...)
+	}
+
+	failures := printFindings(findings)
+	if failures > 0 {
+		return fmt.Errorf("found %d problem(s) that prevent gitness from working correctly", failures)
+	}
+
+	return nil
+}
+
+// printFindings writes the findings to stdout and returns the number of failures.
+func printFindings(findings []finding) int {
+	failures := 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tCHECK\tMESSAGE")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.status, f.check, f.message)
+		if f.hint != "" {
+			fmt.Fprintf(w, "\t\t-> %s\n", f.hint)
+		}
+		if f.status == statusFailure {
+			failures++
+		}
+	}
+	_ = w.Flush()
+
+	return failures
+}
+
+// Register the doctor command.
+func Register(app *kingpin.Application) {
+	c := &command{}
+
+	cmd := app.Command("doctor", "diagnose the installation and print actionable findings").
+		Action(c.run)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("timeout", "maximum duration of all checks").
+		Default("1m").
+		DurationVar(&c.timeout)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type commandDown struct {
+	envfile string
+	steps   int
+	all     bool
+	dryRun  bool
+}
+
+func (c *commandDown) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	db, err := getDB(ctx, c.envfile)
+	if err != nil {
+		return err
+	}
+
+	version := ""
+	if !c.all {
+		version, err = migrate.Previous(ctx, db, c.steps)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.dryRun {
+		var steps []migrate.Step
+		if version == "" {
+			steps, err = migrate.PlanDown(ctx, db)
+		} else {
+			steps, err = migrate.Plan(ctx, db, version)
+		}
+		if err != nil {
+			return err
+		}
+
+		printPlan(steps)
+		return nil
+	}
+
+	// an empty version reverts all down migrations.
+	return migrate.To(ctx, db, version)
+}
+
+func registerDown(app *kingpin.CmdClause) {
+	c := &commandDown{}
+
+	cmd := app.Command("down", "reverts the most recent migrations of the database").
+		Action(c.run)
+
+	cmd.Flag("steps", "number of migrations to revert").
+		Default("1").
+		IntVar(&c.steps)
+
+	cmd.Flag("all", "revert all migrations").
+		BoolVar(&c.all)
+
+	cmd.Flag("dry-run", "print the migrations that would be reverted without reverting them").
+		BoolVar(&c.dryRun)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
@@ -17,7 +17,9 @@ package migrate
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/harness/gitness/app/store/database/migrate"
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/store/database"
 
@@ -33,6 +35,9 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("migrate", "database migration tool")
 	registerCurrent(cmd)
 	registerTo(cmd)
+	registerUp(cmd)
+	registerDown(cmd)
+	registerStatus(cmd)
 }
 
 func getDB(ctx context.Context, envfile string) (*sqlx.DB, error) {
@@ -51,6 +56,20 @@ func getDB(ctx context.Context, envfile string) (*sqlx.DB, error) {
 	return db, nil
 }
 
+// printPlan prints the SQL of all provided migration steps to stdout.
+func printPlan(steps []migrate.Step) {
+	if len(steps) == 0 {
+		fmt.Println("-- no migrations to apply")
+		return
+	}
+
+	for _, step := range steps {
+		fmt.Printf("-- migration %s (%s), version after: '%s'\n", step.Version, step.Direction, step.Result)
+		fmt.Println(strings.TrimSpace(step.SQL))
+		fmt.Println()
+	}
+}
+
 func setupLoggingContext(ctx context.Context) context.Context {
 	zerolog.SetGlobalLevel(zerolog.TraceLevel)
 	log := log.Logger.With().Logger()
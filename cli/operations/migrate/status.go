@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type commandStatus struct {
+	envfile string
+	pending bool
+}
+
+func (c *commandStatus) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	db, err := getDB(ctx, c.envfile)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := migrate.ListStatus(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATUS\tREVERSIBLE")
+	for _, status := range statuses {
+		if c.pending && status.Applied {
+			continue
+		}
+
+		state := "pending"
+		if status.Applied {
+			state = "applied"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%t\n", status.Version, state, status.Reversible)
+	}
+
+	return w.Flush()
+}
+
+func registerStatus(app *kingpin.CmdClause) {
+	c := &commandStatus{}
+
+	cmd := app.Command("status", "display the status of all migrations of the database").
+		Action(c.run)
+
+	cmd.Flag("pending", "only display migrations that are not applied yet").
+		BoolVar(&c.pending)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
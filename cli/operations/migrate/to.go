@@ -26,6 +26,7 @@ import (
 type commandTo struct {
 	envfile string
 	version string
+	dryRun  bool
 }
 
 func (c *commandTo) run(_ *kingpin.ParseContext) error {
@@ -38,6 +39,16 @@ func (c *commandTo) run(_ *kingpin.ParseContext) error {
 		return err
 	}
 
+	if c.dryRun {
+		steps, err := migrate.Plan(ctx, db, c.version)
+		if err != nil {
+			return err
+		}
+
+		printPlan(steps)
+		return nil
+	}
+
 	return migrate.To(ctx, db, c.version)
 }
 
@@ -47,6 +58,9 @@ func registerTo(app *kingpin.CmdClause) {
 	cmd := app.Command("to", "migrates the database to the provided version").
 		Action(c.run)
 
+	cmd.Flag("dry-run", "print the migrations that would be applied without applying them").
+		BoolVar(&c.dryRun)
+
 	cmd.Arg("version", "database version to migrate to").
 		Required().
 		StringVar(&c.version)
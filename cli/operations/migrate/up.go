@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type commandUp struct {
+	envfile string
+	dryRun  bool
+}
+
+func (c *commandUp) run(*kingpin.ParseContext) error {
+	ctx := setupLoggingContext(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	db, err := getDB(ctx, c.envfile)
+	if err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		steps, err := migrate.Plan(ctx, db, "")
+		if err != nil {
+			return err
+		}
+
+		printPlan(steps)
+		return nil
+	}
+
+	return migrate.Migrate(ctx, db)
+}
+
+func registerUp(app *kingpin.CmdClause) {
+	c := &commandUp{}
+
+	cmd := app.Command("up", "migrates the database to the latest version").
+		Action(c.run)
+
+	cmd.Flag("dry-run", "print the migrations that would be applied without applying them").
+		BoolVar(&c.dryRun)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
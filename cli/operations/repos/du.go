@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type duCommand struct {
+	path string
+	json bool
+}
+
+func (c *duCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().RepoDiskUsage(ctx, c.path)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return printJSON(out)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "disk size:\t%s\n", formatBytes(out.DiskSize))
+	fmt.Fprintf(w, "packs:\t%d (%d objects, %s)\n", out.Packs, out.PackedObjects, formatKiB(out.PackSize))
+	fmt.Fprintf(w, "loose objects:\t%d (%s)\n", out.LooseObjects, formatKiB(out.LooseSize))
+	fmt.Fprintf(w, "prunable:\t%d\n", out.PrunePackable)
+	fmt.Fprintf(w, "garbage:\t%d (%s)\n", out.Garbage, formatKiB(out.GarbageSize))
+	return w.Flush()
+}
+
+// helper function registers the repo du command.
+func registerDiskUsage(app *kingpin.CmdClause) {
+	c := &duCommand{}
+
+	cmd := app.Command("du", "show the disk usage of a repository").
+		Action(c.run)
+
+	cmd.Arg("path", "repository path").
+		Required().
+		StringVar(&c.path)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var errRepositoryUnhealthy = errors.New("repository integrity check found problems")
+
+type fsckCommand struct {
+	path    string
+	timeout time.Duration
+	json    bool
+}
+
+func (c *fsckCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	out, err := provide.Client().RepoFsck(ctx, c.path)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		if err = printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		for _, msg := range out.Messages {
+			fmt.Println(msg)
+		}
+	}
+
+	if !out.Healthy {
+		return errRepositoryUnhealthy
+	}
+
+	if !c.json {
+		fmt.Printf("repository %s is healthy\n", c.path)
+	}
+	return nil
+}
+
+// helper function registers the repo fsck command.
+func registerFsck(app *kingpin.CmdClause) {
+	c := &fsckCommand{}
+
+	cmd := app.Command("fsck", "verify the integrity of a repository").
+		Action(c.run)
+
+	cmd.Arg("path", "repository path").
+		Required().
+		StringVar(&c.path)
+
+	cmd.Flag("timeout", "maximum duration of the operation").
+		Default("30m").
+		DurationVar(&c.timeout)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type gcCommand struct {
+	path       string
+	aggressive bool
+	timeout    time.Duration
+	json       bool
+}
+
+func (c *gcCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	out, err := provide.Client().RepoGarbageCollect(ctx, c.path, &repo.GarbageCollectInput{
+		Aggressive: c.aggressive,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return printJSON(out)
+	}
+
+	fmt.Printf("garbage collection of %s completed: %s -> %s\n",
+		c.path, formatKiB(out.SizeBefore), formatKiB(out.SizeAfter))
+	return nil
+}
+
+// helper function registers the repo gc command.
+func registerGC(app *kingpin.CmdClause) {
+	c := &gcCommand{}
+
+	cmd := app.Command("gc", "run the git garbage collection on a repository").
+		Action(c.run)
+
+	cmd.Arg("path", "repository path").
+		Required().
+		StringVar(&c.path)
+
+	cmd.Flag("aggressive", "optimize the repository more aggressively, takes considerably longer").
+		BoolVar(&c.aggressive)
+
+	cmd.Flag("timeout", "maximum duration of the operation").
+		Default("30m").
+		DurationVar(&c.timeout)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -24,13 +24,21 @@ import (
 
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/dependencies"
+	"github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/issuetracker"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/languages"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/systemevent"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
 	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
@@ -59,6 +67,10 @@ func LoadConfig() (*types.Config, error) {
 		return nil, err
 	}
 
+	if err = database.ValidateDriver(config.Database.Driver); err != nil {
+		return nil, err
+	}
+
 	config.InstanceID, err = getSanitizedMachineName()
 	if err != nil {
 		return nil, fmt.Errorf("unable to ensure that instance ID is set in config: %w", err)
@@ -234,8 +246,12 @@ func getSanitizedMachineName() (string, error) {
 // ProvideDatabaseConfig loads the database config from the main config.
 func ProvideDatabaseConfig(config *types.Config) database.Config {
 	return database.Config{
-		Driver:     config.Database.Driver,
-		Datasource: config.Database.Datasource,
+		Driver:                config.Database.Driver,
+		Datasource:            config.Database.Datasource,
+		MaxOpenConnections:    config.Database.MaxOpenConnections,
+		MaxIdleConnections:    config.Database.MaxIdleConnections,
+		ConnectionMaxLifetime: config.Database.ConnectionMaxLifetime,
+		ConnectionMaxIdleTime: config.Database.ConnectionMaxIdleTime,
 	}
 }
 
@@ -257,6 +273,25 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		SignedURLExpiry:       config.BlobStore.SignedURLExpiry,
+		GCS: blob.GCSConfig{
+			KMSKeyName: config.BlobStore.GCS.KMSKeyName,
+		},
+		S3: blob.S3Config{
+			Region:          config.BlobStore.S3.Region,
+			Endpoint:        config.BlobStore.S3.Endpoint,
+			PathStyle:       config.BlobStore.S3.PathStyle,
+			AccessKeyID:     config.BlobStore.S3.AccessKeyID,
+			SecretAccessKey: config.BlobStore.S3.SecretAccessKey,
+			SSE:             config.BlobStore.S3.SSE,
+			SSEKMSKeyID:     config.BlobStore.S3.SSEKMSKeyID,
+		},
+		Azure: blob.AzureConfig{
+			AccountName:     config.BlobStore.Azure.AccountName,
+			AccountKey:      config.BlobStore.Azure.AccountKey,
+			Endpoint:        config.BlobStore.Azure.Endpoint,
+			EncryptionScope: config.BlobStore.Azure.EncryptionScope,
+		},
 	}, nil
 }
 
@@ -267,6 +302,7 @@ func ProvideGitConfig(config *types.Config) gittypes.Config {
 		Root:     config.Git.Root,
 		TmpDir:   config.Git.TmpDir,
 		HookPath: config.Git.HookPath,
+		Proxy:    ProvideProxyConfig(config),
 		LastCommitCache: gittypes.LastCommitCacheConfig{
 			Mode:     config.Git.LastCommitCache.Mode,
 			Duration: config.Git.LastCommitCache.Duration,
@@ -277,10 +313,18 @@ func ProvideGitConfig(config *types.Config) gittypes.Config {
 // ProvideEventsConfig loads the events config from the main config.
 func ProvideEventsConfig(config *types.Config) events.Config {
 	return events.Config{
-		Mode:                  config.Events.Mode,
-		Namespace:             config.Events.Namespace,
-		MaxStreamLength:       config.Events.MaxStreamLength,
-		ApproxMaxStreamLength: config.Events.ApproxMaxStreamLength,
+		Mode:                    config.Events.Mode,
+		Namespace:               config.Events.Namespace,
+		MaxStreamLength:         config.Events.MaxStreamLength,
+		ApproxMaxStreamLength:   config.Events.ApproxMaxStreamLength,
+		OutboxPollInterval:      config.Events.Outbox.PollInterval,
+		OutboxBatchSize:         config.Events.Outbox.BatchSize,
+		IdempotencyTTL:          config.Events.IdempotencyTTL,
+		MaxStreamAge:            config.Events.MaxStreamAge,
+		CategoryMaxStreamLength: config.Events.CategoryMaxStreamLength,
+		CategoryMaxStreamAge:    config.Events.CategoryMaxStreamAge,
+		TrimInterval:            config.Events.TrimInterval,
+		ReaderShutdownTimeout:   config.Events.ReaderShutdownTimeout,
 	}
 }
 
@@ -294,6 +338,7 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		MaxRetries:          config.Webhook.MaxRetries,
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
+		AllowedNetworks:     config.Webhook.AllowedNetworks,
 	}
 }
 
@@ -305,6 +350,34 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 	}
 }
 
+// ProvideIntegrationConfig loads the integration service config from the main config.
+func ProvideIntegrationConfig(config *types.Config) integration.Config {
+	return integration.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Integrations.Concurrency,
+		MaxRetries:      config.Integrations.MaxRetries,
+		AllowedHosts:    config.Integrations.AllowedHosts,
+	}
+}
+
+// ProvideIssueTrackerConfig loads the issue tracker service config from the main config.
+func ProvideIssueTrackerConfig(config *types.Config) issuetracker.Config {
+	return issuetracker.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.IssueTracker.Concurrency,
+		MaxRetries:      config.IssueTracker.MaxRetries,
+	}
+}
+
+// ProvideIssueConfig loads the issue service config from the main config.
+func ProvideIssueConfig(config *types.Config) issue.Config {
+	return issue.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Issues.Concurrency,
+		MaxRetries:      config.Issues.MaxRetries,
+	}
+}
+
 // ProvideTriggerConfig loads the trigger service config from the main config.
 func ProvideTriggerConfig(config *types.Config) trigger.Config {
 	return trigger.Config{
@@ -314,6 +387,15 @@ func ProvideTriggerConfig(config *types.Config) trigger.Config {
 	}
 }
 
+// ProvideProxyConfig generates the `proxy` package config from the gitness config.
+func ProvideProxyConfig(config *types.Config) proxy.Config {
+	return proxy.Config{
+		URL:          config.EgressProxy.URL,
+		NoProxy:      config.EgressProxy.NoProxy,
+		Destinations: config.EgressProxy.Destinations,
+	}
+}
+
 // ProvideLockConfig generates the `lock` package config from the gitness config.
 func ProvideLockConfig(config *types.Config) lock.Config {
 	return lock.Config{
@@ -325,6 +407,9 @@ func ProvideLockConfig(config *types.Config) lock.Config {
 		RetryDelay:    config.Lock.RetryDelay,
 		DriftFactor:   config.Lock.DriftFactor,
 		TimeoutFactor: config.Lock.TimeoutFactor,
+
+		LeaderTTL:           config.Lock.LeaderTTL,
+		LeaderRetryInterval: config.Lock.LeaderRetryInterval,
 	}
 }
 
@@ -345,6 +430,12 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		DeletedUsersRetentionTime:        config.Principal.DeletedUsersRetentionTime,
+		SystemEventsRetentionTime:        config.SystemEvents.RetentionTime,
+		RepoEventsRetentionTime:          config.Realtime.RetentionTime,
+		IdempotencyKeysRetentionTime:     config.IdempotencyKeys.RetentionTime,
+		AuditLogsRetentionTime:           config.Audit.RetentionTime,
+		AuditEnabled:                     config.Audit.Enabled,
 	}
 }
 
@@ -355,6 +446,24 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 	}
 }
 
+// ProvideLanguagesConfig loads the language detection service config from the main config.
+func ProvideLanguagesConfig(config *types.Config) languages.Config {
+	return languages.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Languages.Concurrency,
+		MaxRetries:      config.Languages.MaxRetries,
+	}
+}
+
+// ProvideDependenciesConfig loads the dependency detection service config from the main config.
+func ProvideDependenciesConfig(config *types.Config) dependencies.Config {
+	return dependencies.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Dependencies.Concurrency,
+		MaxRetries:      config.Dependencies.MaxRetries,
+	}
+}
+
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	return keywordsearch.Config{
@@ -364,6 +473,24 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	}
 }
 
+// ProvideSystemEventConfig loads the system event service config from the main config.
+func ProvideSystemEventConfig(config *types.Config) systemevent.Config {
+	return systemevent.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.SystemEvents.Concurrency,
+		MaxRetries:      config.SystemEvents.MaxRetries,
+	}
+}
+
+// ProvideRealtimeConfig loads the realtime service config from the main config.
+func ProvideRealtimeConfig(config *types.Config) realtime.Config {
+	return realtime.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Realtime.Concurrency,
+		MaxRetries:      config.Realtime.MaxRetries,
+	}
+}
+
 func ProvideJobsConfig(config *types.Config) job.Config {
 	return job.Config{
 		InstanceID:                  config.InstanceID,
@@ -100,14 +100,81 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if err := system.services.Usage.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register usage aggregator")
+			return err
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
 		}
 
+		if err := system.services.KeyRotation.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register key rotation service")
+			return err
+		}
+
+		if err := system.services.Runners.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register runner lease service")
+			return err
+		}
+
+		if err := system.services.Registries.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register registry cleanup service")
+			return err
+		}
+
+		if err := system.services.Archival.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repository archival service")
+			return err
+		}
+
+		if err := system.services.Vulnerability.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register vulnerability alerts service")
+			return err
+		}
+
+		if err := system.services.ReviewReminder.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register review reminder service")
+			return err
+		}
+
+		if err := system.services.MergeSchedule.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge schedule service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
+	// reload the settings that can be changed at runtime on SIGHUP (or via the admin api).
+	// The env file is loaded again, overriding the previously loaded values.
+	system.services.ConfigReloader.Register("log level", func(_ context.Context, config *types.Config) error {
+		setLogLevel(config)
+		return nil
+	})
+	g.Go(func() error {
+		return system.services.ConfigReloader.Run(gCtx, func() (*types.Config, error) {
+			_ = godotenv.Overload(c.envfile)
+			return LoadConfig()
+		})
+	})
+
+	if system.services.EventOutboxRelay != nil {
+		g.Go(func() error {
+			return system.services.EventOutboxRelay.Run(gCtx)
+		})
+	}
+
+	if system.services.EventStreamTrimmer != nil {
+		// the streams are shared by all instances, hence only the leader trims them.
+		g.Go(func() error {
+			return system.services.Elector.RunAsLeader(gCtx, "events-stream-trimmer",
+				system.services.EventStreamTrimmer.Run)
+		})
+	}
+
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
@@ -145,15 +212,24 @@ func (c *command) run(*kingpin.ParseContext) error {
 	log.Info().Msg("shutting down gracefully (press Ctrl+C again to force)")
 
 	// shutdown servers gracefully
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.GracefulShutdownTime)
+	shutdownCtx, cancel := context.WithTimeout(log.WithContext(context.Background()), config.GracefulShutdownTime)
 	defer cancel()
 
+	// stop accepting new requests and wait for the in-flight git transfers before closing the listeners,
+	// as the git hooks of in-flight pushes still have to reach the server.
+	if dErr := system.server.Drain(shutdownCtx); dErr != nil {
+		log.Err(dErr).Msg("failed to drain http server gracefully")
+	}
+
 	if sErr := shutdownHTTP(shutdownCtx); sErr != nil {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
 
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
+	// event readers were stopped together with the context and complete the events they already read.
+	system.services.EventSystem.WaitReadersDone(shutdownCtx)
+
 	log.Info().Msg("wait for subroutines to complete")
 	err = g.Wait()
 
@@ -163,14 +239,7 @@ func (c *command) run(*kingpin.ParseContext) error {
 // SetupLogger configures the global logger from the loaded configuration.
 func SetupLogger(config *types.Config) {
 	// configure the log level
-	switch {
-	case config.Trace:
-		zerolog.SetGlobalLevel(zerolog.TraceLevel)
-	case config.Debug:
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	default:
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	}
+	setLogLevel(config)
 
 	// configure time format (ignored if running in terminal)
 	zerolog.TimeFieldFormat = time.RFC3339Nano
@@ -188,6 +257,18 @@ func SetupLogger(config *types.Config) {
 	}
 }
 
+// setLogLevel sets the global log level, it's called again whenever the configuration is reloaded.
+func setLogLevel(config *types.Config) {
+	switch {
+	case config.Trace:
+		zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	case config.Debug:
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	default:
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	}
+}
+
 func SetupProfiler(config *types.Config) {
 	profilerType, parsed := profiler.ParseType(config.Profiler.Type)
 	if !parsed {
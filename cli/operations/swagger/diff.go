@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swagger
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// change describes a single difference between two specs.
+type change struct {
+	kind string // "+" added, "-" removed, "~" changed
+	path []string
+}
+
+func (c change) String() string {
+	b := strings.Builder{}
+	b.WriteString(c.kind)
+	b.WriteString(" ")
+	for i, elem := range c.path {
+		if i > 0 && !strings.HasPrefix(elem, "[") {
+			b.WriteString(".")
+		}
+		b.WriteString(elem)
+	}
+	return b.String()
+}
+
+// diff returns all differences between the old and new generic json values, sorted by path.
+func diff(oldValue, newValue interface{}) []change {
+	var changes []change
+	diffValue(nil, oldValue, newValue, &changes)
+	return changes
+}
+
+func diffValue(path []string, oldValue, newValue interface{}, changes *[]change) {
+	switch oldTyped := oldValue.(type) {
+	case map[string]interface{}:
+		newTyped, ok := newValue.(map[string]interface{})
+		if !ok {
+			break
+		}
+		diffObject(path, oldTyped, newTyped, changes)
+		return
+	case []interface{}:
+		newTyped, ok := newValue.([]interface{})
+		if !ok {
+			break
+		}
+		diffArray(path, oldTyped, newTyped, changes)
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		*changes = append(*changes, change{kind: "~", path: path})
+	}
+}
+
+func diffObject(path []string, oldValue, newValue map[string]interface{}, changes *[]change) {
+	keys := make([]string, 0, len(oldValue)+len(newValue))
+	for key := range oldValue {
+		keys = append(keys, key)
+	}
+	for key := range newValue {
+		if _, ok := oldValue[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := append(path[:len(path):len(path)], key)
+
+		oldEntry, inOld := oldValue[key]
+		newEntry, inNew := newValue[key]
+		switch {
+		case !inOld:
+			*changes = append(*changes, change{kind: "+", path: keyPath})
+		case !inNew:
+			*changes = append(*changes, change{kind: "-", path: keyPath})
+		default:
+			diffValue(keyPath, oldEntry, newEntry, changes)
+		}
+	}
+}
+
+func diffArray(path []string, oldValue, newValue []interface{}, changes *[]change) {
+	for i := 0; i < len(oldValue) || i < len(newValue); i++ {
+		indexPath := append(path[:len(path):len(path)], "["+strconv.Itoa(i)+"]")
+
+		switch {
+		case i >= len(oldValue):
+			*changes = append(*changes, change{kind: "+", path: indexPath})
+		case i >= len(newValue):
+			*changes = append(*changes, change{kind: "-", path: indexPath})
+		default:
+			diffValue(indexPath, oldValue[i], newValue[i], changes)
+		}
+	}
+}
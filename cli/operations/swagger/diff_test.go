@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldSpec := `{
+		"info": {"version": "1.0.0"},
+		"paths": {
+			"/a": {"get": {"parameters": [{"name": "x"}]}},
+			"/b": {}
+		}
+	}`
+	newSpec := `{
+		"info": {"version": "1.1.0"},
+		"paths": {
+			"/a": {"get": {"parameters": [{"name": "x"}, {"name": "y"}]}},
+			"/c": {}
+		}
+	}`
+
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(oldSpec), &oldValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(newSpec), &newValue); err != nil {
+		t.Fatal(err)
+	}
+
+	if changes := diff(oldValue, oldValue); len(changes) != 0 {
+		t.Errorf("expected no changes for identical specs, got %v", changes)
+	}
+
+	changes := diff(oldValue, newValue)
+	got := make([]string, len(changes))
+	for i, c := range changes {
+		got[i] = c.String()
+	}
+
+	want := []string{
+		"~ info.version",
+		"+ paths./a.get.parameters[1]",
+		"- paths./b",
+		"+ paths./c",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected changes %v, got %v", want, got)
+	}
+}
@@ -15,29 +15,118 @@
 package swagger
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 
 	"github.com/harness/gitness/app/api/openapi"
 
+	"github.com/swaggest/openapi-go/openapi3"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
 )
 
 type command struct {
 	openAPIService openapi.Service
 	path           string
+	format         string
+	diff           string
 }
 
 func (c *command) run(*kingpin.ParseContext) error {
 	spec := c.openAPIService.Generate()
-	data, _ := spec.MarshalYAML()
+
+	if c.diff != "" {
+		return c.runDiff(spec)
+	}
+
+	data, err := marshal(spec, c.format)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
 	if c.path == "" {
-		os.Stdout.Write(data)
-		return nil
+		_, err = os.Stdout.Write(data)
+		return err
 	}
 
 	return os.WriteFile(c.path, data, 0o600)
 }
 
+// runDiff compares the generated spec with the existing spec and prints all differences.
+// It returns an error if the specs differ, to allow usage as API compatibility gate.
+func (c *command) runDiff(spec *openapi3.Spec) error {
+	data, err := os.ReadFile(c.diff)
+	if err != nil {
+		return fmt.Errorf("failed to read existing spec: %w", err)
+	}
+
+	oldValue, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse existing spec: %w", err)
+	}
+
+	newData, err := spec.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal generated spec: %w", err)
+	}
+
+	newValue, err := parse(newData)
+	if err != nil {
+		return fmt.Errorf("failed to parse generated spec: %w", err)
+	}
+
+	changes := diff(oldValue, newValue)
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if len(changes) > 0 {
+		return fmt.Errorf("generated spec differs from %s (%d changes)", c.diff, len(changes))
+	}
+
+	return nil
+}
+
+func marshal(spec *openapi3.Spec, format string) ([]byte, error) {
+	if format == formatJSON {
+		return json.MarshalIndent(spec, "", "  ")
+	}
+
+	return spec.MarshalYAML()
+}
+
+// parse converts a yaml or json spec into generic json values that can be compared.
+func parse(data []byte) (interface{}, error) {
+	var v interface{}
+	if json.Valid(data) {
+		err := json.Unmarshal(data, &v)
+		return v, err
+	}
+
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	// round trip through json to ensure numbers are of the same type, independent of the format.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	v = nil
+	if err = json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
 // helper function to register the swagger command.
 func Register(app *kingpin.Application, openAPIService openapi.Service) {
 	c := &command{
@@ -50,4 +139,12 @@ func Register(app *kingpin.Application, openAPIService openapi.Service) {
 
 	cmd.Arg("path", "path to save swagger file").
 		StringVar(&c.path)
+
+	cmd.Flag("format", "output format of the swagger file").
+		Default(formatYAML).
+		EnumVar(&c.format, formatYAML, formatJSON)
+
+	cmd.Flag("diff", "compare the generated spec with an existing spec file and fail if they differ").
+		PlaceHolder("EXISTING-SPEC").
+		StringVar(&c.diff)
 }
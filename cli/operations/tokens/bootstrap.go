@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/token"
+	gitnesscache "github.com/harness/gitness/cache"
+	"github.com/harness/gitness/cli/operations/server"
+	dbstore "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/joho/godotenv"
+)
+
+// stores contains the stores required to create tokens directly in the database.
+type stores struct {
+	principal store.PrincipalStore
+	token     store.TokenStore
+	space     store.SpaceStore
+
+	// systemUID is the uid of the system service principal.
+	systemUID string
+}
+
+// bootstrapStores opens the stores directly on the configured database.
+// It is used to provision tokens without a running server.
+func bootstrapStores(ctx context.Context, envfile string) (*stores, func(), error) {
+	_ = godotenv.Load(envfile)
+
+	config, err := server.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := dbstore.Connect(ctx, config.Database.Driver, config.Database.Datasource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create database handle: %w", err)
+	}
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+
+	return &stores{
+		principal: database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation, gitnesscache.NoRowCache{}),
+		token:     database.NewTokenStore(db, gitnesscache.NoRowCache{}),
+		space:     database.NewSpaceStore(db, spacePathCache, spacePathStore),
+		systemUID: config.Principal.System.UID,
+	}, func() { _ = db.Close() }, nil
+}
+
+// bootstrapCreate creates a token for the user or service account directly in the database.
+// The token is created on behalf of the system service principal.
+func bootstrapCreate(
+	ctx context.Context,
+	stores *stores,
+	principalUID string,
+	identifier string,
+	lifetime *time.Duration,
+	tokenScope *types.TokenScope,
+) (*types.TokenResponse, error) {
+	if err := check.Identifier(identifier); err != nil {
+		return nil, err
+	}
+	if err := check.TokenLifetime(lifetime, true); err != nil {
+		return nil, err
+	}
+
+	scope, err := token.ResolveScope(ctx, stores.space, tokenScope)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := stores.principal.FindByUID(ctx, principalUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal '%s': %w", principalUID, err)
+	}
+
+	createdBy := principal
+	if system, err := stores.principal.FindServiceByUID(ctx, stores.systemUID); err == nil {
+		createdBy = system.ToPrincipal()
+	}
+
+	var tkn *types.Token
+	var jwtToken string
+
+	//nolint:exhaustive // tokens can only be created for users and service accounts
+	switch principal.Type {
+	case enum.PrincipalTypeUser:
+		usr, err := stores.principal.FindUserByUID(ctx, principalUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user '%s': %w", principalUID, err)
+		}
+		tkn, jwtToken, err = token.CreatePAT(ctx, stores.token, createdBy, usr, identifier, lifetime, scope)
+		if err != nil {
+			return nil, err
+		}
+	case enum.PrincipalTypeServiceAccount:
+		sa, err := stores.principal.FindServiceAccountByUID(ctx, principalUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find service account '%s': %w", principalUID, err)
+		}
+		tkn, jwtToken, err = token.CreateSAT(ctx, stores.token, createdBy, sa, identifier, lifetime, scope)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("tokens can't be created for principals of type %s", principal.Type)
+	}
+
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/serviceaccount"
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var errPrincipalRequired = errors.New("principal is required in bootstrap mode")
+
+type createCommand struct {
+	identifier string
+	principal  string
+	scope      string
+	ttl        time.Duration
+	bootstrap  bool
+	envfile    string
+	tmpl       string
+	json       bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	scope, err := parseScope(c.scope)
+	if err != nil {
+		return err
+	}
+
+	var lifetime *time.Duration
+	if c.ttl > 0 {
+		lifetime = &c.ttl
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var tokenResp *types.TokenResponse
+	if c.bootstrap {
+		tokenResp, err = c.createDirect(ctx, lifetime, scope)
+	} else {
+		tokenResp, err = c.createRemote(ctx, lifetime, scope)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = printToken(tokenResp, c.json, c.tmpl); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "the token is shown only once, store it securely.")
+	return nil
+}
+
+// createRemote creates the token using the API.
+// Without a principal, the token is created for the logged in user.
+func (c *createCommand) createRemote(
+	ctx context.Context,
+	lifetime *time.Duration,
+	scope *types.TokenScope,
+) (*types.TokenResponse, error) {
+	cli := provide.Client()
+
+	if c.principal == "" {
+		return cli.UserCreatePAT(ctx, user.CreateTokenInput{
+			Identifier: c.identifier,
+			Lifetime:   lifetime,
+			Scope:      scope,
+		})
+	}
+
+	principals, err := cli.PrincipalList(ctx, c.principal, enum.PrincipalTypeUser, enum.PrincipalTypeServiceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	for _, principal := range principals {
+		if !strings.EqualFold(principal.UID, c.principal) {
+			continue
+		}
+
+		//nolint:exhaustive // tokens can only be created for users and service accounts
+		switch principal.Type {
+		case enum.PrincipalTypeUser:
+			return cli.UserCreateToken(ctx, principal.UID, &user.CreateTokenInput{
+				Identifier: c.identifier,
+				Lifetime:   lifetime,
+				Scope:      scope,
+			})
+		case enum.PrincipalTypeServiceAccount:
+			return cli.ServiceAccountCreateToken(ctx, principal.UID, &serviceaccount.CreateTokenInput{
+				Identifier: c.identifier,
+				Lifetime:   lifetime,
+				Scope:      scope,
+			})
+		}
+	}
+
+	return nil, fmt.Errorf("principal '%s' not found", c.principal)
+}
+
+// createDirect creates the token directly in the database, without a running server.
+func (c *createCommand) createDirect(
+	ctx context.Context,
+	lifetime *time.Duration,
+	scope *types.TokenScope,
+) (*types.TokenResponse, error) {
+	if c.principal == "" {
+		return nil, errPrincipalRequired
+	}
+
+	stores, closeDB, err := bootstrapStores(ctx, c.envfile)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDB()
+
+	return bootstrapCreate(ctx, stores, c.principal, c.identifier, lifetime, scope)
+}
+
+// helper function registers the token create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create an access token, the token is printed only once").
+		Action(c.run)
+
+	cmd.Arg("identifier", "the identifier of the token").
+		Required().
+		StringVar(&c.identifier)
+
+	cmd.Flag("principal", "uid of the user or service account the token is created for "+
+		"(defaults to the logged in user)").
+		StringVar(&c.principal)
+
+	cmd.Flag("scope", "restrict the token to a role within a space, using the format <space>:<role>").
+		StringVar(&c.scope)
+
+	cmd.Flag("ttl", "the lifetime of the token, by default the token doesn't expire").
+		DurationVar(&c.ttl)
+
+	cmd.Flag("bootstrap", "create the token directly in the database, e.g. to provision automation").
+		BoolVar(&c.bootstrap)
+
+	cmd.Flag("envfile", "load the environment variable file (bootstrap mode only)").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(tokenTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
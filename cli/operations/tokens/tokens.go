@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/funcmap"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const tokenTmpl = `
+principalID: {{ .Token.PrincipalID }}
+identifier:  {{ .Token.Identifier }}
+expiresAt:   {{ .Token.ExpiresAt }}
+token:       {{ .AccessToken }}
+` //#nosec G101
+
+var errScopeInvalid = errors.New("scope has to be of the format <space>:<role>")
+
+// Register the command.
+func Register(app *kingpin.Application) {
+	cmd := app.Command("token", "manage access tokens")
+	registerCreate(cmd)
+}
+
+// parseScope parses a token scope of the format <space>:<role>.
+// Returns nil if the scope is empty.
+func parseScope(raw string) (*types.TokenScope, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	i := strings.LastIndex(raw, ":")
+	if i <= 0 || i == len(raw)-1 {
+		return nil, errScopeInvalid
+	}
+
+	role, ok := enum.MembershipRole(raw[i+1:]).Sanitize()
+	if !ok {
+		return nil, fmt.Errorf("role '%s' of scope is invalid, expected one of %v", raw[i+1:], enum.MembershipRoles)
+	}
+
+	return &types.TokenScope{
+		SpaceRef: raw[:i],
+		Role:     role,
+	}, nil
+}
+
+// printToken writes the token to stdout, either json encoded or using the provided template.
+func printToken(tokenResp *types.TokenResponse, asJSON bool, format string) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tokenResp)
+	}
+	tmpl, err := template.New("_").Funcs(funcmap.Funcs).Parse(format)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, tokenResp)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    *types.TokenScope
+		wantErr bool
+	}{
+		{raw: "", want: nil},
+		{raw: "space:reader", want: &types.TokenScope{SpaceRef: "space", Role: enum.MembershipRoleReader}},
+		{
+			raw:  "space/sub:space_owner",
+			want: &types.TokenScope{SpaceRef: "space/sub", Role: enum.MembershipRoleSpaceOwner},
+		},
+		{raw: "space", wantErr: true},
+		{raw: "space:", wantErr: true},
+		{raw: ":reader", wantErr: true},
+		{raw: "space:unknown", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := parseScope(test.raw)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseScope(%q): expected error, got %+v", test.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseScope(%q): unexpected error: %v", test.raw, err)
+			continue
+		}
+		if (got == nil) != (test.want == nil) || (got != nil && *got != *test.want) {
+			t.Errorf("parseScope(%q): expected %+v, got %+v", test.raw, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/cli/operations/server"
+	dbstore "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/joho/godotenv"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bootstrapStore opens the principal store directly on the configured database.
+// It is used when the server isn't running yet, e.g. to create the first admin.
+func bootstrapStore(ctx context.Context, envfile string) (*database.PrincipalStore, func(), error) {
+	_ = godotenv.Load(envfile)
+
+	config, err := server.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := dbstore.Connect(ctx, config.Database.Driver, config.Database.Datasource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create database handle: %w", err)
+	}
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation, cache.NoRowCache{})
+
+	return principalStore, func() { _ = db.Close() }, nil
+}
+
+// bootstrapCreate creates a user directly in the database.
+// The first user created in the system always becomes an admin.
+func bootstrapCreate(
+	ctx context.Context,
+	principalStore *database.PrincipalStore,
+	in *user.CreateInput,
+	admin bool,
+) (*types.User, error) {
+	in.Email = strings.TrimSpace(in.Email)
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+
+	if err := check.PrincipalUIDDefault(in.UID); err != nil {
+		return nil, err
+	}
+	if err := check.Email(in.Email); err != nil {
+		return nil, err
+	}
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return nil, err
+	}
+	if err := check.Password(in.Password); err != nil {
+		return nil, err
+	}
+
+	count, err := principalStore.CountUsers(ctx, &types.UserFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(in.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	usr := &types.User{
+		UID:         in.UID,
+		DisplayName: in.DisplayName,
+		Email:       in.Email,
+		Password:    string(hash),
+		Salt:        uniuri.NewLen(uniuri.UUIDLen),
+		Created:     now,
+		Updated:     now,
+		Admin:       admin || count == 0,
+	}
+
+	if err = principalStore.CreateUser(ctx, usr); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return usr, nil
+}
+
+// bootstrapResetPassword updates the password of a user directly in the database.
+func bootstrapResetPassword(
+	ctx context.Context,
+	principalStore *database.PrincipalStore,
+	uid string,
+	password string,
+) (*types.User, error) {
+	if err := check.Password(password); err != nil {
+		return nil, err
+	}
+
+	usr, err := principalStore.FindUserByUID(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	usr.Password = string(hash)
+	usr.Updated = time.Now().UnixMilli()
+
+	if err = principalStore.UpdateUser(ctx, usr); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return usr, nil
+}
@@ -16,50 +16,83 @@ package users
 
 import (
 	"context"
-	"encoding/json"
-	"os"
-	"text/template"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
 	"github.com/harness/gitness/cli/textui"
 	"github.com/harness/gitness/types"
 
-	"github.com/drone/funcmap"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 type createCommand struct {
-	email string
-	admin bool
-	tmpl  string
-	json  bool
+	uid         string
+	email       string
+	displayName string
+	password    string
+	admin       bool
+	bootstrap   bool
+	envfile     string
+	tmpl        string
+	json        bool
 }
 
 func (c *createCommand) run(*kingpin.ParseContext) error {
-	in := &types.User{
-		Admin:    c.admin,
-		Email:    c.email,
-		Password: textui.Password(),
+	in := &user.CreateInput{
+		UID:         c.uid,
+		Email:       c.email,
+		DisplayName: c.displayName,
+		Password:    c.password,
+	}
+	if in.DisplayName == "" {
+		in.DisplayName = c.uid
+	}
+	if in.Password == "" {
+		in.Password = textui.Password()
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	user, err := provide.Client().UserCreate(ctx, in)
+	var usr *types.User
+	var err error
+	if c.bootstrap {
+		usr, err = c.createDirect(ctx, in)
+	} else {
+		usr, err = c.createRemote(ctx, in)
+	}
 	if err != nil {
 		return err
 	}
-	if c.json {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(user)
+
+	return printUser(usr, c.json, c.tmpl)
+}
+
+// createRemote creates the user using the API, the caller has to be an admin.
+func (c *createCommand) createRemote(ctx context.Context, in *user.CreateInput) (*types.User, error) {
+	cli := provide.Client()
+
+	usr, err := cli.UserCreate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if !c.admin || usr.Admin {
+		return usr, nil
 	}
-	tmpl, err := template.New("_").Funcs(funcmap.Funcs).Parse(c.tmpl)
+
+	return cli.UserUpdateAdmin(ctx, usr.UID, &user.UpdateAdminInput{Admin: true})
+}
+
+// createDirect creates the user directly in the database, without a running server.
+func (c *createCommand) createDirect(ctx context.Context, in *user.CreateInput) (*types.User, error) {
+	principalStore, closeDB, err := bootstrapStore(ctx, c.envfile)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return tmpl.Execute(os.Stdout, user)
+	defer closeDB()
+
+	return bootstrapCreate(ctx, principalStore, in, c.admin)
 }
 
 // helper function registers the user create command.
@@ -69,13 +102,30 @@ func registerCreate(app *kingpin.CmdClause) {
 	cmd := app.Command("create", "create a user").
 		Action(c.run)
 
+	cmd.Arg("uid", "user uid").
+		Required().
+		StringVar(&c.uid)
+
 	cmd.Arg("email", "user email").
 		Required().
 		StringVar(&c.email)
 
-	cmd.Arg("admin", "user is admin").
+	cmd.Flag("display-name", "user display name (defaults to the uid)").
+		StringVar(&c.displayName)
+
+	cmd.Flag("password", "user password (prompted if not provided)").
+		StringVar(&c.password)
+
+	cmd.Flag("admin", "user is admin").
 		BoolVar(&c.admin)
 
+	cmd.Flag("bootstrap", "create the user directly in the database, e.g. to create the first admin").
+		BoolVar(&c.bootstrap)
+
+	cmd.Flag("envfile", "load the environment variable file (bootstrap mode only)").
+		Default("").
+		StringVar(&c.envfile)
+
 	cmd.Flag("json", "json encode the output").
 		BoolVar(&c.json)
 
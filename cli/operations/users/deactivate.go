@@ -16,6 +16,7 @@ package users
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/harness/gitness/cli/provide"
@@ -23,25 +24,31 @@ import (
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
-type deleteCommand struct {
-	email string
+type deactivateCommand struct {
+	uid string
 }
 
-func (c *deleteCommand) run(*kingpin.ParseContext) error {
+func (c *deactivateCommand) run(*kingpin.ParseContext) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	return provide.Client().UserDelete(ctx, c.email)
+	if err := provide.Client().UserDelete(ctx, c.uid); err != nil {
+		return err
+	}
+
+	fmt.Printf("user %s deactivated\n", c.uid)
+	return nil
 }
 
-// helper function registers the user delete command.
-func registerDelete(app *kingpin.CmdClause) {
-	c := &deleteCommand{}
+// helper function registers the user deactivate command.
+func registerDeactivate(app *kingpin.CmdClause) {
+	c := &deactivateCommand{}
 
-	cmd := app.Command("delete", "delete a user").
+	cmd := app.Command("deactivate", "deactivate a user, the account can be reactivated later").
+		Alias("delete").
 		Action(c.run)
 
-	cmd.Arg("id or email", "user id or email").
+	cmd.Arg("uid", "user uid").
 		Required().
-		StringVar(&c.email)
+		StringVar(&c.uid)
 }
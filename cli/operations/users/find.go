@@ -60,7 +60,7 @@ func registerFind(app *kingpin.CmdClause) {
 	cmd := app.Command("find", "display user details").
 		Action(c.run)
 
-	cmd.Arg("id or email", "user id or email").
+	cmd.Arg("uid", "user uid").
 		Required().
 		StringVar(&c.email)
 
@@ -29,16 +29,19 @@ import (
 )
 
 const userTmpl = `
-id:    {{ .ID }}
-email: {{ .Email }}
-admin: {{ .Admin }}
+id:      {{ .ID }}
+uid:     {{ .UID }}
+email:   {{ .Email }}
+admin:   {{ .Admin }}
+blocked: {{ .Blocked }}
 `
 
 type listCommand struct {
-	tmpl string
-	page int
-	size int
-	json bool
+	tmpl    string
+	page    int
+	size    int
+	deleted bool
+	json    bool
 }
 
 func (c *listCommand) run(*kingpin.ParseContext) error {
@@ -46,8 +49,9 @@ func (c *listCommand) run(*kingpin.ParseContext) error {
 	defer cancel()
 
 	list, err := provide.Client().UserList(ctx, types.UserFilter{
-		Size: c.size,
-		Page: c.page,
+		Size:    c.size,
+		Page:    c.page,
+		Deleted: c.deleted,
 	})
 	if err != nil {
 		return err
@@ -74,6 +78,7 @@ func registerList(app *kingpin.CmdClause) {
 	c := &listCommand{}
 
 	cmd := app.Command("ls", "display a list of users").
+		Alias("list").
 		Action(c.run)
 
 	cmd.Flag("page", "page number").
@@ -82,6 +87,9 @@ func registerList(app *kingpin.CmdClause) {
 	cmd.Flag("per-page", "page size").
 		IntVar(&c.size)
 
+	cmd.Flag("deactivated", "list deactivated users").
+		BoolVar(&c.deleted)
+
 	cmd.Flag("json", "json encode the output").
 		BoolVar(&c.json)
 
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type reactivateCommand struct {
+	uid  string
+	tmpl string
+	json bool
+}
+
+func (c *reactivateCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	usr, err := provide.Client().UserRestore(ctx, c.uid)
+	if err != nil {
+		return err
+	}
+
+	return printUser(usr, c.json, c.tmpl)
+}
+
+// helper function registers the user reactivate command.
+func registerReactivate(app *kingpin.CmdClause) {
+	c := &reactivateCommand{}
+
+	cmd := app.Command("reactivate", "reactivate a deactivated user").
+		Action(c.run)
+
+	cmd.Arg("uid", "user uid").
+		Required().
+		StringVar(&c.uid)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(userTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
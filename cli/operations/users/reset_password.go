@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"github.com/dchest/uniuri"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type resetPasswordCommand struct {
+	uid       string
+	password  string
+	generate  bool
+	bootstrap bool
+	envfile   string
+	tmpl      string
+	json      bool
+}
+
+func (c *resetPasswordCommand) run(*kingpin.ParseContext) error {
+	password := c.password
+	switch {
+	case c.generate:
+		const maxRandomChars = 16
+		password = uniuri.NewLen(maxRandomChars)
+		fmt.Printf("generated temporary password: %s\n", password)
+	case password == "":
+		password = textui.Password()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var usr *types.User
+	var err error
+	if c.bootstrap {
+		usr, err = c.resetDirect(ctx, password)
+	} else {
+		usr, err = provide.Client().UserUpdate(ctx, c.uid, &user.UpdateInput{Password: &password})
+	}
+	if err != nil {
+		return err
+	}
+
+	return printUser(usr, c.json, c.tmpl)
+}
+
+// resetDirect updates the password directly in the database, without a running server.
+func (c *resetPasswordCommand) resetDirect(ctx context.Context, password string) (*types.User, error) {
+	principalStore, closeDB, err := bootstrapStore(ctx, c.envfile)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDB()
+
+	return bootstrapResetPassword(ctx, principalStore, c.uid, password)
+}
+
+// helper function registers the user reset-password command.
+func registerResetPassword(app *kingpin.CmdClause) {
+	c := &resetPasswordCommand{}
+
+	cmd := app.Command("reset-password", "reset the password of a user").
+		Action(c.run)
+
+	cmd.Arg("uid", "user uid").
+		Required().
+		StringVar(&c.uid)
+
+	cmd.Flag("password", "new user password (prompted if not provided)").
+		StringVar(&c.password)
+
+	cmd.Flag("generate", "generate a random password").
+		BoolVar(&c.generate)
+
+	cmd.Flag("bootstrap", "update the password directly in the database").
+		BoolVar(&c.bootstrap)
+
+	cmd.Flag("envfile", "load the environment variable file (bootstrap mode only)").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(userTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
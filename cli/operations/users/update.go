@@ -16,17 +16,13 @@ package users
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
-	"text/template"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
-	"github.com/harness/gitness/types"
 
 	"github.com/dchest/uniuri"
-	"github.com/drone/funcmap"
 	"github.com/gotidy/ptr"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -43,19 +39,13 @@ type updateCommand struct {
 }
 
 func (c *updateCommand) run(*kingpin.ParseContext) error {
-	in := new(types.UserInput)
+	in := new(user.UpdateInput)
 	if v := c.email; v != "" {
 		in.Email = ptr.String(v)
 	}
 	if v := c.pass; v != "" {
 		in.Password = ptr.String(v)
 	}
-	if v := c.admin; v {
-		in.Admin = ptr.Bool(v)
-	}
-	if v := c.demote; v {
-		in.Admin = ptr.Bool(false)
-	}
 	if c.passgen {
 		const maxRandomChars = 8
 		v := uniuri.NewLen(maxRandomChars)
@@ -66,20 +56,20 @@ func (c *updateCommand) run(*kingpin.ParseContext) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	user, err := provide.Client().UserUpdate(ctx, c.id, in)
+	cli := provide.Client()
+
+	usr, err := cli.UserUpdate(ctx, c.id, in)
 	if err != nil {
 		return err
 	}
-	if c.json {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(user)
+	if c.admin || c.demote {
+		usr, err = cli.UserUpdateAdmin(ctx, c.id, &user.UpdateAdminInput{Admin: c.admin})
+		if err != nil {
+			return err
+		}
 	}
-	tmpl, err := template.New("_").Funcs(funcmap.Funcs).Parse(c.tmpl)
-	if err != nil {
-		return err
-	}
-	return tmpl.Execute(os.Stdout, user)
+
+	return printUser(usr, c.json, c.tmpl)
 }
 
 // helper function registers the user update command.
@@ -89,7 +79,7 @@ func registerUpdate(app *kingpin.CmdClause) {
 	cmd := app.Command("update", "update a user").
 		Action(c.run)
 
-	cmd.Arg("id or email", "user id or email").
+	cmd.Arg("uid", "user uid").
 		Required().
 		StringVar(&c.id)
 
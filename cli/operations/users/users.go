@@ -15,6 +15,13 @@
 package users
 
 import (
+	"encoding/json"
+	"os"
+	"text/template"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/funcmap"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -25,5 +32,21 @@ func Register(app *kingpin.Application) {
 	registerList(cmd)
 	registerCreate(cmd)
 	registerUpdate(cmd)
-	registerDelete(cmd)
+	registerResetPassword(cmd)
+	registerDeactivate(cmd)
+	registerReactivate(cmd)
+}
+
+// printUser writes the user to stdout, either json encoded or using the provided template.
+func printUser(user *types.User, asJSON bool, format string) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(user)
+	}
+	tmpl, err := template.New("_").Funcs(funcmap.Funcs).Parse(format)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, user)
 }
@@ -24,8 +24,11 @@ import (
 	"net/http/httputil"
 	"net/url"
 
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 	"github.com/harness/gitness/version"
 
 	"github.com/rs/zerolog/log"
@@ -104,10 +107,53 @@ func (c *HTTPClient) UserCreatePAT(ctx context.Context, in user.CreateTokenInput
 	return out, err
 }
 
-// User returns a user by ID or email.
+// UserCreateToken creates a new PAT for a user account by UID.
+func (c *HTTPClient) UserCreateToken(
+	ctx context.Context,
+	key string,
+	in *user.CreateTokenInput,
+) (*types.TokenResponse, error) {
+	out := new(types.TokenResponse)
+	uri := fmt.Sprintf("%s/api/v1/admin/users/%s/tokens", c.base, key)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// ServiceAccountCreateToken creates a new token for a service account by UID.
+func (c *HTTPClient) ServiceAccountCreateToken(
+	ctx context.Context,
+	key string,
+	in *serviceaccount.CreateTokenInput,
+) (*types.TokenResponse, error) {
+	out := new(types.TokenResponse)
+	uri := fmt.Sprintf("%s/api/v1/service-accounts/%s/tokens", c.base, key)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// PrincipalList returns a list of principals matching the query.
+func (c *HTTPClient) PrincipalList(
+	ctx context.Context,
+	query string,
+	principalTypes ...enum.PrincipalType,
+) ([]types.PrincipalInfo, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("limit", "100")
+	for _, principalType := range principalTypes {
+		params.Add("type", string(principalType))
+	}
+
+	out := []types.PrincipalInfo{}
+	uri := fmt.Sprintf("%s/api/v1/principals?%s", c.base, params.Encode())
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// User returns a user by UID.
 func (c *HTTPClient) User(ctx context.Context, key string) (*types.User, error) {
 	out := new(types.User)
-	uri := fmt.Sprintf("%s/api/v1/users/%s", c.base, key)
+	uri := fmt.Sprintf("%s/api/v1/admin/users/%s", c.base, key)
 	err := c.get(ctx, uri, out)
 	return out, err
 }
@@ -115,34 +161,81 @@ func (c *HTTPClient) User(ctx context.Context, key string) (*types.User, error)
 // UserList returns a list of all registered users.
 func (c *HTTPClient) UserList(ctx context.Context, params types.UserFilter) ([]types.User, error) {
 	out := []types.User{}
-	uri := fmt.Sprintf("%s/api/v1/users?page=%d&limit=%d", c.base, params.Page, params.Size)
+	uri := fmt.Sprintf("%s/api/v1/admin/users?page=%d&limit=%d", c.base, params.Page, params.Size)
+	if params.Deleted {
+		uri += "&deleted=true"
+	}
 	err := c.get(ctx, uri, &out)
 	return out, err
 }
 
 // UserCreate creates a new user account.
-func (c *HTTPClient) UserCreate(ctx context.Context, user *types.User) (*types.User, error) {
+func (c *HTTPClient) UserCreate(ctx context.Context, in *user.CreateInput) (*types.User, error) {
 	out := new(types.User)
-	uri := fmt.Sprintf("%s/api/v1/users", c.base)
-	err := c.post(ctx, uri, false, user, out)
+	uri := fmt.Sprintf("%s/api/v1/admin/users", c.base)
+	err := c.post(ctx, uri, false, in, out)
 	return out, err
 }
 
-// UserUpdate updates a user account by ID or email.
-func (c *HTTPClient) UserUpdate(ctx context.Context, key string, user *types.UserInput) (*types.User, error) {
+// UserUpdate updates a user account by UID.
+func (c *HTTPClient) UserUpdate(ctx context.Context, key string, in *user.UpdateInput) (*types.User, error) {
 	out := new(types.User)
-	uri := fmt.Sprintf("%s/api/v1/users/%s", c.base, key)
-	err := c.patch(ctx, uri, user, out)
+	uri := fmt.Sprintf("%s/api/v1/admin/users/%s", c.base, key)
+	err := c.patch(ctx, uri, in, out)
 	return out, err
 }
 
-// UserDelete deletes a user account by ID or email.
+// UserUpdateAdmin updates the admin state of a user account by UID.
+func (c *HTTPClient) UserUpdateAdmin(ctx context.Context, key string, in *user.UpdateAdminInput) (*types.User, error) {
+	out := new(types.User)
+	uri := fmt.Sprintf("%s/api/v1/admin/users/%s/admin", c.base, key)
+	err := c.patch(ctx, uri, in, out)
+	return out, err
+}
+
+// UserDelete deactivates (soft deletes) a user account by UID.
 func (c *HTTPClient) UserDelete(ctx context.Context, key string) error {
-	uri := fmt.Sprintf("%s/api/v1/users/%s", c.base, key)
+	uri := fmt.Sprintf("%s/api/v1/admin/users/%s", c.base, key)
 	err := c.delete(ctx, uri)
 	return err
 }
 
+// UserRestore reactivates a deactivated user account by UID.
+func (c *HTTPClient) UserRestore(ctx context.Context, key string) (*types.User, error) {
+	out := new(types.User)
+	uri := fmt.Sprintf("%s/api/v1/admin/users/%s/restore", c.base, key)
+	err := c.post(ctx, uri, false, nil, out)
+	return out, err
+}
+
+// RepoGarbageCollect runs the git garbage collection on a repository.
+func (c *HTTPClient) RepoGarbageCollect(
+	ctx context.Context,
+	ref string,
+	in *repo.GarbageCollectInput,
+) (*repo.GarbageCollectOutput, error) {
+	out := new(repo.GarbageCollectOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/maintenance/gc", c.base, url.PathEscape(ref))
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// RepoFsck verifies the integrity of a repository.
+func (c *HTTPClient) RepoFsck(ctx context.Context, ref string) (*repo.FsckOutput, error) {
+	out := new(repo.FsckOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/maintenance/fsck", c.base, url.PathEscape(ref))
+	err := c.post(ctx, uri, false, nil, out)
+	return out, err
+}
+
+// RepoDiskUsage returns the disk usage of a repository.
+func (c *HTTPClient) RepoDiskUsage(ctx context.Context, ref string) (*repo.DiskUsageOutput, error) {
+	out := new(repo.DiskUsageOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/maintenance/disk-usage", c.base, url.PathEscape(ref))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
 //
 // http request helper functions
 //
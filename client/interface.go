@@ -17,8 +17,11 @@ package client
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // Client to access the remote APIs.
@@ -32,23 +35,48 @@ type Client interface {
 	// Self returns the currently authenticated user.
 	Self(ctx context.Context) (*types.User, error)
 
-	// User returns a user by ID or email.
+	// User returns a user by UID.
 	User(ctx context.Context, key string) (*types.User, error)
 
 	// UserList returns a list of all registered users.
 	UserList(ctx context.Context, params types.UserFilter) ([]types.User, error)
 
 	// UserCreate creates a new user account.
-	UserCreate(ctx context.Context, user *types.User) (*types.User, error)
+	UserCreate(ctx context.Context, in *user.CreateInput) (*types.User, error)
 
-	// UserUpdate updates a user account by ID or email.
-	UserUpdate(ctx context.Context, key string, input *types.UserInput) (*types.User, error)
+	// UserUpdate updates a user account by UID.
+	UserUpdate(ctx context.Context, key string, in *user.UpdateInput) (*types.User, error)
 
-	// UserDelete deletes a user account by ID or email.
+	// UserUpdateAdmin updates the admin state of a user account by UID.
+	UserUpdateAdmin(ctx context.Context, key string, in *user.UpdateAdminInput) (*types.User, error)
+
+	// UserDelete deactivates (soft deletes) a user account by UID.
 	UserDelete(ctx context.Context, key string) error
 
+	// UserRestore reactivates a deactivated user account by UID.
+	UserRestore(ctx context.Context, key string) (*types.User, error)
+
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// UserCreateToken creates a new PAT for a user account by UID.
+	UserCreateToken(ctx context.Context, key string, in *user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// ServiceAccountCreateToken creates a new token for a service account by UID.
+	ServiceAccountCreateToken(ctx context.Context, key string,
+		in *serviceaccount.CreateTokenInput) (*types.TokenResponse, error)
+
+	// PrincipalList returns a list of principals matching the query.
+	PrincipalList(ctx context.Context, query string, principalTypes ...enum.PrincipalType) ([]types.PrincipalInfo, error)
+
+	// RepoGarbageCollect runs the git garbage collection on a repository.
+	RepoGarbageCollect(ctx context.Context, ref string, in *repo.GarbageCollectInput) (*repo.GarbageCollectOutput, error)
+
+	// RepoFsck verifies the integrity of a repository.
+	RepoFsck(ctx context.Context, ref string) (*repo.FsckOutput, error)
+
+	// RepoDiskUsage returns the disk usage of a repository.
+	RepoDiskUsage(ctx context.Context, ref string) (*repo.DiskUsageOutput, error)
 }
 
 // remoteError store the error payload returned
@@ -15,13 +15,20 @@
 package main
 
 import (
+	_ "time/tzdata" // embed the time zone database, used to validate time zones of users.
+
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/cli"
 	"github.com/harness/gitness/cli/operations/account"
+	"github.com/harness/gitness/cli/operations/backup"
+	"github.com/harness/gitness/cli/operations/config"
+	"github.com/harness/gitness/cli/operations/doctor"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
+	"github.com/harness/gitness/cli/operations/repos"
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/cli/operations/swagger"
+	"github.com/harness/gitness/cli/operations/tokens"
 	"github.com/harness/gitness/cli/operations/user"
 	"github.com/harness/gitness/cli/operations/users"
 	"github.com/harness/gitness/version"
@@ -44,6 +51,8 @@ func main() {
 
 	user.Register(app)
 	users.Register(app)
+	tokens.Register(app)
+	repos.Register(app)
 
 	account.RegisterLogin(app)
 	account.RegisterRegister(app)
@@ -51,6 +60,12 @@ func main() {
 
 	hooks.Register(app)
 
+	backup.RegisterBackup(app)
+	backup.RegisterRestore(app)
+
+	doctor.Register(app)
+	config.Register(app)
+
 	swagger.Register(app, openapi.NewOpenAPIService())
 
 	kingpin.Version(version.Version.String())
@@ -10,17 +10,25 @@ package main
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/controller/announcement"
+	controllerbadge "github.com/harness/gitness/app/api/controller/badge"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	controllerintegration "github.com/harness/gitness/app/api/controller/integration"
+	controllerissue "github.com/harness/gitness/app/api/controller/issue"
+	controllerissuetracker "github.com/harness/gitness/app/api/controller/issuetracker"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
+	controllermarkdown "github.com/harness/gitness/app/api/controller/markdown"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
+	controllerregistry "github.com/harness/gitness/app/api/controller/registry"
 	"github.com/harness/gitness/app/api/controller/repo"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -34,10 +42,14 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/signedurl"
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	systemevents "github.com/harness/gitness/app/events/system"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
@@ -51,20 +63,55 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/archival"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/bandwidth"
+	"github.com/harness/gitness/app/services/breakglass"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/dependencies"
+	"github.com/harness/gitness/app/services/diffcheck"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/externalhook"
+	"github.com/harness/gitness/app/services/highlight"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/app/services/invitation"
+	issueservice "github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/issuetracker"
+	"github.com/harness/gitness/app/services/keyrotation"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/languages"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/malwarescan"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/app/services/mention"
+	"github.com/harness/gitness/app/services/mergeschedule"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/pathindex"
 	"github.com/harness/gitness/app/services/protection"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqlint"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/registries"
+	"github.com/harness/gitness/app/services/repoconfig"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/reviewerassignment"
+	"github.com/harness/gitness/app/services/reviewreminder"
+	"github.com/harness/gitness/app/services/runners"
+	"github.com/harness/gitness/app/services/secrets"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/systemevent"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/vulnerability"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -72,6 +119,7 @@ import (
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/app/writefreeze"
 	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -79,6 +127,7 @@ import (
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
@@ -100,6 +149,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideBlobStoreConfig,
 		mailer.WireSet,
 		notification.WireSet,
+		settings.WireSet,
+		mention.WireSet,
 		blob.WireSet,
 		dbtx.WireSet,
 		cache.WireSet,
@@ -113,6 +164,10 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		repo.WireSet,
 		pullreq.WireSet,
 		controllerwebhook.WireSet,
+		controllerintegration.WireSet,
+		controllerissue.WireSet,
+		controllerissuetracker.WireSet,
+		controllerbadge.WireSet,
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
@@ -120,10 +175,14 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		principal.WireSet,
 		system.WireSet,
 		authn.WireSet,
+		signedurl.WireSet,
 		authz.WireSet,
 		gitevents.WireSet,
+		issueevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		spaceevents.WireSet,
+		systemevents.WireSet,
 		storage.WireSet,
 		adapter.WireSet,
 		cliserver.ProvideGitConfig,
@@ -135,10 +194,19 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		events.WireSet,
 		cliserver.ProvideWebhookConfig,
 		cliserver.ProvideNotificationConfig,
+		cliserver.ProvideIntegrationConfig,
+		integration.WireSet,
+		cliserver.ProvideIssueConfig,
+		issueservice.WireSet,
+		cliserver.ProvideIssueTrackerConfig,
+		issuetracker.WireSet,
 		webhook.WireSet,
+		configreload.WireSet,
 		cliserver.ProvideTriggerConfig,
 		trigger.WireSet,
 		githook.WireSet,
+		cliserver.ProvideProxyConfig,
+		proxy.WireSet,
 		cliserver.ProvideLockConfig,
 		lock.WireSet,
 		cliserver.ProvidePubsubConfig,
@@ -146,7 +214,11 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideJobsConfig,
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
+		breakglass.WireSet,
 		cleanup.WireSet,
+		keyrotation.WireSet,
+		archival.WireSet,
+		vulnerability.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -164,21 +236,55 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		converter.WireSet,
 		runner.WireSet,
 		sse.WireSet,
+		writefreeze.WireSet,
+		maintenance.WireSet,
+		malwarescan.WireSet,
+		instance.WireSet,
+		invitation.WireSet,
 		scheduler.WireSet,
 		commit.WireSet,
 		controllertrigger.WireSet,
 		plugin.WireSet,
 		resolver.WireSet,
 		importer.WireSet,
+		insights.WireSet,
+		cliserver.ProvideLanguagesConfig,
+		languages.WireSet,
+		cliserver.ProvideDependenciesConfig,
+		dependencies.WireSet,
 		canceler.WireSet,
 		exporter.WireSet,
 		metric.WireSet,
 		reposize.WireSet,
+		usage.WireSet,
+		bandwidth.WireSet,
+		avatar.WireSet,
+		externalhook.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
+		cliserver.ProvideSystemEventConfig,
+		systemevent.WireSet,
+		cliserver.ProvideRealtimeConfig,
+		realtime.WireSet,
 		controllerkeywordsearch.WireSet,
+		announcement.WireSet,
+		markdown.WireSet,
+		highlight.WireSet,
+		pathindex.WireSet,
+		secrets.WireSet,
+		repoconfig.WireSet,
+		diffcheck.WireSet,
+		reviewreminder.WireSet,
+		mergeschedule.WireSet,
+		reviewerassignment.WireSet,
+		pullreqlint.WireSet,
+		controllerrunner.WireSet,
+		controllerregistry.WireSet,
+		registries.WireSet,
+		runners.WireSet,
+		controllermarkdown.WireSet,
 		usergroup.WireSet,
 		openapi.WireSet,
 	)
@@ -9,17 +9,25 @@ package main
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/controller/announcement"
+	"github.com/harness/gitness/app/api/controller/badge"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	integration2 "github.com/harness/gitness/app/api/controller/integration"
+	issue2 "github.com/harness/gitness/app/api/controller/issue"
+	issuetracker2 "github.com/harness/gitness/app/api/controller/issuetracker"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
+	markdown2 "github.com/harness/gitness/app/api/controller/markdown"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/registry"
 	"github.com/harness/gitness/app/api/controller/repo"
+	runner2 "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -33,10 +41,14 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/signedurl"
 	"github.com/harness/gitness/app/bootstrap"
 	events4 "github.com/harness/gitness/app/events/git"
-	events3 "github.com/harness/gitness/app/events/pullreq"
-	events2 "github.com/harness/gitness/app/events/repo"
+	events7 "github.com/harness/gitness/app/events/issue"
+	events5 "github.com/harness/gitness/app/events/pullreq"
+	events3 "github.com/harness/gitness/app/events/repo"
+	events6 "github.com/harness/gitness/app/events/space"
+	events2 "github.com/harness/gitness/app/events/system"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
@@ -50,20 +62,55 @@ import (
 	"github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/archival"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/bandwidth"
+	"github.com/harness/gitness/app/services/breakglass"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/configreload"
+	"github.com/harness/gitness/app/services/dependencies"
+	"github.com/harness/gitness/app/services/diffcheck"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/externalhook"
+	"github.com/harness/gitness/app/services/highlight"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/services/instance"
+	"github.com/harness/gitness/app/services/integration"
+	"github.com/harness/gitness/app/services/invitation"
+	"github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/issuetracker"
+	"github.com/harness/gitness/app/services/keyrotation"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/languages"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/malwarescan"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/app/services/mention"
+	"github.com/harness/gitness/app/services/mergeschedule"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/pathindex"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqlint"
+	"github.com/harness/gitness/app/services/realtime"
+	"github.com/harness/gitness/app/services/registries"
+	"github.com/harness/gitness/app/services/repoconfig"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/reviewerassignment"
+	"github.com/harness/gitness/app/services/reviewreminder"
+	"github.com/harness/gitness/app/services/runners"
+	"github.com/harness/gitness/app/services/secrets"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/systemevent"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/vulnerability"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -71,6 +118,7 @@ import (
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/app/writefreeze"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -78,6 +126,7 @@ import (
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/http/proxy"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
@@ -109,31 +158,72 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore)
+	settingsStore := database.ProvideSettingsStore(db)
+	settingsService := settings.ProvideService(settingsStore, spaceStore)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore, settingsService)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
-	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
-	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	universalClient, err := server.ProvideRedis(config)
+	if err != nil {
+		return nil, err
+	}
+	rowCache := cache.ProvideRowCache(config, universalClient)
+	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation, rowCache)
+	tokenStore := database.ProvideTokenStore(db, rowCache)
+	eventsConfig := server.ProvideEventsConfig(config)
+	outboxStore := database.ProvideEventOutboxStore(db)
+	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient, outboxStore)
+	if err != nil {
+		return nil, err
+	}
+	reporter, err := events2.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	auditLogStore := database.ProvideAuditLogStore(db)
+	breakglassService := breakglass.ProvideService(config, settingsService, auditLogStore, reporter)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, breakglassService)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	avatarService := avatar.ProvideService(blobStore)
+	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore, rowCache)
+	userActivityStore := database.ProvideUserActivityStore(db)
+	pubsubConfig := server.ProvidePubsubConfig(config)
+	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
+	reloader := configreload.ProvideReloader()
+	instanceService, err := instance.ProvideService(ctx, config, settingsService, pubSub, reloader)
+	if err != nil {
+		return nil, err
+	}
+	invitationStore := database.ProvideInvitationStore(db)
+	provider, err := url.ProvideURLProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	mailerMailer := mailer.ProvideMailClient(config)
+	notificationClient := notification.ProvideMailClient(mailerMailer)
+	invitationService := invitation.ProvideService(config, invitationStore, principalStore, principalInfoCache, spaceStore, notificationClient, provider)
+	savedReplyStore := database.ProvideSavedReplyStore(db)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, spaceStore, repoStore, userActivityStore, reporter, settingsService, avatarService, instanceService, invitationStore, invitationService, breakglassService, savedReplyStore)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
-	provider, err := url.ProvideURLProvider(config)
+	signer, err := signedurl.ProvideSigner(config)
 	if err != nil {
 		return nil, err
 	}
-	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore, signer)
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
-	protectionManager, err := protection.ProvideManager(ruleStore)
+	protectionManager, err := protection.ProvideManager(ruleStore, repoStore, settingsService)
 	if err != nil {
 		return nil, err
 	}
 	typesConfig := server.ProvideGitConfig(config)
-	universalClient, err := server.ProvideRedis(config)
-	if err != nil {
-		return nil, err
-	}
 	cacheCache, err := adapter.ProvideLastCommitCache(typesConfig, universalClient)
 	if err != nil {
 		return nil, err
@@ -154,41 +244,91 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	jobStore := database.ProvideJobStore(db)
-	pubsubConfig := server.ProvidePubsubConfig(config)
-	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
 	executor := job.ProvideExecutor(jobStore, pubSub)
 	lockConfig := server.ProvideLockConfig(config)
-	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
+	mutexManager, err := lock.ProvideMutexManager(lockConfig, universalClient, db)
+	if err != nil {
+		return nil, err
+	}
 	jobConfig := server.ProvideJobsConfig(config)
 	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
 	if err != nil {
 		return nil, err
 	}
+	usageMetricStore := database.ProvideUsageMetricStore(db)
+	usageService, err := usage.ProvideService(config, usageMetricStore, repoStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
 	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
+	proxyConfig := server.ProvideProxyConfig(config)
+	proxyResolver, err := proxy.ProvideResolver(proxyConfig)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer, proxyResolver)
 	if err != nil {
 		return nil, err
 	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
 	usergroupResolver := usergroup.ProvideUserGroupResolver()
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
-	eventsConfig := server.ProvideEventsConfig(config)
-	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	reporter, err := events2.ProvideReporter(eventsSystem)
+	resourceLimiter, err := limiter.ProvideLimiter()
 	if err != nil {
 		return nil, err
 	}
-	resourceLimiter, err := limiter.ProvideLimiter()
+	repoIdentifier := check.ProvideRepoIdentifierCheck()
+	realtimeConfig := server.ProvideRealtimeConfig(config)
+	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	repoIdentifier := check.ProvideRepoIdentifierCheck()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, mutexManager, repoIdentifier)
+	eventsReaderFactory, err := events5.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	repoEventStore := database.ProvideRepoEventStore(db)
+	realtimeService, err := realtime.ProvideService(ctx, realtimeConfig, readerFactory, eventsReaderFactory, repoEventStore, streamer)
+	if err != nil {
+		return nil, err
+	}
+	bandwidthLimiter := bandwidth.ProvideLimiter(config)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	pushedBranchStore := database.ProvidePushedBranchStore(db)
+	pushStore := database.ProvidePushStore(db)
+	webhookStore := database.ProvideWebhookStore(db, encrypter)
+	repoInsightsStore := database.ProvideRepoInsightsStore(db)
+	insightsService, err := insights.ProvideService(gitInterface, repoStore, repoInsightsStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	renderer := markdown.ProvideRenderer(provider)
+	highlightService := highlight.ProvideService(gitInterface)
+	languagesConfig := server.ProvideLanguagesConfig(config)
+	repoLanguagesStore := database.ProvideRepoLanguagesStore(db)
+	languagesService, err := languages.ProvideService(ctx, languagesConfig, readerFactory, gitInterface, repoStore, repoLanguagesStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	pathindexService, err := pathindex.ProvideService(ctx, config, readerFactory, gitInterface, pubSub)
+	if err != nil {
+		return nil, err
+	}
+	malwareFindingStore := database.ProvideMalwareFindingStore(db)
+	dependenciesConfig := server.ProvideDependenciesConfig(config)
+	repoDependenciesStore := database.ProvideRepoDependenciesStore(db)
+	vulnerabilityAlertStore := database.ProvideVulnerabilityAlertStore(db)
+	dependenciesService, err := dependencies.ProvideService(ctx, dependenciesConfig, transactor, readerFactory, gitInterface, repoStore, repoDependenciesStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, eventsReporter, reporter, indexer, resourceLimiter, mutexManager, repoIdentifier, realtimeService, bandwidthLimiter, pullReqStore, pushedBranchStore, usageService, settingsService, webhookStore, insightsService, languagesService, renderer, highlightService, pathindexService, signer, instanceService, malwareFindingStore, dependenciesService, vulnerabilityAlertStore)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
@@ -210,80 +350,137 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
 	secretStore := database.ProvideSecretStore(db)
+	secretsResolver := secrets.ProvideResolver(config, secretStore, auditLogStore, encrypter)
 	connectorStore := database.ProvideConnectorStore(db)
-	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer, proxyResolver)
+	if err != nil {
+		return nil, err
+	}
+	externalHookStore := database.ProvideExternalHookStore(db, encrypter)
+	reporter4, err := events6.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, externalHookStore, ruleStore, settingsService, usageService, reporter4, instanceService, invitationStore, invitationService)
 	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore)
-	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
+	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore, repoStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
 	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore)
 	templateController := template.ProvideController(templateStore, authorizer, spaceStore)
 	pluginController := plugin.ProvideController(pluginStore)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
 	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
+	pullReqActivityEditStore := database.ProvidePullReqActivityEditStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
 	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
+	reviewReminderStore := database.ProvideReviewReminderStore(db)
+	mergeScheduleStore := database.ProvideMergeScheduleStore(db)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	reporter2, err := events5.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
+	repoGitInfoView := database.ProvideRepoGitInfoView(db)
+	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter2, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	mentionService := mention.ProvideService(principalStore, principalInfoView, usergroupResolver)
+	pullreqlintService, err := pullreqlint.ProvideService(ctx, config, eventsReaderFactory, settingsService, pullReqStore, checkStore)
 	if err != nil {
 		return nil, err
 	}
-	repoGitInfoView := database.ProvideRepoGitInfoView(db)
-	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, pullReqActivityEditStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, reviewReminderStore, mergeScheduleStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter2, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService, realtimeService, mentionService, pullreqlintService, settingsService, auditLogStore, config)
+	issueStore := database.ProvideIssueStore(db, principalInfoCache)
+	reporter5, err := events7.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService)
+	issueController := issue2.ProvideController(transactor, authorizer, repoStore, issueStore, principalInfoCache, reporter5)
 	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	networkPolicy, err := webhook.ProvideNetworkPolicy(webhookConfig, reloader, instanceService)
 	if err != nil {
 		return nil, err
 	}
-	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
-	reporter2, err := events4.ProvideReporter(eventsSystem)
+	readerFactory3, err := events7.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
-	principalController := principal.ProvideController(principalStore)
-	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	readerFactory4, err := events3.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory3, readerFactory4, webhookStore, webhookExecutionStore, repoStore, pullReqStore, issueStore, vulnerabilityAlertStore, pullReqActivityStore, provider, principalStore, gitInterface, reporter, proxyResolver, networkPolicy, secretsResolver)
+	if err != nil {
+		return nil, err
+	}
+	webhookController := webhook2.ProvideController(networkPolicy, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService)
+	integrationStore := database.ProvideIntegrationStore(db, encrypter)
+	integrationConfig := server.ProvideIntegrationConfig(config)
+	integrationService, err := integration.ProvideService(ctx, integrationConfig, eventsReaderFactory, integrationStore, repoStore, spaceStore, pullReqStore, pullReqActivityStore, principalInfoCache, provider, proxyResolver)
+	if err != nil {
+		return nil, err
+	}
+	integrationController := integration2.ProvideController(authorizer, integrationStore, repoStore, spaceStore, integrationService)
+	issuetrackerConfig := server.ProvideIssueTrackerConfig(config)
+	issuetrackerService, err := issuetracker.ProvideService(ctx, issuetrackerConfig, eventsReaderFactory, settingsService, spaceStore, repoStore, pullReqStore, secretStore, principalInfoCache, encrypter, provider, proxyResolver)
+	if err != nil {
+		return nil, err
+	}
+	issuetrackerController := issuetracker2.ProvideController(authorizer, spaceStore, repoStore, settingsService, issuetrackerService)
+	badgeController := badge.ProvideController(authorizer, repoStore, checkStore, pullReqStore, gitInterface)
+	reporter3, err := events4.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	externalhookService := externalhook.ProvideService(config, externalHookStore, spaceStore, proxyResolver)
+	maintenanceService, err := maintenance.ProvideService(ctx, config, settingsService, pubSub)
+	if err != nil {
+		return nil, err
+	}
+	malwarescanService, err := malwarescan.ProvideService(config, gitInterface, malwareFindingStore)
 	if err != nil {
 		return nil, err
 	}
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter3, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, externalhookService, pushedBranchStore, pushStore, maintenanceService, malwarescanService)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	principalController := principal.ProvideController(principalStore, avatarService)
+	v := check2.ProvideCheckSanitizers()
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
+	systemEventStore := database.ProvideSystemEventStore(db)
+	systemController := system.NewController(principalStore, config, db, jobStore, jobScheduler, systemEventStore, streamer, auditLogStore, reloader, gitInterface, eventsSystem, blobStore, usageService, maintenanceService, instanceService)
 	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController, pullReqStore, principalStore)
+	announcementStore := database.ProvideAnnouncementStore(db)
+	announcementController := announcement.ProvideController(announcementStore)
+	markdownController := markdown2.ProvideController(authorizer, repoStore, renderer)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretsResolver, stageStore, stepStore, principalStore)
+	runnerStore := database.ProvideRunnerStore(db)
+	runnerRegistrationTokenStore := database.ProvideRunnerRegistrationTokenStore(db)
+	runnerController := runner2.ProvideController(config, authorizer, spaceStore, repoStore, runnerStore, runnerRegistrationTokenStore, stageStore, stepStore, executionManager, provider)
+	registryStore := database.ProvideRegistryStore(db)
+	registryArtifactStore := database.ProvideRegistryArtifactStore(db)
+	registryVersionStore := database.ProvideRegistryVersionStore(db)
+	registryBlobStore := database.ProvideRegistryBlobStore(db)
+	registryUploadStore := database.ProvideRegistryUploadStore(db)
+	registryController := registry.ProvideController(config, authorizer, spaceStore, registryStore, registryArtifactStore, registryVersionStore, registryBlobStore, registryUploadStore, blobStore)
+	flag := writefreeze.ProvideFlag(config)
+	idempotencyKeyStore := database.ProvideIdempotencyKeyStore(db)
+	aliasResolver := router.ProvideAliasResolver(config, spacePathCache, spacePathStore, provider)
+	apiHandler, err := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, issueController, webhookController, integrationController, issuetrackerController, badgeController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, announcementController, markdownController, runnerController, registryController, flag, maintenanceService, idempotencyKeyStore, principalInfoCache, auditLogStore, reloader, aliasResolver, provider)
+	if err != nil {
+		return nil, err
+	}
+	gitHandler := router.ProvideGitHandler(config, provider, authenticator, repoController, flag, aliasResolver)
 	openapiService := openapi.ProvideOpenAPIService()
-	webHandler := router.ProvideWebHandler(config, openapiService)
-	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
+	webHandler := router.ProvideWebHandler(config, openapiService, systemController)
+	registryHandler := router.ProvideRegistryHandler(config, authenticator, registryController, flag)
+	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, registryHandler, provider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
@@ -305,14 +502,16 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, principalStore, systemEventStore, repoEventStore, idempotencyKeyStore, auditLogStore, membershipStore, repoController)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider, settingsService, mentionService, readerFactory2, webhookStore, webhookExecutionStore, membershipStore, readerFactory4, vulnerabilityAlertStore)
 	if err != nil {
 		return nil, err
 	}
@@ -321,7 +520,45 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService)
+	outboxRelay := events.ProvideOutboxRelay(eventsSystem)
+	keyrotationService := keyrotation.ProvideService(jobScheduler, executor, encrypter, webhookStore, secretStore, externalHookStore, integrationStore)
+	streamTrimmer := events.ProvideStreamTrimmer(eventsSystem)
+	systemeventConfig := server.ProvideSystemEventConfig(config)
+	systemeventService, err := systemevent.ProvideService(ctx, systemeventConfig, readerFactory2, systemEventStore, streamer)
+	if err != nil {
+		return nil, err
+	}
+	issueConfig := server.ProvideIssueConfig(config)
+	issueService, err := issue.ProvideService(ctx, issueConfig, eventsReaderFactory, transactor, pullReqStore, issueStore, reporter5)
+	if err != nil {
+		return nil, err
+	}
+	elector := lock.ProvideElector(lockConfig, mutexManager)
+	repoconfigService, err := repoconfig.ProvideService(ctx, config, readerFactory, gitInterface, repoStore, ruleStore, checkStore, protectionManager)
+	if err != nil {
+		return nil, err
+	}
+	runnersService, err := runners.ProvideService(config, runnerStore, stageStore, schedulerScheduler, executionManager, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	registriesService, err := registries.ProvideService(config, registryStore, registryArtifactStore, registryVersionStore, registryBlobStore, registryUploadStore, blobStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	archivalService := archival.ProvideService(jobScheduler, executor, settingsService, repoStore, spaceStore, reporter)
+	vulnerabilityService := vulnerability.ProvideService(config, jobScheduler, executor, repoDependenciesStore, vulnerabilityAlertStore, eventsReporter)
+	diffcheckService, err := diffcheck.ProvideService(ctx, config, eventsReaderFactory, gitInterface, repoStore, pullReqStore, checkStore)
+	if err != nil {
+		return nil, err
+	}
+	reviewreminderService := reviewreminder.ProvideService(jobScheduler, executor, settingsService, reviewReminderStore, pullReqStore, repoStore, spaceStore, reporter2)
+	mergescheduleService := mergeschedule.ProvideService(jobScheduler, executor, mergeScheduleStore, pullreqController)
+	reviewerassignmentService, err := reviewerassignment.ProvideService(ctx, config, eventsReaderFactory, settingsService, codeownersService, repoStore, pullReqStore, pullReqReviewerStore, pullreqController)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService, outboxRelay, keyrotationService, streamTrimmer, systemeventService, realtimeService, integrationService, issueService, eventsSystem, elector, reloader, usageService, repoconfigService, runnersService, registriesService, archivalService, vulnerabilityService, diffcheckService, reviewreminderService, mergescheduleService, reviewerassignmentService)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
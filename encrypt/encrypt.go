@@ -28,3 +28,10 @@ type Encrypter interface {
 	Encrypt(plaintext string) ([]byte, error)
 	Decrypt(ciphertext []byte) (string, error)
 }
+
+// Reencrypter is implemented by encrypters that support key rotation.
+type Reencrypter interface {
+	// Reencrypt returns the ciphertext encrypted with the current key
+	// and true if the provided ciphertext wasn't encrypted with the current key.
+	Reencrypt(ciphertext []byte) ([]byte, bool, error)
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Keyring is an Encrypter that encrypts using the primary key and decrypts using
+// the primary key or any of the previous keys, which allows rotating the encryption key.
+type Keyring struct {
+	primary  *Aesgcm
+	previous []*Aesgcm
+	compat   bool
+}
+
+// NewKeyring returns a new Keyring. If compat is set, content that can't be decrypted
+// with any of the keys is considered to be unencrypted.
+func NewKeyring(key string, previousKeys []string, compat bool) (*Keyring, error) {
+	primary, err := newAesgcm(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid primary key: %w", err)
+	}
+
+	previous := make([]*Aesgcm, len(previousKeys))
+	for i, previousKey := range previousKeys {
+		previous[i], err = newAesgcm(previousKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous key %d: %w", i, err)
+		}
+	}
+
+	return &Keyring{
+		primary:  primary,
+		previous: previous,
+		compat:   compat,
+	}, nil
+}
+
+func newAesgcm(key string) (*Aesgcm, error) {
+	e, err := New(key, false)
+	if err != nil {
+		return nil, err
+	}
+	return e.(*Aesgcm), nil
+}
+
+// Encrypt encrypts the plaintext using the primary key.
+func (k *Keyring) Encrypt(plaintext string) ([]byte, error) {
+	return k.primary.Encrypt(plaintext)
+}
+
+// Decrypt decrypts the ciphertext using the primary key or any of the previous keys.
+func (k *Keyring) Decrypt(ciphertext []byte) (string, error) {
+	plaintext, _, err := k.decrypt(ciphertext)
+	return plaintext, err
+}
+
+// Reencrypt returns the ciphertext encrypted with the primary key and true
+// if the provided ciphertext wasn't encrypted with the primary key.
+func (k *Keyring) Reencrypt(ciphertext []byte) ([]byte, bool, error) {
+	plaintext, primary, err := k.decrypt(ciphertext)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if primary {
+		return ciphertext, false, nil
+	}
+
+	ciphertext, err = k.primary.Encrypt(plaintext)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return ciphertext, true, nil
+}
+
+// decrypt decrypts the ciphertext and returns whether it was encrypted with the primary key.
+func (k *Keyring) decrypt(ciphertext []byte) (string, bool, error) {
+	if plaintext, err := k.primary.Decrypt(ciphertext); err == nil {
+		return plaintext, true, nil
+	}
+
+	for _, e := range k.previous {
+		if plaintext, err := e.Decrypt(ciphertext); err == nil {
+			return plaintext, false, nil
+		}
+	}
+
+	// in compatibility mode content that can't be decrypted is considered to be unencrypted.
+	if k.compat {
+		return string(ciphertext), false, nil
+	}
+
+	return "", false, errors.New("failed to decrypt ciphertext with any of the keys")
+}
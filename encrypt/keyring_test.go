@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"bytes"
+	"testing"
+)
+
+const (
+	testKeyOld = "00000000000000000000000000000000"
+	testKeyNew = "11111111111111111111111111111111"
+)
+
+func TestKeyring_Reencrypt(t *testing.T) {
+	oldKeyring, err := NewKeyring(testKeyOld, nil, false)
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	keyring, err := NewKeyring(testKeyNew, []string{testKeyOld}, false)
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	oldCiphertext, err := oldKeyring.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	// content encrypted with the previous key can still be decrypted.
+	plaintext, err := keyring.Decrypt(oldCiphertext)
+	if err != nil || plaintext != "secret" {
+		t.Fatalf("decrypt = %q, %v; want %q", plaintext, err, "secret")
+	}
+
+	newCiphertext, changed, err := keyring.Reencrypt(oldCiphertext)
+	if err != nil || !changed {
+		t.Fatalf("reencrypt changed = %t, %v; want true", changed, err)
+	}
+
+	if _, err = oldKeyring.Decrypt(newCiphertext); err == nil {
+		t.Errorf("re-encrypted content must not be decryptable with the previous key")
+	}
+
+	ciphertext, changed, err := keyring.Reencrypt(newCiphertext)
+	if err != nil || changed || !bytes.Equal(ciphertext, newCiphertext) {
+		t.Errorf("reencrypt of current content changed = %t, %v; want false", changed, err)
+	}
+
+	if _, _, err = keyring.Reencrypt([]byte("plaintext")); err == nil {
+		t.Errorf("expected error re-encrypting unencrypted content without compatibility mode")
+	}
+
+	compatKeyring, err := NewKeyring(testKeyNew, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	ciphertext, changed, err = compatKeyring.Reencrypt([]byte("plaintext"))
+	if err != nil || !changed {
+		t.Fatalf("reencrypt of unencrypted content changed = %t, %v; want true", changed, err)
+	}
+
+	plaintext, err = keyring.Decrypt(ciphertext)
+	if err != nil || plaintext != "plaintext" {
+		t.Errorf("decrypt = %q, %v; want %q", plaintext, err, "plaintext")
+	}
+}
@@ -15,9 +15,12 @@
 package encrypt
 
 import (
+	"errors"
+
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
+	"github.com/rs/zerolog/log"
 )
 
 // WireSet provides a wire set for this package.
@@ -27,7 +30,13 @@ var WireSet = wire.NewSet(
 
 func ProvideEncrypter(config *types.Config) (Encrypter, error) {
 	if config.Encrypter.Secret == "" {
+		if len(config.Encrypter.PreviousSecrets) > 0 {
+			return nil, errors.New("previous encryption keys can't be used without a current encryption key")
+		}
+
+		log.Warn().Msg("no encryption key configured, secrets are stored unencrypted")
 		return &none{}, nil
 	}
-	return New(config.Encrypter.Secret, config.Encrypter.MixedContent)
+
+	return NewKeyring(config.Encrypter.Secret, config.Encrypter.PreviousSecrets, config.Encrypter.MixedContent)
 }
@@ -34,9 +34,12 @@ type Event[T interface{}] struct {
 // EventType describes the type of event.
 type EventType string
 
+// streamIDPrefix is the prefix of the streamIDs of all events.
+const streamIDPrefix = "events:"
+
 // getStreamID generates the streamID for a given category and type of event.
 func getStreamID(category string, event EventType) string {
-	return fmt.Sprintf("events:%s:%s", category, event)
+	return fmt.Sprintf("%s%s:%s", streamIDPrefix, category, event)
 }
 
 // Mode defines the different modes of the event framework.
@@ -53,6 +56,27 @@ type Config struct {
 	Namespace             string
 	MaxStreamLength       int64
 	ApproxMaxStreamLength bool
+
+	// OutboxPollInterval is the interval in which the outbox is checked for unpublished events.
+	OutboxPollInterval time.Duration
+	// OutboxBatchSize is the max number of outbox events published within a single transaction.
+	OutboxBatchSize int
+
+	// IdempotencyTTL is the duration for which processed events are remembered by redis readers
+	// to prevent processing them twice.
+	IdempotencyTTL time.Duration
+
+	// MaxStreamAge is the max age of events kept in the streams (0 means unlimited).
+	MaxStreamAge time.Duration
+	// CategoryMaxStreamLength overrides MaxStreamLength for the streams of an event category.
+	// NOTE: MaxStreamLength is enforced when an event is added, hence the override can only be lower.
+	CategoryMaxStreamLength map[string]int64
+	// CategoryMaxStreamAge overrides MaxStreamAge for the streams of an event category.
+	CategoryMaxStreamAge map[string]time.Duration
+	// TrimInterval is the interval in which the retention policies are enforced on the streams (redis only).
+	TrimInterval time.Duration
+	// ReaderShutdownTimeout is the duration for which readers keep processing already read events when stopped.
+	ReaderShutdownTimeout time.Duration
 }
 
 func (c *Config) Validate() error {
@@ -65,6 +89,35 @@ func (c *Config) Validate() error {
 	if c.MaxStreamLength < 1 {
 		return errors.New("config.MaxStreamLength has to be a positive number")
 	}
+	if c.OutboxPollInterval <= 0 {
+		return errors.New("config.OutboxPollInterval has to be a positive duration")
+	}
+	if c.OutboxBatchSize < 1 {
+		return errors.New("config.OutboxBatchSize has to be a positive number")
+	}
+	if c.Mode == ModeRedis && c.IdempotencyTTL <= 0 {
+		return errors.New("config.IdempotencyTTL has to be a positive duration")
+	}
+	if c.ReaderShutdownTimeout < 0 {
+		return errors.New("config.ReaderShutdownTimeout can't be negative")
+	}
+	if c.Mode == ModeRedis && c.TrimInterval <= 0 {
+		return errors.New("config.TrimInterval has to be a positive duration")
+	}
+	if c.MaxStreamAge < 0 {
+		return errors.New("config.MaxStreamAge can't be negative")
+	}
+	for category, maxLength := range c.CategoryMaxStreamLength {
+		if maxLength < 1 || maxLength > c.MaxStreamLength {
+			return fmt.Errorf("config.CategoryMaxStreamLength of category '%s' has to be between 1 and %d",
+				category, c.MaxStreamLength)
+		}
+	}
+	for category, maxAge := range c.CategoryMaxStreamAge {
+		if maxAge <= 0 {
+			return fmt.Errorf("config.CategoryMaxStreamAge of category '%s' has to be a positive duration", category)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/stream"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OutboxMessage is an event message stored in the outbox until it's published to its stream.
+type OutboxMessage struct {
+	ID       int64
+	StreamID string
+	Payload  []byte
+	Created  int64
+}
+
+// OutboxStore persists outbox messages.
+// Implementations are expected to write messages as part of the transaction stored in the context (if any),
+// which makes the event emission atomic with the domain change.
+type OutboxStore interface {
+	// Create stores the outbox message.
+	Create(ctx context.Context, msg *OutboxMessage) error
+
+	// Publish calls the publish function for up to limit of the oldest outbox messages, in order,
+	// and deletes all messages that were published successfully.
+	// The processing stops on the first publish error. Returns the number of published messages.
+	Publish(ctx context.Context, limit int, publish func(msg *OutboxMessage) error) (int, error)
+}
+
+// outboxStreamProducer is a StreamProducer that writes all messages to the outbox.
+// The messages are forwarded to the actual stream producer by the OutboxRelay.
+type outboxStreamProducer struct {
+	store  OutboxStore
+	notify chan struct{}
+}
+
+func (p *outboxStreamProducer) Send(
+	ctx context.Context,
+	streamID string,
+	payload map[string]interface{},
+) (string, error) {
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(payload); err != nil {
+		return "", fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+
+	msg := &OutboxMessage{
+		StreamID: streamID,
+		Payload:  buff.Bytes(),
+		Created:  time.Now().UnixMilli(),
+	}
+
+	if err := p.store.Create(ctx, msg); err != nil {
+		return "", fmt.Errorf("failed to store outbox message: %w", err)
+	}
+
+	// wake up the relay - if the message was written in a transaction that isn't committed yet,
+	// the message will be published by one of the following relay runs.
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+
+	// The stream message ID isn't known yet, hence we return the outbox message ID.
+	return "outbox-" + strconv.FormatInt(msg.ID, 10), nil
+}
+
+// OutboxRelay publishes the messages stored in the outbox to the event streams.
+// Messages are deleted from the outbox only after they were published,
+// which guarantees at-least-once delivery of the events in case of crashes.
+type OutboxRelay struct {
+	store        OutboxStore
+	producer     StreamProducer
+	notify       chan struct{}
+	pollInterval time.Duration
+	batchSize    int
+}
+
+func newOutboxRelay(
+	store OutboxStore,
+	producer StreamProducer,
+	pollInterval time.Duration,
+	batchSize int,
+) (*OutboxRelay, StreamProducer) {
+	notify := make(chan struct{}, 1)
+
+	relay := &OutboxRelay{
+		store:        store,
+		producer:     producer,
+		notify:       notify,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+	}
+
+	return relay, &outboxStreamProducer{
+		store:  store,
+		notify: notify,
+	}
+}
+
+// Run publishes outbox messages until the context is canceled.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-r.notify:
+		}
+
+		if err := r.publishAll(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to publish outbox messages")
+		}
+	}
+}
+
+// publishAll publishes batches of outbox messages until the outbox is empty.
+func (r *OutboxRelay) publishAll(ctx context.Context) error {
+	for {
+		n, err := r.store.Publish(ctx, r.batchSize, func(msg *OutboxMessage) error {
+			return r.publish(ctx, msg)
+		})
+		if err != nil {
+			return err
+		}
+
+		if n < r.batchSize {
+			return nil
+		}
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, msg *OutboxMessage) error {
+	payload := map[string]interface{}{}
+	if err := gob.NewDecoder(bytes.NewReader(msg.Payload)).Decode(&payload); err != nil {
+		// a message that can't be decoded will never be published, hence it's discarded.
+		log.Ctx(ctx).Error().Err(err).
+			Int64("outbox_message_id", msg.ID).
+			Str("stream_id", msg.StreamID).
+			Msg("discarding outbox message with invalid payload")
+		return nil
+	}
+
+	// the outbox message ID is used as idempotency key - if the message is sent again
+	// (e.g. the relay crashed before the message was deleted), it's not processed twice.
+	payload[stream.IdempotencyKeyField] = "outbox-" + strconv.FormatInt(msg.ID, 10)
+
+	if _, err := r.producer.Send(ctx, msg.StreamID, payload); err != nil {
+		return fmt.Errorf("failed to send outbox message %d to stream '%s': %w", msg.ID, msg.StreamID, err)
+	}
+
+	return nil
+}
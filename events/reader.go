@@ -20,6 +20,7 @@ import (
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 )
@@ -35,6 +36,7 @@ type ReaderFactory[R Reader] struct {
 	category                string
 	streamConsumerFactoryFn StreamConsumerFactoryFunc
 	readerFactoryFn         ReaderFactoryFunc[R]
+	readers                 *sync.WaitGroup
 }
 
 // Launch launches a new reader for the provided group and client name.
@@ -83,8 +85,10 @@ func (f *ReaderFactory[R]) Launch(ctx context.Context,
 		return nil, fmt.Errorf("failed custom setup of event reader: %w", err)
 	}
 
-	// hook into all available logs
+	// hook into all available logs (the channels are closed once the stream consumer stopped)
+	consumerDone := make(chan struct{})
 	go func(errorCh <-chan error) {
+		defer close(consumerDone)
 		for err := range errorCh {
 			log.Err(err).Msg("received an error from stream consumer")
 		}
@@ -107,6 +111,13 @@ func (f *ReaderFactory[R]) Launch(ctx context.Context,
 		return nil, fmt.Errorf("failed to start consumer: %w", err)
 	}
 
+	// track the reader until the stream consumer stopped to allow waiting for it during shutdown.
+	f.readers.Add(1)
+	go func() {
+		defer f.readers.Done()
+		<-consumerDone
+	}()
+
 	return &ReaderCanceler{
 		cancelFn: func() error {
 			cancelFn()
@@ -14,13 +14,26 @@
 
 package events
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
 
 // System represents a single contained event system that is used
 // to setup event Reporters and ReaderFactories.
 type System struct {
 	streamConsumerFactoryFn StreamConsumerFactoryFunc
 	streamProducer          StreamProducer
+	outboxRelay             *OutboxRelay
+	streamTrimmer           *StreamTrimmer
+	// healthCheck verifies the connectivity of the stream provider (nil if there's nothing to check).
+	healthCheck func(ctx context.Context) error
+
+	// readers tracks the launched readers until their stream consumers stopped.
+	readers *sync.WaitGroup
 }
 
 func NewSystem(streamConsumerFactoryFunc StreamConsumerFactoryFunc, streamProducer StreamProducer) (*System, error) {
@@ -34,9 +47,16 @@ func NewSystem(streamConsumerFactoryFunc StreamConsumerFactoryFunc, streamProduc
 	return &System{
 		streamConsumerFactoryFn: streamConsumerFactoryFunc,
 		streamProducer:          streamProducer,
+		readers:                 &sync.WaitGroup{},
 	}, nil
 }
 
+// OutboxRelay returns the relay publishing the events stored in the outbox,
+// or nil in case the system doesn't use an outbox.
+func (s *System) OutboxRelay() *OutboxRelay {
+	return s.outboxRelay
+}
+
 func NewReaderFactory[R Reader](system *System, category string, fn ReaderFactoryFunc[R]) (*ReaderFactory[R], error) {
 	if system == nil {
 		return nil, errors.New("system can't be empty")
@@ -51,6 +71,7 @@ func NewReaderFactory[R Reader](system *System, category string, fn ReaderFactor
 	return &ReaderFactory[R]{
 		// values coming from system
 		streamConsumerFactoryFn: system.streamConsumerFactoryFn,
+		readers:                 system.readers,
 
 		// values coming from input parameters
 		category:        category,
@@ -74,3 +95,37 @@ func NewReporter(system *System, category string) (*GenericReporter, error) {
 		category: category,
 	}, nil
 }
+
+// StreamTrimmer returns the trimmer enforcing the retention policies of the event streams,
+// or nil in case the system doesn't require one (in-memory streams are bounded by their max length).
+func (s *System) StreamTrimmer() *StreamTrimmer {
+	return s.streamTrimmer
+}
+
+// CheckHealth verifies the connectivity of the provider of the event streams.
+func (s *System) CheckHealth(ctx context.Context) error {
+	if s.healthCheck == nil {
+		return nil
+	}
+
+	return s.healthCheck(ctx)
+}
+
+// WaitReadersDone waits until all launched readers finished processing the events they already read.
+// It is intended to be used for graceful shutdown, after the context the readers were launched with is canceled.
+func (s *System) WaitReadersDone(ctx context.Context) {
+	log.Ctx(ctx).Debug().Msg("events: stopping... waiting for the readers to finish")
+
+	ch := make(chan struct{})
+	go func() {
+		s.readers.Wait()
+		close(ch)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Ctx(ctx).Warn().Msg("events: stop interrupted")
+	case <-ch:
+		log.Ctx(ctx).Info().Msg("events: readers gracefully stopped")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/stream"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	streamLengthGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gitness",
+		Subsystem: "events",
+		Name:      "stream_length",
+		Help:      "Number of messages in the event stream.",
+	}, []string{"category", "stream"})
+
+	streamLagGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gitness",
+		Subsystem: "events",
+		Name:      "stream_reader_lag",
+		Help:      "Number of messages in the event stream that weren't read by the reader yet.",
+	}, []string{"category", "stream", "reader"})
+
+	streamPendingGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gitness",
+		Subsystem: "events",
+		Name:      "stream_reader_pending",
+		Help:      "Number of messages of the event stream that were read but not yet acknowledged by the reader.",
+	}, []string{"category", "stream", "reader"})
+
+	streamTrimmedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "events",
+		Name:      "stream_trimmed_total",
+		Help:      "Number of messages removed from the event stream by the retention policy.",
+	}, []string{"category", "stream"})
+)
+
+// Retention defines how long messages are kept in the event streams of a category.
+// Non-positive values disable the respective limit.
+type Retention struct {
+	MaxLength int64
+	MaxAge    time.Duration
+}
+
+// StreamTrimmer periodically enforces the retention policies of the event streams
+// and reports the stream length and the lag of the readers as metrics.
+type StreamTrimmer struct {
+	trimmer    *stream.RedisTrimmer
+	interval   time.Duration
+	retention  Retention
+	categories map[string]Retention
+}
+
+func newStreamTrimmer(trimmer *stream.RedisTrimmer, config Config) *StreamTrimmer {
+	for _, c := range []prometheus.Collector{
+		streamLengthGauge, streamLagGauge, streamPendingGauge, streamTrimmedCounter,
+	} {
+		// the metrics are shared by all trimmers (relevant for tests setting up multiple event systems).
+		var errRegistered prometheus.AlreadyRegisteredError
+		if err := prometheus.Register(c); err != nil && !errors.As(err, &errRegistered) {
+			log.Warn().Err(err).Msg("failed to register event stream metrics")
+		}
+	}
+
+	categories := make(map[string]Retention)
+	for category, maxLength := range config.CategoryMaxStreamLength {
+		retention := categories[category]
+		retention.MaxLength = maxLength
+		categories[category] = retention
+	}
+	for category, maxAge := range config.CategoryMaxStreamAge {
+		retention := categories[category]
+		retention.MaxAge = maxAge
+		categories[category] = retention
+	}
+
+	return &StreamTrimmer{
+		trimmer:  trimmer,
+		interval: config.TrimInterval,
+		retention: Retention{
+			MaxLength: config.MaxStreamLength,
+			MaxAge:    config.MaxStreamAge,
+		},
+		categories: categories,
+	}
+}
+
+// RetentionFor returns the retention policy of the event category.
+// Limits that aren't configured for the category fall back to the global limits.
+func (t *StreamTrimmer) RetentionFor(category string) Retention {
+	retention, ok := t.categories[category]
+	if !ok {
+		return t.retention
+	}
+
+	if retention.MaxLength <= 0 {
+		retention.MaxLength = t.retention.MaxLength
+	}
+	if retention.MaxAge <= 0 {
+		retention.MaxAge = t.retention.MaxAge
+	}
+
+	return retention
+}
+
+// Run trims the event streams until the context is canceled.
+func (t *StreamTrimmer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := t.trimAll(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to trim event streams")
+		}
+	}
+}
+
+func (t *StreamTrimmer) trimAll(ctx context.Context) error {
+	streamIDs, err := t.trimmer.Streams(ctx, streamIDPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	for _, streamID := range streamIDs {
+		category := getCategory(streamID)
+		retention := t.RetentionFor(category)
+
+		removed, err := t.trimmer.Trim(ctx, streamID, retention.MaxLength, retention.MaxAge)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("stream_id", streamID).Msg("failed to trim event stream")
+		}
+		if removed > 0 {
+			streamTrimmedCounter.WithLabelValues(category, streamID).Add(float64(removed))
+		}
+
+		t.reportMetrics(ctx, category, streamID)
+	}
+
+	return nil
+}
+
+func (t *StreamTrimmer) reportMetrics(ctx context.Context, category string, streamID string) {
+	length, err := t.trimmer.Length(ctx, streamID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("stream_id", streamID).Msg("failed to get event stream length")
+	} else {
+		streamLengthGauge.WithLabelValues(category, streamID).Set(float64(length))
+	}
+
+	groups, err := t.trimmer.Groups(ctx, streamID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("stream_id", streamID).Msg("failed to get event stream readers")
+		return
+	}
+
+	for _, group := range groups {
+		streamPendingGauge.WithLabelValues(category, streamID, group.Name).Set(float64(group.Pending))
+		if group.Lag >= 0 {
+			streamLagGauge.WithLabelValues(category, streamID, group.Name).Set(float64(group.Lag))
+		}
+	}
+}
+
+// getCategory returns the category of the event stream (see getStreamID).
+func getCategory(streamID string) string {
+	category, _, _ := strings.Cut(strings.TrimPrefix(streamID, streamIDPrefix), ":")
+	return category
+}
@@ -15,8 +15,10 @@
 package events
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/stream"
 
@@ -27,9 +29,11 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideSystem,
+	ProvideOutboxRelay,
+	ProvideStreamTrimmer,
 )
 
-func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, error) {
+func ProvideSystem(config Config, redisClient redis.UniversalClient, outboxStore OutboxStore) (*System, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("provided config is invalid: %w", err)
 	}
@@ -49,9 +53,27 @@ func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, e
 		return nil, fmt.Errorf("failed to setup event system for mode '%s': %w", config.Mode, err)
 	}
 
+	// all events are written to the outbox first, the relay forwards them to the actual stream producer.
+	if outboxStore != nil {
+		system.outboxRelay, system.streamProducer = newOutboxRelay(
+			outboxStore,
+			system.streamProducer,
+			config.OutboxPollInterval,
+			config.OutboxBatchSize,
+		)
+	}
+
 	return system, nil
 }
 
+func ProvideOutboxRelay(system *System) *OutboxRelay {
+	return system.OutboxRelay()
+}
+
+func ProvideStreamTrimmer(system *System) *StreamTrimmer {
+	return system.StreamTrimmer()
+}
+
 func provideSystemInMemory(config Config) (*System, error) {
 	broker, err := stream.NewMemoryBroker(config.MaxStreamLength)
 	if err != nil {
@@ -59,7 +81,7 @@ func provideSystemInMemory(config Config) (*System, error) {
 	}
 
 	return NewSystem(
-		newMemoryStreamConsumerFactoryMethod(broker, config.Namespace),
+		newMemoryStreamConsumerFactoryMethod(broker, config.Namespace, config.ReaderShutdownTimeout),
 		newMemoryStreamProducer(broker, config.Namespace),
 	)
 }
@@ -69,16 +91,38 @@ func provideSystemRedis(config Config, redisClient redis.UniversalClient) (*Syst
 		return nil, errors.New("redis client required")
 	}
 
-	return NewSystem(
-		newRedisStreamConsumerFactoryMethod(redisClient, config.Namespace),
+	system, err := NewSystem(
+		newRedisStreamConsumerFactoryMethod(redisClient, config.Namespace,
+			config.IdempotencyTTL, config.ReaderShutdownTimeout),
 		newRedisStreamProducer(redisClient, config.Namespace,
 			config.MaxStreamLength, config.ApproxMaxStreamLength),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	system.streamTrimmer = newStreamTrimmer(stream.NewRedisTrimmer(redisClient, config.Namespace), config)
+	system.healthCheck = func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	}
+
+	return system, nil
 }
 
-func newMemoryStreamConsumerFactoryMethod(broker *stream.MemoryBroker, namespace string) StreamConsumerFactoryFunc {
+func newMemoryStreamConsumerFactoryMethod(
+	broker *stream.MemoryBroker,
+	namespace string,
+	shutdownTimeout time.Duration,
+) StreamConsumerFactoryFunc {
 	return func(groupName string, _ string) (StreamConsumer, error) {
-		return stream.NewMemoryConsumer(broker, namespace, groupName)
+		consumer, err := stream.NewMemoryConsumer(broker, namespace, groupName)
+		if err != nil {
+			return nil, err
+		}
+
+		consumer.Configure(stream.WithShutdownTimeout(shutdownTimeout))
+
+		return consumer, nil
 	}
 }
 
@@ -89,9 +133,21 @@ func newMemoryStreamProducer(broker *stream.MemoryBroker, namespace string) Stre
 func newRedisStreamConsumerFactoryMethod(
 	redisClient redis.UniversalClient,
 	namespace string,
+	idempotencyTTL time.Duration,
+	shutdownTimeout time.Duration,
 ) StreamConsumerFactoryFunc {
 	return func(groupName string, consumerName string) (StreamConsumer, error) {
-		return stream.NewRedisConsumer(redisClient, namespace, groupName, consumerName)
+		consumer, err := stream.NewRedisConsumer(redisClient, namespace, groupName, consumerName)
+		if err != nil {
+			return nil, err
+		}
+
+		consumer.Configure(
+			stream.WithIdempotencyTTL(idempotencyTTL),
+			stream.WithShutdownTimeout(shutdownTimeout),
+		)
+
+		return consumer, nil
 	}
 }
 
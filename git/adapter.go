@@ -17,9 +17,11 @@ package git
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
 	"code.gitea.io/gitea/modules/git"
@@ -34,6 +36,15 @@ type Adapter interface {
 	SharedRepository(tmp string, repoUID string, remotePath string) (*adapter.SharedRepo, error)
 	Config(ctx context.Context, repoPath, key, value string) error
 	CountObjects(ctx context.Context, repoPath string) (types.ObjectCount, error)
+	CreateBundle(ctx context.Context, repoPath string, w io.Writer) error
+	Archive(ctx context.Context, repoPath string, rev string, format enum.ArchiveFormat, prefix string,
+		w io.Writer) error
+	FetchBundle(ctx context.Context, repoPath string, bundlePath string) error
+	GC(ctx context.Context, repoPath string, aggressive bool, keepUnreachable bool) error
+	GetAlternates(ctx context.Context, repoPath string) ([]string, error)
+	SetAlternates(ctx context.Context, repoPath string, alternates []string) error
+	DetachAlternates(ctx context.Context, repoPath string) error
+	Fsck(ctx context.Context, repoPath string) (bool, []string, error)
 	SetDefaultBranch(ctx context.Context, repoPath string,
 		defaultBranch string, allowEmpty bool) error
 	GetDefaultBranch(ctx context.Context, repoPath string) (string, error)
@@ -48,9 +59,15 @@ type Adapter interface {
 	ReadTree(ctx context.Context, repoPath, ref string, w io.Writer, args ...string) error
 	GetTreeNode(ctx context.Context, repoPath string, ref string, treePath string) (*types.TreeNode, error)
 	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string) ([]types.TreeNode, error)
+	ListTreeFiles(ctx context.Context, repoPath string, ref string) ([]types.TreeFile, error)
+	CheckAttributes(ctx context.Context, repoPath string, tmpDir string, ref string,
+		attributes []string, paths []string) (map[string]map[string]string, error)
 	PathsDetails(ctx context.Context, repoPath string, ref string, paths []string) ([]types.PathDetails, error)
 	GetSubmodule(ctx context.Context, repoPath string, ref string, treePath string) (*types.Submodule, error)
 	GetBlob(ctx context.Context, repoPath string, sha string, sizeLimit int64) (*types.BlobReader, error)
+	ListNewBlobs(ctx context.Context, repoPath string, alternateObjectDirs []string,
+		shas []string, limit int) ([]types.NewBlob, error)
+	ReadBlob(ctx context.Context, repoPath string, alternateObjectDirs []string, sha string, w io.Writer) error
 	WalkReferences(ctx context.Context, repoPath string, handler types.WalkReferencesHandler,
 		opts *types.WalkReferencesOptions) error
 	GetCommit(ctx context.Context, repoPath string, ref string) (*types.Commit, error)
@@ -63,8 +80,12 @@ type Adapter interface {
 		limit int,
 		includeStats bool,
 		filter types.CommitFilter) ([]types.Commit, []types.PathRenameDetails, error)
+	ListNewCommits(ctx context.Context, repoPath string, alternateObjectDirs []string,
+		ref string, limit int) ([]types.Commit, error)
 	ListCommitSHAs(ctx context.Context, repoPath string,
 		ref string, page int, limit int, filter types.CommitFilter) ([]string, error)
+	ListCommitActivity(ctx context.Context, repoPath string,
+		ref string, since time.Time) ([]types.CommitActivity, error)
 	GetLatestCommit(ctx context.Context, repoPath string, ref string, treePath string) (*types.Commit, error)
 	GetFullCommitID(ctx context.Context, repoPath, shortID string) (string, error)
 	GetAnnotatedTag(ctx context.Context, repoPath string, sha string) (*types.Tag, error)
@@ -75,6 +96,7 @@ type Adapter interface {
 		requests []types.CommitDivergenceRequest, max int32) ([]types.CommitDivergence, error)
 	GetRef(ctx context.Context, repoPath string, reference string) (string, error)
 	UpdateRef(ctx context.Context, envVars map[string]string, repoPath, reference, newValue, oldValue string) error
+	UpdateRefs(ctx context.Context, envVars map[string]string, repoPath string, updates []hook.ReferenceUpdate) error
 	CreateTemporaryRepoForPR(ctx context.Context, reposTempPath string, pr *types.PullRequest,
 		baseBranch, trackingBranch string) (types.TempRepository, error)
 	Merge(ctx context.Context, pr *types.PullRequest, mergeMethod enum.MergeMethod, baseBranch, trackingBranch string,
@@ -99,6 +121,7 @@ type Adapter interface {
 		base,
 		head string,
 		mergeBase bool,
+		options types.DiffOptions,
 		paths ...types.FileDiffRequest) error
 
 	CommitDiff(ctx context.Context,
@@ -16,10 +16,13 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 
 	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/http/proxy"
 
 	gitea "code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/setting"
@@ -29,6 +32,7 @@ type Adapter struct {
 	traceGit        bool
 	lastCommitCache cache.Cache[CommitEntryKey, *types.Commit]
 	githookFactory  hook.ClientFactory
+	proxyResolver   *proxy.Resolver
 }
 
 func New(
@@ -44,9 +48,36 @@ func New(
 		return Adapter{}, err
 	}
 
+	proxyResolver, err := proxy.NewResolver(config.Proxy)
+	if err != nil {
+		return Adapter{}, fmt.Errorf("failed to create proxy resolver: %w", err)
+	}
+
 	return Adapter{
 		traceGit:        config.Trace,
 		lastCommitCache: lastCommitCache,
 		githookFactory:  githookFactory,
+		proxyResolver:   proxyResolver,
 	}, nil
 }
+
+// proxyArgs returns the git config arguments setting the proxy used for the remote.
+// No arguments are returned for remotes that aren't accessed via http(s) (e.g. local paths).
+func (a Adapter) proxyArgs(remote string) ([]string, error) {
+	remoteURL, err := url.Parse(remote)
+	if err != nil || (remoteURL.Scheme != "http" && remoteURL.Scheme != "https") {
+		return nil, nil
+	}
+
+	proxyURL, err := a.proxyResolver.ProxyURL(remoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve proxy: %w", err)
+	}
+
+	// an empty http.proxy disables proxying (e.g. for hosts configured as no proxy).
+	if proxyURL == nil {
+		return []string{"-c", "http.proxy="}, nil
+	}
+
+	return []string{"-c", "http.proxy=" + proxyURL.String()}, nil
+}
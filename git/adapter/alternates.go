@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// alternatesFile is the path of the file listing the object directories a repository borrows objects from.
+var alternatesFile = filepath.Join("objects", "info", "alternates")
+
+// GetAlternates returns the object directories the repository borrows objects from.
+// Relative paths are relative to the objects directory of the repository.
+func (a Adapter) GetAlternates(
+	_ context.Context,
+	repoPath string,
+) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoPath, alternatesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alternates: %w", err)
+	}
+
+	var alternates []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			alternates = append(alternates, line)
+		}
+	}
+
+	return alternates, nil
+}
+
+// SetAlternates sets the object directories the repository borrows objects from.
+// The alternates are removed if none are provided.
+func (a Adapter) SetAlternates(
+	_ context.Context,
+	repoPath string,
+	alternates []string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	path := filepath.Join(repoPath, alternatesFile)
+
+	if len(alternates) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove alternates: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create objects info directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(alternates, "\n")+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to write alternates: %w", err)
+	}
+
+	return nil
+}
+
+// DetachAlternates copies all objects the repository borrows from its alternates into its own object
+// storage and removes the alternates afterwards. Nothing is changed if the repository has no alternates.
+func (a Adapter) DetachAlternates(
+	ctx context.Context,
+	repoPath string,
+) error {
+	alternates, err := a.GetAlternates(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+	if len(alternates) == 0 {
+		return nil
+	}
+
+	// without --local all reachable objects are packed, including the ones borrowed from the alternates.
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "--quiet"),
+	)
+	if err = cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return fmt.Errorf("failed to repack objects: %w", err)
+	}
+
+	if err = a.SetAlternates(ctx, repoPath, nil); err != nil {
+		return err
+	}
+
+	// restore the alternates in case objects are still missing, otherwise the repository would be corrupted.
+	cmd = command.New("fsck",
+		command.WithFlag("--connectivity-only", "--no-progress", "--no-dangling"),
+	)
+	if err = cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		if errRestore := a.SetAlternates(ctx, repoPath, alternates); errRestore != nil {
+			return fmt.Errorf("failed to restore alternates after failed connectivity check (%s): %w",
+				err, errRestore)
+		}
+		return fmt.Errorf("repository is incomplete without alternates: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetachAlternates(t *testing.T) {
+	git := setupGit(t)
+	source, teardownSource := setupRepo(t, git, "testdetachalternatessource")
+	defer teardownSource()
+	fork, teardownFork := setupRepo(t, git, "testdetachalternatesfork")
+	defer teardownFork()
+
+	ctx := context.Background()
+
+	_, commitSHA := writeFile(t, source, "file.txt", "some content", nil)
+	if err := source.SetReference("refs/heads/main", commitSHA.String()); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	alternates := []string{filepath.Join(source.Path, "objects")}
+	if err := git.SetAlternates(ctx, fork.Path, alternates); err != nil {
+		t.Fatalf("failed to set alternates: %v", err)
+	}
+
+	got, err := git.GetAlternates(ctx, fork.Path)
+	if err != nil {
+		t.Fatalf("failed to get alternates: %v", err)
+	}
+	if len(got) != 1 || got[0] != alternates[0] {
+		t.Fatalf("expected alternates %v, got %v", alternates, got)
+	}
+
+	// the fork references the commit without having the objects itself.
+	if err = fork.SetReference("refs/heads/main", commitSHA.String()); err != nil {
+		t.Fatalf("failed updating reference 'main' of fork: %v", err)
+	}
+
+	count, err := git.CountObjects(ctx, fork.Path)
+	if err != nil {
+		t.Fatalf("count objects failed: %v", err)
+	}
+	if count.Count != 0 || count.InPack != 0 {
+		t.Fatalf("expected fork to not contain objects, got %d loose and %d packed objects",
+			count.Count, count.InPack)
+	}
+
+	if err = git.DetachAlternates(ctx, fork.Path); err != nil {
+		t.Fatalf("failed to detach alternates: %v", err)
+	}
+
+	got, err = git.GetAlternates(ctx, fork.Path)
+	if err != nil {
+		t.Fatalf("failed to get alternates: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no alternates after detaching, got %v", got)
+	}
+
+	// the fork has to stay healthy once the objects of the source are gone.
+	if err = os.RemoveAll(filepath.Join(source.Path, "objects")); err != nil {
+		t.Fatalf("failed to remove objects of source: %v", err)
+	}
+
+	healthy, messages, err := git.Fsck(ctx, fork.Path)
+	if err != nil {
+		t.Fatalf("fsck failed: %v", err)
+	}
+	if !healthy {
+		t.Errorf("expected detached fork to be healthy, got messages: %v", messages)
+	}
+
+	// detaching a repository without alternates is a no-op.
+	if err = git.DetachAlternates(ctx, fork.Path); err != nil {
+		t.Fatalf("failed to detach repository without alternates: %v", err)
+	}
+}
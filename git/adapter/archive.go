@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/enum"
+)
+
+// Archive writes an archive of the tree of the provided revision to the writer.
+// All files in the archive are placed in the directory with the provided prefix, if any.
+func (a Adapter) Archive(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	format enum.ArchiveFormat,
+	prefix string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	// tar.gz is compressed in process, to not rely on the gzip executable that git would use for it.
+	gitFormat := format
+	var gz *gzip.Writer
+	if format == enum.ArchiveFormatTarGz {
+		gitFormat = enum.ArchiveFormatTar
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+
+	cmd := command.New("archive",
+		command.WithFlag("--format", string(gitFormat)),
+	)
+	if prefix != "" {
+		cmd.Add(command.WithFlag("--prefix", prefix+"/"))
+	}
+	cmd.Add(command.WithArg(rev))
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(w)); err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to finish archive compression: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/harness/gitness/git/enum"
+)
+
+func TestArchiveTarGz(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testarchivetargz")
+	defer teardown()
+
+	_, commitSHA := writeFile(t, repo, "file.txt", "some content", nil)
+
+	buf := &bytes.Buffer{}
+	err := git.Archive(context.Background(), repo.Path, commitSHA.String(), enum.ArchiveFormatTarGz, "repo-main", buf)
+	if err != nil {
+		t.Fatalf("failed to archive repository: %v", err)
+	}
+
+	gz, err := gzip.NewReader(buf)
+	if err != nil {
+		t.Fatalf("archive isn't gzip compressed: %v", err)
+	}
+
+	var names []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read tar archive: %v", err)
+		}
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			// git stores the commit ID in the global header.
+			continue
+		}
+		names = append(names, header.Name)
+	}
+
+	if len(names) != 2 || names[0] != "repo-main/" || names[1] != "repo-main/file.txt" {
+		t.Errorf("unexpected archive content: %v", names)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	attributeValueUnspecified = "unspecified"
+)
+
+// CheckAttributes returns the git attributes of the provided paths as defined by the .gitattributes files
+// of the provided revision. Unspecified attributes aren't included in the result.
+// The attributes are read from a temporary index, because git check-attr can't read a tree directly.
+func (a Adapter) CheckAttributes(
+	ctx context.Context,
+	repoPath string,
+	tmpDir string,
+	rev string,
+	attributes []string,
+	paths []string,
+) (map[string]map[string]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	result := make(map[string]map[string]string)
+	if len(attributes) == 0 || len(paths) == 0 {
+		return result, nil
+	}
+
+	indexDir, err := os.MkdirTemp(tmpDir, "attributes-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory for index: %w", err)
+	}
+
+	defer func() {
+		if errRemove := os.RemoveAll(indexDir); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msg("failed to remove temporary index directory")
+		}
+	}()
+
+	indexEnv := command.WithEnv("GIT_INDEX_FILE", filepath.Join(indexDir, "index"))
+
+	cmd := command.New("read-tree",
+		command.WithArg(rev),
+		indexEnv,
+	)
+	stderr := &bytes.Buffer{}
+	if err = cmd.Run(ctx, command.WithDir(repoPath), command.WithStderr(stderr)); err != nil {
+		if strings.Contains(stderr.String(), "Not a valid object name") {
+			return nil, errors.NotFound("revision %q not found", rev)
+		}
+		return nil, fmt.Errorf("failed to read %s into the index: %w", rev, err)
+	}
+
+	cmd = command.New("check-attr",
+		command.WithFlag("--cached"),
+		command.WithFlag("-z"),
+		command.WithArg(attributes...),
+		command.WithPostSepArg(paths...),
+		indexEnv,
+	)
+	output := &bytes.Buffer{}
+	if err = cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return nil, fmt.Errorf("failed to run git check-attr: %w", err)
+	}
+
+	return parseCheckAttr(output)
+}
+
+// parseCheckAttr parses the output of "git check-attr -z",
+// which consists of NULL separated triplets of path, attribute and value.
+func parseCheckAttr(r io.Reader) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+
+	scan := bufio.NewScanner(r)
+	scan.Split(parser.ScanZeroSeparated)
+
+	var fields []string
+	for scan.Scan() {
+		fields = append(fields, scan.Text())
+		if len(fields) < 3 {
+			continue
+		}
+
+		path, attribute, value := fields[0], fields[1], fields[2]
+		fields = fields[:0]
+
+		if value == attributeValueUnspecified {
+			continue
+		}
+
+		if result[path] == nil {
+			result[path] = make(map[string]string)
+		}
+		result[path][attribute] = value
+	}
+	if err := scan.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git check-attr output: %w", err)
+	}
+
+	if len(fields) != 0 {
+		return nil, fmt.Errorf("unexpected format of git check-attr output: %q", fields)
+	}
+
+	return result, nil
+}
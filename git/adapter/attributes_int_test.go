@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCheckAttributes(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testcheckattributes")
+	defer teardown()
+
+	ctx := context.Background()
+
+	_, commit := writeFile(t, repo, ".gitattributes", "README.md export-ignore\n*.gen.go linguist-generated -diff\n", nil)
+	_, commit = writeFile(t, repo, "README.md", "# readme", []string{commit.String()})
+	_, commit = writeFile(t, repo, "api.gen.go", "package api", []string{commit.String()})
+
+	attributes, err := git.CheckAttributes(ctx, repo.Path, t.TempDir(), commit.String(),
+		[]string{"export-ignore", "linguist-generated", "diff"},
+		[]string{"README.md", "api.gen.go", "main.go"})
+	if err != nil {
+		t.Fatalf("failed to check attributes: %v", err)
+	}
+
+	expected := map[string]map[string]string{
+		"README.md":  {"export-ignore": "set"},
+		"api.gen.go": {"linguist-generated": "set", "diff": "unset"},
+	}
+	if !reflect.DeepEqual(attributes, expected) {
+		t.Errorf("expected %v, got %v", expected, attributes)
+	}
+}
@@ -15,11 +15,17 @@
 package adapter
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/types"
 )
 
@@ -87,3 +93,115 @@ func (l limitReaderCloser) Close() error {
 	l.stop()
 	return nil
 }
+
+// ListNewBlobs lists the blobs reachable from the provided commits that aren't reachable from any existing reference.
+// The alternate object directories allow to access objects that aren't part of the repository yet
+// (e.g. the quarantine directory of objects received during a push).
+func (a Adapter) ListNewBlobs(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []string,
+	limit int,
+) ([]types.NewBlob, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(shas) == 0 {
+		return nil, nil
+	}
+
+	var env []command.CmdOptionFunc
+	if len(alternateObjectDirs) > 0 {
+		env = append(env, command.WithEnv(
+			"GIT_ALTERNATE_OBJECT_DIRECTORIES", strings.Join(alternateObjectDirs, string(os.PathListSeparator))))
+	}
+
+	args := make([]string, len(shas))
+	for i, sha := range shas {
+		// flags always precede the positional args, so "--not" applies to the shas as well - negate them again.
+		args[i] = "^" + sha
+	}
+
+	revList := command.New("rev-list", append(env,
+		command.WithFlag("--objects"),
+		command.WithFlag("--not", "--all"),
+		command.WithArg(args...),
+	)...)
+
+	objects := &bytes.Buffer{}
+	if err := revList.Run(ctx, command.WithDir(repoPath), command.WithStdout(objects)); err != nil {
+		return nil, processGiteaErrorf(err, "failed to list new objects")
+	}
+
+	if objects.Len() == 0 {
+		return nil, nil
+	}
+
+	// rev-list prints "<sha> <path>" for trees and blobs, cat-file keeps the path as rest of the line.
+	catFile := command.New("cat-file", append(env,
+		command.WithFlag("--batch-check=%(objecttype) %(objectname) %(objectsize) %(rest)"),
+	)...)
+
+	output := &bytes.Buffer{}
+	err := catFile.Run(ctx, command.WithDir(repoPath), command.WithStdin(objects), command.WithStdout(output))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to read new object types")
+	}
+
+	var blobs []types.NewBlob
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		objectType, rest, _ := strings.Cut(scanner.Text(), " ")
+		if objectType != string(ObjectBlob) {
+			continue
+		}
+
+		sha, rest, _ := strings.Cut(rest, " ")
+		sizeStr, path, _ := strings.Cut(rest, " ")
+
+		size, err := strconv.ParseInt(sizeStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of blob %s: %w", sha, err)
+		}
+
+		blobs = append(blobs, types.NewBlob{SHA: sha, Path: path, Size: size})
+		if len(blobs) >= limit {
+			break
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read new objects: %w", err)
+	}
+
+	return blobs, nil
+}
+
+// ReadBlob writes the content of the blob to the writer.
+// The alternate object directories allow to access blobs that aren't part of the repository yet.
+func (a Adapter) ReadBlob(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	sha string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("cat-file",
+		command.WithArg("blob", sha),
+	)
+	if len(alternateObjectDirs) > 0 {
+		cmd.Add(command.WithEnv(
+			"GIT_ALTERNATE_OBJECT_DIRECTORIES", strings.Join(alternateObjectDirs, string(os.PathListSeparator))))
+	}
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(w)); err != nil {
+		return processGiteaErrorf(err, "failed to read blob")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestListNewBlobs(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlistnewblobs")
+	defer teardown()
+
+	ctx := context.Background()
+
+	_, base := writeFile(t, repo, "existing.txt", "existing", nil)
+	if err := git.UpdateRef(ctx, nil, repo.Path, "refs/heads/main", types.NilSHA, base.String()); err != nil {
+		t.Fatalf("failed to update ref: %v", err)
+	}
+
+	blob, head := writeFile(t, repo, "dir/new.txt", "new content", []string{base.String()})
+
+	blobs, err := git.ListNewBlobs(ctx, repo.Path, nil, []string{head.String()}, 10)
+	if err != nil {
+		t.Fatalf("failed to list new blobs: %v", err)
+	}
+
+	want := types.NewBlob{SHA: blob.String(), Path: "dir/new.txt", Size: int64(len("new content"))}
+	if len(blobs) != 1 || blobs[0] != want {
+		t.Fatalf("expected new blobs [%+v], got %+v", want, blobs)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = git.ReadBlob(ctx, repo.Path, nil, blob.String(), buf); err != nil {
+		t.Fatalf("failed to read blob: %v", err)
+	}
+	if buf.String() != "new content" {
+		t.Errorf("expected blob content %q, got %q", "new content", buf.String())
+	}
+
+	blobs, err = git.ListNewBlobs(ctx, repo.Path, nil, []string{base.String()}, 10)
+	if err != nil {
+		t.Fatalf("failed to list new blobs: %v", err)
+	}
+	if len(blobs) != 0 {
+		t.Errorf("expected no new blobs for existing commit, got %+v", blobs)
+	}
+}
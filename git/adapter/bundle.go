@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// CreateBundle writes a git bundle containing all refs of the repository to the writer.
+func (a Adapter) CreateBundle(
+	ctx context.Context,
+	repoPath string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("bundle",
+		command.WithAction("create"),
+		command.WithArg("-", "--all"),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(w)); err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	return nil
+}
+
+// FetchBundle fetches all refs of the bundle file into the repository, overwriting existing refs.
+func (a Adapter) FetchBundle(
+	ctx context.Context,
+	repoPath string,
+	bundlePath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("fetch",
+		command.WithFlag("--quiet"),
+		command.WithArg(bundlePath, "+refs/*:refs/*"),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return fmt.Errorf("failed to fetch bundle: %w", err)
+	}
+
+	return nil
+}
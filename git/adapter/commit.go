@@ -15,9 +15,12 @@
 package adapter
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -75,7 +78,16 @@ func (a Adapter) listCommitSHAs(
 	limit int,
 	filter types.CommitFilter,
 ) ([]string, error) {
-	cmd := command.New("rev-list")
+	var cmd *command.Command
+	if filter.Follow && filter.Path != "" {
+		// rev-list doesn't support following renames, hence git log is used to print the commit SHAs.
+		cmd = command.New("log",
+			command.WithFlag("--format=%H"),
+			command.WithFlag("--follow"),
+		)
+	} else {
+		cmd = command.New("rev-list")
+	}
 
 	// return commits only up to a certain reference if requested
 	if filter.AfterRef != "" {
@@ -89,9 +101,9 @@ func (a Adapter) listCommitSHAs(
 		cmd.Add(command.WithPostSepArg(filter.Path))
 	}
 
-	// add pagination if requested
+	// add pagination if requested (keyset pagination is handled while reading the output)
 	// TODO: we should add absolut limits to protect git (return error)
-	if limit > 0 {
+	if limit > 0 && filter.Cursor == "" {
 		cmd.Add(command.WithFlag("--max-count", strconv.Itoa(limit)))
 
 		if page > 1 {
@@ -111,6 +123,14 @@ func (a Adapter) listCommitSHAs(
 	if filter.Committer != "" {
 		cmd.Add(command.WithFlag("--committer", filter.Committer))
 	}
+	if filter.Author != "" {
+		cmd.Add(command.WithFlag("--author", filter.Author))
+	}
+
+	if filter.Cursor != "" {
+		return listCommitSHAsAfterCursor(ctx, cmd, repoPath, filter.Cursor, limit)
+	}
+
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
 	if err != nil {
@@ -121,6 +141,143 @@ func (a Adapter) listCommitSHAs(
 	return parseLinesToSlice(output.Bytes()), nil
 }
 
+// listCommitSHAsAfterCursor reads the commit SHAs printed by the command and returns
+// the ones following the cursor commit (exclusive), up to the limit.
+// The command is stopped as soon as enough commits have been read.
+func listCommitSHAsAfterCursor(
+	ctx context.Context,
+	cmd *command.Command,
+	repoPath string,
+	cursor string,
+	limit int,
+) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(pw))
+		_ = pw.CloseWithError(err)
+		errCh <- err
+	}()
+
+	var commitSHAs []string
+	cursorFound := false
+	limitReached := false
+
+	scanner := bufio.NewScanner(pr)
+	for scanner.Scan() {
+		sha := strings.TrimSpace(scanner.Text())
+		if sha == "" {
+			continue
+		}
+
+		if !cursorFound {
+			cursorFound = sha == cursor
+			continue
+		}
+
+		commitSHAs = append(commitSHAs, sha)
+		if limit > 0 && len(commitSHAs) >= limit {
+			limitReached = true
+			break
+		}
+	}
+
+	// stop the command in case not all of its output has been read
+	cancel()
+	_ = pr.Close()
+
+	err := <-errCh
+	if limitReached {
+		return commitSHAs, nil
+	}
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list commits")
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read commit list: %w", err)
+	}
+
+	if !cursorFound {
+		return nil, errors.InvalidArgument("commit %s isn't part of the commit list", cursor)
+	}
+
+	return commitSHAs, nil
+}
+
+// ListNewCommits lists the commits reachable from ref that aren't reachable from any existing reference.
+// The alternate object directories allow to access objects that aren't part of the repository yet
+// (e.g. the quarantine directory of objects received during a push).
+func (a Adapter) ListNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	ref string,
+	limit int,
+) ([]types.Commit, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	// fields and commits are separated by NUL (which can't be part of any field).
+	const fieldCount = 8
+
+	cmd := command.New("log",
+		command.WithFlag("-z"),
+		command.WithFlag("--format=%H%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%cI%x00%B"),
+		command.WithFlag("--max-count", strconv.Itoa(limit)),
+		command.WithFlag("--not", "--all"),
+		// flags always precede the positional args, so "--not" applies to ref as well - negate it again.
+		command.WithArg("^"+ref),
+	)
+	if len(alternateObjectDirs) > 0 {
+		cmd.Add(command.WithEnv(
+			"GIT_ALTERNATE_OBJECT_DIRECTORIES", strings.Join(alternateObjectDirs, string(os.PathListSeparator))))
+	}
+
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return nil, processGiteaErrorf(err, "failed to list new commits")
+	}
+
+	if output.Len() == 0 {
+		return nil, nil
+	}
+
+	fields := strings.Split(strings.TrimSuffix(output.String(), "\x00"), "\x00")
+	if len(fields)%fieldCount != 0 {
+		return nil, fmt.Errorf("unexpected git log output with %d fields", len(fields))
+	}
+
+	commits := make([]types.Commit, 0, len(fields)/fieldCount)
+	for i := 0; i < len(fields); i += fieldCount {
+		authorWhen, _ := time.Parse(time.RFC3339, fields[i+3])
+		committerWhen, _ := time.Parse(time.RFC3339, fields[i+6])
+		message := fields[i+7]
+		title, _, _ := strings.Cut(message, "\n")
+
+		commits = append(commits, types.Commit{
+			SHA:     fields[i],
+			Title:   title,
+			Message: message,
+			Author: types.Signature{
+				Identity: types.Identity{Name: fields[i+1], Email: fields[i+2]},
+				When:     authorWhen,
+			},
+			Committer: types.Signature{
+				Identity: types.Identity{Name: fields[i+4], Email: fields[i+5]},
+				When:     committerWhen,
+			},
+		})
+	}
+
+	return commits, nil
+}
+
 // ListCommitSHAs lists the commits reachable from ref.
 // Note: ref & afterRef can be Branch / Tag / CommitSHA.
 // Note: commits returned are [ref->...->afterRef).
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/types"
+)
+
+// commitActivityHeaderPrefix marks the header line of each commit in the output of ListCommitActivity.
+const commitActivityHeaderPrefix = "\x00commit\x00"
+
+// ListCommitActivity walks the history of the provided ref (excluding merge commits)
+// and returns the author and the number of added and deleted lines of every commit.
+// Commits authored before the provided time are skipped (ignored if zero).
+func (a Adapter) ListCommitActivity(
+	ctx context.Context,
+	repoPath string,
+	ref string,
+	since time.Time,
+) ([]types.CommitActivity, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("log",
+		command.WithFlag("--no-merges", "--numstat", "--no-renames", "--no-color"),
+		command.WithFlag("--format="+commitActivityHeaderPrefix+"%H%x00%an%x00%ae%x00%at"),
+	)
+	if !since.IsZero() {
+		cmd.Add(command.WithFlag("--since=" + strconv.FormatInt(since.Unix(), 10)))
+	}
+	cmd.Add(command.WithArg(ref))
+	cmd.Add(command.WithPostSepArg())
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		var err error
+
+		defer func() {
+			// If running of the command below fails, make the pipe reader also fail with the same error.
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = cmd.Run(ctx,
+			command.WithDir(repoPath),
+			command.WithStdout(pipeWrite),
+			command.WithStderr(stderr),
+		)
+	}()
+
+	activities, err := parseCommitActivity(pipeRead)
+	if err != nil {
+		_ = pipeRead.CloseWithError(err)
+		return nil, fmt.Errorf("failed to walk the commits of %s: %w (%s)", ref, err, stderr.String())
+	}
+
+	return activities, nil
+}
+
+// parseCommitActivity parses the output of git log with a commit activity header and numstat lines.
+func parseCommitActivity(r io.Reader) ([]types.CommitActivity, error) {
+	var activities []types.CommitActivity
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if header, ok := strings.CutPrefix(line, commitActivityHeaderPrefix); ok {
+			parts := strings.Split(header, "\x00")
+			if len(parts) != 4 {
+				return nil, fmt.Errorf("unexpected commit header %q", header)
+			}
+
+			timestamp, err := strconv.ParseInt(parts[3], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse commit time %q: %w", parts[3], err)
+			}
+
+			activities = append(activities, types.CommitActivity{
+				SHA: parts[0],
+				Author: types.Signature{
+					Identity: types.Identity{Name: parts[1], Email: parts[2]},
+					When:     time.Unix(timestamp, 0).UTC(),
+				},
+			})
+
+			continue
+		}
+
+		if len(activities) == 0 {
+			return nil, fmt.Errorf("unexpected line %q before the first commit header", line)
+		}
+
+		// numstat lines have the format "<additions>\t<deletions>\t<path>", binary files use "-" for both counts.
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected numstat line %q", line)
+		}
+
+		current := &activities[len(activities)-1]
+		if additions, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+			current.Additions += additions
+		}
+		if deletions, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			current.Deletions += deletions
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestParseCommitActivity(t *testing.T) {
+	output := strings.Join([]string{
+		commitActivityHeaderPrefix + "aaa\x00Jane Doe\x00jane@example.com\x001700000000",
+		"",
+		"3\t1\tREADME.md",
+		"-\t-\tlogo.png",
+		"10\t0\tmain.go",
+		commitActivityHeaderPrefix + "bbb\x00John Doe\x00john@example.com\x001600000000",
+		commitActivityHeaderPrefix + "ccc\x00Jane Doe\x00jane@example.com\x001500000000",
+		"",
+		"0\t7\tmain.go",
+		"",
+	}, "\n")
+
+	activities, err := parseCommitActivity(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("failed to parse commit activity: %s", err)
+	}
+
+	expected := []types.CommitActivity{
+		{
+			SHA: "aaa",
+			Author: types.Signature{
+				Identity: types.Identity{Name: "Jane Doe", Email: "jane@example.com"},
+				When:     time.Unix(1700000000, 0).UTC(),
+			},
+			Additions: 13,
+			Deletions: 1,
+		},
+		{
+			SHA: "bbb",
+			Author: types.Signature{
+				Identity: types.Identity{Name: "John Doe", Email: "john@example.com"},
+				When:     time.Unix(1600000000, 0).UTC(),
+			},
+		},
+		{
+			SHA: "ccc",
+			Author: types.Signature{
+				Identity: types.Identity{Name: "Jane Doe", Email: "jane@example.com"},
+				When:     time.Unix(1500000000, 0).UTC(),
+			},
+			Deletions: 7,
+		},
+	}
+
+	if !reflect.DeepEqual(activities, expected) {
+		t.Errorf("expected %+v, got %+v", expected, activities)
+	}
+}
+
+func TestParseCommitActivityInvalid(t *testing.T) {
+	if _, err := parseCommitActivity(strings.NewReader("1\t2\tfile.txt\n")); err == nil {
+		t.Error("expected an error for numstat output without a commit header")
+	}
+}
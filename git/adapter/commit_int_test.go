@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestListCommitsCursor(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlistcommitscursor")
+	defer teardown()
+
+	ctx := context.Background()
+
+	var parents []string
+	shas := make([]string, 5)
+	for i := range shas {
+		_, commit := writeFile(t, repo, "file.txt", string(rune('a'+i)), parents)
+		parents = []string{commit.String()}
+		// commits are listed newest first
+		shas[len(shas)-1-i] = commit.String()
+	}
+	head := shas[0]
+
+	listSHAs := func(filter types.CommitFilter) []string {
+		t.Helper()
+		commits, _, err := git.ListCommits(ctx, repo.Path, head, 0, 2, false, filter)
+		if err != nil {
+			t.Fatalf("failed to list commits: %v", err)
+		}
+		result := make([]string, len(commits))
+		for i := range commits {
+			result[i] = commits[i].SHA
+		}
+		return result
+	}
+
+	if got := listSHAs(types.CommitFilter{}); !reflect.DeepEqual(got, shas[:2]) {
+		t.Errorf("first page: expected %v, got %v", shas[:2], got)
+	}
+	if got := listSHAs(types.CommitFilter{Cursor: shas[1]}); !reflect.DeepEqual(got, shas[2:4]) {
+		t.Errorf("second page: expected %v, got %v", shas[2:4], got)
+	}
+	if got := listSHAs(types.CommitFilter{Cursor: shas[3]}); !reflect.DeepEqual(got, shas[4:]) {
+		t.Errorf("last page: expected %v, got %v", shas[4:], got)
+	}
+	if got := listSHAs(types.CommitFilter{Cursor: shas[4]}); len(got) != 0 {
+		t.Errorf("expected no commits after the last commit, got %v", got)
+	}
+
+	_, _, err := git.ListCommits(ctx, repo.Path, head, 0, 2, false,
+		types.CommitFilter{Cursor: "0000000000000000000000000000000000000001"})
+	if err == nil {
+		t.Errorf("expected error for unknown cursor")
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/types"
 
@@ -149,6 +150,7 @@ func (a Adapter) RawDiff(
 	baseRef string,
 	headRef string,
 	mergeBase bool,
+	options types.DiffOptions,
 	files ...types.FileDiffRequest,
 ) error {
 	if repoPath == "" {
@@ -170,6 +172,7 @@ func (a Adapter) RawDiff(
 	if mergeBase {
 		args = append(args, "--merge-base")
 	}
+	args = append(args, diffOptionsArgs(options)...)
 	perFileDiffRequired := false
 	paths := make([]string, 0, len(files))
 	if len(files) > 0 {
@@ -195,10 +198,15 @@ again:
 	}
 
 	if perFileDiffRequired {
+		// the line range is cut from the full file diff, so it overrides the requested context lines.
 		if startLine > 0 || endLine > 0 {
 			newargs = append(newargs, "-U"+strconv.Itoa(math.MaxInt32))
+		} else if options.ContextLines != nil {
+			newargs = append(newargs, "-U"+strconv.Itoa(*options.ContextLines))
 		}
 		paths = []string{files[processed].Path}
+	} else if options.ContextLines != nil {
+		newargs = append(newargs, "-U"+strconv.Itoa(*options.ContextLines))
 	}
 
 	newargs = append(newargs, baseRef, headRef)
@@ -234,6 +242,21 @@ again:
 	return nil
 }
 
+// diffOptionsArgs returns the git diff arguments of the diff options, except for the context lines.
+func diffOptionsArgs(options types.DiffOptions) []string {
+	var args []string
+	if options.IgnoreWhitespace {
+		args = append(args, "--ignore-all-space")
+	}
+	if options.IgnoreBlankLines {
+		args = append(args, "--ignore-blank-lines")
+	}
+	if options.Algorithm != enum.DiffAlgorithmDefault {
+		args = append(args, "--diff-algorithm="+string(options.Algorithm))
+	}
+	return args
+}
+
 func (a Adapter) rawDiff(
 	ctx context.Context,
 	w io.Writer,
@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"testing"
+
+	"github.com/harness/gitness/git/types"
 )
 
 func TestAdapter_RawDiff(t *testing.T) {
@@ -140,7 +142,8 @@ index ` + oid1.String() + `..` + oid2.String() + ` 100644
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &bytes.Buffer{}
-			err := git.RawDiff(tt.args.ctx, w, tt.args.repoPath, tt.args.baseRef, tt.args.headRef, tt.args.mergeBase)
+			err := git.RawDiff(tt.args.ctx, w, tt.args.repoPath, tt.args.baseRef, tt.args.headRef, tt.args.mergeBase,
+				types.DiffOptions{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RawDiff() error = %v, wantErr %v", err, tt.wantErr)
 				return
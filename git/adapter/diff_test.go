@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/types"
 
 	"github.com/google/go-cmp/cmp"
@@ -243,3 +244,33 @@ some content
 		})
 	}
 }
+
+func Test_diffOptionsArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		options types.DiffOptions
+		want    []string
+	}{
+		{
+			name:    "default",
+			options: types.DiffOptions{},
+			want:    nil,
+		},
+		{
+			name: "all options",
+			options: types.DiffOptions{
+				IgnoreWhitespace: true,
+				IgnoreBlankLines: true,
+				Algorithm:        enum.DiffAlgorithmHistogram,
+			},
+			want: []string{"--ignore-all-space", "--ignore-blank-lines", "--diff-algorithm=histogram"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, diffOptionsArgs(tt.options)); diff != "" {
+				t.Errorf("diffOptionsArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
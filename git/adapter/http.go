@@ -36,7 +36,8 @@ func (a Adapter) InfoRefs(
 	env ...string,
 ) error {
 	cmd := &bytes.Buffer{}
-	if err := git.NewCommand(ctx, service, "--stateless-rpc", "--advertise-refs", ".").
+	args := append(serviceConfigArgs(service), service, "--stateless-rpc", "--advertise-refs", ".")
+	if err := git.NewCommand(ctx, args...).
 		Run(&git.RunOpts{
 			Env:    env,
 			Dir:    repoPath,
@@ -72,7 +73,8 @@ func (a Adapter) ServicePack(
 	var (
 		stderr bytes.Buffer
 	)
-	cmd := git.NewCommand(ctx, service, "--stateless-rpc", repoPath)
+	args := append(serviceConfigArgs(service), service, "--stateless-rpc", repoPath)
+	cmd := git.NewCommand(ctx, args...)
 	cmd.SetDescription(fmt.Sprintf("%s %s %s [repo_path: %s]", git.GitExecutable, service, "--stateless-rpc", repoPath))
 	err := cmd.Run(&git.RunOpts{
 		Dir:               repoPath,
@@ -88,6 +90,15 @@ func (a Adapter) ServicePack(
 	return err
 }
 
+// serviceConfigArgs returns the git config arguments required for the service.
+func serviceConfigArgs(service string) []string {
+	if service == "receive-pack" {
+		// allow clients to send push options, they are forwarded to the server hooks.
+		return []string{"-c", "receive.advertisePushOptions=true"}
+	}
+	return nil
+}
+
 func packetWrite(str string) []byte {
 	s := strconv.FormatInt(int64(len(str)+4), 16)
 	if len(s)%4 != 0 {
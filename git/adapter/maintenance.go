@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// GC runs the git garbage collection on the repository and prunes all unreachable objects.
+// With keepUnreachable the unreachable objects are kept (e.g. as they are still used by forks).
+func (a Adapter) GC(
+	ctx context.Context,
+	repoPath string,
+	aggressive bool,
+	keepUnreachable bool,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	prune := "--prune=now"
+	if keepUnreachable {
+		prune = "--prune=never"
+	}
+
+	cmd := command.New("gc",
+		command.WithFlag("--quiet", prune),
+	)
+	if aggressive {
+		cmd.Add(command.WithFlag("--aggressive"))
+	}
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return fmt.Errorf("failed to run git gc: %w", err)
+	}
+
+	return nil
+}
+
+// Fsck verifies the connectivity and validity of all objects of the repository.
+// It returns whether the repository is healthy and the messages reported by git.
+func (a Adapter) Fsck(
+	ctx context.Context,
+	repoPath string,
+) (bool, []string, error) {
+	if repoPath == "" {
+		return false, nil, ErrRepositoryPathEmpty
+	}
+
+	out := &bytes.Buffer{}
+	cmd := command.New("fsck",
+		command.WithFlag("--full", "--strict", "--no-progress", "--no-dangling"),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(out), command.WithStderr(out))
+	healthy := err == nil
+	if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.ExitCode() != 0 {
+		// a non-zero exit code means that problems were found.
+		err = nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to run git fsck: %w", err)
+	}
+
+	var messages []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			messages = append(messages, line)
+		}
+	}
+
+	return healthy, messages, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGCAndFsck(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testgcandfsck")
+	defer teardown()
+
+	ctx := context.Background()
+
+	_, commitSHA := writeFile(t, repo, "file.txt", "some content", nil)
+	if err := repo.SetReference("refs/heads/main", commitSHA.String()); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	healthy, messages, err := git.Fsck(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("fsck failed: %v", err)
+	}
+	if !healthy {
+		t.Fatalf("expected repository to be healthy, got messages: %v", messages)
+	}
+
+	if err = git.GC(ctx, repo.Path, false, false); err != nil {
+		t.Fatalf("gc failed: %v", err)
+	}
+
+	count, err := git.CountObjects(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("count objects failed: %v", err)
+	}
+	if count.Count != 0 || count.Packs != 1 {
+		t.Errorf("expected all objects to be packed, got %d loose objects and %d packs", count.Count, count.Packs)
+	}
+
+	// remove the packed objects to break the connectivity of the branch.
+	packs, err := filepath.Glob(filepath.Join(repo.Path, "objects", "pack", "*"))
+	if err != nil {
+		t.Fatalf("failed to list packs: %v", err)
+	}
+	for _, pack := range packs {
+		if err = os.Remove(pack); err != nil {
+			t.Fatalf("failed to remove pack: %v", err)
+		}
+	}
+
+	healthy, messages, err = git.Fsck(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("fsck failed: %v", err)
+	}
+	if healthy {
+		t.Errorf("expected repository to be unhealthy")
+	}
+	if len(messages) == 0 {
+		t.Errorf("expected fsck to report problems")
+	}
+}
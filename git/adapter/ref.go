@@ -15,12 +15,14 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"math"
 	"strings"
 
+	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
@@ -231,6 +233,78 @@ func (a Adapter) UpdateRef(
 	return nil
 }
 
+// UpdateRefs updates multiple references in a single atomic git-ref update.
+// Requires both old and new value to be provided explicitly for every reference.
+// pre-receive is called once for all updates before the update, post-receive after.
+func (a Adapter) UpdateRefs(
+	ctx context.Context,
+	envVars map[string]string,
+	repoPath string,
+	updates []hook.ReferenceUpdate,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	stdin := &bytes.Buffer{}
+	for _, update := range updates {
+		if update.Old == "" || update.New == "" {
+			return fmt.Errorf("old and new value of reference %q must be provided", update.Ref)
+		}
+		if update.Old == types.NilSHA && update.New == types.NilSHA {
+			return fmt.Errorf("provided values of reference %q cannot be both empty", update.Ref)
+		}
+
+		if update.New == types.NilSHA {
+			stdin.WriteString("delete " + update.Ref + "\x00" + update.Old + "\x00")
+		} else {
+			stdin.WriteString("update " + update.Ref + "\x00" + update.New + "\x00" + update.Old + "\x00")
+		}
+	}
+
+	githookClient, err := a.githookFactory.NewClient(ctx, envVars)
+	if err != nil {
+		return fmt.Errorf("failed to create githook client: %w", err)
+	}
+
+	// call pre-receive before updating the references
+	out, err := githookClient.PreReceive(ctx, hook.PreReceiveInput{
+		RefUpdates: updates,
+	})
+	if err != nil {
+		return fmt.Errorf("pre-receive call failed with: %w", err)
+	}
+	if out.Error != nil {
+		return fmt.Errorf("pre-receive call returned error: %q", *out.Error)
+	}
+
+	// all updates are applied in a single transaction - either all or none of the references are updated.
+	cmd := command.New("update-ref",
+		command.WithFlag("--stdin"),
+		command.WithFlag("-z"),
+	)
+	err = cmd.Run(ctx, command.WithDir(repoPath), command.WithStdin(stdin))
+	if err != nil {
+		return fmt.Errorf("update of %d references failed: %w", len(updates), err)
+	}
+
+	// call post-receive after updating the references
+	out, err = githookClient.PostReceive(ctx, hook.PostReceiveInput{
+		RefUpdates: updates,
+	})
+	if err != nil {
+		return fmt.Errorf("post-receive call failed with: %w", err)
+	}
+	if out.Error != nil {
+		return fmt.Errorf("post-receive call returned error: %q", *out.Error)
+	}
+
+	return nil
+}
+
 // updateRefWithHooks performs a git-ref update for the provided reference.
 // Requires both old and new value to be provided explcitly, or the call fails (ensures consistency across operation).
 // pre-receice will be called before the update, post-receive after.
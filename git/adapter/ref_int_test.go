@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestWalkReferencesCommitterDate(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testwalkreferencescommitterdate")
+	defer teardown()
+
+	ctx := context.Background()
+
+	_, commit := writeFile(t, repo, "file.txt", "content", nil)
+
+	err := git.UpdateRef(ctx, nil, repo.Path, "refs/heads/main", types.NilSHA, commit.String())
+	if err != nil {
+		t.Fatalf("failed to create branch: %v", err)
+	}
+
+	var entries []types.WalkReferencesEntry
+	err = git.WalkReferences(ctx, repo.Path, func(e types.WalkReferencesEntry) error {
+		entries = append(entries, e)
+		return nil
+	}, &types.WalkReferencesOptions{
+		Patterns: []string{"refs/heads/"},
+		Fields: []types.GitReferenceField{
+			types.GitReferenceFieldRefName,
+			types.GitReferenceFieldCommitterDateUnix,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to walk references: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 reference, got %d", len(entries))
+	}
+
+	if entries[0][types.GitReferenceFieldRefName] != "refs/heads/main" {
+		t.Errorf("unexpected reference %q", entries[0][types.GitReferenceFieldRefName])
+	}
+
+	date, err := strconv.ParseInt(entries[0][types.GitReferenceFieldCommitterDateUnix], 10, 64)
+	if err != nil || date <= 0 {
+		t.Errorf("expected unix committer date, got %q", entries[0][types.GitReferenceFieldCommitterDateUnix])
+	}
+}
+
+func TestUpdateRefsDelete(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testupdaterefsdelete")
+	defer teardown()
+
+	ctx := context.Background()
+
+	_, commit := writeFile(t, repo, "file.txt", "content", nil)
+
+	refs := []string{"refs/heads/branch1", "refs/heads/branch2", "refs/heads/branch3"}
+	for _, ref := range refs {
+		err := git.UpdateRef(ctx, nil, repo.Path, ref, types.NilSHA, commit.String())
+		if err != nil {
+			t.Fatalf("failed to create branch %s: %v", ref, err)
+		}
+	}
+
+	err := git.UpdateRefs(ctx, nil, repo.Path, []hook.ReferenceUpdate{
+		{Ref: refs[0], Old: commit.String(), New: types.NilSHA},
+		{Ref: refs[1], Old: commit.String(), New: types.NilSHA},
+	})
+	if err != nil {
+		t.Fatalf("failed to delete branches: %v", err)
+	}
+
+	for _, ref := range refs[:2] {
+		if _, err = git.GetRef(ctx, repo.Path, ref); !types.IsNotFoundError(err) {
+			t.Errorf("expected branch %s to be deleted, got error %v", ref, err)
+		}
+	}
+
+	if _, err = git.GetRef(ctx, repo.Path, refs[2]); err != nil {
+		t.Errorf("expected branch %s to still exist: %v", refs[2], err)
+	}
+
+	// the update is atomic, an outdated old value fails the update of all references.
+	err = git.UpdateRefs(ctx, nil, repo.Path, []hook.ReferenceUpdate{
+		{Ref: refs[2], Old: commit.String(), New: types.NilSHA},
+		{Ref: refs[0], Old: commit.String(), New: types.NilSHA},
+	})
+	if err == nil {
+		t.Errorf("expected deletion of a missing branch to fail")
+	}
+
+	if _, err = git.GetRef(ctx, repo.Path, refs[2]); err != nil {
+		t.Errorf("expected branch %s to still exist after failed update: %v", refs[2], err)
+	}
+}
@@ -128,14 +128,18 @@ func (a Adapter) GetRemoteDefaultBranch(
 	ctx context.Context,
 	remoteURL string,
 ) (string, error) {
-	args := []string{
+	args, err := a.proxyArgs(remoteURL)
+	if err != nil {
+		return "", err
+	}
+	args = append(args,
 		"-c", "credential.helper=",
 		"ls-remote",
 		"--symref",
 		"-q",
 		remoteURL,
 		"HEAD",
-	}
+	)
 
 	cmd := gitea.NewCommand(ctx, args...)
 	stdOut, _, err := cmd.RunStdString(nil)
@@ -193,7 +197,11 @@ func (a Adapter) Sync(
 	if len(refSpecs) == 0 {
 		refSpecs = []string{"+refs/*:refs/*"}
 	}
-	args := []string{
+	args, err := a.proxyArgs(source)
+	if err != nil {
+		return err
+	}
+	args = append(args,
 		"-c", "advice.fetchShowForcedUpdates=false",
 		"-c", "credential.helper=",
 		"fetch",
@@ -204,11 +212,11 @@ func (a Adapter) Sync(
 		"--no-write-fetch-head",
 		"--no-show-forced-updates",
 		source,
-	}
+	)
 	args = append(args, refSpecs...)
 
 	cmd := gitea.NewCommand(ctx, args...)
-	_, _, err := cmd.RunStdString(&gitea.RunOpts{
+	_, _, err = cmd.RunStdString(&gitea.RunOpts{
 		Dir:               repoPath,
 		UseContextTimeout: true,
 	})
@@ -278,7 +286,12 @@ func (a Adapter) Push(
 	if repoPath == "" {
 		return ErrRepositoryPathEmpty
 	}
-	cmd := gitea.NewCommand(ctx,
+	proxyArgs, err := a.proxyArgs(opts.Remote)
+	if err != nil {
+		return err
+	}
+	cmd := gitea.NewCommand(ctx, proxyArgs...)
+	cmd.AddArguments(
 		"-c", "credential.helper=",
 		"push",
 	)
@@ -322,7 +335,7 @@ func (a Adapter) Push(
 	)
 
 	var outbuf, errbuf strings.Builder
-	err := cmd.Run(&gitea.RunOpts{
+	err = cmd.Run(&gitea.RunOpts{
 		Env:     opts.Env,
 		Timeout: opts.Timeout,
 		Dir:     repoPath,
@@ -22,6 +22,7 @@ import (
 	"io"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/errors"
@@ -215,6 +216,74 @@ func (a Adapter) ListTreeNodes(ctx context.Context, repoPath, rev, treePath stri
 	return list, nil
 }
 
+// regexpLsTreeLongColumns is a regular expression that is used to parse a single line
+// of a "git ls-tree --long" output (which uses the NULL character as the line break).
+var regexpLsTreeLongColumns = regexp.MustCompile(`(?s)^(\d{6})\s+(\w+)\s+(\w+)\s+(-|\d+)\t(.+)`)
+
+// ListTreeFiles returns all blobs of the tree of the provided revision, including the blobs in subdirectories.
+// Submodules aren't included in the result.
+func (a Adapter) ListTreeFiles(ctx context.Context, repoPath, rev string) ([]types.TreeFile, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("ls-tree",
+		command.WithFlag("-r"),
+		command.WithFlag("--long"),
+		command.WithFlag("-z"),
+		command.WithFlag("--full-tree"),
+		command.WithArg(rev),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(output),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "fatal: Not a valid object name") {
+			return nil, errors.NotFound("revision %q not found", rev)
+		}
+		return nil, fmt.Errorf("failed to run git ls-tree: %w", err)
+	}
+
+	return parseLsTreeLong(output)
+}
+
+func parseLsTreeLong(r io.Reader) ([]types.TreeFile, error) {
+	var files []types.TreeFile
+
+	scan := bufio.NewScanner(r)
+	scan.Split(parser.ScanZeroSeparated)
+	for scan.Scan() {
+		line := scan.Text()
+
+		columns := regexpLsTreeLongColumns.FindStringSubmatch(line)
+		if columns == nil {
+			return nil, fmt.Errorf("unrecognized format of git tree listing: %q", line)
+		}
+
+		if columns[2] != "blob" {
+			continue
+		}
+
+		size, err := strconv.ParseInt(columns[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse blob size %q: %w", columns[4], err)
+		}
+
+		files = append(files, types.TreeFile{
+			Path: columns[5],
+			SHA:  columns[3],
+			Size: size,
+		})
+	}
+	if err := scan.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git tree listing: %w", err)
+	}
+
+	return files, nil
+}
+
 func (a Adapter) ReadTree(
 	ctx context.Context,
 	repoPath string,
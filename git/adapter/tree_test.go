@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestParseLsTreeLong(t *testing.T) {
+	output := strings.Join([]string{
+		"100644 blob aaa     120\tREADME.md",
+		"160000 commit bbb       -\tvendor/lib",
+		"100755 blob ccc 7\tdir/with\ttab.sh",
+		"",
+	}, "\x00")
+
+	files, err := parseLsTreeLong(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []types.TreeFile{
+		{Path: "README.md", SHA: "aaa", Size: 120},
+		{Path: "dir/with\ttab.sh", SHA: "ccc", Size: 7},
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %+v, got %+v", expected, files)
+	}
+}
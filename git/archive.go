@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+)
+
+type ArchiveParams struct {
+	ReadParams
+	// GitRef is the revision of which the tree is archived.
+	GitRef string
+	Format enum.ArchiveFormat
+	// Prefix is the name of the directory in which all files of the archive are placed (optional).
+	Prefix string
+}
+
+func (p *ArchiveParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	// git archive doesn't support separating options from revisions.
+	if p.GitRef == "" || strings.HasPrefix(p.GitRef, "-") {
+		return errors.InvalidArgument("a valid git ref needs to be provided")
+	}
+
+	if _, ok := p.Format.Sanitize(); !ok {
+		return errors.InvalidArgument("unsupported archive format '%s'", p.Format)
+	}
+
+	if strings.HasPrefix(p.Prefix, "-") || strings.Contains(p.Prefix, "..") {
+		return errors.InvalidArgument("invalid archive prefix '%s'", p.Prefix)
+	}
+
+	return nil
+}
+
+// Archive writes an archive of the tree of the git ref in the requested format to the writer.
+func (s *Service) Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	format, _ := params.Format.Sanitize()
+
+	err := s.adapter.Archive(ctx, repoPath, params.GitRef, format, params.Prefix, w)
+	if err != nil {
+		return fmt.Errorf("failed to archive repository: %w", err)
+	}
+
+	return nil
+}
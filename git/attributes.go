@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+)
+
+const (
+	// AttributeValueSet is the value of an attribute that is set, e.g. "*.go linguist-generated".
+	AttributeValueSet = "set"
+	// AttributeValueUnset is the value of an attribute that is unset, e.g. "*.bin -diff".
+	AttributeValueUnset = "unset"
+
+	attributeDiff              = "diff"
+	attributeLinguistGenerated = "linguist-generated"
+)
+
+type CheckAttributesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA) from which the .gitattributes files are read.
+	GitREF     string
+	Attributes []string
+	Paths      []string
+}
+
+func (p *CheckAttributesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type CheckAttributesOutput struct {
+	// Attributes maps paths to their attributes. Attributes that aren't specified for a path aren't included.
+	// The value is either AttributeValueSet, AttributeValueUnset or the value assigned to the attribute.
+	Attributes map[string]map[string]string
+}
+
+// CheckAttributes returns the git attributes of the paths as defined by the .gitattributes files of the git ref.
+func (s *Service) CheckAttributes(ctx context.Context, params *CheckAttributesParams) (*CheckAttributesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	attributes, err := s.adapter.CheckAttributes(ctx, repoPath, s.tmpDir, params.GitREF, params.Attributes, params.Paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check attributes: %w", err)
+	}
+
+	return &CheckAttributesOutput{
+		Attributes: attributes,
+	}, nil
+}
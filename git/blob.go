@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"io"
+
+	"github.com/harness/gitness/errors"
 )
 
 type GetBlobParams struct {
@@ -55,3 +57,92 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 		Content:     reader.Content,
 	}, nil
 }
+
+type ListNewBlobsParams struct {
+	ReadParams
+	// AlternateObjectDirs are additional object directories (e.g. the quarantine directory during a push).
+	AlternateObjectDirs []string
+	// SHAs are the commit shas from which the new blobs are listed.
+	SHAs []string
+	// Limit is the maximum number of blobs returned.
+	Limit int
+}
+
+type ListNewBlobsOutput struct {
+	Blobs []NewBlob
+}
+
+type NewBlob struct {
+	SHA  string
+	Path string
+	Size int64
+}
+
+// ListNewBlobs lists the blobs reachable from the provided commits that aren't reachable from any existing reference
+// (e.g. the blobs introduced by a push).
+func (s *Service) ListNewBlobs(ctx context.Context, params *ListNewBlobsParams) (*ListNewBlobsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	for _, sha := range params.SHAs {
+		if !isValidGitSHA(sha) {
+			return nil, errors.InvalidArgument("the provided commit sha '%s' is of invalid format.", sha)
+		}
+	}
+	if params.Limit <= 0 {
+		return nil, errors.InvalidArgument("limit has to be positive")
+	}
+	if err := s.validateAlternateObjectDirs(params.AlternateObjectDirs); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	result, err := s.adapter.ListNewBlobs(ctx, repoPath, params.AlternateObjectDirs, params.SHAs, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([]NewBlob, len(result))
+	for i, blob := range result {
+		blobs[i] = NewBlob{
+			SHA:  blob.SHA,
+			Path: blob.Path,
+			Size: blob.Size,
+		}
+	}
+
+	return &ListNewBlobsOutput{
+		Blobs: blobs,
+	}, nil
+}
+
+type ReadBlobParams struct {
+	ReadParams
+	// AlternateObjectDirs are additional object directories (e.g. the quarantine directory during a push).
+	AlternateObjectDirs []string
+	SHA                 string
+}
+
+// ReadBlob writes the content of the blob to the writer.
+// Unlike GetBlob, it can read blobs that are only available in the alternate object directories.
+func (s *Service) ReadBlob(ctx context.Context, params *ReadBlobParams, w io.Writer) error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	if !isValidGitSHA(params.SHA) {
+		return errors.InvalidArgument("the provided blob sha '%s' is of invalid format.", params.SHA)
+	}
+	if err := s.validateAlternateObjectDirs(params.AlternateObjectDirs); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	return s.adapter.ReadBlob(ctx, repoPath, params.AlternateObjectDirs, params.SHA, w)
+}
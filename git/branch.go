@@ -17,11 +17,13 @@ package git
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
 	"github.com/rs/zerolog/log"
@@ -74,6 +76,19 @@ type DeleteBranchParams struct {
 	BranchName string
 }
 
+type DeleteBranchesParams struct {
+	WriteParams
+	// BranchNames are the names of the branches to delete.
+	BranchNames []string
+}
+
+type DeleteBranchesOutput struct {
+	// Deleted contains the deleted branches with the SHA they pointed to before the deletion.
+	Deleted []Branch
+	// NotFound contains the names of the branches that don't exist.
+	NotFound []string
+}
+
 type ListBranchesParams struct {
 	ReadParams
 	IncludeCommit bool
@@ -82,6 +97,9 @@ type ListBranchesParams struct {
 	Order         SortOrder
 	Page          int32
 	PageSize      int32
+	// CommittedBefore allows to only list stale branches whose latest commit is older than
+	// the provided UNIX timestamp - Optional, ignored if value is 0.
+	CommittedBefore int64
 }
 
 type ListBranchesOutput struct {
@@ -180,6 +198,61 @@ func (s *Service) DeleteBranch(ctx context.Context, params *DeleteBranchParams)
 	return nil
 }
 
+// DeleteBranches deletes all existing branches of the list in a single reference update.
+// Branches that don't exist are reported in the output, they don't fail the call.
+func (s *Service) DeleteBranches(ctx context.Context, params *DeleteBranchesParams) (*DeleteBranchesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	out := &DeleteBranchesOutput{
+		Deleted:  make([]Branch, 0, len(params.BranchNames)),
+		NotFound: make([]string, 0),
+	}
+	updates := make([]hook.ReferenceUpdate, 0, len(params.BranchNames))
+	seen := make(map[string]struct{}, len(params.BranchNames))
+
+	for _, branchName := range params.BranchNames {
+		if _, ok := seen[branchName]; ok {
+			continue
+		}
+		seen[branchName] = struct{}{}
+
+		branchRef := adapter.GetReferenceFromBranchName(branchName)
+
+		sha, err := s.adapter.GetRef(ctx, repoPath, branchRef)
+		if types.IsNotFoundError(err) {
+			out.NotFound = append(out.NotFound, branchName)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch %q: %w", branchName, err)
+		}
+
+		updates = append(updates, hook.ReferenceUpdate{
+			Ref: branchRef,
+			Old: sha,
+			New: types.NilSHA,
+		})
+		out.Deleted = append(out.Deleted, Branch{
+			Name: branchName,
+			SHA:  sha,
+		})
+	}
+
+	err := s.adapter.UpdateRefs(ctx, params.EnvVars, repoPath, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete branch references: %w", err)
+	}
+
+	return out, nil
+}
+
 func (s *Service) ListBranches(ctx context.Context, params *ListBranchesParams) (*ListBranchesOutput, error) {
 	if params == nil {
 		return nil, ErrNoParamsProvided
@@ -188,12 +261,13 @@ func (s *Service) ListBranches(ctx context.Context, params *ListBranchesParams)
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
 	gitBranches, err := s.listBranchesLoadReferenceData(ctx, repoPath, types.BranchFilter{
-		IncludeCommit: params.IncludeCommit,
-		Query:         params.Query,
-		Sort:          mapBranchesSortOption(params.Sort),
-		Order:         mapToSortOrder(params.Order),
-		Page:          params.Page,
-		PageSize:      params.PageSize,
+		IncludeCommit:   params.IncludeCommit,
+		Query:           params.Query,
+		Sort:            mapBranchesSortOption(params.Sort),
+		Order:           mapToSortOrder(params.Order),
+		Page:            params.Page,
+		PageSize:        params.PageSize,
+		CommittedBefore: params.CommittedBefore,
 	})
 	if err != nil {
 		return nil, err
@@ -239,8 +313,17 @@ func (s *Service) listBranchesLoadReferenceData(
 	// TODO: can we be smarter with slice allocation
 	branches := make([]*types.Branch, 0, 16)
 	handler := listBranchesWalkReferencesHandler(&branches)
+
+	// branches only have one target type, default instructor is enough
+	innerInstructor := adapter.DefaultInstructor
+	fields := listBranchesRefFields
+	if filter.CommittedBefore > 0 {
+		innerInstructor = committedBeforeInstructor(filter.CommittedBefore)
+		fields = append([]types.GitReferenceField{types.GitReferenceFieldCommitterDateUnix}, fields...)
+	}
+
 	instructor, endsAfter, err := wrapInstructorWithOptionalPagination(
-		adapter.DefaultInstructor, // branches only have one target type, default instructor is enough
+		innerInstructor,
 		filter.Page,
 		filter.PageSize,
 	)
@@ -248,13 +331,17 @@ func (s *Service) listBranchesLoadReferenceData(
 		return nil, errors.InvalidArgument("invalid pagination details: %v", err)
 	}
 
+	// restrict git to only return as many elements as pagination needs, unless the output is post-filtered.
+	if filter.CommittedBefore > 0 {
+		endsAfter = 0
+	}
+
 	opts := &types.WalkReferencesOptions{
-		Patterns:   createReferenceWalkPatternsFromQuery(gitReferenceNamePrefixBranch, filter.Query),
-		Sort:       filter.Sort,
-		Order:      filter.Order,
-		Fields:     listBranchesRefFields,
-		Instructor: instructor,
-		// we don't do any post-filtering, restrict git to only return as many elements as pagination needs.
+		Patterns:        createReferenceWalkPatternsFromQuery(gitReferenceNamePrefixBranch, filter.Query),
+		Sort:            filter.Sort,
+		Order:           filter.Order,
+		Fields:          fields,
+		Instructor:      instructor,
 		MaxWalkDistance: endsAfter,
 	}
 
@@ -268,6 +355,27 @@ func (s *Service) listBranchesLoadReferenceData(
 	return branches, nil
 }
 
+// committedBeforeInstructor skips all references whose commit has been committed at or after the unix timestamp.
+func committedBeforeInstructor(committedBefore int64) types.WalkReferencesInstructor {
+	return func(e types.WalkReferencesEntry) (types.WalkInstruction, error) {
+		rawDate, ok := e[types.GitReferenceFieldCommitterDateUnix]
+		if !ok {
+			return types.WalkInstructionStop, fmt.Errorf("entry missing committer date")
+		}
+
+		committerDate, err := strconv.ParseInt(rawDate, 10, 64)
+		if err != nil {
+			return types.WalkInstructionStop, fmt.Errorf("failed to parse committer date %q: %w", rawDate, err)
+		}
+
+		if committerDate >= committedBefore {
+			return types.WalkInstructionSkip, nil
+		}
+
+		return types.WalkInstructionHandle, nil
+	}
+}
+
 func listBranchesWalkReferencesHandler(
 	branches *[]*types.Branch,
 ) types.WalkReferencesHandler {
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateBundleParams struct {
+	ReadParams
+	// Writer receives the bundle data.
+	Writer io.Writer
+}
+
+func (p *CreateBundleParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Writer == nil {
+		return errors.InvalidArgument("writer cannot be nil")
+	}
+
+	return nil
+}
+
+type CreateBundleOutput struct {
+	// Empty is true if the repository doesn't contain any refs, in which case no bundle is written.
+	Empty bool
+}
+
+// CreateBundle writes a git bundle containing all refs of the repository.
+func (s *Service) CreateBundle(ctx context.Context, params *CreateBundleParams) (*CreateBundleOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	repo, err := s.adapter.OpenRepository(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("CreateBundle: failed to open repo: %w", err)
+	}
+	defer repo.Close()
+
+	empty, err := repo.IsEmpty()
+	if err != nil {
+		return nil, errors.Internal(err, "failed to check if repository is empty")
+	}
+	if empty {
+		return &CreateBundleOutput{Empty: true}, nil
+	}
+
+	if err = s.adapter.CreateBundle(ctx, repoPath, params.Writer); err != nil {
+		return nil, fmt.Errorf("CreateBundle: %w", err)
+	}
+
+	return &CreateBundleOutput{}, nil
+}
+
+type CreateRepositoryFromBundleParams struct {
+	RepoUID       string
+	DefaultBranch string
+	// Bundle provides the bundle data, nil creates an empty repository.
+	Bundle io.Reader
+}
+
+func (p *CreateRepositoryFromBundleParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.RepoUID == "" {
+		return errors.InvalidArgument("repository id cannot be empty")
+	}
+
+	if p.DefaultBranch == "" {
+		return errors.InvalidArgument("default branch cannot be empty")
+	}
+
+	return nil
+}
+
+// CreateRepositoryFromBundle creates a new repository with all refs of the provided git bundle.
+func (s *Service) CreateRepositoryFromBundle(
+	ctx context.Context,
+	params *CreateRepositoryFromBundleParams,
+) (err error) {
+	if err = params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	if _, errStat := os.Stat(repoPath); !os.IsNotExist(errStat) {
+		return errors.Conflict("repository already exists at path %q", repoPath)
+	}
+
+	if err = s.adapter.InitRepository(ctx, repoPath, true); err != nil {
+		return fmt.Errorf("CreateRepositoryFromBundle: failed to initialize the repository: %w", err)
+	}
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			if errCleanup := s.DeleteRepositoryBestEffort(ctx, params.RepoUID); errCleanup != nil {
+				log.Ctx(ctx).Warn().Err(errCleanup).Msg("failed to cleanup repo dir")
+			}
+		}
+	}()
+
+	if params.Bundle != nil {
+		if err = s.fetchBundle(ctx, repoPath, params.Bundle); err != nil {
+			return fmt.Errorf("CreateRepositoryFromBundle: %w", err)
+		}
+	}
+
+	err = s.adapter.SetDefaultBranch(ctx, repoPath, params.DefaultBranch, true)
+	if err != nil {
+		return fmt.Errorf("CreateRepositoryFromBundle: failed to set default branch: %w", err)
+	}
+
+	// IMPORTANT: Setup hooks after the refs are fetched to avoid issues with externally dependent services.
+	return s.setupServerHooks(repoPath)
+}
+
+// fetchBundle stores the bundle in a temporary file and fetches all of its refs into the repository.
+func (s *Service) fetchBundle(ctx context.Context, repoPath string, bundle io.Reader) error {
+	tempDir, err := os.MkdirTemp(s.tmpDir, "bundle-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		if errRm := os.RemoveAll(tempDir); errRm != nil {
+			log.Ctx(ctx).Warn().Err(errRm).Msg("failed to cleanup temporary dir")
+		}
+	}()
+
+	bundlePath := filepath.Join(tempDir, "repo.bundle")
+
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+
+	_, err = io.Copy(file, bundle)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write bundle file: %w", err)
+	}
+
+	return s.adapter.FetchBundle(ctx, repoPath, bundlePath)
+}
@@ -52,7 +52,21 @@ var descriptions = map[string]builder{
 		flags: NoRefUpdates | NoEndOfOptions,
 	},
 	"bundle": {
-		flags: NoRefUpdates,
+		// We cannot use --end-of-options here because the rev-list arguments like `--all`
+		// follow the bundle file and count as options.
+		flags: NoRefUpdates | NoEndOfOptions,
+		validatePositionalArgs: func(args []string) error {
+			for _, arg := range args {
+				// `-` writes the bundle to stdout, `--all` includes all refs.
+				if arg == "-" || arg == "--all" {
+					continue
+				}
+				if err := validatePositionalArg(arg); err != nil {
+					return fmt.Errorf("bundle: %w", err)
+				}
+			}
+			return nil
+		},
 	},
 	"cat-file": {
 		flags: NoRefUpdates,
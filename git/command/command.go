@@ -22,6 +22,7 @@ import (
 	"io"
 	"os/exec"
 	"regexp"
+	"time"
 )
 
 var (
@@ -117,6 +118,10 @@ func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) (err error) {
 		return err
 	}
 
+	defer func(start time.Time) {
+		observeDuration(c.Name, start, err)
+	}(time.Now())
+
 	result := make(chan error)
 	go func() {
 		result <- cmd.Wait()
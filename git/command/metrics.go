@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+var commandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "gitness",
+	Subsystem: "git",
+	Name:      "command_duration_seconds",
+	Help:      "Duration of the executed git commands in seconds, partitioned by command and result.",
+	Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300},
+}, []string{"command", "result"})
+
+func init() {
+	var errRegistered prometheus.AlreadyRegisteredError
+	if err := prometheus.Register(commandDuration); err != nil && !errors.As(err, &errRegistered) {
+		log.Warn().Err(err).Msg("failed to register git command metrics")
+	}
+}
+
+// observeDuration records the duration of the git command.
+func observeDuration(name string, start time.Time, err error) {
+	result := "success"
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		result = "canceled"
+	case err != nil:
+		result = "failure"
+	}
+
+	commandDuration.WithLabelValues(name, result).Observe(time.Since(start).Seconds())
+}
@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/errors"
@@ -109,6 +111,16 @@ type ListCommitsParams struct {
 	// Committer allows to filter for commits based on the committer - Optional, ignored if string is empty.
 	Committer string
 
+	// Author allows to filter for commits based on the author - Optional, ignored if string is empty.
+	Author string
+
+	// Follow allows to continue listing the commits of Path beyond renames - Optional, ignored if Path is empty.
+	Follow bool
+
+	// Cursor is the SHA of the last commit of the previous page - Optional.
+	// If provided, the commits following that commit are returned and Page is ignored.
+	Cursor string
+
 	// IncludeStats allows to include information about inserted, deletions and status for changed files.
 	IncludeStats bool
 }
@@ -154,6 +166,9 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 			Since:     params.Since,
 			Until:     params.Until,
 			Committer: params.Committer,
+			Author:    params.Author,
+			Follow:    params.Follow,
+			Cursor:    params.Cursor,
 		},
 	)
 	if err != nil {
@@ -162,7 +177,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 
 	// try to get total commits between gitref and After refs
 	totalCommits := 0
-	if params.Page == 1 && len(gitCommits) < int(params.Limit) {
+	if params.Cursor == "" && params.Page == 1 && len(gitCommits) < int(params.Limit) {
 		totalCommits = len(gitCommits)
 	} else if params.After != "" && params.GitREF != params.After {
 		div, err := s.adapter.GetCommitDivergences(ctx, repoPath, []types.CommitDivergenceRequest{
@@ -193,6 +208,76 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListNewCommitsParams struct {
+	ReadParams
+	// AlternateObjectDirs are additional object directories (e.g. the quarantine directory during a push).
+	AlternateObjectDirs []string
+	// SHA is the commit sha from which the new commits are listed.
+	SHA string
+	// Limit is the maximum number of commits returned.
+	Limit int
+}
+
+type ListNewCommitsOutput struct {
+	Commits []Commit
+}
+
+// validateAlternateObjectDirs ensures that only object dirs of repositories are used
+// (e.g. the quarantine dir of the repo).
+func (s *Service) validateAlternateObjectDirs(dirs []string) error {
+	reposRoot, err := filepath.Abs(s.reposRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of repos root: %w", err)
+	}
+	for _, dir := range dirs {
+		if !strings.HasPrefix(filepath.Clean(dir), reposRoot+string(filepath.Separator)) {
+			return errors.InvalidArgument("alternate object dir '%s' is outside of the repositories root", dir)
+		}
+	}
+
+	return nil
+}
+
+// ListNewCommits lists the commits reachable from the provided sha that aren't reachable from any existing reference
+// (e.g. the commits introduced by a push).
+func (s *Service) ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if !isValidGitSHA(params.SHA) {
+		return nil, errors.InvalidArgument("the provided commit sha '%s' is of invalid format.", params.SHA)
+	}
+	if params.Limit <= 0 {
+		return nil, errors.InvalidArgument("limit has to be positive")
+	}
+
+	if err := s.validateAlternateObjectDirs(params.AlternateObjectDirs); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	result, err := s.adapter.ListNewCommits(ctx, repoPath, params.AlternateObjectDirs, params.SHA, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]Commit, len(result))
+	for i := range result {
+		commit, err := mapCommit(&result[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	return &ListNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
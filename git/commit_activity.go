@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+)
+
+type ListCommitActivityParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA) whose history is walked.
+	GitREF string
+	// Since allows to skip commits authored before the provided time - Optional, ignored if zero.
+	Since time.Time
+}
+
+func (p *ListCommitActivityParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type ListCommitActivityOutput struct {
+	Activities []types.CommitActivity
+}
+
+// ListCommitActivity returns the author and the line statistics of all non-merge commits reachable from the ref.
+func (s *Service) ListCommitActivity(
+	ctx context.Context,
+	params *ListCommitActivityParams,
+) (*ListCommitActivityOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	activities, err := s.adapter.ListCommitActivity(ctx, repoPath, params.GitREF, params.Since)
+	if err != nil {
+		return nil, fmt.Errorf("ListCommitActivity: %w", err)
+	}
+
+	return &ListCommitActivityOutput{
+		Activities: activities,
+	}, nil
+}
@@ -16,9 +16,11 @@ package git
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 
 	"github.com/harness/gitness/errors"
@@ -26,6 +28,7 @@ import (
 	"github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/types"
 
+	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -35,6 +38,13 @@ type DiffParams struct {
 	HeadRef      string
 	MergeBase    bool
 	IncludePatch bool
+	// ApplyAttributes makes the diff honor the .gitattributes of the head ref:
+	// files with the diff attribute unset (e.g. "-diff" or "binary") are treated as binary,
+	// and the patches of files marked as linguist-generated are collapsed unless the files are explicitly requested.
+	// The raw diff only applies the diff attribute (as git would in a checkout), generated files are always included.
+	ApplyAttributes bool
+	// Options change how the diff is computed, they are ignored by the diff stats.
+	Options types.DiffOptions
 }
 
 func (p DiffParams) Validate() error {
@@ -45,6 +55,10 @@ func (p DiffParams) Validate() error {
 	if p.HeadRef == "" {
 		return errors.InvalidArgument("head ref cannot be empty")
 	}
+
+	if err := p.Options.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -54,7 +68,39 @@ func (s *Service) RawDiff(
 	params *DiffParams,
 	files ...types.FileDiffRequest,
 ) error {
-	return s.rawDiff(ctx, out, params, files...)
+	if !params.ApplyAttributes {
+		return s.rawDiff(ctx, out, params, files...)
+	}
+
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	attributes, err := s.diffAttributes(ctx, params)
+	if err != nil {
+		// the diff is still usable without attributes, don't fail the whole request.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get git attributes for raw diff")
+	}
+
+	if !hasDiffUnset(attributes) {
+		return s.rawDiff(ctx, out, params, files...)
+	}
+
+	pr, pw := io.Pipe()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		err := s.rawDiff(gctx, pw, params, files...)
+		_ = pw.CloseWithError(err)
+		return err
+	})
+	g.Go(func() error {
+		err := writeRawDiffWithAttributes(pr, out, attributes)
+		_ = pr.CloseWithError(err)
+		return err
+	})
+
+	return g.Wait()
 }
 
 func (s *Service) rawDiff(ctx context.Context, w io.Writer, params *DiffParams, files ...types.FileDiffRequest) error {
@@ -64,7 +110,8 @@ func (s *Service) rawDiff(ctx context.Context, w io.Writer, params *DiffParams,
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
-	err := s.adapter.RawDiff(ctx, w, repoPath, params.BaseRef, params.HeadRef, params.MergeBase, files...)
+	err := s.adapter.RawDiff(ctx, w, repoPath, params.BaseRef, params.HeadRef, params.MergeBase,
+		params.Options, files...)
 	if err != nil {
 		return err
 	}
@@ -307,6 +354,10 @@ type FileDiff struct {
 	Patch       []byte              `json:"patch,omitempty"`
 	IsBinary    bool                `json:"is_binary"`
 	IsSubmodule bool                `json:"is_submodule"`
+	IsGenerated bool                `json:"is_generated"`
+	// IsCollapsed is true if the patch of the file is omitted by default, e.g. for generated files.
+	// The patch can be retrieved by explicitly requesting the file.
+	IsCollapsed bool `json:"is_collapsed"`
 }
 
 func parseFileDiffStatus(ftype diff.FileType) enum.FileDiffStatus {
@@ -358,13 +409,23 @@ func (s *Service) Diff(
 		defer wg.Done()
 		defer pr.Close()
 
+		var attributes map[string]map[string]string
+		if params.ApplyAttributes {
+			var err error
+			attributes, err = s.diffAttributes(ctx, params)
+			if err != nil {
+				// the diff is still usable without attributes, don't fail the whole request.
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to get git attributes for diff")
+			}
+		}
+
 		parser := diff.Parser{
 			Reader:       bufio.NewReader(pr),
 			IncludePatch: params.IncludePatch,
 		}
 
 		err := parser.Parse(func(f *diff.File) error {
-			ch <- &FileDiff{
+			fileDiff := &FileDiff{
 				SHA:         f.SHA,
 				OldSHA:      f.OldSHA,
 				Path:        f.Path,
@@ -377,6 +438,10 @@ func (s *Service) Diff(
 				IsBinary:    f.IsBinary,
 				IsSubmodule: f.IsSubmodule,
 			}
+
+			applyDiffAttributes(fileDiff, attributes[f.Path], len(files) > 0)
+
+			ch <- fileDiff
 			return nil
 		})
 		if err != nil {
@@ -394,6 +459,103 @@ func (s *Service) Diff(
 	return ch, cherr
 }
 
+// diffAttributes returns the diff related git attributes of all files changed between the base and the head ref.
+// The attributes are read from the .gitattributes files of the head ref.
+func (s *Service) diffAttributes(ctx context.Context, params *DiffParams) (map[string]map[string]string, error) {
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	fileNames, err := s.adapter.DiffFileName(ctx, repoPath, params.BaseRef, params.HeadRef, params.MergeBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff file names for attributes: %w", err)
+	}
+
+	if len(fileNames) == 0 {
+		return nil, nil
+	}
+
+	attributes, err := s.adapter.CheckAttributes(ctx, repoPath, s.tmpDir, params.HeadRef,
+		[]string{attributeDiff, attributeLinguistGenerated}, fileNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check diff attributes: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// applyDiffAttributes updates the file diff according to the git attributes of the file.
+// Patches of generated files are only kept if the file has been explicitly requested.
+func applyDiffAttributes(fileDiff *FileDiff, attributes map[string]string, explicitlyRequested bool) {
+	if attributes[attributeDiff] == AttributeValueUnset {
+		fileDiff.IsBinary = true
+		fileDiff.Patch = nil
+	}
+
+	if attributes[attributeLinguistGenerated] == AttributeValueSet ||
+		attributes[attributeLinguistGenerated] == "true" {
+		fileDiff.IsGenerated = true
+		if !explicitlyRequested {
+			fileDiff.IsCollapsed = true
+			fileDiff.Patch = nil
+		}
+	}
+}
+
+// hasDiffUnset returns true if any of the files has the diff attribute unset.
+func hasDiffUnset(attributes map[string]map[string]string) bool {
+	for _, fileAttributes := range attributes {
+		if fileAttributes[attributeDiff] == AttributeValueUnset {
+			return true
+		}
+	}
+	return false
+}
+
+// writeRawDiffWithAttributes copies the raw diff, but replaces the patches of files with the diff attribute unset
+// with the line git uses for binary files.
+func writeRawDiffWithAttributes(r io.Reader, w io.Writer, attributes map[string]map[string]string) error {
+	parser := diff.Parser{
+		Reader:       bufio.NewReader(r),
+		IncludePatch: true,
+	}
+
+	return parser.Parse(func(f *diff.File) error {
+		patch := f.Patch.Bytes()
+		if !f.IsBinary && attributes[f.Path][attributeDiff] == AttributeValueUnset {
+			patch = binaryPatch(patch)
+		}
+
+		if _, err := w.Write(patch); err != nil {
+			return fmt.Errorf("failed to write raw diff: %w", err)
+		}
+		return nil
+	})
+}
+
+// binaryPatch returns the extended header of the patch followed by "Binary files <old> and <new> differ".
+// The file names are taken from the "---" and "+++" lines, patches without content changes are returned as is.
+func binaryPatch(patch []byte) []byte {
+	var header bytes.Buffer
+	var oldName, newName string
+	for _, line := range bytes.SplitAfter(patch, []byte{'\n'}) {
+		switch {
+		case bytes.HasPrefix(line, []byte("--- ")):
+			oldName = strings.TrimRight(string(line[4:]), "\n")
+		case bytes.HasPrefix(line, []byte("+++ ")):
+			newName = strings.TrimRight(string(line[4:]), "\n")
+		case bytes.HasPrefix(line, []byte("@@")):
+			if oldName == "" || newName == "" {
+				return patch
+			}
+			header.WriteString("Binary files " + oldName + " and " + newName + " differ\n")
+			return header.Bytes()
+		default:
+			header.Write(line)
+		}
+	}
+
+	return patch
+}
+
 type DiffFileNamesOutput struct {
 	Files []string
 }
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/types"
+)
+
+// setupDiffAttributesService returns a service with a repository with two commits.
+// The head commit changes a regular file, a file with the diff attribute unset and a generated file,
+// as defined by the .gitattributes file of the head commit.
+func setupDiffAttributesService(t *testing.T) (*Service, string, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	gitAdapter, err := adapter.New(types.Config{}, adapter.NewInMemoryLastCommitCache(time.Minute), nil)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %v", err)
+	}
+
+	s := &Service{reposRoot: t.TempDir(), tmpDir: t.TempDir(), adapter: gitAdapter}
+	repoPath := getFullPathForRepo(s.reposRoot, testRepoUID)
+
+	if err = gitAdapter.InitRepository(ctx, repoPath, true); err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	indexFile := filepath.Join(t.TempDir(), "index")
+	run := func(stdin string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		cmd.Stdin = strings.NewReader(stdin)
+		cmd.Env = append(cmd.Environ(),
+			"GIT_INDEX_FILE="+indexFile,
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	commit := func(files map[string]string, parents ...string) string {
+		for path, content := range files {
+			blob := run(content, "hash-object", "-w", "--stdin")
+			run("", "update-index", "--add", "--cacheinfo", "100644,"+blob+","+path)
+		}
+		args := []string{"commit-tree", run("", "write-tree"), "-m", "commit"}
+		for _, parent := range parents {
+			args = append(args, "-p", parent)
+		}
+		return run("", args...)
+	}
+
+	base := commit(map[string]string{
+		"main.go":    "package main\n",
+		"data.bin":   "line 1\n",
+		"api.gen.go": "package api\n",
+	})
+	head := commit(map[string]string{
+		".gitattributes": "*.bin -diff\n*.gen.go linguist-generated\n",
+		"main.go":        "package main\n\nfunc main() {}\n",
+		"data.bin":       "line 1\nline 2\n",
+		"api.gen.go":     "package api\n\nconst Version = 1\n",
+	}, base)
+
+	return s, base, head
+}
+
+func TestService_Diff_ApplyAttributes(t *testing.T) {
+	s, base, head := setupDiffAttributesService(t)
+
+	ch, errCh := s.Diff(context.Background(), &DiffParams{
+		ReadParams:      ReadParams{RepoUID: testRepoUID},
+		BaseRef:         base,
+		HeadRef:         head,
+		IncludePatch:    true,
+		ApplyAttributes: true,
+	})
+
+	files := map[string]*FileDiff{}
+	for fileDiff := range ch {
+		files[fileDiff.Path] = fileDiff
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("failed to get diff: %v", err)
+	}
+
+	if f := files["main.go"]; f == nil || f.IsBinary || f.IsGenerated || len(f.Patch) == 0 {
+		t.Errorf("expected regular patch of main.go, got %+v", f)
+	}
+	if f := files["data.bin"]; f == nil || !f.IsBinary || f.Patch != nil {
+		t.Errorf("expected data.bin to be treated as binary, got %+v", f)
+	}
+	if f := files["api.gen.go"]; f == nil || !f.IsGenerated || !f.IsCollapsed || f.Patch != nil {
+		t.Errorf("expected api.gen.go to be collapsed as generated, got %+v", f)
+	}
+}
+
+func TestService_RawDiff_ApplyAttributes(t *testing.T) {
+	s, base, head := setupDiffAttributesService(t)
+	ctx := context.Background()
+
+	rawDiff := func(applyAttributes bool) string {
+		out := &bytes.Buffer{}
+		err := s.RawDiff(ctx, out, &DiffParams{
+			ReadParams:      ReadParams{RepoUID: testRepoUID},
+			BaseRef:         base,
+			HeadRef:         head,
+			ApplyAttributes: applyAttributes,
+		})
+		if err != nil {
+			t.Fatalf("failed to get raw diff: %v", err)
+		}
+		return out.String()
+	}
+
+	plain := rawDiff(false)
+	withAttributes := rawDiff(true)
+
+	if !strings.Contains(plain, "+line 2\n") {
+		t.Fatalf("expected plain raw diff to contain the patch of data.bin:\n%s", plain)
+	}
+
+	if strings.Contains(withAttributes, "+line 2\n") ||
+		!strings.Contains(withAttributes, "Binary files a/data.bin and b/data.bin differ\n") {
+		t.Errorf("expected data.bin to be treated as binary:\n%s", withAttributes)
+	}
+
+	// apart from data.bin, the raw diff is unchanged - the patch of the generated file is included.
+	sections := func(raw string) map[string]string {
+		res := map[string]string{}
+		for _, part := range strings.Split(raw, "diff --git ")[1:] {
+			res[strings.SplitN(part, "\n", 2)[0]] = part
+		}
+		return res
+	}
+	plainSections, attributeSections := sections(plain), sections(withAttributes)
+	if len(plainSections) != 4 || len(attributeSections) != 4 {
+		t.Fatalf("expected 4 files in both diffs, got %d and %d", len(plainSections), len(attributeSections))
+	}
+	for name, section := range plainSections {
+		if name == "a/data.bin b/data.bin" {
+			continue
+		}
+		if attributeSections[name] != section {
+			t.Errorf("expected section %q to be unchanged, got:\n%s\nwant:\n%s", name, attributeSections[name], section)
+		}
+	}
+}
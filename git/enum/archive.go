@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "strings"
+
+// ArchiveFormat represents the file format of a repository archive.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatTar   ArchiveFormat = "tar"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+	ArchiveFormatZip   ArchiveFormat = "zip"
+)
+
+var ArchiveFormats = []ArchiveFormat{
+	ArchiveFormatTar,
+	ArchiveFormatTarGz,
+	ArchiveFormatZip,
+}
+
+func (f ArchiveFormat) Sanitize() (ArchiveFormat, bool) {
+	switch f {
+	case ArchiveFormatTar, ArchiveFormatTarGz, ArchiveFormatZip:
+		return f, true
+	case "tgz":
+		return ArchiveFormatTarGz, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType returns the media type of archives of the format.
+func (f ArchiveFormat) ContentType() string {
+	switch f {
+	case ArchiveFormatTar:
+		return "application/x-tar"
+	case ArchiveFormatTarGz:
+		return "application/gzip"
+	case ArchiveFormatZip:
+		return "application/zip"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// ParseArchiveName splits an archive file name (e.g. "main.tar.gz") into the git ref and the archive format.
+func ParseArchiveName(name string) (string, ArchiveFormat, bool) {
+	for _, f := range []ArchiveFormat{ArchiveFormatTarGz, "tgz", ArchiveFormatTar, ArchiveFormatZip} {
+		ref, ok := strings.CutSuffix(name, "."+string(f))
+		if !ok || ref == "" {
+			continue
+		}
+
+		format, _ := f.Sanitize()
+		return ref, format, true
+	}
+
+	return "", "", false
+}
@@ -27,3 +27,25 @@ const (
 	FileDiffStatusRenamed   FileDiffStatus = "RENAMED"
 	FileDiffStatusCopied    FileDiffStatus = "COPIED"
 )
+
+// DiffAlgorithm is the algorithm git uses to compute a diff.
+type DiffAlgorithm string
+
+const (
+	// DiffAlgorithmDefault uses the default algorithm of git (myers).
+	DiffAlgorithmDefault   DiffAlgorithm = ""
+	DiffAlgorithmMyers     DiffAlgorithm = "myers"
+	DiffAlgorithmMinimal   DiffAlgorithm = "minimal"
+	DiffAlgorithmPatience  DiffAlgorithm = "patience"
+	DiffAlgorithmHistogram DiffAlgorithm = "histogram"
+)
+
+// IsValid returns true if the diff algorithm is supported.
+func (a DiffAlgorithm) IsValid() bool {
+	switch a {
+	case DiffAlgorithmDefault, DiffAlgorithmMyers, DiffAlgorithmMinimal,
+		DiffAlgorithmPatience, DiffAlgorithmHistogram:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+// forkRefSpecs are the references copied from the source repository when forking.
+var forkRefSpecs = []string{
+	"+refs/heads/*:refs/heads/*",
+	"+refs/tags/*:refs/tags/*",
+}
+
+type ForkRepositoryParams struct {
+	// SourceRepoUID is the uid of the repository that is forked.
+	SourceRepoUID string
+	// RepoUID is the uid of the fork (optional, generated if not provided).
+	RepoUID string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository id cannot be empty")
+	}
+
+	return nil
+}
+
+type ForkRepositoryOutput struct {
+	UID           string
+	DefaultBranch string
+}
+
+// ForkRepository creates a new repository with all branches and tags of the source repository.
+// The fork borrows the objects of the source repository (via git alternates) instead of copying them,
+// only objects created in the fork are stored in the fork itself.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (_ *ForkRepositoryOutput, err error) {
+	if err = params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.RepoUID == "" {
+		if params.RepoUID, err = NewRepositoryUID(); err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+	}
+
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+	if _, errStat := os.Stat(sourcePath); os.IsNotExist(errStat) {
+		return nil, errors.NotFound("source repository not found")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	if _, errStat := os.Stat(repoPath); !os.IsNotExist(errStat) {
+		return nil, errors.Conflict("repository already exists at path %q", repoPath)
+	}
+
+	if err = s.adapter.InitRepository(ctx, repoPath, true); err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to initialize the repository: %w", err)
+	}
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			if errCleanup := s.DeleteRepositoryBestEffort(ctx, params.RepoUID); errCleanup != nil {
+				log.Ctx(ctx).Warn().Err(errCleanup).Msg("failed to cleanup repo dir")
+			}
+		}
+	}()
+
+	// use a relative path to keep the fork working in case the repositories root is moved.
+	alternate, err := filepath.Rel(filepath.Join(repoPath, "objects"), filepath.Join(sourcePath, "objects"))
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to get relative path of source objects: %w", err)
+	}
+
+	if err = s.adapter.SetAlternates(ctx, repoPath, []string{alternate}); err != nil {
+		return nil, fmt.Errorf("ForkRepository: %w", err)
+	}
+
+	// all objects are available via the alternates already, hence the fetch only copies the references.
+	if err = s.adapter.Sync(ctx, repoPath, sourcePath, forkRefSpecs); err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to fetch references: %w", err)
+	}
+
+	defaultBranch, err := s.adapter.GetDefaultBranch(ctx, sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to get default branch of source repository: %w", err)
+	}
+
+	if err = s.adapter.SetDefaultBranch(ctx, repoPath, defaultBranch, true); err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to set default branch: %w", err)
+	}
+
+	// IMPORTANT: Setup hooks after the refs are fetched to avoid issues with externally dependent services.
+	if err = s.setupServerHooks(repoPath); err != nil {
+		return nil, err
+	}
+
+	return &ForkRepositoryOutput{
+		UID:           params.RepoUID,
+		DefaultBranch: defaultBranch,
+	}, nil
+}
+
+type DetachRepositoryObjectsParams struct {
+	ReadParams
+}
+
+func (p *DetachRepositoryObjectsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+// DetachRepositoryObjects copies all objects a fork borrows from its source repository into the fork,
+// afterwards the fork no longer depends on the source repository (e.g. before the source is deleted).
+// It's a no-op for repositories that don't borrow objects.
+func (s *Service) DetachRepositoryObjects(ctx context.Context, params *DetachRepositoryObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	if _, err := os.Stat(repoPath); os.IsNotExist(err) {
+		return errors.NotFound("repository not found")
+	}
+
+	if err := s.adapter.DetachAlternates(ctx, repoPath); err != nil {
+		return fmt.Errorf("DetachRepositoryObjects: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// CheckHealth verifies that the git executable can be run and that the repositories root is a directory.
+func (s *Service) CheckHealth(ctx context.Context) error {
+	info, err := os.Stat(s.reposRoot)
+	if err != nil {
+		return fmt.Errorf("failed to stat repositories root: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("repositories root '%s' is not a directory", s.reposRoot)
+	}
+
+	if err = command.New("version").Run(ctx); err != nil {
+		return fmt.Errorf("failed to run git: %w", err)
+	}
+
+	return nil
+}
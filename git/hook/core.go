@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // CLICore implements the core of a githook cli. It uses the client and execution timeout
@@ -48,7 +51,9 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 	}
 
 	in := PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		PushOptions: getPushOptions(),
+		Environment: getEnvironment(),
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -79,7 +84,8 @@ func (c *CLICore) PostReceive(ctx context.Context) error {
 	}
 
 	in := PostReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		PushOptions: getPushOptions(),
 	}
 
 	out, err := c.client.PostReceive(ctx, in)
@@ -94,13 +100,16 @@ func handleServerHookOutput(out Output, err error) error {
 	}
 
 	// print messages before any error
+	// NOTE: git forwards the stderr of hooks to the client (prefixed with "remote:").
 	if len(out.Messages) > 0 {
 		// add empty line before and after to make it easier readable
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 		for _, msg := range out.Messages {
-			fmt.Println(msg)
+			for _, line := range strings.Split(msg, "\n") {
+				fmt.Fprintln(os.Stderr, sanitizeMessageLine(line))
+			}
 		}
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 	}
 
 	if out.Error != nil {
@@ -110,6 +119,59 @@ func handleServerHookOutput(out Output, err error) error {
 	return nil
 }
 
+// sanitizeMessageLine removes control characters from a message line, as messages can contain user provided
+// content (like pull request titles) that otherwise could inject terminal escape sequences into the client's output.
+func sanitizeMessageLine(line string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.TrimRight(line, "\r"))
+}
+
+// getPushOptions returns the push options provided by the client.
+// For more details see https://git-scm.com/docs/githooks#pre-receive
+func getPushOptions() []string {
+	count, err := strconv.Atoi(os.Getenv("GIT_PUSH_OPTION_COUNT"))
+	if err != nil || count <= 0 {
+		return nil
+	}
+
+	pushOptions := make([]string, count)
+	for i := 0; i < count; i++ {
+		pushOptions[i] = os.Getenv(fmt.Sprintf("GIT_PUSH_OPTION_%d", i))
+	}
+
+	return pushOptions
+}
+
+// getEnvironment returns the environment the githook is executed in.
+// During a push, git stores the received objects in a quarantine directory that's only accessible to
+// git processes with the object directories provided by git (see https://git-scm.com/docs/git-receive-pack).
+func getEnvironment() Environment {
+	var dirs []string
+	if dir := os.Getenv("GIT_OBJECT_DIRECTORY"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if alternates := os.Getenv("GIT_ALTERNATE_OBJECT_DIRECTORIES"); alternates != "" {
+		dirs = append(dirs, filepath.SplitList(alternates)...)
+	}
+
+	for i, dir := range dirs {
+		if absDir, err := filepath.Abs(dir); err == nil {
+			dirs[i] = absDir
+		}
+	}
+
+	return Environment{
+		AlternateObjectDirs: dirs,
+	}
+}
+
 // getUpdatedReferencesFromStdIn reads the updated references provided by git from stdin.
 // The expected format is "<old-value> SP <new-value> SP <ref-name> LF"
 // For more details see https://git-scm.com/docs/githooks#pre-receive
@@ -14,6 +14,8 @@
 
 package hook
 
+import "strings"
+
 // Output represents the output of server hook api calls.
 type Output struct {
 	// Messages contains standard user facing messages.
@@ -37,12 +39,42 @@ type ReferenceUpdate struct {
 type PostReceiveInput struct {
 	// RefUpdates contains all references that got updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// PushOptions contains the push options provided by the client (git push --push-option).
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 // PreReceiveInput represents the input of the pre-receive git hook.
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// PushOptions contains the push options provided by the client (git push --push-option).
+	PushOptions []string `json:"push_options,omitempty"`
+
+	// Environment contains information about the environment the hook is executed in.
+	Environment Environment `json:"environment"`
+}
+
+// Environment contains information about the environment a git hook is executed in.
+type Environment struct {
+	// AlternateObjectDirs contains the object directories required to access all objects of the operation
+	// (e.g. the quarantine directory containing the objects received during a push).
+	AlternateObjectDirs []string `json:"alternate_object_dirs,omitempty"`
+}
+
+// PushOptionSkipCI is the push option used to skip pipelines triggered by the push (git push -o skip-ci).
+const PushOptionSkipCI = "skip-ci"
+
+// HasPushOption returns true if the push option is part of the provided push options.
+// Push options of the form "key=value" are matched by their key.
+func HasPushOption(pushOptions []string, option string) bool {
+	for _, pushOption := range pushOptions {
+		if key, _, _ := strings.Cut(pushOption, "="); key == option {
+			return true
+		}
+	}
+	return false
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -24,18 +24,30 @@ import (
 type Interface interface {
 	CreateRepository(ctx context.Context, params *CreateRepositoryParams) (*CreateRepositoryOutput, error)
 	DeleteRepository(ctx context.Context, params *DeleteRepositoryParams) error
+	// ForkRepository creates a new repository that borrows the objects of the source repository.
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	// DetachRepositoryObjects copies all borrowed objects into the repository.
+	DetachRepositoryObjects(ctx context.Context, params *DetachRepositoryObjectsParams) error
 	GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*GetTreeNodeOutput, error)
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
+	// ListTreeFiles returns all files of the tree of a git ref, including the files of all subdirectories.
+	ListTreeFiles(ctx context.Context, params *ListTreeFilesParams) (*ListTreeFilesOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
+	ListNewBlobs(ctx context.Context, params *ListNewBlobsParams) (*ListNewBlobsOutput, error)
+	ReadBlob(ctx context.Context, params *ReadBlobParams, w io.Writer) error
+	// Archive writes an archive (tar, tar.gz or zip) of the tree of a git ref to the writer.
+	Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
 	CreateCommitTag(ctx context.Context, params *CreateCommitTagParams) (*CreateCommitTagOutput, error)
 	DeleteTag(ctx context.Context, params *DeleteTagParams) error
 	GetBranch(ctx context.Context, params *GetBranchParams) (*GetBranchOutput, error)
 	DeleteBranch(ctx context.Context, params *DeleteBranchParams) error
+	DeleteBranches(ctx context.Context, params *DeleteBranchesParams) (*DeleteBranchesOutput, error)
 	ListBranches(ctx context.Context, params *ListBranchesParams) (*ListBranchesOutput, error)
 	UpdateDefaultBranch(ctx context.Context, params *UpdateDefaultBranchParams) error
 	GetRef(ctx context.Context, params GetRefParams) (GetRefResponse, error)
+	FindRefConflicts(ctx context.Context, params *FindRefConflictsParams) (*FindRefConflictsOutput, error)
 	PathsDetails(ctx context.Context, params PathsDetailsParams) (PathsDetailsOutput, error)
 
 	GetRepositorySize(ctx context.Context, params *GetRepositorySizeParams) (*GetRepositorySizeOutput, error)
@@ -46,18 +58,36 @@ type Interface interface {
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	// CreateBundle writes a git bundle containing all refs of the repository.
+	CreateBundle(ctx context.Context, params *CreateBundleParams) (*CreateBundleOutput, error)
+	// CreateRepositoryFromBundle creates a new repository with all refs of the provided git bundle.
+	CreateRepositoryFromBundle(ctx context.Context, params *CreateRepositoryFromBundleParams) error
+
+	// GarbageCollect packs the objects of the repository and prunes all unreachable objects (unless kept).
+	GarbageCollect(ctx context.Context, params *GarbageCollectParams) error
+	// Fsck verifies the connectivity and validity of all objects of the repository.
+	Fsck(ctx context.Context, params *FsckParams) (*FsckOutput, error)
+	// GetRepositoryDiskUsage returns the object statistics and the disk size of the repository.
+	GetRepositoryDiskUsage(ctx context.Context,
+		params *GetRepositoryDiskUsageParams) (*GetRepositoryDiskUsageOutput, error)
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
+	// CheckAttributes returns the git attributes of paths as defined by the .gitattributes files of a git ref.
+	CheckAttributes(ctx context.Context, params *CheckAttributesParams) (*CheckAttributesOutput, error)
 
 	/*
 	 * Commits service
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
 	IsAncestor(ctx context.Context, params IsAncestorParams) (IsAncestorOutput, error)
+	// ListCommitActivity returns the author and the line statistics of all non-merge commits reachable from a ref.
+	ListCommitActivity(ctx context.Context, params *ListCommitActivityParams) (*ListCommitActivityOutput, error)
 
 	/*
 	 * Git Cli Service
@@ -90,4 +120,10 @@ type Interface interface {
 	PushRemote(ctx context.Context, params *PushRemoteParams) error
 
 	GeneratePipeline(ctx context.Context, params *GeneratePipelineParams) (GeneratePipelinesOutput, error)
+
+	/*
+	 * Health services
+	 */
+	// CheckHealth verifies that git can be executed and the repositories are accessible.
+	CheckHealth(ctx context.Context) error
 }
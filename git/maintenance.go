@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/harness/gitness/git/types"
+)
+
+type GarbageCollectParams struct {
+	ReadParams
+	// Aggressive optimizes the repository more aggressively, at the expense of taking much more time.
+	Aggressive bool
+	// KeepUnreachable keeps unreachable objects, required for repositories with forks borrowing their objects.
+	KeepUnreachable bool
+}
+
+func (p *GarbageCollectParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+// GarbageCollect packs the objects of the repository and prunes all unreachable objects (unless kept).
+func (s *Service) GarbageCollect(ctx context.Context, params *GarbageCollectParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	if err := s.adapter.GC(ctx, repoPath, params.Aggressive, params.KeepUnreachable); err != nil {
+		return fmt.Errorf("GarbageCollect: %w", err)
+	}
+
+	return nil
+}
+
+type FsckParams struct {
+	ReadParams
+}
+
+func (p *FsckParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type FsckOutput struct {
+	// Healthy is false if git found problems with the repository.
+	Healthy bool
+	// Messages contains the errors and warnings reported by git.
+	Messages []string
+}
+
+// Fsck verifies the connectivity and validity of all objects of the repository.
+func (s *Service) Fsck(ctx context.Context, params *FsckParams) (*FsckOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	healthy, messages, err := s.adapter.Fsck(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("Fsck: %w", err)
+	}
+
+	return &FsckOutput{
+		Healthy:  healthy,
+		Messages: messages,
+	}, nil
+}
+
+type GetRepositoryDiskUsageParams struct {
+	ReadParams
+}
+
+func (p *GetRepositoryDiskUsageParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type GetRepositoryDiskUsageOutput struct {
+	Objects types.ObjectCount
+	// DiskSize is the size of all files of the repository on disk in bytes.
+	DiskSize int64
+}
+
+// GetRepositoryDiskUsage returns the object statistics and the disk size of the repository.
+func (s *Service) GetRepositoryDiskUsage(
+	ctx context.Context,
+	params *GetRepositoryDiskUsageParams,
+) (*GetRepositoryDiskUsageOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	count, err := s.adapter.CountObjects(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count objects for repo: %w", err)
+	}
+
+	var diskSize int64
+	err = filepath.WalkDir(repoPath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		diskSize += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate disk size of repo: %w", err)
+	}
+
+	return &GetRepositoryDiskUsageOutput{
+		Objects:  count,
+		DiskSize: diskSize,
+	}, nil
+}
@@ -279,3 +279,101 @@ func sanitizeReferenceQuery(query string) (string, bool, bool) {
 		matchPrefix,
 		matchSuffix
 }
+
+type FindRefConflictsParams struct {
+	ReadParams
+	// RefNames are the full names of the references that are about to be created.
+	RefNames []string
+	// DeletedRefNames are the full names of existing references that are deleted together with the creation
+	// of the references (e.g. in the same push), they don't conflict with the created references.
+	DeletedRefNames []string
+}
+
+type FindRefConflictsOutput struct {
+	// Conflicts maps the reference names to the first existing reference they are conflicting with.
+	Conflicts map[string]string
+}
+
+// FindRefConflicts finds existing references that conflict with the provided references.
+// Git stores references as files, so a reference can't be created if it's a parent path of an existing reference
+// (e.g. "refs/heads/a" and "refs/heads/a/b") or if an existing reference is a parent path of it.
+func (s *Service) FindRefConflicts(
+	ctx context.Context,
+	params *FindRefConflictsParams,
+) (*FindRefConflictsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	out := &FindRefConflictsOutput{Conflicts: map[string]string{}}
+	if len(params.RefNames) == 0 {
+		return out, nil
+	}
+
+	// a pattern matches the reference itself and all references below it (matching up to a slash).
+	patterns := make([]string, 0, len(params.RefNames))
+	for _, refName := range params.RefNames {
+		patterns = append(patterns, refName)
+		for _, parent := range refParentPaths(refName) {
+			patterns = append(patterns, parent)
+		}
+	}
+
+	deleted := make(map[string]struct{}, len(params.DeletedRefNames))
+	for _, refName := range params.DeletedRefNames {
+		deleted[refName] = struct{}{}
+	}
+
+	handler := func(e types.WalkReferencesEntry) error {
+		existing := e[types.GitReferenceFieldRefName]
+		if _, ok := deleted[existing]; ok {
+			return nil
+		}
+
+		for _, refName := range params.RefNames {
+			if _, ok := out.Conflicts[refName]; ok {
+				continue
+			}
+			if IsRefConflict(refName, existing) {
+				out.Conflicts[refName] = existing
+			}
+		}
+		return nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	err := s.adapter.WalkReferences(ctx, repoPath, handler, &types.WalkReferencesOptions{
+		Patterns: patterns,
+		Fields:   []types.GitReferenceField{types.GitReferenceFieldRefName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk references: %w", err)
+	}
+
+	return out, nil
+}
+
+// IsRefConflict returns true if the two references can't exist at the same time,
+// because one of them is a parent path of the other (e.g. "refs/heads/a" and "refs/heads/a/b").
+func IsRefConflict(refName, other string) bool {
+	return strings.HasPrefix(other, refName+"/") || strings.HasPrefix(refName, other+"/")
+}
+
+// refParentPaths returns the parent paths of a reference below its namespace
+// (e.g. "refs/heads/a/b/c" returns "refs/heads/a" and "refs/heads/a/b").
+func refParentPaths(refName string) []string {
+	parts := strings.Split(refName, "/")
+	if len(parts) <= 3 {
+		return nil
+	}
+
+	parents := make([]string, 0, len(parts)-3)
+	for i := 3; i < len(parts); i++ {
+		parents = append(parents, strings.Join(parts[:i], "/"))
+	}
+
+	return parents
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/types"
+)
+
+const testRepoUID = "testrepouid"
+
+// setupRefConflictsService returns a service with a bare repository containing the provided references.
+func setupRefConflictsService(t *testing.T, refs ...string) *Service {
+	t.Helper()
+	ctx := context.Background()
+
+	gitAdapter, err := adapter.New(types.Config{}, adapter.NewInMemoryLastCommitCache(time.Minute), nil)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %v", err)
+	}
+
+	s := &Service{reposRoot: t.TempDir(), adapter: gitAdapter}
+	repoPath := getFullPathForRepo(s.reposRoot, testRepoUID)
+
+	if err = gitAdapter.InitRepository(ctx, repoPath, true); err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		cmd.Env = append(cmd.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	emptyTree := run("hash-object", "-t", "tree", "-w", "--stdin")
+	commit := run("commit-tree", emptyTree, "-m", "initial")
+	for _, ref := range refs {
+		run("update-ref", ref, commit)
+	}
+
+	return s
+}
+
+func TestService_FindRefConflicts(t *testing.T) {
+	s := setupRefConflictsService(t, "refs/heads/a", "refs/heads/x/y", "refs/tags/v1")
+
+	tests := []struct {
+		name    string
+		refs    []string
+		deleted []string
+		want    map[string]string
+	}{
+		{
+			name: "created below existing",
+			refs: []string{"refs/heads/a/b"},
+			want: map[string]string{"refs/heads/a/b": "refs/heads/a"},
+		},
+		{
+			name: "created above existing",
+			refs: []string{"refs/heads/x"},
+			want: map[string]string{"refs/heads/x": "refs/heads/x/y"},
+		},
+		{
+			name:    "existing deleted in the same push",
+			refs:    []string{"refs/heads/a/b"},
+			deleted: []string{"refs/heads/a"},
+			want:    map[string]string{},
+		},
+		{
+			name: "common prefix without conflict",
+			refs: []string{"refs/heads/ab", "refs/heads/x/z", "refs/tags/v1.1"},
+			want: map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := s.FindRefConflicts(context.Background(), &FindRefConflictsParams{
+				ReadParams:      ReadParams{RepoUID: testRepoUID},
+				RefNames:        test.refs,
+				DeletedRefNames: test.deleted,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(out.Conflicts) != len(test.want) {
+				t.Fatalf("conflicts = %v, want %v", out.Conflicts, test.want)
+			}
+			for ref, existing := range test.want {
+				if out.Conflicts[ref] != existing {
+					t.Errorf("conflict of %s = %q, want %q", ref, out.Conflicts[ref], existing)
+				}
+			}
+		})
+	}
+}
@@ -491,16 +491,25 @@ func (s *Service) createRepositoryInternal(
 
 	// setup server hook symlinks pointing to configured server hook binary
 	// IMPORTANT: Setup hooks after repo creation to avoid issues with externally dependent services.
+	if err = s.setupServerHooks(repoPath); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("repository created. Path: %s", repoPath)
+	return nil
+}
+
+// setupServerHooks creates the server hook symlinks pointing to the configured server hook binary.
+func (s *Service) setupServerHooks(repoPath string) error {
 	for _, hook := range gitServerHookNames {
 		hookPath := path.Join(repoPath, gitHooksDir, hook)
-		err = os.Symlink(s.gitHookPath, hookPath)
+		err := os.Symlink(s.gitHookPath, hookPath)
 		if err != nil {
 			return errors.Internal(err, "failed to setup symlink for hook '%s' ('%s' -> '%s')",
 				hook, hookPath, s.gitHookPath)
 		}
 	}
 
-	log.Info().Msgf("repository created. Path: %s", repoPath)
 	return nil
 }
 
@@ -17,6 +17,9 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
 )
 
 // TreeNodeType specifies the different types of nodes in a git tree.
@@ -150,6 +153,45 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 	}, nil
 }
 
+type ListTreeFilesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+}
+
+func (p *ListTreeFilesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type ListTreeFilesOutput struct {
+	Files []types.TreeFile
+}
+
+func (s *Service) ListTreeFiles(ctx context.Context, params *ListTreeFilesParams) (*ListTreeFilesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	files, err := s.adapter.ListTreeFiles(ctx, repoPath, params.GitREF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree files: %w", err)
+	}
+
+	return &ListTreeFilesOutput{
+		Files: files,
+	}, nil
+}
+
 type PathsDetailsParams struct {
 	ReadParams
 	GitREF string
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/http/proxy"
 )
 
 // Config defines the configuration for the git package.
@@ -31,6 +32,8 @@ type Config struct {
 	TmpDir string
 	// HookPath points to the binary used as git server hook.
 	HookPath string
+	// Proxy defines the proxy used for git operations with remote repositories (e.g. import, export).
+	Proxy proxy.Config
 
 	// LastCommitCache holds configuration options for the last commit cache.
 	LastCommitCache LastCommitCacheConfig
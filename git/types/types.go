@@ -79,6 +79,8 @@ const (
 	GitReferenceFieldObjectType  GitReferenceField = "objecttype"
 	GitReferenceFieldObjectName  GitReferenceField = "objectname"
 	GitReferenceFieldCreatorDate GitReferenceField = "creatordate"
+	// GitReferenceFieldCommitterDateUnix is the committer date of the referenced commit as unix timestamp.
+	GitReferenceFieldCommitterDateUnix GitReferenceField = "committerdate:unix"
 )
 
 func ParseGitReferenceField(f string) (GitReferenceField, error) {
@@ -91,6 +93,8 @@ func ParseGitReferenceField(f string) (GitReferenceField, error) {
 		return GitReferenceFieldObjectName, nil
 	case string(GitReferenceFieldObjectType):
 		return GitReferenceFieldObjectType, nil
+	case string(GitReferenceFieldCommitterDateUnix):
+		return GitReferenceFieldCommitterDateUnix, nil
 	default:
 		return GitReferenceFieldRefName, fmt.Errorf("unknown git reference field '%s'", f)
 	}
@@ -160,6 +164,14 @@ type Commit struct {
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
 }
 
+// CommitActivity contains the author and the number of changed lines of a single commit.
+type CommitActivity struct {
+	SHA       string
+	Author    Signature
+	Additions int64
+	Deletions int64
+}
+
 type Branch struct {
 	Name   string
 	SHA    string
@@ -173,6 +185,8 @@ type BranchFilter struct {
 	Sort          GitReferenceField
 	Order         SortOrder
 	IncludeCommit bool
+	// CommittedBefore is a unix timestamp, if provided only branches with an older latest commit are listed.
+	CommittedBefore int64
 }
 
 type Tag struct {
@@ -251,6 +265,13 @@ type TreeNode struct {
 	Path     string
 }
 
+// TreeFile is a file (blob) found when walking a git tree recursively.
+type TreeFile struct {
+	Path string
+	SHA  string
+	Size int64
+}
+
 // TreeNodeType specifies the different types of nodes in a git tree.
 // IMPORTANT: has to be consistent with rpc.TreeNodeType (proto).
 type TreeNodeType int
@@ -278,6 +299,14 @@ type Submodule struct {
 	URL  string
 }
 
+// NewBlob describes a blob that isn't reachable from any existing reference yet (e.g. a blob added by a push).
+type NewBlob struct {
+	SHA string
+	// Path is the path of the first tree entry that references the blob.
+	Path string
+	Size int64
+}
+
 type BlobReader struct {
 	SHA string
 	// Size is the actual size of the blob.
@@ -361,6 +390,11 @@ type CommitFilter struct {
 	Since     int64
 	Until     int64
 	Committer string
+	Author    string
+	// Follow continues listing the history of the path beyond renames (only used if a path is provided).
+	Follow bool
+	// Cursor is the SHA of the last commit of the previous page (keyset pagination).
+	Cursor string
 }
 
 type TempRepository struct {
@@ -403,3 +437,31 @@ type FileDiffRequest struct {
 }
 
 type FileDiffRequests []FileDiffRequest
+
+// MaxDiffContextLines is the maximum number of context lines that can be requested for a diff.
+const MaxDiffContextLines = 1000
+
+// DiffOptions change how a diff is computed and presented.
+// They don't change the line numbers of the diffed files, so line based references stay valid.
+type DiffOptions struct {
+	// IgnoreWhitespace ignores whitespace when comparing lines.
+	IgnoreWhitespace bool
+	// IgnoreBlankLines ignores changes whose lines are all blank.
+	IgnoreBlankLines bool
+	// Algorithm is the diff algorithm, the git default is used if empty.
+	Algorithm enum.DiffAlgorithm
+	// ContextLines is the number of context lines around changes, the git default is used if nil.
+	ContextLines *int
+}
+
+func (o DiffOptions) Validate() error {
+	if !o.Algorithm.IsValid() {
+		return errors.InvalidArgument("unsupported diff algorithm %q", o.Algorithm)
+	}
+
+	if o.ContextLines != nil && (*o.ContextLines < 0 || *o.ContextLines > MaxDiffContextLines) {
+		return errors.InvalidArgument("context lines must be between 0 and %d", MaxDiffContextLines)
+	}
+
+	return nil
+}
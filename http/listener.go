@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	// listenFDsStart is the first file descriptor passed by the parent process (see sd_listen_fds).
+	listenFDsStart = 3
+
+	envListenPID = "LISTEN_PID"
+	envListenFDs = "LISTEN_FDS"
+)
+
+var (
+	inheritedOnce      sync.Once
+	inheritedListeners []net.Listener
+	inheritedErr       error
+)
+
+// loadInheritedListeners returns the listening sockets passed by the parent process using the
+// systemd socket activation protocol (LISTEN_PID and LISTEN_FDS). Socket handoff allows to replace
+// the running process without closing the listening sockets, hence without refusing connections.
+func loadInheritedListeners() ([]net.Listener, error) {
+	inheritedOnce.Do(func() {
+		pid, err := strconv.Atoi(os.Getenv(envListenPID))
+		if err != nil || pid != os.Getpid() {
+			return
+		}
+
+		n, err := strconv.Atoi(os.Getenv(envListenFDs))
+		if err != nil || n <= 0 {
+			return
+		}
+
+		// unset the variables to ensure child processes don't try to use the sockets.
+		_ = os.Unsetenv(envListenPID)
+		_ = os.Unsetenv(envListenFDs)
+
+		for fd := listenFDsStart; fd < listenFDsStart+n; fd++ {
+			f := os.NewFile(uintptr(fd), fmt.Sprintf("listen_fd_%d", fd))
+
+			l, err := net.FileListener(f)
+			// the listener holds a duplicate of the file descriptor.
+			_ = f.Close()
+			if err != nil {
+				inheritedErr = fmt.Errorf("failed to use inherited file descriptor %d as listener: %w", fd, err)
+				return
+			}
+
+			inheritedListeners = append(inheritedListeners, l)
+		}
+	})
+
+	return inheritedListeners, inheritedErr
+}
+
+// listen returns a tcp listener for the provided address.
+// Listeners inherited from the parent process are used in the order in which the servers are started,
+// otherwise a new socket is opened (with SO_REUSEPORT set if configured).
+func (s *Server) listen(addr string) (net.Listener, error) {
+	inherited, err := loadInheritedListeners()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.inheritedIdx < len(inherited) {
+		l := inherited[s.inheritedIdx]
+		s.inheritedIdx++
+		return l, nil
+	}
+
+	lc := net.ListenConfig{}
+	if s.config.ReusePort {
+		lc.Control = reusePortControl
+	}
+
+	l, err := lc.Listen(context.Background(), "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %w", addr, err)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Config defines the outgoing http(s) proxy configuration.
+type Config struct {
+	// URL is the proxy used for all outgoing http(s) requests.
+	// If empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.
+	URL string
+
+	// NoProxy is a list of hosts that are accessed directly (same semantics as NO_PROXY).
+	// If empty, the NO_PROXY environment variable is used.
+	NoProxy []string
+
+	// Destinations overrides the proxy for specific hosts, each entry is of the form "host=proxy".
+	// A host starting with "." (or "*.") matches all of its subdomains.
+	Destinations []string
+}
+
+// Resolver resolves the proxy to use for outgoing requests.
+type Resolver struct {
+	global       func(*url.URL) (*url.URL, error)
+	destinations []destination
+	addresses    map[string]struct{}
+}
+
+type destination struct {
+	host  string
+	proxy func(*url.URL) (*url.URL, error)
+}
+
+// NewResolver returns a new proxy resolver for the provided config.
+func NewResolver(config Config) (*Resolver, error) {
+	base := httpproxy.FromEnvironment()
+	if config.URL != "" {
+		base.HTTPProxy = config.URL
+		base.HTTPSProxy = config.URL
+	}
+	if len(config.NoProxy) > 0 {
+		base.NoProxy = strings.Join(config.NoProxy, ",")
+	}
+
+	r := &Resolver{
+		global:    base.ProxyFunc(),
+		addresses: map[string]struct{}{},
+	}
+
+	for _, proxyURL := range []string{base.HTTPProxy, base.HTTPSProxy} {
+		if err := r.addAddress(proxyURL); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range config.Destinations {
+		host, proxyURL, ok := strings.Cut(entry, "=")
+		if !ok || host == "" || proxyURL == "" {
+			return nil, fmt.Errorf("invalid proxy destination %q, expected host=proxy", entry)
+		}
+
+		if err := r.addAddress(proxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy for destination %q: %w", host, err)
+		}
+
+		cfg := &httpproxy.Config{
+			HTTPProxy:  proxyURL,
+			HTTPSProxy: proxyURL,
+			NoProxy:    base.NoProxy,
+		}
+		r.destinations = append(r.destinations, destination{
+			host:  strings.ToLower(strings.TrimPrefix(host, "*")),
+			proxy: cfg.ProxyFunc(),
+		})
+	}
+
+	// most specific destinations first
+	sort.Slice(r.destinations, func(i, j int) bool {
+		return len(r.destinations[i].host) > len(r.destinations[j].host)
+	})
+
+	return r, nil
+}
+
+// ProxyURL returns the proxy to use for a request to the target, or nil if no proxy should be used.
+func (r *Resolver) ProxyURL(target *url.URL) (*url.URL, error) {
+	host := strings.ToLower(target.Hostname())
+	for _, d := range r.destinations {
+		if host == strings.TrimPrefix(d.host, ".") || (strings.HasPrefix(d.host, ".") && strings.HasSuffix(host, d.host)) {
+			return d.proxy(target)
+		}
+	}
+
+	return r.global(target)
+}
+
+// Proxy can be used as http.Transport.Proxy.
+func (r *Resolver) Proxy(req *http.Request) (*url.URL, error) {
+	return r.ProxyURL(req.URL)
+}
+
+// IsProxyAddress returns true if the address (host:port) belongs to one of the configured proxies.
+func (r *Resolver) IsProxyAddress(addr string) bool {
+	_, ok := r.addresses[strings.ToLower(addr)]
+	return ok
+}
+
+func (r *Resolver) addAddress(proxyURL string) error {
+	if proxyURL == "" {
+		return nil
+	}
+
+	u, err := parseProxyURL(proxyURL)
+	if err != nil {
+		return err
+	}
+
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+
+	r.addresses[strings.ToLower(net.JoinHostPort(u.Hostname(), port))] = struct{}{}
+
+	return nil
+}
+
+// parseProxyURL parses the proxy url the same way as httpproxy (a missing scheme defaults to http).
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		if u, err := url.Parse("http://" + proxyURL); err == nil {
+			return u, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url %q: %w", proxyURL, err)
+	}
+
+	return u, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	for _, key := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy"} {
+		t.Setenv(key, "")
+	}
+
+	r, err := NewResolver(Config{
+		URL:     "http://proxy.internal:3128",
+		NoProxy: []string{"direct.example.com", "10.0.0.0/8"},
+		Destinations: []string{
+			"github.com=http://github-proxy:8080",
+			".corp.example.com=https://corp-proxy",
+			"*.other.example.com=socks5://other-proxy",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create resolver: %s", err)
+	}
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "https://example.com/hook", want: "http://proxy.internal:3128"},
+		{target: "http://example.com/hook", want: "http://proxy.internal:3128"},
+		{target: "https://direct.example.com/hook", want: ""},
+		{target: "https://10.1.2.3/hook", want: ""},
+		{target: "http://localhost:3000/hook", want: ""},
+		{target: "https://github.com/harness/gitness.git", want: "http://github-proxy:8080"},
+		{target: "https://api.github.com/repos", want: "http://proxy.internal:3128"},
+		{target: "https://git.corp.example.com/repo.git", want: "https://corp-proxy"},
+		{target: "https://corp.example.com/repo.git", want: "https://corp-proxy"},
+		{target: "https://a.other.example.com/repo.git", want: "socks5://other-proxy"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			target, _ := url.Parse(test.target)
+			got, err := r.ProxyURL(target)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			gotStr := ""
+			if got != nil {
+				gotStr = got.String()
+			}
+			if gotStr != test.want {
+				t.Errorf("got proxy %q, want %q", gotStr, test.want)
+			}
+		})
+	}
+
+	for addr, want := range map[string]bool{
+		"proxy.internal:3128": true,
+		"github-proxy:8080":   true,
+		"corp-proxy:443":      true,
+		"other-proxy:1080":    true,
+		"example.com:443":     false,
+	} {
+		if got := r.IsProxyAddress(addr); got != want {
+			t.Errorf("IsProxyAddress(%q) = %t, want %t", addr, got, want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -40,6 +41,8 @@ type Config struct {
 	Key               string
 	AcmeHost          string
 	ReadHeaderTimeout time.Duration
+	// ReusePort sets SO_REUSEPORT on the listening sockets to allow rolling restarts on the same host.
+	ReusePort bool
 }
 
 // Server is a wrapper around http.Server that exposes different async ListenAndServe methods
@@ -47,6 +50,9 @@ type Config struct {
 type Server struct {
 	config  Config
 	handler http.Handler
+
+	// inheritedIdx is the index of the next inherited listener to use.
+	inheritedIdx int
 }
 
 // ShutdownFunction defines a function that is called to shutdown the server.
@@ -80,9 +86,7 @@ func (s *Server) listenAndServe() (*errgroup.Group, ShutdownFunction) {
 		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
 		Handler:           s.handler,
 	}
-	g.Go(func() error {
-		return s1.ListenAndServe()
-	})
+	g.Go(s.serveFunc(s1, s1.Serve))
 
 	return &g, s1.Shutdown
 }
@@ -99,15 +103,10 @@ func (s *Server) listenAndServeTLS() (*errgroup.Group, ShutdownFunction) {
 		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
 		Handler:           s.handler,
 	}
-	g.Go(func() error {
-		return s1.ListenAndServe()
-	})
-	g.Go(func() error {
-		return s2.ListenAndServeTLS(
-			s.config.Cert,
-			s.config.Key,
-		)
-	})
+	g.Go(s.serveFunc(s1, s1.Serve))
+	g.Go(s.serveFunc(s2, func(l net.Listener) error {
+		return s2.ServeTLS(l, s.config.Cert, s.config.Key)
+	}))
 
 	return &g, func(ctx context.Context) error {
 		var sg errgroup.Group
@@ -121,7 +120,7 @@ func (s *Server) listenAndServeTLS() (*errgroup.Group, ShutdownFunction) {
 	}
 }
 
-func (s Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
+func (s *Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
 	var g errgroup.Group
 	m := &autocert.Manager{
 		Cache:      autocert.DirCache(".cache"),
@@ -143,12 +142,10 @@ func (s Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
 			NextProtos:     []string{"h2", "http/1.1"},
 		},
 	}
-	g.Go(func() error {
-		return s1.ListenAndServe()
-	})
-	g.Go(func() error {
-		return s2.ListenAndServeTLS("", "")
-	})
+	g.Go(s.serveFunc(s1, s1.Serve))
+	g.Go(s.serveFunc(s2, func(l net.Listener) error {
+		return s2.ServeTLS(l, "", "")
+	}))
 
 	return &g, func(ctx context.Context) error {
 		var sg errgroup.Group
@@ -162,6 +159,18 @@ func (s Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
 	}
 }
 
+// serveFunc creates the listener of the server and returns the function serving the server on it.
+// The listener is created synchronously to assign inherited listeners in the order the servers are defined.
+func (s *Server) serveFunc(srv *http.Server, serve func(net.Listener) error) func() error {
+	l, err := s.listen(srv.Addr)
+	return func() error {
+		if err != nil {
+			return err
+		}
+		return serve(l)
+	}
+}
+
 func redirect(w http.ResponseWriter, req *http.Request) {
 	// TODO: in case of reverse-proxy the host might be not the external host.
 	target := "https://" + req.Host + "/" + strings.TrimPrefix(req.URL.Path, "/")
@@ -239,6 +239,60 @@ func (s *Scheduler) CancelJob(ctx context.Context, jobUID string) error {
 	return s.pubsubService.Publish(ctx, PubSubTopicCancelJob, []byte(jobUID))
 }
 
+// RetryJob reschedules a failed or canceled job for immediate execution.
+// A recurring job that isn't running is executed immediately, which doesn't affect its regular schedule.
+func (s *Scheduler) RetryJob(ctx context.Context, jobUID string) error {
+	mx, err := globalLock(ctx, s.mxManager)
+	if err != nil {
+		return fmt.Errorf("failed to obtain global lock to retry a job: %w", err)
+	}
+
+	defer func() {
+		if err := mx.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to release global lock after retrying a job")
+		}
+	}()
+
+	job, err := s.store.Find(ctx, jobUID)
+	if err != nil {
+		return fmt.Errorf("failed to find job to retry: %w", err)
+	}
+
+	if !CanRetry(job) {
+		return fmt.Errorf("can't retry job in state '%s'", job.State)
+	}
+
+	now := time.Now()
+
+	job.Updated = now.UnixMilli()
+	job.State = JobStateScheduled
+	job.Scheduled = now.UnixMilli()
+	job.ConsecutiveFailures = 0
+
+	err = s.store.UpdateExecution(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to update job to retry it: %w", err)
+	}
+
+	s.scheduleProcessing(now)
+
+	return nil
+}
+
+// CanRetry returns true if the job can be rescheduled with RetryJob.
+func CanRetry(job *Job) bool {
+	if job.IsRecurring {
+		return job.State != JobStateRunning
+	}
+
+	return job.State == JobStateFailed || job.State == JobStateCanceled
+}
+
+// CanCancel returns true if the job can be canceled with CancelJob.
+func CanCancel(job *Job) bool {
+	return !job.IsRecurring && (job.State == JobStateScheduled || job.State == JobStateRunning)
+}
+
 func (s *Scheduler) handleCancelJob(payload []byte) error {
 	jobUID := string(payload)
 	if jobUID == "" {
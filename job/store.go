@@ -23,6 +23,12 @@ type Store interface {
 	// Find fetches a job by its unique identifier.
 	Find(ctx context.Context, uid string) (*Job, error)
 
+	// List returns a list of jobs matching the filter, most recently scheduled first.
+	List(ctx context.Context, filter *ListFilter) ([]*Job, error)
+
+	// Count returns the number of jobs matching the filter.
+	Count(ctx context.Context, filter *ListFilter) (int64, error)
+
 	// ListByGroupID fetches all jobs for a group id
 	ListByGroupID(ctx context.Context, groupID string) ([]*Job, error)
 
@@ -19,8 +19,9 @@ import "time"
 type Provider string
 
 const (
-	MemoryProvider Provider = "inmemory"
-	RedisProvider  Provider = "redis"
+	MemoryProvider   Provider = "inmemory"
+	RedisProvider    Provider = "redis"
+	DatabaseProvider Provider = "database"
 )
 
 // A DelayFunc is used to decide the amount of time to wait between retries.
@@ -41,4 +42,9 @@ type Config struct {
 
 	GenValueFunc func() (string, error)
 	Value        string
+
+	// LeaderTTL is the duration after which the leadership of an instance that stopped renewing it expires.
+	LeaderTTL time.Duration
+	// LeaderRetryInterval is the interval in which instances campaign for a leadership held by another instance.
+	LeaderRetryInterval time.Duration
 }
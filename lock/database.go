@@ -0,0 +1,243 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // only used to shorten the lock key
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Database is a MutexManager that uses the advisory locks of the database (postgres or mariadb).
+// The locks are bound to a dedicated database connection, hence they are released by the database
+// as soon as the connection of the holding instance is gone. They don't expire otherwise.
+type Database struct {
+	config  Config // force value copy
+	db      *sqlx.DB
+	mariadb bool
+}
+
+// NewDatabase creates a new Database instance. Sqlite doesn't support advisory locks.
+func NewDatabase(config Config, db *sqlx.DB) (*Database, error) {
+	if db == nil {
+		return nil, errors.New("database required")
+	}
+
+	driver := db.DriverName()
+	if driver != "postgres" && driver != "mariadb" {
+		return nil, fmt.Errorf("database driver %q doesn't support advisory locks", driver)
+	}
+
+	return &Database{
+		config:  config,
+		db:      db,
+		mariadb: driver == "mariadb",
+	}, nil
+}
+
+// NewMutex creates a mutex for the given key. The returned mutex is not held
+// and must be acquired with a call to .Lock.
+func (d *Database) NewMutex(key string, options ...Option) (Mutex, error) {
+	// copy default values
+	config := d.config
+
+	// set default delayFunc
+	if config.DelayFunc == nil {
+		config.DelayFunc = func(_ int) time.Duration {
+			return config.RetryDelay
+		}
+	}
+
+	// override config with custom options
+	for _, opt := range options {
+		opt.Apply(&config)
+	}
+
+	// format key
+	key = formatKey(config.App, config.Namespace, key)
+
+	waitTime := config.Expiry
+	if config.TimeoutFactor > 0 {
+		waitTime = time.Duration(int64(float64(config.Expiry) * config.TimeoutFactor))
+	}
+
+	return &databaseMutex{
+		db:        d.db.DB,
+		mariadb:   d.mariadb,
+		key:       key,
+		waitTime:  waitTime,
+		tries:     config.Tries,
+		delayFunc: config.DelayFunc,
+	}, nil
+}
+
+type databaseMutex struct {
+	mutex sync.Mutex // Used while manipulating the internal state of the lock itself
+
+	db      *sql.DB
+	mariadb bool
+
+	key string
+
+	waitTime  time.Duration
+	tries     int
+	delayFunc DelayFunc
+
+	// conn is the connection holding the advisory lock (nil if the lock isn't held).
+	conn *sql.Conn
+}
+
+// Key returns the key to be locked.
+func (m *databaseMutex) Key() string {
+	return m.key
+}
+
+// Lock acquires the lock. It fails with error if the lock is already held.
+func (m *databaseMutex) Lock(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.conn != nil {
+		return NewError(ErrorKindLockHeld, m.key, nil)
+	}
+
+	timeout := time.NewTimer(m.waitTime)
+	defer timeout.Stop()
+
+	for attempt := 1; ; attempt++ {
+		ok, err := m.tryLock(ctx)
+		if err != nil {
+			return NewError(ErrorKindProviderError, m.key, err)
+		}
+		if ok {
+			return nil
+		}
+
+		if attempt >= m.tries {
+			return NewError(ErrorKindMaxRetriesExceeded, m.key, nil)
+		}
+
+		delay := time.NewTimer(m.delayFunc(attempt))
+		select {
+		case <-ctx.Done():
+			delay.Stop()
+			return NewError(ErrorKindContext, m.key, ctx.Err())
+		case <-timeout.C:
+			delay.Stop()
+			return NewError(ErrorKindCannotLock, m.key, nil)
+		case <-delay.C: // just wait
+		}
+	}
+}
+
+// tryLock tries to acquire the advisory lock on a new connection, which is kept open while the lock is held.
+func (m *databaseMutex) tryLock(ctx context.Context) (bool, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	var ok sql.NullBool
+	if m.mariadb {
+		err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", m.mariadbKey()).Scan(&ok)
+	} else {
+		err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", m.postgresKey()).Scan(&ok)
+	}
+	if err != nil || !ok.Bool {
+		_ = conn.Close()
+		return false, err
+	}
+
+	m.conn = conn
+
+	return true, nil
+}
+
+// Unlock releases the lock. It fails with error if the lock is not currently held.
+func (m *databaseMutex) Unlock(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.conn == nil {
+		return NewError(ErrorKindLockNotHeld, m.key, nil)
+	}
+
+	// the lock is released by the database in any case once the connection is closed.
+	defer func() {
+		_ = m.conn.Close()
+		m.conn = nil
+	}()
+
+	var ok sql.NullBool
+	var err error
+	if m.mariadb {
+		err = m.conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", m.mariadbKey()).Scan(&ok)
+	} else {
+		err = m.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", m.postgresKey()).Scan(&ok)
+	}
+	if err != nil {
+		return NewError(ErrorKindProviderError, m.key, err)
+	}
+	if !ok.Bool {
+		return NewError(ErrorKindLockNotHeld, m.key, nil)
+	}
+
+	return nil
+}
+
+// Extend ensures the lock is still held. Advisory locks don't expire,
+// they are only lost if the connection holding them is gone.
+func (m *databaseMutex) Extend(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.conn == nil {
+		return NewError(ErrorKindLockNotHeld, m.key, nil)
+	}
+
+	if err := m.conn.PingContext(ctx); err != nil {
+		_ = m.conn.Close()
+		m.conn = nil
+		return NewError(ErrorKindLockNotHeld, m.key, err)
+	}
+
+	return nil
+}
+
+// postgresKey returns the key of the postgres advisory lock, which is a 64bit integer.
+func (m *databaseMutex) postgresKey() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(m.key))
+	return int64(h.Sum64())
+}
+
+// mariadbKey returns the name of the mariadb user-level lock, which is limited to 64 characters.
+func (m *databaseMutex) mariadbKey() string {
+	const maxLength = 64
+	if len(m.key) <= maxLength {
+		return m.key
+	}
+
+	sum := sha1.Sum([]byte(m.key)) //nolint:gosec // only used to shorten the lock key
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const leaderNamespace = "leader"
+
+// Elector elects a single leader among all instances for a given key.
+// The leader holds a mutex with a short expiry that it extends periodically, in case the leader dies
+// (or can't reach the lock provider anymore) another instance takes over once the mutex expired.
+// NOTE: Leadership is only exclusive across instances if a distributed lock provider is used.
+type Elector struct {
+	manager       MutexManager
+	ttl           time.Duration
+	retryInterval time.Duration
+}
+
+func NewElector(manager MutexManager, ttl time.Duration, retryInterval time.Duration) *Elector {
+	return &Elector{
+		manager:       manager,
+		ttl:           ttl,
+		retryInterval: retryInterval,
+	}
+}
+
+// RunAsLeader campaigns for the leadership of the key and runs fn while this instance is the leader.
+// The context passed to fn is canceled once the leadership is lost, in which case the instance campaigns again.
+// It blocks until the provided context is done or fn returned, and returns the error returned by fn.
+func (e *Elector) RunAsLeader(ctx context.Context, key string, fn func(context.Context) error) error {
+	log := log.Ctx(ctx).With().Str("leader.key", key).Logger()
+	ctx = log.WithContext(ctx)
+
+	for {
+		mx, err := e.manager.NewMutex(key,
+			WithNamespace(leaderNamespace),
+			WithExpiry(e.ttl),
+			WithTries(1),
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = mx.Lock(ctx); err == nil {
+			log.Info().Msg("elector: acquired leadership")
+
+			done, err := e.lead(ctx, mx, fn)
+			if done {
+				return err
+			}
+		} else if ctx.Err() == nil {
+			log.Trace().Err(err).Msg("elector: leadership is held by another instance")
+		}
+
+		timer := time.NewTimer(e.retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// lead runs fn while keeping the leadership alive. It returns true if fn returned,
+// or false if the leadership was lost before.
+func (e *Elector) lead(ctx context.Context, mx Mutex, fn func(context.Context) error) (bool, error) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn(leaderCtx)
+	}()
+
+	// extend the mutex well before it expires to tolerate slow responses of the lock provider.
+	const extendsPerTTL = 3
+	ticker := time.NewTicker(e.ttl / extendsPerTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-errCh:
+			// the context might be done already, still release the leadership for others to take over.
+			unlockCtx, unlockCancel := context.WithTimeout(detachedContext{ctx}, e.ttl)
+			uErr := mx.Unlock(unlockCtx)
+			unlockCancel()
+
+			if uErr != nil {
+				log.Ctx(ctx).Warn().Err(uErr).Msg("elector: failed to release leadership")
+			} else {
+				log.Ctx(ctx).Info().Msg("elector: released leadership")
+			}
+
+			return true, err
+
+		case <-ticker.C:
+			if err := mx.Extend(leaderCtx); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("elector: lost leadership")
+
+				cancel()
+				<-errCh
+
+				return false, nil
+			}
+		}
+	}
+}
+
+// detachedContext keeps the values of its parent context, but isn't canceled with it.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
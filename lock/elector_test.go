@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestElector_RunAsLeader(t *testing.T) {
+	manager := NewInMemory(Config{
+		App:        "gitness",
+		Namespace:  "default",
+		Expiry:     time.Second,
+		Tries:      1,
+		RetryDelay: 10 * time.Millisecond,
+	})
+
+	const ttl = 300 * time.Millisecond
+	const retryInterval = 20 * time.Millisecond
+
+	var leaders atomic.Int32
+	var maxLeaders atomic.Int32
+	lead := func(ctx context.Context) error {
+		n := leaders.Add(1)
+		defer leaders.Add(-1)
+		if n > maxLeaders.Load() {
+			maxLeaders.Store(n)
+		}
+
+		<-ctx.Done()
+		return nil
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	done1 := make(chan error)
+	done2 := make(chan error)
+	go func() { done1 <- NewElector(manager, ttl, retryInterval).RunAsLeader(ctx1, "test", lead) }()
+	go func() { done2 <- NewElector(manager, ttl, retryInterval).RunAsLeader(ctx2, "test", lead) }()
+
+	// leadership has to be kept beyond the ttl, with only one leader at a time.
+	time.Sleep(2 * ttl)
+	if got := leaders.Load(); got != 1 {
+		t.Fatalf("expected exactly one leader, got %d", got)
+	}
+
+	// stop the first instance - the leadership is released or was never held, either way the second one leads.
+	cancel1()
+	if err := <-done1; err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	deadline := time.Now().Add(2 * ttl)
+	for leaders.Load() != 1 && time.Now().Before(deadline) {
+		time.Sleep(retryInterval)
+	}
+	if got := leaders.Load(); got != 1 {
+		t.Fatalf("expected the remaining instance to take over, got %d leaders", got)
+	}
+
+	cancel2()
+	if err := <-done2; err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if got := maxLeaders.Load(); got != 1 {
+		t.Errorf("expected at most one leader at a time, got %d", got)
+	}
+}
+
+func TestElector_RunAsLeaderTakeover(t *testing.T) {
+	manager := NewInMemory(Config{
+		App:       "gitness",
+		Namespace: "default",
+		Tries:     1,
+	})
+
+	elector := NewElector(manager, 200*time.Millisecond, 10*time.Millisecond)
+
+	// the first leader finishes its work, which releases the leadership.
+	ran := make(chan struct{})
+	err := elector.RunAsLeader(context.Background(), "test", func(context.Context) error {
+		close(ran)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-ran
+
+	// the leadership is free for the next instance right away.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	took := make(chan struct{})
+	err = elector.RunAsLeader(ctx, "test", func(context.Context) error {
+		close(took)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	select {
+	case <-took:
+	default:
+		t.Error("expected the second instance to become leader")
+	}
+}
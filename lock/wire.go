@@ -15,20 +15,30 @@
 package lock
 
 import (
+	"fmt"
+
 	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
+	"github.com/jmoiron/sqlx"
 )
 
 var WireSet = wire.NewSet(
 	ProvideMutexManager,
+	ProvideElector,
 )
 
-func ProvideMutexManager(config Config, client redis.UniversalClient) MutexManager {
+func ProvideMutexManager(config Config, client redis.UniversalClient, db *sqlx.DB) (MutexManager, error) {
 	switch config.Provider {
 	case MemoryProvider:
-		return NewInMemory(config)
+		return NewInMemory(config), nil
 	case RedisProvider:
-		return NewRedis(config, client)
+		return NewRedis(config, client), nil
+	case DatabaseProvider:
+		return NewDatabase(config, db)
 	}
-	return nil
+	return nil, fmt.Errorf("lock provider %q is not supported", config.Provider)
+}
+
+func ProvideElector(config Config, manager MutexManager) *Elector {
+	return NewElector(manager, config.LeaderTTL, config.LeaderRetryInterval)
 }
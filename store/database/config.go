@@ -14,8 +14,15 @@
 
 package database
 
+import "time"
+
 // Config specifies the config for the database package.
 type Config struct {
 	Driver     string
 	Datasource string
+
+	MaxOpenConnections    int
+	MaxIdleConnections    int
+	ConnectionMaxLifetime time.Duration
+	ConnectionMaxIdleTime time.Duration
 }
@@ -22,6 +22,7 @@ import (
 
 const (
 	postgres = "postgres"
+	mariadb  = "mariadb"
 )
 
 type locker interface {
@@ -34,7 +35,7 @@ type locker interface {
 var globalMx sync.RWMutex
 
 func needsLocking(driver string) bool {
-	return driver != postgres
+	return driver != postgres && driver != mariadb
 }
 
 func getLocker(db *sqlx.DB) locker {
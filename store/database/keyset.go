@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// KeysetColumn describes a column of the ordering used for keyset pagination.
+// The last column of an ordering must be unique to guarantee a stable order.
+type KeysetColumn struct {
+	Name string
+	Desc bool
+}
+
+// KeysetOrderBy returns the order by clauses for the keyset columns.
+func KeysetOrderBy(columns []KeysetColumn) []string {
+	clauses := make([]string, len(columns))
+	for i, column := range columns {
+		if column.Desc {
+			clauses[i] = column.Name + " DESC"
+		} else {
+			clauses[i] = column.Name + " ASC"
+		}
+	}
+	return clauses
+}
+
+// KeysetCondition returns the condition selecting all rows that follow
+// the row with the provided column values in the ordering of the keyset columns.
+// Row value comparison can't be used as the columns can have different sort directions.
+func KeysetCondition(columns []KeysetColumn, values []any) squirrel.Sqlizer {
+	or := make(squirrel.Or, len(columns))
+	for i, column := range columns {
+		and := make(squirrel.And, 0, i+1)
+		for j := 0; j < i; j++ {
+			and = append(and, squirrel.Eq{columns[j].Name: values[j]})
+		}
+
+		op := " > ?"
+		if column.Desc {
+			op = " < ?"
+		}
+
+		and = append(and, squirrel.Expr(column.Name+op, values[i]))
+		or[i] = and
+	}
+
+	return or
+}
+
+// ApplyKeyset restricts the query to the rows that follow the row identified by the provided ID
+// and orders the result by the keyset columns.
+// The scope restricts the lookup of the cursor row to the parent of the listed rows (e.g. the repository),
+// so that a cursor obtained for one parent can't be used to page through the rows of another.
+// It returns store.ErrResourceNotFound in case the row identified by the ID doesn't exist within the scope.
+func ApplyKeyset(
+	ctx context.Context,
+	db sqlx.QueryerContext,
+	stmt squirrel.SelectBuilder,
+	table string,
+	idColumn string,
+	id int64,
+	scope squirrel.Sqlizer,
+	columns []KeysetColumn,
+) (squirrel.SelectBuilder, error) {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+
+	sql, args, err := Builder.
+		Select(strings.Join(names, ", ")).
+		From(table).
+		Where(idColumn+" = ?", id).
+		Where(scope).
+		ToSql()
+	if err != nil {
+		return stmt, err
+	}
+
+	values := make([]any, len(columns))
+	dst := make([]any, len(columns))
+	for i := range values {
+		dst[i] = &values[i]
+	}
+
+	if err = db.QueryRowxContext(ctx, sql, args...).Scan(dst...); err != nil {
+		return stmt, ProcessSQLErrorf(ctx, err, "Failed to find keyset values of the cursor")
+	}
+
+	// some drivers return text of unknown column types as raw bytes.
+	for i, value := range values {
+		if b, ok := value.([]byte); ok {
+			values[i] = string(b)
+		}
+	}
+
+	return stmt.
+		Where(KeysetCondition(columns, values)).
+		OrderBy(KeysetOrderBy(columns)...), nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// DriverMariaDB is the name of the MariaDB driver used in the config.
+	// MySQL isn't supported as the stores rely on INSERT ... RETURNING, which MySQL doesn't implement.
+	DriverMariaDB = "mariadb"
+
+	// DriverMySQL is the name of the (unsupported) MySQL driver, it's rejected with an explicit error.
+	DriverMySQL = "mysql"
+
+	// mariaDBDollarDriver is the name under which the wrapped MySQL protocol driver is registered.
+	mariaDBDollarDriver = "gitness-mariadb"
+)
+
+// ErrMySQLNotSupported is returned if the mysql driver is configured.
+var ErrMySQLNotSupported = errors.New("database driver mysql isn't supported: " +
+	"the stores rely on INSERT/DELETE ... RETURNING, which MySQL doesn't implement - use mariadb (10.6+) instead")
+
+// ValidateDriver returns an error if the driver is known to be unsupported.
+func ValidateDriver(driverName string) error {
+	if driverName == DriverMySQL {
+		return ErrMySQLNotSupported
+	}
+	return nil
+}
+
+func init() {
+	sql.Register(mariaDBDollarDriver, NewDollarDriver(mysql.MySQLDriver{}))
+}
+
+// IsMariaDB returns true if the driver is the MariaDB driver.
+func IsMariaDB(driverName string) bool {
+	return driverName == DriverMariaDB
+}
+
+// prepareMariaDBDatasource enables the session settings the queries rely on:
+// multiple statements per query (migrations) and ANSI quotes and string concatenation.
+func prepareMariaDBDatasource(datasource string) (string, error) {
+	cfg, err := mysql.ParseDSN(datasource)
+	if err != nil {
+		return "", fmt.Errorf("datasource is of invalid format for driver mariadb: %w", err)
+	}
+
+	cfg.MultiStatements = true
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["sql_mode"] = "CONCAT(@@sql_mode, ',ANSI_QUOTES,PIPES_AS_CONCAT')"
+
+	return cfg.FormatDSN(), nil
+}
+
+// rebindDollar replaces the postgres style placeholders ($1, $2, ...) used by the stores
+// with "?" placeholders. As a placeholder can be used multiple times and in any order,
+// the returned slice contains the (zero based) argument index for each "?" placeholder.
+// Returns a nil slice if the query doesn't contain any postgres style placeholders.
+func rebindDollar(query string) (string, []int) {
+	if !strings.Contains(query, "$") {
+		return query, nil
+	}
+
+	var (
+		b     strings.Builder
+		order []int
+		quote byte
+	)
+
+	b.Grow(len(query))
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		if quote != 0 {
+			b.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+			b.WriteByte(c)
+			continue
+		case '$':
+		default:
+			b.WriteByte(c)
+			continue
+		}
+
+		j := i + 1
+		n := 0
+		for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+			n = n*10 + int(query[j]-'0')
+			j++
+		}
+
+		if j == i+1 {
+			b.WriteByte(c)
+			continue
+		}
+
+		b.WriteByte('?')
+		order = append(order, n-1)
+		i = j - 1
+	}
+
+	return b.String(), order
+}
+
+// reorderArgs returns the arguments in the order of the "?" placeholders.
+func reorderArgs(args []driver.NamedValue, order []int) ([]driver.NamedValue, error) {
+	if order == nil {
+		return args, nil
+	}
+
+	res := make([]driver.NamedValue, len(order))
+	for i, idx := range order {
+		if idx < 0 || idx >= len(args) {
+			return nil, fmt.Errorf("placeholder $%d has no argument (%d provided)", idx+1, len(args))
+		}
+		res[i] = driver.NamedValue{Ordinal: i + 1, Value: args[idx].Value}
+	}
+
+	return res, nil
+}
+
+// NewDollarDriver wraps a driver that uses "?" placeholders so that it accepts
+// the postgres style placeholders ($1, $2, ...) used by the stores.
+func NewDollarDriver(d driver.Driver) driver.Driver {
+	return &dollarDriver{driver: d}
+}
+
+// dollarDriver intentionally doesn't implement driver.DriverContext:
+// database/sql would otherwise open the connections through the connector of the wrapped driver
+// and bypass the placeholder translation.
+type dollarDriver struct {
+	driver driver.Driver
+}
+
+func (d *dollarDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.driver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &dollarConn{Conn: conn}, nil
+}
+
+type dollarConn struct {
+	driver.Conn
+}
+
+var (
+	_ driver.ConnBeginTx        = (*dollarConn)(nil)
+	_ driver.ConnPrepareContext = (*dollarConn)(nil)
+	_ driver.ExecerContext      = (*dollarConn)(nil)
+	_ driver.QueryerContext     = (*dollarConn)(nil)
+	_ driver.Pinger             = (*dollarConn)(nil)
+	_ driver.SessionResetter    = (*dollarConn)(nil)
+	_ driver.Validator          = (*dollarConn)(nil)
+	_ driver.NamedValueChecker  = (*dollarConn)(nil)
+	_ driver.StmtExecContext    = (*dollarStmt)(nil)
+	_ driver.StmtQueryContext   = (*dollarStmt)(nil)
+	_ driver.NamedValueChecker  = (*dollarStmt)(nil)
+)
+
+var errDriverUnsupportedInterface = errors.New("wrapped driver doesn't implement the required interface")
+
+func (c *dollarConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	conn, ok := c.Conn.(driver.ConnBeginTx)
+	if !ok {
+		return nil, errDriverUnsupportedInterface
+	}
+
+	return conn.BeginTx(ctx, opts)
+}
+
+func (c *dollarConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query, order := rebindDollar(query)
+
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+
+	if conn, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = conn.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dollarStmt{Stmt: stmt, order: order}, nil
+}
+
+func (c *dollarConn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	conn, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		// database/sql falls back to a prepared statement.
+		return nil, driver.ErrSkip
+	}
+
+	query, order := rebindDollar(query)
+
+	args, err := reorderArgs(args, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.ExecContext(ctx, query, args)
+}
+
+func (c *dollarConn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	conn, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		// database/sql falls back to a prepared statement.
+		return nil, driver.ErrSkip
+	}
+
+	query, order := rebindDollar(query)
+
+	args, err := reorderArgs(args, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.QueryContext(ctx, query, args)
+}
+
+func (c *dollarConn) Ping(ctx context.Context) error {
+	if conn, ok := c.Conn.(driver.Pinger); ok {
+		return conn.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *dollarConn) ResetSession(ctx context.Context) error {
+	if conn, ok := c.Conn.(driver.SessionResetter); ok {
+		return conn.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *dollarConn) IsValid() bool {
+	if conn, ok := c.Conn.(driver.Validator); ok {
+		return conn.IsValid()
+	}
+	return true
+}
+
+func (c *dollarConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if conn, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return conn.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type dollarStmt struct {
+	driver.Stmt
+	order []int
+}
+
+// NumInput returns -1 as the number of arguments differs from the number of placeholders.
+func (s *dollarStmt) NumInput() int {
+	return -1
+}
+
+func (s *dollarStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	stmt, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, errDriverUnsupportedInterface
+	}
+
+	args, err := reorderArgs(args, s.order)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.ExecContext(ctx, args)
+}
+
+func (s *dollarStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	stmt, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, errDriverUnsupportedInterface
+	}
+
+	args, err := reorderArgs(args, s.order)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryContext(ctx, args)
+}
+
+func (s *dollarStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if stmt, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return stmt.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// isMariaDBUniqueConstraintError returns true if the error is a duplicate entry error.
+func isMariaDBUniqueConstraintError(original error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(original, &mysqlErr) && mysqlErr.Number == 1062 // ER_DUP_ENTRY
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestRebindDollar(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+		wantOrder []int
+	}{
+		{
+			name:      "no placeholders",
+			query:     "SELECT 1",
+			wantQuery: "SELECT 1",
+			wantOrder: nil,
+		},
+		{
+			name:      "in order",
+			query:     "SELECT a FROM t WHERE b = $1 AND c = $2",
+			wantQuery: "SELECT a FROM t WHERE b = ? AND c = ?",
+			wantOrder: []int{0, 1},
+		},
+		{
+			name:      "reused and out of order",
+			query:     "UPDATE t SET a = $2, b = $2 WHERE id = $1",
+			wantQuery: "UPDATE t SET a = ?, b = ? WHERE id = ?",
+			wantOrder: []int{1, 1, 0},
+		},
+		{
+			name:      "multi digit",
+			query:     "VALUES ($9, $10, $11)",
+			wantQuery: "VALUES (?, ?, ?)",
+			wantOrder: []int{8, 9, 10},
+		},
+		{
+			name:      "quoted",
+			query:     `SELECT '$1', "$2", $3, '$'`,
+			wantQuery: `SELECT '$1', "$2", ?, '$'`,
+			wantOrder: []int{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, order := rebindDollar(test.query)
+			if query != test.wantQuery {
+				t.Errorf("query = %q, want %q", query, test.wantQuery)
+			}
+			if !reflect.DeepEqual(order, test.wantOrder) {
+				t.Errorf("order = %v, want %v", order, test.wantOrder)
+			}
+		})
+	}
+}
+
+func TestReorderArgs(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: "a"},
+		{Ordinal: 2, Value: "b"},
+	}
+
+	got, err := reorderArgs(args, []int{1, 1, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []driver.NamedValue{
+		{Ordinal: 1, Value: "b"},
+		{Ordinal: 2, Value: "b"},
+		{Ordinal: 3, Value: "a"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+
+	if _, err = reorderArgs(args, []int{2}); err == nil {
+		t.Errorf("expected error for missing argument")
+	}
+}
+
+func TestNewDollarDriver(t *testing.T) {
+	// database/sql prefers the connector of a driver, which would bypass the placeholder translation.
+	if _, ok := NewDollarDriver(mysql.MySQLDriver{}).(driver.DriverContext); ok {
+		t.Errorf("the wrapped driver must not implement driver.DriverContext")
+	}
+}
+
+func TestValidateDriver(t *testing.T) {
+	for _, driverName := range []string{"sqlite3", "postgres", DriverMariaDB} {
+		if err := ValidateDriver(driverName); err != nil {
+			t.Errorf("ValidateDriver(%q) = %v, want nil", driverName, err)
+		}
+	}
+
+	if err := ValidateDriver(DriverMySQL); !errors.Is(err, ErrMySQLNotSupported) {
+		t.Errorf("ValidateDriver(%q) = %v, want %v", DriverMySQL, err, ErrMySQLNotSupported)
+	}
+
+	if _, err := Open(DriverMySQL, "user:pass@tcp(localhost:3306)/gitness"); !errors.Is(err, ErrMySQLNotSupported) {
+		t.Errorf("Open(%q) = %v, want %v", DriverMySQL, err, ErrMySQLNotSupported)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const reencryptBatchSize = 100
+
+// ReencryptFunc returns the re-encrypted ciphertext and whether it was changed.
+type ReencryptFunc func(ciphertext []byte) ([]byte, bool, error)
+
+// Reencrypt re-encrypts the content of the data column for all rows of the table.
+// Rows are processed in batches and a row is only updated if its content didn't change in the meantime.
+// Rows with empty content are skipped. Returns the number of updated rows.
+func Reencrypt(
+	ctx context.Context,
+	db *sqlx.DB,
+	table string,
+	idColumn string,
+	dataColumn string,
+	reencrypt ReencryptFunc,
+) (int64, error) {
+	selectSQL, _, err := Builder.
+		Select(idColumn, dataColumn).
+		From(table).
+		Where(idColumn + " > ?").
+		OrderBy(idColumn).
+		Limit(reencryptBatchSize).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert select query to sql: %w", err)
+	}
+
+	// sqlite doesn't consider text and blob values equal, hence the stored content is compared as blob.
+	compareColumn := dataColumn
+	if strings.HasPrefix(db.DriverName(), "sqlite") {
+		compareColumn = "CAST(" + dataColumn + " AS BLOB)"
+	}
+
+	updateSQL, _, err := Builder.
+		Update(table).
+		Set(dataColumn, nil).
+		Where(idColumn+" = ?", nil).
+		Where(compareColumn+" = ?", nil).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert update query to sql: %w", err)
+	}
+
+	type row struct {
+		ID   int64
+		Data []byte
+	}
+
+	var total int64
+	var lastID int64
+
+	accessor := dbtx.GetAccessor(ctx, db)
+
+	for {
+		rows, err := accessor.QueryxContext(ctx, selectSQL, lastID)
+		if err != nil {
+			return total, ProcessSQLErrorf(ctx, err, "Failed to select rows for re-encryption")
+		}
+
+		batch := make([]row, 0, reencryptBatchSize)
+		for rows.Next() {
+			var r row
+			if err = rows.Scan(&r.ID, &r.Data); err != nil {
+				_ = rows.Close()
+				return total, ProcessSQLErrorf(ctx, err, "Failed to scan row for re-encryption")
+			}
+			batch = append(batch, r)
+		}
+		if err = rows.Close(); err != nil {
+			return total, ProcessSQLErrorf(ctx, err, "Failed to close rows")
+		}
+		if err = rows.Err(); err != nil {
+			return total, ProcessSQLErrorf(ctx, err, "Failed to read rows for re-encryption")
+		}
+
+		for _, r := range batch {
+			lastID = r.ID
+
+			if len(r.Data) == 0 {
+				continue
+			}
+
+			data, changed, err := reencrypt(r.Data)
+			if err != nil {
+				return total, fmt.Errorf("failed to re-encrypt content of row %d: %w", r.ID, err)
+			}
+			if !changed {
+				continue
+			}
+
+			result, err := accessor.ExecContext(ctx, updateSQL, data, r.ID, r.Data)
+			if err != nil {
+				return total, ProcessSQLErrorf(ctx, err, "Failed to update re-encrypted row")
+			}
+
+			n, err := result.RowsAffected()
+			if err != nil {
+				return total, ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+			}
+
+			total += n
+		}
+
+		if len(batch) < reencryptBatchSize {
+			return total, nil
+		}
+	}
+}
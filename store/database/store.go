@@ -13,7 +13,7 @@
 // limitations under the License.
 
 // Package database provides persistent data storage using
-// a postgres or sqlite3 database.
+// a postgres, mariadb or sqlite3 database.
 package database
 
 import (
@@ -37,27 +37,47 @@ const (
 type Migrator func(ctx context.Context, dbx *sqlx.DB) error
 
 // Builder is a global instance of the sql builder. we are able to
-// hardcode to postgres since sqlite3 is compatible with postgres.
+// hardcode to postgres since sqlite3 is compatible with postgres
+// and the placeholders are translated for mariadb by the wrapped driver.
 var Builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 // Connect to a database and verify with a ping.
 func Connect(ctx context.Context, driver string, datasource string) (*sqlx.DB, error) {
+	dbx, err := Open(driver, datasource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = pingDatabase(ctx, dbx); err != nil {
+		return nil, fmt.Errorf("failed to ping the db: %w", err)
+	}
+
+	return dbx, nil
+}
+
+// Open creates the database handle without verifying the connection.
+func Open(driver string, datasource string) (*sqlx.DB, error) {
+	if err := ValidateDriver(driver); err != nil {
+		return nil, err
+	}
+
 	datasource, err := prepareDatasourceForDriver(driver, datasource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare datasource: %w", err)
 	}
 
-	db, err := sql.Open(driver, datasource)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open the db: %w", err)
+	// the stores use postgres style placeholders, which are translated by the wrapped mariadb driver.
+	sqlDriver := driver
+	if IsMariaDB(driver) {
+		sqlDriver = mariaDBDollarDriver
 	}
 
-	dbx := sqlx.NewDb(db, driver)
-	if err = pingDatabase(ctx, dbx); err != nil {
-		return nil, fmt.Errorf("failed to ping the db: %w", err)
+	db, err := sql.Open(sqlDriver, datasource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the db: %w", err)
 	}
 
-	return dbx, nil
+	return sqlx.NewDb(db, driver), nil
 }
 
 // ConnectAndMigrate creates the database handle and migrates the database.
@@ -74,6 +94,14 @@ func ConnectAndMigrate(ctx context.Context, driver string, datasource string, mi
 	return dbx, nil
 }
 
+// ConfigurePool applies the connection pool configuration to the database handle.
+func ConfigurePool(db *sqlx.DB, config Config) {
+	db.SetMaxOpenConns(config.MaxOpenConnections)
+	db.SetMaxIdleConns(config.MaxIdleConnections)
+	db.SetConnMaxLifetime(config.ConnectionMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnectionMaxIdleTime)
+}
+
 // Must is a helper function that wraps a call to Connect
 // and panics if the error is non-nil.
 func Must(db *sqlx.DB, err error) *sqlx.DB {
@@ -104,6 +132,8 @@ func prepareDatasourceForDriver(driver string, datasource string) (string, error
 		url.RawQuery = query.Encode()
 
 		return url.String(), nil
+	case DriverMariaDB:
+		return prepareMariaDBDatasource(datasource)
 	default:
 		return datasource, nil
 	}
@@ -28,5 +28,5 @@ func isSQLUniqueConstraintError(original error) bool {
 		return pqErr.Code == "23505" // unique_violation
 	}
 
-	return false
+	return isMariaDBUniqueConstraintError(original)
 }
@@ -133,12 +133,15 @@ func (c *MemoryConsumer) Start(ctx context.Context) error {
 		}(streamID)
 	}
 
+	// messages that are already read are processed with a separate context to complete them during shutdown.
+	procCtx, procCancel := newProcessingContext(ctx, c.Config.ShutdownTimeout)
+
 	// start workers
 	for i := 0; i < c.Config.Concurrency; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			c.consume(ctx)
+			c.consume(ctx, procCtx)
 		}()
 	}
 
@@ -146,6 +149,7 @@ func (c *MemoryConsumer) Start(ctx context.Context) error {
 	go func() {
 		// wait for all go routines to complete
 		wg.Wait()
+		procCancel()
 
 		close(c.messageQueue)
 		close(c.infoCh)
@@ -164,64 +168,87 @@ func (c *MemoryConsumer) reader(ctx context.Context, streamID string) {
 		case <-ctx.Done():
 			return
 		case m := <-streamQueue:
-			c.messageQueue <- memoryMessage{
+			select {
+			case c.messageQueue <- memoryMessage{
 				message: m,
 				retries: 0,
+			}:
+			case <-ctx.Done():
+				return
 			}
 		}
 	}
 }
 
-func (c *MemoryConsumer) consume(ctx context.Context) {
+// consume processes the messages of the message queue. Once ctx is done,
+// the messages left in the queue are processed until the queue is empty or procCtx is done.
+func (c *MemoryConsumer) consume(ctx context.Context, procCtx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			c.drain(procCtx)
 			return
 		case m := <-c.messageQueue:
-			handler, ok := c.streams[m.streamID]
-			if !ok {
-				// we only take messages from registered streams, this should never happen.
-				// WARNING this will discard the message
-				c.pushError(fmt.Errorf("discard message with id '%s' from stream '%s' - doesn't belong to us",
-					m.id, m.streamID))
-				continue
-			}
+			c.process(procCtx, m)
+		}
+	}
+}
 
-			err := func() (err error) {
-				// Ensure that handlers don't cause panic.
-				defer func() {
-					if r := recover(); r != nil {
-						c.pushError(fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
-							m.id, m.streamID, debug.Stack()))
-					}
-				}()
-
-				return handler.handle(ctx, m.id, m.values)
-			}()
-
-			if err != nil {
-				c.pushError(fmt.Errorf("failed to process message with id '%s' in stream '%s' (retries: %d): %w",
-					m.id, m.streamID, m.retries, err))
-
-				if m.retries >= int64(handler.config.maxRetries) {
-					c.pushError(fmt.Errorf(
-						"discard message with id '%s' from stream '%s' - failed %d retries",
-						m.id, m.streamID, m.retries))
-					continue
-				}
-
-				// increase retry count
-				m.retries++
-
-				// requeue message for a retry (needs to be in a separate go func to avoid deadlock)
-				// IMPORTANT: this won't requeue to broker, only in this consumer's queue!
-				go func() {
-					// TODO: linear/exponential backoff relative to retry count might be good
-					time.Sleep(handler.config.idleTimeout)
-					c.messageQueue <- m
-				}()
+// drain processes the messages left in the message queue until it's empty or the context is done.
+func (c *MemoryConsumer) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		select {
+		case m := <-c.messageQueue:
+			c.process(ctx, m)
+		default:
+			return
+		}
+	}
+}
+
+func (c *MemoryConsumer) process(ctx context.Context, m memoryMessage) {
+	handler, ok := c.streams[m.streamID]
+	if !ok {
+		// we only take messages from registered streams, this should never happen.
+		// WARNING this will discard the message
+		c.pushError(fmt.Errorf("discard message with id '%s' from stream '%s' - doesn't belong to us",
+			m.id, m.streamID))
+		return
+	}
+
+	err := func() (err error) {
+		// Ensure that handlers don't cause panic.
+		defer func() {
+			if r := recover(); r != nil {
+				c.pushError(fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
+					m.id, m.streamID, debug.Stack()))
 			}
+		}()
+
+		return handler.handle(ctx, m.id, m.values)
+	}()
+
+	if err != nil {
+		c.pushError(fmt.Errorf("failed to process message with id '%s' in stream '%s' (retries: %d): %w",
+			m.id, m.streamID, m.retries, err))
+
+		if m.retries >= int64(handler.config.maxRetries) {
+			c.pushError(fmt.Errorf(
+				"discard message with id '%s' from stream '%s' - failed %d retries",
+				m.id, m.streamID, m.retries))
+			return
 		}
+
+		// increase retry count
+		m.retries++
+
+		// requeue message for a retry (needs to be in a separate go func to avoid deadlock)
+		// IMPORTANT: this won't requeue to broker, only in this consumer's queue!
+		go func() {
+			// TODO: linear/exponential backoff relative to retry count might be good
+			time.Sleep(handler.config.idleTimeout)
+			c.messageQueue <- m
+		}()
 	}
 }
 
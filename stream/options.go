@@ -56,6 +56,27 @@ func WithConcurrency(concurrency int) ConsumerOption {
 }
 
 // WithHandlerOptions sets up the default handler options of a stream consumer.
+func WithIdempotencyTTL(ttl time.Duration) ConsumerOption {
+	if ttl <= 0 {
+		// missconfiguration - panic to keep options clean
+		panic(fmt.Sprintf("provided idempotency ttl %s is invalid - has to be positive", ttl))
+	}
+	return consumerOptionFunc(func(c *ConsumerConfig) {
+		c.IdempotencyTTL = ttl
+	})
+}
+
+// WithShutdownTimeout sets up how long the stream consumer keeps processing already read messages when stopped.
+func WithShutdownTimeout(timeout time.Duration) ConsumerOption {
+	if timeout < 0 {
+		// missconfiguration - panic to keep options clean
+		panic(fmt.Sprintf("provided shutdown timeout %s is invalid - can't be negative", timeout))
+	}
+	return consumerOptionFunc(func(c *ConsumerConfig) {
+		c.ShutdownTimeout = timeout
+	})
+}
+
 func WithHandlerOptions(opts ...HandlerOption) ConsumerOption {
 	return consumerOptionFunc(func(c *ConsumerConfig) {
 		for _, opt := range opts {
@@ -167,18 +167,22 @@ func (c *RedisConsumer) Start(ctx context.Context) error {
 		c.reclaimer(ctx, reclaimInterval)
 	}()
 
+	// messages that are already read are processed with a separate context to complete them during shutdown.
+	procCtx, procCancel := newProcessingContext(ctx, c.Config.ShutdownTimeout)
+
 	for i := 0; i < c.Config.Concurrency; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			// launch redis message consumer, it will finish when the ctx is done
-			c.consumer(ctx)
+			// launch redis message consumer, it will finish when the ctx is done (after draining the queue)
+			c.consumer(ctx, procCtx)
 		}()
 	}
 
 	go func() {
 		// wait for all go routines to complete
 		wg.Wait()
+		procCancel()
 
 		// close all channels
 		close(c.messageQueue)
@@ -313,10 +317,13 @@ func (c *RedisConsumer) reader(ctx context.Context) {
 			// retrieve all messages across all streams and put them into the message queue
 			for _, stream := range resReadStream {
 				for _, m := range stream.Messages {
-					c.messageQueue <- message{
+					// if the consumer is stopped, the remaining messages stay pending (reprocessed on restart).
+					if !c.enqueue(ctx, message{
 						streamID: stream.Stream,
 						id:       m.ID,
 						values:   m.Values,
+					}) {
+						return
 					}
 				}
 			}
@@ -324,29 +331,17 @@ func (c *RedisConsumer) reader(ctx context.Context) {
 	}
 }
 
-// reclaimer periodically inspects pending messages with XPENDING command.
-// If a message sits longer than processingTimeout, we attempt to reclaim the message for this consumer
-// and enqueue it for processing.
-//
-//nolint:funlen,gocognit // refactor if needed
+// reclaimer periodically claims messages with XAUTOCLAIM command that are pending for longer than the idle timeout
+// (e.g. because the consumer that read them died mid-handling) and enqueues them for processing.
+// Messages that exceeded the max number of retries are discarded.
 func (c *RedisConsumer) reclaimer(ctx context.Context, reclaimInterval time.Duration) {
 	reclaimTimer := time.NewTimer(reclaimInterval)
 	defer func() {
 		reclaimTimer.Stop()
 	}()
 
-	const (
-		baseCount = 16
-		maxCount  = 1024
-	)
-
-	// the minimum message ID which we are querying for.
-	// redis treats "-" as smaller than any valid message ID
-	start := "-"
-	// the maximum message ID which we are querying for.
-	// redis treats "+" as bigger than any valid message ID
-	end := "+"
-	count := baseCount
+	// cursors stores the message ID from which XAUTOCLAIM continues scanning the pending entries of each stream.
+	cursors := make(map[string]string, len(c.streams))
 
 	for {
 		select {
@@ -354,119 +349,242 @@ func (c *RedisConsumer) reclaimer(ctx context.Context, reclaimInterval time.Dura
 			return
 		case <-reclaimTimer.C:
 			for streamID, handler := range c.streams {
-				resPending, errPending := c.rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
-					Stream: streamID,
-					Group:  c.groupName,
-					Start:  start,
-					End:    end,
-					Idle:   handler.config.idleTimeout,
-					Count:  int64(count),
-				}).Result()
-				if errPending != nil && !errors.Is(errPending, redis.Nil) {
-					c.pushError(fmt.Errorf("failed to fetch pending messages: %w", errPending))
-					continue
+				cursor, ok := cursors[streamID]
+				if !ok {
+					cursor = autoClaimStart
 				}
 
-				if len(resPending) == 0 {
-					continue
-				}
+				cursors[streamID] = c.reclaimStream(ctx, streamID, handler, cursor)
+			}
 
-				// It's safe to change start of the requested range for the next iteration to oldest message.
-				start = resPending[0].ID
-
-				for _, resMessage := range resPending {
-					if resMessage.RetryCount > int64(handler.config.maxRetries) {
-						// Retry count gets increased after every XCLAIM.
-						// Large retry count might mean there is something wrong with the message, so we'll XACK it.
-						// WARNING this will discard the message!
-						errAck := c.rdb.XAck(ctx, streamID, c.groupName, resMessage.ID).Err()
-						if errAck != nil {
-							c.pushError(fmt.Errorf(
-								"failed to force acknowledge (discard) message '%s' (Retries: %d) in stream '%s': %w",
-								resMessage.ID, resMessage.RetryCount, streamID, errAck))
-						} else {
-							retryCount := resMessage.RetryCount - 1 // redis is counting this execution as retry
-							c.pushError(fmt.Errorf(
-								"force acknowledged (discarded) message '%s' (Retries: %d) in stream '%s'",
-								resMessage.ID, retryCount, streamID))
-						}
-						continue
-					}
+			reclaimTimer.Reset(reclaimInterval)
+		}
+	}
+}
 
-					// Otherwise, claim the message so we can retry it.
-					claimedMessages, errClaim := c.rdb.XClaim(ctx, &redis.XClaimArgs{
-						Stream:   streamID,
-						Group:    c.groupName,
-						Consumer: c.consumerName,
-						MinIdle:  handler.config.idleTimeout,
-						Messages: []string{resMessage.ID},
-					}).Result()
-
-					if errors.Is(errClaim, redis.Nil) {
-						// Receiving redis.Nil here means the message is removed from the stream (because of MAXLEN).
-						// The only option is to acknowledge it with XACK.
-						errAck := c.rdb.XAck(ctx, streamID, c.groupName, resMessage.ID).Err()
-						if errAck != nil {
-							c.pushError(fmt.Errorf("failed to acknowledge failed message '%s' in stream '%s': %w",
-								resMessage.ID, streamID, errAck))
-						} else {
-							c.pushInfo(fmt.Sprintf("acknowledged failed message '%s' in stream '%s'",
-								resMessage.ID, streamID))
-						}
-
-						continue
-					}
+// autoClaimStart is the XAUTOCLAIM cursor for starting the scan of the pending entries list from the beginning.
+const autoClaimStart = "0-0"
+
+// reclaimStream claims pending messages of a single stream starting at the provided cursor.
+// It returns the cursor for the next run.
+//
+//nolint:funlen,gocognit // refactor if needed
+func (c *RedisConsumer) reclaimStream(ctx context.Context, streamID string, handler handler, cursor string) string {
+	const (
+		baseCount = 16
+		maxCount  = 1024
+	)
 
-					if errClaim != nil {
-						// This can happen if two consumers try to claim the same message at once.
-						// One would succeed and the other will get an error.
-						c.pushError(fmt.Errorf("failed to claim message '%s' in stream '%s': %w",
-							resMessage.ID, streamID, errClaim))
+	count := baseCount
 
-						continue
-					}
+	for {
+		res, err := c.rdb.Do(ctx, "XAUTOCLAIM", streamID, c.groupName, c.consumerName,
+			handler.config.idleTimeout.Milliseconds(), cursor, "COUNT", count).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			c.pushError(fmt.Errorf("failed to auto claim messages in stream '%s': %w", streamID, err))
+			return cursor
+		}
 
-					// This is not expected to happen (message will be retried or eventually discarded)
-					if len(claimedMessages) == 0 {
-						c.pushError(fmt.Errorf(
-							"no error when claiming message '%s' in stream '%s', but redis returned no message",
-							resMessage.ID, streamID))
+		next, claimedMessages, deletedIDs, err := parseXAutoClaimReply(res)
+		if err != nil {
+			c.pushError(fmt.Errorf("failed to parse auto claim response for stream '%s': %w", streamID, err))
+			return autoClaimStart
+		}
 
-						continue
-					}
+		// Messages that were removed from the stream (because of MAXLEN) can't be processed anymore.
+		// Redis 7 removes them from the pending entries list, older versions require an explicit XACK.
+		if len(deletedIDs) > 0 {
+			errAck := c.rdb.XAck(ctx, streamID, c.groupName, deletedIDs...).Err()
+			if errAck != nil {
+				c.pushError(fmt.Errorf("failed to acknowledge deleted messages %v in stream '%s': %w",
+					deletedIDs, streamID, errAck))
+			} else {
+				c.pushInfo(fmt.Sprintf("acknowledged deleted messages %v in stream '%s'", deletedIDs, streamID))
+			}
+		}
 
-					// we claimed only one message id so there is only one message in the slice
-					claimedMessage := claimedMessages[0]
-					c.messageQueue <- message{
-						streamID: streamID,
-						id:       claimedMessage.ID,
-						values:   claimedMessage.Values,
-					}
-				}
+		retries, err := c.retryCounts(ctx, streamID, claimedMessages)
+		if err != nil {
+			// without retry counts the messages can't be discarded - still process them, next time we'll try again.
+			c.pushError(err)
+		}
 
-				// If number of messages that we got is equal to the number that we requested
-				// it means that there's a lot for processing, so we'll increase number of messages
-				// that we'll pull in the next iteration.
-				if len(resPending) == count {
-					count *= 2
-					if count > maxCount {
-						count = maxCount
-					}
+		for _, claimedMessage := range claimedMessages {
+			// XAUTOCLAIM already counted this execution as a delivery, hence it's deducted.
+			if retryCount := retries[claimedMessage.ID] - 1; retryCount > int64(handler.config.maxRetries) {
+				// Large retry count might mean there is something wrong with the message, so we'll XACK it.
+				// WARNING this will discard the message!
+				errAck := c.rdb.XAck(ctx, streamID, c.groupName, claimedMessage.ID).Err()
+				if errAck != nil {
+					c.pushError(fmt.Errorf(
+						"failed to force acknowledge (discard) message '%s' (Retries: %d) in stream '%s': %w",
+						claimedMessage.ID, retryCount-1, streamID, errAck))
 				} else {
-					count = baseCount
+					c.pushError(fmt.Errorf(
+						"force acknowledged (discarded) message '%s' (Retries: %d) in stream '%s'",
+						claimedMessage.ID, retryCount-1, streamID))
 				}
+				continue
 			}
 
-			reclaimTimer.Reset(reclaimInterval)
+			if !c.enqueue(ctx, message{
+				streamID: streamID,
+				id:       claimedMessage.ID,
+				values:   claimedMessage.Values,
+			}) {
+				return cursor
+			}
+		}
+
+		// Redis returns the start cursor once the whole pending entries list was scanned.
+		if next == autoClaimStart {
+			return autoClaimStart
+		}
+
+		cursor = next
+
+		// If number of messages that we got is equal to the number that we requested
+		// it means that there's a lot for processing, so we'll keep claiming with a larger batch.
+		// Otherwise, we continue from the cursor in the next run.
+		if len(claimedMessages)+len(deletedIDs) < count {
+			return cursor
+		}
+
+		count *= 2
+		if count > maxCount {
+			count = maxCount
+		}
+	}
+}
+
+// retryCounts returns the delivery counts of the provided messages, which were claimed by this consumer.
+func (c *RedisConsumer) retryCounts(
+	ctx context.Context,
+	streamID string,
+	messages []redis.XMessage,
+) (map[string]int64, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	resPending, err := c.rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   streamID,
+		Group:    c.groupName,
+		Start:    messages[0].ID,
+		End:      messages[len(messages)-1].ID,
+		Count:    int64(len(messages)),
+		Consumer: c.consumerName,
+	}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to fetch retry counts of claimed messages in stream '%s': %w", streamID, err)
+	}
+
+	retries := make(map[string]int64, len(resPending))
+	for _, resMessage := range resPending {
+		retries[resMessage.ID] = resMessage.RetryCount
+	}
+
+	return retries, nil
+}
+
+// parseXAutoClaimReply parses the raw response of the XAUTOCLAIM command.
+// The response format differs between redis versions:
+// Redis 6.2 returns the cursor and the claimed messages, with nil entries for messages that were deleted.
+// Redis 7 returns the cursor, the claimed messages and the IDs of the deleted messages.
+// NOTE: XAutoClaim of the redis client doesn't support the Redis 7 response, hence the raw command is used.
+func parseXAutoClaimReply(res interface{}) (string, []redis.XMessage, []string, error) {
+	if res == nil {
+		return autoClaimStart, nil, nil, nil
+	}
+
+	parts, ok := res.([]interface{})
+	if !ok || len(parts) < 2 {
+		return "", nil, nil, fmt.Errorf("unexpected response %v", res)
+	}
+
+	cursor, ok := parts[0].(string)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("unexpected cursor %v", parts[0])
+	}
+
+	entries, ok := parts[1].([]interface{})
+	if !ok && parts[1] != nil {
+		return "", nil, nil, fmt.Errorf("unexpected messages %v", parts[1])
+	}
+
+	messages := make([]redis.XMessage, 0, len(entries))
+	var deleted []string
+
+	for _, entry := range entries {
+		fields, ok := entry.([]interface{})
+		if !ok || len(fields) != 2 {
+			// nil entries are deleted messages, their ID is unknown (covered by the next scan of redis 6.2).
+			continue
+		}
+
+		id, ok := fields[0].(string)
+		if !ok {
+			return "", nil, nil, fmt.Errorf("unexpected message id %v", fields[0])
+		}
+
+		if fields[1] == nil {
+			deleted = append(deleted, id)
+			continue
+		}
+
+		rawValues, ok := fields[1].([]interface{})
+		if !ok || len(rawValues)%2 != 0 {
+			return "", nil, nil, fmt.Errorf("unexpected values %v of message '%s'", fields[1], id)
+		}
+
+		values := make(map[string]interface{}, len(rawValues)/2)
+		for i := 0; i < len(rawValues); i += 2 {
+			key, ok := rawValues[i].(string)
+			if !ok {
+				return "", nil, nil, fmt.Errorf("unexpected value key %v of message '%s'", rawValues[i], id)
+			}
+			values[key] = rawValues[i+1]
+		}
+
+		messages = append(messages, redis.XMessage{ID: id, Values: values})
+	}
+
+	if len(parts) > 2 {
+		deletedIDs, ok := parts[2].([]interface{})
+		if !ok && parts[2] != nil {
+			return "", nil, nil, fmt.Errorf("unexpected deleted message ids %v", parts[2])
 		}
+		for _, rawID := range deletedIDs {
+			id, ok := rawID.(string)
+			if !ok {
+				return "", nil, nil, fmt.Errorf("unexpected deleted message id %v", rawID)
+			}
+			deleted = append(deleted, id)
+		}
+	}
+
+	return cursor, messages, deleted, nil
+}
+
+// enqueue puts the message into the message queue - returns false if the context is done before.
+func (c *RedisConsumer) enqueue(ctx context.Context, m message) bool {
+	select {
+	case c.messageQueue <- m:
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
 
 // consumer method consumes messages coming from Redis. The method terminates when messageQueue channel closes.
-func (c *RedisConsumer) consumer(ctx context.Context) {
+// Every message is processed at most once per consumer group and idempotency key (see beginProcessing),
+// which prevents double processing if a slow consumer's message gets claimed by another consumer.
+// Once ctx is done, the messages left in the queue are processed until the queue is empty or procCtx is done.
+func (c *RedisConsumer) consumer(ctx context.Context, procCtx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			c.drain(procCtx)
 			return
 		case m := <-c.messageQueue:
 			if m.id == "" {
@@ -474,37 +592,143 @@ func (c *RedisConsumer) consumer(ctx context.Context) {
 				return
 			}
 
-			handler, ok := c.streams[m.streamID]
-			if !ok {
-				// we don't want to ack the message
-				// maybe someone else can claim and process it (worst case it expires)
-				c.pushError(fmt.Errorf("received message '%s' in stream '%s' that doesn't belong to us, skip",
-					m.id, m.streamID))
-				continue
+			c.process(procCtx, m)
+		}
+	}
+}
+
+// drain processes the messages left in the message queue until it's empty or the context is done.
+// Messages that aren't processed stay pending and are claimed again after the idle timeout.
+func (c *RedisConsumer) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		select {
+		case m := <-c.messageQueue:
+			if m.id == "" {
+				return
 			}
 
-			err := func() (err error) {
-				// Ensure that handlers don't cause panic.
-				defer func() {
-					if r := recover(); r != nil {
-						c.pushError(fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
-							m.id, m.streamID, debug.Stack()))
-					}
-				}()
+			c.process(ctx, m)
+		default:
+			return
+		}
+	}
+}
 
-				return handler.handle(ctx, m.id, m.values)
-			}()
-			if err != nil {
-				c.pushError(fmt.Errorf("failed to process message '%s' in stream '%s': %w", m.id, m.streamID, err))
-				continue
-			}
+func (c *RedisConsumer) process(ctx context.Context, m message) {
+	handler, ok := c.streams[m.streamID]
+	if !ok {
+		// we don't want to ack the message
+		// maybe someone else can claim and process it (worst case it expires)
+		c.pushError(fmt.Errorf("received message '%s' in stream '%s' that doesn't belong to us, skip",
+			m.id, m.streamID))
+		return
+	}
 
-			err = c.rdb.XAck(ctx, m.streamID, c.groupName, m.id).Err()
-			if err != nil {
-				c.pushError(fmt.Errorf("failed to acknowledge message '%s' in stream '%s': %w", m.id, m.streamID, err))
-				continue
+	idempotencyKey := c.idempotencyKey(m)
+	state, err := c.beginProcessing(ctx, idempotencyKey, handler.config.idleTimeout)
+	if err != nil {
+		// we don't want to ack the message, it will be claimed again after the idle timeout.
+		c.pushError(fmt.Errorf("failed to begin processing of message '%s' in stream '%s': %w",
+			m.id, m.streamID, err))
+		return
+	}
+
+	switch state {
+	case "":
+	case idempotencyStateDone:
+		// the message was processed already (e.g. the previous acknowledgement failed) - only ack it.
+		c.pushInfo(fmt.Sprintf("skipped already processed message '%s' in stream '%s'", m.id, m.streamID))
+		c.ack(ctx, m)
+		return
+	default:
+		// another consumer is processing the message - if it dies, the message gets claimed again.
+		c.pushInfo(fmt.Sprintf("skipped message '%s' in stream '%s' that is being processed by another consumer",
+			m.id, m.streamID))
+		return
+	}
+
+	err = func() (err error) {
+		// Ensure that handlers don't cause panic.
+		defer func() {
+			if r := recover(); r != nil {
+				c.pushError(fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
+					m.id, m.streamID, debug.Stack()))
+				err = errors.New("handler panicked")
 			}
-		}
+		}()
+
+		return handler.handle(ctx, m.id, m.values)
+	}()
+	if err != nil {
+		c.pushError(fmt.Errorf("failed to process message '%s' in stream '%s': %w", m.id, m.streamID, err))
+		c.abortProcessing(ctx, idempotencyKey)
+		return
+	}
+
+	c.completeProcessing(ctx, idempotencyKey)
+	c.ack(ctx, m)
+}
+
+func (c *RedisConsumer) ack(ctx context.Context, m message) {
+	err := c.rdb.XAck(ctx, m.streamID, c.groupName, m.id).Err()
+	if err != nil {
+		c.pushError(fmt.Errorf("failed to acknowledge message '%s' in stream '%s': %w", m.id, m.streamID, err))
+	}
+}
+
+const (
+	idempotencyStateProcessing = "processing"
+	idempotencyStateDone       = "done"
+)
+
+// idempotencyKey returns the redis key used to track the processing of the message within the consumer group.
+func (c *RedisConsumer) idempotencyKey(m message) string {
+	key := m.streamID + ":" + m.id
+	if v, ok := m.values[IdempotencyKeyField].(string); ok && v != "" {
+		key = m.streamID + ":" + v
+	}
+
+	return c.namespace + ":idempotency:" + c.groupName + ":" + key
+}
+
+// beginProcessing marks the message as being processed by this consumer.
+// If the message was already processed or is being processed by another consumer, the marker isn't changed
+// and its state is returned. Otherwise, an empty state is returned.
+// The processing marker expires after the idle timeout - that's when the message can be claimed by others.
+func (c *RedisConsumer) beginProcessing(ctx context.Context, key string, idleTimeout time.Duration) (string, error) {
+	ok, err := c.rdb.SetNX(ctx, key, idempotencyStateProcessing, idleTimeout).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to set idempotency key '%s': %w", key, err)
+	}
+	if ok {
+		return "", nil
+	}
+
+	state, err := c.rdb.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// the marker expired in the meantime, the next claim of the message will process it.
+		return idempotencyStateProcessing, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get idempotency key '%s': %w", key, err)
+	}
+
+	return state, nil
+}
+
+// completeProcessing marks the message as processed, which prevents processing it again.
+func (c *RedisConsumer) completeProcessing(ctx context.Context, key string) {
+	err := c.rdb.Set(ctx, key, idempotencyStateDone, c.Config.IdempotencyTTL).Err()
+	if err != nil {
+		c.pushError(fmt.Errorf("failed to mark idempotency key '%s' as done: %w", key, err))
+	}
+}
+
+// abortProcessing removes the processing marker of the message, which allows to retry it right away once claimed.
+func (c *RedisConsumer) abortProcessing(ctx context.Context, key string) {
+	err := c.rdb.Del(ctx, key).Err()
+	if err != nil {
+		c.pushError(fmt.Errorf("failed to remove idempotency key '%s': %w", key, err))
 	}
 }
 
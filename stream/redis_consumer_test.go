@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestParseXAutoClaimReply(t *testing.T) {
+	message := []interface{}{"1-0", []interface{}{"event", "data"}}
+
+	tests := []struct {
+		name        string
+		res         interface{}
+		expCursor   string
+		expMessages []redis.XMessage
+		expDeleted  []string
+	}{
+		{
+			name:      "nil",
+			res:       nil,
+			expCursor: autoClaimStart,
+		},
+		{
+			name:        "redis 6.2",
+			res:         []interface{}{"2-0", []interface{}{message, []interface{}{"3-0", nil}}},
+			expCursor:   "2-0",
+			expMessages: []redis.XMessage{{ID: "1-0", Values: map[string]interface{}{"event": "data"}}},
+			expDeleted:  []string{"3-0"},
+		},
+		{
+			name:        "redis 7",
+			res:         []interface{}{"0-0", []interface{}{message}, []interface{}{"3-0", "4-0"}},
+			expCursor:   autoClaimStart,
+			expMessages: []redis.XMessage{{ID: "1-0", Values: map[string]interface{}{"event": "data"}}},
+			expDeleted:  []string{"3-0", "4-0"},
+		},
+		{
+			name:      "empty",
+			res:       []interface{}{"0-0", []interface{}{}, []interface{}{}},
+			expCursor: autoClaimStart,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cursor, messages, deleted, err := parseXAutoClaimReply(test.res)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if cursor != test.expCursor {
+				t.Errorf("cursor: want %s, got %s", test.expCursor, cursor)
+			}
+			if len(messages) != 0 || len(test.expMessages) != 0 {
+				if !reflect.DeepEqual(messages, test.expMessages) {
+					t.Errorf("messages: want %v, got %v", test.expMessages, messages)
+				}
+			}
+			if !reflect.DeepEqual(deleted, test.expDeleted) {
+				t.Errorf("deleted: want %v, got %v", test.expDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestParseXAutoClaimReply_Invalid(t *testing.T) {
+	invalid := []interface{}{
+		"OK",
+		[]interface{}{"0-0"},
+		[]interface{}{1, []interface{}{}},
+		[]interface{}{"0-0", []interface{}{[]interface{}{"1-0", []interface{}{"event"}}}},
+	}
+
+	for _, res := range invalid {
+		if _, _, _, err := parseXAutoClaimReply(res); err == nil {
+			t.Errorf("expected error for response %v", res)
+		}
+	}
+}
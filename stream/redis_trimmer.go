@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisTrimmer provides functionality to enforce retention policies on Redis streams
+// and to inspect the state of the streams and their consumer groups.
+type RedisTrimmer struct {
+	rdb redis.UniversalClient
+	// namespace specifies the namespace of the keys - any stream key will be prefixed with it
+	namespace string
+}
+
+// GroupInfo contains the state of a consumer group of a stream.
+type GroupInfo struct {
+	// Name is the name of the consumer group.
+	Name string
+	// Pending is the number of messages that were read but not yet acknowledged by the group.
+	Pending int64
+	// Lag is the number of messages in the stream that weren't read by the group yet.
+	// It's -1 in case the lag is unknown (requires Redis 7).
+	Lag int64
+}
+
+func NewRedisTrimmer(rdb redis.UniversalClient, namespace string) *RedisTrimmer {
+	return &RedisTrimmer{
+		rdb:       rdb,
+		namespace: namespace,
+	}
+}
+
+// Streams returns the IDs of all streams that match the provided pattern (e.g. "events:*").
+// NOTE: In case of a redis cluster only the streams of a single node are returned.
+func (t *RedisTrimmer) Streams(ctx context.Context, pattern string) ([]string, error) {
+	const count = 100
+
+	var (
+		cursor  uint64
+		streams []string
+	)
+
+	prefix := transposeStreamID(t.namespace, "")
+	for {
+		keys, next, err := t.rdb.ScanType(ctx, cursor, prefix+pattern, count, "stream").Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan for streams matching '%s': %w", pattern, err)
+		}
+
+		for _, key := range keys {
+			streams = append(streams, strings.TrimPrefix(key, prefix))
+		}
+
+		if next == 0 {
+			return streams, nil
+		}
+
+		cursor = next
+	}
+}
+
+// Trim removes the oldest messages of the stream so it contains at most maxLength messages
+// and no messages older than maxAge. A non-positive maxLength or maxAge disables the respective limit.
+// The trimming is approximate (trimming happens only on whole internal nodes), which is far more efficient.
+// It returns the number of removed messages.
+func (t *RedisTrimmer) Trim(ctx context.Context, streamID string, maxLength int64, maxAge time.Duration) (int64, error) {
+	transposedStreamID := transposeStreamID(t.namespace, streamID)
+
+	var total int64
+
+	if maxLength > 0 {
+		n, err := t.rdb.XTrimMaxLenApprox(ctx, transposedStreamID, maxLength, 0).Result()
+		if err != nil {
+			return total, fmt.Errorf("failed to trim stream '%s' to max length %d: %w", streamID, maxLength, err)
+		}
+		total += n
+	}
+
+	if maxAge > 0 {
+		// message IDs start with the unix time in milliseconds at which the message was added.
+		minID := strconv.FormatInt(time.Now().Add(-maxAge).UnixMilli(), 10) + "-0"
+		n, err := t.rdb.XTrimMinIDApprox(ctx, transposedStreamID, minID, 0).Result()
+		if err != nil {
+			return total, fmt.Errorf("failed to trim stream '%s' to max age %s: %w", streamID, maxAge, err)
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// Length returns the number of messages in the stream.
+func (t *RedisTrimmer) Length(ctx context.Context, streamID string) (int64, error) {
+	n, err := t.rdb.XLen(ctx, transposeStreamID(t.namespace, streamID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get length of stream '%s': %w", streamID, err)
+	}
+
+	return n, nil
+}
+
+// Groups returns the state of all consumer groups of the stream.
+func (t *RedisTrimmer) Groups(ctx context.Context, streamID string) ([]GroupInfo, error) {
+	// NOTE: XInfoGroups of the redis client doesn't return the lag, hence the raw command is used.
+	res, err := t.rdb.Do(ctx, "XINFO", "GROUPS", transposeStreamID(t.namespace, streamID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consumer groups of stream '%s': %w", streamID, err)
+	}
+
+	groups, err := parseXInfoGroupsReply(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse consumer groups of stream '%s': %w", streamID, err)
+	}
+
+	return groups, nil
+}
+
+// parseXInfoGroupsReply parses the raw response of the XINFO GROUPS command.
+// Each group is returned as a list of alternating field names and values.
+func parseXInfoGroupsReply(res interface{}) ([]GroupInfo, error) {
+	rawGroups, ok := res.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %v", res)
+	}
+
+	groups := make([]GroupInfo, len(rawGroups))
+	for i, rawGroup := range rawGroups {
+		fields, ok := rawGroup.([]interface{})
+		if !ok || len(fields)%2 != 0 {
+			return nil, fmt.Errorf("unexpected group %v", rawGroup)
+		}
+
+		group := GroupInfo{Lag: -1}
+		for j := 0; j < len(fields); j += 2 {
+			field, _ := fields[j].(string)
+			switch field {
+			case "name":
+				group.Name, _ = fields[j+1].(string)
+			case "pending":
+				group.Pending, _ = fields[j+1].(int64)
+			case "lag":
+				// lag is nil if redis can't determine it (e.g. after messages were deleted from the stream).
+				if lag, ok := fields[j+1].(int64); ok {
+					group.Lag = lag
+				}
+			}
+		}
+
+		if group.Name == "" {
+			return nil, fmt.Errorf("group without name %v", rawGroup)
+		}
+
+		groups[i] = group
+	}
+
+	return groups, nil
+}
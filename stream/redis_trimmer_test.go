@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseXInfoGroupsReply(t *testing.T) {
+	res := []interface{}{
+		[]interface{}{
+			"name", "webhook", "consumers", int64(2), "pending", int64(3),
+			"last-delivered-id", "5-0", "entries-read", int64(5), "lag", int64(7),
+		},
+		[]interface{}{
+			"name", "pullreq", "consumers", int64(1), "pending", int64(0),
+			"last-delivered-id", "1-0", "entries-read", nil, "lag", nil,
+		},
+		[]interface{}{
+			"name", "legacy", "consumers", int64(1), "pending", int64(1), "last-delivered-id", "2-0",
+		},
+	}
+
+	groups, err := parseXInfoGroupsReply(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	exp := []GroupInfo{
+		{Name: "webhook", Pending: 3, Lag: 7},
+		{Name: "pullreq", Pending: 0, Lag: -1},
+		{Name: "legacy", Pending: 1, Lag: -1},
+	}
+	if !reflect.DeepEqual(groups, exp) {
+		t.Errorf("want %v, got %v", exp, groups)
+	}
+
+	if _, err = parseXInfoGroupsReply([]interface{}{[]interface{}{"pending"}}); err == nil {
+		t.Error("expected error for invalid response")
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"time"
+)
+
+// detachedContext keeps the values of its parent context, but isn't canceled with it.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// newProcessingContext returns the context used for processing messages.
+// Once ctx is done, consumers stop reading new messages from the stream, but the messages that are
+// already being processed (or queued locally) can still be completed within the provided shutdown timeout.
+func newProcessingContext(ctx context.Context, shutdownTimeout time.Duration) (context.Context, context.CancelFunc) {
+	procCtx, cancel := context.WithCancel(detachedContext{ctx})
+
+	go func() {
+		select {
+		case <-procCtx.Done():
+			return
+		case <-ctx.Done():
+		}
+
+		timer := time.NewTimer(shutdownTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-procCtx.Done():
+		case <-timer.C:
+			cancel()
+		}
+	}()
+
+	return procCtx, cancel
+}
@@ -21,11 +21,18 @@ import (
 	"time"
 )
 
+// IdempotencyKeyField is the optional payload field containing the idempotency key of a message.
+// Messages with the same idempotency key are processed only once per consumer group.
+// If the field isn't set, the stream message ID is used as idempotency key.
+const IdempotencyKeyField = "idempotency_key"
+
 var (
 	ErrAlreadyStarted = errors.New("consumer already started")
 
 	defaultConfig = ConsumerConfig{
-		Concurrency: 2,
+		Concurrency:     2,
+		IdempotencyTTL:  24 * time.Hour,
+		ShutdownTimeout: 30 * time.Second,
 		DefaultHandlerConfig: HandlerConfig{
 			idleTimeout: 1 * time.Minute,
 			maxRetries:  2,
@@ -39,6 +46,15 @@ type ConsumerConfig struct {
 	// Concurrency specifies the number of worker go routines executing stream handlers.
 	Concurrency int
 
+	// IdempotencyTTL specifies how long a processed message is remembered to prevent processing it again
+	// (e.g. if the acknowledgement failed or the message was sent twice with the same idempotency key).
+	// NOTE: Only used by consumers that are shared across instances (redis).
+	IdempotencyTTL time.Duration
+
+	// ShutdownTimeout specifies how long the consumer keeps processing the messages it already read
+	// (the in-flight messages and the ones queued locally) once it's being stopped.
+	ShutdownTimeout time.Duration
+
 	// DefaultHandlerConfig is the default config used for stream handlers.
 	DefaultHandlerConfig HandlerConfig
 }
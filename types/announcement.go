@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Announcement is a message shown to all users of the instance, like a notice of a maintenance window.
+type Announcement struct {
+	ID       int64                     `json:"id"`
+	Message  string                    `json:"message"`
+	Severity enum.AnnouncementSeverity `json:"severity"`
+	// Starts is the time the announcement is shown from (unix milliseconds), 0 shows it immediately.
+	Starts int64 `json:"starts"`
+	// Ends is the time the announcement is shown until (unix milliseconds), 0 shows it until it's deleted.
+	Ends        int64 `json:"ends"`
+	Dismissible bool  `json:"dismissible"`
+	CreatedBy   int64 `json:"created_by"`
+	Created     int64 `json:"created"`
+	Updated     int64 `json:"updated"`
+}
+
+// IsActive returns true if the announcement is shown at the provided time (unix milliseconds).
+func (a *Announcement) IsActive(now int64) bool {
+	return a.Starts <= now && (a.Ends == 0 || a.Ends > now)
+}
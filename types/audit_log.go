@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// AuditLog is the record of a mutating API request, as captured by the audit middleware.
+// It's correlated with the server logs and git hook calls by the request ID.
+type AuditLog struct {
+	ID          int64  `db:"audit_log_id"           json:"id"`
+	RequestID   string `db:"audit_log_request_id"   json:"request_id"`
+	PrincipalID *int64 `db:"audit_log_principal_id" json:"principal_id,omitempty"`
+	Method      string `db:"audit_log_method"       json:"method"`
+	Path        string `db:"audit_log_path"         json:"path"`
+	Query       string `db:"audit_log_query"        json:"query"`
+	RemoteAddr  string `db:"audit_log_remote_addr"  json:"remote_addr"`
+	UserAgent   string `db:"audit_log_user_agent"   json:"user_agent"`
+	Status      int    `db:"audit_log_status"       json:"status"`
+	// RequestBody is the size-capped request body with secrets redacted.
+	RequestBody          string `db:"audit_log_request_body"           json:"request_body"`
+	RequestBodyTruncated bool   `db:"audit_log_request_body_truncated" json:"request_body_truncated"`
+	// Duration is the time it took to serve the request (in milliseconds).
+	Duration int64 `db:"audit_log_duration" json:"duration"`
+	Created  int64 `db:"audit_log_created"  json:"created"`
+}
+
+// AuditAccessor describes the API request of an action that records itself in the audit log
+// (e.g. to include details like a justification that aren't part of the request).
+type AuditAccessor struct {
+	RequestID  string
+	Path       string
+	RemoteAddr string
+	UserAgent  string
+}
+
+// NewAuditLog returns the audit log of a successful action of the principal, performed by the described request.
+// The method identifies the action, the details of the action are recorded as query.
+func NewAuditLog(
+	accessor AuditAccessor,
+	principalID int64,
+	method string,
+	details url.Values,
+	created int64,
+) *AuditLog {
+	return &AuditLog{
+		RequestID:   accessor.RequestID,
+		PrincipalID: &principalID,
+		Method:      method,
+		Path:        accessor.Path,
+		Query:       details.Encode(),
+		RemoteAddr:  accessor.RemoteAddr,
+		UserAgent:   accessor.UserAgent,
+		Status:      http.StatusOK,
+		Created:     created,
+	}
+}
+
+// AuditLogFilter stores audit log query parameters.
+type AuditLogFilter struct {
+	Page        int    `json:"page"`
+	Size        int    `json:"size"`
+	RequestID   string `json:"request_id"`
+	PrincipalID int64  `json:"principal_id"`
+	// Since filters out audit logs created before the provided timestamp (unix milliseconds).
+	Since int64 `json:"since"`
+}
@@ -79,6 +79,9 @@ type CheckPayload struct {
 // CheckListOptions holds list status checks query parameters.
 type CheckListOptions struct {
 	ListQueryFilter
+	// CursorID is the ID of the last status check of the previous page.
+	// If set, keyset pagination is used and Page is ignored.
+	CursorID int64 `json:"-"`
 }
 
 // CheckRecentOptions holds list recent status check query parameters.
@@ -40,9 +40,21 @@ type Config struct {
 	UserSignupEnabled   bool `envconfig:"GITNESS_USER_SIGNUP_ENABLED" default:"true"`
 	NestedSpacesEnabled bool `envconfig:"GITNESS_NESTED_SPACES_ENABLED" default:"false"`
 
+	// UserSignupAllowedDomains restricts the registration to email addresses of the domains (any domain if empty).
+	UserSignupAllowedDomains []string `envconfig:"GITNESS_USER_SIGNUP_ALLOWED_DOMAINS"`
+	// UserSignupApprovalRequired requires an admin to approve new users before they can login.
+	UserSignupApprovalRequired bool `envconfig:"GITNESS_USER_SIGNUP_APPROVAL_REQUIRED" default:"false"`
+
+	// SpacePathAliasExpiry is for how long requests using the previous path of a renamed or moved space
+	// are redirected to its new path. Afterwards they fail with 410 Gone (0 means aliases never expire).
+	SpacePathAliasExpiry time.Duration `envconfig:"GITNESS_SPACE_PATH_ALIAS_EXPIRY" default:"2160h"`
+
 	// PublicResourceCreationEnabled specifies whether a user can create publicly accessible resources.
 	PublicResourceCreationEnabled bool `envconfig:"GITNESS_PUBLIC_RESOURCE_CREATION_ENABLED" default:"true"`
 
+	// DefaultPublic specifies whether repositories and spaces created without an explicit visibility are public.
+	DefaultPublic bool `envconfig:"GITNESS_DEFAULT_PUBLIC" default:"false"`
+
 	Profiler struct {
 		Type        string `envconfig:"GITNESS_PROFILER_TYPE"`
 		ServiceName string `envconfig:"GITNESS_PROFILER_SERVICE_NAME" default:"gitness"`
@@ -107,12 +119,38 @@ type Config struct {
 			// Duration defines cache duration of last commit.
 			Duration time.Duration `envconfig:"GITNESS_GIT_LAST_COMMIT_CACHE_DURATION" default:"12h"`
 		}
+
+		// BandwidthLimit holds the limits (in bytes per second) of git transfers over http (0 means no limit).
+		// Spaces can further restrict the limit per connection for their repositories.
+		// NOTE: git's smart http protocol doesn't support resuming interrupted transfers,
+		// clients have to restart interrupted clones (fetches only transfer missing objects).
+		BandwidthLimit struct {
+			// PerConnection is the limit of a single clone, fetch or push.
+			PerConnection int64 `envconfig:"GITNESS_GIT_BANDWIDTH_LIMIT_PER_CONNECTION"`
+			// PerPrincipal is the limit shared by all concurrent transfers of a principal.
+			PerPrincipal int64 `envconfig:"GITNESS_GIT_BANDWIDTH_LIMIT_PER_PRINCIPAL"`
+		}
+
+		// ExternalHooks defines the execution of external pre-receive hooks registered for spaces.
+		ExternalHooks struct {
+			// ScriptsDir is the directory containing the scripts that can be registered as script hooks.
+			// If not set, script hooks are disabled.
+			ScriptsDir string `envconfig:"GITNESS_GIT_EXTERNAL_HOOKS_SCRIPTS_DIR"`
+			// DefaultTimeout is the timeout of hooks that don't define their own timeout.
+			DefaultTimeout time.Duration `envconfig:"GITNESS_GIT_EXTERNAL_HOOKS_DEFAULT_TIMEOUT" default:"10s"`
+			// MaxTimeout is the maximum timeout hooks can define.
+			MaxTimeout time.Duration `envconfig:"GITNESS_GIT_EXTERNAL_HOOKS_MAX_TIMEOUT" default:"60s"`
+		}
 	}
 
 	// Encrypter defines the parameters for the encrypter
 	Encrypter struct {
 		Secret       string `envconfig:"GITNESS_ENCRYPTER_SECRET"` // key used for encryption
 		MixedContent bool   `envconfig:"GITNESS_ENCRYPTER_MIXED_CONTENT"`
+
+		// PreviousSecrets are keys that were used for encryption before the key was rotated.
+		// They are only used for decryption until all content is re-encrypted using the current key.
+		PreviousSecrets []string `envconfig:"GITNESS_ENCRYPTER_PREVIOUS_SECRETS"`
 	}
 
 	// Server defines the server configuration parameters.
@@ -121,6 +159,9 @@ type Config struct {
 		HTTP struct {
 			Port  int    `envconfig:"GITNESS_HTTP_PORT" default:"3000"`
 			Proto string `envconfig:"GITNESS_HTTP_PROTO" default:"http"`
+			// ReusePort sets SO_REUSEPORT on the listening sockets, which allows a new instance to bind
+			// the same port while the old instance is still draining (rolling restarts on the same host).
+			ReusePort bool `envconfig:"GITNESS_HTTP_REUSE_PORT" default:"false"`
 		}
 
 		// Acme defines Acme configuration parameters.
@@ -148,20 +189,37 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// RunnerLeaseDuration is the time after which an external runner is considered lost if it stops
+		// sending heartbeats. Stages leased by a lost runner are requeued or failed.
+		RunnerLeaseDuration time.Duration `envconfig:"GITNESS_CI_RUNNER_LEASE_DURATION" default:"2m"`
+		// RunnerMaxPollWait is the maximum time a stage request of an external runner is held open.
+		RunnerMaxPollWait time.Duration `envconfig:"GITNESS_CI_RUNNER_MAX_POLL_WAIT" default:"30s"`
 	}
 
 	// Database defines the database configuration parameters.
 	Database struct {
+		// Driver is the database driver: sqlite3, postgres or mariadb (requires MariaDB 10.6+, MySQL isn't supported).
 		Driver     string `envconfig:"GITNESS_DATABASE_DRIVER" default:"sqlite3"`
 		Datasource string `envconfig:"GITNESS_DATABASE_DATASOURCE" default:"database.sqlite3"`
+
+		// MaxOpenConnections is the maximum number of open connections (0 means unlimited).
+		MaxOpenConnections int `envconfig:"GITNESS_DATABASE_MAX_OPEN_CONNECTIONS" default:"0"`
+		// MaxIdleConnections is the maximum number of idle connections kept in the pool.
+		MaxIdleConnections int `envconfig:"GITNESS_DATABASE_MAX_IDLE_CONNECTIONS" default:"2"`
+		// ConnectionMaxLifetime is the maximum time a connection may be reused (0 means forever).
+		ConnectionMaxLifetime time.Duration `envconfig:"GITNESS_DATABASE_CONNECTION_MAX_LIFETIME" default:"0"`
+		// ConnectionMaxIdleTime is the maximum time a connection may be idle (0 means forever).
+		ConnectionMaxIdleTime time.Duration `envconfig:"GITNESS_DATABASE_CONNECTION_MAX_IDLE_TIME" default:"0"`
 	}
 
 	// BlobStore defines the blob storage configuration parameters.
 	BlobStore struct {
-		// Provider is a name of blob storage service like filesystem or gcs
+		// Provider is a name of blob storage service like filesystem, gcs, s3 or azure
 		Provider blob.Provider `envconfig:"GITNESS_BLOBSTORE_PROVIDER" default:"filesystem"`
 		// Bucket is a path to the directory where the files will be stored when using filesystem blob storage,
-		// in case of gcs provider this will be the actual bucket where the images are stored.
+		// in case of gcs and s3 provider this will be the actual bucket where the images are stored
+		// and in case of azure provider the storage container.
 		Bucket string `envconfig:"GITNESS_BLOBSTORE_BUCKET"`
 
 		// In case of GCS provider, this is expected to be the path to the service account key file.
@@ -171,6 +229,42 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// SignedURLExpiry is the time for which signed download URLs of gcs, s3 and azure storage are valid.
+		SignedURLExpiry time.Duration `envconfig:"GITNESS_BLOBSTORE_SIGNED_URL_EXPIRY" default:"1h"`
+
+		GCS struct {
+			// KMSKeyName is the optional name of the Cloud KMS key used to encrypt uploaded objects.
+			KMSKeyName string `envconfig:"GITNESS_BLOBSTORE_GCS_KMS_KEY_NAME"`
+		}
+
+		// S3 configures the s3 provider, the bucket is used as S3 bucket.
+		S3 struct {
+			Region string `envconfig:"GITNESS_BLOBSTORE_S3_REGION"`
+			// Endpoint is the optional endpoint of an S3 compatible service (e.g. minio).
+			Endpoint  string `envconfig:"GITNESS_BLOBSTORE_S3_ENDPOINT"`
+			PathStyle bool   `envconfig:"GITNESS_BLOBSTORE_S3_PATH_STYLE"`
+
+			// AccessKeyID and SecretAccessKey are optional, the default AWS credential chain is used if not set.
+			AccessKeyID     string `envconfig:"GITNESS_BLOBSTORE_S3_ACCESS_KEY_ID"`
+			SecretAccessKey string `envconfig:"GITNESS_BLOBSTORE_S3_SECRET_ACCESS_KEY"`
+
+			// SSE is the server-side encryption of uploaded objects (AES256 or aws:kms).
+			SSE blob.S3SSE `envconfig:"GITNESS_BLOBSTORE_S3_SSE"`
+			// SSEKMSKeyID is the optional KMS key used with aws:kms encryption.
+			SSEKMSKeyID string `envconfig:"GITNESS_BLOBSTORE_S3_SSE_KMS_KEY_ID"`
+		}
+
+		// Azure configures the azure provider, the bucket is used as storage container.
+		Azure struct {
+			AccountName string `envconfig:"GITNESS_BLOBSTORE_AZURE_ACCOUNT_NAME"`
+			AccountKey  string `envconfig:"GITNESS_BLOBSTORE_AZURE_ACCOUNT_KEY"`
+			// Endpoint is the optional blob service endpoint (defaults to https://<account>.blob.core.windows.net).
+			// For path-style endpoints other than localhost or an IP address the path has to contain the account.
+			Endpoint string `envconfig:"GITNESS_BLOBSTORE_AZURE_ENDPOINT"`
+			// EncryptionScope is the optional encryption scope used to encrypt uploaded blobs.
+			EncryptionScope string `envconfig:"GITNESS_BLOBSTORE_AZURE_ENCRYPTION_SCOPE"`
+		}
 	}
 
 	// Token defines token configuration parameters.
@@ -179,6 +273,23 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// Maintenance defines the maintenance mode of the instance.
+	Maintenance struct {
+		// ReadOnly enables the read-only mode (e.g. for backups or migrations), it can't be disabled at runtime.
+		ReadOnly bool `envconfig:"GITNESS_MAINTENANCE_READ_ONLY" default:"false"`
+		// Message is shown to users whose requests are rejected because of the read-only mode.
+		Message string `envconfig:"GITNESS_MAINTENANCE_MESSAGE"`
+	}
+
+	// SignedURL defines the parameters of the short-lived signed URLs for raw content and archive downloads.
+	SignedURL struct {
+		// Secret is the key used to sign the URLs. If not set, the encrypter secret is used instead.
+		// If neither is set, a random key is generated on startup, so URLs are only valid on the same instance.
+		Secret        string        `envconfig:"GITNESS_SIGNED_URL_SECRET"`
+		DefaultExpiry time.Duration `envconfig:"GITNESS_SIGNED_URL_DEFAULT_EXPIRY" default:"5m"`
+		MaxExpiry     time.Duration `envconfig:"GITNESS_SIGNED_URL_MAX_EXPIRY" default:"1h"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -193,7 +304,7 @@ type Config struct {
 	Cors struct {
 		AllowedOrigins   []string `envconfig:"GITNESS_CORS_ALLOWED_ORIGINS"   default:"*"`
 		AllowedMethods   []string `envconfig:"GITNESS_CORS_ALLOWED_METHODS"   default:"GET,POST,PATCH,PUT,DELETE,OPTIONS"`
-		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id"` //nolint:lll // struct tags can't be multiline
+		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id,Idempotency-Key"` //nolint:lll // struct tags can't be multiline
 		ExposedHeaders   []string `envconfig:"GITNESS_CORS_EXPOSED_HEADERS"   default:"Link"`
 		AllowCredentials bool     `envconfig:"GITNESS_CORS_ALLOW_CREDENTIALS" default:"true"`
 		MaxAge           int      `envconfig:"GITNESS_CORS_MAX_AGE"           default:"300"`
@@ -218,7 +329,37 @@ type Config struct {
 		ReferrerPolicy        string            `envconfig:"GITNESS_HTTP_REFERRER_POLICY"`
 	}
 
+	// RateLimit defines the rate limiting of the rest API.
+	// Authenticated requests are limited per token (or principal), anonymous requests per client IP.
+	RateLimit struct {
+		Enabled bool `envconfig:"GITNESS_RATE_LIMIT_ENABLED" default:"false"`
+
+		// Rate is the number of requests per second an authenticated caller can sustain.
+		Rate float64 `envconfig:"GITNESS_RATE_LIMIT_RATE" default:"10"`
+		// Burst is the maximum number of requests an authenticated caller can send at once.
+		Burst int `envconfig:"GITNESS_RATE_LIMIT_BURST" default:"200"`
+
+		// AnonymousRate is the number of requests per second an anonymous client IP can sustain.
+		AnonymousRate float64 `envconfig:"GITNESS_RATE_LIMIT_ANONYMOUS_RATE" default:"1"`
+		// AnonymousBurst is the maximum number of requests an anonymous client IP can send at once.
+		AnonymousBurst int `envconfig:"GITNESS_RATE_LIMIT_ANONYMOUS_BURST" default:"60"`
+
+		// ExemptServiceAccounts disables the rate limiting for service accounts.
+		ExemptServiceAccounts bool `envconfig:"GITNESS_RATE_LIMIT_EXEMPT_SERVICE_ACCOUNTS" default:"true"`
+
+		// TrustProxyHeaders uses the X-Forwarded-For and X-Real-IP headers to identify anonymous clients.
+		// Only enable this if gitness runs behind a proxy that sets these headers.
+		TrustProxyHeaders bool `envconfig:"GITNESS_RATE_LIMIT_TRUST_PROXY_HEADERS" default:"false"`
+
+		// TrustedProxyHops is the number of trusted proxies in front of gitness that append to X-Forwarded-For.
+		// The client IP is taken that many entries from the right, entries further left are set by the client.
+		TrustedProxyHops int `envconfig:"GITNESS_RATE_LIMIT_TRUSTED_PROXY_HOPS" default:"1"`
+	}
+
 	Principal struct {
+		// DeletedUsersRetentionTime is the duration after which deleted users will be purged.
+		DeletedUsersRetentionTime time.Duration `envconfig:"GITNESS_PRINCIPAL_DELETED_USERS_RETENTION_TIME" default:"720h"` // 30 days
+
 		// System defines the principal information used to create the system service.
 		System struct {
 			UID         string `envconfig:"GITNESS_PRINCIPAL_SYSTEM_UID"          default:"gitness"`
@@ -251,15 +392,47 @@ type Config struct {
 		SentinelEndpoint   string `envconfig:"GITNESS_REDIS_SENTINEL_ENDPOINT"`
 	}
 
+	// RowCache defines the redis-backed cache for hot database lookups (principal, repo and token).
+	RowCache struct {
+		Enabled bool `envconfig:"GITNESS_ROW_CACHE_ENABLED" default:"false"`
+		// Duration is the time an entry is kept in the cache. As entries cached by other
+		// lookups aren't always invalidated (e.g. repo paths after a space move), it bounds staleness.
+		Duration  time.Duration `envconfig:"GITNESS_ROW_CACHE_DURATION"  default:"1m"`
+		Namespace string        `envconfig:"GITNESS_ROW_CACHE_NAMESPACE" default:"gitness:rows:"`
+	}
+
 	Events struct {
 		Mode                  events.Mode `envconfig:"GITNESS_EVENTS_MODE"                     default:"inmemory"`
 		Namespace             string      `envconfig:"GITNESS_EVENTS_NAMESPACE"                default:"gitness"`
 		MaxStreamLength       int64       `envconfig:"GITNESS_EVENTS_MAX_STREAM_LENGTH"        default:"10000"`
 		ApproxMaxStreamLength bool        `envconfig:"GITNESS_EVENTS_APPROX_MAX_STREAM_LENGTH" default:"true"`
+
+		// IdempotencyTTL is the duration for which processed events are remembered (redis mode only).
+		// An event that is delivered again within that duration (e.g. after an instance died mid-handling)
+		// isn't processed twice.
+		IdempotencyTTL time.Duration `envconfig:"GITNESS_EVENTS_IDEMPOTENCY_TTL" default:"24h"`
+
+		// MaxStreamAge is the max age of events kept in the streams (0 means unlimited, redis mode only).
+		MaxStreamAge time.Duration `envconfig:"GITNESS_EVENTS_MAX_STREAM_AGE"`
+		// CategoryMaxStreamLength overrides the max stream length per event category (e.g. "git:5000,pullreq:2000").
+		CategoryMaxStreamLength map[string]int64 `envconfig:"GITNESS_EVENTS_CATEGORY_MAX_STREAM_LENGTH"`
+		// CategoryMaxStreamAge overrides the max stream age per event category (e.g. "git:72h,pullreq:168h").
+		CategoryMaxStreamAge map[string]time.Duration `envconfig:"GITNESS_EVENTS_CATEGORY_MAX_STREAM_AGE"`
+		// TrimInterval is the interval in which the stream retention is enforced and stream metrics are updated.
+		TrimInterval time.Duration `envconfig:"GITNESS_EVENTS_TRIM_INTERVAL" default:"5m"`
+		// ReaderShutdownTimeout is the duration for which event readers keep processing the events
+		// they already read once the server is shutting down.
+		ReaderShutdownTimeout time.Duration `envconfig:"GITNESS_EVENTS_READER_SHUTDOWN_TIMEOUT" default:"30s"`
+
+		Outbox struct {
+			PollInterval time.Duration `envconfig:"GITNESS_EVENTS_OUTBOX_POLL_INTERVAL" default:"1s"`
+			BatchSize    int           `envconfig:"GITNESS_EVENTS_OUTBOX_BATCH_SIZE"    default:"100"`
+		}
 	}
 
 	Lock struct {
 		// Provider is a name of distributed lock service like redis, memory, file etc...
+		// The database provider uses the advisory locks of postgres or mariadb.
 		Provider      lock.Provider `envconfig:"GITNESS_LOCK_PROVIDER"          default:"inmemory"`
 		Expiry        time.Duration `envconfig:"GITNESS_LOCK_EXPIRE"            default:"8s"`
 		Tries         int           `envconfig:"GITNESS_LOCK_TRIES"             default:"8"`
@@ -270,6 +443,10 @@ type Config struct {
 		AppNamespace string `envconfig:"GITNESS_LOCK_APP_NAMESPACE"     default:"gitness"`
 		// DefaultNamespace is when mutex doesn't specify custom namespace for their keys
 		DefaultNamespace string `envconfig:"GITNESS_LOCK_DEFAULT_NAMESPACE" default:"default"`
+		// LeaderTTL is the duration after which another instance takes over the work of a leader that died.
+		LeaderTTL time.Duration `envconfig:"GITNESS_LOCK_LEADER_TTL" default:"15s"`
+		// LeaderRetryInterval is the interval in which instances campaign for leadership.
+		LeaderRetryInterval time.Duration `envconfig:"GITNESS_LOCK_LEADER_RETRY_INTERVAL" default:"5s"`
 	}
 
 	PubSub struct {
@@ -304,10 +481,25 @@ type Config struct {
 		MaxRetries          int    `envconfig:"GITNESS_WEBHOOK_MAX_RETRIES" default:"3"`
 		AllowPrivateNetwork bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_PRIVATE_NETWORK" default:"false"`
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
+		// AllowedNetworks are networks (CIDRs) webhooks can be delivered to even if they are loopback or private.
+		AllowedNetworks []string `envconfig:"GITNESS_WEBHOOK_ALLOWED_NETWORKS"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 	}
 
+	// EgressProxy defines the proxy used for outgoing http(s) requests
+	// (webhook deliveries, repository imports and exports).
+	EgressProxy struct {
+		// URL is the proxy used for all outgoing requests.
+		// If not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.
+		URL string `envconfig:"GITNESS_EGRESS_PROXY_URL"`
+		// NoProxy is a list of hosts, domains and CIDRs that are accessed without proxy (same as NO_PROXY).
+		NoProxy []string `envconfig:"GITNESS_EGRESS_PROXY_NO_PROXY"`
+		// Destinations overrides the proxy for specific hosts (e.g. "github.com=http://proxy:3128").
+		// Hosts starting with "." match all subdomains (e.g. ".corp.example.com=http://corp-proxy:3128").
+		Destinations []string `envconfig:"GITNESS_EGRESS_PROXY_DESTINATIONS"`
+	}
+
 	Trigger struct {
 		Concurrency int `envconfig:"GITNESS_TRIGGER_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
@@ -326,6 +518,15 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	// Usage defines the aggregation of the usage data used for usage reporting.
+	Usage struct {
+		Enabled     bool          `envconfig:"GITNESS_USAGE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_USAGE_CRON" default:"15 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_USAGE_MAX_DURATION" default:"10m"`
+		// Retention is for how long the daily usage data is kept.
+		Retention time.Duration `envconfig:"GITNESS_USAGE_RETENTION" default:"8784h"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
@@ -344,11 +545,185 @@ type Config struct {
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
 	}
 
+	// HTTPLogging defines the access logs of http requests.
+	HTTPLogging struct {
+		// SampleRate is the fraction of requests that are logged. Failed and slow requests are always logged.
+		SampleRate float64 `envconfig:"GITNESS_HTTP_LOGGING_SAMPLE_RATE" default:"1"`
+		// SlowThreshold is the duration after which requests are logged as slow with additional details.
+		SlowThreshold time.Duration `envconfig:"GITNESS_HTTP_LOGGING_SLOW_THRESHOLD" default:"5s"`
+		// RouteLevels overrides the log level per route pattern, e.g. "/{repo_ref}/info/refs:debug,/v1/system/*:debug".
+		RouteLevels map[string]string `envconfig:"GITNESS_HTTP_LOGGING_ROUTE_LEVELS"`
+	}
+
+	// Health defines the liveness (/healthz) and readiness (/readyz) probes.
+	Health struct {
+		// StartupGracePeriod is the duration after startup during which unhealthy dependencies
+		// don't fail the liveness probe (e.g. while migrations are running or dependencies start up).
+		StartupGracePeriod time.Duration `envconfig:"GITNESS_HEALTH_STARTUP_GRACE_PERIOD" default:"60s"`
+		// Timeout is the maximum duration of a single dependency check.
+		Timeout time.Duration `envconfig:"GITNESS_HEALTH_TIMEOUT" default:"5s"`
+	}
+
+	// Diagnostics defines the configuration of the runtime diagnostics endpoints.
+	Diagnostics struct {
+		// Enabled exposes pprof, expvar and the runtime dump endpoints under /api/v1/admin/debug.
+		Enabled bool `envconfig:"GITNESS_DIAGNOSTICS_ENABLED" default:"false"`
+		// DumpDir is the directory runtime dumps are written to (defaults to the temp directory).
+		DumpDir string `envconfig:"GITNESS_DIAGNOSTICS_DUMP_DIR"`
+	}
+
+	// Metrics defines the exposure of the prometheus metrics.
+	Metrics struct {
+		// Enabled exposes the metrics on the /metrics endpoint.
+		Enabled bool `envconfig:"GITNESS_METRICS_ENABLED" default:"false"`
+		// Token is an optional bearer token required to access the metrics endpoint.
+		Token string `envconfig:"GITNESS_METRICS_TOKEN"`
+	}
+
+	// Integrations defines the delivery of pull request events to Slack and Microsoft Teams channels.
+	Integrations struct {
+		Concurrency int `envconfig:"GITNESS_INTEGRATIONS_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_INTEGRATIONS_MAX_RETRIES" default:"3"`
+		// AllowedHosts are hosts integrations can post to in addition to the official Slack and Teams hosts.
+		AllowedHosts []string `envconfig:"GITNESS_INTEGRATIONS_ALLOWED_HOSTS"`
+	}
+
+	// IssueTracker defines the notification of external issue trackers about pull request state transitions.
+	IssueTracker struct {
+		Concurrency int `envconfig:"GITNESS_ISSUE_TRACKER_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_ISSUE_TRACKER_MAX_RETRIES" default:"3"`
+	}
+
+	// Issues defines the closing of issues referenced by merged pull requests.
+	Issues struct {
+		Concurrency int `envconfig:"GITNESS_ISSUES_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_ISSUES_MAX_RETRIES" default:"3"`
+	}
+
+	// Languages defines the language detection that is triggered by pushes to the default branch.
+	Languages struct {
+		Concurrency int `envconfig:"GITNESS_LANGUAGES_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_LANGUAGES_MAX_RETRIES" default:"3"`
+	}
+
+	// Dependencies defines the license and dependency manifest detection
+	// that is triggered by pushes to the default branch.
+	Dependencies struct {
+		Concurrency int `envconfig:"GITNESS_DEPENDENCIES_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_DEPENDENCIES_MAX_RETRIES" default:"3"`
+	}
+
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
 	}
 
+	SystemEvents struct {
+		Concurrency int `envconfig:"GITNESS_SYSTEM_EVENTS_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_SYSTEM_EVENTS_MAX_RETRIES" default:"3"`
+		// RetentionTime is the duration after which system events will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_SYSTEM_EVENTS_RETENTION_TIME" default:"720h"` // 30 days
+	}
+
+	Realtime struct {
+		Concurrency int `envconfig:"GITNESS_REALTIME_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_REALTIME_MAX_RETRIES" default:"3"`
+		// RetentionTime is the duration for which the repository and pull request events are kept,
+		// which limits how far back an event stream can be resumed.
+		RetentionTime time.Duration `envconfig:"GITNESS_REALTIME_RETENTION_TIME" default:"24h"`
+	}
+
+	IdempotencyKeys struct {
+		// RetentionTime is the duration for which the responses of requests with an Idempotency-Key header are kept.
+		// Retries after that duration are executed again.
+		RetentionTime time.Duration `envconfig:"GITNESS_IDEMPOTENCY_KEYS_RETENTION_TIME" default:"24h"`
+		// PendingLease is the duration after which a key of a request that never completed (e.g. server crash)
+		// can be claimed by a retry.
+		PendingLease time.Duration `envconfig:"GITNESS_IDEMPOTENCY_KEYS_PENDING_LEASE" default:"5m"`
+		// MaxRequestBodySize is the maximum size of the body of requests with an Idempotency-Key header.
+		MaxRequestBodySize int64 `envconfig:"GITNESS_IDEMPOTENCY_KEYS_MAX_REQUEST_BODY_SIZE" default:"1048576"`
+	}
+
+	// Audit defines the recording of mutating API requests in the audit log (opt-in).
+	Audit struct {
+		Enabled bool `envconfig:"GITNESS_AUDIT_ENABLED" default:"false"`
+		// CaptureRequestBody records the json request bodies (with the values of secrets redacted).
+		CaptureRequestBody bool `envconfig:"GITNESS_AUDIT_CAPTURE_REQUEST_BODY" default:"true"`
+		// MaxRequestBodySize is the maximum number of bytes recorded per request body.
+		MaxRequestBodySize int `envconfig:"GITNESS_AUDIT_MAX_REQUEST_BODY_SIZE" default:"16384"`
+		// RetentionTime is the duration after which audit logs will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"2160h"` // 90 days
+	}
+
+	// BreakGlass restricts instance admins to the spaces they are members of (opt-in).
+	// Access to any other space requires activating break-glass access with a justification,
+	// which expires automatically after the configured window.
+	BreakGlass struct {
+		Enabled bool          `envconfig:"GITNESS_BREAK_GLASS_ENABLED" default:"false"`
+		Window  time.Duration `envconfig:"GITNESS_BREAK_GLASS_WINDOW" default:"1h"`
+	}
+
+	// MalwareScan scans the files added by a push with a ClamAV or ICAP server before the push is accepted (opt-in).
+	MalwareScan struct {
+		Enabled bool `envconfig:"GITNESS_MALWARE_SCAN_ENABLED" default:"false"`
+		// Protocol is the protocol of the scanner, either "clamav" (clamd INSTREAM) or "icap" (RESPMOD).
+		Protocol string `envconfig:"GITNESS_MALWARE_SCAN_PROTOCOL" default:"clamav"`
+		// Address is the host:port of the scanner.
+		Address string `envconfig:"GITNESS_MALWARE_SCAN_ADDRESS"`
+		// ICAPService is the service name of the ICAP server that's used for scanning.
+		ICAPService string        `envconfig:"GITNESS_MALWARE_SCAN_ICAP_SERVICE" default:"avscan"`
+		Timeout     time.Duration `envconfig:"GITNESS_MALWARE_SCAN_TIMEOUT" default:"30s"`
+		// MaxFileSize is the maximum size of files that are scanned, larger files are skipped.
+		MaxFileSize int64 `envconfig:"GITNESS_MALWARE_SCAN_MAX_FILE_SIZE" default:"26214400"` // 25MiB
+		// MaxFiles is the maximum number of new files that are scanned per push.
+		MaxFiles int `envconfig:"GITNESS_MALWARE_SCAN_MAX_FILES" default:"10000"`
+		// FailOpen accepts pushes if the scanner isn't available, otherwise such pushes are rejected.
+		FailOpen bool `envconfig:"GITNESS_MALWARE_SCAN_FAIL_OPEN" default:"false"`
+	}
+
+	// DiffChecks reports status checks for the changes of pull requests (opt-in):
+	// one fails if the changes add secrets, the other if they add files exceeding the size limit.
+	// The checks can be made required by branch rules to block merging such pull requests,
+	// also for branches that aren't pushed to directly (e.g. mirrored branches).
+	DiffChecks struct {
+		Enabled bool `envconfig:"GITNESS_DIFF_CHECKS_ENABLED" default:"false"`
+		// MaxFileSize is the maximum size of files added or modified by a pull request.
+		MaxFileSize int64 `envconfig:"GITNESS_DIFF_CHECKS_MAX_FILE_SIZE" default:"5242880"` // 5MiB
+	}
+
+	// VulnerabilityAlerts periodically matches the detected dependencies of repositories
+	// against the OSV vulnerability database (opt-in).
+	VulnerabilityAlerts struct {
+		Enabled bool `envconfig:"GITNESS_VULNERABILITY_ALERTS_ENABLED" default:"false"`
+		// OSVURL is the base URL of the OSV API, it can point to a mirror for air-gapped installations.
+		OSVURL  string        `envconfig:"GITNESS_VULNERABILITY_ALERTS_OSV_URL" default:"https://api.osv.dev"`
+		Timeout time.Duration `envconfig:"GITNESS_VULNERABILITY_ALERTS_TIMEOUT" default:"30s"`
+	}
+
+	// GitHubCompat exposes a subset of the GitHub v3 REST API under /api/v3 (opt-in).
+	GitHubCompat struct {
+		Enabled bool `envconfig:"GITNESS_GITHUB_COMPAT_ENABLED" default:"false"`
+	}
+
+	// RepoConfig defines the reconciliation of repositories with the configuration file on their default branch.
+	RepoConfig struct {
+		Enabled  bool   `envconfig:"GITNESS_REPO_CONFIG_ENABLED" default:"true"`
+		FilePath string `envconfig:"GITNESS_REPO_CONFIG_FILE_PATH" default:".gitness/config.yaml"`
+	}
+
+	// Registry defines the artifact registries of the spaces,
+	// container images are served via the OCI distribution API under /v2.
+	Registry struct {
+		Enabled bool `envconfig:"GITNESS_REGISTRY_ENABLED" default:"true"`
+		// MaxManifestSize is the maximum size (in bytes) of an image manifest.
+		MaxManifestSize int64 `envconfig:"GITNESS_REGISTRY_MAX_MANIFEST_SIZE" default:"4194304"`
+		// GCGracePeriod is the minimum age of unreferenced blobs before they are removed.
+		// It protects the blobs of pushes that haven't uploaded their manifest yet.
+		GCGracePeriod time.Duration `envconfig:"GITNESS_REGISTRY_GC_GRACE_PERIOD" default:"24h"`
+		// UploadExpiry is the time after which incomplete blob uploads are removed.
+		UploadExpiry time.Duration `envconfig:"GITNESS_REGISTRY_UPLOAD_EXPIRY" default:"24h"`
+	}
+
 	Repos struct {
 		// DeletedRetentionTime is the duration after which deleted repositories will be purged.
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
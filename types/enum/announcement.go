@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AnnouncementSeverity defines the severity of an instance announcement.
+type AnnouncementSeverity string
+
+func (AnnouncementSeverity) Enum() []interface{} { return toInterfaceSlice(announcementSeverities) }
+func (s AnnouncementSeverity) Sanitize() (AnnouncementSeverity, bool) {
+	return Sanitize(s, GetAllAnnouncementSeverities)
+}
+func GetAllAnnouncementSeverities() ([]AnnouncementSeverity, AnnouncementSeverity) {
+	return announcementSeverities, AnnouncementSeverityInfo
+}
+
+const (
+	AnnouncementSeverityInfo     AnnouncementSeverity = "info"
+	AnnouncementSeverityWarning  AnnouncementSeverity = "warning"
+	AnnouncementSeverityCritical AnnouncementSeverity = "critical"
+)
+
+var announcementSeverities = sortEnum([]AnnouncementSeverity{
+	AnnouncementSeverityInfo,
+	AnnouncementSeverityWarning,
+	AnnouncementSeverityCritical,
+})
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ExternalHookType defines the type of an external git hook.
+type ExternalHookType string
+
+func (ExternalHookType) Enum() []interface{} { return toInterfaceSlice(externalHookTypes) }
+func (s ExternalHookType) Sanitize() (ExternalHookType, bool) {
+	return Sanitize(s, GetAllExternalHookTypes)
+}
+
+func GetAllExternalHookTypes() ([]ExternalHookType, ExternalHookType) {
+	return externalHookTypes, ExternalHookTypeHTTP
+}
+
+const (
+	// ExternalHookTypeHTTP is an external hook that's invoked by sending a POST request to an HTTP endpoint.
+	ExternalHookTypeHTTP ExternalHookType = "http"
+	// ExternalHookTypeScript is an external hook that's invoked by executing a script on the server.
+	ExternalHookTypeScript ExternalHookType = "script"
+)
+
+var externalHookTypes = sortEnum([]ExternalHookType{
+	ExternalHookTypeHTTP,
+	ExternalHookTypeScript,
+})
@@ -114,3 +114,24 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return GitServiceType(""), fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// GitPushOrigin defines where the reference updates of a git push originate from.
+type GitPushOrigin string
+
+func (GitPushOrigin) Enum() []interface{} { return toInterfaceSlice(gitPushOrigins) }
+
+const (
+	// GitPushOriginUser is a direct git push of a user.
+	GitPushOriginUser GitPushOrigin = "user"
+	// GitPushOriginInternal is a git operation executed by Gitness on behalf of a user (e.g. merge, commit via API).
+	GitPushOriginInternal GitPushOrigin = "internal"
+	// GitPushOriginSystem is a git operation executed by Gitness as the system service account
+	// (e.g. pull request head reference updates, repository imports).
+	GitPushOriginSystem GitPushOrigin = "system"
+)
+
+var gitPushOrigins = sortEnum([]GitPushOrigin{
+	GitPushOriginUser,
+	GitPushOriginInternal,
+	GitPushOriginSystem,
+})
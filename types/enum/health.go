@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// HealthStatus represents the overall health of the instance.
+type HealthStatus string
+
+// HealthStatus enumeration.
+const (
+	// HealthStatusOK means all dependencies are healthy.
+	HealthStatusOK HealthStatus = "ok"
+	// HealthStatusStarting means dependencies are unhealthy within the startup grace period.
+	HealthStatusStarting HealthStatus = "starting"
+	// HealthStatusUnavailable means dependencies are unhealthy after the startup grace period.
+	HealthStatusUnavailable HealthStatus = "unavailable"
+)
+
+var healthStatuses = sortEnum([]HealthStatus{
+	HealthStatusOK,
+	HealthStatusStarting,
+	HealthStatusUnavailable,
+})
+
+func (HealthStatus) Enum() []interface{} { return toInterfaceSlice(healthStatuses) }
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// IntegrationType defines the type of a chat integration.
+type IntegrationType string
+
+func (IntegrationType) Enum() []interface{} { return toInterfaceSlice(integrationTypes) }
+func (t IntegrationType) Sanitize() (IntegrationType, bool) {
+	return Sanitize(t, GetAllIntegrationTypes)
+}
+
+func GetAllIntegrationTypes() ([]IntegrationType, IntegrationType) {
+	return integrationTypes, ""
+}
+
+const (
+	// IntegrationTypeSlack posts messages to a Slack channel,
+	// either via an incoming webhook or via a bot token and a channel.
+	IntegrationTypeSlack IntegrationType = "slack"
+	// IntegrationTypeTeams posts messages to a Microsoft Teams channel via an incoming webhook.
+	IntegrationTypeTeams IntegrationType = "teams"
+)
+
+var integrationTypes = sortEnum([]IntegrationType{
+	IntegrationTypeSlack,
+	IntegrationTypeTeams,
+})
+
+// IntegrationEvent defines the events a chat integration can be subscribed to.
+type IntegrationEvent string
+
+func (IntegrationEvent) Enum() []interface{} { return toInterfaceSlice(integrationEvents) }
+func (e IntegrationEvent) Sanitize() (IntegrationEvent, bool) {
+	return Sanitize(e, GetAllIntegrationEvents)
+}
+
+func GetAllIntegrationEvents() ([]IntegrationEvent, IntegrationEvent) {
+	return integrationEvents, ""
+}
+
+const (
+	IntegrationEventPullReqCreated         IntegrationEvent = "pullreq_created"
+	IntegrationEventPullReqReopened        IntegrationEvent = "pullreq_reopened"
+	IntegrationEventPullReqClosed          IntegrationEvent = "pullreq_closed"
+	IntegrationEventPullReqMerged          IntegrationEvent = "pullreq_merged"
+	IntegrationEventPullReqBranchUpdated   IntegrationEvent = "pullreq_branch_updated"
+	IntegrationEventPullReqCommentCreated  IntegrationEvent = "pullreq_comment_created"
+	IntegrationEventPullReqReviewSubmitted IntegrationEvent = "pullreq_review_submitted"
+	IntegrationEventPullReqReviewReminder  IntegrationEvent = "pullreq_review_reminder"
+)
+
+var integrationEvents = sortEnum([]IntegrationEvent{
+	IntegrationEventPullReqCreated,
+	IntegrationEventPullReqReopened,
+	IntegrationEventPullReqClosed,
+	IntegrationEventPullReqMerged,
+	IntegrationEventPullReqBranchUpdated,
+	IntegrationEventPullReqCommentCreated,
+	IntegrationEventPullReqReviewSubmitted,
+	IntegrationEventPullReqReviewReminder,
+})
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// IssueState defines issue state.
+type IssueState string
+
+func (IssueState) Enum() []interface{}              { return toInterfaceSlice(issueStates) }
+func (s IssueState) Sanitize() (IssueState, bool)   { return Sanitize(s, GetAllIssueStates) }
+func GetAllIssueStates() ([]IssueState, IssueState) { return issueStates, "" }
+
+// IssueState enumeration.
+const (
+	IssueStateOpen   IssueState = "open"
+	IssueStateClosed IssueState = "closed"
+)
+
+var issueStates = sortEnum([]IssueState{
+	IssueStateOpen,
+	IssueStateClosed,
+})
+
+// IssueSort defines issue attribute that can be used for sorting.
+type IssueSort string
+
+func (IssueSort) Enum() []interface{}            { return toInterfaceSlice(issueSorts) }
+func (s IssueSort) Sanitize() (IssueSort, bool)  { return Sanitize(s, GetAllIssueSorts) }
+func GetAllIssueSorts() ([]IssueSort, IssueSort) { return issueSorts, IssueSortNumber }
+
+// IssueSort enumeration.
+const (
+	IssueSortNumber  IssueSort = "number"
+	IssueSortCreated IssueSort = "created"
+	IssueSortEdited  IssueSort = "edited"
+	IssueSortUpdated IssueSort = "updated"
+)
+
+var issueSorts = sortEnum([]IssueSort{
+	IssueSortNumber,
+	IssueSortCreated,
+	IssueSortEdited,
+	IssueSortUpdated,
+})
@@ -38,6 +38,7 @@ var membershipRoleReaderPermissions = slices.Clip(slices.Insert([]Permission{},
 	PermissionSecretView,
 	PermissionConnectorView,
 	PermissionTemplateView,
+	PermissionRegistryView,
 ))
 
 var membershipRoleExecutorPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
@@ -46,10 +47,12 @@ var membershipRoleExecutorPermissions = slices.Clip(slices.Insert(membershipRole
 	PermissionSecretAccess,
 	PermissionConnectorAccess,
 	PermissionTemplateAccess,
+	PermissionRegistryPush,
 ))
 
 var membershipRoleContributorPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
 	PermissionRepoPush,
+	PermissionRegistryPush,
 ))
 
 var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
@@ -79,6 +82,10 @@ var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRo
 	PermissionTemplateAccess,
 	PermissionTemplateDelete,
 	PermissionTemplateEdit,
+
+	PermissionRegistryEdit,
+	PermissionRegistryDelete,
+	PermissionRegistryPush,
 ))
 
 func init() {
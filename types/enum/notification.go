@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationLevel defines which notifications a user receives.
+type NotificationLevel string
+
+func (NotificationLevel) Enum() []interface{} { return toInterfaceSlice(notificationLevels) }
+func (l NotificationLevel) Sanitize() (NotificationLevel, bool) {
+	return Sanitize(l, GetAllNotificationLevels)
+}
+
+func GetAllNotificationLevels() ([]NotificationLevel, NotificationLevel) {
+	return notificationLevels, NotificationLevelAll
+}
+
+const (
+	// NotificationLevelAll delivers all notifications, including updates of pull requests the user is reviewing.
+	NotificationLevelAll NotificationLevel = "all"
+	// NotificationLevelParticipating delivers notifications of pull requests and webhooks the user participates in.
+	NotificationLevelParticipating NotificationLevel = "participating"
+	// NotificationLevelMentions only delivers notifications that are directly addressed to the user,
+	// like mentions and review requests.
+	NotificationLevelMentions NotificationLevel = "mentions"
+	// NotificationLevelNone doesn't deliver any notifications.
+	NotificationLevelNone NotificationLevel = "none"
+)
+
+var notificationLevels = sortEnum([]NotificationLevel{
+	NotificationLevelAll,
+	NotificationLevelParticipating,
+	NotificationLevelMentions,
+	NotificationLevelNone,
+})
+
+// Includes returns true if notifications of the provided level are delivered to users with this level.
+func (l NotificationLevel) Includes(other NotificationLevel) bool {
+	return l.rank() >= other.rank()
+}
+
+func (l NotificationLevel) rank() int {
+	switch l {
+	case NotificationLevelAll:
+		return 3
+	case NotificationLevelParticipating:
+		return 2
+	case NotificationLevelMentions:
+		return 1
+	case NotificationLevelNone:
+		return 0
+	default:
+		return 0
+	}
+}
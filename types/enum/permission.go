@@ -27,6 +27,7 @@ const (
 	ResourceTypeSecret         ResourceType = "SECRET"
 	ResourceTypeConnector      ResourceType = "CONNECTOR"
 	ResourceTypeTemplate       ResourceType = "TEMPLATE"
+	ResourceTypeRegistry       ResourceType = "REGISTRY"
 )
 
 // Permission represents the different types of permissions a principal can have.
@@ -120,3 +121,13 @@ const (
 	PermissionTemplateDelete Permission = "template_delete"
 	PermissionTemplateAccess Permission = "template_access"
 )
+
+const (
+	/*
+		----- REGISTRY -----
+	*/
+	PermissionRegistryView   Permission = "registry_view"
+	PermissionRegistryEdit   Permission = "registry_edit"
+	PermissionRegistryDelete Permission = "registry_delete"
+	PermissionRegistryPush   Permission = "registry_push"
+)
@@ -78,14 +78,17 @@ func GetAllPullReqActivityTypes() ([]PullReqActivityType, PullReqActivityType) {
 
 // PullReqActivityType enumeration.
 const (
-	PullReqActivityTypeComment      PullReqActivityType = "comment"
-	PullReqActivityTypeCodeComment  PullReqActivityType = "code-comment"
-	PullReqActivityTypeTitleChange  PullReqActivityType = "title-change"
-	PullReqActivityTypeStateChange  PullReqActivityType = "state-change"
-	PullReqActivityTypeReviewSubmit PullReqActivityType = "review-submit"
-	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
-	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
-	PullReqActivityTypeMerge        PullReqActivityType = "merge"
+	PullReqActivityTypeComment         PullReqActivityType = "comment"
+	PullReqActivityTypeCodeComment     PullReqActivityType = "code-comment"
+	PullReqActivityTypeTitleChange     PullReqActivityType = "title-change"
+	PullReqActivityTypeStateChange     PullReqActivityType = "state-change"
+	PullReqActivityTypeReviewSubmit    PullReqActivityType = "review-submit"
+	PullReqActivityTypeReviewDismiss   PullReqActivityType = "review-dismiss"
+	PullReqActivityTypeBranchUpdate    PullReqActivityType = "branch-update"
+	PullReqActivityTypeBranchDelete    PullReqActivityType = "branch-delete"
+	PullReqActivityTypeBranchForcePush PullReqActivityType = "branch-force-push"
+	PullReqActivityTypeMerge           PullReqActivityType = "merge"
+	PullReqActivityTypeMention         PullReqActivityType = "mention"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -94,9 +97,12 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeTitleChange,
 	PullReqActivityTypeStateChange,
 	PullReqActivityTypeReviewSubmit,
+	PullReqActivityTypeReviewDismiss,
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
+	PullReqActivityTypeBranchForcePush,
 	PullReqActivityTypeMerge,
+	PullReqActivityTypeMention,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -151,6 +157,69 @@ var pullReqCommentStatuses = sortEnum([]PullReqCommentStatus{
 	PullReqCommentStatusResolved,
 })
 
+// PullReqCommentHiddenReason defines the reason a pull request comment got hidden by a moderator.
+type PullReqCommentHiddenReason string
+
+func (PullReqCommentHiddenReason) Enum() []interface{} {
+	return toInterfaceSlice(pullReqCommentHiddenReasons)
+}
+
+func (r PullReqCommentHiddenReason) Sanitize() (PullReqCommentHiddenReason, bool) {
+	return Sanitize(r, GetAllPullReqCommentHiddenReasons)
+}
+
+func GetAllPullReqCommentHiddenReasons() ([]PullReqCommentHiddenReason, PullReqCommentHiddenReason) {
+	return pullReqCommentHiddenReasons, "" // No default value
+}
+
+// PullReqCommentHiddenReason enumeration.
+const (
+	PullReqCommentHiddenReasonSpam      PullReqCommentHiddenReason = "spam"
+	PullReqCommentHiddenReasonAbuse     PullReqCommentHiddenReason = "abuse"
+	PullReqCommentHiddenReasonOffTopic  PullReqCommentHiddenReason = "off_topic"
+	PullReqCommentHiddenReasonOutdated  PullReqCommentHiddenReason = "outdated"
+	PullReqCommentHiddenReasonDuplicate PullReqCommentHiddenReason = "duplicate"
+	PullReqCommentHiddenReasonResolved  PullReqCommentHiddenReason = "resolved"
+)
+
+var pullReqCommentHiddenReasons = sortEnum([]PullReqCommentHiddenReason{
+	PullReqCommentHiddenReasonSpam,
+	PullReqCommentHiddenReasonAbuse,
+	PullReqCommentHiddenReasonOffTopic,
+	PullReqCommentHiddenReasonOutdated,
+	PullReqCommentHiddenReasonDuplicate,
+	PullReqCommentHiddenReasonResolved,
+})
+
+// PullReqConversation defines who can comment on a pull request.
+type PullReqConversation string
+
+func (PullReqConversation) Enum() []interface{} { return toInterfaceSlice(pullReqConversations) }
+
+func (c PullReqConversation) Sanitize() (PullReqConversation, bool) {
+	return Sanitize(c, GetAllPullReqConversations)
+}
+
+func GetAllPullReqConversations() ([]PullReqConversation, PullReqConversation) {
+	return pullReqConversations, PullReqConversationOpen
+}
+
+// PullReqConversation enumeration.
+const (
+	// PullReqConversationOpen allows everyone with access to the repository to comment.
+	PullReqConversationOpen PullReqConversation = "open"
+	// PullReqConversationCollaborators allows only users with push access to the repository to comment.
+	PullReqConversationCollaborators PullReqConversation = "collaborators"
+	// PullReqConversationLocked allows only repository admins to comment.
+	PullReqConversationLocked PullReqConversation = "locked"
+)
+
+var pullReqConversations = sortEnum([]PullReqConversation{
+	PullReqConversationOpen,
+	PullReqConversationCollaborators,
+	PullReqConversationLocked,
+})
+
 // PullReqReviewDecision defines state of a pull request review.
 type PullReqReviewDecision string
 
@@ -207,6 +276,34 @@ var pullReqReviewerTypes = sortEnum([]PullReqReviewerType{
 	PullReqReviewerTypeSelfAssigned,
 })
 
+// ReviewerAssignmentStrategy defines how reviewers are picked when they are assigned to pull requests automatically.
+type ReviewerAssignmentStrategy string
+
+func (ReviewerAssignmentStrategy) Enum() []interface{} {
+	return toInterfaceSlice(reviewerAssignmentStrategies)
+}
+
+func (s ReviewerAssignmentStrategy) Sanitize() (ReviewerAssignmentStrategy, bool) {
+	return Sanitize(s, GetAllReviewerAssignmentStrategies)
+}
+
+func GetAllReviewerAssignmentStrategies() ([]ReviewerAssignmentStrategy, ReviewerAssignmentStrategy) {
+	return reviewerAssignmentStrategies, ReviewerAssignmentStrategyRoundRobin
+}
+
+// ReviewerAssignmentStrategy enumeration.
+const (
+	// ReviewerAssignmentStrategyRoundRobin picks the candidates that were least recently added as reviewers.
+	ReviewerAssignmentStrategyRoundRobin ReviewerAssignmentStrategy = "round_robin"
+	// ReviewerAssignmentStrategyLeastLoaded picks the candidates with the fewest pending review requests.
+	ReviewerAssignmentStrategyLeastLoaded ReviewerAssignmentStrategy = "least_loaded"
+)
+
+var reviewerAssignmentStrategies = sortEnum([]ReviewerAssignmentStrategy{
+	ReviewerAssignmentStrategyRoundRobin,
+	ReviewerAssignmentStrategyLeastLoaded,
+})
+
 type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
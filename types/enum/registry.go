@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RegistryType defines the kind of artifacts an artifact registry hosts.
+type RegistryType string
+
+func (RegistryType) Enum() []interface{} { return toInterfaceSlice(registryTypes) }
+func (t RegistryType) Sanitize() (RegistryType, bool) {
+	return Sanitize(t, GetAllRegistryTypes)
+}
+func GetAllRegistryTypes() ([]RegistryType, RegistryType) {
+	return registryTypes, RegistryTypeDocker
+}
+
+const (
+	// RegistryTypeDocker is a container registry served via the OCI distribution API.
+	RegistryTypeDocker RegistryType = "docker"
+	// RegistryTypeGeneric is a registry for arbitrary files grouped by package and version.
+	RegistryTypeGeneric RegistryType = "generic"
+)
+
+var registryTypes = sortEnum([]RegistryType{
+	RegistryTypeDocker,
+	RegistryTypeGeneric,
+})
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SettingsScope defines the scope a setting is stored for.
+type SettingsScope string
+
+func (SettingsScope) Enum() []interface{} { return toInterfaceSlice(settingsScopes) }
+
+const (
+	// SettingsScopeSystem is the scope of settings that apply to the whole system (scope id is always 0).
+	SettingsScopeSystem SettingsScope = "system"
+	// SettingsScopePrincipal is the scope of settings of a single principal (scope id is the principal id).
+	SettingsScopePrincipal SettingsScope = "principal"
+	// SettingsScopeSpace is the scope of settings of a single space (scope id is the space id).
+	SettingsScopeSpace SettingsScope = "space"
+	// SettingsScopeRepo is the scope of settings of a single repository (scope id is the repo id).
+	SettingsScopeRepo SettingsScope = "repo"
+)
+
+var settingsScopes = sortEnum([]SettingsScope{
+	SettingsScopeSystem,
+	SettingsScopePrincipal,
+	SettingsScopeSpace,
+	SettingsScopeRepo,
+})
+
+// InheritedSetting defines a setting of a space that can be inherited from its ancestors.
+type InheritedSetting string
+
+func (InheritedSetting) Enum() []interface{} { return toInterfaceSlice(inheritedSettings) }
+
+const (
+	InheritedSettingMemberships  InheritedSetting = "memberships"
+	InheritedSettingRules        InheritedSetting = "rules"
+	InheritedSettingIssueTracker InheritedSetting = "issue_tracker"
+	InheritedSettingRepoTemplate InheritedSetting = "repo_template"
+)
+
+var inheritedSettings = sortEnum([]InheritedSetting{
+	InheritedSettingMemberships,
+	InheritedSettingRules,
+	InheritedSettingIssueTracker,
+	InheritedSettingRepoTemplate,
+})
+
+// InstanceSettingSource defines where the effective value of an instance setting comes from.
+type InstanceSettingSource string
+
+func (InstanceSettingSource) Enum() []interface{} { return toInterfaceSlice(instanceSettingSources) }
+
+const (
+	// InstanceSettingSourceConfig is used for values defined by the configuration (environment).
+	InstanceSettingSourceConfig InstanceSettingSource = "config"
+	// InstanceSettingSourceSettings is used for values overridden at runtime, which take precedence over the config.
+	InstanceSettingSourceSettings InstanceSettingSource = "settings"
+)
+
+var instanceSettingSources = sortEnum([]InstanceSettingSource{
+	InstanceSettingSourceConfig,
+	InstanceSettingSourceSettings,
+})
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SignedURLResource defines the kind of repository content a signed URL grants access to.
+type SignedURLResource string
+
+func (SignedURLResource) Enum() []interface{} { return toInterfaceSlice(signedURLResources) }
+func (r SignedURLResource) Sanitize() (SignedURLResource, bool) {
+	return Sanitize(r, GetAllSignedURLResources)
+}
+func GetAllSignedURLResources() ([]SignedURLResource, SignedURLResource) {
+	return signedURLResources, ""
+}
+
+// SignedURLResource enumeration.
+const (
+	SignedURLResourceRaw     SignedURLResource = "raw"
+	SignedURLResourceArchive SignedURLResource = "archive"
+)
+
+var signedURLResources = sortEnum([]SignedURLResource{
+	SignedURLResourceRaw,
+	SignedURLResourceArchive,
+})
@@ -28,4 +28,22 @@ const (
 	SSETypeRepositoryExportCompleted SSEType = "repository_export_completed"
 
 	SSETypePullRequestUpdated SSEType = "pullreq_updated"
+
+	SSETypeSystemEvent SSEType = "system_event"
+
+	SSETypeBranchCreated SSEType = "branch_created"
+	SSETypeBranchUpdated SSEType = "branch_updated"
+	SSETypeBranchDeleted SSEType = "branch_deleted"
+	SSETypeTagCreated    SSEType = "tag_created"
+	SSETypeTagUpdated    SSEType = "tag_updated"
+	SSETypeTagDeleted    SSEType = "tag_deleted"
+
+	SSETypePullRequestCreated         SSEType = "pullreq_created"
+	SSETypePullRequestClosed          SSEType = "pullreq_closed"
+	SSETypePullRequestReopened        SSEType = "pullreq_reopened"
+	SSETypePullRequestMerged          SSEType = "pullreq_merged"
+	SSETypePullRequestBranchUpdated   SSEType = "pullreq_branch_updated"
+	SSETypePullRequestCommentCreated  SSEType = "pullreq_comment_created"
+	SSETypePullRequestReviewSubmitted SSEType = "pullreq_review_submitted"
+	SSETypePullRequestReviewerAdded   SSEType = "pullreq_reviewer_added"
 )
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SystemEventType defines the kind of system activity recorded in the admin event feed.
+type SystemEventType string
+
+func (SystemEventType) Enum() []interface{} { return toInterfaceSlice(systemEventTypes) }
+func (s SystemEventType) Sanitize() (SystemEventType, bool) {
+	return Sanitize(s, GetAllSystemEventTypes)
+}
+func GetAllSystemEventTypes() ([]SystemEventType, SystemEventType) { return systemEventTypes, "" }
+
+// SystemEventType enumeration.
+const (
+	SystemEventTypeRepoCreated   SystemEventType = "repo_created"
+	SystemEventTypeRepoDeleted   SystemEventType = "repo_deleted"
+	SystemEventTypeUserCreated   SystemEventType = "user_created"
+	SystemEventTypeLoginFailed   SystemEventType = "login_failed"
+	SystemEventTypeWebhookFailed SystemEventType = "webhook_failed"
+	SystemEventTypeRepoArchived  SystemEventType = "repo_archived"
+	SystemEventTypeBreakGlass    SystemEventType = "break_glass"
+)
+
+var systemEventTypes = sortEnum([]SystemEventType{
+	SystemEventTypeRepoCreated,
+	SystemEventTypeRepoDeleted,
+	SystemEventTypeUserCreated,
+	SystemEventTypeLoginFailed,
+	SystemEventTypeWebhookFailed,
+	SystemEventTypeRepoArchived,
+	SystemEventTypeBreakGlass,
+})
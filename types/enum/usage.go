@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "time"
+
+// UsageWindow defines the time window of a usage report.
+type UsageWindow string
+
+func (UsageWindow) Enum() []interface{} { return toInterfaceSlice(usageWindows) }
+func (w UsageWindow) Sanitize() (UsageWindow, bool) {
+	return Sanitize(w, GetAllUsageWindows)
+}
+func GetAllUsageWindows() ([]UsageWindow, UsageWindow) {
+	return usageWindows, UsageWindowMonth
+}
+
+const (
+	UsageWindowDay     UsageWindow = "1d"
+	UsageWindowWeek    UsageWindow = "7d"
+	UsageWindowMonth   UsageWindow = "30d"
+	UsageWindowQuarter UsageWindow = "90d"
+	UsageWindowYear    UsageWindow = "365d"
+)
+
+var usageWindows = sortEnum([]UsageWindow{
+	UsageWindowDay,
+	UsageWindowWeek,
+	UsageWindowMonth,
+	UsageWindowQuarter,
+	UsageWindowYear,
+})
+
+// Days returns the number of days covered by the window.
+func (w UsageWindow) Days() int {
+	switch w {
+	case UsageWindowDay:
+		return 1
+	case UsageWindowWeek:
+		return 7
+	case UsageWindowMonth:
+		return 30
+	case UsageWindowQuarter:
+		return 90
+	case UsageWindowYear:
+		return 365
+	default:
+		return 30
+	}
+}
+
+// Duration returns the duration of the window.
+func (w UsageWindow) Duration() time.Duration {
+	return time.Duration(w.Days()) * 24 * time.Hour
+}
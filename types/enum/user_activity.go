@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// UserActivityType defines the type of an entry in the activity feed of a user.
+type UserActivityType string
+
+func (UserActivityType) Enum() []interface{} { return toInterfaceSlice(userActivityTypes) }
+func (t UserActivityType) Sanitize() (UserActivityType, bool) {
+	return Sanitize(t, GetAllUserActivityTypes)
+}
+func GetAllUserActivityTypes() ([]UserActivityType, UserActivityType) {
+	return userActivityTypes, ""
+}
+
+const (
+	UserActivityTypePush             UserActivityType = "push"
+	UserActivityTypePullReqOpened    UserActivityType = "pullreq_opened"
+	UserActivityTypePullReqMerged    UserActivityType = "pullreq_merged"
+	UserActivityTypePullReqReviewed  UserActivityType = "pullreq_reviewed"
+	UserActivityTypePullReqCommented UserActivityType = "pullreq_commented"
+)
+
+var userActivityTypes = sortEnum([]UserActivityType{
+	UserActivityTypePush,
+	UserActivityTypePullReqOpened,
+	UserActivityTypePullReqMerged,
+	UserActivityTypePullReqReviewed,
+	UserActivityTypePullReqCommented,
+})
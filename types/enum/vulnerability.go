@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// VulnerabilityAlertState defines the state of a vulnerability alert.
+type VulnerabilityAlertState string
+
+func (VulnerabilityAlertState) Enum() []interface{} {
+	return toInterfaceSlice(vulnerabilityAlertStates)
+}
+func (s VulnerabilityAlertState) Sanitize() (VulnerabilityAlertState, bool) {
+	return Sanitize(s, GetAllVulnerabilityAlertStates)
+}
+func GetAllVulnerabilityAlertStates() ([]VulnerabilityAlertState, VulnerabilityAlertState) {
+	return vulnerabilityAlertStates, ""
+}
+
+// VulnerabilityAlertState enumeration.
+const (
+	// VulnerabilityAlertStateOpen means the vulnerable dependency is still declared by the repository.
+	VulnerabilityAlertStateOpen VulnerabilityAlertState = "open"
+	// VulnerabilityAlertStateResolved means the vulnerable dependency was upgraded or removed.
+	VulnerabilityAlertStateResolved VulnerabilityAlertState = "resolved"
+)
+
+var vulnerabilityAlertStates = sortEnum([]VulnerabilityAlertState{
+	VulnerabilityAlertStateOpen,
+	VulnerabilityAlertStateResolved,
+})
+
+// VulnerabilitySeverity defines the severity of a vulnerability.
+type VulnerabilitySeverity string
+
+func (VulnerabilitySeverity) Enum() []interface{} { return toInterfaceSlice(vulnerabilitySeverities) }
+
+// VulnerabilitySeverity enumeration.
+const (
+	VulnerabilitySeverityCritical VulnerabilitySeverity = "critical"
+	VulnerabilitySeverityHigh     VulnerabilitySeverity = "high"
+	VulnerabilitySeverityMedium   VulnerabilitySeverity = "medium"
+	VulnerabilitySeverityLow      VulnerabilitySeverity = "low"
+	VulnerabilitySeverityUnknown  VulnerabilitySeverity = "unknown"
+)
+
+var vulnerabilitySeverities = sortEnum([]VulnerabilitySeverity{
+	VulnerabilitySeverityCritical,
+	VulnerabilitySeverityHigh,
+	VulnerabilitySeverityMedium,
+	VulnerabilitySeverityLow,
+	VulnerabilitySeverityUnknown,
+})
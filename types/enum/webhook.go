@@ -158,6 +158,18 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+
+	// WebhookTriggerIssueCreated gets triggered when an issue gets created.
+	WebhookTriggerIssueCreated WebhookTrigger = "issue_created"
+	// WebhookTriggerIssueUpdated gets triggered when the title, description, labels or assignees of an issue change.
+	WebhookTriggerIssueUpdated WebhookTrigger = "issue_updated"
+	// WebhookTriggerIssueClosed gets triggered when an issue gets closed.
+	WebhookTriggerIssueClosed WebhookTrigger = "issue_closed"
+	// WebhookTriggerIssueReopened gets triggered when an issue gets reopened.
+	WebhookTriggerIssueReopened WebhookTrigger = "issue_reopened"
+
+	// WebhookTriggerVulnerabilityAlertsCreated gets triggered when new vulnerabilities affect dependencies of a repo.
+	WebhookTriggerVulnerabilityAlertsCreated WebhookTrigger = "vulnerability_alerts_created"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +185,9 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerIssueCreated,
+	WebhookTriggerIssueUpdated,
+	WebhookTriggerIssueClosed,
+	WebhookTriggerIssueReopened,
+	WebhookTriggerVulnerabilityAlertsCreated,
 })
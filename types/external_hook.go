@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// ExternalHook is a pre-receive hook registered for a space that's invoked for all pushes to
+// repositories of the space (and its subspaces) after the built-in checks passed.
+type ExternalHook struct {
+	ID          int64                 `json:"-"`
+	Version     int64                 `json:"version"`
+	SpaceID     int64                 `json:"space_id"`
+	Identifier  string                `json:"identifier"`
+	Description string                `json:"description"`
+	Type        enum.ExternalHookType `json:"type"`
+	// Target is the URL of an HTTP hook or the file name of a script hook (relative to the scripts directory).
+	Target string `json:"target"`
+	// Secret is used to sign the payload sent to HTTP hooks.
+	Secret string `json:"-"`
+	// Timeout is the maximum time in seconds a hook can take before the push is rejected.
+	Timeout   int64 `json:"timeout"`
+	Enabled   bool  `json:"enabled"`
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// MarshalJSON overrides the default json marshaling for `ExternalHook` allowing us to inject the `HasSecret` field.
+func (h *ExternalHook) MarshalJSON() ([]byte, error) {
+	type alias ExternalHook
+	return json.Marshal(&struct {
+		*alias
+		HasSecret bool `json:"has_secret"`
+	}{
+		alias:     (*alias)(h),
+		HasSecret: h.Secret != "",
+	})
+}
@@ -37,7 +37,12 @@ type CommitFilter struct {
 	Since        int64  `json:"since"`
 	Until        int64  `json:"until"`
 	Committer    string `json:"committer"`
+	Author       string `json:"author"`
+	Follow       bool   `json:"follow"`
 	IncludeStats bool   `json:"include_stats"`
+
+	// CursorSHA is the SHA of the last commit of the previous page.
+	CursorSHA string `json:"-"`
 }
 
 // BranchFilter stores branch query parameters.
@@ -47,6 +52,12 @@ type BranchFilter struct {
 	Order enum.Order            `json:"order"`
 	Page  int                   `json:"page"`
 	Size  int                   `json:"size"`
+
+	// IncludeDivergence indicates whether the ahead/behind counts relative to the default branch are included.
+	IncludeDivergence bool `json:"include_divergence"`
+	// StaleOlderThan is a timestamp (in milliseconds), if provided only the branches
+	// whose latest commit is older are listed.
+	StaleOlderThan int64 `json:"stale_older_than"`
 }
 
 // TagFilter stores commit tag query parameters.
@@ -107,4 +118,5 @@ type ListCommitResponse struct {
 	Commits       []Commit        `json:"commits"`
 	RenameDetails []RenameDetails `json:"rename_details"`
 	TotalCommits  int             `json:"total_commits,omitempty"`
+	NextCursor    string          `json:"next_cursor,omitempty"`
 }
@@ -16,6 +16,7 @@ package types
 
 import (
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types/enum"
 )
 
 // GithookInputBase contains the base input of the githook apis.
@@ -23,6 +24,19 @@ type GithookInputBase struct {
 	RepoID      int64
 	PrincipalID int64
 	Internal    bool // Internal calls originate from Gitness, and external calls are direct git pushes.
+	System      bool // System calls are internal calls executed as the system service account.
+}
+
+// Origin returns the origin of the git push the githook call is made for.
+func (b GithookInputBase) Origin() enum.GitPushOrigin {
+	switch {
+	case b.System:
+		return enum.GitPushOriginSystem
+	case b.Internal:
+		return enum.GitPushOriginInternal
+	default:
+		return enum.GitPushOriginUser
+	}
 }
 
 // GithookPreReceiveInput is the input for the pre-receive githook api call.
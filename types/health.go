@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// HealthReport describes the health of the instance and its dependencies.
+type HealthReport struct {
+	Status enum.HealthStatus `json:"status"`
+	// Started is the time the instance started (unix milliseconds).
+	Started      int64                       `json:"started"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
+// DependencyHealth describes the health of a single dependency of the instance.
+type DependencyHealth struct {
+	Healthy    bool   `json:"healthy"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// DatabaseHealth describes the connectivity, migration status and connection pool of the database.
+type DatabaseHealth struct {
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+
+	// Version is the latest migration applied to the database.
+	Version string `json:"version"`
+	// LatestVersion is the latest migration known to the running binary.
+	LatestVersion string `json:"latest_version"`
+
+	Pool DatabasePoolStats `json:"pool"`
+}
+
+// DatabasePoolStats contains the statistics of the database connection pool.
+type DatabasePoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
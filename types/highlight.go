@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// HighlightToken is a single token of syntax highlighted source code.
+type HighlightToken struct {
+	// Type is the short class name of the token as used by chroma style sheets (e.g. "k" for keywords).
+	// It's empty for plain text.
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+// HighlightLine is a single line of syntax highlighted source code.
+type HighlightLine struct {
+	Number int              `json:"number"`
+	Tokens []HighlightToken `json:"tokens"`
+}
+
+// HighlightedBlob contains the (optionally partial) token stream of a syntax highlighted blob.
+type HighlightedBlob struct {
+	SHA        string          `json:"sha"`
+	Language   string          `json:"language"`
+	TotalLines int             `json:"total_lines"`
+	Lines      []HighlightLine `json:"lines"`
+}
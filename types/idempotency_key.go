@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// IdempotencyKey stores the outcome of a request that was sent with an Idempotency-Key header,
+// so that retries of the same request get the original response instead of being executed again.
+type IdempotencyKey struct {
+	ID          int64
+	PrincipalID int64
+	Key         string
+	// Fingerprint identifies the request (method, path and body) the key was used for.
+	Fingerprint string
+	// ResponseStatus is the status code of the original response, it's zero while the request is in progress.
+	ResponseStatus      int
+	ResponseContentType string
+	ResponseBody        []byte
+	Created             int64
+}
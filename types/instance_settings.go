@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// InstanceSettings are the settings of the instance that can be changed at runtime without a restart.
+// Every value that is set takes precedence over the corresponding configuration value,
+// nil values fall back to the configuration.
+type InstanceSettings struct {
+	// UserSignupEnabled overrides GITNESS_USER_SIGNUP_ENABLED.
+	UserSignupEnabled *bool `json:"user_signup_enabled"`
+	// UserSignupAllowedDomains overrides GITNESS_USER_SIGNUP_ALLOWED_DOMAINS.
+	UserSignupAllowedDomains *[]string `json:"user_signup_allowed_domains"`
+	// UserSignupApprovalRequired overrides GITNESS_USER_SIGNUP_APPROVAL_REQUIRED.
+	UserSignupApprovalRequired *bool `json:"user_signup_approval_required"`
+	// DefaultPublic overrides GITNESS_DEFAULT_PUBLIC.
+	DefaultPublic *bool `json:"default_public"`
+	// WebhookAllowedNetworks overrides GITNESS_WEBHOOK_ALLOWED_NETWORKS.
+	WebhookAllowedNetworks *[]string `json:"webhook_allowed_networks"`
+	// SessionTTL overrides GITNESS_TOKEN_EXPIRE (in seconds).
+	SessionTTL *int64 `json:"session_ttl"`
+}
+
+// InstanceSetting is the effective value of an instance setting, together with where it comes from.
+type InstanceSetting struct {
+	Key    string                     `json:"key"`
+	Value  any                        `json:"value"`
+	Source enum.InstanceSettingSource `json:"source"`
+}
+
+// InstanceSettingsOutput contains the instance settings stored at runtime and the resulting effective values.
+type InstanceSettingsOutput struct {
+	Settings  InstanceSettings  `json:"settings"`
+	Effective []InstanceSetting `json:"effective"`
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Integration links a space or repository to a Slack or Microsoft Teams channel.
+// Messages for the selected events are posted to the channel for all repositories of the parent.
+type Integration struct {
+	ID          int64                   `json:"-"`
+	Version     int64                   `json:"version"`
+	ParentID    int64                   `json:"parent_id"`
+	ParentType  enum.ParentResourceType `json:"parent_type"`
+	Identifier  string                  `json:"identifier"`
+	Description string                  `json:"description"`
+	Type        enum.IntegrationType    `json:"type"`
+	// URL is the incoming webhook URL of the channel. It's a secret, hence never returned.
+	URL string `json:"-"`
+	// Token is the bot token used to post to Slack channels that aren't configured with an incoming webhook.
+	Token string `json:"-"`
+	// Channel is the Slack channel messages are posted to when using a bot token.
+	Channel   string                  `json:"channel"`
+	Events    []enum.IntegrationEvent `json:"events"`
+	Enabled   bool                    `json:"enabled"`
+	CreatedBy int64                   `json:"created_by"`
+	Created   int64                   `json:"created"`
+	Updated   int64                   `json:"updated"`
+}
+
+// MarshalJSON overrides the default json marshaling for `Integration` allowing us to inject the
+// `HasURL` and `HasToken` fields, as the secret URL and token themselves are never returned.
+func (i *Integration) MarshalJSON() ([]byte, error) {
+	type alias Integration
+	return json.Marshal(&struct {
+		*alias
+		HasURL   bool `json:"has_url"`
+		HasToken bool `json:"has_token"`
+	}{
+		alias:    (*alias)(i),
+		HasURL:   i.URL != "",
+		HasToken: i.Token != "",
+	})
+}
+
+// IsSubscribed returns true if the integration is enabled and subscribed to the event.
+func (i *Integration) IsSubscribed(event enum.IntegrationEvent) bool {
+	if !i.Enabled {
+		return false
+	}
+
+	for _, e := range i.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Invitation allows the invited person to register a user account even if open signup is disabled.
+// The user can optionally be added to a space with a pre-assigned role on registration.
+type Invitation struct {
+	ID        int64                `json:"id"`
+	Email     string               `json:"email"`
+	TokenHash string               `json:"-"`
+	SpaceID   *int64               `json:"space_id,omitempty"`
+	Role      *enum.MembershipRole `json:"role,omitempty"`
+	CreatedBy int64                `json:"created_by"`
+	Created   int64                `json:"created"`
+	Expires   int64                `json:"expires"`
+
+	// Sent is the time the invitation email was sent the last time (0 if it was never sent).
+	Sent int64 `json:"sent"`
+
+	// AcceptedBy is the user that registered using the invitation.
+	AcceptedBy *int64 `json:"accepted_by,omitempty"`
+	Accepted   *int64 `json:"accepted,omitempty"`
+}
+
+// InvitationFilter stores invitation query parameters.
+type InvitationFilter struct {
+	ListQueryFilter
+	// Pending indicates whether only invitations that weren't accepted yet are listed.
+	Pending bool `json:"pending"`
+	// SpaceID restricts the list to invitations for the space.
+	SpaceID *int64 `json:"space_id"`
+}
+
+// InvitationCreateOutput contains the created invitation together with its token.
+// The token is only returned once, afterwards only its hash is known.
+type InvitationCreateOutput struct {
+	Invitation
+	Token string `json:"token"`
+	URL   string `json:"url"`
+
+	// EmailSent indicates whether the invitation email was sent, otherwise the URL has to be shared manually.
+	EmailSent bool `json:"email_sent"`
+}
+
+// InvitationInfo contains the information about an invitation that is shown to the invited person.
+type InvitationInfo struct {
+	Email     string               `json:"email"`
+	SpacePath string               `json:"space_path,omitempty"`
+	Role      *enum.MembershipRole `json:"role,omitempty"`
+	InvitedBy PrincipalInfo        `json:"invited_by"`
+	Expires   int64                `json:"expires"`
+
+	// RegisterURL is the url of the sign-up page for the invited person, if it doesn't have an account yet.
+	RegisterURL string `json:"register_url"`
+}
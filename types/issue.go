@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// Issue represents an issue of a repository.
+type Issue struct {
+	ID      int64 `json:"-"` // not returned, it's an internal field
+	Version int64 `json:"-"` // not returned, it's an internal field
+	Number  int64 `json:"number"`
+	RepoID  int64 `json:"repo_id"`
+
+	CreatedBy int64 `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"-"` // not returned, it's updated by the server internally. Clients should use Edited.
+	Edited    int64 `json:"edited"`
+
+	State       enum.IssueState `json:"state"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+
+	ClosedBy *int64 `json:"-"` // not returned, because the closer info is in the Closer field
+	Closed   *int64 `json:"closed"`
+	// ClosedByPullReq is the number of the pull request which closed the issue when it got merged.
+	ClosedByPullReq *int64 `json:"closed_by_pullreq"`
+
+	Labels      []string `json:"labels"`
+	AssigneeIDs []int64  `json:"-"` // not returned, because the assignee infos are in the Assignees field
+
+	Author    PrincipalInfo    `json:"author"`
+	Closer    *PrincipalInfo   `json:"closer"`
+	Assignees []*PrincipalInfo `json:"assignees"`
+}
+
+// IssueFilter stores issue query parameters.
+type IssueFilter struct {
+	Page       int               `json:"page"`
+	Size       int               `json:"size"`
+	Query      string            `json:"query"`
+	CreatedBy  int64             `json:"created_by"`
+	AssigneeID int64             `json:"assignee_id"`
+	Labels     []string          `json:"labels"`
+	States     []enum.IssueState `json:"state"`
+	Sort       enum.IssueSort    `json:"sort"`
+	Order      enum.Order        `json:"order"`
+}
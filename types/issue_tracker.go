@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// IssueTrackerSettings defines how references to issues of an external tracker are linked.
+// The settings are configured per space and apply to all repositories of the space and its subspaces,
+// unless a subspace configures its own settings.
+type IssueTrackerSettings struct {
+	// Patterns are the issue key patterns that are linked in commit messages, pull request titles and comments.
+	Patterns []IssueKeyPattern `json:"patterns"`
+
+	// Jira optionally configures the Jira instance that is notified about pull request state transitions.
+	Jira *JiraSettings `json:"jira,omitempty"`
+}
+
+// IssueKeyPattern is a regular expression matching issue keys (e.g. `PROJ-\d+`),
+// together with the URL template the matched keys link to (e.g. "https://jira.example.com/browse/{key}").
+type IssueKeyPattern struct {
+	Pattern string `json:"pattern"`
+	URL     string `json:"url"`
+}
+
+// JiraSettings contains the details required to comment on Jira issues using the Jira REST API.
+type JiraSettings struct {
+	Enabled  bool   `json:"enabled"`
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// TokenSecret is the identifier of the secret of the space that contains the API token of the user.
+	TokenSecret string `json:"token_secret"`
+}
+
+// IssueReference is a reference to an issue found in a text.
+type IssueReference struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+	// Start and End are the byte offsets of the issue key in the text.
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
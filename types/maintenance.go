@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MaintenanceSettings defines the maintenance mode of the system.
+type MaintenanceSettings struct {
+	// ReadOnly blocks all mutating API calls and git pushes, while reads are still served.
+	ReadOnly bool `json:"read_only"`
+	// Message is shown to users whose requests are rejected because of the read-only mode.
+	Message string `json:"message,omitempty"`
+}
+
+// MaintenanceState is the effective maintenance mode of the system.
+type MaintenanceState struct {
+	MaintenanceSettings
+	// Forced is true if the read-only mode is enabled by the config, in which case it can't be disabled at runtime.
+	Forced bool `json:"forced"`
+}
@@ -33,6 +33,28 @@ type Membership struct {
 	Updated   int64 `json:"updated"`
 
 	Role enum.MembershipRole `json:"role"`
+
+	// Expires is set for temporary access grants and holds the time (unix millis) the grant ends.
+	Expires *int64 `json:"expires,omitempty"`
+	// PreviousRole is the role the member had before a temporary grant elevated it.
+	// It's restored once the grant expires. It's empty if the membership only exists because of the grant.
+	PreviousRole enum.MembershipRole `json:"previous_role,omitempty"`
+}
+
+// IsExpired returns true if the membership is a temporary grant that ended before the provided time.
+func (m *Membership) IsExpired(now int64) bool {
+	return m.Expires != nil && *m.Expires <= now
+}
+
+// EffectiveRole returns the role that applies to the membership at the provided time.
+// For expired temporary grants that's the previous role. The second return value is false
+// if the membership doesn't grant any role at all.
+func (m *Membership) EffectiveRole(now int64) (enum.MembershipRole, bool) {
+	if !m.IsExpired(now) {
+		return m.Role, true
+	}
+
+	return m.PreviousRole, m.PreviousRole != ""
 }
 
 // MembershipUser adds user info to the Membership data.
@@ -25,6 +25,8 @@ type SpacePath struct {
 	Value     string `json:"value"`
 	IsPrimary bool   `json:"is_primary"`
 	SpaceID   int64  `json:"space_id"`
+	// AliasSince is the time the oldest alias segment of a non-primary path became an alias.
+	AliasSince int64 `json:"-"`
 }
 
 // SpacePathSegment represents a segment of a path to a space.
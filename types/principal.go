@@ -16,6 +16,9 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -36,6 +39,28 @@ type Principal struct {
 	// Other info
 	Created int64 `db:"principal_created"                json:"created"`
 	Updated int64 `db:"principal_updated"                json:"updated"`
+
+	// Deleted is the time the principal was deleted at, or nil if the principal isn't deleted.
+	Deleted *int64 `db:"principal_deleted"              json:"deleted,omitempty"`
+
+	// Profile
+	Avatar        string `db:"principal_avatar"         json:"-"`
+	TimeZone      string `db:"principal_time_zone"      json:"time_zone"`
+	Pronouns      string `db:"principal_pronouns"       json:"pronouns"`
+	StatusMessage string `db:"principal_status_message" json:"status_message"`
+}
+
+// MarshalJSON overrides the default json marshaling for `Principal` allowing us to inject the `AvatarURL` field.
+func (p *Principal) MarshalJSON() ([]byte, error) {
+	// PrincipalAlias allows us to embed the original Principal object while avoiding an infinite loop of marshaling.
+	type PrincipalAlias Principal
+	return json.Marshal(&struct {
+		*PrincipalAlias
+		AvatarURL string `json:"avatar_url,omitempty"`
+	}{
+		PrincipalAlias: (*PrincipalAlias)(p),
+		AvatarURL:      PrincipalAvatarURL(p.ID, p.Avatar),
+	})
 }
 
 func (p *Principal) ToPrincipalInfo() *PrincipalInfo {
@@ -47,9 +72,21 @@ func (p *Principal) ToPrincipalInfo() *PrincipalInfo {
 		Type:        p.Type,
 		Created:     p.Created,
 		Updated:     p.Updated,
+		AvatarURL:   PrincipalAvatarURL(p.ID, p.Avatar),
 	}
 }
 
+// PrincipalAvatarURL returns the url of the avatar of the principal, relative to the host of the instance.
+// The avatar is part of the url, to ensure clients don't use a cached version after the avatar got changed.
+// An empty string is returned in case the principal doesn't have an avatar.
+func PrincipalAvatarURL(principalID int64, avatar string) string {
+	if avatar == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("/api/v1/principals/%d/avatar?v=%s", principalID, avatar)
+}
+
 // PrincipalInfo is a compressed representation of a principal we return as part of non-principal APIs.
 type PrincipalInfo struct {
 	ID          int64              `json:"id"`
@@ -59,6 +96,7 @@ type PrincipalInfo struct {
 	Type        enum.PrincipalType `json:"type"`
 	Created     int64              `json:"created"`
 	Updated     int64              `json:"updated"`
+	AvatarURL   string             `json:"avatar_url,omitempty"`
 }
 
 func (p *PrincipalInfo) Identifier() int64 {
@@ -56,6 +56,12 @@ type PullReq struct {
 	MergeSHA         *string               `json:"merge_sha"`
 	MergeConflicts   []string              `json:"merge_conflicts,omitempty"`
 
+	// DeleteSourceBranch overrides the repository setting for deleting the source branch after merge.
+	DeleteSourceBranch *bool `json:"delete_source_branch,omitempty"`
+
+	// Conversation restricts who can comment on the pull request.
+	Conversation enum.PullReqConversation `json:"conversation"`
+
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
@@ -97,6 +103,24 @@ type PullReqFilter struct {
 	States        []enum.PullReqState `json:"state"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
+	// CursorID is the ID of the last pull request of the previous page.
+	// If set, keyset pagination is used and Page is ignored.
+	CursorID int64 `json:"-"`
+}
+
+// PullReqSearchFilter stores pull request full-text search parameters.
+type PullReqSearchFilter struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+
+	// Terms are the individual words a pull request has to contain.
+	Terms []string `json:"terms"`
+
+	// Phrases are the word sequences a pull request has to contain.
+	Phrases []string `json:"phrases"`
+
+	CreatedBy int64   `json:"created_by"`
+	RepoIDs   []int64 `json:"-"`
 }
 
 // PullReqReview holds pull request review.
@@ -133,6 +157,34 @@ type PullReqReviewer struct {
 	AddedBy  PrincipalInfo `json:"added_by"`
 }
 
+// MergeSchedule defines when and how a pull request is merged automatically.
+type MergeSchedule struct {
+	PullReqID int64            `json:"-"`
+	CreatedBy int64            `json:"created_by"`
+	Created   int64            `json:"created"`
+	MergeAt   int64            `json:"merge_at"`
+	Method    enum.MergeMethod `json:"method"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+
+	// SourceSHA is the source branch commit that was approved when the merge was scheduled.
+	// The scheduled merge fails if the source branch got updated in the meantime.
+	SourceSHA string `json:"source_sha"`
+}
+
+// PendingReview is a review that was requested from a reviewer of an open pull request but wasn't submitted yet.
+type PendingReview struct {
+	PullReqID   int64
+	RepoID      int64
+	PrincipalID int64
+	// Requested is the time the review was requested.
+	Requested int64
+	// Reminded is the time the reviewer was last reminded of the review, zero if never.
+	Reminded int64
+	// SnoozedUntil is the time until which the reviewer doesn't want to be reminded.
+	SnoozedUntil int64
+}
+
 // PullReqFileView represents a file reviewed entry for a given pr and principal.
 // NOTE: keep api lightweight and don't return unnecessary extra data.
 type PullReqFileView struct {
@@ -160,9 +212,17 @@ type MergeResponse struct {
 	RequiresCodeOwnersApproval    bool               `json:"requires_code_owners_approval,omitempty"`
 	RequiresCommentResolution     bool               `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests      bool               `json:"requires_no_change_requests,omitempty"`
+	ChangesRequestedBy            []PrincipalInfo    `json:"changes_requested_by,omitempty"`
+	LintViolations                []string           `json:"lint_violations,omitempty"`
 }
 
 type MergeViolations struct {
 	ConflictFiles  []string         `json:"conflict_files,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+	// ChangesRequestedBy lists the reviewers whose change requests block the merge
+	// until they approve the pull request or their review gets dismissed.
+	ChangesRequestedBy []PrincipalInfo `json:"changes_requested_by,omitempty"`
+	// LintViolations lists the problems of the title and description of the pull request
+	// according to the pull request lint policy of the repository.
+	LintViolations []string `json:"lint_violations,omitempty"`
 }
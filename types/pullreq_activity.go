@@ -60,6 +60,10 @@ type PullReqActivity struct {
 	ResolvedBy *int64 `json:"-"` // not returned, because the resolver info is in the Resolver field
 	Resolved   *int64 `json:"resolved,omitempty"`
 
+	HiddenBy     *int64                           `json:"-"` // not returned, only moderators can hide comments
+	Hidden       *int64                           `json:"hidden,omitempty"`
+	HiddenReason *enum.PullReqCommentHiddenReason `json:"hidden_reason,omitempty"`
+
 	Author   PrincipalInfo  `json:"author"`
 	Resolver *PrincipalInfo `json:"resolver,omitempty"`
 
@@ -188,8 +192,11 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadStateChange{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadTitleChange{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewDismiss{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchForcePush{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMention{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -259,6 +266,16 @@ func (a *PullRequestActivityPayloadReviewSubmit) ActivityType() enum.PullReqActi
 	return enum.PullReqActivityTypeReviewSubmit
 }
 
+type PullRequestActivityPayloadReviewDismiss struct {
+	ReviewerID int64  `json:"reviewer_id"`
+	CommitSHA  string `json:"commit_sha"`
+	Reason     string `json:"reason"`
+}
+
+func (a *PullRequestActivityPayloadReviewDismiss) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeReviewDismiss
+}
+
 type PullRequestActivityPayloadBranchUpdate struct {
 	Old string `json:"old"`
 	New string `json:"new"`
@@ -268,6 +285,27 @@ func (a *PullRequestActivityPayloadBranchUpdate) ActivityType() enum.PullReqActi
 	return enum.PullReqActivityTypeBranchUpdate
 }
 
+// PullRequestActivityPayloadBranchForcePush is the payload of a source branch update that rewrote its history.
+type PullRequestActivityPayloadBranchForcePush struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (a *PullRequestActivityPayloadBranchForcePush) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeBranchForcePush
+}
+
+// PullRequestActivityPayloadMention is the payload of principals being mentioned in the pull request.
+type PullRequestActivityPayloadMention struct {
+	PrincipalIDs []int64 `json:"principal_ids"`
+	// CommentID is the id of the comment containing the mentions, nil for mentions in the description.
+	CommentID *int64 `json:"comment_id,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMention) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMention
+}
+
 type PullRequestActivityPayloadBranchDelete struct {
 	SHA string `json:"sha"`
 }
@@ -275,3 +313,15 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+// PullReqActivityEdit is a version of the text of an edited pull request comment.
+// The first version of an edited comment is its original text.
+type PullReqActivityEdit struct {
+	ID         int64  `json:"id"`
+	ActivityID int64  `json:"activity_id"`
+	Text       string `json:"text"`
+	EditedBy   int64  `json:"-"` // not returned, because the editor info is in the Editor field
+	Edited     int64  `json:"edited"`
+
+	Editor PrincipalInfo `json:"editor"`
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PushedBranch represents the latest branch a user created by pushing to a repository.
+// It's used by the UI to offer the creation of a pull request for the branch.
+type PushedBranch struct {
+	RepoID      int64  `json:"repo_id"`
+	PrincipalID int64  `json:"principal_id"`
+	Branch      string `json:"branch"`
+	SHA         string `json:"sha"`
+	Created     int64  `json:"created"`
+
+	// CompareURL is the UI url for comparing the branch with the default branch of the repository.
+	CompareURL string `json:"compare_url,omitempty"`
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Registry is an artifact registry of a space.
+type Registry struct {
+	ID          int64             `json:"id"`
+	SpaceID     int64             `json:"space_id"`
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Type        enum.RegistryType `json:"type"`
+	// MaxVersions is the number of most recent versions kept per artifact, 0 keeps all versions.
+	MaxVersions int64 `json:"max_versions"`
+	// MaxAge is the time (in milliseconds) after which versions are removed, 0 keeps versions forever.
+	MaxAge    int64 `json:"max_age"`
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// RegistryArtifact is a named artifact of a registry, i.e. an image or a generic package.
+type RegistryArtifact struct {
+	ID         int64  `json:"id"`
+	RegistryID int64  `json:"registry_id"`
+	Name       string `json:"name"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+}
+
+// RegistryVersion is a version of an artifact.
+// For container images it's a tag (or an untagged manifest) pointing at a manifest,
+// for generic packages it's a single file of a package version.
+type RegistryVersion struct {
+	ID         int64  `json:"id"`
+	ArtifactID int64  `json:"artifact_id"`
+	Name       string `json:"name"`
+	FileName   string `json:"file_name,omitempty"`
+	Digest     string `json:"digest"`
+	MediaType  string `json:"media_type"`
+	Size       int64  `json:"size"`
+	CreatedBy  int64  `json:"created_by"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+}
+
+// RegistryBlob is a content addressable blob stored for a registry.
+type RegistryBlob struct {
+	ID         int64  `json:"id"`
+	RegistryID int64  `json:"registry_id"`
+	Digest     string `json:"digest"`
+	MediaType  string `json:"media_type"`
+	Size       int64  `json:"size"`
+	Created    int64  `json:"created"`
+}
+
+// RegistryUpload is an in-progress chunked blob upload.
+type RegistryUpload struct {
+	ID         int64  `json:"id"`
+	RegistryID int64  `json:"registry_id"`
+	Identifier string `json:"identifier"`
+	Size       int64  `json:"size"`
+	Parts      int64  `json:"parts"`
+	CreatedBy  int64  `json:"created_by"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+}
+
+// RegistryFilter stores registry query parameters.
+type RegistryFilter struct {
+	ListQueryFilter
+	Type enum.RegistryType `json:"type"`
+}
+
+// RegistryManifestReference records that a manifest references a blob or another manifest.
+type RegistryManifestReference struct {
+	ManifestDigest string `json:"manifest_digest"`
+	Digest         string `json:"digest"`
+}
@@ -24,7 +24,7 @@ import (
 type Repository struct {
 	// TODO: int64 ID doesn't match DB
 	ID          int64  `json:"id"`
-	Version     int64  `json:"-"`
+	Version     int64  `json:"version"`
 	ParentID    int64  `json:"parent_id"`
 	Identifier  string `json:"identifier"`
 	Path        string `json:"path"`
@@ -42,6 +42,7 @@ type Repository struct {
 	DefaultBranch string `json:"default_branch"`
 	ForkID        int64  `json:"fork_id"`
 	PullReqSeq    int64  `json:"-"`
+	IssueSeq      int64  `json:"-"`
 
 	NumForks       int `json:"num_forks"`
 	NumPulls       int `json:"num_pulls"`
@@ -51,6 +52,16 @@ type Repository struct {
 
 	Importing bool `json:"importing"`
 
+	// Archived repositories are read-only until they are unarchived:
+	// they can't be pushed to and their pull requests can't be created or merged.
+	Archived bool `json:"archived"`
+
+	// PullReqSuggestions defines whether pushes of new branches are answered with a link for creating a pull request.
+	PullReqSuggestions bool `json:"pullreq_suggestions"`
+
+	// PullReqDeleteSourceBranch defines whether the source branch of a pull request is deleted after it's merged.
+	PullReqDeleteSourceBranch bool `json:"pullreq_delete_source_branch"`
+
 	// git urls
 	GitURL string `json:"git_url"`
 }
@@ -88,11 +99,17 @@ type RepoFilter struct {
 	Order             enum.Order    `json:"order"`
 	DeletedBeforeOrAt *int64        `json:"deleted_before_or_at,omitempty"`
 	Recursive         bool
+	// Archived restricts the list to archived (true) or unarchived (false) repositories, if set.
+	Archived *bool `json:"archived,omitempty"`
+	// CursorID is the ID of the last repository of the previous page.
+	// If set, keyset pagination is used and Page is ignored.
+	CursorID int64 `json:"-"`
 }
 
 // RepositoryGitInfo holds git info for a repository.
 type RepositoryGitInfo struct {
-	ID       int64
-	ParentID int64
-	GitUID   string
+	ID            int64
+	ParentID      int64
+	GitUID        string
+	DefaultBranch string
 }
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoDependencies contains the license and the declared dependencies of the default branch of a repository.
+type RepoDependencies struct {
+	RepoID int64 `json:"-"`
+	// SHA is the commit of the default branch the dependencies were detected for.
+	SHA      string `json:"sha"`
+	Computed int64  `json:"computed"`
+	// License is the SPDX identifier of the detected repository license, or empty if none was recognized.
+	License      string       `json:"license"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Dependency is a single dependency declared in a dependency manifest of a repository.
+type Dependency struct {
+	// Manifest is the path of the manifest file that declares the dependency.
+	Manifest  string                   `json:"manifest"`
+	Ecosystem enum.DependencyEcosystem `json:"ecosystem"`
+	Name      string                   `json:"name"`
+	// Version is the declared version, or version constraint, of the dependency. It's empty if unspecified.
+	Version string `json:"version"`
+}
@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoEvent is a real-time update of a repository (or one of its pull requests)
+// that is kept for a limited time, so that event stream clients can resume after reconnecting.
+type RepoEvent struct {
+	ID        int64
+	RepoID    int64
+	PullReqID *int64
+	Type      enum.SSEType
+	Data      json.RawMessage
+	Created   int64
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepoInsights contains the statistics computed from the history of the default branch of a repository.
+type RepoInsights struct {
+	RepoID int64 `json:"-"`
+	// SHA is the commit of the default branch the statistics were computed for.
+	SHA      string `json:"sha"`
+	Computed int64  `json:"computed"`
+
+	Weeks        []InsightsWeek        `json:"weeks"`
+	Contributors []InsightsContributor `json:"contributors"`
+}
+
+// InsightsWeek contains the number of commits and changed lines of a single week.
+type InsightsWeek struct {
+	// Week is the start of the week (Monday, 00:00 UTC) in unix milliseconds.
+	Week      int64 `json:"week"`
+	Commits   int64 `json:"commits"`
+	Additions int64 `json:"additions"`
+	Deletions int64 `json:"deletions"`
+}
+
+// InsightsCommitActivity contains the number of commits of a single week.
+type InsightsCommitActivity struct {
+	Week    int64 `json:"week"`
+	Commits int64 `json:"commits"`
+}
+
+// InsightsCodeFrequency contains the number of added and deleted lines of a single week.
+type InsightsCodeFrequency struct {
+	Week      int64 `json:"week"`
+	Additions int64 `json:"additions"`
+	Deletions int64 `json:"deletions"`
+}
+
+// InsightsContributor contains the contribution statistics of a single commit author.
+type InsightsContributor struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Commits     int64  `json:"commits"`
+	Additions   int64  `json:"additions"`
+	Deletions   int64  `json:"deletions"`
+	FirstCommit int64  `json:"first_commit"`
+	LastCommit  int64  `json:"last_commit"`
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepoLanguages contains the language distribution of the default branch of a repository.
+type RepoLanguages struct {
+	RepoID int64 `json:"-"`
+	// SHA is the commit of the default branch the distribution was computed for.
+	SHA       string         `json:"sha"`
+	Computed  int64          `json:"computed"`
+	Languages []LanguageStat `json:"languages"`
+}
+
+// LanguageStat contains the size of all files of a single language.
+type LanguageStat struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+	Files int64  `json:"files"`
+	Bytes int64  `json:"bytes"`
+	// Percentage is the share of the language in the total size of all detected languages.
+	Percentage float64 `json:"percentage"`
+}
+
+// RepositorySummary contains an overview of the contents of a repository.
+type RepositorySummary struct {
+	BranchCount    int64                    `json:"branch_count"`
+	TagCount       int64                    `json:"tag_count"`
+	PullReqSummary RepositoryPullReqSummary `json:"pull_req_summary"`
+	Languages      []LanguageStat           `json:"languages"`
+}
+
+// RepositoryPullReqSummary contains the number of pull requests targeting a repository per state.
+type RepositoryPullReqSummary struct {
+	OpenCount   int64 `json:"open_count"`
+	ClosedCount int64 `json:"closed_count"`
+	MergedCount int64 `json:"merged_count"`
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Runner is an external build agent that executes pipeline stages of the repositories in its space.
+type Runner struct {
+	ID         int64  `json:"id"`
+	SpaceID    int64  `json:"space_id"`
+	Identifier string `json:"identifier"`
+	// TokenHash is the hash of the token the runner authenticates with.
+	TokenHash string            `json:"-"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	Kernel    string            `json:"kernel,omitempty"`
+	Variant   string            `json:"variant,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Version   string            `json:"version,omitempty"`
+	// Capacity is the number of stages the runner can execute in parallel.
+	Capacity int `json:"capacity"`
+	// Running is the number of stages the runner reported to execute at the last heartbeat.
+	Running       int   `json:"running"`
+	LastHeartbeat int64 `json:"last_heartbeat"`
+	Created       int64 `json:"created"`
+	Updated       int64 `json:"updated"`
+}
+
+// RunnerRegistrationToken allows external runners to register themselves in a space.
+type RunnerRegistrationToken struct {
+	ID          int64  `json:"id"`
+	SpaceID     int64  `json:"space_id"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	// Hash is the hash of the token, the token itself is only returned once on creation.
+	Hash      string `json:"-"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	// Expires is the time the token expires at (unix milliseconds), 0 if it never expires.
+	Expires int64 `json:"expires"`
+}
+
+// RunnerStage is a pipeline stage leased by an external runner.
+type RunnerStage struct {
+	// StageID is the id the runner uses to report the progress of the stage.
+	StageID   int64           `json:"stage_id"`
+	Stage     *Stage          `json:"stage"`
+	Execution *Execution      `json:"execution"`
+	Repo      *Repository     `json:"repository"`
+	Secrets   []*RunnerSecret `json:"secrets"`
+	// Config is the pipeline configuration.
+	Config string `json:"config"`
+	// Netrc holds the credentials for cloning the repository.
+	Netrc *RunnerNetrc `json:"netrc,omitempty"`
+	// LeaseExpires is the time the lease expires at (unix milliseconds) unless the runner sends a heartbeat.
+	LeaseExpires int64 `json:"lease_expires"`
+}
+
+// RunnerNetrc contains the credentials used by the runner to clone the repository.
+type RunnerNetrc struct {
+	Machine  string `json:"machine"`
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+// RunnerSecret is a decrypted secret provided to the runner for the execution of a stage.
+type RunnerSecret struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+// RunnerRegistrationTokenCreateOutput contains the created registration token together with the token itself.
+type RunnerRegistrationTokenCreateOutput struct {
+	RunnerRegistrationToken
+	// Token is the registration token, it's only returned once.
+	Token string `json:"token"`
+}
+
+// RunnerRegisterOutput contains the registered runner together with its access token.
+type RunnerRegisterOutput struct {
+	Runner *Runner `json:"runner"`
+	// AccessToken is the token the runner authenticates with, it's only returned once.
+	AccessToken string `json:"access_token"`
+}
+
+// RunnerHeartbeatOutput is returned to an external runner on a heartbeat.
+type RunnerHeartbeatOutput struct {
+	// LeaseDuration is the time (in seconds) within which the runner has to send the next heartbeat.
+	LeaseDuration int64 `json:"lease_duration"`
+}
+
+// RunnerWatchOutput is returned to an external runner watching for the cancellation of an execution.
+type RunnerWatchOutput struct {
+	Cancelled bool `json:"cancelled"`
+}
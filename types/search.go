@@ -31,6 +31,18 @@ type (
 		EnableRegex bool `json:"enable_regex"`
 	}
 
+	// PullReqSearchInput holds the input of a full-text search over pull requests.
+	// The query supports quoted phrases as well as "author:<uid>" and "repo:<path>" filters.
+	PullReqSearchInput struct {
+		Query string `json:"query"`
+
+		// SpacePath contains the path of the space to search in
+		SpacePath string `json:"space_path"`
+
+		Page int `json:"page"`
+		Size int `json:"size"`
+	}
+
 	SearchResult struct {
 		FileMatches []FileMatch `json:"file_matches"`
 		Stats       SearchStats `json:"stats"`
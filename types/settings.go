@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+const (
+	// SettingsKeyNotifications is the key of the notification settings of a principal.
+	SettingsKeyNotifications = "notifications"
+
+	// SettingsKeyIssueTracker is the key of the issue tracker settings of a space.
+	SettingsKeyIssueTracker = "issue_tracker"
+
+	// SettingsKeyInheritance is the key of the inheritance flags of a space.
+	SettingsKeyInheritance = "inheritance"
+
+	// SettingsKeyRepoTemplate is the key of the template for new repositories of a space.
+	SettingsKeyRepoTemplate = "repo_template"
+
+	// SettingsKeyRepoArchival is the key of the automatic archival policy for repositories of a space.
+	SettingsKeyRepoArchival = "repo_archival"
+
+	// SettingsKeyReviewReminders is the key of the review reminder policy for pull requests of a space.
+	SettingsKeyReviewReminders = "review_reminders"
+
+	// SettingsKeyReviewerAssignment is the key of the automatic reviewer assignment settings of a repository.
+	SettingsKeyReviewerAssignment = "reviewer_assignment"
+
+	// SettingsKeyPullReqLint is the key of the pull request title and description lint policy of a repository.
+	SettingsKeyPullReqLint = "pullreq_lint"
+
+	// SettingsKeyMergeMessageTemplates is the key of the merge commit message templates of a repository.
+	SettingsKeyMergeMessageTemplates = "merge_message_templates"
+
+	// SettingsKeyBreakGlass is the key of the break-glass access of an instance admin.
+	SettingsKeyBreakGlass = "break_glass"
+
+	// SettingsKeyMaintenance is the key of the maintenance mode of the system.
+	SettingsKeyMaintenance = "maintenance"
+
+	// SettingsKeyInstance is the key of the instance settings that override the configuration at runtime.
+	SettingsKeyInstance = "instance"
+)
+
+// NotificationSettings contains the notification preferences of a user.
+type NotificationSettings struct {
+	Level enum.NotificationLevel `json:"level"`
+}
+
+// DefaultNotificationSettings returns the notification settings used for users that didn't configure any.
+func DefaultNotificationSettings() *NotificationSettings {
+	return &NotificationSettings{
+		Level: enum.NotificationLevelAll,
+	}
+}
+
+// SpaceInheritance defines which settings a space inherits from its ancestors.
+// A space that doesn't inherit a setting overrides it for itself and all its subspaces.
+type SpaceInheritance struct {
+	// Memberships is false if memberships of ancestor spaces don't grant access to the space.
+	Memberships bool `json:"memberships"`
+	// Rules is false if protection rules of ancestor spaces don't apply to repositories of the space.
+	Rules bool `json:"rules"`
+	// IssueTracker is false if issue tracker settings of ancestor spaces don't apply to the space.
+	IssueTracker bool `json:"issue_tracker"`
+	// RepoTemplate is false if repository templates of ancestor spaces don't apply to the space.
+	RepoTemplate bool `json:"repo_template"`
+}
+
+// DefaultSpaceInheritance returns the inheritance flags used for spaces that didn't configure any.
+func DefaultSpaceInheritance() *SpaceInheritance {
+	return &SpaceInheritance{
+		Memberships:  true,
+		Rules:        true,
+		IssueTracker: true,
+		RepoTemplate: true,
+	}
+}
+
+// Inherits returns true if the space inherits the setting from its parent space.
+func (i *SpaceInheritance) Inherits(setting enum.InheritedSetting) bool {
+	switch setting {
+	case enum.InheritedSettingMemberships:
+		return i.Memberships
+	case enum.InheritedSettingRules:
+		return i.Rules
+	case enum.InheritedSettingIssueTracker:
+		return i.IssueTracker
+	case enum.InheritedSettingRepoTemplate:
+		return i.RepoTemplate
+	default:
+		return true
+	}
+}
+
+// EffectiveSpaceSetting is a setting that applies to a space, together with the space it's defined on.
+type EffectiveSpaceSetting struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+	// SourcePath is the path of the space the value is defined on.
+	SourcePath string `json:"source_path"`
+	// Inherited is true if the value is defined on an ancestor of the space.
+	Inherited bool `json:"inherited"`
+}
+
+// RepoTemplate defines the defaults applied to repositories created in a space.
+type RepoTemplate struct {
+	// DefaultBranch is used in case the repository is created without a default branch.
+	DefaultBranch string `json:"default_branch,omitempty"`
+	// Readme, License and GitIgnore define the files committed to new repositories,
+	// in case the repository is created without them.
+	Readme    bool   `json:"readme"`
+	License   string `json:"license,omitempty"`
+	GitIgnore string `json:"git_ignore,omitempty"`
+	// RequiredChecks are the status checks required to pass before pull requests
+	// can be merged into the default branch of new repositories.
+	RequiredChecks []string              `json:"required_checks"`
+	Webhooks       []RepoTemplateWebhook `json:"webhooks"`
+}
+
+// RepoTemplateWebhook defines a webhook that is created for new repositories.
+type RepoTemplateWebhook struct {
+	Identifier string                `json:"identifier"`
+	URL        string                `json:"url"`
+	Secret     string                `json:"secret,omitempty"`
+	Insecure   bool                  `json:"insecure"`
+	Triggers   []enum.WebhookTrigger `json:"triggers"`
+}
+
+// RepoArchivalPolicy defines when repositories of a space and its subspaces are archived automatically.
+type RepoArchivalPolicy struct {
+	// InactiveMonths is the number of months without any push or pull request activity
+	// after which a repository is archived.
+	InactiveMonths int `json:"inactive_months"`
+}
+
+// ReviewReminderPolicy defines when requested reviewers of pull requests in a space and its subspaces
+// are reminded of the pending review.
+type ReviewReminderPolicy struct {
+	// AfterHours is the number of hours a review can be pending before the reviewer is reminded.
+	AfterHours int `json:"after_hours"`
+	// RepeatHours is the number of hours after which the reminder is repeated, zero reminds only once.
+	RepeatHours int `json:"repeat_hours"`
+}
+
+// ReviewerAssignmentSettings define how reviewers are assigned automatically to new pull requests of a repository.
+type ReviewerAssignmentSettings struct {
+	Strategy enum.ReviewerAssignmentStrategy `json:"strategy"`
+	// Pool lists the ids of the principals from which Count reviewers are picked.
+	Pool  []int64 `json:"pool"`
+	Count int     `json:"count"`
+	// CodeOwners picks one reviewer for every CODEOWNERS entry that applies to the changes of the pull request,
+	// unless one of the owners of the entry already is a reviewer.
+	CodeOwners bool `json:"code_owners"`
+}
+
+// PullReqLintPolicy defines the requirements for the title and description of pull requests of a repository.
+// Pull requests that don't meet them can't be merged.
+type PullReqLintPolicy struct {
+	// TitlePattern is a regular expression the title has to match (e.g. for Conventional Commits).
+	TitlePattern string `json:"title_pattern"`
+	// MinDescriptionLength is the minimum number of characters of the description.
+	MinDescriptionLength int `json:"min_description_length"`
+	// RequiredSections lists the markdown headings of the sections the description has to contain with content.
+	RequiredSections []string `json:"required_sections"`
+}
+
+// MergeMessageTemplates define the commit messages created when pull requests of a repository are merged.
+// Methods without template use the default commit message.
+type MergeMessageTemplates struct {
+	Merge  *CommitMessageTemplate `json:"merge,omitempty"`
+	Squash *CommitMessageTemplate `json:"squash,omitempty"`
+}
+
+// CommitMessageTemplate is the template of a commit message, variables are referenced as {{variable}}.
+type CommitMessageTemplate struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// BreakGlass describes the break-glass access of an instance admin,
+// which grants access to all spaces for incident response until it expires.
+type BreakGlass struct {
+	Justification string `json:"justification"`
+	Activated     int64  `json:"activated"`
+	Expires       int64  `json:"expires"`
+}
+
+// IsActive returns true if the break-glass access didn't expire before the provided time (unix millis).
+func (b *BreakGlass) IsActive(now int64) bool {
+	return b.Expires > now
+}
@@ -34,7 +34,7 @@ https://www.slideshare.net/billkarwin/models-for-hierarchical-data
 */
 type Space struct {
 	ID          int64  `json:"id"`
-	Version     int64  `json:"-"`
+	Version     int64  `json:"version"`
 	ParentID    int64  `json:"parent_id"`
 	Path        string `json:"path"`
 	Identifier  string `json:"identifier"`
@@ -44,6 +44,10 @@ type Space struct {
 	Created     int64  `json:"created"`
 	Updated     int64  `json:"updated"`
 	Deleted     *int64 `json:"deleted,omitempty"`
+
+	// GitBandwidthLimit is the maximum number of bytes per second of a single git transfer
+	// for repositories in the space (0 means no limit, the instance wide limits always apply).
+	GitBandwidthLimit int64 `json:"git_bandwidth_limit"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
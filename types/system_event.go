@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// SystemEvent represents a significant activity on the instance, as shown in the admin event feed.
+type SystemEvent struct {
+	ID           int64                `db:"system_event_id"            json:"id"`
+	Type         enum.SystemEventType `db:"system_event_type"          json:"type"`
+	PrincipalID  *int64               `db:"system_event_principal_id"  json:"principal_id,omitempty"`
+	ResourceID   *int64               `db:"system_event_resource_id"   json:"resource_id,omitempty"`
+	ResourcePath string               `db:"system_event_resource_path" json:"resource_path"`
+	Message      string               `db:"system_event_message"       json:"message"`
+	Created      int64                `db:"system_event_created"       json:"created"`
+}
+
+// SystemEventFilter stores system event query parameters.
+type SystemEventFilter struct {
+	Page  int                    `json:"page"`
+	Size  int                    `json:"size"`
+	Types []enum.SystemEventType `json:"types"`
+	// Since filters out events created before the provided timestamp (unix milliseconds).
+	Since int64 `json:"since"`
+}
@@ -47,6 +47,12 @@ func (t Token) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// TokenScope restricts the access of a token to a role within a space (including its subspaces).
+type TokenScope struct {
+	SpaceRef string              `json:"space_ref"`
+	Role     enum.MembershipRole `json:"role"`
+}
+
 // TokenResponse is returned as part of token creation for PAT / SAT / User Session.
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
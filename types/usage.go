@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// UsageMetric holds the usage of a repository during a single (UTC) day.
+type UsageMetric struct {
+	RepoID int64 `json:"repo_id"`
+	// Date is the start of the day in unix milliseconds.
+	Date            int64 `json:"date"`
+	DiskUsage       int64 `json:"disk_usage"`
+	PullReqsCreated int64 `json:"pullreqs_created"`
+	PullReqsMerged  int64 `json:"pullreqs_merged"`
+	GitBytesIn      int64 `json:"git_bytes_in"`
+	GitBytesOut     int64 `json:"git_bytes_out"`
+	Updated         int64 `json:"updated"`
+}
+
+// UsageFilter stores the usage report query parameters.
+type UsageFilter struct {
+	Window enum.UsageWindow `json:"window"`
+}
+
+// UsageDailyStats holds the aggregated usage of a set of repositories during a single (UTC) day.
+type UsageDailyStats struct {
+	Date            int64 `json:"date"`
+	DiskUsage       int64 `json:"disk_usage"`
+	ActiveUsers     int64 `json:"active_users"`
+	PullReqsCreated int64 `json:"pullreqs_created"`
+	PullReqsMerged  int64 `json:"pullreqs_merged"`
+	GitBytesIn      int64 `json:"git_bytes_in"`
+	GitBytesOut     int64 `json:"git_bytes_out"`
+}
+
+// UsageReport holds the usage of a space (or the whole instance) during a time window.
+type UsageReport struct {
+	Window enum.UsageWindow `json:"window"`
+	From   int64            `json:"from"`
+	To     int64            `json:"to"`
+
+	// RepoCount and DiskUsage reflect the current state.
+	RepoCount int64 `json:"repo_count"`
+	DiskUsage int64 `json:"disk_usage"`
+
+	// ActiveUsers is the number of distinct users that pushed, fetched, opened or merged pull requests.
+	ActiveUsers     int64 `json:"active_users"`
+	PullReqsCreated int64 `json:"pullreqs_created"`
+	PullReqsMerged  int64 `json:"pullreqs_merged"`
+	GitBytesIn      int64 `json:"git_bytes_in"`
+	GitBytesOut     int64 `json:"git_bytes_out"`
+
+	Daily []UsageDailyStats `json:"daily"`
+}
@@ -16,6 +16,8 @@
 package types
 
 import (
+	"encoding/json"
+
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -32,9 +34,18 @@ type (
 		Salt        string `db:"principal_salt"           json:"-"`
 		Created     int64  `db:"principal_created"        json:"created"`
 		Updated     int64  `db:"principal_updated"        json:"updated"`
+		Deleted     *int64 `db:"principal_deleted"        json:"deleted,omitempty"`
+
+		// Profile fields from Principal
+		Avatar        string `db:"principal_avatar"         json:"-"`
+		TimeZone      string `db:"principal_time_zone"      json:"time_zone"`
+		Pronouns      string `db:"principal_pronouns"       json:"pronouns"`
+		StatusMessage string `db:"principal_status_message" json:"status_message"`
 
 		// User specific fields
 		Password string `db:"principal_user_password"    json:"-"`
+		// ApprovalPending is true if the user registered but wasn't approved by an admin yet.
+		ApprovalPending bool `db:"principal_user_approval_pending" json:"approval_pending"`
 	}
 
 	// UserInput store user account details used to
@@ -53,6 +64,12 @@ type (
 		Sort  enum.UserAttr `json:"sort"`
 		Order enum.Order    `json:"order"`
 		Admin bool          `json:"admin"`
+
+		// Deleted indicates whether only deleted users are listed (otherwise deleted users are excluded).
+		Deleted bool `json:"deleted"`
+
+		// ApprovalPending indicates whether only users waiting for the approval of an admin are listed.
+		ApprovalPending bool `json:"approval_pending"`
 	}
 )
 
@@ -68,9 +85,28 @@ func (u *User) ToPrincipal() *Principal {
 		Salt:        u.Salt,
 		Created:     u.Created,
 		Updated:     u.Updated,
+		Deleted:     u.Deleted,
+
+		Avatar:        u.Avatar,
+		TimeZone:      u.TimeZone,
+		Pronouns:      u.Pronouns,
+		StatusMessage: u.StatusMessage,
 	}
 }
 
+// MarshalJSON overrides the default json marshaling for `User` allowing us to inject the `AvatarURL` field.
+func (u *User) MarshalJSON() ([]byte, error) {
+	// UserAlias allows us to embed the original User object while avoiding an infinite loop of marshaling.
+	type UserAlias User
+	return json.Marshal(&struct {
+		*UserAlias
+		AvatarURL string `json:"avatar_url,omitempty"`
+	}{
+		UserAlias: (*UserAlias)(u),
+		AvatarURL: PrincipalAvatarURL(u.ID, u.Avatar),
+	})
+}
+
 func (u *User) ToPrincipalInfo() *PrincipalInfo {
 	return u.ToPrincipal().ToPrincipalInfo()
 }
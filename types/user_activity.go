@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Push represents a single reference update pushed to a repository by a principal.
+type Push struct {
+	ID          int64  `json:"id"`
+	RepoID      int64  `json:"repo_id"`
+	PrincipalID int64  `json:"principal_id"`
+	Ref         string `json:"ref"`
+	OldSHA      string `json:"old_sha"`
+	NewSHA      string `json:"new_sha"`
+	Created     int64  `json:"created"`
+}
+
+// UserActivity represents a single entry in the activity feed of a user.
+type UserActivity struct {
+	Type     enum.UserActivityType `json:"type"`
+	RepoID   int64                 `json:"repo_id"`
+	RepoPath string                `json:"repo_path"`
+	Created  int64                 `json:"created"`
+
+	// Ref and SHA are set for pushes.
+	Ref string `json:"ref,omitempty"`
+	SHA string `json:"sha,omitempty"`
+
+	// PullReqNumber and PullReqTitle are set for all pull request related activities.
+	PullReqNumber int64  `json:"pullreq_number,omitempty"`
+	PullReqTitle  string `json:"pullreq_title,omitempty"`
+
+	// ReviewDecision is set for pull request reviews.
+	ReviewDecision enum.PullReqReviewDecision `json:"review_decision,omitempty"`
+}
+
+// UserActivityFilter stores the user activity query parameters.
+type UserActivityFilter struct {
+	Page  int                     `json:"page"`
+	Size  int                     `json:"size"`
+	Types []enum.UserActivityType `json:"types"`
+	// Since and Until limit the activities to the [since, until) time range (unix milliseconds).
+	Since int64 `json:"since"`
+	Until int64 `json:"until"`
+	// RepoIDs limits the activities to the provided repositories.
+	RepoIDs []int64 `json:"-"`
+}
+
+// UserContributionDay holds the number of contributions of a user during a single day.
+type UserContributionDay struct {
+	// Date is the day in YYYY-MM-DD format, in the time zone of the summary.
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// UserContributions holds the number of contributions of a user per day, as used by profile heatmaps.
+// Days without any contributions are omitted.
+type UserContributions struct {
+	TimeZone string                `json:"time_zone"`
+	From     string                `json:"from"`
+	To       string                `json:"to"`
+	Total    int64                 `json:"total"`
+	Days     []UserContributionDay `json:"days"`
+}
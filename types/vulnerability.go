@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// VulnerabilityAlert records a known vulnerability that affects a dependency declared by a repository.
+type VulnerabilityAlert struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"repo_id"`
+	// VulnerabilityID is the identifier of the vulnerability in the OSV database, e.g. "GHSA-xxxx-xxxx-xxxx".
+	VulnerabilityID string                   `json:"vulnerability_id"`
+	Ecosystem       enum.DependencyEcosystem `json:"ecosystem"`
+	Package         string                   `json:"package"`
+	Version         string                   `json:"version"`
+	// Manifest is the path of the manifest file that declares the vulnerable dependency.
+	Manifest string                     `json:"manifest"`
+	Severity enum.VulnerabilitySeverity `json:"severity"`
+	Summary  string                     `json:"summary"`
+	// FixedVersion is the first version of the package that fixes the vulnerability, or empty if there's none.
+	FixedVersion string                       `json:"fixed_version"`
+	State        enum.VulnerabilityAlertState `json:"state"`
+	Created      int64                        `json:"created"`
+	Updated      int64                        `json:"updated"`
+	Resolved     *int64                       `json:"resolved,omitempty"`
+}
+
+// VulnerabilityAlertFilter stores vulnerability alert query parameters.
+type VulnerabilityAlertFilter struct {
+	Pagination
+	States []enum.VulnerabilityAlertState `json:"states"`
+}
@@ -34,13 +34,16 @@ type Webhook struct {
 
 	Identifier string `json:"identifier"`
 	// TODO [CODE-1364]: Remove once UID/Identifier migration is completed.
-	DisplayName           string                       `json:"display_name"`
-	Description           string                       `json:"description"`
-	URL                   string                       `json:"url"`
-	Secret                string                       `json:"-"`
-	Enabled               bool                         `json:"enabled"`
-	Insecure              bool                         `json:"insecure"`
-	Triggers              []enum.WebhookTrigger        `json:"triggers"`
+	DisplayName string                `json:"display_name"`
+	Description string                `json:"description"`
+	URL         string                `json:"url"`
+	Secret      string                `json:"-"`
+	Enabled     bool                  `json:"enabled"`
+	Insecure    bool                  `json:"insecure"`
+	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// Headers are custom http headers sent with every delivery.
+	// Values can reference secrets using the ${{ secrets.IDENTIFIER }} syntax.
+	Headers               map[string]string            `json:"headers,omitempty"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
 }
 
@@ -111,4 +114,7 @@ type WebhookFilter struct {
 type WebhookExecutionFilter struct {
 	Page int `json:"page"`
 	Size int `json:"size"`
+	// CursorID is the ID of the last webhook execution of the previous page.
+	// If set, keyset pagination is used and Page is ignored.
+	CursorID int64 `json:"-"`
 }
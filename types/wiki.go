@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Wiki describes the wiki of a repository.
+type Wiki struct {
+	GitURL string `json:"git_url"`
+}
+
+// WikiPage represents a single page of the wiki of a repository.
+type WikiPage struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	// SHA is the sha of the git blob of the page, it can be used for optimistic locking of page updates.
+	SHA          string  `json:"sha"`
+	Content      string  `json:"content,omitempty"`
+	LatestCommit *Commit `json:"latest_commit,omitempty"`
+}